@@ -0,0 +1,41 @@
+package http
+
+import (
+	"strings"
+
+	"worker_server/core/service/palette"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ActionHandler serves command palette actions.
+type ActionHandler struct {
+	palette *palette.Service
+}
+
+// NewActionHandler creates a new ActionHandler.
+func NewActionHandler(palette *palette.Service) *ActionHandler {
+	return &ActionHandler{palette: palette}
+}
+
+// Register registers action routes.
+func (h *ActionHandler) Register(router fiber.Router) {
+	router.Get("/actions/search", h.SearchActions)
+}
+
+// SearchActions returns invokable actions ranked by fuzzy match.
+// GET /actions/search?q=inb&limit=20 (q가 없으면 기본 동작 목록)
+func (h *ActionHandler) SearchActions(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	actions := h.palette.Search(c.Context(), userID, query, c.QueryInt("limit", palette.DefaultLimit))
+
+	return c.JSON(fiber.Map{
+		"query":   query,
+		"actions": actions,
+	})
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"time"
+
+	"worker_server/core/service/synclag"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// AdminHandler exposes operator views. ADMIN_USER_IDS에 있는 사용자만 접근할 수 있다.
+type AdminHandler struct {
+	admins  map[uuid.UUID]bool
+	syncLag *synclag.Service
+}
+
+// NewAdminHandler creates a new AdminHandler. 잘못된 ID는 무시한다.
+func NewAdminHandler(adminUserIDs []string, syncLag *synclag.Service) *AdminHandler {
+	admins := make(map[uuid.UUID]bool, len(adminUserIDs))
+	for _, raw := range adminUserIDs {
+		if id, err := uuid.Parse(raw); err == nil {
+			admins[id] = true
+		}
+	}
+	return &AdminHandler{admins: admins, syncLag: syncLag}
+}
+
+// Register registers admin routes.
+func (h *AdminHandler) Register(router fiber.Router) {
+	admin := router.Group("/admin", h.requireAdmin)
+	admin.Get("/connections", h.ListConnections)
+}
+
+func (h *AdminHandler) requireAdmin(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if !h.admins[userID] {
+		return ErrorResponse(c, 403, "admin only")
+	}
+	return c.Next()
+}
+
+// ListConnections lists connections with their sync status and provider-to-DB lag, most lagging first.
+// GET /admin/connections?min_lag=900&limit=50&offset=0 (min_lag: 초)
+func (h *AdminHandler) ListConnections(c *fiber.Ctx) error {
+	minLag := c.QueryInt("min_lag", 0)
+	if minLag < 0 {
+		return ErrorResponse(c, 400, "min_lag must be >= 0")
+	}
+	pagination := GetPaginationParams(c, 50)
+
+	connections, total, err := h.syncLag.Connections(c.Context(), time.Duration(minLag)*time.Second, pagination.Limit, pagination.Offset)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list connections")
+	}
+
+	return c.JSON(fiber.Map{
+		"connections":       connections,
+		"total":             total,
+		"has_more":          pagination.Offset+len(connections) < total,
+		"threshold_seconds": int64(h.syncLag.Threshold().Seconds()),
+	})
+}
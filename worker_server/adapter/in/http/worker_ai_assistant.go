@@ -0,0 +1,56 @@
+package http
+
+import (
+	"errors"
+	"time"
+
+	"worker_server/core/agent"
+	"worker_server/core/agent/llm"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// assistantCommandRequest is a transcribed voice (or typed) command.
+type assistantCommandRequest struct {
+	Text     string `json:"text" validate:"required,max=1000"`
+	Timezone string `json:"timezone,omitempty"` // "지난주" 같은 상대 날짜 해석용 (IANA, 예: Asia/Seoul)
+}
+
+// Command maps a natural-language command to search, batch or send actions.
+// POST /assistant/command {"text": "archive all newsletters from last week"}
+//
+// 검색 결과는 바로 반환하고, 변경/발송은 proposal을 반환한다 (POST /ai/proposals/:id/confirm으로 실행).
+func (h *AIHandler) Command(c *fiber.Ctx) error {
+	if h.orchestrator == nil {
+		return NotConfiguredResponse(c, "orchestrator")
+	}
+
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req assistantCommandRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	loc := time.UTC
+	if req.Timezone != "" {
+		if l, err := time.LoadLocation(req.Timezone); err == nil {
+			loc = l
+		}
+	}
+
+	result, err := h.orchestrator.Command(usageContext(c, llm.TaskChat), userID, req.Text, loc)
+	if err != nil {
+		switch {
+		case errors.Is(err, agent.ErrCommandNotUnderstood):
+			return ErrorResponse(c, 422, err.Error())
+		case errors.Is(err, agent.ErrEmailServiceMissing):
+			return NotConfiguredResponse(c, "email service")
+		}
+		return InternalErrorResponse(c, err, "run assistant command")
+	}
+	return c.JSON(result)
+}
@@ -2,12 +2,16 @@ package http
 
 import (
 	"bufio"
+	"context"
 	"strconv"
+	"strings"
 
 	"worker_server/core/agent"
+	"worker_server/core/agent/llm"
 	"worker_server/core/port/in"
 	"worker_server/core/port/out"
 
+	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -44,6 +48,15 @@ func NewAIHandlerComplete(
 	}
 }
 
+// usageContext attributes LLM calls of requests without a stored email to the current user.
+func usageContext(c *fiber.Ctx, task llm.TaskType) context.Context {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return c.Context()
+	}
+	return llm.WithUsageScope(c.Context(), llm.UsageScope{UserID: userID, Task: task})
+}
+
 func (h *AIHandler) Register(app fiber.Router) {
 	ai := app.Group("/ai")
 	ai.Post("/classify/:id", h.ClassifyEmail)
@@ -72,6 +85,12 @@ func (h *AIHandler) Register(app fiber.Router) {
 	ai.Get("/contacts/important", h.GetImportantContacts)
 	ai.Get("/patterns", h.GetCommunicationPatterns)
 	ai.Get("/phrases", h.GetFrequentPhrases)
+
+	// Mailbox question answering (RAG + SSE)
+	app.Post("/ask", h.Ask)
+
+	// Voice/text commands (변경 작업은 /ai/proposals/:id/confirm으로 확인 후 실행)
+	app.Post("/assistant/command", h.Command)
 }
 
 func (h *AIHandler) ClassifyEmail(c *fiber.Ctx) error {
@@ -93,8 +112,8 @@ func (h *AIHandler) ClassifyBatch(c *fiber.Ctx) error {
 		EmailIDs []int64 `json:"email_ids"`
 	}
 
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	results, err := h.aiService.ClassifyEmailBatch(c.Context(), req.EmailIDs)
@@ -121,7 +140,7 @@ func (h *AIHandler) SummarizeEmail(c *fiber.Ctx) error {
 	var summary string
 	if req.Body != "" {
 		// Use body from request directly (force=true: API 요청이므로 항상 실행)
-		summary, err = h.aiService.SummarizeEmailDirect(c.Context(), req.Subject, req.Body, true)
+		summary, err = h.aiService.SummarizeEmailDirect(usageContext(c, llm.TaskSummarize), req.Subject, req.Body, true)
 	} else {
 		// Fetch from DB (force=true: API 요청이므로 항상 실행)
 		summary, err = h.aiService.SummarizeEmail(c.Context(), emailID, true)
@@ -181,23 +200,19 @@ func (h *AIHandler) TranslateEmail(c *fiber.Ctx) error {
 	}
 
 	var req struct {
-		TargetLang string `json:"target_lang"`
+		TargetLang string `json:"target_lang" validate:"required"`
 		Subject    string `json:"subject"`
 		Body       string `json:"body"`
 	}
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
-	}
-
-	if req.TargetLang == "" {
-		return ErrorResponse(c, 400, "target_lang is required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	var result *in.TranslateEmailResult
 
 	// If subject/body provided, use direct translation (no DB lookup)
 	if req.Subject != "" || req.Body != "" {
-		result, err = h.aiService.TranslateEmailDirect(c.Context(), req.Subject, req.Body, req.TargetLang)
+		result, err = h.aiService.TranslateEmailDirect(usageContext(c, llm.TaskTranslate), req.Subject, req.Body, req.TargetLang)
 	} else {
 		result, err = h.aiService.TranslateEmail(c.Context(), emailID, req.TargetLang)
 	}
@@ -214,21 +229,14 @@ func (h *AIHandler) TranslateEmail(c *fiber.Ctx) error {
 // Body: { "text": "Hello", "target_lang": "ko" }
 func (h *AIHandler) TranslateText(c *fiber.Ctx) error {
 	var req struct {
-		Text       string `json:"text"`
-		TargetLang string `json:"target_lang"`
-	}
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
-	}
-
-	if req.Text == "" {
-		return ErrorResponse(c, 400, "text is required")
+		Text       string `json:"text" validate:"required"`
+		TargetLang string `json:"target_lang" validate:"required"`
 	}
-	if req.TargetLang == "" {
-		return ErrorResponse(c, 400, "target_lang is required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
-	result, err := h.aiService.TranslateText(c.Context(), req.Text, req.TargetLang)
+	result, err := h.aiService.TranslateText(usageContext(c, llm.TaskTranslate), req.Text, req.TargetLang)
 	if err != nil {
 		return InternalErrorResponse(c, err, "operation")
 	}
@@ -243,8 +251,8 @@ func (h *AIHandler) Chat(c *fiber.Ctx) error {
 	}
 
 	var req in.ChatRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	resp, err := h.aiService.Chat(c.Context(), userID, &req)
@@ -298,10 +306,72 @@ func (h *AIHandler) ChatStream(c *fiber.Ctx) error {
 	return nil
 }
 
+// Ask answers a question about the user's mailbox and streams the answer via SSE.
+// POST /ask {"question": "what's my flight number next week?"}
+//
+// Events: sources (근거 메일 목록, JSON) → data (답변 토큰) → citations (인용된 메일 ID, JSON) → [DONE]
+func (h *AIHandler) Ask(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req in.AskRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// fiber.Ctx는 핸들러 반환 후 재사용되므로 스트림 안에서는 fasthttp 컨텍스트만 사용한다
+	ctx := c.Context()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		onSources := func(sources []*in.AskSource) error {
+			return writeSSEJSON(w, "sources", fiber.Map{"sources": sources})
+		}
+		handler := func(chunk string) error {
+			return writeSSE(w, "", chunk)
+		}
+
+		result, err := h.aiService.Ask(ctx, userID, &req, onSources, handler)
+		if err != nil {
+			writeSSE(w, "", "[ERROR] "+err.Error())
+		} else {
+			writeSSEJSON(w, "citations", result)
+		}
+		writeSSE(w, "", "[DONE]")
+	})
+
+	return nil
+}
+
+// writeSSE writes one event. Multi-line data is split into several data: lines per the SSE spec.
+func writeSSE(w *bufio.Writer, event, data string) error {
+	if event != "" {
+		w.WriteString("event: " + event + "\n")
+	}
+	for _, line := range strings.Split(data, "\n") {
+		w.WriteString("data: " + line + "\n")
+	}
+	w.WriteString("\n")
+	return w.Flush()
+}
+
+func writeSSEJSON(w *bufio.Writer, event string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeSSE(w, event, string(data))
+}
+
 // ConfirmProposal confirms and executes a pending proposal
 func (h *AIHandler) ConfirmProposal(c *fiber.Ctx) error {
 	if h.orchestrator == nil {
-		return ErrorResponse(c, 500, "orchestrator not configured")
+		return NotConfiguredResponse(c, "orchestrator")
 	}
 
 	userID, err := GetUserID(c)
@@ -329,7 +399,7 @@ func (h *AIHandler) ConfirmProposal(c *fiber.Ctx) error {
 // RejectProposal cancels a pending proposal
 func (h *AIHandler) RejectProposal(c *fiber.Ctx) error {
 	if h.orchestrator == nil {
-		return ErrorResponse(c, 500, "orchestrator not configured")
+		return NotConfiguredResponse(c, "orchestrator")
 	}
 
 	userID, err := GetUserID(c)
@@ -355,7 +425,7 @@ func (h *AIHandler) RejectProposal(c *fiber.Ctx) error {
 // ListProposals returns all pending proposals for the user
 func (h *AIHandler) ListProposals(c *fiber.Ctx) error {
 	if h.orchestrator == nil {
-		return ErrorResponse(c, 500, "orchestrator not configured")
+		return NotConfiguredResponse(c, "orchestrator")
 	}
 
 	userID, err := GetUserID(c)
@@ -400,7 +470,7 @@ type AutocompleteSuggestion struct {
 // GetAutocomplete returns autocomplete suggestions based on user profile and context.
 func (h *AIHandler) GetAutocomplete(c *fiber.Ctx) error {
 	if h.personStore == nil {
-		return ErrorResponse(c, 500, "personalization not configured")
+		return NotConfiguredResponse(c, "personalization")
 	}
 
 	userID, err := GetUserID(c)
@@ -409,8 +479,8 @@ func (h *AIHandler) GetAutocomplete(c *fiber.Ctx) error {
 	}
 
 	var req AutocompleteRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if req.MaxSuggestions == 0 {
@@ -478,7 +548,7 @@ func (h *AIHandler) buildAutocompleteSuggestions(ctx *out.AutocompleteContext, r
 // GetAutocompleteContext returns the full autocomplete context for a user.
 func (h *AIHandler) GetAutocompleteContext(c *fiber.Ctx) error {
 	if h.personStore == nil {
-		return ErrorResponse(c, 500, "personalization not configured")
+		return NotConfiguredResponse(c, "personalization")
 	}
 
 	userID, err := GetUserID(c)
@@ -501,7 +571,7 @@ func (h *AIHandler) GetAutocompleteContext(c *fiber.Ctx) error {
 // GetUserProfile returns the extended user profile.
 func (h *AIHandler) GetUserProfile(c *fiber.Ctx) error {
 	if h.personStore == nil {
-		return ErrorResponse(c, 500, "personalization not configured")
+		return NotConfiguredResponse(c, "personalization")
 	}
 
 	userID, err := GetUserID(c)
@@ -527,7 +597,7 @@ func (h *AIHandler) GetUserProfile(c *fiber.Ctx) error {
 // UpdateUserProfile updates the user profile manually.
 func (h *AIHandler) UpdateUserProfile(c *fiber.Ctx) error {
 	if h.personStore == nil {
-		return ErrorResponse(c, 500, "personalization not configured")
+		return NotConfiguredResponse(c, "personalization")
 	}
 
 	userID, err := GetUserID(c)
@@ -536,8 +606,8 @@ func (h *AIHandler) UpdateUserProfile(c *fiber.Ctx) error {
 	}
 
 	var profile out.ExtendedUserProfile
-	if err := c.BodyParser(&profile); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &profile); err != nil {
+		return err
 	}
 
 	profile.UserID = userID.String()
@@ -555,7 +625,7 @@ func (h *AIHandler) UpdateUserProfile(c *fiber.Ctx) error {
 // GetFrequentContacts returns frequently contacted contacts.
 func (h *AIHandler) GetFrequentContacts(c *fiber.Ctx) error {
 	if h.personStore == nil {
-		return ErrorResponse(c, 500, "personalization not configured")
+		return NotConfiguredResponse(c, "personalization")
 	}
 
 	userID, err := GetUserID(c)
@@ -579,7 +649,7 @@ func (h *AIHandler) GetFrequentContacts(c *fiber.Ctx) error {
 // GetImportantContacts returns important contacts.
 func (h *AIHandler) GetImportantContacts(c *fiber.Ctx) error {
 	if h.personStore == nil {
-		return ErrorResponse(c, 500, "personalization not configured")
+		return NotConfiguredResponse(c, "personalization")
 	}
 
 	userID, err := GetUserID(c)
@@ -603,7 +673,7 @@ func (h *AIHandler) GetImportantContacts(c *fiber.Ctx) error {
 // GetCommunicationPatterns returns learned communication patterns.
 func (h *AIHandler) GetCommunicationPatterns(c *fiber.Ctx) error {
 	if h.personStore == nil {
-		return ErrorResponse(c, 500, "personalization not configured")
+		return NotConfiguredResponse(c, "personalization")
 	}
 
 	userID, err := GetUserID(c)
@@ -628,7 +698,7 @@ func (h *AIHandler) GetCommunicationPatterns(c *fiber.Ctx) error {
 // GetFrequentPhrases returns frequently used phrases.
 func (h *AIHandler) GetFrequentPhrases(c *fiber.Ctx) error {
 	if h.personStore == nil {
-		return ErrorResponse(c, 500, "personalization not configured")
+		return NotConfiguredResponse(c, "personalization")
 	}
 
 	userID, err := GetUserID(c)
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/service/alias"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AliasHandler handles send-as aliases per connection.
+type AliasHandler struct {
+	aliases *alias.Service
+}
+
+// NewAliasHandler creates a new AliasHandler.
+func NewAliasHandler(aliases *alias.Service) *AliasHandler {
+	return &AliasHandler{aliases: aliases}
+}
+
+// Register registers alias routes.
+func (h *AliasHandler) Register(router fiber.Router) {
+	connections := router.Group("/connections")
+
+	connections.Get("/:id/aliases", h.List)
+}
+
+// List returns the send-as addresses of a connection (Gmail sendAs, Outlook proxy addresses).
+// GET /connections/:id/aliases
+func (h *AliasHandler) List(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	connectionID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid connection id")
+	}
+
+	aliases, err := h.aliases.List(c.Context(), userID, connectionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, alias.ErrConnectionNotFound):
+			return ErrorResponse(c, 404, err.Error())
+		case errors.Is(err, alias.ErrUnsupportedProvider):
+			return ErrorResponse(c, 400, err.Error())
+		}
+		return InternalErrorResponse(c, err, "list aliases")
+	}
+
+	return c.JSON(fiber.Map{"aliases": aliases})
+}
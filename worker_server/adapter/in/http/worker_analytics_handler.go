@@ -0,0 +1,94 @@
+package http
+
+import (
+	"errors"
+	"net/url"
+
+	"worker_server/core/service/analytics"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AnalyticsHandler handles communication analytics.
+type AnalyticsHandler struct {
+	analytics *analytics.Service
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler.
+func NewAnalyticsHandler(analytics *analytics.Service) *AnalyticsHandler {
+	return &AnalyticsHandler{analytics: analytics}
+}
+
+// Register registers analytics routes.
+func (h *AnalyticsHandler) Register(router fiber.Router) {
+	a := router.Group("/analytics")
+
+	a.Get("/relationships", h.Relationships)
+	a.Get("/relationships/:email", h.ContactRelationship) // 연락처별 drill-down
+	a.Get("/overview", h.Overview)
+}
+
+// Overview returns the mailbox dashboard: volume by day/hour, top senders, categories,
+// attachment storage and average time-to-read.
+// GET /analytics/overview?days=30&top=10&tz=Asia/Seoul
+func (h *AnalyticsHandler) Overview(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	result, err := h.analytics.Overview(c.Context(), userID, c.QueryInt("days", 0), c.QueryInt("top", 0), c.Query("tz"))
+	if err != nil {
+		return analyticsErrorResponse(c, err, "mailbox overview")
+	}
+	return c.JSON(result)
+}
+
+// Relationships returns reply-time distribution, send/receive ratios and monthly trends of the contact graph.
+// GET /analytics/relationships?months=12&top=10
+func (h *AnalyticsHandler) Relationships(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	result, err := h.analytics.Relationships(c.Context(), userID, c.QueryInt("months", 0), c.QueryInt("top", 0))
+	if err != nil {
+		return analyticsErrorResponse(c, err, "relationship analytics")
+	}
+	return c.JSON(result)
+}
+
+// ContactRelationship returns the analytics of one contact.
+// GET /analytics/relationships/:email
+func (h *AnalyticsHandler) ContactRelationship(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	email, err := url.PathUnescape(c.Params("email"))
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email")
+	}
+
+	result, err := h.analytics.Contact(c.Context(), userID, email)
+	if err != nil {
+		return analyticsErrorResponse(c, err, "contact analytics")
+	}
+	return c.JSON(result)
+}
+
+func analyticsErrorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, analytics.ErrGraphNotConfigured):
+		return NotConfiguredResponse(c, "contact graph")
+	case errors.Is(err, analytics.ErrContactNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, analytics.ErrStatsNotConfigured):
+		return NotConfiguredResponse(c, "mailbox stats")
+	case errors.Is(err, analytics.ErrInvalidEmail), errors.Is(err, analytics.ErrInvalidTimezone):
+		return ErrorResponse(c, 400, err.Error())
+	}
+	return InternalErrorResponse(c, err, operation)
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/adapter/out/persistence"
+	"worker_server/core/domain"
+	"worker_server/core/service/briefing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BriefingHandler serves the daily briefing.
+type BriefingHandler struct {
+	briefing *briefing.Service
+}
+
+// NewBriefingHandler creates a new BriefingHandler.
+func NewBriefingHandler(briefing *briefing.Service) *BriefingHandler {
+	return &BriefingHandler{briefing: briefing}
+}
+
+// Register registers briefing routes.
+func (h *BriefingHandler) Register(router fiber.Router) {
+	b := router.Group("/briefing")
+
+	b.Get("/today", h.Today)
+	b.Get("/settings", h.GetSettings)
+	b.Put("/settings", h.UpdateSettings)
+}
+
+// UpdateBriefingSettingsRequest represents the HTTP request to change briefing settings.
+type UpdateBriefingSettingsRequest struct {
+	Enabled      bool `json:"enabled"`
+	EmailEnabled bool `json:"email_enabled"`
+	Hour         int  `json:"hour" validate:"min=0,max=23"`
+}
+
+// Today returns today's briefing, generating it if the scheduler has not run yet.
+// GET /briefing/today
+func (h *BriefingHandler) Today(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	b, err := h.briefing.Today(c.Context(), userID)
+	if err != nil {
+		return h.errorResponse(c, err, "get today's briefing")
+	}
+
+	return c.JSON(b)
+}
+
+// GetSettings returns the user's briefing settings.
+// GET /briefing/settings
+func (h *BriefingHandler) GetSettings(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	settings, err := h.briefing.GetSettings(c.Context(), userID)
+	if err != nil {
+		return h.errorResponse(c, err, "get briefing settings")
+	}
+
+	return c.JSON(settings)
+}
+
+// UpdateSettings changes the briefing hour and whether it is emailed.
+// 시간대는 사용자 설정(user_settings.timezone)을 따른다.
+// PUT /briefing/settings
+func (h *BriefingHandler) UpdateSettings(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req UpdateBriefingSettingsRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	settings, err := h.briefing.UpdateSettings(c.Context(), &domain.BriefingSettings{
+		UserID:       userID,
+		Enabled:      req.Enabled,
+		EmailEnabled: req.EmailEnabled,
+		Hour:         req.Hour,
+	})
+	if err != nil {
+		return h.errorResponse(c, err, "update briefing settings")
+	}
+
+	return c.JSON(settings)
+}
+
+func (h *BriefingHandler) errorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, persistence.ErrNotFound):
+		return ErrorResponse(c, 404, "user not found")
+	case errors.Is(err, briefing.ErrInvalidHour):
+		return ErrorResponse(c, 400, err.Error())
+	}
+	return InternalErrorResponse(c, err, operation)
+}
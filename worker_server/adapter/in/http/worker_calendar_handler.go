@@ -111,8 +111,8 @@ func (h *CalendarHandler) CreateEvent(c *fiber.Ctx) error {
 	}
 
 	var req in.CreateEventRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	// Use connection_id from request or query
@@ -137,8 +137,8 @@ func (h *CalendarHandler) UpdateEvent(c *fiber.Ctx) error {
 	}
 
 	var req in.UpdateEventRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	event, err := h.calendarService.UpdateEvent(c.Context(), eventID, &req)
@@ -183,7 +183,7 @@ func (h *CalendarHandler) ListCalendarsFromProvider(c *fiber.Ctx) error {
 		ListCalendarsFromProvider(ctx interface{}, connectionID int64) (interface{}, error)
 	})
 	if !ok {
-		return ErrorResponse(c, 500, "provider not configured")
+		return NotConfiguredResponse(c, "provider")
 	}
 
 	calendars, err := svc.ListCalendarsFromProvider(c.Context(), int64(connectionID))
@@ -226,7 +226,7 @@ func (h *CalendarHandler) ListEventsFromProvider(c *fiber.Ctx) error {
 		ListEventsFromProvider(ctx interface{}, connectionID int64, start, end *time.Time) (interface{}, error)
 	})
 	if !ok {
-		return ErrorResponse(c, 500, "provider not configured")
+		return NotConfiguredResponse(c, "provider")
 	}
 
 	events, err := svc.ListEventsFromProvider(c.Context(), int64(connectionID), startTime, endTime)
@@ -250,8 +250,8 @@ func (h *CalendarHandler) SyncCalendars(c *fiber.Ctx) error {
 	var req struct {
 		ConnectionID int64 `json:"connection_id"`
 	}
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if req.ConnectionID == 0 {
@@ -0,0 +1,216 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/adapter/out/persistence"
+	"worker_server/core/domain"
+	"worker_server/core/service"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CampaignHandler handles mail merge campaigns (bulk personalized send).
+type CampaignHandler struct {
+	campaignService *mail.CampaignService
+}
+
+// NewCampaignHandler creates a new CampaignHandler.
+func NewCampaignHandler(campaignService *mail.CampaignService) *CampaignHandler {
+	return &CampaignHandler{campaignService: campaignService}
+}
+
+// Register registers campaign routes.
+func (h *CampaignHandler) Register(router fiber.Router) {
+	campaigns := router.Group("/email/campaign")
+
+	campaigns.Post("/", h.Create)
+	campaigns.Get("/", h.List)
+	campaigns.Get("/:id", h.Get)                       // 진행 상황
+	campaigns.Get("/:id/recipients", h.ListRecipients) // 수신자별 발송 리포트
+	campaigns.Post("/:id/cancel", h.Cancel)
+}
+
+// CampaignRecipientRequest represents a single recipient of a campaign.
+type CampaignRecipientRequest struct {
+	Email     string            `json:"email"`
+	Name      string            `json:"name"`
+	Variables map[string]string `json:"variables"`
+}
+
+// CreateCampaignRequest represents the HTTP request to create a campaign.
+type CreateCampaignRequest struct {
+	ConnectionID int64                      `json:"connection_id"`
+	TemplateID   int64                      `json:"template_id"`
+	Name         string                     `json:"name"`
+	Subject      string                     `json:"subject"`
+	Body         string                     `json:"body"`
+	HTMLBody     string                     `json:"html_body"`
+	UseSignature bool                       `json:"use_signature"`
+	ThrottleMs   int                        `json:"throttle_ms"`
+	Recipients   []CampaignRecipientRequest `json:"recipients"`
+}
+
+// Create creates a campaign and queues it for sending.
+// @Summary Create mail merge campaign
+// @Tags Campaigns
+// @Accept json
+// @Param request body CreateCampaignRequest true "Campaign"
+// @Success 202 {object} domain.EmailCampaign
+// @Router /api/v1/email/campaign [post]
+func (h *CampaignHandler) Create(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req CreateCampaignRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+	if req.ConnectionID <= 0 {
+		return ErrorResponse(c, 400, "connection_id is required")
+	}
+
+	recipients := make([]mail.CampaignRecipientInput, len(req.Recipients))
+	for i, r := range req.Recipients {
+		recipients[i] = mail.CampaignRecipientInput{
+			Email:     r.Email,
+			Name:      r.Name,
+			Variables: r.Variables,
+		}
+	}
+
+	campaign, err := h.campaignService.CreateCampaign(c.Context(), userID, &mail.CreateCampaignRequest{
+		ConnectionID: req.ConnectionID,
+		TemplateID:   req.TemplateID,
+		Name:         req.Name,
+		Subject:      req.Subject,
+		Body:         req.Body,
+		HTMLBody:     req.HTMLBody,
+		UseSignature: req.UseSignature,
+		ThrottleMs:   req.ThrottleMs,
+		Recipients:   recipients,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, mail.ErrCampaignNoRecipients),
+			errors.Is(err, mail.ErrCampaignTooManyRecipients),
+			errors.Is(err, mail.ErrCampaignInvalidRecipient),
+			errors.Is(err, mail.ErrCampaignEmptyContent),
+			errors.Is(err, mail.ErrCampaignMissingVariables),
+			errors.Is(err, mail.ErrCampaignInvalidConnection):
+			return ErrorResponse(c, 400, err.Error())
+		case errors.Is(err, service.ErrTemplateNotFound):
+			return ErrorResponse(c, 404, err.Error())
+		}
+		return InternalErrorResponse(c, err, "create campaign")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(campaign)
+}
+
+// List returns recent campaigns.
+// GET /email/campaign
+func (h *CampaignHandler) List(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	campaigns, err := h.campaignService.ListCampaigns(c.Context(), userID, c.QueryInt("limit", 20))
+	if err != nil {
+		return InternalErrorResponse(c, err, "list campaigns")
+	}
+
+	return c.JSON(fiber.Map{"campaigns": campaigns})
+}
+
+// Get returns a campaign with progress counters.
+// GET /email/campaign/:id
+func (h *CampaignHandler) Get(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid campaign id")
+	}
+
+	campaign, err := h.campaignService.GetCampaign(c.Context(), userID, id)
+	if err != nil {
+		return h.errorResponse(c, err, "get campaign")
+	}
+
+	return c.JSON(campaign)
+}
+
+// ListRecipients returns the per-recipient send report.
+// GET /email/campaign/:id/recipients?status=failed&limit=100&offset=0
+func (h *CampaignHandler) ListRecipients(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid campaign id")
+	}
+
+	status := domain.CampaignRecipientStatus(c.Query("status"))
+	switch status {
+	case "", domain.RecipientPending, domain.RecipientSent, domain.RecipientFailed, domain.RecipientBounced:
+	default:
+		return ErrorResponse(c, 400, "invalid status")
+	}
+
+	limit := c.QueryInt("limit", 100)
+	offset := c.QueryInt("offset", 0)
+
+	recipients, err := h.campaignService.ListRecipients(c.Context(), userID, id, status, limit, offset)
+	if err != nil {
+		return h.errorResponse(c, err, "list campaign recipients")
+	}
+
+	return c.JSON(fiber.Map{
+		"recipients": recipients,
+		"limit":      limit,
+		"offset":     offset,
+	})
+}
+
+// Cancel stops a pending or sending campaign.
+// POST /email/campaign/:id/cancel
+func (h *CampaignHandler) Cancel(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid campaign id")
+	}
+
+	campaign, err := h.campaignService.CancelCampaign(c.Context(), userID, id)
+	if err != nil {
+		if errors.Is(err, mail.ErrCampaignAlreadyFinished) {
+			return ErrorResponse(c, 409, err.Error())
+		}
+		return h.errorResponse(c, err, "cancel campaign")
+	}
+
+	return c.JSON(campaign)
+}
+
+func (h *CampaignHandler) errorResponse(c *fiber.Ctx, err error, operation string) error {
+	if errors.Is(err, persistence.ErrNotFound) {
+		return ErrorResponse(c, 404, "campaign not found")
+	}
+	return InternalErrorResponse(c, err, operation)
+}
@@ -1,16 +1,22 @@
 package http
 
 import (
+	"errors"
+	"sort"
+
 	"worker_server/core/domain"
 	"worker_server/core/port/out"
+	"worker_server/core/service/category"
 	"worker_server/pkg/logger"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 // CategoryHandler handles category-related API endpoints.
 type CategoryHandler struct {
-	emailRepo out.EmailRepository
+	emailRepo  out.EmailRepository
+	categories *category.Service // 사용자 카테고리 (optional, nil이면 기본 카테고리만)
 }
 
 // NewCategoryHandler creates a new CategoryHandler.
@@ -18,6 +24,11 @@ func NewCategoryHandler(emailRepo out.EmailRepository) *CategoryHandler {
 	return &CategoryHandler{emailRepo: emailRepo}
 }
 
+// SetCategoryService enables per-user custom, renamed and hidden categories.
+func (h *CategoryHandler) SetCategoryService(categories *category.Service) {
+	h.categories = categories
+}
+
 // Register registers category routes.
 func (h *CategoryHandler) Register(app fiber.Router) {
 	cat := app.Group("/categories")
@@ -25,6 +36,11 @@ func (h *CategoryHandler) Register(app fiber.Router) {
 	cat.Get("/stats", h.GetCategoryStats)          // 카테고리별 통계
 	cat.Get("/priorities", h.ListPriorities)       // 우선순위 레벨 정보
 	cat.Get("/subcategories", h.ListSubCategories) // 서브카테고리 목록
+	cat.Post("/", h.CreateCategory)                // 사용자 카테고리 생성
+	cat.Put("/:key", h.UpdateCategory)             // 이름 변경/숨김
+	cat.Delete("/:key", h.DeleteCategory)          // 사용자 카테고리 삭제 (기본 카테고리는 초기화)
+
+	app.Get("/email/categories", h.GetCategoryTree) // 카테고리/서브카테고리 트리 + 메일 수 (/email/:id보다 먼저 등록)
 }
 
 // =============================================================================
@@ -41,6 +57,8 @@ type CategoryMeta struct {
 	Color       string `json:"color"`
 	SortOrder   int    `json:"sort_order"`
 	IsInbox     bool   `json:"is_inbox"` // Inbox 뷰에 표시되는 카테고리
+	IsCustom    bool   `json:"is_custom"`
+	IsHidden    bool   `json:"is_hidden"`
 }
 
 // SubCategoryMeta contains metadata for a sub-category.
@@ -137,21 +155,31 @@ var priorityLevelMetadata = []PriorityLevelMeta{
 // Handlers
 // =============================================================================
 
-// ListCategories returns all category metadata.
-// GET /categories
+// ListCategories returns the user's categories: built-ins with renames applied plus custom categories.
+// GET /categories?inbox_only=true&include_hidden=true
 func (h *CategoryHandler) ListCategories(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	all, err := h.userCategories(c, userID, c.QueryBool("include_hidden", false))
+	if err != nil {
+		return InternalErrorResponse(c, err, "list categories")
+	}
+
 	// Optional: filter by inbox
 	inboxOnly := c.QueryBool("inbox_only", false)
 
 	var categories []CategoryMeta
 	if inboxOnly {
-		for _, cat := range categoryMetadata {
+		for _, cat := range all {
 			if cat.IsInbox {
 				categories = append(categories, cat)
 			}
 		}
 	} else {
-		categories = categoryMetadata
+		categories = all
 	}
 
 	return c.JSON(fiber.Map{
@@ -212,6 +240,11 @@ func (h *CategoryHandler) GetCategoryStats(c *fiber.Ctx) error {
 
 	connectionID := GetConnectionID(c)
 
+	categories, err := h.userCategories(c, userID, false)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list categories")
+	}
+
 	// Get stats from repository
 	stats, err := h.emailRepo.GetCategoryStats(c.Context(), userID, connectionID)
 	if err != nil {
@@ -220,8 +253,9 @@ func (h *CategoryHandler) GetCategoryStats(c *fiber.Ctx) error {
 	}
 
 	// Merge with metadata
-	result := make([]CategoryStats, 0, len(categoryMetadata))
-	for _, meta := range categoryMetadata {
+	result := make([]CategoryStats, 0, len(categories))
+	var inboxTotal, inboxUnread int
+	for _, meta := range categories {
 		stat := CategoryStats{
 			Category: meta.Key,
 			Name:     meta.Name,
@@ -238,19 +272,13 @@ func (h *CategoryHandler) GetCategoryStats(c *fiber.Ctx) error {
 			stat.Unread = s.Unread
 		}
 
-		result = append(result, stat)
-	}
-
-	// Calculate inbox totals
-	var inboxTotal, inboxUnread int
-	for _, stat := range result {
-		for _, meta := range categoryMetadata {
-			if meta.Key == stat.Category && meta.IsInbox {
-				inboxTotal += stat.Total
-				inboxUnread += stat.Unread
-				break
-			}
+		// Inbox totals
+		if meta.IsInbox {
+			inboxTotal += stat.Total
+			inboxUnread += stat.Unread
 		}
+
+		result = append(result, stat)
 	}
 
 	return c.JSON(fiber.Map{
@@ -262,6 +290,301 @@ func (h *CategoryHandler) GetCategoryStats(c *fiber.Ctx) error {
 	})
 }
 
+// CategoryTreeNode is a category with its sub-categories and live counts.
+type CategoryTreeNode struct {
+	Key           string            `json:"key"`
+	Name          string            `json:"name"`
+	NameKo        string            `json:"name_ko"`
+	Icon          string            `json:"icon"`
+	Color         string            `json:"color"`
+	IsInbox       bool              `json:"is_inbox"`
+	IsCustom      bool              `json:"is_custom"`
+	Total         int               `json:"total"`
+	Unread        int               `json:"unread"`
+	SubCategories []SubCategoryNode `json:"sub_categories"`
+}
+
+// SubCategoryNode is a sub-category with its live counts.
+type SubCategoryNode struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	NameKo string `json:"name_ko"`
+	Icon   string `json:"icon"`
+	Total  int    `json:"total"`
+	Unread int    `json:"unread"`
+}
+
+// GetCategoryTree returns the category → sub-category hierarchy with email counts.
+// 메타데이터에 없는 서브카테고리도 메일이 있으면 키를 이름으로 해서 포함한다.
+// GET /email/categories?connection_id=123
+func (h *CategoryHandler) GetCategoryTree(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	connectionID := GetConnectionID(c)
+
+	categories, err := h.userCategories(c, userID, false)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list categories")
+	}
+
+	stats, err := h.emailRepo.GetCategoryStats(c.Context(), userID, connectionID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "get category stats")
+	}
+	subStats, err := h.emailRepo.GetSubCategoryStats(c.Context(), userID, connectionID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "get sub-category stats")
+	}
+
+	tree := make([]CategoryTreeNode, 0, len(categories))
+	for _, meta := range categories {
+		node := CategoryTreeNode{
+			Key:           meta.Key,
+			Name:          meta.Name,
+			NameKo:        meta.NameKo,
+			Icon:          meta.Icon,
+			Color:         meta.Color,
+			IsInbox:       meta.IsInbox,
+			IsCustom:      meta.IsCustom,
+			SubCategories: []SubCategoryNode{},
+		}
+		if s, ok := stats[meta.Key]; ok {
+			node.Total = s.Total
+			node.Unread = s.Unread
+		}
+
+		counts := subStats[meta.Key]
+		seen := make(map[string]bool)
+		for _, sub := range subCategoryMetadata {
+			if sub.ParentKey != meta.Key {
+				continue
+			}
+			seen[sub.Key] = true
+			subNode := SubCategoryNode{Key: sub.Key, Name: sub.Name, NameKo: sub.NameKo, Icon: sub.Icon}
+			if s, ok := counts[sub.Key]; ok {
+				subNode.Total = s.Total
+				subNode.Unread = s.Unread
+			}
+			node.SubCategories = append(node.SubCategories, subNode)
+		}
+
+		// 분류기가 메타데이터 밖의 서브카테고리를 붙인 경우 (예: shopping→delivery)
+		var extra []string
+		for key := range counts {
+			if !seen[key] {
+				extra = append(extra, key)
+			}
+		}
+		sort.Strings(extra)
+		for _, key := range extra {
+			s := counts[key]
+			node.SubCategories = append(node.SubCategories, SubCategoryNode{
+				Key:    key,
+				Name:   key,
+				NameKo: key,
+				Total:  s.Total,
+				Unread: s.Unread,
+			})
+		}
+
+		tree = append(tree, node)
+	}
+
+	return c.JSON(fiber.Map{
+		"categories":    tree,
+		"total":         len(tree),
+		"connection_id": connectionID,
+	})
+}
+
+// =============================================================================
+// User Categories
+// =============================================================================
+
+// CreateCategoryRequest is the body of POST /categories.
+type CreateCategoryRequest struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"` // LLM이 이 카테고리를 고를 기준
+	Color       string `json:"color"`
+	Icon        string `json:"icon"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// UpdateCategoryRequest is the body of PUT /categories/:key; 보내지 않은 필드는 그대로 둔다.
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name"` // 기본 카테고리는 ""이면 기본 이름으로 되돌린다
+	Description *string `json:"description"`
+	Color       *string `json:"color"`
+	Icon        *string `json:"icon"`
+	IsHidden    *bool   `json:"is_hidden"`
+	SortOrder   *int    `json:"sort_order"`
+}
+
+// CreateCategory creates a custom category.
+// POST /categories
+// Body: { "key": "hr", "name": "HR", "description": "HR팀 공지와 급여 안내", "color": "#795548", "icon": "users" }
+func (h *CategoryHandler) CreateCategory(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.categories == nil {
+		return NotConfiguredResponse(c, "custom categories")
+	}
+
+	var req CreateCategoryRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	created, err := h.categories.Create(c.Context(), userID, &category.CreateRequest{
+		Key:         req.Key,
+		Name:        req.Name,
+		Description: req.Description,
+		Color:       req.Color,
+		Icon:        req.Icon,
+		SortOrder:   req.SortOrder,
+	})
+	if err != nil {
+		return h.errorResponse(c, err, "create category")
+	}
+	return c.Status(201).JSON(created)
+}
+
+// UpdateCategory renames, hides or restyles a built-in or custom category.
+// PUT /categories/:key
+// Body: { "name": "프로모션", "is_hidden": true }
+func (h *CategoryHandler) UpdateCategory(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.categories == nil {
+		return NotConfiguredResponse(c, "custom categories")
+	}
+
+	var req UpdateCategoryRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	updated, err := h.categories.Update(c.Context(), userID, c.Params("key"), &category.UpdateRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Color:       req.Color,
+		Icon:        req.Icon,
+		IsHidden:    req.IsHidden,
+		SortOrder:   req.SortOrder,
+	})
+	if err != nil {
+		return h.errorResponse(c, err, "update category")
+	}
+	return c.JSON(updated)
+}
+
+// DeleteCategory deletes a custom category (its emails move to other)
+// or resets a built-in category to its default name and visibility.
+// DELETE /categories/:key
+func (h *CategoryHandler) DeleteCategory(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.categories == nil {
+		return NotConfiguredResponse(c, "custom categories")
+	}
+
+	if err := h.categories.Delete(c.Context(), userID, c.Params("key")); err != nil {
+		return h.errorResponse(c, err, "delete category")
+	}
+	return c.JSON(fiber.Map{"status": "ok"})
+}
+
+func (h *CategoryHandler) errorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, category.ErrCategoryNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, category.ErrCategoryExists):
+		return ErrorResponse(c, 409, err.Error())
+	case errors.Is(err, category.ErrInvalidKey),
+		errors.Is(err, category.ErrInvalidName),
+		errors.Is(err, category.ErrTooLong),
+		errors.Is(err, category.ErrTooManyCustom),
+		errors.Is(err, category.ErrCannotHideOther):
+		return ErrorResponse(c, 400, err.Error())
+	}
+	return InternalErrorResponse(c, err, operation)
+}
+
+// userCategories merges the built-in metadata with the user's overrides and custom categories.
+func (h *CategoryHandler) userCategories(c *fiber.Ctx, userID uuid.UUID, includeHidden bool) ([]CategoryMeta, error) {
+	if h.categories == nil {
+		return categoryMetadata, nil
+	}
+	stored, err := h.categories.List(c.Context(), userID)
+	if err != nil {
+		return nil, err
+	}
+	taxonomy := domain.NewCategoryTaxonomy(stored)
+
+	categories := make([]CategoryMeta, 0, len(categoryMetadata)+len(stored))
+	for _, meta := range categoryMetadata {
+		if o := taxonomy.Get(meta.Key); o != nil {
+			if o.Name != "" {
+				meta.Name, meta.NameKo = o.Name, o.Name
+			}
+			if o.Description != "" {
+				meta.Description = o.Description
+			}
+			if o.Color != "" {
+				meta.Color = o.Color
+			}
+			if o.Icon != "" {
+				meta.Icon = o.Icon
+			}
+			if o.SortOrder != 0 {
+				meta.SortOrder = o.SortOrder
+			}
+			meta.IsHidden = o.IsHidden
+		}
+		categories = append(categories, meta)
+	}
+	for _, o := range stored {
+		if !o.IsCustom {
+			continue
+		}
+		categories = append(categories, CategoryMeta{
+			Key:         o.Key,
+			Name:        o.Name,
+			NameKo:      o.Name,
+			Description: o.Description,
+			Icon:        o.Icon,
+			Color:       o.Color,
+			SortOrder:   o.SortOrder,
+			IsCustom:    true,
+			IsHidden:    o.IsHidden,
+		})
+	}
+
+	if !includeHidden {
+		visible := categories[:0]
+		for _, meta := range categories {
+			if !meta.IsHidden {
+				visible = append(visible, meta)
+			}
+		}
+		categories = visible
+	}
+	sort.SliceStable(categories, func(i, j int) bool {
+		return categories[i].SortOrder < categories[j].SortOrder
+	})
+	return categories, nil
+}
+
 // =============================================================================
 // Helper Functions
 // =============================================================================
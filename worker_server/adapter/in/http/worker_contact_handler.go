@@ -1,6 +1,8 @@
 package http
 
 import (
+	"errors"
+	"net/url"
 	"strconv"
 
 	"worker_server/core/domain"
@@ -26,6 +28,11 @@ func NewContactHandler(contactService *contact.Service) *ContactHandler {
 func (h *ContactHandler) Register(router fiber.Router) {
 	contacts := router.Group("/contacts")
 
+	// VIP (/:id보다 먼저 등록)
+	contacts.Get("/vip", h.ListVIPContacts)
+	contacts.Put("/vip", h.AddVIPContact)
+	contacts.Delete("/vip/:email", h.RemoveVIPContact)
+
 	// Contact CRUD
 	contacts.Get("/", h.ListContacts)
 	contacts.Get("/:id", h.GetContact)
@@ -144,7 +151,7 @@ func (h *ContactHandler) GetContactByEmail(c *fiber.Ctx) error {
 
 // CreateContactRequest represents contact creation request.
 type CreateContactRequest struct {
-	Email   string   `json:"email"`
+	Email   string   `json:"email" validate:"required,email"`
 	Name    *string  `json:"name,omitempty"`
 	Company *string  `json:"company,omitempty"`
 	Title   *string  `json:"title,omitempty"`
@@ -164,12 +171,8 @@ func (h *ContactHandler) CreateContact(c *fiber.Ctx) error {
 	}
 
 	var req CreateContactRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
-	}
-
-	if req.Email == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "Email is required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	contact, err := h.contactService.CreateContact(c.Context(), userID, &in.CreateContactRequest{
@@ -209,8 +212,8 @@ func (h *ContactHandler) UpdateContact(c *fiber.Ctx) error {
 	}
 
 	var req UpdateContactRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	contact, err := h.contactService.UpdateContact(c.Context(), contactID, &in.UpdateContactRequest{
@@ -333,7 +336,7 @@ func (h *ContactHandler) GetCompany(c *fiber.Ctx) error {
 
 // CreateCompanyRequest represents company creation request.
 type CreateCompanyRequest struct {
-	Name        string  `json:"name"`
+	Name        string  `json:"name" validate:"required,max=200"`
 	Domain      *string `json:"domain,omitempty"`
 	Industry    *string `json:"industry,omitempty"`
 	Website     *string `json:"website,omitempty"`
@@ -352,12 +355,8 @@ func (h *ContactHandler) CreateCompany(c *fiber.Ctx) error {
 	}
 
 	var req CreateCompanyRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
-	}
-
-	if req.Name == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "Company name is required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	company, err := h.contactService.CreateCompany(c.Context(), userID, &in.CreateCompanyRequest{
@@ -394,8 +393,8 @@ func (h *ContactHandler) UpdateCompany(c *fiber.Ctx) error {
 	}
 
 	var req UpdateCompanyRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	company, err := h.contactService.UpdateCompany(c.Context(), companyID, &in.UpdateCompanyRequest{
@@ -429,6 +428,95 @@ func (h *ContactHandler) DeleteCompany(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+// =============================================================================
+// VIP Contacts
+// =============================================================================
+
+// VIPContactRequest represents a request to mark a contact as VIP.
+type VIPContactRequest struct {
+	Email string `json:"email" validate:"required"`
+	Name  string `json:"name,omitempty"`
+}
+
+// ListVIPContacts returns the contacts marked as VIP.
+func (h *ContactHandler) ListVIPContacts(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.contactService == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Contact service not available")
+	}
+
+	contacts, err := h.contactService.ListVIPContacts(c.Context(), userID)
+	if err != nil {
+		return vipErrorResponse(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"contacts": contacts,
+		"count":    len(contacts),
+	})
+}
+
+// AddVIPContact marks a contact as VIP.
+func (h *ContactHandler) AddVIPContact(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.contactService == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Contact service not available")
+	}
+
+	var req VIPContactRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.contactService.SetVIP(c.Context(), userID, req.Email, req.Name, true); err != nil {
+		return vipErrorResponse(c, err)
+	}
+
+	return c.JSON(fiber.Map{"email": req.Email, "vip": true})
+}
+
+// RemoveVIPContact unmarks a VIP contact.
+func (h *ContactHandler) RemoveVIPContact(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.contactService == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Contact service not available")
+	}
+
+	email, err := url.PathUnescape(c.Params("email"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid email")
+	}
+
+	if err := h.contactService.SetVIP(c.Context(), userID, email, "", false); err != nil {
+		return vipErrorResponse(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func vipErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, contact.ErrGraphNotConfigured):
+		return NotConfiguredResponse(c, "contact graph")
+	case errors.Is(err, contact.ErrInvalidEmail):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	default:
+		return InternalErrorResponse(c, err, "vip contacts")
+	}
+}
+
 // =============================================================================
 // Utilities
 // =============================================================================
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetEmailActivity returns the timeline of an email, oldest first.
+// GET /email/:id/activity
+func (h *EmailHandler) GetEmailActivity(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	activities, err := h.emailService.EmailActivity(c.Context(), userID, emailID)
+	if err != nil {
+		return activityErrorResponse(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"email_id":   emailID,
+		"activities": activities,
+		"count":      len(activities),
+	})
+}
+
+func activityErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, mail.ErrEmailNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, mail.ErrRepoNotInitialized):
+		return NotConfiguredResponse(c, "email activity")
+	}
+	return InternalErrorResponse(c, err, "get email activity")
+}
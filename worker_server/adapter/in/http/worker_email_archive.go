@@ -0,0 +1,37 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RehydrateEmails moves archived emails back to the mailbox.
+// 목록에서 include_archived=true로 찾은 오래된 메일을 일괄로 되돌릴 때 사용한다 (단건은 열면 자동으로 돌아옴).
+// POST /email/rehydrate
+func (h *EmailHandler) RehydrateEmails(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req EmailIDsRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	restored, err := h.emailService.Rehydrate(c.Context(), userID, req.IDs)
+	if err != nil {
+		if errors.Is(err, mail.ErrRepoNotInitialized) {
+			return NotConfiguredResponse(c, "email archive")
+		}
+		return InternalErrorResponse(c, err, "rehydrate emails")
+	}
+
+	if h.emailCache != nil && len(restored) > 0 {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.JSON(fiber.Map{"status": "ok", "restored": len(restored), "ids": restored})
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/port/out"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetEmailAudio streams an email read aloud (briefing 재생 등).
+// GET /email/:id/audio?mode=summary|full&voice=alloy
+// 캐시된 오디오는 Content-Length와 함께, 새로 생성하는 오디오는 chunked로 보낸다.
+func (h *EmailHandler) GetEmailAudio(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	audio, stream, err := h.emailService.EmailAudio(c.Context(), userID, emailID, c.Query("mode"), c.Query("voice"))
+	if err != nil {
+		switch {
+		case errors.Is(err, mail.ErrInvalidAudioMode), errors.Is(err, out.ErrUnsupportedVoice):
+			return ErrorResponse(c, 400, err.Error())
+		case errors.Is(err, mail.ErrEmailNotFound):
+			return ErrorResponse(c, 404, err.Error())
+		case errors.Is(err, mail.ErrRepoNotInitialized):
+			return NotConfiguredResponse(c, "text-to-speech")
+		}
+		return InternalErrorResponse(c, err, "get email audio")
+	}
+
+	c.Set("Content-Type", audio.MimeType)
+	c.Set("Cache-Control", "private, no-store")
+	c.Set("X-Audio-Cached", strconv.FormatBool(audio.Cached))
+	// 응답 후 fasthttp가 스트림을 닫는다 (끝까지 전송된 오디오만 캐시)
+	return c.SendStream(stream, int(audio.Size))
+}
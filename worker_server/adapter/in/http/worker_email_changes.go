@@ -0,0 +1,33 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetEmailChanges returns email IDs created, updated and deleted since a sync token.
+// since 없이 호출하면 현재 위치의 cursor만 돌려준다. cursor가 만료되면 410 (전체 재조회).
+// GET /email/changes?since=<cursor>&limit=500
+func (h *EmailHandler) GetEmailChanges(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	changes, err := h.emailService.Changes(c.Context(), userID, c.Query("since"), c.QueryInt("limit", 0))
+	if err != nil {
+		switch {
+		case errors.Is(err, mail.ErrInvalidSyncToken):
+			return ErrorResponse(c, 400, err.Error())
+		case errors.Is(err, mail.ErrSyncTokenExpired):
+			return ErrorResponse(c, 410, err.Error())
+		case errors.Is(err, mail.ErrRepoNotInitialized):
+			return NotConfiguredResponse(c, "email changes")
+		}
+		return InternalErrorResponse(c, err, "get email changes")
+	}
+	return c.JSON(changes)
+}
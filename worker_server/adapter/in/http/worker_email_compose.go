@@ -0,0 +1,52 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/core/port/in"
+	"worker_server/core/service/filelink"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// 메일 작성 준비 (첨부 전송 방식 결정 + draft/업로드 세션 생성)
+// =============================================================================
+
+// PrepareCompose plans attachment delivery and creates the draft in one call.
+// POST /compose/prepare
+// Body: { "to": [...], "subject": "...", "body": "...", "attachments": [{ "filename": "a.pdf", "size": 12345678 }] }
+// 응답의 send_request에 inline 첨부를 채우고 업로드 세션을 완료한 뒤 POST /email로 보낸다.
+func (h *EmailHandler) PrepareCompose(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req in.PrepareComposeRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+	if req.ConnectionID == 0 {
+		if connID := GetConnectionID(c); connID != nil {
+			req.ConnectionID = *connID
+		}
+	}
+	for _, att := range req.Attachments {
+		if att.Size <= 0 {
+			return ErrorResponse(c, 400, "attachment size must be positive")
+		}
+	}
+
+	prepared, err := h.emailService.PrepareCompose(c.Context(), userID, &req)
+	if err != nil {
+		if status := uploadErrorStatus(err); status != 0 {
+			return ErrorResponse(c, status, err.Error())
+		}
+		if errors.Is(err, filelink.ErrTooLarge) {
+			return ErrorResponse(c, 413, err.Error())
+		}
+		return InternalErrorResponse(c, err, "prepare compose")
+	}
+	return c.Status(201).JSON(prepared)
+}
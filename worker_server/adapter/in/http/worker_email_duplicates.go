@@ -0,0 +1,55 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// 중복 메일 API (여러 계정에 배달된 같은 메일, 전달 사본)
+// =============================================================================
+
+// ListDuplicates returns emails that have redundant copies with the copies to clean up.
+// GET /email/duplicates
+func (h *EmailHandler) ListDuplicates(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	groups, err := h.emailService.ListDuplicates(c.Context(), userID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list duplicates")
+	}
+
+	copies := 0
+	for _, g := range groups {
+		copies += len(g.Duplicates)
+	}
+	return c.JSON(fiber.Map{"groups": groups, "total": len(groups), "duplicates": copies})
+}
+
+// CleanupDuplicates moves redundant copies to trash, keeping one email per group.
+// POST /email/duplicates/cleanup
+func (h *EmailHandler) CleanupDuplicates(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	ids, err := h.emailService.CleanupDuplicates(c.Context(), userID)
+	if err != nil {
+		if errors.Is(err, mail.ErrRepoNotInitialized) {
+			return NotConfiguredResponse(c, "email repository")
+		}
+		return InternalErrorResponse(c, err, "cleanup duplicates")
+	}
+
+	if h.emailCache != nil && len(ids) > 0 {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.JSON(fiber.Map{"status": "ok", "ids": ids, "count": len(ids)})
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"worker_server/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// emailFieldAliases expands shorthand names in ?fields=.
+var emailFieldAliases = map[string][]string{
+	"from": {"from_email", "from_name"},
+}
+
+// emailFieldIndex maps domain.Email JSON field names to struct field indexes.
+var emailFieldIndex = func() map[string]int {
+	t := reflect.TypeOf(domain.Email{})
+	index := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			index[name] = i
+		}
+	}
+	return index
+}()
+
+// queryEmailFields parses ?fields= (예: id,subject,from,ai_priority) into Email JSON field names.
+// id는 항상 포함하고, 파라미터가 없으면 nil (전체 필드)을 반환한다.
+func queryEmailFields(c *fiber.Ctx) ([]string, error) {
+	raw := strings.TrimSpace(c.Query("fields"))
+	if raw == "" {
+		return nil, nil
+	}
+
+	fields := []string{"id"}
+	seen := map[string]bool{"id": true}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		names, ok := emailFieldAliases[name]
+		if !ok {
+			names = []string{name}
+		}
+		for _, n := range names {
+			if _, ok := emailFieldIndex[n]; !ok {
+				return nil, fmt.Errorf("unknown field: %s", n)
+			}
+			if !seen[n] {
+				seen[n] = true
+				fields = append(fields, n)
+			}
+		}
+	}
+	return fields, nil
+}
+
+// projectEmails keeps only the requested fields of each email. fields가 비어 있으면 그대로 반환한다.
+func projectEmails(emails []*domain.Email, fields []string) any {
+	if len(fields) == 0 {
+		return emails
+	}
+	projected := make([]map[string]any, 0, len(emails))
+	for _, e := range emails {
+		if e == nil {
+			continue
+		}
+		v := reflect.ValueOf(e).Elem()
+		m := make(map[string]any, len(fields))
+		for _, f := range fields {
+			m[f] = v.Field(emailFieldIndex[f]).Interface()
+		}
+		projected = append(projected, m)
+	}
+	return projected
+}
@@ -1,244 +1,11 @@
 package http
 
 import (
-	"fmt"
-	"strings"
-
 	"worker_server/core/domain"
+	"worker_server/core/service/providerquery"
+	"worker_server/pkg/logger"
 )
 
-// =============================================================================
-// AI-Only Filter Detection
-// =============================================================================
-
-// hasAIOnlyFilters checks if the filter contains AI-specific filters
-// that are not supported by Gmail/Outlook APIs.
-// If true, we should skip API calls and return DB results only.
-func hasAIOnlyFilters(filter *domain.EmailFilter) bool {
-	if filter == nil {
-		return false
-	}
-
-	// AI classification filters (not supported by providers)
-	if filter.Category != nil {
-		return true
-	}
-	if filter.SubCategory != nil {
-		return true
-	}
-	if filter.Priority != nil {
-		return true
-	}
-
-	// Our system-specific filters
-	if filter.WorkflowStatus != nil {
-		return true
-	}
-	if len(filter.LabelIDs) > 0 {
-		return true
-	}
-
-	// FolderID is our internal folder system (different from Gmail labels)
-	if filter.FolderID != nil {
-		return true
-	}
-
-	return false
-}
-
-// =============================================================================
-// Gmail Query Builder
-// =============================================================================
-
-// buildGmailQuery converts EmailFilter to Gmail search query string.
-// Gmail uses q parameter with search operators like "in:inbox is:unread from:x@y.com"
-//
-// Supported operators:
-//   - in:inbox, in:sent, in:drafts, in:trash, in:spam (folder)
-//   - is:read, is:unread (read status)
-//   - is:starred (starred)
-//   - has:attachment (attachments)
-//   - from:email@domain.com (sender)
-//   - after:YYYY/MM/DD, before:YYYY/MM/DD (date range)
-//   - keyword (search text)
-func buildGmailQuery(filter *domain.EmailFilter) string {
-	if filter == nil {
-		return ""
-	}
-
-	var parts []string
-
-	// Folder filter
-	if filter.Folder != nil {
-		folder := strings.ToLower(string(*filter.Folder))
-		switch folder {
-		case "inbox":
-			parts = append(parts, "in:inbox")
-		case "sent":
-			parts = append(parts, "in:sent")
-		case "drafts":
-			parts = append(parts, "in:drafts")
-		case "trash":
-			parts = append(parts, "in:trash")
-		case "spam":
-			parts = append(parts, "in:spam")
-		case "archive":
-			parts = append(parts, "-in:inbox -in:spam -in:trash")
-		default:
-			// Custom label
-			parts = append(parts, fmt.Sprintf("label:%s", folder))
-		}
-	}
-
-	// Read status
-	if filter.IsRead != nil {
-		if *filter.IsRead {
-			parts = append(parts, "is:read")
-		} else {
-			parts = append(parts, "is:unread")
-		}
-	}
-
-	// Starred
-	if filter.IsStarred != nil && *filter.IsStarred {
-		parts = append(parts, "is:starred")
-	}
-
-	// From email
-	if filter.FromEmail != nil && *filter.FromEmail != "" {
-		parts = append(parts, fmt.Sprintf("from:%s", *filter.FromEmail))
-	}
-
-	// From domain
-	if filter.FromDomain != nil && *filter.FromDomain != "" {
-		parts = append(parts, fmt.Sprintf("from:@%s", *filter.FromDomain))
-	}
-
-	// Date range (Gmail format: YYYY/MM/DD)
-	if filter.DateFrom != nil {
-		parts = append(parts, fmt.Sprintf("after:%s", filter.DateFrom.Format("2006/01/02")))
-	}
-	if filter.DateTo != nil {
-		parts = append(parts, fmt.Sprintf("before:%s", filter.DateTo.Format("2006/01/02")))
-	}
-
-	// Has attachment
-	if filter.HasAttachment != nil && *filter.HasAttachment {
-		parts = append(parts, "has:attachment")
-	}
-
-	// Search text
-	if filter.Search != nil && *filter.Search != "" {
-		// Gmail treats plain text as keyword search
-		parts = append(parts, *filter.Search)
-	}
-
-	return strings.Join(parts, " ")
-}
-
-// =============================================================================
-// Outlook Filter Builder
-// =============================================================================
-
-// buildOutlookFilter converts EmailFilter to Outlook OData $filter string.
-// Outlook uses $filter parameter with OData syntax like "isRead eq false and from/emailAddress/address eq 'x@y.com'"
-//
-// Supported filters:
-//   - isRead eq true/false (read status)
-//   - flag/flagStatus eq 'flagged' (starred/flagged)
-//   - hasAttachments eq true (attachments)
-//   - from/emailAddress/address eq 'email' (sender)
-//   - receivedDateTime ge/le 2024-01-01T00:00:00Z (date range)
-//
-// Note: Folder is handled via endpoint path, not $filter
-func buildOutlookFilter(filter *domain.EmailFilter) string {
-	if filter == nil {
-		return ""
-	}
-
-	var conditions []string
-
-	// Read status
-	if filter.IsRead != nil {
-		if *filter.IsRead {
-			conditions = append(conditions, "isRead eq true")
-		} else {
-			conditions = append(conditions, "isRead eq false")
-		}
-	}
-
-	// Starred (flagged in Outlook)
-	if filter.IsStarred != nil && *filter.IsStarred {
-		conditions = append(conditions, "flag/flagStatus eq 'flagged'")
-	}
-
-	// From email (exact match)
-	if filter.FromEmail != nil && *filter.FromEmail != "" {
-		// Use contains for partial match, eq for exact match
-		if strings.Contains(*filter.FromEmail, "@") {
-			conditions = append(conditions, fmt.Sprintf("from/emailAddress/address eq '%s'", *filter.FromEmail))
-		} else {
-			conditions = append(conditions, fmt.Sprintf("contains(from/emailAddress/address, '%s')", *filter.FromEmail))
-		}
-	}
-
-	// From domain
-	if filter.FromDomain != nil && *filter.FromDomain != "" {
-		conditions = append(conditions, fmt.Sprintf("endswith(from/emailAddress/address, '@%s')", *filter.FromDomain))
-	}
-
-	// Date range (ISO8601 format)
-	if filter.DateFrom != nil {
-		conditions = append(conditions, fmt.Sprintf("receivedDateTime ge %s", filter.DateFrom.Format("2006-01-02T15:04:05Z")))
-	}
-	if filter.DateTo != nil {
-		conditions = append(conditions, fmt.Sprintf("receivedDateTime le %s", filter.DateTo.Format("2006-01-02T15:04:05Z")))
-	}
-
-	// Has attachment
-	if filter.HasAttachment != nil && *filter.HasAttachment {
-		conditions = append(conditions, "hasAttachments eq true")
-	}
-
-	return strings.Join(conditions, " and ")
-}
-
-// buildOutlookSearch builds $search parameter for Outlook.
-// Note: $filter and $search can be combined in some cases.
-func buildOutlookSearch(filter *domain.EmailFilter) string {
-	if filter == nil || filter.Search == nil || *filter.Search == "" {
-		return ""
-	}
-	// Outlook $search uses KQL (Keyword Query Language)
-	return fmt.Sprintf("\"%s\"", *filter.Search)
-}
-
-// getOutlookFolderPath returns the Outlook folder path for the filter.
-// Outlook uses folder path in URL, not in $filter.
-func getOutlookFolderPath(filter *domain.EmailFilter) string {
-	if filter == nil || filter.Folder == nil {
-		return "messages" // All messages
-	}
-
-	folder := strings.ToLower(string(*filter.Folder))
-	switch folder {
-	case "inbox":
-		return "mailFolders/inbox/messages"
-	case "sent":
-		return "mailFolders/sentitems/messages"
-	case "drafts":
-		return "mailFolders/drafts/messages"
-	case "trash":
-		return "mailFolders/deleteditems/messages"
-	case "spam":
-		return "mailFolders/junkemail/messages"
-	case "archive":
-		return "mailFolders/archive/messages"
-	default:
-		return "messages"
-	}
-}
-
 // =============================================================================
 // Provider Filter Options
 // =============================================================================
@@ -248,10 +15,10 @@ type ProviderFilterOptions struct {
 	// Gmail
 	GmailQuery string
 
-	// Outlook
-	OutlookFilter string
-	OutlookSearch string
-	OutlookFolder string
+	// Outlook ($filter와 $search는 함께 쓰지 않는다)
+	OutlookFilter   string
+	OutlookSearch   string
+	OutlookFolderID string // mailFolder ID 또는 well-known 이름 (비어 있으면 전체 메일)
 
 	// Common
 	MaxResults int
@@ -260,29 +27,50 @@ type ProviderFilterOptions struct {
 	// Skip API call flag
 	SkipAPICall bool
 	SkipReason  string
+
+	// Translation is the full translation (debug 모드에서 필드별 report 포함).
+	Translation *providerquery.Translation
 }
 
 // BuildProviderFilterOptions creates filter options for both Gmail and Outlook.
-func BuildProviderFilterOptions(filter *domain.EmailFilter, limit int, pageToken string) *ProviderFilterOptions {
-	opts := &ProviderFilterOptions{
-		MaxResults: limit,
-		PageToken:  pageToken,
+// opts.Scope가 없으면 folder_id/label_ids는 우리 시스템 전용 필터로 보고 API 호출을 생략한다.
+func BuildProviderFilterOptions(filter *domain.EmailFilter, limit int, pageToken string, opts *providerquery.Options) *ProviderFilterOptions {
+	tr := providerquery.Translate(filter, opts)
+
+	return &ProviderFilterOptions{
+		GmailQuery:      tr.GmailQuery,
+		OutlookFilter:   tr.OutlookFilter,
+		OutlookSearch:   tr.OutlookSearch,
+		OutlookFolderID: tr.OutlookFolderID,
+		MaxResults:      limit,
+		PageToken:       pageToken,
+		SkipAPICall:     tr.Skip,
+		SkipReason:      tr.SkipReason,
+		Translation:     tr,
 	}
+}
+
+// hasSizeFilter reports whether the filter has a size range (DB에 크기가 없어 Provider로만 조회).
+func hasSizeFilter(filter *domain.EmailFilter) bool {
+	return filter.MinSize != nil || filter.MaxSize != nil
+}
 
-	// Check if we should skip API call
-	if hasAIOnlyFilters(filter) {
-		opts.SkipAPICall = true
-		opts.SkipReason = "AI-only filters present (category, sub_category, priority, workflow_status, label_ids)"
+// providerQueryOptions resolves the provider scope of folder_id/label_ids on the filter's connection.
+func (h *EmailHandler) providerQueryOptions(filter *domain.EmailFilter, debug bool) *providerquery.Options {
+	opts := &providerquery.Options{Debug: debug}
+	if filter.ConnectionID == nil || (filter.FolderID == nil && len(filter.LabelIDs) == 0) {
 		return opts
 	}
-
-	// Build Gmail query
-	opts.GmailQuery = buildGmailQuery(filter)
-
-	// Build Outlook filter
-	opts.OutlookFilter = buildOutlookFilter(filter)
-	opts.OutlookSearch = buildOutlookSearch(filter)
-	opts.OutlookFolder = getOutlookFolderPath(filter)
-
+	if scope, ok := h.scopeResolver.Resolve(filter.UserID, *filter.ConnectionID, filter.FolderID, filter.LabelIDs); ok {
+		opts.Scope = scope
+	}
 	return opts
 }
+
+// logProviderTranslation logs the per-field translation report (debug 모드에서만 채워진다).
+func logProviderTranslation(tr *providerquery.Translation) {
+	for _, entry := range tr.Report {
+		logger.Debug("[EmailHandler] query translation %s: gmail=%q outlook=%q status=%s %s",
+			entry.Field, entry.Gmail, entry.Outlook, entry.Status, entry.Note)
+	}
+}
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/core/domain"
+	"worker_server/core/service/category"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// 피드 발신자 그룹 API (카테고리 목록의 발신자별 롤업)
+// =============================================================================
+
+// SetCategoryService enables per-user custom categories in the category routes.
+func (h *EmailHandler) SetCategoryService(categories *category.Service) {
+	h.categories = categories
+}
+
+// isValidCategory reports whether key is a category of the user (기본 + 사용자 카테고리).
+func (h *EmailHandler) isValidCategory(c *fiber.Ctx, userID uuid.UUID, key string) bool {
+	if h.categories == nil {
+		return domain.IsBuiltinCategory(key)
+	}
+	return h.categories.IsValid(c.Context(), userID, key)
+}
+
+// SenderGroupActionRequest lists the senders of the groups to process.
+type SenderGroupActionRequest struct {
+	Senders []string `json:"senders" validate:"required,max=50"`
+}
+
+// listSenderGroups returns the category feed collapsed by sender.
+// GET /email/category/notification?group_by=sender (펼치기: ?sender=noreply@github.com)
+func (h *EmailHandler) listSenderGroups(c *fiber.Ctx, filter *domain.EmailFilter, category string) error {
+	filter.Sender = nil
+	filter.ExcludeReadLater = true
+
+	groups, total, err := h.emailService.ListSenderGroups(c.Context(), filter)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list sender groups")
+	}
+	return c.JSON(fiber.Map{
+		"groups":   groups,
+		"total":    total,
+		"has_more": filter.Offset+len(groups) < total,
+		"category": category,
+		"group_by": "sender",
+	})
+}
+
+// SenderGroupAction marks read or archives every email of the given sender groups.
+// POST /email/category/:category/groups/read, POST /email/category/:category/groups/archive
+func (h *EmailHandler) SenderGroupAction(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	category := c.Params("category")
+	if !h.isValidCategory(c, userID, category) {
+		return ErrorResponse(c, 400, "invalid category: "+category)
+	}
+
+	var req SenderGroupActionRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	cat := domain.EmailCategory(category)
+	filter := &domain.EmailFilter{
+		UserID:           userID,
+		ConnectionID:     GetConnectionID(c),
+		Category:         &cat,
+		SubCategory:      querySubCategory(c, "sub_category"),
+		ExcludeReadLater: true,
+	}
+
+	action := c.Params("action")
+	ids, err := h.emailService.ApplyGroupAction(c.Context(), filter, req.Senders, action)
+	if err != nil {
+		switch {
+		case errors.Is(err, mail.ErrInvalidGroupAction), errors.Is(err, mail.ErrInvalidSenders):
+			return ErrorResponse(c, 400, err.Error())
+		case errors.Is(err, mail.ErrRepoNotInitialized):
+			return NotConfiguredResponse(c, "email repository")
+		}
+		return InternalErrorResponse(c, err, "sender group "+action)
+	}
+
+	if h.emailCache != nil && len(ids) > 0 {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.JSON(fiber.Map{"status": "ok", "action": action, "ids": ids, "count": len(ids)})
+}
@@ -5,7 +5,10 @@ import (
 	"bufio"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"net/url"
+	"path"
 	"regexp"
 	"strconv"
 	"strings"
@@ -15,13 +18,30 @@ import (
 	"github.com/goccy/go-json"
 	"golang.org/x/oauth2"
 
+	"worker_server/adapter/out/persistence"
 	"worker_server/adapter/out/provider"
 	"worker_server/core/agent/rag"
 	"worker_server/core/domain"
 	"worker_server/core/port/in"
 	"worker_server/core/port/out"
+	"worker_server/core/service"
+	"worker_server/core/service/alias"
 	"worker_server/core/service/auth"
+	"worker_server/core/service/category"
+	"worker_server/core/service/common"
+	"worker_server/core/service/email"
+	"worker_server/core/service/filelink"
+	"worker_server/core/service/imageproxy"
+	"worker_server/core/service/job"
+	"worker_server/core/service/nlfilter"
+	"worker_server/core/service/providerquery"
+	"worker_server/core/service/safelink"
 	"worker_server/core/service/search"
+	"worker_server/core/service/share"
+	"worker_server/core/service/signature"
+	"worker_server/core/service/upload"
+	"worker_server/pkg/crypto"
+	"worker_server/pkg/htmlsanitize"
 	"worker_server/pkg/logger"
 	"worker_server/pkg/ratelimit"
 
@@ -34,19 +54,36 @@ import (
 // Compiled once at package level for performance
 var cidPattern = regexp.MustCompile(`src=["']?cid:<?([^"'>\s]+)>?["']?`)
 
+// inlineURLTTL is the minimum lifetime of signed inline attachment URLs.
+const inlineURLTTL = time.Hour
+
 type EmailHandler struct {
 	emailService     in.EmailService
-	oauthService    *auth.OAuthService
-	gmailProvider   *provider.GmailAdapter
-	outlookProvider *provider.OutlookAdapter
+	oauthService     *auth.OAuthService
+	gmailProvider    *provider.GmailAdapter
+	outlookProvider  *provider.OutlookAdapter
 	emailRepo        out.EmailRepository
-	attachmentRepo  out.AttachmentRepository
-	messageProducer out.MessageProducer
-	unifiedProvider *provider.UnifiedMailProvider
-	syncStateRepo   out.SyncStateRepository
-	apiProtector    *ratelimit.APIProtector
-	emailCache      *ratelimit.EmailListCache
-	searchService   *search.Service
+	attachmentRepo   out.AttachmentRepository
+	messageProducer  out.MessageProducer
+	unifiedProvider  *provider.UnifiedMailProvider
+	syncStateRepo    out.SyncStateRepository
+	apiProtector     *ratelimit.APIProtector
+	emailCache       *ratelimit.EmailListCache
+	searchService    *search.Service
+	safeLinks        *safelink.Service
+	imageProxy       *imageproxy.Service
+	urlSigner        *crypto.URLSigner
+	publicBaseURL    string
+	bodyCache        *common.CacheService
+	uploads          *upload.Service
+	jobs             *job.Service
+	backfills        out.ClassificationBackfillRepository
+	classifyAttempts out.ClassifyAttemptRepository
+	shares           *share.Service
+	categories       *category.Service
+	scopeResolver    *providerquery.Resolver
+	nlFilter         *nlfilter.Parser
+	historyBackfill  *mail.SyncService
 }
 
 func NewMailHandler(emailService in.EmailService) *EmailHandler {
@@ -63,7 +100,7 @@ func NewMailHandlerWithProvider(
 	messageProducer out.MessageProducer,
 	syncStateRepo out.SyncStateRepository,
 	redisClient *redis.Client,
-	vectorStore *rag.VectorStore,
+	vectorStore out.VectorStorePort,
 	embedder *rag.Embedder,
 ) *EmailHandler {
 	// Create unified provider
@@ -94,7 +131,9 @@ func NewMailHandlerWithProvider(
 		L1MaxSize:          1000,
 		L1TTL:              30 * time.Second,
 		L2TTL:              1 * time.Minute,
-		MaxCacheableOffset: 100, // offset 100 이상은 캐시 안 함
+		MaxCacheableOffset: 100,                    // offset 100 이상은 캐시 안 함
+		NegativeTTL:        10 * time.Second,       // 빈 목록은 짧게 캐시
+		EarlyRefreshDelta:  300 * time.Millisecond, // 만료 직전 조기 갱신
 	})
 
 	// 통합 검색 서비스 초기화
@@ -104,11 +143,11 @@ func NewMailHandlerWithProvider(
 	}
 
 	return &EmailHandler{
-		emailService:     emailService,
+		emailService:    emailService,
 		oauthService:    oauthService,
 		gmailProvider:   gmailProvider,
 		outlookProvider: outlookProvider,
-		emailRepo:        emailRepo,
+		emailRepo:       emailRepo,
 		attachmentRepo:  attachmentRepo,
 		messageProducer: messageProducer,
 		unifiedProvider: unifiedProvider,
@@ -119,6 +158,83 @@ func NewMailHandlerWithProvider(
 	}
 }
 
+// SetSafeLinkService enables link rewriting in GetEmailBody.
+func (h *EmailHandler) SetSafeLinkService(svc *safelink.Service) {
+	h.safeLinks = svc
+}
+
+// SetURLSigner enables signed inline attachment URLs (cid_mode=url).
+func (h *EmailHandler) SetURLSigner(signer *crypto.URLSigner, publicBaseURL string) {
+	h.urlSigner = signer
+	h.publicBaseURL = strings.TrimRight(publicBaseURL, "/")
+}
+
+// RegisterPublic registers routes accessed without auth headers (서명 URL로 보호).
+func (h *EmailHandler) RegisterPublic(app fiber.Router) {
+	app.Get("/api/v1/inline/:id/:contentId", h.GetSignedInlineAttachment)
+
+	// 공유 링크 (외부 사용자용 읽기 전용 페이지)
+	app.Get(share.Path+"/:token", h.ViewSharedEmail)
+	app.Post(share.Path+"/:token", h.ViewSharedEmail) // 비밀번호 폼 제출
+	app.Get(share.Path+"/:token/attachments/:attachmentId", h.DownloadSharedAttachment)
+}
+
+// SetImageProxy enables remote image proxying in GetEmailBody.
+func (h *EmailHandler) SetImageProxy(svc *imageproxy.Service) {
+	h.imageProxy = svc
+}
+
+// SetUploadService enables session-tracked large attachment uploads.
+func (h *EmailHandler) SetUploadService(svc *upload.Service) {
+	h.uploads = svc
+}
+
+// SetBodyCache enables background body prefetch for the first inbox page.
+func (h *EmailHandler) SetBodyCache(cache *common.CacheService) {
+	h.bodyCache = cache
+}
+
+// SetJobService enables job_id responses for sync, resync and reclassify.
+func (h *EmailHandler) SetJobService(svc *job.Service) {
+	h.jobs = svc
+}
+
+// SetShareService enables public share links of emails.
+func (h *EmailHandler) SetShareService(svc *share.Service) {
+	h.shares = svc
+}
+
+// SetNLFilterParser sets the natural-language filter parser of GET /email?nl= (LLM 보조 포함).
+func (h *EmailHandler) SetNLFilterParser(parser *nlfilter.Parser) {
+	h.nlFilter = parser
+}
+
+// SetSearchScopeRepositories lets search v2 and the list API translate folder_id/label_id scopes into provider queries.
+func (h *EmailHandler) SetSearchScopeRepositories(folders domain.FolderRepository, labels domain.LabelRepository) {
+	h.scopeResolver = providerquery.NewResolver(folders, labels)
+	if h.searchService != nil {
+		h.searchService.SetScopeRepositories(folders, labels)
+	}
+}
+
+// SetBackfillRepository enables the classification backfill progress route.
+func (h *EmailHandler) SetBackfillRepository(repo out.ClassificationBackfillRepository) {
+	h.backfills = repo
+}
+
+// createJob registers a tracked job. 추적은 부가 기능이므로 실패해도 요청은 계속 처리한다.
+func (h *EmailHandler) createJob(c *fiber.Ctx, userID uuid.UUID, jobType domain.BackgroundJobType, connectionID int64, total int) string {
+	if h.jobs == nil {
+		return ""
+	}
+	j, err := h.jobs.Create(c.Context(), userID, jobType, connectionID, total)
+	if err != nil {
+		logger.WithError(err).Warn("[EmailHandler] Failed to create %s job", jobType)
+		return ""
+	}
+	return j.ID.String()
+}
+
 func (h *EmailHandler) Register(app fiber.Router) {
 	mail := app.Group("/email")
 
@@ -129,19 +245,41 @@ func (h *EmailHandler) Register(app fiber.Router) {
 	// TODO: Inbox + 우선순위 정렬 (처리해야 할 메일)
 	// Feed: notification, newsletter, marketing 등 자동 메일은 /category/:category로 조회
 	// =========================================================================
-	mail.Get("/", h.ListEmails)                       // All Mail (전체 메일)
-	mail.Get("/inbox", h.ListInbox)                   // Inbox (primary, work, personal)
-	mail.Get("/inbox/todo", h.ListTodo)               // TODO (Inbox + 우선순위 DESC 정렬)
-	mail.Get("/category/:category", h.ListByCategory) // 카테고리별 (notification, newsletter, finance 등)
+	mail.Get("/", h.ListEmails)                        // All Mail (전체 메일)
+	mail.Get("/inbox", h.ListInbox)                    // Inbox (primary, work, personal)
+	mail.Get("/inbox/todo", h.ListTodo)                // TODO (Inbox + 우선순위 DESC 정렬)
+	mail.Get("/pinned", h.ListPinned)                  // 고정 메일 (사용자 지정 순서)
+	mail.Put("/pinned/order", h.ReorderPins)           // 고정 순서 저장
+	mail.Get("/read-later", h.ListReadLater)           // 나중에 읽기 큐 (inbox/카테고리와 분리)
+	mail.Post("/read-later", h.AddReadLater)           // 큐에 추가
+	mail.Post("/read-later/remove", h.RemoveReadLater) // 큐에서 제거
+	mail.Get("/category/:category", h.ListByCategory)  // 카테고리별 (notification, newsletter, finance 등, group_by=sender: 발신자별 롤업)
+
+	mail.Post("/category/:category/groups/:action", h.SenderGroupAction) // 발신자 그룹 단위 읽음(read)/보관(archive)
+
+	mail.Get("/duplicates", h.ListDuplicates)             // 중복 메일 (같은 Message-ID, 전달 사본)
+	mail.Post("/duplicates/cleanup", h.CleanupDuplicates) // 사본을 휴지통으로 (그룹별 하나만 남김)
+
+	mail.Post("/threads/:threadId/mute", h.MuteThread)     // 스레드 뮤트 (보관 + 이후 메일 자동 보관, 알림 없음)
+	mail.Delete("/threads/:threadId/mute", h.UnmuteThread) // 뮤트 해제
+
+	mail.Get("/outbox", h.ListOutbox)             // 발송 대기열 (일시적 실패로 재시도 중/실패/취소)
+	mail.Post("/outbox/:id/retry", h.RetryOutbox) // 지금 다시 보내기
+	mail.Delete("/outbox/:id", h.CancelOutbox)    // 발송 취소
 
 	// =========================================================================
 	// 폴더별 목록
 	// =========================================================================
-	mail.Get("/sent", h.ListSent)       // Sent (보낸 메일)
-	mail.Get("/drafts", h.ListDrafts)   // Drafts (임시 보관함)
-	mail.Get("/trash", h.ListTrash)     // Trash (휴지통)
-	mail.Get("/spam", h.ListSpam)       // Spam (스팸)
-	mail.Get("/archive", h.ListArchive) // Archive (보관함)
+	mail.Get("/sent", h.ListSent)           // Sent (보낸 메일)
+	mail.Get("/drafts", h.ListDrafts)       // Drafts (임시 보관함)
+	mail.Get("/trash", h.ListTrash)         // Trash (휴지통)
+	mail.Get("/spam", h.ListSpam)           // Spam (스팸)
+	mail.Get("/archive", h.ListArchive)     // Archive (보관함)
+	mail.Get("/deleted", h.ListDeleted)     // 삭제된 메일 (복원 가능 기간 내)
+	mail.Get("/changes", h.GetEmailChanges) // 델타 동기화 (since 토큰 이후 생성/수정/삭제된 ID)
+
+	mail.Get("/sent/:id/status", h.GetSentStatus)         // 보낸 메일 전달/바운스/스팸 신고 상태
+	mail.Get("/sent/:id/engagement", h.GetSentEngagement) // 보낸 메일 열람/클릭 (opt-in 추적)
 
 	// =========================================================================
 	// 검색 API
@@ -152,12 +290,19 @@ func (h *EmailHandler) Register(app fiber.Router) {
 	// =========================================================================
 	// 동기화 API
 	// =========================================================================
-	mail.Get("/unified", h.ListEmailsUnified)        // 통합 목록 (커서 기반 페이징)
-	mail.Get("/fetch", h.FetchFromProvider)          // Provider에서 직접 가져오기
-	mail.Get("/fetch/body", h.FetchBodyFromProvider) // Provider에서 본문 가져오기
-	mail.Post("/sync", h.TriggerSync)                // 동기화 트리거
-	mail.Post("/resync", h.ResyncEmails)             // 재동기화 (첨부파일 갱신)
-	mail.Post("/reclassify", h.ReclassifyEmails)     // 미분류 메일 재분류
+	mail.Get("/unified", h.ListEmailsUnified)                         // 통합 목록 (커서 기반 페이징)
+	mail.Get("/fetch", h.FetchFromProvider)                           // Provider에서 직접 가져오기
+	mail.Get("/fetch/body", h.FetchBodyFromProvider)                  // Provider에서 본문 가져오기
+	mail.Post("/sync", h.TriggerSync)                                 // 동기화 트리거
+	mail.Post("/resync", h.ResyncEmails)                              // 재동기화 (첨부파일 갱신)
+	mail.Post("/reclassify", h.ReclassifyEmails)                      // 미분류 메일 재분류
+	mail.Get("/reclassify/progress", h.GetReclassifyProgress)         // 분류 backfill 진행률
+	mail.Post("/backfill", h.RequestHistoryBackfill)                  // 동기화 기간 이전 메일 가져오기 (저우선순위)
+	mail.Get("/backfill", h.ListHistoryBackfills)                     // backfill 진행 상황 + 남은 할당량
+	mail.Delete("/backfill/:id", h.CancelHistoryBackfill)             // backfill 취소
+	mail.Get("/classification/status", h.GetClassificationStatus)     // AI 분류 상태별 개수 + 예상 완료 시간
+	mail.Get("/classification/failed", h.ListFailedClassifications)   // 분류 실패 메일 + 마지막 실패 사유
+	mail.Post("/classification/reprocess", h.ReprocessClassification) // 실패 메일 재분류 (다른 모델 또는 heuristic)
 
 	// =========================================================================
 	// 첨부파일 API
@@ -165,7 +310,9 @@ func (h *EmailHandler) Register(app fiber.Router) {
 	mail.Get("/attachments", h.ListAllAttachments)                              // 전체 첨부파일 목록
 	mail.Get("/attachments/stats", h.GetAttachmentStats)                        // 첨부파일 통계
 	mail.Get("/attachments/search", h.SearchAttachments)                        // 첨부파일 검색
+	mail.Post("/attachments/download", h.DownloadAttachmentsZip)                // 여러 메일 첨부파일 ZIP
 	mail.Post("/attachments/upload/session", h.CreateUploadSession)             // 업로드 세션 생성
+	mail.Put("/attachments/upload/:sessionId", h.PutUploadChunk)                // 청크 업로드 (staged/proxy)
 	mail.Get("/attachments/upload/:sessionId/status", h.GetUploadSessionStatus) // 업로드 상태
 	mail.Delete("/attachments/upload/:sessionId", h.CancelUploadSession)        // 업로드 취소
 
@@ -174,19 +321,38 @@ func (h *EmailHandler) Register(app fiber.Router) {
 	// =========================================================================
 	mail.Get("/:id", h.GetEmail)                                              // 메일 상세
 	mail.Get("/:id/body", h.GetEmailBody)                                     // 메일 본문
+	mail.Get("/:id/reader", h.GetReader)                                      // 뉴스레터 읽기 모드 (본문 추출, 추적 제거, 읽기 시간)
+	mail.Get("/:id/raw", h.GetEmailRaw)                                       // 원본 MIME (show original)
+	mail.Get("/:id/headers", h.GetEmailHeaders)                               // 전체 헤더 + SPF/DKIM/DMARC
+	mail.Get("/:id/related", h.GetRelatedEmails)                              // 의미상 유사한 과거 메일 (같은 스레드 제외)
 	mail.Get("/:id/attachments", h.GetAttachments)                            // 첨부파일 목록
 	mail.Get("/:id/attachments/:attachmentId", h.GetAttachment)               // 첨부파일 상세
 	mail.Get("/:id/attachments/:attachmentId/download", h.DownloadAttachment) // 첨부파일 다운로드
 	mail.Get("/:id/cid/:contentId", h.GetInlineAttachment)                    // 인라인 첨부파일
 	mail.Get("/:id/attachments/download/all", h.DownloadAllAttachments)       // 전체 첨부파일 ZIP
 	mail.Post("/:id/resync", h.ResyncSingleEmail)                             // 단일 재동기화
+	mail.Get("/:id/notes", h.ListNotes)                                       // 개인 메모 목록
+	mail.Post("/:id/notes", h.AddNote)                                        // 개인 메모/태그 추가 (Provider 동기화 안 함)
+	mail.Put("/:id/notes/:noteId", h.UpdateNote)                              // 메모 수정
+	mail.Delete("/:id/notes/:noteId", h.DeleteNote)                           // 메모 삭제
+	mail.Post("/:id/share", h.CreateShare)                                    // 공유 링크 생성 (만료, 비밀번호 선택)
+	mail.Get("/:id/shares", h.ListShares)                                     // 공유 링크 목록 + 조회수
+	mail.Delete("/:id/shares/:shareId", h.RevokeShare)                        // 공유 링크 폐기
+	mail.Get("/:id/invite", h.GetInvite)                                      // 캘린더 초대 (.ics 첨부에서 추출한 일정 + 내 응답)
+	mail.Post("/:id/rsvp", h.RSVP)                                            // 초대 응답 (accept/tentative/decline, iTIP REPLY 발송)
+	mail.Get("/:id/translate", h.TranslateEmail)                              // 본문 번역 (?to=ko, 메일+언어별 캐시)
+	mail.Get("/:id/audio", h.GetEmailAudio)                                   // 음성으로 듣기 (?mode=summary|full&voice=, 스트리밍)
+	mail.Get("/:id/activity", h.GetEmailActivity)                             // 타임라인 (동기화, 분류 점수, 읽음/이동, 규칙, AI 작업)
+	mail.Get("/:id/classification/attempts", h.ListClassifyAttempts)          // 분류 시도 이력 (모드, 모델, 실패 사유)
 
 	// =========================================================================
 	// 메일 작성 API
 	// =========================================================================
-	mail.Post("/", h.SendEmail)               // 메일 전송
-	mail.Post("/:id/reply", h.ReplyEmail)     // 답장
-	mail.Post("/:id/forward", h.ForwardEmail) // 전달
+	mail.Post("/", h.SendEmail)                    // 메일 전송 (경고가 있으면 409, 일시적 실패는 outbox 저장 후 202)
+	mail.Post("/send/validate", h.ValidateSend)    // 발송 전 검증만 (MX, 외부 수신자, 첨부 누락)
+	app.Post("/compose/prepare", h.PrepareCompose) // 첨부별 inline/업로드 세션/링크 결정 + draft 생성
+	mail.Post("/:id/reply", h.ReplyEmail)          // 답장
+	mail.Post("/:id/forward", h.ForwardEmail)      // 전달
 
 	// =========================================================================
 	// 배치 작업 API (여러 메일 동시 처리)
@@ -195,9 +361,13 @@ func (h *EmailHandler) Register(app fiber.Router) {
 	mail.Post("/unread", h.MarkAsUnread)             // 안읽음 처리
 	mail.Post("/star", h.Star)                       // 별표
 	mail.Post("/unstar", h.Unstar)                   // 별표 해제
+	mail.Post("/pin", h.Pin)                         // 고정 (inbox/todo 맨 위)
+	mail.Post("/unpin", h.Unpin)                     // 고정 해제
 	mail.Post("/archive", h.Archive)                 // 보관
 	mail.Post("/trash", h.Trash)                     // 휴지통
-	mail.Post("/delete", h.DeleteEmails)             // 영구 삭제
+	mail.Post("/delete", h.DeleteEmails)             // 삭제 (30일 동안 복원 가능, 이후 영구 삭제)
+	mail.Post("/restore", h.RestoreEmails)           // 삭제 취소 (복원)
+	mail.Post("/rehydrate", h.RehydrateEmails)       // 아카이브된 오래된 메일 되돌리기
 	mail.Post("/move", h.MoveToFolder)               // 폴더 이동
 	mail.Post("/snooze", h.Snooze)                   // 스누즈
 	mail.Post("/unsnooze", h.Unsnooze)               // 스누즈 해제
@@ -226,7 +396,7 @@ func (h *EmailHandler) FetchFromProvider(c *fiber.Ctx) error {
 	pageToken := c.Query("page_token", "")
 
 	if h.oauthService == nil || h.gmailProvider == nil {
-		return ErrorResponse(c, 500, "provider not configured")
+		return NotConfiguredResponse(c, "provider")
 	}
 
 	logger.Info("[EmailHandler.FetchFromProvider] Fetching emails for user %s, connection %d, pageToken: %s", userID, connectionID, pageToken)
@@ -258,33 +428,43 @@ func (h *EmailHandler) TriggerSync(c *fiber.Ctx) error {
 		ConnectionID int64 `json:"connection_id"`
 		FullSync     bool  `json:"full_sync"`
 	}
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	logger.Info("[EmailHandler.TriggerSync] User %s, Connection %d, FullSync %v", userID, req.ConnectionID, req.FullSync)
 
 	// Publish sync job to Redis
+	var jobID string
 	if h.messageProducer != nil {
+		jobID = h.createJob(c, userID, domain.BackgroundJobMailSync, req.ConnectionID, 0)
 		job := &out.MailSyncJob{
 			UserID:       userID.String(),
 			ConnectionID: req.ConnectionID,
 			Provider:     "google",
 			FullSync:     req.FullSync,
+			JobID:        jobID,
 		}
 		if err := h.messageProducer.PublishMailSync(c.Context(), job); err != nil {
 			logger.Error("[EmailHandler.TriggerSync] Failed to publish sync job: %v", err)
-			return ErrorResponse(c, 500, "failed to queue sync job")
+			if h.jobs != nil {
+				h.jobs.Finish(c.Context(), jobID, err)
+			}
+			return InternalErrorResponse(c, err, "queue sync job")
 		}
 		logger.Info("[EmailHandler.TriggerSync] Sync job published to Redis")
 	} else {
 		logger.Warn("[EmailHandler.TriggerSync] MessageProducer not configured")
 	}
 
-	return c.JSON(fiber.Map{
+	resp := fiber.Map{
 		"status":  "ok",
 		"message": "Sync job queued",
-	})
+	}
+	if jobID != "" {
+		resp["job_id"] = jobID
+	}
+	return c.JSON(resp)
 }
 
 // ResyncEmails resyncs emails to update attachment information
@@ -303,8 +483,8 @@ func (h *EmailHandler) ResyncEmails(c *fiber.Ctx) error {
 		ResyncAttachments bool    `json:"resync_attachments"` // 첨부파일 정보 재동기화 (DB에 첨부파일 없는 이메일)
 		Limit             int     `json:"limit"`              // 재동기화할 이메일 수 제한
 	}
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if req.ConnectionID == 0 {
@@ -317,8 +497,7 @@ func (h *EmailHandler) ResyncEmails(c *fiber.Ctx) error {
 	// OAuth 토큰 가져오기
 	token, err := h.oauthService.GetOAuth2Token(c.Context(), req.ConnectionID)
 	if err != nil {
-		logger.Error("[EmailHandler.ResyncEmails] Failed to get OAuth token: %v", err)
-		return ErrorResponse(c, 500, "failed to get oauth token")
+		return InternalErrorResponse(c, err, "get oauth token")
 	}
 
 	var emailsToResync []*out.MailEntity
@@ -327,8 +506,7 @@ func (h *EmailHandler) ResyncEmails(c *fiber.Ctx) error {
 		// 첨부파일이 있는 이메일 중 pending ID가 있는 것 조회
 		emails, err := h.emailRepo.GetEmailsWithPendingAttachments(c.Context(), userID, req.ConnectionID)
 		if err != nil {
-			logger.Error("[EmailHandler.ResyncEmails] Failed to get emails with pending attachments: %v", err)
-			return ErrorResponse(c, 500, "failed to get emails")
+			return InternalErrorResponse(c, err, "get emails")
 		}
 		emailsToResync = emails
 	} else if req.ResyncAttachments {
@@ -341,16 +519,14 @@ func (h *EmailHandler) ResyncEmails(c *fiber.Ctx) error {
 		// 1. Gmail API로 첨부파일 있는 메시지 ID 조회
 		attachmentMsgIDs, err := h.gmailProvider.GetAttachmentMessageIDs(c.Context(), token, limit)
 		if err != nil {
-			logger.Error("[EmailHandler.ResyncEmails] Failed to get attachment message IDs: %v", err)
-			return ErrorResponse(c, 500, "failed to get attachment messages from Gmail")
+			return InternalErrorResponse(c, err, "get attachment messages from Gmail")
 		}
 		logger.Info("[EmailHandler.ResyncEmails] Gmail returned %d messages with attachments", len(attachmentMsgIDs))
 
 		// 2. DB에서 해당 external_id를 가진 이메일 중 attachment 정보 없는 것 조회
 		emails, err := h.emailRepo.GetEmailsByExternalIDsNeedingAttachments(c.Context(), userID, req.ConnectionID, attachmentMsgIDs)
 		if err != nil {
-			logger.Error("[EmailHandler.ResyncEmails] Failed to get emails needing attachment resync: %v", err)
-			return ErrorResponse(c, 500, "failed to get emails")
+			return InternalErrorResponse(c, err, "get emails")
 		}
 		logger.Info("[EmailHandler.ResyncEmails] Found %d emails needing attachment sync", len(emails))
 		emailsToResync = emails
@@ -380,13 +556,18 @@ func (h *EmailHandler) ResyncEmails(c *fiber.Ctx) error {
 	}
 
 	// 백그라운드로 재동기화 실행
-	go h.resyncEmailsBackground(userID, req.ConnectionID, token, emailsToResync)
+	jobID := h.createJob(c, userID, domain.BackgroundJobMailResync, req.ConnectionID, len(emailsToResync))
+	go h.resyncEmailsBackground(userID, req.ConnectionID, token, emailsToResync, jobID)
 
-	return c.JSON(fiber.Map{
+	resp := fiber.Map{
 		"status":  "ok",
 		"message": "Resync started",
 		"count":   len(emailsToResync),
-	})
+	}
+	if jobID != "" {
+		resp["job_id"] = jobID
+	}
+	return c.JSON(resp)
 }
 
 // ReclassifyEmails triggers reclassification for unclassified emails.
@@ -402,8 +583,8 @@ func (h *EmailHandler) ReclassifyEmails(c *fiber.Ctx) error {
 		ConnectionID int64 `json:"connection_id"`
 		Limit        int   `json:"limit"`
 	}
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if req.ConnectionID == 0 {
@@ -417,8 +598,7 @@ func (h *EmailHandler) ReclassifyEmails(c *fiber.Ctx) error {
 	// 분류되지 않은 이메일 개수 확인
 	count, err := h.emailRepo.CountUnclassified(c.Context(), req.ConnectionID)
 	if err != nil {
-		logger.Error("[EmailHandler.ReclassifyEmails] Failed to count unclassified: %v", err)
-		return ErrorResponse(c, 500, "failed to count unclassified emails")
+		return InternalErrorResponse(c, err, "count unclassified emails")
 	}
 
 	if count == 0 {
@@ -432,29 +612,126 @@ func (h *EmailHandler) ReclassifyEmails(c *fiber.Ctx) error {
 	// 분류되지 않은 이메일 조회
 	unclassified, err := h.emailRepo.ListUnclassifiedByConnection(c.Context(), req.ConnectionID, req.Limit)
 	if err != nil {
-		logger.Error("[EmailHandler.ReclassifyEmails] Failed to list unclassified: %v", err)
-		return ErrorResponse(c, 500, "failed to list unclassified emails")
+		return InternalErrorResponse(c, err, "list unclassified emails")
 	}
 
 	logger.Info("[EmailHandler.ReclassifyEmails] User %s, Connection %d, Found %d unclassified (total: %d)",
 		userID, req.ConnectionID, len(unclassified), count)
 
 	// ai.classify 작업 발행
+	var jobID string
 	if h.messageProducer != nil {
+		jobID = h.createJob(c, userID, domain.BackgroundJobReclassify, req.ConnectionID, len(unclassified))
+		failed := 0
 		for _, email := range unclassified {
-			h.messageProducer.PublishAIClassify(c.Context(), &out.AIClassifyJob{
-				UserID:  userID.String(),
-				EmailID: email.ID,
-			})
+			if err := h.messageProducer.PublishAIClassify(c.Context(), &out.AIClassifyJob{
+				UserID:   userID.String(),
+				EmailID:  email.ID,
+				JobID:    jobID,
+				Priority: out.JobPriorityLow,
+			}); err != nil {
+				failed++
+			}
+		}
+		if failed > 0 && h.jobs != nil {
+			logger.Warn("[EmailHandler.ReclassifyEmails] Failed to queue %d classify jobs", failed)
+			h.jobs.Progress(c.Context(), jobID, 0, failed)
 		}
 	}
 
-	return c.JSON(fiber.Map{
+	resp := fiber.Map{
 		"status":             "ok",
 		"message":            "Reclassification started",
 		"queued":             len(unclassified),
 		"total_unclassified": count,
-	})
+	}
+	if jobID != "" {
+		resp["job_id"] = jobID
+	}
+	return c.JSON(resp)
+}
+
+// GetReclassifyProgress returns the classification backfill checkpoint of a connection.
+// GET /email/reclassify/progress?connection_id=123
+func (h *EmailHandler) GetReclassifyProgress(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.backfills == nil {
+		return NotConfiguredResponse(c, "classification backfill")
+	}
+
+	connectionID, err := strconv.ParseInt(c.Query("connection_id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "connection_id required")
+	}
+
+	backfill, err := h.backfills.Get(c.Context(), connectionID)
+	if errors.Is(err, persistence.ErrNotFound) || (err == nil && backfill.UserID != userID.String()) {
+		return ErrorResponse(c, 404, "no classification backfill for connection")
+	}
+	if err != nil {
+		return InternalErrorResponse(c, err, "get classification backfill")
+	}
+
+	return c.JSON(backfill)
+}
+
+// GetClassificationStatus returns classification counts of a connection and an ETA
+// estimated from the latest reclassify job's throughput.
+// GET /email/classification/status?connection_id=123
+func (h *EmailHandler) GetClassificationStatus(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	connectionID, err := strconv.ParseInt(c.Query("connection_id"), 10, 64)
+	if err != nil || connectionID <= 0 {
+		return ErrorResponse(c, 400, "connection_id required")
+	}
+
+	counts, err := h.emailRepo.CountAIStatuses(c.Context(), userID, connectionID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "count classification status")
+	}
+
+	remaining := counts.Pending + counts.Processing
+	percent := 100.0
+	if counts.Total > 0 {
+		percent = float64(counts.Classified+counts.Failed) * 100 / float64(counts.Total)
+	}
+
+	resp := fiber.Map{
+		"connection_id": connectionID,
+		"total":         counts.Total,
+		"pending":       counts.Pending,
+		"processing":    counts.Processing,
+		"classified":    counts.Classified,
+		"failed":        counts.Failed,
+		"percent":       percent,
+		"eta_seconds":   nil, // 진행 중인 작업이 없으면 추정 불가
+	}
+	if remaining == 0 {
+		resp["eta_seconds"] = 0
+	}
+
+	// 진행 중인 재분류 작업의 처리 속도로 남은 시간을 추정
+	if h.jobs != nil {
+		latest, err := h.jobs.Latest(c.Context(), userID, domain.BackgroundJobReclassify, connectionID)
+		switch {
+		case err == nil:
+			resp["job"] = latest
+			if eta, ok := latest.ETA(remaining, time.Now()); ok {
+				resp["eta_seconds"] = int64(eta.Seconds())
+			}
+		case !errors.Is(err, job.ErrJobNotFound):
+			logger.WithError(err).Warn("[EmailHandler.GetClassificationStatus] Failed to get latest reclassify job")
+		}
+	}
+
+	return c.JSON(resp)
 }
 
 // ResyncSingleEmail resyncs a single email to update attachment information
@@ -487,15 +764,13 @@ func (h *EmailHandler) ResyncSingleEmail(c *fiber.Ctx) error {
 	// OAuth 토큰 가져오기
 	token, err := h.oauthService.GetOAuth2Token(c.Context(), email.ConnectionID)
 	if err != nil {
-		logger.Error("[EmailHandler.ResyncSingleEmail] Failed to get OAuth token: %v", err)
-		return ErrorResponse(c, 500, "failed to get oauth token")
+		return InternalErrorResponse(c, err, "get oauth token")
 	}
 
 	// Provider에서 본문 + 첨부파일 정보 가져오기
 	body, err := h.gmailProvider.GetMessageBody(c.Context(), token, email.ExternalID)
 	if err != nil {
-		logger.Error("[EmailHandler.ResyncSingleEmail] Failed to get message body: %v", err)
-		return ErrorResponse(c, 500, "failed to fetch from provider")
+		return InternalErrorResponse(c, err, "fetch from provider")
 	}
 
 	// URL 기반 방식: 첨부파일 메타데이터는 DB에 저장하지 않음
@@ -516,11 +791,21 @@ func (h *EmailHandler) ResyncSingleEmail(c *fiber.Ctx) error {
 // resyncEmailsBackground performs email resync in background
 // URL 기반 방식: 첨부파일 메타데이터는 DB에 저장하지 않음
 // has_attachment 플래그만 업데이트
-func (h *EmailHandler) resyncEmailsBackground(userID uuid.UUID, connectionID int64, token *oauth2.Token, emails []*out.MailEntity) {
+func (h *EmailHandler) resyncEmailsBackground(userID uuid.UUID, connectionID int64, token *oauth2.Token, emails []*out.MailEntity, jobID string) {
 	// Use timeout context instead of Background to prevent zombie goroutines
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
+	if h.jobs != nil {
+		h.jobs.Start(ctx, jobID)
+	}
+	// 중단 시 작업 상태는 별도 context로 기록 (ctx는 이미 취소됨)
+	abort := func() {
+		if h.jobs != nil {
+			h.jobs.Finish(context.Background(), jobID, fmt.Errorf("resync stopped: %w", ctx.Err()))
+		}
+	}
+
 	successCount := 0
 	errorCount := 0
 
@@ -528,6 +813,7 @@ func (h *EmailHandler) resyncEmailsBackground(userID uuid.UUID, connectionID int
 		// Check context before each iteration
 		if ctx.Err() != nil {
 			logger.Warn("[EmailHandler.resyncEmailsBackground] Context cancelled, stopping. Processed: %d success, %d errors", successCount, errorCount)
+			abort()
 			return
 		}
 
@@ -535,10 +821,14 @@ func (h *EmailHandler) resyncEmailsBackground(userID uuid.UUID, connectionID int
 		body, err := h.gmailProvider.GetMessageBody(ctx, token, email.ExternalID)
 		if err != nil {
 			if ctx.Err() != nil {
+				abort()
 				return // Context cancelled, stop silently
 			}
 			logger.Error("[EmailHandler.resyncEmailsBackground] Failed to get body for email %d: %v", email.ID, err)
 			errorCount++
+			if h.jobs != nil {
+				h.jobs.Progress(ctx, jobID, 0, 1)
+			}
 			continue
 		}
 
@@ -548,11 +838,15 @@ func (h *EmailHandler) resyncEmailsBackground(userID uuid.UUID, connectionID int
 		}
 
 		successCount++
+		if h.jobs != nil {
+			h.jobs.Progress(ctx, jobID, 1, 0)
+		}
 	}
 
 	logger.Info("[EmailHandler.resyncEmailsBackground] Completed: %d success, %d errors", successCount, errorCount)
 }
 
+// ListEmails lists emails. ?fields=id,subject,from,ai_priority 로 필요한 필드만 조회·응답한다 (모바일 payload 축소).
 func (h *EmailHandler) ListEmails(c *fiber.Ctx) error {
 	userID, err := GetUserID(c)
 	if err != nil {
@@ -582,28 +876,62 @@ func (h *EmailHandler) ListEmails(c *fiber.Ctx) error {
 	filter.DateFrom = queryTime(c, "date_from")
 	filter.DateTo = queryTime(c, "date_to")
 	filter.WorkflowStatus = queryWorkflowStatus(c, "workflow_status")
+	filter.AssignedToMe = c.QueryBool("assigned_to_me")      // 공유 메일함에서 나에게 지정된 메일
+	filter.Language = queryLanguage(c, "language")           // 감지된 언어 (ISO 639-1)
+	filter.IncludeArchived = c.QueryBool("include_archived") // 아카이브된 오래된 메일 포함
+	filter.MinSize = QueryInt64(c, "min_size")               // bytes (Provider 검색 전용)
+	filter.MaxSize = QueryInt64(c, "max_size")
+	debug := c.QueryBool("debug") // Provider 쿼리 번역 report 포함
+
+	// ?nl=unread invoices from last month: 자연어 필터 (명시한 파라미터가 우선)
+	var interpreted *nlfilter.Interpretation
+	if nl := QueryString(c, "nl"); nl != nil {
+		parser := h.nlFilter
+		if parser == nil {
+			parser = nlfilter.NewParser(nil)
+		}
+		interpreted = parser.Parse(c.Context(), *nl, time.Now())
+		interpreted.Filter.Apply(filter)
+	}
+
+	// 크기는 DB에 없으므로 Provider로만 조회 가능
+	if hasSizeFilter(filter) && filter.ConnectionID == nil {
+		return ErrorResponse(c, 400, "min_size/max_size require connection_id")
+	}
+
+	fields, err := queryEmailFields(c)
+	if err != nil {
+		return ErrorResponse(c, 400, err.Error())
+	}
+	filter.Fields = fields
 
 	// Pagination
 	pagination := GetPaginationParams(c, 20)
 	filter.Limit = pagination.Limit
 	filter.Offset = pagination.Offset
 
-	// 최대 payload 크기 제한 (메모리 보호)
-	if h.apiProtector != nil && filter.Limit > h.apiProtector.MaxPayloadSize() {
-		filter.Limit = h.apiProtector.MaxPayloadSize()
-	}
+	// 최대 payload 크기 제한 (메모리 보호, 사용자 tier별)
+	h.limitPayload(c.Context(), filter)
 
 	// =============================================================================
 	// 1단계: 캐시 확인 (최신 메일만 캐시)
 	// =============================================================================
-	cacheKey := h.buildCacheKey(userID, filter)
-	if h.emailCache != nil && h.emailCache.ShouldCache(filter.Offset) {
+	cacheKey := mail.EmailsListCacheKey(filter)
+	// 자연어 필터는 캐시 키에 없는 조건을 만들 수 있어 캐시를 쓰지 않는다
+	useCache := h.emailCache != nil && h.emailCache.ShouldCache(filter.Offset) && interpreted == nil
+	if useCache && !debug {
 		if cachedData, found := h.emailCache.GetByString(c.Context(), cacheKey, filter.Offset); found {
 			var cachedEmails []*domain.Email
 			if err := json.Unmarshal(cachedData, &cachedEmails); err == nil {
+				if notModified(c, listETag(cachedEmails, len(cachedEmails))) {
+					return c.SendStatus(304)
+				}
 				logger.Debug("[EmailHandler] Cache hit for %s", cacheKey)
+				if len(cachedEmails) >= filter.Limit && c.QueryBool("prefetch") {
+					h.prefetchNextPage(filter, mail.EmailsListCacheKey, true)
+				}
 				return c.JSON(fiber.Map{
-					"emails":      cachedEmails,
+					"emails":      projectEmails(cachedEmails, filter.Fields),
 					"total":       len(cachedEmails),
 					"has_more":    len(cachedEmails) >= filter.Limit,
 					"sync_status": "synced",
@@ -614,11 +942,15 @@ func (h *EmailHandler) ListEmails(c *fiber.Ctx) error {
 	}
 
 	// =============================================================================
-	// 2단계: DB 조회 (항상 수행)
+	// 2단계: DB 조회 (크기 필터가 없으면 항상 수행)
 	// =============================================================================
-	emails, total, err := h.emailService.ListEmails(c.Context(), filter)
-	if err != nil {
-		return InternalErrorResponse(c, err, "list emails")
+	var emails []*domain.Email
+	total := 0
+	if !hasSizeFilter(filter) {
+		emails, total, err = h.listEmailsCoalesced(c.Context(), filter)
+		if err != nil {
+			return InternalErrorResponse(c, err, "list emails")
+		}
 	}
 
 	hasMore := filter.Offset+len(emails) < total
@@ -628,17 +960,23 @@ func (h *EmailHandler) ListEmails(c *fiber.Ctx) error {
 	// =============================================================================
 	// 3단계: DB 부족 시 API 보충 (보호 레이어 적용)
 	// 조건: DB 결과가 요청 개수보다 적고, connectionID가 있고, offset이 작을 때
-	// 주의: AI 전용 필터 사용 시 API 호출 스킵 (Gmail/Outlook은 AI 분류를 모름)
+	// 주의: 로컬 전용 필터 사용 시 API 호출 스킵 (Gmail/Outlook은 AI 분류를 모름)
 	// =============================================================================
+	var translation *providerquery.Translation
 	if len(emails) < filter.Limit && filter.ConnectionID != nil {
 		needed := filter.Limit - len(emails)
 
-		// AI 전용 필터 체크: category, sub_category, priority, workflow_status, label_ids
+		// 로컬 전용 필터 체크: category, sub_category, priority, workflow_status, 매핑 없는 folder_id/label_ids
 		// 이 필터들은 우리 시스템에서만 존재하므로 API 호출해도 의미 없음
-		providerOpts := BuildProviderFilterOptions(filter, needed, "")
+		providerOpts := BuildProviderFilterOptions(filter, needed, "", h.providerQueryOptions(filter, debug))
+		translation = providerOpts.Translation
+		logProviderTranslation(translation)
 
 		if providerOpts.SkipAPICall {
-			// AI 필터 사용 시 DB 결과만 반환
+			if hasSizeFilter(filter) {
+				return ErrorResponse(c, 400, "min_size/max_size can't be combined with "+providerOpts.SkipReason)
+			}
+			// 로컬 필터 사용 시 DB 결과만 반환
 			logger.Debug("[EmailHandler] Skipping API call: %s", providerOpts.SkipReason)
 		} else {
 			// offset이 작을 때만 API 호출 (오래된 메일은 API로 직접 조회)
@@ -646,15 +984,20 @@ func (h *EmailHandler) ListEmails(c *fiber.Ctx) error {
 			shouldCallAPI := filter.Offset < 100
 
 			if shouldCallAPI && h.apiProtector != nil && h.oauthService != nil {
-				// 보호 레이어 체크: Semaphore → Debounce → Rate Limiter
+				// 보호 레이어 체크: 사용자 쿼터 → Semaphore → Debounce → Rate Limiter
 				protectKey := fmt.Sprintf("mail:list:%s:%d", userID.String(), *filter.ConnectionID)
-				result, release := h.apiProtector.AcquireWithWait(c.Context(), protectKey, 2*time.Second)
+				result, release := h.apiProtector.AcquireForUserWithWait(c.Context(), userID.String(), protectKey, 2*time.Second)
+				setQuotaHeaders(c, result.Quota)
 
+				if result.Quota != nil && result.Quota.Exceeded != "" {
+					// 사용자 tier 쿼터 소진 → 구조화된 429
+					return quotaExceededResponse(c, result.Quota)
+				}
 				if result.Allowed && release != nil {
 					defer release()
 
 					// API 호출 (보호 레이어 통과) - 필터 옵션 전달
-					apiEmails, apiHasMore, apiErr := h.fetchMoreFromProviderWithFilter(c, userID, *filter.ConnectionID, providerOpts, len(emails))
+					apiEmails, apiHasMore, apiErr := h.fetchMoreFromProviderWithFilter(c.Context(), userID, *filter.ConnectionID, providerOpts, len(emails))
 					if apiErr != nil {
 						logger.Warn("[EmailHandler] API fetch failed: %v", apiErr)
 						// API 실패해도 DB 결과는 반환
@@ -695,32 +1038,52 @@ func (h *EmailHandler) ListEmails(c *fiber.Ctx) error {
 	// =============================================================================
 	// 4단계: 캐시 저장 (최신 메일만)
 	// =============================================================================
-	if h.emailCache != nil && h.emailCache.ShouldCache(filter.Offset) && len(emails) > 0 {
+	// 빈 목록도 짧게 캐시 (동기화 요청 중이면 곧 채워지므로 제외)
+	if useCache && syncStatus != "syncing" && len(filter.Fields) == 0 {
 		if cacheData, err := json.Marshal(emails); err == nil {
 			h.emailCache.SetByString(c.Context(), cacheKey, filter.Offset, cacheData)
 		}
 	}
 
-	return c.JSON(fiber.Map{
-		"emails":      emails,
+	if notModified(c, listETag(emails, total)) {
+		return c.SendStatus(304)
+	}
+
+	// 인박스 첫 페이지 본문 미리 로드
+	if filter.Offset == 0 && (filter.Folder == nil || *filter.Folder == domain.LegacyFolderInbox) {
+		h.prefetchBodies(userID, emails)
+	}
+
+	// ?prefetch=true: 다음 페이지를 미리 캐시 (Provider 보충 포함)
+	if useCache && hasMore && c.QueryBool("prefetch") {
+		h.prefetchNextPage(filter, mail.EmailsListCacheKey, true)
+	}
+
+	resp := fiber.Map{
+		"emails":      projectEmails(emails, filter.Fields),
 		"total":       total,
 		"has_more":    hasMore,
 		"sync_status": syncStatus,
 		"source":      source,
-	})
+	}
+	if interpreted != nil {
+		resp["interpreted_filter"] = interpreted
+	}
+	if debug {
+		if translation == nil {
+			translation = providerquery.Translate(filter, h.providerQueryOptions(filter, true))
+		}
+		resp["query_translation"] = translation
+	}
+	return c.JSON(resp)
 }
 
-// buildCacheKey builds cache key for email list.
-func (h *EmailHandler) buildCacheKey(userID uuid.UUID, filter *domain.EmailFilter) string {
-	key := fmt.Sprintf("emails:%s", userID.String())
-	if filter.ConnectionID != nil {
-		key = fmt.Sprintf("%s:conn:%d", key, *filter.ConnectionID)
-	}
-	if filter.Folder != nil {
-		key = fmt.Sprintf("%s:folder:%s", key, *filter.Folder)
+// prefetchBodies warms the body cache for a list page in background.
+func (h *EmailHandler) prefetchBodies(userID uuid.UUID, emails []*domain.Email) {
+	if h.bodyCache == nil {
+		return
 	}
-	key = fmt.Sprintf("%s:limit:%d:offset:%d", key, filter.Limit, filter.Offset)
-	return key
+	h.bodyCache.PrefetchInbox(userID.String(), emails)
 }
 
 // requestBackgroundSync requests background sync via Redis Stream.
@@ -749,14 +1112,8 @@ func (h *EmailHandler) ListInbox(c *fiber.Ctx) error {
 		return ErrorResponse(c, 401, "unauthorized")
 	}
 
-	// Build filter with inbox view type
-	filter := &domain.EmailFilter{
-		UserID:       userID,
-		ConnectionID: GetConnectionID(c),
-		ViewType:     stringPtr("inbox"), // Inbox view: primary, work, personal only
-		Limit:        20,
-		Offset:       0,
-	}
+	// Build filter with inbox view type (primary, work, personal only)
+	filter := mail.InboxListFilter(userID, GetConnectionID(c))
 
 	// Optional filters
 	filter.IsRead = QueryBool(c, "is_read")
@@ -766,27 +1123,37 @@ func (h *EmailHandler) ListInbox(c *fiber.Ctx) error {
 	filter.DateFrom = queryTime(c, "date_from")
 	filter.DateTo = queryTime(c, "date_to")
 	filter.WorkflowStatus = queryWorkflowStatus(c, "workflow_status")
+	filter.AssignedToMe = c.QueryBool("assigned_to_me")
+
+	fields, err := queryEmailFields(c)
+	if err != nil {
+		return ErrorResponse(c, 400, err.Error())
+	}
+	filter.Fields = fields
 
 	// Pagination
 	pagination := GetPaginationParams(c, 20)
 	filter.Limit = pagination.Limit
 	filter.Offset = pagination.Offset
 
-	// Max payload size
-	if h.apiProtector != nil && filter.Limit > h.apiProtector.MaxPayloadSize() {
-		filter.Limit = h.apiProtector.MaxPayloadSize()
-	}
+	// Max payload size (tier별)
+	h.limitPayload(c.Context(), filter)
 
 	// Cache check
-	cacheKey := fmt.Sprintf("inbox:%s:conn:%v:wf:%v:limit:%d:offset:%d",
-		userID.String(), filter.ConnectionID, filter.WorkflowStatus, filter.Limit, filter.Offset)
+	cacheKey := mail.InboxListCacheKey(filter)
 	if h.emailCache != nil && h.emailCache.ShouldCache(filter.Offset) {
 		if cachedData, found := h.emailCache.GetByString(c.Context(), cacheKey, filter.Offset); found {
 			var cachedEmails []*domain.Email
 			if err := json.Unmarshal(cachedData, &cachedEmails); err == nil {
+				if notModified(c, listETag(cachedEmails, len(cachedEmails))) {
+					return c.SendStatus(304)
+				}
 				logger.Debug("[EmailHandler.ListInbox] Cache hit")
+				if len(cachedEmails) >= filter.Limit && c.QueryBool("prefetch") {
+					h.prefetchNextPage(filter, mail.InboxListCacheKey, false)
+				}
 				return c.JSON(fiber.Map{
-					"emails":   cachedEmails,
+					"emails":   projectEmails(cachedEmails, filter.Fields),
 					"total":    len(cachedEmails),
 					"has_more": len(cachedEmails) >= filter.Limit,
 					"view":     "inbox",
@@ -797,22 +1164,33 @@ func (h *EmailHandler) ListInbox(c *fiber.Ctx) error {
 	}
 
 	// DB query
-	emails, total, err := h.emailService.ListEmails(c.Context(), filter)
+	emails, total, err := h.listEmailsCoalesced(c.Context(), filter)
 	if err != nil {
 		return InternalErrorResponse(c, err, "list inbox")
 	}
 
 	hasMore := filter.Offset+len(emails) < total
 
-	// Cache store
-	if h.emailCache != nil && h.emailCache.ShouldCache(filter.Offset) && len(emails) > 0 {
+	// Cache store (빈 목록은 NegativeTTL)
+	if h.emailCache != nil && h.emailCache.ShouldCache(filter.Offset) && len(filter.Fields) == 0 {
 		if cacheData, err := json.Marshal(emails); err == nil {
 			h.emailCache.SetByString(c.Context(), cacheKey, filter.Offset, cacheData)
 		}
 	}
 
+	if notModified(c, listETag(emails, total)) {
+		return c.SendStatus(304)
+	}
+
+	if filter.Offset == 0 {
+		h.prefetchBodies(userID, emails)
+	}
+	if hasMore && c.QueryBool("prefetch") {
+		h.prefetchNextPage(filter, mail.InboxListCacheKey, false)
+	}
+
 	return c.JSON(fiber.Map{
-		"emails":   emails,
+		"emails":   projectEmails(emails, filter.Fields),
 		"total":    total,
 		"has_more": hasMore,
 		"view":     "inbox",
@@ -821,7 +1199,7 @@ func (h *EmailHandler) ListInbox(c *fiber.Ctx) error {
 }
 
 // ListByCategory returns emails filtered by a specific category.
-// Supported categories: newsletter, notification, marketing, social, finance, travel, shopping, spam, other
+// Supported categories: the built-in categories and the user's custom categories
 // GET /email/category/newsletter?connection_id=1&limit=20&offset=0
 func (h *EmailHandler) ListByCategory(c *fiber.Ctx) error {
 	userID, err := GetUserID(c)
@@ -836,25 +1214,12 @@ func (h *EmailHandler) ListByCategory(c *fiber.Ctx) error {
 	}
 
 	// Validate category
-	validCategories := map[string]bool{
-		"primary": true, "work": true, "personal": true,
-		"newsletter": true, "notification": true, "marketing": true,
-		"social": true, "finance": true, "travel": true,
-		"shopping": true, "spam": true, "other": true,
-	}
-	if !validCategories[category] {
+	if !h.isValidCategory(c, userID, category) {
 		return ErrorResponse(c, 400, "invalid category: "+category)
 	}
 
 	// Build filter
-	cat := domain.EmailCategory(category)
-	filter := &domain.EmailFilter{
-		UserID:       userID,
-		ConnectionID: GetConnectionID(c),
-		Category:     &cat,
-		Limit:        20,
-		Offset:       0,
-	}
+	filter := mail.CategoryListFilter(userID, GetConnectionID(c), domain.EmailCategory(category))
 
 	// Optional sub-category filter (e.g., /category/notification?sub_category=shipping)
 	filter.SubCategory = querySubCategory(c, "sub_category")
@@ -864,27 +1229,50 @@ func (h *EmailHandler) ListByCategory(c *fiber.Ctx) error {
 	filter.DateFrom = queryTime(c, "date_from")
 	filter.DateTo = queryTime(c, "date_to")
 	filter.WorkflowStatus = queryWorkflowStatus(c, "workflow_status")
+	filter.Sender = QueryString(c, "sender") // 발신자 그룹 펼치기
+	filter.Language = queryLanguage(c, "language")
 
 	// Pagination
 	pagination := GetPaginationParams(c, 20)
 	filter.Limit = pagination.Limit
 	filter.Offset = pagination.Offset
 
-	// Max payload size
-	if h.apiProtector != nil && filter.Limit > h.apiProtector.MaxPayloadSize() {
-		filter.Limit = h.apiProtector.MaxPayloadSize()
+	// Max payload size (tier별)
+	h.limitPayload(c.Context(), filter)
+
+	fields, err := queryEmailFields(c)
+	if err != nil {
+		return ErrorResponse(c, 400, err.Error())
+	}
+	filter.Fields = fields
+
+	// 발신자별 롤업 (예: "GitHub — 14 notifications")
+	if c.Query("group_by") == "sender" {
+		return h.listSenderGroups(c, filter, category)
 	}
 
 	// Cache check
-	cacheKey := fmt.Sprintf("category:%s:%s:conn:%v:wf:%v:limit:%d:offset:%d",
-		category, userID.String(), filter.ConnectionID, filter.WorkflowStatus, filter.Limit, filter.Offset)
+	cacheKey := mail.CategoryListCacheKey(category, filter)
+
+	// ?prefetch=true: 다음 페이지 캐시 키 (요청이 끝난 뒤에도 쓰므로 category를 복사)
+	prefetch := c.QueryBool("prefetch")
+	prefetchCategory := strings.Clone(category)
+	nextPageKey := func(f *domain.EmailFilter) string {
+		return mail.CategoryListCacheKey(prefetchCategory, f)
+	}
 	if h.emailCache != nil && h.emailCache.ShouldCache(filter.Offset) {
 		if cachedData, found := h.emailCache.GetByString(c.Context(), cacheKey, filter.Offset); found {
 			var cachedEmails []*domain.Email
 			if err := json.Unmarshal(cachedData, &cachedEmails); err == nil {
+				if notModified(c, listETag(cachedEmails, len(cachedEmails))) {
+					return c.SendStatus(304)
+				}
 				logger.Debug("[EmailHandler.ListByCategory] Cache hit for %s", category)
+				if prefetch && len(cachedEmails) >= filter.Limit {
+					h.prefetchNextPage(filter, nextPageKey, false)
+				}
 				return c.JSON(fiber.Map{
-					"emails":   cachedEmails,
+					"emails":   projectEmails(cachedEmails, filter.Fields),
 					"total":    len(cachedEmails),
 					"has_more": len(cachedEmails) >= filter.Limit,
 					"category": category,
@@ -895,22 +1283,30 @@ func (h *EmailHandler) ListByCategory(c *fiber.Ctx) error {
 	}
 
 	// DB query
-	emails, total, err := h.emailService.ListEmails(c.Context(), filter)
+	emails, total, err := h.listEmailsCoalesced(c.Context(), filter)
 	if err != nil {
 		return InternalErrorResponse(c, err, "list by category")
 	}
 
 	hasMore := filter.Offset+len(emails) < total
 
-	// Cache store
-	if h.emailCache != nil && h.emailCache.ShouldCache(filter.Offset) && len(emails) > 0 {
+	// Cache store (빈 목록은 NegativeTTL)
+	if h.emailCache != nil && h.emailCache.ShouldCache(filter.Offset) && len(filter.Fields) == 0 {
 		if cacheData, err := json.Marshal(emails); err == nil {
 			h.emailCache.SetByString(c.Context(), cacheKey, filter.Offset, cacheData)
 		}
 	}
 
+	if notModified(c, listETag(emails, total)) {
+		return c.SendStatus(304)
+	}
+
+	if prefetch && hasMore {
+		h.prefetchNextPage(filter, nextPageKey, false)
+	}
+
 	return c.JSON(fiber.Map{
-		"emails":   emails,
+		"emails":   projectEmails(emails, filter.Fields),
 		"total":    total,
 		"has_more": hasMore,
 		"category": category,
@@ -924,19 +1320,27 @@ func stringPtr(s string) *string {
 }
 
 // ListTodo returns Inbox emails sorted by priority (highest first).
-// This is the TODO view - same filter as Inbox but sorted by ai_priority DESC.
-// GET /email/inbox/todo?connection_id=1&limit=20&offset=0
+// This is the TODO view - same filter as Inbox but sorted by urgency
+// (ai_priority + deadline proximity in the user's timezone), each email carrying an urgency explanation.
+// sort=priority keeps the ai_priority DESC order.
+// GET /email/inbox/todo?connection_id=1&limit=20&offset=0&sort=urgency
 func (h *EmailHandler) ListTodo(c *fiber.Ctx) error {
 	userID, err := GetUserID(c)
 	if err != nil {
 		return ErrorResponse(c, 401, "unauthorized")
 	}
 
+	sortBy := c.Query("sort", "urgency")
+	if sortBy != "urgency" && sortBy != "priority" {
+		return ErrorResponse(c, 400, "sort must be urgency or priority")
+	}
+
 	filter := &domain.EmailFilter{
 		UserID:       userID,
 		ConnectionID: GetConnectionID(c),
 		ViewType:     stringPtr("inbox"),
-		SortBy:       "priority", // Sort by ai_priority DESC
+		SortBy:       sortBy,
+		PinnedFirst:  true,
 		Limit:        20,
 		Offset:       0,
 	}
@@ -944,25 +1348,36 @@ func (h *EmailHandler) ListTodo(c *fiber.Ctx) error {
 	filter.IsRead = QueryBool(c, "is_read")
 	filter.IsStarred = QueryBool(c, "is_starred")
 	filter.Search = QueryString(c, "search")
+	filter.AssignedToMe = c.QueryBool("assigned_to_me")
+
+	fields, err := queryEmailFields(c)
+	if err != nil {
+		return ErrorResponse(c, 400, err.Error())
+	}
+	filter.Fields = fields
 
 	pagination := GetPaginationParams(c, 20)
 	filter.Limit = pagination.Limit
 	filter.Offset = pagination.Offset
 
-	if h.apiProtector != nil && filter.Limit > h.apiProtector.MaxPayloadSize() {
-		filter.Limit = h.apiProtector.MaxPayloadSize()
-	}
+	h.limitPayload(c.Context(), filter)
 
-	emails, total, err := h.emailService.ListEmails(c.Context(), filter)
+	emails, total, err := h.emailService.ListTodo(c.Context(), filter)
 	if err != nil {
 		return InternalErrorResponse(c, err, "list todo")
 	}
 
+	if notModified(c, listETag(emails, total)) {
+		return c.SendStatus(304)
+	}
+
 	return c.JSON(fiber.Map{
-		"emails":   emails,
+		"emails":   projectEmails(emails, filter.Fields),
 		"total":    total,
 		"has_more": filter.Offset+len(emails) < total,
 		"view":     "todo",
+		"sort":     filter.SortBy,
+		"timezone": filter.Timezone,
 		"source":   "db",
 	})
 }
@@ -973,6 +1388,62 @@ func (h *EmailHandler) ListSent(c *fiber.Ctx) error {
 	return h.listByFolder(c, "sent")
 }
 
+// GetSentStatus returns delivered/bounced/complained state per recipient of a sent email.
+// GET /email/sent/:id/status
+func (h *EmailHandler) GetSentStatus(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	status, err := h.emailService.GetDeliveryStatus(c.Context(), userID, emailID)
+	if err != nil {
+		switch {
+		case errors.Is(err, mail.ErrEmailNotFound), errors.Is(err, common.ErrForbidden):
+			return ErrorResponse(c, 404, "email not found")
+		case errors.Is(err, mail.ErrNotSentEmail):
+			return ErrorResponse(c, 400, err.Error())
+		}
+		return InternalErrorResponse(c, err, "get delivery status")
+	}
+
+	return c.JSON(status)
+}
+
+// GetSentEngagement returns opens and link clicks of a tracked sent email.
+// GET /email/sent/:id/engagement
+func (h *EmailHandler) GetSentEngagement(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	engagement, err := h.emailService.GetEngagement(c.Context(), userID, emailID)
+	if err != nil {
+		switch {
+		case errors.Is(err, mail.ErrTrackingDisabled):
+			return ErrorResponse(c, 404, err.Error())
+		case errors.Is(err, mail.ErrEmailNotFound), errors.Is(err, common.ErrForbidden):
+			return ErrorResponse(c, 404, "email not found")
+		case errors.Is(err, mail.ErrNotSentEmail):
+			return ErrorResponse(c, 400, err.Error())
+		}
+		return InternalErrorResponse(c, err, "get engagement")
+	}
+
+	return c.JSON(engagement)
+}
+
 // ListDrafts returns draft emails.
 // GET /email/drafts?connection_id=1&limit=20&offset=0
 func (h *EmailHandler) ListDrafts(c *fiber.Ctx) error {
@@ -1020,21 +1491,29 @@ func (h *EmailHandler) listByFolder(c *fiber.Ctx, folder string) error {
 	filter.DateFrom = queryTime(c, "date_from")
 	filter.DateTo = queryTime(c, "date_to")
 
+	fields, err := queryEmailFields(c)
+	if err != nil {
+		return ErrorResponse(c, 400, err.Error())
+	}
+	filter.Fields = fields
+
 	pagination := GetPaginationParams(c, 20)
 	filter.Limit = pagination.Limit
 	filter.Offset = pagination.Offset
 
-	if h.apiProtector != nil && filter.Limit > h.apiProtector.MaxPayloadSize() {
-		filter.Limit = h.apiProtector.MaxPayloadSize()
-	}
+	h.limitPayload(c.Context(), filter)
 
 	emails, total, err := h.emailService.ListEmails(c.Context(), filter)
 	if err != nil {
 		return InternalErrorResponse(c, err, "list "+folder)
 	}
 
+	if notModified(c, listETag(emails, total)) {
+		return c.SendStatus(304)
+	}
+
 	return c.JSON(fiber.Map{
-		"emails":   emails,
+		"emails":   projectEmails(emails, filter.Fields),
 		"total":    total,
 		"has_more": filter.Offset+len(emails) < total,
 		"folder":   folder,
@@ -1044,6 +1523,8 @@ func (h *EmailHandler) listByFolder(c *fiber.Ctx, folder string) error {
 
 // ListEmailsUnified lists emails using unified provider with cursor-based pagination.
 // 모든 연결된 계정(Gmail, Outlook)을 통합 조회하고, 커서 기반 페이징을 지원합니다.
+// GET /email/unified?category=primary,work&min_priority=0.6&workflow_status=todo&rank=blended
+// 중복 사본은 duplicates 배열로 묶는다 (끄기: collapse_duplicates=false).
 func (h *EmailHandler) ListEmailsUnified(c *fiber.Ctx) error {
 	userID, err := GetUserID(c)
 	if err != nil {
@@ -1051,7 +1532,7 @@ func (h *EmailHandler) ListEmailsUnified(c *fiber.Ctx) error {
 	}
 
 	if h.unifiedProvider == nil {
-		return ErrorResponse(c, 500, "unified provider not configured")
+		return NotConfiguredResponse(c, "unified provider")
 	}
 
 	// Parse options
@@ -1070,6 +1551,31 @@ func (h *EmailHandler) ListEmailsUnified(c *fiber.Ctx) error {
 		search = &s
 	}
 
+	// AI filters (DB only)
+	var categories []string
+	for _, cat := range strings.Split(c.Query("category"), ",") {
+		if cat = strings.TrimSpace(cat); cat != "" {
+			categories = append(categories, cat)
+		}
+	}
+
+	var minPriority *float64
+	if p := c.Query("min_priority"); p != "" {
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			v = float64(domain.ParsePriority(p)) // "high", "urgent" 등 레벨 이름 허용
+		}
+		if v < 0 || v > 1 {
+			return ErrorResponse(c, 400, "min_priority must be between 0 and 1")
+		}
+		minPriority = &v
+	}
+
+	rank := provider.UnifiedRank(c.Query("rank", string(provider.UnifiedRankTime)))
+	if rank != provider.UnifiedRankTime && rank != provider.UnifiedRankBlended {
+		return ErrorResponse(c, 400, "rank must be time or blended")
+	}
+
 	// Decode cursor
 	var cursor *provider.UnifiedCursor
 	if cursorStr := c.Query("cursor"); cursorStr != "" {
@@ -1078,11 +1584,16 @@ func (h *EmailHandler) ListEmailsUnified(c *fiber.Ctx) error {
 
 	// Call unified provider
 	result, err := h.unifiedProvider.ListAll(c.Context(), &provider.UnifiedListOptions{
-		UserID: userID,
-		Limit:  limit,
-		Cursor: cursor,
-		Folder: folder,
-		Search: search,
+		UserID:         userID,
+		Limit:          limit,
+		Cursor:         cursor,
+		Folder:         folder,
+		Search:         search,
+		Categories:     categories,
+		MinPriority:    minPriority,
+		WorkflowStatus: c.Query("workflow_status"),
+		Rank:           rank,
+		KeepDuplicates: c.Query("collapse_duplicates") == "false",
 	})
 	if err != nil {
 		return InternalErrorResponse(c, err, "list emails unified")
@@ -1181,6 +1692,7 @@ func (h *EmailHandler) SearchEmails(c *fiber.Ctx) error {
 
 // SearchEmailsV2 performs unified search across DB, Vector, and Provider.
 // GET /email/search/v2?q=query&connection_id=123&strategy=balanced&limit=20
+// Scope (optional): folder_id=5, thread_id=<provider thread ID>, label_id=1,2 (라벨 중 하나라도)
 //
 // Strategies:
 //   - fast: DB only (fastest, ~50ms)
@@ -1245,6 +1757,16 @@ func (h *EmailHandler) SearchEmailsV2(c *fiber.Ctx) error {
 		req.Filters.Category = &category
 	}
 
+	// Parse optional scope
+	scope := &search.SearchScope{
+		FolderID: QueryInt64(c, "folder_id"),
+		ThreadID: c.Query("thread_id"),
+		LabelIDs: queryInt64Array(c, "label_id"),
+	}
+	if !scope.IsEmpty() {
+		req.Scope = scope
+	}
+
 	// Create provider search function
 	providerSearchFunc := h.createProviderSearchFunc(c, int64(connectionID))
 
@@ -1274,12 +1796,49 @@ func (h *EmailHandler) SearchEmailsV2(c *fiber.Ctx) error {
 		"db_count":       response.DBCount,
 		"vector_count":   response.VectorCount,
 		"provider_count": response.ProviderCount,
+		"metadata_only":  response.MetadataOnly,
 	})
 }
 
+// GetRelatedEmails returns semantically similar past emails, excluding the same thread.
+// GET /email/:id/related?limit=5&min_score=0.75
+func (h *EmailHandler) GetRelatedEmails(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	if h.searchService == nil {
+		return NotConfiguredResponse(c, "vector search")
+	}
+
+	minScore := search.DefaultRelatedMinScore
+	if v := c.Query("min_score"); v != "" {
+		minScore, err = strconv.ParseFloat(v, 64)
+		if err != nil || minScore <= 0 || minScore > 1 {
+			return ErrorResponse(c, 400, "min_score must be between 0 and 1")
+		}
+	}
+
+	resp, err := h.searchService.Related(c.Context(), userID, emailID, c.QueryInt("limit", search.DefaultRelatedLimit), minScore)
+	if err != nil {
+		if errors.Is(err, search.ErrEmailNotFound) {
+			return ErrorResponse(c, 404, "email not found")
+		}
+		return InternalErrorResponse(c, err, "find related emails")
+	}
+
+	return c.JSON(resp)
+}
+
 // createProviderSearchFunc creates a provider-specific search function.
 func (h *EmailHandler) createProviderSearchFunc(c *fiber.Ctx, connectionID int64) search.ProviderSearchFunc {
-	return func(ctx context.Context, token *oauth2.Token, query string, limit int) ([]*search.SearchResult, error) {
+	return func(ctx context.Context, token *oauth2.Token, query *search.ProviderSearchQuery) ([]*search.SearchResult, error) {
 		if h.oauthService == nil {
 			return nil, nil
 		}
@@ -1292,21 +1851,23 @@ func (h *EmailHandler) createProviderSearchFunc(c *fiber.Ctx, connectionID int64
 		var result *out.ProviderListResult
 
 		switch conn.Provider {
-		case "gmail":
+		case "gmail", "google":
 			if h.gmailProvider == nil {
 				return nil, nil
 			}
 			result, err = h.gmailProvider.ListMessages(ctx, token, &out.ProviderListOptions{
-				MaxResults: limit,
-				Query:      query,
+				MaxResults: query.Limit,
+				Query:      query.GmailQuery,
 			})
 		case "outlook":
 			if h.outlookProvider == nil {
 				return nil, nil
 			}
 			result, err = h.outlookProvider.ListMessages(ctx, token, &out.ProviderListOptions{
-				MaxResults: limit,
-				Query:      query,
+				MaxResults: query.Limit,
+				Query:      query.OutlookQuery,
+				Filter:     query.OutlookFilter,
+				FolderID:   query.OutlookFolderID,
 			})
 		default:
 			return nil, nil
@@ -1331,6 +1892,7 @@ func (h *EmailHandler) createProviderSearchFunc(c *fiber.Ctx, connectionID int64
 
 			results = append(results, &search.SearchResult{
 				ProviderID: msg.ExternalID,
+				ThreadID:   msg.ExternalThreadID,
 				Subject:    msg.Subject,
 				Snippet:    msg.Snippet,
 				From:       from,
@@ -1463,10 +2025,8 @@ func (h *EmailHandler) searchViaProvider(c *fiber.Ctx, userID uuid.UUID, connect
 
 // fetchMoreFromProviderWithFilter fetches emails from provider API with filter support.
 // Gmail: uses q parameter for search query
-// Outlook: uses $filter OData parameter
-func (h *EmailHandler) fetchMoreFromProviderWithFilter(c *fiber.Ctx, userID uuid.UUID, connectionID int64, opts *ProviderFilterOptions, offset int) ([]*domain.Email, bool, error) {
-	ctx := c.Context()
-
+// Outlook: uses $filter OData or $search KQL parameter
+func (h *EmailHandler) fetchMoreFromProviderWithFilter(ctx context.Context, userID uuid.UUID, connectionID int64, opts *ProviderFilterOptions, offset int) ([]*domain.Email, bool, error) {
 	// Get OAuth token
 	token, err := h.oauthService.GetOAuth2Token(ctx, connectionID)
 	if err != nil {
@@ -1503,12 +2063,13 @@ func (h *EmailHandler) fetchMoreFromProviderWithFilter(c *fiber.Ctx, userID uuid
 		if h.outlookProvider == nil {
 			return nil, false, fmt.Errorf("outlook provider not configured")
 		}
-		// Outlook: $filter 파라미터로 필터 전달
+		// Outlook: $filter 또는 $search(KQL), 폴더는 mailFolders 경로로 전달
 		listResult, err = h.outlookProvider.ListMessages(ctx, token, &out.ProviderListOptions{
 			MaxResults: fetchLimit,
-			Query:      opts.OutlookFilter, // "isRead eq false and from/emailAddress/address eq 'x@y.com'"
+			Query:      opts.OutlookSearch, // "from:x@y.com AND size>=1048576"
+			Filter:     opts.OutlookFilter, // "isRead eq false and from/emailAddress/address eq 'x@y.com'"
+			FolderID:   opts.OutlookFolderID,
 			PageToken:  opts.PageToken,
-			// Outlook folder는 별도 경로로 처리 (TODO: OutlookFolder 지원 필요시 추가)
 		})
 	default:
 		return nil, false, fmt.Errorf("unsupported provider: %s", conn.Provider)
@@ -2004,48 +2565,162 @@ func (h *EmailHandler) GetEmailBody(c *fiber.Ctx) error {
 		return ErrorResponse(c, 400, "invalid email id")
 	}
 
-	body, err := h.emailService.GetEmailBody(c.Context(), emailID)
+	sanitizeLevel, err := htmlsanitize.ParseLevel(c.Query("sanitize"))
 	if err != nil {
-		return InternalErrorResponse(c, err, "get email body")
+		return ErrorResponse(c, 400, "sanitize must be strict, standard or off")
+	}
+
+	// <img src="..."> 요청에는 인증 헤더가 없으므로 서명 URL(기본) 또는 Base64 data URL로 치환
+	// cid_mode=url|base64|off (replace_cid=false는 off와 동일)
+	cidMode := c.Query("cid_mode", "url")
+	if !c.QueryBool("replace_cid", true) {
+		cidMode = "off"
+	}
+	if cidMode == "url" && h.urlSigner == nil {
+		cidMode = "base64"
+	}
+
+	// 안전 링크: safe_links 쿼리가 없으면 사용자 설정을 따름
+	var safeLinksUser *uuid.UUID
+	if h.safeLinks != nil {
+		if userID, err := GetUserID(c); err == nil {
+			enabled := c.QueryBool("safe_links", false)
+			if c.Query("safe_links") == "" {
+				enabled = h.safeLinks.Enabled(userID)
+			}
+			if enabled {
+				safeLinksUser = &userID
+			}
+		}
 	}
 
 	// 첨부파일 조회해서 body에 포함
+	var attEntities []*out.EmailAttachmentEntity
 	if h.attachmentRepo != nil {
-		attEntities, err := h.attachmentRepo.GetByEmailID(c.Context(), emailID)
+		attEntities, err = h.attachmentRepo.GetByEmailID(c.Context(), emailID)
 		if err != nil {
 			logger.Warn("[GetEmailBody] Failed to get attachments for email %d: %v", emailID, err)
 		}
 		logger.Info("[GetEmailBody] Found %d attachments in DB for email %d", len(attEntities), emailID)
-		for _, att := range attEntities {
-			contentID := ""
-			if att.ContentID != nil {
-				contentID = *att.ContentID
-			}
-			body.Attachments = append(body.Attachments, &domain.Attachment{
-				ID:         att.ID,
-				EmailID:    att.EmailID,
-				ExternalID: att.ExternalID,
-				Filename:   att.Filename,
-				MimeType:   att.MimeType,
-				Size:       att.Size,
-				ContentID:  contentID,
-				IsInline:   att.IsInline,
-			})
-		}
 	} else {
 		logger.Warn("[GetEmailBody] attachmentRepo is nil, cannot fetch attachments")
 	}
 
-	// Replace CID references with Base64 data URLs (inline images)
-	// This avoids authentication issues with <img src="..."> requests
-	replaceCID := c.QueryBool("replace_cid", true) // default: true
-	if replaceCID && body != nil && body.HTMLBody != "" && h.attachmentRepo != nil {
-		body.HTMLBody = h.replaceCIDWithBase64(c.Context(), emailID, body.HTMLBody)
+	// ETag: 본문을 읽기 전에 비교해 변경이 없으면 MongoDB 조회와 HTML 처리를 건너뜀
+	var latest time.Time
+	for _, att := range attEntities {
+		if att.CreatedAt.After(latest) {
+			latest = att.CreatedAt
+		}
+	}
+	variant := fmt.Sprintf("s%t", safeLinksUser != nil)
+	if cidMode == "url" {
+		variant += fmt.Sprintf("-h%d", time.Now().Truncate(time.Hour).Unix()) // 서명 URL 만료 시각이 시간 단위로 바뀜
+	}
+	if notModified(c, bodyETag(emailID, len(attEntities), latest, variant)) {
+		return c.SendStatus(304)
+	}
+
+	body, err := h.emailService.GetEmailBody(c.Context(), emailID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "get email body")
+	}
+	if body != nil {
+		// singleflight로 공유된 포인터일 수 있으므로 복사 후 수정
+		copied := *body
+		body = &copied
+	}
+
+	for _, att := range attEntities {
+		contentID := ""
+		if att.ContentID != nil {
+			contentID = *att.ContentID
+		}
+		body.Attachments = append(body.Attachments, &domain.Attachment{
+			ID:         att.ID,
+			EmailID:    att.EmailID,
+			ExternalID: att.ExternalID,
+			Filename:   att.Filename,
+			MimeType:   att.MimeType,
+			Size:       att.Size,
+			ContentID:  contentID,
+			IsInline:   att.IsInline,
+		})
+	}
+
+	// 스크립트, 추적 픽셀, 위험한 CSS 제거 (CID/링크 치환 전에 적용)
+	if body != nil && body.HTMLBody != "" && sanitizeLevel != htmlsanitize.LevelOff {
+		sanitized := htmlsanitize.Sanitize(body.HTMLBody, sanitizeLevel)
+		body.HTMLBody = sanitized.HTML
+		body.Sanitize = string(sanitizeLevel)
+		body.TrackersBlocked = sanitized.TrackersBlocked
+	}
+
+	// 원격 이미지를 프록시로 (쿠키/Referer 차단, 추적 픽셀 제거) - proxy_images=false로 끔
+	if body != nil && body.HTMLBody != "" && h.imageProxy != nil && c.QueryBool("proxy_images", true) {
+		var blocked int
+		body.HTMLBody, _, blocked = h.imageProxy.RewriteHTML(body.HTMLBody)
+		body.TrackersBlocked += blocked
+	}
+
+	// Replace CID references (inline images)
+	if body != nil && body.HTMLBody != "" && h.attachmentRepo != nil {
+		switch cidMode {
+		case "url":
+			body.HTMLBody = h.replaceCIDWithSignedURLs(c.Context(), emailID, body.HTMLBody)
+		case "base64":
+			body.HTMLBody = h.replaceCIDWithBase64(c.Context(), emailID, body.HTMLBody)
+		}
+	}
+
+	if body != nil && body.HTMLBody != "" && safeLinksUser != nil {
+		body.HTMLBody, _ = h.safeLinks.RewriteHTML(*safeLinksUser, emailID, body.HTMLBody)
 	}
 
 	return c.JSON(body)
 }
 
+// replaceCIDWithSignedURLs replaces cid: references with short-lived signed URLs
+// to the inline attachment route. 응답 크기가 커지지 않고 본문 조회 시 Provider 다운로드가 없다.
+func (h *EmailHandler) replaceCIDWithSignedURLs(ctx context.Context, emailID int64, html string) string {
+	if h.attachmentRepo == nil || h.urlSigner == nil {
+		return html
+	}
+
+	inlineAttachments, err := h.attachmentRepo.GetInlineByEmailID(ctx, emailID)
+	if err != nil || len(inlineAttachments) == 0 {
+		return html
+	}
+
+	known := make(map[string]bool, len(inlineAttachments))
+	for _, att := range inlineAttachments {
+		if att.ContentID != nil && *att.ContentID != "" {
+			known[strings.Trim(*att.ContentID, "<>")] = true
+		}
+	}
+	if len(known) == 0 {
+		return html
+	}
+
+	// 만료 시각을 시간 단위로 맞춰 같은 시간대에는 URL이 동일 → 브라우저 캐시 재사용
+	expiresAt := time.Now().Truncate(time.Hour).Add(inlineURLTTL + time.Hour)
+
+	return cidPattern.ReplaceAllStringFunc(html, func(match string) string {
+		submatches := cidPattern.FindStringSubmatch(match)
+		if len(submatches) < 2 || !known[submatches[1]] {
+			return match
+		}
+		cid := submatches[1]
+		exp, sig := h.urlSigner.Sign(inlinePayload(emailID, cid), expiresAt)
+		return fmt.Sprintf(`src="%s/api/v1/inline/%d/%s?exp=%s&amp;sig=%s"`,
+			h.publicBaseURL, emailID, url.PathEscape(cid), exp, sig)
+	})
+}
+
+func inlinePayload(emailID int64, contentID string) string {
+	return fmt.Sprintf("cid:%d:%s", emailID, contentID)
+}
+
 // replaceCIDWithBase64 replaces cid: references in HTML with Base64 data URLs.
 // This solves the authentication issue where <img src="..."> requests don't include auth headers.
 // e.g., src="cid:image001" -> src="data:image/png;base64,iVBORw0KGgo..."
@@ -2216,7 +2891,7 @@ func (h *EmailHandler) FetchBodyFromProvider(c *fiber.Ctx) error {
 	}
 
 	if h.oauthService == nil {
-		return ErrorResponse(c, 500, "oauth service not configured")
+		return NotConfiguredResponse(c, "oauth service")
 	}
 
 	ctx := c.Context()
@@ -2224,15 +2899,13 @@ func (h *EmailHandler) FetchBodyFromProvider(c *fiber.Ctx) error {
 	// Get OAuth token
 	token, err := h.oauthService.GetOAuth2Token(ctx, int64(connectionID))
 	if err != nil {
-		logger.WithError(err).Error("[EmailHandler.FetchBodyFromProvider] Failed to get OAuth token")
-		return ErrorResponse(c, 500, "failed to get oauth token")
+		return InternalErrorResponse(c, err, "get oauth token")
 	}
 
 	// Get connection info to determine provider type
 	conn, err := h.oauthService.GetConnection(ctx, int64(connectionID))
 	if err != nil {
-		logger.WithError(err).Error("[EmailHandler.FetchBodyFromProvider] Failed to get connection")
-		return ErrorResponse(c, 500, "failed to get connection info")
+		return InternalErrorResponse(c, err, "get connection info")
 	}
 
 	var body *out.ProviderMessageBody
@@ -2241,12 +2914,12 @@ func (h *EmailHandler) FetchBodyFromProvider(c *fiber.Ctx) error {
 	switch conn.Provider {
 	case "google", "gmail":
 		if h.gmailProvider == nil {
-			return ErrorResponse(c, 500, "gmail provider not configured")
+			return NotConfiguredResponse(c, "gmail provider")
 		}
 		body, err = h.gmailProvider.GetMessageBody(ctx, token, providerID)
 	case "outlook", "microsoft":
 		if h.outlookProvider == nil {
-			return ErrorResponse(c, 500, "outlook provider not configured")
+			return NotConfiguredResponse(c, "outlook provider")
 		}
 		body, err = h.outlookProvider.GetMessageBody(ctx, token, providerID)
 	default:
@@ -2270,8 +2943,8 @@ func (h *EmailHandler) SendEmail(c *fiber.Ctx) error {
 	}
 
 	var req in.SendEmailRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	// Use connection_id from request or query
@@ -2283,6 +2956,27 @@ func (h *EmailHandler) SendEmail(c *fiber.Ctx) error {
 
 	email, err := h.emailService.SendEmail(c.Context(), userID, &req)
 	if err != nil {
+		if warned, resp := sendWarningsResponse(c, err); warned {
+			return resp
+		}
+		if queued, resp := sendQueuedResponse(c, err); queued {
+			return resp
+		}
+		if status := uploadErrorStatus(err); status != 0 {
+			return ErrorResponse(c, status, err.Error())
+		}
+		if errors.Is(err, filelink.ErrTooLarge) {
+			return ErrorResponse(c, 413, err.Error())
+		}
+		if errors.Is(err, signature.ErrNotFound) || errors.Is(err, service.ErrMissingVariables) ||
+			errors.Is(err, mail.ErrTrackingDisabled) || errors.Is(err, mail.ErrTrackingRequiresHTML) ||
+			errors.Is(err, alias.ErrAliasNotFound) || errors.Is(err, alias.ErrAliasNotVerified) ||
+			errors.Is(err, alias.ErrUnsupportedProvider) {
+			return ErrorResponse(c, 400, err.Error())
+		}
+		if errors.Is(err, service.ErrTemplateNotFound) {
+			return ErrorResponse(c, 404, err.Error())
+		}
 		return InternalErrorResponse(c, err, "send email")
 	}
 
@@ -2301,12 +2995,24 @@ func (h *EmailHandler) ReplyEmail(c *fiber.Ctx) error {
 	}
 
 	var req in.ReplyEmailRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	email, err := h.emailService.ReplyEmail(c.Context(), userID, emailID, &req)
 	if err != nil {
+		if warned, resp := sendWarningsResponse(c, err); warned {
+			return resp
+		}
+		if queued, resp := sendQueuedResponse(c, err); queued {
+			return resp
+		}
+		if errors.Is(err, filelink.ErrTooLarge) {
+			return ErrorResponse(c, 413, err.Error())
+		}
+		if errors.Is(err, signature.ErrNotFound) {
+			return ErrorResponse(c, 400, err.Error())
+		}
 		return InternalErrorResponse(c, err, "reply email")
 	}
 
@@ -2325,12 +3031,18 @@ func (h *EmailHandler) ForwardEmail(c *fiber.Ctx) error {
 	}
 
 	var req in.ForwardEmailRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	email, err := h.emailService.ForwardEmail(c.Context(), userID, emailID, &req)
 	if err != nil {
+		if queued, resp := sendQueuedResponse(c, err); queued {
+			return resp
+		}
+		if errors.Is(err, filelink.ErrTooLarge) {
+			return ErrorResponse(c, 413, err.Error())
+		}
 		return InternalErrorResponse(c, err, "forward email")
 	}
 
@@ -2338,8 +3050,10 @@ func (h *EmailHandler) ForwardEmail(c *fiber.Ctx) error {
 }
 
 type EmailIDsRequest struct {
-	IDs          []int64 `json:"ids"`
+	IDs          []int64 `json:"ids" validate:"required,max=1000"`
 	ConnectionID int64   `json:"connection_id,omitempty"`
+	// Versions holds the updated_at the client last saw per ID. 주어진 ID는 그 이후 바뀌었으면 갱신하지 않는다.
+	Versions map[int64]time.Time `json:"versions,omitempty"`
 }
 
 func (h *EmailHandler) MarkAsRead(c *fiber.Ctx) error {
@@ -2349,20 +3063,23 @@ func (h *EmailHandler) MarkAsRead(c *fiber.Ctx) error {
 	}
 
 	var req EmailIDsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
-	if err := h.emailService.MarkAsRead(c.Context(), userID, req.IDs); err != nil {
+	result, err := h.applyBatch(c.Context(), userID, req.IDs, req.Versions, "read", func(ids []int64) error {
+		return h.emailService.MarkAsRead(c.Context(), userID, ids)
+	})
+	if err != nil {
 		return InternalErrorResponse(c, err, "mark as read")
 	}
 
 	// Patch cache instead of full invalidation (Optimistic Update)
 	if h.emailCache != nil {
-		h.emailCache.PatchReadStatus(c.Context(), userID.String(), req.IDs, true)
+		h.emailCache.PatchReadStatus(c.Context(), userID.String(), result.Updated, true)
 	}
 
-	return c.JSON(fiber.Map{"status": "ok", "ids": req.IDs})
+	return batchResponse(c, result, nil)
 }
 
 func (h *EmailHandler) MarkAsUnread(c *fiber.Ctx) error {
@@ -2372,20 +3089,23 @@ func (h *EmailHandler) MarkAsUnread(c *fiber.Ctx) error {
 	}
 
 	var req EmailIDsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
-	if err := h.emailService.MarkAsUnread(c.Context(), userID, req.IDs); err != nil {
+	result, err := h.applyBatch(c.Context(), userID, req.IDs, req.Versions, "unread", func(ids []int64) error {
+		return h.emailService.MarkAsUnread(c.Context(), userID, ids)
+	})
+	if err != nil {
 		return InternalErrorResponse(c, err, "mark as unread")
 	}
 
 	// Patch cache instead of full invalidation (Optimistic Update)
 	if h.emailCache != nil {
-		h.emailCache.PatchReadStatus(c.Context(), userID.String(), req.IDs, false)
+		h.emailCache.PatchReadStatus(c.Context(), userID.String(), result.Updated, false)
 	}
 
-	return c.JSON(fiber.Map{"status": "ok", "ids": req.IDs})
+	return batchResponse(c, result, nil)
 }
 
 func (h *EmailHandler) Star(c *fiber.Ctx) error {
@@ -2395,20 +3115,23 @@ func (h *EmailHandler) Star(c *fiber.Ctx) error {
 	}
 
 	var req EmailIDsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
-	if err := h.emailService.Star(c.Context(), userID, req.IDs); err != nil {
+	result, err := h.applyBatch(c.Context(), userID, req.IDs, req.Versions, "star", func(ids []int64) error {
+		return h.emailService.Star(c.Context(), userID, ids)
+	})
+	if err != nil {
 		return InternalErrorResponse(c, err, "star emails")
 	}
 
 	// Patch cache instead of full invalidation (Optimistic Update)
 	if h.emailCache != nil {
-		h.emailCache.PatchStarStatus(c.Context(), userID.String(), req.IDs, true)
+		h.emailCache.PatchStarStatus(c.Context(), userID.String(), result.Updated, true)
 	}
 
-	return c.JSON(fiber.Map{"status": "ok", "ids": req.IDs})
+	return batchResponse(c, result, nil)
 }
 
 func (h *EmailHandler) Unstar(c *fiber.Ctx) error {
@@ -2418,20 +3141,23 @@ func (h *EmailHandler) Unstar(c *fiber.Ctx) error {
 	}
 
 	var req EmailIDsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
-	if err := h.emailService.Unstar(c.Context(), userID, req.IDs); err != nil {
+	result, err := h.applyBatch(c.Context(), userID, req.IDs, req.Versions, "unstar", func(ids []int64) error {
+		return h.emailService.Unstar(c.Context(), userID, ids)
+	})
+	if err != nil {
 		return InternalErrorResponse(c, err, "unstar emails")
 	}
 
 	// Patch cache instead of full invalidation (Optimistic Update)
 	if h.emailCache != nil {
-		h.emailCache.PatchStarStatus(c.Context(), userID.String(), req.IDs, false)
+		h.emailCache.PatchStarStatus(c.Context(), userID.String(), result.Updated, false)
 	}
 
-	return c.JSON(fiber.Map{"status": "ok", "ids": req.IDs})
+	return batchResponse(c, result, nil)
 }
 
 func (h *EmailHandler) Archive(c *fiber.Ctx) error {
@@ -2441,20 +3167,23 @@ func (h *EmailHandler) Archive(c *fiber.Ctx) error {
 	}
 
 	var req EmailIDsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
-	if err := h.emailService.Archive(c.Context(), userID, req.IDs); err != nil {
+	result, err := h.applyBatch(c.Context(), userID, req.IDs, req.Versions, "archive", func(ids []int64) error {
+		return h.emailService.Archive(c.Context(), userID, ids)
+	})
+	if err != nil {
 		return InternalErrorResponse(c, err, "archive emails")
 	}
 
 	// Remove from inbox cache and patch folder (Optimistic Update)
 	if h.emailCache != nil {
-		h.emailCache.PatchFolder(c.Context(), userID.String(), req.IDs, "archive")
+		h.emailCache.PatchFolder(c.Context(), userID.String(), result.Updated, "archive")
 	}
 
-	return c.JSON(fiber.Map{"status": "ok", "ids": req.IDs})
+	return batchResponse(c, result, nil)
 }
 
 func (h *EmailHandler) Trash(c *fiber.Ctx) error {
@@ -2464,23 +3193,26 @@ func (h *EmailHandler) Trash(c *fiber.Ctx) error {
 	}
 
 	var req EmailIDsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
-	if err := h.emailService.Trash(c.Context(), userID, req.IDs); err != nil {
+	result, err := h.applyBatch(c.Context(), userID, req.IDs, req.Versions, "trash", func(ids []int64) error {
+		return h.emailService.Trash(c.Context(), userID, ids)
+	})
+	if err != nil {
 		return InternalErrorResponse(c, err, "trash emails")
 	}
 
 	// Remove from current folder cache and patch folder (Optimistic Update)
 	if h.emailCache != nil {
-		h.emailCache.PatchFolder(c.Context(), userID.String(), req.IDs, "trash")
+		h.emailCache.PatchFolder(c.Context(), userID.String(), result.Updated, "trash")
 	}
 
-	return c.JSON(fiber.Map{"status": "ok", "ids": req.IDs})
+	return batchResponse(c, result, nil)
 }
 
-// DeleteEmails permanently deletes emails.
+// DeleteEmails deletes emails. 복원 가능 기간이 지나면 영구 삭제된다 (POST /email/restore로 복원).
 func (h *EmailHandler) DeleteEmails(c *fiber.Ctx) error {
 	userID, err := GetUserID(c)
 	if err != nil {
@@ -2488,8 +3220,8 @@ func (h *EmailHandler) DeleteEmails(c *fiber.Ctx) error {
 	}
 
 	var req EmailIDsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.emailService.Delete(c.Context(), userID, req.IDs); err != nil {
@@ -2501,13 +3233,14 @@ func (h *EmailHandler) DeleteEmails(c *fiber.Ctx) error {
 		h.emailCache.RemoveFromCache(c.Context(), userID.String(), req.IDs)
 	}
 
-	return c.JSON(fiber.Map{"status": "ok", "deleted": len(req.IDs), "ids": req.IDs})
+	return c.JSON(fiber.Map{"status": "ok", "deleted": len(req.IDs), "ids": req.IDs, "restorable_until": time.Now().Add(mail.DeleteRetention)})
 }
 
 // MoveToFolderRequest represents move to folder request.
 type MoveToFolderRequest struct {
-	IDs    []int64 `json:"ids"`
-	Folder string  `json:"folder"`
+	IDs      []int64             `json:"ids" validate:"required,max=1000"`
+	Folder   string              `json:"folder" validate:"required,folder"`
+	Versions map[int64]time.Time `json:"versions,omitempty"`
 }
 
 // MoveToFolder moves emails to a specific folder.
@@ -2518,30 +3251,29 @@ func (h *EmailHandler) MoveToFolder(c *fiber.Ctx) error {
 	}
 
 	var req MoveToFolderRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
-	if req.Folder == "" {
-		return ErrorResponse(c, 400, "folder is required")
-	}
-
-	if err := h.emailService.MoveToFolder(c.Context(), userID, req.IDs, req.Folder); err != nil {
+	result, err := h.applyBatch(c.Context(), userID, req.IDs, req.Versions, "move:"+req.Folder, func(ids []int64) error {
+		return h.emailService.MoveToFolder(c.Context(), userID, ids, req.Folder)
+	})
+	if err != nil {
 		return InternalErrorResponse(c, err, "move to folder")
 	}
 
 	// Patch folder in cache (Optimistic Update)
 	if h.emailCache != nil {
-		h.emailCache.PatchFolder(c.Context(), userID.String(), req.IDs, req.Folder)
+		h.emailCache.PatchFolder(c.Context(), userID.String(), result.Updated, req.Folder)
 	}
 
-	return c.JSON(fiber.Map{"status": "ok", "moved": len(req.IDs), "folder": req.Folder, "ids": req.IDs})
+	return batchResponse(c, result, fiber.Map{"moved": len(result.Updated), "folder": req.Folder})
 }
 
 // SnoozeRequest represents snooze request.
 type SnoozeRequest struct {
-	IDs   []int64   `json:"ids"`
-	Until time.Time `json:"until"`
+	IDs   []int64   `json:"ids" validate:"required,max=1000"`
+	Until time.Time `json:"until" validate:"required"`
 }
 
 // Snooze snoozes emails until a specific time.
@@ -2552,12 +3284,8 @@ func (h *EmailHandler) Snooze(c *fiber.Ctx) error {
 	}
 
 	var req SnoozeRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
-	}
-
-	if req.Until.IsZero() {
-		return ErrorResponse(c, 400, "until time is required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.emailService.Snooze(c.Context(), userID, req.IDs, req.Until); err != nil {
@@ -2580,8 +3308,8 @@ func (h *EmailHandler) Unsnooze(c *fiber.Ctx) error {
 	}
 
 	var req EmailIDsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.emailService.Unsnooze(c.Context(), userID, req.IDs); err != nil {
@@ -2598,8 +3326,8 @@ func (h *EmailHandler) Unsnooze(c *fiber.Ctx) error {
 
 // WorkflowStatusRequest represents workflow status update request.
 type WorkflowStatusRequest struct {
-	IDs    []int64 `json:"email_ids"`
-	Status string  `json:"status"` // "todo", "done", "none"
+	IDs    []int64 `json:"email_ids" validate:"required,max=1000"`
+	Status string  `json:"status" validate:"required,oneof=todo done none"`
 }
 
 // UpdateWorkflowStatus changes the workflow status of emails.
@@ -2610,18 +3338,8 @@ func (h *EmailHandler) UpdateWorkflowStatus(c *fiber.Ctx) error {
 	}
 
 	var req WorkflowStatusRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
-	}
-
-	if len(req.IDs) == 0 {
-		return ErrorResponse(c, 400, "email_ids are required")
-	}
-
-	// Validate status
-	validStatuses := map[string]bool{"todo": true, "done": true, "none": true}
-	if !validStatuses[req.Status] {
-		return ErrorResponse(c, 400, "invalid status: must be 'todo', 'done', or 'none'")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.emailService.UpdateWorkflowStatus(c.Context(), userID, req.IDs, req.Status); err != nil {
@@ -2638,8 +3356,8 @@ func (h *EmailHandler) UpdateWorkflowStatus(c *fiber.Ctx) error {
 
 // BatchLabelsRequest represents batch labels request.
 type BatchLabelsRequest struct {
-	IDs    []int64  `json:"ids"`
-	Labels []string `json:"labels"`
+	IDs    []int64  `json:"ids" validate:"required,max=1000"`
+	Labels []string `json:"labels" validate:"required,max=100"`
 }
 
 // BatchAddLabels adds labels to multiple emails.
@@ -2650,12 +3368,8 @@ func (h *EmailHandler) BatchAddLabels(c *fiber.Ctx) error {
 	}
 
 	var req BatchLabelsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
-	}
-
-	if len(req.Labels) == 0 {
-		return ErrorResponse(c, 400, "labels are required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.emailService.BatchAddLabels(c.Context(), userID, req.IDs, req.Labels); err != nil {
@@ -2678,12 +3392,8 @@ func (h *EmailHandler) BatchRemoveLabels(c *fiber.Ctx) error {
 	}
 
 	var req BatchLabelsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
-	}
-
-	if len(req.Labels) == 0 {
-		return ErrorResponse(c, 400, "labels are required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.emailService.BatchRemoveLabels(c.Context(), userID, req.IDs, req.Labels); err != nil {
@@ -2711,7 +3421,7 @@ func (h *EmailHandler) ListAllAttachments(c *fiber.Ctx) error {
 	}
 
 	if h.attachmentRepo == nil {
-		return ErrorResponse(c, 500, "attachment repository not configured")
+		return NotConfiguredResponse(c, "attachment repository")
 	}
 
 	// Parse query parameters
@@ -2846,7 +3556,7 @@ func (h *EmailHandler) GetAttachmentStats(c *fiber.Ctx) error {
 	}
 
 	if h.attachmentRepo == nil {
-		return ErrorResponse(c, 500, "attachment repository not configured")
+		return NotConfiguredResponse(c, "attachment repository")
 	}
 
 	stats, err := h.attachmentRepo.GetStatsByUser(c.Context(), userID)
@@ -2880,7 +3590,7 @@ func (h *EmailHandler) SearchAttachments(c *fiber.Ctx) error {
 	offset := c.QueryInt("offset", 0)
 
 	if h.attachmentRepo == nil {
-		return ErrorResponse(c, 500, "attachment repository not configured")
+		return NotConfiguredResponse(c, "attachment repository")
 	}
 
 	attachments, total, err := h.attachmentRepo.SearchByUser(c.Context(), userID, query, limit, offset)
@@ -3114,7 +3824,7 @@ func (h *EmailHandler) GetAttachment(c *fiber.Ctx) error {
 	}
 
 	if h.attachmentRepo == nil {
-		return ErrorResponse(c, 500, "attachment repository not configured")
+		return NotConfiguredResponse(c, "attachment repository")
 	}
 
 	attachment, err := h.attachmentRepo.GetByID(c.Context(), attachmentID)
@@ -3156,7 +3866,7 @@ func (h *EmailHandler) DownloadAttachment(c *fiber.Ctx) error {
 
 	// Get email to find connection and provider info
 	if h.emailRepo == nil {
-		return ErrorResponse(c, 500, "mail repository not configured")
+		return NotConfiguredResponse(c, "mail repository")
 	}
 
 	email, err := h.emailRepo.GetByID(c.Context(), emailID)
@@ -3169,13 +3879,12 @@ func (h *EmailHandler) DownloadAttachment(c *fiber.Ctx) error {
 
 	// Get OAuth token
 	if h.oauthService == nil {
-		return ErrorResponse(c, 500, "oauth service not configured")
+		return NotConfiguredResponse(c, "oauth service")
 	}
 
 	token, err := h.oauthService.GetOAuth2Token(c.Context(), email.ConnectionID)
 	if err != nil {
-		logger.WithError(err).Error("[EmailHandler.DownloadAttachment] Failed to get OAuth token")
-		return ErrorResponse(c, 500, "failed to get oauth token")
+		return InternalErrorResponse(c, err, "get oauth token")
 	}
 
 	// attachmentId 확인: DB ID인지 external_id인지 판별
@@ -3206,12 +3915,12 @@ func (h *EmailHandler) DownloadAttachment(c *fiber.Ctx) error {
 	switch email.Provider {
 	case "google", "gmail":
 		if h.gmailProvider == nil {
-			return ErrorResponse(c, 500, "gmail provider not configured")
+			return NotConfiguredResponse(c, "gmail provider")
 		}
 		data, mimeType, err = h.gmailProvider.GetAttachment(c.Context(), token, email.ExternalID, attachmentExternalID)
 	case "outlook", "microsoft":
 		if h.outlookProvider == nil {
-			return ErrorResponse(c, 500, "outlook provider not configured")
+			return NotConfiguredResponse(c, "outlook provider")
 		}
 		data, mimeType, err = h.outlookProvider.GetAttachment(c.Context(), token, email.ExternalID, attachmentExternalID)
 	default:
@@ -3219,8 +3928,7 @@ func (h *EmailHandler) DownloadAttachment(c *fiber.Ctx) error {
 	}
 
 	if err != nil {
-		logger.WithError(err).Error("[EmailHandler.DownloadAttachment] Failed to download attachment")
-		return ErrorResponse(c, 500, "failed to download attachment")
+		return InternalErrorResponse(c, err, "download attachment")
 	}
 
 	// Use stored mime type if provider didn't return one
@@ -3263,8 +3971,40 @@ func (h *EmailHandler) GetInlineAttachment(c *fiber.Ctx) error {
 		return ErrorResponse(c, 400, "content id required")
 	}
 
+	return h.serveInlineAttachment(c, emailID, contentID)
+}
+
+// GetSignedInlineAttachment serves an inline attachment via a signed URL (no auth header).
+// GET /api/v1/inline/:id/:contentId?exp=...&sig=...
+func (h *EmailHandler) GetSignedInlineAttachment(c *fiber.Ctx) error {
+	if h.urlSigner == nil {
+		return ErrorResponse(c, 404, "not found")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	contentID, err := url.PathUnescape(c.Params("contentId"))
+	if err != nil || contentID == "" {
+		return ErrorResponse(c, 400, "content id required")
+	}
+
+	if err := h.urlSigner.Verify(inlinePayload(emailID, contentID), c.Query("exp"), c.Query("sig")); err != nil {
+		if errors.Is(err, crypto.ErrSignatureExpired) {
+			return ErrorResponse(c, 410, "link expired")
+		}
+		return ErrorResponse(c, 403, "invalid signature")
+	}
+
+	return h.serveInlineAttachment(c, emailID, contentID)
+}
+
+// serveInlineAttachment downloads an inline attachment from the provider and sends it.
+func (h *EmailHandler) serveInlineAttachment(c *fiber.Ctx, emailID int64, contentID string) error {
 	if h.attachmentRepo == nil {
-		return ErrorResponse(c, 500, "attachment repository not configured")
+		return NotConfiguredResponse(c, "attachment repository")
 	}
 
 	// Find attachment by Content-ID
@@ -3278,7 +4018,7 @@ func (h *EmailHandler) GetInlineAttachment(c *fiber.Ctx) error {
 
 	// Get email to find provider info
 	if h.emailRepo == nil {
-		return ErrorResponse(c, 500, "mail repository not configured")
+		return NotConfiguredResponse(c, "mail repository")
 	}
 
 	email, err := h.emailRepo.GetByID(c.Context(), emailID)
@@ -3292,7 +4032,7 @@ func (h *EmailHandler) GetInlineAttachment(c *fiber.Ctx) error {
 	// Get OAuth token
 	token, err := h.oauthService.GetOAuth2Token(c.Context(), email.ConnectionID)
 	if err != nil {
-		return ErrorResponse(c, 500, "failed to get oauth token")
+		return InternalErrorResponse(c, err, "get oauth token")
 	}
 
 	// Download from provider
@@ -3302,12 +4042,12 @@ func (h *EmailHandler) GetInlineAttachment(c *fiber.Ctx) error {
 	switch email.Provider {
 	case "google", "gmail":
 		if h.gmailProvider == nil {
-			return ErrorResponse(c, 500, "gmail provider not configured")
+			return NotConfiguredResponse(c, "gmail provider")
 		}
 		data, mimeType, err = h.gmailProvider.GetAttachment(c.Context(), token, email.ExternalID, attachment.ExternalID)
 	case "outlook", "microsoft":
 		if h.outlookProvider == nil {
-			return ErrorResponse(c, 500, "outlook provider not configured")
+			return NotConfiguredResponse(c, "outlook provider")
 		}
 		data, mimeType, err = h.outlookProvider.GetAttachment(c.Context(), token, email.ExternalID, attachment.ExternalID)
 	default:
@@ -3315,7 +4055,7 @@ func (h *EmailHandler) GetInlineAttachment(c *fiber.Ctx) error {
 	}
 
 	if err != nil {
-		return ErrorResponse(c, 500, "failed to download attachment")
+		return InternalErrorResponse(c, err, "download attachment")
 	}
 
 	if mimeType == "" {
@@ -3345,7 +4085,7 @@ func (h *EmailHandler) DownloadAllAttachments(c *fiber.Ctx) error {
 	}
 
 	if h.attachmentRepo == nil {
-		return ErrorResponse(c, 500, "attachment repository not configured")
+		return NotConfiguredResponse(c, "attachment repository")
 	}
 
 	// Get all attachments for the email
@@ -3369,7 +4109,7 @@ func (h *EmailHandler) DownloadAllAttachments(c *fiber.Ctx) error {
 	// Get OAuth token
 	token, err := h.oauthService.GetOAuth2Token(c.Context(), email.ConnectionID)
 	if err != nil {
-		return ErrorResponse(c, 500, "failed to get oauth token")
+		return InternalErrorResponse(c, err, "get oauth token")
 	}
 
 	// Filter non-inline attachments
@@ -3444,59 +4184,343 @@ func (h *EmailHandler) DownloadAllAttachments(c *fiber.Ctx) error {
 	return nil
 }
 
+const (
+	// maxZipAttachments limits attachments per multi-email ZIP request.
+	maxZipAttachments = 200
+	// maxZipTotalSize limits the total (declared) size of a multi-email ZIP.
+	maxZipTotalSize = 500 * 1024 * 1024
+)
+
+// DownloadAttachmentsRequest represents the request body for a multi-email ZIP download.
+type DownloadAttachmentsRequest struct {
+	AttachmentIDs []int64 `json:"attachment_ids" validate:"required"`
+	Filename      string  `json:"filename,omitempty"` // ZIP 파일명 (기본 attachments.zip)
+}
+
+// zipManifestEntry records the result of one requested attachment.
+type zipManifestEntry struct {
+	AttachmentID int64  `json:"attachment_id"`
+	EmailID      int64  `json:"email_id,omitempty"`
+	Filename     string `json:"filename,omitempty"` // ZIP 내 파일명 (중복 시 변경됨)
+	Size         int    `json:"size,omitempty"`
+	Status       string `json:"status"` // ok, error, not_found
+	Error        string `json:"error,omitempty"`
+}
+
+// DownloadAttachmentsZip streams attachments from multiple emails (e.g. search results) as one ZIP.
+// 파일명은 중복 제거되며, 실패한 파일은 건너뛰고 manifest.json에 결과를 기록한다.
+// POST /email/attachments/download
+func (h *EmailHandler) DownloadAttachmentsZip(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req DownloadAttachmentsRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	ids := uniqueInt64s(req.AttachmentIDs)
+	if len(ids) == 0 {
+		return ErrorResponse(c, 400, "attachment_ids required")
+	}
+	if len(ids) > maxZipAttachments {
+		return ErrorResponse(c, 400, fmt.Sprintf("too many attachments (max %d)", maxZipAttachments))
+	}
+	if h.attachmentRepo == nil || h.emailRepo == nil || h.oauthService == nil {
+		return NotConfiguredResponse(c, "attachment repository")
+	}
+
+	attachments, err := h.attachmentRepo.GetByIDs(c.Context(), ids)
+	if err != nil {
+		return InternalErrorResponse(c, err, "get attachments")
+	}
+
+	// 권한 확인과 토큰 조회는 스트리밍 전에 처리 (스트리밍 시작 후에는 상태 코드를 바꿀 수 없음)
+	type zipJob struct {
+		att   *out.EmailAttachmentEntity
+		email *out.MailEntity
+		token *oauth2.Token
+	}
+	manifest := make([]zipManifestEntry, 0, len(ids))
+	found := make(map[int64]bool, len(attachments))
+	emails := make(map[int64]*out.MailEntity)
+	tokens := make(map[int64]*oauth2.Token)
+	tokenErrs := make(map[int64]error)
+	var jobs []zipJob
+	var totalSize int64
+
+	for _, att := range attachments {
+		email, ok := emails[att.EmailID]
+		if !ok {
+			email, err = h.emailRepo.GetByID(c.Context(), att.EmailID)
+			if err != nil {
+				return InternalErrorResponse(c, err, "get email")
+			}
+			emails[att.EmailID] = email
+		}
+		// 다른 사용자의 첨부파일은 존재하지 않는 것으로 처리
+		if email == nil || email.UserID != userID {
+			continue
+		}
+		found[att.ID] = true
+
+		token, ok := tokens[email.ConnectionID]
+		if !ok && tokenErrs[email.ConnectionID] == nil {
+			token, err = h.oauthService.GetOAuth2Token(c.Context(), email.ConnectionID)
+			if err != nil {
+				tokenErrs[email.ConnectionID] = err
+			} else {
+				tokens[email.ConnectionID] = token
+			}
+		}
+		if tokenErrs[email.ConnectionID] != nil {
+			manifest = append(manifest, zipManifestEntry{
+				AttachmentID: att.ID, EmailID: att.EmailID, Status: "error", Error: "failed to get oauth token",
+			})
+			continue
+		}
+
+		totalSize += att.Size
+		jobs = append(jobs, zipJob{att: att, email: email, token: token})
+	}
+
+	for _, id := range ids {
+		if !found[id] {
+			manifest = append(manifest, zipManifestEntry{AttachmentID: id, Status: "not_found"})
+		}
+	}
+	if len(jobs) == 0 {
+		return ErrorResponse(c, 404, "no downloadable attachments found")
+	}
+	if totalSize > maxZipTotalSize {
+		return ErrorResponse(c, 413, "attachments too large for a single zip")
+	}
+
+	zipName := sanitizeZipEntryName(req.Filename)
+	if zipName == "" || zipName == "attachment" {
+		zipName = "attachments"
+	}
+	if !strings.HasSuffix(strings.ToLower(zipName), ".zip") {
+		zipName += ".zip"
+	}
+
+	c.Set("Content-Type", "application/zip")
+	c.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, zipName))
+	c.Set("Transfer-Encoding", "chunked")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+
+		zipWriter := zip.NewWriter(w)
+		defer zipWriter.Close()
+
+		const maxConcurrent = 3
+		sem := make(chan struct{}, maxConcurrent)
+		type downloadResult struct {
+			att  *out.EmailAttachmentEntity
+			data []byte
+			err  error
+		}
+		results := make(chan downloadResult, len(jobs))
+
+		var wg sync.WaitGroup
+		for _, job := range jobs {
+			wg.Add(1)
+			go func(job zipJob) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				data, dlErr := h.downloadProviderAttachment(ctx, job.email, job.token, job.att.ExternalID)
+				results <- downloadResult{att: job.att, data: data, err: dlErr}
+			}(job)
+		}
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		// manifest.json 이름은 예약
+		used := map[string]bool{"manifest.json": true}
+		for result := range results {
+			entry := zipManifestEntry{AttachmentID: result.att.ID, EmailID: result.att.EmailID}
+			if result.err != nil {
+				logger.WithError(result.err).Warn("[EmailHandler.DownloadAttachmentsZip] Failed to download: %d", result.att.ID)
+				entry.Status = "error"
+				entry.Error = "download failed"
+				manifest = append(manifest, entry)
+				continue
+			}
+
+			name := uniqueZipName(used, sanitizeZipEntryName(result.att.Filename))
+			zw, err := zipWriter.Create(name)
+			if err == nil {
+				_, err = zw.Write(result.data)
+			}
+			if err != nil {
+				entry.Status = "error"
+				entry.Error = "failed to write zip entry"
+				manifest = append(manifest, entry)
+				continue
+			}
+			entry.Filename = name
+			entry.Size = len(result.data)
+			entry.Status = "ok"
+			manifest = append(manifest, entry)
+		}
+
+		if data, err := json.MarshalIndent(manifest, "", "  "); err == nil {
+			if zw, err := zipWriter.Create("manifest.json"); err == nil {
+				zw.Write(data)
+			}
+		}
+	})
+
+	return nil
+}
+
+// downloadProviderAttachment downloads attachment content from the email's provider.
+func (h *EmailHandler) downloadProviderAttachment(ctx context.Context, email *out.MailEntity, token *oauth2.Token, externalID string) ([]byte, error) {
+	var data []byte
+	var err error
+	switch email.Provider {
+	case "google", "gmail":
+		if h.gmailProvider == nil {
+			return nil, fmt.Errorf("gmail provider not configured")
+		}
+		data, _, err = h.gmailProvider.GetAttachment(ctx, token, email.ExternalID, externalID)
+	case "outlook", "microsoft":
+		if h.outlookProvider == nil {
+			return nil, fmt.Errorf("outlook provider not configured")
+		}
+		data, _, err = h.outlookProvider.GetAttachment(ctx, token, email.ExternalID, externalID)
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", email.Provider)
+	}
+	return data, err
+}
+
+// sanitizeZipEntryName strips directory components and control characters (zip slip 방지).
+func sanitizeZipEntryName(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." {
+		return "attachment"
+	}
+	return name
+}
+
+// uniqueZipName returns name, or "name (n).ext" if it is already used.
+func uniqueZipName(used map[string]bool, name string) string {
+	key := strings.ToLower(name)
+	if !used[key] {
+		used[key] = true
+		return name
+	}
+
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)%s", base, i, ext)
+		if !used[strings.ToLower(candidate)] {
+			used[strings.ToLower(candidate)] = true
+			return candidate
+		}
+	}
+}
+
+// uniqueInt64s removes duplicates and non-positive IDs, keeping order.
+func uniqueInt64s(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
 // =============================================================================
 // Upload Session API (Provider Delegation for Large Attachments)
 // =============================================================================
 
 // CreateUploadSessionRequest represents the request body for creating an upload session.
 type CreateUploadSessionRequest struct {
-	ConnectionID int64  `json:"connection_id"`
+	ConnectionID int64  `json:"connection_id" validate:"required"`
 	MessageID    string `json:"message_id,omitempty"` // For attaching to existing draft
-	Filename     string `json:"filename"`
-	Size         int64  `json:"size"`
+	Filename     string `json:"filename" validate:"required,max=255"`
+	Size         int64  `json:"size" validate:"required,min=1"`
 	MimeType     string `json:"mime_type"`
 	IsInline     bool   `json:"is_inline,omitempty"`
 	ContentID    string `json:"content_id,omitempty"`
+	Proxy        bool   `json:"proxy,omitempty"` // Provider로 직접 업로드할 수 없는 클라이언트용 (CORS)
 }
 
 // CreateUploadSession creates an upload session for large attachments.
 // The frontend will receive an uploadUrl to directly upload chunks to Gmail/Outlook.
 // POST /email/attachments/upload/session
 func (h *EmailHandler) CreateUploadSession(c *fiber.Ctx) error {
-	_, err := GetUserID(c)
+	userID, err := GetUserID(c)
 	if err != nil {
 		return ErrorResponse(c, 401, "unauthorized")
 	}
 
 	var req CreateUploadSessionRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
-	// Validation
-	if req.ConnectionID == 0 {
-		return ErrorResponse(c, 400, "connection_id required")
-	}
-	if req.Filename == "" {
-		return ErrorResponse(c, 400, "filename required")
-	}
-	if req.Size <= 0 {
-		return ErrorResponse(c, 400, "size must be positive")
-	}
 	if req.MimeType == "" {
 		req.MimeType = "application/octet-stream"
 	}
 
+	// 세션 추적 모드 - 발송 시 upload_session_ids로 첨부
+	if h.uploads != nil {
+		session, err := h.uploads.Create(c.Context(), userID, &upload.CreateRequest{
+			ConnectionID: req.ConnectionID,
+			MessageID:    req.MessageID,
+			Filename:     req.Filename,
+			Size:         req.Size,
+			MimeType:     req.MimeType,
+			IsInline:     req.IsInline,
+			ContentID:    req.ContentID,
+			Proxy:        req.Proxy,
+		})
+		if err != nil {
+			if status := uploadErrorStatus(err); status != 0 {
+				return ErrorResponse(c, status, err.Error())
+			}
+			return InternalErrorResponse(c, err, "create upload session")
+		}
+		return c.Status(201).JSON(session)
+	}
+
 	// Get connection info
 	conn, err := h.oauthService.GetConnection(c.Context(), req.ConnectionID)
 	if err != nil {
-		return ErrorResponse(c, 500, "failed to get connection")
+		return InternalErrorResponse(c, err, "get connection")
 	}
 
 	// Get OAuth token
 	token, err := h.oauthService.GetOAuth2Token(c.Context(), req.ConnectionID)
 	if err != nil {
-		return ErrorResponse(c, 500, "failed to get oauth token")
+		return InternalErrorResponse(c, err, "get oauth token")
 	}
 
 	// Build upload session request
@@ -3513,12 +4537,12 @@ func (h *EmailHandler) CreateUploadSession(c *fiber.Ctx) error {
 	switch conn.Provider {
 	case "google", "gmail":
 		if h.gmailProvider == nil {
-			return ErrorResponse(c, 500, "gmail provider not configured")
+			return NotConfiguredResponse(c, "gmail provider")
 		}
 		resp, err = h.gmailProvider.CreateUploadSession(c.Context(), token, req.MessageID, uploadReq)
 	case "outlook", "microsoft":
 		if h.outlookProvider == nil {
-			return ErrorResponse(c, 500, "outlook provider not configured")
+			return NotConfiguredResponse(c, "outlook provider")
 		}
 		if req.MessageID == "" {
 			return ErrorResponse(c, 400, "message_id required for Outlook attachments")
@@ -3529,8 +4553,7 @@ func (h *EmailHandler) CreateUploadSession(c *fiber.Ctx) error {
 	}
 
 	if err != nil {
-		logger.WithError(err).Error("[EmailHandler.CreateUploadSession] Failed")
-		return ErrorResponse(c, 500, "failed to create upload session: "+err.Error())
+		return InternalErrorResponse(c, err, "create upload session")
 	}
 
 	return c.Status(201).JSON(fiber.Map{
@@ -3546,11 +4569,22 @@ func (h *EmailHandler) CreateUploadSession(c *fiber.Ctx) error {
 // GetUploadSessionStatus checks the status of an upload session.
 // GET /email/attachments/upload/:sessionId/status
 func (h *EmailHandler) GetUploadSessionStatus(c *fiber.Ctx) error {
-	_, err := GetUserID(c)
+	userID, err := GetUserID(c)
 	if err != nil {
 		return ErrorResponse(c, 401, "unauthorized")
 	}
 
+	if h.uploads != nil && c.Query("upload_url") == "" {
+		session, err := h.uploads.Status(c.Context(), userID, c.Params("sessionId"))
+		if err != nil {
+			if status := uploadErrorStatus(err); status != 0 {
+				return ErrorResponse(c, status, err.Error())
+			}
+			return InternalErrorResponse(c, err, "get upload status")
+		}
+		return c.JSON(session)
+	}
+
 	uploadURL := c.Query("upload_url")
 	if uploadURL == "" {
 		return ErrorResponse(c, 400, "upload_url query parameter required")
@@ -3564,13 +4598,13 @@ func (h *EmailHandler) GetUploadSessionStatus(c *fiber.Ctx) error {
 	// Get connection info
 	conn, err := h.oauthService.GetConnection(c.Context(), int64(connectionID))
 	if err != nil {
-		return ErrorResponse(c, 500, "failed to get connection")
+		return InternalErrorResponse(c, err, "get connection")
 	}
 
 	// Get OAuth token
 	token, err := h.oauthService.GetOAuth2Token(c.Context(), int64(connectionID))
 	if err != nil {
-		return ErrorResponse(c, 500, "failed to get oauth token")
+		return InternalErrorResponse(c, err, "get oauth token")
 	}
 
 	var status *out.UploadSessionStatus
@@ -3578,12 +4612,12 @@ func (h *EmailHandler) GetUploadSessionStatus(c *fiber.Ctx) error {
 	switch conn.Provider {
 	case "google", "gmail":
 		if h.gmailProvider == nil {
-			return ErrorResponse(c, 500, "gmail provider not configured")
+			return NotConfiguredResponse(c, "gmail provider")
 		}
 		status, err = h.gmailProvider.GetUploadSessionStatus(c.Context(), token, uploadURL)
 	case "outlook", "microsoft":
 		if h.outlookProvider == nil {
-			return ErrorResponse(c, 500, "outlook provider not configured")
+			return NotConfiguredResponse(c, "outlook provider")
 		}
 		status, err = h.outlookProvider.GetUploadSessionStatus(c.Context(), token, uploadURL)
 	default:
@@ -3591,8 +4625,7 @@ func (h *EmailHandler) GetUploadSessionStatus(c *fiber.Ctx) error {
 	}
 
 	if err != nil {
-		logger.WithError(err).Error("[EmailHandler.GetUploadSessionStatus] Failed")
-		return ErrorResponse(c, 500, "failed to get upload status: "+err.Error())
+		return InternalErrorResponse(c, err, "get upload status")
 	}
 
 	return c.JSON(fiber.Map{
@@ -3608,11 +4641,21 @@ func (h *EmailHandler) GetUploadSessionStatus(c *fiber.Ctx) error {
 // CancelUploadSession cancels an upload session.
 // DELETE /email/attachments/upload/:sessionId
 func (h *EmailHandler) CancelUploadSession(c *fiber.Ctx) error {
-	_, err := GetUserID(c)
+	userID, err := GetUserID(c)
 	if err != nil {
 		return ErrorResponse(c, 401, "unauthorized")
 	}
 
+	if h.uploads != nil && c.Query("upload_url") == "" {
+		if err := h.uploads.Cancel(c.Context(), userID, c.Params("sessionId")); err != nil {
+			if status := uploadErrorStatus(err); status != 0 {
+				return ErrorResponse(c, status, err.Error())
+			}
+			return InternalErrorResponse(c, err, "cancel upload")
+		}
+		return c.SendStatus(204)
+	}
+
 	uploadURL := c.Query("upload_url")
 	if uploadURL == "" {
 		return ErrorResponse(c, 400, "upload_url query parameter required")
@@ -3626,24 +4669,24 @@ func (h *EmailHandler) CancelUploadSession(c *fiber.Ctx) error {
 	// Get connection info
 	conn, err := h.oauthService.GetConnection(c.Context(), int64(connectionID))
 	if err != nil {
-		return ErrorResponse(c, 500, "failed to get connection")
+		return InternalErrorResponse(c, err, "get connection")
 	}
 
 	// Get OAuth token
 	token, err := h.oauthService.GetOAuth2Token(c.Context(), int64(connectionID))
 	if err != nil {
-		return ErrorResponse(c, 500, "failed to get oauth token")
+		return InternalErrorResponse(c, err, "get oauth token")
 	}
 
 	switch conn.Provider {
 	case "google", "gmail":
 		if h.gmailProvider == nil {
-			return ErrorResponse(c, 500, "gmail provider not configured")
+			return NotConfiguredResponse(c, "gmail provider")
 		}
 		err = h.gmailProvider.CancelUploadSession(c.Context(), token, uploadURL)
 	case "outlook", "microsoft":
 		if h.outlookProvider == nil {
-			return ErrorResponse(c, 500, "outlook provider not configured")
+			return NotConfiguredResponse(c, "outlook provider")
 		}
 		err = h.outlookProvider.CancelUploadSession(c.Context(), token, uploadURL)
 	default:
@@ -3651,13 +4694,83 @@ func (h *EmailHandler) CancelUploadSession(c *fiber.Ctx) error {
 	}
 
 	if err != nil {
-		logger.WithError(err).Error("[EmailHandler.CancelUploadSession] Failed")
-		return ErrorResponse(c, 500, "failed to cancel upload: "+err.Error())
+		return InternalErrorResponse(c, err, "cancel upload")
 	}
 
 	return c.SendStatus(204)
 }
 
+// PutUploadChunk receives a chunk for a staged or proxy upload session.
+// proxy 세션은 청크를 Provider 업로드 URL로 그대로 전달하며, 진행률은 upload.progress 이벤트로도 푸시된다.
+// Content-Range: bytes <start>-<end>/<total> (없으면 단일 요청으로 전체 업로드)
+// 실패 시 GET status의 bytes_uploaded부터 다시 보내면 된다.
+// PUT /email/attachments/upload/:sessionId
+func (h *EmailHandler) PutUploadChunk(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.uploads == nil {
+		return NotConfiguredResponse(c, "upload sessions")
+	}
+
+	offset, err := parseContentRangeStart(c.Get("Content-Range"))
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid Content-Range")
+	}
+
+	session, err := h.uploads.WriteChunk(c.Context(), userID, c.Params("sessionId"), offset, c.Body())
+	if err != nil {
+		if errors.Is(err, upload.ErrInvalidRange) {
+			// 클라이언트가 재개 위치를 알 수 있도록 현재 상태 반환
+			if current, getErr := h.uploads.Get(c.Context(), userID, c.Params("sessionId")); getErr == nil {
+				return c.Status(416).JSON(current)
+			}
+		}
+		if status := uploadErrorStatus(err); status != 0 {
+			return ErrorResponse(c, status, err.Error())
+		}
+		return InternalErrorResponse(c, err, "upload chunk")
+	}
+
+	if !session.IsComplete {
+		return c.Status(202).JSON(session)
+	}
+	return c.JSON(session)
+}
+
+// parseContentRangeStart returns the start offset of "bytes start-end/total".
+func parseContentRangeStart(header string) (int64, error) {
+	if header == "" {
+		return 0, nil
+	}
+	spec := strings.TrimSpace(strings.TrimPrefix(header, "bytes"))
+	dash := strings.IndexByte(spec, '-')
+	if dash <= 0 {
+		return 0, fmt.Errorf("invalid content range: %s", header)
+	}
+	return strconv.ParseInt(strings.TrimSpace(spec[:dash]), 10, 64)
+}
+
+// uploadErrorStatus maps upload session errors to HTTP status codes (0 = not an upload error).
+func uploadErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, upload.ErrSessionNotFound), errors.Is(err, upload.ErrConnectionNotFound):
+		return 404
+	case errors.Is(err, upload.ErrUploadIncomplete), errors.Is(err, upload.ErrSessionBusy):
+		return 409
+	case errors.Is(err, upload.ErrTooLarge):
+		return 413
+	case errors.Is(err, upload.ErrInvalidRange):
+		return 416
+	case errors.Is(err, upload.ErrDraftMismatch), errors.Is(err, upload.ErrUnsupportedProvider):
+		return 400
+	case errors.Is(err, upload.ErrNotConfigured):
+		return 501
+	}
+	return 0
+}
+
 // =============================================================================
 // Query Parameter Helpers for Domain Types
 // =============================================================================
@@ -3692,6 +4805,15 @@ func querySubCategory(c *fiber.Ctx, key string) *domain.EmailSubCategory {
 	return &subCat
 }
 
+// queryLanguage parses a language code query parameter (ISO 639-1, 소문자로 정규화)
+func queryLanguage(c *fiber.Ctx, key string) *string {
+	val := strings.ToLower(strings.TrimSpace(c.Query(key)))
+	if val == "" {
+		return nil
+	}
+	return &val
+}
+
 // queryPriority parses priority query parameter
 func queryPriority(c *fiber.Ctx, key string) *domain.Priority {
 	val := c.QueryInt(key, 0)
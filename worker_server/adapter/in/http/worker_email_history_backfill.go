@@ -0,0 +1,130 @@
+package http
+
+import (
+	"errors"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/service/email"
+	"worker_server/pkg/apperr"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetHistoryBackfill enables POST/GET /email/backfill (동기화 기간 이전 메일 가져오기).
+func (h *EmailHandler) SetHistoryBackfill(svc *mail.SyncService) {
+	h.historyBackfill = svc
+}
+
+// RequestHistoryBackfill queues a low-priority import of mail older than the sync window.
+// POST /email/backfill
+// Body: { "connection_id": 123, "since": "2019-01-01", "until": "2021-12-31", "max_messages": 5000 }
+// until을 생략하면 동기화 기간의 시작까지 가져온다.
+func (h *EmailHandler) RequestHistoryBackfill(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.historyBackfill == nil {
+		return NotConfiguredResponse(c, "history backfill")
+	}
+
+	var req struct {
+		ConnectionID int64  `json:"connection_id" validate:"required"`
+		Since        string `json:"since" validate:"required"`
+		Until        string `json:"until"`
+		MaxMessages  int    `json:"max_messages"`
+	}
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	since, err := parseBackfillDate(req.Since)
+	if err != nil {
+		return apperr.WriteProblem(c, apperr.InvalidInput("since", "must be YYYY-MM-DD or RFC3339"))
+	}
+	var until time.Time
+	if req.Until != "" {
+		if until, err = parseBackfillDate(req.Until); err != nil {
+			return apperr.WriteProblem(c, apperr.InvalidInput("until", "must be YYYY-MM-DD or RFC3339"))
+		}
+	}
+
+	bf, err := h.historyBackfill.RequestHistoryBackfill(c.Context(), userID, req.ConnectionID, since, until, req.MaxMessages)
+	switch {
+	case errors.Is(err, mail.ErrConnectionNotFound):
+		return ErrorResponse(c, 404, "connection not found")
+	case errors.Is(err, mail.ErrHistoryBackfillUnsupported), errors.Is(err, mail.ErrHistoryBackfillInvalidRange):
+		return ErrorResponse(c, 400, err.Error())
+	case errors.Is(err, mail.ErrHistoryBackfillActive):
+		return apperr.WriteProblem(c, apperr.Conflict(err.Error()).WithDetail("backfill_id", bf.ID))
+	case errors.Is(err, mail.ErrHistoryBackfillQuota):
+		appErr := apperr.RateLimited(int((24 * time.Hour).Seconds())).WithDetail("quota", "history_backfill")
+		appErr.Message = err.Error()
+		return apperr.WriteProblem(c, appErr)
+	case err != nil:
+		return InternalErrorResponse(c, err, "request history backfill")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"backfill": bf})
+}
+
+// ListHistoryBackfills lists the user's recent history backfills and the remaining quota.
+// GET /email/backfill
+func (h *EmailHandler) ListHistoryBackfills(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.historyBackfill == nil {
+		return NotConfiguredResponse(c, "history backfill")
+	}
+
+	backfills, err := h.historyBackfill.ListHistoryBackfills(c.Context(), userID, 20)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list history backfills")
+	}
+	remaining, err := h.historyBackfill.HistoryBackfillQuotaRemaining(c.Context(), userID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "get history backfill quota")
+	}
+	if backfills == nil {
+		backfills = []*domain.HistoryBackfill{}
+	}
+	return c.JSON(fiber.Map{
+		"backfills":       backfills,
+		"quota_remaining": remaining,
+	})
+}
+
+// CancelHistoryBackfill stops a queued or running history backfill.
+// DELETE /email/backfill/:id
+func (h *EmailHandler) CancelHistoryBackfill(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.historyBackfill == nil {
+		return NotConfiguredResponse(c, "history backfill")
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return ErrorResponse(c, 400, "invalid backfill id")
+	}
+
+	bf, err := h.historyBackfill.CancelHistoryBackfill(c.Context(), userID, int64(id))
+	if errors.Is(err, mail.ErrHistoryBackfillNotFound) {
+		return ErrorResponse(c, 404, "backfill not found")
+	}
+	if err != nil {
+		return InternalErrorResponse(c, err, "cancel history backfill")
+	}
+	return c.JSON(fiber.Map{"backfill": bf})
+}
+
+func parseBackfillDate(val string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", val)
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/pkg/logger"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	// prefetchTimeout bounds a background next-page warm-up (?prefetch=true).
+	prefetchTimeout = 10 * time.Second
+
+	// prefetchMaxOffset - 이보다 깊은 페이지는 Provider로 보충하지 않는다 (ListEmails와 같은 기준)
+	prefetchMaxOffset = 100
+)
+
+// emailListResult is a DB list result shared between coalesced requests.
+type emailListResult struct {
+	emails []*domain.Email
+	total  int
+}
+
+// listEmailsCoalesced runs the DB list query once for concurrent cache misses of the same list.
+// 공유된 결과는 요청마다 슬라이스를 복사해서 돌려준다 (API 보충 시 append하므로).
+// 캐시 키에 없는 필터(검색, 라벨, 날짜, 자연어 해석 결과 등)도 있으므로 병합 키는 filter 전체로 만든다.
+func (h *EmailHandler) listEmailsCoalesced(ctx context.Context, filter *domain.EmailFilter) ([]*domain.Email, int, error) {
+	if h.emailCache == nil || !h.emailCache.ShouldCache(filter.Offset) {
+		return h.emailService.ListEmails(ctx, filter)
+	}
+	key, err := coalesceKey(filter)
+	if err != nil {
+		return h.emailService.ListEmails(ctx, filter)
+	}
+
+	v, _, err := h.emailCache.Coalesce(key, func() (any, error) {
+		emails, total, err := h.emailService.ListEmails(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		return &emailListResult{emails: emails, total: total}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	result := v.(*emailListResult)
+	return slices.Clone(result.emails), result.total, nil
+}
+
+// coalesceKey returns the singleflight key of a list query (필드 선택 포함 filter 전체의 해시).
+func coalesceKey(filter *domain.EmailFilter) (string, error) {
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return "list:" + filter.UserID.String() + ":" + hex.EncodeToString(sum[:16]), nil
+}
+
+// prefetchNextPage warms page N+1 of a list into the cache after page N was served (?prefetch=true).
+// cacheKey는 다음 페이지 filter로 목록 API와 같은 키를 만든다.
+// supplement면 DB가 부족한 페이지를 Provider로 채워, 빠르게 스크롤해도 보충 경로를 기다리지 않게 한다.
+func (h *EmailHandler) prefetchNextPage(filter *domain.EmailFilter, cacheKey func(*domain.EmailFilter) string, supplement bool) {
+	if h.emailCache == nil || len(filter.Fields) > 0 {
+		return
+	}
+	next, err := detachFilter(filter)
+	if err != nil {
+		return
+	}
+	next.Offset += next.Limit
+	if !h.emailCache.ShouldCache(next.Offset) {
+		return
+	}
+	key := cacheKey(next)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), prefetchTimeout)
+		defer cancel()
+
+		if _, found := h.emailCache.GetByString(ctx, key, next.Offset); found {
+			return
+		}
+		// 같은 페이지를 동시에 여러 번 데우지 않는다
+		h.emailCache.Coalesce("prefetch:"+key, func() (any, error) {
+			var emails []*domain.Email
+			if !hasSizeFilter(next) {
+				var err error
+				if emails, _, err = h.emailService.ListEmails(ctx, next); err != nil {
+					logger.Warn("[EmailHandler.prefetchNextPage] Failed to load %s: %v", key, err)
+					return nil, err
+				}
+			}
+			if supplement && len(emails) < next.Limit && next.ConnectionID != nil && next.Offset < prefetchMaxOffset {
+				emails = h.supplementFromProvider(ctx, next, emails)
+			}
+
+			data, err := json.Marshal(emails)
+			if err != nil {
+				return nil, err
+			}
+			h.emailCache.SetByString(ctx, key, next.Offset, data)
+			return nil, nil
+		})
+	}()
+}
+
+// supplementFromProvider fills a short DB page from the provider (백그라운드용: 보호 레이어에서 막히면 그대로 둔다).
+func (h *EmailHandler) supplementFromProvider(ctx context.Context, filter *domain.EmailFilter, emails []*domain.Email) []*domain.Email {
+	if h.apiProtector == nil || h.oauthService == nil {
+		return emails
+	}
+	providerOpts := BuildProviderFilterOptions(filter, filter.Limit-len(emails), "", h.providerQueryOptions(filter, false))
+	if providerOpts.SkipAPICall {
+		return emails
+	}
+
+	protectKey := fmt.Sprintf("mail:list:%s:%d", filter.UserID.String(), *filter.ConnectionID)
+	result, release := h.apiProtector.AcquireForUser(ctx, filter.UserID.String(), protectKey)
+	if !result.Allowed || release == nil {
+		return emails
+	}
+	defer release()
+
+	// ListEmails의 보충과 같이 DB 결과 수만큼 건너뛴다
+	apiEmails, _, err := h.fetchMoreFromProviderWithFilter(ctx, filter.UserID, *filter.ConnectionID, providerOpts, len(emails))
+	if err != nil {
+		logger.Warn("[EmailHandler.supplementFromProvider] API fetch failed: %v", err)
+		return emails
+	}
+	existing := make(map[string]bool, len(emails))
+	for _, e := range emails {
+		existing[e.ProviderID] = true
+	}
+	for _, e := range apiEmails {
+		if !existing[e.ProviderID] {
+			emails = append(emails, e)
+			existing[e.ProviderID] = true
+		}
+	}
+	return emails
+}
+
+// detachFilter deep-copies a filter for use after the request returns.
+// Fiber의 쿼리 문자열은 요청이 끝나면 재사용되는 버퍼를 가리키므로 문자열까지 복사해야 한다.
+func detachFilter(filter *domain.EmailFilter) (*domain.EmailFilter, error) {
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+	var detached domain.EmailFilter
+	if err := json.Unmarshal(data, &detached); err != nil {
+		return nil, err
+	}
+	return &detached, nil
+}
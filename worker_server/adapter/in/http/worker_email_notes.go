@@ -0,0 +1,138 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/port/in"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// 개인 메모 API (로컬 전용, Provider에는 동기화하지 않음)
+// =============================================================================
+
+// ListNotes returns the private notes of an email.
+// GET /email/:id/notes
+func (h *EmailHandler) ListNotes(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	notes, err := h.emailService.ListNotes(c.Context(), userID, emailID)
+	if err != nil {
+		return noteErrorResponse(c, err, "list notes")
+	}
+	return c.JSON(fiber.Map{"notes": notes})
+}
+
+// AddNote attaches a private note and tags to an email.
+// POST /email/:id/notes
+func (h *EmailHandler) AddNote(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	var req in.EmailNoteRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	note, err := h.emailService.AddNote(c.Context(), userID, emailID, &req)
+	if err != nil {
+		return noteErrorResponse(c, err, "add note")
+	}
+
+	if h.emailCache != nil {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.Status(fiber.StatusCreated).JSON(note)
+}
+
+// UpdateNote replaces the body and tags of a note.
+// PUT /email/:id/notes/:noteId
+func (h *EmailHandler) UpdateNote(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+	noteID, err := strconv.ParseInt(c.Params("noteId"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid note id")
+	}
+
+	var req in.EmailNoteRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	note, err := h.emailService.UpdateNote(c.Context(), userID, emailID, noteID, &req)
+	if err != nil {
+		return noteErrorResponse(c, err, "update note")
+	}
+
+	if h.emailCache != nil {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.JSON(note)
+}
+
+// DeleteNote deletes a note.
+// DELETE /email/:id/notes/:noteId
+func (h *EmailHandler) DeleteNote(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+	noteID, err := strconv.ParseInt(c.Params("noteId"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid note id")
+	}
+
+	if err := h.emailService.DeleteNote(c.Context(), userID, emailID, noteID); err != nil {
+		return noteErrorResponse(c, err, "delete note")
+	}
+
+	if h.emailCache != nil {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func noteErrorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, mail.ErrEmailNotFound), errors.Is(err, mail.ErrNoteNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, mail.ErrNoteEmpty),
+		errors.Is(err, mail.ErrNoteTooLong),
+		errors.Is(err, mail.ErrInvalidTags):
+		return ErrorResponse(c, 400, err.Error())
+	case errors.Is(err, mail.ErrRepoNotInitialized):
+		return NotConfiguredResponse(c, "email notes")
+	}
+	return InternalErrorResponse(c, err, operation)
+}
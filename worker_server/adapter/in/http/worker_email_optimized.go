@@ -326,8 +326,8 @@ func (h *OptimizedMailHandler) BatchMarkAsRead(c *fiber.Ctx) error {
 		IDs    []int64 `json:"ids"`
 		Folder string  `json:"folder,omitempty"`
 	}
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.emailService.MarkAsRead(c.Context(), userID, req.IDs); err != nil {
@@ -356,8 +356,8 @@ func (h *OptimizedMailHandler) BatchArchive(c *fiber.Ctx) error {
 		IDs    []int64 `json:"ids"`
 		Folder string  `json:"folder,omitempty"`
 	}
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.emailService.Archive(c.Context(), userID, req.IDs); err != nil {
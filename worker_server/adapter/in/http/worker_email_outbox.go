@@ -0,0 +1,109 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/domain"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// 발송 대기열 API (Provider 일시적 실패 - rate limit, 5xx, 네트워크)
+// =============================================================================
+
+var outboxStatuses = map[string]bool{
+	domain.OutboxStatusQueued:    true,
+	domain.OutboxStatusSending:   true,
+	domain.OutboxStatusSent:      true,
+	domain.OutboxStatusFailed:    true,
+	domain.OutboxStatusCancelled: true,
+}
+
+// ListOutbox returns queued, failed and cancelled messages (status=sent로 발송 완료도 조회).
+// GET /email/outbox?status=
+func (h *EmailHandler) ListOutbox(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	status := c.Query("status")
+	if status != "" && !outboxStatuses[status] {
+		return ErrorResponse(c, 400, "invalid status")
+	}
+
+	messages, err := h.emailService.ListOutbox(c.Context(), userID, status)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list outbox")
+	}
+	return c.JSON(fiber.Map{"messages": messages, "total": len(messages)})
+}
+
+// RetryOutbox sends a queued or failed message now.
+// POST /email/outbox/:id/retry
+func (h *EmailHandler) RetryOutbox(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid outbox id")
+	}
+
+	msg, err := h.emailService.RetryOutbox(c.Context(), userID, id)
+	if err != nil {
+		return outboxErrorResponse(c, err, "retry outbox")
+	}
+	return c.JSON(msg)
+}
+
+// CancelOutbox cancels a queued or failed message.
+// DELETE /email/outbox/:id
+func (h *EmailHandler) CancelOutbox(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid outbox id")
+	}
+
+	msg, err := h.emailService.CancelOutbox(c.Context(), userID, id)
+	if err != nil {
+		return outboxErrorResponse(c, err, "cancel outbox")
+	}
+	return c.JSON(msg)
+}
+
+func outboxErrorResponse(c *fiber.Ctx, err error, op string) error {
+	switch {
+	case errors.Is(err, mail.ErrOutboxNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, mail.ErrOutboxNotPending):
+		return ErrorResponse(c, 409, err.Error())
+	case errors.Is(err, mail.ErrRepoNotInitialized):
+		return NotConfiguredResponse(c, "outbox")
+	}
+	return InternalErrorResponse(c, err, op)
+}
+
+// sendQueuedResponse returns 202 with the outbox message when a send failed temporarily.
+// 발송되면 outbox.sent, 재시도 한도를 넘으면 outbox.failed SSE 이벤트가 온다.
+func sendQueuedResponse(c *fiber.Ctx, err error) (bool, error) {
+	var queued *mail.SendQueuedError
+	if !errors.As(err, &queued) {
+		return false, nil
+	}
+	return true, c.Status(202).JSON(fiber.Map{
+		"status": domain.OutboxStatusQueued,
+		"outbox": queued.Message,
+		"error":  queued.Cause.Error(),
+	})
+}
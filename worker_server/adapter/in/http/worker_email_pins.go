@@ -0,0 +1,124 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/core/domain"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// 고정 메일 API (inbox/todo 맨 위, 로컬 전용)
+// =============================================================================
+
+// PinOrderRequest lists every pinned email ID in the new order.
+type PinOrderRequest struct {
+	IDs []int64 `json:"ids" validate:"required,max=50"`
+}
+
+// ListPinned returns the pinned emails in the user's pinned order.
+// GET /email/pinned?connection_id=1
+func (h *EmailHandler) ListPinned(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	filter := &domain.EmailFilter{
+		UserID:       userID,
+		ConnectionID: GetConnectionID(c),
+		Limit:        50,
+	}
+
+	emails, total, err := h.emailService.ListPinned(c.Context(), filter)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list pinned emails")
+	}
+	return c.JSON(fiber.Map{
+		"emails": emails,
+		"total":  total,
+		"view":   "pinned",
+	})
+}
+
+// Pin pins emails to the top of the inbox/todo views (새로 고정한 메일이 맨 위).
+// POST /email/pin
+func (h *EmailHandler) Pin(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req EmailIDsRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	pinned, err := h.emailService.Pin(c.Context(), userID, req.IDs)
+	if err != nil {
+		return pinErrorResponse(c, err, "pin emails")
+	}
+
+	if h.emailCache != nil {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.JSON(fiber.Map{"status": "ok", "ids": pinned})
+}
+
+// Unpin removes emails from the pinned list.
+// POST /email/unpin
+func (h *EmailHandler) Unpin(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req EmailIDsRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.emailService.Unpin(c.Context(), userID, req.IDs); err != nil {
+		return pinErrorResponse(c, err, "unpin emails")
+	}
+
+	if h.emailCache != nil {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.JSON(fiber.Map{"status": "ok", "ids": req.IDs})
+}
+
+// ReorderPins saves the pinned order after a drag and drop.
+// PUT /email/pinned/order
+func (h *EmailHandler) ReorderPins(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req PinOrderRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.emailService.ReorderPins(c.Context(), userID, req.IDs); err != nil {
+		return pinErrorResponse(c, err, "reorder pinned emails")
+	}
+
+	if h.emailCache != nil {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.JSON(fiber.Map{"status": "ok", "ids": req.IDs})
+}
+
+func pinErrorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, mail.ErrTooManyPins), errors.Is(err, mail.ErrInvalidPinOrder):
+		return ErrorResponse(c, 400, err.Error())
+	case errors.Is(err, mail.ErrRepoNotInitialized):
+		return NotConfiguredResponse(c, "pinned emails")
+	}
+	return InternalErrorResponse(c, err, operation)
+}
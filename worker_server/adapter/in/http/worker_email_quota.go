@@ -0,0 +1,54 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"worker_server/core/domain"
+	"worker_server/pkg/apperr"
+	"worker_server/pkg/ratelimit"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetUserQuotas enables per-user tier quotas (free/pro) on the provider protection layer.
+func (h *EmailHandler) SetUserQuotas(limits map[ratelimit.Tier]ratelimit.TierLimits, lookup ratelimit.TierLookup) {
+	if h.apiProtector != nil {
+		h.apiProtector.SetTiers(limits, lookup)
+	}
+}
+
+// limitPayload clamps filter.Limit to the user's tier payload size (메모리 보호).
+func (h *EmailHandler) limitPayload(ctx context.Context, filter *domain.EmailFilter) {
+	if h.apiProtector == nil {
+		return
+	}
+	if maxSize := h.apiProtector.MaxPayloadSizeFor(ctx, filter.UserID.String()); filter.Limit > maxSize {
+		filter.Limit = maxSize
+	}
+}
+
+// setQuotaHeaders reports the user's provider quota (X-Quota-Tier/Limit/Remaining).
+func setQuotaHeaders(c *fiber.Ctx, q *ratelimit.QuotaStatus) {
+	if q == nil {
+		return
+	}
+	c.Set("X-Quota-Tier", string(q.Tier))
+	c.Set("X-Quota-Limit", strconv.Itoa(q.Limit))
+	c.Set("X-Quota-Remaining", strconv.Itoa(q.Remaining))
+}
+
+// quotaExceededResponse writes a 429 problem for an exhausted user quota.
+// details에 quota(concurrency/rate), tier, limit을 담아 클라이언트가 업그레이드 안내 등을 분기할 수 있게 한다.
+func quotaExceededResponse(c *fiber.Ctx, q *ratelimit.QuotaStatus) error {
+	setQuotaHeaders(c, q)
+	retryAfter := int(math.Ceil(q.RetryAfter.Seconds()))
+	appErr := apperr.RateLimited(max(retryAfter, 1)).
+		WithDetail("quota", q.Exceeded).
+		WithDetail("tier", string(q.Tier)).
+		WithDetail("limit", q.Limit)
+	appErr.Message = fmt.Sprintf("%s quota exceeded for %s tier", q.Exceeded, q.Tier)
+	return apperr.WriteProblem(c, appErr)
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/domain"
+	"worker_server/core/service/common"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// 뉴스레터 읽기 모드 + 나중에 읽기 큐
+// =============================================================================
+
+// GetReader returns the article content of a newsletter with reading time, trackers stripped.
+// GET /email/:id/reader
+func (h *EmailHandler) GetReader(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	view, err := h.emailService.Reader(c.Context(), userID, emailID)
+	if err != nil {
+		return readLaterErrorResponse(c, err, "get reader view")
+	}
+	return c.JSON(view)
+}
+
+// ListReadLater returns the read-later queue, most recently added first.
+// GET /email/read-later?connection_id=1&limit=20&offset=0
+func (h *EmailHandler) ListReadLater(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	pagination := GetPaginationParams(c, 20)
+	filter := &domain.EmailFilter{
+		UserID:       userID,
+		ConnectionID: GetConnectionID(c),
+		IsRead:       QueryBool(c, "is_read"),
+		Limit:        pagination.Limit,
+		Offset:       pagination.Offset,
+	}
+
+	emails, total, err := h.emailService.ListReadLater(c.Context(), filter)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list read later emails")
+	}
+	return c.JSON(fiber.Map{
+		"emails":   emails,
+		"total":    total,
+		"has_more": filter.Offset+len(emails) < total,
+		"view":     "read_later",
+	})
+}
+
+// AddReadLater queues emails for later reading. 큐에 넣은 메일은 inbox/카테고리 목록에서 빠진다.
+// POST /email/read-later
+func (h *EmailHandler) AddReadLater(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req EmailIDsRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	added, err := h.emailService.AddReadLater(c.Context(), userID, req.IDs)
+	if err != nil {
+		return readLaterErrorResponse(c, err, "add read later emails")
+	}
+
+	if h.emailCache != nil {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.JSON(fiber.Map{"status": "ok", "ids": added})
+}
+
+// RemoveReadLater takes emails out of the read-later queue.
+// POST /email/read-later/remove
+func (h *EmailHandler) RemoveReadLater(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req EmailIDsRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.emailService.RemoveReadLater(c.Context(), userID, req.IDs); err != nil {
+		return readLaterErrorResponse(c, err, "remove read later emails")
+	}
+
+	if h.emailCache != nil {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.JSON(fiber.Map{"status": "ok", "ids": req.IDs})
+}
+
+func readLaterErrorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, mail.ErrEmailNotFound), errors.Is(err, common.ErrForbidden):
+		return ErrorResponse(c, 404, "email not found")
+	case errors.Is(err, mail.ErrNotNewsletter):
+		return ErrorResponse(c, 422, err.Error())
+	case errors.Is(err, mail.ErrRepoNotInitialized):
+		return NotConfiguredResponse(c, "read later")
+	}
+	return InternalErrorResponse(c, err, operation)
+}
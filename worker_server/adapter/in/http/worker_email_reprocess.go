@@ -0,0 +1,186 @@
+package http
+
+import (
+	"strconv"
+
+	"worker_server/core/agent/llm"
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// 분류 실패 재처리 API (ai_status = 'failed')
+// =============================================================================
+
+// maxReprocessEmails bounds one reprocess request (실패 목록 조회 상한과 같다).
+const maxReprocessEmails = 500
+
+// reprocessModels are the models a failed classification can be retried with.
+var reprocessModels = map[string]bool{
+	string(llm.ModelMini):     true,
+	string(llm.ModelStandard): true,
+}
+
+// SetClassifyAttemptRepository enables the classification failure and reprocess routes.
+func (h *EmailHandler) SetClassifyAttemptRepository(repo out.ClassifyAttemptRepository) {
+	h.classifyAttempts = repo
+}
+
+// ListFailedClassifications returns emails whose classification failed with the last failure reason.
+// GET /email/classification/failed?connection_id=&limit=
+func (h *EmailHandler) ListFailedClassifications(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.classifyAttempts == nil {
+		return NotConfiguredResponse(c, "classification attempts")
+	}
+
+	connectionID, _ := strconv.ParseInt(c.Query("connection_id"), 10, 64)
+	params := GetPaginationParams(c, 50)
+
+	failed, err := h.classifyAttempts.ListFailed(c.Context(), userID, connectionID, params.Limit)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list failed classifications")
+	}
+	return c.JSON(fiber.Map{"emails": failed, "total": len(failed)})
+}
+
+// ListClassifyAttempts returns the classification attempt history of an email, newest first.
+// GET /email/:id/classification/attempts
+func (h *EmailHandler) ListClassifyAttempts(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.classifyAttempts == nil {
+		return NotConfiguredResponse(c, "classification attempts")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	attempts, err := h.classifyAttempts.ListByEmail(c.Context(), userID, emailID, 50)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list classify attempts")
+	}
+	return c.JSON(fiber.Map{
+		"email_id": emailID,
+		"attempts": attempts,
+		"count":    len(attempts),
+	})
+}
+
+// ReprocessClassification requeues failed emails with a different model or the heuristic stages only.
+// POST /email/classification/reprocess
+// Body: { "connection_id": 123, "email_ids": [1, 2], "mode": "llm|heuristic", "model": "gpt-4o" }
+// email_ids가 없으면 실패한 메일 전체(최대 500개)를 다시 분류한다.
+func (h *EmailHandler) ReprocessClassification(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.classifyAttempts == nil || h.messageProducer == nil {
+		return NotConfiguredResponse(c, "classification reprocess")
+	}
+
+	var req struct {
+		ConnectionID int64   `json:"connection_id"`
+		EmailIDs     []int64 `json:"email_ids"`
+		Mode         string  `json:"mode"`
+		Model        string  `json:"model"`
+	}
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	switch req.Mode {
+	case "", domain.ClassifyModeLLM:
+		req.Mode = domain.ClassifyModeLLM
+		if req.Model != "" && !reprocessModels[req.Model] {
+			return ErrorResponse(c, 400, "unsupported model")
+		}
+	case domain.ClassifyModeHeuristic:
+		req.Model = ""
+	default:
+		return ErrorResponse(c, 400, "mode must be llm or heuristic")
+	}
+	if len(req.EmailIDs) > maxReprocessEmails {
+		return ErrorResponse(c, 400, "too many email_ids")
+	}
+
+	// 사용자의 실패 메일만 재처리 (다른 사용자의 메일 ID는 걸러진다)
+	failed, err := h.classifyAttempts.ListFailed(c.Context(), userID, req.ConnectionID, maxReprocessEmails)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list failed classifications")
+	}
+	ids := failedEmailIDs(failed, req.EmailIDs)
+	if len(ids) == 0 {
+		return c.JSON(fiber.Map{"status": "ok", "message": "No failed emails to reprocess", "queued": 0})
+	}
+
+	jobID := h.createJob(c, userID, domain.BackgroundJobReclassify, req.ConnectionID, len(ids))
+	queued := 0
+	for _, emailID := range ids {
+		if err := h.messageProducer.PublishAIClassify(c.Context(), &out.AIClassifyJob{
+			UserID:   userID.String(),
+			EmailID:  emailID,
+			JobID:    jobID,
+			Priority: out.JobPriorityLow,
+			Mode:     req.Mode,
+			Model:    req.Model,
+		}); err != nil {
+			continue
+		}
+		queued++
+	}
+	if failedCount := len(ids) - queued; failedCount > 0 {
+		logger.Warn("[EmailHandler.ReprocessClassification] Failed to queue %d reprocess jobs", failedCount)
+		if h.jobs != nil {
+			h.jobs.Progress(c.Context(), jobID, 0, failedCount)
+		}
+	}
+
+	resp := fiber.Map{
+		"status": "ok",
+		"queued": queued,
+		"mode":   req.Mode,
+	}
+	if req.Model != "" {
+		resp["model"] = req.Model
+	}
+	if jobID != "" {
+		resp["job_id"] = jobID
+	}
+	return c.JSON(resp)
+}
+
+// failedEmailIDs returns the IDs of failed emails, restricted to requested when it is not empty.
+func failedEmailIDs(failed []*domain.FailedClassification, requested []int64) []int64 {
+	if len(requested) == 0 {
+		ids := make([]int64, len(failed))
+		for i, f := range failed {
+			ids[i] = f.EmailID
+		}
+		return ids
+	}
+
+	isFailed := make(map[int64]bool, len(failed))
+	for _, f := range failed {
+		isFailed[f.EmailID] = true
+	}
+	ids := make([]int64, 0, len(requested))
+	for _, id := range requested {
+		if isFailed[id] {
+			ids = append(ids, id)
+			delete(isFailed, id) // 중복 ID는 한 번만
+		}
+	}
+	return ids
+}
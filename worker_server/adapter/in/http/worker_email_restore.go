@@ -0,0 +1,72 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/core/domain"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListDeleted returns deleted emails that can still be restored, most recently deleted first.
+// GET /email/deleted?connection_id=1&limit=20&offset=0
+func (h *EmailHandler) ListDeleted(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	pagination := GetPaginationParams(c, 20)
+	filter := &domain.EmailFilter{
+		UserID:       userID,
+		ConnectionID: GetConnectionID(c),
+		Deleted:      true,
+		Limit:        pagination.Limit,
+		Offset:       pagination.Offset,
+	}
+	if filter.Fields, err = queryEmailFields(c); err != nil {
+		return ErrorResponse(c, 400, err.Error())
+	}
+
+	emails, total, err := h.emailService.ListEmails(c.Context(), filter)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list deleted emails")
+	}
+	if notModified(c, listETag(emails, total)) {
+		return c.SendStatus(304)
+	}
+	return c.JSON(fiber.Map{
+		"emails":         projectEmails(emails, filter.Fields),
+		"total":          total,
+		"has_more":       filter.Offset+len(emails) < total,
+		"retention_days": int(mail.DeleteRetention.Hours() / 24),
+	})
+}
+
+// RestoreEmails restores emails deleted within the retention window.
+// POST /email/restore
+func (h *EmailHandler) RestoreEmails(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req EmailIDsRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	restored, err := h.emailService.Restore(c.Context(), userID, req.IDs)
+	if err != nil {
+		if errors.Is(err, mail.ErrRepoNotInitialized) {
+			return NotConfiguredResponse(c, "email restore")
+		}
+		return InternalErrorResponse(c, err, "restore emails")
+	}
+
+	if h.emailCache != nil {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.JSON(fiber.Map{"status": "ok", "restored": len(restored), "ids": restored})
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/in"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// 캘린더 초대 응답 API (.ics 첨부 → iTIP REPLY)
+// =============================================================================
+
+// GetInvite returns the calendar invite of an email and the user's response.
+// GET /email/:id/invite
+func (h *EmailHandler) GetInvite(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	invite, err := h.emailService.GetInvite(c.Context(), userID, emailID)
+	if err != nil {
+		return rsvpErrorResponse(c, err, "get invite")
+	}
+	return c.JSON(invite)
+}
+
+// RSVP accepts, tentatively accepts or declines the invite of an email.
+// POST /email/:id/rsvp
+// 일시적 발송 실패는 응답을 기록한 뒤 outbox 정보와 함께 202를 반환한다.
+func (h *EmailHandler) RSVP(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	var req in.RSVPRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	invite, err := h.emailService.RSVP(c.Context(), userID, emailID, &req)
+	if err != nil {
+		var queued *mail.SendQueuedError
+		if errors.As(err, &queued) && invite != nil {
+			return c.Status(202).JSON(fiber.Map{
+				"invite": invite,
+				"status": domain.OutboxStatusQueued,
+				"outbox": queued.Message,
+			})
+		}
+		return rsvpErrorResponse(c, err, "send rsvp")
+	}
+	return c.JSON(invite)
+}
+
+func rsvpErrorResponse(c *fiber.Ctx, err error, op string) error {
+	switch {
+	case errors.Is(err, mail.ErrInvalidRSVP):
+		return ErrorResponse(c, 400, err.Error())
+	case errors.Is(err, mail.ErrEmailNotFound), errors.Is(err, mail.ErrNoCalendarInvite):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, mail.ErrInviteCancelled):
+		return ErrorResponse(c, 409, err.Error())
+	case errors.Is(err, mail.ErrRepoNotInitialized):
+		return NotConfiguredResponse(c, "calendar invites")
+	}
+	return InternalErrorResponse(c, err, op)
+}
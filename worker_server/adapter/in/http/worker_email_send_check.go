@@ -0,0 +1,53 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/core/port/in"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// 발송 전 검증 (수신 도메인 MX, 내부 스레드의 외부 수신자, 첨부 누락)
+// =============================================================================
+
+// ValidateSend returns the pre-send warnings of a message without sending it.
+// POST /email/send/validate (body: SendEmail과 같음)
+func (h *EmailHandler) ValidateSend(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req in.SendEmailRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+	if req.ConnectionID == 0 {
+		if connID := GetConnectionID(c); connID != nil {
+			req.ConnectionID = *connID
+		}
+	}
+
+	warnings, err := h.emailService.ValidateSend(c.Context(), userID, &req)
+	if err != nil {
+		return InternalErrorResponse(c, err, "validate send")
+	}
+	return c.JSON(fiber.Map{"warnings": warnings, "requires_confirmation": len(warnings) > 0})
+}
+
+// sendWarningsResponse returns 409 with the warnings when a send needs confirmation.
+// 클라이언트는 사용자 확인 후 confirm_warnings: true로 다시 보낸다.
+func sendWarningsResponse(c *fiber.Ctx, err error) (bool, error) {
+	var warned *mail.SendWarningsError
+	if !errors.As(err, &warned) {
+		return false, nil
+	}
+	return true, c.Status(409).JSON(fiber.Map{
+		"error":                 mail.ErrSendWarnings.Error(),
+		"warnings":              warned.Warnings,
+		"requires_confirmation": true,
+	})
+}
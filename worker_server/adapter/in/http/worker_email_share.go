@@ -0,0 +1,336 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/core/service/share"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// 메일 공유 링크 API
+// =============================================================================
+
+// CreateShareRequest represents the HTTP request to create a share link.
+type CreateShareRequest struct {
+	ExpiresInHours int     `json:"expires_in_hours,omitempty" validate:"omitempty,min=1,max=720"` // 기본 168 (7일)
+	Password       string  `json:"password,omitempty" validate:"omitempty,max=72"`
+	AttachmentIDs  []int64 `json:"attachment_ids,omitempty" validate:"omitempty,max=20"`
+}
+
+// CreateShare creates an expiring read-only link to an email.
+// URL은 이 응답에서만 받을 수 있다.
+// POST /email/:id/share
+func (h *EmailHandler) CreateShare(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.shares == nil {
+		return NotConfiguredResponse(c, "share links")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	var req CreateShareRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	created, err := h.shares.Create(c.Context(), userID, emailID, &share.CreateRequest{
+		ExpiresIn:     time.Duration(req.ExpiresInHours) * time.Hour,
+		Password:      req.Password,
+		AttachmentIDs: req.AttachmentIDs,
+	})
+	if err != nil {
+		return shareErrorResponse(c, err, "create share link")
+	}
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// ListShares returns the share links of an email with their view counts.
+// GET /email/:id/shares
+func (h *EmailHandler) ListShares(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.shares == nil {
+		return NotConfiguredResponse(c, "share links")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	shares, err := h.shares.List(c.Context(), userID, emailID)
+	if err != nil {
+		return shareErrorResponse(c, err, "list share links")
+	}
+	return c.JSON(fiber.Map{"shares": shares})
+}
+
+// RevokeShare disables a share link immediately.
+// DELETE /email/:id/shares/:shareId
+func (h *EmailHandler) RevokeShare(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+	if h.shares == nil {
+		return NotConfiguredResponse(c, "share links")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+	shareID, err := strconv.ParseInt(c.Params("shareId"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid share id")
+	}
+
+	if err := h.shares.Revoke(c.Context(), userID, emailID, shareID); err != nil {
+		return shareErrorResponse(c, err, "revoke share link")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ViewSharedEmail renders the read-only shared email (no auth).
+// 비밀번호가 있으면 폼을 보여주고, POST(password 필드)로 확인한다.
+// ?format=json 이면 JSON으로 응답하며 비밀번호는 X-Share-Password 헤더로 받는다.
+// GET|POST /api/v1/share/:token
+func (h *EmailHandler) ViewSharedEmail(c *fiber.Ctx) error {
+	if h.shares == nil {
+		return ErrorResponse(c, 404, "not found")
+	}
+	setSharePageHeaders(c)
+
+	asJSON := c.Query("format") == "json"
+	password := c.Get("X-Share-Password")
+	if c.Method() == fiber.MethodPost {
+		password = c.FormValue("password")
+	}
+
+	view, err := h.shares.View(c.Context(), c.Params("token"), password, c.IP())
+	if err != nil {
+		status, message := sharePageError(err)
+		if status == 0 {
+			return InternalErrorResponse(c, err, "view shared email")
+		}
+		if asJSON {
+			return ErrorResponse(c, status, message)
+		}
+		showForm := errors.Is(err, share.ErrPasswordRequired) || errors.Is(err, share.ErrWrongPassword)
+		return renderSharePage(c, status, &sharePageData{Error: message, PasswordForm: showForm})
+	}
+
+	if asJSON {
+		return c.JSON(view)
+	}
+	return renderSharePage(c, fiber.StatusOK, &sharePageData{Email: view, Body: template.HTML(view.HTML)})
+}
+
+// DownloadSharedAttachment downloads an attachment selected for a share link (no auth).
+// URL은 공유 페이지에서 서명되어 발급된다.
+// GET /api/v1/share/:token/attachments/:attachmentId?exp=...&sig=...
+func (h *EmailHandler) DownloadSharedAttachment(c *fiber.Ctx) error {
+	if h.shares == nil {
+		return ErrorResponse(c, 404, "not found")
+	}
+
+	attachmentID, err := strconv.ParseInt(c.Params("attachmentId"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid attachment id")
+	}
+
+	shared, attachment, err := h.shares.Attachment(c.Context(), c.Params("token"), attachmentID, c.Query("exp"), c.Query("sig"))
+	if err != nil {
+		status, message := sharePageError(err)
+		if status == 0 {
+			return InternalErrorResponse(c, err, "get shared attachment")
+		}
+		return ErrorResponse(c, status, message)
+	}
+
+	if h.emailRepo == nil || h.oauthService == nil {
+		return NotConfiguredResponse(c, "attachment download")
+	}
+	email, err := h.emailRepo.GetByID(c.Context(), shared.EmailID)
+	if err != nil || email == nil {
+		return ErrorResponse(c, 404, "email not found")
+	}
+
+	data, mimeType, err := h.fetchProviderAttachment(c.Context(), email, attachment.ExternalID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "download shared attachment")
+	}
+	if attachment.MimeType != "" {
+		mimeType = attachment.MimeType
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	filename := attachment.Filename
+	if filename == "" {
+		filename = "attachment"
+	}
+
+	c.Set("Cache-Control", "private, no-store")
+	c.Set("X-Content-Type-Options", "nosniff")
+	c.Set("Content-Type", mimeType)
+	c.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Set("Content-Length", strconv.Itoa(len(data)))
+	return c.Send(data)
+}
+
+// fetchProviderAttachment downloads attachment content from the email's provider.
+func (h *EmailHandler) fetchProviderAttachment(ctx context.Context, email *out.MailEntity, externalID string) ([]byte, string, error) {
+	token, err := h.oauthService.GetOAuth2Token(ctx, email.ConnectionID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch email.Provider {
+	case "google", "gmail":
+		if h.gmailProvider == nil {
+			return nil, "", fmt.Errorf("gmail provider not configured")
+		}
+		return h.gmailProvider.GetAttachment(ctx, token, email.ExternalID, externalID)
+	case "outlook", "microsoft":
+		if h.outlookProvider == nil {
+			return nil, "", fmt.Errorf("outlook provider not configured")
+		}
+		return h.outlookProvider.GetAttachment(ctx, token, email.ExternalID, externalID)
+	}
+	return nil, "", fmt.Errorf("unsupported provider: %s", email.Provider)
+}
+
+func shareErrorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, share.ErrEmailNotFound), errors.Is(err, share.ErrShareNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, share.ErrInvalidExpiry),
+		errors.Is(err, share.ErrInvalidPassword),
+		errors.Is(err, share.ErrInvalidAttachment):
+		return ErrorResponse(c, 400, err.Error())
+	}
+	return InternalErrorResponse(c, err, operation)
+}
+
+// sharePageError maps errors of the public share routes. 0이면 내부 오류.
+func sharePageError(err error) (int, string) {
+	switch {
+	case errors.Is(err, share.ErrShareNotFound):
+		return 404, "This link does not exist."
+	case errors.Is(err, share.ErrShareExpired):
+		return 410, "This link has expired or was revoked."
+	case errors.Is(err, share.ErrPasswordRequired):
+		return 401, "This email is password protected."
+	case errors.Is(err, share.ErrWrongPassword):
+		return 403, "Wrong password."
+	case errors.Is(err, share.ErrTooManyAttempts):
+		return 429, "Too many wrong passwords. Try again later."
+	case errors.Is(err, share.ErrInvalidSignature):
+		return 403, "This download link has expired. Reload the shared page."
+	}
+	return 0, ""
+}
+
+// setSharePageHeaders keeps the shared page out of caches and search engines
+// and blocks scripts and remote loads even if sanitizing missed something.
+func setSharePageHeaders(c *fiber.Ctx) {
+	c.Set("Cache-Control", "private, no-store")
+	c.Set("Referrer-Policy", "no-referrer")
+	c.Set("X-Robots-Tag", "noindex, nofollow")
+	c.Set("X-Content-Type-Options", "nosniff")
+	c.Set("X-Frame-Options", "DENY")
+	c.Set("Content-Security-Policy",
+		"default-src 'none'; style-src 'unsafe-inline'; img-src data:; form-action 'self'; base-uri 'none'; frame-ancestors 'none'")
+}
+
+type sharePageData struct {
+	Email        *domain.SharedEmail
+	Body         template.HTML // sanitize 완료된 HTML
+	Error        string
+	PasswordForm bool
+}
+
+func renderSharePage(c *fiber.Ctx, status int, data *sharePageData) error {
+	var buf bytes.Buffer
+	if err := sharePageTemplate.Execute(&buf, data); err != nil {
+		return InternalErrorResponse(c, err, "render shared email")
+	}
+	c.Set("Content-Type", "text/html; charset=utf-8")
+	return c.Status(status).Send(buf.Bytes())
+}
+
+var sharePageTemplate = template.Must(template.New("share").Funcs(template.FuncMap{
+	"size": func(n int64) string {
+		switch {
+		case n >= 1<<20:
+			return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+		case n >= 1<<10:
+			return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+		}
+		return fmt.Sprintf("%d B", n)
+	},
+	"date": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+	"trim": strings.TrimSpace,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex, nofollow">
+<title>{{if .Email}}{{.Email.Subject}}{{else}}Shared email{{end}}</title>
+</head>
+<body style="margin:0;background:#f5f5f5;font-family:-apple-system,Segoe UI,Roboto,sans-serif;color:#222">
+<div style="max-width:820px;margin:24px auto;background:#fff;border:1px solid #e2e2e2;border-radius:8px;padding:24px">
+{{- if .Email}}
+<h1 style="font-size:20px;margin:0 0 12px">{{.Email.Subject}}</h1>
+<div style="font-size:14px;color:#555;margin-bottom:16px">
+{{- if trim .Email.FromName}}{{.Email.FromName}} &lt;{{.Email.FromEmail}}&gt;{{else}}{{.Email.FromEmail}}{{end}} · {{date .Email.Date}}
+</div>
+<hr style="border:0;border-top:1px solid #eee">
+{{- if .Body}}
+<div>{{.Body}}</div>
+{{- else}}
+<pre style="white-space:pre-wrap;font-family:inherit">{{.Email.Text}}</pre>
+{{- end}}
+{{- if .Email.Attachments}}
+<hr style="border:0;border-top:1px solid #eee">
+<ul style="font-size:14px;padding-left:18px">
+{{- range .Email.Attachments}}
+<li><a href="{{.URL}}" rel="noopener noreferrer">{{.Filename}}</a> ({{size .Size}})</li>
+{{- end}}
+</ul>
+{{- end}}
+<p style="font-size:12px;color:#888;margin-top:24px">Read-only copy. This link expires {{date .Email.ExpiresAt}}.</p>
+{{- else}}
+<p>{{.Error}}</p>
+{{- if .PasswordForm}}
+<form method="post">
+<input type="password" name="password" autofocus required style="padding:6px;font-size:14px">
+<button type="submit" style="padding:6px 12px;font-size:14px">Open</button>
+</form>
+{{- end}}
+{{- end}}
+</div>
+</body>
+</html>`))
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/mailparse"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// =============================================================================
+// 원본 메일 (Show original) API
+// =============================================================================
+
+const (
+	// defaultRawMaxSize is the raw source limit when max_size is not given.
+	defaultRawMaxSize = 10 * 1024 * 1024
+	// maxRawMaxSize caps the max_size query param (Gmail 최대 메시지 크기).
+	maxRawMaxSize = 35 * 1024 * 1024
+)
+
+// GetEmailRaw returns the original RFC 822 source of an email.
+// GET /email/:id/raw?download=false&gzip=false&max_size=10485760
+func (h *EmailHandler) GetEmailRaw(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	maxSize := int64(c.QueryInt("max_size", defaultRawMaxSize))
+	if maxSize <= 0 || maxSize > maxRawMaxSize {
+		maxSize = maxRawMaxSize
+	}
+
+	raw, status, err := h.fetchRawSource(c, userID, emailID, maxSize)
+	if err != nil {
+		return ErrorResponse(c, status, err.Error())
+	}
+
+	filename := fmt.Sprintf("message-%d.eml", emailID)
+	contentType := "message/rfc822"
+
+	if c.QueryBool("gzip", false) {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(raw); err != nil {
+			return InternalErrorResponse(c, err, "compress raw message")
+		}
+		if err := zw.Close(); err != nil {
+			return InternalErrorResponse(c, err, "compress raw message")
+		}
+		raw = buf.Bytes()
+		filename += ".gz"
+		contentType = "application/gzip"
+	}
+
+	disposition := "inline"
+	if c.QueryBool("download", false) {
+		disposition = "attachment"
+	}
+
+	c.Set("Content-Type", contentType)
+	c.Set("Content-Disposition", fmt.Sprintf(`%s; filename="%s"`, disposition, filename))
+	c.Set("Content-Length", strconv.Itoa(len(raw)))
+	c.Set("Cache-Control", "private, no-store")
+
+	return c.Send(raw)
+}
+
+// GetEmailHeaders returns every header of an email plus parsed SPF/DKIM/DMARC results.
+// GET /email/:id/headers
+func (h *EmailHandler) GetEmailHeaders(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	email, provider, token, status, err := h.resolveProviderMessage(c, userID, emailID)
+	if err != nil {
+		return ErrorResponse(c, status, err.Error())
+	}
+
+	headers, err := provider.GetMessageHeaders(c.Context(), token, email.ExternalID)
+	if err != nil {
+		status, msg := providerErrorStatus(err, "failed to get message headers")
+		if status == 502 {
+			logger.WithError(err).Error("[EmailHandler.GetEmailHeaders] Failed to get headers")
+		}
+		return ErrorResponse(c, status, msg)
+	}
+
+	var authResults []string
+	var receivedSPF string
+	for _, hdr := range headers {
+		switch {
+		case strings.EqualFold(hdr.Name, "Authentication-Results"):
+			authResults = append(authResults, hdr.Value)
+		case strings.EqualFold(hdr.Name, "Received-SPF") && receivedSPF == "":
+			receivedSPF = hdr.Value
+		}
+	}
+	auth := mailparse.ParseAuthenticationResults(authResults, receivedSPF)
+
+	c.Set("Cache-Control", "private, no-store")
+	return c.JSON(fiber.Map{
+		"email_id":       emailID,
+		"headers":        headers,
+		"authentication": auth,
+		"verdict":        auth.Verdict(),
+	})
+}
+
+// fetchRawSource downloads the MIME source of an email from its provider.
+// Returns the HTTP status to use on failure.
+func (h *EmailHandler) fetchRawSource(c *fiber.Ctx, userID uuid.UUID, emailID int64, maxSize int64) ([]byte, int, error) {
+	email, provider, token, status, err := h.resolveProviderMessage(c, userID, emailID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	raw, err := provider.GetMessageRaw(c.Context(), token, email.ExternalID, maxSize)
+	if err != nil {
+		status, msg := providerErrorStatus(err, "failed to get raw message")
+		if status == 502 {
+			logger.WithError(err).Error("[EmailHandler.fetchRawSource] Failed to get raw message")
+		}
+		return nil, status, errors.New(msg)
+	}
+
+	return raw, 200, nil
+}
+
+// resolveProviderMessage loads the email, checks ownership and returns its provider and token.
+// Returns the HTTP status to use on failure.
+func (h *EmailHandler) resolveProviderMessage(c *fiber.Ctx, userID uuid.UUID, emailID int64) (*out.MailEntity, out.EmailProviderPort, *oauth2.Token, int, error) {
+	if h.emailRepo == nil || h.oauthService == nil {
+		return nil, nil, nil, 500, errors.New("mail repository not configured")
+	}
+
+	email, err := h.emailRepo.GetByID(c.Context(), emailID)
+	if err != nil || email == nil || email.UserID != userID {
+		return nil, nil, nil, 404, errors.New("email not found")
+	}
+
+	var provider out.EmailProviderPort
+	switch email.Provider {
+	case "google", "gmail":
+		if h.gmailProvider != nil {
+			provider = h.gmailProvider
+		}
+	case "outlook", "microsoft":
+		if h.outlookProvider != nil {
+			provider = h.outlookProvider
+		}
+	case string(domain.MailProviderLocal):
+		return nil, nil, nil, 404, errors.New("original source is not available for imported mail")
+	}
+	if provider == nil {
+		return nil, nil, nil, 400, errors.New("unsupported provider: " + email.Provider)
+	}
+
+	token, err := h.oauthService.GetOAuth2Token(c.Context(), email.ConnectionID)
+	if err != nil {
+		logger.WithError(err).Error("[EmailHandler.resolveProviderMessage] Failed to get OAuth token")
+		return nil, nil, nil, 500, errors.New("failed to get oauth token")
+	}
+
+	return email, provider, token, 200, nil
+}
+
+// providerErrorStatus maps provider errors to an HTTP status and client message.
+// 매핑되지 않은 에러는 502와 fallback 메시지를 반환한다.
+func providerErrorStatus(err error, fallback string) (int, string) {
+	var providerErr *out.ProviderError
+	if errors.As(err, &providerErr) {
+		switch providerErr.Code {
+		case out.ProviderErrTooLarge:
+			return 413, providerErr.Message
+		case out.ProviderErrNotFound:
+			return 404, "message not found at provider"
+		}
+	}
+	return 502, fallback
+}
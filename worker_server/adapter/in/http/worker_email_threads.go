@@ -0,0 +1,67 @@
+package http
+
+import (
+	"errors"
+	"net/url"
+
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// =============================================================================
+// 스레드 뮤트 API (Gmail 뮤트와 같지만 모든 Provider에서 동작)
+// =============================================================================
+
+// MuteThread mutes a thread: its inbox emails are archived and future messages skip inbox and notifications.
+// POST /email/threads/:threadId/mute?connection_id=1 (threadId: 메일의 thread_id, connection_id 생략 시 모든 계정)
+func (h *EmailHandler) MuteThread(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	threadID, err := url.PathUnescape(c.Params("threadId"))
+	if err != nil || threadID == "" {
+		return ErrorResponse(c, 400, "invalid thread id")
+	}
+
+	archived, err := h.emailService.MuteThread(c.Context(), userID, threadID, GetConnectionID(c))
+	if err != nil {
+		return threadMuteErrorResponse(c, err, "mute thread")
+	}
+
+	if h.emailCache != nil && len(archived) > 0 {
+		h.emailCache.InvalidateByUser(c.Context(), userID.String())
+	}
+	return c.JSON(fiber.Map{"status": "muted", "thread_id": threadID, "archived": archived})
+}
+
+// UnmuteThread unmutes a thread. 이미 보관된 메일은 그대로 둔다.
+// DELETE /email/threads/:threadId/mute
+func (h *EmailHandler) UnmuteThread(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	threadID, err := url.PathUnescape(c.Params("threadId"))
+	if err != nil || threadID == "" {
+		return ErrorResponse(c, 400, "invalid thread id")
+	}
+
+	if err := h.emailService.UnmuteThread(c.Context(), userID, threadID); err != nil {
+		return threadMuteErrorResponse(c, err, "unmute thread")
+	}
+	return c.JSON(fiber.Map{"status": "unmuted", "thread_id": threadID})
+}
+
+func threadMuteErrorResponse(c *fiber.Ctx, err error, action string) error {
+	switch {
+	case errors.Is(err, mail.ErrThreadNotFound), errors.Is(err, mail.ErrThreadNotMuted):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, mail.ErrRepoNotInitialized):
+		return NotConfiguredResponse(c, "thread mute")
+	}
+	return InternalErrorResponse(c, err, action)
+}
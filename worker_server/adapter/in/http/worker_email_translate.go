@@ -0,0 +1,43 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/agent/llm"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TranslateEmail returns the subject and body of an email translated into ?to=.
+// GET /email/:id/translate?to=ko
+func (h *EmailHandler) TranslateEmail(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	translation, err := h.emailService.TranslateEmail(usageContext(c, llm.TaskTranslate), userID, emailID, c.Query("to"))
+	if err != nil {
+		return translateErrorResponse(c, err)
+	}
+	return c.JSON(translation)
+}
+
+func translateErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, mail.ErrInvalidLanguage):
+		return ErrorResponse(c, 400, err.Error())
+	case errors.Is(err, mail.ErrEmailNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, mail.ErrRepoNotInitialized):
+		return NotConfiguredResponse(c, "translation")
+	}
+	return InternalErrorResponse(c, err, "translate email")
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/port/in"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// applyBatch runs a batch action. versions에 있는 ID는 updated_at 조건부로 갱신하고 (ModifyIfUnchanged),
+// 나머지 ID는 기존처럼 무조건 갱신한다.
+func (h *EmailHandler) applyBatch(ctx context.Context, userID uuid.UUID, ids []int64, versions map[int64]time.Time, action string, apply func([]int64) error) (*in.BatchModifyResult, error) {
+	if len(versions) == 0 {
+		if err := apply(ids); err != nil {
+			return nil, err
+		}
+		return &in.BatchModifyResult{Updated: ids}, nil
+	}
+
+	var plain []int64
+	conditional := make(map[int64]time.Time, len(versions))
+	for _, id := range ids {
+		if v, ok := versions[id]; ok {
+			conditional[id] = v
+		} else {
+			plain = append(plain, id)
+		}
+	}
+
+	result := &in.BatchModifyResult{Updated: []int64{}}
+	if len(conditional) > 0 {
+		r, err := h.emailService.ModifyIfUnchanged(ctx, userID, action, conditional)
+		if err != nil {
+			return nil, err
+		}
+		result = r
+	}
+	if len(plain) > 0 {
+		if err := apply(plain); err != nil {
+			return nil, err
+		}
+		result.Updated = append(result.Updated, plain...)
+	}
+	return result, nil
+}
+
+// batchResponse writes the result of applyBatch. 충돌하거나 없는 ID가 있으면 status가 "partial"이다.
+func batchResponse(c *fiber.Ctx, result *in.BatchModifyResult, extra fiber.Map) error {
+	resp := fiber.Map{"status": "ok", "ids": result.Updated}
+	if len(result.Versions) > 0 {
+		resp["versions"] = result.Versions
+	}
+	if len(result.Conflicts) > 0 || len(result.NotFound) > 0 {
+		resp["status"] = "partial"
+		resp["conflicts"] = result.Conflicts
+		resp["not_found"] = result.NotFound
+	}
+	for k, v := range extra {
+		resp[k] = v
+	}
+	return c.JSON(resp)
+}
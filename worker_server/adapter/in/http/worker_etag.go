@@ -0,0 +1,46 @@
+package http
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// listETag derives a weak ETag for a list page from max(updated_at) and the counts.
+// 삭제·이동으로 페이지에서 빠진 메일도 반영되도록 ID 목록 해시를 함께 넣는다.
+// 고정/SLA 상태는 updated_at을 바꾸지 않고 목록에서 채우므로 메일별로 해시에 넣는다.
+func listETag(emails []*domain.Email, total int) string {
+	var latest time.Time
+	page := fnv.New64a()
+	for _, e := range emails {
+		if e.UpdatedAt.After(latest) {
+			latest = e.UpdatedAt
+		}
+		fmt.Fprintf(page, "%d:%t:%s,", e.ID, e.IsPinned, e.SLAStatus)
+	}
+	return fmt.Sprintf(`W/"%d-%d-%d-%x"`, total, len(emails), latest.UnixMicro(), page.Sum64())
+}
+
+// bodyETag derives a weak ETag for an email body. 본문은 메일별로 바뀌지 않으므로
+// 첨부 수와 최신 첨부 시각, 응답을 바꾸는 옵션(쿼리 외)만 반영한다.
+func bodyETag(emailID int64, attachments int, latest time.Time, variant string) string {
+	return fmt.Sprintf(`W/"b%d-%d-%d-%s"`, emailID, attachments, latest.UnixMicro(), variant)
+}
+
+// notModified sets the ETag header and reports whether If-None-Match matches it (약한 비교).
+// true면 핸들러는 본문 없이 304로 응답한다.
+func notModified(c *fiber.Ctx, etag string) bool {
+	c.Set("ETag", etag)
+	for _, tag := range strings.Split(c.Get("If-None-Match"), ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"errors"
+	"mime"
+
+	"worker_server/core/service/filelink"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FileLinkHandler serves large attachments that were sent as download links.
+type FileLinkHandler struct {
+	files *filelink.Service
+}
+
+// NewFileLinkHandler creates a new FileLinkHandler.
+func NewFileLinkHandler(files *filelink.Service) *FileLinkHandler {
+	return &FileLinkHandler{files: files}
+}
+
+// RegisterPublic registers the download route (no auth - 수신자가 메일 본문 링크로 연다).
+func (h *FileLinkHandler) RegisterPublic(app fiber.Router) {
+	app.Get(filelink.Path+"/:id", h.Download)
+}
+
+// Download streams a stored attachment after checking the link signature.
+// GET /api/v1/files/:id?exp=<unix>&sig=<signature>
+func (h *FileLinkHandler) Download(c *fiber.Ctx) error {
+	blob, data, err := h.files.Open(c.Context(), c.Params("id"), c.Query("exp"), c.Query("sig"))
+	if err != nil {
+		switch {
+		case errors.Is(err, filelink.ErrInvalidSignature):
+			return ErrorResponse(c, 403, err.Error())
+		case errors.Is(err, filelink.ErrNotFound):
+			return ErrorResponse(c, 404, err.Error())
+		}
+		return InternalErrorResponse(c, err, "download file")
+	}
+
+	mimeType := blob.MimeType
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	c.Set("Cache-Control", "private, no-store")
+	c.Set("X-Content-Type-Options", "nosniff")
+	c.Set("Referrer-Policy", "no-referrer")
+	c.Set("Content-Type", mimeType)
+	c.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": blob.Filename}))
+	// 응답 후 fasthttp가 스트림을 닫는다
+	return c.SendStream(data, int(blob.Size))
+}
@@ -1,9 +1,12 @@
 package http
 
 import (
+	"errors"
 	"strconv"
 
+	"worker_server/adapter/out/persistence"
 	"worker_server/core/domain"
+	"worker_server/core/service/folder"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -11,14 +14,14 @@ import (
 
 // FolderHandler handles folder-related HTTP requests.
 type FolderHandler struct {
-	folderRepo      domain.FolderRepository
+	folderService   *folder.Service
 	smartFolderRepo domain.SmartFolderRepository
 }
 
 // NewFolderHandler creates a new FolderHandler.
-func NewFolderHandler(folderRepo domain.FolderRepository, smartFolderRepo domain.SmartFolderRepository) *FolderHandler {
+func NewFolderHandler(folderService *folder.Service, smartFolderRepo domain.SmartFolderRepository) *FolderHandler {
 	return &FolderHandler{
-		folderRepo:      folderRepo,
+		folderService:   folderService,
 		smartFolderRepo: smartFolderRepo,
 	}
 }
@@ -28,6 +31,7 @@ func (h *FolderHandler) RegisterRoutes(app fiber.Router) {
 	folders := app.Group("/folders")
 	folders.Get("/", h.ListFolders)
 	folders.Post("/", h.CreateFolder)
+	folders.Post("/sync", h.SyncFolders) // Provider 폴더 가져오기 + 누락된 폴더 생성 (?connection_id=)
 	folders.Get("/:id", h.GetFolder)
 	folders.Put("/:id", h.UpdateFolder)
 	folders.Delete("/:id", h.DeleteFolder)
@@ -41,16 +45,16 @@ func (h *FolderHandler) RegisterRoutes(app fiber.Router) {
 	smartFolders.Get("/:id/count", h.GetSmartFolderCount)
 }
 
-// ListFolders returns all folders for the current user.
+// ListFolders returns all folders for the current user with their provider mappings.
 func (h *FolderHandler) ListFolders(c *fiber.Ctx) error {
-	userID, ok := c.Locals("user_id").(uuid.UUID)
-	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
 	}
 
-	folders, err := h.folderRepo.GetByUserID(userID)
+	folders, err := h.folderService.List(c.Context(), userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return InternalErrorResponse(c, err, "list folders")
 	}
 
 	return c.JSON(fiber.Map{
@@ -59,142 +63,125 @@ func (h *FolderHandler) ListFolders(c *fiber.Ctx) error {
 	})
 }
 
-// CreateFolder creates a new folder.
+// CreateFolder creates a custom folder on every connected account.
 func (h *FolderHandler) CreateFolder(c *fiber.Ctx) error {
-	userID, ok := c.Locals("user_id").(uuid.UUID)
-	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "unauthorized"})
-	}
-
-	var req struct {
-		Name     string  `json:"name"`
-		Color    *string `json:"color,omitempty"`
-		Icon     *string `json:"icon,omitempty"`
-		Position int     `json:"position"`
-	}
-
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
-	}
-
-	if req.Name == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "name is required"})
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
 	}
 
-	folder := &domain.EmailFolder{
-		UserID:   userID,
-		Name:     req.Name,
-		Type:     domain.FolderTypeUser,
-		Color:    req.Color,
-		Icon:     req.Icon,
-		Position: req.Position,
+	var req folder.CreateRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
-	if err := h.folderRepo.Create(folder); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	created, err := h.folderService.Create(c.Context(), userID, &req)
+	if err != nil {
+		return h.folderError(c, err, "create folder")
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(folder)
+	return c.Status(fiber.StatusCreated).JSON(created)
 }
 
 // GetFolder returns a single folder by ID.
 func (h *FolderHandler) GetFolder(c *fiber.Ctx) error {
-	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	userID, err := GetUserID(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid folder ID"})
+		return ErrorResponse(c, 401, "unauthorized")
 	}
 
-	folder, err := h.folderRepo.GetByID(id)
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "folder not found"})
+		return ErrorResponse(c, 400, "invalid folder ID")
 	}
 
-	// Check ownership
-	userID, _ := c.Locals("user_id").(uuid.UUID)
-	if folder.UserID != userID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "access denied"})
+	result, err := h.folderService.Get(c.Context(), userID, id)
+	if err != nil {
+		return h.folderError(c, err, "get folder")
 	}
 
-	return c.JSON(folder)
+	return c.JSON(result)
 }
 
-// UpdateFolder updates a folder.
+// UpdateFolder updates a folder (이름 변경은 Provider 폴더에도 반영).
 func (h *FolderHandler) UpdateFolder(c *fiber.Ctx) error {
-	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	userID, err := GetUserID(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid folder ID"})
+		return ErrorResponse(c, 401, "unauthorized")
 	}
 
-	folder, err := h.folderRepo.GetByID(id)
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "folder not found"})
+		return ErrorResponse(c, 400, "invalid folder ID")
 	}
 
-	// Check ownership
-	userID, _ := c.Locals("user_id").(uuid.UUID)
-	if folder.UserID != userID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "access denied"})
+	var req folder.UpdateRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
-	var req struct {
-		Name     *string `json:"name,omitempty"`
-		Color    *string `json:"color,omitempty"`
-		Icon     *string `json:"icon,omitempty"`
-		Position *int    `json:"position,omitempty"`
-	}
-
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
-	}
-
-	if req.Name != nil {
-		folder.Name = *req.Name
-	}
-	if req.Color != nil {
-		folder.Color = req.Color
-	}
-	if req.Icon != nil {
-		folder.Icon = req.Icon
-	}
-	if req.Position != nil {
-		folder.Position = *req.Position
-	}
-
-	if err := h.folderRepo.Update(folder); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	result, err := h.folderService.Update(c.Context(), userID, id, &req)
+	if err != nil {
+		return h.folderError(c, err, "update folder")
 	}
 
-	return c.JSON(folder)
+	return c.JSON(result)
 }
 
-// DeleteFolder deletes a folder.
+// DeleteFolder deletes a folder locally and on the providers.
 func (h *FolderHandler) DeleteFolder(c *fiber.Ctx) error {
-	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	userID, err := GetUserID(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid folder ID"})
+		return ErrorResponse(c, 401, "unauthorized")
 	}
 
-	folder, err := h.folderRepo.GetByID(id)
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "folder not found"})
+		return ErrorResponse(c, 400, "invalid folder ID")
 	}
 
-	// Check ownership
-	userID, _ := c.Locals("user_id").(uuid.UUID)
-	if folder.UserID != userID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "access denied"})
+	if err := h.folderService.Delete(c.Context(), userID, id); err != nil {
+		return h.folderError(c, err, "delete folder")
 	}
 
-	// Cannot delete system folders
-	if folder.IsSystem() {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "cannot delete system folder"})
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// SyncFolders reconciles folders with the providers.
+// POST /folders/sync?connection_id=123 (없으면 모든 연결)
+func (h *FolderHandler) SyncFolders(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
 	}
 
-	if err := h.folderRepo.Delete(id); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	var results []*domain.FolderSyncResult
+	if connID := GetConnectionID(c); connID != nil {
+		result, err := h.folderService.Sync(c.Context(), userID, *connID)
+		if err != nil {
+			return h.folderError(c, err, "sync folders")
+		}
+		results = append(results, result)
+	} else if results, err = h.folderService.SyncAll(c.Context(), userID); err != nil {
+		return h.folderError(c, err, "sync folders")
 	}
 
-	return c.SendStatus(fiber.StatusNoContent)
+	return c.JSON(fiber.Map{"results": results})
+}
+
+func (h *FolderHandler) folderError(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, folder.ErrNotFound), errors.Is(err, persistence.ErrNotFound),
+		errors.Is(err, folder.ErrConnectionNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, folder.ErrSystemFolder):
+		return ErrorResponse(c, 403, err.Error())
+	case errors.Is(err, folder.ErrNameTaken):
+		return ErrorResponse(c, 409, err.Error())
+	case errors.Is(err, folder.ErrInvalidName):
+		return ErrorResponse(c, 400, err.Error())
+	}
+	return InternalErrorResponse(c, err, operation)
 }
 
 // ListSmartFolders returns all smart folders for the current user.
@@ -223,19 +210,15 @@ func (h *FolderHandler) CreateSmartFolder(c *fiber.Ctx) error {
 	}
 
 	var req struct {
-		Name     string                  `json:"name"`
+		Name     string                  `json:"name" validate:"required,max=100"`
 		Icon     *string                 `json:"icon,omitempty"`
 		Color    *string                 `json:"color,omitempty"`
 		Query    domain.SmartFolderQuery `json:"query"`
 		Position int                     `json:"position"`
 	}
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
-	}
-
-	if req.Name == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "name is required"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	folder := &domain.SmartFolder{
@@ -302,8 +285,8 @@ func (h *FolderHandler) UpdateSmartFolder(c *fiber.Ctx) error {
 		Position *int                     `json:"position,omitempty"`
 	}
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if req.Name != nil {
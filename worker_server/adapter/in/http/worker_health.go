@@ -4,6 +4,10 @@ import (
 	"context"
 	"time"
 
+	"worker_server/core/domain"
+	"worker_server/pkg/metrics"
+	"worker_server/pkg/resilience"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
@@ -13,9 +17,15 @@ type HealthChecker interface {
 	Ping(ctx context.Context) error
 }
 
+// WatchStatsSource counts Gmail watches by state (mail.SyncService).
+type WatchStatsSource interface {
+	WatchStats(ctx context.Context) (*domain.WatchStats, error)
+}
+
 type HealthHandler struct {
-	db    *pgxpool.Pool
-	redis *redis.Client
+	db      *pgxpool.Pool
+	redis   *redis.Client
+	watches WatchStatsSource
 }
 
 func NewHealthHandler() *HealthHandler {
@@ -32,6 +42,14 @@ func NewHealthHandlerWithDeps(db *pgxpool.Pool, redis *redis.Client) *HealthHand
 func (h *HealthHandler) Register(app *fiber.App) {
 	app.Get("/health", h.Health)
 	app.Get("/ready", h.Ready)
+	app.Get("/health/pools", h.Pools)
+	app.Get("/health/breakers", h.Breakers)
+	app.Get("/health/watches", h.Watches)
+}
+
+// SetWatchStats enables GET /health/watches.
+func (h *HealthHandler) SetWatchStats(src WatchStatsSource) {
+	h.watches = src
 }
 
 func (h *HealthHandler) Health(c *fiber.Ctx) error {
@@ -85,3 +103,50 @@ func (h *HealthHandler) Ready(c *fiber.Ctx) error {
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
 	})
 }
+
+// Pools reports connection pool saturation (Postgres, replica, Redis).
+// in_use가 max에 가깝거나 wait_count가 늘면 느린 의존성이 요청을 붙잡고 있다는 신호다.
+func (h *HealthHandler) Pools(c *fiber.Ctx) error {
+	stats := metrics.GetAllPoolStats()
+	health := metrics.GetAllPoolHealth()
+
+	pools := make(map[string]fiber.Map, len(stats))
+	for name, s := range stats {
+		pools[name] = fiber.Map{
+			"stats":  s.ToMap(),
+			"health": health[name],
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"pools":     pools,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// Breakers reports the circuit breaker state of external dependencies
+// (gmail-api, outlook-api, neo4j-personalization, vector-store, redis-producer).
+func (h *HealthHandler) Breakers(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"breakers":  resilience.BreakerStatuses(),
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// Watches reports Gmail watches by state. expired가 늘면 푸시 대신 폴링으로 동기화되는 연결이 늘고 있다는 신호다.
+func (h *HealthHandler) Watches(c *fiber.Ctx) error {
+	if h.watches == nil {
+		return ErrorResponse(c, 503, "watch stats not available")
+	}
+	ctx, cancel := context.WithTimeout(c.Context(), 5*time.Second)
+	defer cancel()
+
+	stats, err := h.watches.WatchStats(ctx)
+	if err != nil {
+		return InternalErrorResponse(c, err, "count watches")
+	}
+	return c.JSON(fiber.Map{
+		"watches":   stats,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -2,10 +2,14 @@ package http
 
 import (
 	"errors"
+	"reflect"
 	"time"
 
 	"worker_server/pkg/apperr"
 	"worker_server/pkg/logger"
+	"worker_server/pkg/validate"
+
+	"github.com/goccy/go-json"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -66,58 +70,108 @@ type APIError struct {
 	Details map[string]interface{} `json:"details,omitempty"`
 }
 
-// ErrorResponse sends a standardized JSON error response
+// ErrorResponse sends an RFC 7807 problem response with the default code for status
 func ErrorResponse(c *fiber.Ctx, status int, message string) error {
-	requestID, _ := c.Locals("request_id").(string)
-	return c.Status(status).JSON(APIResponse{
-		Success:   false,
-		Error:     &APIError{Code: mapStatusToCode(status), Message: message},
-		RequestID: requestID,
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-	})
+	return apperr.WriteProblem(c, apperr.New(apperr.CodeForStatus(status), message, status))
 }
 
-// ErrorResponseWithCode sends a standardized error response with custom code
+// ErrorResponseWithCode sends a problem response with custom code
 func ErrorResponseWithCode(c *fiber.Ctx, status int, code, message string) error {
-	requestID, _ := c.Locals("request_id").(string)
-	return c.Status(status).JSON(APIResponse{
-		Success:   false,
-		Error:     &APIError{Code: code, Message: message},
-		RequestID: requestID,
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-	})
+	return apperr.WriteProblem(c, apperr.New(code, message, status))
 }
 
-// ErrorResponseWithDetails sends a standardized error with details
+// ErrorResponseWithDetails sends a problem response with details
 func ErrorResponseWithDetails(c *fiber.Ctx, status int, code, message string, details map[string]interface{}) error {
-	requestID, _ := c.Locals("request_id").(string)
-	return c.Status(status).JSON(APIResponse{
-		Success:   false,
-		Error:     &APIError{Code: code, Message: message, Details: details},
-		RequestID: requestID,
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-	})
+	appErr := apperr.New(code, message, status)
+	appErr.Details = details
+	return apperr.WriteProblem(c, appErr)
 }
 
-// AppErrorResponse handles apperr.AppError and returns appropriate response
+// AppErrorResponse renders any error as a problem response.
+// Provider/timeout errors are classified; unknown errors become INTERNAL_ERROR.
 func AppErrorResponse(c *fiber.Ctx, err error) error {
-	appErr := apperr.AsAppError(err)
-	requestID, _ := c.Locals("request_id").(string)
-	return c.Status(appErr.Status).JSON(APIResponse{
-		Success:   false,
-		Error:     &APIError{Code: appErr.Code, Message: appErr.Message, Details: appErr.Details},
-		RequestID: requestID,
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-	})
+	if appErr := classifyError(err); appErr != nil {
+		return apperr.WriteProblem(c, appErr)
+	}
+	return apperr.WriteProblem(c, apperr.AsAppError(err))
 }
 
-// InternalErrorResponse returns a safe 500 error without exposing internal details.
+// InternalErrorResponse returns a safe error response without exposing internal details.
 // Use this instead of ErrorResponse(c, 500, err.Error()) to prevent information leakage.
-// The error is logged with context but only a generic message is returned to the client.
+// Known provider errors keep their status and code (e.g. PROVIDER_RATE_LIMITED);
+// anything else is logged and returned as a generic INTERNAL_ERROR.
 func InternalErrorResponse(c *fiber.Ctx, err error, operation string) error {
+	if appErr := classifyError(err); appErr != nil {
+		logger.WithError(err).WithField("operation", operation).WithField("error_code", appErr.Code).Warn("request failed")
+		return apperr.WriteProblem(c, appErr)
+	}
 	// Log the actual error for debugging
 	logger.WithError(err).WithField("operation", operation).Error("internal error")
-	return ErrorResponseWithCode(c, 500, "INTERNAL_ERROR", operation+" failed")
+	return apperr.WriteProblem(c, apperr.Wrap(err, apperr.CodeInternalError, operation+" failed", 500))
+}
+
+// NotConfiguredResponse reports a component that isn't wired in this deployment (503).
+func NotConfiguredResponse(c *fiber.Ctx, component string) error {
+	return apperr.WriteProblem(c, apperr.NotConfigured(component))
+}
+
+// BindBody parses the JSON body into v and validates its `validate` tags.
+// 실패하면 필드별 에러(details.errors)를 담은 AppError를 반환하므로 호출자는 그대로 return 한다.
+func BindBody(c *fiber.Ctx, v any) error {
+	if err := c.BodyParser(v); err != nil {
+		return bodyParseError(err)
+	}
+	if err := validate.Struct(v); err != nil {
+		var fieldErrs validate.Errors
+		if errors.As(err, &fieldErrs) {
+			return apperr.ValidationFailed("request validation failed").WithDetail("errors", fieldErrs)
+		}
+		return apperr.ValidationFailed(err.Error())
+	}
+	return nil
+}
+
+// bodyParseError converts a BodyParser error into a validation error with the offending field.
+func bodyParseError(err error) *apperr.AppError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		fieldErr := validate.FieldError{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Param:   typeErr.Type.String(),
+			Message: "must be " + jsonTypeName(typeErr.Type.Kind()),
+		}
+		return apperr.ValidationFailed("request validation failed").WithDetail("errors", validate.Errors{fieldErr})
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return apperr.BadRequest("malformed JSON body").WithDetail("offset", syntaxErr.Offset)
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusUnprocessableEntity {
+		return apperr.New("UNSUPPORTED_MEDIA_TYPE", "unsupported content type, expected application/json", fiber.StatusUnsupportedMediaType)
+	}
+	return apperr.BadRequest("invalid request body")
+}
+
+// jsonTypeName describes a Go kind as a JSON type.
+func jsonTypeName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
 }
 
 // SuccessResponse sends a standardized JSON success response
@@ -136,28 +190,6 @@ func SuccessResponseSimple(c *fiber.Ctx, data any) error {
 	return c.JSON(data)
 }
 
-// mapStatusToCode maps HTTP status to error code
-func mapStatusToCode(status int) string {
-	switch status {
-	case 400:
-		return apperr.CodeBadRequest
-	case 401:
-		return apperr.CodeUnauthorized
-	case 403:
-		return apperr.CodeForbidden
-	case 404:
-		return apperr.CodeNotFound
-	case 409:
-		return apperr.CodeConflict
-	case 429:
-		return "RATE_LIMITED"
-	case 500:
-		return apperr.CodeInternalError
-	default:
-		return "UNKNOWN_ERROR"
-	}
-}
-
 // =============================================================================
 // Pagination Helpers
 // =============================================================================
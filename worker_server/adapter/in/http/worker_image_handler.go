@@ -54,7 +54,7 @@ func (h *ImageHandler) Register(app fiber.Router) {
 
 // GenerateImageRequest represents the request for simple image generation
 type GenerateImageRequest struct {
-	Prompt      string              `json:"prompt"`
+	Prompt      string              `json:"prompt" validate:"required"`
 	Type        domain.ImageType    `json:"type,omitempty"`
 	Style       domain.ImageStyle   `json:"style,omitempty"`
 	Quality     domain.ImageQuality `json:"quality,omitempty"`
@@ -72,12 +72,8 @@ func (h *ImageHandler) GenerateImage(c *fiber.Ctx) error {
 	}
 
 	var req GenerateImageRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
-	}
-
-	if req.Prompt == "" {
-		return ErrorResponse(c, 400, "prompt is required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	// Set defaults
@@ -112,7 +108,7 @@ func (h *ImageHandler) GenerateImage(c *fiber.Ctx) error {
 
 // GenerateImagesRequest represents the request for batch image generation
 type GenerateImagesRequest struct {
-	Prompt      string              `json:"prompt"`
+	Prompt      string              `json:"prompt" validate:"required"`
 	Type        domain.ImageType    `json:"type,omitempty"`
 	Style       domain.ImageStyle   `json:"style,omitempty"`
 	Quality     domain.ImageQuality `json:"quality,omitempty"`
@@ -131,12 +127,8 @@ func (h *ImageHandler) GenerateImages(c *fiber.Ctx) error {
 	}
 
 	var req GenerateImagesRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
-	}
-
-	if req.Prompt == "" {
-		return ErrorResponse(c, 400, "prompt is required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	// Set defaults
@@ -184,7 +176,7 @@ func (h *ImageHandler) GenerateImages(c *fiber.Ctx) error {
 type IconBatchRequest struct {
 	TemplateID string               `json:"template_id,omitempty"`
 	Style      *domain.IconStyle    `json:"style,omitempty"`
-	Icons      []domain.IconRequest `json:"icons"`
+	Icons      []domain.IconRequest `json:"icons" validate:"required,max=10"`
 	Sizes      []int                `json:"sizes,omitempty"`
 	Formats    []domain.ImageFormat `json:"formats,omitempty"`
 	Variations int                  `json:"variations,omitempty"`
@@ -199,16 +191,8 @@ func (h *ImageHandler) GenerateIconBatch(c *fiber.Ctx) error {
 	}
 
 	var req IconBatchRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
-	}
-
-	if len(req.Icons) == 0 {
-		return ErrorResponse(c, 400, "at least one icon is required")
-	}
-
-	if len(req.Icons) > 10 {
-		return ErrorResponse(c, 400, "maximum 10 icons per batch")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	domainReq := domain.IconBatchRequest{
@@ -237,7 +221,7 @@ func (h *ImageHandler) GenerateIconBatch(c *fiber.Ctx) error {
 
 // PosterRequest represents the request for poster generation
 type PosterRequest struct {
-	Prompt     string                 `json:"prompt"`
+	Prompt     string                 `json:"prompt" validate:"required"`
 	Preset     domain.SizePreset      `json:"preset,omitempty"`
 	CustomSize *domain.ImageSize      `json:"custom_size,omitempty"`
 	Style      domain.PosterStyle     `json:"style,omitempty"`
@@ -255,12 +239,8 @@ func (h *ImageHandler) GeneratePoster(c *fiber.Ctx) error {
 	}
 
 	var req PosterRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
-	}
-
-	if req.Prompt == "" {
-		return ErrorResponse(c, 400, "prompt is required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	domainReq := domain.PosterRequest{
@@ -287,10 +267,10 @@ func (h *ImageHandler) GeneratePoster(c *fiber.Ctx) error {
 
 // MultiSizeRequest represents the request for multi-size generation
 type MultiSizeRequest struct {
-	Prompt      string                 `json:"prompt"`
+	Prompt      string                 `json:"prompt" validate:"required"`
 	BaseDesign  domain.PosterStyle     `json:"base_design,omitempty"`
 	Elements    *domain.PosterElements `json:"elements,omitempty"`
-	Sizes       []domain.SizeRequest   `json:"sizes"`
+	Sizes       []domain.SizeRequest   `json:"sizes" validate:"required,max=5"`
 	BrandKitID  string                 `json:"brand_kit_id,omitempty"`
 	AdaptLayout bool                   `json:"adapt_layout,omitempty"`
 }
@@ -304,20 +284,8 @@ func (h *ImageHandler) GenerateMultiSize(c *fiber.Ctx) error {
 	}
 
 	var req MultiSizeRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
-	}
-
-	if req.Prompt == "" {
-		return ErrorResponse(c, 400, "prompt is required")
-	}
-
-	if len(req.Sizes) == 0 {
-		return ErrorResponse(c, 400, "at least one size is required")
-	}
-
-	if len(req.Sizes) > 5 {
-		return ErrorResponse(c, 400, "maximum 5 sizes per request")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	domainReq := domain.MultiSizeRequest{
@@ -495,8 +463,8 @@ func (h *ImageHandler) CreateBrandKit(c *fiber.Ctx) error {
 	}
 
 	var req BrandKitRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if req.Name == "" {
@@ -574,8 +542,8 @@ func (h *ImageHandler) UpdateBrandKit(c *fiber.Ctx) error {
 	}
 
 	var req BrandKitRequest
-	if err := c.BodyParser(&req); err != nil {
-		return ErrorResponse(c, 400, "invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	// Update fields
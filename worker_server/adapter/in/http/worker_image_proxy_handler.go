@@ -0,0 +1,59 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/core/service/imageproxy"
+	"worker_server/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ImageProxyHandler serves remote email images through the proxy.
+type ImageProxyHandler struct {
+	proxy *imageproxy.Service
+}
+
+// NewImageProxyHandler creates a new ImageProxyHandler.
+func NewImageProxyHandler(proxy *imageproxy.Service) *ImageProxyHandler {
+	return &ImageProxyHandler{proxy: proxy}
+}
+
+// RegisterPublic registers the proxy route (no auth - <img> 요청에는 인증 헤더가 없음).
+// 서명된 URL만 허용하므로 임의 URL 프록시로 악용되지 않는다.
+func (h *ImageProxyHandler) RegisterPublic(app fiber.Router) {
+	app.Get(imageproxy.ProxyPath, h.Proxy)
+}
+
+// Proxy fetches a remote image without forwarding cookies or referrer.
+// GET /api/v1/img?u=<base64url>&s=<signature>
+func (h *ImageProxyHandler) Proxy(c *fiber.Ctx) error {
+	rawURL, err := h.proxy.Verify(c.Query("u"), c.Query("s"))
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid image url")
+	}
+
+	img, err := h.proxy.Fetch(c.Context(), rawURL)
+	if err != nil {
+		switch {
+		case errors.Is(err, imageproxy.ErrImageTooLarge):
+			return ErrorResponse(c, 413, err.Error())
+		case errors.Is(err, imageproxy.ErrNotImage), errors.Is(err, imageproxy.ErrBlockedHost):
+			return ErrorResponse(c, 422, err.Error())
+		}
+		logger.WithError(err).Debug("[ImageProxyHandler.Proxy] Failed to fetch image")
+		return ErrorResponse(c, 502, "failed to fetch image")
+	}
+
+	c.Set("Content-Type", img.ContentType)
+	c.Set("Cache-Control", "private, max-age=86400")
+	c.Set("X-Content-Type-Options", "nosniff")
+	c.Set("Referrer-Policy", "no-referrer")
+	// SVG를 직접 열어도 스크립트가 실행되지 않도록
+	c.Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	if img.Blocked {
+		c.Set("X-Tracker-Blocked", "1")
+	}
+
+	return c.Send(img.Data)
+}
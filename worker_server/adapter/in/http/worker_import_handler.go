@@ -0,0 +1,115 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"worker_server/adapter/out/persistence"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ImportHandler handles MBOX/EML mail import.
+type ImportHandler struct {
+	importService *mail.ImportService
+}
+
+// NewImportHandler creates a new ImportHandler.
+func NewImportHandler(importService *mail.ImportService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+// Register registers import routes.
+func (h *ImportHandler) Register(router fiber.Router) {
+	imports := router.Group("/imports")
+
+	imports.Post("/", h.Create)
+	imports.Get("/", h.List)
+	imports.Get("/:id", h.Get)
+}
+
+// Create uploads an MBOX/EML file and queues it for import.
+// @Summary Import mail from MBOX/EML
+// @Tags Imports
+// @Accept multipart/form-data
+// @Param file formData file true "MBOX or EML file"
+// @Success 202 {object} domain.MailImport
+// @Router /api/v1/imports [post]
+func (h *ImportHandler) Create(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return ErrorResponse(c, 400, "file is required")
+	}
+	if fileHeader.Size > mail.MaxImportSize {
+		return ErrorResponse(c, 413, "import file is too large")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return InternalErrorResponse(c, err, "open upload")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, mail.MaxImportSize+1))
+	if err != nil {
+		return InternalErrorResponse(c, err, "read upload")
+	}
+
+	job, err := h.importService.CreateImport(c.Context(), userID, fileHeader.Filename, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, mail.ErrImportEmpty), errors.Is(err, mail.ErrImportUnknownFormat):
+			return ErrorResponse(c, 400, err.Error())
+		case errors.Is(err, mail.ErrImportTooLarge):
+			return ErrorResponse(c, 413, err.Error())
+		}
+		return InternalErrorResponse(c, err, "create import")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(job)
+}
+
+// List returns recent import jobs.
+func (h *ImportHandler) List(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	jobs, err := h.importService.ListImports(c.Context(), userID, c.QueryInt("limit", 20))
+	if err != nil {
+		return InternalErrorResponse(c, err, "list imports")
+	}
+
+	return c.JSON(fiber.Map{"imports": jobs})
+}
+
+// Get returns a single import job with progress counters.
+func (h *ImportHandler) Get(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid import id")
+	}
+
+	job, err := h.importService.GetImport(c.Context(), userID, id)
+	if err != nil {
+		if errors.Is(err, persistence.ErrNotFound) {
+			return ErrorResponse(c, 404, "import not found")
+		}
+		return InternalErrorResponse(c, err, "get import")
+	}
+
+	return c.JSON(job)
+}
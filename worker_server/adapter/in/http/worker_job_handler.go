@@ -0,0 +1,49 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/core/service/job"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// JobHandler exposes background job status (sync, resync, reclassify).
+type JobHandler struct {
+	jobs *job.Service
+}
+
+// NewJobHandler creates a new JobHandler.
+func NewJobHandler(jobs *job.Service) *JobHandler {
+	return &JobHandler{jobs: jobs}
+}
+
+// Register registers job routes.
+func (h *JobHandler) Register(router fiber.Router) {
+	router.Get("/jobs/:id", h.Get)
+}
+
+// Get returns state, progress, error and timing of a job.
+// GET /jobs/:id
+func (h *JobHandler) Get(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid job id")
+	}
+
+	j, err := h.jobs.Get(c.Context(), userID, id)
+	if err != nil {
+		if errors.Is(err, job.ErrJobNotFound) {
+			return ErrorResponse(c, 404, err.Error())
+		}
+		return InternalErrorResponse(c, err, "get job")
+	}
+
+	return c.JSON(j)
+}
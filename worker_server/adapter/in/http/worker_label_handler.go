@@ -96,7 +96,7 @@ func (h *LabelHandler) GetLabel(c *fiber.Ctx) error {
 
 // CreateLabelRequest represents the request body for creating a label.
 type CreateLabelRequest struct {
-	Name  string  `json:"name" validate:"required"`
+	Name  string  `json:"name" validate:"required,folder"`
 	Color *string `json:"color,omitempty"`
 }
 
@@ -113,12 +113,8 @@ func (h *LabelHandler) CreateLabel(c *fiber.Ctx) error {
 	}
 
 	var req CreateLabelRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
-	}
-
-	if req.Name == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "Label name is required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	label := &domain.Label{
@@ -138,7 +134,7 @@ func (h *LabelHandler) CreateLabel(c *fiber.Ctx) error {
 
 // UpdateLabelRequest represents the request body for updating a label.
 type UpdateLabelRequest struct {
-	Name      *string `json:"name,omitempty"`
+	Name      *string `json:"name,omitempty" validate:"folder"`
 	Color     *string `json:"color,omitempty"`
 	IsVisible *bool   `json:"is_visible,omitempty"`
 }
@@ -177,8 +173,8 @@ func (h *LabelHandler) UpdateLabel(c *fiber.Ctx) error {
 	}
 
 	var req UpdateLabelRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	// Apply updates
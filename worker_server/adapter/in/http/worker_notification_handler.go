@@ -1,8 +1,10 @@
 package http
 
 import (
+	"errors"
 	"strconv"
 
+	"worker_server/adapter/out/persistence"
 	"worker_server/core/domain"
 	"worker_server/core/service/notification"
 
@@ -29,6 +31,11 @@ func (h *NotificationHandler) Register(router fiber.Router) {
 	notifications.Get("/unread-count", h.GetUnreadCount)
 	notifications.Post("/mark-read", h.MarkAsRead)
 	notifications.Post("/mark-all-read", h.MarkAllAsRead)
+	notifications.Get("/settings", h.GetSettings)
+	notifications.Put("/settings", h.UpdateSettings)
+	notifications.Get("/devices", h.ListDevices)
+	notifications.Post("/devices", h.RegisterDevice)
+	notifications.Delete("/devices/:token", h.UnregisterDevice)
 	notifications.Delete("/:id", h.DeleteNotification)
 	notifications.Delete("/", h.DeleteAllNotifications)
 }
@@ -104,7 +111,7 @@ func (h *NotificationHandler) GetUnreadCount(c *fiber.Ctx) error {
 
 // MarkAsReadRequest represents mark as read request.
 type MarkAsReadRequest struct {
-	NotificationIDs []int64 `json:"notification_ids"`
+	NotificationIDs []int64 `json:"notification_ids" validate:"required,max=1000"`
 }
 
 // MarkAsRead marks notifications as read.
@@ -119,12 +126,8 @@ func (h *NotificationHandler) MarkAsRead(c *fiber.Ctx) error {
 	}
 
 	var req MarkAsReadRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
-	}
-
-	if len(req.NotificationIDs) == 0 {
-		return fiber.NewError(fiber.StatusBadRequest, "notification_ids is required")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.notificationService.MarkAsRead(c.Context(), userID, req.NotificationIDs); err != nil {
@@ -196,3 +199,144 @@ func (h *NotificationHandler) DeleteAllNotifications(c *fiber.Ctx) error {
 		"message": "All notifications deleted",
 	})
 }
+
+// =============================================================================
+// Settings & Push Devices
+// =============================================================================
+
+// GetSettings returns the user's notification settings.
+// GET /notifications/settings
+func (h *NotificationHandler) GetSettings(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.notificationService == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Notification service not available")
+	}
+
+	settings, err := h.notificationService.GetSettings(c.Context(), userID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "get notification settings")
+	}
+
+	return c.JSON(settings)
+}
+
+// UpdateSettings replaces the user's notification settings.
+// push_categories/min_priority_for_notification으로 푸시를 보낼 메일을 정한다.
+// PUT /notifications/settings
+func (h *NotificationHandler) UpdateSettings(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.notificationService == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Notification service not available")
+	}
+
+	settings := domain.DefaultNotificationSettings(userID)
+	if err := BindBody(c, settings); err != nil {
+		return err
+	}
+	settings.UserID = userID
+
+	updated, err := h.notificationService.UpdateSettings(c.Context(), settings)
+	if err != nil {
+		return h.pushErrorResponse(c, err, "update notification settings")
+	}
+
+	return c.JSON(updated)
+}
+
+// RegisterDeviceRequest represents the HTTP request to register a push device.
+type RegisterDeviceRequest struct {
+	Platform   string `json:"platform" validate:"required,oneof=fcm apns"`
+	Token      string `json:"token" validate:"required,max=4096"`
+	DeviceName string `json:"device_name" validate:"max=255"`
+}
+
+// ListDevices returns the user's push devices.
+// GET /notifications/devices
+func (h *NotificationHandler) ListDevices(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.notificationService == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Notification service not available")
+	}
+
+	devices, err := h.notificationService.ListDevices(c.Context(), userID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list push devices")
+	}
+
+	return c.JSON(fiber.Map{"devices": devices})
+}
+
+// RegisterDevice registers an FCM/APNs device token for new mail push.
+// 앱 시작 시마다 호출해도 된다 (같은 토큰은 갱신).
+// POST /notifications/devices
+func (h *NotificationHandler) RegisterDevice(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.notificationService == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Notification service not available")
+	}
+
+	var req RegisterDeviceRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	device := &domain.PushDevice{
+		UserID:     userID,
+		Platform:   domain.PushPlatform(req.Platform),
+		Token:      req.Token,
+		DeviceName: req.DeviceName,
+	}
+	if err := h.notificationService.RegisterDevice(c.Context(), device); err != nil {
+		return h.pushErrorResponse(c, err, "register push device")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(device)
+}
+
+// UnregisterDevice removes a push device (로그아웃 시 호출).
+// DELETE /notifications/devices/:token
+func (h *NotificationHandler) UnregisterDevice(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if h.notificationService == nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Notification service not available")
+	}
+
+	if err := h.notificationService.UnregisterDevice(c.Context(), userID, c.Params("token")); err != nil {
+		return h.pushErrorResponse(c, err, "unregister push device")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *NotificationHandler) pushErrorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, persistence.ErrNotFound):
+		return ErrorResponse(c, 404, "device not found")
+	case errors.Is(err, notification.ErrPushNotConfigured):
+		return NotConfiguredResponse(c, "push notifications")
+	case errors.Is(err, notification.ErrUnsupportedPlatform),
+		errors.Is(err, notification.ErrInvalidSettings):
+		return ErrorResponse(c, 400, err.Error())
+	}
+	return InternalErrorResponse(c, err, operation)
+}
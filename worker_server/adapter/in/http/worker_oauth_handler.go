@@ -4,12 +4,14 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"worker_server/core/domain"
 	"worker_server/core/port/in"
+	"worker_server/core/service/auth"
 	"worker_server/pkg/logger"
 
 	"github.com/gofiber/fiber/v2"
@@ -31,8 +33,9 @@ const OAuthStateKey = "oauth:state:"
 const OAuthStateTTL = 10 * time.Minute
 
 type OAuthHandler struct {
-	oauthService in.OAuthService
-	stateStore   OAuthStateStore
+	oauthService  in.OAuthService
+	stateStore    OAuthStateStore
+	healthService *auth.ConnectionHealthService
 }
 
 func NewOAuthHandler(oauthService in.OAuthService) *OAuthHandler {
@@ -50,6 +53,11 @@ func NewOAuthHandlerWithStateStore(oauthService in.OAuthService, stateStore OAut
 	}
 }
 
+// SetHealthService enables connection health-check and reconnect routes.
+func (h *OAuthHandler) SetHealthService(svc *auth.ConnectionHealthService) {
+	h.healthService = svc
+}
+
 // generateSecureState 암호학적으로 안전한 state 생성
 func generateSecureState() (string, error) {
 	bytes := make([]byte, 32)
@@ -68,6 +76,12 @@ func (h *OAuthHandler) Register(app fiber.Router) {
 	oauth.Get("/connections/default", h.GetDefaultConnection)
 	oauth.Post("/connections/:id/default", h.SetDefaultConnection)
 	oauth.Delete("/connections/:id", h.Disconnect)
+
+	if h.healthService != nil {
+		connections := app.Group("/connections")
+		connections.Get("/:id/health", h.ConnectionHealth)
+		connections.Post("/:id/reconnect", h.Reconnect)
+	}
 }
 
 func (h *OAuthHandler) Connect(c *fiber.Ctx) error {
@@ -81,24 +95,9 @@ func (h *OAuthHandler) Connect(c *fiber.Ctx) error {
 	}
 	logger.Info("[OAuth Connect] UserID: %s", userID)
 
-	// 암호학적으로 안전한 state 생성
-	secureRandom, err := generateSecureState()
+	state, err := h.issueState(c.Context(), userID)
 	if err != nil {
-		logger.WithError(err).Error("[OAuth Connect] Failed to generate secure state")
-		return ErrorResponse(c, 500, "failed to generate state")
-	}
-
-	// state 형식: "userID:secureRandomString"
-	state := userID.String() + ":" + secureRandom
-	logger.Debug("[OAuth Connect] Generated state: %s", state[:50]+"...")
-
-	// CSRF 보호: state를 Redis에 저장 (활성화된 경우)
-	if h.stateStore != nil {
-		if err := h.stateStore.StoreState(c.Context(), state, userID, OAuthStateTTL); err != nil {
-			logger.WithError(err).Error("[OAuth Connect] Failed to store state")
-			return ErrorResponse(c, 500, "failed to store state")
-		}
-		logger.Debug("[OAuth Connect] State stored in Redis with TTL: %v", OAuthStateTTL)
+		return InternalErrorResponse(c, err, "issue oauth state")
 	}
 
 	authURL, err := h.oauthService.GetAuthURL(c.Context(), provider, state)
@@ -114,6 +113,27 @@ func (h *OAuthHandler) Connect(c *fiber.Ctx) error {
 	})
 }
 
+// issueState creates a "userID:random" state and stores it for CSRF validation.
+func (h *OAuthHandler) issueState(ctx context.Context, userID uuid.UUID) (string, error) {
+	// 암호학적으로 안전한 state 생성
+	secureRandom, err := generateSecureState()
+	if err != nil {
+		return "", errors.New("failed to generate state")
+	}
+
+	// state 형식: "userID:secureRandomString"
+	state := userID.String() + ":" + secureRandom
+
+	// CSRF 보호: state를 Redis에 저장 (활성화된 경우)
+	if h.stateStore != nil {
+		if err := h.stateStore.StoreState(ctx, state, userID, OAuthStateTTL); err != nil {
+			logger.WithError(err).Error("[OAuth] Failed to store state")
+			return "", errors.New("failed to store state")
+		}
+	}
+	return state, nil
+}
+
 func (h *OAuthHandler) Callback(c *fiber.Ctx) error {
 	provider := domain.OAuthProvider(c.Params("provider"))
 	code := c.Query("code")
@@ -285,3 +305,63 @@ func (h *OAuthHandler) SetDefaultConnection(c *fiber.Ctx) error {
 		"message": "default connection updated",
 	})
 }
+
+// ConnectionHealth reports token, watch and sync health of a connection.
+// GET /connections/:id/health
+func (h *OAuthHandler) ConnectionHealth(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	connID, err := c.ParamsInt("id")
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid connection id")
+	}
+
+	health, err := h.healthService.Check(c.Context(), userID, int64(connID))
+	if err != nil {
+		if errors.Is(err, auth.ErrConnectionNotFound) {
+			return ErrorResponse(c, 404, err.Error())
+		}
+		return InternalErrorResponse(c, err, "check connection health")
+	}
+
+	return c.JSON(health)
+}
+
+// Reconnect returns a fresh OAuth URL to re-authorize a broken connection.
+// POST /connections/:id/reconnect
+func (h *OAuthHandler) Reconnect(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	connID, err := c.ParamsInt("id")
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid connection id")
+	}
+
+	state, err := h.issueState(c.Context(), userID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "issue oauth state")
+	}
+
+	authURL, conn, err := h.healthService.ReconnectURL(c.Context(), userID, int64(connID), state)
+	if err != nil {
+		if errors.Is(err, auth.ErrConnectionNotFound) {
+			return ErrorResponse(c, 404, err.Error())
+		}
+		return ErrorResponse(c, 400, err.Error())
+	}
+
+	logger.Info("[OAuth Reconnect] Connection %d (%s)", conn.ID, conn.Provider)
+	return c.JSON(fiber.Map{
+		"auth_url":      authURL,
+		"state":         state,
+		"connection_id": conn.ID,
+		"provider":      conn.Provider,
+		"email":         conn.Email,
+	})
+}
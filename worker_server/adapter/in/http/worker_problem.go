@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"worker_server/core/port/out"
+	"worker_server/core/service/auth"
+	"worker_server/core/service/common"
+	"worker_server/pkg/apperr"
+
+	"golang.org/x/oauth2"
+)
+
+// classifyError maps known domain/provider errors to typed API errors.
+// 분류할 수 없는 에러는 nil을 반환하고 호출자가 INTERNAL_ERROR로 처리한다.
+func classifyError(err error) *apperr.AppError {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *apperr.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var providerErr *out.ProviderError
+	if errors.As(err, &providerErr) {
+		return providerAppError(providerErr)
+	}
+
+	var retrieveErr *oauth2.RetrieveError
+	switch {
+	case errors.As(err, &retrieveErr),
+		errors.Is(err, auth.ErrTokenExpired),
+		errors.Is(err, common.ErrTokenExpired),
+		errors.Is(err, common.ErrInvalidToken):
+		return providerAuthFailed("", err)
+	case errors.Is(err, common.ErrRateLimited):
+		return apperr.RateLimited(0)
+	case errors.Is(err, common.ErrForbidden):
+		return apperr.Forbidden("")
+	case errors.Is(err, common.ErrNotFound):
+		return apperr.NotFound("resource")
+	case errors.Is(err, context.DeadlineExceeded):
+		return apperr.Timeout("request")
+	}
+	return nil
+}
+
+// providerAppError maps a provider adapter error to an API error.
+func providerAppError(e *out.ProviderError) *apperr.AppError {
+	switch e.Code {
+	case out.ProviderErrAuth, out.ProviderErrTokenExpired:
+		return providerAuthFailed(e.Provider, e)
+	case out.ProviderErrRateLimit:
+		return apperr.Provider(apperr.CodeProviderRateLimited, e.Provider, "provider rate limit exceeded", http.StatusTooManyRequests, true, e)
+	case out.ProviderErrNotFound:
+		return apperr.Provider(apperr.CodeProviderNotFound, e.Provider, "resource not found at provider", http.StatusNotFound, false, e)
+	case out.ProviderErrInvalidInput:
+		return apperr.Provider(apperr.CodeProviderInvalidInput, e.Provider, "provider rejected the request", http.StatusBadRequest, false, e)
+	case out.ProviderErrSyncRequired:
+		return apperr.Provider(apperr.CodeProviderSyncRequired, e.Provider, "full sync required", http.StatusConflict, true, e)
+	case out.ProviderErrTooLarge:
+		return apperr.Provider(apperr.CodeProviderTooLarge, e.Provider, "payload too large for provider", http.StatusRequestEntityTooLarge, false, e)
+	default:
+		return apperr.Provider(apperr.CodeProviderUnavailable, e.Provider, "provider request failed", http.StatusBadGateway, e.Retryable, e)
+	}
+}
+
+// providerAuthFailed - 토큰 만료/권한 철회. 앱 로그인(401)과 구분되도록 403으로 응답한다.
+func providerAuthFailed(provider string, err error) *apperr.AppError {
+	appErr := apperr.Provider(apperr.CodeProviderAuthFailed, provider, "provider authorization failed, reconnect the account", http.StatusForbidden, false, err)
+	return appErr.WithDetail("needs_reconnect", true)
+}
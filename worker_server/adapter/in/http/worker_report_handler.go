@@ -112,9 +112,9 @@ func (h *ReportHandler) GetLatestReport(c *fiber.Ctx) error {
 
 // GenerateReportRequest represents report generation request.
 type GenerateReportRequest struct {
-	Type      string `json:"type"`                 // daily, weekly, monthly, custom
-	StartDate string `json:"start_date,omitempty"` // YYYY-MM-DD
-	EndDate   string `json:"end_date,omitempty"`   // YYYY-MM-DD
+	Type      string `json:"type" validate:"oneof=daily weekly monthly custom"`
+	StartDate string `json:"start_date,omitempty" validate:"date"` // YYYY-MM-DD
+	EndDate   string `json:"end_date,omitempty" validate:"date"`   // YYYY-MM-DD
 }
 
 // GenerateReport generates a new report.
@@ -129,8 +129,8 @@ func (h *ReportHandler) GenerateReport(c *fiber.Ctx) error {
 	}
 
 	var req GenerateReportRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if req.Type == "" {
@@ -167,6 +167,9 @@ func (h *ReportHandler) GenerateReport(c *fiber.Ctx) error {
 		if err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, "Invalid end_date format (use YYYY-MM-DD)")
 		}
+		if endDate.Before(startDate) {
+			return fiber.NewError(fiber.StatusBadRequest, "end_date must not be before start_date")
+		}
 		endDate = endDate.Add(24 * time.Hour) // Include the end date
 	default:
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid report type")
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"html"
+
+	"worker_server/core/service/safelink"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SafeLinkHandler handles rewritten link redirects and click analytics.
+type SafeLinkHandler struct {
+	safeLinks *safelink.Service
+}
+
+// NewSafeLinkHandler creates a new SafeLinkHandler.
+func NewSafeLinkHandler(safeLinks *safelink.Service) *SafeLinkHandler {
+	return &SafeLinkHandler{safeLinks: safeLinks}
+}
+
+// Register registers authenticated analytics routes.
+func (h *SafeLinkHandler) Register(router fiber.Router) {
+	links := router.Group("/links")
+
+	links.Get("/clicks", h.ListClicks)
+	links.Get("/stats", h.GetStats)
+}
+
+// RegisterPublic registers redirect routes (no auth - 브라우저 클릭에는 인증 헤더가 없음).
+// 토큰 서명으로 생성된 링크만 리다이렉트되므로 open redirect가 되지 않는다.
+func (h *SafeLinkHandler) RegisterPublic(app fiber.Router) {
+	app.Get(safelink.RedirectPath, h.Redirect)
+	app.Get("/api/v1/links/preview", h.Preview)
+}
+
+// Redirect checks the destination, records the click and redirects.
+// GET /api/v1/links/r?t=<token>
+func (h *SafeLinkHandler) Redirect(c *fiber.Ctx) error {
+	link, err := h.safeLinks.Verify(c.Query("t"))
+	if err != nil {
+		if errors.Is(err, safelink.ErrNotConfigured) {
+			return NotConfiguredResponse(c, "safe links")
+		}
+		return ErrorResponse(c, 400, "invalid link")
+	}
+
+	rep := h.safeLinks.Check(c.Context(), link)
+	h.safeLinks.RecordClick(c.Context(), link, rep)
+
+	c.Set("Cache-Control", "private, no-store")
+	c.Set("Referrer-Policy", "no-referrer")
+
+	if rep.IsBlocked() {
+		c.Set("Content-Type", "text/html; charset=utf-8")
+		return c.Status(fiber.StatusForbidden).SendString(blockedLinkPage(link.URL, rep.Threats))
+	}
+
+	return c.Redirect(link.URL, fiber.StatusFound)
+}
+
+// Preview returns the destination and verdict without following the link.
+// GET /api/v1/links/preview?t=<token>
+func (h *SafeLinkHandler) Preview(c *fiber.Ctx) error {
+	link, err := h.safeLinks.Verify(c.Query("t"))
+	if err != nil {
+		if errors.Is(err, safelink.ErrNotConfigured) {
+			return NotConfiguredResponse(c, "safe links")
+		}
+		return ErrorResponse(c, 400, "invalid link")
+	}
+
+	rep := h.safeLinks.Check(c.Context(), link)
+
+	c.Set("Cache-Control", "private, no-store")
+	return c.JSON(fiber.Map{
+		"url":     link.URL,
+		"verdict": rep.Verdict,
+		"threats": rep.Threats,
+		"source":  rep.Source,
+	})
+}
+
+// ListClicks returns recent link clicks of the user.
+// GET /links/clicks?limit=50
+func (h *SafeLinkHandler) ListClicks(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	clicks, err := h.safeLinks.ListClicks(c.Context(), userID, limit)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list link clicks")
+	}
+
+	return c.JSON(fiber.Map{
+		"clicks": clicks,
+		"total":  len(clicks),
+	})
+}
+
+// GetStats returns click statistics of the user.
+// GET /links/stats?days=30
+func (h *SafeLinkHandler) GetStats(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	days := c.QueryInt("days", 30)
+	if days <= 0 || days > 365 {
+		days = 30
+	}
+
+	stats, err := h.safeLinks.GetStats(c.Context(), userID, days)
+	if err != nil {
+		return InternalErrorResponse(c, err, "get link stats")
+	}
+
+	return c.JSON(stats)
+}
+
+// blockedLinkPage renders the interstitial shown for blocked destinations.
+func blockedLinkPage(target string, threats []string) string {
+	reason := "This link was flagged as unsafe."
+	if len(threats) > 0 {
+		reason = fmt.Sprintf("This link was flagged as unsafe (%s).", html.EscapeString(threats[0]))
+	}
+	return `<!DOCTYPE html><html><head><meta charset="utf-8"><meta name="robots" content="noindex">` +
+		`<title>Blocked link</title></head><body style="font-family:sans-serif;max-width:560px;margin:64px auto">` +
+		`<h1>Blocked link</h1><p>` + reason + `</p>` +
+		`<p>Destination: <code style="word-break:break-all">` + html.EscapeString(target) + `</code></p>` +
+		`</body></html>`
+}
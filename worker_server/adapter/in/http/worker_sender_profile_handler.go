@@ -138,8 +138,8 @@ func (h *SenderProfileHandler) UpdateSenderProfile(c *fiber.Ctx) error {
 		DisplayName        *string `json:"display_name,omitempty"`
 	}
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if req.LearnedCategory != nil {
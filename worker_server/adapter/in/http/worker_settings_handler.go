@@ -218,9 +218,11 @@ func (h *SettingsHandler) GetSettings(c *fiber.Ctx) error {
 // UpdateSettingsRequest represents settings update request.
 type UpdateSettingsRequest struct {
 	// Email settings
-	DefaultSignature *string `json:"default_signature,omitempty"`
-	AutoReplyEnabled *bool   `json:"auto_reply_enabled,omitempty"`
-	AutoReplyMessage *string `json:"auto_reply_message,omitempty"`
+	DefaultSignature     *string `json:"default_signature,omitempty"`
+	AutoReplyEnabled     *bool   `json:"auto_reply_enabled,omitempty"`
+	AutoReplyMessage     *string `json:"auto_reply_message,omitempty"`
+	SafeLinksEnabled     *bool   `json:"safe_links_enabled,omitempty"`
+	LargeAttachmentLinks *bool   `json:"large_attachment_links,omitempty"`
 
 	// AI settings
 	AIEnabled      *bool   `json:"ai_enabled,omitempty"`
@@ -245,8 +247,8 @@ func (h *SettingsHandler) UpdateSettings(c *fiber.Ctx) error {
 	}
 
 	var req UpdateSettingsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	// Convert to map for partial updates
@@ -260,6 +262,12 @@ func (h *SettingsHandler) UpdateSettings(c *fiber.Ctx) error {
 	if req.AutoReplyMessage != nil {
 		updates["auto_reply_message"] = *req.AutoReplyMessage
 	}
+	if req.SafeLinksEnabled != nil {
+		updates["safe_links_enabled"] = *req.SafeLinksEnabled
+	}
+	if req.LargeAttachmentLinks != nil {
+		updates["large_attachment_links"] = *req.LargeAttachmentLinks
+	}
 	if req.AIEnabled != nil {
 		updates["ai_enabled"] = *req.AIEnabled
 	}
@@ -343,8 +351,8 @@ func (h *SettingsHandler) UpdateAISettings(c *fiber.Ctx) error {
 	}
 
 	var req UpdateAISettingsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	updates := make(map[string]any)
@@ -419,8 +427,8 @@ func (h *SettingsHandler) UpdateClassificationRules(c *fiber.Ctx) error {
 	}
 
 	var req UpdateClassificationRulesRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	rules := &domain.ClassificationRules{
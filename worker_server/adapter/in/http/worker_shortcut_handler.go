@@ -93,10 +93,8 @@ func (h *ShortcutHandler) UpdateShortcuts(c *fiber.Ctx) error {
 	}
 
 	var req UpdateShortcutsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid request body",
-		})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	// Get existing or create new
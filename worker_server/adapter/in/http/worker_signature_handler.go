@@ -0,0 +1,198 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/service/signature"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SignatureHandler handles email signature management.
+type SignatureHandler struct {
+	service *signature.Service
+}
+
+// NewSignatureHandler creates a new SignatureHandler.
+func NewSignatureHandler(service *signature.Service) *SignatureHandler {
+	return &SignatureHandler{service: service}
+}
+
+// Register registers signature routes.
+func (h *SignatureHandler) Register(router fiber.Router) {
+	signatures := router.Group("/signatures")
+
+	signatures.Get("/", h.List)
+	signatures.Post("/", h.Create)
+	signatures.Put("/connections/:connectionId", h.SetConnectionDefault) // 계정별 기본 서명
+	signatures.Get("/:id", h.Get)
+	signatures.Put("/:id", h.Update)
+	signatures.Delete("/:id", h.Delete)
+	signatures.Post("/:id/default", h.SetDefault)
+}
+
+// SignatureRequest represents the HTTP request to create or update a signature.
+type SignatureRequest struct {
+	Name      string `json:"name"`
+	Text      string `json:"text"`
+	HTML      string `json:"html"`
+	IsDefault bool   `json:"is_default"`
+}
+
+// ConnectionSignatureRequest sets the default signature of a connection (빈 값이면 해제).
+type ConnectionSignatureRequest struct {
+	SignatureID string `json:"signature_id"`
+}
+
+// List returns all signatures.
+// GET /signatures
+func (h *SignatureHandler) List(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	signatures, err := h.service.List(c.Context(), userID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list signatures")
+	}
+
+	return c.JSON(fiber.Map{
+		"signatures": signatures,
+		"total":      len(signatures),
+	})
+}
+
+// Get returns a signature.
+// GET /signatures/:id
+func (h *SignatureHandler) Get(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	sig, err := h.service.Get(c.Context(), userID, c.Params("id"))
+	if err != nil {
+		return h.errorResponse(c, err, "get signature")
+	}
+
+	return c.JSON(sig)
+}
+
+// Create creates a signature.
+// POST /signatures
+func (h *SignatureHandler) Create(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req SignatureRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	sig, err := h.service.Create(c.Context(), userID, &signature.SaveRequest{
+		Name:      req.Name,
+		Text:      req.Text,
+		HTML:      req.HTML,
+		IsDefault: req.IsDefault,
+	})
+	if err != nil {
+		return h.errorResponse(c, err, "create signature")
+	}
+
+	return c.Status(201).JSON(sig)
+}
+
+// Update updates a signature.
+// PUT /signatures/:id
+func (h *SignatureHandler) Update(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req SignatureRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	sig, err := h.service.Update(c.Context(), userID, c.Params("id"), &signature.SaveRequest{
+		Name:      req.Name,
+		Text:      req.Text,
+		HTML:      req.HTML,
+		IsDefault: req.IsDefault,
+	})
+	if err != nil {
+		return h.errorResponse(c, err, "update signature")
+	}
+
+	return c.JSON(sig)
+}
+
+// Delete deletes a signature.
+// DELETE /signatures/:id
+func (h *SignatureHandler) Delete(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	if err := h.service.Delete(c.Context(), userID, c.Params("id")); err != nil {
+		return h.errorResponse(c, err, "delete signature")
+	}
+
+	return c.SendStatus(204)
+}
+
+// SetDefault sets the user's default signature.
+// POST /signatures/:id/default
+func (h *SignatureHandler) SetDefault(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	if err := h.service.SetDefault(c.Context(), userID, c.Params("id")); err != nil {
+		return h.errorResponse(c, err, "set default signature")
+	}
+
+	return c.JSON(fiber.Map{"success": true})
+}
+
+// SetConnectionDefault sets the default signature of a connection.
+// PUT /signatures/connections/:connectionId
+func (h *SignatureHandler) SetConnectionDefault(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	connectionID, err := strconv.ParseInt(c.Params("connectionId"), 10, 64)
+	if err != nil || connectionID <= 0 {
+		return ErrorResponse(c, 400, "invalid connection id")
+	}
+
+	var req ConnectionSignatureRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.service.SetConnectionDefault(c.Context(), userID, connectionID, req.SignatureID); err != nil {
+		return h.errorResponse(c, err, "set connection signature")
+	}
+
+	return c.JSON(fiber.Map{"success": true})
+}
+
+func (h *SignatureHandler) errorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, signature.ErrNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, signature.ErrEmpty), errors.Is(err, signature.ErrTooLarge):
+		return ErrorResponse(c, 400, err.Error())
+	}
+	return InternalErrorResponse(c, err, operation)
+}
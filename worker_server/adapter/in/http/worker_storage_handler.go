@@ -0,0 +1,141 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/domain"
+	"worker_server/core/service/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// StorageHandler exposes per-connection storage usage, caps and sync mode.
+type StorageHandler struct {
+	storage *storage.Service
+}
+
+// NewStorageHandler creates a new StorageHandler.
+func NewStorageHandler(storage *storage.Service) *StorageHandler {
+	return &StorageHandler{storage: storage}
+}
+
+// Register registers storage usage routes.
+func (h *StorageHandler) Register(router fiber.Router) {
+	connections := router.Group("/connections")
+
+	connections.Get("/:id/usage", h.GetUsage)
+	connections.Put("/:id/usage/cap", h.SetCap)
+	connections.Put("/:id/sync-mode", h.SetSyncMode)
+}
+
+// SetStorageCapRequest represents the HTTP request to set a connection's storage cap.
+// cap_mb: null = 기본값, 0 = 상한 없음
+type SetStorageCapRequest struct {
+	CapMB *int64 `json:"cap_mb"`
+}
+
+// SetSyncModeRequest represents the HTTP request to set a connection's sync mode.
+type SetSyncModeRequest struct {
+	Mode string `json:"mode" validate:"required"` // full, metadata
+}
+
+// GetUsage returns the storage consumed by a connection (bodies, attachments, vectors).
+// GET /connections/:id/usage
+func (h *StorageHandler) GetUsage(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	connectionID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid connection id")
+	}
+
+	usage, err := h.storage.Usage(c.Context(), userID, connectionID)
+	if err != nil {
+		return h.errorResponse(c, err, "get storage usage")
+	}
+
+	return c.JSON(h.usageResponse(usage))
+}
+
+// SetCap sets the storage cap of a connection. 상한을 넘으면 본문/임베딩 저장을 멈춘다 (metadata-only).
+// PUT /connections/:id/usage/cap
+func (h *StorageHandler) SetCap(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	connectionID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid connection id")
+	}
+
+	var req SetStorageCapRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	var capBytes *int64
+	if req.CapMB != nil {
+		v := *req.CapMB * 1024 * 1024
+		capBytes = &v
+	}
+
+	usage, err := h.storage.SetCap(c.Context(), userID, connectionID, capBytes)
+	if err != nil {
+		return h.errorResponse(c, err, "set storage cap")
+	}
+
+	return c.JSON(h.usageResponse(usage))
+}
+
+// SetSyncMode sets the sync mode of a connection.
+// metadata: 본문/임베딩을 저장하지 않고 열람 시 Provider에서 가져온다 (기존 본문/임베딩은 삭제).
+// PUT /connections/:id/sync-mode
+func (h *StorageHandler) SetSyncMode(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	connectionID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid connection id")
+	}
+
+	var req SetSyncModeRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	usage, err := h.storage.SetSyncMode(c.Context(), userID, connectionID, domain.ConnectionSyncMode(req.Mode))
+	if err != nil {
+		return h.errorResponse(c, err, "set sync mode")
+	}
+
+	return c.JSON(h.usageResponse(usage))
+}
+
+func (h *StorageHandler) usageResponse(usage *domain.ConnectionStorage) fiber.Map {
+	return fiber.Map{
+		"usage":       usage,
+		"total_bytes": usage.TotalBytes(),
+		"cap_bytes":   h.storage.EffectiveCap(usage), // 0 = 상한 없음
+	}
+}
+
+func (h *StorageHandler) errorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, storage.ErrConnectionNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, storage.ErrInvalidCap),
+		errors.Is(err, storage.ErrInvalidSyncMode),
+		errors.Is(err, storage.ErrLocalSyncMode):
+		return ErrorResponse(c, 400, err.Error())
+	}
+	return InternalErrorResponse(c, err, operation)
+}
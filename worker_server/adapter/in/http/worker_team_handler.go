@@ -0,0 +1,525 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/domain"
+	"worker_server/core/service/team"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// TeamHandler handles orgs, shared mailboxes and internal email comments.
+type TeamHandler struct {
+	team *team.Service
+}
+
+// NewTeamHandler creates a new TeamHandler.
+func NewTeamHandler(team *team.Service) *TeamHandler {
+	return &TeamHandler{team: team}
+}
+
+// Register registers org and email comment routes.
+func (h *TeamHandler) Register(router fiber.Router) {
+	orgs := router.Group("/orgs")
+	orgs.Get("/", h.ListOrgs)
+	orgs.Post("/", h.CreateOrg)
+	orgs.Get("/:id/members", h.ListMembers)
+	orgs.Post("/:id/members", h.AddMember)
+	orgs.Delete("/:id/members/:userId", h.RemoveMember)
+	orgs.Get("/:id/mailboxes", h.ListMailboxes)
+	orgs.Post("/:id/mailboxes", h.ShareMailbox)
+	orgs.Delete("/:id/mailboxes/:connectionId", h.UnshareMailbox)
+	orgs.Get("/:id/slas", h.ListSLAs)
+	orgs.Put("/:id/slas/:category", h.SetSLA) // category = ai_category 또는 default
+	orgs.Delete("/:id/slas/:category", h.DeleteSLA)
+
+	// 공유 메일함 메일의 내부 댓글 (조직 멤버만)
+	mail := router.Group("/email")
+	mail.Get("/:id/comments", h.ListComments)
+	mail.Post("/:id/comments", h.AddComment)
+	mail.Put("/:id/comments/:commentId", h.UpdateComment)
+	mail.Delete("/:id/comments/:commentId", h.DeleteComment)
+
+	// 담당자 지정 (목록의 assigned_to_me 필터와 함께 사용)
+	mail.Get("/:id/assignment", h.GetAssignment)
+	mail.Post("/:id/assign", h.Assign)
+}
+
+// CreateOrgRequest represents the HTTP request to create an org.
+type CreateOrgRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// AddOrgMemberRequest adds an existing user to an org by email.
+type AddOrgMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role,omitempty"` // member (default), owner
+}
+
+// ShareMailboxRequest shares one of the caller's connections with an org.
+type ShareMailboxRequest struct {
+	ConnectionID int64 `json:"connection_id" validate:"required"`
+}
+
+// EmailCommentRequest represents the HTTP request to add or edit a comment.
+// 본문의 "@member@example.com"도 멘션으로 처리한다.
+type EmailCommentRequest struct {
+	Body       string   `json:"body" validate:"required,max=5000"`
+	ParentID   *int64   `json:"parent_id,omitempty"`
+	MentionIDs []string `json:"mention_ids,omitempty"`
+}
+
+// OrgSLARequest sets the response-time SLA of a category.
+type OrgSLARequest struct {
+	ResponseMinutes int `json:"response_minutes" validate:"required,min=1,max=43200"`
+	AtRiskPercent   int `json:"at_risk_percent,omitempty" validate:"omitempty,min=1,max=99"` // 기본 75
+}
+
+// AssignEmailRequest sets the assignee of a shared email. assignee_id가 비어 있으면 담당 해제.
+type AssignEmailRequest struct {
+	AssigneeID string `json:"assignee_id,omitempty"`
+	Note       string `json:"note,omitempty" validate:"max=500"`
+}
+
+// ListOrgs returns the caller's orgs.
+// GET /orgs
+func (h *TeamHandler) ListOrgs(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	orgs, err := h.team.ListOrgs(c.Context(), userID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list orgs")
+	}
+	return c.JSON(fiber.Map{"orgs": orgs})
+}
+
+// CreateOrg creates an org owned by the caller.
+// POST /orgs
+func (h *TeamHandler) CreateOrg(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req CreateOrgRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	org, err := h.team.CreateOrg(c.Context(), userID, req.Name)
+	if err != nil {
+		return teamErrorResponse(c, err, "create org")
+	}
+	return c.Status(fiber.StatusCreated).JSON(org)
+}
+
+// ListMembers returns the members of an org.
+// GET /orgs/:id/members
+func (h *TeamHandler) ListMembers(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	orgID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid org id")
+	}
+
+	members, err := h.team.ListMembers(c.Context(), userID, orgID)
+	if err != nil {
+		return teamErrorResponse(c, err, "list org members")
+	}
+	return c.JSON(fiber.Map{"members": members})
+}
+
+// AddMember adds a user to an org. Owner only.
+// POST /orgs/:id/members
+func (h *TeamHandler) AddMember(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	orgID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid org id")
+	}
+
+	var req AddOrgMemberRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	member, err := h.team.AddMember(c.Context(), userID, orgID, req.Email, domain.OrgRole(req.Role))
+	if err != nil {
+		return teamErrorResponse(c, err, "add org member")
+	}
+	return c.Status(fiber.StatusCreated).JSON(member)
+}
+
+// RemoveMember removes a member, or leaves the org when userId is the caller.
+// DELETE /orgs/:id/members/:userId
+func (h *TeamHandler) RemoveMember(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	orgID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid org id")
+	}
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid user id")
+	}
+
+	if err := h.team.RemoveMember(c.Context(), userID, orgID, memberID); err != nil {
+		return teamErrorResponse(c, err, "remove org member")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListMailboxes returns the mailboxes shared with an org.
+// GET /orgs/:id/mailboxes
+func (h *TeamHandler) ListMailboxes(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	orgID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid org id")
+	}
+
+	mailboxes, err := h.team.ListMailboxes(c.Context(), userID, orgID)
+	if err != nil {
+		return teamErrorResponse(c, err, "list org mailboxes")
+	}
+	return c.JSON(fiber.Map{"mailboxes": mailboxes})
+}
+
+// ShareMailbox shares one of the caller's connections with an org.
+// POST /orgs/:id/mailboxes
+func (h *TeamHandler) ShareMailbox(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	orgID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid org id")
+	}
+
+	var req ShareMailboxRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	mailbox, err := h.team.ShareMailbox(c.Context(), userID, orgID, req.ConnectionID)
+	if err != nil {
+		return teamErrorResponse(c, err, "share mailbox")
+	}
+	return c.Status(fiber.StatusCreated).JSON(mailbox)
+}
+
+// UnshareMailbox stops sharing a mailbox with an org.
+// DELETE /orgs/:id/mailboxes/:connectionId
+func (h *TeamHandler) UnshareMailbox(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	orgID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid org id")
+	}
+	connectionID, err := strconv.ParseInt(c.Params("connectionId"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid connection id")
+	}
+
+	if err := h.team.UnshareMailbox(c.Context(), userID, orgID, connectionID); err != nil {
+		return teamErrorResponse(c, err, "unshare mailbox")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListSLAs returns the response-time SLAs of an org.
+// GET /orgs/:id/slas
+func (h *TeamHandler) ListSLAs(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	orgID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid org id")
+	}
+
+	slas, err := h.team.ListSLAs(c.Context(), userID, orgID)
+	if err != nil {
+		return teamErrorResponse(c, err, "list slas")
+	}
+	return c.JSON(fiber.Map{"slas": slas})
+}
+
+// SetSLA creates or updates the SLA of a category. Owner only.
+// PUT /orgs/:id/slas/:category
+func (h *TeamHandler) SetSLA(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	orgID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid org id")
+	}
+
+	var req OrgSLARequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	sla, err := h.team.SetSLA(c.Context(), userID, orgID, c.Params("category"), &team.SLARequest{
+		ResponseMinutes: req.ResponseMinutes,
+		AtRiskPercent:   req.AtRiskPercent,
+	})
+	if err != nil {
+		return teamErrorResponse(c, err, "set sla")
+	}
+	return c.JSON(sla)
+}
+
+// DeleteSLA deletes the SLA of a category. Owner only.
+// DELETE /orgs/:id/slas/:category
+func (h *TeamHandler) DeleteSLA(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	orgID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid org id")
+	}
+
+	if err := h.team.DeleteSLA(c.Context(), userID, orgID, c.Params("category")); err != nil {
+		return teamErrorResponse(c, err, "delete sla")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListComments returns the comment threads of a shared email.
+// GET /email/:id/comments
+func (h *TeamHandler) ListComments(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	comments, err := h.team.ListComments(c.Context(), userID, emailID)
+	if err != nil {
+		return teamErrorResponse(c, err, "list email comments")
+	}
+	return c.JSON(fiber.Map{"comments": comments})
+}
+
+// AddComment adds a comment or a reply to a shared email.
+// POST /email/:id/comments
+func (h *TeamHandler) AddComment(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	var req EmailCommentRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+	svcReq, err := req.toService()
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid mention id")
+	}
+
+	comment, err := h.team.AddComment(c.Context(), userID, emailID, svcReq)
+	if err != nil {
+		return teamErrorResponse(c, err, "add email comment")
+	}
+	return c.Status(fiber.StatusCreated).JSON(comment)
+}
+
+// UpdateComment edits the caller's comment.
+// PUT /email/:id/comments/:commentId
+func (h *TeamHandler) UpdateComment(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+	commentID, err := strconv.ParseInt(c.Params("commentId"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid comment id")
+	}
+
+	var req EmailCommentRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+	svcReq, err := req.toService()
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid mention id")
+	}
+
+	comment, err := h.team.UpdateComment(c.Context(), userID, emailID, commentID, svcReq)
+	if err != nil {
+		return teamErrorResponse(c, err, "update email comment")
+	}
+	return c.JSON(comment)
+}
+
+// DeleteComment deletes the caller's comment and its replies.
+// DELETE /email/:id/comments/:commentId
+func (h *TeamHandler) DeleteComment(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+	commentID, err := strconv.ParseInt(c.Params("commentId"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid comment id")
+	}
+
+	if err := h.team.DeleteComment(c.Context(), userID, emailID, commentID); err != nil {
+		return teamErrorResponse(c, err, "delete email comment")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetAssignment returns the current assignee of a shared email and its history.
+// GET /email/:id/assignment
+func (h *TeamHandler) GetAssignment(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	view, err := h.team.Assignment(c.Context(), userID, emailID)
+	if err != nil {
+		return teamErrorResponse(c, err, "get email assignment")
+	}
+	return c.JSON(view)
+}
+
+// Assign assigns a shared email to an org member, or unassigns it.
+// 새 담당자와 이전 담당자에게 SSE(email.assigned)로 알린다.
+// POST /email/:id/assign
+func (h *TeamHandler) Assign(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	emailID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid email id")
+	}
+
+	var req AssignEmailRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+	svcReq := &team.AssignRequest{Note: req.Note}
+	if req.AssigneeID != "" {
+		assigneeID, err := uuid.Parse(req.AssigneeID)
+		if err != nil {
+			return ErrorResponse(c, 400, "invalid assignee id")
+		}
+		svcReq.AssigneeID = &assigneeID
+	}
+
+	assignment, err := h.team.Assign(c.Context(), userID, emailID, svcReq)
+	if err != nil {
+		return teamErrorResponse(c, err, "assign email")
+	}
+	return c.JSON(assignment)
+}
+
+func (r *EmailCommentRequest) toService() (*team.CommentRequest, error) {
+	req := &team.CommentRequest{Body: r.Body, ParentID: r.ParentID}
+	for _, s := range r.MentionIDs {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		req.MentionIDs = append(req.MentionIDs, id)
+	}
+	return req, nil
+}
+
+func teamErrorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, team.ErrOrgNotFound),
+		errors.Is(err, team.ErrUserNotFound),
+		errors.Is(err, team.ErrMailboxNotFound),
+		errors.Is(err, team.ErrEmailNotShared),
+		errors.Is(err, team.ErrCommentNotFound),
+		errors.Is(err, team.ErrSLANotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, team.ErrForbidden),
+		errors.Is(err, team.ErrOwnerCannotLeave):
+		return ErrorResponse(c, 403, err.Error())
+	case errors.Is(err, team.ErrInvalidName),
+		errors.Is(err, team.ErrInvalidRole),
+		errors.Is(err, team.ErrCommentEmpty),
+		errors.Is(err, team.ErrCommentTooLong),
+		errors.Is(err, team.ErrInvalidMentionID),
+		errors.Is(err, team.ErrInvalidAssignee),
+		errors.Is(err, team.ErrNoteTooLong),
+		errors.Is(err, team.ErrInvalidSLACategory),
+		errors.Is(err, team.ErrInvalidSLAMinutes),
+		errors.Is(err, team.ErrInvalidSLAPercent):
+		return ErrorResponse(c, 400, err.Error())
+	case errors.Is(err, team.ErrAssignmentsDisabled):
+		return NotConfiguredResponse(c, "email assignment")
+	case errors.Is(err, team.ErrSLADisabled):
+		return NotConfiguredResponse(c, "shared inbox sla")
+	}
+	return InternalErrorResponse(c, err, operation)
+}
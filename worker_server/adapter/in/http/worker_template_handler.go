@@ -35,6 +35,7 @@ func (h *TemplateHandler) Register(router fiber.Router) {
 	templates.Put("/:id", h.Update)
 	templates.Delete("/:id", h.Delete)
 	templates.Post("/:id/use", h.UseTemplate)
+	templates.Post("/:id/render", h.Render)
 	templates.Post("/:id/default", h.SetDefault)
 	templates.Post("/:id/archive", h.Archive)
 	templates.Post("/:id/restore", h.Restore)
@@ -114,8 +115,8 @@ func (h *TemplateHandler) Create(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uuid.UUID)
 
 	var req CreateTemplateRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	template, err := h.service.Create(c.Context(), userID, &service.CreateTemplateRequest{
@@ -153,8 +154,8 @@ func (h *TemplateHandler) Update(c *fiber.Ctx) error {
 	}
 
 	var req UpdateTemplateRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	template, err := h.service.Update(c.Context(), userID, &service.UpdateTemplateRequest{
@@ -432,6 +433,45 @@ func (h *TemplateHandler) UseTemplate(c *fiber.Ctx) error {
 		"subject":   rendered.Subject,
 		"body":      rendered.Body,
 		"html_body": rendered.HTMLBody,
+		"missing":   rendered.Missing,
+	})
+}
+
+// Render previews a template with variables without counting it as used
+// @Summary Render a template preview
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param request body UseTemplateRequest true "Variables"
+// @Success 200 {object} map[string]interface{}
+// @Router /api/v1/templates/{id}/render [post]
+func (h *TemplateHandler) Render(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(uuid.UUID)
+
+	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid template ID"})
+	}
+
+	var req UseTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		req.Variables = make(map[string]string)
+	}
+
+	rendered, err := h.service.Render(c.Context(), userID, id, req.Variables)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.Status(404).JSON(fiber.Map{"error": "template not found"})
+		}
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"subject":   rendered.Subject,
+		"body":      rendered.Body,
+		"html_body": rendered.HTMLBody,
+		"missing":   rendered.Missing,
 	})
 }
 
@@ -448,8 +488,8 @@ func (h *TemplateHandler) DeleteBatch(c *fiber.Ctx) error {
 	var req struct {
 		IDs []int64 `json:"ids"`
 	}
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.service.DeleteBatch(c.Context(), userID, req.IDs); err != nil {
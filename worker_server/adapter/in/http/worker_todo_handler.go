@@ -145,12 +145,8 @@ func (h *TodoHandler) Create(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uuid.UUID)
 
 	var req in.CreateTodoRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
-	}
-
-	if req.Title == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "title is required"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	todo, err := h.service.CreateTodo(c.Context(), userID, &req)
@@ -205,8 +201,8 @@ func (h *TodoHandler) Update(c *fiber.Ctx) error {
 	}
 
 	var req in.UpdateTodoRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	todo, err := h.service.UpdateTodo(c.Context(), userID, id, &req)
@@ -315,8 +311,8 @@ func (h *TodoHandler) UpdateStatus(c *fiber.Ctx) error {
 	var req struct {
 		Status string `json:"status"`
 	}
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.service.UpdateStatus(c.Context(), userID, id, domain.TodoStatus(req.Status)); err != nil {
@@ -346,8 +342,8 @@ func (h *TodoHandler) BatchComplete(c *fiber.Ctx) error {
 	var req struct {
 		IDs []int64 `json:"ids"`
 	}
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.service.CompleteTodos(c.Context(), userID, req.IDs); err != nil {
@@ -370,8 +366,8 @@ func (h *TodoHandler) BatchDelete(c *fiber.Ctx) error {
 	var req struct {
 		IDs []int64 `json:"ids"`
 	}
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.service.DeleteTodos(c.Context(), userID, req.IDs); err != nil {
@@ -533,12 +529,8 @@ func (h *TodoHandler) CreateFromEmail(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uuid.UUID)
 
 	var req in.CreateTodoFromEmailRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
-	}
-
-	if req.EmailID == 0 || req.Title == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "email_id and title are required"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	todo, err := h.service.CreateFromEmail(c.Context(), userID, &req)
@@ -561,12 +553,8 @@ func (h *TodoHandler) CreateFromCalendar(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uuid.UUID)
 
 	var req in.CreateTodoFromCalendarRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
-	}
-
-	if req.EventID == 0 || req.Title == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "event_id and title are required"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	todo, err := h.service.CreateFromCalendar(c.Context(), userID, &req)
@@ -589,12 +577,8 @@ func (h *TodoHandler) CreateFromAgent(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uuid.UUID)
 
 	var req in.CreateTodoFromAgentRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
-	}
-
-	if req.Title == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "title is required"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	todo, err := h.service.CreateFromAgent(c.Context(), userID, &req)
@@ -650,12 +634,8 @@ func (h *TodoHandler) AddSubtask(c *fiber.Ctx) error {
 	}
 
 	var req in.CreateTodoRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
-	}
-
-	if req.Title == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "title is required"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	subtask, err := h.service.AddSubtask(c.Context(), userID, id, &req)
@@ -720,12 +700,8 @@ func (h *TodoHandler) CreateProject(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(uuid.UUID)
 
 	var req in.CreateProjectRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
-	}
-
-	if req.Name == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "name is required"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	project, err := h.service.CreateProject(c.Context(), userID, &req)
@@ -780,8 +756,8 @@ func (h *TodoHandler) UpdateProject(c *fiber.Ctx) error {
 	}
 
 	var req in.UpdateProjectRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request body"})
+	if err := BindBody(c, &req); err != nil {
+		return err
 	}
 
 	project, err := h.service.UpdateProject(c.Context(), userID, id, &req)
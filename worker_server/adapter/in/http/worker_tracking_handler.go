@@ -0,0 +1,48 @@
+package http
+
+import (
+	"worker_server/core/service/tracking"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TrackingHandler serves open pixels and click redirects of tracked sent mail.
+type TrackingHandler struct {
+	tracking *tracking.Service
+}
+
+// NewTrackingHandler creates a new TrackingHandler.
+func NewTrackingHandler(tracking *tracking.Service) *TrackingHandler {
+	return &TrackingHandler{tracking: tracking}
+}
+
+// RegisterPublic registers tracking routes (no auth - 수신자 메일 클라이언트가 요청).
+// 토큰 서명으로 생성된 링크만 리다이렉트되므로 open redirect가 되지 않는다.
+func (h *TrackingHandler) RegisterPublic(app fiber.Router) {
+	app.Get(tracking.OpenPath, h.Open)
+	app.Get(tracking.ClickPath, h.Click)
+}
+
+// Open records an open and returns a transparent pixel.
+// 토큰이 잘못되어도 항상 픽셀을 반환한다 (메일 본문에 깨진 이미지 표시 방지).
+// GET /api/v1/track/o?t=<token>
+func (h *TrackingHandler) Open(c *fiber.Ctx) error {
+	h.tracking.RecordOpen(c.Context(), c.Query("t"), c.Get(fiber.HeaderUserAgent))
+
+	c.Set("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0")
+	c.Set("Content-Type", "image/gif")
+	return c.Send(tracking.Pixel)
+}
+
+// Click records a click and redirects to the original link.
+// GET /api/v1/track/c?t=<token>
+func (h *TrackingHandler) Click(c *fiber.Ctx) error {
+	target, err := h.tracking.RecordClick(c.Context(), c.Query("t"), c.Get(fiber.HeaderUserAgent))
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid link")
+	}
+
+	c.Set("Cache-Control", "private, no-store")
+	c.Set("Referrer-Policy", "no-referrer")
+	return c.Redirect(target, fiber.StatusFound)
+}
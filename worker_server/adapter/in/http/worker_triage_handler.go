@@ -0,0 +1,116 @@
+package http
+
+import (
+	"errors"
+
+	"worker_server/adapter/out/persistence"
+	"worker_server/core/service/email"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// TriageHandler handles the inbox zero assistant (bulk clean-up proposals).
+type TriageHandler struct {
+	triageService *mail.TriageService
+}
+
+// NewTriageHandler creates a new TriageHandler.
+func NewTriageHandler(triageService *mail.TriageService) *TriageHandler {
+	return &TriageHandler{triageService: triageService}
+}
+
+// Register registers inbox triage routes.
+func (h *TriageHandler) Register(router fiber.Router) {
+	triage := router.Group("/inbox/triage")
+
+	triage.Post("/", h.Propose)
+	triage.Get("/:id", h.Get)              // 제안 + 실행 결과
+	triage.Post("/:id/confirm", h.Confirm) // 확인 후 워커에서 실행
+}
+
+// ConfirmTriageRequest selects the actions to run. 비어 있으면 제안된 작업을 모두 실행한다.
+type ConfirmTriageRequest struct {
+	ActionIDs []string `json:"action_ids" validate:"max=10"`
+}
+
+// Propose scans the inbox and proposes bulk actions.
+// POST /inbox/triage?connection_id=123
+func (h *TriageHandler) Propose(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	triage, err := h.triageService.Propose(c.Context(), userID, GetConnectionID(c))
+	if err != nil {
+		return InternalErrorResponse(c, err, "propose inbox triage")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"triage":       triage,
+		"total_emails": triage.TotalEmails(),
+	})
+}
+
+// Get returns a triage proposal with its results once executed.
+// GET /inbox/triage/:id
+func (h *TriageHandler) Get(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid triage id")
+	}
+
+	triage, err := h.triageService.GetTriage(c.Context(), userID, id)
+	if err != nil {
+		return h.errorResponse(c, err, "get inbox triage")
+	}
+	return c.JSON(triage)
+}
+
+// Confirm queues the proposal for execution by the worker.
+// POST /inbox/triage/:id/confirm
+// Body (optional): { "action_ids": ["stale_newsletters", "old_notifications"] }
+func (h *TriageHandler) Confirm(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid triage id")
+	}
+
+	var req ConfirmTriageRequest
+	if len(c.Body()) > 0 {
+		if err := BindBody(c, &req); err != nil {
+			return err
+		}
+	}
+
+	triage, err := h.triageService.Confirm(c.Context(), userID, id, req.ActionIDs)
+	if err != nil {
+		return h.errorResponse(c, err, "confirm inbox triage")
+	}
+	return c.Status(fiber.StatusAccepted).JSON(triage)
+}
+
+func (h *TriageHandler) errorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, persistence.ErrNotFound):
+		return ErrorResponse(c, 404, "triage not found")
+	case errors.Is(err, mail.ErrTriageAlreadyConfirmed):
+		return ErrorResponse(c, 409, err.Error())
+	case errors.Is(err, mail.ErrTriageExpired):
+		return ErrorResponse(c, 410, err.Error())
+	case errors.Is(err, mail.ErrTriageNothingToDo), errors.Is(err, mail.ErrTriageUnknownAction):
+		return ErrorResponse(c, 400, err.Error())
+	}
+	return InternalErrorResponse(c, err, operation)
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"time"
+
+	"worker_server/core/service/usage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UsageHandler exposes AI token usage and the monthly budget.
+type UsageHandler struct {
+	usage *usage.Service
+}
+
+// NewUsageHandler creates a new UsageHandler.
+func NewUsageHandler(usage *usage.Service) *UsageHandler {
+	return &UsageHandler{usage: usage}
+}
+
+// Register registers usage routes.
+func (h *UsageHandler) Register(router fiber.Router) {
+	router.Get("/usage/ai", h.GetAIUsage)
+}
+
+// GetAIUsage returns the user's LLM/embedding usage by task, connection and model.
+// GET /usage/ai?period=2026-10 (기본값: 이번 달)
+func (h *UsageHandler) GetAIUsage(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	period := c.Query("period")
+	if period != "" {
+		if _, err := time.Parse("2006-01", period); err != nil {
+			return ErrorResponse(c, 400, "invalid period, expected YYYY-MM")
+		}
+	}
+
+	summary, err := h.usage.Summary(c.Context(), userID, period)
+	if err != nil {
+		return InternalErrorResponse(c, err, "get ai usage")
+	}
+
+	return c.JSON(summary)
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"worker_server/core/service/vacation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// VacationHandler handles out-of-office responders per connection.
+type VacationHandler struct {
+	vacation *vacation.Service
+}
+
+// NewVacationHandler creates a new VacationHandler.
+func NewVacationHandler(vacation *vacation.Service) *VacationHandler {
+	return &VacationHandler{vacation: vacation}
+}
+
+// Register registers vacation responder routes.
+func (h *VacationHandler) Register(router fiber.Router) {
+	connections := router.Group("/connections")
+
+	connections.Get("/:id/vacation", h.Get)
+	connections.Put("/:id/vacation", h.Update)
+}
+
+// UpdateVacationRequest represents the HTTP request to set a vacation responder.
+type UpdateVacationRequest struct {
+	Enabled bool       `json:"enabled"`
+	Subject string     `json:"subject"`
+	Body    string     `json:"body"`
+	IsHTML  bool       `json:"is_html"`
+	StartAt *time.Time `json:"start_at"`
+	EndAt   *time.Time `json:"end_at"`
+}
+
+// Get returns the vacation responder of a connection.
+// GET /connections/:id/vacation
+func (h *VacationHandler) Get(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	connectionID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid connection id")
+	}
+
+	responder, err := h.vacation.Get(c.Context(), userID, connectionID)
+	if err != nil {
+		return h.errorResponse(c, err, "get vacation responder")
+	}
+
+	return c.JSON(responder)
+}
+
+// Update sets the vacation responder of a connection.
+// Gmail/Outlook은 Provider 설정을 변경하고(mode=native), 그 외에는 동기화 중 자동 응답한다(mode=auto_reply).
+// PUT /connections/:id/vacation
+func (h *VacationHandler) Update(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	connectionID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid connection id")
+	}
+
+	var req UpdateVacationRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	responder, err := h.vacation.Update(c.Context(), userID, connectionID, &vacation.UpdateRequest{
+		Enabled: req.Enabled,
+		Subject: req.Subject,
+		Body:    req.Body,
+		IsHTML:  req.IsHTML,
+		StartAt: req.StartAt,
+		EndAt:   req.EndAt,
+	})
+	if err != nil {
+		return h.errorResponse(c, err, "update vacation responder")
+	}
+
+	return c.JSON(responder)
+}
+
+func (h *VacationHandler) errorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, vacation.ErrConnectionNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, vacation.ErrEmptyMessage),
+		errors.Is(err, vacation.ErrMessageTooLong),
+		errors.Is(err, vacation.ErrInvalidSchedule),
+		errors.Is(err, vacation.ErrUnsupportedProvider):
+		return ErrorResponse(c, 400, err.Error())
+	}
+	return InternalErrorResponse(c, err, operation)
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"worker_server/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// =============================================================================
+// Gmail Push Gate - historyId 중복 제거 + 단조 증가 게이트
+// =============================================================================
+//
+// Pub/Sub은 같은 historyId를 여러 번, 또는 순서가 뒤바뀐 채로 보낼 수 있다.
+// DeltaSync는 항상 저장된 historyId부터 최신까지 가져오므로, 이미 받아들인 것보다
+// 작거나 같은 historyId는 앞선(또는 대기 중인) 동기화가 처리한다 → 건너뛴다.
+
+const (
+	// SeenHistoryTTL - 연결별로 본 historyId를 기억하는 시간
+	SeenHistoryTTL = 10 * time.Minute
+	// HistoryHighWaterTTL - 받아들인 최대 historyId를 기억하는 시간 (만료 후에는 sync_states로 판단)
+	HistoryHighWaterTTL = time.Hour
+)
+
+type historyGateResult int
+
+const (
+	historyAccepted  historyGateResult = iota
+	historyDuplicate                   // 이미 본 historyId
+	historyStale                       // 받아들인 historyId보다 오래됨
+)
+
+// historyGateScript records historyId in the seen zset and raises the high-water mark.
+// seen은 본 시각을 점수로 둔 ZSET이라 SeenHistoryTTL이 지난 항목은 매번 잘라낸다
+// (키 EXPIRE는 알림이 계속 오면 갱신되므로 항목 만료에 쓸 수 없다).
+// KEYS[1]=seen zset, KEYS[2]=high-water; ARGV[1]=historyId, ARGV[2]=now(unix s), ARGV[3]=seen TTL(s), ARGV[4]=high-water TTL(s)
+// 0 = accepted, 1 = duplicate, 2 = stale
+var historyGateScript = redis.NewScript(`
+	redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', tonumber(ARGV[2]) - tonumber(ARGV[3]))
+	if redis.call('ZSCORE', KEYS[1], ARGV[1]) then
+		return 1
+	end
+	redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+	redis.call('EXPIRE', KEYS[1], ARGV[3])
+	local hw = tonumber(redis.call('GET', KEYS[2]) or '0')
+	if tonumber(ARGV[1]) <= hw then
+		return 2
+	end
+	redis.call('SET', KEYS[2], ARGV[1], 'EX', ARGV[4])
+	return 0
+`)
+
+// historySeenKey - ZSET (이전 SET 키와 타입이 달라 이름을 바꿨다)
+func (h *WebhookHandler) historySeenKey(connID int64) string {
+	return fmt.Sprintf("webhook:history:seen_at:%d", connID)
+}
+
+func (h *WebhookHandler) historyHighWaterKey(connID int64) string {
+	return fmt.Sprintf("webhook:history:hw:%d", connID)
+}
+
+// gateGmailHistory decides whether a Gmail notification needs a DeltaSync.
+// Redis를 못 쓰면 sync_states의 historyId만으로 판단한다 (보수적으로 받아들임).
+func (h *WebhookHandler) gateGmailHistory(ctx context.Context, connID int64, historyID uint64) historyGateResult {
+	result := historyAccepted
+	if h.redis != nil {
+		code, err := historyGateScript.Run(ctx, h.redis,
+			[]string{h.historySeenKey(connID), h.historyHighWaterKey(connID)},
+			historyID, time.Now().Unix(), int(SeenHistoryTTL.Seconds()), int(HistoryHighWaterTTL.Seconds()),
+		).Int()
+		if err != nil {
+			logger.WithError(err).Warn("[GmailWebhook] History gate unavailable: conn=%d", connID)
+		} else {
+			result = historyGateResult(code)
+		}
+	}
+
+	// 이미 동기화된 historyId (high-water가 만료됐거나 Redis가 없을 때)
+	if result == historyAccepted && h.syncRepo != nil {
+		if state, err := h.syncRepo.GetByConnectionID(ctx, connID); err == nil && state != nil && historyID <= state.HistoryID {
+			result = historyStale
+		}
+	}
+
+	switch result {
+	case historyDuplicate:
+		atomic.AddInt64(&h.metrics.Duplicates, 1)
+	case historyStale:
+		atomic.AddInt64(&h.metrics.Stale, 1)
+	}
+	return result
+}
+
+// resetHistoryHighWater forgets the high-water mark when the DeltaSync it stood for was never enqueued,
+// 그래야 뒤이어 오는 작은 historyId가 건너뛰어지지 않는다.
+func (h *WebhookHandler) resetHistoryHighWater(ctx context.Context, connID int64) {
+	if h.redis == nil {
+		return
+	}
+	_ = h.redis.Del(ctx, h.historyHighWaterKey(connID))
+}
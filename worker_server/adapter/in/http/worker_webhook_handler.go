@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"sync/atomic"
 	"time"
@@ -28,6 +29,7 @@ const (
 type WebhookMetrics struct {
 	Processed  int64
 	Duplicates int64
+	Stale      int64 // 받아들인 historyId보다 오래된 알림 (앞선 DeltaSync가 처리)
 	Errors     int64
 	Queued     int64
 	Direct     int64
@@ -73,6 +75,7 @@ func (h *WebhookHandler) GetMetrics() WebhookMetrics {
 	return WebhookMetrics{
 		Processed:  atomic.LoadInt64(&h.metrics.Processed),
 		Duplicates: atomic.LoadInt64(&h.metrics.Duplicates),
+		Stale:      atomic.LoadInt64(&h.metrics.Stale),
 		Errors:     atomic.LoadInt64(&h.metrics.Errors),
 		Queued:     atomic.LoadInt64(&h.metrics.Queued),
 		Direct:     atomic.LoadInt64(&h.metrics.Direct),
@@ -249,7 +252,7 @@ func (h *WebhookHandler) DisableWebhook(c *fiber.Ctx) error {
 func (h *WebhookHandler) GetWebhookMetrics(c *fiber.Ctx) error {
 	m := h.GetMetrics()
 	return c.JSON(fiber.Map{
-		"processed": m.Processed, "duplicates": m.Duplicates,
+		"processed": m.Processed, "duplicates": m.Duplicates, "stale": m.Stale,
 		"errors": m.Errors, "queued": m.Queued, "direct": m.Direct,
 	})
 }
@@ -310,9 +313,13 @@ func (h *WebhookHandler) GmailWebhook(c *fiber.Ctx) error {
 		return c.SendStatus(fiber.StatusOK)
 	}
 
-	if h.checkIdempotency(ctx, "google", conn.ID, notificationData.HistoryID) {
+	switch h.gateGmailHistory(ctx, conn.ID, notificationData.HistoryID) {
+	case historyDuplicate:
 		logger.Debug("[GmailWebhook] Duplicate skipped: conn=%d, historyId=%d", conn.ID, notificationData.HistoryID)
 		return c.SendStatus(fiber.StatusOK)
+	case historyStale:
+		logger.Debug("[GmailWebhook] Stale skipped: conn=%d, historyId=%d", conn.ID, notificationData.HistoryID)
+		return c.SendStatus(fiber.StatusOK)
 	}
 
 	if !h.acquireSyncLock(ctx, conn.ID) {
@@ -342,6 +349,7 @@ func (h *WebhookHandler) GmailWebhook(c *fiber.Ctx) error {
 		logger.Error("[GmailWebhook] No sync service or producer available")
 		atomic.AddInt64(&h.metrics.Errors, 1)
 		h.releaseSyncLock(ctx, conn.ID)
+		h.resetHistoryHighWater(ctx, conn.ID)
 	}
 
 	return c.SendStatus(fiber.StatusOK)
@@ -356,7 +364,10 @@ func (h *WebhookHandler) processGmailDirect(ctx context.Context, connID int64, u
 		syncCtx, cancel := context.WithTimeout(context.Background(), SyncLockTTL)
 		defer cancel()
 
-		if err := h.mailSyncService.DeltaSync(syncCtx, connID, historyID); err != nil {
+		if err := h.mailSyncService.DeltaSync(syncCtx, connID, historyID); errors.Is(err, mail.ErrSyncInProgress) {
+			// 다른 워커가 이 연결을 동기화 중 - 진행 중인 동기화가 완료 이벤트를 보낸다
+			logger.Info("[GmailWebhook] Sync in progress on another worker, skipped: conn=%d", connID)
+		} else if err != nil {
 			logger.WithError(err).Error("[GmailWebhook] DeltaSync failed: conn=%d", connID)
 			atomic.AddInt64(&h.metrics.Errors, 1)
 			if h.realtime != nil {
@@ -400,6 +411,7 @@ func (h *WebhookHandler) processGmailQueued(ctx context.Context, connID int64, u
 		logger.WithError(err).Error("[GmailWebhook] Failed to publish: conn=%d", connID)
 		atomic.AddInt64(&h.metrics.Errors, 1)
 		h.releaseSyncLock(ctx, connID)
+		h.resetHistoryHighWater(ctx, connID)
 	} else {
 		logger.Info("[GmailWebhook] Queued: conn=%d, historyId=%d", connID, historyID)
 	}
@@ -0,0 +1,215 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+
+	"worker_server/core/domain"
+	"worker_server/core/service/workflow"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// WorkflowHandler handles the kanban workflow board.
+type WorkflowHandler struct {
+	workflow *workflow.Service
+}
+
+// NewWorkflowHandler creates a new WorkflowHandler.
+func NewWorkflowHandler(workflow *workflow.Service) *WorkflowHandler {
+	return &WorkflowHandler{workflow: workflow}
+}
+
+// Register registers workflow board routes.
+func (h *WorkflowHandler) Register(router fiber.Router) {
+	wf := router.Group("/workflow")
+
+	wf.Get("/board", h.Board)
+	wf.Get("/stages", h.ListStages)
+	wf.Post("/stages", h.CreateStage)
+	wf.Put("/stages/order", h.ReorderStages) // /:id보다 먼저 등록
+	wf.Put("/stages/:id", h.UpdateStage)
+	wf.Delete("/stages/:id", h.DeleteStage)
+	wf.Put("/stages/:id/cards", h.MoveCards) // 드래그 앤 드롭 결과 저장
+}
+
+// WorkflowStageRequest represents the HTTP request to create or update a stage.
+type WorkflowStageRequest struct {
+	Name   string `json:"name" validate:"required,max=50"`
+	Color  string `json:"color,omitempty"`
+	Status string `json:"status,omitempty"` // todo (default), done
+}
+
+// ReorderStagesRequest lists every stage ID in the new column order.
+type ReorderStagesRequest struct {
+	StageIDs []int64 `json:"stage_ids" validate:"required"`
+}
+
+// MoveCardsRequest lists the emails of a column in their new order after a drop.
+type MoveCardsRequest struct {
+	EmailIDs []int64 `json:"email_ids" validate:"required,max=500"`
+}
+
+// Board returns every stage with its emails.
+// GET /workflow/board?connection_id=1&limit=50
+func (h *WorkflowHandler) Board(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	board, err := h.workflow.Board(c.Context(), userID, GetConnectionID(c), c.QueryInt("limit", 0))
+	if err != nil {
+		return InternalErrorResponse(c, err, "get workflow board")
+	}
+	return c.JSON(board)
+}
+
+// ListStages returns the stages in board order.
+// GET /workflow/stages
+func (h *WorkflowHandler) ListStages(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	stages, err := h.workflow.ListStages(c.Context(), userID)
+	if err != nil {
+		return InternalErrorResponse(c, err, "list workflow stages")
+	}
+	return c.JSON(fiber.Map{"stages": stages})
+}
+
+// CreateStage appends a stage to the board.
+// POST /workflow/stages
+func (h *WorkflowHandler) CreateStage(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req WorkflowStageRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	stage, err := h.workflow.CreateStage(c.Context(), userID, req.toService())
+	if err != nil {
+		return h.errorResponse(c, err, "create workflow stage")
+	}
+	return c.Status(fiber.StatusCreated).JSON(stage)
+}
+
+// UpdateStage renames, recolors or remaps a stage to another status.
+// PUT /workflow/stages/:id
+func (h *WorkflowHandler) UpdateStage(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	stageID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid stage id")
+	}
+
+	var req WorkflowStageRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	stage, err := h.workflow.UpdateStage(c.Context(), userID, stageID, req.toService())
+	if err != nil {
+		return h.errorResponse(c, err, "update workflow stage")
+	}
+	return c.JSON(stage)
+}
+
+// DeleteStage deletes a stage. Its emails fall back to the first stage of the same status.
+// DELETE /workflow/stages/:id
+func (h *WorkflowHandler) DeleteStage(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	stageID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid stage id")
+	}
+
+	if err := h.workflow.DeleteStage(c.Context(), userID, stageID); err != nil {
+		return h.errorResponse(c, err, "delete workflow stage")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ReorderStages saves the column order.
+// PUT /workflow/stages/order
+func (h *WorkflowHandler) ReorderStages(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	var req ReorderStagesRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	stages, err := h.workflow.ReorderStages(c.Context(), userID, req.StageIDs)
+	if err != nil {
+		return h.errorResponse(c, err, "reorder workflow stages")
+	}
+	return c.JSON(fiber.Map{"stages": stages})
+}
+
+// MoveCards saves the order of a column after a drag and drop.
+// 다른 상태의 단계로 옮긴 메일은 workflow_status도 바뀐다.
+// PUT /workflow/stages/:id/cards
+func (h *WorkflowHandler) MoveCards(c *fiber.Ctx) error {
+	userID, err := GetUserID(c)
+	if err != nil {
+		return ErrorResponse(c, 401, "unauthorized")
+	}
+
+	stageID, err := strconv.ParseInt(c.Params("id"), 10, 64)
+	if err != nil {
+		return ErrorResponse(c, 400, "invalid stage id")
+	}
+
+	var req MoveCardsRequest
+	if err := BindBody(c, &req); err != nil {
+		return err
+	}
+
+	placed, err := h.workflow.MoveCards(c.Context(), userID, stageID, req.EmailIDs)
+	if err != nil {
+		return h.errorResponse(c, err, "move workflow cards")
+	}
+	return c.JSON(fiber.Map{"status": "ok", "stage_id": stageID, "email_ids": placed})
+}
+
+func (r *WorkflowStageRequest) toService() *workflow.StageRequest {
+	return &workflow.StageRequest{
+		Name:   r.Name,
+		Color:  r.Color,
+		Status: domain.WorkflowStatus(r.Status),
+	}
+}
+
+func (h *WorkflowHandler) errorResponse(c *fiber.Ctx, err error, operation string) error {
+	switch {
+	case errors.Is(err, workflow.ErrStageNotFound):
+		return ErrorResponse(c, 404, err.Error())
+	case errors.Is(err, workflow.ErrInvalidName),
+		errors.Is(err, workflow.ErrInvalidStatus),
+		errors.Is(err, workflow.ErrInvalidColor),
+		errors.Is(err, workflow.ErrTooManyStages),
+		errors.Is(err, workflow.ErrLastStage),
+		errors.Is(err, workflow.ErrInvalidOrder),
+		errors.Is(err, workflow.ErrTooManyCards):
+		return ErrorResponse(c, 400, err.Error())
+	}
+	return InternalErrorResponse(c, err, operation)
+}
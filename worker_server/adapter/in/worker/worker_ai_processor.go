@@ -6,10 +6,16 @@ import (
 	"sync"
 	"time"
 
+	"worker_server/core/agent/llm"
 	"worker_server/core/domain"
 	"worker_server/core/port/out"
 	"worker_server/core/service/ai"
+	"worker_server/core/service/classification"
+	"worker_server/core/service/job"
+	"worker_server/core/service/usage"
 	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
 )
 
 // =============================================================================
@@ -29,17 +35,28 @@ type AIProcessor struct {
 	emailRepo         out.EmailRepository
 	realtime         out.RealtimePort
 
-	// Batch accumulator
-	classifyBatch  []int64
-	summarizeBatch []int64
-	batchMu        sync.Mutex
-	batchSize      int
-	batchTimeout   time.Duration
-	lastBatchTime  time.Time
+	// Batch accumulator (분류는 사용자별로 모아 한 번의 LLM 요청으로 처리)
+	classifyBatch     map[uuid.UUID][]int64
+	summarizeBatch    []int64
+	batchMu           sync.Mutex
+	batchSize         int
+	classifyBatchSize int
+	batchTimeout      time.Duration
+	lastBatchTime     time.Time
 
 	// Processing control
 	processingMu sync.Mutex
 	isProcessing bool
+
+	// Job tracking (재분류 요청): emailID -> jobID, batchMu로 보호
+	jobs         *job.Service
+	classifyJobs map[int64]string
+
+	// AI 월 예산 (nil = 제한 없음): 초과 시 요약 작업을 건너뛴다
+	usage *usage.Service
+
+	// 분류 시도 이력 (nil = 기록 안 함, ai_status는 그대로 갱신)
+	attempts out.ClassifyAttemptRepository
 }
 
 // NewAIProcessor creates a new AI processor.
@@ -47,12 +64,13 @@ func NewAIProcessor(aiService *ai.Service, emailRepo out.EmailRepository, realti
 	p := &AIProcessor{
 		aiService:      aiService,
 		emailRepo:       emailRepo,
-		realtime:       realtime,
-		classifyBatch:  make([]int64, 0, 10),
-		summarizeBatch: make([]int64, 0, 10),
-		batchSize:      10,              // 10개씩 배치 처리
-		batchTimeout:   3 * time.Second, // 최대 3초 대기
-		lastBatchTime:  time.Now(),
+		realtime:          realtime,
+		classifyBatch:     make(map[uuid.UUID][]int64),
+		summarizeBatch:    make([]int64, 0, 10),
+		batchSize:         10,              // 10개씩 배치 처리
+		classifyBatchSize: 20,              // 사용자별 20개 = LLM 배치 요청 1회
+		batchTimeout:      3 * time.Second, // 최대 3초 대기
+		lastBatchTime:     time.Now(),
 	}
 
 	// Start batch flush goroutine
@@ -66,12 +84,13 @@ func NewAIProcessorOptimized(optimizedService *ai.OptimizedService, emailRepo ou
 	p := &AIProcessor{
 		optimizedService: optimizedService,
 		emailRepo:         emailRepo,
-		realtime:         realtime,
-		classifyBatch:    make([]int64, 0, 10),
-		summarizeBatch:   make([]int64, 0, 10),
-		batchSize:        10,
-		batchTimeout:     3 * time.Second,
-		lastBatchTime:    time.Now(),
+		realtime:          realtime,
+		classifyBatch:     make(map[uuid.UUID][]int64),
+		summarizeBatch:    make([]int64, 0, 10),
+		batchSize:         10,
+		classifyBatchSize: 20,
+		batchTimeout:      3 * time.Second,
+		lastBatchTime:     time.Now(),
 	}
 
 	go p.batchFlusher()
@@ -79,61 +98,85 @@ func NewAIProcessorOptimized(optimizedService *ai.OptimizedService, emailRepo ou
 	return p
 }
 
+// SetJobService enables progress tracking for reclassify jobs.
+func (p *AIProcessor) SetJobService(jobs *job.Service) {
+	p.batchMu.Lock()
+	defer p.batchMu.Unlock()
+	p.jobs = jobs
+	p.classifyJobs = make(map[int64]string)
+}
+
+// SetUsageService enables monthly AI budget checks for non-essential jobs.
+func (p *AIProcessor) SetUsageService(u *usage.Service) {
+	p.usage = u
+}
+
+// SetClassifyAttemptRepository enables per-email classification attempt history.
+func (p *AIProcessor) SetClassifyAttemptRepository(repo out.ClassifyAttemptRepository) {
+	p.attempts = repo
+}
+
 // ProcessClassify handles single classify job - accumulates for batch
 func (p *AIProcessor) ProcessClassify(ctx context.Context, msg *Message) error {
+	// ai:classify 스트림에는 AIBatchClassifyJob(email_ids)도 발행된다
+	if _, ok := msg.Payload["email_ids"]; ok {
+		return p.ProcessClassifyBatch(ctx, msg)
+	}
+
 	payload, err := ParsePayload[AIClassifyPayload](msg)
 	if err != nil {
 		return fmt.Errorf("failed to parse payload: %w", err)
 	}
 
+	// 실패 재처리 (모델 지정/heuristic)는 배치에 섞지 않고 바로 분류
+	if payload.Mode != "" || payload.Model != "" {
+		return p.reprocessClassify(ctx, payload)
+	}
+
 	// Skip check for optimized service
 	if p.optimizedService != nil {
 		if skip, _ := p.optimizedService.ShouldSkipClassification(ctx, payload.EmailID); skip {
+			if p.jobs != nil {
+				p.jobs.Progress(ctx, payload.JobID, 1, 0)
+			}
 			return nil
 		}
 	}
 
 	// Add to batch
-	p.batchMu.Lock()
-	p.classifyBatch = append(p.classifyBatch, payload.EmailID)
-	shouldProcess := len(p.classifyBatch) >= p.batchSize
-	p.batchMu.Unlock()
-
-	if shouldProcess {
-		p.flushClassifyBatch(ctx)
-	}
-
+	p.enqueueClassify(ctx, payload.UserID, []int64{payload.EmailID}, payload.JobID)
 	return nil
 }
 
-// ProcessClassifyBatch handles batch classify job
+// ProcessClassifyBatch handles batch classify job - accumulates into the user's batch
 func (p *AIProcessor) ProcessClassifyBatch(ctx context.Context, msg *Message) error {
 	payload, err := ParsePayload[AIClassifyBatchPayload](msg)
 	if err != nil {
 		return fmt.Errorf("failed to parse payload: %w", err)
 	}
-
-	log := logger.WithFields(map[string]any{
-		"job":   "ai.classify_batch",
-		"count": len(payload.EmailIDs),
-	})
-
-	var results []*domain.ClassificationResult
-	if p.optimizedService != nil {
-		results, err = p.optimizedService.ClassifyEmailBatch(ctx, payload.EmailIDs)
-	} else if p.aiService != nil {
-		results, err = p.aiService.ClassifyEmailBatch(ctx, payload.EmailIDs)
-	} else {
+	if p.optimizedService == nil && p.aiService == nil {
 		return fmt.Errorf("AI service not initialized")
 	}
 
-	if err != nil {
-		log.WithError(err).Error("batch classify failed")
-		return fmt.Errorf("batch classify failed: %w", err)
+	p.enqueueClassify(ctx, payload.UserID, payload.EmailIDs, "")
+	return nil
+}
+
+// enqueueClassify adds emails to the user's pending batch and flushes it once full.
+func (p *AIProcessor) enqueueClassify(ctx context.Context, userID uuid.UUID, emailIDs []int64, jobID string) {
+	p.batchMu.Lock()
+	p.classifyBatch[userID] = append(p.classifyBatch[userID], emailIDs...)
+	if p.jobs != nil && jobID != "" {
+		for _, emailID := range emailIDs {
+			p.classifyJobs[emailID] = jobID
+		}
 	}
+	shouldProcess := len(p.classifyBatch[userID]) >= p.classifyBatchSize
+	p.batchMu.Unlock()
 
-	log.WithField("classified", len(results)).Debug("batch classified")
-	return nil
+	if shouldProcess {
+		p.flushClassifyBatch(ctx, userID)
+	}
 }
 
 // ProcessSummarize handles summarize job
@@ -143,6 +186,15 @@ func (p *AIProcessor) ProcessSummarize(ctx context.Context, msg *Message) error
 		return fmt.Errorf("failed to parse payload: %w", err)
 	}
 
+	if !budgetAllows(ctx, p.usage, payload.UserID, llm.TaskSummarize) {
+		logger.WithFields(map[string]any{
+			"job":      "ai.summarize",
+			"email_id": payload.EmailID,
+			"user_id":  payload.UserID,
+		}).Info("monthly AI budget exceeded, summary paused")
+		return nil
+	}
+
 	p.batchMu.Lock()
 	p.summarizeBatch = append(p.summarizeBatch, payload.EmailID)
 	shouldProcess := len(p.summarizeBatch) >= p.batchSize
@@ -159,7 +211,8 @@ func (p *AIProcessor) ProcessSummarize(ctx context.Context, msg *Message) error
 // Batch Processing
 // =============================================================================
 
-func (p *AIProcessor) flushClassifyBatch(ctx context.Context) {
+// flushClassifyBatch classifies pending batches of the given users (all users if none).
+func (p *AIProcessor) flushClassifyBatch(ctx context.Context, userIDs ...uuid.UUID) {
 	p.processingMu.Lock()
 	if p.isProcessing {
 		p.processingMu.Unlock()
@@ -175,14 +228,26 @@ func (p *AIProcessor) flushClassifyBatch(ctx context.Context) {
 	}()
 
 	p.batchMu.Lock()
-	if len(p.classifyBatch) == 0 {
+	if len(userIDs) == 0 {
+		for userID := range p.classifyBatch {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	var batch []int64
+	owners := make(map[int64]uuid.UUID)
+	for _, userID := range userIDs {
+		for _, emailID := range p.classifyBatch[userID] {
+			owners[emailID] = userID
+		}
+		batch = append(batch, p.classifyBatch[userID]...)
+		delete(p.classifyBatch, userID)
+	}
+	if len(batch) == 0 {
 		p.batchMu.Unlock()
 		return
 	}
-	batch := make([]int64, len(p.classifyBatch))
-	copy(batch, p.classifyBatch)
-	p.classifyBatch = p.classifyBatch[:0]
 	p.lastBatchTime = time.Now()
+	batchJobs := p.takeClassifyJobs(batch)
 	p.batchMu.Unlock()
 
 	log := logger.WithFields(map[string]any{
@@ -198,6 +263,8 @@ func (p *AIProcessor) flushClassifyBatch(ctx context.Context) {
 	} else if p.aiService != nil {
 		results, err = p.aiService.ClassifyEmailBatch(ctx, batch)
 	}
+	p.reportClassifyJobs(ctx, batchJobs, results)
+	p.recordClassifyOutcome(ctx, owners, results, err)
 
 	if err != nil {
 		log.WithError(err).Error("batch classify failed")
@@ -208,6 +275,156 @@ func (p *AIProcessor) flushClassifyBatch(ctx context.Context) {
 	log.WithField("classified", len(results)).Debug("batch flushed")
 }
 
+// takeClassifyJobs removes and returns job IDs of the batch. Caller must hold batchMu.
+func (p *AIProcessor) takeClassifyJobs(batch []int64) map[int64]string {
+	if len(p.classifyJobs) == 0 {
+		return nil
+	}
+	jobs := make(map[int64]string)
+	for _, emailID := range batch {
+		if jobID, ok := p.classifyJobs[emailID]; ok {
+			jobs[emailID] = jobID
+			delete(p.classifyJobs, emailID)
+		}
+	}
+	return jobs
+}
+
+// reportClassifyJobs records per-job progress; emails without a result count as failed.
+func (p *AIProcessor) reportClassifyJobs(ctx context.Context, batchJobs map[int64]string, results []*domain.ClassificationResult) {
+	if len(batchJobs) == 0 {
+		return
+	}
+	classified := make(map[int64]bool, len(results))
+	for _, r := range results {
+		if r != nil {
+			classified[r.EmailID] = true
+		}
+	}
+
+	type counts struct{ done, failed int }
+	byJob := make(map[string]*counts)
+	for emailID, jobID := range batchJobs {
+		c, ok := byJob[jobID]
+		if !ok {
+			c = &counts{}
+			byJob[jobID] = c
+		}
+		if classified[emailID] {
+			c.done++
+		} else {
+			c.failed++
+		}
+	}
+	for jobID, c := range byJob {
+		p.jobs.Progress(ctx, jobID, c.done, c.failed)
+	}
+}
+
+// recordClassifyOutcome updates ai_status of a flushed batch and records the attempts.
+// 결과가 없거나 LLM 단계가 실패해 기본값이 적용된 메일은 failed로 표시해 재처리 대상이 된다.
+func (p *AIProcessor) recordClassifyOutcome(ctx context.Context, owners map[int64]uuid.UUID, results []*domain.ClassificationResult, batchErr error) {
+	// optimized service는 이미 분류된 메일을 결과에서 빼므로 결과 누락을 실패로 볼 수 없다
+	if p.aiService == nil || len(owners) == 0 {
+		return
+	}
+
+	byEmail := make(map[int64]*domain.ClassificationResult, len(results))
+	for _, r := range results {
+		if r != nil {
+			byEmail[r.EmailID] = r
+		}
+	}
+
+	var completed, failed []int64
+	attempts := make([]*domain.ClassifyAttempt, 0, len(owners))
+	for emailID, userID := range owners {
+		attempt := &domain.ClassifyAttempt{EmailID: emailID, UserID: userID, Mode: domain.ClassifyModeLLM}
+		r := byEmail[emailID]
+		switch {
+		case r == nil:
+			attempt.Error = "no classification result"
+			if batchErr != nil {
+				attempt.Error = batchErr.Error()
+			}
+		case r.Stage == domain.ClassificationStageLLMFailed:
+			attempt.Stage = string(r.Stage)
+			attempt.Error = r.Error
+		default:
+			attempt.Stage = string(r.Stage)
+			attempt.Succeeded = true
+		}
+
+		if attempt.Succeeded {
+			completed = append(completed, emailID)
+		} else {
+			failed = append(failed, emailID)
+		}
+		attempts = append(attempts, attempt)
+	}
+	p.saveClassifyOutcome(ctx, completed, failed, attempts...)
+}
+
+// saveClassifyOutcome stores ai_status and attempt history. 기록 실패는 분류 결과에 영향을 주지 않는다.
+func (p *AIProcessor) saveClassifyOutcome(ctx context.Context, completed, failed []int64, attempts ...*domain.ClassifyAttempt) {
+	if err := p.emailRepo.SetAIStatus(ctx, completed, "completed"); err != nil {
+		logger.WithError(err).Warn("[AIProcessor] Failed to mark %d emails classified", len(completed))
+	}
+	if err := p.emailRepo.SetAIStatus(ctx, failed, "failed"); err != nil {
+		logger.WithError(err).Warn("[AIProcessor] Failed to mark %d emails failed", len(failed))
+	}
+	if p.attempts != nil {
+		if err := p.attempts.Record(ctx, attempts...); err != nil {
+			logger.WithError(err).Warn("[AIProcessor] Failed to record classify attempts")
+		}
+	}
+}
+
+// reprocessClassify classifies a failed email again with the requested model or heuristics.
+// 실패도 이력에 남기므로 메시지는 재시도하지 않는다 (사용자가 다른 방법으로 다시 요청).
+func (p *AIProcessor) reprocessClassify(ctx context.Context, payload *AIClassifyPayload) error {
+	if p.aiService == nil {
+		return fmt.Errorf("classification reprocess requires the pipeline AI service")
+	}
+
+	opts := classification.ReclassifyOptions{
+		Model:     payload.Model,
+		Heuristic: payload.Mode == domain.ClassifyModeHeuristic,
+	}
+	attempt := &domain.ClassifyAttempt{
+		EmailID: payload.EmailID,
+		UserID:  payload.UserID,
+		Mode:    domain.ClassifyModeLLM,
+		Model:   payload.Model,
+	}
+	if opts.Heuristic {
+		attempt.Mode, attempt.Model = domain.ClassifyModeHeuristic, ""
+	}
+
+	result, err := p.aiService.ReclassifyEmail(ctx, payload.EmailID, opts)
+	if err != nil {
+		attempt.Error = err.Error()
+		p.saveClassifyOutcome(ctx, nil, []int64{payload.EmailID}, attempt)
+		if p.jobs != nil {
+			p.jobs.Progress(ctx, payload.JobID, 0, 1)
+		}
+		logger.WithFields(map[string]any{
+			"job":      "ai.reprocess",
+			"email_id": payload.EmailID,
+			"mode":     attempt.Mode,
+		}).WithError(err).Warn("reprocess failed")
+		return nil
+	}
+
+	attempt.Stage, attempt.Succeeded = string(result.Stage), true
+	p.saveClassifyOutcome(ctx, []int64{payload.EmailID}, nil, attempt)
+	if p.jobs != nil {
+		p.jobs.Progress(ctx, payload.JobID, 1, 0)
+	}
+	p.notifyClassificationComplete(ctx, []*domain.ClassificationResult{result})
+	return nil
+}
+
 func (p *AIProcessor) flushSummarizeBatch(ctx context.Context) {
 	p.batchMu.Lock()
 	if len(p.summarizeBatch) == 0 {
@@ -290,7 +507,21 @@ func (p *AIProcessor) ProcessGenerateReply(ctx context.Context, msg *Message) er
 
 // AISummarizePayload for summarize jobs
 type AISummarizePayload struct {
-	EmailID int64 `json:"email_id"`
+	UserID  string `json:"user_id"`
+	EmailID int64  `json:"email_id"`
+}
+
+// budgetAllows reports whether a non-essential task may run for the user.
+// 예산 서비스가 없거나 사용자 ID를 알 수 없으면 허용한다.
+func budgetAllows(ctx context.Context, u *usage.Service, userID string, task llm.TaskType) bool {
+	if u == nil {
+		return true
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return true
+	}
+	return u.Allow(ctx, id, task)
 }
 
 // AIReplyPayload for reply generation jobs
@@ -309,12 +540,22 @@ func (p *AIProcessor) notifyClassificationComplete(ctx context.Context, results
 		return
 	}
 
+	// 사용자별 캐시 무효화 힌트 (배치 단위로 한 번에 보낸다)
+	hints := make(map[string]*domain.EmailInvalidateData)
+
 	for _, result := range results {
 		email, err := p.emailRepo.GetByID(ctx, result.EmailID)
 		if err != nil || email == nil {
 			continue
 		}
 
+		hint, ok := hints[email.UserID.String()]
+		if !ok {
+			hint = domain.NewEmailInvalidateData(domain.InvalidateSourceClassification)
+			hints[email.UserID.String()] = hint
+		}
+		hint.Add(result.EmailID, classificationPatch(result))
+
 		category := ""
 		priority := ""
 		if result.Category != nil {
@@ -332,10 +573,72 @@ func (p *AIProcessor) notifyClassificationComplete(ctx context.Context, results
 				Category:   category,
 				Priority:   priority,
 				Confidence: result.Score,
+				Stage:      string(result.Stage),
 			},
 		}
 
 		p.realtime.Push(ctx, email.UserID.String(), event)
+
+		// 본문 분석으로 고위험이 된 경우 보안 알림
+		if result.SecurityEscalated && result.Security != nil {
+			p.realtime.Push(ctx, email.UserID.String(), &domain.RealtimeEvent{
+				Type:      domain.EventEmailSecurityAlert,
+				Timestamp: time.Now(),
+				Data: &domain.SecurityAlertData{
+					EmailID:   result.EmailID,
+					Subject:   email.Subject,
+					From:      email.FromEmail,
+					FromName:  email.FromName,
+					RiskLevel: result.Security.RiskLevel,
+					RiskScore: result.Security.RiskScore,
+					Signals:   result.Security.Signals,
+				},
+			})
+		}
+	}
+
+	p.pushInvalidations(ctx, hints)
+}
+
+// classificationPatch returns the email fields changed by a classification result.
+func classificationPatch(result *domain.ClassificationResult) map[string]any {
+	patch := map[string]any{"ai_score": result.Score}
+	if result.Category != nil {
+		patch["ai_category"] = *result.Category
+	}
+	if result.SubCategory != nil {
+		patch["ai_sub_category"] = *result.SubCategory
+	}
+	if result.Priority != nil {
+		patch["ai_priority"] = *result.Priority
+	}
+	if result.Summary != nil {
+		patch["ai_summary"] = *result.Summary
+	}
+	if len(result.Tags) > 0 {
+		patch["ai_tags"] = result.Tags
+	}
+	if result.Source != "" {
+		patch["classification_source"] = result.Source
+	}
+	if result.Stage != "" {
+		patch["classification_stage"] = result.Stage
+	}
+	if result.Security != nil {
+		patch["security"] = result.Security
+	}
+	return patch
+}
+
+// pushInvalidations sends one cache invalidation hint per user.
+func (p *AIProcessor) pushInvalidations(ctx context.Context, hints map[string]*domain.EmailInvalidateData) {
+	for userID, hint := range hints {
+		if hint.Empty() {
+			continue
+		}
+		if err := p.realtime.Push(ctx, userID, domain.NewEmailInvalidateEvent(userID, hint)); err != nil {
+			logger.Warn("[AIProcessor.pushInvalidations] failed to push invalidate event: %v", err)
+		}
 	}
 }
 
@@ -345,12 +648,21 @@ func (p *AIProcessor) notifySummarizationComplete(ctx context.Context, summaries
 		return
 	}
 
+	hints := make(map[string]*domain.EmailInvalidateData)
+
 	for emailID, summary := range summaries {
 		email, err := p.emailRepo.GetByID(ctx, emailID)
 		if err != nil || email == nil {
 			continue
 		}
 
+		hint, ok := hints[email.UserID.String()]
+		if !ok {
+			hint = domain.NewEmailInvalidateData(domain.InvalidateSourceSummary)
+			hints[email.UserID.String()] = hint
+		}
+		hint.Add(emailID, map[string]any{"ai_summary": summary})
+
 		event := &domain.RealtimeEvent{
 			Type:      domain.EventEmailSummarized,
 			Timestamp: time.Now(),
@@ -362,4 +674,6 @@ func (p *AIProcessor) notifySummarizationComplete(ctx context.Context, summaries
 
 		p.realtime.Push(ctx, email.UserID.String(), event)
 	}
+
+	p.pushInvalidations(ctx, hints)
 }
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/service/email"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// BackfillResumeScheduler - 중단된 분류 backfill 이어하기
+// =============================================================================
+//
+// 시작 시와 주기적으로 running 상태로 남은 classification backfill을 찾아
+// 체크포인트부터 이어서 발행합니다 (소유 워커가 죽은 경우).
+
+type BackfillResumeScheduler struct {
+	mailSyncService *mail.SyncService
+	checkInterval   time.Duration
+	ctx             context.Context
+	cancel          context.CancelFunc
+}
+
+// NewBackfillResumeScheduler creates a new backfill resume scheduler.
+func NewBackfillResumeScheduler(mailSyncService *mail.SyncService) *BackfillResumeScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &BackfillResumeScheduler{
+		mailSyncService: mailSyncService,
+		checkInterval:   5 * time.Minute,
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+}
+
+// Start starts the scheduler.
+func (s *BackfillResumeScheduler) Start() {
+	logger.Info("[BackfillResumeScheduler] Starting...")
+	go s.run()
+}
+
+// Stop stops the scheduler. 진행 중인 backfill은 체크포인트를 남기고 멈춘다.
+func (s *BackfillResumeScheduler) Stop() {
+	logger.Info("[BackfillResumeScheduler] Stopping...")
+	s.cancel()
+}
+
+func (s *BackfillResumeScheduler) run() {
+	s.mailSyncService.ResumeClassificationBackfills(s.ctx)
+
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			logger.Info("[BackfillResumeScheduler] Stopped")
+			return
+		case <-ticker.C:
+			s.mailSyncService.ResumeClassificationBackfills(s.ctx)
+		}
+	}
+}
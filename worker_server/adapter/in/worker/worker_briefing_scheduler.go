@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/service/briefing"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// BriefingScheduler - 일일 브리핑 스케줄러
+// =============================================================================
+//
+// 주기적으로 사용자 시간대의 브리핑 시각이 지난 사용자에게 오늘 브리핑을 생성하고,
+// 메일 발송을 켠 사용자에게는 본인 메일함으로 보냅니다.
+
+type BriefingScheduler struct {
+	briefingService *briefing.Service
+	checkInterval   time.Duration
+	ctx             context.Context
+	cancel          context.CancelFunc
+}
+
+// NewBriefingScheduler creates a new briefing scheduler.
+func NewBriefingScheduler(briefingService *briefing.Service) *BriefingScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &BriefingScheduler{
+		briefingService: briefingService,
+		checkInterval:   15 * time.Minute,
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+}
+
+// Start starts the briefing scheduler.
+func (s *BriefingScheduler) Start() {
+	logger.Info("[BriefingScheduler] Starting with interval %v", s.checkInterval)
+	go s.run()
+}
+
+// Stop stops the briefing scheduler.
+func (s *BriefingScheduler) Stop() {
+	logger.Info("[BriefingScheduler] Stopping...")
+	s.cancel()
+}
+
+func (s *BriefingScheduler) run() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	s.generateDue()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			logger.Info("[BriefingScheduler] Stopped")
+			return
+		case <-ticker.C:
+			s.generateDue()
+		}
+	}
+}
+
+func (s *BriefingScheduler) generateDue() {
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Minute)
+	defer cancel()
+
+	n, err := s.briefingService.RunDue(ctx)
+	if err != nil {
+		logger.Error("[BriefingScheduler] Failed to generate briefings: %v", err)
+	}
+	if n > 0 {
+		logger.Info("[BriefingScheduler] Generated %d briefings", n)
+	}
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"worker_server/core/service/contact"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// ContactProcessor processes contact jobs.
+type ContactProcessor struct {
+	contactService *contact.Service
+}
+
+// NewContactProcessor creates a new contact processor.
+func NewContactProcessor(contactService *contact.Service) *ContactProcessor {
+	return &ContactProcessor{
+		contactService: contactService,
+	}
+}
+
+// ProcessEnrich handles contact enrichment jobs for newly important contacts.
+func (p *ContactProcessor) ProcessEnrich(ctx context.Context, msg *Message) error {
+	payload, err := ParsePayload[ContactEnrichPayload](msg)
+	if err != nil {
+		return fmt.Errorf("failed to parse payload: %w", err)
+	}
+
+	if p == nil || p.contactService == nil {
+		return fmt.Errorf("contact service not initialized")
+	}
+
+	userID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id: %w", err)
+	}
+
+	enrichment, err := p.contactService.EnrichContact(ctx, userID, payload.Email)
+	if err != nil {
+		// 설정이 꺼졌거나 잘못된 주소는 재시도해도 소용없다
+		if errors.Is(err, contact.ErrEnrichmentNotConfigured) || errors.Is(err, contact.ErrInvalidEmail) {
+			logger.Warn("[ContactProcessor] Skipping enrichment for %s: %v", payload.Email, err)
+			return nil
+		}
+		return fmt.Errorf("failed to enrich contact %s: %w", payload.Email, err)
+	}
+
+	if enrichment != nil {
+		logger.Info("[ContactProcessor] Enriched %s from %s", payload.Email, enrichment.Source)
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ type Handler struct {
 	ragProcessor      *RAGProcessor
 	calendarProcessor *CalendarProcessor
 	webhookProcessor  *WebhookProcessor
+	contactProcessor  *ContactProcessor
 }
 
 func NewHandler(
@@ -31,6 +32,11 @@ func NewHandler(
 	}
 }
 
+// SetContactProcessor enables contact enrichment jobs.
+func (h *Handler) SetContactProcessor(contactProcessor *ContactProcessor) {
+	h.contactProcessor = contactProcessor
+}
+
 func (h *Handler) Process(ctx context.Context, msg *Message) error {
 	logger.Debug("Processing message: %s", msg.Type)
 
@@ -46,6 +52,12 @@ func (h *Handler) Process(ctx context.Context, msg *Message) error {
 		return h.mailProcessor.ProcessSave(ctx, msg)
 	case JobMailModify:
 		return h.mailProcessor.ProcessModify(ctx, msg)
+	case JobMailImport:
+		return h.mailProcessor.ProcessImport(ctx, msg)
+	case JobMailCampaign:
+		return h.mailProcessor.ProcessCampaign(ctx, msg)
+	case JobMailTriage:
+		return h.mailProcessor.ProcessTriage(ctx, msg)
 
 	// AI jobs
 	case JobAIClassify:
@@ -65,6 +77,10 @@ func (h *Handler) Process(ctx context.Context, msg *Message) error {
 	case JobCalendarSync:
 		return h.calendarProcessor.ProcessSync(ctx, msg)
 
+	// Contact jobs
+	case JobContactEnrich:
+		return h.contactProcessor.ProcessEnrich(ctx, msg)
+
 	// Webhook jobs
 	case JobWebhookRenew:
 		return h.webhookProcessor.ProcessRenew(ctx, msg)
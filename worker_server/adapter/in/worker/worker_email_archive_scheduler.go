@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/service/email"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// EmailArchiveScheduler - 대용량 메일함 아카이브 스케줄러
+// =============================================================================
+//
+// 주기적으로 대용량 메일함의 오래된 메일을 월별 파티션 아카이브(emails_archive)로 옮겨
+// hot 테이블과 인덱스를 작게 유지합니다. 아카이브된 메일은 열 때 다시 돌아옵니다.
+
+type EmailArchiveScheduler struct {
+	mailService   *mail.Service
+	checkInterval time.Duration
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+// NewEmailArchiveScheduler creates a new email archive scheduler.
+func NewEmailArchiveScheduler(mailService *mail.Service) *EmailArchiveScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &EmailArchiveScheduler{
+		mailService:   mailService,
+		checkInterval: 6 * time.Hour,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start starts the email archive scheduler.
+func (s *EmailArchiveScheduler) Start() {
+	logger.Info("[EmailArchiveScheduler] Starting with interval %v", s.checkInterval)
+	go s.run()
+}
+
+// Stop stops the email archive scheduler.
+func (s *EmailArchiveScheduler) Stop() {
+	logger.Info("[EmailArchiveScheduler] Stopping...")
+	s.cancel()
+}
+
+func (s *EmailArchiveScheduler) run() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	s.archive()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			logger.Info("[EmailArchiveScheduler] Stopped")
+			return
+		case <-ticker.C:
+			s.archive()
+		}
+	}
+}
+
+func (s *EmailArchiveScheduler) archive() {
+	ctx, cancel := context.WithTimeout(s.ctx, time.Hour)
+	defer cancel()
+
+	n, err := s.mailService.ArchiveColdEmails(ctx)
+	if err != nil {
+		logger.Error("[EmailArchiveScheduler] Failed to archive emails: %v", err)
+	}
+	if n > 0 {
+		logger.Info("[EmailArchiveScheduler] Archived %d emails", n)
+	}
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"worker_server/core/port/out"
 	"worker_server/core/service/auth"
 	"worker_server/core/service/email"
+	"worker_server/core/service/job"
 	"worker_server/pkg/logger"
 
 	"github.com/google/uuid"
@@ -25,6 +27,10 @@ type MailProcessor struct {
 	emailBodyRepo    out.EmailBodyRepository
 	messageProducer out.MessageProducer
 	realtime        out.RealtimePort
+	importService   *mail.ImportService
+	campaignService *mail.CampaignService
+	triageService   *mail.TriageService
+	jobs            *job.Service
 }
 
 // NewMailProcessor creates a new mail processor.
@@ -48,6 +54,26 @@ func NewMailProcessor(
 	}
 }
 
+// SetImportService sets the MBOX/EML import service.
+func (p *MailProcessor) SetImportService(importService *mail.ImportService) {
+	p.importService = importService
+}
+
+// SetCampaignService sets the mail merge campaign service.
+func (p *MailProcessor) SetCampaignService(campaignService *mail.CampaignService) {
+	p.campaignService = campaignService
+}
+
+// SetTriageService sets the inbox triage service.
+func (p *MailProcessor) SetTriageService(triageService *mail.TriageService) {
+	p.triageService = triageService
+}
+
+// SetJobService enables job status tracking for API-triggered syncs.
+func (p *MailProcessor) SetJobService(jobs *job.Service) {
+	p.jobs = jobs
+}
+
 // ProcessSync processes mail sync jobs using Push-based real-time sync.
 // No polling fallback - requires MailSyncService (Superhuman-style).
 func (p *MailProcessor) ProcessSync(ctx context.Context, msg *Message) error {
@@ -56,6 +82,32 @@ func (p *MailProcessor) ProcessSync(ctx context.Context, msg *Message) error {
 		return fmt.Errorf("failed to parse payload: %w", err)
 	}
 
+	if p.jobs == nil || payload.JobID == "" {
+		return p.runSync(ctx, payload)
+	}
+
+	p.jobs.Start(ctx, payload.JobID)
+	err = p.runSync(ctx, payload)
+	// 재시도가 남아 있으면 running 유지, 마지막 시도에서만 결과 기록
+	if err == nil || msg.Retries >= maxJobRetries {
+		p.jobs.Finish(context.WithoutCancel(ctx), payload.JobID, err)
+	}
+	return err
+}
+
+// runSync runs an initial or delta sync depending on the payload.
+// 다른 워커가 같은 연결을 동기화 중이면 중복 실행하지 않고 건너뛴다.
+func (p *MailProcessor) runSync(ctx context.Context, payload *MailSyncPayload) error {
+	err := p.dispatchSync(ctx, payload)
+	if errors.Is(err, mail.ErrSyncInProgress) {
+		logger.Info("[MailProcessor.ProcessSync] connection=%d already syncing on another worker, skipped", payload.ConnectionID)
+		return nil
+	}
+	return err
+}
+
+func (p *MailProcessor) dispatchSync(ctx context.Context, payload *MailSyncPayload) error {
+
 	logger.Info("[MailProcessor.ProcessSync] connection=%d, user=%s, full=%v, historyID=%d",
 		payload.ConnectionID, payload.UserID, payload.FullSync, payload.HistoryID)
 
@@ -92,7 +144,13 @@ func (p *MailProcessor) ProcessDeltaSync(ctx context.Context, msg *Message) erro
 		return fmt.Errorf("mailSyncService not initialized")
 	}
 
-	return p.mailSyncService.DeltaSync(ctx, payload.ConnectionID, payload.HistoryID)
+	err = p.mailSyncService.DeltaSync(ctx, payload.ConnectionID, payload.HistoryID)
+	if errors.Is(err, mail.ErrSyncInProgress) {
+		// 진행 중인 동기화가 최신 history까지 가져오므로 건너뛴다
+		logger.Info("[MailProcessor.ProcessDeltaSync] connection=%d already syncing, skipped", payload.ConnectionID)
+		return nil
+	}
+	return err
 }
 
 // ProcessSend processes mail send jobs.
@@ -226,7 +284,7 @@ func (p *MailProcessor) actionToEventType(action string) domain.EventType {
 
 // ProcessSave processes mail save jobs (async metadata save from Gmail API).
 // 철학: "메일은 즉시 반환, DB 저장은 Worker에서"
-// 최적화: BulkUpsert로 1번의 쿼리로 N개 저장
+// 최적화: BulkUpsert로 배치 저장 (500건 이상은 COPY + 한 번의 upsert)
 func (p *MailProcessor) ProcessSave(ctx context.Context, msg *Message) error {
 	payload, err := ParsePayload[MailSavePayload](msg)
 	if err != nil {
@@ -275,7 +333,7 @@ func (p *MailProcessor) ProcessSave(ctx context.Context, msg *Message) error {
 		entities = append(entities, entity)
 	}
 
-	// 2. BulkUpsert로 1번의 쿼리로 저장 (ON CONFLICT 처리)
+	// 2. BulkUpsert로 저장 (external_id 기준 ON CONFLICT 처리)
 	if err := p.emailRepo.BulkUpsert(ctx, userUUID, payload.ConnectionID, entities); err != nil {
 		return fmt.Errorf("failed to bulk upsert emails: %w", err)
 	}
@@ -330,6 +388,73 @@ func (p *MailProcessor) ProcessSave(ctx context.Context, msg *Message) error {
 	return nil
 }
 
+// ProcessImport processes MBOX/EML import jobs.
+func (p *MailProcessor) ProcessImport(ctx context.Context, msg *Message) error {
+	payload, err := ParsePayload[MailImportPayload](msg)
+	if err != nil {
+		return fmt.Errorf("failed to parse payload: %w", err)
+	}
+
+	logger.Info("[MailProcessor.ProcessImport] user=%s, import=%d", payload.UserID, payload.ImportID)
+
+	if p.importService == nil {
+		return fmt.Errorf("importService not initialized")
+	}
+
+	userUUID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	return p.importService.ProcessImport(ctx, userUUID, payload.ImportID)
+}
+
+// ProcessCampaign processes mail merge campaign jobs.
+func (p *MailProcessor) ProcessCampaign(ctx context.Context, msg *Message) error {
+	payload, err := ParsePayload[MailCampaignPayload](msg)
+	if err != nil {
+		return fmt.Errorf("failed to parse payload: %w", err)
+	}
+
+	logger.Info("[MailProcessor.ProcessCampaign] user=%s, campaign=%d", payload.UserID, payload.CampaignID)
+
+	if p.campaignService == nil {
+		return fmt.Errorf("campaignService not initialized")
+	}
+
+	userUUID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id format: %w", err)
+	}
+
+	return p.campaignService.ProcessCampaign(ctx, userUUID, payload.CampaignID)
+}
+
+// ProcessTriage executes a confirmed inbox triage.
+func (p *MailProcessor) ProcessTriage(ctx context.Context, msg *Message) error {
+	payload, err := ParsePayload[MailTriagePayload](msg)
+	if err != nil {
+		return fmt.Errorf("failed to parse payload: %w", err)
+	}
+
+	logger.Info("[MailProcessor.ProcessTriage] user=%s, triage=%s", payload.UserID, payload.TriageID)
+
+	if p.triageService == nil {
+		return fmt.Errorf("triageService not initialized")
+	}
+
+	userUUID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id format: %w", err)
+	}
+	triageID, err := uuid.Parse(payload.TriageID)
+	if err != nil {
+		return fmt.Errorf("invalid triage_id format: %w", err)
+	}
+
+	return p.triageService.ProcessTriage(ctx, userUUID, triageID)
+}
+
 // ProcessModify processes mail modify jobs (async provider sync + SSE broadcast).
 // 1. 다른 클라이언트에 SSE로 상태 변경 알림 (즉시)
 // 2. Provider(Gmail/Outlook)에 상태 동기화 (API 호출)
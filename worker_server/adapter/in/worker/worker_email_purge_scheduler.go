@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/service/email"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// EmailPurgeScheduler - 삭제된 메일 영구 삭제 스케줄러
+// =============================================================================
+//
+// 주기적으로 복원 가능 기간(30일)이 지난 soft-delete 메일을 DB에서 영구 삭제하고,
+// 보관 기간이 지난 변경 로그(email_changes)를 정리합니다.
+
+type EmailPurgeScheduler struct {
+	mailService   *mail.Service
+	checkInterval time.Duration
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+// NewEmailPurgeScheduler creates a new email purge scheduler.
+func NewEmailPurgeScheduler(mailService *mail.Service) *EmailPurgeScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &EmailPurgeScheduler{
+		mailService:   mailService,
+		checkInterval: 1 * time.Hour,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start starts the email purge scheduler.
+func (s *EmailPurgeScheduler) Start() {
+	logger.Info("[EmailPurgeScheduler] Starting with interval %v", s.checkInterval)
+	go s.run()
+}
+
+// Stop stops the email purge scheduler.
+func (s *EmailPurgeScheduler) Stop() {
+	logger.Info("[EmailPurgeScheduler] Stopping...")
+	s.cancel()
+}
+
+func (s *EmailPurgeScheduler) run() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	s.purge()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			logger.Info("[EmailPurgeScheduler] Stopped")
+			return
+		case <-ticker.C:
+			s.purge()
+		}
+	}
+}
+
+func (s *EmailPurgeScheduler) purge() {
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Minute)
+	defer cancel()
+
+	n, err := s.mailService.PurgeDeleted(ctx)
+	if err != nil {
+		logger.Error("[EmailPurgeScheduler] Failed to purge deleted emails: %v", err)
+	}
+	if n > 0 {
+		logger.Info("[EmailPurgeScheduler] Purged %d deleted emails", n)
+	}
+
+	pruned, err := s.mailService.PruneChanges(ctx)
+	if err != nil {
+		logger.Error("[EmailPurgeScheduler] Failed to prune email changes: %v", err)
+	}
+	if pruned > 0 {
+		logger.Info("[EmailPurgeScheduler] Pruned %d email changes", pruned)
+	}
+}
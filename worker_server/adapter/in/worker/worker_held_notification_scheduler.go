@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/service/notification"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// HeldNotificationScheduler - 방해 금지 시간 보류 알림 전달 스케줄러
+// =============================================================================
+//
+// 주기적으로 방해 금지 시간이 끝난 사용자의 보류 알림을 꺼내
+// 사용자별 요약 알림 하나로 전달합니다.
+
+type HeldNotificationScheduler struct {
+	notificationService *notification.Service
+	checkInterval       time.Duration
+	ctx                 context.Context
+	cancel              context.CancelFunc
+}
+
+// NewHeldNotificationScheduler creates a new held notification scheduler.
+func NewHeldNotificationScheduler(notificationService *notification.Service) *HeldNotificationScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &HeldNotificationScheduler{
+		notificationService: notificationService,
+		checkInterval:       1 * time.Minute,
+		ctx:                 ctx,
+		cancel:              cancel,
+	}
+}
+
+// Start starts the held notification scheduler.
+func (s *HeldNotificationScheduler) Start() {
+	logger.Info("[HeldNotificationScheduler] Starting with interval %v", s.checkInterval)
+	go s.run()
+}
+
+// Stop stops the held notification scheduler.
+func (s *HeldNotificationScheduler) Stop() {
+	logger.Info("[HeldNotificationScheduler] Stopping...")
+	s.cancel()
+}
+
+func (s *HeldNotificationScheduler) run() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	s.releaseDue()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			logger.Info("[HeldNotificationScheduler] Stopped")
+			return
+		case <-ticker.C:
+			s.releaseDue()
+		}
+	}
+}
+
+func (s *HeldNotificationScheduler) releaseDue() {
+	ctx, cancel := context.WithTimeout(s.ctx, 5*time.Minute)
+	defer cancel()
+
+	n, err := s.notificationService.ReleaseHeld(ctx)
+	if err != nil {
+		logger.Error("[HeldNotificationScheduler] Failed to release held notifications: %v", err)
+	}
+	if n > 0 {
+		logger.Info("[HeldNotificationScheduler] Sent quiet hours summaries to %d users", n)
+	}
+}
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/service/email"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// HistoryBackfillScheduler - 동기화 기간 이전 메일 가져오기 (POST /email/backfill)
+// =============================================================================
+//
+// 주기마다 진행 중인 history backfill을 몇 페이지씩만 진행합니다 (저우선순위).
+// 중단되어도 체크포인트(page token)부터 이어서 처리합니다.
+
+type HistoryBackfillScheduler struct {
+	mailSyncService *mail.SyncService
+	checkInterval   time.Duration
+	ctx             context.Context
+	cancel          context.CancelFunc
+}
+
+// NewHistoryBackfillScheduler creates a new history backfill scheduler.
+func NewHistoryBackfillScheduler(mailSyncService *mail.SyncService) *HistoryBackfillScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &HistoryBackfillScheduler{
+		mailSyncService: mailSyncService,
+		checkInterval:   time.Minute,
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+}
+
+// Start starts the scheduler.
+func (s *HistoryBackfillScheduler) Start() {
+	logger.Info("[HistoryBackfillScheduler] Starting with interval %v", s.checkInterval)
+	go s.run()
+}
+
+// Stop stops the scheduler. 진행 중인 backfill은 체크포인트를 남기고 멈춘다.
+func (s *HistoryBackfillScheduler) Stop() {
+	logger.Info("[HistoryBackfillScheduler] Stopping...")
+	s.cancel()
+}
+
+func (s *HistoryBackfillScheduler) run() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			logger.Info("[HistoryBackfillScheduler] Stopped")
+			return
+		case <-ticker.C:
+			s.mailSyncService.RunHistoryBackfills(s.ctx)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/service/analytics"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// MailboxStatsScheduler - 메일함 대시보드 증분 집계 스케줄러
+// =============================================================================
+//
+// 주기적으로 집계가 오래된 사용자의 새 메일, 첨부파일, 읽음 기록을 집계 테이블에 반영합니다.
+// GET /analytics/overview는 이 테이블만 읽습니다.
+
+type MailboxStatsScheduler struct {
+	analyticsService *analytics.Service
+	checkInterval    time.Duration
+	ctx              context.Context
+	cancel           context.CancelFunc
+}
+
+// NewMailboxStatsScheduler creates a new mailbox stats scheduler.
+func NewMailboxStatsScheduler(analyticsService *analytics.Service) *MailboxStatsScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &MailboxStatsScheduler{
+		analyticsService: analyticsService,
+		checkInterval:    1 * time.Minute,
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+}
+
+// Start starts the mailbox stats scheduler.
+func (s *MailboxStatsScheduler) Start() {
+	logger.Info("[MailboxStatsScheduler] Starting with interval %v", s.checkInterval)
+	go s.run()
+}
+
+// Stop stops the mailbox stats scheduler.
+func (s *MailboxStatsScheduler) Stop() {
+	logger.Info("[MailboxStatsScheduler] Stopping...")
+	s.cancel()
+}
+
+func (s *MailboxStatsScheduler) run() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	s.aggregate()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			logger.Info("[MailboxStatsScheduler] Stopped")
+			return
+		case <-ticker.C:
+			s.aggregate()
+		}
+	}
+}
+
+func (s *MailboxStatsScheduler) aggregate() {
+	ctx, cancel := context.WithTimeout(s.ctx, 10*time.Minute)
+	defer cancel()
+
+	n, err := s.analyticsService.AggregateMailboxStats(ctx)
+	if err != nil {
+		logger.Error("[MailboxStatsScheduler] Failed to aggregate mailbox stats: %v", err)
+	}
+	if n > 0 {
+		logger.Info("[MailboxStatsScheduler] Aggregated mailbox stats for %d users", n)
+	}
+}
@@ -27,8 +27,11 @@ const (
 	JobMailBatch             = "mail.batch"
 	JobMailSend              = "mail.send"
 	JobMailReply             = "mail.reply"
-	JobMailSave              = "mail.save"   // 비동기 메타데이터 저장
-	JobMailModify            = "mail.modify" // Provider 상태 동기화
+	JobMailSave              = "mail.save"     // 비동기 메타데이터 저장
+	JobMailModify            = "mail.modify"   // Provider 상태 동기화
+	JobMailImport            = "mail.import"   // MBOX/EML 가져오기
+	JobMailCampaign          = "mail.campaign" // 메일 머지 캠페인 발송
+	JobMailTriage            = "mail.triage"   // 받은편지함 정리 제안 실행
 
 	// AI jobs
 	JobAIClassify  = "ai.classify"
@@ -50,6 +53,9 @@ const (
 	// Calendar jobs
 	JobCalendarSync = "calendar.sync"
 
+	// Contact jobs
+	JobContactEnrich = "contact.enrich" // 중요 연락처 프로필 보강 (회사, 직함, 아바타)
+
 	// Webhook jobs
 	JobWebhookRenew = "webhook.renew"
 )
@@ -99,6 +105,7 @@ type MailSyncPayload struct {
 	FullSync     bool   `json:"full_sync"`
 	PageToken    string `json:"page_token,omitempty"`
 	HistoryID    uint64 `json:"history_id,omitempty"` // Pub/Sub delta sync용
+	JobID        string `json:"job_id,omitempty"`     // background_jobs 추적 ID
 }
 
 type MailSendPayload struct {
@@ -162,10 +169,31 @@ type MailModifyPayload struct {
 	TargetFolder string   `json:"target_folder"` // Target folder for move
 }
 
+// MailImportPayload represents MBOX/EML import job payload.
+type MailImportPayload struct {
+	UserID   string `json:"user_id"`
+	ImportID int64  `json:"import_id"`
+}
+
+// MailCampaignPayload represents mail merge campaign job payload.
+type MailCampaignPayload struct {
+	UserID     string `json:"user_id"`
+	CampaignID int64  `json:"campaign_id"`
+}
+
+// MailTriagePayload represents inbox triage job payload.
+type MailTriagePayload struct {
+	UserID   string `json:"user_id"`
+	TriageID string `json:"triage_id"`
+}
+
 // AI payloads
 type AIClassifyPayload struct {
 	EmailID int64     `json:"email_id"`
 	UserID  uuid.UUID `json:"user_id"`
+	JobID   string    `json:"job_id,omitempty"` // background_jobs 추적 ID
+	Mode    string    `json:"mode,omitempty"`   // 실패 재처리: llm, heuristic
+	Model   string    `json:"model,omitempty"`  // 실패 재처리에 쓸 LLM 모델
 }
 
 type AIClassifyBatchPayload struct {
@@ -218,6 +246,13 @@ type CalendarSyncPayload struct {
 	SyncToken    string `json:"sync_token,omitempty"` // For incremental sync
 }
 
+// Contact payloads
+type ContactEnrichPayload struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name,omitempty"`
+}
+
 // Webhook payloads
 type WebhookRenewPayload struct {
 	WebhookID    int64 `json:"webhook_id,omitempty"`
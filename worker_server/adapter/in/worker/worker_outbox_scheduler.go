@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/service/email"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// OutboxScheduler - 발송 대기열 재시도 스케줄러
+// =============================================================================
+//
+// 주기적으로 재시도 시간이 된 outbox 메시지를 가져와 다시 발송합니다.
+// 실패하면 백오프로 다시 예약하고, 한도를 넘으면 failed로 표시합니다.
+
+type OutboxScheduler struct {
+	mailService   *mail.Service
+	checkInterval time.Duration
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+// NewOutboxScheduler creates a new outbox scheduler.
+func NewOutboxScheduler(mailService *mail.Service) *OutboxScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &OutboxScheduler{
+		mailService:   mailService,
+		checkInterval: 30 * time.Second,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start starts the outbox scheduler.
+func (s *OutboxScheduler) Start() {
+	logger.Info("[OutboxScheduler] Starting with interval %v", s.checkInterval)
+	go s.run()
+}
+
+// Stop stops the outbox scheduler.
+func (s *OutboxScheduler) Stop() {
+	logger.Info("[OutboxScheduler] Stopping...")
+	s.cancel()
+}
+
+func (s *OutboxScheduler) run() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	s.processDue()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			logger.Info("[OutboxScheduler] Stopped")
+			return
+		case <-ticker.C:
+			s.processDue()
+		}
+	}
+}
+
+func (s *OutboxScheduler) processDue() {
+	ctx, cancel := context.WithTimeout(s.ctx, 5*time.Minute)
+	defer cancel()
+
+	n, err := s.mailService.ProcessOutbox(ctx)
+	if err != nil {
+		logger.Error("[OutboxScheduler] Failed to process outbox: %v", err)
+	}
+	if n > 0 {
+		logger.Info("[OutboxScheduler] Sent %d queued messages", n)
+	}
+}
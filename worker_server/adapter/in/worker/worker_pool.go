@@ -2,11 +2,12 @@ package worker
 
 import (
 	"context"
-	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"worker_server/pkg/retry"
+
 	"github.com/go-pkgz/pool"
 	"github.com/rs/zerolog"
 )
@@ -15,6 +16,17 @@ import (
 // go-pkgz/pool 기반 고성능 Worker Pool (41% 성능 향상)
 // =============================================================================
 
+// maxJobRetries - 실패한 작업은 지수 백오프로 이 횟수까지 재시도 후 DLQ로 이동
+const maxJobRetries = 3
+
+// jobRetryPolicy - 재시도 간격 2s, 4s, 8s (±25% jitter)
+var jobRetryPolicy = retry.Policy{
+	MaxAttempts: maxJobRetries + 1,
+	BaseDelay:   2 * time.Second,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.25,
+}
+
 // PoolConfig holds worker pool configuration.
 type PoolConfig struct {
 	MinWorkers         int                       // 최소 워커 수
@@ -50,7 +62,11 @@ func DefaultPoolConfig() *PoolConfig {
 			JobMailSend:       30 * time.Second, // 메일 전송
 			JobMailReply:      30 * time.Second, // 메일 답장
 			JobMailModify:     1 * time.Minute,  // Provider 상태 동기화
+			JobMailImport:     15 * time.Minute, // MBOX 가져오기 (대용량 아카이브)
+			JobMailCampaign:   30 * time.Minute, // 캠페인 발송 (throttle, 초과 시 재큐잉)
+			JobMailTriage:     5 * time.Minute,  // 받은편지함 정리 (일괄 보관/읽음)
 			JobCalendarSync:   3 * time.Minute,  // 캘린더 동기화
+			JobContactEnrich:  30 * time.Second, // 연락처 프로필 보강 (외부 API)
 			JobAIClassify:     60 * time.Second, // AI 분류 (OpenAI 응답 지연 대비)
 			JobAISummarize:    45 * time.Second, // AI 요약
 			JobAIReply:        45 * time.Second, // AI 답장 생성
@@ -372,15 +388,12 @@ func (p *Pool) processJob(ctx context.Context, msg *Message) error {
 			Msg("job processing failed")
 
 		// Retry with exponential backoff + jitter (prevents thundering herd)
-		if msg.Retries < 3 {
+		// 다시 해도 실패할 에러(인증 만료, 잘못된 요청 등)는 바로 DLQ로 보낸다
+		if msg.Retries < maxJobRetries && !retry.IsPermanent(err) {
+			backoff := jobRetryPolicy.Delay(msg.Retries)
 			msg.Retries++
 			atomic.AddInt64(&p.metrics.JobsRetried, 1)
 
-			// Exponential backoff with jitter: base * 2^retries + random(0, 500ms)
-			base := time.Duration(1<<msg.Retries) * time.Second
-			jitter := time.Duration(rand.Intn(500)) * time.Millisecond
-			backoff := base + jitter
-
 			time.AfterFunc(backoff, func() {
 				p.Submit(msg)
 			})
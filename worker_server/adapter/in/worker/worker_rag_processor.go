@@ -3,10 +3,15 @@ package worker
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	"worker_server/core/agent/llm"
 	"worker_server/core/agent/rag"
 	"worker_server/core/port/out"
+	"worker_server/core/service/storage"
+	"worker_server/core/service/usage"
 	"worker_server/pkg/logger"
 
 	"github.com/google/uuid"
@@ -17,6 +22,23 @@ type RAGProcessor struct {
 	styleAnalyzer *rag.StyleAnalyzer
 	emailRepo     out.EmailRepository
 	bodyRepo      out.EmailBodyRepository
+	noteRepo      out.EmailNoteRepository // 개인 메모 (nil이면 메모 없이 색인)
+
+	// AI 월 예산 (nil = 제한 없음): 초과 시 임베딩 작업을 건너뛴다
+	usage *usage.Service
+
+	// 연결별 저장 상한 (nil = 제한 없음): metadata-only 연결은 임베딩을 저장하지 않는다
+	storage *storage.Service
+
+	// 임베딩 큐: rag:index 작업을 사용자별로 모아 배치 임베딩한다
+	// 큐가 maxQueued에 도달하면 호출한 워커가 직접 flush하며 대기한다 (backpressure)
+	indexQueue    map[uuid.UUID][]*rag.EmailIndexRequest
+	indexQueued   int
+	indexMu       sync.Mutex
+	flushMu       sync.Mutex
+	indexBatch    int
+	maxQueued     int
+	flushInterval time.Duration
 }
 
 func NewRAGProcessor(
@@ -25,12 +47,48 @@ func NewRAGProcessor(
 	emailRepo out.EmailRepository,
 	bodyRepo out.EmailBodyRepository,
 ) *RAGProcessor {
-	return &RAGProcessor{
+	p := &RAGProcessor{
 		indexer:       indexer,
 		styleAnalyzer: styleAnalyzer,
 		emailRepo:     emailRepo,
 		bodyRepo:      bodyRepo,
+		indexQueue:    make(map[uuid.UUID][]*rag.EmailIndexRequest),
+		indexBatch:    rag.DefaultEmbeddingBatch,
+		maxQueued:     4 * rag.DefaultEmbeddingBatch,
+		flushInterval: 3 * time.Second,
 	}
+
+	if indexer != nil {
+		go p.indexFlusher()
+	}
+
+	return p
+}
+
+// SetIndexBatchSize sets how many queued emails of a user are embedded together.
+func (p *RAGProcessor) SetIndexBatchSize(n int) {
+	if n <= 0 {
+		return
+	}
+	p.indexMu.Lock()
+	defer p.indexMu.Unlock()
+	p.indexBatch = n
+	p.maxQueued = 4 * n
+}
+
+// SetNoteRepository includes private email notes in the embedded text.
+func (p *RAGProcessor) SetNoteRepository(repo out.EmailNoteRepository) {
+	p.noteRepo = repo
+}
+
+// SetUsageService enables monthly AI budget checks for embedding jobs.
+func (p *RAGProcessor) SetUsageService(u *usage.Service) {
+	p.usage = u
+}
+
+// SetStorageService skips embeddings of connections in metadata-only mode.
+func (p *RAGProcessor) SetStorageService(s *storage.Service) {
+	p.storage = s
 }
 
 // RAGIndexMinimalPayload is the minimal payload from sync (only IDs)
@@ -56,6 +114,11 @@ func (p *RAGProcessor) ProcessIndex(ctx context.Context, msg *Message) error {
 		return nil
 	}
 
+	if !budgetAllows(ctx, p.usage, minPayload.UserID, llm.TaskEmbed) {
+		log.Info("monthly AI budget exceeded, embedding paused")
+		return nil
+	}
+
 	if p.emailRepo == nil {
 		log.Error("email repository not configured")
 		return fmt.Errorf("email repository not configured")
@@ -70,6 +133,10 @@ func (p *RAGProcessor) ProcessIndex(ctx context.Context, msg *Message) error {
 		log.Debug("email not found, skipping")
 		return nil
 	}
+	if p.storage != nil && p.storage.MetadataOnly(ctx, email.ConnectionID) {
+		log.Debug("connection in metadata-only mode, skipping embedding")
+		return nil
+	}
 
 	// Fetch email body from MongoDB
 	var bodyText string
@@ -110,17 +177,113 @@ func (p *RAGProcessor) ProcessIndex(ctx context.Context, msg *Message) error {
 		Direction:  direction,
 		Folder:     string(email.Folder),
 		ReceivedAt: email.ReceivedAt,
+		Category:   email.Category,
+		Notes:      p.notesText(ctx, userUUID, email.ID),
+		ThreadID:   email.ExternalThreadID,
+		FolderID:   email.FolderID,
 	}
 
-	if err := p.indexer.IndexEmail(ctx, req); err != nil {
-		log.WithError(err).Error("indexing failed")
-		return err
+	if !p.indexer.Indexable(req) {
+		log.Debug("short or bulk email, skipping embedding")
+		return nil
 	}
 
-	log.Debug("indexed successfully")
+	p.enqueueIndex(ctx, req)
 	return nil
 }
 
+// notesText joins the email's private notes and tags for embedding.
+func (p *RAGProcessor) notesText(ctx context.Context, userID uuid.UUID, emailID int64) string {
+	if p.noteRepo == nil {
+		return ""
+	}
+	notes, err := p.noteRepo.ListByEmailID(ctx, userID, emailID)
+	if err != nil {
+		logger.WithError(err).Debug("failed to load notes of email %d", emailID)
+		return ""
+	}
+
+	parts := make([]string, 0, len(notes))
+	for _, n := range notes {
+		text := n.Body
+		for _, tag := range n.Tags {
+			text += " #" + tag
+		}
+		parts = append(parts, strings.TrimSpace(text))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// enqueueIndex queues an email for batch embedding and flushes the user's batch when full.
+func (p *RAGProcessor) enqueueIndex(ctx context.Context, req *rag.EmailIndexRequest) {
+	p.indexMu.Lock()
+	p.indexQueue[req.UserID] = append(p.indexQueue[req.UserID], req)
+	p.indexQueued++
+	userFull := len(p.indexQueue[req.UserID]) >= p.indexBatch
+	queueFull := p.indexQueued >= p.maxQueued
+	p.indexMu.Unlock()
+
+	switch {
+	case queueFull:
+		p.flushIndexQueue(ctx)
+	case userFull:
+		p.flushIndexQueue(ctx, req.UserID)
+	}
+}
+
+// flushIndexQueue embeds queued emails of the given users (all users if none), one batch per user.
+// 임베딩은 Embedder의 rate limit을 따르므로 한도에 걸리면 여기서 대기한다.
+func (p *RAGProcessor) flushIndexQueue(ctx context.Context, userIDs ...uuid.UUID) {
+	p.flushMu.Lock()
+	defer p.flushMu.Unlock()
+
+	p.indexMu.Lock()
+	if len(userIDs) == 0 {
+		for userID := range p.indexQueue {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	batches := make(map[uuid.UUID][]*rag.EmailIndexRequest, len(userIDs))
+	for _, userID := range userIDs {
+		if reqs := p.indexQueue[userID]; len(reqs) > 0 {
+			batches[userID] = reqs
+			p.indexQueued -= len(reqs)
+		}
+		delete(p.indexQueue, userID)
+	}
+	p.indexMu.Unlock()
+
+	for userID, reqs := range batches {
+		log := logger.WithFields(map[string]any{
+			"job":     "rag.index_flush",
+			"user_id": userID,
+			"count":   len(reqs),
+		})
+		userCtx := llm.WithUsageScope(ctx, llm.UsageScope{UserID: userID, Task: llm.TaskEmbed})
+		if err := p.indexer.IndexBatch(userCtx, reqs); err != nil {
+			log.WithError(err).Error("batch embedding failed")
+			continue
+		}
+		log.Debug("batch embedded")
+	}
+}
+
+// indexFlusher periodically embeds queued emails that did not fill a batch.
+func (p *RAGProcessor) indexFlusher() {
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.indexMu.Lock()
+		pending := p.indexQueued
+		p.indexMu.Unlock()
+
+		if pending > 0 {
+			p.flushIndexQueue(context.Background())
+		}
+	}
+}
+
 // RAGBatchIndexMinimalPayload is the minimal payload for batch indexing (only IDs)
 type RAGBatchIndexMinimalPayload struct {
 	UserID       string  `json:"user_id"`
@@ -145,6 +308,16 @@ func (p *RAGProcessor) ProcessBatchIndex(ctx context.Context, msg *Message) erro
 		return nil
 	}
 
+	if !budgetAllows(ctx, p.usage, payload.UserID, llm.TaskEmbed) {
+		log.Info("monthly AI budget exceeded, embedding paused")
+		return nil
+	}
+
+	if p.storage != nil && p.storage.MetadataOnly(ctx, payload.ConnectionID) {
+		log.Debug("connection in metadata-only mode, skipping embedding")
+		return nil
+	}
+
 	if p.emailRepo == nil {
 		log.Error("email repository not configured")
 		return fmt.Errorf("email repository not configured")
@@ -162,6 +335,9 @@ func (p *RAGProcessor) ProcessBatchIndex(ctx context.Context, msg *Message) erro
 		if err != nil || email == nil {
 			continue
 		}
+		if p.storage != nil && email.ConnectionID != payload.ConnectionID && p.storage.MetadataOnly(ctx, email.ConnectionID) {
+			continue
+		}
 
 		var bodyText string
 		if p.bodyRepo != nil {
@@ -192,6 +368,9 @@ func (p *RAGProcessor) ProcessBatchIndex(ctx context.Context, msg *Message) erro
 			Direction:  direction,
 			Folder:     string(email.Folder),
 			ReceivedAt: email.ReceivedAt,
+			Category:   email.Category,
+			ThreadID:   email.ExternalThreadID,
+			FolderID:   email.FolderID,
 		})
 	}
 
@@ -200,6 +379,7 @@ func (p *RAGProcessor) ProcessBatchIndex(ctx context.Context, msg *Message) erro
 		return nil
 	}
 
+	ctx = llm.WithUsageScope(ctx, llm.UsageScope{UserID: userUUID, ConnectionID: payload.ConnectionID, Task: llm.TaskEmbed})
 	if err := p.indexer.IndexBatch(ctx, requests); err != nil {
 		log.WithError(err).Error("batch indexing failed")
 		return err
@@ -249,6 +429,12 @@ func (p *RAGProcessor) ProcessProfileAnalysis(ctx context.Context, msg *Message)
 		ThreadID:       payload.ThreadID,
 	}
 
+	if !budgetAllows(ctx, p.usage, payload.UserID, llm.TaskEmbed) {
+		log.Info("monthly AI budget exceeded, analysis paused")
+		return nil
+	}
+
+	ctx = llm.WithUsageScope(ctx, llm.UsageScope{UserID: userUUID, Task: llm.TaskEmbed})
 	result, err := p.styleAnalyzer.AnalyzeSentEmail(ctx, input)
 	if err != nil {
 		log.WithError(err).Error("analysis failed")
@@ -285,7 +471,7 @@ func (p *RAGProcessor) ProcessBatchProfileAnalysis(ctx context.Context, msg *Mes
 	analyzed := 0
 	for _, email := range payload.Emails {
 		userUUID, err := uuid.Parse(email.UserID)
-		if err != nil {
+		if err != nil || !budgetAllows(ctx, p.usage, email.UserID, llm.TaskEmbed) {
 			continue
 		}
 
@@ -301,7 +487,8 @@ func (p *RAGProcessor) ProcessBatchProfileAnalysis(ctx context.Context, msg *Mes
 			ThreadID:       email.ThreadID,
 		}
 
-		if _, err := p.styleAnalyzer.AnalyzeSentEmail(ctx, input); err == nil {
+		userCtx := llm.WithUsageScope(ctx, llm.UsageScope{UserID: userUUID, Task: llm.TaskEmbed})
+		if _, err := p.styleAnalyzer.AnalyzeSentEmail(userCtx, input); err == nil {
 			analyzed++
 		}
 	}
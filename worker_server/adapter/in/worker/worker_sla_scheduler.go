@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/service/team"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// SLAScheduler - 공유 메일함 응답 SLA 평가 스케줄러
+// =============================================================================
+//
+// 주기적으로 SLA가 설정된 조직 메일함의 메일 상태(ok/at_risk/breached/met)를 다시 계산하고
+// 임박/초과된 메일의 담당자와 조직 소유자에게 에스컬레이션 알림을 보냅니다.
+
+type SLAScheduler struct {
+	teamService   *team.Service
+	checkInterval time.Duration
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+// NewSLAScheduler creates a new SLA scheduler.
+func NewSLAScheduler(teamService *team.Service) *SLAScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SLAScheduler{
+		teamService:   teamService,
+		checkInterval: 1 * time.Minute,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start starts the SLA scheduler.
+func (s *SLAScheduler) Start() {
+	logger.Info("[SLAScheduler] Starting with interval %v", s.checkInterval)
+	go s.run()
+}
+
+// Stop stops the SLA scheduler.
+func (s *SLAScheduler) Stop() {
+	logger.Info("[SLAScheduler] Stopping...")
+	s.cancel()
+}
+
+func (s *SLAScheduler) run() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	s.evaluate()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			logger.Info("[SLAScheduler] Stopped")
+			return
+		case <-ticker.C:
+			s.evaluate()
+		}
+	}
+}
+
+func (s *SLAScheduler) evaluate() {
+	ctx, cancel := context.WithTimeout(s.ctx, 5*time.Minute)
+	defer cancel()
+
+	n, err := s.teamService.EvaluateSLAs(ctx)
+	if err != nil {
+		logger.Error("[SLAScheduler] Failed to evaluate slas: %v", err)
+	}
+	if n > 0 {
+		logger.Info("[SLAScheduler] Sent %d sla escalations", n)
+	}
+}
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/service/storage"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// StorageUsageScheduler - 연결별 저장 용량 측정 및 상한 적용
+// =============================================================================
+//
+// 주기적으로 연결별 본문/첨부/임베딩 용량을 측정해 기록하고 (GET /connections/:id/usage),
+// 상한을 넘은 연결은 metadata-only 모드로 전환합니다. 상한의 90% 아래로 내려가면 해제됩니다.
+
+type StorageUsageScheduler struct {
+	service       *storage.Service
+	checkInterval time.Duration
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+// NewStorageUsageScheduler creates a new storage usage scheduler. interval <= 0 uses 1 hour.
+func NewStorageUsageScheduler(service *storage.Service, interval time.Duration) *StorageUsageScheduler {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &StorageUsageScheduler{
+		service:       service,
+		checkInterval: interval,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start starts the storage usage scheduler.
+func (s *StorageUsageScheduler) Start() {
+	logger.Info("[StorageUsageScheduler] Starting with interval %v", s.checkInterval)
+	go s.run()
+}
+
+// Stop stops the storage usage scheduler.
+func (s *StorageUsageScheduler) Stop() {
+	logger.Info("[StorageUsageScheduler] Stopping...")
+	s.cancel()
+}
+
+func (s *StorageUsageScheduler) run() {
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			logger.Info("[StorageUsageScheduler] Stopped")
+			return
+		case <-ticker.C:
+			s.enforce()
+		}
+	}
+}
+
+func (s *StorageUsageScheduler) enforce() {
+	ctx, cancel := context.WithTimeout(s.ctx, 30*time.Minute)
+	defer cancel()
+
+	report, err := s.service.EnforceAll(ctx)
+	if err != nil {
+		logger.Error("[StorageUsageScheduler] Failed to measure storage: %v", err)
+		return
+	}
+	logger.Info("[StorageUsageScheduler] measured=%d metadata_only=%d entered=%d resumed=%d failed=%d",
+		report.Measured, report.MetadataOnly, report.Entered, report.Resumed, report.Failed)
+}
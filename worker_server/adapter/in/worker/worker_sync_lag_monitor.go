@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/service/synclag"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// SyncLagMonitor - Provider ↔ DB 동기화 지연 SLO 모니터
+// =============================================================================
+//
+// 주기적으로 idle 연결의 Provider 최신 메일과 DB 최신 메일 날짜를 비교해 lag를 기록하고,
+// 임계값을 넘으면 경고 로그를 남기고 GapSync를 예약합니다 (GET /admin/connections에서 조회).
+
+type SyncLagMonitor struct {
+	service       *synclag.Service
+	checkInterval time.Duration
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+// NewSyncLagMonitor creates a new sync lag monitor. interval <= 0 uses 5 minutes.
+func NewSyncLagMonitor(service *synclag.Service, interval time.Duration) *SyncLagMonitor {
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SyncLagMonitor{
+		service:       service,
+		checkInterval: interval,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start starts the sync lag monitor.
+func (m *SyncLagMonitor) Start() {
+	logger.Info("[SyncLagMonitor] Starting with interval %v (threshold %v)", m.checkInterval, m.service.Threshold())
+	go m.run()
+}
+
+// Stop stops the sync lag monitor.
+func (m *SyncLagMonitor) Stop() {
+	logger.Info("[SyncLagMonitor] Stopping...")
+	m.cancel()
+}
+
+func (m *SyncLagMonitor) run() {
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			logger.Info("[SyncLagMonitor] Stopped")
+			return
+		case <-ticker.C:
+			m.check()
+		}
+	}
+}
+
+func (m *SyncLagMonitor) check() {
+	ctx, cancel := context.WithTimeout(m.ctx, 5*time.Minute)
+	defer cancel()
+
+	report, err := m.service.Check(ctx)
+	if err != nil {
+		logger.Error("[SyncLagMonitor] Failed to check sync lag: %v", err)
+		return
+	}
+	if report.Breaches > 0 || report.Failed > 0 {
+		logger.Info("[SyncLagMonitor] checked=%d breaches=%d remediated=%d failed=%d",
+			report.Checked, report.Breaches, report.Remediated, report.Failed)
+	}
+}
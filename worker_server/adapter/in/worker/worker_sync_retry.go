@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"worker_server/core/port/out"
@@ -109,6 +110,10 @@ func (s *SyncRetryScheduler) processRetry(connectionID int64, userID string) {
 
 	// InitialSync 호출 (체크포인트가 있으면 자동으로 이어서 처리)
 	if err := s.mailSyncService.InitialSync(ctx, userID, connectionID); err != nil {
+		if errors.Is(err, mail.ErrSyncInProgress) {
+			logger.Info("[SyncRetryScheduler] Connection %d is already syncing on another worker", connectionID)
+			return
+		}
 		logger.Error("[SyncRetryScheduler] Retry failed for connection %d: %v", connectionID, err)
 		// 실패 시 SyncService 내부에서 다음 재시도를 스케줄링함
 		return
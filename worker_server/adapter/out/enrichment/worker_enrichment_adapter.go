@@ -0,0 +1,69 @@
+// Package enrichment provides contact enrichment adapters (Gravatar, Clearbit 스타일 API).
+package enrichment
+
+import (
+	"context"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+)
+
+// ChainAdapter asks several enrichers in order and fills each field from the first one that has it.
+// 개별 enricher 실패는 무시하고 나머지 결과를 사용한다.
+type ChainAdapter struct {
+	enrichers []out.ContactEnricher
+}
+
+// NewChainAdapter creates a new ChainAdapter.
+func NewChainAdapter(enrichers ...out.ContactEnricher) *ChainAdapter {
+	return &ChainAdapter{enrichers: enrichers}
+}
+
+// Enrich implements out.ContactEnricher.
+func (a *ChainAdapter) Enrich(ctx context.Context, email string) (*domain.ContactEnrichment, error) {
+	merged := &domain.ContactEnrichment{}
+	var sources []string
+	var lastErr error
+	succeeded := false
+
+	for _, enricher := range a.enrichers {
+		found, err := enricher.Enrich(ctx, email)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		succeeded = true
+		if found.IsEmpty() {
+			continue
+		}
+
+		contributed := false
+		if merged.Company == "" && found.Company != "" {
+			merged.Company, contributed = found.Company, true
+		}
+		if merged.JobTitle == "" && found.JobTitle != "" {
+			merged.JobTitle, contributed = found.JobTitle, true
+		}
+		if merged.AvatarURL == "" && found.AvatarURL != "" {
+			merged.AvatarURL, contributed = found.AvatarURL, true
+		}
+		if contributed && found.Source != "" {
+			sources = append(sources, found.Source)
+		}
+		if merged.Company != "" && merged.JobTitle != "" && merged.AvatarURL != "" {
+			break
+		}
+	}
+
+	if !succeeded && lastErr != nil {
+		return nil, lastErr
+	}
+	if merged.IsEmpty() {
+		return nil, nil
+	}
+	merged.Source = strings.Join(sources, ",")
+	return merged, nil
+}
+
+var _ out.ContactEnricher = (*ChainAdapter)(nil)
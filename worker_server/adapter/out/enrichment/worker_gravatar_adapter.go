@@ -0,0 +1,84 @@
+package enrichment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/goccy/go-json"
+)
+
+const gravatarEndpoint = "https://api.gravatar.com/v3/profiles/"
+
+// GravatarAdapter looks up public Gravatar profiles (REST API v3).
+// API 키가 없어도 동작하지만 요청 한도가 낮다.
+type GravatarAdapter struct {
+	apiKey   string
+	client   *http.Client
+	endpoint string
+}
+
+// NewGravatarAdapter creates a new GravatarAdapter.
+func NewGravatarAdapter(apiKey string) *GravatarAdapter {
+	return &GravatarAdapter{
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: 5 * time.Second},
+		endpoint: gravatarEndpoint,
+	}
+}
+
+type gravatarProfile struct {
+	AvatarURL string `json:"avatar_url"`
+	JobTitle  string `json:"job_title"`
+	Company   string `json:"company"`
+}
+
+// Enrich implements out.ContactEnricher.
+func (a *GravatarAdapter) Enrich(ctx context.Context, email string) (*domain.ContactEnrichment, error) {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.endpoint+hex.EncodeToString(sum[:]), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if a.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.apiKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gravatar request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("gravatar returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var profile gravatarProfile
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return nil, fmt.Errorf("failed to decode gravatar profile: %w", err)
+	}
+
+	return &domain.ContactEnrichment{
+		Company:   strings.TrimSpace(profile.Company),
+		JobTitle:  strings.TrimSpace(profile.JobTitle),
+		AvatarURL: profile.AvatarURL,
+		Source:    "gravatar",
+	}, nil
+}
+
+var _ out.ContactEnricher = (*GravatarAdapter)(nil)
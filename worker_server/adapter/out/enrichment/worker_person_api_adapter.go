@@ -0,0 +1,100 @@
+package enrichment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/goccy/go-json"
+)
+
+// PersonAPIAdapter looks up people with a Clearbit-style person API
+// (GET {endpoint}?email=..., Bearer 키 인증).
+type PersonAPIAdapter struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+// NewPersonAPIAdapter creates a new PersonAPIAdapter.
+func NewPersonAPIAdapter(endpoint, apiKey string) *PersonAPIAdapter {
+	return &PersonAPIAdapter{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type personAPIPerson struct {
+	Avatar     string `json:"avatar"`
+	Employment struct {
+		Name  string `json:"name"`
+		Title string `json:"title"`
+	} `json:"employment"`
+}
+
+// personAPIResponse accepts both the person response and the combined {person, company} response.
+type personAPIResponse struct {
+	personAPIPerson
+	Person  *personAPIPerson `json:"person"`
+	Company *struct {
+		Name string `json:"name"`
+	} `json:"company"`
+}
+
+// Enrich implements out.ContactEnricher.
+func (a *PersonAPIAdapter) Enrich(ctx context.Context, email string) (*domain.ContactEnrichment, error) {
+	reqURL := a.endpoint + "?email=" + url.QueryEscape(strings.TrimSpace(email))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if a.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.apiKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("person api request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 404: 찾지 못함, 202: 조회 대기 중 (다음 enrichment에서 다시 시도)
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusAccepted {
+		return nil, nil
+	}
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("person api returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var body personAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode person api response: %w", err)
+	}
+
+	person := body.personAPIPerson
+	if body.Person != nil {
+		person = *body.Person
+	}
+	result := &domain.ContactEnrichment{
+		Company:   strings.TrimSpace(person.Employment.Name),
+		JobTitle:  strings.TrimSpace(person.Employment.Title),
+		AvatarURL: person.Avatar,
+		Source:    "person_api",
+	}
+	if result.Company == "" && body.Company != nil {
+		result.Company = strings.TrimSpace(body.Company.Name)
+	}
+	return result, nil
+}
+
+var _ out.ContactEnricher = (*PersonAPIAdapter)(nil)
@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"worker_server/pkg/logger"
+	"worker_server/pkg/resilience"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/sony/gobreaker"
+)
+
+const (
+	stalePersonalizationEntries = 5000
+	stalePersonalizationMaxAge  = time.Hour
+)
+
+// WithCircuitBreaker wraps driver so sessions fail fast while Neo4j is down.
+// 쿼리 오류(문법, 제약 조건)는 세지 않고 연결 실패와 timeout만 실패로 본다.
+func WithCircuitBreaker(driver neo4j.DriverWithContext, name string) neo4j.DriverWithContext {
+	return &breakerDriver{
+		DriverWithContext: driver,
+		cb:                resilience.NewBreaker(name, isNeo4jOutage),
+	}
+}
+
+// isNeo4jOutage reports whether err means Neo4j is unavailable or overloaded.
+// 어댑터가 %w로 감싼 에러도 판별한다.
+func isNeo4jOutage(err error) bool {
+	var connErr *neo4j.ConnectivityError
+	var limitErr *neo4j.TransactionExecutionLimit
+	var dbErr *neo4j.Neo4jError
+	return neo4j.IsRetryable(err) ||
+		errors.As(err, &connErr) ||
+		errors.As(err, &limitErr) ||
+		(errors.As(err, &dbErr) && dbErr.IsRetriable()) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+type breakerDriver struct {
+	neo4j.DriverWithContext
+	cb *gobreaker.CircuitBreaker
+}
+
+func (d *breakerDriver) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	return &breakerSession{
+		SessionWithContext: d.DriverWithContext.NewSession(ctx, config),
+		cb:                 d.cb,
+	}
+}
+
+type breakerSession struct {
+	neo4j.SessionWithContext
+	cb *gobreaker.CircuitBreaker
+}
+
+func (s *breakerSession) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	return s.cb.Execute(func() (any, error) {
+		return s.SessionWithContext.ExecuteRead(ctx, work, configurers...)
+	})
+}
+
+func (s *breakerSession) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	return s.cb.Execute(func() (any, error) {
+		return s.SessionWithContext.ExecuteWrite(ctx, work, configurers...)
+	})
+}
+
+func (s *breakerSession) Run(ctx context.Context, cypher string, params map[string]any, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ResultWithContext, error) {
+	result, err := s.cb.Execute(func() (any, error) {
+		return s.SessionWithContext.Run(ctx, cypher, params, configurers...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(neo4j.ResultWithContext), nil
+}
+
+func (s *breakerSession) BeginTransaction(ctx context.Context, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ExplicitTransaction, error) {
+	tx, err := s.cb.Execute(func() (any, error) {
+		return s.SessionWithContext.BeginTransaction(ctx, configurers...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tx.(neo4j.ExplicitTransaction), nil
+}
+
+// staleRead runs read and remembers its result; while Neo4j is down it serves the last result.
+func staleRead[T any](cache *resilience.StaleCache, key string, read func() (T, error)) (T, error) {
+	value, err := read()
+	if err == nil {
+		cache.Set(key, value)
+		return value, nil
+	}
+	if resilience.IsBreakerOpen(err) || isNeo4jOutage(err) {
+		if cached, ok := cache.Get(key); ok {
+			logger.Warn("[Personalization] Serving cached %s: %v", key, err)
+			return cached.(T), nil
+		}
+	}
+	return value, err
+}
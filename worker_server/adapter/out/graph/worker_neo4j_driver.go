@@ -4,18 +4,42 @@ package graph
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
+// DriverConfig holds Neo4j connection pool and timeout settings.
+type DriverConfig struct {
+	MaxPoolSize    int           // 최대 연결 수 (0이면 드라이버 기본값)
+	AcquireTimeout time.Duration // 풀이 가득 찼을 때 연결 대기 한도
+	TxTimeout      time.Duration // 트랜잭션 1회당 서버 측 timeout (0이면 서버 기본값)
+}
+
 // NewDriver creates a new Neo4j driver.
 func NewDriver(url, username, password string) (neo4j.DriverWithContext, error) {
+	return NewDriverWithConfig(url, username, password, nil)
+}
+
+// NewDriverWithConfig creates a new Neo4j driver with pool and timeout settings.
+func NewDriverWithConfig(url, username, password string, cfg *DriverConfig) (neo4j.DriverWithContext, error) {
+	if cfg == nil {
+		cfg = &DriverConfig{}
+	}
+
 	auth := neo4j.NoAuth()
 	if username != "" && password != "" {
 		auth = neo4j.BasicAuth(username, password, "")
 	}
 
-	driver, err := neo4j.NewDriverWithContext(url, auth)
+	driver, err := neo4j.NewDriverWithContext(url, auth, func(c *neo4j.Config) {
+		if cfg.MaxPoolSize > 0 {
+			c.MaxConnectionPoolSize = cfg.MaxPoolSize
+		}
+		if cfg.AcquireTimeout > 0 {
+			c.ConnectionAcquisitionTimeout = cfg.AcquireTimeout
+		}
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
 	}
@@ -27,5 +51,48 @@ func NewDriver(url, username, password string) (neo4j.DriverWithContext, error)
 		return nil, fmt.Errorf("failed to verify Neo4j connectivity: %w", err)
 	}
 
+	if cfg.TxTimeout > 0 {
+		return &timeoutDriver{DriverWithContext: driver, txTimeout: cfg.TxTimeout}, nil
+	}
 	return driver, nil
 }
+
+// timeoutDriver applies a default transaction timeout to every session.
+// 어댑터마다 WithTxTimeout을 넣지 않아도 느린 쿼리가 연결을 무한정 붙잡지 않는다.
+type timeoutDriver struct {
+	neo4j.DriverWithContext
+	txTimeout time.Duration
+}
+
+func (d *timeoutDriver) NewSession(ctx context.Context, config neo4j.SessionConfig) neo4j.SessionWithContext {
+	return &timeoutSession{
+		SessionWithContext: d.DriverWithContext.NewSession(ctx, config),
+		txTimeout:          d.txTimeout,
+	}
+}
+
+type timeoutSession struct {
+	neo4j.SessionWithContext
+	txTimeout time.Duration
+}
+
+func (s *timeoutSession) ExecuteRead(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	return s.SessionWithContext.ExecuteRead(ctx, work, s.withTimeout(configurers)...)
+}
+
+func (s *timeoutSession) ExecuteWrite(ctx context.Context, work neo4j.ManagedTransactionWork, configurers ...func(*neo4j.TransactionConfig)) (any, error) {
+	return s.SessionWithContext.ExecuteWrite(ctx, work, s.withTimeout(configurers)...)
+}
+
+func (s *timeoutSession) Run(ctx context.Context, cypher string, params map[string]any, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ResultWithContext, error) {
+	return s.SessionWithContext.Run(ctx, cypher, params, s.withTimeout(configurers)...)
+}
+
+func (s *timeoutSession) BeginTransaction(ctx context.Context, configurers ...func(*neo4j.TransactionConfig)) (neo4j.ExplicitTransaction, error) {
+	return s.SessionWithContext.BeginTransaction(ctx, s.withTimeout(configurers)...)
+}
+
+// withTimeout prepends the default timeout so caller-provided configurers still win.
+func (s *timeoutSession) withTimeout(configurers []func(*neo4j.TransactionConfig)) []func(*neo4j.TransactionConfig) {
+	return append([]func(*neo4j.TransactionConfig){neo4j.WithTxTimeout(s.txTimeout)}, configurers...)
+}
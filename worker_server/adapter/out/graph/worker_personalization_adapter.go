@@ -2,7 +2,9 @@
 package graph
 
 import (
+	"worker_server/core/domain"
 	"worker_server/core/port/out"
+	"worker_server/pkg/resilience"
 	"context"
 	"fmt"
 	"time"
@@ -18,6 +20,7 @@ import (
 type PersonalizationAdapter struct {
 	driver neo4j.DriverWithContext
 	dbName string
+	stale  *resilience.StaleCache // Neo4j 장애 시 조회 대체 응답
 }
 
 // NewPersonalizationAdapter creates a new Neo4j personalization adapter.
@@ -25,6 +28,7 @@ func NewPersonalizationAdapter(driver neo4j.DriverWithContext, dbName string) *P
 	return &PersonalizationAdapter{
 		driver: driver,
 		dbName: dbName,
+		stale:  resilience.NewStaleCache(stalePersonalizationEntries, stalePersonalizationMaxAge),
 	}
 }
 
@@ -198,6 +202,12 @@ func (a *PersonalizationAdapter) UpdateUserTrait(ctx context.Context, userID str
 
 // GetWritingStyle retrieves user writing style.
 func (a *PersonalizationAdapter) GetWritingStyle(ctx context.Context, userID string) (*out.WritingStyle, error) {
+	return staleRead(a.stale, "style:"+userID, func() (*out.WritingStyle, error) {
+		return a.getWritingStyle(ctx, userID)
+	})
+}
+
+func (a *PersonalizationAdapter) getWritingStyle(ctx context.Context, userID string) (*out.WritingStyle, error) {
 	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
 	defer session.Close(ctx)
 
@@ -352,6 +362,12 @@ func (a *PersonalizationAdapter) UpdateTonePreference(ctx context.Context, userI
 
 // GetFrequentPhrases retrieves frequently used phrases.
 func (a *PersonalizationAdapter) GetFrequentPhrases(ctx context.Context, userID string, limit int) ([]*out.FrequentPhrase, error) {
+	return staleRead(a.stale, fmt.Sprintf("phrases:%s:%d", userID, limit), func() ([]*out.FrequentPhrase, error) {
+		return a.getFrequentPhrases(ctx, userID, limit)
+	})
+}
+
+func (a *PersonalizationAdapter) getFrequentPhrases(ctx context.Context, userID string, limit int) ([]*out.FrequentPhrase, error) {
 	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
 	defer session.Close(ctx)
 
@@ -453,13 +469,20 @@ func (a *PersonalizationAdapter) IncrementPhraseCount(ctx context.Context, userI
 
 // GetSignatures retrieves user signatures.
 func (a *PersonalizationAdapter) GetSignatures(ctx context.Context, userID string) ([]*out.Signature, error) {
+	return staleRead(a.stale, "signatures:"+userID, func() ([]*out.Signature, error) {
+		return a.getSignatures(ctx, userID)
+	})
+}
+
+func (a *PersonalizationAdapter) getSignatures(ctx context.Context, userID string) ([]*out.Signature, error) {
 	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
 	defer session.Close(ctx)
 
 	query := `
 		MATCH (u:User {user_id: $userID})-[:HAS_SIGNATURE]->(s:Signature)
-		RETURN s.id AS id, s.text AS text, s.is_default AS is_default
-		ORDER BY s.is_default DESC
+		RETURN s.id AS id, s.name AS name, s.text AS text, s.html AS html,
+			s.is_default AS is_default, s.connection_ids AS connection_ids, s.updated_at AS updated_at
+		ORDER BY s.is_default DESC, s.updated_at DESC
 	`
 
 	result, err := session.Run(ctx, query, map[string]interface{}{"userID": userID})
@@ -470,16 +493,79 @@ func (a *PersonalizationAdapter) GetSignatures(ctx context.Context, userID strin
 	var signatures []*out.Signature
 	for result.Next(ctx) {
 		record := result.Record()
-		signatures = append(signatures, &out.Signature{
-			ID:        getStringValue(record, "id"),
-			Text:      getStringValue(record, "text"),
-			IsDefault: getBoolValue(record, "is_default"),
-		})
+		sig := &out.Signature{
+			ID:            getStringValue(record, "id"),
+			Name:          getStringValue(record, "name"),
+			Text:          getStringValue(record, "text"),
+			HTML:          getStringValue(record, "html"),
+			IsDefault:     getBoolValue(record, "is_default"),
+			ConnectionIDs: getInt64ArrayValue(record, "connection_ids"),
+		}
+		if ts := getInt64Value(record, "updated_at"); ts > 0 {
+			sig.UpdatedAt = time.UnixMilli(ts)
+		}
+		signatures = append(signatures, sig)
 	}
 
 	return signatures, nil
 }
 
+// SaveSignature creates or updates a signature.
+// is_default / connection_ids는 SetDefaultSignature, SetConnectionSignature로만 변경한다.
+func (a *PersonalizationAdapter) SaveSignature(ctx context.Context, userID string, signature *out.Signature) error {
+	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
+	defer session.Close(ctx)
+
+	query := `
+		MERGE (u:User {user_id: $userID})
+		MERGE (s:Signature {user_id: $userID, id: $id})
+		ON CREATE SET s.is_default = false, s.connection_ids = []
+		SET s.name = $name,
+			s.text = $text,
+			s.html = $html,
+			s.updated_at = timestamp()
+		MERGE (u)-[:HAS_SIGNATURE]->(s)
+	`
+
+	params := map[string]interface{}{
+		"userID": userID,
+		"id":     signature.ID,
+		"name":   signature.Name,
+		"text":   signature.Text,
+		"html":   signature.HTML,
+	}
+
+	_, err := session.Run(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to save signature: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSignature deletes a signature.
+func (a *PersonalizationAdapter) DeleteSignature(ctx context.Context, userID string, signatureID string) error {
+	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (u:User {user_id: $userID})-[:HAS_SIGNATURE]->(s:Signature {id: $signatureID})
+		DETACH DELETE s
+	`
+
+	params := map[string]interface{}{
+		"userID":      userID,
+		"signatureID": signatureID,
+	}
+
+	_, err := session.Run(ctx, query, params)
+	if err != nil {
+		return fmt.Errorf("failed to delete signature: %w", err)
+	}
+
+	return nil
+}
+
 // SetDefaultSignature sets the default signature.
 func (a *PersonalizationAdapter) SetDefaultSignature(ctx context.Context, userID string, signatureID string) error {
 	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
@@ -515,6 +601,46 @@ func (a *PersonalizationAdapter) SetDefaultSignature(ctx context.Context, userID
 	return nil
 }
 
+// SetConnectionSignature sets the default signature of a connection (empty signatureID clears it).
+func (a *PersonalizationAdapter) SetConnectionSignature(ctx context.Context, userID string, connectionID int64, signatureID string) error {
+	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
+	defer session.Close(ctx)
+
+	// 계정당 하나의 서명만 기본으로 지정
+	query1 := `
+		MATCH (u:User {user_id: $userID})-[:HAS_SIGNATURE]->(s:Signature)
+		WHERE $connectionID IN coalesce(s.connection_ids, [])
+		SET s.connection_ids = [id IN s.connection_ids WHERE id <> $connectionID]
+	`
+
+	params := map[string]interface{}{
+		"userID":       userID,
+		"connectionID": connectionID,
+		"signatureID":  signatureID,
+	}
+
+	_, err := session.Run(ctx, query1, params)
+	if err != nil {
+		return fmt.Errorf("failed to unset connection signature: %w", err)
+	}
+
+	if signatureID == "" {
+		return nil
+	}
+
+	query2 := `
+		MATCH (u:User {user_id: $userID})-[:HAS_SIGNATURE]->(s:Signature {id: $signatureID})
+		SET s.connection_ids = coalesce(s.connection_ids, []) + $connectionID
+	`
+
+	_, err = session.Run(ctx, query2, params)
+	if err != nil {
+		return fmt.Errorf("failed to set connection signature: %w", err)
+	}
+
+	return nil
+}
+
 // =============================================================================
 // Helper Functions
 // =============================================================================
@@ -559,6 +685,12 @@ func getBoolValue(record *neo4j.Record, key string) bool {
 
 // GetExtendedProfile retrieves comprehensive user profile.
 func (a *PersonalizationAdapter) GetExtendedProfile(ctx context.Context, userID string) (*out.ExtendedUserProfile, error) {
+	return staleRead(a.stale, "profile:"+userID, func() (*out.ExtendedUserProfile, error) {
+		return a.getExtendedProfile(ctx, userID)
+	})
+}
+
+func (a *PersonalizationAdapter) getExtendedProfile(ctx context.Context, userID string) (*out.ExtendedUserProfile, error) {
 	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
 	defer session.Close(ctx)
 
@@ -709,7 +841,9 @@ func (a *PersonalizationAdapter) GetContactRelationships(ctx context.Context, us
 			   r.tone_used AS tone_used, r.formality_level AS formality_level,
 			   r.avg_reply_time AS avg_reply_time,
 			   r.importance_score AS importance_score,
-			   r.is_frequent AS is_frequent, r.is_important AS is_important
+			   r.is_frequent AS is_frequent, r.is_important AS is_important,
+			   c.company AS company, c.job_title AS job_title,
+			   c.avatar_url AS avatar_url, c.enriched_at AS enriched_at
 		ORDER BY r.importance_score DESC, r.last_contact DESC
 		LIMIT $limit
 	`
@@ -727,6 +861,12 @@ func (a *PersonalizationAdapter) GetContactRelationships(ctx context.Context, us
 
 // GetContactRelationship retrieves a specific contact relationship.
 func (a *PersonalizationAdapter) GetContactRelationship(ctx context.Context, userID, contactEmail string) (*out.ContactRelationship, error) {
+	return staleRead(a.stale, "contact:"+userID+":"+contactEmail, func() (*out.ContactRelationship, error) {
+		return a.getContactRelationship(ctx, userID, contactEmail)
+	})
+}
+
+func (a *PersonalizationAdapter) getContactRelationship(ctx context.Context, userID, contactEmail string) (*out.ContactRelationship, error) {
 	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
 	defer session.Close(ctx)
 
@@ -739,7 +879,9 @@ func (a *PersonalizationAdapter) GetContactRelationship(ctx context.Context, use
 			   r.tone_used AS tone_used, r.formality_level AS formality_level,
 			   r.avg_reply_time AS avg_reply_time,
 			   r.importance_score AS importance_score,
-			   r.is_frequent AS is_frequent, r.is_important AS is_important
+			   r.is_frequent AS is_frequent, r.is_important AS is_important,
+			   c.company AS company, c.job_title AS job_title,
+			   c.avatar_url AS avatar_url, c.enriched_at AS enriched_at
 	`
 
 	result, err := session.Run(ctx, query, map[string]interface{}{
@@ -824,7 +966,9 @@ func (a *PersonalizationAdapter) GetFrequentContacts(ctx context.Context, userID
 			   r.tone_used AS tone_used, r.formality_level AS formality_level,
 			   r.avg_reply_time AS avg_reply_time,
 			   r.importance_score AS importance_score,
-			   r.is_frequent AS is_frequent, r.is_important AS is_important
+			   r.is_frequent AS is_frequent, r.is_important AS is_important,
+			   c.company AS company, c.job_title AS job_title,
+			   c.avatar_url AS avatar_url, c.enriched_at AS enriched_at
 		ORDER BY (r.emails_sent + r.emails_received) DESC
 		LIMIT $limit
 	`
@@ -842,6 +986,12 @@ func (a *PersonalizationAdapter) GetFrequentContacts(ctx context.Context, userID
 
 // GetImportantContacts retrieves important contacts.
 func (a *PersonalizationAdapter) GetImportantContacts(ctx context.Context, userID string, limit int) ([]*out.ContactRelationship, error) {
+	return staleRead(a.stale, fmt.Sprintf("important:%s:%d", userID, limit), func() ([]*out.ContactRelationship, error) {
+		return a.getImportantContacts(ctx, userID, limit)
+	})
+}
+
+func (a *PersonalizationAdapter) getImportantContacts(ctx context.Context, userID string, limit int) ([]*out.ContactRelationship, error) {
 	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
 	defer session.Close(ctx)
 
@@ -855,7 +1005,9 @@ func (a *PersonalizationAdapter) GetImportantContacts(ctx context.Context, userI
 			   r.tone_used AS tone_used, r.formality_level AS formality_level,
 			   r.avg_reply_time AS avg_reply_time,
 			   r.importance_score AS importance_score,
-			   r.is_frequent AS is_frequent, r.is_important AS is_important
+			   r.is_frequent AS is_frequent, r.is_important AS is_important,
+			   c.company AS company, c.job_title AS job_title,
+			   c.avatar_url AS avatar_url, c.enriched_at AS enriched_at
 		ORDER BY r.importance_score DESC
 		LIMIT $limit
 	`
@@ -871,6 +1023,70 @@ func (a *PersonalizationAdapter) GetImportantContacts(ctx context.Context, userI
 	return parseContactRelationships(ctx, result)
 }
 
+// SetContactImportant marks or unmarks a contact as VIP.
+// 통계 필드는 건드리지 않고, 처음 보는 연락처면 관계만 만든다.
+func (a *PersonalizationAdapter) SetContactImportant(ctx context.Context, userID, contactEmail, contactName string, important bool) error {
+	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
+	defer session.Close(ctx)
+
+	query := `
+		MERGE (u:User {user_id: $userID})
+		MERGE (c:Contact {email: $contactEmail})
+		SET c.name = CASE WHEN $contactName = '' THEN c.name ELSE $contactName END
+		MERGE (u)-[r:COMMUNICATES_WITH]->(c)
+		ON CREATE SET r.emails_sent = 0, r.emails_received = 0,
+			r.importance_score = 0.0, r.is_frequent = false
+		SET r.is_important = $isImportant,
+			r.updated_at = timestamp()
+	`
+
+	_, err := session.Run(ctx, query, map[string]interface{}{
+		"userID":       userID,
+		"contactEmail": contactEmail,
+		"contactName":  contactName,
+		"isImportant":  important,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set contact importance: %w", err)
+	}
+
+	return nil
+}
+
+// SetContactEnrichment stores enrichment data on the Contact node.
+// 빈 값은 덮어쓰지 않고, enriched_at은 항상 갱신해 재조회 주기를 판단한다.
+func (a *PersonalizationAdapter) SetContactEnrichment(ctx context.Context, contactEmail string, enrichment *domain.ContactEnrichment) error {
+	session := a.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: a.dbName})
+	defer session.Close(ctx)
+
+	if enrichment == nil {
+		enrichment = &domain.ContactEnrichment{}
+	}
+
+	query := `
+		MERGE (c:Contact {email: $contactEmail})
+		SET c.company = CASE WHEN $company = '' THEN c.company ELSE $company END,
+			c.job_title = CASE WHEN $jobTitle = '' THEN c.job_title ELSE $jobTitle END,
+			c.avatar_url = CASE WHEN $avatarURL = '' THEN c.avatar_url ELSE $avatarURL END,
+			c.enrichment_source = CASE WHEN $source = '' THEN c.enrichment_source ELSE $source END,
+			c.enriched_at = $enrichedAt
+	`
+
+	_, err := session.Run(ctx, query, map[string]interface{}{
+		"contactEmail": contactEmail,
+		"company":      enrichment.Company,
+		"jobTitle":     enrichment.JobTitle,
+		"avatarURL":    enrichment.AvatarURL,
+		"source":       enrichment.Source,
+		"enrichedAt":   time.Now().Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set contact enrichment: %w", err)
+	}
+
+	return nil
+}
+
 // =============================================================================
 // Communication Pattern Operations
 // =============================================================================
@@ -1193,6 +1409,21 @@ func getStringArrayValue(record *neo4j.Record, key string) []string {
 	return nil
 }
 
+func getInt64ArrayValue(record *neo4j.Record, key string) []int64 {
+	if val, ok := record.Get(key); ok && val != nil {
+		if arr, ok := val.([]interface{}); ok {
+			result := make([]int64, 0, len(arr))
+			for _, v := range arr {
+				if n, ok := v.(int64); ok {
+					result = append(result, n)
+				}
+			}
+			return result
+		}
+	}
+	return nil
+}
+
 func getFloatValue(record *neo4j.Record, key string) float64 {
 	if val, ok := record.Get(key); ok && val != nil {
 		if f, ok := val.(float64); ok {
@@ -1233,6 +1464,15 @@ func parseContactRelationships(ctx context.Context, result neo4j.ResultWithConte
 			ImportanceScore: getFloatValue(record, "importance_score"),
 			IsFrequent:      getBoolValue(record, "is_frequent"),
 			IsImportant:     getBoolValue(record, "is_important"),
+			Company:         getStringValue(record, "company"),
+			JobTitle:        getStringValue(record, "job_title"),
+			AvatarURL:       getStringValue(record, "avatar_url"),
+		}
+		if ts, ok := record.Get("enriched_at"); ok && ts != nil {
+			if tsInt, ok := ts.(int64); ok {
+				enrichedAt := time.Unix(tsInt, 0)
+				rel.EnrichedAt = &enrichedAt
+			}
 		}
 		rels = append(rels, rel)
 	}
@@ -0,0 +1,230 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// =============================================================================
+// Stream Partitioning - 연결별 작업 affinity
+// =============================================================================
+//
+// mail:sync 작업은 connection_id 해시로 mail:sync:{n} 파티션에 발행된다.
+// 각 파티션은 lease를 가진 인스턴스 하나만 읽으므로 같은 연결의 작업이
+// 여러 워커에 동시에 흩어지지 않는다. 인스턴스 수가 바뀌면 lease 만료/반납으로
+// 재분배되며, 재분배 순간의 겹침은 SyncService의 연결별 락이 막는다.
+
+// PartitionStream returns the partition stream of a connection ("mail:sync:3").
+// partitions <= 1 means no partitioning and returns base.
+func PartitionStream(base string, connectionID int64, partitions int) string {
+	if partitions <= 1 {
+		return base
+	}
+	return fmt.Sprintf("%s:%d", base, partitionOf(connectionID, partitions))
+}
+
+// PartitionStreams returns all partition streams of base.
+func PartitionStreams(base string, partitions int) []string {
+	if partitions <= 1 {
+		return nil
+	}
+	streams := make([]string, partitions)
+	for i := range streams {
+		streams[i] = fmt.Sprintf("%s:%d", base, i)
+	}
+	return streams
+}
+
+// BaseStream strips a partition suffix ("mail:sync:3" → "mail:sync").
+func BaseStream(stream string) string {
+	i := strings.LastIndexByte(stream, ':')
+	if i < 0 {
+		return stream
+	}
+	if _, err := strconv.Atoi(stream[i+1:]); err != nil {
+		return stream
+	}
+	return stream[:i]
+}
+
+func partitionOf(connectionID int64, partitions int) int {
+	h := fnv.New32a()
+	h.Write([]byte(strconv.FormatInt(connectionID, 10)))
+	return int(h.Sum32() % uint32(partitions))
+}
+
+// leaseRenewScript extends a lease only if we still own it.
+var leaseRenewScript = redis.NewScript(`
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	end
+	return 0
+`)
+
+// leaseReleaseScript deletes a lease only if we still own it.
+var leaseReleaseScript = redis.NewScript(`
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('DEL', KEYS[1])
+	end
+	return 0
+`)
+
+// PartitionAssigner distributes the partitions of a stream across live instances.
+// 각 인스턴스는 ceil(파티션 수 / 살아있는 인스턴스 수)개까지 lease를 잡는다.
+type PartitionAssigner struct {
+	client     *redis.Client
+	base       string
+	partitions int
+	instance   string
+	leaseTTL   time.Duration
+	log        zerolog.Logger
+
+	mu    sync.RWMutex
+	owned map[int]bool
+}
+
+// NewPartitionAssigner creates a new assigner for base split into partitions.
+func NewPartitionAssigner(client *redis.Client, base string, partitions int, instance string, log zerolog.Logger) *PartitionAssigner {
+	return &PartitionAssigner{
+		client:     client,
+		base:       base,
+		partitions: partitions,
+		instance:   instance,
+		leaseTTL:   30 * time.Second,
+		log:        log,
+		owned:      make(map[int]bool),
+	}
+}
+
+// Streams returns all partition streams (for consumer group creation).
+func (a *PartitionAssigner) Streams() []string {
+	return PartitionStreams(a.base, a.partitions)
+}
+
+// Owned returns the partition streams currently leased by this instance.
+func (a *PartitionAssigner) Owned() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	streams := make([]string, 0, len(a.owned))
+	for p := range a.owned {
+		streams = append(streams, fmt.Sprintf("%s:%d", a.base, p))
+	}
+	sort.Strings(streams)
+	return streams
+}
+
+// Run heartbeats and rebalances until ctx is done, then releases all leases.
+func (a *PartitionAssigner) Run(ctx context.Context) {
+	a.rebalance(ctx)
+
+	ticker := time.NewTicker(a.leaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			a.releaseAll(context.Background())
+			return
+		case <-ticker.C:
+			a.rebalance(ctx)
+		}
+	}
+}
+
+func (a *PartitionAssigner) leaseKey(p int) string {
+	return fmt.Sprintf("partition:lease:%s:%d", a.base, p)
+}
+
+func (a *PartitionAssigner) membersKey() string {
+	return "partition:members:" + a.base
+}
+
+// rebalance renews owned leases, then grows or shrinks to the fair share.
+func (a *PartitionAssigner) rebalance(ctx context.Context) {
+	now := time.Now()
+	members := a.membersKey()
+
+	// 1. heartbeat + 죽은 인스턴스 정리
+	pipe := a.client.TxPipeline()
+	pipe.ZAdd(ctx, members, redis.Z{Score: float64(now.UnixMilli()), Member: a.instance})
+	pipe.ZRemRangeByScore(ctx, members, "-inf", strconv.FormatInt(now.Add(-a.leaseTTL).UnixMilli(), 10))
+	live := pipe.ZCard(ctx, members)
+	if _, err := pipe.Exec(ctx); err != nil {
+		a.log.Warn().Err(err).Str("stream", a.base).Msg("partition heartbeat failed")
+		return
+	}
+
+	share := (a.partitions + int(live.Val()) - 1) / max(int(live.Val()), 1)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// 2. 보유 lease 연장 (실패하면 다른 인스턴스가 가져간 것)
+	for p := range a.owned {
+		n, err := leaseRenewScript.Run(ctx, a.client, []string{a.leaseKey(p)}, a.instance, a.leaseTTL.Milliseconds()).Int()
+		if err == nil && n == 0 {
+			delete(a.owned, p)
+			a.log.Info().Str("stream", a.base).Int("partition", p).Msg("partition lease lost")
+		}
+	}
+
+	// 3. 몫보다 많으면 반납 (새 인스턴스가 가져갈 수 있도록)
+	if len(a.owned) > share {
+		for _, p := range sortedPartitions(a.owned)[share:] {
+			leaseReleaseScript.Run(ctx, a.client, []string{a.leaseKey(p)}, a.instance)
+			delete(a.owned, p)
+			a.log.Info().Str("stream", a.base).Int("partition", p).Msg("partition released for rebalance")
+		}
+	}
+
+	// 4. 몫보다 적으면 빈 파티션 획득 (인스턴스마다 시작 위치를 달리해 경합을 줄임)
+	start := partitionOf(int64(fnvString(a.instance)), a.partitions)
+	for i := 0; i < a.partitions && len(a.owned) < share; i++ {
+		p := (start + i) % a.partitions
+		if a.owned[p] {
+			continue
+		}
+		ok, err := a.client.SetNX(ctx, a.leaseKey(p), a.instance, a.leaseTTL).Result()
+		if err != nil || !ok {
+			continue
+		}
+		a.owned[p] = true
+		a.log.Info().Str("stream", a.base).Int("partition", p).Int("share", share).Msg("partition acquired")
+	}
+}
+
+func (a *PartitionAssigner) releaseAll(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for p := range a.owned {
+		leaseReleaseScript.Run(ctx, a.client, []string{a.leaseKey(p)}, a.instance)
+		delete(a.owned, p)
+	}
+	a.client.ZRem(ctx, a.membersKey(), a.instance)
+}
+
+func sortedPartitions(owned map[int]bool) []int {
+	ps := make([]int, 0, len(owned))
+	for p := range owned {
+		ps = append(ps, p)
+	}
+	sort.Ints(ps)
+	return ps
+}
+
+func fnvString(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
+}
@@ -0,0 +1,99 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// Local Backlog - Redis 장애 중 발행 작업 보관
+// Redis가 내려가도 API 요청은 성공시키고, 회복되면 받은 순서대로 다시 발행한다.
+// 메모리에만 있으므로 프로세스가 재시작되면 잃는다.
+// =============================================================================
+
+const (
+	maxBacklogJobs      = 10000
+	backlogRetryDelay   = 5 * time.Second
+	backlogPublishLimit = 5 * time.Second
+)
+
+// ErrBacklogFull is returned when Redis is down and the local backlog is full.
+var ErrBacklogFull = errors.New("redis unavailable and local job backlog is full")
+
+type pendingJob struct {
+	stream string
+	data   string
+}
+
+// BacklogSize returns the number of jobs waiting for Redis to recover.
+func (p *RedisProducer) BacklogSize() int {
+	p.backlogMu.Lock()
+	defer p.backlogMu.Unlock()
+	return len(p.backlog)
+}
+
+func (p *RedisProducer) hasBacklog() bool {
+	return p.BacklogSize() > 0
+}
+
+// enqueueBacklog keeps a job in memory and starts the flush loop if needed.
+func (p *RedisProducer) enqueueBacklog(stream, data string) error {
+	p.backlogMu.Lock()
+	defer p.backlogMu.Unlock()
+
+	if len(p.backlog) >= maxBacklogJobs {
+		return ErrBacklogFull
+	}
+	p.backlog = append(p.backlog, pendingJob{stream: stream, data: data})
+
+	if !p.flushing {
+		p.flushing = true
+		go p.flushLoop()
+	}
+	return nil
+}
+
+func (p *RedisProducer) flushLoop() {
+	ticker := time.NewTicker(backlogRetryDelay)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if p.flushBacklog() {
+			return
+		}
+	}
+}
+
+// flushBacklog republishes backlogged jobs in order and reports whether the backlog is empty.
+func (p *RedisProducer) flushBacklog() bool {
+	flushed := 0
+	for {
+		p.backlogMu.Lock()
+		if len(p.backlog) == 0 {
+			p.flushing = false
+			p.backlogMu.Unlock()
+			if flushed > 0 {
+				logger.Info("[RedisProducer] Flushed %d backlogged jobs", flushed)
+			}
+			return true
+		}
+		job := p.backlog[0]
+		p.backlogMu.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), backlogPublishLimit)
+		err := p.xadd(ctx, job.stream, job.data)
+		cancel()
+		if err != nil {
+			return false
+		}
+
+		p.backlogMu.Lock()
+		p.backlog[0] = pendingJob{}
+		p.backlog = p.backlog[1:]
+		p.backlogMu.Unlock()
+		flushed++
+	}
+}
@@ -25,6 +25,13 @@ type Consumer struct {
 	handler  JobHandler
 	log      zerolog.Logger
 
+	// 우선순위 티어: priority → streams(+파티션) → low 순서로 읽는다
+	priorityStreams []string
+	lowStreams      []string
+
+	// 파티션 스트림 (nil이면 streams만 읽음)
+	partitions *PartitionAssigner
+
 	// Pending 메시지 재처리 설정
 	pendingCheckInterval time.Duration // Pending 메시지 체크 간격
 	pendingIdleTime      time.Duration // 이 시간 이상 pending이면 재처리
@@ -39,6 +46,13 @@ type ConsumerConfig struct {
 	Handler  JobHandler
 	Logger   zerolog.Logger
 
+	// Optional: PriorityStreams는 항상 먼저 읽고, LowStreams는 다른 스트림이 비어 있을 때만 읽는다
+	PriorityStreams []string
+	LowStreams      []string
+
+	// Optional: 파티션 스트림은 lease를 가진 파티션만 읽는다
+	Partitions *PartitionAssigner
+
 	// Optional: Pending 설정 (기본값 사용 가능)
 	PendingCheckInterval time.Duration
 	PendingIdleTime      time.Duration
@@ -70,6 +84,9 @@ func NewConsumer(client *redis.Client, cfg *ConsumerConfig) *Consumer {
 		streams:              cfg.Streams,
 		handler:              cfg.Handler,
 		log:                  cfg.Logger,
+		priorityStreams:      cfg.PriorityStreams,
+		lowStreams:           cfg.LowStreams,
+		partitions:           cfg.Partitions,
 		pendingCheckInterval: pendingCheckInterval,
 		pendingIdleTime:      pendingIdleTime,
 		maxRetries:           maxRetries,
@@ -82,10 +99,16 @@ func (c *Consumer) Run(ctx context.Context) error {
 		Str("group", c.group).
 		Str("consumer", c.consumer).
 		Strs("streams", c.streams).
+		Strs("priority_streams", c.priorityStreams).
+		Strs("low_streams", c.lowStreams).
 		Msg("starting consumer")
 
 	// Ensure consumer groups exist
-	for _, stream := range c.streams {
+	groupStreams := append(append(append([]string(nil), c.priorityStreams...), c.streams...), c.lowStreams...)
+	if c.partitions != nil {
+		groupStreams = append(groupStreams, c.partitions.Streams()...)
+	}
+	for _, stream := range groupStreams {
 		c.createConsumerGroup(ctx, stream)
 	}
 
@@ -155,9 +178,25 @@ func (c *Consumer) processPendingMessages(ctx context.Context) {
 	}
 }
 
+// regularStreams returns the static streams plus currently owned partitions.
+func (c *Consumer) regularStreams() []string {
+	if c.partitions == nil {
+		return c.streams
+	}
+	return append(append([]string(nil), c.streams...), c.partitions.Owned()...)
+}
+
+// activeStreams returns every stream this consumer reads, highest tier first.
+func (c *Consumer) activeStreams() []string {
+	streams := append([]string(nil), c.priorityStreams...)
+	streams = append(streams, c.regularStreams()...)
+	return append(streams, c.lowStreams...)
+}
+
 // claimAndProcessPending claims stuck pending messages and reprocesses them.
+// 다른 인스턴스에서 넘겨받은 파티션의 pending 메시지도 여기서 회수된다.
 func (c *Consumer) claimAndProcessPending(ctx context.Context) {
-	for _, stream := range c.streams {
+	for _, stream := range c.activeStreams() {
 		// Get pending messages for this stream
 		pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
 			Stream: stream,
@@ -248,17 +287,41 @@ func (c *Consumer) createConsumerGroup(ctx context.Context, stream string) {
 	}
 }
 
-// readMessages reads messages from all streams using XREADGROUP.
+// readMessages reads messages by priority tier using XREADGROUP.
+// 우선순위 스트림에 메시지가 있으면 그것만 반환하고, low 스트림은 상위 티어가 비어 있을 때만 읽힌다.
+// 모든 티어가 비어 있으면 전체 스트림에서 블로킹 대기하므로 우선순위 메시지는 도착 즉시 깨어난다.
 func (c *Consumer) readMessages(ctx context.Context) ([]redis.XStream, error) {
-	if len(c.streams) == 0 {
+	tiers := [][]string{c.priorityStreams}
+	if len(c.lowStreams) > 0 {
+		tiers = append(tiers, c.regularStreams())
+	}
+	for _, tier := range tiers {
+		if len(tier) == 0 {
+			continue
+		}
+		result, err := c.xreadGroup(ctx, tier, -1)
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+		if len(result) > 0 {
+			return result, nil
+		}
+	}
+
+	streams := c.activeStreams()
+	if len(streams) == 0 {
 		return nil, nil
 	}
+	return c.xreadGroup(ctx, streams, 5*time.Second)
+}
 
+// xreadGroup reads new messages from streams. block < 0 returns immediately.
+func (c *Consumer) xreadGroup(ctx context.Context, streams []string, block time.Duration) ([]redis.XStream, error) {
 	// Build streams and IDs for XREADGROUP
-	args := make([]string, len(c.streams)*2)
-	for i, stream := range c.streams {
+	args := make([]string, len(streams)*2)
+	for i, stream := range streams {
 		args[i] = stream
-		args[len(c.streams)+i] = ">"
+		args[len(streams)+i] = ">"
 	}
 
 	result, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
@@ -266,7 +329,7 @@ func (c *Consumer) readMessages(ctx context.Context) ([]redis.XStream, error) {
 		Consumer: c.consumer,
 		Streams:  args,
 		Count:    10,
-		Block:    5 * time.Second,
+		Block:    block,
 	}).Result()
 	if err != nil {
 		if err != redis.Nil {
@@ -3,13 +3,18 @@ package messaging
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/goccy/go-json"
 
 	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/resilience"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/sony/gobreaker"
 )
 
 // Stream names
@@ -19,6 +24,9 @@ const (
 	StreamMailBatch       = "mail:batch"
 	StreamMailSave        = "mail:save"
 	StreamMailModify      = "mail:modify"
+	StreamMailImport      = "mail:import"
+	StreamMailCampaign    = "mail:campaign"
+	StreamMailTriage      = "mail:triage"
 	StreamCalendarSync    = "calendar:sync"
 	StreamCalendarEvent   = "calendar:event"
 	StreamAIClassify      = "ai:classify"
@@ -31,21 +39,45 @@ const (
 	StreamRAGBatchIndex   = "rag:batch"
 	StreamRAGSearch       = "rag:search"
 	StreamProfile         = "profile:analyze"
+	StreamContactEnrich   = "contact:enrich"
 
 	// Priority streams
 	StreamMailPriority     = "mail:priority"
 	StreamCalendarPriority = "calendar:priority"
 	StreamAIPriority       = "ai:priority"
+
+	// Low priority streams (우선순위 스트림과 일반 스트림이 비어 있을 때만 읽음)
+	StreamAIClassifyLow = "ai:classify:low"
 )
 
 // RedisProducer implements out.MessageProducer using Redis Streams.
 type RedisProducer struct {
-	client *redis.Client
+	client         *redis.Client
+	syncPartitions int
+	cb             *gobreaker.CircuitBreaker
+
+	// Redis 장애 중 발행한 작업 (worker_stream_backlog.go)
+	backlogMu sync.Mutex
+	backlog   []pendingJob
+	flushing  bool
 }
 
 // NewRedisProducer creates a new RedisProducer.
 func NewRedisProducer(client *redis.Client) *RedisProducer {
-	return &RedisProducer{client: client}
+	return &RedisProducer{
+		client: client,
+		cb:     resilience.NewBreaker("redis-producer", nil),
+	}
+}
+
+// SetSyncPartitions routes mail sync jobs to connection-hashed partition streams.
+// n <= 1 keeps publishing to the single mail:sync stream.
+func (p *RedisProducer) SetSyncPartitions(n int) {
+	p.syncPartitions = n
+}
+
+func (p *RedisProducer) syncStream(connectionID int64) string {
+	return PartitionStream(StreamMailSync, connectionID, p.syncPartitions)
 }
 
 // PublishMailSend publishes a mail send job.
@@ -55,7 +87,7 @@ func (p *RedisProducer) PublishMailSend(ctx context.Context, job *out.MailSendJo
 
 // PublishMailSync publishes a mail sync job.
 func (p *RedisProducer) PublishMailSync(ctx context.Context, job *out.MailSyncJob) error {
-	return p.publish(ctx, StreamMailSync, job)
+	return p.publish(ctx, p.syncStream(job.ConnectionID), job)
 }
 
 // PublishMailBatch publishes a mail batch job.
@@ -73,6 +105,21 @@ func (p *RedisProducer) PublishMailModify(ctx context.Context, job *out.MailModi
 	return p.publish(ctx, StreamMailModify, job)
 }
 
+// PublishMailImport publishes an MBOX/EML import job.
+func (p *RedisProducer) PublishMailImport(ctx context.Context, job *out.MailImportJob) error {
+	return p.publish(ctx, StreamMailImport, job)
+}
+
+// PublishMailCampaign publishes a mail merge campaign job.
+func (p *RedisProducer) PublishMailCampaign(ctx context.Context, job *out.MailCampaignJob) error {
+	return p.publish(ctx, StreamMailCampaign, job)
+}
+
+// PublishMailTriage publishes a confirmed inbox triage job.
+func (p *RedisProducer) PublishMailTriage(ctx context.Context, job *out.MailTriageJob) error {
+	return p.publish(ctx, StreamMailTriage, job)
+}
+
 // PublishCalendarSync publishes a calendar sync job.
 func (p *RedisProducer) PublishCalendarSync(ctx context.Context, job *out.CalendarSyncJob) error {
 	return p.publish(ctx, StreamCalendarSync, job)
@@ -83,14 +130,26 @@ func (p *RedisProducer) PublishCalendarEvent(ctx context.Context, job *out.Calen
 	return p.publish(ctx, StreamCalendarEvent, job)
 }
 
-// PublishAIClassify publishes an AI classify job.
+// PublishAIClassify publishes an AI classify job to the stream of its priority.
 func (p *RedisProducer) PublishAIClassify(ctx context.Context, job *out.AIClassifyJob) error {
-	return p.publish(ctx, StreamAIClassify, job)
+	return p.publish(ctx, aiClassifyStream(job.Priority), job)
 }
 
-// PublishAIBatchClassify publishes an AI batch classify job.
+// PublishAIBatchClassify publishes an AI batch classify job to the stream of its priority.
 func (p *RedisProducer) PublishAIBatchClassify(ctx context.Context, job *out.AIBatchClassifyJob) error {
-	return p.publish(ctx, StreamAIClassify, job)
+	return p.publish(ctx, aiClassifyStream(job.Priority), job)
+}
+
+// aiClassifyStream maps a job priority to its classify stream.
+func aiClassifyStream(priority out.JobPriority) string {
+	switch priority {
+	case out.JobPriorityHigh:
+		return StreamAIPriority
+	case out.JobPriorityLow:
+		return StreamAIClassifyLow
+	default:
+		return StreamAIClassify
+	}
 }
 
 // PublishAISummarize publishes an AI summarize job.
@@ -143,6 +202,11 @@ func (p *RedisProducer) PublishProfileAnalyze(ctx context.Context, job *out.Prof
 	return p.publish(ctx, StreamProfile, job)
 }
 
+// PublishContactEnrich publishes a contact enrichment job.
+func (p *RedisProducer) PublishContactEnrich(ctx context.Context, job *out.ContactEnrichJob) error {
+	return p.publish(ctx, StreamContactEnrich, job)
+}
+
 // PublishPriority publishes a priority job.
 func (p *RedisProducer) PublishPriority(ctx context.Context, stream string, job interface{}) error {
 	return p.publish(ctx, stream, job)
@@ -150,12 +214,12 @@ func (p *RedisProducer) PublishPriority(ctx context.Context, stream string, job
 
 // PublishMailSyncInit publishes a mail sync init job for parallel sync.
 func (p *RedisProducer) PublishMailSyncInit(ctx context.Context, job *out.MailSyncInitJob) error {
-	return p.publish(ctx, StreamMailSync, job)
+	return p.publish(ctx, p.syncStream(job.ConnectionID), job)
 }
 
 // PublishMailSyncPage publishes a mail sync page job for parallel sync.
 func (p *RedisProducer) PublishMailSyncPage(ctx context.Context, job *out.MailSyncPageJob) error {
-	return p.publish(ctx, StreamMailSync, job)
+	return p.publish(ctx, p.syncStream(job.ConnectionID), job)
 }
 
 // =============================================================================
@@ -245,19 +309,37 @@ func (p *RedisProducer) publish(ctx context.Context, stream string, job interfac
 		return fmt.Errorf("failed to marshal job: %w", err)
 	}
 
-	err = p.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: stream,
-		ID:     "*",
-		Values: map[string]interface{}{
-			"data": string(data),
-		},
-	}).Err()
-	if err != nil {
-		return fmt.Errorf("failed to publish to %s: %w", stream, err)
+	// 밀린 작업이 있으면 순서를 지키도록 뒤에 붙인다
+	if p.hasBacklog() {
+		return p.enqueueBacklog(stream, string(data))
+	}
+
+	if err := p.xadd(ctx, stream, string(data)); err != nil {
+		if errors.Is(err, context.Canceled) {
+			return fmt.Errorf("failed to publish to %s: %w", stream, err)
+		}
+		logger.Warn("[RedisProducer] Redis unavailable, queueing job for %s locally: %v", stream, err)
+		if qerr := p.enqueueBacklog(stream, string(data)); qerr != nil {
+			return fmt.Errorf("failed to publish to %s: %w", stream, err)
+		}
 	}
 
 	return nil
 }
 
+// xadd appends a job to a stream through the circuit breaker.
+func (p *RedisProducer) xadd(ctx context.Context, stream, data string) error {
+	_, err := p.cb.Execute(func() (interface{}, error) {
+		return nil, p.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: stream,
+			ID:     "*",
+			Values: map[string]interface{}{
+				"data": data,
+			},
+		}).Err()
+	})
+	return err
+}
+
 // Ensure RedisProducer implements out.MessageProducer
 var _ out.MessageProducer = (*RedisProducer)(nil)
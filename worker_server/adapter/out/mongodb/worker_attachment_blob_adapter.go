@@ -0,0 +1,155 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// =============================================================================
+// MongoDB Attachment Blob Adapter (GridFS)
+// =============================================================================
+
+const bucketLinkedAttachments = "linked_attachments"
+
+// AttachmentBlobAdapter implements out.AttachmentBlobStore using GridFS.
+// 링크로 바꿔 보낸 대용량 첨부 - 만료 후 DeleteExpired로 정리한다.
+type AttachmentBlobAdapter struct {
+	db *mongo.Database
+}
+
+// NewAttachmentBlobAdapter creates a new GridFS attachment blob adapter.
+func NewAttachmentBlobAdapter(db *mongo.Database) *AttachmentBlobAdapter {
+	return &AttachmentBlobAdapter{db: db}
+}
+
+// EnsureIndexes creates the expiry index on the files collection.
+func (a *AttachmentBlobAdapter) EnsureIndexes(ctx context.Context) error {
+	_, err := a.db.Collection(bucketLinkedAttachments+".files").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "metadata.expires_at", Value: 1}},
+	})
+	return err
+}
+
+// blobMetadata is the GridFS file metadata.
+type blobMetadata struct {
+	UserID    string    `bson:"user_id"`
+	MimeType  string    `bson:"mime_type"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+// bucket opens the bucket for one operation (GridFS 업/다운로드는 ctx 대신 deadline을 사용).
+func (a *AttachmentBlobAdapter) bucket(ctx context.Context) (*gridfs.Bucket, error) {
+	bucket, err := gridfs.NewBucket(a.db, options.GridFSBucket().SetName(bucketLinkedAttachments))
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetWriteDeadline(deadline)
+		bucket.SetReadDeadline(deadline)
+	}
+	return bucket, nil
+}
+
+// Put stores data and sets blob.ID and blob.CreatedAt.
+func (a *AttachmentBlobAdapter) Put(ctx context.Context, blob *domain.LinkedAttachment, data io.Reader) error {
+	bucket, err := a.bucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	meta := blobMetadata{
+		UserID:    blob.UserID.String(),
+		MimeType:  blob.MimeType,
+		ExpiresAt: blob.ExpiresAt,
+	}
+	id, err := bucket.UploadFromStream(blob.Filename, data, options.GridFSUpload().SetMetadata(meta))
+	if err != nil {
+		return fmt.Errorf("failed to upload attachment blob: %w", err)
+	}
+
+	blob.ID = id.Hex()
+	blob.CreatedAt = time.Now()
+	return nil
+}
+
+// Open returns the metadata and content of a blob.
+func (a *AttachmentBlobAdapter) Open(ctx context.Context, id string) (*domain.LinkedAttachment, io.ReadCloser, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil, out.ErrBlobNotFound
+	}
+
+	bucket, err := a.bucket(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	stream, err := bucket.OpenDownloadStream(objectID)
+	if err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, nil, out.ErrBlobNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to open attachment blob: %w", err)
+	}
+
+	file := stream.GetFile()
+	var meta blobMetadata
+	if file.Metadata != nil {
+		if err := bson.Unmarshal(file.Metadata, &meta); err != nil {
+			stream.Close()
+			return nil, nil, fmt.Errorf("failed to decode attachment blob metadata: %w", err)
+		}
+	}
+
+	userID, _ := uuid.Parse(meta.UserID)
+	return &domain.LinkedAttachment{
+		ID:        id,
+		UserID:    userID,
+		Filename:  file.Name,
+		MimeType:  meta.MimeType,
+		Size:      file.Length,
+		ExpiresAt: meta.ExpiresAt,
+		CreatedAt: file.UploadDate,
+	}, stream, nil
+}
+
+// DeleteExpired removes blobs that expired before t (files와 chunks 모두 삭제).
+func (a *AttachmentBlobAdapter) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	bucket, err := a.bucket(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	cursor, err := bucket.FindContext(ctx, bson.M{"metadata.expires_at": bson.M{"$lt": before}},
+		options.GridFSFind().SetLimit(1000))
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired attachment blobs: %w", err)
+	}
+	var files []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &files); err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, f := range files {
+		if err := bucket.DeleteContext(ctx, f.ID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return deleted, fmt.Errorf("failed to delete attachment blob: %w", err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
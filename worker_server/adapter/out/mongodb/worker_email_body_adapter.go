@@ -58,6 +58,10 @@ func (a *MailBodyAdapter) EnsureIndexes(ctx context.Context) error {
 		{
 			Keys: bson.D{{Key: "cached_at", Value: 1}},
 		},
+		{
+			// LRU 제거용
+			Keys: bson.D{{Key: "connection_id", Value: 1}, {Key: "last_accessed_at", Value: 1}},
+		},
 	}
 
 	_, err := a.collection.Indexes().CreateMany(ctx, indexes)
@@ -90,6 +94,8 @@ type mailBodyDocument struct {
 	CachedAt  time.Time `bson:"cached_at"`
 	ExpiresAt time.Time `bson:"expires_at"`
 	TTLDays   int       `bson:"ttl_days"`
+
+	LastAccessedAt time.Time `bson:"last_accessed_at"`
 }
 
 type attachmentDocument struct {
@@ -290,6 +296,103 @@ func (a *MailBodyAdapter) DeleteOlderThan(ctx context.Context, before time.Time)
 	return result.DeletedCount, nil
 }
 
+// =============================================================================
+// LRU Operations
+// =============================================================================
+
+// TouchBody updates last_accessed_at and slides expires_at.
+func (a *MailBodyAdapter) TouchBody(ctx context.Context, emailID int64, expiresAt time.Time) error {
+	filter := bson.M{"email_id": emailID}
+	update := bson.M{"$set": bson.M{
+		"last_accessed_at": time.Now(),
+		"expires_at":       expiresAt,
+	}}
+
+	if _, err := a.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("failed to touch mail body: %w", err)
+	}
+
+	return nil
+}
+
+// GetConnectionCacheSize returns the total compressed size of bodies for a connection.
+func (a *MailBodyAdapter) GetConnectionCacheSize(ctx context.Context, connectionID int64) (int64, error) {
+	_, size, err := a.GetConnectionCacheUsage(ctx, connectionID)
+	return size, err
+}
+
+// GetConnectionCacheUsage returns the number and total compressed size of bodies for a connection.
+func (a *MailBodyAdapter) GetConnectionCacheUsage(ctx context.Context, connectionID int64) (int64, int64, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{"connection_id": connectionID}},
+		{"$group": bson.M{"_id": nil, "count": bson.M{"$sum": 1}, "size": bson.M{"$sum": "$compressed_size"}}},
+	}
+
+	cursor, err := a.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to aggregate cache size: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Count int64 `bson:"count"`
+		Size  int64 `bson:"size"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return 0, 0, fmt.Errorf("failed to decode cache size: %w", err)
+	}
+	if len(results) == 0 {
+		return 0, 0, nil
+	}
+
+	return results[0].Count, results[0].Size, nil
+}
+
+// EvictLRU deletes the least recently accessed bodies of a connection
+// until its total size is at most maxBytes.
+func (a *MailBodyAdapter) EvictLRU(ctx context.Context, connectionID int64, maxBytes int64) (int64, error) {
+	total, err := a.GetConnectionCacheSize(ctx, connectionID)
+	if err != nil {
+		return 0, err
+	}
+	if total <= maxBytes {
+		return 0, nil
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "last_accessed_at", Value: 1}}).
+		SetProjection(bson.M{"email_id": 1, "compressed_size": 1})
+
+	cursor, err := a.collection.Find(ctx, bson.M{"connection_id": connectionID}, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find LRU bodies: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var emailIDs []int64
+	for total > maxBytes && cursor.Next(ctx) {
+		var doc struct {
+			EmailID        int64 `bson:"email_id"`
+			CompressedSize int64 `bson:"compressed_size"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+		emailIDs = append(emailIDs, doc.EmailID)
+		total -= doc.CompressedSize
+	}
+	if len(emailIDs) == 0 {
+		return 0, nil
+	}
+
+	result, err := a.collection.DeleteMany(ctx, bson.M{"email_id": bson.M{"$in": emailIDs}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to evict bodies: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
 // =============================================================================
 // Stats
 // =============================================================================
@@ -443,6 +546,11 @@ func (a *MailBodyAdapter) toDocument(entity *out.MailBodyEntity) (*mailBodyDocum
 		}
 	}
 
+	lastAccessedAt := entity.LastAccessedAt
+	if lastAccessedAt.IsZero() {
+		lastAccessedAt = entity.CachedAt
+	}
+
 	return &mailBodyDocument{
 		EmailID:        entity.EmailID,
 		ConnectionID:   entity.ConnectionID,
@@ -456,6 +564,7 @@ func (a *MailBodyAdapter) toDocument(entity *out.MailBodyEntity) (*mailBodyDocum
 		CachedAt:       entity.CachedAt,
 		ExpiresAt:      entity.ExpiresAt,
 		TTLDays:        entity.TTLDays,
+		LastAccessedAt: lastAccessedAt,
 	}, nil
 }
 
@@ -502,6 +611,7 @@ func (a *MailBodyAdapter) toEntity(doc *mailBodyDocument) (*out.MailBodyEntity,
 		CachedAt:       doc.CachedAt,
 		ExpiresAt:      doc.ExpiresAt,
 		TTLDays:        doc.TTLDays,
+		LastAccessedAt: doc.LastAccessedAt,
 	}, nil
 }
 
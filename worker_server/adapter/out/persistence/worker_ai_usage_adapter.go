@@ -0,0 +1,107 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// AIUsageAdapter implements out.AIUsageRepository using PostgreSQL.
+type AIUsageAdapter struct {
+	db *sqlx.DB
+}
+
+// NewAIUsageAdapter creates a new AIUsageAdapter.
+func NewAIUsageAdapter(db *sqlx.DB) *AIUsageAdapter {
+	return &AIUsageAdapter{db: db}
+}
+
+// aiUsageRow represents the database row for aggregated AI usage.
+type aiUsageRow struct {
+	UserID           uuid.UUID `db:"user_id"`
+	ConnectionID     int64     `db:"connection_id"`
+	Task             string    `db:"task"`
+	Model            string    `db:"model"`
+	Period           string    `db:"period"`
+	Requests         int64     `db:"requests"`
+	PromptTokens     int64     `db:"prompt_tokens"`
+	CompletionTokens int64     `db:"completion_tokens"`
+	CostUSD          float64   `db:"cost_usd"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+func (r *aiUsageRow) toDomain() *domain.AIUsage {
+	return &domain.AIUsage{
+		UserID:           r.UserID,
+		ConnectionID:     r.ConnectionID,
+		Task:             r.Task,
+		Model:            r.Model,
+		Period:           r.Period,
+		Requests:         r.Requests,
+		PromptTokens:     r.PromptTokens,
+		CompletionTokens: r.CompletionTokens,
+		CostUSD:          r.CostUSD,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
+
+// Add accumulates usage into its monthly row.
+func (a *AIUsageAdapter) Add(ctx context.Context, u *domain.AIUsage) error {
+	query := `
+		INSERT INTO ai_usage (user_id, connection_id, task, model, period, requests, prompt_tokens, completion_tokens, cost_usd)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, period, connection_id, task, model) DO UPDATE SET
+			requests = ai_usage.requests + EXCLUDED.requests,
+			prompt_tokens = ai_usage.prompt_tokens + EXCLUDED.prompt_tokens,
+			completion_tokens = ai_usage.completion_tokens + EXCLUDED.completion_tokens,
+			cost_usd = ai_usage.cost_usd + EXCLUDED.cost_usd,
+			updated_at = NOW()
+	`
+	_, err := a.db.ExecContext(ctx, query,
+		u.UserID, u.ConnectionID, u.Task, u.Model, u.Period,
+		u.Requests, u.PromptTokens, u.CompletionTokens, u.CostUSD,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add ai usage: %w", err)
+	}
+	return nil
+}
+
+// ListByUser returns all usage rows of a user in the period.
+func (a *AIUsageAdapter) ListByUser(ctx context.Context, userID uuid.UUID, period string) ([]*domain.AIUsage, error) {
+	query := `
+		SELECT user_id, connection_id, task, model, period, requests, prompt_tokens, completion_tokens, cost_usd, updated_at
+		FROM ai_usage
+		WHERE user_id = $1 AND period = $2
+		ORDER BY cost_usd DESC
+	`
+
+	var rows []aiUsageRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID, period); err != nil {
+		return nil, fmt.Errorf("failed to list ai usage: %w", err)
+	}
+	usage := make([]*domain.AIUsage, len(rows))
+	for i := range rows {
+		usage[i] = rows[i].toDomain()
+	}
+	return usage, nil
+}
+
+// CostByUser returns the total cost of a user in the period.
+func (a *AIUsageAdapter) CostByUser(ctx context.Context, userID uuid.UUID, period string) (float64, error) {
+	query := `SELECT COALESCE(SUM(cost_usd), 0) FROM ai_usage WHERE user_id = $1 AND period = $2`
+
+	var cost float64
+	if err := a.db.GetContext(ctx, &cost, query, userID, period); err != nil {
+		return 0, fmt.Errorf("failed to sum ai usage cost: %w", err)
+	}
+	return cost, nil
+}
+
+var _ out.AIUsageRepository = (*AIUsageAdapter)(nil)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // =============================================================================
@@ -144,6 +145,32 @@ func (a *AttachmentAdapter) GetByID(ctx context.Context, id int64) (*out.EmailAt
 	return row.toEntity(), nil
 }
 
+// GetByIDs retrieves attachments by IDs (missing IDs are skipped).
+func (a *AttachmentAdapter) GetByIDs(ctx context.Context, ids []int64) ([]*out.EmailAttachmentEntity, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var rows []attachmentRow
+	query := `
+		SELECT id, email_id, external_id, filename, mime_type, size, content_id, is_inline, created_at
+		FROM email_attachments
+		WHERE id = ANY($1)
+		ORDER BY email_id, id`
+
+	err := a.db.SelectContext(ctx, &rows, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*out.EmailAttachmentEntity, len(rows))
+	for i, row := range rows {
+		result[i] = row.toEntity()
+	}
+
+	return result, nil
+}
+
 // GetByEmailID retrieves all attachments for an email.
 func (a *AttachmentAdapter) GetByEmailID(ctx context.Context, emailID int64) ([]*out.EmailAttachmentEntity, error) {
 	var rows []attachmentRow
@@ -0,0 +1,148 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BackfillAdapter implements out.ClassificationBackfillRepository using PostgreSQL.
+type BackfillAdapter struct {
+	db *sqlx.DB
+}
+
+// NewBackfillAdapter creates a new BackfillAdapter.
+func NewBackfillAdapter(db *sqlx.DB) *BackfillAdapter {
+	return &BackfillAdapter{db: db}
+}
+
+// backfillRow represents the database row for a classification backfill.
+type backfillRow struct {
+	ConnectionID int64          `db:"connection_id"`
+	UserID       string         `db:"user_id"`
+	Status       string         `db:"status"`
+	CursorID     int64          `db:"cursor_id"`
+	Total        int            `db:"total"`
+	Published    int            `db:"published"`
+	Failed       int            `db:"failed"`
+	LastError    sql.NullString `db:"last_error"`
+	StartedAt    time.Time      `db:"started_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+	CompletedAt  sql.NullTime   `db:"completed_at"`
+}
+
+const backfillColumns = `connection_id, user_id, status, cursor_id, total, published, failed, last_error, started_at, updated_at, completed_at`
+
+func (r *backfillRow) toDomain() *domain.ClassificationBackfill {
+	b := &domain.ClassificationBackfill{
+		ConnectionID: r.ConnectionID,
+		UserID:       r.UserID,
+		Status:       domain.BackfillStatus(r.Status),
+		CursorID:     r.CursorID,
+		Total:        r.Total,
+		Published:    r.Published,
+		Failed:       r.Failed,
+		LastError:    r.LastError.String,
+		StartedAt:    r.StartedAt,
+		UpdatedAt:    r.UpdatedAt,
+	}
+	if r.CompletedAt.Valid {
+		b.CompletedAt = &r.CompletedAt.Time
+	}
+	b.Fill()
+	return b
+}
+
+// Get retrieves the backfill of a connection.
+func (a *BackfillAdapter) Get(ctx context.Context, connectionID int64) (*domain.ClassificationBackfill, error) {
+	query := `SELECT ` + backfillColumns + ` FROM classification_backfills WHERE connection_id = $1`
+
+	var row backfillRow
+	if err := a.db.GetContext(ctx, &row, query, connectionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get classification backfill: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// Start resets the checkpoint of a connection and marks it running.
+func (a *BackfillAdapter) Start(ctx context.Context, userID string, connectionID int64, total int) (*domain.ClassificationBackfill, error) {
+	query := `
+		INSERT INTO classification_backfills (connection_id, user_id, status, total)
+		VALUES ($1, $2, 'running', $3)
+		ON CONFLICT (connection_id) DO UPDATE SET
+			status = 'running',
+			cursor_id = 0,
+			total = EXCLUDED.total,
+			published = 0,
+			failed = 0,
+			last_error = NULL,
+			started_at = NOW(),
+			updated_at = NOW(),
+			completed_at = NULL
+		RETURNING ` + backfillColumns
+
+	var row backfillRow
+	if err := a.db.GetContext(ctx, &row, query, connectionID, userID, total); err != nil {
+		return nil, fmt.Errorf("failed to start classification backfill: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// SaveCheckpoint records progress of a running backfill.
+func (a *BackfillAdapter) SaveCheckpoint(ctx context.Context, connectionID, cursorID int64, published, failed int) error {
+	query := `
+		UPDATE classification_backfills SET
+			cursor_id = $2,
+			published = $3,
+			failed = $4,
+			updated_at = NOW()
+		WHERE connection_id = $1 AND status = 'running'
+	`
+	if _, err := a.db.ExecContext(ctx, query, connectionID, cursorID, published, failed); err != nil {
+		return fmt.Errorf("failed to save classification backfill checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Finish sets the terminal status of a running backfill.
+func (a *BackfillAdapter) Finish(ctx context.Context, connectionID int64, status domain.BackfillStatus, errMsg string) error {
+	query := `
+		UPDATE classification_backfills SET
+			status = $2,
+			last_error = NULLIF($3, ''),
+			updated_at = NOW(),
+			completed_at = NOW()
+		WHERE connection_id = $1 AND status = 'running'
+	`
+	if _, err := a.db.ExecContext(ctx, query, connectionID, string(status), errMsg); err != nil {
+		return fmt.Errorf("failed to finish classification backfill: %w", err)
+	}
+	return nil
+}
+
+// ListRunning returns all running backfills, oldest checkpoint first.
+func (a *BackfillAdapter) ListRunning(ctx context.Context) ([]*domain.ClassificationBackfill, error) {
+	query := `SELECT ` + backfillColumns + ` FROM classification_backfills WHERE status = 'running' ORDER BY updated_at`
+
+	var rows []backfillRow
+	if err := a.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to list running classification backfills: %w", err)
+	}
+	backfills := make([]*domain.ClassificationBackfill, len(rows))
+	for i := range rows {
+		backfills[i] = rows[i].toDomain()
+	}
+	return backfills, nil
+}
+
+var _ out.ClassificationBackfillRepository = (*BackfillAdapter)(nil)
@@ -0,0 +1,216 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// BriefingAdapter implements out.BriefingRepository using PostgreSQL.
+type BriefingAdapter struct {
+	db *sqlx.DB
+}
+
+// NewBriefingAdapter creates a new BriefingAdapter.
+func NewBriefingAdapter(db *sqlx.DB) *BriefingAdapter {
+	return &BriefingAdapter{db: db}
+}
+
+// briefingSettingsRow represents briefing_settings joined with user_settings.timezone.
+type briefingSettingsRow struct {
+	UserID       uuid.UUID      `db:"user_id"`
+	Enabled      bool           `db:"enabled"`
+	EmailEnabled bool           `db:"email_enabled"`
+	Hour         int            `db:"hour"`
+	Timezone     sql.NullString `db:"timezone"`
+}
+
+func (r *briefingSettingsRow) toDomain() *domain.BriefingSettings {
+	return &domain.BriefingSettings{
+		UserID:       r.UserID,
+		Enabled:      r.Enabled,
+		EmailEnabled: r.EmailEnabled,
+		Hour:         r.Hour,
+		Timezone:     r.Timezone.String,
+	}
+}
+
+// Get retrieves the briefing of a local date, or nil if none was generated.
+func (a *BriefingAdapter) Get(ctx context.Context, userID uuid.UUID, date string) (*domain.Briefing, error) {
+	query := `SELECT payload, emailed_at FROM briefings WHERE user_id = $1 AND briefing_date = $2`
+
+	var row struct {
+		Payload   []byte       `db:"payload"`
+		EmailedAt sql.NullTime `db:"emailed_at"`
+	}
+	if err := a.db.GetContext(ctx, &row, query, userID, date); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get briefing: %w", err)
+	}
+
+	var b domain.Briefing
+	if err := json.Unmarshal(row.Payload, &b); err != nil {
+		return nil, fmt.Errorf("failed to decode briefing: %w", err)
+	}
+	b.UserID = userID
+	if row.EmailedAt.Valid {
+		b.EmailedAt = &row.EmailedAt.Time
+	}
+	return &b, nil
+}
+
+// Save upserts the briefing of (user, date). emailed_at is kept on regeneration.
+func (a *BriefingAdapter) Save(ctx context.Context, b *domain.Briefing) error {
+	payload, err := json.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("failed to encode briefing: %w", err)
+	}
+
+	query := `
+		INSERT INTO briefings (user_id, briefing_date, timezone, payload, generated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, briefing_date) DO UPDATE SET
+			timezone = EXCLUDED.timezone,
+			payload = EXCLUDED.payload,
+			generated_at = EXCLUDED.generated_at
+	`
+	if _, err := a.db.ExecContext(ctx, query, b.UserID, b.Date, b.Timezone, payload, b.GeneratedAt); err != nil {
+		return fmt.Errorf("failed to save briefing: %w", err)
+	}
+	return nil
+}
+
+// MarkEmailed records when the briefing was emailed.
+func (a *BriefingAdapter) MarkEmailed(ctx context.Context, userID uuid.UUID, date string, at time.Time) error {
+	query := `UPDATE briefings SET emailed_at = $3 WHERE user_id = $1 AND briefing_date = $2`
+	if _, err := a.db.ExecContext(ctx, query, userID, date, at); err != nil {
+		return fmt.Errorf("failed to mark briefing emailed: %w", err)
+	}
+	return nil
+}
+
+// GetSettings returns the user's briefing settings, with defaults when none were saved.
+func (a *BriefingAdapter) GetSettings(ctx context.Context, userID uuid.UUID) (*domain.BriefingSettings, error) {
+	query := `
+		SELECT u.id AS user_id,
+			COALESCE(b.enabled, TRUE) AS enabled,
+			COALESCE(b.email_enabled, FALSE) AS email_enabled,
+			COALESCE(b.hour, $2) AS hour,
+			s.timezone
+		FROM users u
+		LEFT JOIN briefing_settings b ON b.user_id = u.id
+		LEFT JOIN user_settings s ON s.user_id = u.id
+		WHERE u.id = $1
+	`
+
+	var row briefingSettingsRow
+	if err := a.db.GetContext(ctx, &row, query, userID, domain.DefaultBriefingHour); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get briefing settings: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// SaveSettings upserts the user's briefing settings.
+func (a *BriefingAdapter) SaveSettings(ctx context.Context, s *domain.BriefingSettings) error {
+	query := `
+		INSERT INTO briefing_settings (user_id, enabled, email_enabled, hour, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			email_enabled = EXCLUDED.email_enabled,
+			hour = EXCLUDED.hour,
+			updated_at = NOW()
+	`
+	if _, err := a.db.ExecContext(ctx, query, s.UserID, s.Enabled, s.EmailEnabled, s.Hour); err != nil {
+		return fmt.Errorf("failed to save briefing settings: %w", err)
+	}
+	return nil
+}
+
+// ListEnabledSettings returns the settings of connected users with briefings enabled.
+func (a *BriefingAdapter) ListEnabledSettings(ctx context.Context) ([]*domain.BriefingSettings, error) {
+	query := `
+		SELECT u.id AS user_id,
+			COALESCE(b.enabled, TRUE) AS enabled,
+			COALESCE(b.email_enabled, FALSE) AS email_enabled,
+			COALESCE(b.hour, $1) AS hour,
+			s.timezone
+		FROM users u
+		LEFT JOIN briefing_settings b ON b.user_id = u.id
+		LEFT JOIN user_settings s ON s.user_id = u.id
+		WHERE COALESCE(b.enabled, TRUE)
+		  AND EXISTS (SELECT 1 FROM oauth_connections c WHERE c.user_id = u.id AND c.is_connected = TRUE)
+	`
+
+	var rows []briefingSettingsRow
+	if err := a.db.SelectContext(ctx, &rows, query, domain.DefaultBriefingHour); err != nil {
+		return nil, fmt.Errorf("failed to list briefing settings: %w", err)
+	}
+	settings := make([]*domain.BriefingSettings, len(rows))
+	for i := range rows {
+		settings[i] = rows[i].toDomain()
+	}
+	return settings, nil
+}
+
+// ListAwaitingReply returns threads whose latest message is one the user sent in [sentAfter, sentBefore).
+func (a *BriefingAdapter) ListAwaitingReply(ctx context.Context, userID uuid.UUID, sentAfter, sentBefore time.Time, limit int) ([]domain.BriefingThread, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	// 스레드별 최신 메일이 보낸 메일이면 상대의 답장을 기다리는 중이다
+	query := `
+		SELECT id, thread_id, subject, to_emails, email_date
+		FROM (
+			SELECT DISTINCT ON (thread_id) id, thread_id, subject, to_emails, email_date, folder
+			FROM emails
+			WHERE user_id = $1 AND thread_id IS NOT NULL AND email_date >= $2
+			  AND folder NOT IN ('trash', 'spam', 'drafts') AND deleted_at IS NULL
+			ORDER BY thread_id, email_date DESC
+		) latest
+		WHERE folder = 'sent' AND email_date < $3
+		ORDER BY email_date ASC
+		LIMIT $4
+	`
+
+	var rows []struct {
+		ID       int64          `db:"id"`
+		ThreadID int64          `db:"thread_id"`
+		Subject  sql.NullString `db:"subject"`
+		To       pq.StringArray `db:"to_emails"`
+		SentAt   time.Time      `db:"email_date"`
+	}
+	if err := a.db.SelectContext(ctx, &rows, query, userID, sentAfter, sentBefore, limit); err != nil {
+		return nil, fmt.Errorf("failed to list awaiting reply threads: %w", err)
+	}
+
+	threads := make([]domain.BriefingThread, len(rows))
+	for i, r := range rows {
+		threads[i] = domain.BriefingThread{
+			ThreadID: r.ThreadID,
+			EmailID:  r.ID,
+			Subject:  r.Subject.String,
+			To:       []string(r.To),
+			SentAt:   r.SentAt,
+		}
+	}
+	return threads, nil
+}
+
+var _ out.BriefingRepository = (*BriefingAdapter)(nil)
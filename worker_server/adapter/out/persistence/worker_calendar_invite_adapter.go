@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// CalendarInviteAdapter implements out.CalendarInviteRepository using PostgreSQL.
+type CalendarInviteAdapter struct {
+	db *sqlx.DB
+}
+
+// NewCalendarInviteAdapter creates a new CalendarInviteAdapter.
+func NewCalendarInviteAdapter(db *sqlx.DB) *CalendarInviteAdapter {
+	return &CalendarInviteAdapter{db: db}
+}
+
+// calendarInviteRow represents the database row for a calendar invite.
+type calendarInviteRow struct {
+	EmailID     int64          `db:"email_id"`
+	UserID      uuid.UUID      `db:"user_id"`
+	UID         string         `db:"uid"`
+	Sequence    int            `db:"sequence"`
+	Method      string         `db:"method"`
+	Summary     string         `db:"summary"`
+	Location    sql.NullString `db:"location"`
+	Organizer   string         `db:"organizer"`
+	Attendee    string         `db:"attendee"`
+	StartTime   sql.NullTime   `db:"start_time"`
+	EndTime     sql.NullTime   `db:"end_time"`
+	IsAllDay    bool           `db:"is_all_day"`
+	Response    string         `db:"response"`
+	RespondedAt sql.NullTime   `db:"responded_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+func (r *calendarInviteRow) toDomain() *domain.CalendarInvite {
+	invite := &domain.CalendarInvite{
+		EmailID:   r.EmailID,
+		UserID:    r.UserID,
+		UID:       r.UID,
+		Sequence:  r.Sequence,
+		Method:    r.Method,
+		Summary:   r.Summary,
+		Location:  r.Location.String,
+		Organizer: r.Organizer,
+		Attendee:  r.Attendee,
+		IsAllDay:  r.IsAllDay,
+		Response:  r.Response,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	if r.StartTime.Valid {
+		invite.StartTime = &r.StartTime.Time
+	}
+	if r.EndTime.Valid {
+		invite.EndTime = &r.EndTime.Time
+	}
+	if r.RespondedAt.Valid {
+		invite.RespondedAt = &r.RespondedAt.Time
+	}
+	return invite
+}
+
+// Get returns the invite extracted from an email of the user.
+func (a *CalendarInviteAdapter) Get(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.CalendarInvite, error) {
+	query := `
+		SELECT email_id, user_id, uid, sequence, method, summary, location, organizer, attendee,
+			start_time, end_time, is_all_day, response, responded_at, created_at, updated_at
+		FROM email_calendar_invites
+		WHERE email_id = $1 AND user_id = $2
+	`
+	var row calendarInviteRow
+	if err := a.db.GetContext(ctx, &row, query, emailID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, out.ErrCalendarInviteNotFound
+		}
+		return nil, fmt.Errorf("failed to get calendar invite: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// Save upserts the invite and sets its timestamps.
+func (a *CalendarInviteAdapter) Save(ctx context.Context, invite *domain.CalendarInvite) error {
+	query := `
+		INSERT INTO email_calendar_invites (email_id, user_id, uid, sequence, method, summary, location, organizer,
+			attendee, start_time, end_time, is_all_day, response, responded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (email_id) DO UPDATE SET
+			uid = EXCLUDED.uid,
+			sequence = EXCLUDED.sequence,
+			method = EXCLUDED.method,
+			summary = EXCLUDED.summary,
+			location = EXCLUDED.location,
+			organizer = EXCLUDED.organizer,
+			attendee = EXCLUDED.attendee,
+			start_time = EXCLUDED.start_time,
+			end_time = EXCLUDED.end_time,
+			is_all_day = EXCLUDED.is_all_day,
+			response = EXCLUDED.response,
+			responded_at = EXCLUDED.responded_at,
+			updated_at = NOW()
+		WHERE email_calendar_invites.user_id = EXCLUDED.user_id
+		RETURNING created_at, updated_at
+	`
+	err := a.db.QueryRowxContext(ctx, query,
+		invite.EmailID, invite.UserID, invite.UID, invite.Sequence, invite.Method, invite.Summary, invite.Location,
+		invite.Organizer, invite.Attendee, invite.StartTime, invite.EndTime, invite.IsAllDay, invite.Response, invite.RespondedAt,
+	).Scan(&invite.CreatedAt, &invite.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return out.ErrCalendarInviteNotFound
+		}
+		return fmt.Errorf("failed to save calendar invite: %w", err)
+	}
+	return nil
+}
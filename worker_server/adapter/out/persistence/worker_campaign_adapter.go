@@ -0,0 +1,366 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// CampaignAdapter implements out.CampaignRepository using PostgreSQL.
+type CampaignAdapter struct {
+	db *sqlx.DB
+}
+
+// NewCampaignAdapter creates a new CampaignAdapter.
+func NewCampaignAdapter(db *sqlx.DB) *CampaignAdapter {
+	return &CampaignAdapter{db: db}
+}
+
+// campaignRow represents the database row for email campaigns.
+type campaignRow struct {
+	ID           int64          `db:"id"`
+	UserID       uuid.UUID      `db:"user_id"`
+	ConnectionID int64          `db:"connection_id"`
+	TemplateID   sql.NullInt64  `db:"template_id"`
+	Name         string         `db:"name"`
+	Subject      string         `db:"subject"`
+	Body         string         `db:"body"`
+	HTMLBody     string         `db:"html_body"`
+	UseSignature bool           `db:"use_signature"`
+	ThrottleMs   int            `db:"throttle_ms"`
+	Status       string         `db:"status"`
+	TotalCount   int            `db:"total_count"`
+	SentCount    int            `db:"sent_count"`
+	FailedCount  int            `db:"failed_count"`
+	BouncedCount int            `db:"bounced_count"`
+	ErrorMessage sql.NullString `db:"error_message"`
+	StartedAt    sql.NullTime   `db:"started_at"`
+	CompletedAt  sql.NullTime   `db:"completed_at"`
+	CreatedAt    sql.NullTime   `db:"created_at"`
+	UpdatedAt    sql.NullTime   `db:"updated_at"`
+}
+
+const campaignColumns = `
+	id, user_id, connection_id, template_id, name, subject, body, html_body,
+	use_signature, throttle_ms, status, total_count, sent_count, failed_count, bounced_count,
+	error_message, started_at, completed_at, created_at, updated_at`
+
+func (r *campaignRow) toDomain() *domain.EmailCampaign {
+	campaign := &domain.EmailCampaign{
+		ID:           r.ID,
+		UserID:       r.UserID,
+		ConnectionID: r.ConnectionID,
+		Name:         r.Name,
+		Subject:      r.Subject,
+		Body:         r.Body,
+		HTMLBody:     r.HTMLBody,
+		UseSignature: r.UseSignature,
+		ThrottleMs:   r.ThrottleMs,
+		Status:       domain.CampaignStatus(r.Status),
+		TotalCount:   r.TotalCount,
+		SentCount:    r.SentCount,
+		FailedCount:  r.FailedCount,
+		BouncedCount: r.BouncedCount,
+	}
+
+	if r.TemplateID.Valid {
+		campaign.TemplateID = &r.TemplateID.Int64
+	}
+	if r.ErrorMessage.Valid {
+		campaign.ErrorMessage = r.ErrorMessage.String
+	}
+	if r.StartedAt.Valid {
+		campaign.StartedAt = &r.StartedAt.Time
+	}
+	if r.CompletedAt.Valid {
+		campaign.CompletedAt = &r.CompletedAt.Time
+	}
+	if r.CreatedAt.Valid {
+		campaign.CreatedAt = r.CreatedAt.Time
+	}
+	if r.UpdatedAt.Valid {
+		campaign.UpdatedAt = r.UpdatedAt.Time
+	}
+
+	return campaign
+}
+
+// campaignRecipientRow represents the database row for campaign recipients.
+type campaignRecipientRow struct {
+	ID                int64          `db:"id"`
+	CampaignID        int64          `db:"campaign_id"`
+	Email             string         `db:"email"`
+	Name              sql.NullString `db:"name"`
+	Variables         []byte         `db:"variables"` // JSONB
+	Status            string         `db:"status"`
+	ProviderMessageID sql.NullString `db:"provider_message_id"`
+	ErrorMessage      sql.NullString `db:"error_message"`
+	SentAt            sql.NullTime   `db:"sent_at"`
+}
+
+const campaignRecipientColumns = `
+	id, campaign_id, email, name, variables, status, provider_message_id, error_message, sent_at`
+
+func (r *campaignRecipientRow) toDomain() *domain.CampaignRecipient {
+	recipient := &domain.CampaignRecipient{
+		ID:         r.ID,
+		CampaignID: r.CampaignID,
+		Email:      r.Email,
+		Status:     domain.CampaignRecipientStatus(r.Status),
+	}
+
+	if r.Name.Valid {
+		recipient.Name = r.Name.String
+	}
+	if len(r.Variables) > 0 {
+		_ = json.Unmarshal(r.Variables, &recipient.Variables)
+	}
+	if r.ProviderMessageID.Valid {
+		recipient.ProviderMessageID = r.ProviderMessageID.String
+	}
+	if r.ErrorMessage.Valid {
+		recipient.ErrorMessage = r.ErrorMessage.String
+	}
+	if r.SentAt.Valid {
+		recipient.SentAt = &r.SentAt.Time
+	}
+
+	return recipient
+}
+
+// Create stores a campaign together with its recipients.
+func (a *CampaignAdapter) Create(ctx context.Context, campaign *domain.EmailCampaign, recipients []*domain.CampaignRecipient) error {
+	if campaign.Status == "" {
+		campaign.Status = domain.CampaignPending
+	}
+	campaign.TotalCount = len(recipients)
+
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO email_campaigns (
+			user_id, connection_id, template_id, name, subject, body, html_body,
+			use_signature, throttle_ms, status, total_count
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at, updated_at
+	`
+
+	var templateID sql.NullInt64
+	if campaign.TemplateID != nil {
+		templateID = sql.NullInt64{Int64: *campaign.TemplateID, Valid: true}
+	}
+
+	if err := tx.QueryRowxContext(ctx, query,
+		campaign.UserID,
+		campaign.ConnectionID,
+		templateID,
+		campaign.Name,
+		campaign.Subject,
+		campaign.Body,
+		campaign.HTMLBody,
+		campaign.UseSignature,
+		campaign.ThrottleMs,
+		campaign.Status,
+		campaign.TotalCount,
+	).Scan(&campaign.ID, &campaign.CreatedAt, &campaign.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create campaign: %w", err)
+	}
+
+	stmt, err := tx.PreparexContext(ctx, `
+		INSERT INTO email_campaign_recipients (campaign_id, email, name, variables, status)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+		RETURNING id
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range recipients {
+		variables, err := json.Marshal(r.Variables)
+		if err != nil {
+			return err
+		}
+		r.CampaignID = campaign.ID
+		r.Status = domain.RecipientPending
+		if err := stmt.QueryRowxContext(ctx, campaign.ID, r.Email, r.Name, variables, r.Status).Scan(&r.ID); err != nil {
+			return fmt.Errorf("failed to create campaign recipient: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID retrieves a campaign owned by the user.
+func (a *CampaignAdapter) GetByID(ctx context.Context, userID uuid.UUID, id int64) (*domain.EmailCampaign, error) {
+	query := `SELECT ` + campaignColumns + ` FROM email_campaigns WHERE id = $1 AND user_id = $2`
+
+	var row campaignRow
+	if err := a.db.GetContext(ctx, &row, query, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// ListByUser lists recent campaigns for a user.
+func (a *CampaignAdapter) ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.EmailCampaign, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	query := `SELECT ` + campaignColumns + `
+		FROM email_campaigns
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	var rows []campaignRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+
+	campaigns := make([]*domain.EmailCampaign, len(rows))
+	for i := range rows {
+		campaigns[i] = rows[i].toDomain()
+	}
+	return campaigns, nil
+}
+
+// ListRecipients lists recipients of a campaign, optionally filtered by status.
+func (a *CampaignAdapter) ListRecipients(ctx context.Context, campaignID int64, status domain.CampaignRecipientStatus, limit, offset int) ([]*domain.CampaignRecipient, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	query := `SELECT ` + campaignRecipientColumns + `
+		FROM email_campaign_recipients
+		WHERE campaign_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id
+		LIMIT $3 OFFSET $4`
+
+	return a.selectRecipients(ctx, query, campaignID, string(status), limit, offset)
+}
+
+// NextPendingRecipients returns the next recipients to send.
+func (a *CampaignAdapter) NextPendingRecipients(ctx context.Context, campaignID int64, limit int) ([]*domain.CampaignRecipient, error) {
+	query := `SELECT ` + campaignRecipientColumns + `
+		FROM email_campaign_recipients
+		WHERE campaign_id = $1 AND status = $2
+		ORDER BY id
+		LIMIT $3`
+
+	return a.selectRecipients(ctx, query, campaignID, domain.RecipientPending, limit)
+}
+
+func (a *CampaignAdapter) selectRecipients(ctx context.Context, query string, args ...interface{}) ([]*domain.CampaignRecipient, error) {
+	var rows []campaignRecipientRow
+	if err := a.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list campaign recipients: %w", err)
+	}
+
+	recipients := make([]*domain.CampaignRecipient, len(rows))
+	for i := range rows {
+		recipients[i] = rows[i].toDomain()
+	}
+	return recipients, nil
+}
+
+// MarkRecipientSent records a successful send.
+func (a *CampaignAdapter) MarkRecipientSent(ctx context.Context, recipientID int64, providerMessageID string) error {
+	query := `
+		UPDATE email_campaign_recipients
+		SET status = $2, provider_message_id = NULLIF($3, ''), error_message = NULL, sent_at = NOW()
+		WHERE id = $1
+	`
+	_, err := a.db.ExecContext(ctx, query, recipientID, domain.RecipientSent, providerMessageID)
+	return err
+}
+
+// MarkRecipientFailed records a failed send.
+func (a *CampaignAdapter) MarkRecipientFailed(ctx context.Context, recipientID int64, errMsg string) error {
+	query := `UPDATE email_campaign_recipients SET status = $2, error_message = $3 WHERE id = $1`
+	_, err := a.db.ExecContext(ctx, query, recipientID, domain.RecipientFailed, errMsg)
+	return err
+}
+
+// MarkRecipientBounced marks a sent recipient as bounced and refreshes counts of its campaign.
+func (a *CampaignAdapter) MarkRecipientBounced(ctx context.Context, providerMessageID, email, reason string) error {
+	query := `
+		UPDATE email_campaign_recipients
+		SET status = $3, error_message = NULLIF($4, '')
+		WHERE provider_message_id = $1 AND email = $2 AND status = $5
+		RETURNING campaign_id
+	`
+
+	var campaignIDs []int64
+	if err := a.db.SelectContext(ctx, &campaignIDs, query,
+		providerMessageID, strings.ToLower(email), domain.RecipientBounced, reason, domain.RecipientSent); err != nil {
+		return err
+	}
+	for _, id := range campaignIDs {
+		if err := a.RefreshCounts(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarkSending marks the campaign as started (started_at is kept on resume).
+func (a *CampaignAdapter) MarkSending(ctx context.Context, id int64) error {
+	query := `
+		UPDATE email_campaigns
+		SET status = $2, started_at = COALESCE(started_at, NOW()), updated_at = NOW()
+		WHERE id = $1 AND status IN ($3, $2)
+	`
+	_, err := a.db.ExecContext(ctx, query, id, domain.CampaignSending, domain.CampaignPending)
+	return err
+}
+
+// RefreshCounts recomputes counters from recipient states.
+func (a *CampaignAdapter) RefreshCounts(ctx context.Context, id int64) error {
+	query := `
+		UPDATE email_campaigns c
+		SET sent_count = s.sent, failed_count = s.failed, bounced_count = s.bounced, updated_at = NOW()
+		FROM (
+			SELECT
+				COUNT(*) FILTER (WHERE status = 'sent') AS sent,
+				COUNT(*) FILTER (WHERE status = 'failed') AS failed,
+				COUNT(*) FILTER (WHERE status = 'bounced') AS bounced
+			FROM email_campaign_recipients
+			WHERE campaign_id = $1
+		) s
+		WHERE c.id = $1
+	`
+	_, err := a.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// MarkFinished sets a terminal status. 이미 종료된 캠페인(예: 취소)은 덮어쓰지 않는다.
+func (a *CampaignAdapter) MarkFinished(ctx context.Context, id int64, status domain.CampaignStatus, errMsg string) error {
+	query := `
+		UPDATE email_campaigns
+		SET status = $2, error_message = NULLIF($3, ''), completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status IN ($4, $5)
+	`
+	_, err := a.db.ExecContext(ctx, query, id, status, errMsg, domain.CampaignPending, domain.CampaignSending)
+	return err
+}
+
+var _ out.CampaignRepository = (*CampaignAdapter)(nil)
@@ -0,0 +1,144 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// ClassifyAttemptAdapter implements out.ClassifyAttemptRepository using PostgreSQL.
+type ClassifyAttemptAdapter struct {
+	db *sqlx.DB
+}
+
+// NewClassifyAttemptAdapter creates a new ClassifyAttemptAdapter.
+func NewClassifyAttemptAdapter(db *sqlx.DB) *ClassifyAttemptAdapter {
+	return &ClassifyAttemptAdapter{db: db}
+}
+
+type classifyAttemptRow struct {
+	ID        int64          `db:"id"`
+	EmailID   int64          `db:"email_id"`
+	UserID    uuid.UUID      `db:"user_id"`
+	Mode      string         `db:"mode"`
+	Model     sql.NullString `db:"model"`
+	Succeeded bool           `db:"succeeded"`
+	Stage     sql.NullString `db:"stage"`
+	Error     sql.NullString `db:"error"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (r *classifyAttemptRow) toDomain() *domain.ClassifyAttempt {
+	return &domain.ClassifyAttempt{
+		ID:        r.ID,
+		EmailID:   r.EmailID,
+		UserID:    r.UserID,
+		Mode:      r.Mode,
+		Model:     r.Model.String,
+		Succeeded: r.Succeeded,
+		Stage:     r.Stage.String,
+		Error:     r.Error.String,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// Record inserts attempts in a single statement.
+func (a *ClassifyAttemptAdapter) Record(ctx context.Context, attempts ...*domain.ClassifyAttempt) error {
+	if len(attempts) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(attempts))
+	args := make([]any, 0, len(attempts)*7)
+	for _, at := range attempts {
+		n := len(args)
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7))
+		args = append(args, at.EmailID, at.UserID, at.Mode, toNullableString(at.Model), at.Succeeded,
+			toNullableString(at.Stage), toNullableString(at.Error))
+	}
+
+	query := `INSERT INTO ai_classify_attempts (email_id, user_id, mode, model, succeeded, stage, error) VALUES ` + strings.Join(values, ", ")
+	if _, err := a.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to record classify attempts: %w", err)
+	}
+	return nil
+}
+
+// ListByEmail returns the attempts of an email, newest first.
+func (a *ClassifyAttemptAdapter) ListByEmail(ctx context.Context, userID uuid.UUID, emailID int64, limit int) ([]*domain.ClassifyAttempt, error) {
+	query := `
+		SELECT id, email_id, user_id, mode, model, succeeded, stage, error, created_at
+		FROM ai_classify_attempts
+		WHERE email_id = $1 AND user_id = $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
+	var rows []classifyAttemptRow
+	if err := a.db.SelectContext(ctx, &rows, query, emailID, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list classify attempts: %w", err)
+	}
+
+	attempts := make([]*domain.ClassifyAttempt, len(rows))
+	for i := range rows {
+		attempts[i] = rows[i].toDomain()
+	}
+	return attempts, nil
+}
+
+// ListFailed returns emails with ai_status='failed' and their last failure reason.
+func (a *ClassifyAttemptAdapter) ListFailed(ctx context.Context, userID uuid.UUID, connectionID int64, limit int) ([]*domain.FailedClassification, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT e.id, e.connection_id, COALESCE(e.subject, '') AS subject, COALESCE(e.from_email, '') AS from_email,
+			last.error, last.created_at, COALESCE(cnt.attempts, 0) AS attempts
+		FROM emails e
+		LEFT JOIN LATERAL (
+			SELECT error, created_at FROM ai_classify_attempts
+			WHERE email_id = e.id
+			ORDER BY created_at DESC, id DESC
+			LIMIT 1
+		) last ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS attempts FROM ai_classify_attempts WHERE email_id = e.id
+		) cnt ON TRUE
+		WHERE e.user_id = $1 AND ($2::bigint = 0 OR e.connection_id = $2)
+			AND e.deleted_at IS NULL AND e.ai_status = 'failed'
+		ORDER BY e.updated_at DESC
+		LIMIT $3
+	`
+	rows, err := a.db.QueryxContext(ctx, query, userID, connectionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed classifications: %w", err)
+	}
+	defer rows.Close()
+
+	var failed []*domain.FailedClassification
+	for rows.Next() {
+		var (
+			f         domain.FailedClassification
+			lastError sql.NullString
+			lastAt    sql.NullTime
+		)
+		if err := rows.Scan(&f.EmailID, &f.ConnectionID, &f.Subject, &f.FromEmail, &lastError, &lastAt, &f.Attempts); err != nil {
+			return nil, fmt.Errorf("failed to scan failed classification: %w", err)
+		}
+		f.Error = lastError.String
+		if lastAt.Valid {
+			f.LastAttemptAt = &lastAt.Time
+		}
+		failed = append(failed, &f)
+	}
+	return failed, rows.Err()
+}
+
+var _ out.ClassifyAttemptRepository = (*ClassifyAttemptAdapter)(nil)
@@ -0,0 +1,222 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ConnectionStorageAdapter implements out.ConnectionStorageRepository using PostgreSQL.
+type ConnectionStorageAdapter struct {
+	db *sqlx.DB
+}
+
+// NewConnectionStorageAdapter creates a new ConnectionStorageAdapter.
+func NewConnectionStorageAdapter(db *sqlx.DB) *ConnectionStorageAdapter {
+	return &ConnectionStorageAdapter{db: db}
+}
+
+type connectionStorageEntity struct {
+	ConnectionID      int64         `db:"connection_id"`
+	UserID            string        `db:"user_id"`
+	MetadataCount     int64         `db:"metadata_count"`
+	MetadataBytes     int64         `db:"metadata_bytes"`
+	BodyCount         int64         `db:"body_count"`
+	BodyBytes         int64         `db:"body_bytes"`
+	AttachmentCount   int64         `db:"attachment_count"`
+	AttachmentBytes   int64         `db:"attachment_bytes"`
+	VectorCount       int64         `db:"vector_count"`
+	VectorBytes       int64         `db:"vector_bytes"`
+	SyncMode          string        `db:"sync_mode"`
+	CapBytes          sql.NullInt64 `db:"cap_bytes"`
+	MetadataOnly      bool          `db:"metadata_only"`
+	MetadataOnlySince sql.NullTime  `db:"metadata_only_since"`
+	MeasuredAt        sql.NullTime  `db:"measured_at"`
+	Local             bool          `db:"local"`
+}
+
+func (e *connectionStorageEntity) toDomain() *domain.ConnectionStorage {
+	s := &domain.ConnectionStorage{
+		ConnectionID:      e.ConnectionID,
+		UserID:            e.UserID,
+		Metadata:          domain.StorageUsage{Count: e.MetadataCount, Bytes: e.MetadataBytes},
+		Bodies:            domain.StorageUsage{Count: e.BodyCount, Bytes: e.BodyBytes},
+		Attachments:       domain.StorageUsage{Count: e.AttachmentCount, Bytes: e.AttachmentBytes},
+		Vectors:           domain.StorageUsage{Count: e.VectorCount, Bytes: e.VectorBytes},
+		SyncMode:          domain.ConnectionSyncMode(e.SyncMode),
+		MetadataOnly:      e.MetadataOnly,
+		MetadataOnlySince: nullTimePtr(e.MetadataOnlySince),
+		MeasuredAt:        nullTimePtr(e.MeasuredAt),
+		Local:             e.Local,
+	}
+	if e.CapBytes.Valid {
+		v := e.CapBytes.Int64
+		s.CapBytes = &v
+	}
+	return s
+}
+
+// MeasureDB fills metadata, attachment and vector usage of the connection.
+// 행 크기는 pg_column_size 기준 (TOAST 압축 후, 인덱스 제외).
+func (a *ConnectionStorageAdapter) MeasureDB(ctx context.Context, usage *domain.ConnectionStorage) error {
+	var r struct {
+		MetadataCount   int64 `db:"metadata_count"`
+		MetadataBytes   int64 `db:"metadata_bytes"`
+		VectorCount     int64 `db:"vector_count"`
+		VectorBytes     int64 `db:"vector_bytes"`
+		AttachmentCount int64 `db:"attachment_count"`
+		AttachmentBytes int64 `db:"attachment_bytes"`
+	}
+	query := `
+		SELECT
+			COUNT(*) AS metadata_count,
+			COALESCE(SUM(pg_column_size(e.*) - COALESCE(pg_column_size(e.embedding), 0)), 0) AS metadata_bytes,
+			COUNT(e.embedding) AS vector_count,
+			COALESCE(SUM(pg_column_size(e.embedding)), 0) AS vector_bytes,
+			COALESCE((
+				SELECT COUNT(*) FROM email_attachments a
+				JOIN emails ae ON ae.id = a.email_id
+				WHERE ae.connection_id = $1
+			), 0) AS attachment_count,
+			COALESCE((
+				SELECT SUM(pg_column_size(a.*)) FROM email_attachments a
+				JOIN emails ae ON ae.id = a.email_id
+				WHERE ae.connection_id = $1
+			), 0) AS attachment_bytes
+		FROM emails e
+		WHERE e.connection_id = $1
+	`
+	if err := a.db.GetContext(ctx, &r, query, usage.ConnectionID); err != nil {
+		return fmt.Errorf("failed to measure connection storage: %w", err)
+	}
+
+	usage.Metadata = domain.StorageUsage{Count: r.MetadataCount, Bytes: r.MetadataBytes}
+	usage.Vectors = domain.StorageUsage{Count: r.VectorCount, Bytes: r.VectorBytes}
+	usage.Attachments = domain.StorageUsage{Count: r.AttachmentCount, Bytes: r.AttachmentBytes}
+	return nil
+}
+
+// Get returns the last measurement of the connection.
+func (a *ConnectionStorageAdapter) Get(ctx context.Context, connectionID int64) (*domain.ConnectionStorage, error) {
+	var e connectionStorageEntity
+	query := `
+		SELECT c.*, COALESCE(oc.provider = 'local', FALSE) AS local
+		FROM connection_storage c
+		LEFT JOIN oauth_connections oc ON oc.id = c.connection_id
+		WHERE c.connection_id = $1
+	`
+	err := a.db.GetContext(ctx, &e, query, connectionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection storage: %w", err)
+	}
+	return e.toDomain(), nil
+}
+
+// Save upserts the measured usage and mode. cap_bytes/sync_mode는 SetCap/SetSyncMode로만 바꾼다.
+func (a *ConnectionStorageAdapter) Save(ctx context.Context, usage *domain.ConnectionStorage) error {
+	query := `
+		INSERT INTO connection_storage (
+			connection_id, user_id, metadata_count, metadata_bytes, body_count, body_bytes,
+			attachment_count, attachment_bytes, vector_count, vector_bytes,
+			metadata_only, metadata_only_since, measured_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (connection_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			metadata_count = EXCLUDED.metadata_count,
+			metadata_bytes = EXCLUDED.metadata_bytes,
+			body_count = EXCLUDED.body_count,
+			body_bytes = EXCLUDED.body_bytes,
+			attachment_count = EXCLUDED.attachment_count,
+			attachment_bytes = EXCLUDED.attachment_bytes,
+			vector_count = EXCLUDED.vector_count,
+			vector_bytes = EXCLUDED.vector_bytes,
+			metadata_only = EXCLUDED.metadata_only,
+			metadata_only_since = EXCLUDED.metadata_only_since,
+			measured_at = EXCLUDED.measured_at
+	`
+	_, err := a.db.ExecContext(ctx, query,
+		usage.ConnectionID, usage.UserID,
+		usage.Metadata.Count, usage.Metadata.Bytes, usage.Bodies.Count, usage.Bodies.Bytes,
+		usage.Attachments.Count, usage.Attachments.Bytes, usage.Vectors.Count, usage.Vectors.Bytes,
+		usage.MetadataOnly, usage.MetadataOnlySince, usage.MeasuredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save connection storage: %w", err)
+	}
+	return nil
+}
+
+// SetCap sets the per-connection cap (nil = 기본값).
+func (a *ConnectionStorageAdapter) SetCap(ctx context.Context, connectionID int64, userID string, capBytes *int64) error {
+	query := `
+		INSERT INTO connection_storage (connection_id, user_id, cap_bytes)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (connection_id) DO UPDATE SET cap_bytes = EXCLUDED.cap_bytes
+	`
+	if _, err := a.db.ExecContext(ctx, query, connectionID, userID, capBytes); err != nil {
+		return fmt.Errorf("failed to set storage cap: %w", err)
+	}
+	return nil
+}
+
+// SetSyncMode sets the user's sync mode.
+func (a *ConnectionStorageAdapter) SetSyncMode(ctx context.Context, connectionID int64, userID string, mode domain.ConnectionSyncMode) error {
+	query := `
+		INSERT INTO connection_storage (connection_id, user_id, sync_mode)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (connection_id) DO UPDATE SET sync_mode = EXCLUDED.sync_mode
+	`
+	if _, err := a.db.ExecContext(ctx, query, connectionID, userID, string(mode)); err != nil {
+		return fmt.Errorf("failed to set sync mode: %w", err)
+	}
+	return nil
+}
+
+// ListEmailIDs returns email IDs of the connection after afterID (본문/임베딩 정리용 페이지 순회).
+func (a *ConnectionStorageAdapter) ListEmailIDs(ctx context.Context, connectionID, afterID int64, limit int) ([]int64, error) {
+	var ids []int64
+	query := `SELECT id FROM emails WHERE connection_id = $1 AND id > $2 ORDER BY id LIMIT $3`
+	if err := a.db.SelectContext(ctx, &ids, query, connectionID, afterID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list connection emails: %w", err)
+	}
+	return ids, nil
+}
+
+// ListConnections returns synced connections with their stored cap and mode.
+func (a *ConnectionStorageAdapter) ListConnections(ctx context.Context) ([]*domain.ConnectionStorage, error) {
+	var rows []connectionStorageEntity
+	query := `
+		SELECT s.connection_id, s.user_id,
+			COALESCE(c.metadata_count, 0) AS metadata_count, COALESCE(c.metadata_bytes, 0) AS metadata_bytes,
+			COALESCE(c.body_count, 0) AS body_count, COALESCE(c.body_bytes, 0) AS body_bytes,
+			COALESCE(c.attachment_count, 0) AS attachment_count, COALESCE(c.attachment_bytes, 0) AS attachment_bytes,
+			COALESCE(c.vector_count, 0) AS vector_count, COALESCE(c.vector_bytes, 0) AS vector_bytes,
+			COALESCE(c.sync_mode, 'full') AS sync_mode,
+			c.cap_bytes, COALESCE(c.metadata_only, FALSE) AS metadata_only, c.metadata_only_since, c.measured_at,
+			COALESCE(oc.provider = 'local', FALSE) AS local
+		FROM sync_states s
+		LEFT JOIN connection_storage c ON c.connection_id = s.connection_id
+		LEFT JOIN oauth_connections oc ON oc.id = s.connection_id
+		ORDER BY s.connection_id
+	`
+	if err := a.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to list connection storage: %w", err)
+	}
+
+	result := make([]*domain.ConnectionStorage, len(rows))
+	for i := range rows {
+		result[i] = rows[i].toDomain()
+	}
+	return result, nil
+}
+
+var _ out.ConnectionStorageRepository = (*ConnectionStorageAdapter)(nil)
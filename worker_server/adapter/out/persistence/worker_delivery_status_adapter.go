@@ -0,0 +1,131 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// DeliveryStatusAdapter implements out.DeliveryStatusRepository using PostgreSQL.
+type DeliveryStatusAdapter struct {
+	db *sqlx.DB
+}
+
+// NewDeliveryStatusAdapter creates a new DeliveryStatusAdapter.
+func NewDeliveryStatusAdapter(db *sqlx.DB) *DeliveryStatusAdapter {
+	return &DeliveryStatusAdapter{db: db}
+}
+
+// deliveryEventRow represents the database row for delivery events.
+type deliveryEventRow struct {
+	ID                int64          `db:"id"`
+	UserID            uuid.UUID      `db:"user_id"`
+	ConnectionID      int64          `db:"connection_id"`
+	SentEmailID       sql.NullInt64  `db:"sent_email_id"`
+	ReportEmailID     sql.NullInt64  `db:"report_email_id"`
+	OriginalMessageID sql.NullString `db:"original_message_id"`
+	Recipient         string         `db:"recipient"`
+	State             string         `db:"state"`
+	StatusCode        sql.NullString `db:"status_code"`
+	Diagnostic        sql.NullString `db:"diagnostic"`
+	Permanent         bool           `db:"permanent"`
+	ReportingMTA      sql.NullString `db:"reporting_mta"`
+	ReportedAt        sql.NullTime   `db:"reported_at"`
+}
+
+const deliveryEventColumns = `
+	id, user_id, connection_id, sent_email_id, report_email_id, original_message_id,
+	recipient, state, status_code, diagnostic, permanent, reporting_mta, reported_at`
+
+func (r *deliveryEventRow) toDomain() *domain.DeliveryEvent {
+	event := &domain.DeliveryEvent{
+		ID:                r.ID,
+		UserID:            r.UserID,
+		ConnectionID:      r.ConnectionID,
+		ReportEmailID:     r.ReportEmailID.Int64,
+		OriginalMessageID: r.OriginalMessageID.String,
+		Recipient:         r.Recipient,
+		State:             domain.DeliveryState(r.State),
+		StatusCode:        r.StatusCode.String,
+		Diagnostic:        r.Diagnostic.String,
+		Permanent:         r.Permanent,
+		ReportingMTA:      r.ReportingMTA.String,
+	}
+	if r.SentEmailID.Valid {
+		event.SentEmailID = &r.SentEmailID.Int64
+	}
+	if r.ReportedAt.Valid {
+		event.ReportedAt = r.ReportedAt.Time
+	}
+	return event
+}
+
+// SaveEvents stores report events, resolving the sent email by Message-ID.
+// 같은 리포트 메일이 재동기화되어도 (report_email_id, recipient) 기준으로 중복 저장하지 않는다.
+func (a *DeliveryStatusAdapter) SaveEvents(ctx context.Context, events []*domain.DeliveryEvent) error {
+	query := `
+		INSERT INTO email_delivery_events (
+			user_id, connection_id, sent_email_id, report_email_id, original_message_id,
+			recipient, state, status_code, diagnostic, permanent, reporting_mta, reported_at
+		) VALUES (
+			$1, $2,
+			(SELECT id FROM emails WHERE connection_id = $2 AND message_id = $4 AND $4 <> '' ORDER BY id LIMIT 1),
+			NULLIF($3, 0), NULLIF($4, ''),
+			$5, $6, NULLIF($7, ''), NULLIF($8, ''), $9, NULLIF($10, ''), $11
+		)
+		ON CONFLICT (report_email_id, recipient) DO UPDATE SET
+			sent_email_id = COALESCE(email_delivery_events.sent_email_id, EXCLUDED.sent_email_id)
+		RETURNING id, sent_email_id
+	`
+
+	for _, e := range events {
+		var sentEmailID sql.NullInt64
+		if err := a.db.QueryRowxContext(ctx, query,
+			e.UserID,
+			e.ConnectionID,
+			e.ReportEmailID,
+			e.OriginalMessageID,
+			e.Recipient,
+			e.State,
+			e.StatusCode,
+			e.Diagnostic,
+			e.Permanent,
+			e.ReportingMTA,
+			e.ReportedAt,
+		).Scan(&e.ID, &sentEmailID); err != nil {
+			return fmt.Errorf("failed to save delivery event: %w", err)
+		}
+		if sentEmailID.Valid {
+			e.SentEmailID = &sentEmailID.Int64
+		}
+	}
+	return nil
+}
+
+// ListForSentEmail returns events linked to the sent email or to its Message-ID.
+func (a *DeliveryStatusAdapter) ListForSentEmail(ctx context.Context, userID uuid.UUID, connectionID, sentEmailID int64, messageID string) ([]*domain.DeliveryEvent, error) {
+	query := `SELECT ` + deliveryEventColumns + `
+		FROM email_delivery_events
+		WHERE user_id = $1
+		  AND (sent_email_id = $3 OR ($4 <> '' AND connection_id = $2 AND original_message_id = $4))
+		ORDER BY reported_at`
+
+	var rows []deliveryEventRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID, connectionID, sentEmailID, messageID); err != nil {
+		return nil, fmt.Errorf("failed to list delivery events: %w", err)
+	}
+
+	events := make([]*domain.DeliveryEvent, len(rows))
+	for i := range rows {
+		events[i] = rows[i].toDomain()
+	}
+	return events, nil
+}
+
+var _ out.DeliveryStatusRepository = (*DeliveryStatusAdapter)(nil)
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// EmailActivityAdapter implements out.EmailActivityRepository using PostgreSQL.
+type EmailActivityAdapter struct {
+	db *sqlx.DB
+}
+
+// NewEmailActivityAdapter creates a new EmailActivityAdapter.
+func NewEmailActivityAdapter(db *sqlx.DB) *EmailActivityAdapter {
+	return &EmailActivityAdapter{db: db}
+}
+
+type emailActivityRow struct {
+	ID        int64     `db:"id"`
+	EmailID   int64     `db:"email_id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Type      string    `db:"type"`
+	Source    string    `db:"source"`
+	Detail    []byte    `db:"detail"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (r *emailActivityRow) toDomain() *domain.EmailActivity {
+	activity := &domain.EmailActivity{
+		ID:        r.ID,
+		EmailID:   r.EmailID,
+		UserID:    r.UserID,
+		Type:      r.Type,
+		Source:    r.Source,
+		CreatedAt: r.CreatedAt,
+	}
+	if len(r.Detail) > 0 {
+		_ = json.Unmarshal(r.Detail, &activity.Detail)
+	}
+	return activity
+}
+
+// Record inserts activities in a single statement.
+func (a *EmailActivityAdapter) Record(ctx context.Context, activities ...*domain.EmailActivity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(activities))
+	args := make([]any, 0, len(activities)*5)
+	for _, act := range activities {
+		var detail []byte
+		if len(act.Detail) > 0 {
+			var err error
+			if detail, err = json.Marshal(act.Detail); err != nil {
+				return fmt.Errorf("failed to marshal activity detail: %w", err)
+			}
+		}
+		n := len(args)
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5))
+		args = append(args, act.EmailID, act.UserID, act.Type, act.Source, detail)
+	}
+
+	query := `INSERT INTO email_activity (email_id, user_id, type, source, detail) VALUES ` + strings.Join(values, ", ")
+	if _, err := a.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to record email activity: %w", err)
+	}
+	return nil
+}
+
+// ListByEmail returns the activities of an email, oldest first.
+func (a *EmailActivityAdapter) ListByEmail(ctx context.Context, userID uuid.UUID, emailID int64, limit int) ([]*domain.EmailActivity, error) {
+	query := `
+		SELECT id, email_id, user_id, type, source, detail, created_at
+		FROM email_activity
+		WHERE email_id = $1 AND user_id = $2
+		ORDER BY created_at, id
+		LIMIT $3
+	`
+	var rows []emailActivityRow
+	if err := a.db.SelectContext(ctx, &rows, query, emailID, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list email activity: %w", err)
+	}
+
+	activities := make([]*domain.EmailActivity, len(rows))
+	for i := range rows {
+		activities[i] = rows[i].toDomain()
+	}
+	return activities, nil
+}
+
+var _ out.EmailActivityRepository = (*EmailActivityAdapter)(nil)
@@ -21,7 +21,8 @@ import (
 
 // MailAdapter implements out.EmailRepository using PostgreSQL.
 type MailAdapter struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	replica *ReadReplica // 목록/검색/통계 조회 (nil이면 primary)
 }
 
 // NewMailAdapter creates a new MailAdapter.
@@ -29,6 +30,19 @@ func NewMailAdapter(db *sqlx.DB) *MailAdapter {
 	return &MailAdapter{db: db}
 }
 
+// SetReadReplica routes list, search and stats queries to a read replica.
+func (a *MailAdapter) SetReadReplica(replica *ReadReplica) {
+	a.replica = replica
+}
+
+// reader returns the DB for heavy read-only queries. 쓰기와 단건 조회는 a.db를 쓴다.
+func (a *MailAdapter) reader(ctx context.Context) *sqlx.DB {
+	if a.replica == nil {
+		return a.db
+	}
+	return a.replica.Reader(ctx)
+}
+
 // =============================================================================
 // Database Row Mapping
 // =============================================================================
@@ -40,10 +54,66 @@ const mailSelectColumns = `
 	e.provider, e.account_email, e.message_id, e.in_reply_to, e.references,
 	e.from_email, e.from_name, e.to_emails, e.cc_emails, e.bcc_emails,
 	e.subject, e.snippet, e.direction, e.is_read, e.is_draft, e.has_attachment, e.is_replied, e.is_forwarded,
-	e.folder, e.labels, e.tags, e.workflow_status, e.snooze_until,
+	e.folder, e.folder_id, e.labels, e.tags, e.workflow_status, e.snooze_until,
 	e.ai_status, e.ai_category, e.ai_priority, e.ai_summary, e.ai_intent, e.ai_is_urgent,
 	e.ai_due_date, e.ai_action_item, e.ai_sentiment, e.ai_tags,
-	e.contact_id, e.email_date, e.created_at, e.updated_at`
+	e.contact_id, e.language, e.email_date, e.created_at, e.updated_at, e.deleted_at`
+
+// mailBaseColumns are always selected by a projected list (소유자 확인, API 보충 중복 제거, 날짜 필터에 필요).
+var mailBaseColumns = []string{"e.id", "e.user_id", "e.connection_id", "e.external_id", "e.email_date"}
+
+// mailFieldColumns maps Email JSON fields to the columns they are built from (?fields= 프로젝션).
+// 컬럼이 없는 필드(is_pinned, sla_status 등)는 서비스에서 채우므로 여기 없다.
+var mailFieldColumns = map[string][]string{
+	"provider":        {"e.provider"},
+	"account_email":   {"e.account_email"},
+	"thread_id":       {"e.external_thread_id"},
+	"message_id":      {"e.message_id"},
+	"subject":         {"e.subject"},
+	"from_email":      {"e.from_email"},
+	"from_name":       {"e.from_name"},
+	"to_emails":       {"e.to_emails"},
+	"cc_emails":       {"e.cc_emails"},
+	"bcc_emails":      {"e.bcc_emails"},
+	"snippet":         {"e.snippet"},
+	"folder":          {"e.folder"},
+	"labels":          {"e.labels"},
+	"is_read":         {"e.is_read"},
+	"is_starred":      {"e.tags"},
+	"has_attachments": {"e.has_attachment"},
+	"ai_category":     {"e.ai_category"},
+	"ai_priority":     {"e.ai_priority"},
+	"ai_summary":      {"e.ai_summary"},
+	"ai_tags":         {"e.tags"},
+	"urgency":         {"e.ai_priority"}, // ListTodo가 우선순위로 계산
+	"language":        {"e.language"},
+	"workflow_status": {"e.workflow_status"},
+	"snoozed_until":   {"e.snooze_until"},
+	"created_at":      {"e.created_at"},
+	"updated_at":      {"e.updated_at"},
+	"deleted_at":      {"e.deleted_at"},
+}
+
+// mailProjection returns the SELECT list for the requested fields. 비어 있으면 전체 컬럼.
+func mailProjection(fields []string) string {
+	if len(fields) == 0 {
+		return mailSelectColumns
+	}
+	cols := append([]string(nil), mailBaseColumns...)
+	seen := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		seen[col] = true
+	}
+	for _, f := range fields {
+		for _, col := range mailFieldColumns[f] {
+			if !seen[col] {
+				seen[col] = true
+				cols = append(cols, col)
+			}
+		}
+	}
+	return strings.Join(cols, ", ")
+}
 
 // mailRow represents the database row for emails.
 type mailRow struct {
@@ -81,9 +151,10 @@ type mailRow struct {
 	IsForwarded   bool   `db:"is_forwarded"`
 
 	// Organization
-	Folder string         `db:"folder"`
-	Labels pq.StringArray `db:"labels"`
-	Tags   pq.StringArray `db:"tags"`
+	Folder   string         `db:"folder"`
+	FolderID sql.NullInt64  `db:"folder_id"`
+	Labels   pq.StringArray `db:"labels"`
+	Tags     pq.StringArray `db:"tags"`
 
 	// Workflow
 	WorkflowStatus string       `db:"workflow_status"`
@@ -104,13 +175,17 @@ type mailRow struct {
 	// Contact
 	ContactID sql.NullInt64 `db:"contact_id"`
 
+	// Language (분류 시 감지)
+	Language sql.NullString `db:"language"`
+
 	// Embedding (vector)
 	// Note: embedding column은 별도 쿼리로 처리 (pgvector)
 
 	// Timestamps
-	ReceivedAt time.Time `db:"email_date"`
-	CreatedAt  time.Time `db:"created_at"`
-	UpdatedAt  time.Time `db:"updated_at"`
+	ReceivedAt time.Time    `db:"email_date"`
+	CreatedAt  time.Time    `db:"created_at"`
+	UpdatedAt  time.Time    `db:"updated_at"`
+	DeletedAt  sql.NullTime `db:"deleted_at"` // soft delete (복원 가능 기간)
 
 	// Join fields (optional)
 	ContactName    sql.NullString `db:"contact_name"`
@@ -173,9 +248,15 @@ func (r *mailRow) toEntity() *out.MailEntity {
 	if r.ThreadID.Valid {
 		entity.ThreadID = &r.ThreadID.Int64
 	}
+	if r.FolderID.Valid {
+		entity.FolderID = &r.FolderID.Int64
+	}
 	if r.MessageID.Valid {
 		entity.MessageID = r.MessageID.String
 	}
+	if r.ExternalThreadID.Valid {
+		entity.ExternalThreadID = r.ExternalThreadID.String
+	}
 	if r.InReplyTo.Valid {
 		entity.InReplyTo = r.InReplyTo.String
 	}
@@ -207,6 +288,12 @@ func (r *mailRow) toEntity() *out.MailEntity {
 	if r.ContactID.Valid {
 		entity.ContactID = &r.ContactID.Int64
 	}
+	if r.Language.Valid {
+		entity.Language = r.Language.String
+	}
+	if r.DeletedAt.Valid {
+		entity.DeletedAt = &r.DeletedAt.Time
+	}
 
 	return entity
 }
@@ -217,6 +304,10 @@ func (r *mailRow) toEntity() *out.MailEntity {
 
 // Create creates a new email.
 func (a *MailAdapter) Create(ctx context.Context, mail *out.MailEntity) error {
+	if err := a.rehydrateArchived(ctx, mail.ConnectionID, []string{mail.ExternalID}); err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO emails (
 			user_id, connection_id, provider, account_email,
@@ -226,16 +317,17 @@ func (a *MailAdapter) Create(ctx context.Context, mail *out.MailEntity) error {
 			is_read, is_draft, has_attachment, is_replied, is_forwarded,
 			workflow_status, snooze_until,
 			ai_status, ai_category, ai_priority, ai_summary, ai_sentiment, ai_action_item,
-			contact_id, email_date
+			contact_id, email_date, external_thread_id
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14,
 			$15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26,
-			$27, $28, $29, $30, $31, $32, $33, $34
+			$27, $28, $29, $30, $31, $32, $33, $34, $35
 		)
 		ON CONFLICT (user_id, connection_id, external_id) DO UPDATE SET
 			labels = EXCLUDED.labels,
 			is_read = EXCLUDED.is_read,
 			folder = EXCLUDED.folder,
+			external_thread_id = COALESCE(EXCLUDED.external_thread_id, emails.external_thread_id),
 			updated_at = NOW()
 		RETURNING id, created_at, updated_at`
 
@@ -247,7 +339,7 @@ func (a *MailAdapter) Create(ctx context.Context, mail *out.MailEntity) error {
 		mail.IsRead, mail.IsDraft, mail.HasAttachment, mail.IsReplied, mail.IsForwarded,
 		mail.WorkflowStatus, mail.SnoozedUntil,
 		mail.AIStatus, nullStr(mail.Category), nullFloat64(mail.Priority), nullStr(mail.Summary), mail.Sentiment, nullStr(mail.ActionItem),
-		mail.ContactID, mail.ReceivedAt,
+		mail.ContactID, mail.ReceivedAt, nullStr(mail.ExternalThreadID),
 	).Scan(&mail.ID, &mail.CreatedAt, &mail.UpdatedAt)
 }
 
@@ -264,8 +356,9 @@ func (a *MailAdapter) Update(ctx context.Context, mail *out.MailEntity) error {
 			ai_priority = COALESCE($22, ai_priority),
 			ai_summary = $23, ai_sentiment = $24, ai_action_item = $25,
 			ai_score = $26, classification_source = $27,
-			contact_id = $28, updated_at = NOW()
-		WHERE id = $29`
+			classification_stage = COALESCE(NULLIF($28, ''), classification_stage),
+			contact_id = $29, language = COALESCE(NULLIF($30, ''), language), updated_at = NOW()
+		WHERE id = $31`
 
 	result, err := a.db.ExecContext(ctx, query,
 		mail.ThreadID, mail.FromEmail, nullStr(mail.FromName),
@@ -276,7 +369,8 @@ func (a *MailAdapter) Update(ctx context.Context, mail *out.MailEntity) error {
 		mail.AIStatus, nullStr(mail.Category), nullSubCategory(mail.SubCategory), nullFloat64(mail.Priority),
 		nullStr(mail.Summary), mail.Sentiment, nullStr(mail.ActionItem),
 		nullFloat64(mail.AIScore), nullStr(mail.ClassificationSource),
-		mail.ContactID, mail.ID,
+		mail.ClassificationStage,
+		mail.ContactID, mail.Language, mail.ID,
 	)
 	if err != nil {
 		return err
@@ -304,6 +398,9 @@ func (a *MailAdapter) Delete(ctx context.Context, id int64) error {
 
 // GetByID gets an email by ID with contact info.
 func (a *MailAdapter) GetByID(ctx context.Context, id int64) (*out.MailEntity, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
 	query := fmt.Sprintf(`
 		SELECT %s,
 			c.name as contact_name,
@@ -353,6 +450,11 @@ func (a *MailAdapter) GetByExternalIDs(ctx context.Context, connectionID int64,
 		return make(map[string]*out.MailEntity), nil
 	}
 
+	// 아카이브된 메일을 다시 받았으면 새 행으로 저장하지 않도록 먼저 되돌린다
+	if err := a.rehydrateArchived(ctx, connectionID, externalIDs); err != nil {
+		return nil, err
+	}
+
 	query := fmt.Sprintf(`
 		SELECT %s
 		FROM emails e
@@ -385,15 +487,43 @@ func (a *MailAdapter) GetByExternalIDs(ctx context.Context, connectionID int64,
 	return result, nil
 }
 
+// rehydrateArchived moves archived emails with the given external IDs back to the emails table.
+// 동기화 중복 검사와 upsert는 emails만 보므로, 저장 전에 불러야 같은 메일이 두 행이 되지 않는다 (079).
+func (a *MailAdapter) rehydrateArchived(ctx context.Context, connectionID int64, externalIDs []string) error {
+	if len(externalIDs) == 0 {
+		return nil
+	}
+	var ids []int64
+	if err := a.db.SelectContext(ctx, &ids, `SELECT rehydrate_external_emails($1, $2)`, connectionID, pq.Array(externalIDs)); err != nil {
+		return fmt.Errorf("failed to rehydrate archived emails: %w", err)
+	}
+	return nil
+}
+
 // =============================================================================
 // Query Operations
 // =============================================================================
 
 // List lists emails with filters.
+// urgencyOrderExpr mirrors domain.ScoreTodoUrgency (우선순위 0.6 + 마감 근접도 0.4).
+// 구간을 바꾸면 domain.DeadlineProximity도 같이 바꿔야 한다.
+const urgencyOrderExpr = `(0.6 * COALESCE(e.ai_priority, 0.5) + 0.4 * CASE
+			WHEN dl.days_left IS NULL OR dl.days_left < -7 THEN 0
+			WHEN dl.days_left < 0 THEN 0.9
+			WHEN dl.days_left = 0 THEN 1.0
+			WHEN dl.days_left = 1 THEN 0.8
+			WHEN dl.days_left <= 3 THEN 0.6
+			WHEN dl.days_left <= 7 THEN 0.4
+			WHEN dl.days_left <= 14 THEN 0.2
+			ELSE 0 END)`
+
 // List lists emails with filters.
 // 최적화: COUNT(*) OVER() 윈도우 함수로 단일 쿼리 (2번 → 1번으로 감소)
 // Contact JOIN 제거로 쿼리 단순화 (목록에서는 불필요)
 func (a *MailAdapter) List(ctx context.Context, userID uuid.UUID, req *out.MailListQuery) ([]*out.MailEntity, int, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
 	if req == nil {
 		req = &out.MailListQuery{}
 	}
@@ -421,6 +551,7 @@ func (a *MailAdapter) List(ctx context.Context, userID uuid.UUID, req *out.MailL
 	validOrderBy := map[string]bool{
 		"email_date": true, "created_at": true, "updated_at": true,
 		"ai_priority": true, "from_email": true, "subject": true,
+		"urgency": true, "deleted_at": true,
 	}
 	if !validOrderBy[req.OrderBy] {
 		req.OrderBy = "email_date"
@@ -442,16 +573,52 @@ func (a *MailAdapter) List(ctx context.Context, userID uuid.UUID, req *out.MailL
 		orderClause = fmt.Sprintf("e.ai_priority %s, e.email_date DESC", req.Order)
 	}
 
+	// 긴급도 정렬: 사용자 시간대 기준 마감일까지 남은 일수를 LATERAL JOIN으로 계산
+	joinClause := ""
+	if req.OrderBy == "urgency" {
+		tz := "UTC"
+		if _, err := time.LoadLocation(req.Timezone); err == nil && req.Timezone != "" {
+			tz = req.Timezone
+		}
+		joinClause = fmt.Sprintf(`
+		LEFT JOIN LATERAL (
+			SELECT (dl.deadline_at AT TIME ZONE $%d)::date - (NOW() AT TIME ZONE $%d)::date AS days_left
+			FROM email_deadlines dl WHERE dl.email_id = e.id
+		) dl ON TRUE`, argIdx, argIdx)
+		args = append(args, tz)
+		argIdx++
+		orderClause = fmt.Sprintf("%s %s, e.email_date DESC", urgencyOrderExpr, req.Order)
+	}
+
+	// 고정된 메일: 사용자가 정한 고정 순서가 정렬 기준보다 우선
+	switch {
+	case req.PinnedOnly:
+		joinClause += `
+		JOIN email_pins pin ON pin.email_id = e.id`
+		orderClause = "pin.position ASC, pin.pinned_at DESC"
+	case req.PinnedFirst:
+		joinClause += `
+		LEFT JOIN email_pins pin ON pin.email_id = e.id`
+		orderClause = "pin.position ASC NULLS LAST, " + orderClause
+	}
+
+	// 나중에 읽기 큐: 추가한 순서(최신 먼저)
+	if req.ReadLaterOnly {
+		joinClause += `
+		JOIN email_read_later rl ON rl.email_id = e.id`
+		orderClause = "rl.added_at DESC"
+	}
+
 	selectQuery := fmt.Sprintf(`
 		SELECT %s, COUNT(*) OVER() as total_count
-		FROM emails e
+		FROM %s%s
 		WHERE %s
 		ORDER BY %s
 		LIMIT $%d OFFSET $%d`,
-		mailSelectColumns, where, orderClause, argIdx, argIdx+1)
+		mailProjection(req.Fields), mailSource(req), joinClause, where, orderClause, argIdx, argIdx+1)
 	args = append(args, req.Limit, req.Offset)
 
-	rows, err := a.db.QueryxContext(ctx, selectQuery, args...)
+	rows, err := a.reader(ctx).QueryxContext(ctx, selectQuery, args...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -473,40 +640,70 @@ func (a *MailAdapter) List(ctx context.Context, userID uuid.UUID, req *out.MailL
 
 // Search searches emails using PostgreSQL full-text search.
 // 최적화: GIN 인덱스 활용 + 단일 쿼리 + 윈도우 함수
-// 검색 우선순위: 1) Full-text (subject + snippet) 2) From email exact match
-func (a *MailAdapter) Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]*out.MailEntity, int, error) {
+// 검색 우선순위: 1) Full-text (subject + snippet) 2) From email exact match 3) 개인 메모 본문/태그
+func (a *MailAdapter) Search(ctx context.Context, userID uuid.UUID, query string, scope *out.MailListQuery, limit, offset int) ([]*out.MailEntity, int, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
 	if limit <= 0 || limit > 100 {
 		limit = 50
 	}
+	if scope == nil {
+		scope = &out.MailListQuery{}
+	}
 
 	// Full-text search query 생성: "hello world" → "hello:* & world:*"
 	tsQuery := buildTsQuery(query)
 
+	// 메모 태그는 검색어 단어와 정확히 일치할 때 매칭 ("#urgent" → "urgent")
+	var noteTags []string
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if tag := strings.TrimPrefix(word, "#"); tag != "" {
+			noteTags = append(noteTags, tag)
+		}
+	}
+
+	// 범위 필터 ($1 = user_id) 뒤에 검색 인자를 붙인다
+	where, args := a.buildWhereClause(userID, scope)
+	n := len(args)
+	tsArg, likeArg, limitArg, offsetArg, tagsArg := n+1, n+2, n+3, n+4, n+5
+
 	// BM25 스타일 검색 점수 계산:
 	// - 제목 매칭: 가중치 2.0 (제목이 더 중요)
 	// - 본문 매칭: 가중치 1.0
 	// - 발신자 매칭: 가중치 1.5 (발신자 검색도 중요)
+	// - 메모 매칭: 가중치 1.0 (사용자가 직접 남긴 메모)
 	// - ts_rank 정규화: normalization 32 (문서 길이 정규화)
 	selectSQL := fmt.Sprintf(`
-		SELECT %s,
+		WITH note_hits AS (
+			SELECT DISTINCT n.email_id
+			FROM email_notes n
+			WHERE n.user_id = $1
+			AND (to_tsvector('english', n.body) @@ to_tsquery('english', $%[3]d) OR n.tags && $%[7]d)
+		)
+		SELECT %[1]s,
 			COUNT(*) OVER() as total_count,
 			(
-				COALESCE(ts_rank(setweight(to_tsvector('english', e.subject), 'A'), to_tsquery('english', $2), 32), 0) * 2.0 +
-				COALESCE(ts_rank(to_tsvector('english', e.snippet), to_tsquery('english', $2), 32), 0) * 1.0 +
-				CASE WHEN e.from_email ILIKE $3 OR e.from_name ILIKE $3 THEN 0.5 ELSE 0 END
+				COALESCE(ts_rank(setweight(to_tsvector('english', e.subject), 'A'), to_tsquery('english', $%[3]d), 32), 0) * 2.0 +
+				COALESCE(ts_rank(to_tsvector('english', e.snippet), to_tsquery('english', $%[3]d), 32), 0) * 1.0 +
+				CASE WHEN e.from_email ILIKE $%[4]d OR e.from_name ILIKE $%[4]d THEN 0.5 ELSE 0 END +
+				CASE WHEN nh.email_id IS NOT NULL THEN 1.0 ELSE 0 END
 			) as search_score
-		FROM emails e
-		WHERE e.user_id = $1
+		FROM %[8]s
+		LEFT JOIN note_hits nh ON nh.email_id = e.id
+		WHERE %[2]s
 		AND (
-			to_tsvector('english', e.subject || ' ' || e.snippet) @@ to_tsquery('english', $2)
-			OR e.from_email ILIKE $3
-			OR e.from_name ILIKE $3
+			to_tsvector('english', e.subject || ' ' || e.snippet) @@ to_tsquery('english', $%[3]d)
+			OR e.from_email ILIKE $%[4]d
+			OR e.from_name ILIKE $%[4]d
+			OR nh.email_id IS NOT NULL
 		)
 		ORDER BY search_score DESC, e.email_date DESC
-		LIMIT $4 OFFSET $5`, mailSelectColumns)
+		LIMIT $%[5]d OFFSET $%[6]d`, mailSelectColumns, where, tsArg, likeArg, limitArg, offsetArg, tagsArg, mailSource(scope))
 
 	likeQuery := "%" + query + "%"
-	rows, err := a.db.QueryxContext(ctx, selectSQL, userID, tsQuery, likeQuery, limit, offset)
+	args = append(args, tsQuery, likeQuery, limit, offset, pq.Array(noteTags))
+	rows, err := a.reader(ctx).QueryxContext(ctx, selectSQL, args...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -560,13 +757,16 @@ func buildTsQuery(query string) string {
 // ListByContact lists emails by contact ID using window function optimization.
 // Combines 3 queries (contact lookup + COUNT + SELECT) into 2 for ~33% improvement.
 func (a *MailAdapter) ListByContact(ctx context.Context, userID uuid.UUID, contactID int64, limit, offset int) ([]*out.MailEntity, int, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
 	if limit <= 0 || limit > 100 {
 		limit = 50
 	}
 
 	// Get contact email first (필수 - contact 존재 확인)
 	var contactEmail string
-	if err := a.db.QueryRowxContext(ctx, "SELECT email FROM contacts WHERE id = $1 AND user_id = $2", contactID, userID).Scan(&contactEmail); err != nil {
+	if err := a.reader(ctx).QueryRowxContext(ctx, "SELECT email FROM contacts WHERE id = $1 AND user_id = $2", contactID, userID).Scan(&contactEmail); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, 0, fmt.Errorf("contact not found")
 		}
@@ -581,11 +781,11 @@ func (a *MailAdapter) ListByContact(ctx context.Context, userID uuid.UUID, conta
 			NULL as contact_photo,
 			COUNT(*) OVER() as total_count
 		FROM emails e
-		WHERE e.user_id = $1 AND (e.from_email = $2 OR $2 = ANY(e.to_emails))
+		WHERE e.user_id = $1 AND e.deleted_at IS NULL AND (e.from_email = $2 OR $2 = ANY(e.to_emails))
 		ORDER BY e.email_date DESC
 		LIMIT $3 OFFSET $4`, mailSelectColumns)
 
-	rows, err := a.db.QueryxContext(ctx, selectSQL, userID, contactEmail, limit, offset)
+	rows, err := a.reader(ctx).QueryxContext(ctx, selectSQL, userID, contactEmail, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -737,6 +937,90 @@ func (a *MailAdapter) BatchDelete(ctx context.Context, ids []int64) error {
 	return err
 }
 
+// SoftDelete marks the user's emails as deleted and returns the IDs that changed.
+func (a *MailAdapter) SoftDelete(ctx context.Context, userID uuid.UUID, ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var deleted []int64
+	err := a.db.SelectContext(ctx, &deleted,
+		"UPDATE emails SET deleted_at = NOW(), updated_at = NOW() WHERE user_id = $1 AND id = ANY($2) AND deleted_at IS NULL RETURNING id",
+		userID, pq.Array(ids))
+	return deleted, err
+}
+
+// Restore clears deleted_at of the user's emails deleted after since and returns the restored IDs.
+func (a *MailAdapter) Restore(ctx context.Context, userID uuid.UUID, ids []int64, since time.Time) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var restored []int64
+	err := a.db.SelectContext(ctx, &restored,
+		"UPDATE emails SET deleted_at = NULL, updated_at = NOW() WHERE user_id = $1 AND id = ANY($2) AND deleted_at >= $3 RETURNING id",
+		userID, pq.Array(ids), since)
+	return restored, err
+}
+
+// PurgeDeleted permanently deletes up to limit emails soft-deleted before the cutoff.
+func (a *MailAdapter) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	result, err := a.db.ExecContext(ctx, `
+		DELETE FROM emails WHERE id IN (
+			SELECT id FROM emails WHERE deleted_at < $1 ORDER BY deleted_at LIMIT $2
+		)`, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	n, _ := result.RowsAffected()
+	return int(n), nil
+}
+
+// UpdateIfUnchanged applies update to the user's emails whose updated_at is not newer than the expected version.
+// 클라이언트 타임스탬프는 밀리초 정밀도일 수 있어 DB 값을 밀리초로 잘라 비교한다.
+func (a *MailAdapter) UpdateIfUnchanged(ctx context.Context, userID uuid.UUID, versions map[int64]time.Time, update *out.MailBatchUpdate) ([]*out.MailVersionResult, error) {
+	if len(versions) == 0 {
+		return nil, nil
+	}
+	ids := make([]int64, 0, len(versions))
+	stamps := make([]string, 0, len(versions))
+	for id, v := range versions {
+		ids = append(ids, id)
+		stamps = append(stamps, v.UTC().Format(time.RFC3339Nano))
+	}
+	// nil 배열은 NULL이 되어 태그 계산이 깨지므로 빈 배열로 넘긴다
+	addTags := append(pq.StringArray{}, update.AddTags...)
+	removeTags := append(pq.StringArray{}, update.RemoveTags...)
+
+	query := `
+		WITH expected AS (
+			SELECT * FROM unnest($2::bigint[], $3::timestamptz[]) AS x(id, version)
+		), updated AS (
+			UPDATE emails e SET
+				is_read = COALESCE($4::boolean, e.is_read),
+				folder = COALESCE($5::text, e.folder),
+				tags = CASE WHEN cardinality($6::text[]) + cardinality($7::text[]) = 0 THEN e.tags ELSE (
+					SELECT array_agg(DISTINCT t)
+					FROM unnest(array_cat(e.tags, $6::text[])) t
+					WHERE t != ALL($7::text[])
+				) END,
+				updated_at = NOW()
+			FROM expected x
+			WHERE e.id = x.id AND e.user_id = $1 AND e.deleted_at IS NULL
+				AND date_trunc('milliseconds', e.updated_at) <= x.version
+			RETURNING e.id, e.updated_at
+		)
+		SELECT e.id, u.id IS NOT NULL AS updated, COALESCE(u.updated_at, e.updated_at) AS updated_at
+		FROM emails e
+		JOIN expected x ON x.id = e.id
+		LEFT JOIN updated u ON u.id = e.id
+		WHERE e.user_id = $1 AND e.deleted_at IS NULL`
+
+	var results []*out.MailVersionResult
+	err := a.db.SelectContext(ctx, &results, query,
+		userID, pq.Array(ids), pq.StringArray(stamps),
+		update.IsRead, update.Folder, addTags, removeTags)
+	return results, err
+}
+
 // BatchUpdateWorkflowStatus batch updates workflow status (snooze 등).
 func (a *MailAdapter) BatchUpdateWorkflowStatus(ctx context.Context, ids []int64, status string, snoozeUntil *time.Time) error {
 	if len(ids) == 0 {
@@ -755,6 +1039,9 @@ func (a *MailAdapter) BatchUpdateWorkflowStatus(ctx context.Context, ids []int64
 // GetStats gets mail statistics using a single optimized query.
 // Combines 3 separate queries into 1 for ~66% performance improvement.
 func (a *MailAdapter) GetStats(ctx context.Context, userID uuid.UUID) (*out.MailStats, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
 	stats := &out.MailStats{
 		ByFolder:   make(map[string]int, 10),
 		ByCategory: make(map[string]int, 12),
@@ -765,7 +1052,7 @@ func (a *MailAdapter) GetStats(ctx context.Context, userID uuid.UUID) (*out.Mail
 	query := `
 		WITH base AS (
 			SELECT folder, ai_category, is_read, tags, workflow_status
-			FROM emails WHERE user_id = $1
+			FROM emails WHERE user_id = $1 AND deleted_at IS NULL
 		)
 		SELECT 'summary' as type, '' as key,
 			COUNT(*) as total,
@@ -781,7 +1068,7 @@ func (a *MailAdapter) GetStats(ctx context.Context, userID uuid.UUID) (*out.Mail
 		SELECT 'category' as type, ai_category as key, COUNT(*) as total, 0, 0, 0, 0
 		FROM base WHERE ai_category IS NOT NULL GROUP BY ai_category`
 
-	rows, err := a.db.QueryxContext(ctx, query, userID)
+	rows, err := a.reader(ctx).QueryxContext(ctx, query, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -813,8 +1100,11 @@ func (a *MailAdapter) GetStats(ctx context.Context, userID uuid.UUID) (*out.Mail
 
 // CountUnread counts unread emails.
 func (a *MailAdapter) CountUnread(ctx context.Context, userID uuid.UUID, connectionID *int64) (int, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
 	var count int
-	query := "SELECT COUNT(*) FROM emails WHERE user_id = $1 AND is_read = false"
+	query := "SELECT COUNT(*) FROM emails WHERE user_id = $1 AND is_read = false AND deleted_at IS NULL"
 	args := []interface{}{userID}
 
 	if connectionID != nil {
@@ -822,7 +1112,7 @@ func (a *MailAdapter) CountUnread(ctx context.Context, userID uuid.UUID, connect
 		args = append(args, *connectionID)
 	}
 
-	if err := a.db.QueryRowxContext(ctx, query, args...).Scan(&count); err != nil {
+	if err := a.reader(ctx).QueryRowxContext(ctx, query, args...).Scan(&count); err != nil {
 		return 0, err
 	}
 	return count, nil
@@ -830,13 +1120,16 @@ func (a *MailAdapter) CountUnread(ctx context.Context, userID uuid.UUID, connect
 
 // GetCategoryStats returns email counts per category.
 func (a *MailAdapter) GetCategoryStats(ctx context.Context, userID uuid.UUID, connectionID *int64) (map[string]*out.CategoryStatItem, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
 	query := `
 		SELECT
 			COALESCE(ai_category, 'other') as category,
 			COUNT(*) as total,
 			COUNT(*) FILTER (WHERE is_read = false) as unread
 		FROM emails
-		WHERE user_id = $1`
+		WHERE user_id = $1 AND deleted_at IS NULL`
 	args := []interface{}{userID}
 
 	if connectionID != nil {
@@ -846,7 +1139,7 @@ func (a *MailAdapter) GetCategoryStats(ctx context.Context, userID uuid.UUID, co
 
 	query += " GROUP BY ai_category"
 
-	rows, err := a.db.QueryxContext(ctx, query, args...)
+	rows, err := a.reader(ctx).QueryxContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -868,15 +1161,109 @@ func (a *MailAdapter) GetCategoryStats(ctx context.Context, userID uuid.UUID, co
 	return result, nil
 }
 
+// GetSubCategoryStats returns email counts per category and sub-category.
+func (a *MailAdapter) GetSubCategoryStats(ctx context.Context, userID uuid.UUID, connectionID *int64) (map[string]map[string]*out.CategoryStatItem, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT
+			COALESCE(ai_category, 'other') as category,
+			ai_sub_category as sub_category,
+			COUNT(*) as total,
+			COUNT(*) FILTER (WHERE is_read = false) as unread
+		FROM emails
+		WHERE user_id = $1 AND deleted_at IS NULL AND ai_sub_category IS NOT NULL`
+	args := []interface{}{userID}
+
+	if connectionID != nil {
+		query += " AND connection_id = $2"
+		args = append(args, *connectionID)
+	}
+
+	query += " GROUP BY ai_category, ai_sub_category"
+
+	rows, err := a.reader(ctx).QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]map[string]*out.CategoryStatItem)
+	for rows.Next() {
+		var category, subCategory string
+		var total, unread int
+		if err := rows.Scan(&category, &subCategory, &total, &unread); err != nil {
+			return nil, err
+		}
+		if result[category] == nil {
+			result[category] = make(map[string]*out.CategoryStatItem)
+		}
+		result[category][subCategory] = &out.CategoryStatItem{
+			Total:  total,
+			Unread: unread,
+		}
+	}
+
+	return result, rows.Err()
+}
+
 // =============================================================================
 // Helper Functions
 // =============================================================================
 
+// archivedMailSource merges the user's archived emails ($1) into list queries.
+// 079 이전에 다시 동기화되어 hot 테이블에도 있는 메일은 아카이브 쪽을 빼서 두 번 나오지 않게 한다.
+// jsonb_populate_record가 현재 emails 컬럼 순서대로 행을 만들므로 UNION ALL 양쪽이 항상 일치한다.
+const archivedMailSource = `(
+			SELECT * FROM emails
+			UNION ALL
+			SELECT r.* FROM emails_archive ea, jsonb_populate_record(NULL::emails, ea.data) r
+			WHERE ea.user_id = $1
+			AND NOT EXISTS (
+				SELECT 1 FROM emails h
+				WHERE h.user_id = ea.user_id AND h.connection_id = ea.connection_id AND h.external_id = ea.external_id
+			)
+		) e`
+
+// mailSource returns the FROM source of a list query (아카이브 포함 여부).
+func mailSource(req *out.MailListQuery) string {
+	if readsArchive(req) {
+		return archivedMailSource
+	}
+	return "emails e"
+}
+
+// readsArchive reports whether the query also reads emails_archive.
+func readsArchive(req *out.MailListQuery) bool {
+	return req.IncludeArchived && !req.AssignedToMe && !req.Deleted
+}
+
 func (a *MailAdapter) buildWhereClause(userID uuid.UUID, req *out.MailListQuery) (string, []interface{}) {
 	conditions := []string{"e.user_id = $1"}
 	args := []interface{}{userID}
 	argIdx := 2
 
+	// 지정된 메일은 다른 멤버의 메일함에 있다 - 현재 공유 중이고 아직 멤버인 경우만
+	if req.AssignedToMe {
+		conditions[0] = `EXISTS (
+			SELECT 1 FROM email_assignments asg
+			JOIN org_mailboxes mb ON mb.org_id = asg.org_id AND mb.connection_id = e.connection_id
+			JOIN org_members om ON om.org_id = asg.org_id AND om.user_id = $1
+			WHERE asg.email_id = e.id AND asg.assignee_id = $1)`
+	}
+
+	// 삭제된 메일은 복원 목록에서만 보인다
+	if req.Deleted {
+		conditions = append(conditions, "e.deleted_at IS NOT NULL")
+	} else {
+		conditions = append(conditions, "e.deleted_at IS NULL")
+	}
+
+	if req.ExcludeReadLater {
+		conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM email_read_later rl WHERE rl.email_id = e.id)")
+	}
+
 	if req.Folder != "" {
 		conditions = append(conditions, fmt.Sprintf("e.folder = $%d", argIdx))
 		args = append(args, req.Folder)
@@ -970,9 +1357,36 @@ func (a *MailAdapter) buildWhereClause(userID uuid.UUID, req *out.MailListQuery)
 		argIdx++
 	}
 
+	// Sender filter (exact, 피드 그룹 펼치기)
+	if req.Sender != "" {
+		conditions = append(conditions, fmt.Sprintf("LOWER(e.from_email) = LOWER($%d)", argIdx))
+		args = append(args, req.Sender)
+		argIdx++
+	}
+
+	// Language filter (분류 시 감지한 언어)
+	if req.Language != "" {
+		conditions = append(conditions, fmt.Sprintf("e.language = $%d", argIdx))
+		args = append(args, req.Language)
+		argIdx++
+	}
+
+	if req.ThreadID != "" {
+		conditions = append(conditions, fmt.Sprintf("e.external_thread_id = $%d", argIdx))
+		args = append(args, req.ThreadID)
+		argIdx++
+	}
+
 	// Label IDs filter
 	if len(req.LabelIDs) > 0 {
-		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM email_labels el WHERE el.email_id = e.id AND el.label_id = ANY($%d))", argIdx))
+		labelCond := fmt.Sprintf("EXISTS (SELECT 1 FROM email_labels el WHERE el.email_id = e.id AND el.label_id = ANY($%d))", argIdx)
+		// 아카이브된 메일의 라벨은 related에 있다
+		if readsArchive(req) {
+			labelCond = fmt.Sprintf(`(%s OR EXISTS (
+				SELECT 1 FROM emails_archive la, jsonb_array_elements(la.related->'email_labels') l
+				WHERE la.user_id = $1 AND la.id = e.id AND (l->>'label_id')::bigint = ANY($%d)))`, labelCond, argIdx)
+		}
+		conditions = append(conditions, labelCond)
 		args = append(args, pq.Array(req.LabelIDs))
 		argIdx++
 	}
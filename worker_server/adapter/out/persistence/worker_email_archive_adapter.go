@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// EmailArchiveAdapter implements out.EmailArchiveRepository using PostgreSQL.
+// 실제 이동은 archive_emails / rehydrate_emails 함수가 한 트랜잭션으로 처리한다 (066_email_archive.sql).
+type EmailArchiveAdapter struct {
+	db *sqlx.DB
+}
+
+// NewEmailArchiveAdapter creates a new EmailArchiveAdapter.
+func NewEmailArchiveAdapter(db *sqlx.DB) *EmailArchiveAdapter {
+	return &EmailArchiveAdapter{db: db}
+}
+
+// LargeMailboxes returns users that received at least minEmails emails.
+// 누적 집계(mailbox_category_stats)를 써서 emails 전체를 세지 않는다.
+func (a *EmailArchiveAdapter) LargeMailboxes(ctx context.Context, minEmails, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT user_id
+		FROM mailbox_category_stats
+		GROUP BY user_id
+		HAVING SUM(emails) >= $1
+		ORDER BY SUM(emails) DESC
+		LIMIT $2
+	`
+	var ids []uuid.UUID
+	if err := a.db.SelectContext(ctx, &ids, query, minEmails, limit); err != nil {
+		return nil, fmt.Errorf("failed to list large mailboxes: %w", err)
+	}
+	return ids, nil
+}
+
+// Archive moves up to limit emails dated before the cutoff and returns their IDs.
+func (a *EmailArchiveAdapter) Archive(ctx context.Context, userID uuid.UUID, before time.Time, limit int) ([]int64, error) {
+	var ids []int64
+	if err := a.db.SelectContext(ctx, &ids, `SELECT archive_emails($1, $2, $3)`, userID, before, limit); err != nil {
+		return nil, fmt.Errorf("failed to archive emails: %w", err)
+	}
+	return ids, nil
+}
+
+// Rehydrate moves archived emails back to the emails table and returns the restored IDs.
+func (a *EmailArchiveAdapter) Rehydrate(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error) {
+	if len(emailIDs) == 0 {
+		return nil, nil
+	}
+	var ids []int64
+	if err := a.db.SelectContext(ctx, &ids, `SELECT rehydrate_emails($1, $2)`, userID, pq.Array(emailIDs)); err != nil {
+		return nil, fmt.Errorf("failed to rehydrate emails: %w", err)
+	}
+	return ids, nil
+}
+
+// IsArchived reports whether the user's email is in the archive.
+func (a *EmailArchiveAdapter) IsArchived(ctx context.Context, userID uuid.UUID, emailID int64) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM emails_archive WHERE user_id = $1 AND id = $2)`
+	if err := a.db.GetContext(ctx, &exists, query, userID, emailID); err != nil {
+		return false, fmt.Errorf("failed to check archived email: %w", err)
+	}
+	return exists, nil
+}
+
+var _ out.EmailArchiveRepository = (*EmailArchiveAdapter)(nil)
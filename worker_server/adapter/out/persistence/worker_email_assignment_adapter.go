@@ -0,0 +1,160 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// EmailAssignmentAdapter implements out.EmailAssignmentRepository using PostgreSQL.
+type EmailAssignmentAdapter struct {
+	db *sqlx.DB
+}
+
+// NewEmailAssignmentAdapter creates a new EmailAssignmentAdapter.
+func NewEmailAssignmentAdapter(db *sqlx.DB) *EmailAssignmentAdapter {
+	return &EmailAssignmentAdapter{db: db}
+}
+
+type emailAssignmentRow struct {
+	ID            int64          `db:"id"`
+	EmailID       int64          `db:"email_id"`
+	OrgID         int64          `db:"org_id"`
+	AssigneeID    uuid.NullUUID  `db:"assignee_id"`
+	AssigneeEmail sql.NullString `db:"assignee_email"`
+	AssigneeName  sql.NullString `db:"assignee_name"`
+	AssignedBy    uuid.UUID      `db:"assigned_by"`
+	AssignerEmail sql.NullString `db:"assigner_email"`
+	Note          sql.NullString `db:"note"`
+	AssignedAt    time.Time      `db:"assigned_at"`
+}
+
+func (r *emailAssignmentRow) toDomain() *domain.EmailAssignment {
+	a := &domain.EmailAssignment{
+		ID:            r.ID,
+		EmailID:       r.EmailID,
+		OrgID:         r.OrgID,
+		AssigneeEmail: r.AssigneeEmail.String,
+		AssigneeName:  r.AssigneeName.String,
+		AssignedBy:    r.AssignedBy,
+		AssignerEmail: r.AssignerEmail.String,
+		Note:          r.Note.String,
+		AssignedAt:    r.AssignedAt,
+	}
+	if r.AssigneeID.Valid {
+		id := r.AssigneeID.UUID
+		a.AssigneeID = &id
+	}
+	return a
+}
+
+// Set replaces the current assignee and records the change in the history.
+func (a *EmailAssignmentAdapter) Set(ctx context.Context, assignment *domain.EmailAssignment) (*uuid.UUID, error) {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 동시에 지정하는 경우를 막기 위해 현재 행을 잠근다
+	var previous uuid.NullUUID
+	err = tx.GetContext(ctx, &previous, `SELECT assignee_id FROM email_assignments WHERE email_id = $1 FOR UPDATE`, assignment.EmailID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get email assignment: %w", err)
+	}
+
+	if assignment.AssigneeID == nil {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM email_assignments WHERE email_id = $1`, assignment.EmailID); err != nil {
+			return nil, fmt.Errorf("failed to unassign email: %w", err)
+		}
+	} else {
+		query := `
+			INSERT INTO email_assignments (email_id, org_id, assignee_id, assigned_by, note)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+			ON CONFLICT (email_id) DO UPDATE SET
+				org_id = EXCLUDED.org_id,
+				assignee_id = EXCLUDED.assignee_id,
+				assigned_by = EXCLUDED.assigned_by,
+				note = EXCLUDED.note,
+				assigned_at = NOW()
+		`
+		if _, err := tx.ExecContext(ctx, query,
+			assignment.EmailID, assignment.OrgID, *assignment.AssigneeID, assignment.AssignedBy, assignment.Note,
+		); err != nil {
+			return nil, fmt.Errorf("failed to assign email: %w", err)
+		}
+	}
+
+	history := `
+		INSERT INTO email_assignment_history (email_id, org_id, assignee_id, assigned_by, note)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		RETURNING id, created_at
+	`
+	if err := tx.QueryRowxContext(ctx, history,
+		assignment.EmailID, assignment.OrgID, assignment.AssigneeID, assignment.AssignedBy, assignment.Note,
+	).Scan(&assignment.ID, &assignment.AssignedAt); err != nil {
+		return nil, fmt.Errorf("failed to record assignment history: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	if !previous.Valid {
+		return nil, nil
+	}
+	return &previous.UUID, nil
+}
+
+// Get returns the current assignment of an email.
+func (a *EmailAssignmentAdapter) Get(ctx context.Context, emailID int64) (*domain.EmailAssignment, error) {
+	query := `
+		SELECT 0 AS id, a.email_id, a.org_id, a.assignee_id, u.email AS assignee_email, u.name AS assignee_name,
+		       a.assigned_by, b.email AS assigner_email, a.note, a.assigned_at
+		FROM email_assignments a
+		LEFT JOIN users u ON u.id = a.assignee_id
+		LEFT JOIN users b ON b.id = a.assigned_by
+		WHERE a.email_id = $1
+	`
+	var row emailAssignmentRow
+	if err := a.db.GetContext(ctx, &row, query, emailID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get email assignment: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// History returns the assignment changes of an email, newest first.
+func (a *EmailAssignmentAdapter) History(ctx context.Context, emailID int64, limit int) ([]*domain.EmailAssignment, error) {
+	query := `
+		SELECT h.id, h.email_id, h.org_id, h.assignee_id, u.email AS assignee_email, u.name AS assignee_name,
+		       h.assigned_by, b.email AS assigner_email, h.note, h.created_at AS assigned_at
+		FROM email_assignment_history h
+		LEFT JOIN users u ON u.id = h.assignee_id
+		LEFT JOIN users b ON b.id = h.assigned_by
+		WHERE h.email_id = $1
+		ORDER BY h.created_at DESC, h.id DESC
+		LIMIT $2
+	`
+	var rows []emailAssignmentRow
+	if err := a.db.SelectContext(ctx, &rows, query, emailID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list assignment history: %w", err)
+	}
+
+	history := make([]*domain.EmailAssignment, len(rows))
+	for i := range rows {
+		history[i] = rows[i].toDomain()
+	}
+	return history, nil
+}
+
+var _ out.EmailAssignmentRepository = (*EmailAssignmentAdapter)(nil)
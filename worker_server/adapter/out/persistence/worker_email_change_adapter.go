@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// EmailChangeAdapter implements out.EmailChangeRepository using PostgreSQL.
+type EmailChangeAdapter struct {
+	db *sqlx.DB
+}
+
+// NewEmailChangeAdapter creates a new EmailChangeAdapter.
+func NewEmailChangeAdapter(db *sqlx.DB) *EmailChangeAdapter {
+	return &EmailChangeAdapter{db: db}
+}
+
+type emailChangeRow struct {
+	ID         int64     `db:"id"`
+	UserID     uuid.UUID `db:"user_id"`
+	EmailID    int64     `db:"email_id"`
+	ChangeType string    `db:"change_type"`
+	CreatedAt  time.Time `db:"created_at"`
+	TxID       int64     `db:"tx_id"`
+}
+
+// ListSince returns the user's changes after the position in (tx_id, id) order.
+// snapshot xmin보다 앞선 트랜잭션은 모두 끝났으므로 그 변경만 돌려주면 커서가 진행 중인 변경을 지나치지 않는다.
+func (a *EmailChangeAdapter) ListSince(ctx context.Context, userID uuid.UUID, after domain.EmailChangePosition, limit int) ([]*domain.EmailChange, error) {
+	query := `
+		SELECT id, user_id, email_id, change_type, created_at, tx_id::text::bigint AS tx_id
+		FROM email_changes
+		WHERE user_id = $1
+		  AND (tx_id, id) > ($2::text::xid8, $3)
+		  AND tx_id < pg_snapshot_xmin(pg_current_snapshot())
+		ORDER BY tx_id, id
+		LIMIT $4
+	`
+	var rows []emailChangeRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID, after.TxID, after.ID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list email changes: %w", err)
+	}
+
+	changes := make([]*domain.EmailChange, len(rows))
+	for i, r := range rows {
+		changes[i] = &domain.EmailChange{
+			ID:        r.ID,
+			TxID:      r.TxID,
+			UserID:    r.UserID,
+			EmailID:   r.EmailID,
+			Type:      r.ChangeType,
+			CreatedAt: r.CreatedAt,
+		}
+	}
+	return changes, nil
+}
+
+// Position returns the oldest in-flight transaction as a position (진행 중인 변경은 이 뒤에 온다).
+func (a *EmailChangeAdapter) Position(ctx context.Context) (domain.EmailChangePosition, error) {
+	var txID int64
+	if err := a.db.GetContext(ctx, &txID, `SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint`); err != nil {
+		return domain.EmailChangePosition{}, fmt.Errorf("failed to get email change position: %w", err)
+	}
+	return domain.EmailChangePosition{TxID: txID}, nil
+}
+
+// Prune deletes up to limit changes recorded before the cutoff.
+func (a *EmailChangeAdapter) Prune(ctx context.Context, before time.Time, limit int) (int, error) {
+	result, err := a.db.ExecContext(ctx, `
+		DELETE FROM email_changes WHERE id IN (
+			SELECT id FROM email_changes WHERE created_at < $1 ORDER BY id LIMIT $2
+		)`, before, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune email changes: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return int(n), nil
+}
+
+var _ out.EmailChangeRepository = (*EmailChangeAdapter)(nil)
@@ -0,0 +1,156 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// EmailCommentAdapter implements out.EmailCommentRepository using PostgreSQL.
+type EmailCommentAdapter struct {
+	db *sqlx.DB
+}
+
+// NewEmailCommentAdapter creates a new EmailCommentAdapter.
+func NewEmailCommentAdapter(db *sqlx.DB) *EmailCommentAdapter {
+	return &EmailCommentAdapter{db: db}
+}
+
+type emailCommentRow struct {
+	ID          int64          `db:"id"`
+	EmailID     int64          `db:"email_id"`
+	OrgID       int64          `db:"org_id"`
+	ParentID    sql.NullInt64  `db:"parent_id"`
+	AuthorID    uuid.UUID      `db:"author_id"`
+	AuthorEmail sql.NullString `db:"author_email"`
+	AuthorName  sql.NullString `db:"author_name"`
+	Body        string         `db:"body"`
+	Mentions    pq.StringArray `db:"mentions"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+func (r *emailCommentRow) toDomain() *domain.EmailComment {
+	comment := &domain.EmailComment{
+		ID:          r.ID,
+		EmailID:     r.EmailID,
+		OrgID:       r.OrgID,
+		AuthorID:    r.AuthorID,
+		AuthorEmail: r.AuthorEmail.String,
+		AuthorName:  r.AuthorName.String,
+		Body:        r.Body,
+		Mentions:    make([]uuid.UUID, 0, len(r.Mentions)),
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if r.ParentID.Valid {
+		parentID := r.ParentID.Int64
+		comment.ParentID = &parentID
+	}
+	for _, m := range r.Mentions {
+		if id, err := uuid.Parse(m); err == nil {
+			comment.Mentions = append(comment.Mentions, id)
+		}
+	}
+	return comment
+}
+
+func mentionArray(ids []uuid.UUID) pq.StringArray {
+	arr := make(pq.StringArray, len(ids))
+	for i, id := range ids {
+		arr[i] = id.String()
+	}
+	return arr
+}
+
+// Create inserts a comment. 답글의 부모는 같은 메일의 댓글이어야 한다.
+func (a *EmailCommentAdapter) Create(ctx context.Context, comment *domain.EmailComment) error {
+	query := `
+		INSERT INTO email_comments (email_id, org_id, parent_id, author_id, body, mentions)
+		SELECT $1, $2, $3, $4, $5, $6::uuid[]
+		WHERE $3::bigint IS NULL
+		   OR EXISTS (SELECT 1 FROM email_comments p WHERE p.id = $3 AND p.email_id = $1)
+		RETURNING id, created_at, updated_at
+	`
+	err := a.db.QueryRowxContext(ctx, query,
+		comment.EmailID, comment.OrgID, comment.ParentID, comment.AuthorID, comment.Body, mentionArray(comment.Mentions),
+	).Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return out.ErrEmailCommentNotFound
+		}
+		return fmt.Errorf("failed to create email comment: %w", err)
+	}
+	return nil
+}
+
+// Update replaces body and mentions of the author's comment.
+func (a *EmailCommentAdapter) Update(ctx context.Context, comment *domain.EmailComment) error {
+	query := `
+		UPDATE email_comments SET body = $4, mentions = $5::uuid[], updated_at = NOW()
+		WHERE id = $1 AND email_id = $2 AND author_id = $3
+		RETURNING org_id, parent_id, created_at, updated_at
+	`
+	var parentID sql.NullInt64
+	err := a.db.QueryRowxContext(ctx, query,
+		comment.ID, comment.EmailID, comment.AuthorID, comment.Body, mentionArray(comment.Mentions),
+	).Scan(&comment.OrgID, &parentID, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return out.ErrEmailCommentNotFound
+		}
+		return fmt.Errorf("failed to update email comment: %w", err)
+	}
+	if parentID.Valid {
+		comment.ParentID = &parentID.Int64
+	}
+	return nil
+}
+
+// Delete deletes the author's comment and its replies.
+func (a *EmailCommentAdapter) Delete(ctx context.Context, authorID uuid.UUID, emailID, commentID int64) error {
+	result, err := a.db.ExecContext(ctx,
+		`DELETE FROM email_comments WHERE id = $1 AND email_id = $2 AND author_id = $3`,
+		commentID, emailID, authorID)
+	if err != nil {
+		return fmt.Errorf("failed to delete email comment: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return out.ErrEmailCommentNotFound
+	}
+	return nil
+}
+
+// ListByEmailID returns the comments of an email, oldest first.
+func (a *EmailCommentAdapter) ListByEmailID(ctx context.Context, emailID int64) ([]*domain.EmailComment, error) {
+	query := `
+		SELECT c.id, c.email_id, c.org_id, c.parent_id, c.author_id,
+		       u.email AS author_email, u.name AS author_name,
+		       c.body, c.mentions, c.created_at, c.updated_at
+		FROM email_comments c
+		LEFT JOIN users u ON u.id = c.author_id
+		WHERE c.email_id = $1
+		ORDER BY c.created_at, c.id
+	`
+	var rows []emailCommentRow
+	if err := a.db.SelectContext(ctx, &rows, query, emailID); err != nil {
+		return nil, fmt.Errorf("failed to list email comments: %w", err)
+	}
+
+	comments := make([]*domain.EmailComment, len(rows))
+	for i := range rows {
+		comments[i] = rows[i].toDomain()
+	}
+	return comments, nil
+}
+
+var _ out.EmailCommentRepository = (*EmailCommentAdapter)(nil)
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/lib/pq"
+)
+
+// =============================================================================
+// COPY Upsert - 대량 동기화 배치 저장
+// 100행씩 INSERT하면 1만 건에 100번 왕복하므로, 큰 배치는 COPY로 임시 테이블에 넣고
+// INSERT ... SELECT 한 번으로 upsert한다.
+// =============================================================================
+
+// copyUpsertThreshold is the batch size from which BulkUpsert switches to COPY.
+const copyUpsertThreshold = 500
+
+// errCopyUnsupported is returned when the sqlx connection is not a pgx connection.
+var errCopyUnsupported = errors.New("copy upsert requires the pgx driver")
+
+// upsertByCopy upserts emails through a COPY-loaded staging table in one transaction.
+// 같은 external_id가 배치에 여러 번 있으면 마지막 행만 반영한다 (ON CONFLICT는 한 행을 두 번 갱신할 수 없음).
+func (a *MailAdapter) upsertByCopy(ctx context.Context, userID uuid.UUID, connectionID int64, mails []*out.MailEntity) error {
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		sc, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errCopyUnsupported
+		}
+		pgxConn := sc.Conn()
+
+		tx, err := pgxConn.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+
+		columns := strings.Join(bulkUpsertColumns, ", ")
+
+		// 컬럼 타입만 복사 (기본값/제약 없음 → id 시퀀스를 소비하지 않음)
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`
+			CREATE TEMP TABLE email_upsert_stage ON COMMIT DROP AS
+			SELECT %s, 0::int AS seq FROM emails WITH NO DATA`, columns)); err != nil {
+			return fmt.Errorf("failed to create staging table: %w", err)
+		}
+
+		rows := make([][]any, len(mails))
+		for i, mail := range mails {
+			rows[i] = append(copyMailValues(userID, connectionID, mail), i)
+		}
+		copyColumns := append(append([]string{}, bulkUpsertColumns...), "seq")
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{"email_upsert_stage"}, copyColumns, pgx.CopyFromRows(rows)); err != nil {
+			return fmt.Errorf("failed to copy emails: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`
+			INSERT INTO emails (%s, updated_at)
+			SELECT DISTINCT ON (user_id, connection_id, external_id) %s, NOW()
+			FROM email_upsert_stage
+			ORDER BY user_id, connection_id, external_id, seq DESC
+			%s`, columns, columns, bulkUpsertConflict)); err != nil {
+			return fmt.Errorf("failed to upsert staged emails: %w", err)
+		}
+
+		return tx.Commit(ctx)
+	})
+}
+
+// copyMailValues returns the bulk upsert values with plain slices for COPY.
+// pq.Array는 텍스트 형식이라 COPY 바이너리 인코딩에 맞게 []string으로 되돌린다.
+func copyMailValues(userID uuid.UUID, connectionID int64, mail *out.MailEntity) []any {
+	values := buildMailValues(userID, connectionID, mail)
+	for i, v := range values {
+		if arr, ok := v.(*pq.StringArray); ok {
+			values[i] = []string(*arr)
+		}
+	}
+	return values
+}
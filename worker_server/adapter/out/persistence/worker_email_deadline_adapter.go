@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// EmailDeadlineAdapter implements out.EmailDeadlineRepository using PostgreSQL.
+type EmailDeadlineAdapter struct {
+	db *sqlx.DB
+}
+
+// NewEmailDeadlineAdapter creates a new EmailDeadlineAdapter.
+func NewEmailDeadlineAdapter(db *sqlx.DB) *EmailDeadlineAdapter {
+	return &EmailDeadlineAdapter{db: db}
+}
+
+// emailDeadlineRow represents the database row for an extracted deadline.
+type emailDeadlineRow struct {
+	EmailID     int64          `db:"email_id"`
+	UserID      uuid.UUID      `db:"user_id"`
+	DeadlineAt  sql.NullTime   `db:"deadline_at"`
+	SourceText  sql.NullString `db:"source_text"`
+	Explicit    bool           `db:"explicit"`
+	ExtractedAt sql.NullTime   `db:"extracted_at"`
+}
+
+func (r *emailDeadlineRow) toDomain() *domain.EmailDeadline {
+	return &domain.EmailDeadline{
+		EmailID:     r.EmailID,
+		UserID:      r.UserID,
+		DeadlineAt:  r.DeadlineAt.Time,
+		SourceText:  r.SourceText.String,
+		Explicit:    r.Explicit,
+		ExtractedAt: r.ExtractedAt.Time,
+	}
+}
+
+// Save upserts the deadline of an email.
+func (a *EmailDeadlineAdapter) Save(ctx context.Context, deadline *domain.EmailDeadline) error {
+	query := `
+		INSERT INTO email_deadlines (email_id, user_id, deadline_at, source_text, explicit, extracted_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, NOW())
+		ON CONFLICT (email_id) DO UPDATE SET
+			deadline_at = EXCLUDED.deadline_at,
+			source_text = EXCLUDED.source_text,
+			explicit = EXCLUDED.explicit,
+			extracted_at = EXCLUDED.extracted_at
+	`
+
+	_, err := a.db.ExecContext(ctx, query,
+		deadline.EmailID,
+		deadline.UserID,
+		deadline.DeadlineAt,
+		deadline.SourceText,
+		deadline.Explicit,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save email deadline: %w", err)
+	}
+	return nil
+}
+
+// GetByEmailIDs retrieves deadlines for multiple emails of a user.
+func (a *EmailDeadlineAdapter) GetByEmailIDs(ctx context.Context, userID uuid.UUID, emailIDs []int64) (map[int64]*domain.EmailDeadline, error) {
+	result := make(map[int64]*domain.EmailDeadline, len(emailIDs))
+	if len(emailIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT email_id, user_id, deadline_at, source_text, explicit, extracted_at
+		FROM email_deadlines
+		WHERE user_id = $1 AND email_id = ANY($2)
+	`
+
+	var rows []emailDeadlineRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID, pq.Array(emailIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get email deadlines: %w", err)
+	}
+
+	for i := range rows {
+		result[rows[i].EmailID] = rows[i].toDomain()
+	}
+	return result, nil
+}
+
+// UserTimezone returns the time zone from the user's settings, or "" if unset.
+func (a *EmailDeadlineAdapter) UserTimezone(ctx context.Context, userID uuid.UUID) (string, error) {
+	var tz sql.NullString
+	err := a.db.GetContext(ctx, &tz, `SELECT timezone FROM user_settings WHERE user_id = $1`, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get user timezone: %w", err)
+	}
+	return strings.TrimSpace(tz.String), nil
+}
+
+var _ out.EmailDeadlineRepository = (*EmailDeadlineAdapter)(nil)
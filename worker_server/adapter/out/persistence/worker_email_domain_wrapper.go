@@ -39,7 +39,8 @@ func (w *MailDomainWrapper) entityToDomain(e *out.MailEntity) *domain.Email {
 		ConnectionID: e.ConnectionID,
 		Provider:     domain.Provider(e.Provider),
 		ProviderID:   e.ExternalID,
-		ThreadID:     "",
+		ThreadID:     e.ExternalThreadID,
+		MessageID:    e.MessageID,
 		Subject:      e.Subject,
 		FromEmail:    e.FromEmail,
 		ToEmails:     e.ToEmails,
@@ -73,6 +74,10 @@ func (w *MailDomainWrapper) entityToDomain(e *out.MailEntity) *domain.Email {
 	if e.Tags != nil {
 		email.AITags = e.Tags
 	}
+	if e.Language != "" {
+		email.Language = &e.Language
+	}
+	email.DeletedAt = e.DeletedAt
 
 	return email
 }
@@ -89,6 +94,7 @@ func (w *MailDomainWrapper) domainToEntity(d *domain.Email) *out.MailEntity {
 		ConnectionID:  d.ConnectionID,
 		Provider:      string(d.Provider),
 		ExternalID:    d.ProviderID,
+		MessageID:     d.MessageID,
 		FromEmail:     d.FromEmail,
 		ToEmails:      d.ToEmails,
 		CcEmails:      d.CcEmails,
@@ -127,6 +133,12 @@ func (w *MailDomainWrapper) domainToEntity(d *domain.Email) *out.MailEntity {
 	if d.ClassificationSource != nil {
 		entity.ClassificationSource = string(*d.ClassificationSource)
 	}
+	if d.ClassificationStage != nil {
+		entity.ClassificationStage = string(*d.ClassificationStage)
+	}
+	if d.Language != nil {
+		entity.Language = *d.Language
+	}
 
 	return entity
 }
@@ -179,6 +191,9 @@ func (w *MailDomainWrapper) GetByDateRange(userID uuid.UUID, startDate, endDate
 // List implements domain.EmailRepository.
 func (w *MailDomainWrapper) List(filter *domain.EmailFilter) ([]*domain.Email, int, error) {
 	ctx := context.Background()
+	if filter.PrimaryRead {
+		ctx = out.WithPrimaryRead(ctx)
+	}
 
 	query := &out.MailListQuery{
 		Limit:  filter.Limit,
@@ -223,6 +238,12 @@ func (w *MailDomainWrapper) List(filter *domain.EmailFilter) ([]*domain.Email, i
 	if filter.FromDomain != nil {
 		query.FromDomain = *filter.FromDomain
 	}
+	if filter.Sender != nil {
+		query.Sender = *filter.Sender
+	}
+	if filter.Language != nil {
+		query.Language = *filter.Language
+	}
 	if len(filter.LabelIDs) > 0 {
 		query.LabelIDs = filter.LabelIDs
 	}
@@ -251,10 +272,33 @@ func (w *MailDomainWrapper) List(filter *domain.EmailFilter) ([]*domain.Email, i
 	}
 
 	// === Sorting ===
-	// SortBy: "date" (default), "priority" (TODO view)
-	if filter.SortBy == "priority" {
+	// SortBy: "date" (default), "priority" / "urgency" (TODO view)
+	switch filter.SortBy {
+	case "priority":
 		query.OrderBy = "ai_priority"
 		query.Order = "desc"
+	case "urgency":
+		query.OrderBy = "urgency"
+		query.Order = "desc"
+		query.Timezone = filter.Timezone
+	}
+	query.PinnedFirst = filter.PinnedFirst
+	query.PinnedOnly = filter.PinnedOnly
+	query.ReadLaterOnly = filter.ReadLaterOnly
+	query.ExcludeReadLater = filter.ExcludeReadLater
+	query.AssignedToMe = filter.AssignedToMe
+	query.IncludeArchived = filter.IncludeArchived
+	if len(filter.Fields) > 0 {
+		query.Fields = filter.Fields
+		// 아래 메모리 필터가 쓰는 컬럼은 요청에 없어도 조회
+		if filter.Search != nil || filter.FromEmail != nil {
+			query.Fields = append(query.Fields[:len(query.Fields):len(query.Fields)], "subject", "from_email")
+		}
+	}
+	if filter.Deleted {
+		query.Deleted = true
+		query.OrderBy = "deleted_at"
+		query.Order = "desc"
 	}
 
 	entities, total, err := w.adapter.List(ctx, filter.UserID, query)
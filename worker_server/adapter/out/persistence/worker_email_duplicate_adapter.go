@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// Duplicate Emails (여러 계정에 배달된 같은 메일, 내 계정으로 전달한 사본)
+// =============================================================================
+
+type duplicateRow struct {
+	ID               int64     `db:"id"`
+	ConnectionID     int64     `db:"connection_id"`
+	Folder           string    `db:"folder"`
+	EmailDate        time.Time `db:"email_date"`
+	Reason           string    `db:"reason"`
+	KeepID           int64     `db:"keep_id"`
+	KeepConnectionID int64     `db:"keep_connection_id"`
+	KeepSubject      string    `db:"keep_subject"`
+}
+
+// ListDuplicateGroups returns emails with redundant copies, newest kept email first.
+// Message-ID 중복은 방향(inbound/outbound)별로 가장 먼저 받은 휴지통 밖의 메일을 남기고,
+// 전달 사본은 30일 안에 받은 원본(제목 일치 + 스니펫에 원본 발신자 포함)에 묶는다.
+func (a *MailAdapter) ListDuplicateGroups(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.DuplicateGroup, error) {
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	query := `
+		WITH own AS (
+			SELECT LOWER(email) AS email FROM oauth_connections WHERE user_id = $1
+		),
+		by_message AS (
+			SELECT e.id, e.connection_id, e.folder, e.email_date, 'message_id' AS reason,
+				FIRST_VALUE(e.id) OVER (
+					PARTITION BY e.message_id, e.direction
+					ORDER BY (e.folder = 'trash'), e.email_date, e.id
+				) AS keep_id
+			FROM emails e
+			WHERE e.user_id = $1 AND e.deleted_at IS NULL AND e.message_id IS NOT NULL AND e.message_id != ''
+		),
+		forwarded AS (
+			SELECT f.id, f.connection_id, f.folder, f.email_date, 'forwarded' AS reason, o.id AS keep_id
+			FROM emails f
+			JOIN LATERAL (
+				SELECT o.id FROM emails o
+				WHERE o.user_id = $1 AND o.id != f.id AND o.deleted_at IS NULL
+					AND o.direction = 'inbound' AND o.folder != 'trash'
+					AND LOWER(TRIM(o.subject)) = LOWER(TRIM(regexp_replace(f.subject, '^\s*((fwd?|fw|전달)\s*:\s*)+', '', 'i')))
+					AND o.email_date <= f.email_date AND o.email_date > f.email_date - INTERVAL '30 days'
+					AND POSITION(LOWER(o.from_email) IN LOWER(COALESCE(f.snippet, ''))) > 0
+				ORDER BY o.email_date DESC
+				LIMIT 1
+			) o ON TRUE
+			WHERE f.user_id = $1 AND f.deleted_at IS NULL AND f.direction = 'inbound' AND f.folder != 'trash'
+				AND f.subject ~* '^\s*(fwd?|fw|전달)\s*:'
+				AND LOWER(f.from_email) IN (SELECT email FROM own)
+		),
+		copies AS (
+			SELECT DISTINCT ON (d.id) d.*
+			FROM (
+				SELECT * FROM by_message WHERE id != keep_id AND folder != 'trash'
+				UNION ALL
+				SELECT * FROM forwarded
+			) d
+			ORDER BY d.id, d.reason DESC
+		)
+		SELECT c.id, c.connection_id, c.folder, c.email_date, c.reason, c.keep_id,
+			k.connection_id AS keep_connection_id, COALESCE(k.subject, '') AS keep_subject
+		FROM copies c
+		JOIN emails k ON k.id = c.keep_id
+		ORDER BY k.email_date DESC, c.keep_id, c.id
+		LIMIT $2`
+
+	var rows []duplicateRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list duplicate emails: %w", err)
+	}
+
+	groups := make([]*domain.DuplicateGroup, 0)
+	byKeep := make(map[int64]*domain.DuplicateGroup)
+	for _, r := range rows {
+		g, ok := byKeep[r.KeepID]
+		if !ok {
+			g = &domain.DuplicateGroup{
+				EmailID:      r.KeepID,
+				ConnectionID: r.KeepConnectionID,
+				Subject:      r.KeepSubject,
+			}
+			byKeep[r.KeepID] = g
+			groups = append(groups, g)
+		}
+		g.Duplicates = append(g.Duplicates, &domain.DuplicateCopy{
+			EmailID:      r.ID,
+			ConnectionID: r.ConnectionID,
+			Folder:       r.Folder,
+			Reason:       r.Reason,
+			ReceivedAt:   r.EmailDate,
+		})
+	}
+	return groups, nil
+}
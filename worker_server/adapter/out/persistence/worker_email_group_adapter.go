@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// =============================================================================
+// Feed Sender Groups (피드 카테고리 발신자별 롤업)
+// =============================================================================
+
+type senderGroupRow struct {
+	Sender        string         `db:"sender"`
+	SenderName    sql.NullString `db:"sender_name"`
+	Count         int            `db:"count"`
+	UnreadCount   int            `db:"unread_count"`
+	LatestEmailID int64          `db:"latest_email_id"`
+	LatestSubject sql.NullString `db:"latest_subject"`
+	LatestAt      time.Time      `db:"latest_at"`
+	TotalGroups   int            `db:"total_groups"`
+}
+
+// ListSenderGroups collapses matching emails into one group per sender (대소문자 무시), latest first.
+func (a *MailAdapter) ListSenderGroups(ctx context.Context, userID uuid.UUID, req *out.MailListQuery) ([]*domain.SenderGroup, int, error) {
+	if req == nil {
+		req = &out.MailListQuery{}
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 50
+	}
+
+	where, args := a.buildWhereClause(userID, req)
+	argIdx := len(args) + 1
+
+	query := fmt.Sprintf(`
+		SELECT LOWER(e.from_email) AS sender,
+			(ARRAY_AGG(e.from_name ORDER BY e.email_date DESC) FILTER (WHERE e.from_name IS NOT NULL AND e.from_name != ''))[1] AS sender_name,
+			COUNT(*) AS count,
+			COUNT(*) FILTER (WHERE NOT e.is_read) AS unread_count,
+			(ARRAY_AGG(e.id ORDER BY e.email_date DESC))[1] AS latest_email_id,
+			(ARRAY_AGG(e.subject ORDER BY e.email_date DESC))[1] AS latest_subject,
+			MAX(e.email_date) AS latest_at,
+			COUNT(*) OVER() AS total_groups
+		FROM emails e
+		WHERE %s
+		GROUP BY LOWER(e.from_email)
+		ORDER BY latest_at DESC
+		LIMIT $%d OFFSET $%d`,
+		where, argIdx, argIdx+1)
+	args = append(args, req.Limit, req.Offset)
+
+	var rows []senderGroupRow
+	if err := a.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list sender groups: %w", err)
+	}
+
+	groups := make([]*domain.SenderGroup, 0, len(rows))
+	total := 0
+	for _, r := range rows {
+		groups = append(groups, &domain.SenderGroup{
+			Sender:        r.Sender,
+			SenderName:    r.SenderName.String,
+			Count:         r.Count,
+			UnreadCount:   r.UnreadCount,
+			LatestEmailID: r.LatestEmailID,
+			LatestSubject: r.LatestSubject.String,
+			LatestAt:      r.LatestAt,
+		})
+		total = r.TotalGroups
+	}
+	return groups, total, nil
+}
+
+// ListIDsBySenders returns IDs of matching emails from the given senders, newest first.
+func (a *MailAdapter) ListIDsBySenders(ctx context.Context, userID uuid.UUID, req *out.MailListQuery, senders []string, limit int) ([]int64, error) {
+	if req == nil {
+		req = &out.MailListQuery{}
+	}
+
+	where, args := a.buildWhereClause(userID, req)
+	argIdx := len(args) + 1
+
+	query := fmt.Sprintf(`
+		SELECT e.id FROM emails e
+		WHERE %s AND LOWER(e.from_email) = ANY($%d)
+		ORDER BY e.email_date DESC
+		LIMIT $%d`,
+		where, argIdx, argIdx+1)
+	args = append(args, pq.Array(senders), limit)
+
+	var ids []int64
+	if err := a.db.SelectContext(ctx, &ids, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list sender group emails: %w", err)
+	}
+	return ids, nil
+}
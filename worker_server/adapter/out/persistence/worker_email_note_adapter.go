@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// EmailNoteAdapter implements out.EmailNoteRepository using PostgreSQL.
+type EmailNoteAdapter struct {
+	db *sqlx.DB
+}
+
+// NewEmailNoteAdapter creates a new EmailNoteAdapter.
+func NewEmailNoteAdapter(db *sqlx.DB) *EmailNoteAdapter {
+	return &EmailNoteAdapter{db: db}
+}
+
+// emailNoteRow represents the database row for an email note.
+type emailNoteRow struct {
+	ID        int64          `db:"id"`
+	EmailID   int64          `db:"email_id"`
+	UserID    uuid.UUID      `db:"user_id"`
+	Body      string         `db:"body"`
+	Tags      pq.StringArray `db:"tags"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+func (r *emailNoteRow) toDomain() *domain.EmailNote {
+	tags := []string(r.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+	return &domain.EmailNote{
+		ID:        r.ID,
+		EmailID:   r.EmailID,
+		UserID:    r.UserID,
+		Body:      r.Body,
+		Tags:      tags,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// Create inserts a note only if the email belongs to the note's user.
+func (a *EmailNoteAdapter) Create(ctx context.Context, note *domain.EmailNote) error {
+	query := `
+		INSERT INTO email_notes (email_id, user_id, body, tags)
+		SELECT e.id, e.user_id, $3, $4
+		FROM emails e
+		WHERE e.id = $1 AND e.user_id = $2
+		RETURNING id, created_at, updated_at
+	`
+	err := a.db.QueryRowxContext(ctx, query, note.EmailID, note.UserID, note.Body, pq.Array(note.Tags)).
+		Scan(&note.ID, &note.CreatedAt, &note.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return out.ErrEmailNoteNotFound
+		}
+		return fmt.Errorf("failed to create email note: %w", err)
+	}
+	return nil
+}
+
+// Update replaces the body and tags of a note.
+func (a *EmailNoteAdapter) Update(ctx context.Context, note *domain.EmailNote) error {
+	query := `
+		UPDATE email_notes
+		SET body = $4, tags = $5, updated_at = NOW()
+		WHERE id = $1 AND email_id = $2 AND user_id = $3
+		RETURNING created_at, updated_at
+	`
+	err := a.db.QueryRowxContext(ctx, query, note.ID, note.EmailID, note.UserID, note.Body, pq.Array(note.Tags)).
+		Scan(&note.CreatedAt, &note.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return out.ErrEmailNoteNotFound
+		}
+		return fmt.Errorf("failed to update email note: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a note.
+func (a *EmailNoteAdapter) Delete(ctx context.Context, userID uuid.UUID, emailID, noteID int64) error {
+	result, err := a.db.ExecContext(ctx,
+		`DELETE FROM email_notes WHERE id = $1 AND email_id = $2 AND user_id = $3`,
+		noteID, emailID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete email note: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return out.ErrEmailNoteNotFound
+	}
+	return nil
+}
+
+// ListByEmailID returns the notes of an email (oldest first).
+func (a *EmailNoteAdapter) ListByEmailID(ctx context.Context, userID uuid.UUID, emailID int64) ([]*domain.EmailNote, error) {
+	query := `
+		SELECT id, email_id, user_id, body, tags, created_at, updated_at
+		FROM email_notes
+		WHERE email_id = $1 AND user_id = $2
+		ORDER BY created_at, id
+	`
+	var rows []emailNoteRow
+	if err := a.db.SelectContext(ctx, &rows, query, emailID, userID); err != nil {
+		return nil, fmt.Errorf("failed to list email notes: %w", err)
+	}
+
+	notes := make([]*domain.EmailNote, len(rows))
+	for i := range rows {
+		notes[i] = rows[i].toDomain()
+	}
+	return notes, nil
+}
+
+var _ out.EmailNoteRepository = (*EmailNoteAdapter)(nil)
@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// EmailPinAdapter implements out.EmailPinRepository using PostgreSQL.
+type EmailPinAdapter struct {
+	db *sqlx.DB
+}
+
+// NewEmailPinAdapter creates a new EmailPinAdapter.
+func NewEmailPinAdapter(db *sqlx.DB) *EmailPinAdapter {
+	return &EmailPinAdapter{db: db}
+}
+
+// Pin puts emails at the top of the pinned list. 이미 고정된 메일도 맨 위로 올라간다.
+func (a *EmailPinAdapter) Pin(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error) {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	shift := `
+		UPDATE email_pins
+		SET position = position + $2
+		WHERE user_id = $1 AND NOT (email_id = ANY($3))
+	`
+	if _, err := tx.ExecContext(ctx, shift, userID, len(emailIDs), pq.Array(emailIDs)); err != nil {
+		return nil, fmt.Errorf("failed to shift email pins: %w", err)
+	}
+
+	pin := `
+		INSERT INTO email_pins (email_id, user_id, position, pinned_at)
+		SELECT e.id, $1, array_position($2::bigint[], e.id) - 1, NOW()
+		FROM emails e
+		WHERE e.user_id = $1 AND e.id = ANY($2) AND e.deleted_at IS NULL
+		ON CONFLICT (email_id) DO UPDATE SET position = EXCLUDED.position
+		RETURNING email_id
+	`
+	var pinned []int64
+	if err := tx.SelectContext(ctx, &pinned, pin, userID, pq.Array(emailIDs)); err != nil {
+		return nil, fmt.Errorf("failed to pin emails: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return pinned, nil
+}
+
+// Unpin removes emails from the pinned list.
+func (a *EmailPinAdapter) Unpin(ctx context.Context, userID uuid.UUID, emailIDs []int64) error {
+	query := `DELETE FROM email_pins WHERE user_id = $1 AND email_id = ANY($2)`
+	if _, err := a.db.ExecContext(ctx, query, userID, pq.Array(emailIDs)); err != nil {
+		return fmt.Errorf("failed to unpin emails: %w", err)
+	}
+	return nil
+}
+
+// Reorder sets pin positions to the order of emailIDs.
+func (a *EmailPinAdapter) Reorder(ctx context.Context, userID uuid.UUID, emailIDs []int64) error {
+	query := `
+		UPDATE email_pins p
+		SET position = o.ord - 1
+		FROM unnest($2::bigint[]) WITH ORDINALITY AS o(id, ord)
+		WHERE p.email_id = o.id AND p.user_id = $1
+	`
+	if _, err := a.db.ExecContext(ctx, query, userID, pq.Array(emailIDs)); err != nil {
+		return fmt.Errorf("failed to reorder email pins: %w", err)
+	}
+	return nil
+}
+
+// ListIDs returns the pinned email IDs, top first.
+// 휴지통(soft delete)에 있는 메일의 고정은 남겨 두지만 (복원 시 유지) 목록과 개수에서는 뺀다.
+func (a *EmailPinAdapter) ListIDs(ctx context.Context, userID uuid.UUID) ([]int64, error) {
+	query := `
+		SELECT p.email_id
+		FROM email_pins p
+		JOIN emails e ON e.id = p.email_id AND e.deleted_at IS NULL
+		WHERE p.user_id = $1
+		ORDER BY p.position, p.pinned_at DESC
+	`
+	var ids []int64
+	if err := a.db.SelectContext(ctx, &ids, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list email pins: %w", err)
+	}
+	return ids, nil
+}
+
+var _ out.EmailPinRepository = (*EmailPinAdapter)(nil)
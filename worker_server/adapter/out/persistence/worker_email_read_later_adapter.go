@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// EmailReadLaterAdapter implements out.EmailReadLaterRepository using PostgreSQL.
+type EmailReadLaterAdapter struct {
+	db *sqlx.DB
+}
+
+// NewEmailReadLaterAdapter creates a new EmailReadLaterAdapter.
+func NewEmailReadLaterAdapter(db *sqlx.DB) *EmailReadLaterAdapter {
+	return &EmailReadLaterAdapter{db: db}
+}
+
+// Add queues the user's emails. 다른 사용자의 메일 ID는 무시한다.
+func (a *EmailReadLaterAdapter) Add(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error) {
+	query := `
+		INSERT INTO email_read_later (email_id, user_id, added_at)
+		SELECT e.id, $1, NOW()
+		FROM emails e
+		WHERE e.user_id = $1 AND e.id = ANY($2)
+		ON CONFLICT (email_id) DO UPDATE SET added_at = EXCLUDED.added_at
+		RETURNING email_id
+	`
+	var added []int64
+	if err := a.db.SelectContext(ctx, &added, query, userID, pq.Array(emailIDs)); err != nil {
+		return nil, fmt.Errorf("failed to add read later emails: %w", err)
+	}
+	return added, nil
+}
+
+// Remove takes emails out of the queue.
+func (a *EmailReadLaterAdapter) Remove(ctx context.Context, userID uuid.UUID, emailIDs []int64) error {
+	query := `DELETE FROM email_read_later WHERE user_id = $1 AND email_id = ANY($2)`
+	if _, err := a.db.ExecContext(ctx, query, userID, pq.Array(emailIDs)); err != nil {
+		return fmt.Errorf("failed to remove read later emails: %w", err)
+	}
+	return nil
+}
+
+// Contains reports whether the email is queued.
+func (a *EmailReadLaterAdapter) Contains(ctx context.Context, userID uuid.UUID, emailID int64) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM email_read_later WHERE user_id = $1 AND email_id = $2)`
+	var exists bool
+	if err := a.db.GetContext(ctx, &exists, query, userID, emailID); err != nil {
+		return false, fmt.Errorf("failed to check read later email: %w", err)
+	}
+	return exists, nil
+}
+
+var _ out.EmailReadLaterRepository = (*EmailReadLaterAdapter)(nil)
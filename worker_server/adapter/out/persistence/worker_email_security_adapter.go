@@ -0,0 +1,136 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// EmailSecurityAdapter implements out.EmailSecurityRepository using PostgreSQL.
+type EmailSecurityAdapter struct {
+	db *sqlx.DB
+}
+
+// NewEmailSecurityAdapter creates a new EmailSecurityAdapter.
+func NewEmailSecurityAdapter(db *sqlx.DB) *EmailSecurityAdapter {
+	return &EmailSecurityAdapter{db: db}
+}
+
+// emailSecurityRow represents the database row for email security results.
+type emailSecurityRow struct {
+	EmailID    int64          `db:"email_id"`
+	RiskLevel  string         `db:"risk_level"`
+	RiskScore  float64        `db:"risk_score"`
+	Signals    []byte         `db:"signals"`
+	SPF        sql.NullString `db:"spf"`
+	DKIM       sql.NullString `db:"dkim"`
+	DMARC      sql.NullString `db:"dmarc"`
+	AnalyzedAt sql.NullTime   `db:"analyzed_at"`
+}
+
+const emailSecurityColumns = `email_id, risk_level, risk_score, signals, spf, dkim, dmarc, analyzed_at`
+
+func (r *emailSecurityRow) toDomain() *domain.EmailSecurity {
+	sec := &domain.EmailSecurity{
+		EmailID:   r.EmailID,
+		RiskLevel: domain.SecurityRiskLevel(r.RiskLevel),
+		RiskScore: r.RiskScore,
+		SPF:       r.SPF.String,
+		DKIM:      r.DKIM.String,
+		DMARC:     r.DMARC.String,
+	}
+	if len(r.Signals) > 0 {
+		_ = json.Unmarshal(r.Signals, &sec.Signals)
+	}
+	if r.AnalyzedAt.Valid {
+		sec.AnalyzedAt = r.AnalyzedAt.Time
+	}
+	return sec
+}
+
+// Save upserts the analysis result of an email.
+func (a *EmailSecurityAdapter) Save(ctx context.Context, userID uuid.UUID, security *domain.EmailSecurity) error {
+	signals := security.Signals
+	if signals == nil {
+		signals = []domain.SecuritySignal{}
+	}
+	signalsJSON, err := json.Marshal(signals)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO email_security (email_id, user_id, risk_level, risk_score, signals, spf, dkim, dmarc, analyzed_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), $9)
+		ON CONFLICT (email_id) DO UPDATE SET
+			risk_level = EXCLUDED.risk_level,
+			risk_score = EXCLUDED.risk_score,
+			signals = EXCLUDED.signals,
+			spf = COALESCE(EXCLUDED.spf, email_security.spf),
+			dkim = COALESCE(EXCLUDED.dkim, email_security.dkim),
+			dmarc = COALESCE(EXCLUDED.dmarc, email_security.dmarc),
+			analyzed_at = EXCLUDED.analyzed_at
+	`
+
+	_, err = a.db.ExecContext(ctx, query,
+		security.EmailID,
+		userID,
+		security.RiskLevel,
+		security.RiskScore,
+		signalsJSON,
+		security.SPF,
+		security.DKIM,
+		security.DMARC,
+		security.AnalyzedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save email security: %w", err)
+	}
+	return nil
+}
+
+// GetByEmailID retrieves the analysis result of an email.
+func (a *EmailSecurityAdapter) GetByEmailID(ctx context.Context, emailID int64) (*domain.EmailSecurity, error) {
+	query := `SELECT ` + emailSecurityColumns + ` FROM email_security WHERE email_id = $1`
+
+	var row emailSecurityRow
+	if err := a.db.GetContext(ctx, &row, query, emailID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get email security: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// GetByEmailIDs retrieves analysis results for multiple emails.
+func (a *EmailSecurityAdapter) GetByEmailIDs(ctx context.Context, emailIDs []int64) (map[int64]*domain.EmailSecurity, error) {
+	result := make(map[int64]*domain.EmailSecurity, len(emailIDs))
+	if len(emailIDs) == 0 {
+		return result, nil
+	}
+
+	query := `SELECT ` + emailSecurityColumns + ` FROM email_security WHERE email_id = ANY($1)`
+
+	var rows []emailSecurityRow
+	if err := a.db.SelectContext(ctx, &rows, query, pq.Array(emailIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get email security: %w", err)
+	}
+
+	for i := range rows {
+		result[rows[i].EmailID] = rows[i].toDomain()
+	}
+	return result, nil
+}
+
+var _ out.EmailSecurityRepository = (*EmailSecurityAdapter)(nil)
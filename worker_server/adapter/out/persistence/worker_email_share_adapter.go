@@ -0,0 +1,146 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// EmailShareAdapter implements out.EmailShareRepository using PostgreSQL.
+type EmailShareAdapter struct {
+	db *sqlx.DB
+}
+
+// NewEmailShareAdapter creates a new EmailShareAdapter.
+func NewEmailShareAdapter(db *sqlx.DB) *EmailShareAdapter {
+	return &EmailShareAdapter{db: db}
+}
+
+// emailShareRow represents the database row for a share link.
+type emailShareRow struct {
+	ID            int64          `db:"id"`
+	EmailID       int64          `db:"email_id"`
+	UserID        uuid.UUID      `db:"user_id"`
+	TokenHash     string         `db:"token_hash"`
+	PasswordHash  sql.NullString `db:"password_hash"`
+	AttachmentIDs pq.Int64Array  `db:"attachment_ids"`
+	ExpiresAt     time.Time      `db:"expires_at"`
+	RevokedAt     sql.NullTime   `db:"revoked_at"`
+	ViewCount     int            `db:"view_count"`
+	LastViewedAt  sql.NullTime   `db:"last_viewed_at"`
+	CreatedAt     time.Time      `db:"created_at"`
+}
+
+const emailShareColumns = `id, email_id, user_id, token_hash, password_hash, attachment_ids,
+	expires_at, revoked_at, view_count, last_viewed_at, created_at`
+
+func (r *emailShareRow) toDomain() *domain.EmailShare {
+	share := &domain.EmailShare{
+		ID:            r.ID,
+		EmailID:       r.EmailID,
+		UserID:        r.UserID,
+		TokenHash:     r.TokenHash,
+		PasswordHash:  r.PasswordHash.String,
+		HasPassword:   r.PasswordHash.Valid && r.PasswordHash.String != "",
+		AttachmentIDs: []int64(r.AttachmentIDs),
+		ExpiresAt:     r.ExpiresAt,
+		ViewCount:     r.ViewCount,
+		CreatedAt:     r.CreatedAt,
+	}
+	if share.AttachmentIDs == nil {
+		share.AttachmentIDs = []int64{}
+	}
+	if r.RevokedAt.Valid {
+		share.RevokedAt = &r.RevokedAt.Time
+	}
+	if r.LastViewedAt.Valid {
+		share.LastViewedAt = &r.LastViewedAt.Time
+	}
+	return share
+}
+
+// Create inserts a share link only if the email belongs to the share's user.
+func (a *EmailShareAdapter) Create(ctx context.Context, share *domain.EmailShare) error {
+	query := `
+		INSERT INTO email_shares (email_id, user_id, token_hash, password_hash, attachment_ids, expires_at)
+		SELECT e.id, e.user_id, $3, NULLIF($4, ''), $5, $6
+		FROM emails e
+		WHERE e.id = $1 AND e.user_id = $2
+		RETURNING id, created_at
+	`
+	err := a.db.QueryRowxContext(ctx, query,
+		share.EmailID, share.UserID, share.TokenHash, share.PasswordHash, pq.Array(share.AttachmentIDs), share.ExpiresAt,
+	).Scan(&share.ID, &share.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return out.ErrEmailShareNotFound
+		}
+		return fmt.Errorf("failed to create email share: %w", err)
+	}
+	return nil
+}
+
+// GetByTokenHash returns the share link with the given token hash.
+func (a *EmailShareAdapter) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.EmailShare, error) {
+	query := `SELECT ` + emailShareColumns + ` FROM email_shares WHERE token_hash = $1`
+	var row emailShareRow
+	if err := a.db.GetContext(ctx, &row, query, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, out.ErrEmailShareNotFound
+		}
+		return nil, fmt.Errorf("failed to get email share: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// ListByEmailID returns the share links of an email, newest first.
+func (a *EmailShareAdapter) ListByEmailID(ctx context.Context, userID uuid.UUID, emailID int64) ([]*domain.EmailShare, error) {
+	query := `SELECT ` + emailShareColumns + ` FROM email_shares WHERE user_id = $1 AND email_id = $2 ORDER BY created_at DESC`
+	var rows []emailShareRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID, emailID); err != nil {
+		return nil, fmt.Errorf("failed to list email shares: %w", err)
+	}
+
+	shares := make([]*domain.EmailShare, len(rows))
+	for i := range rows {
+		shares[i] = rows[i].toDomain()
+	}
+	return shares, nil
+}
+
+// Revoke disables a share link.
+func (a *EmailShareAdapter) Revoke(ctx context.Context, userID uuid.UUID, emailID, shareID int64) error {
+	query := `
+		UPDATE email_shares
+		SET revoked_at = COALESCE(revoked_at, NOW())
+		WHERE id = $1 AND email_id = $2 AND user_id = $3
+	`
+	result, err := a.db.ExecContext(ctx, query, shareID, emailID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke email share: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return out.ErrEmailShareNotFound
+	}
+	return nil
+}
+
+// RecordView increments the view count of a share link.
+func (a *EmailShareAdapter) RecordView(ctx context.Context, shareID int64) error {
+	query := `UPDATE email_shares SET view_count = view_count + 1, last_viewed_at = NOW() WHERE id = $1`
+	if _, err := a.db.ExecContext(ctx, query, shareID); err != nil {
+		return fmt.Errorf("failed to record email share view: %w", err)
+	}
+	return nil
+}
+
+var _ out.EmailShareRepository = (*EmailShareAdapter)(nil)
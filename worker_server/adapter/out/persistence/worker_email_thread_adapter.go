@@ -3,6 +3,7 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -93,7 +94,7 @@ func (a *MailAdapter) GetThreadMessages(ctx context.Context, threadID int64) ([]
 			c.photo_url as contact_photo
 		FROM emails e
 		LEFT JOIN contacts c ON c.user_id = e.user_id AND c.email = e.from_email
-		WHERE e.thread_id = $1
+		WHERE e.thread_id = $1 AND e.deleted_at IS NULL
 		ORDER BY e.email_date ASC`, mailSelectColumns)
 
 	rows, err := a.db.QueryxContext(ctx, query, threadID)
@@ -418,7 +419,7 @@ func (a *MailAdapter) ListPendingAI(ctx context.Context, userID uuid.UUID, limit
 			c.photo_url as contact_photo
 		FROM emails e
 		LEFT JOIN contacts c ON c.user_id = e.user_id AND c.email = e.from_email
-		WHERE e.user_id = $1 AND (e.ai_status IN ('none', 'pending') OR e.ai_category IS NULL)
+		WHERE e.user_id = $1 AND e.deleted_at IS NULL AND (e.ai_status IN ('none', 'pending') OR e.ai_category IS NULL)
 		ORDER BY e.email_date DESC
 		LIMIT $2`, mailSelectColumns)
 
@@ -445,11 +446,47 @@ func (a *MailAdapter) CountUnclassified(ctx context.Context, connectionID int64)
 	var count int
 	err := a.db.QueryRowxContext(ctx, `
 		SELECT COUNT(*) FROM emails
-		WHERE connection_id = $1 AND (ai_status IN ('none', 'pending') OR ai_category IS NULL)`,
+		WHERE connection_id = $1 AND deleted_at IS NULL AND (ai_status IN ('none', 'pending') OR ai_category IS NULL)`,
 		connectionID).Scan(&count)
 	return count, err
 }
 
+// SetAIStatus sets ai_status of emails.
+func (a *MailAdapter) SetAIStatus(ctx context.Context, ids []int64, status string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := a.db.ExecContext(ctx,
+		"UPDATE emails SET ai_status = $1::ai_status, updated_at = NOW() WHERE id = ANY($2)",
+		status, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to set ai status: %w", err)
+	}
+	return nil
+}
+
+// CountAIStatuses counts a connection's emails by classification state.
+func (a *MailAdapter) CountAIStatuses(ctx context.Context, userID uuid.UUID, connectionID int64) (*out.AIStatusCounts, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	var counts out.AIStatusCounts
+	err := a.db.QueryRowxContext(ctx, `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE ai_status::text NOT IN ('processing', 'failed') AND (ai_status::text IN ('none', 'pending') OR ai_category IS NULL)),
+			COUNT(*) FILTER (WHERE ai_status::text = 'processing'),
+			COUNT(*) FILTER (WHERE ai_status::text NOT IN ('none', 'pending', 'processing', 'failed') AND ai_category IS NOT NULL),
+			COUNT(*) FILTER (WHERE ai_status::text = 'failed')
+		FROM emails
+		WHERE user_id = $1 AND connection_id = $2 AND deleted_at IS NULL`,
+		userID, connectionID).Scan(&counts.Total, &counts.Pending, &counts.Processing, &counts.Classified, &counts.Failed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count ai statuses: %w", err)
+	}
+	return &counts, nil
+}
+
 // ListUnclassifiedByConnection lists emails that need classification for a specific connection.
 // Used for reclassification after OAuth reconnect or classification errors.
 func (a *MailAdapter) ListUnclassifiedByConnection(ctx context.Context, connectionID int64, limit int) ([]*out.MailEntity, error) {
@@ -464,7 +501,7 @@ func (a *MailAdapter) ListUnclassifiedByConnection(ctx context.Context, connecti
 			c.photo_url as contact_photo
 		FROM emails e
 		LEFT JOIN contacts c ON c.user_id = e.user_id AND c.email = e.from_email
-		WHERE e.connection_id = $1 AND (e.ai_status IN ('none', 'pending') OR e.ai_category IS NULL)
+		WHERE e.connection_id = $1 AND e.deleted_at IS NULL AND (e.ai_status IN ('none', 'pending') OR e.ai_category IS NULL)
 		ORDER BY e.email_date DESC
 		LIMIT $2`, mailSelectColumns)
 
@@ -486,16 +523,48 @@ func (a *MailAdapter) ListUnclassifiedByConnection(ctx context.Context, connecti
 	return emails, nil
 }
 
+// ListUnclassifiedIDsAfter lists unclassified email IDs after afterID (keyset paging by id).
+func (a *MailAdapter) ListUnclassifiedIDsAfter(ctx context.Context, connectionID, afterID int64, limit int) ([]int64, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	var ids []int64
+	err := a.db.SelectContext(ctx, &ids, `
+		SELECT id FROM emails
+		WHERE connection_id = $1 AND id > $2 AND deleted_at IS NULL AND (ai_status IN ('none', 'pending') OR ai_category IS NULL)
+		ORDER BY id
+		LIMIT $3`,
+		connectionID, afterID, limit)
+	return ids, err
+}
+
 // =============================================================================
 // Bulk Operations
 // =============================================================================
 
 // BulkUpsert bulk upserts emails.
+// 대량 배치는 COPY로 임시 테이블에 넣고 한 번에 upsert한다 (upsertByCopy).
 func (a *MailAdapter) BulkUpsert(ctx context.Context, userID uuid.UUID, connectionID int64, mails []*out.MailEntity) error {
 	if len(mails) == 0 {
 		return nil
 	}
 
+	externalIDs := make([]string, len(mails))
+	for i, mail := range mails {
+		externalIDs[i] = mail.ExternalID
+	}
+	if err := a.rehydrateArchived(ctx, connectionID, externalIDs); err != nil {
+		return err
+	}
+
+	if len(mails) >= copyUpsertThreshold {
+		err := a.upsertByCopy(ctx, userID, connectionID, mails)
+		if !errors.Is(err, errCopyUnsupported) {
+			return err
+		}
+	}
+
 	// Process in batches of 100
 	const batchSize = 100
 	for i := 0; i < len(mails); i += batchSize {
@@ -519,9 +588,21 @@ var bulkUpsertColumns = []string{
 	"from_email", "from_name", "to_emails", "cc_emails", "bcc_emails",
 	"subject", "snippet", "direction", "folder", "labels",
 	"is_read", "is_draft", "has_attachment", "is_replied", "is_forwarded",
-	"tags", "workflow_status", "ai_status", "email_date",
+	"tags", "workflow_status", "ai_status", "email_date", "external_thread_id",
 }
 
+// bulkUpsertConflict updates synced fields of existing emails (external_id 기준).
+const bulkUpsertConflict = `ON CONFLICT (user_id, connection_id, external_id)
+		DO UPDATE SET
+			from_email = EXCLUDED.from_email, from_name = EXCLUDED.from_name,
+			to_emails = EXCLUDED.to_emails, cc_emails = EXCLUDED.cc_emails,
+			subject = EXCLUDED.subject, snippet = EXCLUDED.snippet,
+			direction = EXCLUDED.direction, folder = EXCLUDED.folder, labels = EXCLUDED.labels,
+			is_read = EXCLUDED.is_read, tags = EXCLUDED.tags,
+			has_attachment = EXCLUDED.has_attachment,
+			external_thread_id = COALESCE(EXCLUDED.external_thread_id, emails.external_thread_id),
+			updated_at = NOW()`
+
 // buildPlaceholders generates ($1, $2, ..., $N, NOW()) for a single row
 func buildPlaceholders(rowIndex, paramsPerRow int) string {
 	placeholders := make([]string, paramsPerRow)
@@ -561,7 +642,7 @@ func buildMailValues(userID uuid.UUID, connectionID int64, mail *out.MailEntity)
 		mail.Subject, mail.Snippet, direction, mail.Folder, pq.Array(mail.Labels),
 		mail.IsRead, mail.IsDraft, mail.HasAttachment, mail.IsReplied, mail.IsForwarded,
 		pq.Array(mail.Tags), workflowStatus, aiStatus,
-		mail.ReceivedAt,
+		mail.ReceivedAt, nullStr(mail.ExternalThreadID),
 	}
 }
 
@@ -580,16 +661,8 @@ func (a *MailAdapter) bulkUpsertBatch(ctx context.Context, userID uuid.UUID, con
 
 	query := fmt.Sprintf(`
 		INSERT INTO emails (%s) VALUES %s
-		ON CONFLICT (user_id, connection_id, external_id)
-		DO UPDATE SET
-			from_email = EXCLUDED.from_email, from_name = EXCLUDED.from_name,
-			to_emails = EXCLUDED.to_emails, cc_emails = EXCLUDED.cc_emails,
-			subject = EXCLUDED.subject, snippet = EXCLUDED.snippet,
-			direction = EXCLUDED.direction, folder = EXCLUDED.folder, labels = EXCLUDED.labels,
-			is_read = EXCLUDED.is_read, tags = EXCLUDED.tags,
-			has_attachment = EXCLUDED.has_attachment,
-			updated_at = NOW()`,
-		columnList, strings.Join(valueStrings, ", "))
+		%s`,
+		columnList, strings.Join(valueStrings, ", "), bulkUpsertConflict)
 
 	_, err := a.db.ExecContext(ctx, query, valueArgs...)
 	return err
@@ -76,7 +76,7 @@ func (a *FolderAdapter) GetByID(id int64) (*domain.EmailFolder, error) {
 
 	if err := a.db.Get(&row, query, id); err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("folder not found: %d", id)
+			return nil, fmt.Errorf("folder %d: %w", id, ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get folder: %w", err)
 	}
@@ -186,7 +186,7 @@ func (a *FolderAdapter) Update(folder *domain.EmailFolder) error {
 	return nil
 }
 
-// Delete soft-deletes a folder.
+// Delete soft-deletes a folder and detaches its emails (메일은 원래 위치에 남는다).
 func (a *FolderAdapter) Delete(id int64) error {
 	query := `UPDATE folders SET deleted_at = NOW() WHERE id = $1 AND type = 'user' AND deleted_at IS NULL`
 
@@ -200,6 +200,10 @@ func (a *FolderAdapter) Delete(id int64) error {
 		return fmt.Errorf("folder not found or is system folder: %d", id)
 	}
 
+	if _, err := a.db.Exec(`UPDATE emails SET folder_id = NULL WHERE folder_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to detach folder emails: %w", err)
+	}
+
 	return nil
 }
 
@@ -211,6 +215,7 @@ type folderProviderMappingRow struct {
 	Provider     string         `db:"provider"`
 	ExternalID   sql.NullString `db:"external_id"`
 	MappingType  string         `db:"mapping_type"`
+	SyncedAt     sql.NullTime   `db:"synced_at"`
 	CreatedAt    time.Time      `db:"created_at"`
 	UpdatedAt    time.Time      `db:"updated_at"`
 }
@@ -228,6 +233,9 @@ func (r *folderProviderMappingRow) toEntity() *domain.FolderProviderMapping {
 	if r.ExternalID.Valid {
 		mapping.ExternalID = &r.ExternalID.String
 	}
+	if r.SyncedAt.Valid {
+		mapping.SyncedAt = &r.SyncedAt.Time
+	}
 	return mapping
 }
 
@@ -264,9 +272,9 @@ func (a *FolderAdapter) GetMappingByExternalID(connectionID int64, externalID st
 // CreateMapping creates a new folder provider mapping.
 func (a *FolderAdapter) CreateMapping(mapping *domain.FolderProviderMapping) error {
 	query := `
-		INSERT INTO folder_provider_mappings (folder_id, connection_id, provider, external_id, mapping_type)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, created_at, updated_at`
+		INSERT INTO folder_provider_mappings (folder_id, connection_id, provider, external_id, mapping_type, synced_at)
+		VALUES ($1, $2, $3, $4, $5, CASE WHEN $4::VARCHAR IS NULL THEN NULL ELSE NOW() END)
+		RETURNING id, synced_at, created_at, updated_at`
 
 	var externalID sql.NullString
 	if mapping.ExternalID != nil {
@@ -280,7 +288,7 @@ func (a *FolderAdapter) CreateMapping(mapping *domain.FolderProviderMapping) err
 		string(mapping.Provider),
 		externalID,
 		string(mapping.MappingType),
-	).Scan(&mapping.ID, &mapping.CreatedAt, &mapping.UpdatedAt)
+	).Scan(&mapping.ID, &mapping.SyncedAt, &mapping.CreatedAt, &mapping.UpdatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create folder mapping: %w", err)
@@ -293,7 +301,7 @@ func (a *FolderAdapter) CreateMapping(mapping *domain.FolderProviderMapping) err
 func (a *FolderAdapter) UpdateMapping(mapping *domain.FolderProviderMapping) error {
 	query := `
 		UPDATE folder_provider_mappings
-		SET external_id = $2, updated_at = NOW()
+		SET external_id = $2, synced_at = NOW(), updated_at = NOW()
 		WHERE id = $1`
 
 	var externalID sql.NullString
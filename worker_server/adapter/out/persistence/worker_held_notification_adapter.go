@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// HeldNotificationAdapter implements out.HeldNotificationRepository using PostgreSQL.
+type HeldNotificationAdapter struct {
+	db *sqlx.DB
+}
+
+// NewHeldNotificationAdapter creates a new HeldNotificationAdapter.
+func NewHeldNotificationAdapter(db *sqlx.DB) *HeldNotificationAdapter {
+	return &HeldNotificationAdapter{db: db}
+}
+
+// heldNotificationRow represents the database row for a held notification.
+type heldNotificationRow struct {
+	ID         int64          `db:"id"`
+	UserID     uuid.UUID      `db:"user_id"`
+	Type       string         `db:"type"`
+	Title      string         `db:"title"`
+	Body       sql.NullString `db:"body"`
+	EntityType sql.NullString `db:"entity_type"`
+	EntityID   sql.NullInt64  `db:"entity_id"`
+	Priority   string         `db:"priority"`
+	ReleaseAt  time.Time      `db:"release_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (r *heldNotificationRow) toDomain() *domain.HeldNotification {
+	return &domain.HeldNotification{
+		ID:         r.ID,
+		UserID:     r.UserID,
+		Type:       domain.NotificationType(r.Type),
+		Title:      r.Title,
+		Body:       r.Body.String,
+		EntityType: r.EntityType.String,
+		EntityID:   r.EntityID.Int64,
+		Priority:   domain.NotificationPriority(r.Priority),
+		ReleaseAt:  r.ReleaseAt,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+// Hold stores a notification until its release time.
+func (a *HeldNotificationAdapter) Hold(ctx context.Context, n *domain.HeldNotification) error {
+	query := `
+		INSERT INTO held_notifications (user_id, type, title, body, entity_type, entity_id, priority, release_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+	entityID := sql.NullInt64{Int64: n.EntityID, Valid: n.EntityID != 0}
+	err := a.db.QueryRowxContext(ctx, query,
+		n.UserID, n.Type, n.Title, nullStr(n.Body), nullStr(n.EntityType), entityID, n.Priority, n.ReleaseAt,
+	).Scan(&n.ID, &n.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to hold notification: %w", err)
+	}
+	return nil
+}
+
+// ListDueUsers returns users with held notifications whose release time has passed.
+func (a *HeldNotificationAdapter) ListDueUsers(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT user_id FROM held_notifications
+		WHERE release_at <= $1
+		GROUP BY user_id
+		ORDER BY MIN(release_at)
+		LIMIT $2
+	`
+
+	var userIDs []uuid.UUID
+	if err := a.db.SelectContext(ctx, &userIDs, query, now, limit); err != nil {
+		return nil, fmt.Errorf("failed to list due held notifications: %w", err)
+	}
+	return userIDs, nil
+}
+
+// ClaimDue deletes and returns the user's due notifications, oldest first.
+func (a *HeldNotificationAdapter) ClaimDue(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.HeldNotification, error) {
+	query := `
+		DELETE FROM held_notifications
+		WHERE user_id = $1 AND release_at <= $2
+		RETURNING id, user_id, type, title, body, entity_type, entity_id, priority, release_at, created_at
+	`
+
+	var rows []heldNotificationRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID, now); err != nil {
+		return nil, fmt.Errorf("failed to claim held notifications: %w", err)
+	}
+	held := make([]*domain.HeldNotification, len(rows))
+	for i := range rows {
+		held[i] = rows[i].toDomain()
+	}
+	// RETURNING은 순서를 보장하지 않으므로 생성 순으로 정렬한다.
+	sort.Slice(held, func(i, j int) bool { return held[i].CreatedAt.Before(held[j].CreatedAt) })
+	return held, nil
+}
+
+var _ out.HeldNotificationRepository = (*HeldNotificationAdapter)(nil)
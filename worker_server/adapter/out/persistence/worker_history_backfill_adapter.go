@@ -0,0 +1,190 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// HistoryBackfillAdapter implements out.HistoryBackfillRepository using PostgreSQL.
+type HistoryBackfillAdapter struct {
+	db *sqlx.DB
+}
+
+// NewHistoryBackfillAdapter creates a new HistoryBackfillAdapter.
+func NewHistoryBackfillAdapter(db *sqlx.DB) *HistoryBackfillAdapter {
+	return &HistoryBackfillAdapter{db: db}
+}
+
+// historyBackfillRow represents the database row for a history backfill.
+type historyBackfillRow struct {
+	ID           int64          `db:"id"`
+	UserID       string         `db:"user_id"`
+	ConnectionID int64          `db:"connection_id"`
+	Since        time.Time      `db:"since"`
+	Until        time.Time      `db:"until"`
+	Status       string         `db:"status"`
+	PageToken    sql.NullString `db:"page_token"`
+	Budget       int            `db:"budget"`
+	Fetched      int            `db:"fetched"`
+	Saved        int            `db:"saved"`
+	LastError    sql.NullString `db:"last_error"`
+	CreatedAt    time.Time      `db:"created_at"`
+	StartedAt    sql.NullTime   `db:"started_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+	CompletedAt  sql.NullTime   `db:"completed_at"`
+}
+
+const historyBackfillColumns = `id, user_id, connection_id, since, until, status, page_token, budget, fetched, saved, last_error, created_at, started_at, updated_at, completed_at`
+
+func (r *historyBackfillRow) toDomain() *domain.HistoryBackfill {
+	return &domain.HistoryBackfill{
+		ID:           r.ID,
+		UserID:       r.UserID,
+		ConnectionID: r.ConnectionID,
+		Since:        r.Since,
+		Until:        r.Until,
+		Status:       domain.HistoryBackfillStatus(r.Status),
+		PageToken:    r.PageToken.String,
+		Budget:       r.Budget,
+		Fetched:      r.Fetched,
+		Saved:        r.Saved,
+		LastError:    r.LastError.String,
+		CreatedAt:    r.CreatedAt,
+		StartedAt:    nullTimePtr(r.StartedAt),
+		UpdatedAt:    r.UpdatedAt,
+		CompletedAt:  nullTimePtr(r.CompletedAt),
+	}
+}
+
+func historyBackfillsToDomain(rows []historyBackfillRow) []*domain.HistoryBackfill {
+	result := make([]*domain.HistoryBackfill, len(rows))
+	for i := range rows {
+		result[i] = rows[i].toDomain()
+	}
+	return result
+}
+
+// Create inserts a pending backfill and fills its ID and timestamps.
+func (a *HistoryBackfillAdapter) Create(ctx context.Context, b *domain.HistoryBackfill) error {
+	query := `
+		INSERT INTO history_backfills (user_id, connection_id, since, until, budget)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + historyBackfillColumns
+
+	var row historyBackfillRow
+	if err := a.db.GetContext(ctx, &row, query, b.UserID, b.ConnectionID, b.Since, b.Until, b.Budget); err != nil {
+		return fmt.Errorf("failed to create history backfill: %w", err)
+	}
+	*b = *row.toDomain()
+	return nil
+}
+
+// Get retrieves a backfill by ID (없으면 nil).
+func (a *HistoryBackfillAdapter) Get(ctx context.Context, id int64) (*domain.HistoryBackfill, error) {
+	return a.getOne(ctx, `SELECT `+historyBackfillColumns+` FROM history_backfills WHERE id = $1`, id)
+}
+
+// GetActive retrieves the pending/running backfill of a connection (없으면 nil).
+func (a *HistoryBackfillAdapter) GetActive(ctx context.Context, connectionID int64) (*domain.HistoryBackfill, error) {
+	return a.getOne(ctx, `SELECT `+historyBackfillColumns+` FROM history_backfills
+		WHERE connection_id = $1 AND status IN ('pending', 'running')`, connectionID)
+}
+
+func (a *HistoryBackfillAdapter) getOne(ctx context.Context, query string, arg any) (*domain.HistoryBackfill, error) {
+	var row historyBackfillRow
+	err := a.db.GetContext(ctx, &row, query, arg)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history backfill: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// ListByUser lists the user's backfills, newest first.
+func (a *HistoryBackfillAdapter) ListByUser(ctx context.Context, userID string, limit int) ([]*domain.HistoryBackfill, error) {
+	query := `SELECT ` + historyBackfillColumns + ` FROM history_backfills
+		WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2`
+
+	var rows []historyBackfillRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list history backfills: %w", err)
+	}
+	return historyBackfillsToDomain(rows), nil
+}
+
+// ListActive lists pending/running backfills, least recently updated first.
+func (a *HistoryBackfillAdapter) ListActive(ctx context.Context, limit int) ([]*domain.HistoryBackfill, error) {
+	query := `SELECT ` + historyBackfillColumns + ` FROM history_backfills
+		WHERE status IN ('pending', 'running') ORDER BY updated_at LIMIT $1`
+
+	var rows []historyBackfillRow
+	if err := a.db.SelectContext(ctx, &rows, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to list active history backfills: %w", err)
+	}
+	return historyBackfillsToDomain(rows), nil
+}
+
+// SaveCheckpoint records the next page token and counts of an active backfill.
+func (a *HistoryBackfillAdapter) SaveCheckpoint(ctx context.Context, id int64, pageToken string, fetched, saved int) (bool, error) {
+	query := `
+		UPDATE history_backfills SET
+			status = 'running',
+			page_token = NULLIF($2, ''),
+			fetched = $3,
+			saved = $4,
+			started_at = COALESCE(started_at, NOW()),
+			updated_at = NOW()
+		WHERE id = $1 AND status IN ('pending', 'running')
+	`
+	res, err := a.db.ExecContext(ctx, query, id, pageToken, fetched, saved)
+	if err != nil {
+		return false, fmt.Errorf("failed to save history backfill checkpoint: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Finish sets the terminal status of an active backfill.
+func (a *HistoryBackfillAdapter) Finish(ctx context.Context, id int64, status domain.HistoryBackfillStatus, errMsg string) error {
+	query := `
+		UPDATE history_backfills SET
+			status = $2,
+			last_error = NULLIF($3, ''),
+			updated_at = NOW(),
+			completed_at = NOW()
+		WHERE id = $1 AND status IN ('pending', 'running')
+	`
+	if _, err := a.db.ExecContext(ctx, query, id, string(status), errMsg); err != nil {
+		return fmt.Errorf("failed to finish history backfill: %w", err)
+	}
+	return nil
+}
+
+// UsedBudget sums fetched messages of finished backfills and the whole budget of active ones.
+func (a *HistoryBackfillAdapter) UsedBudget(ctx context.Context, userID string, since time.Time) (int, error) {
+	query := `
+		SELECT COALESCE(SUM(CASE WHEN status IN ('pending', 'running') THEN budget ELSE fetched END), 0)
+		FROM history_backfills
+		WHERE user_id = $1 AND created_at >= $2
+	`
+	var used int
+	if err := a.db.GetContext(ctx, &used, query, userID, since); err != nil {
+		return 0, fmt.Errorf("failed to sum history backfill budget: %w", err)
+	}
+	return used, nil
+}
+
+var _ out.HistoryBackfillRepository = (*HistoryBackfillAdapter)(nil)
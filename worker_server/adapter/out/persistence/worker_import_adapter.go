@@ -0,0 +1,199 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// MailImportAdapter implements out.MailImportRepository using PostgreSQL.
+type MailImportAdapter struct {
+	db *sqlx.DB
+}
+
+// NewMailImportAdapter creates a new MailImportAdapter.
+func NewMailImportAdapter(db *sqlx.DB) *MailImportAdapter {
+	return &MailImportAdapter{db: db}
+}
+
+// mailImportRow represents the database row for mail imports.
+type mailImportRow struct {
+	ID            int64          `db:"id"`
+	UserID        uuid.UUID      `db:"user_id"`
+	ConnectionID  sql.NullInt64  `db:"connection_id"`
+	Filename      string         `db:"filename"`
+	Format        string         `db:"format"`
+	SizeBytes     int64          `db:"size_bytes"`
+	Status        string         `db:"status"`
+	TotalCount    int            `db:"total_count"`
+	ImportedCount int            `db:"imported_count"`
+	SkippedCount  int            `db:"skipped_count"`
+	FailedCount   int            `db:"failed_count"`
+	ErrorMessage  sql.NullString `db:"error_message"`
+	StartedAt     sql.NullTime   `db:"started_at"`
+	CompletedAt   sql.NullTime   `db:"completed_at"`
+	CreatedAt     sql.NullTime   `db:"created_at"`
+	UpdatedAt     sql.NullTime   `db:"updated_at"`
+}
+
+const mailImportColumns = `
+	id, user_id, connection_id, filename, format, size_bytes, status,
+	total_count, imported_count, skipped_count, failed_count, error_message,
+	started_at, completed_at, created_at, updated_at`
+
+func (r *mailImportRow) toDomain() *domain.MailImport {
+	job := &domain.MailImport{
+		ID:            r.ID,
+		UserID:        r.UserID,
+		Filename:      r.Filename,
+		Format:        domain.MailImportFormat(r.Format),
+		SizeBytes:     r.SizeBytes,
+		Status:        domain.MailImportStatus(r.Status),
+		TotalCount:    r.TotalCount,
+		ImportedCount: r.ImportedCount,
+		SkippedCount:  r.SkippedCount,
+		FailedCount:   r.FailedCount,
+	}
+
+	if r.ConnectionID.Valid {
+		job.ConnectionID = &r.ConnectionID.Int64
+	}
+	if r.ErrorMessage.Valid {
+		job.ErrorMessage = r.ErrorMessage.String
+	}
+	if r.StartedAt.Valid {
+		job.StartedAt = &r.StartedAt.Time
+	}
+	if r.CompletedAt.Valid {
+		job.CompletedAt = &r.CompletedAt.Time
+	}
+	if r.CreatedAt.Valid {
+		job.CreatedAt = r.CreatedAt.Time
+	}
+	if r.UpdatedAt.Valid {
+		job.UpdatedAt = r.UpdatedAt.Time
+	}
+
+	return job
+}
+
+// Create stores a new import job together with the uploaded source.
+func (a *MailImportAdapter) Create(ctx context.Context, job *domain.MailImport, source []byte) error {
+	if job.Status == "" {
+		job.Status = domain.MailImportPending
+	}
+
+	query := `
+		INSERT INTO mail_imports (user_id, filename, format, size_bytes, source, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+
+	return a.db.QueryRowxContext(ctx, query,
+		job.UserID,
+		job.Filename,
+		job.Format,
+		job.SizeBytes,
+		source,
+		job.Status,
+	).Scan(&job.ID, &job.CreatedAt, &job.UpdatedAt)
+}
+
+// GetByID retrieves an import job owned by the user.
+func (a *MailImportAdapter) GetByID(ctx context.Context, userID uuid.UUID, id int64) (*domain.MailImport, error) {
+	query := `SELECT ` + mailImportColumns + ` FROM mail_imports WHERE id = $1 AND user_id = $2`
+
+	var row mailImportRow
+	if err := a.db.GetContext(ctx, &row, query, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get mail import: %w", err)
+	}
+
+	return row.toDomain(), nil
+}
+
+// ListByUser lists recent import jobs for a user.
+func (a *MailImportAdapter) ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.MailImport, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	query := `SELECT ` + mailImportColumns + `
+		FROM mail_imports
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	var rows []mailImportRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list mail imports: %w", err)
+	}
+
+	jobs := make([]*domain.MailImport, len(rows))
+	for i := range rows {
+		jobs[i] = rows[i].toDomain()
+	}
+	return jobs, nil
+}
+
+// GetSource returns the uploaded archive.
+func (a *MailImportAdapter) GetSource(ctx context.Context, id int64) ([]byte, error) {
+	var source []byte
+	if err := a.db.GetContext(ctx, &source, `SELECT source FROM mail_imports WHERE id = $1`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get import source: %w", err)
+	}
+	return source, nil
+}
+
+// ClearSource drops the stored archive once it has been processed.
+func (a *MailImportAdapter) ClearSource(ctx context.Context, id int64) error {
+	_, err := a.db.ExecContext(ctx, `UPDATE mail_imports SET source = NULL, updated_at = NOW() WHERE id = $1`, id)
+	return err
+}
+
+// MarkProcessing marks the job as started.
+func (a *MailImportAdapter) MarkProcessing(ctx context.Context, id int64, connectionID int64) error {
+	query := `
+		UPDATE mail_imports
+		SET status = $2, connection_id = $3, started_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err := a.db.ExecContext(ctx, query, id, domain.MailImportProcessing, connectionID)
+	return err
+}
+
+// UpdateProgress updates counters.
+func (a *MailImportAdapter) UpdateProgress(ctx context.Context, id int64, total, imported, skipped, failed int) error {
+	query := `
+		UPDATE mail_imports
+		SET total_count = $2, imported_count = $3, skipped_count = $4, failed_count = $5, updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err := a.db.ExecContext(ctx, query, id, total, imported, skipped, failed)
+	return err
+}
+
+// MarkFinished sets a terminal status.
+func (a *MailImportAdapter) MarkFinished(ctx context.Context, id int64, status domain.MailImportStatus, errMsg string) error {
+	query := `
+		UPDATE mail_imports
+		SET status = $2, error_message = NULLIF($3, ''), completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err := a.db.ExecContext(ctx, query, id, status, errMsg)
+	return err
+}
+
+var _ out.MailImportRepository = (*MailImportAdapter)(nil)
@@ -0,0 +1,175 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"worker_server/core/domain"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// InboxTriageAdapter implements out.InboxTriageRepository using PostgreSQL.
+type InboxTriageAdapter struct {
+	db *sqlx.DB
+}
+
+// NewInboxTriageAdapter creates a new InboxTriageAdapter.
+func NewInboxTriageAdapter(db *sqlx.DB) *InboxTriageAdapter {
+	return &InboxTriageAdapter{db: db}
+}
+
+// inboxTriageRow represents the database row for inbox triages.
+type inboxTriageRow struct {
+	ID           uuid.UUID      `db:"id"`
+	UserID       uuid.UUID      `db:"user_id"`
+	ConnectionID sql.NullInt64  `db:"connection_id"`
+	Status       string         `db:"status"`
+	Actions      []byte         `db:"actions"` // JSONB
+	Results      []byte         `db:"results"` // JSONB
+	ErrorMessage sql.NullString `db:"error_message"`
+	ExpiresAt    sql.NullTime   `db:"expires_at"`
+	ConfirmedAt  sql.NullTime   `db:"confirmed_at"`
+	CompletedAt  sql.NullTime   `db:"completed_at"`
+	CreatedAt    sql.NullTime   `db:"created_at"`
+	UpdatedAt    sql.NullTime   `db:"updated_at"`
+}
+
+const inboxTriageColumns = `
+	id, user_id, connection_id, status, actions, results, error_message,
+	expires_at, confirmed_at, completed_at, created_at, updated_at`
+
+func (r *inboxTriageRow) toDomain() *domain.InboxTriage {
+	triage := &domain.InboxTriage{
+		ID:     r.ID,
+		UserID: r.UserID,
+		Status: domain.TriageStatus(r.Status),
+	}
+
+	if r.ConnectionID.Valid {
+		triage.ConnectionID = &r.ConnectionID.Int64
+	}
+	if len(r.Actions) > 0 {
+		_ = json.Unmarshal(r.Actions, &triage.Actions)
+	}
+	if len(r.Results) > 0 {
+		_ = json.Unmarshal(r.Results, &triage.Results)
+	}
+	if r.ErrorMessage.Valid {
+		triage.ErrorMessage = r.ErrorMessage.String
+	}
+	if r.ExpiresAt.Valid {
+		triage.ExpiresAt = r.ExpiresAt.Time
+	}
+	if r.ConfirmedAt.Valid {
+		triage.ConfirmedAt = &r.ConfirmedAt.Time
+	}
+	if r.CompletedAt.Valid {
+		triage.CompletedAt = &r.CompletedAt.Time
+	}
+	if r.CreatedAt.Valid {
+		triage.CreatedAt = r.CreatedAt.Time
+	}
+	if r.UpdatedAt.Valid {
+		triage.UpdatedAt = r.UpdatedAt.Time
+	}
+
+	return triage
+}
+
+// Create stores a proposed triage.
+func (a *InboxTriageAdapter) Create(ctx context.Context, triage *domain.InboxTriage) error {
+	if triage.ID == uuid.Nil {
+		triage.ID = uuid.New()
+	}
+	if triage.Status == "" {
+		triage.Status = domain.TriageProposed
+	}
+
+	actions, err := json.Marshal(triage.Actions)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO inbox_triages (id, user_id, connection_id, status, actions, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at, updated_at
+	`
+	if err := a.db.QueryRowxContext(ctx, query,
+		triage.ID,
+		triage.UserID,
+		triage.ConnectionID,
+		triage.Status,
+		actions,
+		triage.ExpiresAt,
+	).Scan(&triage.CreatedAt, &triage.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create inbox triage: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a triage owned by the user.
+func (a *InboxTriageAdapter) GetByID(ctx context.Context, userID, id uuid.UUID) (*domain.InboxTriage, error) {
+	query := `SELECT ` + inboxTriageColumns + ` FROM inbox_triages WHERE id = $1 AND user_id = $2`
+
+	var row inboxTriageRow
+	if err := a.db.GetContext(ctx, &row, query, id, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get inbox triage: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// MarkQueued confirms a proposed triage with the actions to run.
+func (a *InboxTriageAdapter) MarkQueued(ctx context.Context, id uuid.UUID, actions []*domain.TriageAction) (bool, error) {
+	data, err := json.Marshal(actions)
+	if err != nil {
+		return false, err
+	}
+
+	query := `
+		UPDATE inbox_triages
+		SET status = $2, actions = $3, confirmed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = $4
+	`
+	res, err := a.db.ExecContext(ctx, query, id, domain.TriageQueued, data, domain.TriageProposed)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// MarkRunning marks a queued triage as running (재전달 시 running 유지).
+func (a *InboxTriageAdapter) MarkRunning(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE inbox_triages
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND status IN ($3, $2)
+	`
+	_, err := a.db.ExecContext(ctx, query, id, domain.TriageRunning, domain.TriageQueued)
+	return err
+}
+
+// Finish stores the results and the final status of a triage.
+func (a *InboxTriageAdapter) Finish(ctx context.Context, id uuid.UUID, status domain.TriageStatus, results []*domain.TriageActionResult, errMsg string) error {
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE inbox_triages
+		SET status = $2, results = $3, error_message = NULLIF($4, ''), completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err = a.db.ExecContext(ctx, query, id, status, data, errMsg)
+	return err
+}
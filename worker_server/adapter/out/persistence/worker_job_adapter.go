@@ -0,0 +1,182 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// JobAdapter implements out.JobRepository using PostgreSQL.
+type JobAdapter struct {
+	db *sqlx.DB
+}
+
+// NewJobAdapter creates a new JobAdapter.
+func NewJobAdapter(db *sqlx.DB) *JobAdapter {
+	return &JobAdapter{db: db}
+}
+
+// jobRow represents the database row for a background job.
+type jobRow struct {
+	ID             uuid.UUID      `db:"id"`
+	UserID         uuid.UUID      `db:"user_id"`
+	Type           string         `db:"type"`
+	ConnectionID   sql.NullInt64  `db:"connection_id"`
+	State          string         `db:"state"`
+	ProgressTotal  int            `db:"progress_total"`
+	ProgressDone   int            `db:"progress_done"`
+	ProgressFailed int            `db:"progress_failed"`
+	Error          sql.NullString `db:"error"`
+	CreatedAt      time.Time      `db:"created_at"`
+	StartedAt      sql.NullTime   `db:"started_at"`
+	FinishedAt     sql.NullTime   `db:"finished_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+const jobColumns = `id, user_id, type, connection_id, state, progress_total, progress_done, progress_failed, error, created_at, started_at, finished_at, updated_at`
+
+func (r *jobRow) toDomain() *domain.BackgroundJob {
+	j := &domain.BackgroundJob{
+		ID:     r.ID,
+		UserID: r.UserID,
+		Type:   domain.BackgroundJobType(r.Type),
+		State:  domain.JobState(r.State),
+		Progress: domain.JobProgress{
+			Total:  r.ProgressTotal,
+			Done:   r.ProgressDone,
+			Failed: r.ProgressFailed,
+		},
+		Error:     r.Error.String,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	if r.ConnectionID.Valid {
+		j.ConnectionID = &r.ConnectionID.Int64
+	}
+	if r.StartedAt.Valid {
+		j.StartedAt = &r.StartedAt.Time
+	}
+	if r.FinishedAt.Valid {
+		j.FinishedAt = &r.FinishedAt.Time
+	}
+	return j
+}
+
+// Create inserts a queued job.
+func (a *JobAdapter) Create(ctx context.Context, job *domain.BackgroundJob) error {
+	query := `
+		INSERT INTO background_jobs (id, user_id, type, connection_id, state, progress_total)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at, updated_at
+	`
+
+	err := a.db.QueryRowxContext(ctx, query,
+		job.ID,
+		job.UserID,
+		string(job.Type),
+		job.ConnectionID,
+		string(job.State),
+		job.Progress.Total,
+	).Scan(&job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a job.
+func (a *JobAdapter) GetByID(ctx context.Context, id uuid.UUID) (*domain.BackgroundJob, error) {
+	query := `SELECT ` + jobColumns + ` FROM background_jobs WHERE id = $1`
+
+	var row jobRow
+	if err := a.db.GetContext(ctx, &row, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// GetLatest retrieves the most recently created job of jobType for a connection.
+func (a *JobAdapter) GetLatest(ctx context.Context, userID uuid.UUID, jobType domain.BackgroundJobType, connectionID int64) (*domain.BackgroundJob, error) {
+	query := `SELECT ` + jobColumns + ` FROM background_jobs
+		WHERE user_id = $1 AND type = $2 AND connection_id = $3
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	var row jobRow
+	if err := a.db.GetContext(ctx, &row, query, userID, string(jobType), connectionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get latest job: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// MarkRunning moves a queued job to running.
+func (a *JobAdapter) MarkRunning(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE background_jobs SET
+			state = 'running',
+			started_at = COALESCE(started_at, NOW()),
+			updated_at = NOW()
+		WHERE id = $1 AND state IN ('queued', 'running')
+	`
+	if _, err := a.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark job running: %w", err)
+	}
+	return nil
+}
+
+// AddProgress adds processed counts; the job succeeds once every item is processed.
+func (a *JobAdapter) AddProgress(ctx context.Context, id uuid.UUID, done, failed int) error {
+	query := `
+		UPDATE background_jobs SET
+			progress_done = progress_done + $2,
+			progress_failed = progress_failed + $3,
+			started_at = COALESCE(started_at, NOW()),
+			state = CASE
+				WHEN progress_total > 0 AND progress_done + progress_failed + $2 + $3 >= progress_total THEN 'succeeded'
+				ELSE 'running'
+			END,
+			finished_at = CASE
+				WHEN progress_total > 0 AND progress_done + progress_failed + $2 + $3 >= progress_total THEN NOW()
+				ELSE finished_at
+			END,
+			updated_at = NOW()
+		WHERE id = $1 AND state IN ('queued', 'running')
+	`
+	if _, err := a.db.ExecContext(ctx, query, id, done, failed); err != nil {
+		return fmt.Errorf("failed to update job progress: %w", err)
+	}
+	return nil
+}
+
+// Finish sets a terminal state.
+func (a *JobAdapter) Finish(ctx context.Context, id uuid.UUID, state domain.JobState, errMsg string) error {
+	query := `
+		UPDATE background_jobs SET
+			state = $2,
+			error = $3,
+			started_at = COALESCE(started_at, NOW()),
+			finished_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND state IN ('queued', 'running')
+	`
+	if _, err := a.db.ExecContext(ctx, query, id, string(state), toNullableString(errMsg)); err != nil {
+		return fmt.Errorf("failed to finish job: %w", err)
+	}
+	return nil
+}
+
+var _ out.JobRepository = (*JobAdapter)(nil)
@@ -0,0 +1,142 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// LinkClickAdapter implements out.LinkClickRepository using PostgreSQL.
+type LinkClickAdapter struct {
+	db *sqlx.DB
+}
+
+// NewLinkClickAdapter creates a new LinkClickAdapter.
+func NewLinkClickAdapter(db *sqlx.DB) *LinkClickAdapter {
+	return &LinkClickAdapter{db: db}
+}
+
+// linkClickRow represents the database row for a link click.
+type linkClickRow struct {
+	ID        int64         `db:"id"`
+	UserID    uuid.UUID     `db:"user_id"`
+	EmailID   sql.NullInt64 `db:"email_id"`
+	URL       string        `db:"url"`
+	Domain    string        `db:"domain"`
+	Verdict   string        `db:"verdict"`
+	ClickedAt time.Time     `db:"clicked_at"`
+}
+
+func (r *linkClickRow) toDomain() *domain.LinkClick {
+	return &domain.LinkClick{
+		ID:        r.ID,
+		UserID:    r.UserID,
+		EmailID:   r.EmailID.Int64,
+		URL:       r.URL,
+		Domain:    r.Domain,
+		Verdict:   domain.LinkVerdict(r.Verdict),
+		ClickedAt: r.ClickedAt,
+	}
+}
+
+// Record stores a click on a rewritten link.
+func (a *LinkClickAdapter) Record(ctx context.Context, click *domain.LinkClick) error {
+	query := `
+		INSERT INTO link_clicks (user_id, email_id, url, domain, verdict, clicked_at)
+		VALUES ($1, NULLIF($2, 0), $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	if click.ClickedAt.IsZero() {
+		click.ClickedAt = time.Now()
+	}
+	err := a.db.QueryRowxContext(ctx, query,
+		click.UserID,
+		click.EmailID,
+		click.URL,
+		click.Domain,
+		click.Verdict,
+		click.ClickedAt,
+	).Scan(&click.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record link click: %w", err)
+	}
+	return nil
+}
+
+// ListByUser returns the most recent clicks of a user.
+func (a *LinkClickAdapter) ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.LinkClick, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := `
+		SELECT id, user_id, email_id, url, domain, verdict, clicked_at
+		FROM link_clicks
+		WHERE user_id = $1
+		ORDER BY clicked_at DESC
+		LIMIT $2
+	`
+
+	var rows []linkClickRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to list link clicks: %w", err)
+	}
+
+	clicks := make([]*domain.LinkClick, 0, len(rows))
+	for i := range rows {
+		clicks = append(clicks, rows[i].toDomain())
+	}
+	return clicks, nil
+}
+
+// GetStats aggregates clicks since the given time.
+func (a *LinkClickAdapter) GetStats(ctx context.Context, userID uuid.UUID, since time.Time, topN int) (*domain.LinkClickStats, error) {
+	if topN <= 0 {
+		topN = 10
+	}
+
+	stats := &domain.LinkClickStats{Since: since, TopDomains: []domain.LinkDomainCount{}}
+
+	totalsQuery := `
+		SELECT COUNT(*) AS total,
+		       COUNT(*) FILTER (WHERE verdict = 'blocked') AS blocked,
+		       COUNT(DISTINCT domain) AS domains
+		FROM link_clicks
+		WHERE user_id = $1 AND clicked_at >= $2
+	`
+	var totals struct {
+		Total   int `db:"total"`
+		Blocked int `db:"blocked"`
+		Domains int `db:"domains"`
+	}
+	if err := a.db.GetContext(ctx, &totals, totalsQuery, userID, since); err != nil {
+		return nil, fmt.Errorf("failed to get link click stats: %w", err)
+	}
+	stats.TotalClicks = totals.Total
+	stats.BlockedClicks = totals.Blocked
+	stats.UniqueDomains = totals.Domains
+
+	domainsQuery := `
+		SELECT domain, COUNT(*) AS clicks
+		FROM link_clicks
+		WHERE user_id = $1 AND clicked_at >= $2
+		GROUP BY domain
+		ORDER BY clicks DESC, domain
+		LIMIT $3
+	`
+	if err := a.db.SelectContext(ctx, &stats.TopDomains, domainsQuery, userID, since, topN); err != nil {
+		return nil, fmt.Errorf("failed to get top link domains: %w", err)
+	}
+
+	return stats, nil
+}
+
+var _ out.LinkClickRepository = (*LinkClickAdapter)(nil)
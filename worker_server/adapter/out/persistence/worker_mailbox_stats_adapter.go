@@ -0,0 +1,333 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// MailboxStatsAdapter implements out.MailboxStatsRepository using PostgreSQL.
+type MailboxStatsAdapter struct {
+	db      *sqlx.DB
+	replica *ReadReplica // 분석 조회 (집계는 primary)
+}
+
+// NewMailboxStatsAdapter creates a new MailboxStatsAdapter.
+func NewMailboxStatsAdapter(db *sqlx.DB) *MailboxStatsAdapter {
+	return &MailboxStatsAdapter{db: db}
+}
+
+// SetReadReplica routes analytics queries to a read replica.
+func (a *MailboxStatsAdapter) SetReadReplica(replica *ReadReplica) {
+	a.replica = replica
+}
+
+func (a *MailboxStatsAdapter) reader(ctx context.Context) *sqlx.DB {
+	if a.replica == nil {
+		return a.db
+	}
+	return a.replica.Reader(ctx)
+}
+
+// StaleUsers returns users whose stats are older than the interval.
+func (a *MailboxStatsAdapter) StaleUsers(ctx context.Context, interval time.Duration, limit int) ([]uuid.UUID, error) {
+	query := `
+		SELECT u.id
+		FROM users u
+		LEFT JOIN mailbox_stats_state s ON s.user_id = u.id
+		WHERE s.user_id IS NULL OR s.updated_at < NOW() - $1 * INTERVAL '1 second'
+		ORDER BY s.updated_at ASC NULLS FIRST
+		LIMIT $2
+	`
+	var ids []uuid.UUID
+	if err := a.db.SelectContext(ctx, &ids, query, interval.Seconds(), limit); err != nil {
+		return nil, fmt.Errorf("failed to list stale mailbox stats users: %w", err)
+	}
+	return ids, nil
+}
+
+// Aggregate folds new emails, attachments and reads into the aggregate tables in one transaction.
+func (a *MailboxStatsAdapter) Aggregate(ctx context.Context, userID uuid.UUID, settle time.Duration, batchSize int) error {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 상태 행을 잠가 여러 워커가 같은 사용자를 중복 집계하지 않게 한다
+	if _, err := tx.ExecContext(ctx, `INSERT INTO mailbox_stats_state (user_id) VALUES ($1) ON CONFLICT DO NOTHING`, userID); err != nil {
+		return fmt.Errorf("failed to init mailbox stats state: %w", err)
+	}
+	var state struct {
+		LastEmailID      int64     `db:"last_email_id"`
+		LastAttachmentID int64     `db:"last_attachment_id"`
+		ReadThrough      time.Time `db:"read_through"`
+	}
+	if err := tx.GetContext(ctx, &state, `
+		SELECT last_email_id, last_attachment_id, read_through
+		FROM mailbox_stats_state WHERE user_id = $1 FOR UPDATE
+	`, userID); err != nil {
+		return fmt.Errorf("failed to lock mailbox stats state: %w", err)
+	}
+
+	lastEmailID, err := a.aggregateEmails(ctx, tx, userID, state.LastEmailID, settle, batchSize)
+	if err != nil {
+		return err
+	}
+	lastAttachmentID, err := a.aggregateAttachments(ctx, tx, userID, state.LastAttachmentID)
+	if err != nil {
+		return err
+	}
+	readThrough, err := a.aggregateReads(ctx, tx, userID, state.ReadThrough)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE mailbox_stats_state
+		SET last_email_id = $2, last_attachment_id = $3, read_through = $4, updated_at = NOW()
+		WHERE user_id = $1
+	`, userID, lastEmailID, lastAttachmentID, readThrough); err != nil {
+		return fmt.Errorf("failed to update mailbox stats state: %w", err)
+	}
+	return tx.Commit()
+}
+
+// aggregateEmails adds hourly volume, sender and category counts for emails after lastID.
+func (a *MailboxStatsAdapter) aggregateEmails(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, lastID int64, settle time.Duration, batchSize int) (int64, error) {
+	var upTo sql.NullInt64
+	if err := tx.GetContext(ctx, &upTo, `
+		SELECT MAX(id) FROM (
+			SELECT id FROM emails
+			WHERE user_id = $1 AND id > $2 AND created_at < NOW() - $3 * INTERVAL '1 second'
+			ORDER BY id
+			LIMIT $4
+		) batch
+	`, userID, lastID, settle.Seconds(), batchSize); err != nil {
+		return 0, fmt.Errorf("failed to find mailbox stats batch: %w", err)
+	}
+	if !upTo.Valid {
+		return lastID, nil
+	}
+
+	queries := []string{
+		// UTC 정시 버킷
+		`INSERT INTO mailbox_hourly_stats (user_id, bucket, received, sent)
+		SELECT $1, date_trunc('hour', email_date AT TIME ZONE 'UTC') AT TIME ZONE 'UTC',
+		       COUNT(*) FILTER (WHERE folder <> 'sent'), COUNT(*) FILTER (WHERE folder = 'sent')
+		FROM emails
+		WHERE user_id = $1 AND id > $2 AND id <= $3 AND folder <> 'drafts' AND deleted_at IS NULL
+		GROUP BY 2
+		ON CONFLICT (user_id, bucket) DO UPDATE SET
+			received = mailbox_hourly_stats.received + EXCLUDED.received,
+			sent = mailbox_hourly_stats.sent + EXCLUDED.sent`,
+
+		`INSERT INTO mailbox_sender_stats (user_id, from_email, from_name, emails, last_received_at)
+		SELECT $1, LOWER(from_email), MAX(from_name), COUNT(*), MAX(email_date)
+		FROM emails
+		WHERE user_id = $1 AND id > $2 AND id <= $3 AND folder NOT IN ('sent', 'drafts') AND deleted_at IS NULL AND from_email <> ''
+		GROUP BY LOWER(from_email)
+		ON CONFLICT (user_id, from_email) DO UPDATE SET
+			emails = mailbox_sender_stats.emails + EXCLUDED.emails,
+			from_name = COALESCE(NULLIF(EXCLUDED.from_name, ''), mailbox_sender_stats.from_name),
+			last_received_at = GREATEST(mailbox_sender_stats.last_received_at, EXCLUDED.last_received_at)`,
+
+		`INSERT INTO mailbox_category_stats (user_id, category, emails)
+		SELECT $1, COALESCE(NULLIF(ai_category, ''), '` + domain.UncategorizedCategory + `'), COUNT(*)
+		FROM emails
+		WHERE user_id = $1 AND id > $2 AND id <= $3 AND folder NOT IN ('sent', 'drafts') AND deleted_at IS NULL
+		GROUP BY 2
+		ON CONFLICT (user_id, category) DO UPDATE SET
+			emails = mailbox_category_stats.emails + EXCLUDED.emails`,
+	}
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, userID, lastID, upTo.Int64); err != nil {
+			return 0, fmt.Errorf("failed to aggregate mailbox stats: %w", err)
+		}
+	}
+	return upTo.Int64, nil
+}
+
+// aggregateAttachments adds attachments stored after lastID (본문을 나중에 가져오면 첨부도 나중에 저장된다).
+func (a *MailboxStatsAdapter) aggregateAttachments(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, lastID int64) (int64, error) {
+	var row struct {
+		Count  int64         `db:"count"`
+		Bytes  int64         `db:"bytes"`
+		LastID sql.NullInt64 `db:"last_id"`
+	}
+	if err := tx.GetContext(ctx, &row, `
+		SELECT COUNT(*) AS count, COALESCE(SUM(a.size), 0) AS bytes, MAX(a.id) AS last_id
+		FROM email_attachments a
+		JOIN emails e ON e.id = a.email_id
+		WHERE e.user_id = $1 AND a.id > $2 AND e.deleted_at IS NULL
+	`, userID, lastID); err != nil {
+		return 0, fmt.Errorf("failed to aggregate attachments: %w", err)
+	}
+	if !row.LastID.Valid {
+		return lastID, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE mailbox_stats_state
+		SET attachment_count = attachment_count + $2, attachment_bytes = attachment_bytes + $3
+		WHERE user_id = $1
+	`, userID, row.Count, row.Bytes); err != nil {
+		return 0, fmt.Errorf("failed to update attachment usage: %w", err)
+	}
+	return row.LastID.Int64, nil
+}
+
+// aggregateReads adds time-to-read of emails first read after readThrough, bucketed by read hour.
+func (a *MailboxStatsAdapter) aggregateReads(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, readThrough time.Time) (time.Time, error) {
+	// 진행 중인 트랜잭션의 읽음 처리를 놓치지 않도록 1분 전까지만 집계한다
+	var until time.Time
+	if err := tx.GetContext(ctx, &until, `SELECT NOW() - INTERVAL '1 minute'`); err != nil {
+		return readThrough, err
+	}
+	if !until.After(readThrough) {
+		return readThrough, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO mailbox_hourly_stats (user_id, bucket, read_count, read_seconds)
+		SELECT $1, date_trunc('hour', read_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC',
+		       COUNT(*), SUM(EXTRACT(EPOCH FROM read_at - email_date))::BIGINT
+		FROM emails
+		WHERE user_id = $1 AND read_at > $2 AND read_at <= $3
+		  AND read_at >= email_date AND folder NOT IN ('sent', 'drafts') AND deleted_at IS NULL
+		GROUP BY 2
+		ON CONFLICT (user_id, bucket) DO UPDATE SET
+			read_count = mailbox_hourly_stats.read_count + EXCLUDED.read_count,
+			read_seconds = mailbox_hourly_stats.read_seconds + EXCLUDED.read_seconds
+	`, userID, readThrough, until); err != nil {
+		return readThrough, fmt.Errorf("failed to aggregate reads: %w", err)
+	}
+	return until, nil
+}
+
+type mailboxHourlyRow struct {
+	Bucket      time.Time `db:"bucket"`
+	Received    int       `db:"received"`
+	Sent        int       `db:"sent"`
+	ReadCount   int       `db:"read_count"`
+	ReadSeconds int64     `db:"read_seconds"`
+}
+
+// HourlyStats returns the hour buckets in [from, to).
+func (a *MailboxStatsAdapter) HourlyStats(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.MailboxHourlyStat, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT bucket, received, sent, read_count, read_seconds
+		FROM mailbox_hourly_stats
+		WHERE user_id = $1 AND bucket >= $2 AND bucket < $3
+		ORDER BY bucket
+	`
+	var rows []mailboxHourlyRow
+	if err := a.reader(ctx).SelectContext(ctx, &rows, query, userID, from, to); err != nil {
+		return nil, fmt.Errorf("failed to get hourly mailbox stats: %w", err)
+	}
+
+	stats := make([]*domain.MailboxHourlyStat, len(rows))
+	for i, r := range rows {
+		stats[i] = &domain.MailboxHourlyStat{
+			Bucket:      r.Bucket,
+			Received:    r.Received,
+			Sent:        r.Sent,
+			ReadCount:   r.ReadCount,
+			ReadSeconds: r.ReadSeconds,
+		}
+	}
+	return stats, nil
+}
+
+// TopSenders returns the senders with the most received emails.
+func (a *MailboxStatsAdapter) TopSenders(ctx context.Context, userID uuid.UUID, limit int) ([]domain.SenderStat, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT from_email, from_name, emails
+		FROM mailbox_sender_stats
+		WHERE user_id = $1
+		ORDER BY emails DESC, last_received_at DESC
+		LIMIT $2
+	`
+	var rows []struct {
+		Email  string         `db:"from_email"`
+		Name   sql.NullString `db:"from_name"`
+		Emails int            `db:"emails"`
+	}
+	if err := a.reader(ctx).SelectContext(ctx, &rows, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to get top senders: %w", err)
+	}
+
+	senders := make([]domain.SenderStat, len(rows))
+	for i, r := range rows {
+		senders[i] = domain.SenderStat{Email: r.Email, Name: r.Name.String, Count: r.Emails}
+	}
+	return senders, nil
+}
+
+// Categories returns the email count of each category.
+func (a *MailboxStatsAdapter) Categories(ctx context.Context, userID uuid.UUID) ([]*domain.CategoryStat, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT category, emails
+		FROM mailbox_category_stats
+		WHERE user_id = $1 AND emails > 0
+		ORDER BY emails DESC, category
+	`
+	var rows []struct {
+		Category string `db:"category"`
+		Emails   int    `db:"emails"`
+	}
+	if err := a.reader(ctx).SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get category stats: %w", err)
+	}
+
+	categories := make([]*domain.CategoryStat, len(rows))
+	for i, r := range rows {
+		categories[i] = &domain.CategoryStat{Category: r.Category, Emails: r.Emails}
+	}
+	return categories, nil
+}
+
+// Totals returns the attachment usage and last aggregation time.
+func (a *MailboxStatsAdapter) Totals(ctx context.Context, userID uuid.UUID) (*domain.MailboxStatsTotals, error) {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT attachment_count, attachment_bytes, updated_at
+		FROM mailbox_stats_state WHERE user_id = $1
+	`
+	var row struct {
+		AttachmentCount int64     `db:"attachment_count"`
+		AttachmentBytes int64     `db:"attachment_bytes"`
+		UpdatedAt       time.Time `db:"updated_at"`
+	}
+	if err := a.reader(ctx).GetContext(ctx, &row, query, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get mailbox stats totals: %w", err)
+	}
+	return &domain.MailboxStatsTotals{
+		AttachmentCount: row.AttachmentCount,
+		AttachmentBytes: row.AttachmentBytes,
+		UpdatedAt:       row.UpdatedAt,
+	}, nil
+}
+
+var _ out.MailboxStatsRepository = (*MailboxStatsAdapter)(nil)
@@ -0,0 +1,331 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// NotificationSettingsAdapter implements domain.NotificationSettingsRepository using PostgreSQL.
+type NotificationSettingsAdapter struct {
+	db *sqlx.DB
+}
+
+// NewNotificationSettingsAdapter creates a new NotificationSettingsAdapter.
+func NewNotificationSettingsAdapter(db *sqlx.DB) *NotificationSettingsAdapter {
+	return &NotificationSettingsAdapter{db: db}
+}
+
+// notificationSettingsRow represents the database row for notification settings.
+type notificationSettingsRow struct {
+	ID     int64     `db:"id"`
+	UserID uuid.UUID `db:"user_id"`
+
+	PushEnabled    bool `db:"push_enabled"`
+	EmailEnabled   bool `db:"email_enabled"`
+	DesktopEnabled bool `db:"desktop_enabled"`
+	InAppEnabled   bool `db:"inapp_enabled"`
+
+	NotifyNewEmail      bool `db:"notify_new_email"`
+	NotifyImportantOnly bool `db:"notify_important_only"`
+	NotifyFromVIPOnly   bool `db:"notify_from_vip_only"`
+	NotifyMentions      bool `db:"notify_mentions"`
+
+	NotifyCalendarEvents   bool `db:"notify_calendar_events"`
+	NotifyCalendarReminder bool `db:"notify_calendar_reminder"`
+	ReminderMinutesBefore  int  `db:"reminder_minutes_before"`
+
+	NotifySyncComplete bool `db:"notify_sync_complete"`
+	NotifySyncError    bool `db:"notify_sync_error"`
+	NotifyAIClassified bool `db:"notify_ai_classified"`
+	NotifyAISummarized bool `db:"notify_ai_summarized"`
+
+	QuietHoursEnabled  bool   `db:"quiet_hours_enabled"`
+	QuietHoursStart    string `db:"quiet_hours_start"`
+	QuietHoursEnd      string `db:"quiet_hours_end"`
+	QuietHoursTimezone string `db:"quiet_hours_timezone"`
+
+	VIPSenders     pq.StringArray `db:"vip_senders"`
+	MutedSenders   pq.StringArray `db:"muted_senders"`
+	MutedThreadIDs pq.Int64Array  `db:"muted_thread_ids"`
+
+	MinPriorityForNotification int            `db:"min_priority_for_notification"`
+	PushCategories             pq.StringArray `db:"push_categories"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (r *notificationSettingsRow) toDomain() *domain.NotificationSettings {
+	return &domain.NotificationSettings{
+		ID:                         r.ID,
+		UserID:                     r.UserID,
+		PushEnabled:                r.PushEnabled,
+		EmailEnabled:               r.EmailEnabled,
+		DesktopEnabled:             r.DesktopEnabled,
+		InAppEnabled:               r.InAppEnabled,
+		NotifyNewEmail:             r.NotifyNewEmail,
+		NotifyImportantOnly:        r.NotifyImportantOnly,
+		NotifyFromVIPOnly:          r.NotifyFromVIPOnly,
+		NotifyMentions:             r.NotifyMentions,
+		NotifyCalendarEvents:       r.NotifyCalendarEvents,
+		NotifyCalendarReminder:     r.NotifyCalendarReminder,
+		ReminderMinutesBefore:      r.ReminderMinutesBefore,
+		NotifySyncComplete:         r.NotifySyncComplete,
+		NotifySyncError:            r.NotifySyncError,
+		NotifyAIClassified:         r.NotifyAIClassified,
+		NotifyAISummarized:         r.NotifyAISummarized,
+		QuietHoursEnabled:          r.QuietHoursEnabled,
+		QuietHoursStart:            r.QuietHoursStart,
+		QuietHoursEnd:              r.QuietHoursEnd,
+		QuietHoursTimezone:         r.QuietHoursTimezone,
+		VIPSenders:                 nonNilStrings(r.VIPSenders),
+		MutedSenders:               nonNilStrings(r.MutedSenders),
+		MutedThreadIDs:             nonNilInt64s(r.MutedThreadIDs),
+		MinPriorityForNotification: r.MinPriorityForNotification,
+		PushCategories:             nonNilStrings(r.PushCategories),
+		CreatedAt:                  r.CreatedAt,
+		UpdatedAt:                  r.UpdatedAt,
+	}
+}
+
+func nonNilStrings(a []string) []string {
+	if a == nil {
+		return []string{}
+	}
+	return a
+}
+
+func nonNilInt64s(a []int64) []int64 {
+	if a == nil {
+		return []int64{}
+	}
+	return a
+}
+
+// notificationSettingsColumns maps JSON field names accepted by UpdatePartial to columns.
+var notificationSettingsColumns = map[string]string{
+	"push_enabled":                  "push_enabled",
+	"email_enabled":                 "email_enabled",
+	"desktop_enabled":               "desktop_enabled",
+	"in_app_enabled":                "inapp_enabled",
+	"notify_new_email":              "notify_new_email",
+	"notify_important_only":         "notify_important_only",
+	"notify_from_vip_only":          "notify_from_vip_only",
+	"notify_mentions":               "notify_mentions",
+	"notify_calendar_events":        "notify_calendar_events",
+	"notify_calendar_reminder":      "notify_calendar_reminder",
+	"reminder_minutes_before":       "reminder_minutes_before",
+	"notify_sync_complete":          "notify_sync_complete",
+	"notify_sync_error":             "notify_sync_error",
+	"notify_ai_classified":          "notify_ai_classified",
+	"notify_ai_summarized":          "notify_ai_summarized",
+	"quiet_hours_enabled":           "quiet_hours_enabled",
+	"quiet_hours_start":             "quiet_hours_start",
+	"quiet_hours_end":               "quiet_hours_end",
+	"quiet_hours_timezone":          "quiet_hours_timezone",
+	"min_priority_for_notification": "min_priority_for_notification",
+	"push_categories":               "push_categories",
+}
+
+// Get returns the user's settings, or the defaults if none were saved.
+// 방해 금지 시간의 시간대는 사용자 프로필(user_settings.timezone)을 따른다.
+func (a *NotificationSettingsAdapter) Get(ctx context.Context, userID uuid.UUID) (*domain.NotificationSettings, error) {
+	var settings *domain.NotificationSettings
+	var row notificationSettingsRow
+	err := a.db.GetContext(ctx, &row, `SELECT * FROM notification_settings WHERE user_id = $1`, userID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		settings = domain.DefaultNotificationSettings(userID)
+	case err != nil:
+		return nil, fmt.Errorf("failed to get notification settings: %w", err)
+	default:
+		settings = row.toDomain()
+	}
+
+	if tz := a.profileTimezone(ctx, userID); tz != "" {
+		settings.QuietHoursTimezone = tz
+	}
+	return settings, nil
+}
+
+// profileTimezone returns the time zone from the user's profile, or "" if unset.
+func (a *NotificationSettingsAdapter) profileTimezone(ctx context.Context, userID uuid.UUID) string {
+	var tz sql.NullString
+	if err := a.db.GetContext(ctx, &tz, `SELECT timezone FROM user_settings WHERE user_id = $1`, userID); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(tz.String)
+}
+
+// Create inserts the user's settings.
+func (a *NotificationSettingsAdapter) Create(ctx context.Context, s *domain.NotificationSettings) error {
+	return a.upsert(ctx, s)
+}
+
+// Update replaces the user's settings (행이 없으면 만든다).
+func (a *NotificationSettingsAdapter) Update(ctx context.Context, s *domain.NotificationSettings) error {
+	return a.upsert(ctx, s)
+}
+
+func (a *NotificationSettingsAdapter) upsert(ctx context.Context, s *domain.NotificationSettings) error {
+	query := `
+		INSERT INTO notification_settings (
+			user_id, push_enabled, email_enabled, desktop_enabled, inapp_enabled,
+			notify_new_email, notify_important_only, notify_from_vip_only, notify_mentions,
+			notify_calendar_events, notify_calendar_reminder, reminder_minutes_before,
+			notify_sync_complete, notify_sync_error, notify_ai_classified, notify_ai_summarized,
+			quiet_hours_enabled, quiet_hours_start, quiet_hours_end, quiet_hours_timezone,
+			vip_senders, muted_senders, muted_thread_ids,
+			min_priority_for_notification, push_categories
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)
+		ON CONFLICT (user_id) DO UPDATE SET
+			push_enabled = EXCLUDED.push_enabled,
+			email_enabled = EXCLUDED.email_enabled,
+			desktop_enabled = EXCLUDED.desktop_enabled,
+			inapp_enabled = EXCLUDED.inapp_enabled,
+			notify_new_email = EXCLUDED.notify_new_email,
+			notify_important_only = EXCLUDED.notify_important_only,
+			notify_from_vip_only = EXCLUDED.notify_from_vip_only,
+			notify_mentions = EXCLUDED.notify_mentions,
+			notify_calendar_events = EXCLUDED.notify_calendar_events,
+			notify_calendar_reminder = EXCLUDED.notify_calendar_reminder,
+			reminder_minutes_before = EXCLUDED.reminder_minutes_before,
+			notify_sync_complete = EXCLUDED.notify_sync_complete,
+			notify_sync_error = EXCLUDED.notify_sync_error,
+			notify_ai_classified = EXCLUDED.notify_ai_classified,
+			notify_ai_summarized = EXCLUDED.notify_ai_summarized,
+			quiet_hours_enabled = EXCLUDED.quiet_hours_enabled,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			quiet_hours_timezone = EXCLUDED.quiet_hours_timezone,
+			vip_senders = EXCLUDED.vip_senders,
+			muted_senders = EXCLUDED.muted_senders,
+			muted_thread_ids = EXCLUDED.muted_thread_ids,
+			min_priority_for_notification = EXCLUDED.min_priority_for_notification,
+			push_categories = EXCLUDED.push_categories,
+			updated_at = NOW()
+		RETURNING id, created_at, updated_at
+	`
+	err := a.db.QueryRowxContext(ctx, query,
+		s.UserID, s.PushEnabled, s.EmailEnabled, s.DesktopEnabled, s.InAppEnabled,
+		s.NotifyNewEmail, s.NotifyImportantOnly, s.NotifyFromVIPOnly, s.NotifyMentions,
+		s.NotifyCalendarEvents, s.NotifyCalendarReminder, s.ReminderMinutesBefore,
+		s.NotifySyncComplete, s.NotifySyncError, s.NotifyAIClassified, s.NotifyAISummarized,
+		s.QuietHoursEnabled, s.QuietHoursStart, s.QuietHoursEnd, s.QuietHoursTimezone,
+		pq.Array(nonNilStrings(s.VIPSenders)), pq.Array(nonNilStrings(s.MutedSenders)), pq.Array(nonNilInt64s(s.MutedThreadIDs)),
+		s.MinPriorityForNotification, pq.Array(nonNilStrings(s.PushCategories)),
+	).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save notification settings: %w", err)
+	}
+	return nil
+}
+
+// UpdatePartial updates the given fields (JSON field names) of the user's settings.
+func (a *NotificationSettingsAdapter) UpdatePartial(ctx context.Context, userID uuid.UUID, updates map[string]any) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(updates))
+	for key := range updates {
+		if _, ok := notificationSettingsColumns[key]; !ok {
+			return fmt.Errorf("unknown notification setting: %s", key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	columns := make([]string, len(keys))
+	sets := make([]string, len(keys))
+	args := []any{userID}
+	for i, key := range keys {
+		col := notificationSettingsColumns[key]
+		value := updates[key]
+		if list, ok := value.([]string); ok {
+			value = pq.Array(list)
+		}
+		args = append(args, value)
+		columns[i] = col
+		sets[i] = fmt.Sprintf("%s = EXCLUDED.%s", col, col)
+	}
+	placeholders := make([]string, len(keys))
+	for i := range keys {
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO notification_settings (user_id, %s) VALUES ($1, %s)
+		ON CONFLICT (user_id) DO UPDATE SET %s, updated_at = NOW()
+	`, strings.Join(columns, ", "), strings.Join(placeholders, ", "), strings.Join(sets, ", "))
+	if _, err := a.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to update notification settings: %w", err)
+	}
+	return nil
+}
+
+// AddVIPSender adds a sender to the VIP list.
+func (a *NotificationSettingsAdapter) AddVIPSender(ctx context.Context, userID uuid.UUID, email string) error {
+	return a.arrayAdd(ctx, userID, "vip_senders", strings.ToLower(email))
+}
+
+// RemoveVIPSender removes a sender from the VIP list.
+func (a *NotificationSettingsAdapter) RemoveVIPSender(ctx context.Context, userID uuid.UUID, email string) error {
+	return a.arrayRemove(ctx, userID, "vip_senders", strings.ToLower(email))
+}
+
+// AddMutedSender mutes a sender.
+func (a *NotificationSettingsAdapter) AddMutedSender(ctx context.Context, userID uuid.UUID, email string) error {
+	return a.arrayAdd(ctx, userID, "muted_senders", strings.ToLower(email))
+}
+
+// RemoveMutedSender unmutes a sender.
+func (a *NotificationSettingsAdapter) RemoveMutedSender(ctx context.Context, userID uuid.UUID, email string) error {
+	return a.arrayRemove(ctx, userID, "muted_senders", strings.ToLower(email))
+}
+
+// AddMutedThread mutes a thread.
+func (a *NotificationSettingsAdapter) AddMutedThread(ctx context.Context, userID uuid.UUID, threadID int64) error {
+	return a.arrayAdd(ctx, userID, "muted_thread_ids", threadID)
+}
+
+// RemoveMutedThread unmutes a thread.
+func (a *NotificationSettingsAdapter) RemoveMutedThread(ctx context.Context, userID uuid.UUID, threadID int64) error {
+	return a.arrayRemove(ctx, userID, "muted_thread_ids", threadID)
+}
+
+// arrayAdd appends value to an array column once (column은 상수만 전달한다).
+func (a *NotificationSettingsAdapter) arrayAdd(ctx context.Context, userID uuid.UUID, column string, value any) error {
+	query := fmt.Sprintf(`
+		INSERT INTO notification_settings (user_id, %[1]s) VALUES ($1, ARRAY[$2])
+		ON CONFLICT (user_id) DO UPDATE SET
+			%[1]s = CASE WHEN $2 = ANY(notification_settings.%[1]s) THEN notification_settings.%[1]s
+				ELSE array_append(notification_settings.%[1]s, $2) END,
+			updated_at = NOW()
+	`, column)
+	if _, err := a.db.ExecContext(ctx, query, userID, value); err != nil {
+		return fmt.Errorf("failed to add to %s: %w", column, err)
+	}
+	return nil
+}
+
+func (a *NotificationSettingsAdapter) arrayRemove(ctx context.Context, userID uuid.UUID, column string, value any) error {
+	query := fmt.Sprintf(`UPDATE notification_settings SET %[1]s = array_remove(%[1]s, $2), updated_at = NOW() WHERE user_id = $1`, column)
+	if _, err := a.db.ExecContext(ctx, query, userID, value); err != nil {
+		return fmt.Errorf("failed to remove from %s: %w", column, err)
+	}
+	return nil
+}
+
+var _ domain.NotificationSettingsRepository = (*NotificationSettingsAdapter)(nil)
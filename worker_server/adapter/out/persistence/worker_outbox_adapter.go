@@ -0,0 +1,236 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// staleSendingAfter - 발송 중 상태로 남은 메시지를 다시 가져가는 시간 (프로세스 중단 대비)
+const staleSendingAfter = 10 * time.Minute
+
+// OutboxAdapter implements out.OutboxRepository using PostgreSQL.
+type OutboxAdapter struct {
+	db *sqlx.DB
+}
+
+// NewOutboxAdapter creates a new OutboxAdapter.
+func NewOutboxAdapter(db *sqlx.DB) *OutboxAdapter {
+	return &OutboxAdapter{db: db}
+}
+
+// outboxRow represents the database row for an outbox message.
+type outboxRow struct {
+	ID            int64          `db:"id"`
+	UserID        uuid.UUID      `db:"user_id"`
+	ConnectionID  int64          `db:"connection_id"`
+	Kind          string         `db:"kind"`
+	ReplyToID     sql.NullString `db:"reply_to_id"`
+	Subject       string         `db:"subject"`
+	ToEmails      pq.StringArray `db:"to_emails"`
+	Payload       []byte         `db:"payload"`
+	Status        string         `db:"status"`
+	Attempts      int            `db:"attempts"`
+	LastError     sql.NullString `db:"last_error"`
+	NextAttemptAt sql.NullTime   `db:"next_attempt_at"`
+	ProviderID    sql.NullString `db:"provider_id"`
+	SentAt        sql.NullTime   `db:"sent_at"`
+	TrackingID    sql.NullInt64  `db:"tracking_id"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+const outboxColumns = `id, user_id, connection_id, kind, reply_to_id, subject, to_emails, status, attempts,
+	last_error, next_attempt_at, provider_id, sent_at, tracking_id, created_at, updated_at`
+
+func (r *outboxRow) toDomain() *domain.OutboxMessage {
+	msg := &domain.OutboxMessage{
+		ID:           r.ID,
+		UserID:       r.UserID,
+		ConnectionID: r.ConnectionID,
+		Kind:         r.Kind,
+		ReplyToID:    r.ReplyToID.String,
+		Subject:      r.Subject,
+		ToEmails:     []string(r.ToEmails),
+		Status:       r.Status,
+		Attempts:     r.Attempts,
+		LastError:    r.LastError.String,
+		ProviderID:   r.ProviderID.String,
+		TrackingID:   r.TrackingID.Int64,
+		CreatedAt:    r.CreatedAt,
+		UpdatedAt:    r.UpdatedAt,
+		Payload:      r.Payload,
+	}
+	if msg.ToEmails == nil {
+		msg.ToEmails = []string{}
+	}
+	if r.NextAttemptAt.Valid {
+		msg.NextAttemptAt = &r.NextAttemptAt.Time
+	}
+	if r.SentAt.Valid {
+		msg.SentAt = &r.SentAt.Time
+	}
+	return msg
+}
+
+// Enqueue stores a queued message and sets its ID.
+func (a *OutboxAdapter) Enqueue(ctx context.Context, msg *domain.OutboxMessage) error {
+	query := `
+		INSERT INTO email_outbox (user_id, connection_id, kind, reply_to_id, subject, to_emails, payload,
+			status, attempts, last_error, next_attempt_at, tracking_id)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, NULLIF($10, ''), $11, NULLIF($12::bigint, 0))
+		RETURNING id, created_at, updated_at
+	`
+	err := a.db.QueryRowxContext(ctx, query,
+		msg.UserID, msg.ConnectionID, msg.Kind, msg.ReplyToID, msg.Subject, pq.StringArray(msg.ToEmails), msg.Payload,
+		msg.Status, msg.Attempts, msg.LastError, msg.NextAttemptAt, msg.TrackingID,
+	).Scan(&msg.ID, &msg.CreatedAt, &msg.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue outbox message: %w", err)
+	}
+	return nil
+}
+
+// List returns the user's messages without payload, newest first.
+func (a *OutboxAdapter) List(ctx context.Context, userID uuid.UUID, statuses []string, limit int) ([]*domain.OutboxMessage, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	query := `SELECT ` + outboxColumns + ` FROM email_outbox WHERE user_id = $1`
+	args := []any{userID, limit}
+	if len(statuses) > 0 {
+		query += ` AND status = ANY($3)`
+		args = append(args, pq.StringArray(statuses))
+	} else {
+		query += ` AND status != 'sent'`
+	}
+	query += ` ORDER BY created_at DESC LIMIT $2`
+
+	var rows []outboxRow
+	if err := a.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list outbox: %w", err)
+	}
+
+	messages := make([]*domain.OutboxMessage, 0, len(rows))
+	for i := range rows {
+		messages = append(messages, rows[i].toDomain())
+	}
+	return messages, nil
+}
+
+// ClaimDue marks due queued messages (and stale sending ones) as sending and returns them with payload.
+// 여러 워커가 동시에 실행되어도 SKIP LOCKED로 같은 메시지를 두 번 가져가지 않는다.
+func (a *OutboxAdapter) ClaimDue(ctx context.Context, limit int) ([]*domain.OutboxMessage, error) {
+	query := `
+		UPDATE email_outbox SET status = 'sending', updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM email_outbox
+			WHERE (status = 'queued' AND next_attempt_at <= NOW())
+				OR (status = 'sending' AND updated_at < $2)
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + outboxColumns + `, payload`
+
+	var rows []outboxRow
+	if err := a.db.SelectContext(ctx, &rows, query, limit, time.Now().Add(-staleSendingAfter)); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	messages := make([]*domain.OutboxMessage, 0, len(rows))
+	for i := range rows {
+		messages = append(messages, rows[i].toDomain())
+	}
+	return messages, nil
+}
+
+// Claim marks one queued/failed message of the user as sending (failed는 재시도 횟수 초기화).
+func (a *OutboxAdapter) Claim(ctx context.Context, userID uuid.UUID, id int64) (*domain.OutboxMessage, bool, error) {
+	return a.transition(ctx, userID, id, `status = 'sending', attempts = CASE WHEN status = 'failed' THEN 0 ELSE attempts END, updated_at = NOW()`, true)
+}
+
+// Cancel cancels a queued/failed message of the user and drops its payload.
+func (a *OutboxAdapter) Cancel(ctx context.Context, userID uuid.UUID, id int64) (*domain.OutboxMessage, bool, error) {
+	return a.transition(ctx, userID, id, `status = 'cancelled', payload = '{}'::jsonb, next_attempt_at = NULL, updated_at = NOW()`, false)
+}
+
+// transition applies set to a queued/failed message. 상태가 맞지 않으면 현재 메시지와 false를 반환한다.
+func (a *OutboxAdapter) transition(ctx context.Context, userID uuid.UUID, id int64, set string, withPayload bool) (*domain.OutboxMessage, bool, error) {
+	returning := outboxColumns
+	if withPayload {
+		returning += `, payload`
+	}
+	query := `
+		UPDATE email_outbox SET ` + set + `
+		WHERE id = $1 AND user_id = $2 AND status IN ('queued', 'failed')
+		RETURNING ` + returning
+
+	var row outboxRow
+	err := a.db.GetContext(ctx, &row, query, id, userID)
+	if err == nil {
+		return row.toDomain(), true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, fmt.Errorf("failed to update outbox message: %w", err)
+	}
+
+	err = a.db.GetContext(ctx, &row, `SELECT `+outboxColumns+` FROM email_outbox WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, out.ErrOutboxNotFound
+		}
+		return nil, false, fmt.Errorf("failed to get outbox message: %w", err)
+	}
+	return row.toDomain(), false, nil
+}
+
+// MarkSent records the provider ID and drops the payload (첨부 데이터는 더 필요 없음).
+func (a *OutboxAdapter) MarkSent(ctx context.Context, id int64, providerID string, sentAt time.Time) error {
+	query := `
+		UPDATE email_outbox
+		SET status = 'sent', provider_id = $2, sent_at = $3, attempts = attempts + 1,
+			last_error = NULL, next_attempt_at = NULL, payload = '{}'::jsonb, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := a.db.ExecContext(ctx, query, id, providerID, sentAt); err != nil {
+		return fmt.Errorf("failed to mark outbox message sent: %w", err)
+	}
+	return nil
+}
+
+// Reschedule records a failed attempt and queues the next one.
+func (a *OutboxAdapter) Reschedule(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE email_outbox
+		SET status = 'queued', attempts = attempts + 1, last_error = $2, next_attempt_at = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := a.db.ExecContext(ctx, query, id, lastError, nextAttemptAt); err != nil {
+		return fmt.Errorf("failed to reschedule outbox message: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records the last attempt and stops retrying (수동 재시도는 가능).
+func (a *OutboxAdapter) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	query := `
+		UPDATE email_outbox
+		SET status = 'failed', attempts = attempts + 1, last_error = $2, next_attempt_at = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+	if _, err := a.db.ExecContext(ctx, query, id, lastError); err != nil {
+		return fmt.Errorf("failed to mark outbox message failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// PushDeviceAdapter implements out.PushDeviceRepository using PostgreSQL.
+type PushDeviceAdapter struct {
+	db *sqlx.DB
+}
+
+// NewPushDeviceAdapter creates a new PushDeviceAdapter.
+func NewPushDeviceAdapter(db *sqlx.DB) *PushDeviceAdapter {
+	return &PushDeviceAdapter{db: db}
+}
+
+// pushDeviceRow represents the database row for a push device.
+type pushDeviceRow struct {
+	ID         int64          `db:"id"`
+	UserID     uuid.UUID      `db:"user_id"`
+	Platform   string         `db:"platform"`
+	Token      string         `db:"token"`
+	DeviceName sql.NullString `db:"device_name"`
+	CreatedAt  time.Time      `db:"created_at"`
+	LastSeenAt time.Time      `db:"last_seen_at"`
+}
+
+func (r *pushDeviceRow) toDomain() *domain.PushDevice {
+	return &domain.PushDevice{
+		ID:         r.ID,
+		UserID:     r.UserID,
+		Platform:   domain.PushPlatform(r.Platform),
+		Token:      r.Token,
+		DeviceName: r.DeviceName.String,
+		CreatedAt:  r.CreatedAt,
+		LastSeenAt: r.LastSeenAt,
+	}
+}
+
+// Register upserts a device by token and returns id/timestamps into it.
+func (a *PushDeviceAdapter) Register(ctx context.Context, d *domain.PushDevice) error {
+	query := `
+		INSERT INTO push_devices (user_id, platform, token, device_name)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (token) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			platform = EXCLUDED.platform,
+			device_name = EXCLUDED.device_name,
+			last_seen_at = NOW()
+		RETURNING id, created_at, last_seen_at
+	`
+	err := a.db.QueryRowxContext(ctx, query, d.UserID, d.Platform, d.Token, nullStr(d.DeviceName)).
+		Scan(&d.ID, &d.CreatedAt, &d.LastSeenAt)
+	if err != nil {
+		return fmt.Errorf("failed to register push device: %w", err)
+	}
+	return nil
+}
+
+// ListByUser returns the user's devices, most recently seen first.
+func (a *PushDeviceAdapter) ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.PushDevice, error) {
+	query := `
+		SELECT id, user_id, platform, token, device_name, created_at, last_seen_at
+		FROM push_devices WHERE user_id = $1
+		ORDER BY last_seen_at DESC
+	`
+
+	var rows []pushDeviceRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list push devices: %w", err)
+	}
+	devices := make([]*domain.PushDevice, len(rows))
+	for i := range rows {
+		devices[i] = rows[i].toDomain()
+	}
+	return devices, nil
+}
+
+// Delete removes one of the user's devices.
+func (a *PushDeviceAdapter) Delete(ctx context.Context, userID uuid.UUID, token string) error {
+	result, err := a.db.ExecContext(ctx, `DELETE FROM push_devices WHERE user_id = $1 AND token = $2`, userID, token)
+	if err != nil {
+		return fmt.Errorf("failed to delete push device: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteByToken removes a token rejected by the push service.
+func (a *PushDeviceAdapter) DeleteByToken(ctx context.Context, token string) error {
+	if _, err := a.db.ExecContext(ctx, `DELETE FROM push_devices WHERE token = $1`, token); err != nil {
+		return fmt.Errorf("failed to delete push token: %w", err)
+	}
+	return nil
+}
+
+var _ out.PushDeviceRepository = (*PushDeviceAdapter)(nil)
@@ -0,0 +1,28 @@
+package persistence
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// queryTimeout is the per-call deadline for heavy repository reads (0이면 적용 안 함).
+var queryTimeout atomic.Int64
+
+// SetQueryTimeout sets the per-call deadline for heavy repository reads.
+// 느린 DB가 Fiber 요청 goroutine과 풀 연결을 오래 붙잡지 않도록 한다.
+func SetQueryTimeout(d time.Duration) {
+	queryTimeout.Store(int64(d))
+}
+
+// withQueryTimeout bounds ctx by the query timeout; an earlier caller deadline is kept.
+func withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	d := time.Duration(queryTimeout.Load())
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= d {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
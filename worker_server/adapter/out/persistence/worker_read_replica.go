@@ -0,0 +1,121 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// =============================================================================
+// Read Replica - 목록/검색/통계 조회 분리
+// 쓰기와 동기화는 항상 primary, 무거운 읽기만 복제 지연이 허용 범위일 때 replica로 보낸다.
+// =============================================================================
+
+// replicaLagQuery returns the replay lag in seconds (WAL을 모두 반영했으면 0).
+// primary에 연결된 경우 두 LSN이 NULL이라 pg_last_xact_replay_timestamp()도 NULL → 0.
+const replicaLagQuery = `
+	SELECT COALESCE(
+		CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE EXTRACT(EPOCH FROM NOW() - pg_last_xact_replay_timestamp()) END,
+	0)::float8
+`
+
+// ReplicaConfig configures read replica routing.
+type ReplicaConfig struct {
+	MaxLag        time.Duration // 허용 복제 지연 (넘으면 primary)
+	CheckInterval time.Duration // 지연 확인 주기
+	CheckTimeout  time.Duration
+}
+
+// DefaultReplicaConfig returns defaults.
+func DefaultReplicaConfig() *ReplicaConfig {
+	return &ReplicaConfig{
+		MaxLag:        5 * time.Second,
+		CheckInterval: 10 * time.Second,
+		CheckTimeout:  2 * time.Second,
+	}
+}
+
+// ReadReplica routes read-only queries to a replica while its lag is within tolerance.
+type ReadReplica struct {
+	primary *sqlx.DB
+	replica *sqlx.DB
+	config  *ReplicaConfig
+
+	mu        sync.RWMutex
+	healthy   bool
+	lag       time.Duration
+	checkedAt time.Time
+	checking  atomic.Bool
+}
+
+// NewReadReplica creates a read replica router. 첫 확인 전까지는 primary를 사용한다.
+func NewReadReplica(primary, replica *sqlx.DB, config *ReplicaConfig) *ReadReplica {
+	if config == nil {
+		config = DefaultReplicaConfig()
+	}
+	r := &ReadReplica{primary: primary, replica: replica, config: config}
+	r.check()
+	return r
+}
+
+// Reader returns the DB for a read-only query.
+// 확인 주기가 지나면 백그라운드에서 지연을 다시 재고, 그동안은 마지막 결과를 따른다.
+func (r *ReadReplica) Reader(ctx context.Context) *sqlx.DB {
+	if out.PrimaryReadRequired(ctx) {
+		return r.primary
+	}
+
+	r.mu.RLock()
+	healthy, checkedAt := r.healthy, r.checkedAt
+	r.mu.RUnlock()
+
+	if time.Since(checkedAt) >= r.config.CheckInterval && r.checking.CompareAndSwap(false, true) {
+		go func() {
+			defer r.checking.Store(false)
+			r.check()
+		}()
+	}
+	if !healthy {
+		return r.primary
+	}
+	return r.replica
+}
+
+// Lag returns the last measured replication lag and whether the replica is in use.
+func (r *ReadReplica) Lag() (time.Duration, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lag, r.healthy
+}
+
+func (r *ReadReplica) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.CheckTimeout)
+	defer cancel()
+
+	var seconds sql.NullFloat64
+	err := r.replica.QueryRowContext(ctx, replicaLagQuery).Scan(&seconds)
+	lag := time.Duration(seconds.Float64 * float64(time.Second))
+	healthy := err == nil && lag <= r.config.MaxLag
+
+	r.mu.Lock()
+	wasHealthy := r.healthy
+	r.healthy, r.lag, r.checkedAt = healthy, lag, time.Now()
+	r.mu.Unlock()
+
+	switch {
+	case err != nil && wasHealthy:
+		logger.Warn("[ReadReplica] Replica check failed, reading from primary: %v", err)
+	case err == nil && !healthy && wasHealthy:
+		logger.Warn("[ReadReplica] Replica lag %v exceeds %v, reading from primary", lag, r.config.MaxLag)
+	case healthy && !wasHealthy:
+		logger.Info("[ReadReplica] Reading from replica (lag %v)", lag)
+	}
+}
@@ -0,0 +1,190 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// SendTrackingAdapter implements out.SendTrackingRepository using PostgreSQL.
+type SendTrackingAdapter struct {
+	db *sqlx.DB
+}
+
+// NewSendTrackingAdapter creates a new SendTrackingAdapter.
+func NewSendTrackingAdapter(db *sqlx.DB) *SendTrackingAdapter {
+	return &SendTrackingAdapter{db: db}
+}
+
+// sentTrackingRow represents the database row for a tracked send.
+type sentTrackingRow struct {
+	ID           int64          `db:"id"`
+	UserID       uuid.UUID      `db:"user_id"`
+	ConnectionID int64          `db:"connection_id"`
+	ExternalID   sql.NullString `db:"external_id"`
+	Subject      sql.NullString `db:"subject"`
+	TrackOpens   bool           `db:"track_opens"`
+	TrackClicks  bool           `db:"track_clicks"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
+
+const sentTrackingColumns = `id, user_id, connection_id, external_id, subject, track_opens, track_clicks, created_at`
+
+func (r *sentTrackingRow) toDomain() *domain.SentTracking {
+	return &domain.SentTracking{
+		ID:           r.ID,
+		UserID:       r.UserID,
+		ConnectionID: r.ConnectionID,
+		ExternalID:   r.ExternalID.String,
+		Subject:      r.Subject.String,
+		TrackOpens:   r.TrackOpens,
+		TrackClicks:  r.TrackClicks,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+// trackingEventRow represents the database row for an open/click event.
+type trackingEventRow struct {
+	ID         int64          `db:"id"`
+	TrackingID int64          `db:"tracking_id"`
+	EventType  string         `db:"event_type"`
+	URL        sql.NullString `db:"url"`
+	UserAgent  sql.NullString `db:"user_agent"`
+	OccurredAt time.Time      `db:"occurred_at"`
+}
+
+func (r *trackingEventRow) toDomain() *domain.TrackingEvent {
+	return &domain.TrackingEvent{
+		ID:         r.ID,
+		TrackingID: r.TrackingID,
+		Type:       domain.TrackingEventType(r.EventType),
+		URL:        r.URL.String,
+		UserAgent:  r.UserAgent.String,
+		OccurredAt: r.OccurredAt,
+	}
+}
+
+// Create stores a tracking record before the message is sent.
+func (a *SendTrackingAdapter) Create(ctx context.Context, tracking *domain.SentTracking) error {
+	query := `
+		INSERT INTO sent_email_tracking (user_id, connection_id, subject, track_opens, track_clicks)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := a.db.QueryRowxContext(ctx, query,
+		tracking.UserID,
+		tracking.ConnectionID,
+		tracking.Subject,
+		tracking.TrackOpens,
+		tracking.TrackClicks,
+	).Scan(&tracking.ID, &tracking.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create send tracking: %w", err)
+	}
+	return nil
+}
+
+// SetExternalID links the record to the provider message ID.
+func (a *SendTrackingAdapter) SetExternalID(ctx context.Context, id int64, externalID string) error {
+	query := `UPDATE sent_email_tracking SET external_id = $2 WHERE id = $1`
+
+	if _, err := a.db.ExecContext(ctx, query, id, externalID); err != nil {
+		return fmt.Errorf("failed to set tracking external id: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a tracking record (발송 실패 시).
+func (a *SendTrackingAdapter) Delete(ctx context.Context, id int64) error {
+	if _, err := a.db.ExecContext(ctx, `DELETE FROM sent_email_tracking WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete send tracking: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a tracking record (pixel/click 요청 검증용).
+func (a *SendTrackingAdapter) GetByID(ctx context.Context, id int64) (*domain.SentTracking, error) {
+	query := `SELECT ` + sentTrackingColumns + ` FROM sent_email_tracking WHERE id = $1`
+
+	var row sentTrackingRow
+	if err := a.db.GetContext(ctx, &row, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get send tracking: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// GetByExternalID retrieves the tracking record of a sent message.
+func (a *SendTrackingAdapter) GetByExternalID(ctx context.Context, userID uuid.UUID, connectionID int64, externalID string) (*domain.SentTracking, error) {
+	query := `SELECT ` + sentTrackingColumns + `
+		FROM sent_email_tracking
+		WHERE user_id = $1 AND connection_id = $2 AND external_id = $3
+		ORDER BY id DESC LIMIT 1`
+
+	var row sentTrackingRow
+	if err := a.db.GetContext(ctx, &row, query, userID, connectionID, externalID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get send tracking: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// RecordEvent stores an open or click event.
+func (a *SendTrackingAdapter) RecordEvent(ctx context.Context, event *domain.TrackingEvent) error {
+	query := `
+		INSERT INTO sent_email_tracking_events (tracking_id, event_type, url, user_agent, occurred_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5)
+		RETURNING id
+	`
+
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	err := a.db.QueryRowxContext(ctx, query,
+		event.TrackingID,
+		event.Type,
+		event.URL,
+		event.UserAgent,
+		event.OccurredAt,
+	).Scan(&event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record tracking event: %w", err)
+	}
+	return nil
+}
+
+// ListEvents returns events of a tracked send ordered by time.
+func (a *SendTrackingAdapter) ListEvents(ctx context.Context, trackingID int64) ([]*domain.TrackingEvent, error) {
+	query := `
+		SELECT id, tracking_id, event_type, url, user_agent, occurred_at
+		FROM sent_email_tracking_events
+		WHERE tracking_id = $1
+		ORDER BY occurred_at
+	`
+
+	var rows []trackingEventRow
+	if err := a.db.SelectContext(ctx, &rows, query, trackingID); err != nil {
+		return nil, fmt.Errorf("failed to list tracking events: %w", err)
+	}
+
+	events := make([]*domain.TrackingEvent, len(rows))
+	for i := range rows {
+		events[i] = rows[i].toDomain()
+	}
+	return events, nil
+}
+
+var _ out.SendTrackingRepository = (*SendTrackingAdapter)(nil)
@@ -389,6 +389,29 @@ func (a *SenderProfileAdapter) GetContactSenders(userID uuid.UUID) ([]*domain.Se
 	return profiles, nil
 }
 
+// GetLowEngagementSenders retrieves frequent senders with a low read rate.
+func (a *SenderProfileAdapter) GetLowEngagementSenders(userID uuid.UUID, maxReadRate float64, minEmails, limit int) ([]*domain.SenderProfile, error) {
+	var rows []senderProfileRow
+	query := `
+		SELECT * FROM sender_profiles
+		WHERE user_id = $1 AND COALESCE(read_rate, 0) <= $2 AND email_count >= $3
+			AND is_vip IS NOT TRUE AND is_muted IS NOT TRUE AND is_contact IS NOT TRUE
+			AND COALESCE(reply_rate, 0) = 0
+		ORDER BY email_count DESC
+		LIMIT $4`
+
+	if err := a.db.Select(&rows, query, userID, maxReadRate, minEmails, limit); err != nil {
+		return nil, fmt.Errorf("failed to get low engagement senders: %w", err)
+	}
+
+	profiles := make([]*domain.SenderProfile, len(rows))
+	for i, row := range rows {
+		profiles[i] = row.toEntity()
+	}
+
+	return profiles, nil
+}
+
 // UpdateDeleteRate updates the delete rate for a sender profile.
 func (a *SenderProfileAdapter) UpdateDeleteRate(id int64, newRate float64) error {
 	query := `UPDATE sender_profiles SET delete_rate = $2, updated_at = NOW() WHERE id = $1`
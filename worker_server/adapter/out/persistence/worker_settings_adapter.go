@@ -140,19 +140,21 @@ func nullString(s string) sql.NullString {
 
 // userSettingsRow represents the database row for user settings.
 type userSettingsRow struct {
-	ID               int64          `db:"id"`
-	UserID           uuid.UUID      `db:"user_id"`
-	DefaultSignature sql.NullString `db:"default_signature"`
-	AutoReplyEnabled bool           `db:"auto_reply_enabled"`
-	AutoReplyMessage sql.NullString `db:"auto_reply_message"`
-	AIEnabled        bool           `db:"ai_enabled"`
-	AIAutoClassify   bool           `db:"ai_auto_classify"`
-	AITone           string         `db:"ai_tone"`
-	Theme            string         `db:"theme"`
-	Language         string         `db:"language"`
-	Timezone         string         `db:"timezone"`
-	CreatedAt        time.Time      `db:"created_at"`
-	UpdatedAt        time.Time      `db:"updated_at"`
+	ID                   int64          `db:"id"`
+	UserID               uuid.UUID      `db:"user_id"`
+	DefaultSignature     sql.NullString `db:"default_signature"`
+	AutoReplyEnabled     bool           `db:"auto_reply_enabled"`
+	AutoReplyMessage     sql.NullString `db:"auto_reply_message"`
+	SafeLinksEnabled     sql.NullBool   `db:"safe_links_enabled"`
+	LargeAttachmentLinks sql.NullBool   `db:"large_attachment_links"`
+	AIEnabled            bool           `db:"ai_enabled"`
+	AIAutoClassify       bool           `db:"ai_auto_classify"`
+	AITone               string         `db:"ai_tone"`
+	Theme                string         `db:"theme"`
+	Language             string         `db:"language"`
+	Timezone             string         `db:"timezone"`
+	CreatedAt            time.Time      `db:"created_at"`
+	UpdatedAt            time.Time      `db:"updated_at"`
 }
 
 func (r *userSettingsRow) toDomain() *domain.UserSettings {
@@ -160,6 +162,7 @@ func (r *userSettingsRow) toDomain() *domain.UserSettings {
 		ID:               r.ID,
 		UserID:           r.UserID,
 		AutoReplyEnabled: r.AutoReplyEnabled,
+		SafeLinksEnabled: r.SafeLinksEnabled.Bool,
 		AIEnabled:        r.AIEnabled,
 		AIAutoClassify:   r.AIAutoClassify,
 		AITone:           r.AITone,
@@ -170,6 +173,9 @@ func (r *userSettingsRow) toDomain() *domain.UserSettings {
 		UpdatedAt:        r.UpdatedAt,
 	}
 
+	// NULL이면 기본값(켜짐)
+	settings.LargeAttachmentLinks = !r.LargeAttachmentLinks.Valid || r.LargeAttachmentLinks.Bool
+
 	if r.DefaultSignature.Valid {
 		settings.DefaultSignature = &r.DefaultSignature.String
 	}
@@ -184,7 +190,7 @@ func (r *userSettingsRow) toDomain() *domain.UserSettings {
 func (a *SettingsAdapter) GetByUserID(userID uuid.UUID) (*domain.UserSettings, error) {
 	const query = `
 		SELECT id, user_id, default_signature, auto_reply_enabled, auto_reply_message,
-		       ai_enabled, ai_auto_classify, ai_tone,
+		       safe_links_enabled, large_attachment_links, ai_enabled, ai_auto_classify, ai_tone,
 		       theme, language, timezone, created_at, updated_at
 		FROM user_settings
 		WHERE user_id = $1
@@ -207,9 +213,9 @@ func (a *SettingsAdapter) Create(settings *domain.UserSettings) error {
 		INSERT INTO user_settings (
 			user_id, default_signature, auto_reply_enabled, auto_reply_message,
 			ai_enabled, ai_auto_classify, ai_tone,
-			theme, language, timezone
+			theme, language, timezone, safe_links_enabled, large_attachment_links
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 		)
 		RETURNING id, created_at, updated_at
 	`
@@ -233,6 +239,8 @@ func (a *SettingsAdapter) Create(settings *domain.UserSettings) error {
 		settings.Theme,
 		settings.Language,
 		settings.Timezone,
+		settings.SafeLinksEnabled,
+		settings.LargeAttachmentLinks,
 	).Scan(&settings.ID, &settings.CreatedAt, &settings.UpdatedAt)
 }
 
@@ -249,8 +257,10 @@ func (a *SettingsAdapter) Update(settings *domain.UserSettings) error {
 			theme = $7,
 			language = $8,
 			timezone = $9,
+			safe_links_enabled = $10,
+			large_attachment_links = $11,
 			updated_at = NOW()
-		WHERE user_id = $10
+		WHERE user_id = $12
 	`
 
 	var sig, autoReply sql.NullString
@@ -271,6 +281,8 @@ func (a *SettingsAdapter) Update(settings *domain.UserSettings) error {
 		settings.Theme,
 		settings.Language,
 		settings.Timezone,
+		settings.SafeLinksEnabled,
+		settings.LargeAttachmentLinks,
 		settings.UserID,
 	)
 
@@ -0,0 +1,238 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// slaWindow - SLA를 평가하는 최근 메일 범위
+const slaWindow = 30 * 24 * time.Hour
+
+// SLAAdapter implements out.SLARepository using PostgreSQL.
+type SLAAdapter struct {
+	db *sqlx.DB
+}
+
+// NewSLAAdapter creates a new SLAAdapter.
+func NewSLAAdapter(db *sqlx.DB) *SLAAdapter {
+	return &SLAAdapter{db: db}
+}
+
+type orgSLARow struct {
+	OrgID           int64     `db:"org_id"`
+	Category        string    `db:"category"`
+	ResponseMinutes int       `db:"response_minutes"`
+	AtRiskPercent   int       `db:"at_risk_percent"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+func (r *orgSLARow) toDomain() *domain.OrgSLA {
+	return &domain.OrgSLA{
+		OrgID:           r.OrgID,
+		Category:        r.Category,
+		ResponseMinutes: r.ResponseMinutes,
+		AtRiskPercent:   r.AtRiskPercent,
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+}
+
+// ListSLAs returns the SLAs of an org.
+func (a *SLAAdapter) ListSLAs(ctx context.Context, orgID int64) ([]*domain.OrgSLA, error) {
+	query := `
+		SELECT org_id, category, response_minutes, at_risk_percent, created_at, updated_at
+		FROM org_slas WHERE org_id = $1
+		ORDER BY category = 'default' DESC, category
+	`
+	var rows []orgSLARow
+	if err := a.db.SelectContext(ctx, &rows, query, orgID); err != nil {
+		return nil, fmt.Errorf("failed to list slas: %w", err)
+	}
+
+	slas := make([]*domain.OrgSLA, len(rows))
+	for i := range rows {
+		slas[i] = rows[i].toDomain()
+	}
+	return slas, nil
+}
+
+// UpsertSLA creates or updates the SLA of a category.
+func (a *SLAAdapter) UpsertSLA(ctx context.Context, sla *domain.OrgSLA) error {
+	query := `
+		INSERT INTO org_slas (org_id, category, response_minutes, at_risk_percent)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (org_id, category) DO UPDATE SET
+			response_minutes = EXCLUDED.response_minutes,
+			at_risk_percent = EXCLUDED.at_risk_percent,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+	if err := a.db.QueryRowxContext(ctx, query, sla.OrgID, sla.Category, sla.ResponseMinutes, sla.AtRiskPercent).
+		Scan(&sla.CreatedAt, &sla.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to save sla: %w", err)
+	}
+	return nil
+}
+
+// DeleteSLA deletes the SLA of a category.
+func (a *SLAAdapter) DeleteSLA(ctx context.Context, orgID int64, category string) error {
+	result, err := a.db.ExecContext(ctx, `DELETE FROM org_slas WHERE org_id = $1 AND category = $2`, orgID, category)
+	if err != nil {
+		return fmt.Errorf("failed to delete sla: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return out.ErrSLANotFound
+	}
+	return nil
+}
+
+// Evaluate recomputes the SLA state of inbox emails received after the SLA was set.
+// 답장했거나 완료 처리한 메일은 met, 기한을 넘기면 breached, at_risk_percent를 넘기면 at_risk.
+// 카테고리 SLA가 없으면 default SLA를 쓰고, 둘 다 없으면 평가하지 않는다.
+func (a *SLAAdapter) Evaluate(ctx context.Context, now time.Time) (int, error) {
+	// 공유 해제되었거나 SLA가 삭제된 메일, 삭제된 메일의 상태는 지운다
+	cleanup := `
+		DELETE FROM email_slas es
+		USING emails e
+		WHERE e.id = es.email_id
+		  AND (e.deleted_at IS NOT NULL OR NOT EXISTS (
+			SELECT 1 FROM org_mailboxes mb
+			JOIN org_slas sl ON sl.org_id = mb.org_id AND sl.category IN (COALESCE(e.ai_category, ''), 'default')
+			WHERE mb.connection_id = e.connection_id AND mb.org_id = es.org_id
+		  ))
+	`
+	removed, err := a.db.ExecContext(ctx, cleanup)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clean up slas: %w", err)
+	}
+
+	query := `
+		WITH eligible AS (
+			SELECT e.id AS email_id, mb.org_id,
+			       e.email_date + make_interval(mins => s.response_minutes) AS due_at,
+			       e.email_date + make_interval(mins => s.response_minutes * s.at_risk_percent / 100) AS risk_at,
+			       (e.is_replied OR e.workflow_status = 'done') AS resolved
+			FROM emails e
+			JOIN org_mailboxes mb ON mb.connection_id = e.connection_id
+			JOIN LATERAL (
+				SELECT sl.response_minutes, sl.at_risk_percent, sl.created_at
+				FROM org_slas sl
+				WHERE sl.org_id = mb.org_id AND sl.category IN (COALESCE(e.ai_category, ''), 'default')
+				ORDER BY sl.category = 'default'
+				LIMIT 1
+			) s ON TRUE
+			WHERE e.folder = 'inbox'
+			  AND e.deleted_at IS NULL
+			  AND e.email_date >= s.created_at
+			  AND e.email_date > $2
+		)
+		INSERT INTO email_slas (email_id, org_id, due_at, status, evaluated_at)
+		SELECT email_id, org_id, due_at,
+		       CASE WHEN resolved THEN 'met'
+		            WHEN $1 >= due_at THEN 'breached'
+		            WHEN $1 >= risk_at THEN 'at_risk'
+		            ELSE 'ok' END,
+		       $1
+		FROM eligible
+		ON CONFLICT (email_id) DO UPDATE SET
+			org_id = EXCLUDED.org_id,
+			due_at = EXCLUDED.due_at,
+			status = EXCLUDED.status,
+			evaluated_at = EXCLUDED.evaluated_at
+		WHERE email_slas.status IS DISTINCT FROM EXCLUDED.status
+		   OR email_slas.due_at IS DISTINCT FROM EXCLUDED.due_at
+	`
+	result, err := a.db.ExecContext(ctx, query, now, now.Add(-slaWindow))
+	if err != nil {
+		return 0, fmt.Errorf("failed to evaluate slas: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	m, _ := removed.RowsAffected()
+	return int(n + m), nil
+}
+
+// PendingEscalations returns at_risk/breached emails not yet notified for their current state.
+func (a *SLAAdapter) PendingEscalations(ctx context.Context, limit int) ([]*domain.SLAEscalation, error) {
+	query := `
+		SELECT es.email_id, es.org_id, es.status, es.due_at,
+		       COALESCE(e.subject, '') AS subject, COALESCE(e.from_email, '') AS from_email,
+		       asg.assignee_id
+		FROM email_slas es
+		JOIN emails e ON e.id = es.email_id
+		LEFT JOIN email_assignments asg ON asg.email_id = es.email_id
+		WHERE es.status IN ('at_risk', 'breached')
+		  AND es.notified_status IS DISTINCT FROM es.status
+		  AND e.deleted_at IS NULL
+		ORDER BY es.due_at
+		LIMIT $1
+	`
+	var rows []struct {
+		EmailID    int64         `db:"email_id"`
+		OrgID      int64         `db:"org_id"`
+		Status     string        `db:"status"`
+		DueAt      time.Time     `db:"due_at"`
+		Subject    string        `db:"subject"`
+		FromEmail  string        `db:"from_email"`
+		AssigneeID uuid.NullUUID `db:"assignee_id"`
+	}
+	if err := a.db.SelectContext(ctx, &rows, query, limit); err != nil {
+		return nil, fmt.Errorf("failed to list sla escalations: %w", err)
+	}
+
+	escalations := make([]*domain.SLAEscalation, len(rows))
+	for i, r := range rows {
+		escalations[i] = &domain.SLAEscalation{
+			EmailID:   r.EmailID,
+			OrgID:     r.OrgID,
+			Status:    domain.SLAStatus(r.Status),
+			DueAt:     r.DueAt,
+			Subject:   r.Subject,
+			FromEmail: r.FromEmail,
+		}
+		if r.AssigneeID.Valid {
+			id := r.AssigneeID.UUID
+			escalations[i].AssigneeID = &id
+		}
+	}
+	return escalations, nil
+}
+
+// MarkNotified records that the escalation for the state was sent.
+func (a *SLAAdapter) MarkNotified(ctx context.Context, emailID int64, status domain.SLAStatus) error {
+	if _, err := a.db.ExecContext(ctx, `UPDATE email_slas SET notified_status = $2 WHERE email_id = $1`, emailID, status); err != nil {
+		return fmt.Errorf("failed to mark sla notified: %w", err)
+	}
+	return nil
+}
+
+// StatusByEmailIDs returns the SLA state of the given emails.
+func (a *SLAAdapter) StatusByEmailIDs(ctx context.Context, emailIDs []int64) (map[int64]domain.SLAStatus, error) {
+	statuses := make(map[int64]domain.SLAStatus)
+	if len(emailIDs) == 0 {
+		return statuses, nil
+	}
+
+	var rows []struct {
+		EmailID int64          `db:"email_id"`
+		Status  sql.NullString `db:"status"`
+	}
+	if err := a.db.SelectContext(ctx, &rows, `SELECT email_id, status FROM email_slas WHERE email_id = ANY($1)`, pq.Array(emailIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get sla status: %w", err)
+	}
+	for _, r := range rows {
+		statuses[r.EmailID] = domain.SLAStatus(r.Status.String)
+	}
+	return statuses, nil
+}
+
+var _ out.SLARepository = (*SLAAdapter)(nil)
@@ -0,0 +1,172 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SyncLagAdapter implements out.SyncLagRepository using PostgreSQL.
+type SyncLagAdapter struct {
+	db *sqlx.DB
+}
+
+// NewSyncLagAdapter creates a new SyncLagAdapter.
+func NewSyncLagAdapter(db *sqlx.DB) *SyncLagAdapter {
+	return &SyncLagAdapter{db: db}
+}
+
+type syncLagEntity struct {
+	ConnectionID     int64        `db:"connection_id"`
+	UserID           string       `db:"user_id"`
+	ProviderLatestAt sql.NullTime `db:"provider_latest_at"`
+	StoredLatestAt   sql.NullTime `db:"stored_latest_at"`
+	LagSeconds       int64        `db:"lag_seconds"`
+	CheckedAt        time.Time    `db:"checked_at"`
+	AlertedAt        sql.NullTime `db:"alerted_at"`
+	RemediatedAt     sql.NullTime `db:"remediated_at"`
+}
+
+func (e *syncLagEntity) toDomain() *domain.SyncLag {
+	return &domain.SyncLag{
+		ConnectionID:     e.ConnectionID,
+		UserID:           e.UserID,
+		ProviderLatestAt: nullTimePtr(e.ProviderLatestAt),
+		StoredLatestAt:   nullTimePtr(e.StoredLatestAt),
+		LagSeconds:       e.LagSeconds,
+		CheckedAt:        e.CheckedAt,
+		AlertedAt:        nullTimePtr(e.AlertedAt),
+		RemediatedAt:     nullTimePtr(e.RemediatedAt),
+	}
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
+
+// LatestStoredAt returns the newest stored email date of the connection.
+func (a *SyncLagAdapter) LatestStoredAt(ctx context.Context, connectionID int64) (time.Time, error) {
+	var latest sql.NullTime
+	query := `SELECT MAX(email_date) FROM emails WHERE connection_id = $1`
+	if err := a.db.GetContext(ctx, &latest, query, connectionID); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get latest stored email: %w", err)
+	}
+	return latest.Time, nil
+}
+
+// Get returns the last lag measurement of the connection.
+func (a *SyncLagAdapter) Get(ctx context.Context, connectionID int64) (*domain.SyncLag, error) {
+	var e syncLagEntity
+	err := a.db.GetContext(ctx, &e, `SELECT * FROM sync_lags WHERE connection_id = $1`, connectionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync lag: %w", err)
+	}
+	return e.toDomain(), nil
+}
+
+// Save upserts a lag measurement.
+func (a *SyncLagAdapter) Save(ctx context.Context, lag *domain.SyncLag) error {
+	query := `
+		INSERT INTO sync_lags (connection_id, user_id, provider_latest_at, stored_latest_at, lag_seconds, checked_at, alerted_at, remediated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (connection_id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			provider_latest_at = EXCLUDED.provider_latest_at,
+			stored_latest_at = EXCLUDED.stored_latest_at,
+			lag_seconds = EXCLUDED.lag_seconds,
+			checked_at = EXCLUDED.checked_at,
+			alerted_at = EXCLUDED.alerted_at,
+			remediated_at = EXCLUDED.remediated_at
+	`
+	_, err := a.db.ExecContext(ctx, query,
+		lag.ConnectionID, lag.UserID, lag.ProviderLatestAt, lag.StoredLatestAt,
+		lag.LagSeconds, lag.CheckedAt, lag.AlertedAt, lag.RemediatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save sync lag: %w", err)
+	}
+	return nil
+}
+
+// ListConnections lists sync states joined with their lag, most lagging first.
+// 측정 전인 연결은 minLagSeconds가 0일 때만 포함한다.
+func (a *SyncLagAdapter) ListConnections(ctx context.Context, minLagSeconds int64, limit, offset int) ([]*domain.ConnectionSyncLag, int, error) {
+	where := `WHERE ($1 = 0 OR l.lag_seconds >= $1)`
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM sync_states s LEFT JOIN sync_lags l ON l.connection_id = s.connection_id ` + where
+	if err := a.db.GetContext(ctx, &total, countQuery, minLagSeconds); err != nil {
+		return nil, 0, fmt.Errorf("failed to count connections: %w", err)
+	}
+
+	var rows []struct {
+		ConnectionID     int64          `db:"connection_id"`
+		UserID           string         `db:"user_id"`
+		Provider         string         `db:"provider"`
+		Status           string         `db:"status"`
+		LastSyncAt       sql.NullTime   `db:"last_sync_at"`
+		LastError        sql.NullString `db:"last_error"`
+		Measured         bool           `db:"measured"`
+		ProviderLatestAt sql.NullTime   `db:"provider_latest_at"`
+		StoredLatestAt   sql.NullTime   `db:"stored_latest_at"`
+		LagSeconds       sql.NullInt64  `db:"lag_seconds"`
+		CheckedAt        sql.NullTime   `db:"checked_at"`
+		AlertedAt        sql.NullTime   `db:"alerted_at"`
+		RemediatedAt     sql.NullTime   `db:"remediated_at"`
+	}
+	query := `
+		SELECT s.connection_id, s.user_id, s.provider, s.status, s.last_sync_at, s.last_error,
+			l.connection_id IS NOT NULL AS measured,
+			l.provider_latest_at, l.stored_latest_at, l.lag_seconds, l.checked_at, l.alerted_at, l.remediated_at
+		FROM sync_states s
+		LEFT JOIN sync_lags l ON l.connection_id = s.connection_id
+		` + where + `
+		ORDER BY l.lag_seconds DESC NULLS LAST, s.connection_id
+		LIMIT $2 OFFSET $3
+	`
+	if err := a.db.SelectContext(ctx, &rows, query, minLagSeconds, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	result := make([]*domain.ConnectionSyncLag, len(rows))
+	for i, r := range rows {
+		c := &domain.ConnectionSyncLag{
+			ConnectionID: r.ConnectionID,
+			UserID:       r.UserID,
+			Provider:     domain.Provider(r.Provider),
+			Status:       domain.SyncStatus(r.Status),
+			LastSyncAt:   nullTimePtr(r.LastSyncAt),
+			LastError:    r.LastError.String,
+		}
+		if r.Measured {
+			c.Lag = &domain.SyncLag{
+				ConnectionID:     r.ConnectionID,
+				UserID:           r.UserID,
+				ProviderLatestAt: nullTimePtr(r.ProviderLatestAt),
+				StoredLatestAt:   nullTimePtr(r.StoredLatestAt),
+				LagSeconds:       r.LagSeconds.Int64,
+				CheckedAt:        r.CheckedAt.Time,
+				AlertedAt:        nullTimePtr(r.AlertedAt),
+				RemediatedAt:     nullTimePtr(r.RemediatedAt),
+			}
+		}
+		result[i] = c
+	}
+	return result, total, nil
+}
+
+var _ out.SyncLagRepository = (*SyncLagAdapter)(nil)
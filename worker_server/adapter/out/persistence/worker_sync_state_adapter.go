@@ -38,6 +38,11 @@ type syncStateEntity struct {
 	WatchExpiry     sql.NullTime   `db:"watch_expiry"`
 	WatchResourceID sql.NullString `db:"watch_resource_id"`
 
+	// Watch 갱신 실패
+	WatchFailures    int          `db:"watch_failures"`
+	WatchRetryAt     sql.NullTime `db:"watch_retry_at"`
+	WatchEscalatedAt sql.NullTime `db:"watch_escalated_at"`
+
 	// 재시도 관련
 	RetryCount  int          `db:"retry_count"`
 	MaxRetries  int          `db:"max_retries"`
@@ -49,6 +54,7 @@ type syncStateEntity struct {
 	CheckpointSyncedCount int            `db:"checkpoint_synced_count"`
 	CheckpointTotalCount  sql.NullInt32  `db:"checkpoint_total_count"`
 	CheckpointUpdatedAt   sql.NullTime   `db:"checkpoint_updated_at"`
+	CheckpointStartedAt   sql.NullTime   `db:"checkpoint_started_at"`
 
 	// 통계
 	TotalSynced          int64        `db:"total_synced"`
@@ -73,6 +79,7 @@ func (e *syncStateEntity) toDomain() *domain.SyncState {
 		Provider:              domain.Provider(e.Provider),
 		Status:                domain.SyncStatus(e.Status),
 		HistoryID:             uint64(e.HistoryID),
+		WatchFailures:         e.WatchFailures,
 		RetryCount:            e.RetryCount,
 		MaxRetries:            e.MaxRetries,
 		CheckpointSyncedCount: e.CheckpointSyncedCount,
@@ -95,6 +102,12 @@ func (e *syncStateEntity) toDomain() *domain.SyncState {
 	if e.WatchResourceID.Valid {
 		state.WatchResourceID = e.WatchResourceID.String
 	}
+	if e.WatchRetryAt.Valid {
+		state.WatchRetryAt = e.WatchRetryAt.Time
+	}
+	if e.WatchEscalatedAt.Valid {
+		state.WatchEscalatedAt = e.WatchEscalatedAt.Time
+	}
 	if e.NextRetryAt.Valid {
 		state.NextRetryAt = e.NextRetryAt.Time
 	}
@@ -110,6 +123,9 @@ func (e *syncStateEntity) toDomain() *domain.SyncState {
 	if e.CheckpointUpdatedAt.Valid {
 		state.CheckpointUpdatedAt = e.CheckpointUpdatedAt.Time
 	}
+	if e.CheckpointStartedAt.Valid {
+		state.CheckpointStartedAt = e.CheckpointStartedAt.Time
+	}
 	if e.LastSyncAt.Valid {
 		state.LastSyncAt = e.LastSyncAt.Time
 	}
@@ -254,9 +270,9 @@ func (a *SyncStateAdapter) GetExpiredWatches(ctx context.Context, before time.Ti
 	var entities []syncStateEntity
 	query := `
 		SELECT * FROM sync_states
-		WHERE watch_expiry IS NOT NULL
-		  AND watch_expiry < $1
-		  AND status NOT IN ('watch_expired', 'error', 'none')
+		WHERE (watch_expiry < $1 OR (watch_expiry IS NULL AND status = 'watch_expired'))
+		  AND status NOT IN ('error', 'none')
+		  AND (watch_retry_at IS NULL OR watch_retry_at <= NOW())
 		ORDER BY watch_expiry ASC
 	`
 	if err := a.db.SelectContext(ctx, &entities, query, before); err != nil {
@@ -275,13 +291,70 @@ func (a *SyncStateAdapter) UpdateWatchExpiry(ctx context.Context, connectionID i
 		UPDATE sync_states SET
 			watch_expiry = $1,
 			watch_resource_id = $2,
-			status = 'idle'
+			status = 'idle',
+			watch_failures = 0,
+			watch_retry_at = NULL,
+			watch_escalated_at = NULL
 		WHERE connection_id = $3
 	`
 	_, err := a.db.ExecContext(ctx, query, expiry, resourceID, connectionID)
 	return err
 }
 
+// RecordWatchFailure counts a failed renewal and schedules the next attempt.
+func (a *SyncStateAdapter) RecordWatchFailure(ctx context.Context, connectionID int64, retryAt time.Time, lastError string) (int, error) {
+	query := `
+		UPDATE sync_states SET
+			status = 'watch_expired',
+			last_error = $2,
+			watch_failures = watch_failures + 1,
+			watch_retry_at = $3
+		WHERE connection_id = $1
+		RETURNING watch_failures
+	`
+	var failures int
+	err := a.db.QueryRowContext(ctx, query, connectionID, lastError, retryAt).Scan(&failures)
+	return failures, err
+}
+
+func (a *SyncStateAdapter) MarkWatchEscalated(ctx context.Context, connectionID int64, at time.Time) error {
+	query := `UPDATE sync_states SET watch_escalated_at = $2 WHERE connection_id = $1`
+	_, err := a.db.ExecContext(ctx, query, connectionID, at)
+	return err
+}
+
+// CountWatches counts watches by state (GET /health/watches).
+func (a *SyncStateAdapter) CountWatches(ctx context.Context, expiringWithin time.Duration) (*domain.WatchStats, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE status <> 'watch_expired' AND watch_expiry >= NOW()) AS active,
+			COUNT(*) FILTER (WHERE status <> 'watch_expired' AND watch_expiry >= NOW() AND watch_expiry < $1) AS expiring,
+			COUNT(*) FILTER (WHERE status = 'watch_expired' OR watch_expiry < NOW()) AS expired,
+			COUNT(*) FILTER (WHERE watch_escalated_at IS NOT NULL) AS degraded,
+			COUNT(*) FILTER (WHERE watch_failures > 0) AS failing
+		FROM sync_states
+		WHERE (watch_expiry IS NOT NULL OR status = 'watch_expired')
+		  AND status NOT IN ('error', 'none')
+	`
+	var row struct {
+		Active   int `db:"active"`
+		Expiring int `db:"expiring"`
+		Expired  int `db:"expired"`
+		Degraded int `db:"degraded"`
+		Failing  int `db:"failing"`
+	}
+	if err := a.db.GetContext(ctx, &row, query, time.Now().Add(expiringWithin)); err != nil {
+		return nil, err
+	}
+	return &domain.WatchStats{
+		Active:       row.Active,
+		ExpiringSoon: row.Expiring,
+		Expired:      row.Expired,
+		Degraded:     row.Degraded,
+		Failing:      row.Failing,
+	}, nil
+}
+
 // =============================================================================
 // Gap Sync 관리 (Phase 2)
 // =============================================================================
@@ -475,7 +548,8 @@ func (a *SyncStateAdapter) SaveCheckpoint(ctx context.Context, connectionID int6
 			checkpoint_page_token = $1,
 			checkpoint_synced_count = $2,
 			checkpoint_total_count = $3,
-			checkpoint_updated_at = NOW()
+			checkpoint_updated_at = NOW(),
+			checkpoint_started_at = COALESCE(checkpoint_started_at, NOW())
 		WHERE connection_id = $4
 	`
 	_, err := a.db.ExecContext(ctx, query, pageToken, syncedCount, totalCount, connectionID)
@@ -488,7 +562,8 @@ func (a *SyncStateAdapter) ClearCheckpoint(ctx context.Context, connectionID int
 			checkpoint_page_token = NULL,
 			checkpoint_synced_count = 0,
 			checkpoint_total_count = NULL,
-			checkpoint_updated_at = NULL
+			checkpoint_updated_at = NULL,
+			checkpoint_started_at = NULL
 		WHERE connection_id = $1
 	`
 	_, err := a.db.ExecContext(ctx, query, connectionID)
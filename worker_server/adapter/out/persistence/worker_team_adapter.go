@@ -0,0 +1,267 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// TeamAdapter implements out.TeamRepository using PostgreSQL.
+type TeamAdapter struct {
+	db *sqlx.DB
+}
+
+// NewTeamAdapter creates a new TeamAdapter.
+func NewTeamAdapter(db *sqlx.DB) *TeamAdapter {
+	return &TeamAdapter{db: db}
+}
+
+type orgRow struct {
+	ID        int64          `db:"id"`
+	Name      string         `db:"name"`
+	OwnerID   uuid.UUID      `db:"owner_id"`
+	Role      sql.NullString `db:"role"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+type orgMemberRow struct {
+	OrgID    int64          `db:"org_id"`
+	UserID   uuid.UUID      `db:"user_id"`
+	Email    string         `db:"email"`
+	Name     sql.NullString `db:"name"`
+	Role     string         `db:"role"`
+	JoinedAt time.Time      `db:"joined_at"`
+}
+
+func (r *orgMemberRow) toDomain() *domain.OrgMember {
+	return &domain.OrgMember{
+		OrgID:    r.OrgID,
+		UserID:   r.UserID,
+		Email:    r.Email,
+		Name:     r.Name.String,
+		Role:     domain.OrgRole(r.Role),
+		JoinedAt: r.JoinedAt,
+	}
+}
+
+type orgMailboxRow struct {
+	OrgID        int64     `db:"org_id"`
+	ConnectionID int64     `db:"connection_id"`
+	Email        string    `db:"email"`
+	SharedBy     uuid.UUID `db:"shared_by"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+func (r *orgMailboxRow) toDomain() *domain.OrgMailbox {
+	return &domain.OrgMailbox{
+		OrgID:        r.OrgID,
+		ConnectionID: r.ConnectionID,
+		Email:        r.Email,
+		SharedBy:     r.SharedBy,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+// CreateOrg creates an org with its owner as the first member.
+func (a *TeamAdapter) CreateOrg(ctx context.Context, org *domain.Org) error {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO orgs (name, owner_id) VALUES ($1, $2) RETURNING id, created_at`
+	if err := tx.QueryRowxContext(ctx, query, org.Name, org.OwnerID).Scan(&org.ID, &org.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create org: %w", err)
+	}
+
+	member := `INSERT INTO org_members (org_id, user_id, role) VALUES ($1, $2, $3)`
+	if _, err := tx.ExecContext(ctx, member, org.ID, org.OwnerID, domain.OrgRoleOwner); err != nil {
+		return fmt.Errorf("failed to add org owner: %w", err)
+	}
+
+	org.Role = domain.OrgRoleOwner
+	return tx.Commit()
+}
+
+// ListOrgs returns the orgs the user belongs to.
+func (a *TeamAdapter) ListOrgs(ctx context.Context, userID uuid.UUID) ([]*domain.Org, error) {
+	query := `
+		SELECT o.id, o.name, o.owner_id, m.role, o.created_at
+		FROM orgs o
+		JOIN org_members m ON m.org_id = o.id AND m.user_id = $1
+		ORDER BY o.created_at
+	`
+	var rows []orgRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list orgs: %w", err)
+	}
+
+	orgs := make([]*domain.Org, len(rows))
+	for i, r := range rows {
+		orgs[i] = &domain.Org{ID: r.ID, Name: r.Name, OwnerID: r.OwnerID, Role: domain.OrgRole(r.Role.String), CreatedAt: r.CreatedAt}
+	}
+	return orgs, nil
+}
+
+// MemberRole returns the user's role in the org.
+func (a *TeamAdapter) MemberRole(ctx context.Context, orgID int64, userID uuid.UUID) (domain.OrgRole, error) {
+	var role string
+	err := a.db.GetContext(ctx, &role, `SELECT role FROM org_members WHERE org_id = $1 AND user_id = $2`, orgID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", out.ErrTeamNotFound
+		}
+		return "", fmt.Errorf("failed to get org role: %w", err)
+	}
+	return domain.OrgRole(role), nil
+}
+
+// ListMembers returns the members of an org.
+func (a *TeamAdapter) ListMembers(ctx context.Context, orgID int64) ([]*domain.OrgMember, error) {
+	query := `
+		SELECT m.org_id, m.user_id, u.email, u.name, m.role, m.joined_at
+		FROM org_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1
+		ORDER BY m.joined_at
+	`
+	var rows []orgMemberRow
+	if err := a.db.SelectContext(ctx, &rows, query, orgID); err != nil {
+		return nil, fmt.Errorf("failed to list org members: %w", err)
+	}
+
+	members := make([]*domain.OrgMember, len(rows))
+	for i := range rows {
+		members[i] = rows[i].toDomain()
+	}
+	return members, nil
+}
+
+// AddMemberByEmail adds the user with the email address. 이미 멤버면 역할만 유지한다.
+func (a *TeamAdapter) AddMemberByEmail(ctx context.Context, orgID int64, email string, role domain.OrgRole) (*domain.OrgMember, error) {
+	query := `
+		WITH added AS (
+			INSERT INTO org_members (org_id, user_id, role)
+			SELECT $1, u.id, $3 FROM users u WHERE LOWER(u.email) = LOWER($2) AND u.deleted_at IS NULL
+			ON CONFLICT (org_id, user_id) DO UPDATE SET role = org_members.role
+			RETURNING org_id, user_id, role, joined_at
+		)
+		SELECT a.org_id, a.user_id, u.email, u.name, a.role, a.joined_at
+		FROM added a JOIN users u ON u.id = a.user_id
+	`
+	var row orgMemberRow
+	if err := a.db.GetContext(ctx, &row, query, orgID, email, role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, out.ErrTeamUserNotFound
+		}
+		return nil, fmt.Errorf("failed to add org member: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// RemoveMember removes a member and the mailboxes they shared with the org.
+func (a *TeamAdapter) RemoveMember(ctx context.Context, orgID int64, userID uuid.UUID) error {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM org_members WHERE org_id = $1 AND user_id = $2`, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove org member: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return out.ErrTeamNotFound
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM org_mailboxes WHERE org_id = $1 AND shared_by = $2`, orgID, userID); err != nil {
+		return fmt.Errorf("failed to unshare member mailboxes: %w", err)
+	}
+	return tx.Commit()
+}
+
+// ListMailboxes returns the mailboxes shared with an org.
+func (a *TeamAdapter) ListMailboxes(ctx context.Context, orgID int64) ([]*domain.OrgMailbox, error) {
+	query := `
+		SELECT m.org_id, m.connection_id, c.email, m.shared_by, m.created_at
+		FROM org_mailboxes m
+		JOIN oauth_connections c ON c.id = m.connection_id
+		WHERE m.org_id = $1
+		ORDER BY m.created_at
+	`
+	var rows []orgMailboxRow
+	if err := a.db.SelectContext(ctx, &rows, query, orgID); err != nil {
+		return nil, fmt.Errorf("failed to list org mailboxes: %w", err)
+	}
+
+	mailboxes := make([]*domain.OrgMailbox, len(rows))
+	for i := range rows {
+		mailboxes[i] = rows[i].toDomain()
+	}
+	return mailboxes, nil
+}
+
+// ShareMailbox shares a connection owned by the user with the org.
+// 다른 조직에 공유된 메일함이면 이 조직으로 옮긴다.
+func (a *TeamAdapter) ShareMailbox(ctx context.Context, orgID int64, userID uuid.UUID, connectionID int64) (*domain.OrgMailbox, error) {
+	query := `
+		WITH shared AS (
+			INSERT INTO org_mailboxes (connection_id, org_id, shared_by)
+			SELECT c.id, $1, $2 FROM oauth_connections c WHERE c.id = $3 AND c.user_id = $2
+			ON CONFLICT (connection_id) DO UPDATE SET org_id = EXCLUDED.org_id, shared_by = EXCLUDED.shared_by
+			RETURNING org_id, connection_id, shared_by, created_at
+		)
+		SELECT s.org_id, s.connection_id, c.email, s.shared_by, s.created_at
+		FROM shared s JOIN oauth_connections c ON c.id = s.connection_id
+	`
+	var row orgMailboxRow
+	if err := a.db.GetContext(ctx, &row, query, orgID, userID, connectionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, out.ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to share mailbox: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// UnshareMailbox stops sharing a mailbox with the org.
+func (a *TeamAdapter) UnshareMailbox(ctx context.Context, orgID, connectionID int64) error {
+	result, err := a.db.ExecContext(ctx, `DELETE FROM org_mailboxes WHERE org_id = $1 AND connection_id = $2`, orgID, connectionID)
+	if err != nil {
+		return fmt.Errorf("failed to unshare mailbox: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return out.ErrTeamNotFound
+	}
+	return nil
+}
+
+// EmailOrg returns the org through which the user sees the email.
+func (a *TeamAdapter) EmailOrg(ctx context.Context, userID uuid.UUID, emailID int64) (int64, error) {
+	query := `
+		SELECT mb.org_id
+		FROM emails e
+		JOIN org_mailboxes mb ON mb.connection_id = e.connection_id
+		JOIN org_members m ON m.org_id = mb.org_id AND m.user_id = $1
+		WHERE e.id = $2
+	`
+	var orgID int64
+	if err := a.db.GetContext(ctx, &orgID, query, userID, emailID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, out.ErrTeamNotFound
+		}
+		return 0, fmt.Errorf("failed to get email org: %w", err)
+	}
+	return orgID, nil
+}
+
+var _ out.TeamRepository = (*TeamAdapter)(nil)
@@ -0,0 +1,66 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ThreadMuteAdapter implements out.ThreadMuteRepository using PostgreSQL.
+type ThreadMuteAdapter struct {
+	db *sqlx.DB
+}
+
+// NewThreadMuteAdapter creates a new ThreadMuteAdapter.
+func NewThreadMuteAdapter(db *sqlx.DB) *ThreadMuteAdapter {
+	return &ThreadMuteAdapter{db: db}
+}
+
+// Mute mutes a thread of the connection. 이미 뮤트된 스레드는 그대로 둔다.
+func (a *ThreadMuteAdapter) Mute(ctx context.Context, userID uuid.UUID, connectionID int64, threadID string) error {
+	query := `
+		INSERT INTO email_thread_mutes (connection_id, thread_id, user_id, muted_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (connection_id, thread_id) DO NOTHING
+	`
+	if _, err := a.db.ExecContext(ctx, query, connectionID, threadID, userID); err != nil {
+		return fmt.Errorf("failed to mute thread: %w", err)
+	}
+	return nil
+}
+
+// Unmute unmutes the thread on every connection of the user.
+func (a *ThreadMuteAdapter) Unmute(ctx context.Context, userID uuid.UUID, threadID string) (bool, error) {
+	query := `DELETE FROM email_thread_mutes WHERE user_id = $1 AND thread_id = $2`
+	result, err := a.db.ExecContext(ctx, query, userID, threadID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unmute thread: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// MutedThreads returns the muted threads among threadIDs.
+func (a *ThreadMuteAdapter) MutedThreads(ctx context.Context, connectionID int64, threadIDs []string) (map[string]bool, error) {
+	muted := make(map[string]bool)
+	if len(threadIDs) == 0 {
+		return muted, nil
+	}
+
+	query := `SELECT thread_id FROM email_thread_mutes WHERE connection_id = $1 AND thread_id = ANY($2)`
+	var ids []string
+	if err := a.db.SelectContext(ctx, &ids, query, connectionID, pq.Array(threadIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get muted threads: %w", err)
+	}
+	for _, id := range ids {
+		muted[id] = true
+	}
+	return muted, nil
+}
+
+var _ out.ThreadMuteRepository = (*ThreadMuteAdapter)(nil)
@@ -0,0 +1,134 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// UserCategoryAdapter implements out.UserCategoryRepository using PostgreSQL.
+type UserCategoryAdapter struct {
+	db *sqlx.DB
+}
+
+// NewUserCategoryAdapter creates a new UserCategoryAdapter.
+func NewUserCategoryAdapter(db *sqlx.DB) *UserCategoryAdapter {
+	return &UserCategoryAdapter{db: db}
+}
+
+type userCategoryRow struct {
+	UserID      uuid.UUID      `db:"user_id"`
+	Key         string         `db:"key"`
+	Name        string         `db:"name"`
+	Description sql.NullString `db:"description"`
+	Color       sql.NullString `db:"color"`
+	Icon        sql.NullString `db:"icon"`
+	IsHidden    bool           `db:"is_hidden"`
+	IsCustom    bool           `db:"is_custom"`
+	SortOrder   int            `db:"sort_order"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+func (r *userCategoryRow) toDomain() *domain.UserCategory {
+	return &domain.UserCategory{
+		UserID:      r.UserID,
+		Key:         r.Key,
+		Name:        r.Name,
+		Description: r.Description.String,
+		Color:       r.Color.String,
+		Icon:        r.Icon.String,
+		IsHidden:    r.IsHidden,
+		IsCustom:    r.IsCustom,
+		SortOrder:   r.SortOrder,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+// List returns all stored categories of the user ordered by sort_order.
+func (a *UserCategoryAdapter) List(ctx context.Context, userID uuid.UUID) ([]*domain.UserCategory, error) {
+	query := `
+		SELECT user_id, key, name, description, color, icon, is_hidden, is_custom, sort_order, created_at, updated_at
+		FROM user_categories
+		WHERE user_id = $1
+		ORDER BY sort_order, key
+	`
+	var rows []userCategoryRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list user categories: %w", err)
+	}
+
+	categories := make([]*domain.UserCategory, len(rows))
+	for i := range rows {
+		categories[i] = rows[i].toDomain()
+	}
+	return categories, nil
+}
+
+// Upsert creates or replaces a category.
+func (a *UserCategoryAdapter) Upsert(ctx context.Context, category *domain.UserCategory) error {
+	query := `
+		INSERT INTO user_categories (user_id, key, name, description, color, icon, is_hidden, is_custom, sort_order)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, key) DO UPDATE SET
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			color = EXCLUDED.color,
+			icon = EXCLUDED.icon,
+			is_hidden = EXCLUDED.is_hidden,
+			is_custom = EXCLUDED.is_custom,
+			sort_order = EXCLUDED.sort_order,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+	err := a.db.QueryRowxContext(ctx, query,
+		category.UserID, category.Key, category.Name,
+		toNullableString(category.Description), toNullableString(category.Color), toNullableString(category.Icon),
+		category.IsHidden, category.IsCustom, category.SortOrder,
+	).Scan(&category.CreatedAt, &category.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert user category: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a category and optionally moves its emails to reassignTo.
+func (a *UserCategoryAdapter) Delete(ctx context.Context, userID uuid.UUID, key string, reassignTo domain.EmailCategory) (bool, error) {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM user_categories WHERE user_id = $1 AND key = $2`, userID, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete user category: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+
+	if reassignTo != "" {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE emails SET ai_category = $3, updated_at = NOW()
+			WHERE user_id = $1 AND ai_category = $2
+		`, userID, key, string(reassignTo)); err != nil {
+			return false, fmt.Errorf("failed to reassign category emails: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit category delete: %w", err)
+	}
+	return true, nil
+}
+
+var _ out.UserCategoryRepository = (*UserCategoryAdapter)(nil)
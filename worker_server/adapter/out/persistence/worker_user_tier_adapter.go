@@ -0,0 +1,38 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// UserTierAdapter implements out.UserTierRepository using PostgreSQL.
+type UserTierAdapter struct {
+	db *sqlx.DB
+}
+
+// NewUserTierAdapter creates a new UserTierAdapter.
+func NewUserTierAdapter(db *sqlx.DB) *UserTierAdapter {
+	return &UserTierAdapter{db: db}
+}
+
+// GetTier returns the user's tier. users 행이 없으면 free.
+func (a *UserTierAdapter) GetTier(ctx context.Context, userID uuid.UUID) (string, error) {
+	var tier string
+	err := a.db.GetContext(ctx, &tier, `SELECT tier FROM users WHERE id = $1`, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "free", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get user tier: %w", err)
+	}
+	return tier, nil
+}
+
+var _ out.UserTierRepository = (*UserTierAdapter)(nil)
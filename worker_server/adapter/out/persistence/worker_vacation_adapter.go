@@ -0,0 +1,147 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// VacationAdapter implements out.VacationRepository using PostgreSQL.
+type VacationAdapter struct {
+	db *sqlx.DB
+}
+
+// NewVacationAdapter creates a new VacationAdapter.
+func NewVacationAdapter(db *sqlx.DB) *VacationAdapter {
+	return &VacationAdapter{db: db}
+}
+
+// vacationRow represents the database row for a vacation responder.
+type vacationRow struct {
+	ConnectionID int64          `db:"connection_id"`
+	UserID       uuid.UUID      `db:"user_id"`
+	Enabled      bool           `db:"enabled"`
+	Mode         string         `db:"mode"`
+	Subject      sql.NullString `db:"subject"`
+	Body         sql.NullString `db:"body"`
+	IsHTML       bool           `db:"is_html"`
+	StartAt      sql.NullTime   `db:"start_at"`
+	EndAt        sql.NullTime   `db:"end_at"`
+	EnabledAt    sql.NullTime   `db:"enabled_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+}
+
+const vacationColumns = `connection_id, user_id, enabled, mode, subject, body, is_html, start_at, end_at, enabled_at, updated_at`
+
+func (r *vacationRow) toDomain() *domain.VacationResponder {
+	v := &domain.VacationResponder{
+		ConnectionID: r.ConnectionID,
+		UserID:       r.UserID,
+		Enabled:      r.Enabled,
+		Mode:         domain.VacationMode(r.Mode),
+		Subject:      r.Subject.String,
+		Body:         r.Body.String,
+		IsHTML:       r.IsHTML,
+		UpdatedAt:    r.UpdatedAt,
+	}
+	if r.StartAt.Valid {
+		v.StartAt = &r.StartAt.Time
+	}
+	if r.EndAt.Valid {
+		v.EndAt = &r.EndAt.Time
+	}
+	if r.EnabledAt.Valid {
+		v.EnabledAt = &r.EnabledAt.Time
+	}
+	return v
+}
+
+// Get retrieves the responder of a connection.
+func (a *VacationAdapter) Get(ctx context.Context, connectionID int64) (*domain.VacationResponder, error) {
+	query := `SELECT ` + vacationColumns + ` FROM vacation_responders WHERE connection_id = $1`
+
+	var row vacationRow
+	if err := a.db.GetContext(ctx, &row, query, connectionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get vacation responder: %w", err)
+	}
+	return row.toDomain(), nil
+}
+
+// Upsert saves the responder and returns enabled_at/updated_at into it.
+func (a *VacationAdapter) Upsert(ctx context.Context, v *domain.VacationResponder) error {
+	query := `
+		INSERT INTO vacation_responders (
+			connection_id, user_id, enabled, mode, subject, body, is_html, start_at, end_at, enabled_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, CASE WHEN $3 THEN NOW() END, NOW()
+		)
+		ON CONFLICT (connection_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			mode = EXCLUDED.mode,
+			subject = EXCLUDED.subject,
+			body = EXCLUDED.body,
+			is_html = EXCLUDED.is_html,
+			start_at = EXCLUDED.start_at,
+			end_at = EXCLUDED.end_at,
+			enabled_at = CASE
+				WHEN EXCLUDED.enabled AND NOT vacation_responders.enabled THEN NOW()
+				ELSE vacation_responders.enabled_at
+			END,
+			updated_at = NOW()
+		RETURNING enabled_at, updated_at
+	`
+
+	var enabledAt sql.NullTime
+	err := a.db.QueryRowxContext(ctx, query,
+		v.ConnectionID,
+		v.UserID,
+		v.Enabled,
+		v.Mode,
+		v.Subject,
+		v.Body,
+		v.IsHTML,
+		v.StartAt,
+		v.EndAt,
+	).Scan(&enabledAt, &v.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save vacation responder: %w", err)
+	}
+	v.EnabledAt = nil
+	if enabledAt.Valid {
+		v.EnabledAt = &enabledAt.Time
+	}
+	return nil
+}
+
+// TryRecordReply records a reply to sender unless one was sent after since.
+func (a *VacationAdapter) TryRecordReply(ctx context.Context, connectionID int64, sender string, since time.Time) (bool, error) {
+	query := `
+		INSERT INTO vacation_replies (connection_id, sender, replied_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (connection_id, sender) DO UPDATE SET replied_at = NOW()
+		WHERE vacation_replies.replied_at < $3
+		RETURNING replied_at
+	`
+
+	var repliedAt time.Time
+	if err := a.db.QueryRowxContext(ctx, query, connectionID, sender, since).Scan(&repliedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to record vacation reply: %w", err)
+	}
+	return true, nil
+}
+
+var _ out.VacationRepository = (*VacationAdapter)(nil)
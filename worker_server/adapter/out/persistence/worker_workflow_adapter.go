@@ -0,0 +1,286 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// WorkflowAdapter implements out.WorkflowRepository using PostgreSQL.
+type WorkflowAdapter struct {
+	db *sqlx.DB
+}
+
+// NewWorkflowAdapter creates a new WorkflowAdapter.
+func NewWorkflowAdapter(db *sqlx.DB) *WorkflowAdapter {
+	return &WorkflowAdapter{db: db}
+}
+
+// workflowStageRow represents the database row for a board stage.
+type workflowStageRow struct {
+	ID        int64          `db:"id"`
+	UserID    uuid.UUID      `db:"user_id"`
+	Name      string         `db:"name"`
+	Color     sql.NullString `db:"color"`
+	Status    string         `db:"workflow_status"`
+	Position  int            `db:"position"`
+	CreatedAt time.Time      `db:"created_at"`
+	UpdatedAt time.Time      `db:"updated_at"`
+}
+
+const workflowStageColumns = `id, user_id, name, color, workflow_status, position, created_at, updated_at`
+
+func (r *workflowStageRow) toDomain() *domain.WorkflowStage {
+	return &domain.WorkflowStage{
+		ID:        r.ID,
+		UserID:    r.UserID,
+		Name:      r.Name,
+		Color:     r.Color.String,
+		Status:    domain.WorkflowStatus(r.Status),
+		Position:  r.Position,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// workflowCardRow represents an email row projected for the board.
+type workflowCardRow struct {
+	EmailID      int64           `db:"id"`
+	ConnectionID int64           `db:"connection_id"`
+	Subject      sql.NullString  `db:"subject"`
+	FromEmail    sql.NullString  `db:"from_email"`
+	FromName     sql.NullString  `db:"from_name"`
+	Snippet      sql.NullString  `db:"snippet"`
+	EmailDate    sql.NullTime    `db:"email_date"`
+	IsRead       bool            `db:"is_read"`
+	AIPriority   sql.NullFloat64 `db:"ai_priority"`
+	Status       string          `db:"workflow_status"`
+	Position     sql.NullInt64   `db:"position"`
+	TotalCount   int             `db:"total_count"`
+}
+
+func (r *workflowCardRow) toDomain() *domain.WorkflowCard {
+	card := &domain.WorkflowCard{
+		EmailID:      r.EmailID,
+		ConnectionID: r.ConnectionID,
+		Subject:      r.Subject.String,
+		FromEmail:    r.FromEmail.String,
+		FromName:     r.FromName.String,
+		Snippet:      r.Snippet.String,
+		Date:         r.EmailDate.Time,
+		IsRead:       r.IsRead,
+		Status:       domain.WorkflowStatus(r.Status),
+	}
+	if r.AIPriority.Valid {
+		p := domain.Priority(r.AIPriority.Float64)
+		card.AIPriority = &p
+	}
+	if r.Position.Valid {
+		pos := int(r.Position.Int64)
+		card.Position = &pos
+	}
+	return card
+}
+
+// ListStages returns the user's stages in board order.
+func (a *WorkflowAdapter) ListStages(ctx context.Context, userID uuid.UUID) ([]*domain.WorkflowStage, error) {
+	query := `SELECT ` + workflowStageColumns + ` FROM workflow_stages WHERE user_id = $1 ORDER BY position, id`
+
+	var rows []workflowStageRow
+	if err := a.db.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list workflow stages: %w", err)
+	}
+
+	stages := make([]*domain.WorkflowStage, len(rows))
+	for i := range rows {
+		stages[i] = rows[i].toDomain()
+	}
+	return stages, nil
+}
+
+// CreateDefaultStages inserts stages only if the user has none yet.
+func (a *WorkflowAdapter) CreateDefaultStages(ctx context.Context, userID uuid.UUID, stages []*domain.WorkflowStage) error {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 동시에 보드를 처음 연 요청끼리 기본 단계를 중복 생성하지 않도록 사용자 단위로 잠근다
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "workflow_stages:"+userID.String()); err != nil {
+		return fmt.Errorf("failed to lock workflow stages: %w", err)
+	}
+
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM workflow_stages WHERE user_id = $1)`, userID); err != nil {
+		return fmt.Errorf("failed to check workflow stages: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	for _, stage := range stages {
+		if err := insertWorkflowStage(ctx, tx, stage); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// CreateStage inserts a stage.
+func (a *WorkflowAdapter) CreateStage(ctx context.Context, stage *domain.WorkflowStage) error {
+	return insertWorkflowStage(ctx, a.db, stage)
+}
+
+func insertWorkflowStage(ctx context.Context, q sqlx.QueryerContext, stage *domain.WorkflowStage) error {
+	query := `
+		INSERT INTO workflow_stages (user_id, name, color, workflow_status, position)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+	row := q.QueryRowxContext(ctx, query, stage.UserID, stage.Name, stage.Color, stage.Status, stage.Position)
+	if err := row.Scan(&stage.ID, &stage.CreatedAt, &stage.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create workflow stage: %w", err)
+	}
+	return nil
+}
+
+// UpdateStage updates name, color and status of a stage.
+func (a *WorkflowAdapter) UpdateStage(ctx context.Context, stage *domain.WorkflowStage) error {
+	query := `
+		UPDATE workflow_stages
+		SET name = $3, color = NULLIF($4, ''), workflow_status = $5, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING updated_at
+	`
+	err := a.db.GetContext(ctx, &stage.UpdatedAt, query, stage.ID, stage.UserID, stage.Name, stage.Color, stage.Status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update workflow stage: %w", err)
+	}
+	return nil
+}
+
+// DeleteStage deletes a stage. 배치된 카드는 같이 지워져 상태별 첫 단계로 돌아간다.
+func (a *WorkflowAdapter) DeleteStage(ctx context.Context, userID uuid.UUID, stageID int64) error {
+	result, err := a.db.ExecContext(ctx, `DELETE FROM workflow_stages WHERE id = $1 AND user_id = $2`, stageID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete workflow stage: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ReorderStages sets stage positions to the order of stageIDs.
+func (a *WorkflowAdapter) ReorderStages(ctx context.Context, userID uuid.UUID, stageIDs []int64) error {
+	query := `
+		UPDATE workflow_stages s
+		SET position = o.ord - 1, updated_at = NOW()
+		FROM unnest($2::bigint[]) WITH ORDINALITY AS o(id, ord)
+		WHERE s.id = o.id AND s.user_id = $1
+	`
+	if _, err := a.db.ExecContext(ctx, query, userID, pq.Array(stageIDs)); err != nil {
+		return fmt.Errorf("failed to reorder workflow stages: %w", err)
+	}
+	return nil
+}
+
+// ListCards returns the cards of a stage.
+// 카드의 단계 상태와 메일 상태가 어긋나면 (다른 API로 상태 변경) 미배치로 취급한다.
+func (a *WorkflowAdapter) ListCards(ctx context.Context, userID uuid.UUID, stage *domain.WorkflowStage, includeUnplaced bool, connectionID *int64, limit int) ([]*domain.WorkflowCard, int, error) {
+	args := []interface{}{userID, stage.Status, stage.ID, includeUnplaced, limit}
+	connFilter := ""
+	if connectionID != nil {
+		connFilter = "AND e.connection_id = $6"
+		args = append(args, *connectionID)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT e.id, e.connection_id, e.subject, e.from_email, e.from_name, e.snippet, e.email_date,
+			e.is_read, e.ai_priority, e.workflow_status,
+			CASE WHEN c.stage_id = $3 THEN c.position END AS position,
+			COUNT(*) OVER() AS total_count
+		FROM emails e
+		LEFT JOIN workflow_board_cards c ON c.email_id = e.id
+		LEFT JOIN workflow_stages cs ON cs.id = c.stage_id
+		WHERE e.user_id = $1
+			AND e.workflow_status = $2
+			AND e.folder NOT IN ('trash', 'spam')
+			AND e.deleted_at IS NULL
+			AND (
+				(c.stage_id = $3 AND cs.workflow_status = e.workflow_status)
+				OR ($4 AND (c.email_id IS NULL OR cs.workflow_status <> e.workflow_status))
+			)
+			%s
+		ORDER BY position ASC NULLS LAST, e.ai_priority DESC NULLS LAST, e.email_date DESC
+		LIMIT $5`, connFilter)
+
+	var rows []workflowCardRow
+	if err := a.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to list workflow cards: %w", err)
+	}
+
+	cards := make([]*domain.WorkflowCard, len(rows))
+	total := 0
+	for i := range rows {
+		cards[i] = rows[i].toDomain()
+		total = rows[i].TotalCount
+	}
+	return cards, total, nil
+}
+
+// PlaceCards moves emails into the stage in the given order.
+// 목록에 없는 기존 카드는 뒤로 밀어 순서를 유지한다.
+func (a *WorkflowAdapter) PlaceCards(ctx context.Context, userID uuid.UUID, stageID int64, emailIDs []int64) ([]int64, error) {
+	tx, err := a.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	shift := `
+		UPDATE workflow_board_cards
+		SET position = position + $3
+		WHERE stage_id = $1 AND user_id = $2 AND NOT (email_id = ANY($4))
+	`
+	if _, err := tx.ExecContext(ctx, shift, stageID, userID, len(emailIDs), pq.Array(emailIDs)); err != nil {
+		return nil, fmt.Errorf("failed to shift workflow cards: %w", err)
+	}
+
+	place := `
+		INSERT INTO workflow_board_cards (email_id, user_id, stage_id, position, updated_at)
+		SELECT e.id, $1, s.id, array_position($3::bigint[], e.id) - 1, NOW()
+		FROM emails e
+		JOIN workflow_stages s ON s.id = $2 AND s.user_id = $1
+		WHERE e.user_id = $1 AND e.id = ANY($3) AND e.deleted_at IS NULL
+		ON CONFLICT (email_id) DO UPDATE SET
+			stage_id = EXCLUDED.stage_id,
+			position = EXCLUDED.position,
+			updated_at = EXCLUDED.updated_at
+		RETURNING email_id
+	`
+	var placed []int64
+	if err := tx.SelectContext(ctx, &placed, place, userID, stageID, pq.Array(emailIDs)); err != nil {
+		return nil, fmt.Errorf("failed to place workflow cards: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return placed, nil
+}
+
+var _ out.WorkflowRepository = (*WorkflowAdapter)(nil)
@@ -45,6 +45,14 @@ var gmailMetadataHeaders = []string{
 	"X-Mailer",    // Sending client
 	"Feedback-ID", // Gmail bulk tracking
 
+	// Sender Authentication
+	"Authentication-Results", // RFC 8601 - SPF/DKIM/DMARC
+	"Received-SPF",           // RFC 7208
+	"Reply-To",
+
+	// Delivery Reports (bounce tracking)
+	"X-Failed-Recipients",
+
 	// ESP (Email Service Provider) Detection
 	"X-MC-User",           // Mailchimp
 	"X-SG-EID",            // SendGrid
@@ -129,32 +137,17 @@ func NewGmailAdapter(cfg *GmailConfig) *GmailAdapter {
 			gmail.GmailSendScope,
 			gmail.GmailModifyScope,
 			gmail.GmailLabelsScope,
+			gmail.GmailSettingsBasicScope, // vacation responder
 		},
 		Endpoint: google.Endpoint,
 	}
 
-	cbSettings := gobreaker.Settings{
-		Name:        "gmail-api",
-		MaxRequests: 3,                // Half-open 상태에서 허용할 요청 수
-		Interval:    60 * time.Second, // Closed 상태에서 카운터 리셋 간격
-		Timeout:     30 * time.Second, // Open 상태 유지 시간 (이후 Half-open)
-		ReadyToTrip: func(counts gobreaker.Counts) bool {
-			// 연속 5회 실패 또는 60% 이상 실패율 (최소 10회 요청)
-			failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
-			return counts.ConsecutiveFailures > 5 ||
-				(counts.Requests >= 10 && failureRatio >= 0.6)
-		},
-		OnStateChange: func(name string, from, to gobreaker.State) {
-			log.Printf("[CircuitBreaker] %s: state changed from %s to %s", name, from.String(), to.String())
-		},
-	}
-
 	return &GmailAdapter{
 		config:       config,
 		projectID:    cfg.ProjectID,
 		topicName:    fmt.Sprintf("projects/%s/topics/gmail-push", cfg.ProjectID),
 		tokenManager: NewTokenManager(config),
-		cb:           gobreaker.NewCircuitBreaker(cbSettings),
+		cb:           getGmailBreaker(),
 	}
 }
 
@@ -242,6 +235,7 @@ func (a *GmailAdapter) InitialSync(ctx context.Context, token *oauth2.Token, opt
 	}
 
 	var allMessages []out.ProviderMailMessage
+	var totalEstimate int64
 	pageToken := ""
 	if opts != nil && opts.PageToken != "" {
 		pageToken = opts.PageToken
@@ -256,6 +250,9 @@ func (a *GmailAdapter) InitialSync(ctx context.Context, token *oauth2.Token, opt
 		if err != nil {
 			return nil, a.wrapError(err, "failed to list messages")
 		}
+		if totalEstimate == 0 {
+			totalEstimate = resp.ResultSizeEstimate
+		}
 
 		// 병렬 처리로 메시지 가져오기 (첨부파일 ID 목록 전달)
 		messages := a.fetchMessagesParallelWithAttachmentInfo(ctx, svc, resp.Messages, attachmentMsgIDs)
@@ -278,6 +275,7 @@ func (a *GmailAdapter) InitialSync(ctx context.Context, token *oauth2.Token, opt
 				NextSyncState: historyID,
 				NextPageToken: pageToken,
 				HasMore:       true,
+				TotalEstimate: totalEstimate,
 			}, nil
 		}
 	}
@@ -292,6 +290,7 @@ func (a *GmailAdapter) InitialSync(ctx context.Context, token *oauth2.Token, opt
 		Messages:      allMessages,
 		NextSyncState: fmt.Sprintf("%d", profile.HistoryId),
 		HasMore:       false,
+		TotalEstimate: totalEstimate,
 	}, nil
 }
 
@@ -390,6 +389,20 @@ func (a *GmailAdapter) IncrementalSync(ctx context.Context, token *oauth2.Token,
 
 	var deletedIDs []string
 	seenIDs := make(map[string]bool)
+	deleted := make(map[string]bool)
+
+	// 라벨 변경: 메시지별 마지막 라벨 상태만 남긴다 (history는 오래된 순)
+	labelChanges := make(map[string][]string)
+	var labelOrder []string
+	recordLabels := func(msg *gmail.Message) {
+		if msg == nil {
+			return
+		}
+		if _, ok := labelChanges[msg.Id]; !ok {
+			labelOrder = append(labelOrder, msg.Id)
+		}
+		labelChanges[msg.Id] = msg.LabelIds
+	}
 
 	// 추가된 메시지 ID 수집 (중복 제거)
 	var addedMsgRefs []*gmail.Message
@@ -401,9 +414,37 @@ func (a *GmailAdapter) IncrementalSync(ctx context.Context, token *oauth2.Token,
 			}
 		}
 
-		for _, deleted := range history.MessagesDeleted {
-			deletedIDs = append(deletedIDs, deleted.Message.Id)
+		for _, d := range history.MessagesDeleted {
+			deletedIDs = append(deletedIDs, d.Message.Id)
+			deleted[d.Message.Id] = true
 		}
+
+		for _, l := range history.LabelsAdded {
+			recordLabels(l.Message)
+		}
+		for _, l := range history.LabelsRemoved {
+			recordLabels(l.Message)
+		}
+	}
+
+	// 새로 가져오는 메시지와 삭제된 메시지는 라벨 변경에서 제외
+	var changes []out.ProviderLabelChange
+	for _, id := range labelOrder {
+		if seenIDs[id] || deleted[id] {
+			continue
+		}
+		labels := labelChanges[id]
+		isRead, isStarred, folder := labelStatus(labels)
+		if folder == "" {
+			folder = "archive" // INBOX가 빠지면 보관처리
+		}
+		changes = append(changes, out.ProviderLabelChange{
+			ExternalID: id,
+			Labels:     labels,
+			IsRead:     isRead,
+			IsStarred:  isStarred,
+			Folder:     folder,
+		})
 	}
 
 	// 병렬 처리로 추가된 메시지 가져오기
@@ -412,6 +453,7 @@ func (a *GmailAdapter) IncrementalSync(ctx context.Context, token *oauth2.Token,
 	return &out.ProviderSyncResult{
 		Messages:      messages,
 		DeletedIDs:    deletedIDs,
+		LabelChanges:  changes,
 		NextSyncState: fmt.Sprintf("%d", resp.HistoryId),
 		HasMore:       false,
 	}, nil
@@ -430,7 +472,7 @@ func (a *GmailAdapter) Watch(ctx context.Context, token *oauth2.Token) (*out.Pro
 	}
 
 	var resp *gmail.WatchResponse
-	cbErr := a.executeWithCircuitBreaker(ctx, "Watch", func() error {
+	cbErr := a.executeWithRetry(ctx, "Watch", func() error {
 		var apiErr error
 		resp, apiErr = svc.Users.Watch("me", req).Context(ctx).Do()
 		return apiErr
@@ -471,7 +513,7 @@ func (a *GmailAdapter) GetMessage(ctx context.Context, token *oauth2.Token, exte
 	}
 
 	var msg *gmail.Message
-	cbErr := a.executeWithCircuitBreaker(ctx, "GetMessage", func() error {
+	cbErr := a.executeWithRetry(ctx, "GetMessage", func() error {
 		var apiErr error
 		msg, apiErr = svc.Users.Messages.Get("me", externalID).Format("full").Context(ctx).Do()
 		return apiErr
@@ -492,7 +534,7 @@ func (a *GmailAdapter) GetMessageBody(ctx context.Context, token *oauth2.Token,
 	}
 
 	var msg *gmail.Message
-	cbErr := a.executeWithCircuitBreaker(ctx, "GetMessageBody", func() error {
+	cbErr := a.executeWithRetry(ctx, "GetMessageBody", func() error {
 		var apiErr error
 		msg, apiErr = svc.Users.Messages.Get("me", externalID).Format("full").Context(ctx).Do()
 		return apiErr
@@ -512,6 +554,80 @@ func (a *GmailAdapter) GetMessageBody(ctx context.Context, token *oauth2.Token,
 	return body, nil
 }
 
+// GetMessageRaw retrieves the original RFC 822 source (format=raw).
+func (a *GmailAdapter) GetMessageRaw(ctx context.Context, token *oauth2.Token, externalID string, maxSize int64) ([]byte, error) {
+	svc, err := a.getService(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// raw 응답은 base64로 ~33% 커지므로 크기를 먼저 확인
+	if maxSize > 0 {
+		var meta *gmail.Message
+		cbErr := a.executeWithRetry(ctx, "GetMessageSize", func() error {
+			var apiErr error
+			meta, apiErr = svc.Users.Messages.Get("me", externalID).Format("minimal").Fields("sizeEstimate").Context(ctx).Do()
+			return apiErr
+		})
+		if cbErr != nil {
+			return nil, a.wrapError(cbErr, "failed to get message size")
+		}
+		if meta.SizeEstimate > maxSize {
+			return nil, out.NewProviderError("gmail", out.ProviderErrTooLarge,
+				fmt.Sprintf("message size %d exceeds limit %d", meta.SizeEstimate, maxSize), nil, false)
+		}
+	}
+
+	var msg *gmail.Message
+	cbErr := a.executeWithRetry(ctx, "GetMessageRaw", func() error {
+		var apiErr error
+		msg, apiErr = svc.Users.Messages.Get("me", externalID).Format("raw").Context(ctx).Do()
+		return apiErr
+	})
+	if cbErr != nil {
+		return nil, a.wrapError(cbErr, "failed to get raw message")
+	}
+
+	raw, err := base64.URLEncoding.DecodeString(msg.Raw)
+	if err != nil {
+		// 일부 응답은 padding 없이 내려옴
+		raw, err = base64.RawURLEncoding.DecodeString(msg.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode raw message: %w", err)
+		}
+	}
+
+	return raw, nil
+}
+
+// GetMessageHeaders returns all headers of a message.
+func (a *GmailAdapter) GetMessageHeaders(ctx context.Context, token *oauth2.Token, externalID string) ([]out.ProviderMessageHeader, error) {
+	svc, err := a.getService(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// metadata 포맷에서 MetadataHeaders를 지정하지 않으면 전체 헤더 반환
+	var msg *gmail.Message
+	cbErr := a.executeWithRetry(ctx, "GetMessageHeaders", func() error {
+		var apiErr error
+		msg, apiErr = svc.Users.Messages.Get("me", externalID).Format("metadata").Fields("payload/headers").Context(ctx).Do()
+		return apiErr
+	})
+	if cbErr != nil {
+		return nil, a.wrapError(cbErr, "failed to get message headers")
+	}
+
+	if msg.Payload == nil {
+		return []out.ProviderMessageHeader{}, nil
+	}
+	headers := make([]out.ProviderMessageHeader, 0, len(msg.Payload.Headers))
+	for _, h := range msg.Payload.Headers {
+		headers = append(headers, out.ProviderMessageHeader{Name: h.Name, Value: h.Value})
+	}
+	return headers, nil
+}
+
 // ListMessages lists messages with options.
 func (a *GmailAdapter) ListMessages(ctx context.Context, token *oauth2.Token, opts *out.ProviderListOptions) (*out.ProviderListResult, error) {
 	svc, err := a.getService(ctx, token)
@@ -810,14 +926,19 @@ func (a *GmailAdapter) Send(ctx context.Context, token *oauth2.Token, msg *out.P
 	}
 
 	raw := a.buildRawMessage(msg)
-	gmailMsg := &gmail.Message{
+	call := svc.Users.Messages.Send("me", &gmail.Message{
 		Raw: base64.URLEncoding.EncodeToString([]byte(raw)),
+	})
+	// 큰 메시지(대용량 첨부)는 raw JSON 한도를 넘으므로 media upload로 전송
+	if len(raw) > gmailSimpleSendLimit {
+		call = svc.Users.Messages.Send("me", &gmail.Message{}).
+			Media(strings.NewReader(raw), googleapi.ContentType("message/rfc822"))
 	}
 
 	var sent *gmail.Message
 	cbErr := a.executeWithCircuitBreaker(ctx, "Send", func() error {
 		var apiErr error
-		sent, apiErr = svc.Users.Messages.Send("me", gmailMsg).Context(ctx).Do()
+		sent, apiErr = call.Context(ctx).Do()
 		return apiErr
 	})
 	if cbErr != nil {
@@ -1111,6 +1232,50 @@ func (a *GmailAdapter) DeleteLabel(ctx context.Context, token *oauth2.Token, lab
 	return nil
 }
 
+// ListFolders lists user labels, which back custom folders on Gmail.
+func (a *GmailAdapter) ListFolders(ctx context.Context, token *oauth2.Token) ([]out.ProviderMailFolder, error) {
+	labels, err := a.ListLabels(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var folders []out.ProviderMailFolder
+	for _, l := range labels {
+		if l.Type != "user" {
+			continue
+		}
+		folders = append(folders, out.ProviderMailFolder{ExternalID: l.ExternalID, Name: l.Name})
+	}
+	return folders, nil
+}
+
+// CreateFolder creates a user label for a custom folder.
+func (a *GmailAdapter) CreateFolder(ctx context.Context, token *oauth2.Token, name string) (*out.ProviderMailFolder, error) {
+	label, err := a.CreateLabel(ctx, token, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &out.ProviderMailFolder{ExternalID: label.ExternalID, Name: label.Name}, nil
+}
+
+// RenameFolder renames the label of a custom folder.
+func (a *GmailAdapter) RenameFolder(ctx context.Context, token *oauth2.Token, folderID, name string) error {
+	svc, err := a.getService(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if _, err := svc.Users.Labels.Patch("me", folderID, &gmail.Label{Name: name}).Context(ctx).Do(); err != nil {
+		return a.wrapError(err, "failed to rename label")
+	}
+	return nil
+}
+
+// DeleteFolder deletes the label of a custom folder (메일은 삭제되지 않음).
+func (a *GmailAdapter) DeleteFolder(ctx context.Context, token *oauth2.Token, folderID string) error {
+	return a.DeleteLabel(ctx, token, folderID)
+}
+
 // AddLabel adds a label to a message.
 func (a *GmailAdapter) AddLabel(ctx context.Context, token *oauth2.Token, messageID, labelID string) error {
 	return a.modifyLabels(ctx, token, messageID, []string{labelID}, nil)
@@ -1133,7 +1298,7 @@ func (a *GmailAdapter) GetAttachment(ctx context.Context, token *oauth2.Token, m
 	}
 
 	var att *gmail.MessagePartBody
-	cbErr := a.executeWithCircuitBreaker(ctx, "GetAttachment", func() error {
+	cbErr := a.executeWithRetry(ctx, "GetAttachment", func() error {
 		var apiErr error
 		att, apiErr = svc.Users.Messages.Attachments.Get("me", messageID, attachmentID).Context(ctx).Do()
 		return apiErr
@@ -1173,6 +1338,7 @@ const (
 	gmailRecommendedChunk = 8 * 1024 * 1024  // 8MB recommended
 	gmailMinChunkSize     = 256 * 1024       // 256KB minimum (must be multiple)
 	gmailMaxChunkSize     = 50 * 1024 * 1024 // 50MB max per request
+	gmailSimpleSendLimit  = 5 * 1024 * 1024  // raw 메시지 JSON 전송 한도
 )
 
 // CreateUploadSession creates a resumable upload session for large attachments.
@@ -1324,6 +1490,103 @@ func (a *GmailAdapter) GetProfile(ctx context.Context, token *oauth2.Token) (*ou
 	}, nil
 }
 
+// =============================================================================
+// Send-as Aliases
+// =============================================================================
+
+// ListAliases lists Gmail send-as addresses.
+func (a *GmailAdapter) ListAliases(ctx context.Context, token *oauth2.Token) ([]out.ProviderAlias, error) {
+	svc, err := a.getService(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := svc.Users.Settings.SendAs.List("me").Context(ctx).Do()
+	if err != nil {
+		return nil, a.wrapError(err, "failed to list send-as aliases")
+	}
+
+	aliases := make([]out.ProviderAlias, 0, len(resp.SendAs))
+	for _, sa := range resp.SendAs {
+		aliases = append(aliases, out.ProviderAlias{
+			Email:       sa.SendAsEmail,
+			DisplayName: sa.DisplayName,
+			ReplyTo:     sa.ReplyToAddress,
+			IsPrimary:   sa.IsPrimary,
+			IsDefault:   sa.IsDefault,
+			// 기본 주소는 verificationStatus가 비어 있다
+			Verified: sa.IsPrimary || sa.VerificationStatus == "accepted",
+		})
+	}
+	return aliases, nil
+}
+
+// =============================================================================
+// Vacation Responder (gmail.settings.basic scope 필요)
+// =============================================================================
+
+// GetVacation retrieves the Gmail vacation responder settings.
+func (a *GmailAdapter) GetVacation(ctx context.Context, token *oauth2.Token) (*out.ProviderVacationSettings, error) {
+	svc, err := a.getService(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := svc.Users.Settings.GetVacation("me").Context(ctx).Do()
+	if err != nil {
+		return nil, a.wrapError(err, "failed to get vacation settings")
+	}
+
+	settings := &out.ProviderVacationSettings{
+		Enabled: v.EnableAutoReply,
+		Subject: v.ResponseSubject,
+		Body:    v.ResponseBodyPlainText,
+	}
+	if v.ResponseBodyHtml != "" {
+		settings.Body = v.ResponseBodyHtml
+		settings.IsHTML = true
+	}
+	if v.StartTime > 0 {
+		t := time.UnixMilli(v.StartTime)
+		settings.StartAt = &t
+	}
+	if v.EndTime > 0 {
+		t := time.UnixMilli(v.EndTime)
+		settings.EndAt = &t
+	}
+	return settings, nil
+}
+
+// SetVacation updates the Gmail vacation responder settings.
+func (a *GmailAdapter) SetVacation(ctx context.Context, token *oauth2.Token, settings *out.ProviderVacationSettings) error {
+	svc, err := a.getService(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	v := &gmail.VacationSettings{
+		EnableAutoReply: settings.Enabled,
+		ResponseSubject: settings.Subject,
+		ForceSendFields: []string{"EnableAutoReply"}, // false도 전송
+	}
+	if settings.IsHTML {
+		v.ResponseBodyHtml = settings.Body
+	} else {
+		v.ResponseBodyPlainText = settings.Body
+	}
+	if settings.StartAt != nil {
+		v.StartTime = settings.StartAt.UnixMilli()
+	}
+	if settings.EndAt != nil {
+		v.EndTime = settings.EndAt.UnixMilli()
+	}
+
+	if _, err := svc.Users.Settings.UpdateVacation("me", v).Context(ctx).Do(); err != nil {
+		return a.wrapError(err, "failed to update vacation settings")
+	}
+	return nil
+}
+
 // =============================================================================
 // Internal Helpers
 // =============================================================================
@@ -1417,6 +1680,31 @@ func (a *GmailAdapter) modifyLabels(ctx context.Context, token *oauth2.Token, me
 	return nil
 }
 
+// labelStatus derives read/starred state and the system folder from Gmail label IDs.
+// 시스템 폴더 라벨이 없으면 folder는 빈 문자열이다.
+func labelStatus(labels []string) (isRead, isStarred bool, folder string) {
+	isRead = true // UNREAD가 없으면 읽음
+	for _, label := range labels {
+		switch label {
+		case "UNREAD":
+			isRead = false
+		case "STARRED":
+			isStarred = true
+		case "INBOX":
+			folder = "inbox"
+		case "SENT":
+			folder = "sent"
+		case "DRAFT":
+			folder = "drafts"
+		case "TRASH":
+			folder = "trash"
+		case "SPAM":
+			folder = "spam"
+		}
+	}
+	return isRead, isStarred, folder
+}
+
 func (a *GmailAdapter) convertMessage(msg *gmail.Message) out.ProviderMailMessage {
 	result := out.ProviderMailMessage{
 		ExternalID:       msg.Id,
@@ -1481,6 +1769,29 @@ func (a *GmailAdapter) convertMessage(msg *gmail.Message) out.ProviderMailMessag
 				classHeaders.FeedbackID = h.Value
 				hasClassificationHeaders = true
 
+			// Sender Authentication (피싱/스푸핑 판단용)
+			case "Authentication-Results":
+				classHeaders.AuthenticationResults = append(classHeaders.AuthenticationResults, h.Value)
+				hasClassificationHeaders = true
+			case "Received-SPF":
+				if classHeaders.ReceivedSPF == "" {
+					classHeaders.ReceivedSPF = h.Value
+				}
+				hasClassificationHeaders = true
+			case "Reply-To":
+				classHeaders.ReplyTo = h.Value
+				hasClassificationHeaders = true
+
+			// Delivery Reports (bounce tracking)
+			case "Content-Type":
+				if strings.HasPrefix(strings.ToLower(h.Value), "multipart/report") {
+					classHeaders.ContentType = h.Value
+					hasClassificationHeaders = true
+				}
+			case "X-Failed-Recipients":
+				classHeaders.XFailedRecipients = h.Value
+				hasClassificationHeaders = true
+
 			// ESP Detection Headers
 			case "X-MC-User":
 				classHeaders.IsMailchimp = true
@@ -1574,34 +1885,11 @@ func (a *GmailAdapter) convertMessage(msg *gmail.Message) out.ProviderMailMessag
 	result.ReceivedAt = time.Unix(0, msg.InternalDate*int64(time.Millisecond))
 
 	// Parse status from labels
-	for _, label := range msg.LabelIds {
-		switch label {
-		case "UNREAD":
-			result.IsRead = false
-		case "STARRED":
-			result.IsStarred = true
-		case "INBOX":
-			result.Folder = "inbox"
-		case "SENT":
-			result.Folder = "sent"
-		case "DRAFT":
-			result.Folder = "drafts"
-		case "TRASH":
-			result.Folder = "trash"
-		case "SPAM":
-			result.Folder = "spam"
-		}
-	}
-
+	result.IsRead, result.IsStarred, result.Folder = labelStatus(msg.LabelIds)
 	if result.Folder == "" {
 		result.Folder = "inbox"
 	}
 
-	// Default to read if UNREAD not in labels
-	if !contains(msg.LabelIds, "UNREAD") {
-		result.IsRead = true
-	}
-
 	// Parse attachments
 	if msg.Payload != nil {
 		result.Attachments = a.extractAttachments(msg.Payload)
@@ -1748,6 +2036,9 @@ func (a *GmailAdapter) buildRawMessage(msg *out.ProviderOutgoingMessage) string
 	var buf strings.Builder
 
 	// Headers
+	if msg.From != nil {
+		buf.WriteString(fmt.Sprintf("From: %s\r\n", formatAddresses([]out.ProviderEmailAddress{*msg.From})))
+	}
 	if len(msg.To) > 0 {
 		buf.WriteString(fmt.Sprintf("To: %s\r\n", formatAddresses(msg.To)))
 	}
@@ -1765,6 +2056,9 @@ func (a *GmailAdapter) buildRawMessage(msg *out.ProviderOutgoingMessage) string
 	if msg.References != "" {
 		buf.WriteString(fmt.Sprintf("References: %s\r\n", msg.References))
 	}
+	for _, h := range msg.Headers {
+		buf.WriteString(fmt.Sprintf("%s: %s\r\n", h.Name, h.Value))
+	}
 
 	// Check if we have attachments
 	if len(msg.Attachments) > 0 {
@@ -1849,15 +2143,6 @@ func formatAddresses(addrs []out.ProviderEmailAddress) string {
 	return strings.Join(parts, ", ")
 }
 
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
-
 // =============================================================================
 // Interface Compliance
 // =============================================================================
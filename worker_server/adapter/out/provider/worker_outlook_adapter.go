@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
 	"net/url"
@@ -52,6 +53,7 @@ func NewOutlookAdapterWithConfig(cfg *OutlookConfig) *OutlookAdapter {
 		Scopes: []string{
 			"https://graph.microsoft.com/Mail.ReadWrite",
 			"https://graph.microsoft.com/Mail.Send",
+			"https://graph.microsoft.com/MailboxSettings.ReadWrite", // automatic replies
 			"https://graph.microsoft.com/User.Read",
 			"offline_access",
 		},
@@ -98,7 +100,7 @@ func (a *OutlookAdapter) RefreshToken(ctx context.Context, token *oauth2.Token)
 
 // ValidateToken validates the token.
 func (a *OutlookAdapter) ValidateToken(ctx context.Context, token *oauth2.Token) (bool, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 	resp, err := client.Get(graphBaseURL + "/me")
 	if err != nil {
 		return false, err
@@ -117,7 +119,7 @@ func (a *OutlookAdapter) ValidateToken(ctx context.Context, token *oauth2.Token)
 
 // InitialSync performs initial mail sync.
 func (a *OutlookAdapter) InitialSync(ctx context.Context, token *oauth2.Token, opts *out.ProviderSyncOptions) (*out.ProviderSyncResult, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	maxResults := 100
 	if opts != nil && opts.MaxResults > 0 {
@@ -128,6 +130,7 @@ func (a *OutlookAdapter) InitialSync(ctx context.Context, token *oauth2.Token, o
 	params.Set("$top", fmt.Sprintf("%d", maxResults))
 	params.Set("$orderby", "receivedDateTime desc")
 	params.Set("$select", "id,conversationId,subject,bodyPreview,from,toRecipients,ccRecipients,isRead,flag,categories,hasAttachments,receivedDateTime,body")
+	params.Set("$count", "true") // 진행률 표시용 전체 개수
 
 	// 날짜 기반 필터 적용 (StartDate가 있으면 receivedDateTime 필터 추가)
 	if opts != nil && opts.StartDate != nil {
@@ -136,17 +139,22 @@ func (a *OutlookAdapter) InitialSync(ctx context.Context, token *oauth2.Token, o
 	}
 
 	var messages []out.ProviderMailMessage
+	var totalEstimate int64
 	nextLink := graphBaseURL + "/me/messages?" + params.Encode()
 
 	for nextLink != "" {
 		var resp struct {
 			Value    []graphMessage `json:"value"`
 			NextLink string         `json:"@odata.nextLink"`
+			Count    int64          `json:"@odata.count"`
 		}
 
 		if err := a.doGet(client, nextLink, &resp); err != nil {
 			return nil, err
 		}
+		if totalEstimate == 0 {
+			totalEstimate = resp.Count
+		}
 
 		for _, msg := range resp.Value {
 			messages = append(messages, a.convertMessage(&msg))
@@ -157,6 +165,7 @@ func (a *OutlookAdapter) InitialSync(ctx context.Context, token *oauth2.Token, o
 				Messages:      messages[:maxResults],
 				NextSyncState: resp.NextLink,
 				HasMore:       true,
+				TotalEstimate: totalEstimate,
 			}, nil
 		}
 
@@ -167,8 +176,9 @@ func (a *OutlookAdapter) InitialSync(ctx context.Context, token *oauth2.Token, o
 	deltaResp, err := a.getDeltaLink(client)
 	if err != nil {
 		return &out.ProviderSyncResult{
-			Messages: messages,
-			HasMore:  false,
+			Messages:      messages,
+			HasMore:       false,
+			TotalEstimate: totalEstimate,
 		}, nil
 	}
 
@@ -176,12 +186,13 @@ func (a *OutlookAdapter) InitialSync(ctx context.Context, token *oauth2.Token, o
 		Messages:      messages,
 		NextSyncState: deltaResp,
 		HasMore:       false,
+		TotalEstimate: totalEstimate,
 	}, nil
 }
 
 // IncrementalSync performs incremental sync using delta.
 func (a *OutlookAdapter) IncrementalSync(ctx context.Context, token *oauth2.Token, syncState string) (*out.ProviderSyncResult, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	var messages []out.ProviderMailMessage
 	var deletedIDs []string
@@ -252,7 +263,7 @@ func (a *OutlookAdapter) StopWatch(ctx context.Context, token *oauth2.Token) err
 
 // GetMessage retrieves a single message.
 func (a *OutlookAdapter) GetMessage(ctx context.Context, token *oauth2.Token, externalID string) (*out.ProviderMailMessage, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	var msg graphMessage
 	if err := a.doGet(client, graphBaseURL+"/me/messages/"+externalID, &msg); err != nil {
@@ -265,7 +276,7 @@ func (a *OutlookAdapter) GetMessage(ctx context.Context, token *oauth2.Token, ex
 
 // GetMessageBody retrieves message body.
 func (a *OutlookAdapter) GetMessageBody(ctx context.Context, token *oauth2.Token, externalID string) (*out.ProviderMessageBody, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	var msg struct {
 		Body graphBody `json:"body"`
@@ -290,6 +301,68 @@ func (a *OutlookAdapter) GetMessageBody(ctx context.Context, token *oauth2.Token
 	return body, nil
 }
 
+// GetMessageRaw retrieves the original MIME source via the $value endpoint.
+func (a *OutlookAdapter) GetMessageRaw(ctx context.Context, token *oauth2.Token, externalID string, maxSize int64) ([]byte, error) {
+	client := a.client(ctx, token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, graphBaseURL+"/me/messages/"+externalID+"/$value", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, a.wrapError(err, "request failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, a.wrapHTTPError(resp.StatusCode, string(body))
+	}
+
+	reader := io.Reader(resp.Body)
+	if maxSize > 0 {
+		if resp.ContentLength > maxSize {
+			return nil, out.NewProviderError("outlook", out.ProviderErrTooLarge,
+				fmt.Sprintf("message size %d exceeds limit %d", resp.ContentLength, maxSize), nil, false)
+		}
+		reader = io.LimitReader(resp.Body, maxSize+1)
+	}
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, a.wrapError(err, "failed to read raw message")
+	}
+	if maxSize > 0 && int64(len(raw)) > maxSize {
+		return nil, out.NewProviderError("outlook", out.ProviderErrTooLarge,
+			fmt.Sprintf("message exceeds limit %d", maxSize), nil, false)
+	}
+
+	return raw, nil
+}
+
+// GetMessageHeaders returns the internet message headers of a message.
+func (a *OutlookAdapter) GetMessageHeaders(ctx context.Context, token *oauth2.Token, externalID string) ([]out.ProviderMessageHeader, error) {
+	client := a.client(ctx, token)
+
+	var resp struct {
+		InternetMessageHeaders []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"internetMessageHeaders"`
+	}
+	if err := a.doGet(client, graphBaseURL+"/me/messages/"+externalID+"?$select=internetMessageHeaders", &resp); err != nil {
+		return nil, err
+	}
+
+	headers := make([]out.ProviderMessageHeader, 0, len(resp.InternetMessageHeaders))
+	for _, h := range resp.InternetMessageHeaders {
+		headers = append(headers, out.ProviderMessageHeader{Name: h.Name, Value: h.Value})
+	}
+	return headers, nil
+}
+
 // listAttachments retrieves all attachments for a message.
 func (a *OutlookAdapter) listAttachments(ctx context.Context, client *http.Client, messageID string) ([]out.ProviderMailAttachment, error) {
 	var resp struct {
@@ -325,7 +398,7 @@ func (a *OutlookAdapter) listAttachments(ctx context.Context, client *http.Clien
 
 // ListMessages lists messages with options.
 func (a *OutlookAdapter) ListMessages(ctx context.Context, token *oauth2.Token, opts *out.ProviderListOptions) (*out.ProviderListResult, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	maxResults := 50
 	if opts != nil && opts.MaxResults > 0 {
@@ -336,10 +409,19 @@ func (a *OutlookAdapter) ListMessages(ctx context.Context, token *oauth2.Token,
 	params.Set("$top", fmt.Sprintf("%d", maxResults))
 	params.Set("$orderby", "receivedDateTime desc")
 
+	path := "/me/messages"
 	if opts != nil {
 		if opts.Query != "" {
 			params.Set("$search", fmt.Sprintf("\"%s\"", opts.Query))
 		}
+		if opts.Filter != "" {
+			// $filter에 없는 속성으로는 정렬할 수 없다 (InefficientFilter)
+			params.Set("$filter", opts.Filter)
+			params.Del("$orderby")
+		}
+		if opts.FolderID != "" {
+			path = "/me/mailFolders/" + url.PathEscape(opts.FolderID) + "/messages"
+		}
 		if opts.PageToken != "" {
 			params.Set("$skip", opts.PageToken)
 		}
@@ -351,7 +433,7 @@ func (a *OutlookAdapter) ListMessages(ctx context.Context, token *oauth2.Token,
 		Count    int64          `json:"@odata.count"`
 	}
 
-	if err := a.doGet(client, graphBaseURL+"/me/messages?"+params.Encode(), &resp); err != nil {
+	if err := a.doGet(client, graphBaseURL+path+"?"+params.Encode(), &resp); err != nil {
 		return nil, err
 	}
 
@@ -373,7 +455,7 @@ func (a *OutlookAdapter) ListMessages(ctx context.Context, token *oauth2.Token,
 
 // Send sends a new message.
 func (a *OutlookAdapter) Send(ctx context.Context, token *oauth2.Token, msg *out.ProviderOutgoingMessage) (*out.ProviderSendResult, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	body := struct {
 		Message         interface{} `json:"message"`
@@ -394,7 +476,7 @@ func (a *OutlookAdapter) Send(ctx context.Context, token *oauth2.Token, msg *out
 
 // Reply sends a reply.
 func (a *OutlookAdapter) Reply(ctx context.Context, token *oauth2.Token, replyToID string, msg *out.ProviderOutgoingMessage) (*out.ProviderSendResult, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	body := struct {
 		Message interface{} `json:"message"`
@@ -420,7 +502,7 @@ func (a *OutlookAdapter) Forward(ctx context.Context, token *oauth2.Token, forwa
 
 // CreateDraft creates a draft.
 func (a *OutlookAdapter) CreateDraft(ctx context.Context, token *oauth2.Token, msg *out.ProviderOutgoingMessage) (*out.ProviderDraftResult, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	graphMsg := a.buildGraphMessage(msg)
 
@@ -439,7 +521,7 @@ func (a *OutlookAdapter) CreateDraft(ctx context.Context, token *oauth2.Token, m
 
 // UpdateDraft updates a draft.
 func (a *OutlookAdapter) UpdateDraft(ctx context.Context, token *oauth2.Token, draftID string, msg *out.ProviderOutgoingMessage) (*out.ProviderDraftResult, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	graphMsg := a.buildGraphMessage(msg)
 
@@ -459,7 +541,7 @@ func (a *OutlookAdapter) DeleteDraft(ctx context.Context, token *oauth2.Token, d
 
 // SendDraft sends a draft.
 func (a *OutlookAdapter) SendDraft(ctx context.Context, token *oauth2.Token, draftID string) (*out.ProviderSendResult, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	if err := a.doPost(client, graphBaseURL+"/me/messages/"+draftID+"/send", nil, nil); err != nil {
 		return nil, err
@@ -477,19 +559,19 @@ func (a *OutlookAdapter) SendDraft(ctx context.Context, token *oauth2.Token, dra
 
 // MarkAsRead marks message as read.
 func (a *OutlookAdapter) MarkAsRead(ctx context.Context, token *oauth2.Token, externalID string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 	return a.doPatch(client, graphBaseURL+"/me/messages/"+externalID, map[string]bool{"isRead": true})
 }
 
 // MarkAsUnread marks message as unread.
 func (a *OutlookAdapter) MarkAsUnread(ctx context.Context, token *oauth2.Token, externalID string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 	return a.doPatch(client, graphBaseURL+"/me/messages/"+externalID, map[string]bool{"isRead": false})
 }
 
 // Star flags a message.
 func (a *OutlookAdapter) Star(ctx context.Context, token *oauth2.Token, externalID string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 	return a.doPatch(client, graphBaseURL+"/me/messages/"+externalID, map[string]interface{}{
 		"flag": map[string]string{"flagStatus": "flagged"},
 	})
@@ -497,7 +579,7 @@ func (a *OutlookAdapter) Star(ctx context.Context, token *oauth2.Token, external
 
 // Unstar removes flag from a message.
 func (a *OutlookAdapter) Unstar(ctx context.Context, token *oauth2.Token, externalID string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 	return a.doPatch(client, graphBaseURL+"/me/messages/"+externalID, map[string]interface{}{
 		"flag": map[string]string{"flagStatus": "notFlagged"},
 	})
@@ -505,7 +587,7 @@ func (a *OutlookAdapter) Unstar(ctx context.Context, token *oauth2.Token, extern
 
 // Archive archives a message.
 func (a *OutlookAdapter) Archive(ctx context.Context, token *oauth2.Token, externalID string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 	return a.doPost(client, graphBaseURL+"/me/messages/"+externalID+"/move", map[string]string{
 		"destinationId": "archive",
 	}, nil)
@@ -513,7 +595,7 @@ func (a *OutlookAdapter) Archive(ctx context.Context, token *oauth2.Token, exter
 
 // Trash moves message to trash.
 func (a *OutlookAdapter) Trash(ctx context.Context, token *oauth2.Token, externalID string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 	return a.doPost(client, graphBaseURL+"/me/messages/"+externalID+"/move", map[string]string{
 		"destinationId": "deleteditems",
 	}, nil)
@@ -521,7 +603,7 @@ func (a *OutlookAdapter) Trash(ctx context.Context, token *oauth2.Token, externa
 
 // Restore restores message from trash.
 func (a *OutlookAdapter) Restore(ctx context.Context, token *oauth2.Token, externalID string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 	return a.doPost(client, graphBaseURL+"/me/messages/"+externalID+"/move", map[string]string{
 		"destinationId": "inbox",
 	}, nil)
@@ -529,7 +611,7 @@ func (a *OutlookAdapter) Restore(ctx context.Context, token *oauth2.Token, exter
 
 // Delete permanently deletes a message.
 func (a *OutlookAdapter) Delete(ctx context.Context, token *oauth2.Token, externalID string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 	return a.doDelete(client, graphBaseURL+"/me/messages/"+externalID)
 }
 
@@ -557,7 +639,7 @@ func (a *OutlookAdapter) BatchModify(ctx context.Context, token *oauth2.Token, r
 
 // ListLabels lists all categories.
 func (a *OutlookAdapter) ListLabels(ctx context.Context, token *oauth2.Token) ([]out.ProviderMailLabel, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	var resp struct {
 		Value []struct {
@@ -585,7 +667,7 @@ func (a *OutlookAdapter) ListLabels(ctx context.Context, token *oauth2.Token) ([
 
 // CreateLabel creates a new category.
 func (a *OutlookAdapter) CreateLabel(ctx context.Context, token *oauth2.Token, name string, color *string) (*out.ProviderMailLabel, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	body := map[string]string{
 		"displayName": name,
@@ -612,13 +694,84 @@ func (a *OutlookAdapter) CreateLabel(ctx context.Context, token *oauth2.Token, n
 
 // DeleteLabel deletes a category.
 func (a *OutlookAdapter) DeleteLabel(ctx context.Context, token *oauth2.Token, labelID string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 	return a.doDelete(client, graphBaseURL+"/me/outlook/masterCategories/"+labelID)
 }
 
+// outlookSystemFolders are the well-known folders excluded from custom folders.
+var outlookSystemFolders = []string{
+	"inbox", "sentitems", "drafts", "deleteditems", "junkemail",
+	"archive", "outbox", "conversationhistory",
+}
+
+// ListFolders lists the custom top-level mail folders.
+// v1.0 mailFolder에는 wellKnownName이 없어 시스템 폴더 ID를 따로 조회해 제외한다.
+func (a *OutlookAdapter) ListFolders(ctx context.Context, token *oauth2.Token) ([]out.ProviderMailFolder, error) {
+	client := a.client(ctx, token)
+
+	system := make(map[string]bool)
+	for _, name := range outlookSystemFolders {
+		var folder struct {
+			ID string `json:"id"`
+		}
+		if err := a.doGet(client, graphBaseURL+"/me/mailFolders/"+name+"?$select=id", &folder); err == nil {
+			system[folder.ID] = true
+		}
+	}
+
+	var folders []out.ProviderMailFolder
+	next := graphBaseURL + "/me/mailFolders?$select=id,displayName&$top=100"
+	for next != "" {
+		var resp struct {
+			Value []struct {
+				ID          string `json:"id"`
+				DisplayName string `json:"displayName"`
+			} `json:"value"`
+			NextLink string `json:"@odata.nextLink"`
+		}
+		if err := a.doGet(client, next, &resp); err != nil {
+			return nil, err
+		}
+		for _, f := range resp.Value {
+			if system[f.ID] {
+				continue
+			}
+			folders = append(folders, out.ProviderMailFolder{ExternalID: f.ID, Name: f.DisplayName})
+		}
+		next = resp.NextLink
+	}
+	return folders, nil
+}
+
+// CreateFolder creates a top-level mail folder.
+func (a *OutlookAdapter) CreateFolder(ctx context.Context, token *oauth2.Token, name string) (*out.ProviderMailFolder, error) {
+	client := a.client(ctx, token)
+
+	var resp struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"displayName"`
+	}
+	if err := a.doPost(client, graphBaseURL+"/me/mailFolders", map[string]string{"displayName": name}, &resp); err != nil {
+		return nil, err
+	}
+	return &out.ProviderMailFolder{ExternalID: resp.ID, Name: resp.DisplayName}, nil
+}
+
+// RenameFolder renames a mail folder.
+func (a *OutlookAdapter) RenameFolder(ctx context.Context, token *oauth2.Token, folderID, name string) error {
+	client := a.client(ctx, token)
+	return a.doPatch(client, graphBaseURL+"/me/mailFolders/"+folderID, map[string]string{"displayName": name})
+}
+
+// DeleteFolder deletes a mail folder (Graph는 폴더의 메일을 지운 편지함으로 옮긴다).
+func (a *OutlookAdapter) DeleteFolder(ctx context.Context, token *oauth2.Token, folderID string) error {
+	client := a.client(ctx, token)
+	return a.doDelete(client, graphBaseURL+"/me/mailFolders/"+folderID)
+}
+
 // AddLabel adds a category to a message.
 func (a *OutlookAdapter) AddLabel(ctx context.Context, token *oauth2.Token, messageID, labelID string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	// Get current categories
 	var msg struct {
@@ -641,7 +794,7 @@ func (a *OutlookAdapter) AddLabel(ctx context.Context, token *oauth2.Token, mess
 
 // RemoveLabel removes a category from a message.
 func (a *OutlookAdapter) RemoveLabel(ctx context.Context, token *oauth2.Token, messageID, labelID string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	var msg struct {
 		Categories []string `json:"categories"`
@@ -667,7 +820,7 @@ func (a *OutlookAdapter) RemoveLabel(ctx context.Context, token *oauth2.Token, m
 
 // GetAttachment retrieves an attachment.
 func (a *OutlookAdapter) GetAttachment(ctx context.Context, token *oauth2.Token, messageID, attachmentID string) ([]byte, string, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	var resp struct {
 		ContentBytes string `json:"contentBytes"`
@@ -712,7 +865,7 @@ const (
 // Outlook uses createUploadSession API for files 3MB ~ 150MB.
 // Returns uploadUrl that frontend can use to directly upload chunks to Microsoft Graph.
 func (a *OutlookAdapter) CreateUploadSession(ctx context.Context, token *oauth2.Token, messageID string, req *out.UploadSessionRequest) (*out.UploadSessionResponse, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	// Request body for createUploadSession
 	body := map[string]interface{}{
@@ -766,7 +919,7 @@ func (a *OutlookAdapter) CreateUploadSession(ctx context.Context, token *oauth2.
 // GetUploadSessionStatus checks the status of an upload session.
 // For Outlook, we GET the upload URL to check status.
 func (a *OutlookAdapter) GetUploadSessionStatus(ctx context.Context, token *oauth2.Token, uploadURL string) (*out.UploadSessionStatus, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	// Note: Outlook upload URLs are pre-authenticated, don't need Authorization header
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", uploadURL, nil)
@@ -819,7 +972,7 @@ func (a *OutlookAdapter) GetUploadSessionStatus(ctx context.Context, token *oaut
 // CancelUploadSession cancels an upload session.
 // For Outlook, we DELETE the upload URL.
 func (a *OutlookAdapter) CancelUploadSession(ctx context.Context, token *oauth2.Token, uploadURL string) error {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", uploadURL, nil)
 	if err != nil {
@@ -840,13 +993,96 @@ func (a *OutlookAdapter) CancelUploadSession(ctx context.Context, token *oauth2.
 	return nil
 }
 
+// UploadChunk uploads one chunk to an upload session URL (proxy mode).
+// Upload URLs are pre-authenticated - Graph rejects requests carrying an Authorization header.
+func (a *OutlookAdapter) UploadChunk(ctx context.Context, uploadURL string, offset, total int64, data []byte) (*out.UploadSessionStatus, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "PUT", uploadURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chunk request: %w", err)
+	}
+	httpReq.ContentLength = int64(len(data))
+	httpReq.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+int64(len(data))-1, total))
+
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, a.wrapError(err, "chunk upload failed")
+	}
+	defer resp.Body.Close()
+
+	status := &out.UploadSessionStatus{
+		SessionID:  uploadURL,
+		TotalBytes: total,
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted:
+		var result struct {
+			NextExpectedRanges []string `json:"nextExpectedRanges"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err == nil && len(result.NextExpectedRanges) > 0 {
+			fmt.Sscanf(result.NextExpectedRanges[0], "%d-", &status.NextRangeStart)
+			status.BytesUploaded = status.NextRangeStart
+		} else {
+			status.BytesUploaded = offset + int64(len(data))
+			status.NextRangeStart = status.BytesUploaded
+		}
+	case http.StatusCreated:
+		// Upload complete - 첨부 ID는 Location 헤더의 Attachments('<id>')에 있다
+		status.IsComplete = true
+		status.BytesUploaded = total
+		if loc := resp.Header.Get("Location"); loc != "" {
+			if i := strings.LastIndex(loc, "Attachments('"); i >= 0 {
+				status.AttachmentID = strings.TrimSuffix(loc[i+len("Attachments('"):], "')")
+			}
+		}
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, a.wrapHTTPError(resp.StatusCode, string(body))
+	}
+
+	return status, nil
+}
+
+// ListMessageAttachments lists attachment metadata of a message (e.g. a draft after upload sessions).
+func (a *OutlookAdapter) ListMessageAttachments(ctx context.Context, token *oauth2.Token, messageID string) ([]out.ProviderMailAttachment, error) {
+	client := a.client(ctx, token)
+
+	var resp struct {
+		Value []struct {
+			ID          string `json:"id"`
+			Name        string `json:"name"`
+			ContentType string `json:"contentType"`
+			Size        int64  `json:"size"`
+			IsInline    bool   `json:"isInline"`
+		} `json:"value"`
+	}
+
+	endpoint := fmt.Sprintf("%s/me/messages/%s/attachments?$select=id,name,contentType,size,isInline", graphBaseURL, messageID)
+	if err := a.doGet(client, endpoint, &resp); err != nil {
+		return nil, err
+	}
+
+	attachments := make([]out.ProviderMailAttachment, len(resp.Value))
+	for i, att := range resp.Value {
+		attachments[i] = out.ProviderMailAttachment{
+			ID:       att.ID,
+			Filename: att.Name,
+			MimeType: att.ContentType,
+			Size:     att.Size,
+			IsInline: att.IsInline,
+		}
+	}
+	return attachments, nil
+}
+
 // =============================================================================
 // Profile
 // =============================================================================
 
 // GetProfile retrieves user profile.
 func (a *OutlookAdapter) GetProfile(ctx context.Context, token *oauth2.Token) (*out.ProviderProfile, error) {
-	client := a.config.Client(ctx, token)
+	client := a.client(ctx, token)
 
 	var user struct {
 		ID   string `json:"id"`
@@ -864,6 +1100,145 @@ func (a *OutlookAdapter) GetProfile(ctx context.Context, token *oauth2.Token) (*
 	}, nil
 }
 
+// =============================================================================
+// Send-as Aliases
+// =============================================================================
+
+// ListAliases lists the mailbox proxy addresses ("SMTP:" = primary, "smtp:" = alias).
+func (a *OutlookAdapter) ListAliases(ctx context.Context, token *oauth2.Token) ([]out.ProviderAlias, error) {
+	client := a.client(ctx, token)
+
+	var user struct {
+		Mail           string   `json:"mail"`
+		Name           string   `json:"displayName"`
+		ProxyAddresses []string `json:"proxyAddresses"`
+	}
+	if err := a.doGet(client, graphBaseURL+"/me?$select=mail,displayName,proxyAddresses", &user); err != nil {
+		return nil, err
+	}
+
+	var aliases []out.ProviderAlias
+	seen := make(map[string]bool)
+	add := func(email string, primary bool) {
+		key := strings.ToLower(email)
+		if email == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		aliases = append(aliases, out.ProviderAlias{
+			Email:       email,
+			DisplayName: user.Name,
+			IsPrimary:   primary,
+			IsDefault:   primary,
+			Verified:    true, // 프록시 주소는 Exchange에서 이미 소유 확인됨
+		})
+	}
+
+	add(user.Mail, true)
+	for _, addr := range user.ProxyAddresses {
+		prefix, email, ok := strings.Cut(addr, ":")
+		if !ok || !strings.EqualFold(prefix, "smtp") {
+			continue
+		}
+		add(email, prefix == "SMTP" && user.Mail == "")
+	}
+	return aliases, nil
+}
+
+// =============================================================================
+// Vacation Responder (MailboxSettings.ReadWrite scope 필요)
+// =============================================================================
+
+// graphAutomaticReplies is the automaticRepliesSetting of mailboxSettings.
+type graphAutomaticReplies struct {
+	Status                 string             `json:"status"` // disabled, alwaysEnabled, scheduled
+	ExternalAudience       string             `json:"externalAudience,omitempty"`
+	InternalReplyMessage   string             `json:"internalReplyMessage"`
+	ExternalReplyMessage   string             `json:"externalReplyMessage"`
+	ScheduledStartDateTime *graphDateTimeZone `json:"scheduledStartDateTime,omitempty"`
+	ScheduledEndDateTime   *graphDateTimeZone `json:"scheduledEndDateTime,omitempty"`
+}
+
+type graphDateTimeZone struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+const graphDateTimeLayout = "2006-01-02T15:04:05.0000000"
+
+// GetVacation retrieves Outlook automatic replies.
+func (a *OutlookAdapter) GetVacation(ctx context.Context, token *oauth2.Token) (*out.ProviderVacationSettings, error) {
+	client := a.client(ctx, token)
+
+	var replies graphAutomaticReplies
+	if err := a.doGet(client, graphBaseURL+"/me/mailboxSettings/automaticRepliesSetting", &replies); err != nil {
+		return nil, err
+	}
+
+	settings := &out.ProviderVacationSettings{
+		Enabled: replies.Status != "" && replies.Status != "disabled",
+		Body:    replies.ExternalReplyMessage,
+		IsHTML:  true,
+	}
+	if replies.Status == "scheduled" {
+		settings.StartAt = parseGraphDateTime(replies.ScheduledStartDateTime)
+		settings.EndAt = parseGraphDateTime(replies.ScheduledEndDateTime)
+	}
+	return settings, nil
+}
+
+// SetVacation updates Outlook automatic replies (내부/외부 수신자에게 같은 메시지).
+func (a *OutlookAdapter) SetVacation(ctx context.Context, token *oauth2.Token, settings *out.ProviderVacationSettings) error {
+	client := a.client(ctx, token)
+
+	body := settings.Body
+	if !settings.IsHTML {
+		body = strings.ReplaceAll(html.EscapeString(body), "\n", "<br>")
+	}
+
+	replies := graphAutomaticReplies{
+		Status:               "disabled",
+		ExternalAudience:     "all",
+		InternalReplyMessage: body,
+		ExternalReplyMessage: body,
+	}
+	if settings.Enabled {
+		replies.Status = "alwaysEnabled"
+		if settings.StartAt != nil || settings.EndAt != nil {
+			// scheduled는 시작/종료가 모두 필요
+			start, end := time.Now(), time.Now().AddDate(10, 0, 0)
+			if settings.StartAt != nil {
+				start = *settings.StartAt
+			}
+			if settings.EndAt != nil {
+				end = *settings.EndAt
+			}
+			replies.Status = "scheduled"
+			replies.ScheduledStartDateTime = &graphDateTimeZone{DateTime: start.UTC().Format(graphDateTimeLayout), TimeZone: "UTC"}
+			replies.ScheduledEndDateTime = &graphDateTimeZone{DateTime: end.UTC().Format(graphDateTimeLayout), TimeZone: "UTC"}
+		}
+	}
+
+	return a.doPatch(client, graphBaseURL+"/me/mailboxSettings", map[string]interface{}{
+		"automaticRepliesSetting": replies,
+	})
+}
+
+func parseGraphDateTime(v *graphDateTimeZone) *time.Time {
+	if v == nil || v.DateTime == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(v.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	t, err := time.ParseInLocation(graphDateTimeLayout, v.DateTime, loc)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
 // =============================================================================
 // Internal Helpers
 // =============================================================================
@@ -1045,6 +1420,16 @@ func (a *OutlookAdapter) buildGraphMessage(msg *out.ProviderOutgoingMessage) map
 		},
 	}
 
+	if msg.From != nil {
+		// Send As 권한이 있는 주소만 허용된다 (없으면 Graph가 403 반환)
+		result["from"] = map[string]interface{}{
+			"emailAddress": map[string]string{
+				"name":    msg.From.Name,
+				"address": msg.From.Email,
+			},
+		}
+	}
+
 	if len(msg.To) > 0 {
 		toRecipients := make([]map[string]interface{}, len(msg.To))
 		for i, addr := range msg.To {
@@ -125,6 +125,7 @@ func (f *Factory) getGmailOAuthConfig() *oauth2.Config {
 			gmail.GmailSendScope,
 			gmail.GmailModifyScope,
 			gmail.GmailLabelsScope,
+			gmail.GmailSettingsBasicScope, // vacation responder
 		},
 		Endpoint: google.Endpoint,
 	}
@@ -144,6 +145,7 @@ func (f *Factory) getOutlookOAuthConfig() *oauth2.Config {
 		Scopes: []string{
 			"https://graph.microsoft.com/Mail.ReadWrite",
 			"https://graph.microsoft.com/Mail.Send",
+			"https://graph.microsoft.com/MailboxSettings.ReadWrite", // automatic replies
 			"https://graph.microsoft.com/User.Read",
 			"offline_access",
 		},
@@ -233,6 +235,16 @@ func (p *tokenRefreshingProvider) GetMessageBody(ctx context.Context, token *oau
 	return p.provider.GetMessageBody(ctx, token, externalID)
 }
 
+// GetMessageRaw retrieves the original RFC 822 source.
+func (p *tokenRefreshingProvider) GetMessageRaw(ctx context.Context, token *oauth2.Token, externalID string, maxSize int64) ([]byte, error) {
+	return p.provider.GetMessageRaw(ctx, token, externalID, maxSize)
+}
+
+// GetMessageHeaders retrieves all message headers.
+func (p *tokenRefreshingProvider) GetMessageHeaders(ctx context.Context, token *oauth2.Token, externalID string) ([]out.ProviderMessageHeader, error) {
+	return p.provider.GetMessageHeaders(ctx, token, externalID)
+}
+
 // ListMessages lists messages.
 func (p *tokenRefreshingProvider) ListMessages(ctx context.Context, token *oauth2.Token, opts *out.ProviderListOptions) (*out.ProviderListResult, error) {
 	return p.provider.ListMessages(ctx, token, opts)
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	"worker_server/pkg/resilience"
+	"worker_server/pkg/retry"
+
+	"github.com/sony/gobreaker"
+	"golang.org/x/oauth2"
+)
+
+// 어댑터는 ProviderFactory에서 요청마다 생성되므로 breaker는 Provider별로 프로세스에서 하나를 공유한다.
+var (
+	gmailBreaker       *gobreaker.CircuitBreaker
+	gmailBreakerOnce   sync.Once
+	outlookBreaker     *gobreaker.TwoStepCircuitBreaker
+	outlookBreakerOnce sync.Once
+)
+
+// getGmailBreaker returns the shared Gmail API breaker (클라이언트 에러는 nonCircuitError로 감싸 세지 않음).
+func getGmailBreaker() *gobreaker.CircuitBreaker {
+	gmailBreakerOnce.Do(func() {
+		gmailBreaker = resilience.NewBreaker("gmail-api", func(err error) bool {
+			var nce *nonCircuitError
+			return !errors.As(err, &nce)
+		})
+	})
+	return gmailBreaker
+}
+
+func getOutlookBreaker() *gobreaker.TwoStepCircuitBreaker {
+	outlookBreakerOnce.Do(func() {
+		outlookBreaker = resilience.NewTwoStepBreaker("outlook-api")
+	})
+	return outlookBreaker
+}
+
+// providerRetryPolicy retries idempotent provider API calls on transient failures.
+var providerRetryPolicy = retry.DefaultPolicy()
+
+// client returns an authorized Graph API client protected by the Outlook circuit breaker.
+func (a *OutlookAdapter) client(ctx context.Context, token *oauth2.Token) *http.Client {
+	client := a.config.Client(ctx, token)
+	client.Transport = &breakerTransport{base: client.Transport, cb: getOutlookBreaker()}
+	return client
+}
+
+// breakerTransport fails fast while the Graph API is down and retries transient failures.
+type breakerTransport struct {
+	base http.RoundTripper
+	cb   *gobreaker.TwoStepCircuitBreaker
+}
+
+// RoundTrip retries idempotent requests on transient failures; the last response is returned as is.
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isReplayable(req) {
+		return t.roundTrip(req)
+	}
+
+	var resp *http.Response
+	attempt := 0
+	err := retry.Do(req.Context(), providerRetryPolicy, func(ctx context.Context) error {
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			resp = nil
+		}
+
+		r := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return retry.Permanent(err)
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+		attempt++
+
+		var err error
+		resp, err = t.roundTrip(r)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 400 {
+			// 재시도 여부는 ProviderError 분류를 따른다 (429, 5xx만 재시도)
+			return (&OutlookAdapter{}).wrapHTTPError(resp.StatusCode, resp.Status)
+		}
+		return nil
+	})
+	if resp != nil {
+		return resp, nil
+	}
+	return nil, err
+}
+
+func (t *breakerTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	done, err := t.cb.Allow()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	done(!isOutlookOutage(resp, err))
+	return resp, err
+}
+
+// isReplayable reports whether req is idempotent and its body can be sent again.
+// POST(발송, 이동)는 중복 실행될 수 있어 재시도하지 않는다.
+func isReplayable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	default:
+		return false
+	}
+}
+
+// isOutlookOutage reports whether a Graph API round trip indicates a service outage.
+// 429는 메일함 단위 throttling이고, 토큰 갱신 거부는 사용자 문제라 세지 않는다.
+func isOutlookOutage(resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return false
+		}
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			return retrieveErr.Response != nil && retrieveErr.Response.StatusCode >= 500
+		}
+		return true
+	}
+	return resp.StatusCode >= 500
+}
+
+// executeWithRetry runs an idempotent Gmail API call through the circuit breaker with jittered retries.
+// Send처럼 중복 실행되면 안 되는 호출은 executeWithCircuitBreaker를 직접 쓴다.
+func (a *GmailAdapter) executeWithRetry(ctx context.Context, operation string, fn func() error) error {
+	policy := providerRetryPolicy
+	policy.Retriable = func(err error) bool {
+		return retry.IsRetriable(a.wrapError(err, operation))
+	}
+	return retry.Do(ctx, policy, func(ctx context.Context) error {
+		return a.executeWithCircuitBreaker(ctx, operation, fn)
+	})
+}
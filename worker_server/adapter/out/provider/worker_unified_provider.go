@@ -4,7 +4,10 @@ package provider
 import (
 	"context"
 	"encoding/base64"
+	"math"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -46,6 +49,38 @@ type UnifiedListOptions struct {
 	Cursor *UnifiedCursor
 	Folder *string
 	Search *string
+
+	// AI 필터 - DB에만 존재하므로 지정 시 Provider 보충을 건너뜀
+	Categories     []string
+	MinPriority    *float64 // 0.0 ~ 1.0
+	WorkflowStatus string
+
+	Rank UnifiedRank
+
+	// KeepDuplicates disables collapsing duplicate copies into Duplicates.
+	KeepDuplicates bool
+}
+
+// UnifiedRank selects how a unified page is ordered.
+type UnifiedRank string
+
+const (
+	UnifiedRankTime    UnifiedRank = "time"    // received_at DESC (default)
+	UnifiedRankBlended UnifiedRank = "blended" // 최신성 + AI 우선순위
+)
+
+// Blended ranking weights.
+// 페이지 경계는 시간순으로 자르고, 페이지 안에서만 재정렬하므로 커서가 순위 변화에 영향받지 않는다.
+const (
+	blendedPriorityWeight = 0.6
+	blendedRecencyWeight  = 0.4
+	blendedHalfLife       = 24 * time.Hour
+	unclassifiedPriority  = 0.5 // Provider에서 직접 가져온 미분류 메일
+)
+
+// hasAIFilters reports whether the options use filters only the DB can answer.
+func (o *UnifiedListOptions) hasAIFilters() bool {
+	return len(o.Categories) > 0 || o.MinPriority != nil || o.WorkflowStatus != ""
 }
 
 // UnifiedCursor tracks pagination state across DB and multiple providers.
@@ -57,9 +92,12 @@ type UnifiedCursor struct {
 }
 
 // ProviderCursor tracks pagination state for a single provider.
+// PageToken은 현재 소비 중인 페이지를 가리키고, Consumed는 그 페이지에서 이미 반환한 개수다.
+// 병합 후 잘려나간 메일은 다음 요청에서 같은 페이지를 다시 받아 이어서 반환한다.
 type ProviderCursor struct {
 	Type      string `json:"type"` // "gmail" or "outlook"
 	PageToken string `json:"page_token"`
+	Consumed  int    `json:"consumed,omitempty"`
 	Exhausted bool   `json:"exhausted"` // no more data from this provider
 }
 
@@ -77,6 +115,7 @@ type UnifiedEmail struct {
 	ConnectionID int64     `json:"connection_id"`
 	ProviderType string    `json:"provider_type"` // "gmail" or "outlook"
 	ProviderID   string    `json:"provider_id"`   // external ID
+	MessageID    string    `json:"message_id,omitempty"`
 	Subject      string    `json:"subject"`
 	FromEmail    string    `json:"from_email"`
 	FromName     *string   `json:"from_name,omitempty"`
@@ -87,6 +126,25 @@ type UnifiedEmail struct {
 	IsStarred    bool      `json:"is_starred"`
 	HasAttach    bool      `json:"has_attachments"`
 	ReceivedAt   time.Time `json:"received_at"`
+
+	// AI 결과 (DB에 있는 메일만)
+	Category       string   `json:"category,omitempty"`
+	Priority       *float64 `json:"priority,omitempty"`
+	WorkflowStatus string   `json:"workflow_status,omitempty"`
+	Score          float64  `json:"score,omitempty"` // blended ranking score
+
+	// Duplicates are copies collapsed into this email (다른 계정에 배달된 같은 메일, 전달 사본).
+	Duplicates []*UnifiedDuplicate `json:"duplicates,omitempty"`
+}
+
+// UnifiedDuplicate is a redundant copy of a unified email.
+type UnifiedDuplicate struct {
+	ID           int64  `json:"id,omitempty"`
+	ConnectionID int64  `json:"connection_id"`
+	ProviderType string `json:"provider_type"`
+	ProviderID   string `json:"provider_id"`
+	Folder       string `json:"folder"`
+	Reason       string `json:"reason"` // message_id, forwarded
 }
 
 // NewUnifiedMailProvider creates a new unified mail provider.
@@ -116,6 +174,7 @@ func (u *UnifiedMailProvider) RegisterProvider(providerType string, provider out
 
 // ListAll lists emails from all connections with unified pagination.
 // 철학: DB 먼저 조회, 부족하면 Provider에서 보충, 시간순 정렬
+// Rank가 blended이면 시간순으로 자른 페이지를 최신성 + 우선순위로 재정렬한다.
 func (u *UnifiedMailProvider) ListAll(ctx context.Context, opts *UnifiedListOptions) (*UnifiedListResult, error) {
 	if opts == nil {
 		return nil, nil
@@ -153,26 +212,26 @@ func (u *UnifiedMailProvider) ListAll(ctx context.Context, opts *UnifiedListOpti
 	// 3. Calculate how many more we need
 	needed := opts.Limit - len(dbEmails)
 
-	// Mark DB as exhausted if we got fewer than requested
-	if len(dbEmails) < opts.Limit && !cursor.DBExhausted {
-		cursor.DBExhausted = true
-	}
-
-	// 4. If DB is exhausted or insufficient, fetch from providers
-	var providerEmails []*UnifiedEmail
-	if needed > 0 && len(connections) > 0 {
-		providerEmails, err = u.fetchFromProviders(ctx, connections, needed, cursor)
-		if err != nil {
-			logger.Warn("[UnifiedProvider] Provider fetch failed: %v", err)
-		}
+	// 4. If DB is insufficient, fetch from providers
+	// AI 필터는 Gmail/Outlook이 모르므로 DB 결과만 반환
+	var fetches map[int64]*providerFetch
+	if needed > 0 && len(connections) > 0 && !opts.hasAIFilters() {
+		fetches = u.fetchFromProviders(ctx, connections, needed, cursor)
 	}
 
 	// 5. Merge and deduplicate (by ProviderID)
-	allEmails := u.mergeAndDeduplicate(dbEmails, providerEmails)
+	allEmails := u.mergeAndDeduplicate(dbEmails, fetches)
 
-	// 6. Sort by received_at DESC
-	sort.Slice(allEmails, func(i, j int) bool {
-		return allEmails[i].ReceivedAt.After(allEmails[j].ReceivedAt)
+	// 6. Sort by received_at DESC (ties broken by connection/provider ID for stable pages)
+	sort.SliceStable(allEmails, func(i, j int) bool {
+		a, b := allEmails[i], allEmails[j]
+		if !a.ReceivedAt.Equal(b.ReceivedAt) {
+			return a.ReceivedAt.After(b.ReceivedAt)
+		}
+		if a.ConnectionID != b.ConnectionID {
+			return a.ConnectionID < b.ConnectionID
+		}
+		return a.ProviderID < b.ProviderID
 	})
 
 	// 7. Trim to limit
@@ -180,19 +239,25 @@ func (u *UnifiedMailProvider) ListAll(ctx context.Context, opts *UnifiedListOpti
 		allEmails = allEmails[:opts.Limit]
 	}
 
-	// 8. Update cursor for next page
-	nextCursor := &UnifiedCursor{
-		DBOffset:    cursor.DBOffset + len(dbEmails),
-		DBExhausted: cursor.DBExhausted,
-		Providers:   cursor.Providers,
-	}
+	// 8. Advance cursors by what was actually returned
+	nextCursor := u.advanceCursor(cursor, allEmails, len(dbEmails), opts.Limit, fetches)
 	if len(allEmails) > 0 {
 		lastTime := allEmails[len(allEmails)-1].ReceivedAt
 		nextCursor.LastTime = &lastTime
 	}
 
-	// 9. Determine if there's more
-	hasMore := u.hasMoreData(cursor, dbTotal, len(dbEmails))
+	// 9. Collapse duplicate copies (커서는 이미 전진했으므로 묶인 사본도 소비된 것으로 처리)
+	if !opts.KeepDuplicates {
+		allEmails = collapseDuplicates(allEmails, connections)
+	}
+
+	// 10. Re-rank within the page
+	if opts.Rank == UnifiedRankBlended {
+		rankBlended(allEmails)
+	}
+
+	// 11. Determine if there's more
+	hasMore := u.hasMoreData(nextCursor, dbTotal, connections, opts.hasAIFilters())
 
 	return &UnifiedListResult{
 		Emails:     allEmails,
@@ -214,10 +279,13 @@ func (u *UnifiedMailProvider) queryDB(ctx context.Context, opts *UnifiedListOpti
 
 	// Build filter
 	query := &out.MailListQuery{
-		Limit:   opts.Limit,
-		Offset:  offset,
-		OrderBy: "received_at",
-		Order:   "DESC",
+		Limit:          opts.Limit,
+		Offset:         offset,
+		OrderBy:        "email_date",
+		Order:          "desc",
+		Categories:     opts.Categories,
+		Priority:       opts.MinPriority,
+		WorkflowStatus: opts.WorkflowStatus,
 	}
 	if opts.Folder != nil {
 		query.Folder = *opts.Folder
@@ -236,41 +304,58 @@ func (u *UnifiedMailProvider) queryDB(ctx context.Context, opts *UnifiedListOpti
 		if e.FromName != "" {
 			fromName = &e.FromName
 		}
+		var priority *float64
+		if e.Priority > 0 || e.Category != "" {
+			p := e.Priority
+			priority = &p
+		}
 		result[i] = &UnifiedEmail{
-			ID:           e.ID,
-			ConnectionID: e.ConnectionID,
-			ProviderType: e.Provider,
-			ProviderID:   e.ExternalID,
-			Subject:      e.Subject,
-			FromEmail:    e.FromEmail,
-			FromName:     fromName,
-			Snippet:      e.Snippet,
-			Folder:       e.Folder,
-			IsRead:       e.IsRead,
-			HasAttach:    e.HasAttachment,
-			ReceivedAt:   e.ReceivedAt,
+			ID:             e.ID,
+			ConnectionID:   e.ConnectionID,
+			ProviderType:   e.Provider,
+			ProviderID:     e.ExternalID,
+			MessageID:      e.MessageID,
+			Subject:        e.Subject,
+			FromEmail:      e.FromEmail,
+			FromName:       fromName,
+			Snippet:        e.Snippet,
+			Folder:         e.Folder,
+			IsRead:         e.IsRead,
+			IsStarred:      e.IsStarred,
+			HasAttach:      e.HasAttachment,
+			ReceivedAt:     e.ReceivedAt,
+			Category:       e.Category,
+			Priority:       priority,
+			WorkflowStatus: e.WorkflowStatus,
 		}
 	}
 
 	return result, total, nil
 }
 
-// fetchFromProviders fetches emails from all providers in parallel.
+// providerFetch is the result of fetching one connection's current page.
+type providerFetch struct {
+	provType      string
+	pageToken     string          // 요청한 페이지 토큰
+	consumed      int             // 요청 전 이 페이지에서 이미 반환한 개수
+	emails        []*UnifiedEmail // consumed 이후 메일 (provider 순서)
+	nextPageToken string
+	err           error
+}
+
+// fetchFromProviders fetches the unconsumed part of each connection's current page in parallel.
 func (u *UnifiedMailProvider) fetchFromProviders(
 	ctx context.Context,
 	connections []*domain.OAuthConnection,
 	needed int,
 	cursor *UnifiedCursor,
-) ([]*UnifiedEmail, error) {
+) map[int64]*providerFetch {
 	u.mu.RLock()
 	defer u.mu.RUnlock()
 
 	type fetchResult struct {
-		emails    []*UnifiedEmail
-		connID    int64
-		pageToken string
-		exhausted bool
-		err       error
+		connID int64
+		fetch  *providerFetch
 	}
 
 	results := make(chan fetchResult, len(connections))
@@ -301,32 +386,42 @@ func (u *UnifiedMailProvider) fetchFromProviders(
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
+			// Get page token from cursor
+			fetch := &providerFetch{provType: provType}
+			if pc, ok := cursor.Providers[conn.ID]; ok {
+				fetch.pageToken = pc.PageToken
+				fetch.consumed = pc.Consumed
+			}
+
 			// Get token
 			token, err := u.oauthGetter.GetOAuth2Token(ctx, conn.ID)
 			if err != nil {
-				results <- fetchResult{connID: conn.ID, err: err}
+				fetch.err = err
+				results <- fetchResult{connID: conn.ID, fetch: fetch}
 				return
 			}
 
-			// Get page token from cursor
-			var pageToken string
-			if pc, ok := cursor.Providers[conn.ID]; ok {
-				pageToken = pc.PageToken
-			}
-
-			// Fetch from provider
+			// Fetch from provider (이미 반환한 앞부분까지 포함해 다시 받은 뒤 건너뜀)
 			listResult, err := provider.ListMessages(ctx, token, &out.ProviderListOptions{
-				MaxResults: needed,
-				PageToken:  pageToken,
+				MaxResults: fetch.consumed + needed,
+				PageToken:  fetch.pageToken,
 			})
 			if err != nil {
-				results <- fetchResult{connID: conn.ID, err: err}
+				fetch.err = err
+				results <- fetchResult{connID: conn.ID, fetch: fetch}
 				return
 			}
 
+			messages := listResult.Messages
+			if fetch.consumed < len(messages) {
+				messages = messages[fetch.consumed:]
+			} else {
+				messages = nil
+			}
+
 			// Convert to UnifiedEmail
-			emails := make([]*UnifiedEmail, len(listResult.Messages))
-			for i, msg := range listResult.Messages {
+			emails := make([]*UnifiedEmail, len(messages))
+			for i, msg := range messages {
 				var fromName *string
 				if msg.From.Name != "" {
 					fromName = &msg.From.Name
@@ -335,6 +430,7 @@ func (u *UnifiedMailProvider) fetchFromProviders(
 					ConnectionID: conn.ID,
 					ProviderType: provType,
 					ProviderID:   msg.ExternalID,
+					MessageID:    msg.MessageID,
 					Subject:      msg.Subject,
 					FromEmail:    msg.From.Email,
 					FromName:     fromName,
@@ -347,13 +443,9 @@ func (u *UnifiedMailProvider) fetchFromProviders(
 				}
 			}
 
-			exhausted := listResult.NextPageToken == ""
-			results <- fetchResult{
-				emails:    emails,
-				connID:    conn.ID,
-				pageToken: listResult.NextPageToken,
-				exhausted: exhausted,
-			}
+			fetch.emails = emails
+			fetch.nextPageToken = listResult.NextPageToken
+			results <- fetchResult{connID: conn.ID, fetch: fetch}
 		}(conn, provider, providerType)
 	}
 
@@ -364,30 +456,19 @@ func (u *UnifiedMailProvider) fetchFromProviders(
 	}()
 
 	// Collect results
-	var allEmails []*UnifiedEmail
+	fetches := make(map[int64]*providerFetch, len(connections))
 	for result := range results {
-		if result.err != nil {
-			logger.Warn("[UnifiedProvider] Failed to fetch from connection %d: %v", result.connID, result.err)
-			continue
-		}
-
-		allEmails = append(allEmails, result.emails...)
-
-		// Update cursor
-		if cursor.Providers == nil {
-			cursor.Providers = make(map[int64]*ProviderCursor)
-		}
-		cursor.Providers[result.connID] = &ProviderCursor{
-			PageToken: result.pageToken,
-			Exhausted: result.exhausted,
+		if result.fetch.err != nil {
+			logger.Warn("[UnifiedProvider] Failed to fetch from connection %d: %v", result.connID, result.fetch.err)
 		}
+		fetches[result.connID] = result.fetch
 	}
 
-	return allEmails, nil
+	return fetches
 }
 
 // mergeAndDeduplicate merges DB and provider emails, removing duplicates.
-func (u *UnifiedMailProvider) mergeAndDeduplicate(dbEmails, providerEmails []*UnifiedEmail) []*UnifiedEmail {
+func (u *UnifiedMailProvider) mergeAndDeduplicate(dbEmails []*UnifiedEmail, fetches map[int64]*providerFetch) []*UnifiedEmail {
 	// Build set of existing ProviderIDs from DB
 	seen := make(map[string]bool, len(dbEmails))
 	for _, e := range dbEmails {
@@ -395,30 +476,95 @@ func (u *UnifiedMailProvider) mergeAndDeduplicate(dbEmails, providerEmails []*Un
 	}
 
 	// Start with DB emails
-	result := make([]*UnifiedEmail, 0, len(dbEmails)+len(providerEmails))
+	result := make([]*UnifiedEmail, 0, len(dbEmails))
 	result = append(result, dbEmails...)
 
 	// Add provider emails that aren't in DB
-	for _, e := range providerEmails {
-		if !seen[e.ProviderID] {
-			result = append(result, e)
-			seen[e.ProviderID] = true
+	for _, f := range fetches {
+		for _, e := range f.emails {
+			if !seen[e.ProviderID] {
+				result = append(result, e)
+				seen[e.ProviderID] = true
+			}
 		}
 	}
 
 	return result
 }
 
+// advanceCursor builds the next cursor from the emails actually returned on this page.
+// 잘려나간 DB/Provider 메일은 소비하지 않은 것으로 남겨 다음 페이지에서 다시 나오도록 한다.
+func (u *UnifiedMailProvider) advanceCursor(
+	cursor *UnifiedCursor,
+	page []*UnifiedEmail,
+	dbFetched, limit int,
+	fetches map[int64]*providerFetch,
+) *UnifiedCursor {
+	returned := make(map[string]bool, len(page))
+	dbKept := 0
+	for _, e := range page {
+		if e.ID != 0 {
+			dbKept++
+		}
+		returned[e.ProviderID] = true
+	}
+
+	next := &UnifiedCursor{
+		DBOffset:    cursor.DBOffset + dbKept,
+		DBExhausted: cursor.DBExhausted || (dbFetched < limit && dbKept == dbFetched),
+		Providers:   make(map[int64]*ProviderCursor, len(cursor.Providers)),
+	}
+	for connID, pc := range cursor.Providers {
+		cp := *pc
+		next.Providers[connID] = &cp
+	}
+
+	for connID, f := range fetches {
+		if f.err != nil {
+			continue
+		}
+
+		// Provider는 최신순으로 주므로 반환된(또는 DB에 이미 있는) 앞부분만 소비
+		n := 0
+		for _, e := range f.emails {
+			if !returned[e.ProviderID] {
+				break
+			}
+			n++
+		}
+
+		pc := &ProviderCursor{Type: f.provType}
+		if n == len(f.emails) {
+			pc.PageToken = f.nextPageToken
+			pc.Exhausted = f.nextPageToken == ""
+		} else {
+			pc.PageToken = f.pageToken
+			pc.Consumed = f.consumed + n
+		}
+		next.Providers[connID] = pc
+	}
+
+	return next
+}
+
 // hasMoreData checks if there's more data available.
-func (u *UnifiedMailProvider) hasMoreData(cursor *UnifiedCursor, dbTotal, dbFetched int) bool {
+func (u *UnifiedMailProvider) hasMoreData(cursor *UnifiedCursor, dbTotal int, connections []*domain.OAuthConnection, aiFilters bool) bool {
 	// More in DB?
-	if !cursor.DBExhausted && cursor.DBOffset+dbFetched < dbTotal {
+	if !cursor.DBExhausted && cursor.DBOffset < dbTotal {
 		return true
 	}
+	if aiFilters {
+		return false
+	}
 
-	// More in any provider?
-	for _, pc := range cursor.Providers {
-		if !pc.Exhausted {
+	// More in any provider? (아직 조회하지 않은 연결 포함)
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	for _, conn := range connections {
+		if _, ok := u.providers[string(conn.Provider)]; !ok {
+			continue
+		}
+		if pc, ok := cursor.Providers[conn.ID]; !ok || !pc.Exhausted {
 			return true
 		}
 	}
@@ -426,6 +572,106 @@ func (u *UnifiedMailProvider) hasMoreData(cursor *UnifiedCursor, dbTotal, dbFetc
 	return false
 }
 
+// collapseDuplicates folds redundant copies of a page into the email that is kept.
+// 같은 Message-ID(방향별)는 먼저 나온 메일을, 내 계정에서 전달한 사본은 원본을 남긴다.
+func collapseDuplicates(emails []*UnifiedEmail, connections []*domain.OAuthConnection) []*UnifiedEmail {
+	if len(emails) < 2 {
+		return emails
+	}
+
+	own := make(map[string]bool, len(connections))
+	for _, conn := range connections {
+		own[strings.ToLower(conn.Email)] = true
+	}
+
+	collapsed := make(map[*UnifiedEmail]bool)
+	byMessage := make(map[string]*UnifiedEmail, len(emails))
+	for _, e := range emails {
+		if e.MessageID == "" {
+			continue
+		}
+		key := strings.ToLower(strings.Trim(e.MessageID, "<> ")) + "|" + strconv.FormatBool(e.Folder == "sent")
+		if kept, ok := byMessage[key]; ok {
+			kept.Duplicates = append(kept.Duplicates, newUnifiedDuplicate(e, domain.DuplicateReasonMessageID))
+			collapsed[e] = true
+			continue
+		}
+		byMessage[key] = e
+	}
+
+	for _, e := range emails {
+		if collapsed[e] || !own[strings.ToLower(e.FromEmail)] {
+			continue
+		}
+		subject, forwarded := domain.StripForwardPrefix(e.Subject)
+		if !forwarded || subject == "" {
+			continue
+		}
+		snippet := strings.ToLower(e.Snippet)
+		for _, o := range emails {
+			if o == e || collapsed[o] || o.ReceivedAt.After(e.ReceivedAt) || o.FromEmail == "" {
+				continue
+			}
+			if strings.EqualFold(strings.TrimSpace(o.Subject), subject) && strings.Contains(snippet, strings.ToLower(o.FromEmail)) {
+				o.Duplicates = append(o.Duplicates, newUnifiedDuplicate(e, domain.DuplicateReasonForwarded))
+				collapsed[e] = true
+				break
+			}
+		}
+	}
+
+	if len(collapsed) == 0 {
+		return emails
+	}
+	result := make([]*UnifiedEmail, 0, len(emails)-len(collapsed))
+	for _, e := range emails {
+		if !collapsed[e] {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func newUnifiedDuplicate(e *UnifiedEmail, reason string) *UnifiedDuplicate {
+	return &UnifiedDuplicate{
+		ID:           e.ID,
+		ConnectionID: e.ConnectionID,
+		ProviderType: e.ProviderType,
+		ProviderID:   e.ProviderID,
+		Folder:       e.Folder,
+		Reason:       reason,
+	}
+}
+
+// rankBlended re-orders a page by recency and AI priority.
+// 최신성은 페이지에서 가장 최근 메일을 기준으로 반감기 감쇠하므로 요청 시각과 무관하게 같은 순서가 나온다.
+func rankBlended(emails []*UnifiedEmail) {
+	if len(emails) == 0 {
+		return
+	}
+
+	anchor := emails[0].ReceivedAt
+	for _, e := range emails {
+		if e.ReceivedAt.After(anchor) {
+			anchor = e.ReceivedAt
+		}
+	}
+
+	for _, e := range emails {
+		priority := unclassifiedPriority
+		if e.Priority != nil {
+			priority = *e.Priority
+		}
+		age := anchor.Sub(e.ReceivedAt).Hours() / blendedHalfLife.Hours()
+		recency := math.Pow(0.5, age)
+		e.Score = blendedPriorityWeight*priority + blendedRecencyWeight*recency
+	}
+
+	sort.SliceStable(emails, func(i, j int) bool {
+		return emails[i].Score > emails[j].Score
+	})
+}
+
 // =============================================================================
 // Cursor Encoding/Decoding
 // =============================================================================
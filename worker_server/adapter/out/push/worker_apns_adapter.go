@@ -0,0 +1,158 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/goccy/go-json"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	apnsProductionURL  = "https://api.push.apple.com"
+	apnsDevelopmentURL = "https://api.sandbox.push.apple.com"
+
+	// apnsTokenTTL - APNs는 20~60분 사이에 provider token을 갱신해야 한다
+	apnsTokenTTL = 50 * time.Minute
+
+	apnsMaxCollapseID = 64
+)
+
+// APNsAdapter sends push notifications through APNs with token-based (.p8) authentication.
+// 기본 http.Client가 TLS ALPN으로 HTTP/2를 사용한다.
+type APNsAdapter struct {
+	baseURL string
+	topic   string // 앱 bundle id
+	keyID   string
+	teamID  string
+	key     *ecdsa.PrivateKey
+	client  *http.Client
+
+	mu       sync.Mutex
+	jwt      string
+	issuedAt time.Time
+}
+
+// NewAPNsAdapter creates an APNs adapter from a .p8 auth key.
+func NewAPNsAdapter(p8 []byte, keyID, teamID, topic string, production bool) (*APNsAdapter, error) {
+	key, err := jwt.ParseECPrivateKeyFromPEM(p8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid APNs auth key: %w", err)
+	}
+	if keyID == "" || teamID == "" || topic == "" {
+		return nil, fmt.Errorf("APNs key id, team id and topic are required")
+	}
+
+	baseURL := apnsDevelopmentURL
+	if production {
+		baseURL = apnsProductionURL
+	}
+	return &APNsAdapter{
+		baseURL: baseURL,
+		topic:   topic,
+		keyID:   keyID,
+		teamID:  teamID,
+		key:     key,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Platform returns domain.PushPlatformAPNs.
+func (a *APNsAdapter) Platform() domain.PushPlatform {
+	return domain.PushPlatformAPNs
+}
+
+// bearer returns the cached provider token, refreshing it every apnsTokenTTL.
+func (a *APNsAdapter) bearer() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.jwt != "" && time.Since(a.issuedAt) < apnsTokenTTL {
+		return a.jwt, nil
+	}
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": a.teamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = a.keyID
+	signed, err := token.SignedString(a.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign APNs token: %w", err)
+	}
+	a.jwt, a.issuedAt = signed, now
+	return signed, nil
+}
+
+// Send delivers msg to an APNs device token.
+func (a *APNsAdapter) Send(ctx context.Context, token string, msg *domain.PushMessage) error {
+	aps := map[string]any{
+		"alert": map[string]string{"title": msg.Title, "body": msg.Body},
+		"sound": "default",
+	}
+	if msg.CollapseKey != "" {
+		aps["thread-id"] = msg.CollapseKey // 알림 센터에서 스레드별로 묶는다
+	}
+	payload := map[string]any{"aps": aps}
+	for k, v := range msg.Data {
+		if k != "aps" {
+			payload[k] = v
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	bearer, err := a.bearer()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/3/device/"+token, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("authorization", "bearer "+bearer)
+	req.Header.Set("apns-topic", a.topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "5")
+	if msg.Priority == domain.NotificationPriorityHigh || msg.Priority == domain.NotificationPriorityUrgent {
+		req.Header.Set("apns-priority", "10")
+	}
+	if msg.CollapseKey != "" && len(msg.CollapseKey) <= apnsMaxCollapseID {
+		req.Header.Set("apns-collapse-id", msg.CollapseKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("apns request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var apnsErr struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.Unmarshal(data, &apnsErr)
+	switch {
+	case resp.StatusCode == http.StatusGone,
+		apnsErr.Reason == "BadDeviceToken",
+		apnsErr.Reason == "DeviceTokenNotForTopic":
+		return out.ErrPushTokenInvalid
+	}
+	return fmt.Errorf("apns send failed: %d %s", resp.StatusCode, apnsErr.Reason)
+}
+
+var _ out.MobilePushPort = (*APNsAdapter)(nil)
@@ -0,0 +1,139 @@
+// Package push provides out.MobilePushPort adapters for FCM and APNs.
+package push
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/goccy/go-json"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	fcmScope    = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmEndpoint = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+)
+
+// FCMAdapter sends push notifications through the FCM HTTP v1 API.
+type FCMAdapter struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewFCMAdapter creates an FCM adapter from a service account JSON key.
+// projectID가 비어 있으면 서비스 계정의 project_id를 사용한다.
+func NewFCMAdapter(ctx context.Context, credentialsJSON []byte, projectID string) (*FCMAdapter, error) {
+	creds, err := google.CredentialsFromJSON(ctx, credentialsJSON, fcmScope)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FCM credentials: %w", err)
+	}
+	if projectID == "" {
+		projectID = creds.ProjectID
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("FCM project id is required")
+	}
+
+	client := oauth2.NewClient(context.WithoutCancel(ctx), creds.TokenSource)
+	client.Timeout = 10 * time.Second
+	return &FCMAdapter{
+		endpoint: fmt.Sprintf(fcmEndpoint, projectID),
+		client:   client,
+	}, nil
+}
+
+// Platform returns domain.PushPlatformFCM.
+func (a *FCMAdapter) Platform() domain.PushPlatform {
+	return domain.PushPlatformFCM
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      fcmAndroid        `json:"android"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+type fcmAndroid struct {
+	Priority     string `json:"priority"` // HIGH | NORMAL
+	CollapseKey  string `json:"collapse_key,omitempty"`
+	Notification struct {
+		Tag string `json:"tag,omitempty"` // 같은 tag의 알림은 교체된다
+	} `json:"notification"`
+}
+
+type fcmErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// Send delivers msg to an FCM registration token.
+func (a *FCMAdapter) Send(ctx context.Context, token string, msg *domain.PushMessage) error {
+	req := fcmRequest{Message: fcmMessage{
+		Token:        token,
+		Notification: fcmNotification{Title: msg.Title, Body: msg.Body},
+		Data:         msg.Data,
+		Android:      fcmAndroid{Priority: "NORMAL", CollapseKey: msg.CollapseKey},
+	}}
+	req.Message.Android.Notification.Tag = msg.CollapseKey
+	if msg.Priority == domain.NotificationPriorityHigh || msg.Priority == domain.NotificationPriorityUrgent {
+		req.Message.Android.Priority = "HIGH"
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("fcm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	var fcmErr fcmErrorResponse
+	_ = json.Unmarshal(data, &fcmErr)
+	for _, d := range fcmErr.Error.Details {
+		if d.ErrorCode == "UNREGISTERED" {
+			return out.ErrPushTokenInvalid
+		}
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return out.ErrPushTokenInvalid
+	}
+	return fmt.Errorf("fcm send failed: %d %s %s", resp.StatusCode, fcmErr.Error.Status, fcmErr.Error.Message)
+}
+
+var _ out.MobilePushPort = (*FCMAdapter)(nil)
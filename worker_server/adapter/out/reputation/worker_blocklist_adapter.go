@@ -0,0 +1,118 @@
+// Package reputation provides URL reputation adapters (blocklist, Safe Browsing).
+package reputation
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+)
+
+// BlocklistAdapter checks URLs against a static list of blocked domains.
+// 서브도메인도 차단된다 (evil.com → login.evil.com).
+type BlocklistAdapter struct {
+	domains map[string]struct{}
+}
+
+// NewBlocklistAdapter creates a new BlocklistAdapter.
+func NewBlocklistAdapter(domains []string) *BlocklistAdapter {
+	a := &BlocklistAdapter{domains: make(map[string]struct{}, len(domains))}
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimSpace(d))
+		d = strings.TrimPrefix(d, "*.")
+		if d != "" {
+			a.domains[d] = struct{}{}
+		}
+	}
+	return a
+}
+
+// CheckURLs implements out.URLReputationChecker.
+func (a *BlocklistAdapter) CheckURLs(ctx context.Context, urls []string) (map[string]*domain.URLReputation, error) {
+	result := make(map[string]*domain.URLReputation, len(urls))
+	for _, raw := range urls {
+		rep := &domain.URLReputation{URL: raw, Verdict: domain.LinkVerdictSafe, Source: "blocklist"}
+		if a.isBlocked(hostOf(raw)) {
+			rep.Verdict = domain.LinkVerdictBlocked
+			rep.Threats = []string{"BLOCKLIST"}
+		}
+		result[raw] = rep
+	}
+	return result, nil
+}
+
+func (a *BlocklistAdapter) isBlocked(host string) bool {
+	for host != "" {
+		if _, ok := a.domains[host]; ok {
+			return true
+		}
+		i := strings.Index(host, ".")
+		if i < 0 {
+			break
+		}
+		host = host[i+1:]
+	}
+	return false
+}
+
+// ChainAdapter runs several checkers in order; the first blocked verdict wins.
+// 개별 checker 실패는 무시하고 나머지 결과를 사용한다.
+type ChainAdapter struct {
+	checkers []out.URLReputationChecker
+}
+
+// NewChainAdapter creates a new ChainAdapter.
+func NewChainAdapter(checkers ...out.URLReputationChecker) *ChainAdapter {
+	return &ChainAdapter{checkers: checkers}
+}
+
+// CheckURLs implements out.URLReputationChecker.
+func (a *ChainAdapter) CheckURLs(ctx context.Context, urls []string) (map[string]*domain.URLReputation, error) {
+	result := make(map[string]*domain.URLReputation, len(urls))
+	var lastErr error
+	succeeded := false
+
+	for _, checker := range a.checkers {
+		pending := make([]string, 0, len(urls))
+		for _, u := range urls {
+			if !result[u].IsBlocked() {
+				pending = append(pending, u)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		reps, err := checker.CheckURLs(ctx, pending)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		succeeded = true
+		for u, rep := range reps {
+			if rep.IsBlocked() || result[u] == nil {
+				result[u] = rep
+			}
+		}
+	}
+
+	if !succeeded && lastErr != nil {
+		return nil, lastErr
+	}
+	return result, nil
+}
+
+func hostOf(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+}
+
+var (
+	_ out.URLReputationChecker = (*BlocklistAdapter)(nil)
+	_ out.URLReputationChecker = (*ChainAdapter)(nil)
+)
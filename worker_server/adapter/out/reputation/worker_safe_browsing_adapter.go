@@ -0,0 +1,133 @@
+package reputation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	safeBrowsingEndpoint = "https://safebrowsing.googleapis.com/v4/threatMatches:find"
+	// safeBrowsingMaxURLs is the per-request threatEntries limit.
+	safeBrowsingMaxURLs = 500
+)
+
+// SafeBrowsingAdapter checks URLs with the Google Safe Browsing Lookup API (v4).
+type SafeBrowsingAdapter struct {
+	apiKey   string
+	client   *http.Client
+	endpoint string
+}
+
+// NewSafeBrowsingAdapter creates a new SafeBrowsingAdapter.
+func NewSafeBrowsingAdapter(apiKey string) *SafeBrowsingAdapter {
+	return &SafeBrowsingAdapter{
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: 5 * time.Second},
+		endpoint: safeBrowsingEndpoint,
+	}
+}
+
+type sbThreatEntry struct {
+	URL string `json:"url"`
+}
+
+type sbRequest struct {
+	Client struct {
+		ClientID      string `json:"clientId"`
+		ClientVersion string `json:"clientVersion"`
+	} `json:"client"`
+	ThreatInfo struct {
+		ThreatTypes      []string        `json:"threatTypes"`
+		PlatformTypes    []string        `json:"platformTypes"`
+		ThreatEntryTypes []string        `json:"threatEntryTypes"`
+		ThreatEntries    []sbThreatEntry `json:"threatEntries"`
+	} `json:"threatInfo"`
+}
+
+type sbResponse struct {
+	Matches []struct {
+		ThreatType string        `json:"threatType"`
+		Threat     sbThreatEntry `json:"threat"`
+	} `json:"matches"`
+}
+
+// CheckURLs implements out.URLReputationChecker.
+func (a *SafeBrowsingAdapter) CheckURLs(ctx context.Context, urls []string) (map[string]*domain.URLReputation, error) {
+	result := make(map[string]*domain.URLReputation, len(urls))
+
+	for start := 0; start < len(urls); start += safeBrowsingMaxURLs {
+		end := start + safeBrowsingMaxURLs
+		if end > len(urls) {
+			end = len(urls)
+		}
+		if err := a.lookup(ctx, urls[start:end], result); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (a *SafeBrowsingAdapter) lookup(ctx context.Context, urls []string, result map[string]*domain.URLReputation) error {
+	var reqBody sbRequest
+	reqBody.Client.ClientID = "worker_server"
+	reqBody.Client.ClientVersion = "1.0"
+	reqBody.ThreatInfo.ThreatTypes = []string{"MALWARE", "SOCIAL_ENGINEERING", "UNWANTED_SOFTWARE", "POTENTIALLY_HARMFUL_APPLICATION"}
+	reqBody.ThreatInfo.PlatformTypes = []string{"ANY_PLATFORM"}
+	reqBody.ThreatInfo.ThreatEntryTypes = []string{"URL"}
+	for _, u := range urls {
+		reqBody.ThreatInfo.ThreatEntries = append(reqBody.ThreatInfo.ThreatEntries, sbThreatEntry{URL: u})
+	}
+
+	data, err := json.Marshal(reqBody)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint+"?key="+a.apiKey, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("safe browsing request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("safe browsing returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var sbResp sbResponse
+	if err := json.NewDecoder(resp.Body).Decode(&sbResp); err != nil {
+		return fmt.Errorf("failed to decode safe browsing response: %w", err)
+	}
+
+	for _, u := range urls {
+		result[u] = &domain.URLReputation{URL: u, Verdict: domain.LinkVerdictSafe, Source: "safe_browsing"}
+	}
+	for _, m := range sbResp.Matches {
+		rep, ok := result[m.Threat.URL]
+		if !ok {
+			continue
+		}
+		rep.Verdict = domain.LinkVerdictBlocked
+		rep.Threats = append(rep.Threats, m.ThreatType)
+	}
+
+	return nil
+}
+
+var _ out.URLReputationChecker = (*SafeBrowsingAdapter)(nil)
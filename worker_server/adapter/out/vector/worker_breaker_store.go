@@ -0,0 +1,135 @@
+package vector
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/resilience"
+
+	"github.com/sony/gobreaker"
+)
+
+const (
+	staleSearchEntries = 1000
+	staleSearchMaxAge  = 30 * time.Minute
+)
+
+// BreakerStore wraps a vector store with a circuit breaker.
+// 장애 중 검색은 같은 질의의 마지막 결과를 돌려주고, 쓰기는 바로 실패해 인덱서가 나중에 다시 시도한다.
+type BreakerStore struct {
+	inner    out.VectorStorePort
+	cb       *gobreaker.CircuitBreaker
+	searches *resilience.StaleCache
+}
+
+// NewBreakerStore wraps inner with a circuit breaker named name (e.g. "vector-qdrant").
+func NewBreakerStore(name string, inner out.VectorStorePort) *BreakerStore {
+	return &BreakerStore{
+		inner:    inner,
+		cb:       resilience.NewBreaker(name, nil),
+		searches: resilience.NewStaleCache(staleSearchEntries, staleSearchMaxAge),
+	}
+}
+
+func (s *BreakerStore) execute(fn func() error) error {
+	_, err := s.cb.Execute(func() (any, error) {
+		return nil, fn()
+	})
+	return err
+}
+
+// Store stores one email vector.
+func (s *BreakerStore) Store(ctx context.Context, record *out.EmailVector) error {
+	return s.execute(func() error { return s.inner.Store(ctx, record) })
+}
+
+// StoreBatch stores email vectors.
+func (s *BreakerStore) StoreBatch(ctx context.Context, records []*out.EmailVector) error {
+	return s.execute(func() error { return s.inner.StoreBatch(ctx, records) })
+}
+
+// Search runs a similarity search, serving the last result of the same query while the store is down.
+func (s *BreakerStore) Search(ctx context.Context, embedding []float32, opts *out.EmailVectorQuery) ([]*out.EmailVectorMatch, error) {
+	key := searchKey(embedding, opts)
+
+	var matches []*out.EmailVectorMatch
+	err := s.execute(func() error {
+		var err error
+		matches, err = s.inner.Search(ctx, embedding, opts)
+		return err
+	})
+	if err == nil {
+		s.searches.Set(key, matches)
+		return matches, nil
+	}
+
+	if cached, ok := s.searches.Get(key); ok {
+		logger.Warn("[VectorStore.Search] Serving cached result: %v", err)
+		return cached.([]*out.EmailVectorMatch), nil
+	}
+	return nil, err
+}
+
+// Delete removes an email vector.
+func (s *BreakerStore) Delete(ctx context.Context, emailID int64) error {
+	return s.execute(func() error { return s.inner.Delete(ctx, emailID) })
+}
+
+// HasEmbedding reports whether the email has been indexed.
+func (s *BreakerStore) HasEmbedding(ctx context.Context, emailID int64) (bool, error) {
+	var has bool
+	err := s.execute(func() error {
+		var err error
+		has, err = s.inner.HasEmbedding(ctx, emailID)
+		return err
+	})
+	return has, err
+}
+
+// Get returns the stored vector of an email.
+func (s *BreakerStore) Get(ctx context.Context, emailID int64) (*out.EmailVector, error) {
+	var record *out.EmailVector
+	err := s.execute(func() error {
+		var err error
+		record, err = s.inner.Get(ctx, emailID)
+		return err
+	})
+	return record, err
+}
+
+// Scan returns stored vectors with email ID > afterID.
+func (s *BreakerStore) Scan(ctx context.Context, afterID int64, limit int) ([]*out.EmailVector, error) {
+	var records []*out.EmailVector
+	err := s.execute(func() error {
+		var err error
+		records, err = s.inner.Scan(ctx, afterID, limit)
+		return err
+	})
+	return records, err
+}
+
+// searchKey hashes the query embedding and filters into a cache key.
+func searchKey(embedding []float32, opts *out.EmailVectorQuery) string {
+	h := fnv.New64a()
+	var buf [4]byte
+	for _, v := range embedding {
+		binary.LittleEndian.PutUint32(buf[:], math.Float32bits(v))
+		h.Write(buf[:])
+	}
+	if opts != nil {
+		fmt.Fprintf(h, "|%s|%t|%t|%t|%d|%g", opts.UserID, opts.SentOnly, opts.ReceivedOnly, opts.AllEmails, opts.Limit, opts.MinScore)
+		if opts.FolderID != nil {
+			fmt.Fprintf(h, "|f%d", *opts.FolderID)
+		}
+		fmt.Fprintf(h, "|t%s|l%v", opts.ThreadID, opts.LabelIDs)
+	}
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+var _ out.VectorStorePort = (*BreakerStore)(nil)
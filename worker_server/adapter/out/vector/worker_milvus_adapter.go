@@ -0,0 +1,292 @@
+package vector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"worker_server/core/port/out"
+
+	"github.com/goccy/go-json"
+)
+
+// milvusOutputFields - 검색/스캔 결과로 받을 필드 (id 외에는 dynamic field)
+var milvusOutputFields = []string{"user_id", "direction", "content", "metadata"}
+
+// MilvusAdapter stores email embeddings in a Milvus collection (RESTful API v2).
+// 기본 키 id = email ID, 나머지 필드는 dynamic field로 저장한다.
+type MilvusAdapter struct {
+	rest       *restClient
+	collection string
+
+	mu      sync.Mutex
+	ensured bool
+}
+
+// NewMilvusAdapter creates a new MilvusAdapter. token is "user:password" or an API key (empty = no auth).
+func NewMilvusAdapter(baseURL, token, collection string) *MilvusAdapter {
+	headers := map[string]string{}
+	if token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+	return &MilvusAdapter{
+		rest:       newRESTClient("milvus", baseURL, headers),
+		collection: collection,
+	}
+}
+
+type milvusResponse struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// call posts to a v2 endpoint. Milvus reports errors in the body code, not the HTTP status.
+func (a *MilvusAdapter) call(ctx context.Context, endpoint string, body map[string]any, data any) error {
+	body["collectionName"] = a.collection
+
+	var resp milvusResponse
+	if err := a.rest.do(ctx, http.MethodPost, "/v2/vectordb"+endpoint, body, &resp); err != nil {
+		return err
+	}
+	if resp.Code != 0 {
+		return fmt.Errorf("milvus %s failed (%d): %s", endpoint, resp.Code, resp.Message)
+	}
+	if data == nil || len(resp.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, data)
+}
+
+// exists reports whether the collection exists, creating it when dim > 0.
+func (a *MilvusAdapter) exists(ctx context.Context, dim int) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.ensured {
+		return true, nil
+	}
+
+	var has struct {
+		Has bool `json:"has"`
+	}
+	if err := a.call(ctx, "/collections/has", map[string]any{}, &has); err != nil {
+		return false, err
+	}
+	if !has.Has {
+		if dim == 0 {
+			return false, nil
+		}
+		// quick setup: COSINE 인덱스 생성 및 로드까지 수행, dynamic field 활성화
+		create := map[string]any{
+			"dimension":        dim,
+			"metricType":       "COSINE",
+			"idType":           "Int64",
+			"primaryFieldName": "id",
+			"vectorFieldName":  "vector",
+			"autoId":           false,
+		}
+		if err := a.call(ctx, "/collections/create", create, nil); err != nil {
+			return false, fmt.Errorf("failed to create milvus collection %s: %w", a.collection, err)
+		}
+	}
+
+	a.ensured = true
+	return true, nil
+}
+
+// Store implements out.VectorStorePort.
+func (a *MilvusAdapter) Store(ctx context.Context, record *out.EmailVector) error {
+	return a.StoreBatch(ctx, []*out.EmailVector{record})
+}
+
+// StoreBatch upserts the records in one request.
+func (a *MilvusAdapter) StoreBatch(ctx context.Context, records []*out.EmailVector) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if _, err := a.exists(ctx, len(records[0].Embedding)); err != nil {
+		return err
+	}
+
+	rows := make([]map[string]any, len(records))
+	for i, r := range records {
+		rows[i] = map[string]any{
+			"id":        r.EmailID,
+			"vector":    r.Embedding,
+			"user_id":   r.UserID,
+			"direction": r.Direction,
+			"content":   r.Content,
+			"metadata":  r.Metadata,
+		}
+	}
+	return a.call(ctx, "/entities/upsert", map[string]any{"data": rows}, nil)
+}
+
+// Search implements out.VectorStorePort.
+func (a *MilvusAdapter) Search(ctx context.Context, embedding []float32, opts *out.EmailVectorQuery) ([]*out.EmailVectorMatch, error) {
+	if opts.Limit == 0 {
+		opts.Limit = 10
+	}
+	if ok, err := a.exists(ctx, 0); err != nil || !ok {
+		return nil, err
+	}
+
+	filter := "user_id == " + strconv.Quote(opts.UserID)
+	if opts.SentOnly {
+		filter += ` and direction == "outbound"`
+	} else if opts.ReceivedOnly {
+		filter += ` and direction != "outbound"`
+	}
+	if opts.ThreadID != "" {
+		filter += ` and metadata["thread_id"] == ` + strconv.Quote(opts.ThreadID)
+	}
+	if opts.FolderID != nil {
+		filter += ` and metadata["folder_id"] == ` + strconv.FormatInt(*opts.FolderID, 10)
+	}
+	if len(opts.LabelIDs) > 0 {
+		ids := make([]string, len(opts.LabelIDs))
+		for i, id := range opts.LabelIDs {
+			ids[i] = strconv.FormatInt(id, 10)
+		}
+		filter += ` and array_contains_any(metadata["label_ids"], [` + strings.Join(ids, ",") + `])`
+	}
+
+	body := map[string]any{
+		"data":         [][]float32{embedding},
+		"annsField":    "vector",
+		"filter":       filter,
+		"limit":        opts.Limit,
+		"outputFields": milvusOutputFields,
+	}
+	var rows []map[string]any
+	if err := a.call(ctx, "/entities/search", body, &rows); err != nil {
+		return nil, err
+	}
+
+	results := make([]*out.EmailVectorMatch, 0, len(rows))
+	for _, row := range rows {
+		// COSINE metric: distance가 곧 유사도 (클수록 유사)
+		score, _ := row["distance"].(float64)
+		if opts.MinScore > 0 && score < opts.MinScore {
+			continue
+		}
+		results = append(results, &out.EmailVectorMatch{
+			EmailID:  milvusID(row),
+			Score:    score,
+			Content:  payloadString(row, "content"),
+			Metadata: payloadMap(row, "metadata"),
+		})
+	}
+	return results, nil
+}
+
+// Delete implements out.VectorStorePort.
+func (a *MilvusAdapter) Delete(ctx context.Context, emailID int64) error {
+	if ok, err := a.exists(ctx, 0); err != nil || !ok {
+		return err
+	}
+	return a.call(ctx, "/entities/delete", map[string]any{
+		"filter": "id in [" + strconv.FormatInt(emailID, 10) + "]",
+	}, nil)
+}
+
+// HasEmbedding implements out.VectorStorePort.
+func (a *MilvusAdapter) HasEmbedding(ctx context.Context, emailID int64) (bool, error) {
+	if ok, err := a.exists(ctx, 0); err != nil || !ok {
+		return false, err
+	}
+	var rows []map[string]any
+	err := a.call(ctx, "/entities/get", map[string]any{
+		"id":           []int64{emailID},
+		"outputFields": []string{"id"},
+	}, &rows)
+	if err != nil {
+		return false, err
+	}
+	return len(rows) > 0, nil
+}
+
+// Get implements out.VectorStorePort.
+func (a *MilvusAdapter) Get(ctx context.Context, emailID int64) (*out.EmailVector, error) {
+	if ok, err := a.exists(ctx, 0); err != nil || !ok {
+		return nil, err
+	}
+	var rows []map[string]any
+	err := a.call(ctx, "/entities/get", map[string]any{
+		"id":           []int64{emailID},
+		"outputFields": append([]string{"id", "vector"}, milvusOutputFields...),
+	}, &rows)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return milvusRecord(rows[0]), nil
+}
+
+// Scan implements out.VectorStorePort.
+// Milvus query는 기본 키 순서로 병합해 반환하지만, 순서 보장은 문서화되어 있지 않아 정렬 후 반환한다.
+func (a *MilvusAdapter) Scan(ctx context.Context, afterID int64, limit int) ([]*out.EmailVector, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	if ok, err := a.exists(ctx, 0); err != nil || !ok {
+		return nil, err
+	}
+
+	var rows []map[string]any
+	err := a.call(ctx, "/entities/query", map[string]any{
+		"filter":       "id > " + strconv.FormatInt(afterID, 10),
+		"limit":        limit,
+		"outputFields": append([]string{"id", "vector"}, milvusOutputFields...),
+	}, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*out.EmailVector, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, milvusRecord(row))
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].EmailID < records[j].EmailID })
+	return records, nil
+}
+
+func milvusRecord(row map[string]any) *out.EmailVector {
+	id := milvusID(row)
+	return &out.EmailVector{
+		ID:        id,
+		EmailID:   id,
+		UserID:    payloadString(row, "user_id"),
+		Direction: payloadString(row, "direction"),
+		Embedding: milvusVector(row["vector"]),
+		Content:   payloadString(row, "content"),
+		Metadata:  payloadMap(row, "metadata"),
+	}
+}
+
+// milvusID reads the int64 primary key (JSON number, or string for large IDs).
+func milvusID(row map[string]any) int64 {
+	switch v := row["id"].(type) {
+	case float64:
+		return int64(v)
+	case string:
+		id, _ := strconv.ParseInt(v, 10, 64)
+		return id
+	}
+	return 0
+}
+
+func milvusVector(v any) []float32 {
+	values, _ := v.([]any)
+	vec := make([]float32, 0, len(values))
+	for _, x := range values {
+		f, _ := x.(float64)
+		vec = append(vec, float32(f))
+	}
+	return vec
+}
+
+var _ out.VectorStorePort = (*MilvusAdapter)(nil)
@@ -0,0 +1,248 @@
+package vector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"worker_server/core/port/out"
+)
+
+// QdrantAdapter stores email embeddings in a Qdrant collection (REST API).
+// 포인트 ID = email ID, payload에 user_id/direction/content/metadata를 저장한다.
+type QdrantAdapter struct {
+	rest       *restClient
+	collection string
+
+	mu      sync.Mutex
+	ensured bool
+}
+
+// NewQdrantAdapter creates a new QdrantAdapter. apiKey may be empty for unauthenticated instances.
+func NewQdrantAdapter(baseURL, apiKey, collection string) *QdrantAdapter {
+	headers := map[string]string{}
+	if apiKey != "" {
+		headers["api-key"] = apiKey
+	}
+	return &QdrantAdapter{
+		rest:       newRESTClient("qdrant", baseURL, headers),
+		collection: collection,
+	}
+}
+
+type qdrantPoint struct {
+	ID      int64          `json:"id"`
+	Vector  []float32      `json:"vector,omitempty"`
+	Payload map[string]any `json:"payload,omitempty"`
+	Score   float64        `json:"score,omitempty"`
+}
+
+func (a *QdrantAdapter) path(suffix string) string {
+	return "/collections/" + url.PathEscape(a.collection) + suffix
+}
+
+// ensureCollection creates the collection (cosine distance) on first write.
+func (a *QdrantAdapter) ensureCollection(ctx context.Context, dim int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.ensured {
+		return nil
+	}
+
+	err := a.rest.do(ctx, http.MethodGet, a.path(""), nil, nil)
+	if err == errNotFound {
+		create := map[string]any{
+			"vectors": map[string]any{"size": dim, "distance": "Cosine"},
+		}
+		if err = a.rest.do(ctx, http.MethodPut, a.path(""), create, nil); err != nil {
+			return fmt.Errorf("failed to create qdrant collection %s: %w", a.collection, err)
+		}
+		// user_id 필터 검색용 payload 인덱스
+		index := map[string]any{"field_name": "user_id", "field_schema": "keyword"}
+		err = a.rest.do(ctx, http.MethodPut, a.path("/index?wait=true"), index, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	a.ensured = true
+	return nil
+}
+
+// Store implements out.VectorStorePort.
+func (a *QdrantAdapter) Store(ctx context.Context, record *out.EmailVector) error {
+	return a.StoreBatch(ctx, []*out.EmailVector{record})
+}
+
+// StoreBatch upserts the records in one request.
+func (a *QdrantAdapter) StoreBatch(ctx context.Context, records []*out.EmailVector) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if err := a.ensureCollection(ctx, len(records[0].Embedding)); err != nil {
+		return err
+	}
+
+	points := make([]qdrantPoint, len(records))
+	for i, r := range records {
+		points[i] = qdrantPoint{
+			ID:     r.EmailID,
+			Vector: r.Embedding,
+			Payload: map[string]any{
+				"user_id":   r.UserID,
+				"direction": r.Direction,
+				"content":   r.Content,
+				"metadata":  r.Metadata,
+			},
+		}
+	}
+	return a.rest.do(ctx, http.MethodPut, a.path("/points?wait=true"), map[string]any{"points": points}, nil)
+}
+
+// Search implements out.VectorStorePort.
+func (a *QdrantAdapter) Search(ctx context.Context, embedding []float32, opts *out.EmailVectorQuery) ([]*out.EmailVectorMatch, error) {
+	if opts.Limit == 0 {
+		opts.Limit = 10
+	}
+
+	filter := map[string]any{
+		"must": []any{
+			map[string]any{"key": "user_id", "match": map[string]any{"value": opts.UserID}},
+		},
+	}
+	direction := map[string]any{"key": "direction", "match": map[string]any{"value": "outbound"}}
+	if opts.SentOnly {
+		filter["must"] = append(filter["must"].([]any), direction)
+	} else if opts.ReceivedOnly {
+		filter["must_not"] = []any{direction}
+	}
+	if opts.ThreadID != "" {
+		filter["must"] = append(filter["must"].([]any), map[string]any{"key": "metadata.thread_id", "match": map[string]any{"value": opts.ThreadID}})
+	}
+	if opts.FolderID != nil {
+		filter["must"] = append(filter["must"].([]any), map[string]any{"key": "metadata.folder_id", "match": map[string]any{"value": *opts.FolderID}})
+	}
+	if len(opts.LabelIDs) > 0 {
+		filter["must"] = append(filter["must"].([]any), map[string]any{"key": "metadata.label_ids", "match": map[string]any{"any": opts.LabelIDs}})
+	}
+
+	body := map[string]any{
+		"vector":       embedding,
+		"filter":       filter,
+		"limit":        opts.Limit,
+		"with_payload": true,
+	}
+	if opts.MinScore > 0 {
+		body["score_threshold"] = opts.MinScore
+	}
+
+	var resp struct {
+		Result []qdrantPoint `json:"result"`
+	}
+	if err := a.rest.do(ctx, http.MethodPost, a.path("/points/search"), body, &resp); err != nil {
+		if err == errNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	results := make([]*out.EmailVectorMatch, 0, len(resp.Result))
+	for _, p := range resp.Result {
+		results = append(results, &out.EmailVectorMatch{
+			EmailID:  p.ID,
+			Score:    p.Score,
+			Content:  payloadString(p.Payload, "content"),
+			Metadata: payloadMap(p.Payload, "metadata"),
+		})
+	}
+	return results, nil
+}
+
+// Delete implements out.VectorStorePort.
+func (a *QdrantAdapter) Delete(ctx context.Context, emailID int64) error {
+	err := a.rest.do(ctx, http.MethodPost, a.path("/points/delete?wait=true"), map[string]any{"points": []int64{emailID}}, nil)
+	if err == errNotFound {
+		return nil
+	}
+	return err
+}
+
+// HasEmbedding implements out.VectorStorePort.
+func (a *QdrantAdapter) HasEmbedding(ctx context.Context, emailID int64) (bool, error) {
+	body := map[string]any{"ids": []int64{emailID}, "with_payload": false, "with_vector": false}
+	var resp struct {
+		Result []qdrantPoint `json:"result"`
+	}
+	if err := a.rest.do(ctx, http.MethodPost, a.path("/points"), body, &resp); err != nil {
+		if err == errNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(resp.Result) > 0, nil
+}
+
+// Get implements out.VectorStorePort.
+func (a *QdrantAdapter) Get(ctx context.Context, emailID int64) (*out.EmailVector, error) {
+	body := map[string]any{"ids": []int64{emailID}, "with_payload": true, "with_vector": true}
+	var resp struct {
+		Result []qdrantPoint `json:"result"`
+	}
+	if err := a.rest.do(ctx, http.MethodPost, a.path("/points"), body, &resp); err != nil {
+		if err == errNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(resp.Result) == 0 {
+		return nil, nil
+	}
+	return qdrantRecord(resp.Result[0]), nil
+}
+
+// Scan implements out.VectorStorePort. Qdrant scroll는 정수 ID 오름차순이므로 offset = afterID+1.
+func (a *QdrantAdapter) Scan(ctx context.Context, afterID int64, limit int) ([]*out.EmailVector, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	body := map[string]any{
+		"offset":       afterID + 1,
+		"limit":        limit,
+		"with_payload": true,
+		"with_vector":  true,
+	}
+	var resp struct {
+		Result struct {
+			Points []qdrantPoint `json:"points"`
+		} `json:"result"`
+	}
+	if err := a.rest.do(ctx, http.MethodPost, a.path("/points/scroll"), body, &resp); err != nil {
+		if err == errNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	records := make([]*out.EmailVector, 0, len(resp.Result.Points))
+	for _, p := range resp.Result.Points {
+		records = append(records, qdrantRecord(p))
+	}
+	return records, nil
+}
+
+func qdrantRecord(p qdrantPoint) *out.EmailVector {
+	return &out.EmailVector{
+		ID:        p.ID,
+		EmailID:   p.ID,
+		UserID:    payloadString(p.Payload, "user_id"),
+		Direction: payloadString(p.Payload, "direction"),
+		Embedding: p.Vector,
+		Content:   payloadString(p.Payload, "content"),
+		Metadata:  payloadMap(p.Payload, "metadata"),
+	}
+}
+
+var _ out.VectorStorePort = (*QdrantAdapter)(nil)
@@ -0,0 +1,91 @@
+// Package vector provides out.VectorStorePort adapters for external vector databases (Qdrant, Milvus).
+// pgvector 구현은 emails 테이블을 직접 사용하므로 rag.VectorStore에 있다.
+package vector
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// errNotFound is returned for HTTP 404 (e.g. the collection has not been created yet).
+var errNotFound = errors.New("not found")
+
+// restClient is a small JSON-over-HTTP client shared by the adapters.
+type restClient struct {
+	name    string
+	baseURL string
+	headers map[string]string
+	client  *http.Client
+}
+
+func newRESTClient(name, baseURL string, headers map[string]string) *restClient {
+	return &restClient{
+		name:    name,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		headers: headers,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// do sends body as JSON and decodes the response into result (if non-nil).
+func (c *restClient) do(ctx context.Context, method, path string, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", c.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+	if resp.StatusCode >= 400 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s returned %d: %s", c.name, resp.StatusCode, string(msg))
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", c.name, err)
+	}
+	return nil
+}
+
+// payloadString reads a string field from a decoded payload.
+func payloadString(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// payloadMap reads an object field from a decoded payload.
+func payloadMap(m map[string]any, key string) map[string]any {
+	v, _ := m[key].(map[string]any)
+	return v
+}
@@ -28,6 +28,24 @@ type Config struct {
 	MongoDBName string
 	RedisURL    string
 
+	// Read replica (목록/검색/통계 조회용, 비우면 primary만 사용)
+	DatabaseReplicaURL    string
+	ReplicaMaxLagSeconds  int // 허용 복제 지연, 넘으면 primary로 조회
+	ReplicaCheckIntervalS int // 복제 지연 확인 주기
+
+	// Connection pools & per-call deadlines (느린 의존성이 요청 goroutine을 붙잡지 않도록)
+	DBMaxConns            int // Postgres 풀 최대 연결 (pgxpool, sqlx 각각)
+	DBMaxIdleConns        int
+	DBStatementTimeoutMS  int // 서버 측 statement_timeout (0이면 설정 안 함, PgBouncer 트랜잭션 모드는 startup 파라미터 미지원)
+	DBQueryTimeoutMS      int // 리포지토리 조회 1회당 deadline
+	RedisPoolSize         int
+	RedisMinIdleConns     int
+	RedisTimeoutMS        int // 읽기/쓰기 timeout
+	RedisPoolTimeoutMS    int // 풀이 가득 찼을 때 연결 대기 한도
+	Neo4jMaxPoolSize      int
+	Neo4jAcquireTimeoutMS int // 연결 획득 대기 한도
+	Neo4jQueryTimeoutMS   int // 트랜잭션 1회당 timeout
+
 	// Neo4j
 	Neo4jURL      string
 	Neo4jUsername string
@@ -49,6 +67,26 @@ type Config struct {
 	LLMTimeoutSec  int
 	LLMMaxRetries  int
 
+	// ClassificationMode: auto (API 키가 있으면 LLM 사용) | heuristic (RFC 헤더 + 규칙만, LLM 호출 없음)
+	ClassificationMode string
+
+	// AIMonthlyBudgetUSD: 사용자별 월 AI 예산 (0 = 무제한). 초과 시 요약/임베딩 같은 비필수 작업을 중단
+	AIMonthlyBudgetUSD float64
+
+	// RAG 임베딩: 요청당 입력 수, 분당 요청 수 제한(0 = 무제한), 임베딩할 최소 길이(제목+본문 글자 수)
+	RAGEmbedBatchSize int
+	RAGEmbedRPM       int
+	RAGMinIndexChars  int
+
+	// VectorStore: 이메일 임베딩 저장소 pgvector (기본, emails.embedding) | qdrant | milvus
+	VectorStore      string
+	QdrantURL        string
+	QdrantAPIKey     string
+	QdrantCollection string
+	MilvusURL        string
+	MilvusToken      string
+	MilvusCollection string
+
 	// OAuth - Google
 	GoogleClientID     string
 	GoogleClientSecret string
@@ -82,6 +120,8 @@ type Config struct {
 	CacheCalendarTTLMin int
 	CacheSessionTTLHour int
 	CacheMaxEntries     int
+	CacheBodyTTLMin     int // 메일 본문 Redis 캐시 TTL (hot reload)
+	CacheListTTLMin     int // 메일 목록 Redis 캐시 TTL (hot reload)
 
 	// WebSocket
 	WSMaxMessageSize  int
@@ -95,14 +135,104 @@ type Config struct {
 	WebhookRetryDelaySec int
 	WebhookWorkerCount   int
 
+	// Mobile push - 설정된 플랫폼만 활성화 (FCM: 서비스 계정 JSON, APNs: .p8 인증 키)
+	FCMCredentialsFile string
+	FCMProjectID       string
+	APNsKeyFile        string
+	APNsKeyID          string
+	APNsTeamID         string
+	APNsTopic          string // iOS 앱 bundle id
+	APNsProduction     bool
+
 	// CORS
 	AllowedOrigins []string
 
+	// Rate Limit (라우트별 토큰 버킷, Redis 필요)
+	RateLimitEnabled       bool
+	RateLimitListPerMin    int
+	RateLimitListBurst     int
+	RateLimitSendPerMin    int
+	RateLimitSendBurst     int
+	RateLimitDefaultPerMin int
+	RateLimitDefaultBurst  int
+
+	// User Tier Quotas (Provider API 보호 레이어의 사용자별 한도, tier는 users.tier)
+	TierFreeMaxConcurrent int
+	TierFreeRPS           int
+	TierFreeMaxPayload    int
+	TierProMaxConcurrent  int
+	TierProRPS            int
+	TierProMaxPayload     int
+	TierProUsers          []string // DB와 관계없이 pro로 취급할 사용자 ID
+
 	// Scheduler
 	SchedulerEnabled bool
+
+	// Sync
+	SyncWindowMonths int // 초기 동기화 기간: 최근 N개월
+	SyncPartitions   int // mail:sync 파티션 수 (1이면 단일 스트림)
+
+	// Sync Lag SLO (Provider 최신 메일 대비 DB 지연, 초과 시 GapSync)
+	SyncLagThresholdMin     int
+	SyncLagCheckIntervalMin int
+
+	// History Backfill (POST /email/backfill - 요청당 최대 메시지 수, 사용자별 30일 할당량)
+	HistoryBackfillMaxMessages int
+	HistoryBackfillQuota       int
+
+	// Connection Storage (연결별 기본 용량 상한, 0 = 없음 - 초과 시 본문/임베딩 저장 중단)
+	StorageCapMB            int
+	StorageCheckIntervalMin int
+
+	// Admin (GET /admin/* 접근 가능한 사용자 ID)
+	AdminUserIDs []string
+
+	// Feature Flags (FEATURE_FLAGS="name,other=false")
+	FeatureFlags map[string]bool
+
+	// Hot Reload (CONFIG_FILE의 KEY=VALUE와 Redis config:overrides가 env보다 우선)
+	ConfigFile          string
+	ConfigWatchInterval time.Duration
+
+	// Public URL (메일 본문에 삽입되는 링크의 기준 주소)
+	PublicBaseURL string
+
+	// Safe Links
+	LinkSigningSecret  string
+	SafeBrowsingAPIKey string
+	LinkBlocklist      []string
+
+	// Contact Enrichment (새 중요 연락처의 회사/직함/아바타 조회 - Gravatar + Clearbit 스타일 API)
+	ContactEnrichmentEnabled bool
+	GravatarAPIKey           string
+	PersonAPIURL             string // 예: https://person.clearbit.com/v2/people/find
+	PersonAPIKey             string
+
+	// Send Tracking (보낸 메일 열람/클릭 추적 - 기본 비활성, 요청별 opt-in)
+	SendTrackingEnabled bool
+
+	// Image Proxy
+	ImageProxyEnabled   bool
+	ImageProxyMaxSizeMB int
+
+	// Body Cache
+	BodyCacheMaxBodyKB          int // 이보다 큰 본문은 캐시하지 않음
+	BodyCacheConnectionMaxMB    int // 연결당 MongoDB 본문 캐시 상한 (LRU 제거)
+	BodyCacheArchiveTTLDays     int // 오래된 메일 본문의 sliding TTL
+	BodyCachePrefetchSize       int // 인박스 첫 페이지 prefetch 개수
+	BodyCacheMetadataOnlyTTLMin int // metadata-only 연결의 Redis 본문 TTL (열람 시 가져온 본문)
+
+	// Email Archive (대용량 메일함의 오래된 메일을 아카이브 파티션으로 이동)
+	EmailArchiveAfterMonths int // 0이면 아카이브 안 함
+	EmailArchiveMinEmails   int // 이 이상 받은 메일함만 대상
 }
 
 func Load() (*Config, error) {
+	// 첫 로드 시 CONFIG_FILE 값을 적용한다 (이후에는 Watcher가 갱신)
+	if overrides.Load() == nil {
+		seedOverrides(os.Getenv("CONFIG_FILE"))
+	}
+
 	return &Config{
 		Port:        getEnv("PORT", "8080"),
 		Environment: getEnv("ENV", "development"),
@@ -114,6 +244,24 @@ func Load() (*Config, error) {
 		MongoDBName: getEnv("MONGODB_DATABASE", "bridgify"),
 		RedisURL:    getEnv("REDIS_URL", ""),
 
+		// Read replica
+		DatabaseReplicaURL:    getEnv("DATABASE_REPLICA_URL", ""),
+		ReplicaMaxLagSeconds:  getEnvInt("DB_REPLICA_MAX_LAG_SECONDS", 5),
+		ReplicaCheckIntervalS: getEnvInt("DB_REPLICA_CHECK_INTERVAL_SECONDS", 10),
+
+		// Connection pools
+		DBMaxConns:            getEnvInt("DB_MAX_CONNS", 25),
+		DBMaxIdleConns:        getEnvInt("DB_MAX_IDLE_CONNS", 10),
+		DBStatementTimeoutMS:  getEnvInt("DB_STATEMENT_TIMEOUT_MS", 0),
+		DBQueryTimeoutMS:      getEnvInt("DB_QUERY_TIMEOUT_MS", 10000),
+		RedisPoolSize:         getEnvInt("REDIS_POOL_SIZE", 50),
+		RedisMinIdleConns:     getEnvInt("REDIS_MIN_IDLE_CONNS", 10),
+		RedisTimeoutMS:        getEnvInt("REDIS_TIMEOUT_MS", 3000),
+		RedisPoolTimeoutMS:    getEnvInt("REDIS_POOL_TIMEOUT_MS", 4000),
+		Neo4jMaxPoolSize:      getEnvInt("NEO4J_MAX_POOL_SIZE", 50),
+		Neo4jAcquireTimeoutMS: getEnvInt("NEO4J_ACQUIRE_TIMEOUT_MS", 5000),
+		Neo4jQueryTimeoutMS:   getEnvInt("NEO4J_QUERY_TIMEOUT_MS", 10000),
+
 		// Neo4j
 		Neo4jURL:      getEnv("NEO4J_URL", ""),
 		Neo4jUsername: getEnv("NEO4J_USERNAME", "neo4j"),
@@ -135,6 +283,21 @@ func Load() (*Config, error) {
 		LLMTimeoutSec:  getEnvInt("LLM_TIMEOUT_SEC", 60),
 		LLMMaxRetries:  getEnvInt("LLM_MAX_RETRIES", 3),
 
+		ClassificationMode: getEnv("CLASSIFICATION_MODE", "auto"),
+		AIMonthlyBudgetUSD: getEnvFloat("AI_MONTHLY_BUDGET_USD", 0),
+		RAGEmbedBatchSize:  getEnvInt("RAG_EMBED_BATCH_SIZE", 100),
+		RAGEmbedRPM:        getEnvInt("RAG_EMBED_RPM", 300),
+		RAGMinIndexChars:   getEnvInt("RAG_MIN_INDEX_CHARS", 80),
+
+		// Vector store
+		VectorStore:      getEnv("VECTOR_STORE", "pgvector"),
+		QdrantURL:        getEnv("QDRANT_URL", "http://localhost:6333"),
+		QdrantAPIKey:     getEnv("QDRANT_API_KEY", ""),
+		QdrantCollection: getEnv("QDRANT_COLLECTION", "email_embeddings"),
+		MilvusURL:        getEnv("MILVUS_URL", "http://localhost:19530"),
+		MilvusToken:      getEnv("MILVUS_TOKEN", ""),
+		MilvusCollection: getEnv("MILVUS_COLLECTION", "email_embeddings"),
+
 		// OAuth - Google
 		GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
 		GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
@@ -168,6 +331,8 @@ func Load() (*Config, error) {
 		CacheCalendarTTLMin: getEnvInt("CACHE_CALENDAR_TTL_MIN", 15),
 		CacheSessionTTLHour: getEnvInt("CACHE_SESSION_TTL_HOUR", 24),
 		CacheMaxEntries:     getEnvInt("CACHE_MAX_ENTRIES", 10000),
+		CacheBodyTTLMin:     getEnvInt("CACHE_BODY_TTL_MIN", 30),
+		CacheListTTLMin:     getEnvInt("CACHE_LIST_TTL_MIN", 15),
 
 		// WebSocket
 		WSMaxMessageSize:  getEnvInt("WS_MAX_MESSAGE_SIZE", 524288),
@@ -181,23 +346,108 @@ func Load() (*Config, error) {
 		WebhookRetryDelaySec: getEnvInt("WEBHOOK_RETRY_DELAY_SEC", 5),
 		WebhookWorkerCount:   getEnvInt("WEBHOOK_WORKER_COUNT", 10),
 
+		// Mobile push
+		FCMCredentialsFile: getEnv("FCM_CREDENTIALS_FILE", ""),
+		FCMProjectID:       getEnv("FCM_PROJECT_ID", ""),
+		APNsKeyFile:        getEnv("APNS_KEY_FILE", ""),
+		APNsKeyID:          getEnv("APNS_KEY_ID", ""),
+		APNsTeamID:         getEnv("APNS_TEAM_ID", ""),
+		APNsTopic:          getEnv("APNS_TOPIC", ""),
+		APNsProduction:     getEnvBool("APNS_PRODUCTION", false),
+
 		// CORS
 		AllowedOrigins: getEnvSlice("ALLOWED_ORIGINS", []string{"http://localhost:3000", "http://localhost:5173"}),
 
+		// Rate Limit
+		RateLimitEnabled:       getEnvBool("RATE_LIMIT_ENABLED", true),
+		RateLimitListPerMin:    getEnvInt("RATE_LIMIT_LIST_PER_MIN", 120),
+		RateLimitListBurst:     getEnvInt("RATE_LIMIT_LIST_BURST", 60),
+		RateLimitSendPerMin:    getEnvInt("RATE_LIMIT_SEND_PER_MIN", 20),
+		RateLimitSendBurst:     getEnvInt("RATE_LIMIT_SEND_BURST", 5),
+		RateLimitDefaultPerMin: getEnvInt("RATE_LIMIT_DEFAULT_PER_MIN", 600),
+		RateLimitDefaultBurst:  getEnvInt("RATE_LIMIT_DEFAULT_BURST", 120),
+
+		// User Tier Quotas
+		TierFreeMaxConcurrent: getEnvInt("TIER_FREE_MAX_CONCURRENT", 2),
+		TierFreeRPS:           getEnvInt("TIER_FREE_RPS", 2),
+		TierFreeMaxPayload:    getEnvInt("TIER_FREE_MAX_PAYLOAD", 50),
+		TierProMaxConcurrent:  getEnvInt("TIER_PRO_MAX_CONCURRENT", 8),
+		TierProRPS:            getEnvInt("TIER_PRO_RPS", 10),
+		TierProMaxPayload:     getEnvInt("TIER_PRO_MAX_PAYLOAD", 100),
+		TierProUsers:          getEnvSlice("TIER_PRO_USERS", nil),
+
 		// Scheduler
 		SchedulerEnabled: getEnvBool("SCHEDULER_ENABLED", true),
+
+		// Sync
+		SyncWindowMonths: getEnvInt("SYNC_WINDOW_MONTHS", 3),
+		SyncPartitions:   getEnvInt("SYNC_PARTITIONS", 1),
+
+		// Sync Lag SLO
+		SyncLagThresholdMin:     getEnvInt("SYNC_LAG_THRESHOLD_MIN", 15),
+		SyncLagCheckIntervalMin: getEnvInt("SYNC_LAG_CHECK_INTERVAL_MIN", 5),
+
+		// History Backfill
+		HistoryBackfillMaxMessages: getEnvInt("HISTORY_BACKFILL_MAX_MESSAGES", 20000),
+		HistoryBackfillQuota:       getEnvInt("HISTORY_BACKFILL_QUOTA", 100000),
+
+		// Connection Storage
+		StorageCapMB:            getEnvInt("STORAGE_CAP_MB", 0),
+		StorageCheckIntervalMin: getEnvInt("STORAGE_CHECK_INTERVAL_MIN", 60),
+
+		// Admin
+		AdminUserIDs: getEnvSlice("ADMIN_USER_IDS", nil),
+
+		// Feature Flags
+		FeatureFlags: getEnvFlags("FEATURE_FLAGS"),
+
+		// Hot Reload
+		ConfigFile:          getEnv("CONFIG_FILE", ""),
+		ConfigWatchInterval: time.Duration(getEnvInt("CONFIG_WATCH_INTERVAL_SEC", 30)) * time.Second,
+
+		// Public URL
+		PublicBaseURL: strings.TrimRight(getEnv("PUBLIC_BASE_URL", ""), "/"),
+
+		// Safe Links (서명 키 미설정 시 JWT secret 사용)
+		LinkSigningSecret:  getEnv("LINK_SIGNING_SECRET", getEnv("SUPABASE_JWT_SECRET", "")),
+		SafeBrowsingAPIKey: getEnv("SAFE_BROWSING_API_KEY", ""),
+		LinkBlocklist:      getEnvSlice("LINK_BLOCKLIST", nil),
+
+		// Contact Enrichment
+		ContactEnrichmentEnabled: getEnvBool("CONTACT_ENRICHMENT_ENABLED", false),
+		GravatarAPIKey:           getEnv("GRAVATAR_API_KEY", ""),
+		PersonAPIURL:             getEnv("PERSON_API_URL", ""),
+		PersonAPIKey:             getEnv("PERSON_API_KEY", ""),
+
+		// Send Tracking
+		SendTrackingEnabled: getEnvBool("SEND_TRACKING_ENABLED", false),
+
+		// Image Proxy
+		ImageProxyEnabled:   getEnvBool("IMAGE_PROXY_ENABLED", true),
+		ImageProxyMaxSizeMB: getEnvInt("IMAGE_PROXY_MAX_SIZE_MB", 5),
+
+		// Body Cache
+		BodyCacheMaxBodyKB:          getEnvInt("BODY_CACHE_MAX_BODY_KB", 2048),
+		BodyCacheConnectionMaxMB:    getEnvInt("BODY_CACHE_CONNECTION_MAX_MB", 256),
+		BodyCacheArchiveTTLDays:     getEnvInt("BODY_CACHE_ARCHIVE_TTL_DAYS", 7),
+		BodyCachePrefetchSize:       getEnvInt("BODY_CACHE_PREFETCH_SIZE", 20),
+		BodyCacheMetadataOnlyTTLMin: getEnvInt("BODY_CACHE_METADATA_ONLY_TTL_MIN", 5),
+
+		// Email Archive
+		EmailArchiveAfterMonths: getEnvInt("EMAIL_ARCHIVE_AFTER_MONTHS", 12),
+		EmailArchiveMinEmails:   getEnvInt("EMAIL_ARCHIVE_MIN_EMAILS", 500000),
 	}, nil
 }
 
 func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := lookupEnv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
 func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
+	if value := lookupEnv(key); value != "" {
 		if intValue, err := strconv.Atoi(value); err == nil {
 			return intValue
 		}
@@ -206,7 +456,7 @@ func getEnvInt(key string, defaultValue int) int {
 }
 
 func getEnvFloat(key string, defaultValue float64) float64 {
-	if value := os.Getenv(key); value != "" {
+	if value := lookupEnv(key); value != "" {
 		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
 			return floatValue
 		}
@@ -215,7 +465,7 @@ func getEnvFloat(key string, defaultValue float64) float64 {
 }
 
 func getEnvBool(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
+	if value := lookupEnv(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
 			return boolValue
 		}
@@ -224,12 +474,40 @@ func getEnvBool(key string, defaultValue bool) bool {
 }
 
 func getEnvSlice(key string, defaultValue []string) []string {
-	if value := os.Getenv(key); value != "" {
+	if value := lookupEnv(key); value != "" {
 		return strings.Split(value, ",")
 	}
 	return defaultValue
 }
 
+// getEnvFlags parses "a,b=false,c" into {a: true, b: false, c: true}.
+func getEnvFlags(key string) map[string]bool {
+	flags := map[string]bool{}
+	for _, item := range strings.Split(lookupEnv(key), ",") {
+		name, value, hasValue := strings.Cut(strings.TrimSpace(item), "=")
+		if name == "" {
+			continue
+		}
+		enabled := true
+		if hasValue {
+			enabled, _ = strconv.ParseBool(strings.TrimSpace(value))
+		}
+		flags[name] = enabled
+	}
+	return flags
+}
+
+// Feature reports whether the named feature flag is enabled.
+func (c *Config) Feature(name string) bool {
+	return c.FeatureFlags[name]
+}
+
+// HeuristicClassification reports whether classification runs without the LLM stage.
+// API 키가 없으면 CLASSIFICATION_MODE와 관계없이 heuristic으로 동작한다.
+func (c *Config) HeuristicClassification() bool {
+	return strings.EqualFold(c.ClassificationMode, "heuristic") || c.OpenAIAPIKey == ""
+}
+
 // IsDevelopment returns true if running in development mode
 func (c *Config) IsDevelopment() bool {
 	return c.Environment == "development"
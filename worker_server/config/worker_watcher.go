@@ -0,0 +1,221 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"worker_server/pkg/logger"
+
+	"github.com/joho/godotenv"
+)
+
+// RemoteOverridesKey is the Redis hash holding remote overrides (field = env key).
+//
+//	HSET config:overrides RATE_LIMIT_SEND_PER_MIN 10 FEATURE_FLAGS "smart_reply"
+const RemoteOverridesKey = "config:overrides"
+
+// hotReloadable lists the fields applied at runtime; other changes need a restart.
+var hotReloadable = map[string]bool{
+	"RateLimitEnabled":       true,
+	"RateLimitListPerMin":    true,
+	"RateLimitListBurst":     true,
+	"RateLimitSendPerMin":    true,
+	"RateLimitSendBurst":     true,
+	"RateLimitDefaultPerMin": true,
+	"RateLimitDefaultBurst":  true,
+	"CacheBodyTTLMin":        true,
+	"CacheListTTLMin":        true,
+	"SyncWindowMonths":       true,
+	"FeatureFlags":           true,
+}
+
+// overrides holds values from the config file and remote source.
+// 파일/원격 값이 프로세스 env보다 우선한다.
+var overrides atomic.Pointer[map[string]string]
+
+func lookupEnv(key string) string {
+	if m := overrides.Load(); m != nil {
+		if value, ok := (*m)[key]; ok && value != "" {
+			return value
+		}
+	}
+	return os.Getenv(key)
+}
+
+func seedOverrides(file string) {
+	values := map[string]string{}
+	if file != "" {
+		read, err := godotenv.Read(file)
+		if err != nil {
+			logger.Warn("[Config] Failed to read CONFIG_FILE %s: %v", file, err)
+		} else {
+			values = read
+		}
+	}
+	overrides.Store(&values)
+}
+
+// Source returns overrides keyed by env variable name.
+type Source func(ctx context.Context) (map[string]string, error)
+
+// Watcher reloads the configuration from CONFIG_FILE and an optional remote source
+// on an interval or SIGHUP, and notifies subscribers of hot-reloadable changes.
+type Watcher struct {
+	current  atomic.Pointer[Config]
+	file     string
+	remote   Source
+	interval time.Duration
+
+	mu          sync.Mutex // Reload 직렬화 + handlers 보호
+	handlers    []func(old, new *Config)
+	lastPending string // 같은 재시작 필요 경고를 반복하지 않기 위함
+}
+
+// NewWatcher creates a watcher starting from cfg.
+func NewWatcher(cfg *Config) *Watcher {
+	w := &Watcher{
+		file:     cfg.ConfigFile,
+		interval: cfg.ConfigWatchInterval,
+	}
+	w.current.Store(cfg)
+	return w
+}
+
+// SetRemoteSource sets the remote override source (e.g. Redis hash).
+func (w *Watcher) SetRemoteSource(src Source) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.remote = src
+}
+
+// Current returns the latest effective configuration.
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// OnChange registers fn, called after hot-reloadable fields change.
+func (w *Watcher) OnChange(fn func(old, new *Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers = append(w.handlers, fn)
+}
+
+// Start polls for changes until ctx is done. SIGHUP triggers an immediate reload.
+func (w *Watcher) Start(ctx context.Context) {
+	if w.interval <= 0 {
+		w.interval = 30 * time.Second
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(hup)
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-hup:
+				logger.Info("[ConfigWatcher] SIGHUP received, reloading config")
+			}
+			if err := w.Reload(ctx); err != nil {
+				logger.Warn("[ConfigWatcher] Reload failed: %v", err)
+			}
+		}
+	}()
+}
+
+// Reload re-reads all sources and applies hot-reloadable changes.
+// 재시작이 필요한 필드가 바뀌면 경고만 남기고 이전 값을 유지한다.
+func (w *Watcher) Reload(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	merged, err := w.readSources(ctx)
+	if err != nil {
+		return err
+	}
+	overrides.Store(&merged)
+
+	loaded, err := Load()
+	if err != nil {
+		return err
+	}
+
+	old := w.current.Load()
+	next := *old
+	var applied, restartOnly []string
+
+	ov, nv, xv := reflect.ValueOf(old).Elem(), reflect.ValueOf(loaded).Elem(), reflect.ValueOf(&next).Elem()
+	for i := 0; i < ov.NumField(); i++ {
+		name := ov.Type().Field(i).Name
+		if name == "WorkerID" || reflect.DeepEqual(ov.Field(i).Interface(), nv.Field(i).Interface()) {
+			continue
+		}
+		if hotReloadable[name] {
+			xv.Field(i).Set(nv.Field(i))
+			applied = append(applied, name)
+		} else {
+			restartOnly = append(restartOnly, name)
+		}
+	}
+
+	sort.Strings(restartOnly)
+	if pending := strings.Join(restartOnly, ", "); pending != w.lastPending {
+		if pending != "" {
+			logger.Warn("[ConfigWatcher] Changes require restart: %s", pending)
+		}
+		w.lastPending = pending
+	}
+	if len(applied) == 0 {
+		return nil
+	}
+
+	w.current.Store(&next)
+	logger.Info("[ConfigWatcher] Applied: %s", strings.Join(applied, ", "))
+	for _, fn := range w.handlers {
+		fn(old, &next)
+	}
+	return nil
+}
+
+// readSources merges the config file and remote overrides (remote wins).
+func (w *Watcher) readSources(ctx context.Context) (map[string]string, error) {
+	merged := map[string]string{}
+
+	if w.file != "" {
+		values, err := godotenv.Read(w.file)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		for k, v := range values {
+			merged[k] = v
+		}
+	}
+
+	if w.remote != nil {
+		values, err := w.remote(ctx)
+		if err != nil {
+			// 원격 저장소 장애 시 이전 원격 값을 유지하지 않고 파일/env만 사용하면
+			// 설정이 출렁이므로 reload 자체를 건너뛴다.
+			return nil, err
+		}
+		for k, v := range values {
+			merged[k] = v
+		}
+	}
+	return merged, nil
+}
@@ -37,6 +37,10 @@ const (
 	TaskReply      TaskType = "reply"       // 답장 생성 → standard
 	TaskIntent     TaskType = "intent"      // 의도 분석 → mini
 	TaskToolSelect TaskType = "tool_select" // 도구 선택 → mini
+	TaskTranslate  TaskType = "translate"   // 번역 → mini
+	TaskExtract    TaskType = "extract"     // 일정/미팅 추출 → mini
+	TaskChat       TaskType = "chat"        // 에이전트 대화
+	TaskEmbed      TaskType = "embed"       // 임베딩 (RAG 인덱싱/검색)
 )
 
 // GetModelForTask returns the appropriate model for a task type
@@ -105,6 +109,7 @@ func (c *Client) ClassifyEmailBatch(ctx context.Context, emails []BatchClassifyI
 	if err != nil {
 		return nil, fmt.Errorf("batch classify failed: %w", err)
 	}
+	c.recordUsage(ctx, string(ModelMini), resp.Usage)
 
 	if len(resp.Choices) == 0 {
 		return nil, fmt.Errorf("no response from LLM")
@@ -308,6 +313,7 @@ func (c *Client) SummarizeEmailBatch(ctx context.Context, emails []BatchSummariz
 	if err != nil {
 		return nil, err
 	}
+	c.recordUsage(ctx, string(ModelMini), resp.Usage)
 
 	if len(resp.Choices) == 0 {
 		return nil, fmt.Errorf("no response")
@@ -343,6 +349,11 @@ var modelPricing = map[string]struct {
 }{
 	"gpt-4o-mini": {InputPer1M: 0.15, OutputPer1M: 0.60},
 	"gpt-4o":      {InputPer1M: 5.00, OutputPer1M: 15.00},
+
+	// Embeddings (입력 토큰만 과금)
+	"text-embedding-ada-002": {InputPer1M: 0.10},
+	"text-embedding-3-small": {InputPer1M: 0.02},
+	"text-embedding-3-large": {InputPer1M: 0.13},
 }
 
 // CalculateCost calculates estimated cost for token usage
@@ -156,37 +156,124 @@ type UserLLMRules struct {
 	LowPriorityRules   string // "내부 공지는 낮은 우선순위"
 	CategoryRules      string // "HR팀 메일은 admin 카테고리"
 	CustomInstructions string // 추가 지시사항
+
+	Categories []CustomCategory // 사용자가 만든 카테고리 (기본 카테고리에 추가)
+}
+
+// CustomCategory is a user-defined category the LLM may assign.
+type CustomCategory struct {
+	Key         string
+	Name        string
+	Description string
 }
 
 // ClassifyEmailWithUserRules performs email classification with user's natural language rules.
 // This is used in Stage 3 of the classification pipeline.
 func (c *Client) ClassifyEmailWithUserRules(ctx context.Context, email *domain.Email, body string, userRules *UserLLMRules) (*EnhancedClassificationResponse, error) {
-	// Build user rules context
-	userRulesContext := ""
-	if userRules != nil {
-		var rulesParts []string
+	systemPrompt := `You are an email classification AI. Analyze the email and respond with JSON only.
 
-		if userRules.HighPriorityRules != "" {
-			rulesParts = append(rulesParts, fmt.Sprintf("High Priority Rules: %s", userRules.HighPriorityRules))
-		}
-		if userRules.LowPriorityRules != "" {
-			rulesParts = append(rulesParts, fmt.Sprintf("Low Priority Rules: %s", userRules.LowPriorityRules))
-		}
-		if userRules.CategoryRules != "" {
-			rulesParts = append(rulesParts, fmt.Sprintf("Category Rules: %s", userRules.CategoryRules))
-		}
-		if userRules.CustomInstructions != "" {
-			rulesParts = append(rulesParts, fmt.Sprintf("Custom Instructions: %s", userRules.CustomInstructions))
-		}
+` + enhancedClassifyGuide + userRulesPrompt(userRules) + `
 
-		if len(rulesParts) > 0 {
-			userRulesContext = "\n\n## User-Defined Rules (MUST follow):\n" + strings.Join(rulesParts, "\n")
-		}
+Respond with this exact JSON format:
+{
+  "category": "category_name",
+  "sub_category": "sub_category_name or empty",
+  "priority": 0.0-1.0,
+  "summary": "brief 1-2 sentence summary in the same language as the email",
+  "tags": ["tag1", "tag2"],
+  "score": 0.0-1.0
+}`
+
+	resp, err := c.CompleteWithSystem(ctx, systemPrompt, emailPrompt(email, body, 2000))
+	if err != nil {
+		return nil, err
 	}
 
-	systemPrompt := `You are an email classification AI. Analyze the email and respond with JSON only.
+	// Parse JSON response
+	var result EnhancedClassificationResponse
+	if err := json.Unmarshal([]byte(trimJSONFence(resp)), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse classification response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// MaxClassifyBatch is the maximum number of emails sent in one batched classification request.
+const MaxClassifyBatch = 20
+
+// BatchEmailInput is one email of a batched classification request.
+type BatchEmailInput struct {
+	Email *domain.Email
+	Body  string
+}
+
+// ClassifyEmailsWithUserRules classifies emails of one user in a single LLM call.
+// 응답에 빠졌거나 형식이 잘못된 항목은 결과 map에 없으므로 호출자가 개별 분류로 fallback해야 한다.
+func (c *Client) ClassifyEmailsWithUserRules(ctx context.Context, emails []BatchEmailInput, userRules *UserLLMRules) (map[int64]*EnhancedClassificationResponse, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	if len(emails) > MaxClassifyBatch {
+		return nil, fmt.Errorf("batch of %d emails exceeds limit %d", len(emails), MaxClassifyBatch)
+	}
+
+	systemPrompt := `You are an email classification AI. Classify EACH email independently and respond with JSON only.
+
+` + enhancedClassifyGuide + userRulesPrompt(userRules) + `
+
+Respond with this exact JSON format, one result per email, using the id given in [brackets]:
+{
+  "results": [
+    {"id": 1, "category": "category_name", "sub_category": "", "priority": 0.0-1.0, "tags": ["tag1"], "score": 0.0-1.0}
+  ]
+}`
+
+	// 이메일당 본문을 짧게 잘라 20개를 묶어도 컨텍스트를 넘지 않도록 한다
+	var sb strings.Builder
+	for _, e := range emails {
+		sb.WriteString(fmt.Sprintf("[%d]\n%s\n\n", e.Email.ID, emailPrompt(e.Email, e.Body, 500)))
+	}
+
+	resp, err := c.CompleteWithSystem(ctx, systemPrompt, sb.String())
+	if err != nil {
+		return nil, err
+	}
+	return parseBatchClassification(resp, emails)
+}
 
-## Categories (pick ONE):
+// batchClassificationItem is one result of a batched classification response.
+type batchClassificationItem struct {
+	ID int64 `json:"id"`
+	EnhancedClassificationResponse
+}
+
+// parseBatchClassification keeps results whose id belongs to the request and has a category.
+func parseBatchClassification(resp string, emails []BatchEmailInput) (map[int64]*EnhancedClassificationResponse, error) {
+	var parsed struct {
+		Results []batchClassificationItem `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(trimJSONFence(resp)), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse batch classification response: %w", err)
+	}
+
+	requested := make(map[int64]bool, len(emails))
+	for _, e := range emails {
+		requested[e.Email.ID] = true
+	}
+
+	results := make(map[int64]*EnhancedClassificationResponse, len(parsed.Results))
+	for i := range parsed.Results {
+		item := &parsed.Results[i]
+		if !requested[item.ID] || item.Category == "" {
+			continue
+		}
+		results[item.ID] = &item.EnhancedClassificationResponse
+	}
+	return results, nil
+}
+
+// enhancedClassifyGuide describes categories and priority levels for the enhanced classification prompts.
+const enhancedClassifyGuide = `## Categories (pick ONE):
 - work: Work-related emails (meetings, projects, colleagues, clients)
 - personal: Personal emails from friends/family
 - finance: Banking, payments, invoices, receipts
@@ -205,43 +292,60 @@ Focus on distinguishing work vs personal emails and their importance.
 - 0.60-0.79: High (should read soon)
 - 0.40-0.59: Normal (standard priority)
 - 0.20-0.39: Low (not urgent)
-- 0.00-0.19: Lowest (can read later)` + userRulesContext + `
+- 0.00-0.19: Lowest (can read later)`
 
-Respond with this exact JSON format:
-{
-  "category": "category_name",
-  "sub_category": "sub_category_name or empty",
-  "priority": 0.0-1.0,
-  "summary": "brief 1-2 sentence summary in the same language as the email",
-  "tags": ["tag1", "tag2"],
-  "score": 0.0-1.0
-}`
+// userRulesPrompt builds the user rules section of the classification prompt.
+func userRulesPrompt(userRules *UserLLMRules) string {
+	if userRules == nil {
+		return ""
+	}
+
+	var rulesParts []string
+	if userRules.HighPriorityRules != "" {
+		rulesParts = append(rulesParts, fmt.Sprintf("High Priority Rules: %s", userRules.HighPriorityRules))
+	}
+	if userRules.LowPriorityRules != "" {
+		rulesParts = append(rulesParts, fmt.Sprintf("Low Priority Rules: %s", userRules.LowPriorityRules))
+	}
+	if userRules.CategoryRules != "" {
+		rulesParts = append(rulesParts, fmt.Sprintf("Category Rules: %s", userRules.CategoryRules))
+	}
+	if userRules.CustomInstructions != "" {
+		rulesParts = append(rulesParts, fmt.Sprintf("Custom Instructions: %s", userRules.CustomInstructions))
+	}
+
+	var prompt string
+	if len(rulesParts) > 0 {
+		prompt = "\n\n## User-Defined Rules (MUST follow):\n" + strings.Join(rulesParts, "\n")
+	}
+	if len(userRules.Categories) > 0 {
+		categoryParts := make([]string, len(userRules.Categories))
+		for i, c := range userRules.Categories {
+			categoryParts[i] = fmt.Sprintf("- %s (%s): %s", c.Key, c.Name, c.Description)
+		}
+		prompt += "\n\n## User Categories (use the key as category when the email fits better than the built-in ones):\n" +
+			strings.Join(categoryParts, "\n")
+	}
+	return prompt
+}
 
+// emailPrompt formats an email for classification prompts.
+func emailPrompt(email *domain.Email, body string, maxBodyLen int) string {
 	fromName := ""
 	if email.FromName != nil {
 		fromName = *email.FromName
 	}
-	userPrompt := fmt.Sprintf("From: %s <%s>\nSubject: %s\nDate: %s\n\nBody:\n%s",
+	return fmt.Sprintf("From: %s <%s>\nSubject: %s\nDate: %s\n\nBody:\n%s",
 		fromName, email.FromEmail, email.Subject,
 		email.ReceivedAt.Format("2006-01-02 15:04"),
-		truncateBody(body, 2000))
-
-	resp, err := c.CompleteWithSystem(ctx, systemPrompt, userPrompt)
-	if err != nil {
-		return nil, err
-	}
+		truncateBody(body, maxBodyLen))
+}
 
-	// Parse JSON response
-	var result EnhancedClassificationResponse
+// trimJSONFence strips a markdown code fence around a JSON response.
+func trimJSONFence(resp string) string {
 	resp = strings.TrimPrefix(resp, "```json")
 	resp = strings.TrimSuffix(resp, "```")
-	resp = strings.TrimSpace(resp)
-
-	if err := json.Unmarshal([]byte(resp), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse classification response: %w", err)
-	}
-
-	return &result, nil
+	return strings.TrimSpace(resp)
 }
 
 func truncateBody(body string, maxLen int) string {
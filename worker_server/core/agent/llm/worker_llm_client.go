@@ -15,6 +15,9 @@ type Client struct {
 	model       string
 	maxTokens   int
 	temperature float32
+
+	// usage receives token usage of every call (nil = 기록 안 함)
+	usage UsageRecorder
 }
 
 type ClientConfig struct {
@@ -56,6 +59,13 @@ func NewClientWithConfig(cfg ClientConfig) *Client {
 	}
 }
 
+// WithModel returns a copy of the client that sends requests to model (분류 재처리 등 호출별 모델 지정).
+func (c *Client) WithModel(model string) *Client {
+	clone := *c
+	clone.model = model
+	return &clone
+}
+
 func (c *Client) Complete(ctx context.Context, prompt string) (string, error) {
 	resp, err := c.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
 		Model: c.model,
@@ -69,6 +79,7 @@ func (c *Client) Complete(ctx context.Context, prompt string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	c.recordUsage(ctx, c.model, resp.Usage)
 
 	if len(resp.Choices) == 0 {
 		return "", nil
@@ -94,6 +105,7 @@ func (c *Client) CompleteWithSystem(ctx context.Context, systemPrompt, userPromp
 	if err != nil {
 		return "", err
 	}
+	c.recordUsage(ctx, c.model, resp.Usage)
 
 	if len(resp.Choices) == 0 {
 		return "", nil
@@ -102,6 +114,7 @@ func (c *Client) CompleteWithSystem(ctx context.Context, systemPrompt, userPromp
 	return resp.Choices[0].Message.Content, nil
 }
 
+// Stream streams a completion. 스트리밍 응답에는 usage가 없어 사용량이 기록되지 않는다.
 func (c *Client) Stream(ctx context.Context, prompt string, handler func(chunk string) error) error {
 	stream, err := c.client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
 		Model: c.model,
@@ -141,6 +154,7 @@ func (c *Client) Embedding(ctx context.Context, text string) ([]float32, error)
 	if err != nil {
 		return nil, err
 	}
+	c.recordUsage(ctx, openai.AdaEmbeddingV2.String(), resp.Usage)
 
 	if len(resp.Data) == 0 {
 		return nil, nil
@@ -157,6 +171,7 @@ func (c *Client) EmbeddingBatch(ctx context.Context, texts []string) ([][]float3
 	if err != nil {
 		return nil, err
 	}
+	c.recordUsage(ctx, openai.AdaEmbeddingV2.String(), resp.Usage)
 
 	result := make([][]float32, len(resp.Data))
 	for i, data := range resp.Data {
@@ -183,6 +198,7 @@ func (c *Client) CompleteJSON(ctx context.Context, prompt string) (string, error
 	if err != nil {
 		return "", err
 	}
+	c.recordUsage(ctx, c.model, resp.Usage)
 
 	if len(resp.Choices) == 0 {
 		return "{}", nil
@@ -223,6 +239,7 @@ func (c *Client) CompleteWithTools(ctx context.Context, systemPrompt, userPrompt
 	if err != nil {
 		return "", nil, err
 	}
+	c.recordUsage(ctx, c.model, resp.Usage)
 
 	if len(resp.Choices) == 0 {
 		return "", nil, nil
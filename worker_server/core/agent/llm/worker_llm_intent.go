@@ -137,6 +137,58 @@ Convert relative dates:
 	return &intent, nil
 }
 
+// MailFilterIntent is a mail list filter extracted from natural language (빈 값 = 조건 없음).
+type MailFilterIntent struct {
+	Folder        string `json:"folder,omitempty"`
+	Category      string `json:"category,omitempty"`
+	SubCategory   string `json:"sub_category,omitempty"`
+	Priority      string `json:"priority,omitempty"` // high, urgent
+	IsRead        *bool  `json:"is_read,omitempty"`
+	IsStarred     *bool  `json:"is_starred,omitempty"`
+	HasAttachment *bool  `json:"has_attachment,omitempty"`
+	From          string `json:"from,omitempty"`      // 이메일, 도메인 또는 이름 일부
+	DateFrom      string `json:"date_from,omitempty"` // YYYY-MM-DD
+	DateTo        string `json:"date_to,omitempty"`   // YYYY-MM-DD (포함)
+	Search        string `json:"search,omitempty"`    // 남은 검색어
+}
+
+// ParseMailFilter extracts a mail list filter from a natural-language request.
+func (c *Client) ParseMailFilter(ctx context.Context, message string, currentTime time.Time, categories, subCategories []string) (*MailFilterIntent, error) {
+	systemPrompt := fmt.Sprintf(`You convert a natural-language mail list request into a filter. Today: %s
+
+Respond with JSON only (omit fields that are not mentioned):
+{
+  "folder": "inbox|sent|drafts|trash|spam|archive",
+  "category": "one of: %s",
+  "sub_category": "one of: %s",
+  "priority": "high|urgent",
+  "is_read": true|false,
+  "is_starred": true|false,
+  "has_attachment": true|false,
+  "from": "sender email, domain or name",
+  "date_from": "YYYY-MM-DD",
+  "date_to": "YYYY-MM-DD (inclusive)",
+  "search": "remaining keywords to search in subject/body"
+}
+
+Convert relative dates ("last month", "지난달", "this week") to actual dates.
+Only use a category/sub_category when the request clearly names that kind of mail.`,
+		currentTime.Format("2006-01-02 (Monday)"), strings.Join(categories, ", "), strings.Join(subCategories, ", "))
+
+	resp, err := c.CompleteWithSystem(ctx, systemPrompt, message)
+	if err != nil {
+		return nil, err
+	}
+
+	var intent MailFilterIntent
+	resp = cleanJSONResponse(resp)
+	if err := json.Unmarshal([]byte(resp), &intent); err != nil {
+		return nil, fmt.Errorf("failed to parse mail filter: %w", err)
+	}
+
+	return &intent, nil
+}
+
 // GenerateEventProposal creates an event proposal from calendar intent
 func (c *Client) GenerateEventProposal(ctx context.Context, intent *CalendarIntent, currentTime time.Time) (*EventProposal, error) {
 	// Parse date and time
@@ -10,6 +10,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"worker_server/pkg/retry"
+
 	"github.com/goccy/go-json"
 
 	openai "github.com/sashabaranov/go-openai"
@@ -145,32 +147,26 @@ func (c *OptimizedClient) CompleteWithCache(ctx context.Context, model ModelType
 		return "", fmt.Errorf("rate limit exceeded: %w", err)
 	}
 
-	// Make request with retry
+	// Make request with retry (jittered exponential backoff)
+	policy := retry.Policy{
+		MaxAttempts: c.config.MaxRetries + 1,
+		BaseDelay:   c.config.RetryBaseDelay * 2,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+		Retriable:   isRetryableError,
+	}
 	var resp string
-	var err error
-	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
+	attempt := 0
+	err := retry.Do(ctx, policy, func(ctx context.Context) error {
 		if attempt > 0 {
 			atomic.AddInt64(&c.metrics.RetryCount, 1)
-			delay := c.config.RetryBaseDelay * time.Duration(1<<attempt)
-			select {
-			case <-ctx.Done():
-				return "", ctx.Err()
-			case <-time.After(delay):
-			}
 		}
+		attempt++
 
+		var err error
 		resp, err = c.doRequest(ctx, string(model), systemPrompt, userPrompt)
-		if err == nil {
-			break
-		}
-
-		// Check if error is retryable
-		if !isRetryableError(err) {
-			atomic.AddInt64(&c.metrics.ErrorCount, 1)
-			return "", err
-		}
-	}
-
+		return err
+	})
 	if err != nil {
 		atomic.AddInt64(&c.metrics.ErrorCount, 1)
 		return "", err
@@ -0,0 +1,32 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"worker_server/core/port/out"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// Synthesize converts text to MP3 speech with the TTS model. Implements out.SpeechSynthesizer.
+// 응답 본문을 그대로 돌려주므로 생성되는 대로 스트리밍된다 (토큰 사용량 없음).
+func (c *Client) Synthesize(ctx context.Context, text, voice string) (io.ReadCloser, string, error) {
+	if voice == "" {
+		voice = string(openai.VoiceAlloy)
+	}
+	audio, err := c.client.CreateSpeech(ctx, openai.CreateSpeechRequest{
+		Model:          openai.TTSModel1,
+		Input:          text,
+		Voice:          openai.SpeechVoice(voice),
+		ResponseFormat: openai.SpeechResponseFormatMp3,
+	})
+	if err != nil {
+		if errors.Is(err, openai.ErrInvalidVoice) {
+			return nil, "", out.ErrUnsupportedVoice
+		}
+		return nil, "", err
+	}
+	return audio, "audio/mpeg", nil
+}
@@ -155,6 +155,36 @@ func TestClassificationRuleIntegration(t *testing.T) {
 	}
 }
 
+func TestParseBatchClassification(t *testing.T) {
+	emails := []BatchEmailInput{
+		{Email: &domain.Email{ID: 10}},
+		{Email: &domain.Email{ID: 11}},
+		{Email: &domain.Email{ID: 12}},
+	}
+
+	// 11은 누락, 12는 카테고리 없음, 99는 요청하지 않은 id
+	resp := "```json\n" + `{"results": [
+		{"id": 10, "category": "work", "priority": 0.7, "score": 0.9},
+		{"id": 12, "category": "", "priority": 0.5},
+		{"id": 99, "category": "personal", "priority": 0.3}
+	]}` + "\n```"
+
+	results, err := parseBatchClassification(resp, emails)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if r := results[10]; r == nil || r.Category != "work" || r.Priority != 0.7 {
+		t.Errorf("unexpected result for email 10: %+v", r)
+	}
+
+	if _, err := parseBatchClassification("not json", emails); err == nil {
+		t.Error("expected error for malformed response")
+	}
+}
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -0,0 +1,26 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Translate translates text into targetLang (ISO 639-1). Implements out.Translator.
+func (c *Client) Translate(ctx context.Context, text, targetLang string, isHTML bool) (string, error) {
+	format := "plain text. Keep line breaks."
+	if isHTML {
+		format = "HTML. Translate only the visible text; keep every tag, attribute, link and image unchanged."
+	}
+
+	systemPrompt := fmt.Sprintf(`You are a professional email translator. Translate the email into the language with ISO 639-1 code %q.
+Keep names, email addresses, URLs, numbers and dates as they are.
+The input is %s
+Return only the translation without any explanation or code fences.`, targetLang, format)
+
+	result, err := c.CompleteWithSystem(ctx, systemPrompt, text)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(result), nil
+}
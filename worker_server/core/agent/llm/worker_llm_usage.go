@@ -0,0 +1,87 @@
+package llm
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// =============================================================================
+// Usage Accounting
+// =============================================================================
+//
+// Client는 호출마다 응답의 토큰 사용량을 UsageRecorder에 전달한다.
+// 사용자/연결/작업 귀속 정보는 호출자가 context에 WithUsageScope로 넣어 둔다.
+// scope가 없는 호출은 uuid.Nil 사용자, TaskOther로 기록된다 (시스템 사용량).
+
+// TaskOther is the task of calls made without a usage scope.
+const TaskOther TaskType = "other"
+
+// UsageScope attributes LLM usage to a user, connection and task.
+type UsageScope struct {
+	UserID       uuid.UUID
+	ConnectionID int64
+	Task         TaskType
+}
+
+// UsageEvent is the token usage of a single LLM or embedding call.
+type UsageEvent struct {
+	Scope            UsageScope
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	CostUSD          float64
+}
+
+// UsageRecorder receives the usage of every call made by a Client.
+// 호출 경로에서 실행되므로 빠르게 반환해야 하며, 기록 실패로 호출을 실패시키지 않는다.
+type UsageRecorder interface {
+	RecordUsage(ctx context.Context, event UsageEvent)
+}
+
+type usageScopeKey struct{}
+
+// WithUsageScope returns a context whose LLM calls are attributed to scope.
+func WithUsageScope(ctx context.Context, scope UsageScope) context.Context {
+	return context.WithValue(ctx, usageScopeKey{}, scope)
+}
+
+// WithUsageTask overrides the task of the scope in ctx, keeping user and connection.
+func WithUsageTask(ctx context.Context, task TaskType) context.Context {
+	scope, _ := UsageScopeFrom(ctx)
+	scope.Task = task
+	return WithUsageScope(ctx, scope)
+}
+
+// UsageScopeFrom returns the usage scope stored in ctx.
+func UsageScopeFrom(ctx context.Context) (UsageScope, bool) {
+	scope, ok := ctx.Value(usageScopeKey{}).(UsageScope)
+	return scope, ok
+}
+
+// SetUsageRecorder enables token usage accounting for every call of the client.
+func (c *Client) SetUsageRecorder(recorder UsageRecorder) {
+	c.usage = recorder
+}
+
+// recordUsage reports the usage of a call to the recorder, if any.
+func (c *Client) recordUsage(ctx context.Context, model string, usage openai.Usage) {
+	if c.usage == nil || (usage.PromptTokens == 0 && usage.CompletionTokens == 0) {
+		return
+	}
+
+	scope, _ := UsageScopeFrom(ctx)
+	if scope.Task == "" {
+		scope.Task = TaskOther
+	}
+
+	// 호출 ctx가 취소되어도 사용량은 기록되어야 한다
+	c.usage.RecordUsage(context.WithoutCancel(ctx), UsageEvent{
+		Scope:            scope,
+		Model:            model,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		CostUSD:          CalculateCost(model, usage.PromptTokens, usage.CompletionTokens),
+	})
+}
@@ -2,24 +2,107 @@ package rag
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"worker_server/core/agent/llm"
 )
 
+const (
+	// MaxEmbeddingBatch is the provider's maximum number of inputs per embeddings request.
+	MaxEmbeddingBatch = 2048
+
+	// DefaultEmbeddingBatch - 8000자 입력 기준 요청당 토큰 한도 안쪽으로 유지
+	DefaultEmbeddingBatch = 100
+)
+
+// Embedder generates embeddings in provider-sized batches under a request rate limit.
+// 한도에 걸리면 호출한 워커가 대기하므로 큐 적체가 스트림 소비 속도로 전달된다 (backpressure).
 type Embedder struct {
-	client *llm.Client
+	client    *llm.Client
+	batchSize int
+
+	// 요청 간 최소 간격 (0 = 제한 없음)
+	mu          sync.Mutex
+	minInterval time.Duration
+	nextAllowed time.Time
 }
 
 func NewEmbedder(client *llm.Client) *Embedder {
-	return &Embedder{client: client}
+	return &Embedder{client: client, batchSize: DefaultEmbeddingBatch}
+}
+
+// SetBatchSize sets the number of inputs per embeddings request (capped at MaxEmbeddingBatch).
+func (e *Embedder) SetBatchSize(n int) {
+	if n <= 0 {
+		n = DefaultEmbeddingBatch
+	}
+	e.batchSize = min(n, MaxEmbeddingBatch)
+}
+
+// SetRateLimit limits embeddings requests per minute (0 = unlimited).
+func (e *Embedder) SetRateLimit(requestsPerMinute int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if requestsPerMinute <= 0 {
+		e.minInterval = 0
+		return
+	}
+	e.minInterval = time.Minute / time.Duration(requestsPerMinute)
 }
 
 func (e *Embedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	if err := e.wait(ctx); err != nil {
+		return nil, err
+	}
 	return e.client.Embedding(ctx, text)
 }
 
+// EmbedBatch embeds texts with one request per batchSize inputs, in order.
 func (e *Embedder) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
-	return e.client.EmbeddingBatch(ctx, texts)
+	embeddings := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += e.batchSize {
+		end := min(start+e.batchSize, len(texts))
+
+		if err := e.wait(ctx); err != nil {
+			return nil, err
+		}
+		batch, err := e.client.EmbeddingBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		embeddings = append(embeddings, batch...)
+	}
+	return embeddings, nil
+}
+
+// wait blocks until the next request is allowed by the rate limit.
+func (e *Embedder) wait(ctx context.Context) error {
+	e.mu.Lock()
+	if e.minInterval == 0 {
+		e.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	at := e.nextAllowed
+	if at.Before(now) {
+		at = now
+	}
+	e.nextAllowed = at.Add(e.minInterval)
+	e.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 // PrepareText preprocesses text for embedding
@@ -2,23 +2,66 @@ package rag
 
 import (
 	"context"
+	"strings"
 	"time"
+	"unicode/utf8"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
 
 	"github.com/google/uuid"
 )
 
+// DefaultMinIndexChars - 제목+본문이 이보다 짧은 메일은 임베딩하지 않는다 (검색 가치 대비 벡터만 늘어남)
+const DefaultMinIndexChars = 80
+
+// skipIndexCategories are received-mail categories that are never embedded.
+var skipIndexCategories = map[domain.EmailCategory]bool{
+	domain.CategoryMarketing:  true,
+	domain.CategoryNewsletter: true,
+	domain.CategoryBulk:       true,
+	domain.CategorySpam:       true,
+}
+
 type IndexerService struct {
-	embedder    *Embedder
-	vectorStore *VectorStore
+	embedder      *Embedder
+	vectorStore   out.VectorStorePort
+	minIndexChars int
 }
 
-func NewIndexerService(embedder *Embedder, vectorStore *VectorStore) *IndexerService {
+func NewIndexerService(embedder *Embedder, vectorStore out.VectorStorePort) *IndexerService {
 	return &IndexerService{
-		embedder:    embedder,
-		vectorStore: vectorStore,
+		embedder:      embedder,
+		vectorStore:   vectorStore,
+		minIndexChars: DefaultMinIndexChars,
 	}
 }
 
+// SetMinIndexChars sets the minimum subject+body length (in characters) worth embedding.
+func (s *IndexerService) SetMinIndexChars(n int) {
+	s.minIndexChars = n
+}
+
+// Indexable reports whether the email is worth embedding.
+// 마케팅/뉴스레터 등 대량 메일과 아주 짧은 메일은 건너뛴다. 보낸 메일은 스타일 학습용이라 카테고리와 무관하게 색인한다.
+func (s *IndexerService) Indexable(req *EmailIndexRequest) bool {
+	if req.Direction != "outbound" && skipIndexCategories[domain.EmailCategory(req.Category)] {
+		return false
+	}
+	length := utf8.RuneCountInString(strings.TrimSpace(req.Subject)) + utf8.RuneCountInString(strings.TrimSpace(req.Body)) +
+		utf8.RuneCountInString(strings.TrimSpace(req.Notes))
+	return length >= s.minIndexChars
+}
+
+// indexText builds the embedding text. 메모는 본문이 잘리더라도 남도록 본문 앞에 둔다.
+func (s *IndexerService) indexText(req *EmailIndexRequest) string {
+	body := req.Body
+	if notes := strings.TrimSpace(req.Notes); notes != "" {
+		body = "Notes: " + notes + "\n\n" + body
+	}
+	return s.embedder.PrepareText(req.Subject, body, 8000)
+}
+
 type EmailIndexRequest struct {
 	EmailID    int64
 	UserID     uuid.UUID
@@ -28,12 +71,43 @@ type EmailIndexRequest struct {
 	Direction  string // inbound, outbound
 	ReceivedAt time.Time
 	Folder     string
+	Category   string // AI 분류 카테고리 (미분류면 빈 값)
+	Notes      string // 사용자 개인 메모/태그 (로컬 전용, 임베딩에 포함)
+
+	// 검색 범위 필터용 metadata (외부 벡터 저장소)
+	ThreadID string
+	FolderID *int64
+	LabelIDs []int64
 }
 
-// IndexEmail indexes a single email for RAG search
+// indexMetadata builds the metadata stored with the vector.
+func indexMetadata(req *EmailIndexRequest) map[string]any {
+	metadata := map[string]any{
+		"subject":     req.Subject,
+		"from":        req.FromEmail,
+		"received_at": req.ReceivedAt,
+		"folder":      req.Folder,
+	}
+	if req.ThreadID != "" {
+		metadata["thread_id"] = req.ThreadID
+	}
+	if req.FolderID != nil {
+		metadata["folder_id"] = *req.FolderID
+	}
+	if len(req.LabelIDs) > 0 {
+		metadata["label_ids"] = req.LabelIDs
+	}
+	return metadata
+}
+
+// IndexEmail indexes a single email for RAG search. Emails that are not Indexable are skipped.
 func (s *IndexerService) IndexEmail(ctx context.Context, req *EmailIndexRequest) error {
+	if !s.Indexable(req) {
+		return nil
+	}
+
 	// Prepare text for embedding
-	text := s.embedder.PrepareText(req.Subject, req.Body, 8000)
+	text := s.indexText(req)
 
 	// Generate embedding
 	embedding, err := s.embedder.Embed(ctx, text)
@@ -48,19 +122,15 @@ func (s *IndexerService) IndexEmail(ctx context.Context, req *EmailIndexRequest)
 		Direction: req.Direction,
 		Embedding: embedding,
 		Content:   text,
-		Metadata: map[string]any{
-			"subject":     req.Subject,
-			"from":        req.FromEmail,
-			"received_at": req.ReceivedAt,
-			"folder":      req.Folder,
-		},
+		Metadata:  indexMetadata(req),
 	}
 
 	return s.vectorStore.Store(ctx, record)
 }
 
-// IndexBatch indexes multiple emails in batch
+// IndexBatch indexes multiple emails in batch. Emails that are not Indexable are skipped.
 func (s *IndexerService) IndexBatch(ctx context.Context, requests []*EmailIndexRequest) error {
+	requests = s.filterIndexable(requests)
 	if len(requests) == 0 {
 		return nil
 	}
@@ -68,7 +138,7 @@ func (s *IndexerService) IndexBatch(ctx context.Context, requests []*EmailIndexR
 	// Prepare texts
 	texts := make([]string, len(requests))
 	for i, req := range requests {
-		texts[i] = s.embedder.PrepareText(req.Subject, req.Body, 8000)
+		texts[i] = s.indexText(req)
 	}
 
 	// Batch embed
@@ -86,18 +156,24 @@ func (s *IndexerService) IndexBatch(ctx context.Context, requests []*EmailIndexR
 			Direction: req.Direction,
 			Embedding: embeddings[i],
 			Content:   texts[i],
-			Metadata: map[string]any{
-				"subject":     req.Subject,
-				"from":        req.FromEmail,
-				"received_at": req.ReceivedAt,
-				"folder":      req.Folder,
-			},
+			Metadata:  indexMetadata(req),
 		}
 	}
 
 	return s.vectorStore.StoreBatch(ctx, records)
 }
 
+// filterIndexable returns the indexable requests.
+func (s *IndexerService) filterIndexable(requests []*EmailIndexRequest) []*EmailIndexRequest {
+	kept := make([]*EmailIndexRequest, 0, len(requests))
+	for _, req := range requests {
+		if s.Indexable(req) {
+			kept = append(kept, req)
+		}
+	}
+	return kept
+}
+
 // DeleteEmail removes an email from the index
 func (s *IndexerService) DeleteEmail(ctx context.Context, emailID int64) error {
 	return s.vectorStore.Delete(ctx, emailID)
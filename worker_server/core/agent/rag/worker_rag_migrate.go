@@ -0,0 +1,58 @@
+package rag
+
+import (
+	"context"
+	"fmt"
+
+	"worker_server/core/port/out"
+)
+
+// DefaultMigrateBatch is the number of vectors copied per Scan/StoreBatch round.
+const DefaultMigrateBatch = 500
+
+// MigrateOptions configures MigrateVectors.
+type MigrateOptions struct {
+	BatchSize int
+	AfterID   int64 // 중단된 마이그레이션을 이어서 할 때 마지막으로 복사된 email ID
+
+	// Progress is called after each batch with the running total and the last copied email ID.
+	Progress func(copied int, lastID int64)
+}
+
+// MigrateVectors copies every embedding from src to dst in ascending email ID order.
+// 임베딩을 다시 생성하지 않고 저장된 벡터를 그대로 옮기므로 LLM 비용이 들지 않는다.
+// 실패 시 지금까지 복사한 개수와 마지막 ID를 반환하므로 AfterID로 재개할 수 있다.
+func MigrateVectors(ctx context.Context, src, dst out.VectorStorePort, opts MigrateOptions) (int, int64, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultMigrateBatch
+	}
+
+	copied, lastID := 0, opts.AfterID
+	for {
+		if err := ctx.Err(); err != nil {
+			return copied, lastID, err
+		}
+
+		records, err := src.Scan(ctx, lastID, opts.BatchSize)
+		if err != nil {
+			return copied, lastID, fmt.Errorf("scan after %d: %w", lastID, err)
+		}
+		if len(records) == 0 {
+			return copied, lastID, nil
+		}
+
+		if err := dst.StoreBatch(ctx, records); err != nil {
+			return copied, lastID, fmt.Errorf("store batch after %d: %w", lastID, err)
+		}
+
+		copied += len(records)
+		lastID = records[len(records)-1].EmailID
+		if opts.Progress != nil {
+			opts.Progress(copied, lastID)
+		}
+
+		if len(records) < opts.BatchSize {
+			return copied, lastID, nil
+		}
+	}
+}
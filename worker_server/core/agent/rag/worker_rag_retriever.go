@@ -3,15 +3,17 @@ package rag
 import (
 	"context"
 
+	"worker_server/core/port/out"
+
 	"github.com/google/uuid"
 )
 
 type Retriever struct {
 	embedder    *Embedder
-	vectorStore *VectorStore
+	vectorStore out.VectorStorePort
 }
 
-func NewRetriever(embedder *Embedder, vectorStore *VectorStore) *Retriever {
+func NewRetriever(embedder *Embedder, vectorStore out.VectorStorePort) *Retriever {
 	return &Retriever{
 		embedder:    embedder,
 		vectorStore: vectorStore,
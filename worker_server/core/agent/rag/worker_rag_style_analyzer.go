@@ -25,14 +25,20 @@ import (
 type StyleAnalyzer struct {
 	embedder    *Embedder
 	personStore out.ExtendedPersonalizationStore
-	vectorStore *VectorStore
+	vectorStore out.VectorStorePort
+
+	// 새 중요 연락처 프로필 보강 (nil이면 비활성)
+	producer out.MessageProducer
 }
 
+// importantContactScore - 중요도가 처음 이 점수를 넘은 연락처는 프로필 보강 작업을 요청한다
+const importantContactScore = 0.7
+
 // NewStyleAnalyzer creates a new style analyzer.
 func NewStyleAnalyzer(
 	embedder *Embedder,
 	personStore out.ExtendedPersonalizationStore,
-	vectorStore *VectorStore,
+	vectorStore out.VectorStorePort,
 ) *StyleAnalyzer {
 	return &StyleAnalyzer{
 		embedder:    embedder,
@@ -41,6 +47,11 @@ func NewStyleAnalyzer(
 	}
 }
 
+// SetEnrichmentProducer enables enrichment jobs for contacts that become important.
+func (a *StyleAnalyzer) SetEnrichmentProducer(producer out.MessageProducer) {
+	a.producer = producer
+}
+
 // AnalysisInput represents input for style analysis.
 type AnalysisInput struct {
 	UserID         uuid.UUID
@@ -164,9 +175,11 @@ func (a *StyleAnalyzer) updateContactRelationship(ctx context.Context, userID st
 	inferredRelation := inferRelationType(input.RecipientEmail, input.Body)
 
 	var rel *out.ContactRelationship
+	var prevScore float64
 	if existing != nil {
 		// Update existing relationship
 		rel = existing
+		prevScore = rel.ImportanceScore
 		rel.EmailsSent++
 		rel.LastContact = input.SentAt
 		rel.LastActivityDate = input.SentAt
@@ -253,7 +266,25 @@ func (a *StyleAnalyzer) updateContactRelationship(ctx context.Context, userID st
 		}
 	}
 
-	return a.personStore.UpsertContactRelationship(ctx, userID, rel)
+	if err := a.personStore.UpsertContactRelationship(ctx, userID, rel); err != nil {
+		return err
+	}
+
+	if prevScore < importantContactScore && rel.ImportanceScore >= importantContactScore {
+		a.requestEnrichment(ctx, userID, rel)
+	}
+	return nil
+}
+
+// requestEnrichment queues a profile lookup (company, title, avatar) for a newly important contact.
+func (a *StyleAnalyzer) requestEnrichment(ctx context.Context, userID string, rel *out.ContactRelationship) {
+	if a.producer == nil || rel.EnrichedAt != nil {
+		return
+	}
+	job := &out.ContactEnrichJob{UserID: userID, Email: rel.ContactEmail, Name: rel.ContactName}
+	if err := a.producer.PublishContactEnrich(ctx, job); err != nil {
+		fmt.Printf("warning: failed to publish contact enrichment: %v\n", err)
+	}
 }
 
 // calculateRelationChangeConfidence calculates confidence score for relationship type change.
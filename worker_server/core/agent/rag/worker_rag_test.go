@@ -178,6 +178,28 @@ func TestIndexerServiceStruct(t *testing.T) {
 	}
 }
 
+func TestIndexable(t *testing.T) {
+	service := NewIndexerService(nil, nil)
+	longBody := "프로젝트 일정 관련해서 다음 주 화요일 오후 회의에서 논의할 안건과 준비 자료를 정리해 공유드립니다. 검토 부탁드립니다. 감사합니다. 추가 의견 있으시면 회신 주세요."
+
+	tests := []struct {
+		name string
+		req  *EmailIndexRequest
+		want bool
+	}{
+		{"regular", &EmailIndexRequest{Subject: "회의 안건", Body: longBody, Direction: "inbound"}, true},
+		{"too short", &EmailIndexRequest{Subject: "Re: ok", Body: "감사합니다", Direction: "inbound"}, false},
+		{"marketing", &EmailIndexRequest{Subject: "SALE", Body: longBody, Direction: "inbound", Category: "marketing"}, false},
+		{"newsletter", &EmailIndexRequest{Subject: "Weekly", Body: longBody, Direction: "inbound", Category: "newsletter"}, false},
+		{"sent ignores category", &EmailIndexRequest{Subject: "회의 안건", Body: longBody, Direction: "outbound", Category: "marketing"}, true},
+	}
+	for _, tt := range tests {
+		if got := service.Indexable(tt.req); got != tt.want {
+			t.Errorf("%s: Indexable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
 // Test embedding dimension constant
 func TestEmbeddingDimension(t *testing.T) {
 	// OpenAI Ada-002 uses 1536 dimensions
@@ -239,3 +261,88 @@ func TestVectorRecordStruct(t *testing.T) {
 		t.Errorf("expected direction 'outbound', got %s", record.Direction)
 	}
 }
+
+func TestParsePgVectorRoundTrip(t *testing.T) {
+	input := []float32{1.0, -2.5, 0.125}
+	v, err := parsePgVector(pgVector(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v) != len(input) {
+		t.Fatalf("expected %d values, got %d", len(input), len(v))
+	}
+	for i := range input {
+		if v[i] != input[i] {
+			t.Errorf("value %d: expected %v, got %v", i, input[i], v[i])
+		}
+	}
+}
+
+// memVectorStore is an in-memory out.VectorStorePort ordered by email ID.
+type memVectorStore struct {
+	MockVectorStore
+	records []*VectorRecord
+}
+
+func (m *memVectorStore) StoreBatch(ctx context.Context, records []*VectorRecord) error {
+	m.records = append(m.records, records...)
+	return nil
+}
+
+func (m *memVectorStore) HasEmbedding(ctx context.Context, emailID int64) (bool, error) {
+	for _, r := range m.records {
+		if r.EmailID == emailID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memVectorStore) Get(ctx context.Context, emailID int64) (*VectorRecord, error) {
+	for _, r := range m.records {
+		if r.EmailID == emailID {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memVectorStore) Scan(ctx context.Context, afterID int64, limit int) ([]*VectorRecord, error) {
+	var records []*VectorRecord
+	for _, r := range m.records {
+		if r.EmailID > afterID && len(records) < limit {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+func TestMigrateVectors(t *testing.T) {
+	src := &memVectorStore{}
+	for id := int64(1); id <= 7; id++ {
+		src.records = append(src.records, &VectorRecord{EmailID: id, UserID: "u", Embedding: []float32{float32(id)}})
+	}
+
+	dst := &memVectorStore{}
+	batches := 0
+	copied, lastID, err := MigrateVectors(context.Background(), src, dst, MigrateOptions{
+		BatchSize: 3,
+		Progress:  func(int, int64) { batches++ },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied != 7 || lastID != 7 || len(dst.records) != 7 || batches != 3 {
+		t.Errorf("copied=%d lastID=%d dst=%d batches=%d", copied, lastID, len(dst.records), batches)
+	}
+
+	// AfterID로 재개하면 이미 복사한 벡터는 건너뛴다
+	resumed := &memVectorStore{}
+	copied, _, err = MigrateVectors(context.Background(), src, resumed, MigrateOptions{BatchSize: 3, AfterID: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied != 2 || resumed.records[0].EmailID != 6 {
+		t.Errorf("expected to resume after 5, copied %d", copied)
+	}
+}
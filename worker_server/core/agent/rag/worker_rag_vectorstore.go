@@ -3,10 +3,22 @@ package rag
 import (
 	"context"
 	"strconv"
+	"strings"
 
+	"worker_server/core/port/out"
+
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// VectorRecord, SearchOptions and SearchResult are the port types used by the RAG components.
+type (
+	VectorRecord  = out.EmailVector
+	SearchOptions = out.EmailVectorQuery
+	SearchResult  = out.EmailVectorMatch
+)
+
+// VectorStore is the pgvector implementation of out.VectorStorePort (emails.embedding 컬럼).
 type VectorStore struct {
 	db *pgxpool.Pool
 }
@@ -15,16 +27,6 @@ func NewVectorStore(db *pgxpool.Pool) *VectorStore {
 	return &VectorStore{db: db}
 }
 
-type VectorRecord struct {
-	ID        int64
-	EmailID   int64
-	UserID    string
-	Direction string // inbound, outbound
-	Embedding []float32
-	Content   string
-	Metadata  map[string]any
-}
-
 // Store stores embedding directly in the emails table
 func (s *VectorStore) Store(ctx context.Context, record *VectorRecord) error {
 	query := `
@@ -52,31 +54,29 @@ func (s *VectorStore) StoreBatch(ctx context.Context, records []*VectorRecord) e
 	return nil
 }
 
-type SearchOptions struct {
-	UserID       string
-	SentOnly     bool // outbound only (for style learning)
-	ReceivedOnly bool // inbound only
-	AllEmails    bool // both directions
-	Limit        int
-	MinScore     float64
-}
-
-type SearchResult struct {
-	EmailID  int64
-	Score    float64
-	Content  string
-	Metadata map[string]any
-}
-
 // Search performs vector similarity search on emails table
 func (s *VectorStore) Search(ctx context.Context, embedding []float32, opts *SearchOptions) ([]*SearchResult, error) {
 	if opts.Limit == 0 {
 		opts.Limit = 10
 	}
 
+	// 아카이브된 메일은 emails_archive에 임베딩과 함께 옮겨진다 (077)
 	query := `
 		SELECT id, 1 - (embedding <=> $1) as score, subject, snippet
-		FROM emails
+		FROM (
+			SELECT id, user_id, embedding, subject, snippet, folder, folder_id, external_thread_id, NULL::jsonb AS archived_labels
+			FROM emails
+			WHERE deleted_at IS NULL
+			UNION ALL
+			SELECT a.id, a.user_id, a.embedding, a.data->>'subject', a.data->>'snippet', a.data->>'folder',
+				(a.data->>'folder_id')::bigint, a.data->>'external_thread_id', a.related->'email_labels'
+			FROM emails_archive a
+			WHERE a.user_id = $2
+			AND NOT EXISTS (
+				SELECT 1 FROM emails h
+				WHERE h.user_id = a.user_id AND h.connection_id = a.connection_id AND h.external_id = a.external_id
+			)
+		) emails
 		WHERE user_id = $2
 		AND embedding IS NOT NULL
 	`
@@ -91,9 +91,25 @@ func (s *VectorStore) Search(ctx context.Context, embedding []float32, opts *Sea
 		query += ` AND 1 - (embedding <=> $1) >= ` + strconv.FormatFloat(opts.MinScore, 'f', 2, 64)
 	}
 
+	args := []any{pgVector(embedding), opts.UserID, opts.Limit}
+	if opts.ThreadID != "" {
+		args = append(args, opts.ThreadID)
+		query += ` AND external_thread_id = $` + strconv.Itoa(len(args))
+	}
+	if opts.FolderID != nil {
+		args = append(args, *opts.FolderID)
+		query += ` AND folder_id = $` + strconv.Itoa(len(args))
+	}
+	if len(opts.LabelIDs) > 0 {
+		args = append(args, opts.LabelIDs)
+		n := strconv.Itoa(len(args))
+		query += ` AND (EXISTS (SELECT 1 FROM email_labels el WHERE el.email_id = emails.id AND el.label_id = ANY($` + n + `))` +
+			` OR EXISTS (SELECT 1 FROM jsonb_array_elements(emails.archived_labels) l WHERE (l->>'label_id')::bigint = ANY($` + n + `)))`
+	}
+
 	query += ` ORDER BY embedding <=> $1 LIMIT $3`
 
-	rows, err := s.db.Query(ctx, query, pgVector(embedding), opts.UserID, opts.Limit)
+	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +153,95 @@ func (s *VectorStore) HasEmbedding(ctx context.Context, emailID int64) (bool, er
 	return exists, nil
 }
 
+// vectorColumns are the columns read by scanVectorRecord.
+const vectorColumns = `id, user_id::text, COALESCE(folder, ''), COALESCE(subject, ''), COALESCE(snippet, ''), embedding::text`
+
+// Get returns the stored embedding of an email, or nil if it has none.
+func (s *VectorStore) Get(ctx context.Context, emailID int64) (*VectorRecord, error) {
+	rows, err := s.db.Query(ctx, `SELECT `+vectorColumns+` FROM emails WHERE id = $1 AND embedding IS NOT NULL`, emailID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	return scanVectorRecord(rows)
+}
+
+// Scan returns stored embeddings with email ID > afterID in ascending ID order (for backend migration).
+func (s *VectorStore) Scan(ctx context.Context, afterID int64, limit int) ([]*VectorRecord, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT `+vectorColumns+`
+		FROM emails
+		WHERE id > $1 AND embedding IS NOT NULL
+		ORDER BY id
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*VectorRecord
+	for rows.Next() {
+		r, err := scanVectorRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+func scanVectorRecord(rows pgx.Rows) (*VectorRecord, error) {
+	var r VectorRecord
+	var folder, subject, snippet, embedding string
+	if err := rows.Scan(&r.EmailID, &r.UserID, &folder, &subject, &snippet, &embedding); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if r.Embedding, err = parsePgVector(embedding); err != nil {
+		return nil, err
+	}
+	r.ID = r.EmailID
+	r.Direction = "inbound"
+	if folder == "sent" {
+		r.Direction = "outbound"
+	}
+	r.Content = subject + "\n" + snippet
+	r.Metadata = map[string]any{
+		"subject": subject,
+		"snippet": snippet,
+	}
+	return &r, nil
+}
+
+// parsePgVector parses the pgvector text format "[0.1,0.2,...]".
+func parsePgVector(s string) ([]float32, error) {
+	s = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(s), "["), "]")
+	if s == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	v := make([]float32, len(parts))
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 32)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = float32(f)
+	}
+	return v, nil
+}
+
 // pgVector converts float32 slice to pgvector format string
 // Optimized version using []byte buffer
 func pgVector(v []float32) string {
@@ -158,3 +263,5 @@ func pgVector(v []float32) string {
 	buf = append(buf, ']')
 	return string(buf)
 }
+
+var _ out.VectorStorePort = (*VectorStore)(nil)
@@ -12,6 +12,7 @@ import (
 	"worker_server/core/agent/session"
 	"worker_server/core/agent/tools"
 	"worker_server/core/domain"
+	"worker_server/core/port/in"
 	"worker_server/core/port/out"
 	"worker_server/pkg/logger"
 
@@ -38,6 +39,7 @@ type Orchestrator struct {
 	calendarProvider out.CalendarProviderPort
 	oauthProvider    OAuthTokenProvider
 	labelRepo        domain.LabelRepository
+	emailService     in.EmailService // assistant commands (배치/검색/발송)
 }
 
 // NewOrchestrator creates a new AI Agent orchestrator
@@ -314,6 +316,12 @@ func (o *Orchestrator) executeProposal(ctx context.Context, userID uuid.UUID, pr
 		return o.executeRemoveLabel(ctx, userID, proposal.Data)
 	case "label.create":
 		return o.executeCreateLabel(ctx, userID, proposal.Data)
+	case actionCommandBatch:
+		return o.executeCommandBatch(ctx, userID, proposal.Data)
+	case actionCommandReply:
+		return o.executeCommandReply(ctx, userID, proposal.Data)
+	case actionCommandSend:
+		return o.executeCommandSend(ctx, userID, proposal.Data)
 	default:
 		return nil, fmt.Errorf("unknown action: %s", proposal.Action)
 	}
@@ -0,0 +1,428 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"worker_server/core/agent/tools"
+	"worker_server/core/domain"
+	"worker_server/core/port/in"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// Assistant Commands (POST /assistant/command)
+// =============================================================================
+//
+// 음성 인식된 명령("지난주 뉴스레터 전부 보관해", "Kim에게 늦는다고 답장해")을 하나의 액션과
+// 메일 필터로 해석한다. 검색은 바로 실행하고, 메일을 바꾸거나 보내는 작업은 Proposal로
+// 돌려준 뒤 확인(POST /ai/proposals/:id/confirm)을 받아 EmailService로 실행한다.
+
+// Command actions
+const (
+	CommandSearch     = "search"
+	CommandArchive    = "archive"
+	CommandTrash      = "trash"
+	CommandMarkRead   = "mark_read"
+	CommandMarkUnread = "mark_unread"
+	CommandStar       = "star"
+	CommandReply      = "reply"
+	CommandSend       = "send"
+)
+
+// Proposal actions executed through EmailService
+const (
+	actionCommandBatch = "command.batch"
+	actionCommandReply = "command.reply"
+	actionCommandSend  = "command.send"
+)
+
+const (
+	maxCommandTargets  = 500 // 한 번의 명령으로 바꾸는 최대 메일 수
+	maxCommandResults  = 20  // search 결과 수
+	commandPreviewSize = 5   // 확인 전에 보여주는 대상 메일 수
+	commandExpiry      = 10 * time.Minute
+)
+
+var (
+	ErrCommandNotUnderstood = errors.New("command not understood")
+	ErrEmailServiceMissing  = errors.New("email service not configured")
+)
+
+var batchCommands = map[string]string{
+	CommandArchive:    "Archive",
+	CommandTrash:      "Move to trash",
+	CommandMarkRead:   "Mark as read",
+	CommandMarkUnread: "Mark as unread",
+	CommandStar:       "Star",
+}
+
+// ParsedCommand is a natural-language command mapped to an action and an email filter.
+type ParsedCommand struct {
+	Action  string        `json:"action"`
+	Filter  CommandFilter `json:"filter"`
+	To      string        `json:"to,omitempty"` // reply/send: 이름 또는 주소
+	Subject string        `json:"subject,omitempty"`
+	Body    string        `json:"body,omitempty"`
+}
+
+// CommandFilter selects the emails a command applies to (날짜는 사용자 시간대의 YYYY-MM-DD).
+type CommandFilter struct {
+	Category string `json:"category,omitempty"`
+	From     string `json:"from,omitempty"`
+	Keyword  string `json:"keyword,omitempty"`
+	After    string `json:"after,omitempty"`  // 이 날짜부터
+	Before   string `json:"before,omitempty"` // 이 날짜까지 (포함)
+	Unread   *bool  `json:"unread,omitempty"`
+	Folder   string `json:"folder,omitempty"`
+}
+
+// CommandResult is the outcome of a command.
+type CommandResult struct {
+	Command  *ParsedCommand        `json:"command"`
+	Message  string                `json:"message"`
+	Emails   []*domain.Email       `json:"emails,omitempty"` // search 결과 또는 대상 미리보기
+	Total    int                   `json:"total"`
+	Proposal *tools.ActionProposal `json:"proposal,omitempty"` // 확인이 필요한 작업
+}
+
+// SetEmailService sets the email service used by assistant commands.
+func (o *Orchestrator) SetEmailService(svc in.EmailService) {
+	o.emailService = svc
+}
+
+// Command interprets a transcribed command and runs it or proposes it for confirmation.
+func (o *Orchestrator) Command(ctx context.Context, userID uuid.UUID, text string, loc *time.Location) (*CommandResult, error) {
+	if o.emailService == nil {
+		return nil, ErrEmailServiceMissing
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	cmd, err := o.parseCommand(ctx, text, time.Now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+	filter, err := commandFilter(userID, cmd, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CommandResult{Command: cmd}
+	switch {
+	case cmd.Action == CommandSearch:
+		filter.Limit = maxCommandResults
+		emails, total, err := o.emailService.ListEmails(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search emails: %w", err)
+		}
+		result.Emails, result.Total = emails, total
+		result.Message = fmt.Sprintf("Found %d email(s)", total)
+		return result, nil
+
+	case batchCommands[cmd.Action] != "":
+		return o.proposeBatch(ctx, userID, cmd, filter, result)
+
+	case cmd.Action == CommandReply:
+		return o.proposeReply(ctx, userID, cmd, filter, result)
+
+	case cmd.Action == CommandSend:
+		return o.proposeSend(ctx, userID, cmd, result)
+	}
+	return nil, ErrCommandNotUnderstood
+}
+
+func (o *Orchestrator) proposeBatch(ctx context.Context, userID uuid.UUID, cmd *ParsedCommand, filter *domain.EmailFilter, result *CommandResult) (*CommandResult, error) {
+	filter.Limit = maxCommandTargets
+	emails, _, err := o.emailService.ListEmails(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find emails: %w", err)
+	}
+	result.Total = len(emails)
+	if len(emails) == 0 {
+		result.Message = "No emails match this command"
+		return result, nil
+	}
+
+	ids := make([]int64, len(emails))
+	for i, e := range emails {
+		ids[i] = e.ID
+	}
+	result.Emails = emails[:min(len(emails), commandPreviewSize)]
+	result.Proposal = o.storeCommandProposal(userID, actionCommandBatch,
+		fmt.Sprintf("%s %d email(s)", batchCommands[cmd.Action], len(ids)),
+		map[string]any{"operation": cmd.Action, "email_ids": ids})
+	result.Message = result.Proposal.Description + ". Confirm to proceed."
+	return result, nil
+}
+
+func (o *Orchestrator) proposeReply(ctx context.Context, userID uuid.UUID, cmd *ParsedCommand, filter *domain.EmailFilter, result *CommandResult) (*CommandResult, error) {
+	if strings.TrimSpace(cmd.Body) == "" {
+		return nil, ErrCommandNotUnderstood
+	}
+	if filter.FromEmail == nil && cmd.To != "" {
+		filter.FromEmail = &cmd.To
+	}
+	original, err := o.latestEmail(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		result.Message = "No email found to reply to"
+		return result, nil
+	}
+
+	result.Emails, result.Total = []*domain.Email{original}, 1
+	result.Proposal = o.storeCommandProposal(userID, actionCommandReply,
+		fmt.Sprintf("Reply to %s (%q): %s", original.FromEmail, original.Subject, cmd.Body),
+		map[string]any{"email_id": original.ID, "body": cmd.Body})
+	result.Message = result.Proposal.Description + ". Confirm to send."
+	return result, nil
+}
+
+func (o *Orchestrator) proposeSend(ctx context.Context, userID uuid.UUID, cmd *ParsedCommand, result *CommandResult) (*CommandResult, error) {
+	if strings.TrimSpace(cmd.To) == "" || strings.TrimSpace(cmd.Body) == "" {
+		return nil, ErrCommandNotUnderstood
+	}
+
+	// 이름만 말했으면 그 사람에게서 받은 최근 메일의 주소로 보낸다
+	to := cmd.To
+	if !strings.Contains(to, "@") {
+		latest, err := o.latestEmail(ctx, &domain.EmailFilter{UserID: userID, FromEmail: &cmd.To})
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil {
+			result.Message = fmt.Sprintf("No address found for %q", cmd.To)
+			return result, nil
+		}
+		to = latest.FromEmail
+	}
+
+	result.Proposal = o.storeCommandProposal(userID, actionCommandSend,
+		fmt.Sprintf("Send email to %s (%q): %s", to, cmd.Subject, cmd.Body),
+		map[string]any{"to": []string{to}, "subject": cmd.Subject, "body": cmd.Body})
+	result.Message = result.Proposal.Description + ". Confirm to send."
+	return result, nil
+}
+
+// latestEmail returns the most recent email matching filter. 검색어/날짜는 조회 후 걸러지므로 여유 있게 가져온다.
+func (o *Orchestrator) latestEmail(ctx context.Context, filter *domain.EmailFilter) (*domain.Email, error) {
+	filter.Limit = maxCommandResults
+	emails, _, err := o.emailService.ListEmails(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find email: %w", err)
+	}
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	return emails[0], nil
+}
+
+func (o *Orchestrator) storeCommandProposal(userID uuid.UUID, action, description string, data map[string]any) *tools.ActionProposal {
+	proposal := &tools.ActionProposal{
+		ID:          uuid.New().String(),
+		Action:      action,
+		Description: description,
+		Data:        data,
+		ExpiresAt:   time.Now().Add(commandExpiry),
+	}
+	o.proposalStore.Store(userID, proposal)
+	return proposal
+}
+
+// parseCommand maps the command text to a ParsedCommand with the LLM.
+func (o *Orchestrator) parseCommand(ctx context.Context, text string, now time.Time) (*ParsedCommand, error) {
+	prompt := fmt.Sprintf(commandPrompt, now.Format("2006-01-02 (Monday)"), commandCategories(), text)
+	response, err := o.llmClient.CompleteJSON(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse command: %w", err)
+	}
+
+	var cmd ParsedCommand
+	if err := json.Unmarshal([]byte(response), &cmd); err != nil {
+		logger.Debug("[Orchestrator.parseCommand] invalid response: %v", err)
+		return nil, ErrCommandNotUnderstood
+	}
+	cmd.Action = strings.ToLower(strings.TrimSpace(cmd.Action))
+	if cmd.Action != CommandSearch && cmd.Action != CommandReply && cmd.Action != CommandSend && batchCommands[cmd.Action] == "" {
+		return nil, ErrCommandNotUnderstood
+	}
+	return &cmd, nil
+}
+
+// commandFilter converts the filter of a command to an EmailFilter.
+// 폴더를 말하지 않은 보관 명령은 받은편지함 메일만 대상으로 한다.
+func commandFilter(userID uuid.UUID, cmd *ParsedCommand, loc *time.Location) (*domain.EmailFilter, error) {
+	f := cmd.Filter
+	filter := &domain.EmailFilter{UserID: userID}
+
+	folder := domain.LegacyFolder(strings.ToLower(f.Folder))
+	if folder == "" && cmd.Action == CommandArchive {
+		folder = domain.LegacyFolderInbox
+	}
+	if folder != "" {
+		filter.Folder = &folder
+	}
+	if f.Category != "" {
+		category := domain.EmailCategory(strings.ToLower(f.Category))
+		filter.Category = &category
+	}
+	if f.From != "" {
+		filter.FromEmail = &f.From
+	}
+	if f.Keyword != "" {
+		filter.Search = &f.Keyword
+	}
+	if f.Unread != nil {
+		isRead := !*f.Unread
+		filter.IsRead = &isRead
+	}
+	if f.After != "" {
+		after, err := time.ParseInLocation("2006-01-02", f.After, loc)
+		if err != nil {
+			return nil, ErrCommandNotUnderstood
+		}
+		filter.DateFrom = &after
+	}
+	if f.Before != "" {
+		before, err := time.ParseInLocation("2006-01-02", f.Before, loc)
+		if err != nil {
+			return nil, ErrCommandNotUnderstood
+		}
+		end := before.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		filter.DateTo = &end
+	}
+	return filter, nil
+}
+
+func commandCategories() string {
+	categories := []domain.EmailCategory{
+		domain.CategoryPrimary, domain.CategoryWork, domain.CategoryPersonal, domain.CategoryNotification,
+		domain.CategoryNewsletter, domain.CategoryMarketing, domain.CategorySocial, domain.CategoryFinance,
+		domain.CategoryTravel, domain.CategoryShopping, domain.CategoryDeveloper, domain.CategorySecurity,
+	}
+	names := make([]string, len(categories))
+	for i, c := range categories {
+		names[i] = string(c)
+	}
+	return strings.Join(names, ", ")
+}
+
+// executeCommandBatch runs a confirmed batch command through EmailService.
+func (o *Orchestrator) executeCommandBatch(ctx context.Context, userID uuid.UUID, data map[string]any) (any, error) {
+	if o.emailService == nil {
+		return nil, ErrEmailServiceMissing
+	}
+	ids, _ := data["email_ids"].([]int64)
+	operation := getStringFromAny(data["operation"])
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("missing required field: email_ids")
+	}
+
+	var err error
+	switch operation {
+	case CommandArchive:
+		err = o.emailService.Archive(ctx, userID, ids)
+	case CommandTrash:
+		err = o.emailService.Trash(ctx, userID, ids)
+	case CommandMarkRead:
+		err = o.emailService.MarkAsRead(ctx, userID, ids)
+	case CommandMarkUnread:
+		err = o.emailService.MarkAsUnread(ctx, userID, ids)
+	case CommandStar:
+		err = o.emailService.Star(ctx, userID, ids)
+	default:
+		return nil, fmt.Errorf("unknown operation: %s", operation)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s emails: %w", strings.ReplaceAll(operation, "_", " "), err)
+	}
+
+	logger.Info("[Orchestrator.executeCommandBatch] user=%s %s %d email(s)", userID, operation, len(ids))
+	return map[string]any{
+		"status":    operation,
+		"count":     len(ids),
+		"email_ids": ids,
+	}, nil
+}
+
+// executeCommandReply sends a confirmed reply through EmailService.
+func (o *Orchestrator) executeCommandReply(ctx context.Context, userID uuid.UUID, data map[string]any) (any, error) {
+	if o.emailService == nil {
+		return nil, ErrEmailServiceMissing
+	}
+	emailID := getInt64FromAny(data["email_id"])
+	body := getStringFromAny(data["body"])
+	if emailID == 0 || body == "" {
+		return nil, fmt.Errorf("missing required fields: email_id, body")
+	}
+
+	sent, err := o.emailService.ReplyEmail(ctx, userID, emailID, &in.ReplyEmailRequest{Body: body})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send reply: %w", err)
+	}
+	return map[string]any{
+		"status":   "replied",
+		"email_id": sent.ID,
+	}, nil
+}
+
+// executeCommandSend sends a confirmed new email through EmailService.
+func (o *Orchestrator) executeCommandSend(ctx context.Context, userID uuid.UUID, data map[string]any) (any, error) {
+	if o.emailService == nil {
+		return nil, ErrEmailServiceMissing
+	}
+	to := getStringArrayFromAny(data["to"])
+	body := getStringFromAny(data["body"])
+	if len(to) == 0 || body == "" {
+		return nil, fmt.Errorf("missing required fields: to, body")
+	}
+
+	sent, err := o.emailService.SendEmail(ctx, userID, &in.SendEmailRequest{
+		To:      to,
+		Subject: getStringFromAny(data["subject"]),
+		Body:    body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send email: %w", err)
+	}
+	return map[string]any{
+		"status":   "sent",
+		"email_id": sent.ID,
+	}, nil
+}
+
+const commandPrompt = `You turn a spoken email command into JSON. Today is %s in the user's time zone.
+
+Return a JSON object:
+{
+  "action": "search" | "archive" | "trash" | "mark_read" | "mark_unread" | "star" | "reply" | "send",
+  "filter": {
+    "category": one of [%s] or "",
+    "from": sender name or address fragment or "",
+    "keyword": subject keyword or "",
+    "after": "YYYY-MM-DD" or "",
+    "before": "YYYY-MM-DD" or "",
+    "unread": true | false | null,
+    "folder": "inbox" | "sent" | "archive" | "trash" | "spam" | ""
+  },
+  "to": recipient name or address (reply/send only),
+  "subject": short subject (send only),
+  "body": the message to send, written as a short polite email in the language of the command (reply/send only)
+}
+
+Rules:
+- "last week" means Monday to Sunday of the previous week; "today", "yesterday" and weekdays are resolved from today.
+- For reply, put the person being replied to in both "to" and "filter.from".
+- Leave fields empty when the command does not mention them.
+
+Command: %s`
@@ -0,0 +1,49 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+func TestCommandFilter(t *testing.T) {
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	unread := true
+	cmd := &ParsedCommand{
+		Action: CommandArchive,
+		Filter: CommandFilter{Category: "Newsletter", After: "2026-10-05", Before: "2026-10-11", Unread: &unread},
+	}
+
+	filter, err := commandFilter(uuid.New(), cmd, seoul)
+	if err != nil {
+		t.Fatalf("commandFilter() error = %v", err)
+	}
+	if filter.Folder == nil || *filter.Folder != domain.LegacyFolderInbox {
+		t.Errorf("archive without folder should target inbox, got %v", filter.Folder)
+	}
+	if filter.Category == nil || *filter.Category != domain.CategoryNewsletter {
+		t.Errorf("category = %v", filter.Category)
+	}
+	if filter.IsRead == nil || *filter.IsRead {
+		t.Errorf("unread command should filter is_read=false")
+	}
+	if want := time.Date(2026, 10, 5, 0, 0, 0, 0, seoul); !filter.DateFrom.Equal(want) {
+		t.Errorf("DateFrom = %v, want %v", filter.DateFrom, want)
+	}
+	// before는 그 날짜를 포함한다
+	if end := time.Date(2026, 10, 11, 23, 59, 0, 0, seoul); filter.DateTo.Before(end) {
+		t.Errorf("DateTo = %v, want end of 2026-10-11", filter.DateTo)
+	}
+
+	search, _ := commandFilter(uuid.New(), &ParsedCommand{Action: CommandSearch}, time.UTC)
+	if search.Folder != nil {
+		t.Errorf("search without folder should not restrict folder")
+	}
+
+	if _, err := commandFilter(uuid.New(), &ParsedCommand{Action: CommandTrash, Filter: CommandFilter{After: "last week"}}, time.UTC); err != ErrCommandNotUnderstood {
+		t.Errorf("invalid date error = %v, want ErrCommandNotUnderstood", err)
+	}
+}
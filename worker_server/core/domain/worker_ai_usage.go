@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AIUsage is the aggregated LLM/embedding token usage of a user, connection, task and model in a month.
+// ConnectionID가 0이면 특정 연결에 귀속되지 않은 사용량(채팅, 검색 등)이다.
+type AIUsage struct {
+	UserID           uuid.UUID `json:"-"`
+	ConnectionID     int64     `json:"connection_id"`
+	Task             string    `json:"task"`
+	Model            string    `json:"model"`
+	Period           string    `json:"period"` // YYYY-MM (UTC)
+	Requests         int64     `json:"requests"`
+	PromptTokens     int64     `json:"prompt_tokens"`
+	CompletionTokens int64     `json:"completion_tokens"`
+	CostUSD          float64   `json:"cost_usd"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// AIUsageTotals sums requests, tokens and cost.
+type AIUsageTotals struct {
+	Requests         int64   `json:"requests"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	TotalTokens      int64   `json:"total_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+}
+
+// Add accumulates u into the totals.
+func (t *AIUsageTotals) Add(u *AIUsage) {
+	t.Requests += u.Requests
+	t.PromptTokens += u.PromptTokens
+	t.CompletionTokens += u.CompletionTokens
+	t.TotalTokens += u.PromptTokens + u.CompletionTokens
+	t.CostUSD += u.CostUSD
+}
+
+// AIUsageSummary is a user's monthly AI usage against the budget.
+type AIUsageSummary struct {
+	Period       string                   `json:"period"`
+	Total        AIUsageTotals            `json:"total"`
+	ByTask       map[string]AIUsageTotals `json:"by_task"`
+	ByConnection map[int64]AIUsageTotals  `json:"by_connection"`
+	ByModel      map[string]AIUsageTotals `json:"by_model"`
+	BudgetUSD    float64                  `json:"budget_usd"` // 0 = 무제한
+	// BudgetExceeded가 true이면 비필수 작업(요약, 임베딩)이 다음 달까지 중단된다
+	BudgetExceeded bool     `json:"budget_exceeded"`
+	PausedTasks    []string `json:"paused_tasks,omitempty"`
+}
+
+// UsagePeriod returns the monthly accounting period of t (UTC).
+func UsagePeriod(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
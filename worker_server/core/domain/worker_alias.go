@@ -0,0 +1,11 @@
+package domain
+
+// EmailAlias is an address a connection can send as.
+type EmailAlias struct {
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name,omitempty"`
+	ReplyTo     string `json:"reply_to,omitempty"`
+	IsPrimary   bool   `json:"is_primary"`
+	IsDefault   bool   `json:"is_default"`
+	Verified    bool   `json:"verified"` // 미확인 주소로는 발송할 수 없다
+}
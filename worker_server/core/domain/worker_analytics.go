@@ -0,0 +1,150 @@
+package domain
+
+import "time"
+
+// Contact balance - 보낸 메일과 받은 메일의 비율로 나눈 관계 유형
+const (
+	BalanceMostlySent     = "mostly_sent"     // 보낸 메일이 받은 메일의 2배 이상
+	BalanceBalanced       = "balanced"        // 그 사이
+	BalanceMostlyReceived = "mostly_received" // 받은 메일이 보낸 메일의 2배 이상
+)
+
+// RelationshipAnalytics summarizes the user's contact graph (Neo4j COMMUNICATES_WITH 관계 기준).
+type RelationshipAnalytics struct {
+	TotalContacts  int `json:"total_contacts"`
+	ActiveContacts int `json:"active_contacts"` // 최근 90일 안에 연락한 연락처
+	EmailsSent     int `json:"emails_sent"`
+	EmailsReceived int `json:"emails_received"`
+	// SendReceiveRatio is emails_sent / emails_received (받은 메일이 없으면 null).
+	SendReceiveRatio *float64 `json:"send_receive_ratio"`
+
+	ReplyTime     *ReplyTimeDistribution `json:"reply_time"`
+	Balance       map[string]int         `json:"balance"`        // mostly_sent, balanced, mostly_received별 연락처 수
+	RelationTypes map[string]int         `json:"relation_types"` // colleague, client, ... (미분류는 unknown)
+
+	// Trend는 월별 새 연락처와 활동 연락처 수다 (첫 연락 ~ 마지막 연락 구간 기준).
+	Trend       []*RelationshipTrendPoint `json:"trend"`
+	TopContacts []*ContactAnalytics       `json:"top_contacts"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// ReplyTimeDistribution buckets contacts by their average reply time.
+type ReplyTimeDistribution struct {
+	Buckets      []*ReplyTimeBucket `json:"buckets"`
+	AvgHours     float64            `json:"avg_hours"`
+	MedianHours  float64            `json:"median_hours"`
+	SampleCount  int                `json:"sample_count"`  // 답장 시간이 기록된 연락처 수
+	UnknownCount int                `json:"unknown_count"` // 기록이 없는 연락처 수
+}
+
+// ReplyTimeBucket is one range of a reply-time distribution. MaxHours 0 means no upper bound.
+type ReplyTimeBucket struct {
+	Label    string `json:"label"`
+	MinHours int    `json:"min_hours"`
+	MaxHours int    `json:"max_hours,omitempty"`
+	Contacts int    `json:"contacts"`
+}
+
+// RelationshipTrendPoint is the contact activity of one month.
+type RelationshipTrendPoint struct {
+	Month          string `json:"month"` // YYYY-MM
+	NewContacts    int    `json:"new_contacts"`
+	ActiveContacts int    `json:"active_contacts"`
+}
+
+// ContactAnalytics is the per-contact drill-down of the relationship analytics.
+type ContactAnalytics struct {
+	Email        string `json:"email"`
+	Name         string `json:"name,omitempty"`
+	RelationType string `json:"relation_type,omitempty"`
+	Company      string `json:"company,omitempty"`
+	JobTitle     string `json:"job_title,omitempty"`
+	AvatarURL    string `json:"avatar_url,omitempty"`
+
+	EmailsSent       int      `json:"emails_sent"`
+	EmailsReceived   int      `json:"emails_received"`
+	SendReceiveRatio *float64 `json:"send_receive_ratio"`
+	Balance          string   `json:"balance"`
+	EmailsPerMonth   float64  `json:"emails_per_month"`
+
+	AvgReplyTimeHours int    `json:"avg_reply_time_hours,omitempty"`
+	ReplyTimeBucket   string `json:"reply_time_bucket,omitempty"`
+
+	FirstContact         *time.Time `json:"first_contact,omitempty"`
+	LastContact          *time.Time `json:"last_contact,omitempty"`
+	DaysSinceLastContact int        `json:"days_since_last_contact"`
+
+	ImportanceScore float64 `json:"importance_score"`
+	ImportanceRank  int     `json:"importance_rank,omitempty"` // 1 = 가장 중요한 연락처
+	IsFrequent      bool    `json:"is_frequent"`
+	IsImportant     bool    `json:"is_important"`
+}
+
+// UncategorizedCategory is the category of emails without ai_category in mailbox stats.
+const UncategorizedCategory = "uncategorized"
+
+// MailboxOverview is the mailbox analytics dashboard, read from the aggregate tables.
+// 발신자/카테고리/첨부파일은 전체 기간 누적이고, 메일 수와 읽기 시간은 조회 기간 기준이다.
+type MailboxOverview struct {
+	Days     int    `json:"days"`
+	Timezone string `json:"timezone"`
+
+	Received     int             `json:"received"`
+	Sent         int             `json:"sent"`
+	VolumeByDay  []*DailyVolume  `json:"volume_by_day"`
+	VolumeByHour []*HourlyVolume `json:"volume_by_hour"` // 0~23시, 사용자 시간대 기준
+
+	TopSenders  []SenderStat     `json:"top_senders"`
+	Categories  []*CategoryStat  `json:"categories"`
+	Attachments *AttachmentUsage `json:"attachments"`
+
+	// AvgTimeToReadMinutes is the mean time from receipt to first read (읽은 메일이 없으면 null).
+	AvgTimeToReadMinutes *float64 `json:"avg_time_to_read_minutes"`
+	ReadCount            int      `json:"read_count"`
+
+	AggregatedAt *time.Time `json:"aggregated_at,omitempty"` // 마지막 집계 시각
+}
+
+// DailyVolume is the number of emails of one day.
+type DailyVolume struct {
+	Date     string `json:"date"` // YYYY-MM-DD
+	Received int    `json:"received"`
+	Sent     int    `json:"sent"`
+}
+
+// HourlyVolume is the number of emails by hour of day.
+type HourlyVolume struct {
+	Hour     int `json:"hour"`
+	Received int `json:"received"`
+	Sent     int `json:"sent"`
+}
+
+// CategoryStat is the number of emails in one category.
+type CategoryStat struct {
+	Category string  `json:"category"`
+	Emails   int     `json:"emails"`
+	Percent  float64 `json:"percent"`
+}
+
+// AttachmentUsage is the attachment storage of a mailbox.
+type AttachmentUsage struct {
+	Count int64 `json:"count"`
+	Bytes int64 `json:"bytes"`
+}
+
+// MailboxHourlyStat is one UTC hour bucket of the mailbox aggregate.
+type MailboxHourlyStat struct {
+	Bucket      time.Time
+	Received    int
+	Sent        int
+	ReadCount   int
+	ReadSeconds int64
+}
+
+// MailboxStatsTotals is the per-user aggregate state.
+type MailboxStatsTotals struct {
+	AttachmentCount int64
+	AttachmentBytes int64
+	UpdatedAt       time.Time
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultBriefingHour is the local hour at which the daily briefing is generated.
+const DefaultBriefingHour = 7
+
+// Briefing is a user's daily digest, generated once per local day.
+type Briefing struct {
+	UserID      uuid.UUID `json:"-"`
+	Date        string    `json:"date"` // YYYY-MM-DD (사용자 시간대 기준)
+	Timezone    string    `json:"timezone"`
+	GeneratedAt time.Time `json:"generated_at"`
+
+	UnreadImportant []BriefingEmail  `json:"unread_important"` // 안읽은 높은 우선순위 메일
+	Todos           []BriefingTodo   `json:"todos"`            // 할 일 (메일 TODO + todo 항목)
+	UpcomingEvents  []BriefingEvent  `json:"upcoming_events"`  // 오늘/내일 일정 (메일에서 추출한 일정 포함)
+	AwaitingReply   []BriefingThread `json:"awaiting_reply"`   // 보낸 뒤 답장을 기다리는 스레드
+
+	EmailedAt *time.Time `json:"emailed_at,omitempty"`
+}
+
+// IsEmpty reports whether the briefing has nothing to show.
+func (b *Briefing) IsEmpty() bool {
+	return len(b.UnreadImportant) == 0 && len(b.Todos) == 0 && len(b.UpcomingEvents) == 0 && len(b.AwaitingReply) == 0
+}
+
+// BriefingEmail is an email listed in a briefing.
+type BriefingEmail struct {
+	EmailID    int64     `json:"email_id"`
+	Subject    string    `json:"subject"`
+	FromEmail  string    `json:"from_email"`
+	FromName   string    `json:"from_name,omitempty"`
+	Snippet    string    `json:"snippet"`
+	Summary    string    `json:"summary,omitempty"`
+	Priority   float64   `json:"priority"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// BriefingTodo is a pending task: a todo item or an email marked as todo.
+type BriefingTodo struct {
+	Source   string     `json:"source"` // todo, email
+	TodoID   int64      `json:"todo_id,omitempty"`
+	EmailID  *int64     `json:"email_id,omitempty"`
+	Title    string     `json:"title"`
+	Priority float64    `json:"priority"` // 0.0 ~ 1.0 (todo 우선순위도 같은 척도로 환산)
+	DueAt    *time.Time `json:"due_at,omitempty"`
+	Overdue  bool       `json:"overdue,omitempty"`
+}
+
+// BriefingEvent is an upcoming calendar event.
+type BriefingEvent struct {
+	EventID        int64     `json:"event_id"`
+	Title          string    `json:"title"`
+	StartTime      time.Time `json:"start_time"`
+	EndTime        time.Time `json:"end_time"`
+	IsAllDay       bool      `json:"is_all_day"`
+	Location       string    `json:"location,omitempty"`
+	MeetingURL     string    `json:"meeting_url,omitempty"`
+	Extracted      bool      `json:"extracted"` // 메일에서 자동 추출된 일정
+	RelatedEmailID *int64    `json:"related_email_id,omitempty"`
+}
+
+// BriefingThread is a thread whose last message was sent by the user and has no reply yet.
+type BriefingThread struct {
+	ThreadID    int64     `json:"thread_id"`
+	EmailID     int64     `json:"email_id"` // 마지막으로 보낸 메일
+	Subject     string    `json:"subject"`
+	To          []string  `json:"to"`
+	SentAt      time.Time `json:"sent_at"`
+	DaysWaiting int       `json:"days_waiting"`
+}
+
+// BriefingSettings are a user's briefing preferences.
+type BriefingSettings struct {
+	UserID       uuid.UUID `json:"-"`
+	Enabled      bool      `json:"enabled"`
+	EmailEnabled bool      `json:"email_enabled"` // 생성된 브리핑을 본인 메일함으로 발송
+	Hour         int       `json:"hour"`          // 생성 시각 (사용자 시간대 0~23)
+	Timezone     string    `json:"timezone"`      // user_settings.timezone (읽기 전용)
+}
+
+// Location returns the settings' time zone, falling back to UTC.
+func (s *BriefingSettings) Location() *time.Location {
+	if s != nil && s.Timezone != "" {
+		if loc, err := time.LoadLocation(s.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RSVP responses of a calendar invite (iTIP PARTSTAT)
+const (
+	RSVPNeedsAction = "needs_action"
+	RSVPAccepted    = "accepted"
+	RSVPTentative   = "tentative"
+	RSVPDeclined    = "declined"
+)
+
+// CalendarInvite is the event extracted from an invite email (.ics 첨부) and the user's response.
+type CalendarInvite struct {
+	EmailID     int64      `json:"email_id"`
+	UserID      uuid.UUID  `json:"-"`
+	UID         string     `json:"uid"`
+	Sequence    int        `json:"sequence"`
+	Method      string     `json:"method"` // REQUEST, CANCEL
+	Summary     string     `json:"summary"`
+	Location    string     `json:"location,omitempty"`
+	Organizer   string     `json:"organizer"`
+	Attendee    string     `json:"attendee"` // 초대받은 내 주소
+	StartTime   *time.Time `json:"start_time,omitempty"`
+	EndTime     *time.Time `json:"end_time,omitempty"`
+	IsAllDay    bool       `json:"is_all_day"`
+	Response    string     `json:"response"` // RSVP*
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
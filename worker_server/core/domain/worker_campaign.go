@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CampaignStatus represents the lifecycle of a mail merge campaign.
+type CampaignStatus string
+
+const (
+	CampaignPending   CampaignStatus = "pending"
+	CampaignSending   CampaignStatus = "sending"
+	CampaignCompleted CampaignStatus = "completed"
+	CampaignFailed    CampaignStatus = "failed"
+	CampaignCancelled CampaignStatus = "cancelled"
+)
+
+// CampaignRecipientStatus represents the delivery state of a single recipient.
+type CampaignRecipientStatus string
+
+const (
+	RecipientPending CampaignRecipientStatus = "pending"
+	RecipientSent    CampaignRecipientStatus = "sent"
+	RecipientFailed  CampaignRecipientStatus = "failed"
+	RecipientBounced CampaignRecipientStatus = "bounced" // 발송 후 바운스(DSN) 수신
+)
+
+// EmailCampaign represents a mail merge (bulk personalized send) job.
+type EmailCampaign struct {
+	ID           int64          `json:"id"`
+	UserID       uuid.UUID      `json:"user_id"`
+	ConnectionID int64          `json:"connection_id"`
+	TemplateID   *int64         `json:"template_id,omitempty"`
+	Name         string         `json:"name"`
+	Subject      string         `json:"subject"`
+	Body         string         `json:"body,omitempty"`
+	HTMLBody     string         `json:"html_body,omitempty"`
+	UseSignature bool           `json:"use_signature"`
+	ThrottleMs   int            `json:"throttle_ms"`
+	Status       CampaignStatus `json:"status"`
+	TotalCount   int            `json:"total_count"`
+	SentCount    int            `json:"sent_count"`
+	FailedCount  int            `json:"failed_count"`
+	BouncedCount int            `json:"bounced_count"`
+	ErrorMessage string         `json:"error_message,omitempty"`
+	StartedAt    *time.Time     `json:"started_at,omitempty"`
+	CompletedAt  *time.Time     `json:"completed_at,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// IsFinished returns true if the campaign reached a terminal state.
+func (c *EmailCampaign) IsFinished() bool {
+	return c.Status == CampaignCompleted || c.Status == CampaignFailed || c.Status == CampaignCancelled
+}
+
+// CampaignRecipient is a single personalized message of a campaign.
+type CampaignRecipient struct {
+	ID                int64                   `json:"id"`
+	CampaignID        int64                   `json:"campaign_id"`
+	Email             string                  `json:"email"`
+	Name              string                  `json:"name,omitempty"`
+	Variables         map[string]string       `json:"variables,omitempty"`
+	Status            CampaignRecipientStatus `json:"status"`
+	ProviderMessageID string                  `json:"provider_message_id,omitempty"`
+	ErrorMessage      string                  `json:"error_message,omitempty"`
+	SentAt            *time.Time              `json:"sent_at,omitempty"`
+}
@@ -222,14 +222,22 @@ type ClassificationResult struct {
 	Tags        []string             `json:"tags,omitempty"`
 	Score       float64              `json:"score"`
 	Source      ClassificationSource `json:"source"` // header, domain, llm, user
+	Stage       ClassificationStage  `json:"stage,omitempty"`
 
 	// Which rules matched
 	MatchedRules []int64 `json:"matched_rules,omitempty"`
 
+	// Security stage result
+	Security          *EmailSecurity `json:"security,omitempty"`
+	SecurityEscalated bool           `json:"-"` // 이번 분석으로 고위험이 된 경우 (SSE 알림 대상)
+
 	// Processing info
 	ProcessedAt time.Time `json:"processed_at"`
 	ModelUsed   string    `json:"model_used"`
 	TokensUsed  int       `json:"tokens_used"`
+
+	// Failure reason when the LLM stage failed and a default was applied (재처리 대상)
+	Error string `json:"error,omitempty"`
 }
 
 // ClassificationPipelineResult represents the result of the 3-stage classification pipeline
@@ -238,9 +246,13 @@ type ClassificationPipelineResult struct {
 	SubCategory *EmailSubCategory    `json:"sub_category,omitempty"`
 	Priority    Priority             `json:"priority"`
 	Source      ClassificationSource `json:"source"`
+	Stage       ClassificationStage  `json:"stage"`
 	Confidence  float64              `json:"confidence"`
 
 	// Cost tracking
 	LLMUsed    bool `json:"llm_used"`
 	TokensUsed int  `json:"tokens_used,omitempty"`
+
+	// Error is the LLM error when Stage is ClassificationStageLLMFailed.
+	Error string `json:"error,omitempty"`
 }
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// BackfillStatus is the state of a classification backfill.
+type BackfillStatus string
+
+const (
+	BackfillRunning   BackfillStatus = "running"
+	BackfillCompleted BackfillStatus = "completed"
+	BackfillFailed    BackfillStatus = "failed"
+)
+
+// ClassificationBackfill is the per-connection checkpoint of reclassifying unclassified emails.
+// 이메일 ID 오름차순으로 발행하고 마지막 발행 ID(CursorID)를 저장해, 워커가 죽어도 이어서 처리한다.
+type ClassificationBackfill struct {
+	ConnectionID int64          `json:"connection_id"`
+	UserID       string         `json:"user_id"`
+	Status       BackfillStatus `json:"status"`
+	CursorID     int64          `json:"cursor_id"`
+	Total        int            `json:"total"`     // 시작 시점 미분류 개수
+	Published    int            `json:"published"` // ai:classify로 발행한 개수
+	Failed       int            `json:"failed"`    // 발행 실패 개수
+	Percent      float64        `json:"percent"`
+	LastError    string         `json:"last_error,omitempty"`
+	StartedAt    time.Time      `json:"started_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	CompletedAt  *time.Time     `json:"completed_at,omitempty"`
+}
+
+// Fill computes Percent. 시작 후 새로 들어온 메일이 있으면 Published가 Total을 넘을 수 있다.
+func (b *ClassificationBackfill) Fill() {
+	switch {
+	case b.Status == BackfillCompleted:
+		b.Percent = 100
+	case b.Total > 0:
+		b.Percent = min(float64(b.Published+b.Failed)*100/float64(b.Total), 99)
+	}
+}
@@ -251,9 +251,13 @@ type ScoreResult struct {
 type ClassificationStage string
 
 const (
-	ClassificationStageRFC    ClassificationStage = "rfc"
-	ClassificationStageSender ClassificationStage = "sender"
-	ClassificationStageRule   ClassificationStage = "rule"
-	ClassificationStageCache  ClassificationStage = "cache"
-	ClassificationStageLLM    ClassificationStage = "llm"
+	ClassificationStageRFC         ClassificationStage = "rfc"
+	ClassificationStageDomainScore ClassificationStage = "domain_score" // 알려진 서비스 도메인
+	ClassificationStageSubject     ClassificationStage = "subject"      // 제목 패턴
+	ClassificationStageSender      ClassificationStage = "sender"       // SenderProfile, KnownDomain DB
+	ClassificationStageRule        ClassificationStage = "rule"         // 사용자 도메인/키워드 규칙
+	ClassificationStageCache       ClassificationStage = "cache"
+	ClassificationStageLLM         ClassificationStage = "llm"
+	ClassificationStageLLMFailed   ClassificationStage = "llm_failed" // LLM 호출 실패 → 기본값
+	ClassificationStageDefault     ClassificationStage = "default"    // 매칭 단계 없음 + LLM 비활성 → 기본값
 )
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Classification modes of an attempt
+const (
+	ClassifyModeLLM       = "llm"
+	ClassifyModeHeuristic = "heuristic" // LLM 없이 규칙/도메인 단계만, 매칭이 없으면 기본값
+)
+
+// ClassifyAttempt is one classification try of an email (실패 재처리 이력).
+type ClassifyAttempt struct {
+	ID        int64     `json:"id"`
+	EmailID   int64     `json:"email_id"`
+	UserID    uuid.UUID `json:"-"`
+	Mode      string    `json:"mode"`
+	Model     string    `json:"model,omitempty"`
+	Succeeded bool      `json:"succeeded"`
+	Stage     string    `json:"stage,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FailedClassification is an email whose classification failed, with its last failure reason.
+type FailedClassification struct {
+	EmailID       int64      `json:"email_id"`
+	ConnectionID  int64      `json:"connection_id"`
+	Subject       string     `json:"subject"`
+	FromEmail     string     `json:"from_email"`
+	Error         string     `json:"error,omitempty"`
+	Attempts      int        `json:"attempts"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+}
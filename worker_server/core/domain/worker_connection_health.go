@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// ConnectionHealthStatus summarizes whether a connection can sync and send.
+type ConnectionHealthStatus string
+
+const (
+	ConnectionHealthy  ConnectionHealthStatus = "healthy"
+	ConnectionDegraded ConnectionHealthStatus = "degraded" // 동작은 하지만 실시간 동기화/지연 문제
+	ConnectionBroken   ConnectionHealthStatus = "broken"   // 재연결 필요
+)
+
+// Connection health issue codes.
+const (
+	HealthIssueDisconnected     = "disconnected"
+	HealthIssueTokenRefresh     = "token_refresh_failed"
+	HealthIssueTokenInvalid     = "token_invalid"
+	HealthIssueProviderError    = "provider_unreachable"
+	HealthIssueWatchMissing     = "watch_missing"
+	HealthIssueWatchExpired     = "watch_expired"
+	HealthIssueSyncError        = "sync_error"
+	HealthIssueSyncStale        = "sync_stale"
+	HealthIssueNeverSynced      = "never_synced"
+	HealthIssueSyncStateMissing = "sync_state_missing"
+)
+
+// ConnectionHealth is the result of GET /connections/:id/health.
+type ConnectionHealth struct {
+	ConnectionID   int64                  `json:"connection_id"`
+	Provider       OAuthProvider          `json:"provider"`
+	Email          string                 `json:"email"`
+	Status         ConnectionHealthStatus `json:"status"`
+	NeedsReconnect bool                   `json:"needs_reconnect"`
+	Issues         []string               `json:"issues"`
+
+	Token TokenHealth `json:"token"`
+	Watch WatchHealth `json:"watch"`
+	Sync  SyncHealth  `json:"sync"`
+
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// TokenHealth reports the OAuth token state.
+type TokenHealth struct {
+	Valid     bool      `json:"valid"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// WatchHealth reports the push notification (Gmail watch / Graph subscription) state.
+type WatchHealth struct {
+	Active     bool       `json:"active"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	ResourceID string     `json:"resource_id,omitempty"`
+}
+
+// SyncHealth reports the last sync outcome and how far behind the mailbox is.
+type SyncHealth struct {
+	Status         SyncStatus `json:"status,omitempty"`
+	Phase          SyncPhase  `json:"phase,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	LastSuccessAt  *time.Time `json:"last_success_at,omitempty"`
+	LagSeconds     int64      `json:"lag_seconds"`
+	RetryCount     int        `json:"retry_count,omitempty"`
+	NextRetryAt    *time.Time `json:"next_retry_at,omitempty"`
+	TotalSynced    int64      `json:"total_synced"`
+	LastSyncCount  int        `json:"last_sync_count"`
+	LastDurationMs int        `json:"last_duration_ms,omitempty"`
+
+	// 진행 중인 초기 동기화 (체크포인트 기준)
+	Progress *SyncProgressData `json:"progress,omitempty"`
+}
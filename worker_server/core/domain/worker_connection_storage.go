@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+// ConnectionSyncMode decides what sync stores for a connection.
+type ConnectionSyncMode string
+
+const (
+	ConnectionSyncFull     ConnectionSyncMode = "full"     // 본문 캐시 + 임베딩
+	ConnectionSyncMetadata ConnectionSyncMode = "metadata" // 메타데이터만 (본문은 열람 시 가져와 짧게 캐시)
+)
+
+// IsValid reports whether m is a known sync mode.
+func (m ConnectionSyncMode) IsValid() bool {
+	return m == ConnectionSyncFull || m == ConnectionSyncMetadata
+}
+
+// StorageUsage is the number of stored items and their size in bytes.
+type StorageUsage struct {
+	Count int64 `json:"count"`
+	Bytes int64 `json:"bytes"`
+}
+
+// ConnectionStorage is the storage consumed by one connection (GET /connections/:id/usage).
+// 상한을 넘으면 MetadataOnly가 켜져 본문/임베딩 저장을 멈춘다.
+type ConnectionStorage struct {
+	ConnectionID int64  `json:"connection_id"`
+	UserID       string `json:"user_id"`
+
+	Metadata    StorageUsage `json:"metadata"`    // emails 행 (PostgreSQL)
+	Bodies      StorageUsage `json:"bodies"`      // 본문 캐시 (MongoDB, 압축 후)
+	Attachments StorageUsage `json:"attachments"` // 첨부파일 메타데이터
+	Vectors     StorageUsage `json:"vectors"`     // 임베딩 (pgvector)
+
+	SyncMode          ConnectionSyncMode `json:"sync_mode"`           // 사용자 설정
+	CapBytes          *int64             `json:"cap_bytes,omitempty"` // 연결별 상한 (nil = 기본값)
+	MetadataOnly      bool               `json:"metadata_only"`       // 상한 초과로 전환됨
+	MetadataOnlySince *time.Time         `json:"metadata_only_since,omitempty"`
+	MeasuredAt        *time.Time         `json:"measured_at,omitempty"` // 아직 측정 전이면 nil
+
+	// Local - MBOX/EML 가져오기 연결. 본문을 다시 가져올 수 없어 metadata 모드/상한을 적용하지 않는다.
+	Local bool `json:"local"`
+}
+
+// SkipsBodies reports whether bodies and embeddings must not be stored (사용자 설정 또는 상한 초과).
+func (s *ConnectionStorage) SkipsBodies() bool {
+	if s.Local {
+		return false
+	}
+	return s.SyncMode == ConnectionSyncMetadata || s.MetadataOnly
+}
+
+// TotalBytes returns the storage consumed across all stores.
+func (s *ConnectionStorage) TotalBytes() int64 {
+	return s.Metadata.Bytes + s.Bodies.Bytes + s.Attachments.Bytes + s.Vectors.Bytes
+}
@@ -57,6 +57,19 @@ type Company struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// ContactEnrichment is public profile data looked up for a contact (Gravatar, Clearbit 등).
+type ContactEnrichment struct {
+	Company   string `json:"company,omitempty"`
+	JobTitle  string `json:"job_title,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+	Source    string `json:"source,omitempty"`
+}
+
+// IsEmpty reports whether the lookup found nothing.
+func (e *ContactEnrichment) IsEmpty() bool {
+	return e == nil || (e.Company == "" && e.JobTitle == "" && e.AvatarURL == "")
+}
+
 type ContactFilter struct {
 	UserID  uuid.UUID
 	Search  *string
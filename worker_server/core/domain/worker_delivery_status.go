@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryState represents the delivery outcome of a sent message for one recipient.
+type DeliveryState string
+
+const (
+	DeliveryDelivered  DeliveryState = "delivered"
+	DeliveryDelayed    DeliveryState = "delayed"
+	DeliveryBounced    DeliveryState = "bounced"
+	DeliveryComplained DeliveryState = "complained" // 수신자 스팸 신고 (ARF)
+)
+
+// severity orders states so the worst one wins when summarizing.
+func (s DeliveryState) severity() int {
+	switch s {
+	case DeliveryComplained:
+		return 3
+	case DeliveryBounced:
+		return 2
+	case DeliveryDelayed:
+		return 1
+	}
+	return 0
+}
+
+// DeliveryEvent is a bounce/complaint report for a single recipient, parsed during sync.
+type DeliveryEvent struct {
+	ID                int64         `json:"id"`
+	UserID            uuid.UUID     `json:"user_id"`
+	ConnectionID      int64         `json:"connection_id"`
+	SentEmailID       *int64        `json:"sent_email_id,omitempty"`
+	ReportEmailID     int64         `json:"report_email_id,omitempty"`
+	OriginalMessageID string        `json:"original_message_id,omitempty"`
+	Recipient         string        `json:"recipient"`
+	State             DeliveryState `json:"state"`
+	StatusCode        string        `json:"status_code,omitempty"`
+	Diagnostic        string        `json:"diagnostic,omitempty"`
+	Permanent         bool          `json:"permanent"`
+	ReportingMTA      string        `json:"reporting_mta,omitempty"`
+	ReportedAt        time.Time     `json:"reported_at"`
+}
+
+// RecipientDeliveryStatus is the latest known state of one recipient.
+// Confirmed가 false이면 리포트가 없어 전달된 것으로 간주한 상태다.
+type RecipientDeliveryStatus struct {
+	Email      string        `json:"email"`
+	State      DeliveryState `json:"state"`
+	Confirmed  bool          `json:"confirmed"`
+	StatusCode string        `json:"status_code,omitempty"`
+	Diagnostic string        `json:"diagnostic,omitempty"`
+	Permanent  bool          `json:"permanent,omitempty"`
+	ReportedAt *time.Time    `json:"reported_at,omitempty"`
+}
+
+// SentDeliveryStatus summarizes delivery of a sent message.
+type SentDeliveryStatus struct {
+	EmailID    int64                      `json:"email_id"`
+	MessageID  string                     `json:"message_id,omitempty"`
+	Status     DeliveryState              `json:"status"` // 가장 나쁜 수신자 상태
+	Recipients []*RecipientDeliveryStatus `json:"recipients"`
+}
+
+// BuildSentDeliveryStatus merges delivery events into per-recipient states.
+// 같은 수신자의 리포트가 여러 개면 가장 최근 리포트를 사용한다 (delayed → bounced 등).
+func BuildSentDeliveryStatus(emailID int64, messageID string, recipients []string, events []*DeliveryEvent) *SentDeliveryStatus {
+	status := &SentDeliveryStatus{
+		EmailID:   emailID,
+		MessageID: messageID,
+		Status:    DeliveryDelivered,
+	}
+
+	byEmail := make(map[string]*RecipientDeliveryStatus, len(recipients))
+	add := func(email string) *RecipientDeliveryStatus {
+		if r, ok := byEmail[email]; ok {
+			return r
+		}
+		r := &RecipientDeliveryStatus{Email: email, State: DeliveryDelivered}
+		byEmail[email] = r
+		status.Recipients = append(status.Recipients, r)
+		return r
+	}
+
+	for _, email := range recipients {
+		add(email)
+	}
+	for _, e := range events {
+		r := add(e.Recipient)
+		if r.ReportedAt != nil && r.ReportedAt.After(e.ReportedAt) {
+			continue
+		}
+		reportedAt := e.ReportedAt
+		r.State = e.State
+		r.Confirmed = true
+		r.StatusCode = e.StatusCode
+		r.Diagnostic = e.Diagnostic
+		r.Permanent = e.Permanent
+		r.ReportedAt = &reportedAt
+	}
+
+	for _, r := range status.Recipients {
+		if r.State.severity() > status.Status.severity() {
+			status.Status = r.State
+		}
+	}
+	if status.Recipients == nil {
+		status.Recipients = []*RecipientDeliveryStatus{}
+	}
+	return status
+}
@@ -12,6 +12,7 @@ type Provider string
 const (
 	MailProviderGmail   Provider = "google" // DB enum: google, outlook
 	MailProviderOutlook Provider = "outlook"
+	MailProviderLocal   Provider = "local" // MBOX/EML 가져오기 (Provider 없음)
 )
 
 // LegacyFolder is the old folder type kept for backward compatibility
@@ -141,6 +142,7 @@ type Email struct {
 	AccountEmail string    `json:"account_email"` // OAuth account email
 	ProviderID   string    `json:"provider_id"`
 	ThreadID     string    `json:"thread_id"`
+	MessageID    string    `json:"message_id,omitempty"` // RFC 5322 Message-ID (꺾쇠 제외)
 
 	// Headers
 	Subject   string    `json:"subject"`
@@ -162,6 +164,10 @@ type Email struct {
 	IsRead    bool `json:"is_read"`
 	IsStarred bool `json:"is_starred"`
 	HasAttach bool `json:"has_attachments"`
+	IsPinned  bool `json:"is_pinned,omitempty"` // 고정 (inbox/todo/pinned 목록에서만 채움)
+
+	// SLAStatus: 공유 메일함의 응답 SLA 상태 (SLA가 설정된 조직 메일함의 메일만, 목록에서 채움)
+	SLAStatus SLAStatus `json:"sla_status,omitempty"`
 
 	// AI Classification (updated to use new types)
 	AICategory           *EmailCategory        `json:"ai_category,omitempty"`
@@ -171,10 +177,21 @@ type Email struct {
 	AITags               []string              `json:"ai_tags,omitempty"`
 	AIScore              *float64              `json:"ai_score,omitempty"`
 	ClassificationSource *ClassificationSource `json:"classification_source,omitempty"`
+	ClassificationStage  *ClassificationStage  `json:"classification_stage,omitempty"`
+	Language             *string               `json:"language,omitempty"` // 분류 시 감지한 언어 (ISO 639-1)
 
 	// RFC Classification Headers (for Stage 0 classification)
 	ClassificationHeaders *ClassificationHeaders `json:"classification_headers,omitempty"`
 
+	// Security analysis (phishing/spoofing)
+	Security *EmailSecurity `json:"security,omitempty"`
+
+	// TODO view urgency (priority + deadline, ListTodo에서만 채움)
+	Urgency *TodoUrgency `json:"urgency,omitempty"`
+
+	// Private notes (상세 조회에서만 채움, Provider에는 동기화하지 않음)
+	Notes []*EmailNote `json:"notes,omitempty"`
+
 	// Workflow
 	WorkflowStatus WorkflowStatus `json:"workflow_status"`
 	SnoozedUntil   *time.Time     `json:"snoozed_until,omitempty"`
@@ -192,6 +209,10 @@ type EmailBody struct {
 	HTMLBody     string        `json:"html_body"`
 	IsCompressed bool          `json:"is_compressed"`
 	Attachments  []*Attachment `json:"attachments,omitempty"` // 첨부파일 메타 포함
+
+	// 응답 시 HTML 정리 결과 (sanitize=off면 생략)
+	Sanitize        string `json:"sanitize,omitempty"`
+	TrackersBlocked int    `json:"trackers_blocked"`
 }
 
 // Attachment represents an email attachment metadata
@@ -286,10 +307,14 @@ type EmailFilter struct {
 	MinPriority    *Priority // Minimum priority threshold (>= this value)
 	IsRead         *bool
 	IsStarred      *bool
-	HasAttachment  *bool // has:attachment filter (Provider-compatible)
+	HasAttachment  *bool  // has:attachment filter (Provider-compatible)
+	MinSize        *int64 // 크기 하한 (bytes, Provider 검색 전용 - DB에 크기 없음)
+	MaxSize        *int64 // 크기 상한 (bytes, Provider 검색 전용)
 	Search         *string
 	FromEmail      *string
 	FromDomain     *string
+	Sender         *string // from_email 정확히 일치 (피드 그룹 펼치기)
+	Language       *string // 감지된 언어 (ISO 639-1, 예: en, ko)
 	DateFrom       *time.Time
 	DateTo         *time.Time
 	LabelIDs       []int64
@@ -297,6 +322,9 @@ type EmailFilter struct {
 	Limit          int
 	Offset         int
 
+	// Fields: 응답에 포함할 JSON 필드 (?fields=, 예: id,subject,from_email). 비어 있으면 전체
+	Fields []string
+
 	// === Inbox/Category View Filters ===
 	// ViewType: predefined view filter
 	// "inbox" = personal mail only (primary, work, personal) in inbox folder
@@ -319,9 +347,35 @@ type EmailFilter struct {
 	ActionRequired *bool
 
 	// === Sorting ===
-	// SortBy: "date" (default), "priority"
+	// SortBy: "date" (default), "priority", "urgency"
 	// "priority" = ai_priority DESC, email_date DESC (TODO view)
+	// "urgency" = ai_priority + 마감일 근접도 DESC, email_date DESC (TODO view)
 	SortBy string
+
+	// Timezone: IANA timezone used by "urgency" sorting (마감일까지 남은 일수 계산)
+	Timezone string
+
+	// PinnedFirst: 고정된 메일을 날짜와 관계없이 맨 위에 표시 (inbox/todo)
+	// PinnedOnly: 고정된 메일만 고정 순서대로 조회 (GET /email/pinned)
+	PinnedFirst bool
+	PinnedOnly  bool
+
+	// ReadLaterOnly: 나중에 읽기 큐만 조회 (GET /email/read-later)
+	// ExcludeReadLater: 나중에 읽기 큐에 있는 메일 제외 (inbox/카테고리 목록)
+	ReadLaterOnly    bool
+	ExcludeReadLater bool
+
+	// AssignedToMe: 공유 메일함에서 요청한 사용자에게 지정된 메일 (다른 멤버의 메일함 포함)
+	AssignedToMe bool
+
+	// Deleted: 삭제 후 복원 가능한 메일만 최근 삭제 순으로 조회 (GET /email/deleted)
+	Deleted bool
+
+	// IncludeArchived: 아카이브된 오래된 메일도 함께 조회 (대용량 메일함)
+	IncludeArchived bool
+
+	// PrimaryRead: read replica 대신 primary에서 조회 (동기화 직후 목록 캐시 워밍)
+	PrimaryRead bool
 }
 
 type EmailRepository interface {
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Email activity types
+const (
+	ActivitySynced      = "synced"
+	ActivityClassified  = "classified"
+	ActivityRuleApplied = "rule_applied"
+	ActivityRead        = "read"
+	ActivityUnread      = "unread"
+	ActivityStarred     = "starred"
+	ActivityUnstarred   = "unstarred"
+	ActivityArchived    = "archived"
+	ActivityTrashed     = "trashed"
+	ActivityDeleted     = "deleted"
+	ActivityRestored    = "restored"
+	ActivityMoved       = "moved"
+	ActivitySnoozed     = "snoozed"
+	ActivityUnsnoozed   = "unsnoozed"
+	ActivityWorkflow    = "workflow"
+	ActivityLabeled     = "labeled"
+	ActivityReplied     = "replied"
+	ActivityForwarded   = "forwarded"
+	ActivityAIJob       = "ai_job"
+)
+
+// Email activity sources (누가 변경했는지)
+const (
+	ActivitySourceSync = "sync"
+	ActivitySourceUser = "user"
+	ActivitySourceAI   = "ai"
+)
+
+// EmailActivity is an entry of the per-email timeline ("왜 이 메일이 이동했는지" 디버깅용).
+type EmailActivity struct {
+	ID        int64          `json:"id"`
+	EmailID   int64          `json:"email_id"`
+	UserID    uuid.UUID      `json:"-"`
+	Type      string         `json:"type"`
+	Source    string         `json:"source"`
+	Detail    map[string]any `json:"detail,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
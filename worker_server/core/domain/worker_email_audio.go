@@ -0,0 +1,17 @@
+package domain
+
+// Email audio modes (GET /email/:id/audio?mode=)
+const (
+	EmailAudioSummary = "summary" // 발신자, 제목, AI 요약 (요약이 없으면 본문)
+	EmailAudioFull    = "full"    // 발신자, 제목, 본문 전체
+)
+
+// EmailAudio describes the spoken version of an email.
+type EmailAudio struct {
+	EmailID  int64
+	Mode     string
+	Voice    string
+	MimeType string
+	Size     int64 // 캐시된 오디오만 알 수 있음 (생성 중이면 -1)
+	Cached   bool
+}
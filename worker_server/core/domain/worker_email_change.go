@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Email change types (GET /email/changes)
+const (
+	EmailChangeCreated = "created" // 새 메일 또는 복원
+	EmailChangeUpdated = "updated"
+	EmailChangeDeleted = "deleted" // soft delete 또는 영구 삭제
+)
+
+// EmailChange is an entry of the email change log written by the emails trigger.
+type EmailChange struct {
+	ID        int64     `json:"id"`
+	TxID      int64     `json:"-"` // 기록한 트랜잭션 (커서 순서)
+	UserID    uuid.UUID `json:"user_id"`
+	EmailID   int64     `json:"email_id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EmailChangePosition is a position in the change log, ordered by (TxID, ID).
+// id만으로는 커밋 순서와 달라 늦게 커밋된 작은 id를 건너뛸 수 있다.
+type EmailChangePosition struct {
+	TxID int64
+	ID   int64
+}
+
+// EmailChanges is the delta since a sync token, collapsed to the latest change per email.
+type EmailChanges struct {
+	Created []int64 `json:"created"`
+	Updated []int64 `json:"updated"`
+	Deleted []int64 `json:"deleted"`
+	Cursor  string  `json:"cursor"` // 다음 요청의 since
+	HasMore bool    `json:"has_more"`
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailDeadline is a deadline mentioned in an email's subject or body.
+type EmailDeadline struct {
+	EmailID     int64     `json:"email_id"`
+	UserID      uuid.UUID `json:"-"`
+	DeadlineAt  time.Time `json:"deadline_at"`
+	SourceText  string    `json:"source_text"` // 원문 표현 (예: "by Friday 5pm")
+	Explicit    bool      `json:"explicit"`    // "by", "due", "까지" 같은 마감 표현이 붙었는지
+	ExtractedAt time.Time `json:"extracted_at"`
+}
+
+// TodoUrgency explains where an email lands in the TODO view.
+type TodoUrgency struct {
+	Score        float64    `json:"score"`
+	Priority     float64    `json:"priority"`
+	DeadlineAt   *time.Time `json:"deadline_at,omitempty"`
+	DeadlineText string     `json:"deadline_text,omitempty"`
+	DaysLeft     *int       `json:"days_left,omitempty"` // 사용자 시간대 기준 남은 일수 (음수면 지남)
+	Explain      string     `json:"explain"`
+}
+
+const (
+	// UrgencyPriorityWeight / UrgencyDeadlineWeight - 긴급도 = 우선순위*0.6 + 마감 근접도*0.4
+	UrgencyPriorityWeight = 0.6
+	UrgencyDeadlineWeight = 0.4
+)
+
+// DeadlineProximity maps days left until a deadline to a 0~1 factor.
+// persistence 어댑터의 SQL 정렬식과 같은 구간을 써야 한다.
+func DeadlineProximity(daysLeft int) float64 {
+	switch {
+	case daysLeft < -7:
+		return 0 // 오래 지난 마감은 더 이상 밀어 올리지 않는다
+	case daysLeft < 0:
+		return 0.9
+	case daysLeft == 0:
+		return 1.0
+	case daysLeft == 1:
+		return 0.8
+	case daysLeft <= 3:
+		return 0.6
+	case daysLeft <= 7:
+		return 0.4
+	case daysLeft <= 14:
+		return 0.2
+	default:
+		return 0
+	}
+}
+
+// ScoreTodoUrgency combines AI priority with deadline proximity in the user's timezone.
+// 날짜 차이는 시각이 아닌 loc 기준 달력 날짜로 계산한다.
+func ScoreTodoUrgency(priority *Priority, deadline *EmailDeadline, now time.Time, loc *time.Location) *TodoUrgency {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	p := PriorityNormal
+	if priority != nil {
+		p = *priority
+	}
+
+	u := &TodoUrgency{Priority: float64(p)}
+	parts := []string{fmt.Sprintf("%s priority (%.2f)", p.String(), float64(p))}
+
+	proximity := 0.0
+	if deadline != nil {
+		days := calendarDaysBetween(now.In(loc), deadline.DeadlineAt.In(loc))
+		at := deadline.DeadlineAt.In(loc)
+		u.DeadlineAt = &at
+		u.DeadlineText = deadline.SourceText
+		u.DaysLeft = &days
+		proximity = DeadlineProximity(days)
+
+		due := describeDaysLeft(days)
+		if deadline.SourceText != "" {
+			due += fmt.Sprintf(" (%q)", deadline.SourceText)
+		}
+		parts = append(parts, due)
+	} else {
+		parts = append(parts, "no deadline found")
+	}
+
+	u.Score = UrgencyPriorityWeight*float64(p) + UrgencyDeadlineWeight*proximity
+	u.Explain = strings.Join(parts, ", ")
+	return u
+}
+
+func calendarDaysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+func describeDaysLeft(days int) string {
+	switch {
+	case days < -1:
+		return fmt.Sprintf("overdue by %d days", -days)
+	case days == -1:
+		return "overdue since yesterday"
+	case days == 0:
+		return "due today"
+	case days == 1:
+		return "due tomorrow"
+	default:
+		return fmt.Sprintf("due in %d days", days)
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Duplicate reasons (중복 메일 판별 기준)
+const (
+	DuplicateReasonMessageID = "message_id" // 같은 Message-ID가 여러 연결 계정으로 배달됨
+	DuplicateReasonForwarded = "forwarded"  // 내 다른 계정으로 전달한 사본
+)
+
+// forwardPrefix matches the forward prefixes of a subject ("Fwd:", "FW:", "전달:" 반복 포함).
+var forwardPrefix = regexp.MustCompile(`(?i)^\s*((fwd?|fw|전달)\s*:\s*)+`)
+
+// DuplicateCopy is a redundant copy of an email.
+type DuplicateCopy struct {
+	EmailID      int64     `json:"email_id"`
+	ConnectionID int64     `json:"connection_id"`
+	Folder       string    `json:"folder"`
+	Reason       string    `json:"reason"`
+	ReceivedAt   time.Time `json:"received_at"`
+}
+
+// DuplicateGroup is an email kept in views with its redundant copies.
+type DuplicateGroup struct {
+	EmailID      int64            `json:"email_id"` // 남겨둘 메일
+	ConnectionID int64            `json:"connection_id"`
+	Subject      string           `json:"subject"`
+	Duplicates   []*DuplicateCopy `json:"duplicates"`
+}
+
+// StripForwardPrefix removes forward prefixes from a subject and reports whether any were present.
+func StripForwardPrefix(subject string) (string, bool) {
+	loc := forwardPrefix.FindStringIndex(subject)
+	if loc == nil {
+		return strings.TrimSpace(subject), false
+	}
+	return strings.TrimSpace(subject[loc[1]:]), true
+}
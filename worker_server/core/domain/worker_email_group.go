@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Feed group actions (그룹 단위 일괄 처리)
+const (
+	GroupActionRead    = "read"
+	GroupActionArchive = "archive"
+)
+
+// SenderGroup is a feed rollup of one sender's emails in a category (예: "GitHub — 14 notifications").
+type SenderGroup struct {
+	Sender      string `json:"sender"` // 소문자 from_email (펼치기: ?sender=)
+	SenderName  string `json:"sender_name,omitempty"`
+	Label       string `json:"label"`
+	Count       int    `json:"count"`
+	UnreadCount int    `json:"unread_count"`
+
+	LatestEmailID int64     `json:"latest_email_id"`
+	LatestSubject string    `json:"latest_subject"`
+	LatestAt      time.Time `json:"latest_at"`
+}
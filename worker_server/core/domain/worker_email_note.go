@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailNote is a private note attached to an email.
+// 로컬에만 저장되며 Provider(Gmail/Outlook)에는 동기화하지 않는다.
+type EmailNote struct {
+	ID        int64     `json:"id"`
+	EmailID   int64     `json:"email_id"`
+	UserID    uuid.UUID `json:"-"`
+	Body      string    `json:"body"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// ReaderView is the reader mode of a newsletter: 본문만 추출하고 추적 픽셀/링크 추적을 제거한 결과.
+type ReaderView struct {
+	EmailID   int64     `json:"email_id"`
+	Subject   string    `json:"subject"`
+	FromName  string    `json:"from_name,omitempty"`
+	FromEmail string    `json:"from_email"`
+	Date      time.Time `json:"date"`
+
+	ContentHTML string `json:"content_html"` // p, h1~h6, a, img, ul/ol, blockquote만 남긴 HTML
+	ContentText string `json:"content_text"`
+
+	WordCount       int `json:"word_count"`
+	ReadingMinutes  int `json:"reading_minutes"`
+	TrackersRemoved int `json:"trackers_removed"`
+	LinksCleaned    int `json:"links_cleaned"`
+
+	InReadLater bool `json:"in_read_later"`
+}
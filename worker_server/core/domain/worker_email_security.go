@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"time"
+)
+
+// =============================================================================
+// Email Security (Phishing / Spoofing Detection)
+// =============================================================================
+
+// SecurityRiskLevel represents phishing/spoofing risk of an email.
+type SecurityRiskLevel string
+
+const (
+	SecurityRiskNone   SecurityRiskLevel = "none"
+	SecurityRiskLow    SecurityRiskLevel = "low"
+	SecurityRiskMedium SecurityRiskLevel = "medium"
+	SecurityRiskHigh   SecurityRiskLevel = "high"
+)
+
+// SecuritySignalCode identifies a single phishing indicator.
+type SecuritySignalCode string
+
+const (
+	SignalDMARCFail           SecuritySignalCode = "dmarc_fail"
+	SignalSPFFail             SecuritySignalCode = "spf_fail"
+	SignalDKIMFail            SecuritySignalCode = "dkim_fail"
+	SignalLookalikeDomain     SecuritySignalCode = "lookalike_domain"
+	SignalDisplayNameMismatch SecuritySignalCode = "display_name_mismatch"
+	SignalReplyToMismatch     SecuritySignalCode = "reply_to_mismatch"
+	SignalSuspiciousURL       SecuritySignalCode = "suspicious_url"
+	SignalLinkTextMismatch    SecuritySignalCode = "link_text_mismatch"
+)
+
+// 위험도 임계값
+const (
+	SecurityHighThreshold   = 0.7
+	SecurityMediumThreshold = 0.4
+)
+
+// SecuritySignal is a detected phishing indicator with its weight.
+type SecuritySignal struct {
+	Code   SecuritySignalCode `json:"code"`
+	Detail string             `json:"detail,omitempty"`
+	Weight float64            `json:"weight"`
+}
+
+// EmailSecurity is the result of the security analysis stage.
+type EmailSecurity struct {
+	EmailID    int64             `json:"email_id,omitempty"`
+	RiskLevel  SecurityRiskLevel `json:"risk_level"`
+	RiskScore  float64           `json:"risk_score"`
+	Signals    []SecuritySignal  `json:"signals,omitempty"`
+	SPF        string            `json:"spf,omitempty"`   // pass, fail, softfail, none ...
+	DKIM       string            `json:"dkim,omitempty"`  // pass, fail, none ...
+	DMARC      string            `json:"dmarc,omitempty"` // pass, fail, none ...
+	AnalyzedAt time.Time         `json:"analyzed_at"`
+}
+
+// AddSignal appends a signal, ignoring duplicates of the same code.
+func (s *EmailSecurity) AddSignal(code SecuritySignalCode, detail string, weight float64) {
+	for _, sig := range s.Signals {
+		if sig.Code == code {
+			return
+		}
+	}
+	s.Signals = append(s.Signals, SecuritySignal{Code: code, Detail: detail, Weight: weight})
+}
+
+// HasSignal reports whether the signal code was detected.
+func (s *EmailSecurity) HasSignal(code SecuritySignalCode) bool {
+	for _, sig := range s.Signals {
+		if sig.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Recalculate recomputes RiskScore and RiskLevel from signals.
+func (s *EmailSecurity) Recalculate() {
+	score := 0.0
+	for _, sig := range s.Signals {
+		score += sig.Weight
+	}
+	if score > 1 {
+		score = 1
+	}
+	s.RiskScore = score
+
+	switch {
+	case score >= SecurityHighThreshold:
+		s.RiskLevel = SecurityRiskHigh
+	case score >= SecurityMediumThreshold:
+		s.RiskLevel = SecurityRiskMedium
+	case score > 0:
+		s.RiskLevel = SecurityRiskLow
+	default:
+		s.RiskLevel = SecurityRiskNone
+	}
+}
+
+// Merge combines a later analysis (e.g. body-based) into an earlier one (header-based).
+// 인증 결과는 비어있지 않은 값만 덮어쓴다.
+func (s *EmailSecurity) Merge(other *EmailSecurity) {
+	if other == nil {
+		return
+	}
+	for _, sig := range other.Signals {
+		s.AddSignal(sig.Code, sig.Detail, sig.Weight)
+	}
+	if other.SPF != "" {
+		s.SPF = other.SPF
+	}
+	if other.DKIM != "" {
+		s.DKIM = other.DKIM
+	}
+	if other.DMARC != "" {
+		s.DMARC = other.DMARC
+	}
+	if other.AnalyzedAt.After(s.AnalyzedAt) {
+		s.AnalyzedAt = other.AnalyzedAt
+	}
+	s.Recalculate()
+}
+
+// IsHighRisk returns true if the email should trigger a security alert.
+func (s *EmailSecurity) IsHighRisk() bool {
+	return s != nil && s.RiskLevel == SecurityRiskHigh
+}
+
+// SecurityAlertData - 고위험 메일 SSE 알림 데이터
+type SecurityAlertData struct {
+	EmailID   int64             `json:"email_id"`
+	Subject   string            `json:"subject"`
+	From      string            `json:"from"`
+	FromName  string            `json:"from_name,omitempty"`
+	RiskLevel SecurityRiskLevel `json:"risk_level"`
+	RiskScore float64           `json:"risk_score"`
+	Signals   []SecuritySignal  `json:"signals"`
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailShare is an expiring, read-only public link to an email.
+// 토큰은 해시만 저장하므로 URL은 생성 응답에서만 받을 수 있다.
+type EmailShare struct {
+	ID            int64      `json:"id"`
+	EmailID       int64      `json:"email_id"`
+	UserID        uuid.UUID  `json:"-"`
+	TokenHash     string     `json:"-"`
+	PasswordHash  string     `json:"-"`
+	HasPassword   bool       `json:"has_password"`
+	AttachmentIDs []int64    `json:"attachment_ids"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	ViewCount     int        `json:"view_count"`
+	LastViewedAt  *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+
+	// URL is the public link (생성 직후에만 채움)
+	URL string `json:"url,omitempty"`
+}
+
+// Active reports whether the link can still be opened.
+func (s *EmailShare) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// SharedEmail is the sanitized, read-only view shown to share link visitors.
+type SharedEmail struct {
+	Subject     string              `json:"subject"`
+	FromName    string              `json:"from_name"`
+	FromEmail   string              `json:"from_email"`
+	Date        time.Time           `json:"date"`
+	HTML        string              `json:"html,omitempty"` // sanitize(strict) + 원격 이미지 제거
+	Text        string              `json:"text,omitempty"`
+	Attachments []*SharedAttachment `json:"attachments"`
+	ExpiresAt   time.Time           `json:"expires_at"`
+}
+
+// SharedAttachment is an attachment selected for a share link.
+type SharedAttachment struct {
+	ID       int64  `json:"id"`
+	Filename string `json:"filename"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+	URL      string `json:"url"` // 서명된 다운로드 URL (비밀번호 확인 후 발급)
+}
@@ -0,0 +1,12 @@
+package domain
+
+// EmailTranslation is an email subject and body translated on demand (GET /email/:id/translate).
+type EmailTranslation struct {
+	EmailID        int64  `json:"email_id"`
+	SourceLanguage string `json:"source_language,omitempty"` // 판별하지 못하면 빈 값
+	TargetLanguage string `json:"target_language"`
+	Subject        string `json:"subject"`
+	Body           string `json:"body"`
+	IsHTML         bool   `json:"is_html"`
+	Cached         bool   `json:"cached"`
+}
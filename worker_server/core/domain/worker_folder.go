@@ -26,6 +26,65 @@ const (
 	SystemFolderArchive SystemFolderKey = "archive"
 )
 
+// SystemFolderKeys lists the system folders in display order.
+var SystemFolderKeys = []SystemFolderKey{
+	SystemFolderInbox, SystemFolderSent, SystemFolderDrafts,
+	SystemFolderArchive, SystemFolderSpam, SystemFolderTrash,
+}
+
+var systemFolderNames = map[SystemFolderKey]string{
+	SystemFolderInbox:   "Inbox",
+	SystemFolderSent:    "Sent",
+	SystemFolderDrafts:  "Drafts",
+	SystemFolderArchive: "Archive",
+	SystemFolderSpam:    "Spam",
+	SystemFolderTrash:   "Trash",
+}
+
+// DefaultName returns the display name of a system folder.
+func (k SystemFolderKey) DefaultName() string {
+	return systemFolderNames[k]
+}
+
+// LegacyFolder returns the emails.folder value of a system folder (같은 키 문자열).
+func (k SystemFolderKey) LegacyFolder() LegacyFolder {
+	return LegacyFolder(k)
+}
+
+// systemFolderExternalIDs maps system folders to provider IDs.
+// Gmail은 시스템 라벨 ID, Outlook은 Graph well-known 폴더 이름 (ID 대신 사용 가능).
+// Gmail 보관은 INBOX 라벨 제거라 대응하는 라벨이 없다.
+var systemFolderExternalIDs = map[Provider]map[SystemFolderKey]string{
+	MailProviderGmail: {
+		SystemFolderInbox:  "INBOX",
+		SystemFolderSent:   "SENT",
+		SystemFolderDrafts: "DRAFT",
+		SystemFolderSpam:   "SPAM",
+		SystemFolderTrash:  "TRASH",
+	},
+	MailProviderOutlook: {
+		SystemFolderInbox:   "inbox",
+		SystemFolderSent:    "sentitems",
+		SystemFolderDrafts:  "drafts",
+		SystemFolderArchive: "archive",
+		SystemFolderSpam:    "junkemail",
+		SystemFolderTrash:   "deleteditems",
+	},
+}
+
+// SystemFolderExternalID returns the provider ID of a system folder ("" = 대응 없음).
+func SystemFolderExternalID(provider Provider, key SystemFolderKey) string {
+	return systemFolderExternalIDs[provider][key]
+}
+
+// FolderMappingType returns how custom folders are represented by a provider.
+func FolderMappingType(provider Provider) ProviderMappingType {
+	if provider == MailProviderOutlook {
+		return MappingTypeFolder
+	}
+	return MappingTypeLabel
+}
+
 // EmailFolder represents a user's email folder (both system and custom)
 type EmailFolder struct {
 	ID        int64            `json:"id"`
@@ -68,6 +127,7 @@ type FolderProviderMapping struct {
 	Provider     Provider            `json:"provider"`
 	ExternalID   *string             `json:"external_id,omitempty"` // Provider's folder/label ID (nil = not yet created)
 	MappingType  ProviderMappingType `json:"mapping_type"`
+	SyncedAt     *time.Time          `json:"synced_at,omitempty"` // 마지막으로 Provider와 맞춘 시각
 	CreatedAt    time.Time           `json:"created_at"`
 	UpdatedAt    time.Time           `json:"updated_at"`
 }
@@ -78,6 +138,16 @@ type EmailFolderWithMappings struct {
 	Mappings []*FolderProviderMapping `json:"mappings,omitempty"`
 }
 
+// FolderSyncResult summarizes a folder sync with one connection.
+type FolderSyncResult struct {
+	ConnectionID int64 `json:"connection_id"`
+	Imported     int   `json:"imported"` // Provider에서 가져온 폴더
+	Exported     int   `json:"exported"` // Provider에 새로 만든 폴더
+	Renamed      int   `json:"renamed"`  // Provider에서 이름이 바뀐 폴더
+	Removed      int   `json:"removed"`  // Provider에서 삭제되어 지운 폴더
+	Failed       int   `json:"failed"`
+}
+
 // FolderRepository interface for folder operations
 type FolderRepository interface {
 	// Basic CRUD
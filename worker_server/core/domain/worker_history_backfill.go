@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// HistoryBackfillStatus is the state of a history backfill.
+type HistoryBackfillStatus string
+
+const (
+	HistoryBackfillPending   HistoryBackfillStatus = "pending"
+	HistoryBackfillRunning   HistoryBackfillStatus = "running"
+	HistoryBackfillCompleted HistoryBackfillStatus = "completed"
+	HistoryBackfillFailed    HistoryBackfillStatus = "failed"
+	HistoryBackfillCancelled HistoryBackfillStatus = "cancelled"
+)
+
+// HistoryBackfill imports mail older than the sync window on request (POST /email/backfill).
+// 페이지 단위로 PageToken을 체크포인트로 저장하고, Budget개를 가져오면 멈춘다.
+type HistoryBackfill struct {
+	ID           int64                 `json:"id"`
+	UserID       string                `json:"user_id"`
+	ConnectionID int64                 `json:"connection_id"`
+	Since        time.Time             `json:"since"`
+	Until        time.Time             `json:"until"`
+	Status       HistoryBackfillStatus `json:"status"`
+	PageToken    string                `json:"-"`
+	Budget       int                   `json:"budget"`  // 가져올 최대 메시지 수
+	Fetched      int                   `json:"fetched"` // Provider에서 가져온 메시지 수
+	Saved        int                   `json:"saved"`   // 새로 저장된 메일 수
+	LastError    string                `json:"last_error,omitempty"`
+	CreatedAt    time.Time             `json:"created_at"`
+	StartedAt    *time.Time            `json:"started_at,omitempty"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+	CompletedAt  *time.Time            `json:"completed_at,omitempty"`
+}
+
+// IsActive reports whether the backfill is still queued or running.
+func (b *HistoryBackfill) IsActive() bool {
+	return b.Status == HistoryBackfillPending || b.Status == HistoryBackfillRunning
+}
+
+// Remaining returns how many messages the backfill may still fetch.
+func (b *HistoryBackfill) Remaining() int {
+	return max(b.Budget-b.Fetched, 0)
+}
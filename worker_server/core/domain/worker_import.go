@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MailImportStatus represents the lifecycle of an import job.
+type MailImportStatus string
+
+const (
+	MailImportPending    MailImportStatus = "pending"
+	MailImportProcessing MailImportStatus = "processing"
+	MailImportCompleted  MailImportStatus = "completed"
+	MailImportFailed     MailImportStatus = "failed"
+)
+
+// MailImportFormat is the uploaded archive format.
+type MailImportFormat string
+
+const (
+	MailImportFormatMbox MailImportFormat = "mbox"
+	MailImportFormatEML  MailImportFormat = "eml"
+)
+
+// LocalArchiveEmail is the pseudo account address of the local archive connection.
+// 가져온 메일은 provider = local, email = local-archive 연결 아래에 저장된다.
+const LocalArchiveEmail = "local-archive"
+
+// MailImport represents an MBOX/EML import job.
+type MailImport struct {
+	ID            int64            `json:"id"`
+	UserID        uuid.UUID        `json:"user_id"`
+	ConnectionID  *int64           `json:"connection_id,omitempty"`
+	Filename      string           `json:"filename"`
+	Format        MailImportFormat `json:"format"`
+	SizeBytes     int64            `json:"size_bytes"`
+	Status        MailImportStatus `json:"status"`
+	TotalCount    int              `json:"total_count"`
+	ImportedCount int              `json:"imported_count"`
+	SkippedCount  int              `json:"skipped_count"`
+	FailedCount   int              `json:"failed_count"`
+	ErrorMessage  string           `json:"error_message,omitempty"`
+	StartedAt     *time.Time       `json:"started_at,omitempty"`
+	CompletedAt   *time.Time       `json:"completed_at,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// IsFinished returns true if the import reached a terminal state.
+func (m *MailImport) IsFinished() bool {
+	return m.Status == MailImportCompleted || m.Status == MailImportFailed
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TriageStatus represents the lifecycle of an inbox triage proposal.
+type TriageStatus string
+
+const (
+	TriageProposed  TriageStatus = "proposed"  // 확인 대기
+	TriageQueued    TriageStatus = "queued"    // 확인됨, 워커 대기
+	TriageRunning   TriageStatus = "running"   // 워커 실행 중
+	TriageCompleted TriageStatus = "completed" // 실행 완료 (일부 실패 포함, 결과는 Results)
+	TriageFailed    TriageStatus = "failed"
+)
+
+// Triage action types
+const (
+	TriageActionArchive     = "archive"     // 메일 보관
+	TriageActionUnsubscribe = "unsubscribe" // 발신자 뮤트 + 받은편지함 메일 보관
+	TriageActionMarkRead    = "mark_read"   // 읽음 처리
+)
+
+// TriageAction is one proposed bulk action (예: "Archive 40 stale newsletters").
+type TriageAction struct {
+	ID          string   `json:"id"` // 확인 시 일부만 실행할 때 사용 (예: stale_newsletters)
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	Reason      string   `json:"reason"`
+	Count       int      `json:"count"` // 대상 메일 수
+	EmailIDs    []int64  `json:"email_ids"`
+	Senders     []string `json:"senders,omitempty"` // unsubscribe 대상 발신자
+}
+
+// TriageActionResult reports how one action went.
+type TriageActionResult struct {
+	ActionID  string `json:"action_id"`
+	Type      string `json:"type"`
+	Requested int    `json:"requested"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	Senders   int    `json:"senders,omitempty"` // 뮤트한 발신자 수
+	Error     string `json:"error,omitempty"`
+}
+
+// InboxTriage is a set of bulk actions proposed to get the inbox to zero.
+type InboxTriage struct {
+	ID           uuid.UUID             `json:"id"`
+	UserID       uuid.UUID             `json:"-"`
+	ConnectionID *int64                `json:"connection_id,omitempty"`
+	Status       TriageStatus          `json:"status"`
+	Actions      []*TriageAction       `json:"actions"`
+	Results      []*TriageActionResult `json:"results,omitempty"`
+	ErrorMessage string                `json:"error_message,omitempty"`
+	ExpiresAt    time.Time             `json:"expires_at"` // 이후에는 확인할 수 없다 (메일 상태가 바뀌었을 수 있음)
+	ConfirmedAt  *time.Time            `json:"confirmed_at,omitempty"`
+	CompletedAt  *time.Time            `json:"completed_at,omitempty"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+}
+
+// IsExpired reports whether the proposal can no longer be confirmed.
+func (t *InboxTriage) IsExpired(now time.Time) bool {
+	return t.Status == TriageProposed && now.After(t.ExpiresAt)
+}
+
+// TotalEmails counts the emails touched by all actions.
+func (t *InboxTriage) TotalEmails() int {
+	total := 0
+	for _, a := range t.Actions {
+		total += a.Count
+	}
+	return total
+}
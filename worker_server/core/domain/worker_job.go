@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobState is the lifecycle state of a background job.
+type JobState string
+
+const (
+	JobQueued    JobState = "queued"
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+)
+
+// IsFinished reports whether the job reached a terminal state.
+func (s JobState) IsFinished() bool {
+	return s == JobSucceeded || s == JobFailed
+}
+
+// BackgroundJobType identifies what a tracked job does.
+type BackgroundJobType string
+
+const (
+	BackgroundJobMailSync   BackgroundJobType = "mail.sync"
+	BackgroundJobMailResync BackgroundJobType = "mail.resync"
+	BackgroundJobReclassify BackgroundJobType = "ai.reclassify"
+)
+
+// JobProgress counts processed items. Total가 0이면 진행률을 알 수 없는 작업이다.
+type JobProgress struct {
+	Total   int     `json:"total"`
+	Done    int     `json:"done"`
+	Failed  int     `json:"failed"`
+	Percent float64 `json:"percent"`
+}
+
+// BackgroundJob tracks a job published through MessageProducer (or run in the background).
+type BackgroundJob struct {
+	ID           uuid.UUID         `json:"id"`
+	UserID       uuid.UUID         `json:"user_id"`
+	Type         BackgroundJobType `json:"type"`
+	ConnectionID *int64            `json:"connection_id,omitempty"`
+	State        JobState          `json:"state"`
+	Progress     JobProgress       `json:"progress"`
+	Error        string            `json:"error,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	StartedAt    *time.Time        `json:"started_at,omitempty"`
+	FinishedAt   *time.Time        `json:"finished_at,omitempty"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	DurationMs   int64             `json:"duration_ms,omitempty"` // 시작 ~ 종료(또는 현재)
+}
+
+// Fill computes derived fields (percent, duration) as of now.
+func (j *BackgroundJob) Fill(now time.Time) {
+	if j.Progress.Total > 0 {
+		processed := j.Progress.Done + j.Progress.Failed
+		j.Progress.Percent = float64(processed) * 100 / float64(j.Progress.Total)
+		if j.Progress.Percent > 100 {
+			j.Progress.Percent = 100
+		}
+	} else if j.State == JobSucceeded {
+		j.Progress.Percent = 100
+	}
+
+	if j.StartedAt != nil {
+		end := now
+		if j.FinishedAt != nil {
+			end = *j.FinishedAt
+		}
+		j.DurationMs = end.Sub(*j.StartedAt).Milliseconds()
+	}
+}
+
+// ETA estimates how long remaining items take at the job's observed throughput.
+// 끝났거나 아직 처리한 항목이 없으면 추정할 수 없다 (false).
+func (j *BackgroundJob) ETA(remaining int, now time.Time) (time.Duration, bool) {
+	if remaining <= 0 {
+		return 0, true
+	}
+	processed := j.Progress.Done + j.Progress.Failed
+	if j.State.IsFinished() || j.StartedAt == nil || processed == 0 {
+		return 0, false
+	}
+	elapsed := now.Sub(*j.StartedAt)
+	if elapsed <= 0 {
+		return 0, false
+	}
+	perItem := elapsed / time.Duration(processed)
+	return perItem * time.Duration(remaining), true
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LinkedAttachment is an outgoing attachment over the provider size limit,
+// stored on our side and sent as a download link in the body.
+type LinkedAttachment struct {
+	ID        string    `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Filename  string    `json:"filename"`
+	MimeType  string    `json:"mime_type"`
+	Size      int64     `json:"size"`
+	URL       string    `json:"url,omitempty"` // 서명된 공개 다운로드 URL (저장하지 않음)
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -36,6 +37,8 @@ const (
 	NotificationTypeSystem   NotificationType = "system"
 	NotificationTypeSync     NotificationType = "sync"
 	NotificationTypeAI       NotificationType = "ai"
+	NotificationTypeMention  NotificationType = "mention" // 팀 댓글 멘션
+	NotificationTypeSLA      NotificationType = "sla"     // 공유 메일함 응답 SLA 임박/초과
 )
 
 type NotificationPriority string
@@ -71,6 +74,25 @@ type NotificationRepository interface {
 	CountUnread(userID uuid.UUID) (int64, error)
 }
 
+// =============================================================================
+// HeldNotification - 방해 금지 시간 동안 보류된 알림
+// =============================================================================
+
+// HeldNotification is a notification deferred until quiet hours end.
+// 보류된 알림은 ReleaseAt 이후 사용자별 요약 알림 하나로 묶어 전달한다.
+type HeldNotification struct {
+	ID         int64                `json:"id"`
+	UserID     uuid.UUID            `json:"user_id"`
+	Type       NotificationType     `json:"type"`
+	Title      string               `json:"title"`
+	Body       string               `json:"body,omitempty"`
+	EntityType string               `json:"entity_type,omitempty"`
+	EntityID   int64                `json:"entity_id,omitempty"`
+	Priority   NotificationPriority `json:"priority"`
+	ReleaseAt  time.Time            `json:"release_at"`
+	CreatedAt  time.Time            `json:"created_at"`
+}
+
 // =============================================================================
 // RealtimeEvent - SSE로 프론트엔드에 전송되는 이벤트
 // =============================================================================
@@ -106,6 +128,13 @@ const (
 	EventEmailSnoozed    EventType = "email.snoozed"
 	EventEmailUnsnoozed  EventType = "email.unsnoozed"
 	EventEmailBatchState EventType = "email.batch_state" // 일괄 상태 변경
+	EventEmailInvalidate EventType = "email.invalidate"  // 캐시 무효화 힌트 (바뀐 필드와 새 값)
+
+	// Security events
+	EventEmailSecurityAlert EventType = "email.security_alert" // 피싱/스푸핑 의심 메일
+
+	// VIP events
+	EventEmailVIP EventType = "email.vip" // VIP 발신자 메일 즉시 알림 (NewEmailData)
 
 	// Sync events
 	EventSyncStarted    EventType = "sync.started"
@@ -115,6 +144,17 @@ const (
 	EventSyncError      EventType = "sync.error"
 	EventSyncRetry      EventType = "sync.retry" // 재시도 예약됨
 
+	// Team events (공유 메일함)
+	EventEmailComment  EventType = "email.comment"  // 내부 댓글 추가/수정/삭제 (조직 멤버에게)
+	EventEmailAssigned EventType = "email.assigned" // 메일 담당자 지정/해제 (담당자에게)
+
+	// Upload events
+	EventUploadProgress EventType = "upload.progress" // 대용량 첨부 업로드 진행률
+
+	// Outbox events
+	EventOutboxSent   EventType = "outbox.sent"   // 대기 중이던 메일 발송 완료
+	EventOutboxFailed EventType = "outbox.failed" // 재시도 한도 초과로 발송 실패
+
 	// OAuth events
 	EventTokenExpired EventType = "oauth.token_expired" // 토큰 만료 - 재연결 필요
 
@@ -172,6 +212,7 @@ type ClassifiedData struct {
 	Intent     string  `json:"intent,omitempty"`
 	Summary    string  `json:"summary,omitempty"`
 	Confidence float64 `json:"confidence"`
+	Stage      string  `json:"stage,omitempty"` // 라벨을 붙인 분류 단계
 }
 
 // SyncProgressData - 동기화 진행 상황
@@ -183,6 +224,41 @@ type SyncProgressData struct {
 	Phase        string `json:"phase,omitempty"` // initial_first_batch, initial_remaining, delta, gap
 	RetryCount   int    `json:"retry_count,omitempty"`
 	NextRetryAt  string `json:"next_retry_at,omitempty"`
+
+	// 초기 동기화 진행률 - Total은 provider 추정치 ("3,214 of ~12,000 (27%)")
+	Estimated  bool `json:"estimated,omitempty"`
+	Percent    int  `json:"percent,omitempty"`
+	ETASeconds int  `json:"eta_seconds,omitempty"`
+}
+
+// WithEstimate fills Total, Percent and ETASeconds from an estimated total.
+// processed is the number synced during elapsed (this run), used for the rate.
+// 추정치보다 많이 가져오면 Total을 Current로 올리고, 완료 전에는 99%를 넘기지 않는다.
+func (d *SyncProgressData) WithEstimate(total, processed int, elapsed time.Duration) *SyncProgressData {
+	if total <= 0 {
+		return d
+	}
+	if d.Current > total {
+		total = d.Current
+	}
+	d.Total = total
+	d.Estimated = true
+	d.Percent = min(d.Current*100/total, 99)
+
+	if processed > 0 && elapsed > 0 {
+		rate := float64(processed) / elapsed.Seconds()
+		d.ETASeconds = int(float64(total-d.Current) / rate)
+	}
+	return d
+}
+
+// UploadProgressData - 첨부 업로드 진행 상황
+type UploadProgressData struct {
+	SessionID     string `json:"session_id"`
+	Filename      string `json:"filename"`
+	BytesUploaded int64  `json:"bytes_uploaded"`
+	Size          int64  `json:"size"`
+	IsComplete    bool   `json:"is_complete"`
 }
 
 // SummarizedData - AI 요약 완료 이벤트 데이터
@@ -230,6 +306,61 @@ type EmailBatchStateChangeData struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// Cache invalidation sources - 어떤 작업이 메일을 바꿨는지
+const (
+	InvalidateSourceClassification = "classification"
+	InvalidateSourceSummary        = "summary"
+	InvalidateSourceLabelSync      = "label_sync"
+)
+
+// EmailInvalidateData - 클라이언트 캐시 무효화 힌트
+// 목록을 다시 불러오지 않고 스토어의 해당 메일만 패치하도록, 바뀐 필드(Email JSON 이름)와 새 값을 보낸다.
+type EmailInvalidateData struct {
+	EmailIDs []int64                  `json:"email_ids"`
+	Fields   []string                 `json:"fields"`            // 하나 이상의 메일에서 바뀐 필드
+	Patches  map[int64]map[string]any `json:"patches,omitempty"` // email_id -> field -> 새 값
+	Source   string                   `json:"source"`
+}
+
+// NewEmailInvalidateData creates an empty invalidation hint for the given source.
+func NewEmailInvalidateData(source string) *EmailInvalidateData {
+	return &EmailInvalidateData{Source: source, Patches: make(map[int64]map[string]any)}
+}
+
+// Add records the changed fields of an email. 같은 메일을 다시 추가하면 패치를 합친다.
+func (d *EmailInvalidateData) Add(emailID int64, patch map[string]any) {
+	if len(patch) == 0 {
+		return
+	}
+	existing, ok := d.Patches[emailID]
+	if !ok {
+		d.EmailIDs = append(d.EmailIDs, emailID)
+		existing = make(map[string]any, len(patch))
+		d.Patches[emailID] = existing
+	}
+	for field, value := range patch {
+		existing[field] = value
+		if !slices.Contains(d.Fields, field) {
+			d.Fields = append(d.Fields, field)
+		}
+	}
+}
+
+// Empty reports whether no email was added.
+func (d *EmailInvalidateData) Empty() bool {
+	return len(d.EmailIDs) == 0
+}
+
+// NewEmailInvalidateEvent creates a cache invalidation event.
+func NewEmailInvalidateEvent(userID string, data *EmailInvalidateData) *RealtimeEvent {
+	return &RealtimeEvent{
+		Type:      EventEmailInvalidate,
+		UserID:    userID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+}
+
 // NewEmailStateChangeEvent creates a new email state change event.
 func NewEmailStateChangeEvent(userID string, data *EmailStateChangeData) *RealtimeEvent {
 	eventType := EmailActionToEventType(data.Action)
@@ -317,7 +448,7 @@ type NotificationSettings struct {
 	QuietHoursEnabled  bool   `json:"quiet_hours_enabled"`
 	QuietHoursStart    string `json:"quiet_hours_start"`    // HH:MM 형식 (예: "22:00")
 	QuietHoursEnd      string `json:"quiet_hours_end"`      // HH:MM 형식 (예: "08:00")
-	QuietHoursTimezone string `json:"quiet_hours_timezone"` // 타임존 (예: "Asia/Seoul")
+	QuietHoursTimezone string `json:"quiet_hours_timezone"` // 타임존 (예: "Asia/Seoul"), 프로필 시간대가 있으면 그 값
 
 	// VIP 발신자 (이 목록의 발신자는 항상 알림)
 	VIPSenders []string `json:"vip_senders"` // 이메일 주소 목록
@@ -331,6 +462,9 @@ type NotificationSettings struct {
 	// 우선순위 필터 (이 우선순위 이상만 알림)
 	MinPriorityForNotification int `json:"min_priority_for_notification"` // 1=urgent, 2=high, 3=normal, 4=low (기본 3)
 
+	// 푸시 알림을 보낼 메일 카테고리 (비어 있으면 전체, 예: primary, work, personal)
+	PushCategories []string `json:"push_categories"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -369,6 +503,7 @@ func DefaultNotificationSettings(userID uuid.UUID) *NotificationSettings {
 		MutedSenders:   []string{},
 
 		MinPriorityForNotification: 3, // normal 이상
+		PushCategories:             []string{},
 	}
 }
 
@@ -379,13 +514,7 @@ func (s *NotificationSettings) ShouldNotify(notifType NotificationType, priority
 		return false
 	}
 
-	// 방해 금지 시간 체크
-	if s.QuietHoursEnabled && s.isInQuietHours() {
-		// VIP 발신자는 방해 금지 시간에도 알림
-		if !s.isVIPSender(senderEmail) {
-			return false
-		}
-	}
+	// 방해 금지 시간은 여기서 거르지 않는다 (ShouldHold로 보류 후 묶음 전달)
 
 	// 뮤트된 발신자 체크
 	if s.isMutedSender(senderEmail) {
@@ -438,19 +567,66 @@ func (s *NotificationSettings) ShouldNotify(notifType NotificationType, priority
 	return true
 }
 
-// isInQuietHours checks if current time is within quiet hours.
-func (s *NotificationSettings) isInQuietHours() bool {
-	if s.QuietHoursStart == "" || s.QuietHoursEnd == "" {
+// ShouldPushEmail checks if a new email should trigger a mobile push.
+// VIP 발신자는 카테고리, 우선순위와 관계없이 푸시한다. 방해 금지 시간은 ShouldHold가 판단한다.
+func (s *NotificationSettings) ShouldPushEmail(category string, priority Priority, senderEmail string) bool {
+	if !s.PushEnabled || !s.NotifyNewEmail || s.isMutedSender(senderEmail) {
+		return false
+	}
+	if s.isVIPSender(senderEmail) {
+		return true
+	}
+	if s.NotifyFromVIPOnly {
+		return false
+	}
+	if len(s.PushCategories) > 0 && !slices.Contains(s.PushCategories, category) {
 		return false
 	}
 
-	loc, err := time.LoadLocation(s.QuietHoursTimezone)
-	if err != nil {
-		loc = time.UTC
+	notifPriority := EmailNotificationPriority(priority)
+	if s.NotifyImportantOnly && notifPriority != NotificationPriorityUrgent && notifPriority != NotificationPriorityHigh {
+		return false
 	}
+	return s.getPriorityLevel(notifPriority) <= s.MinPriorityForNotification
+}
 
-	now := time.Now().In(loc)
-	currentMinutes := now.Hour()*60 + now.Minute()
+// ShouldPushVIPEmail checks if mail from a VIP contact should be pushed.
+// VIP 메일은 푸시 끄기와 뮤트된 발신자만 따른다.
+func (s *NotificationSettings) ShouldPushVIPEmail(senderEmail string) bool {
+	return s.PushEnabled && !s.isMutedSender(senderEmail)
+}
+
+// EmailNotificationPriority maps an email priority score onto notification priority.
+func EmailNotificationPriority(p Priority) NotificationPriority {
+	switch p.String() {
+	case "urgent":
+		return NotificationPriorityUrgent
+	case "high":
+		return NotificationPriorityHigh
+	case "normal":
+		return NotificationPriorityNormal
+	default:
+		return NotificationPriorityLow
+	}
+}
+
+// ShouldHold reports whether a notification should be held until quiet hours end.
+// 긴급 알림과 VIP 발신자는 방해 금지 시간에도 바로 전달한다.
+func (s *NotificationSettings) ShouldHold(priority NotificationPriority, senderEmail string, now time.Time) bool {
+	if priority == NotificationPriorityUrgent || s.isVIPSender(senderEmail) {
+		return false
+	}
+	return s.InQuietHours(now)
+}
+
+// InQuietHours checks if t is within quiet hours in the user's time zone.
+func (s *NotificationSettings) InQuietHours(t time.Time) bool {
+	if !s.QuietHoursEnabled || s.QuietHoursStart == "" || s.QuietHoursEnd == "" {
+		return false
+	}
+
+	local := t.In(s.quietHoursLocation())
+	currentMinutes := local.Hour()*60 + local.Minute()
 
 	startHour, startMin := parseTime(s.QuietHoursStart)
 	endHour, endMin := parseTime(s.QuietHoursEnd)
@@ -467,6 +643,28 @@ func (s *NotificationSettings) isInQuietHours() bool {
 	return currentMinutes >= startMinutes && currentMinutes < endMinutes
 }
 
+// QuietHoursEndAt returns the first quiet hours end time after t.
+func (s *NotificationSettings) QuietHoursEndAt(t time.Time) time.Time {
+	loc := s.quietHoursLocation()
+	local := t.In(loc)
+	endHour, endMin := parseTime(s.QuietHoursEnd)
+
+	end := time.Date(local.Year(), local.Month(), local.Day(), endHour, endMin, 0, 0, loc)
+	if !end.After(local) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// quietHoursLocation loads the quiet hours time zone, falling back to UTC.
+func (s *NotificationSettings) quietHoursLocation() *time.Location {
+	loc, err := time.LoadLocation(s.QuietHoursTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 // parseTime parses HH:MM format.
 func parseTime(timeStr string) (hour, min int) {
 	if len(timeStr) < 5 {
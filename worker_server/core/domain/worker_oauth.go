@@ -13,6 +13,7 @@ const (
 	ProviderGmail          OAuthProvider = "google" // alias for backward compatibility
 	ProviderOutlook        OAuthProvider = "outlook"
 	ProviderGoogleCalendar OAuthProvider = "google_calendar"
+	ProviderLocal          OAuthProvider = "local" // 가져온 메일용 가상 연결 (local archive)
 )
 
 type OAuthConnection struct {
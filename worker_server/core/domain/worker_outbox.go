@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outbox message status
+const (
+	OutboxStatusQueued    = "queued"    // 재시도 대기 (next_attempt_at)
+	OutboxStatusSending   = "sending"   // 발송 중 (claim됨)
+	OutboxStatusSent      = "sent"      // 발송 완료
+	OutboxStatusFailed    = "failed"    // 재시도 한도 초과 또는 재시도할 수 없는 오류 (수동 재시도 가능)
+	OutboxStatusCancelled = "cancelled" // 사용자가 취소
+)
+
+// Outbox message kind (재시도 시 호출할 Provider API)
+const (
+	OutboxKindSend  = "send"
+	OutboxKindReply = "reply"
+)
+
+// OutboxMessage is an outgoing message whose provider send failed and is retried with backoff.
+type OutboxMessage struct {
+	ID            int64      `json:"id"`
+	UserID        uuid.UUID  `json:"-"`
+	ConnectionID  int64      `json:"connection_id"`
+	Kind          string     `json:"kind"`
+	ReplyToID     string     `json:"reply_to_id,omitempty"` // 답장 대상의 Provider 메시지 ID
+	Subject       string     `json:"subject"`
+	ToEmails      []string   `json:"to_emails"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	ProviderID    string     `json:"provider_id,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	TrackingID    int64      `json:"-"` // 열람/클릭 추적 (발송 후 Provider ID 연결)
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	// Payload is the JSON-encoded outgoing message including attachments (목록 조회에서는 비어 있음).
+	Payload []byte `json:"-"`
+}
+
+// OutboxEventData - outbox.sent / outbox.failed 이벤트 데이터
+type OutboxEventData struct {
+	OutboxID   int64  `json:"outbox_id"`
+	Status     string `json:"status"`
+	Subject    string `json:"subject"`
+	ProviderID string `json:"provider_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PushPlatform is the mobile push service a device token belongs to.
+type PushPlatform string
+
+const (
+	PushPlatformFCM  PushPlatform = "fcm"  // Firebase Cloud Messaging (Android, Web)
+	PushPlatformAPNs PushPlatform = "apns" // Apple Push Notification service (iOS)
+)
+
+// IsValid reports whether p is a supported platform.
+func (p PushPlatform) IsValid() bool {
+	return p == PushPlatformFCM || p == PushPlatformAPNs
+}
+
+// PushDevice is a registered mobile device of a user.
+type PushDevice struct {
+	ID         int64        `json:"id"`
+	UserID     uuid.UUID    `json:"-"`
+	Platform   PushPlatform `json:"platform"`
+	Token      string       `json:"token"`
+	DeviceName string       `json:"device_name,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	LastSeenAt time.Time    `json:"last_seen_at"`
+}
+
+// PushMessage is a platform-independent push notification.
+type PushMessage struct {
+	Title string
+	Body  string
+	// Data is delivered to the app with the notification (email_id 등).
+	Data map[string]string
+	// CollapseKey groups notifications so newer ones replace older ones (스레드 단위).
+	CollapseKey string
+	Priority    NotificationPriority
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// Safe Links (링크 재작성 / 안전 미리보기)
+// =============================================================================
+
+// LinkVerdict is the reputation verdict of a link destination.
+type LinkVerdict string
+
+const (
+	LinkVerdictSafe    LinkVerdict = "safe"
+	LinkVerdictBlocked LinkVerdict = "blocked"
+	LinkVerdictUnknown LinkVerdict = "unknown" // 조회 실패 - 경고 없이 통과
+)
+
+// URLReputation is the result of checking a URL against blocklists.
+type URLReputation struct {
+	URL     string      `json:"url"`
+	Verdict LinkVerdict `json:"verdict"`
+	Threats []string    `json:"threats,omitempty"` // MALWARE, SOCIAL_ENGINEERING, blocklist ...
+	Source  string      `json:"source,omitempty"`  // blocklist, safe_browsing
+}
+
+// IsBlocked returns true if the destination must not be opened directly.
+func (r *URLReputation) IsBlocked() bool {
+	return r != nil && r.Verdict == LinkVerdictBlocked
+}
+
+// LinkClick is a recorded click on a rewritten link.
+type LinkClick struct {
+	ID        int64       `json:"id"`
+	UserID    uuid.UUID   `json:"user_id"`
+	EmailID   int64       `json:"email_id"`
+	URL       string      `json:"url"`
+	Domain    string      `json:"domain"`
+	Verdict   LinkVerdict `json:"verdict"`
+	ClickedAt time.Time   `json:"clicked_at"`
+}
+
+// LinkDomainCount is a per-domain click count.
+type LinkDomainCount struct {
+	Domain string `json:"domain"`
+	Clicks int    `json:"clicks"`
+}
+
+// LinkClickStats summarizes a user's link clicks.
+type LinkClickStats struct {
+	TotalClicks   int               `json:"total_clicks"`
+	BlockedClicks int               `json:"blocked_clicks"`
+	UniqueDomains int               `json:"unique_domains"`
+	TopDomains    []LinkDomainCount `json:"top_domains"`
+	Since         time.Time         `json:"since"`
+}
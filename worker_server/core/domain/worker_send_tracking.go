@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrackingEventType is the kind of engagement recorded for a tracked send.
+type TrackingEventType string
+
+const (
+	TrackingOpen  TrackingEventType = "open"
+	TrackingClick TrackingEventType = "click"
+)
+
+// SentTracking is an opt-in tracking record for one sent message.
+type SentTracking struct {
+	ID           int64     `json:"id"`
+	UserID       uuid.UUID `json:"user_id"`
+	ConnectionID int64     `json:"connection_id"`
+	ExternalID   string    `json:"external_id,omitempty"` // provider message ID
+	Subject      string    `json:"subject,omitempty"`
+	TrackOpens   bool      `json:"track_opens"`
+	TrackClicks  bool      `json:"track_clicks"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TrackingEvent is a single pixel load or link click.
+type TrackingEvent struct {
+	ID         int64             `json:"id"`
+	TrackingID int64             `json:"tracking_id"`
+	Type       TrackingEventType `json:"type"`
+	URL        string            `json:"url,omitempty"`
+	UserAgent  string            `json:"user_agent,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+// LinkEngagement is the click count of one link.
+type LinkEngagement struct {
+	URL         string     `json:"url"`
+	Clicks      int        `json:"clicks"`
+	LastClicked *time.Time `json:"last_clicked_at,omitempty"`
+}
+
+// SentEngagement summarizes opens and clicks of a tracked message.
+// 이미지 프록시(Gmail 등)나 보안 스캐너가 픽셀/링크를 미리 불러올 수 있어 추정치다.
+type SentEngagement struct {
+	EmailID       int64             `json:"email_id"`
+	Tracked       bool              `json:"tracked"`
+	TrackOpens    bool              `json:"track_opens"`
+	TrackClicks   bool              `json:"track_clicks"`
+	Opens         int               `json:"opens"`
+	FirstOpenedAt *time.Time        `json:"first_opened_at,omitempty"`
+	LastOpenedAt  *time.Time        `json:"last_opened_at,omitempty"`
+	Clicks        int               `json:"clicks"`
+	Links         []*LinkEngagement `json:"links"`
+}
+
+// BuildSentEngagement aggregates tracking events (ordered by time) into a summary.
+func BuildSentEngagement(emailID int64, tracking *SentTracking, events []*TrackingEvent) *SentEngagement {
+	engagement := &SentEngagement{EmailID: emailID, Links: []*LinkEngagement{}}
+	if tracking == nil {
+		return engagement
+	}
+	engagement.Tracked = true
+	engagement.TrackOpens = tracking.TrackOpens
+	engagement.TrackClicks = tracking.TrackClicks
+
+	byURL := make(map[string]*LinkEngagement)
+	for _, e := range events {
+		at := e.OccurredAt
+		switch e.Type {
+		case TrackingOpen:
+			engagement.Opens++
+			if engagement.FirstOpenedAt == nil || at.Before(*engagement.FirstOpenedAt) {
+				engagement.FirstOpenedAt = &at
+			}
+			if engagement.LastOpenedAt == nil || at.After(*engagement.LastOpenedAt) {
+				engagement.LastOpenedAt = &at
+			}
+		case TrackingClick:
+			engagement.Clicks++
+			link, ok := byURL[e.URL]
+			if !ok {
+				link = &LinkEngagement{URL: e.URL}
+				byURL[e.URL] = link
+				engagement.Links = append(engagement.Links, link)
+			}
+			link.Clicks++
+			if link.LastClicked == nil || at.After(*link.LastClicked) {
+				link.LastClicked = &at
+			}
+		}
+	}
+	return engagement
+}
@@ -0,0 +1,16 @@
+package domain
+
+// Send warning codes (발송 전 검증 - confirm_warnings로 확인하면 그대로 발송)
+const (
+	SendWarningUndeliverableDomain = "undeliverable_domain" // MX/A 레코드가 없는 수신 도메인
+	SendWarningExternalRecipients  = "external_recipients"  // 내부 스레드 답장에 외부 수신자 포함
+	SendWarningMissingAttachment   = "missing_attachment"   // 본문에 첨부 언급이 있지만 첨부 없음
+	SendWarningLargeRecipientList  = "large_recipient_list" // 수신자가 25명 초과 (reply all 실수 방지)
+)
+
+// SendWarning is one issue found before sending, which the client may confirm.
+type SendWarning struct {
+	Code       string   `json:"code"`
+	Message    string   `json:"message"`
+	Recipients []string `json:"recipients,omitempty"`
+}
@@ -180,6 +180,8 @@ type SenderProfileRepository interface {
 	// Important senders by score (new)
 	GetTopSenders(userID uuid.UUID, minScore float64, limit int) ([]*SenderProfile, error)
 	GetContactSenders(userID uuid.UUID) ([]*SenderProfile, error)
+	// GetLowEngagementSenders returns frequent senders the user rarely reads (VIP, 연락처, 뮤트, 답장한 발신자 제외).
+	GetLowEngagementSenders(userID uuid.UUID, maxReadRate float64, minEmails, limit int) ([]*SenderProfile, error)
 
 	// Stats update
 	IncrementEmailCount(id int64) error
@@ -14,9 +14,11 @@ type UserSettings struct {
 	UserID uuid.UUID `json:"user_id"`
 
 	// Email settings
-	DefaultSignature *string `json:"default_signature,omitempty"`
-	AutoReplyEnabled bool    `json:"auto_reply_enabled"`
-	AutoReplyMessage *string `json:"auto_reply_message,omitempty"`
+	DefaultSignature     *string `json:"default_signature,omitempty"`
+	AutoReplyEnabled     bool    `json:"auto_reply_enabled"`
+	AutoReplyMessage     *string `json:"auto_reply_message,omitempty"`
+	SafeLinksEnabled     bool    `json:"safe_links_enabled"`     // 본문 링크를 안전 리다이렉트로 재작성
+	LargeAttachmentLinks bool    `json:"large_attachment_links"` // 발송 한도를 넘는 첨부를 다운로드 링크로 변환
 
 	// AI settings
 	AIEnabled      bool   `json:"ai_enabled"`
@@ -37,6 +39,9 @@ func DefaultUserSettings(userID uuid.UUID) *UserSettings {
 	return &UserSettings{
 		UserID: userID,
 
+		// Email
+		LargeAttachmentLinks: true,
+
 		// AI
 		AIEnabled:      true,
 		AIAutoClassify: true,
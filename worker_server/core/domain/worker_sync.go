@@ -56,6 +56,11 @@ type SyncState struct {
 	WatchExpiry     time.Time `json:"watch_expiry"`
 	WatchResourceID string    `json:"watch_resource_id,omitempty"`
 
+	// Watch 갱신 실패 (연속 실패 수, 다음 시도 시각, 폴링 전환 알림 시각)
+	WatchFailures    int       `json:"watch_failures,omitempty"`
+	WatchRetryAt     time.Time `json:"watch_retry_at,omitempty"`
+	WatchEscalatedAt time.Time `json:"watch_escalated_at,omitempty"`
+
 	// 재시도 관련
 	RetryCount  int       `json:"retry_count"`
 	MaxRetries  int       `json:"max_retries"`
@@ -67,6 +72,7 @@ type SyncState struct {
 	CheckpointSyncedCount int       `json:"checkpoint_synced_count"`
 	CheckpointTotalCount  int       `json:"checkpoint_total_count,omitempty"`
 	CheckpointUpdatedAt   time.Time `json:"checkpoint_updated_at,omitempty"`
+	CheckpointStartedAt   time.Time `json:"checkpoint_started_at,omitempty"` // ETA 계산용
 
 	// 통계
 	TotalSynced          int64     `json:"total_synced"`
@@ -114,6 +120,33 @@ func (s *SyncState) SyncProgress() float64 {
 	return float64(s.CheckpointSyncedCount) / float64(s.CheckpointTotalCount) * 100
 }
 
+// CheckpointProgress - 저장된 체크포인트로 진행률 복원 (재접속한 UI용, 체크포인트가 없으면 nil)
+func (s *SyncState) CheckpointProgress() *SyncProgressData {
+	if !s.HasCheckpoint() {
+		return nil
+	}
+	progress := &SyncProgressData{
+		ConnectionID: s.ConnectionID,
+		Current:      s.CheckpointSyncedCount,
+		Status:       string(s.Status),
+		Phase:        string(s.Phase),
+	}
+	var elapsed time.Duration
+	if !s.CheckpointStartedAt.IsZero() {
+		elapsed = s.CheckpointUpdatedAt.Sub(s.CheckpointStartedAt)
+	}
+	return progress.WithEstimate(s.CheckpointTotalCount, s.CheckpointSyncedCount, elapsed)
+}
+
+// WatchStats - 상태별 Gmail watch 수 (GET /health/watches)
+type WatchStats struct {
+	Active       int `json:"active"`
+	ExpiringSoon int `json:"expiring_soon"` // Active 중 곧 만료 (갱신 대상)
+	Expired      int `json:"expired"`       // 갱신 실패/만료 - 푸시 없이 폴링으로만 동기화
+	Degraded     int `json:"degraded"`      // 사용자에게 폴링 전환을 알린 연결
+	Failing      int `json:"failing"`       // 연속 갱신 실패 중인 연결
+}
+
 // =============================================================================
 // SyncJob - Redis Stream에 발행되는 동기화 작업
 // =============================================================================
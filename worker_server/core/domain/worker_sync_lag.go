@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// SyncLag is how far the stored mailbox trails the provider (Provider 최신 메일 날짜 - DB 최신 메일 날짜).
+type SyncLag struct {
+	ConnectionID     int64      `json:"connection_id"`
+	UserID           string     `json:"user_id"`
+	ProviderLatestAt *time.Time `json:"provider_latest_at,omitempty"`
+	StoredLatestAt   *time.Time `json:"stored_latest_at,omitempty"`
+	LagSeconds       int64      `json:"lag_seconds"`
+	CheckedAt        time.Time  `json:"checked_at"`
+	AlertedAt        *time.Time `json:"alerted_at,omitempty"`    // 마지막 SLO 위반 알림
+	RemediatedAt     *time.Time `json:"remediated_at,omitempty"` // 마지막 자동 GapSync 예약
+}
+
+// Lag returns the lag as a duration.
+func (l *SyncLag) Lag() time.Duration {
+	return time.Duration(l.LagSeconds) * time.Second
+}
+
+// ConnectionSyncLag is one row of GET /admin/connections (동기화 상태 + 최근 lag 측정값).
+type ConnectionSyncLag struct {
+	ConnectionID int64      `json:"connection_id"`
+	UserID       string     `json:"user_id"`
+	Provider     Provider   `json:"provider"`
+	Status       SyncStatus `json:"status"`
+	LastSyncAt   *time.Time `json:"last_sync_at,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	Lag          *SyncLag   `json:"lag,omitempty"` // 아직 측정 전이면 nil
+}
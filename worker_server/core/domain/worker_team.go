@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrgRole is the role of a member in an org.
+type OrgRole string
+
+const (
+	OrgRoleOwner  OrgRole = "owner"  // 멤버 관리, 메일함 공유 해제
+	OrgRoleMember OrgRole = "member" // 공유 메일함 열람, 댓글
+)
+
+// Org is a team that shares mailboxes.
+type Org struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	Role      OrgRole   `json:"role,omitempty"` // 요청한 사용자의 역할
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OrgMember is a user in an org.
+type OrgMember struct {
+	OrgID    int64     `json:"org_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Email    string    `json:"email"`
+	Name     string    `json:"name,omitempty"`
+	Role     OrgRole   `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// OrgMailbox is a connection shared with an org (shared inbox).
+type OrgMailbox struct {
+	OrgID        int64     `json:"org_id"`
+	ConnectionID int64     `json:"connection_id"`
+	Email        string    `json:"email"` // 메일함 주소
+	SharedBy     uuid.UUID `json:"shared_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// EmailComment is an internal comment on an email of a shared mailbox.
+// 조직 멤버에게만 보이며 Provider에는 동기화하지 않는다.
+type EmailComment struct {
+	ID          int64       `json:"id"`
+	EmailID     int64       `json:"email_id"`
+	OrgID       int64       `json:"org_id"`
+	ParentID    *int64      `json:"parent_id,omitempty"`
+	AuthorID    uuid.UUID   `json:"author_id"`
+	AuthorEmail string      `json:"author_email,omitempty"`
+	AuthorName  string      `json:"author_name,omitempty"`
+	Body        string      `json:"body"`
+	Mentions    []uuid.UUID `json:"mentions"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+
+	Replies []*EmailComment `json:"replies,omitempty"` // 스레드 (목록 조회 시 채움)
+}
+
+// EmailAssignment is the assignee of a shared email, or an entry of its assignment history.
+// AssigneeID가 nil이면 담당 해제.
+type EmailAssignment struct {
+	ID            int64      `json:"id,omitempty"` // 이력 항목 ID
+	EmailID       int64      `json:"email_id"`
+	OrgID         int64      `json:"org_id"`
+	AssigneeID    *uuid.UUID `json:"assignee_id"`
+	AssigneeEmail string     `json:"assignee_email,omitempty"`
+	AssigneeName  string     `json:"assignee_name,omitempty"`
+	AssignedBy    uuid.UUID  `json:"assigned_by"`
+	AssignerEmail string     `json:"assigner_email,omitempty"`
+	Note          string     `json:"note,omitempty"`
+	AssignedAt    time.Time  `json:"assigned_at"`
+}
+
+// SLAStatus is the response-time SLA state of a shared email.
+type SLAStatus string
+
+const (
+	SLAStatusOK       SLAStatus = "ok"
+	SLAStatusAtRisk   SLAStatus = "at_risk"  // 허용 시간의 AtRiskPercent 경과
+	SLAStatusBreached SLAStatus = "breached" // 응답 기한 초과
+	SLAStatusMet      SLAStatus = "met"      // 답장 또는 완료 처리됨
+)
+
+// SLADefaultCategory applies to categories without their own SLA.
+const SLADefaultCategory = "default"
+
+// OrgSLA is the response-time target for a category of an org's shared mailboxes.
+type OrgSLA struct {
+	OrgID           int64     `json:"org_id"`
+	Category        string    `json:"category"` // ai_category 또는 "default"
+	ResponseMinutes int       `json:"response_minutes"`
+	AtRiskPercent   int       `json:"at_risk_percent"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// SLAEscalation is a shared email whose SLA state needs a notification.
+type SLAEscalation struct {
+	EmailID    int64
+	OrgID      int64
+	Status     SLAStatus
+	DueAt      time.Time
+	Subject    string
+	FromEmail  string
+	AssigneeID *uuid.UUID
+}
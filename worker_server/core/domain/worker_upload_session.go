@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// Upload Session (대용량 첨부파일 업로드)
+// =============================================================================
+
+// UploadMode describes where the client sends the file bytes.
+type UploadMode string
+
+const (
+	// UploadModeDirect - 클라이언트가 Provider 업로드 URL로 직접 전송 (Outlook draft 첨부)
+	UploadModeDirect UploadMode = "direct"
+	// UploadModeStaged - 우리 API로 청크 전송 후 발송 시 MIME에 포함 (Gmail은 첨부 업로드 API가 없음)
+	UploadModeStaged UploadMode = "staged"
+	// UploadModeProxy - 우리 API로 청크 전송 후 Provider 업로드 URL로 그대로 전달 (CORS 제한 클라이언트)
+	UploadModeProxy UploadMode = "proxy"
+)
+
+// UploadSession tracks a large attachment upload until it is sent.
+type UploadSession struct {
+	ID           string     `json:"session_id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	ConnectionID int64      `json:"connection_id"`
+	Provider     string     `json:"provider"`
+	Mode         UploadMode `json:"mode"`
+	MessageID    string     `json:"message_id,omitempty"` // 첨부가 붙는 draft (direct 모드)
+
+	Filename  string `json:"filename"`
+	MimeType  string `json:"mime_type"`
+	Size      int64  `json:"size"`
+	IsInline  bool   `json:"is_inline,omitempty"`
+	ContentID string `json:"content_id,omitempty"`
+
+	UploadURL     string `json:"upload_url"`
+	BytesUploaded int64  `json:"bytes_uploaded"`
+	IsComplete    bool   `json:"is_complete"`
+	AttachmentID  string `json:"attachment_id,omitempty"` // Provider attachment ID (완료 시)
+
+	ChunkSize    int64     `json:"chunk_size"`
+	MaxChunkSize int64     `json:"max_chunk_size"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// OnDraft reports whether the file is uploaded to a provider draft (direct/proxy).
+func (s *UploadSession) OnDraft() bool {
+	return s.Mode == UploadModeDirect || s.Mode == UploadModeProxy
+}
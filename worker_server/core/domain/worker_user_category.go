@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BuiltinCategories are the categories every user starts with (분류기가 기본으로 내보내는 키).
+var BuiltinCategories = []EmailCategory{
+	CategoryPrimary, CategoryWork, CategoryPersonal,
+	CategoryNotification, CategoryNewsletter, CategoryMarketing, CategorySocial,
+	CategoryFinance, CategoryShopping, CategoryTravel,
+	CategorySpam, CategoryOther,
+}
+
+var builtinCategorySet = func() map[string]bool {
+	set := make(map[string]bool, len(BuiltinCategories))
+	for _, c := range BuiltinCategories {
+		set[string(c)] = true
+	}
+	return set
+}()
+
+// IsBuiltinCategory reports whether key is one of BuiltinCategories.
+func IsBuiltinCategory(key string) bool {
+	return builtinCategorySet[key]
+}
+
+// UserCategory is a user's custom category or an override (rename/hide) of a built-in one.
+type UserCategory struct {
+	UserID      uuid.UUID `json:"-"`
+	Key         string    `json:"key"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"` // 사용자 카테고리는 LLM 분류 기준으로도 쓰인다
+	Color       string    `json:"color,omitempty"`
+	Icon        string    `json:"icon,omitempty"`
+	IsHidden    bool      `json:"is_hidden"`
+	IsCustom    bool      `json:"is_custom"`
+	SortOrder   int       `json:"sort_order"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CategoryTaxonomy is the effective category set of one user.
+// nil이면 기본 카테고리만 있는 것으로 본다.
+type CategoryTaxonomy struct {
+	entries map[string]*UserCategory
+}
+
+// NewCategoryTaxonomy builds a taxonomy from the user's stored categories.
+func NewCategoryTaxonomy(categories []*UserCategory) *CategoryTaxonomy {
+	t := &CategoryTaxonomy{entries: make(map[string]*UserCategory, len(categories))}
+	for _, c := range categories {
+		t.entries[c.Key] = c
+	}
+	return t
+}
+
+// Get returns the stored category or override for key, or nil.
+func (t *CategoryTaxonomy) Get(key string) *UserCategory {
+	if t == nil {
+		return nil
+	}
+	return t.entries[key]
+}
+
+// IsValid reports whether key is a built-in or custom category of the user (숨긴 카테고리 포함).
+func (t *CategoryTaxonomy) IsValid(key string) bool {
+	if IsBuiltinCategory(key) {
+		return true
+	}
+	c := t.Get(key)
+	return c != nil && c.IsCustom
+}
+
+// IsHidden reports whether the user hid the category.
+func (t *CategoryTaxonomy) IsHidden(key string) bool {
+	c := t.Get(key)
+	return c != nil && c.IsHidden
+}
+
+// Resolve maps a classifier category onto the taxonomy: unknown or hidden categories become other.
+func (t *CategoryTaxonomy) Resolve(category EmailCategory) EmailCategory {
+	key := string(category)
+	if !t.IsValid(key) || t.IsHidden(key) {
+		return CategoryOther
+	}
+	return category
+}
+
+// Custom returns the visible custom categories ordered by sort_order.
+func (t *CategoryTaxonomy) Custom() []*UserCategory {
+	if t == nil {
+		return nil
+	}
+	var custom []*UserCategory
+	for _, c := range t.entries {
+		if c.IsCustom && !c.IsHidden {
+			custom = append(custom, c)
+		}
+	}
+	sort.Slice(custom, func(i, j int) bool {
+		if custom[i].SortOrder != custom[j].SortOrder {
+			return custom[i].SortOrder < custom[j].SortOrder
+		}
+		return custom[i].Key < custom[j].Key
+	})
+	return custom
+}
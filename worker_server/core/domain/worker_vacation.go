@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VacationMode is how a vacation responder is carried out.
+type VacationMode string
+
+const (
+	VacationModeNative    VacationMode = "native"     // Gmail/Outlook 자체 부재중 설정
+	VacationModeAutoReply VacationMode = "auto_reply" // delta sync에서 직접 응답
+)
+
+// VacationResponder is the out-of-office configuration of a connection.
+type VacationResponder struct {
+	ConnectionID int64        `json:"connection_id"`
+	UserID       uuid.UUID    `json:"user_id"`
+	Enabled      bool         `json:"enabled"`
+	Mode         VacationMode `json:"mode"`
+	Subject      string       `json:"subject"`
+	Body         string       `json:"body"`
+	IsHTML       bool         `json:"is_html"`
+	StartAt      *time.Time   `json:"start_at,omitempty"`
+	EndAt        *time.Time   `json:"end_at,omitempty"`
+	EnabledAt    *time.Time   `json:"enabled_at,omitempty"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// ActiveAt reports whether the responder should answer mail received at t.
+func (v *VacationResponder) ActiveAt(t time.Time) bool {
+	if v == nil || !v.Enabled {
+		return false
+	}
+	if v.EnabledAt != nil && t.Before(*v.EnabledAt) {
+		return false
+	}
+	if v.StartAt != nil && t.Before(*v.StartAt) {
+		return false
+	}
+	if v.EndAt != nil && !t.Before(*v.EndAt) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkflowStage is a user-defined column of the workflow board.
+// 각 단계는 emails.workflow_status (todo/done) 하나에 매핑된다.
+type WorkflowStage struct {
+	ID        int64          `json:"id"`
+	UserID    uuid.UUID      `json:"-"`
+	Name      string         `json:"name"`
+	Color     string         `json:"color,omitempty"`
+	Status    WorkflowStatus `json:"status"`
+	Position  int            `json:"position"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// WorkflowCard is an email shown on the workflow board.
+type WorkflowCard struct {
+	EmailID      int64          `json:"email_id"`
+	ConnectionID int64          `json:"connection_id"`
+	Subject      string         `json:"subject"`
+	FromEmail    string         `json:"from_email"`
+	FromName     string         `json:"from_name,omitempty"`
+	Snippet      string         `json:"snippet"`
+	Date         time.Time      `json:"date"`
+	IsRead       bool           `json:"is_read"`
+	AIPriority   *Priority      `json:"ai_priority,omitempty"`
+	Status       WorkflowStatus `json:"status"`
+	Position     *int           `json:"position,omitempty"` // nil이면 아직 드래그로 배치되지 않음 (우선순위/날짜 순)
+}
+
+// WorkflowColumn is a stage with its cards.
+type WorkflowColumn struct {
+	Stage *WorkflowStage  `json:"stage"`
+	Cards []*WorkflowCard `json:"cards"`
+	Total int             `json:"total"`
+}
+
+// WorkflowBoard is the kanban view of the user's workflow.
+type WorkflowBoard struct {
+	Columns []*WorkflowColumn `json:"columns"`
+}
+
+// DefaultWorkflowStages are created on first use of the board.
+func DefaultWorkflowStages(userID uuid.UUID) []*WorkflowStage {
+	return []*WorkflowStage{
+		{UserID: userID, Name: "To Do", Status: WorkflowTodo, Position: 0},
+		{UserID: userID, Name: "Done", Status: WorkflowDone, Position: 1},
+	}
+}
+
+// IsBoardStatus reports whether stages can map to the status (snoozed/none은 보드에 없다).
+func (s WorkflowStatus) IsBoardStatus() bool {
+	return s == WorkflowTodo || s == WorkflowDone
+}
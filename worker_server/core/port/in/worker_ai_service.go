@@ -40,6 +40,9 @@ type AIService interface {
 	Chat(ctx context.Context, userID uuid.UUID, req *ChatRequest) (*ChatResponse, error)
 	ChatStream(ctx context.Context, userID uuid.UUID, req *ChatRequest, handler StreamHandler) error
 
+	// Mailbox question answering (RAG): 검색한 메일을 근거로 답하고 인용한 메일 ID를 반환
+	Ask(ctx context.Context, userID uuid.UUID, req *AskRequest, onSources func([]*AskSource) error, handler StreamHandler) (*AskResult, error)
+
 	// Tool execution
 	ExecuteTool(ctx context.Context, userID uuid.UUID, toolName string, args map[string]any) (*tools.ToolResult, error)
 	ConfirmProposal(ctx context.Context, userID uuid.UUID, proposalID string) (*tools.ToolResult, error)
@@ -90,6 +93,27 @@ type ProposalInfo struct {
 
 type StreamHandler func(chunk string) error
 
+// AskRequest is a natural-language question about the user's mailbox.
+type AskRequest struct {
+	Question   string `json:"question" validate:"required,max=1000"`
+	MaxSources int    `json:"max_sources,omitempty" validate:"omitempty,min=1,max=10"`
+	Timezone   string `json:"timezone,omitempty"` // "다음 주" 같은 상대 날짜 해석용 (IANA, 예: Asia/Seoul)
+}
+
+// AskSource is an email retrieved as evidence for an answer.
+type AskSource struct {
+	EmailID   int64     `json:"email_id"`
+	Subject   string    `json:"subject"`
+	FromEmail string    `json:"from_email"`
+	Date      time.Time `json:"date"`
+	Score     float64   `json:"score"`
+}
+
+// AskResult lists the source emails the answer actually cited.
+type AskResult struct {
+	Citations []int64 `json:"citations"`
+}
+
 // =============================================================================
 // Translation Types
 // =============================================================================
@@ -28,22 +28,22 @@ type CalendarService interface {
 type CreateEventRequest struct {
 	ConnectionID int64     `json:"connection_id,omitempty"` // For multi-account support
 	CalendarID   int64     `json:"calendar_id"`
-	Title        string    `json:"title"`
+	Title        string    `json:"title" validate:"max=1024"`
 	Description  *string   `json:"description,omitempty"`
 	Location     *string   `json:"location,omitempty"`
-	StartTime    time.Time `json:"start_time"`
-	EndTime      time.Time `json:"end_time"`
+	StartTime    time.Time `json:"start_time" validate:"required"`
+	EndTime      time.Time `json:"end_time" validate:"required,after=StartTime"`
 	IsAllDay     bool      `json:"is_all_day"`
 	Timezone     string    `json:"timezone"`
-	Attendees    []string  `json:"attendees,omitempty"`
+	Attendees    []string  `json:"attendees,omitempty" validate:"email"`
 	Reminders    []int     `json:"reminders,omitempty"`
 }
 
 type UpdateEventRequest struct {
-	Title       *string    `json:"title,omitempty"`
+	Title       *string    `json:"title,omitempty" validate:"max=1024"`
 	Description *string    `json:"description,omitempty"`
 	Location    *string    `json:"location,omitempty"`
 	StartTime   *time.Time `json:"start_time,omitempty"`
-	EndTime     *time.Time `json:"end_time,omitempty"`
-	Attendees   []string   `json:"attendees,omitempty"`
+	EndTime     *time.Time `json:"end_time,omitempty" validate:"after=StartTime"`
+	Attendees   []string   `json:"attendees,omitempty" validate:"email"`
 }
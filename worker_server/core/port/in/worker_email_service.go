@@ -2,6 +2,7 @@ package in
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"worker_server/core/domain"
@@ -13,6 +14,7 @@ type EmailService interface {
 	// Email operations
 	GetEmail(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.Email, error)
 	ListEmails(ctx context.Context, filter *domain.EmailFilter) ([]*domain.Email, int, error)
+	ListTodo(ctx context.Context, filter *domain.EmailFilter) ([]*domain.Email, int, error) // 긴급도(우선순위+마감일) 정렬
 	GetEmailBody(ctx context.Context, emailID int64) (*domain.EmailBody, error)
 
 	// Email actions (배치 지원)
@@ -23,7 +25,15 @@ type EmailService interface {
 	Archive(ctx context.Context, userID uuid.UUID, emailIDs []int64) error
 	Trash(ctx context.Context, userID uuid.UUID, emailIDs []int64) error
 	Delete(ctx context.Context, userID uuid.UUID, emailIDs []int64) error
+	// Restore undeletes emails deleted within the retention window and returns the restored IDs.
+	Restore(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error)
+	// Rehydrate moves archived emails back to the mailbox and returns the restored IDs.
+	Rehydrate(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error)
 	MoveToFolder(ctx context.Context, userID uuid.UUID, emailIDs []int64, folder string) error
+	// Changes returns the emails created, updated and deleted since a sync token (델타 동기화).
+	Changes(ctx context.Context, userID uuid.UUID, since string, limit int) (*domain.EmailChanges, error)
+	// ModifyIfUnchanged applies a batch action only to emails not modified since the given versions (updated_at).
+	ModifyIfUnchanged(ctx context.Context, userID uuid.UUID, action string, versions map[int64]time.Time) (*BatchModifyResult, error)
 	Snooze(ctx context.Context, userID uuid.UUID, emailIDs []int64, until time.Time) error
 	Unsnooze(ctx context.Context, userID uuid.UUID, emailIDs []int64) error
 	UpdateWorkflowStatus(ctx context.Context, userID uuid.UUID, emailIDs []int64, status string) error
@@ -32,6 +42,8 @@ type EmailService interface {
 	SendEmail(ctx context.Context, userID uuid.UUID, req *SendEmailRequest) (*domain.Email, error)
 	ReplyEmail(ctx context.Context, userID uuid.UUID, emailID int64, req *ReplyEmailRequest) (*domain.Email, error)
 	ForwardEmail(ctx context.Context, userID uuid.UUID, emailID int64, req *ForwardEmailRequest) (*domain.Email, error)
+	GetDeliveryStatus(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.SentDeliveryStatus, error) // 바운스/신고 상태
+	GetEngagement(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.SentEngagement, error)         // 열람/클릭 (opt-in)
 
 	// Sync
 	SyncEmails(ctx context.Context, connectionID int64) error
@@ -41,28 +53,187 @@ type EmailService interface {
 	RemoveLabels(ctx context.Context, userID uuid.UUID, emailID int64, labelIDs []int64) error
 	BatchAddLabels(ctx context.Context, userID uuid.UUID, emailIDs []int64, labels []string) error
 	BatchRemoveLabels(ctx context.Context, userID uuid.UUID, emailIDs []int64, labels []string) error
+
+	// Notes (개인 메모/태그, Provider에는 동기화하지 않음)
+	ListNotes(ctx context.Context, userID uuid.UUID, emailID int64) ([]*domain.EmailNote, error)
+	AddNote(ctx context.Context, userID uuid.UUID, emailID int64, req *EmailNoteRequest) (*domain.EmailNote, error)
+	UpdateNote(ctx context.Context, userID uuid.UUID, emailID, noteID int64, req *EmailNoteRequest) (*domain.EmailNote, error)
+	DeleteNote(ctx context.Context, userID uuid.UUID, emailID, noteID int64) error
+
+	// Pins (고정 메일은 inbox/todo 맨 위, 사용자별 순서)
+	Pin(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error)
+	Unpin(ctx context.Context, userID uuid.UUID, emailIDs []int64) error
+	ReorderPins(ctx context.Context, userID uuid.UUID, emailIDs []int64) error
+	ListPinned(ctx context.Context, filter *domain.EmailFilter) ([]*domain.Email, int, error)
+
+	// Reader mode + read later (뉴스레터 본문 추출, 큐는 inbox/카테고리 목록과 분리)
+	Reader(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.ReaderView, error)
+	AddReadLater(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error)
+	RemoveReadLater(ctx context.Context, userID uuid.UUID, emailIDs []int64) error
+	ListReadLater(ctx context.Context, filter *domain.EmailFilter) ([]*domain.Email, int, error)
+
+	// Feed groups (피드 카테고리 발신자별 롤업 + 그룹 단위 읽음/보관)
+	ListSenderGroups(ctx context.Context, filter *domain.EmailFilter) ([]*domain.SenderGroup, int, error)
+	ApplyGroupAction(ctx context.Context, filter *domain.EmailFilter, senders []string, action string) ([]int64, error)
+
+	// Duplicates (여러 계정에 배달된 같은 메일, 전달 사본)
+	ListDuplicates(ctx context.Context, userID uuid.UUID) ([]*domain.DuplicateGroup, error)
+	CleanupDuplicates(ctx context.Context, userID uuid.UUID) ([]int64, error)
+
+	// Thread mute (이후 메일 자동 보관, 알림 없음)
+	MuteThread(ctx context.Context, userID uuid.UUID, threadID string, connectionID *int64) ([]int64, error)
+	UnmuteThread(ctx context.Context, userID uuid.UUID, threadID string) error
+
+	// Pre-send validation (SendEmail과 같은 검사, 발송하지 않음)
+	ValidateSend(ctx context.Context, userID uuid.UUID, req *SendEmailRequest) ([]*domain.SendWarning, error)
+	// Compose preparation (첨부별 inline/업로드 세션/링크 결정 + draft 생성)
+	PrepareCompose(ctx context.Context, userID uuid.UUID, req *PrepareComposeRequest) (*PreparedCompose, error)
+
+	// Outbox (일시적 발송 실패 대기열 - 백오프 재시도, 수동 재시도/취소)
+	ListOutbox(ctx context.Context, userID uuid.UUID, status string) ([]*domain.OutboxMessage, error)
+	RetryOutbox(ctx context.Context, userID uuid.UUID, id int64) (*domain.OutboxMessage, error)
+	CancelOutbox(ctx context.Context, userID uuid.UUID, id int64) (*domain.OutboxMessage, error)
+
+	// Calendar invites (.ics 첨부 추출, iTIP REPLY로 참석 응답)
+	GetInvite(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.CalendarInvite, error)
+	RSVP(ctx context.Context, userID uuid.UUID, emailID int64, req *RSVPRequest) (*domain.CalendarInvite, error)
+
+	// Translation (to: ISO 639-1, 메일+언어별 캐시)
+	TranslateEmail(ctx context.Context, userID uuid.UUID, emailID int64, to string) (*domain.EmailTranslation, error)
+
+	// Audio (TTS로 읽어주기, 생성한 오디오는 blob 저장소에 캐시)
+	EmailAudio(ctx context.Context, userID uuid.UUID, emailID int64, mode, voice string) (*domain.EmailAudio, io.ReadCloser, error)
+
+	// Activity timeline (동기화, 분류, 사용자 작업, 규칙, AI 작업)
+	EmailActivity(ctx context.Context, userID uuid.UUID, emailID int64) ([]*domain.EmailActivity, error)
+}
+
+// BatchModifyResult is the per-ID outcome of a conditional batch action.
+type BatchModifyResult struct {
+	Updated   []int64             `json:"updated"`
+	Versions  map[int64]time.Time `json:"versions,omitempty"` // 갱신된 메일의 새 updated_at
+	Conflicts []VersionConflict   `json:"conflicts,omitempty"`
+	NotFound  []int64             `json:"not_found,omitempty"`
+}
+
+// VersionConflict is an email changed after the client's version (클라이언트는 다시 불러와야 한다).
+type VersionConflict struct {
+	ID        int64     `json:"id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RSVPRequest answers a calendar invite (response: accept, tentative, decline).
+type RSVPRequest struct {
+	Response string `json:"response" validate:"required"`
+	Comment  string `json:"comment,omitempty" validate:"max=1000"`
+}
+
+// EmailNoteRequest creates or updates a private note (body 또는 tags 중 하나는 필요).
+type EmailNoteRequest struct {
+	Body string   `json:"body" validate:"max=10000"`
+	Tags []string `json:"tags,omitempty" validate:"max=20"`
 }
 
 type SendEmailRequest struct {
 	ConnectionID int64        `json:"connection_id,omitempty"` // For multi-account support
-	To           []string     `json:"to"`
-	Cc           []string     `json:"cc,omitempty"`
-	Bcc          []string     `json:"bcc,omitempty"`
+	FromAlias    string       `json:"from_alias,omitempty"`    // send-as 주소 (GET /connections/:id/aliases)
+	To           []string     `json:"to" validate:"required,max=100,email"`
+	Cc           []string     `json:"cc,omitempty" validate:"max=100,email"`
+	Bcc          []string     `json:"bcc,omitempty" validate:"max=100,email"`
 	Subject      string       `json:"subject"`
 	Body         string       `json:"body"`
 	IsHTML       bool         `json:"is_html"`
 	Attachments  []Attachment `json:"attachments,omitempty"`
+
+	// UploadSessionIDs references completed large attachment uploads (POST /email/attachments/upload/session)
+	UploadSessionIDs []string `json:"upload_session_ids,omitempty"`
+	// DraftID is the compose draft from POST /compose/prepare, deleted once the message is sent.
+	DraftID string `json:"draft_id,omitempty"`
+
+	// UseSignature appends the signature (signature_id > connection default > user default)
+	UseSignature bool   `json:"use_signature,omitempty"`
+	SignatureID  string `json:"signature_id,omitempty"`
+
+	// TemplateID renders subject/body from a template ({{name}} / ${name} variables).
+	// 요청의 subject/body가 비어 있을 때만 템플릿 값으로 채운다.
+	TemplateID int64             `json:"template_id,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty"`
+
+	// TrackOpens/TrackClicks opt in to open pixel and link click tracking (HTML only).
+	// 서버에서 SEND_TRACKING_ENABLED가 꺼져 있으면 거부된다.
+	TrackOpens  bool `json:"track_opens,omitempty"`
+	TrackClicks bool `json:"track_clicks,omitempty"`
+
+	// ReplyToEmailID is the email being answered (내부 스레드에 외부 수신자를 넣으면 경고).
+	ReplyToEmailID int64 `json:"reply_to_email_id,omitempty"`
+	// ConfirmWarnings sends despite pre-send warnings (MX 없는 도메인, 외부 수신자, 첨부 누락).
+	ConfirmWarnings bool `json:"confirm_warnings,omitempty"`
+}
+
+// PrepareComposeRequest describes a message and its attachments before upload (POST /compose/prepare).
+type PrepareComposeRequest struct {
+	ConnectionID int64               `json:"connection_id,omitempty"`
+	To           []string            `json:"to" validate:"required,max=100,email"`
+	Cc           []string            `json:"cc,omitempty" validate:"max=100,email"`
+	Bcc          []string            `json:"bcc,omitempty" validate:"max=100,email"`
+	Subject      string              `json:"subject"`
+	Body         string              `json:"body"`
+	IsHTML       bool                `json:"is_html"`
+	Attachments  []ComposeAttachment `json:"attachments,omitempty" validate:"max=50"`
+	Proxy        bool                `json:"proxy,omitempty"` // Outlook 업로드를 우리 API로 중계 (CORS 제한 클라이언트)
+}
+
+// ComposeAttachment is an attachment descriptor (바이트 없이 크기/형식만).
+type ComposeAttachment struct {
+	Filename  string `json:"filename" validate:"required"`
+	Size      int64  `json:"size" validate:"required"`
+	MimeType  string `json:"mime_type,omitempty"`
+	IsInline  bool   `json:"is_inline,omitempty"`
+	ContentID string `json:"content_id,omitempty"`
+}
+
+// Compose attachment delivery modes.
+const (
+	ComposeModeInline        = "inline"         // send_request.attachments에 base64로 포함
+	ComposeModeUploadSession = "upload_session" // upload_session으로 업로드 후 upload_session_ids로 참조
+	ComposeModeLink          = "link"           // 업로드 세션으로 올리면 발송 시 다운로드 링크로 바뀜
+)
+
+// PreparedAttachment is the delivery plan of one attachment (요청 순서 유지).
+type PreparedAttachment struct {
+	Index         int                   `json:"index"`
+	Filename      string                `json:"filename"`
+	Size          int64                 `json:"size"`
+	Mode          string                `json:"mode"`
+	UploadSession *domain.UploadSession `json:"upload_session,omitempty"`
+}
+
+// PreparedCompose is everything the client needs to finish the send:
+// inline 첨부는 send_request.attachments에 채우고, 업로드 세션을 완료한 뒤 send_request를 POST /email로 보낸다.
+type PreparedCompose struct {
+	ConnectionID int64                 `json:"connection_id"`
+	Provider     string                `json:"provider"`
+	DraftID      string                `json:"draft_id"`
+	Attachments  []*PreparedAttachment `json:"attachments"`
+	SendRequest  *SendEmailRequest     `json:"send_request"`
+	Warnings     []*domain.SendWarning `json:"warnings,omitempty"`
 }
 
 type ReplyEmailRequest struct {
 	Body        string       `json:"body"`
 	IsHTML      bool         `json:"is_html"`
-	ReplyAll    bool         `json:"reply_all"`
+	ReplyAll    bool         `json:"reply_all"` // 원본 To/Cc에서 내 주소와 별칭을 빼고 중복 제거
 	Attachments []Attachment `json:"attachments,omitempty"`
+
+	UseSignature bool   `json:"use_signature,omitempty"`
+	SignatureID  string `json:"signature_id,omitempty"`
+
+	// ConfirmWarnings sends despite pre-send warnings (수신자 25명 초과 포함).
+	ConfirmWarnings bool `json:"confirm_warnings,omitempty"`
 }
 
 type ForwardEmailRequest struct {
-	To          []string     `json:"to"`
+	To          []string     `json:"to" validate:"required,max=100,email"`
 	Message     string       `json:"message,omitempty"`
 	Attachments []Attachment `json:"attachments,omitempty"`
 }
@@ -0,0 +1,19 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// AIUsageRepository stores monthly aggregated LLM/embedding token usage.
+type AIUsageRepository interface {
+	// Add accumulates usage into the row of (user, period, connection, task, model).
+	Add(ctx context.Context, usage *domain.AIUsage) error
+	// ListByUser returns all usage rows of a user in the period (YYYY-MM).
+	ListByUser(ctx context.Context, userID uuid.UUID, period string) ([]*domain.AIUsage, error)
+	// CostByUser returns the total cost of a user in the period.
+	CostByUser(ctx context.Context, userID uuid.UUID, period string) (float64, error)
+}
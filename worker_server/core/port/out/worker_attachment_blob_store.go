@@ -0,0 +1,23 @@
+package out
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"worker_server/core/domain"
+)
+
+// ErrBlobNotFound is returned when the stored attachment does not exist.
+var ErrBlobNotFound = errors.New("attachment blob not found")
+
+// AttachmentBlobStore stores outgoing attachments that are sent as download links.
+type AttachmentBlobStore interface {
+	// Put stores data and sets blob.ID and blob.CreatedAt.
+	Put(ctx context.Context, blob *domain.LinkedAttachment, data io.Reader) error
+	// Open returns the metadata and content of a blob. The caller closes the reader.
+	Open(ctx context.Context, id string) (*domain.LinkedAttachment, io.ReadCloser, error)
+	// DeleteExpired removes blobs that expired before t and returns the number removed.
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,20 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+)
+
+// ClassificationBackfillRepository stores classification backfill checkpoints per connection.
+type ClassificationBackfillRepository interface {
+	Get(ctx context.Context, connectionID int64) (*domain.ClassificationBackfill, error)
+	// Start (re)starts the backfill from the beginning with the given total.
+	Start(ctx context.Context, userID string, connectionID int64, total int) (*domain.ClassificationBackfill, error)
+	// SaveCheckpoint records the last published email ID and counts.
+	SaveCheckpoint(ctx context.Context, connectionID, cursorID int64, published, failed int) error
+	// Finish sets the terminal status. errMsg is stored for failed backfills.
+	Finish(ctx context.Context, connectionID int64, status domain.BackfillStatus, errMsg string) error
+	// ListRunning returns backfills left running (e.g. by a worker that died).
+	ListRunning(ctx context.Context) ([]*domain.ClassificationBackfill, error)
+}
@@ -0,0 +1,28 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// BriefingRepository defines the outbound port for daily briefings.
+type BriefingRepository interface {
+	// Get returns the briefing of a local date (YYYY-MM-DD), or nil if none was generated.
+	Get(ctx context.Context, userID uuid.UUID, date string) (*domain.Briefing, error)
+	// Save upserts the briefing of (user, date).
+	Save(ctx context.Context, briefing *domain.Briefing) error
+	MarkEmailed(ctx context.Context, userID uuid.UUID, date string, at time.Time) error
+
+	// GetSettings returns the user's preferences (기본값 포함, 시간대는 user_settings에서 읽는다).
+	GetSettings(ctx context.Context, userID uuid.UUID) (*domain.BriefingSettings, error)
+	SaveSettings(ctx context.Context, settings *domain.BriefingSettings) error
+	// ListEnabledSettings returns the settings of users with a connected account and briefings enabled.
+	ListEnabledSettings(ctx context.Context) ([]*domain.BriefingSettings, error)
+
+	// ListAwaitingReply returns threads whose latest message is one the user sent between sentAfter and sentBefore.
+	ListAwaitingReply(ctx context.Context, userID uuid.UUID, sentAfter, sentBefore time.Time, limit int) ([]domain.BriefingThread, error)
+}
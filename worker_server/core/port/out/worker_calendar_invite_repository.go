@@ -0,0 +1,19 @@
+package out
+
+import (
+	"context"
+	"errors"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+var ErrCalendarInviteNotFound = errors.New("calendar invite not found")
+
+// CalendarInviteRepository defines the outbound port for invites extracted from emails.
+type CalendarInviteRepository interface {
+	Get(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.CalendarInvite, error)
+	// Save upserts the invite. 같은 메일의 응답(response)은 새 값으로 덮어쓴다.
+	Save(ctx context.Context, invite *domain.CalendarInvite) error
+}
@@ -0,0 +1,30 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// CampaignRepository defines the outbound port for mail merge campaigns.
+type CampaignRepository interface {
+	// Create stores a campaign together with its recipients.
+	Create(ctx context.Context, campaign *domain.EmailCampaign, recipients []*domain.CampaignRecipient) error
+	GetByID(ctx context.Context, userID uuid.UUID, id int64) (*domain.EmailCampaign, error)
+	ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.EmailCampaign, error)
+	ListRecipients(ctx context.Context, campaignID int64, status domain.CampaignRecipientStatus, limit, offset int) ([]*domain.CampaignRecipient, error)
+
+	// Worker 발송 처리
+	NextPendingRecipients(ctx context.Context, campaignID int64, limit int) ([]*domain.CampaignRecipient, error)
+	MarkRecipientSent(ctx context.Context, recipientID int64, providerMessageID string) error
+	MarkRecipientFailed(ctx context.Context, recipientID int64, errMsg string) error
+	// MarkRecipientBounced marks a sent recipient as bounced (동기화 중 DSN 수신) and refreshes counts.
+	MarkRecipientBounced(ctx context.Context, providerMessageID, email, reason string) error
+
+	// 진행 상태 갱신
+	MarkSending(ctx context.Context, id int64) error
+	RefreshCounts(ctx context.Context, id int64) error
+	MarkFinished(ctx context.Context, id int64, status domain.CampaignStatus, errMsg string) error
+}
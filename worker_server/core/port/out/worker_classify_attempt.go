@@ -0,0 +1,18 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ClassifyAttemptRepository defines the outbound port for classification attempt history.
+type ClassifyAttemptRepository interface {
+	Record(ctx context.Context, attempts ...*domain.ClassifyAttempt) error
+	// ListByEmail returns the attempts of an email, newest first.
+	ListByEmail(ctx context.Context, userID uuid.UUID, emailID int64, limit int) ([]*domain.ClassifyAttempt, error)
+	// ListFailed returns emails with ai_status='failed' (connectionID 0 = all connections), most recently failed first.
+	ListFailed(ctx context.Context, userID uuid.UUID, connectionID int64, limit int) ([]*domain.FailedClassification, error)
+}
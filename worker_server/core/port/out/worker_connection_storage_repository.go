@@ -0,0 +1,25 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+)
+
+// ConnectionStorageRepository defines the outbound port for per-connection storage usage.
+type ConnectionStorageRepository interface {
+	// MeasureDB fills Metadata/Attachments/Vectors from PostgreSQL (본문은 EmailBodyRepository에서 측정).
+	MeasureDB(ctx context.Context, usage *domain.ConnectionStorage) error
+	// Get returns the last measurement with cap and mode (없으면 nil).
+	Get(ctx context.Context, connectionID int64) (*domain.ConnectionStorage, error)
+	// Save upserts the measured usage and metadata-only mode (cap_bytes는 유지).
+	Save(ctx context.Context, usage *domain.ConnectionStorage) error
+	// SetCap sets the per-connection cap (nil = 기본값 사용).
+	SetCap(ctx context.Context, connectionID int64, userID string, capBytes *int64) error
+	// SetSyncMode sets the user's sync mode (full / metadata).
+	SetSyncMode(ctx context.Context, connectionID int64, userID string, mode domain.ConnectionSyncMode) error
+	// ListEmailIDs returns up to limit email IDs of the connection with ID > afterID in ascending order.
+	ListEmailIDs(ctx context.Context, connectionID, afterID int64, limit int) ([]int64, error)
+	// ListConnections returns every synced connection with its stored cap and mode (측정 전이면 사용량 0).
+	ListConnections(ctx context.Context) ([]*domain.ConnectionStorage, error)
+}
@@ -0,0 +1,13 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+)
+
+// ContactEnricher looks up public profile data for an email address (Gravatar, Clearbit 스타일 API).
+type ContactEnricher interface {
+	// Enrich returns the profile data found for the address, or nil if nothing was found.
+	Enrich(ctx context.Context, email string) (*domain.ContactEnrichment, error)
+}
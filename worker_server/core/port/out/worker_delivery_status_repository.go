@@ -0,0 +1,17 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryStatusRepository defines the outbound port for bounce/complaint events.
+type DeliveryStatusRepository interface {
+	// SaveEvents stores report events. 원본 발송 메일을 Message-ID로 찾으면 SentEmailID를 채운다.
+	SaveEvents(ctx context.Context, events []*domain.DeliveryEvent) error
+	// ListForSentEmail returns events linked to the sent email or to its Message-ID.
+	ListForSentEmail(ctx context.Context, userID uuid.UUID, connectionID, sentEmailID int64, messageID string) ([]*domain.DeliveryEvent, error)
+}
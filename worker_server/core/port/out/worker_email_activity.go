@@ -0,0 +1,16 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// EmailActivityRepository defines the outbound port for the per-email activity timeline.
+type EmailActivityRepository interface {
+	Record(ctx context.Context, activities ...*domain.EmailActivity) error
+	// ListByEmail returns the activities of an email, oldest first.
+	ListByEmail(ctx context.Context, userID uuid.UUID, emailID int64, limit int) ([]*domain.EmailActivity, error)
+}
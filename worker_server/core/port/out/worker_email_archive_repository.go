@@ -0,0 +1,21 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailArchiveRepository moves old emails of large mailboxes to the partitioned archive tier.
+// 아카이브된 메일은 목록에서 IncludeArchived로 함께 조회하고, 열면 hot 테이블로 되돌린다.
+type EmailArchiveRepository interface {
+	// LargeMailboxes returns users that received at least minEmails emails.
+	LargeMailboxes(ctx context.Context, minEmails, limit int) ([]uuid.UUID, error)
+	// Archive moves up to limit emails dated before the cutoff and returns their IDs.
+	Archive(ctx context.Context, userID uuid.UUID, before time.Time, limit int) ([]int64, error)
+	// Rehydrate moves archived emails back to the emails table and returns the restored IDs.
+	Rehydrate(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error)
+	// IsArchived reports whether the user's email is in the archive.
+	IsArchived(ctx context.Context, userID uuid.UUID, emailID int64) (bool, error)
+}
@@ -0,0 +1,20 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// EmailAssignmentRepository defines the outbound port for shared email assignees and their history.
+type EmailAssignmentRepository interface {
+	// Set replaces the current assignee (AssigneeID nil = 해제), records history
+	// and returns the previous assignee.
+	Set(ctx context.Context, assignment *domain.EmailAssignment) (*uuid.UUID, error)
+	// Get returns the current assignment, or nil if the email is unassigned.
+	Get(ctx context.Context, emailID int64) (*domain.EmailAssignment, error)
+	// History returns assignment changes, newest first.
+	History(ctx context.Context, emailID int64, limit int) ([]*domain.EmailAssignment, error)
+}
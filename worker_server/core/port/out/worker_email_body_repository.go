@@ -32,6 +32,16 @@ type EmailBodyRepository interface {
 	DeleteByConnectionID(ctx context.Context, connectionID int64) (int64, error)
 	DeleteOlderThan(ctx context.Context, before time.Time) (int64, error)
 
+	// LRU operations
+	// TouchBody updates last access time and slides the expiry.
+	TouchBody(ctx context.Context, emailID int64, expiresAt time.Time) error
+	// GetConnectionCacheSize returns the stored (compressed) size for a connection.
+	GetConnectionCacheSize(ctx context.Context, connectionID int64) (int64, error)
+	// GetConnectionCacheUsage returns the body count and stored (compressed) size for a connection.
+	GetConnectionCacheUsage(ctx context.Context, connectionID int64) (count int64, size int64, err error)
+	// EvictLRU deletes least recently accessed bodies until the connection fits maxBytes.
+	EvictLRU(ctx context.Context, connectionID int64, maxBytes int64) (int64, error)
+
 	// Stats
 	GetStorageStats(ctx context.Context) (*BodyStorageStats, error)
 	GetCompressionStats(ctx context.Context) (*CompressionStats, error)
@@ -59,6 +69,9 @@ type MailBodyEntity struct {
 	CachedAt  time.Time
 	ExpiresAt time.Time // 30일 후
 	TTLDays   int       // 기본 30일
+
+	// LastAccessedAt - LRU 제거 기준 (미설정 시 CachedAt)
+	LastAccessedAt time.Time
 }
 
 // AttachmentEntity represents attachment metadata.
@@ -0,0 +1,21 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// EmailChangeRepository reads the email change log (emails 트리거가 기록).
+type EmailChangeRepository interface {
+	// ListSince returns the user's changes after the position in (TxID, ID) order.
+	// 진행 중인 트랜잭션이 있으면 그보다 앞선 트랜잭션의 변경까지만 돌려준다.
+	ListSince(ctx context.Context, userID uuid.UUID, after domain.EmailChangePosition, limit int) ([]*domain.EmailChange, error)
+	// Position returns the current position: 이후 변경은 모두 이 위치 뒤에 온다.
+	Position(ctx context.Context) (domain.EmailChangePosition, error)
+	// Prune deletes up to limit changes recorded before the cutoff.
+	Prune(ctx context.Context, before time.Time, limit int) (int, error)
+}
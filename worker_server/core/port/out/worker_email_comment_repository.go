@@ -0,0 +1,24 @@
+package out
+
+import (
+	"context"
+	"errors"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ErrEmailCommentNotFound is returned when the comment (or reply parent) does not exist on the email.
+var ErrEmailCommentNotFound = errors.New("email comment not found")
+
+// EmailCommentRepository defines the outbound port for internal email comments.
+type EmailCommentRepository interface {
+	// Create inserts a comment. ParentID must be a comment of the same email.
+	Create(ctx context.Context, comment *domain.EmailComment) error
+	// Update replaces body and mentions of a comment written by comment.AuthorID.
+	Update(ctx context.Context, comment *domain.EmailComment) error
+	Delete(ctx context.Context, authorID uuid.UUID, emailID, commentID int64) error
+	// ListByEmailID returns the comments of an email, oldest first, with author info.
+	ListByEmailID(ctx context.Context, emailID int64) ([]*domain.EmailComment, error)
+}
@@ -0,0 +1,19 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// EmailDeadlineRepository defines the outbound port for deadlines extracted from emails.
+type EmailDeadlineRepository interface {
+	// Save upserts the deadline of an email.
+	Save(ctx context.Context, deadline *domain.EmailDeadline) error
+	// GetByEmailIDs returns deadlines keyed by email ID (목록 화면용 일괄 조회).
+	GetByEmailIDs(ctx context.Context, userID uuid.UUID, emailIDs []int64) (map[int64]*domain.EmailDeadline, error)
+	// UserTimezone returns the IANA timezone from user_settings ("" if unset).
+	UserTimezone(ctx context.Context, userID uuid.UUID) (string, error)
+}
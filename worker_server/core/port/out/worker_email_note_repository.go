@@ -0,0 +1,22 @@
+package out
+
+import (
+	"context"
+	"errors"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ErrEmailNoteNotFound is returned when the note (or its email) does not belong to the user.
+var ErrEmailNoteNotFound = errors.New("email note not found")
+
+// EmailNoteRepository defines the outbound port for private email notes.
+type EmailNoteRepository interface {
+	// Create inserts a note only if the email belongs to the note's user.
+	Create(ctx context.Context, note *domain.EmailNote) error
+	Update(ctx context.Context, note *domain.EmailNote) error
+	Delete(ctx context.Context, userID uuid.UUID, emailID, noteID int64) error
+	ListByEmailID(ctx context.Context, userID uuid.UUID, emailID int64) ([]*domain.EmailNote, error)
+}
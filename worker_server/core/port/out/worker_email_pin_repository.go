@@ -0,0 +1,18 @@
+package out
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// EmailPinRepository defines the outbound port for pinned emails and their per-user order.
+type EmailPinRepository interface {
+	// Pin moves emails to the top of the pinned list in the given order and returns the IDs owned by the user.
+	Pin(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error)
+	Unpin(ctx context.Context, userID uuid.UUID, emailIDs []int64) error
+	// Reorder sets pin positions to the order of emailIDs.
+	Reorder(ctx context.Context, userID uuid.UUID, emailIDs []int64) error
+	// ListIDs returns the pinned email IDs, top first.
+	ListIDs(ctx context.Context, userID uuid.UUID) ([]int64, error)
+}
@@ -63,6 +63,10 @@ type MailSyncer interface {
 type MailMessageReader interface {
 	GetMessage(ctx context.Context, token *oauth2.Token, externalID string) (*ProviderMailMessage, error)
 	GetMessageBody(ctx context.Context, token *oauth2.Token, externalID string) (*ProviderMessageBody, error)
+	// GetMessageRaw returns the original RFC 822 source. maxSize > 0 이면 초과 시 ProviderErrTooLarge.
+	GetMessageRaw(ctx context.Context, token *oauth2.Token, externalID string, maxSize int64) ([]byte, error)
+	// GetMessageHeaders returns every header of the message in original order.
+	GetMessageHeaders(ctx context.Context, token *oauth2.Token, externalID string) ([]ProviderMessageHeader, error)
 	ListMessages(ctx context.Context, token *oauth2.Token, opts *ProviderListOptions) (*ProviderListResult, error)
 }
 
@@ -158,9 +162,20 @@ type ProviderSyncOptions struct {
 type ProviderSyncResult struct {
 	Messages      []ProviderMailMessage
 	DeletedIDs    []string
-	NextSyncState string // History ID for delta sync
-	NextPageToken string // 다음 페이지 토큰 (Progressive Loading용)
+	LabelChanges  []ProviderLabelChange // 기존 메시지의 라벨 변경 (읽음/별표/폴더 포함)
+	NextSyncState string                // History ID for delta sync
+	NextPageToken string                // 다음 페이지 토큰 (Progressive Loading용)
 	HasMore       bool
+	TotalEstimate int64 // 조건에 맞는 전체 메일 추정치 (Gmail resultSizeEstimate / Graph @odata.count, 0이면 모름)
+}
+
+// ProviderLabelChange is the label state of an existing message after a provider-side change.
+type ProviderLabelChange struct {
+	ExternalID string
+	Labels     []string // 변경 후 전체 라벨
+	IsRead     bool
+	IsStarred  bool
+	Folder     string
 }
 
 // ProviderWatchResponse represents push notification subscription.
@@ -176,6 +191,10 @@ type ProviderListOptions struct {
 	Labels     []string
 	MaxResults int
 	PageToken  string
+
+	// Outlook 전용: $filter 식과 mailFolder 범위 (비어 있으면 전체 메일)
+	Filter   string
+	FolderID string
 }
 
 // ProviderListResult represents list result.
@@ -269,6 +288,21 @@ type ProviderClassificationHeaders struct {
 
 	// CC Address (for GitHub notification type detection)
 	CCAddresses []string `json:"cc_addresses,omitempty"`
+
+	// Sender Authentication (RFC 8601, RFC 7208)
+	AuthenticationResults []string `json:"authentication_results,omitempty"`
+	ReceivedSPF           string   `json:"received_spf,omitempty"`
+	ReplyTo               string   `json:"reply_to,omitempty"`
+
+	// Delivery Reports (RFC 3464 DSN, RFC 5965 ARF) - 바운스/신고 추적
+	ContentType       string `json:"content_type,omitempty"` // multipart/report일 때만 설정
+	XFailedRecipients string `json:"x_failed_recipients,omitempty"`
+}
+
+// ProviderMessageHeader is a single raw header field.
+type ProviderMessageHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // ProviderMessageBody represents message body.
@@ -304,6 +338,7 @@ type ProviderAttachmentStream struct {
 
 // ProviderOutgoingMessage represents outgoing message.
 type ProviderOutgoingMessage struct {
+	From    *ProviderEmailAddress // send-as alias (nil이면 계정 기본 주소)
 	To      []ProviderEmailAddress
 	CC      []ProviderEmailAddress
 	BCC     []ProviderEmailAddress
@@ -316,6 +351,30 @@ type ProviderOutgoingMessage struct {
 	InReplyTo  string
 	References string
 	ThreadID   string
+
+	// Headers are extra RFC 5322 headers (예: Auto-Submitted). Raw MIME 기반 Provider만 지원.
+	Headers []ProviderMessageHeader
+}
+
+// ProviderAlias represents an address the account can send as (Gmail sendAs, Outlook proxy address).
+type ProviderAlias struct {
+	Email       string
+	DisplayName string
+	ReplyTo     string
+	IsPrimary   bool
+	IsDefault   bool
+	Verified    bool
+}
+
+// ProviderVacationSettings represents the provider-native vacation responder.
+// Outlook 자동 응답은 제목이 없으므로 Subject는 무시된다.
+type ProviderVacationSettings struct {
+	Enabled bool
+	Subject string
+	Body    string
+	IsHTML  bool
+	StartAt *time.Time
+	EndAt   *time.Time
 }
 
 // ProviderOutgoingAttachment represents outgoing attachment.
@@ -354,6 +413,12 @@ type ProviderMailLabel struct {
 	MessagesUnread int64
 }
 
+// ProviderMailFolder represents a custom provider folder (Gmail 사용자 라벨, Outlook mailFolder).
+type ProviderMailFolder struct {
+	ExternalID string
+	Name       string
+}
+
 // ProviderProfile represents user profile.
 type ProviderProfile struct {
 	Email     string
@@ -378,6 +443,7 @@ const (
 	ProviderErrServer       ProviderErrorCode = "server_error"
 	ProviderErrInvalidInput ProviderErrorCode = "invalid_input"
 	ProviderErrSyncRequired ProviderErrorCode = "full_sync_required"
+	ProviderErrTooLarge     ProviderErrorCode = "too_large"
 )
 
 // ProviderError represents a provider error.
@@ -400,6 +466,11 @@ func (e *ProviderError) Unwrap() error {
 	return e.Err
 }
 
+// IsRetryable reports whether the request may succeed if retried (pkg/retry 분류에 사용).
+func (e *ProviderError) IsRetryable() bool {
+	return e.Retryable
+}
+
 // NewProviderError creates a new provider error.
 func NewProviderError(provider string, code ProviderErrorCode, message string, err error, retryable bool) *ProviderError {
 	return &ProviderError{
@@ -0,0 +1,15 @@
+package out
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// EmailReadLaterRepository defines the outbound port for the read-later queue.
+type EmailReadLaterRepository interface {
+	// Add queues emails and returns the IDs owned by the user. 이미 큐에 있으면 맨 앞으로 옮긴다.
+	Add(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error)
+	Remove(ctx context.Context, userID uuid.UUID, emailIDs []int64) error
+	Contains(ctx context.Context, userID uuid.UUID, emailID int64) (bool, error)
+}
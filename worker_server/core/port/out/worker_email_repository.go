@@ -6,6 +6,8 @@ import (
 	"context"
 	"time"
 
+	"worker_server/core/domain"
+
 	"github.com/google/uuid"
 )
 
@@ -26,8 +28,15 @@ type EmailRepository interface {
 
 	// Query operations
 	List(ctx context.Context, userID uuid.UUID, req *MailListQuery) ([]*MailEntity, int, error)
-	Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]*MailEntity, int, error)
+	// Search runs full-text search; scope의 필터(폴더/스레드/라벨 등)로 범위를 좁힌다 (nil = 전체).
+	Search(ctx context.Context, userID uuid.UUID, query string, scope *MailListQuery, limit, offset int) ([]*MailEntity, int, error)
 	ListByContact(ctx context.Context, userID uuid.UUID, contactID int64, limit, offset int) ([]*MailEntity, int, error)
+	// ListSenderGroups collapses matching emails into one group per sender, latest first (피드 롤업).
+	ListSenderGroups(ctx context.Context, userID uuid.UUID, req *MailListQuery) ([]*domain.SenderGroup, int, error)
+	// ListIDsBySenders returns IDs of matching emails from the given senders (그룹 단위 일괄 처리).
+	ListIDsBySenders(ctx context.Context, userID uuid.UUID, req *MailListQuery, senders []string, limit int) ([]int64, error)
+	// ListDuplicateGroups returns emails with redundant copies (같은 Message-ID, 내 계정으로 전달한 사본).
+	ListDuplicateGroups(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.DuplicateGroup, error)
 
 	// Thread operations
 	GetThreadMessages(ctx context.Context, threadID int64) ([]*MailEntity, error)
@@ -55,6 +64,15 @@ type EmailRepository interface {
 	BatchUpdateTags(ctx context.Context, ids []int64, addTags, removeTags []string) error
 	BatchUpdateWorkflowStatus(ctx context.Context, ids []int64, status string, snoozeUntil *time.Time) error
 	BatchDelete(ctx context.Context, ids []int64) error
+	// SoftDelete marks the user's emails deleted (복원 가능) and returns the IDs that changed.
+	SoftDelete(ctx context.Context, userID uuid.UUID, ids []int64) ([]int64, error)
+	// Restore undeletes the user's emails deleted after since and returns the restored IDs.
+	Restore(ctx context.Context, userID uuid.UUID, ids []int64, since time.Time) ([]int64, error)
+	// PurgeDeleted permanently deletes up to limit emails soft-deleted before the cutoff.
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error)
+	// UpdateIfUnchanged applies update only to the user's emails not modified after the expected updated_at.
+	// 낙관적 동시성: 찾은 메일마다 적용 여부와 updated_at을 돌려준다 (없는 ID는 결과에서 빠진다).
+	UpdateIfUnchanged(ctx context.Context, userID uuid.UUID, versions map[int64]time.Time, update *MailBatchUpdate) ([]*MailVersionResult, error)
 	BulkUpsert(ctx context.Context, userID uuid.UUID, connectionID int64, mails []*MailEntity) error
 	DeleteByExternalIDs(ctx context.Context, connectionID int64, externalIDs []string) error
 
@@ -62,6 +80,8 @@ type EmailRepository interface {
 	GetStats(ctx context.Context, userID uuid.UUID) (*MailStats, error)
 	CountUnread(ctx context.Context, userID uuid.UUID, connectionID *int64) (int, error)
 	GetCategoryStats(ctx context.Context, userID uuid.UUID, connectionID *int64) (map[string]*CategoryStatItem, error)
+	// GetSubCategoryStats returns email counts per category and sub-category (category → sub_category → counts).
+	GetSubCategoryStats(ctx context.Context, userID uuid.UUID, connectionID *int64) (map[string]map[string]*CategoryStatItem, error)
 
 	// Snooze
 	GetSnoozedToWake(ctx context.Context) ([]*MailEntity, error)
@@ -76,6 +96,12 @@ type EmailRepository interface {
 	ListPendingAI(ctx context.Context, userID uuid.UUID, limit int) ([]*MailEntity, error)
 	CountUnclassified(ctx context.Context, connectionID int64) (int, error)
 	ListUnclassifiedByConnection(ctx context.Context, connectionID int64, limit int) ([]*MailEntity, error)
+	// ListUnclassifiedIDsAfter returns unclassified email IDs greater than afterID in ascending order (backfill paging).
+	ListUnclassifiedIDsAfter(ctx context.Context, connectionID, afterID int64, limit int) ([]int64, error)
+	// SetAIStatus sets ai_status (pending, processing, completed, failed) of emails.
+	SetAIStatus(ctx context.Context, ids []int64, status string) error
+	// CountAIStatuses counts a connection's emails by classification state.
+	CountAIStatuses(ctx context.Context, userID uuid.UUID, connectionID int64) (*AIStatusCounts, error)
 
 	// Profile analysis
 	GetSentEmails(ctx context.Context, userID uuid.UUID, connectionID int64, limit int) ([]*MailEntity, error)
@@ -133,9 +159,10 @@ type MailEntity struct {
 	AccountEmail string
 
 	// Message IDs for threading
-	MessageID  string
-	InReplyTo  string
-	References []string
+	MessageID        string
+	InReplyTo        string
+	References       []string
+	ExternalThreadID string // Provider 스레드 ID (Gmail threadId, Outlook conversationId)
 
 	// Sender/Recipients (raw emails)
 	FromEmail string
@@ -160,9 +187,10 @@ type MailEntity struct {
 	IsForwarded   bool
 
 	// Organization
-	Folder string
-	Labels []string
-	Tags   []string
+	Folder   string
+	FolderID *int64 // 사용자 정의 폴더 (folders.id)
+	Labels   []string
+	Tags     []string
 
 	// Workflow
 	WorkflowStatus string
@@ -181,10 +209,17 @@ type MailEntity struct {
 	DueDate              *string // 감지된 마감일
 	AIScore              float64 // Classification confidence score
 	ClassificationSource string  // header, domain, llm, user
+	ClassificationStage  string  // 라벨을 붙인 파이프라인 단계 (rfc, rule, llm, default 등)
 
 	// Contact link
 	ContactID *int64
 
+	// Language is the ISO 639-1 code detected during classification (비어있으면 미감지).
+	Language string
+
+	// DeletedAt is set while the email is soft-deleted (복원 가능 기간).
+	DeletedAt *time.Time
+
 	// Timestamps
 	ReceivedAt time.Time
 	CreatedAt  time.Time
@@ -256,6 +291,10 @@ type MailListQuery struct {
 	WorkflowStatus string
 	FromEmail      string
 	FromDomain     string
+	Sender         string // from_email 정확히 일치 (대소문자 무시, 피드 그룹 펼치기)
+	Language       string // 감지된 언어 (ISO 639-1)
+	ThreadID       string // external_thread_id 일치 (스레드 뮤트)
+	Deleted        bool   // 삭제된(복원 가능한) 메일만 조회, false면 삭제된 메일 제외
 	LabelIDs       []int64
 
 	// === Inbox/Category View Filters ===
@@ -281,9 +320,32 @@ type MailListQuery struct {
 	Limit  int
 	Offset int
 
+	// Fields: 조회할 Email JSON 필드 (?fields=). 비어 있으면 전체 컬럼
+	Fields []string
+
 	// Sorting
 	OrderBy string
 	Order   string
+	// Timezone: IANA timezone for OrderBy "urgency" (마감일까지 남은 일수 계산, 기본 UTC)
+	Timezone string
+
+	// Pinned emails (email_pins)
+	// PinnedFirst: 고정된 메일을 고정 순서대로 맨 위에 둔 뒤 OrderBy 적용
+	// PinnedOnly: 고정된 메일만 고정 순서대로 조회
+	PinnedFirst bool
+	PinnedOnly  bool
+
+	// Read later queue (email_read_later)
+	// ReadLaterOnly: 큐에 있는 메일만 추가한 순서(최신 먼저)로 조회
+	// ExcludeReadLater: 큐에 있는 메일 제외 (inbox/카테고리 목록)
+	ReadLaterOnly    bool
+	ExcludeReadLater bool
+
+	// AssignedToMe: user_id 대신 email_assignments.assignee_id로 범위를 정한다 (공유 메일함)
+	AssignedToMe bool
+
+	// IncludeArchived: 아카이브 파티션(emails_archive)의 오래된 메일도 함께 조회
+	IncludeArchived bool
 }
 
 // MailAIResult represents AI processing result.
@@ -312,6 +374,16 @@ type MailStats struct {
 	ByPriority   map[string]int // "urgent", "high", "normal", "low", "lowest"
 }
 
+// AIStatusCounts counts emails by classification state.
+// Pending은 CountUnclassified와 같은 기준(none/pending 또는 카테고리 없음)이다.
+type AIStatusCounts struct {
+	Total      int `json:"total"`
+	Pending    int `json:"pending"`
+	Processing int `json:"processing"`
+	Classified int `json:"classified"`
+	Failed     int `json:"failed"`
+}
+
 // CategoryStatItem represents statistics for a single category.
 type CategoryStatItem struct {
 	Total  int `json:"total"`
@@ -327,6 +399,21 @@ type MailTranslation struct {
 	CreatedAt  time.Time
 }
 
+// MailBatchUpdate is a batch modification. nil/빈 필드는 바꾸지 않는다.
+type MailBatchUpdate struct {
+	IsRead     *bool
+	Folder     *string
+	AddTags    []string
+	RemoveTags []string
+}
+
+// MailVersionResult is the outcome of a conditional update for one email.
+type MailVersionResult struct {
+	ID        int64     `db:"id"`
+	Updated   bool      `db:"updated"`
+	UpdatedAt time.Time `db:"updated_at"` // 갱신됐으면 새 값, 충돌이면 현재 값
+}
+
 // =============================================================================
 // Attachment Repository
 // =============================================================================
@@ -338,6 +425,7 @@ type AttachmentRepository interface {
 	Create(ctx context.Context, attachment *EmailAttachmentEntity) error
 	CreateBatch(ctx context.Context, attachments []*EmailAttachmentEntity) error
 	GetByID(ctx context.Context, id int64) (*EmailAttachmentEntity, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]*EmailAttachmentEntity, error)
 	GetByEmailID(ctx context.Context, emailID int64) ([]*EmailAttachmentEntity, error)
 	GetByExternalID(ctx context.Context, emailID int64, externalID string) (*EmailAttachmentEntity, error)
 	GetByContentID(ctx context.Context, emailID int64, contentID string) (*EmailAttachmentEntity, error) // 인라인 첨부파일 조회
@@ -0,0 +1,18 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// EmailSecurityRepository defines the outbound port for phishing/spoofing analysis results.
+type EmailSecurityRepository interface {
+	// Save upserts the analysis result of an email.
+	Save(ctx context.Context, userID uuid.UUID, security *domain.EmailSecurity) error
+	GetByEmailID(ctx context.Context, emailID int64) (*domain.EmailSecurity, error)
+	// GetByEmailIDs returns results keyed by email ID (목록 화면용 일괄 조회).
+	GetByEmailIDs(ctx context.Context, emailIDs []int64) (map[int64]*domain.EmailSecurity, error)
+}
@@ -0,0 +1,24 @@
+package out
+
+import (
+	"context"
+	"errors"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ErrEmailShareNotFound is returned when the share link does not exist or does not belong to the user.
+var ErrEmailShareNotFound = errors.New("email share not found")
+
+// EmailShareRepository defines the outbound port for email share links.
+type EmailShareRepository interface {
+	Create(ctx context.Context, share *domain.EmailShare) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.EmailShare, error)
+	ListByEmailID(ctx context.Context, userID uuid.UUID, emailID int64) ([]*domain.EmailShare, error)
+	// Revoke disables a link. 이미 폐기된 링크도 성공으로 처리한다.
+	Revoke(ctx context.Context, userID uuid.UUID, emailID, shareID int64) error
+	// RecordView increments the view count of a link.
+	RecordView(ctx context.Context, shareID int64) error
+}
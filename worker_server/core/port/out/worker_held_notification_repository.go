@@ -0,0 +1,19 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// HeldNotificationRepository stores notifications held during quiet hours.
+type HeldNotificationRepository interface {
+	Hold(ctx context.Context, n *domain.HeldNotification) error
+	// ListDueUsers returns users with held notifications whose release time has passed.
+	ListDueUsers(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+	// ClaimDue deletes and returns the user's due notifications (여러 인스턴스가 같은 알림을 두 번 보내지 않도록).
+	ClaimDue(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.HeldNotification, error)
+}
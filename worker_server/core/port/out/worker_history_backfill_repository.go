@@ -0,0 +1,26 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/domain"
+)
+
+// HistoryBackfillRepository stores history backfill requests and their checkpoints.
+type HistoryBackfillRepository interface {
+	Create(ctx context.Context, b *domain.HistoryBackfill) error
+	Get(ctx context.Context, id int64) (*domain.HistoryBackfill, error)
+	ListByUser(ctx context.Context, userID string, limit int) ([]*domain.HistoryBackfill, error)
+	// GetActive returns the pending/running backfill of a connection (없으면 nil).
+	GetActive(ctx context.Context, connectionID int64) (*domain.HistoryBackfill, error)
+	// ListActive returns pending/running backfills, least recently updated first.
+	ListActive(ctx context.Context, limit int) ([]*domain.HistoryBackfill, error)
+	// SaveCheckpoint records progress and marks the backfill running.
+	// 취소 등으로 더 이상 active가 아니면 false를 반환한다.
+	SaveCheckpoint(ctx context.Context, id int64, pageToken string, fetched, saved int) (bool, error)
+	// Finish sets the terminal status of an active backfill. errMsg is stored for failed backfills.
+	Finish(ctx context.Context, id int64, status domain.HistoryBackfillStatus, errMsg string) error
+	// UsedBudget sums the budget the user spent since the given time (active backfills count their whole budget).
+	UsedBudget(ctx context.Context, userID string, since time.Time) (int, error)
+}
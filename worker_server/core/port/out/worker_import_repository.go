@@ -0,0 +1,26 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// MailImportRepository defines the outbound port for MBOX/EML import jobs.
+type MailImportRepository interface {
+	// Create stores a new import job together with the uploaded source.
+	Create(ctx context.Context, job *domain.MailImport, source []byte) error
+	GetByID(ctx context.Context, userID uuid.UUID, id int64) (*domain.MailImport, error)
+	ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.MailImport, error)
+
+	// GetSource returns the uploaded archive (nil after ClearSource).
+	GetSource(ctx context.Context, id int64) ([]byte, error)
+	ClearSource(ctx context.Context, id int64) error
+
+	// 진행 상태 갱신
+	MarkProcessing(ctx context.Context, id int64, connectionID int64) error
+	UpdateProgress(ctx context.Context, id int64, total, imported, skipped, failed int) error
+	MarkFinished(ctx context.Context, id int64, status domain.MailImportStatus, errMsg string) error
+}
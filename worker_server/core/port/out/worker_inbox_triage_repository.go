@@ -0,0 +1,21 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// InboxTriageRepository defines the outbound port for inbox triage proposals.
+type InboxTriageRepository interface {
+	Create(ctx context.Context, triage *domain.InboxTriage) error
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*domain.InboxTriage, error)
+
+	// MarkQueued confirms a proposed triage with the actions to run.
+	// 이미 확인된 제안이면 false를 돌려준다 (중복 확인 방지).
+	MarkQueued(ctx context.Context, id uuid.UUID, actions []*domain.TriageAction) (bool, error)
+	MarkRunning(ctx context.Context, id uuid.UUID) error
+	Finish(ctx context.Context, id uuid.UUID, status domain.TriageStatus, results []*domain.TriageActionResult, errMsg string) error
+}
@@ -0,0 +1,25 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// JobRepository stores background job state.
+// 상태 전이 메서드는 이미 끝난(succeeded/failed) 작업을 바꾸지 않는다.
+type JobRepository interface {
+	Create(ctx context.Context, job *domain.BackgroundJob) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.BackgroundJob, error)
+	// GetLatest returns the most recently created job of jobType for a connection.
+	GetLatest(ctx context.Context, userID uuid.UUID, jobType domain.BackgroundJobType, connectionID int64) (*domain.BackgroundJob, error)
+
+	// MarkRunning sets state=running and started_at (first call only).
+	MarkRunning(ctx context.Context, id uuid.UUID) error
+	// AddProgress adds processed counts and finishes the job when done+failed reaches total.
+	AddProgress(ctx context.Context, id uuid.UUID, done, failed int) error
+	// Finish sets the terminal state. errMsg is stored for failed jobs.
+	Finish(ctx context.Context, id uuid.UUID, state domain.JobState, errMsg string) error
+}
@@ -0,0 +1,26 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// MailboxStatsRepository defines the outbound port for the mailbox analytics aggregate tables.
+type MailboxStatsRepository interface {
+	// StaleUsers returns users not aggregated within the interval (한 번도 집계하지 않은 사용자 먼저).
+	StaleUsers(ctx context.Context, interval time.Duration, limit int) ([]uuid.UUID, error)
+	// Aggregate folds emails, attachments and reads added since the last run into the aggregate tables.
+	// 분류가 끝나도록 settle보다 최근에 저장된 메일은 다음 실행으로 미룬다. batchSize는 한 번에 처리할 메일 수다.
+	Aggregate(ctx context.Context, userID uuid.UUID, settle time.Duration, batchSize int) error
+
+	// HourlyStats returns the hour buckets in [from, to).
+	HourlyStats(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.MailboxHourlyStat, error)
+	TopSenders(ctx context.Context, userID uuid.UUID, limit int) ([]domain.SenderStat, error)
+	Categories(ctx context.Context, userID uuid.UUID) ([]*domain.CategoryStat, error)
+	// Totals returns nil if the user has not been aggregated yet.
+	Totals(ctx context.Context, userID uuid.UUID) (*domain.MailboxStatsTotals, error)
+}
@@ -16,8 +16,11 @@ type MessageProducer interface {
 	PublishMailSyncInit(ctx context.Context, job *MailSyncInitJob) error
 	PublishMailSyncPage(ctx context.Context, job *MailSyncPageJob) error
 	PublishMailBatch(ctx context.Context, job *MailBatchJob) error
-	PublishMailSave(ctx context.Context, job *MailSaveJob) error     // 메타데이터 저장 (비동기)
-	PublishMailModify(ctx context.Context, job *MailModifyJob) error // Provider 상태 동기화 (비동기)
+	PublishMailSave(ctx context.Context, job *MailSaveJob) error         // 메타데이터 저장 (비동기)
+	PublishMailModify(ctx context.Context, job *MailModifyJob) error     // Provider 상태 동기화 (비동기)
+	PublishMailImport(ctx context.Context, job *MailImportJob) error     // MBOX/EML 가져오기
+	PublishMailCampaign(ctx context.Context, job *MailCampaignJob) error // 메일 머지 캠페인 발송
+	PublishMailTriage(ctx context.Context, job *MailTriageJob) error     // 받은편지함 정리 제안 실행
 
 	// Calendar jobs
 	PublishCalendarSync(ctx context.Context, job *CalendarSyncJob) error
@@ -41,6 +44,9 @@ type MessageProducer interface {
 	// Profile jobs
 	PublishProfileAnalyze(ctx context.Context, job *ProfileAnalyzeJob) error
 
+	// Contact jobs
+	PublishContactEnrich(ctx context.Context, job *ContactEnrichJob) error // 중요 연락처 프로필 보강
+
 	// Priority jobs
 	PublishPriority(ctx context.Context, stream string, job interface{}) error
 
@@ -74,6 +80,7 @@ type MailSyncJob struct {
 	PageToken    string `json:"page_token,omitempty"`
 	HistoryID    uint64 `json:"history_id,omitempty"` // Gmail Pub/Sub delta sync용
 	Background   bool   `json:"background,omitempty"` // 백그라운드 점진적 동기화
+	JobID        string `json:"job_id,omitempty"`     // background_jobs 추적 ID (API 요청 시)
 }
 
 // MailBatchJob represents mail batch job.
@@ -125,6 +132,27 @@ type MailModifyJob struct {
 	TargetFolder string   `json:"target_folder"` // Target folder for move
 }
 
+// MailImportJob represents an MBOX/EML import job.
+// 원본 파일은 mail_imports 테이블에 있으므로 ID만 전달한다.
+type MailImportJob struct {
+	UserID   string `json:"user_id"`
+	ImportID int64  `json:"import_id"`
+}
+
+// MailCampaignJob represents a mail merge campaign send job.
+// 수신자 목록은 email_campaign_recipients 테이블에 있으므로 ID만 전달한다.
+type MailCampaignJob struct {
+	UserID     string `json:"user_id"`
+	CampaignID int64  `json:"campaign_id"`
+}
+
+// MailTriageJob represents a confirmed inbox triage job.
+// 실행할 작업 목록은 inbox_triages 테이블에 있으므로 ID만 전달한다.
+type MailTriageJob struct {
+	UserID   string `json:"user_id"`
+	TriageID string `json:"triage_id"`
+}
+
 // CalendarSyncJob represents calendar sync job.
 type CalendarSyncJob struct {
 	UserID       string `json:"user_id"`
@@ -179,18 +207,31 @@ type EventBatchDeleteJob struct {
 	EventIDs []int64 `json:"event_ids"`
 }
 
+// JobPriority is the processing tier of a job within the same job type.
+type JobPriority string
+
+const (
+	JobPriorityHigh   JobPriority = "high" // 실시간 수신 메일 (delta/gap sync)
+	JobPriorityNormal JobPriority = ""     // 기본 (초기 동기화 등)
+	JobPriorityLow    JobPriority = "low"  // backfill, 일괄 재분류, 가져오기
+)
+
 // AIClassifyJob represents AI classify job.
 type AIClassifyJob struct {
-	UserID        string   `json:"user_id"`
-	EmailID       int64    `json:"email_id"`
-	From          string   `json:"from,omitempty"`
-	FromName      string   `json:"from_name,omitempty"`
-	To            []string `json:"to,omitempty"`
-	Subject       string   `json:"subject,omitempty"`
-	Body          string   `json:"body,omitempty"`
-	Snippet       string   `json:"snippet,omitempty"`
-	HasAttachment bool     `json:"has_attachment,omitempty"`
-	IsReply       bool     `json:"is_reply,omitempty"`
+	UserID        string      `json:"user_id"`
+	EmailID       int64       `json:"email_id"`
+	From          string      `json:"from,omitempty"`
+	FromName      string      `json:"from_name,omitempty"`
+	To            []string    `json:"to,omitempty"`
+	Subject       string      `json:"subject,omitempty"`
+	Body          string      `json:"body,omitempty"`
+	Snippet       string      `json:"snippet,omitempty"`
+	HasAttachment bool        `json:"has_attachment,omitempty"`
+	IsReply       bool        `json:"is_reply,omitempty"`
+	JobID         string      `json:"job_id,omitempty"`   // background_jobs 추적 ID (재분류 요청 시)
+	Priority      JobPriority `json:"priority,omitempty"` // 발행 스트림 결정 (high: ai:priority, low: ai:classify:low)
+	Mode          string      `json:"mode,omitempty"`     // 실패 재처리: llm, heuristic (비어 있으면 일반 배치 분류)
+	Model         string      `json:"model,omitempty"`    // 실패 재처리에 쓸 LLM 모델
 }
 
 // AIBatchClassifyJob represents AI batch classify job for multiple emails.
@@ -198,6 +239,7 @@ type AIBatchClassifyJob struct {
 	UserID   string            `json:"user_id"`
 	EmailIDs []int64           `json:"email_ids"`
 	Emails   []AIClassifyEmail `json:"emails,omitempty"` // 이미 로드된 이메일 정보 (DB 재조회 방지)
+	Priority JobPriority       `json:"priority,omitempty"`
 }
 
 // AIClassifyEmail represents email data for batch classification.
@@ -304,6 +346,13 @@ type ProfileAnalyzeJob struct {
 	SampleSize   int    `json:"sample_size"` // Number of sent emails to analyze (default: 50)
 }
 
+// ContactEnrichJob represents a contact enrichment job for a newly important contact.
+type ContactEnrichJob struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name,omitempty"`
+}
+
 // =============================================================================
 // Sync Status
 // =============================================================================
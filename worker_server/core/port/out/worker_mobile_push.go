@@ -0,0 +1,30 @@
+package out
+
+import (
+	"context"
+	"errors"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ErrPushTokenInvalid is returned when the push service rejects a device token permanently
+// (앱 삭제, 토큰 만료 등). 호출자는 해당 디바이스를 삭제한다.
+var ErrPushTokenInvalid = errors.New("push token is no longer valid")
+
+// MobilePushPort sends mobile push notifications through one push service (FCM, APNs).
+type MobilePushPort interface {
+	Platform() domain.PushPlatform
+	Send(ctx context.Context, token string, msg *domain.PushMessage) error
+}
+
+// PushDeviceRepository stores users' registered push devices.
+type PushDeviceRepository interface {
+	// Register upserts a device by token (같은 토큰이 다른 사용자로 다시 등록되면 소유자를 옮긴다).
+	Register(ctx context.Context, device *domain.PushDevice) error
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*domain.PushDevice, error)
+	Delete(ctx context.Context, userID uuid.UUID, token string) error
+	// DeleteByToken removes a token rejected by the push service.
+	DeleteByToken(ctx context.Context, token string) error
+}
@@ -0,0 +1,32 @@
+package out
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ErrOutboxNotFound is returned when the outbox message does not exist or does not belong to the user.
+var ErrOutboxNotFound = errors.New("outbox message not found")
+
+// OutboxRepository defines the outbound port for the durable send queue.
+type OutboxRepository interface {
+	// Enqueue stores a queued message and sets its ID.
+	Enqueue(ctx context.Context, msg *domain.OutboxMessage) error
+	// List returns the user's messages without payload, newest first. 빈 statuses는 발송 완료 제외.
+	List(ctx context.Context, userID uuid.UUID, statuses []string, limit int) ([]*domain.OutboxMessage, error)
+	// ClaimDue marks due queued messages (and stale sending ones) as sending and returns them with payload.
+	ClaimDue(ctx context.Context, limit int) ([]*domain.OutboxMessage, error)
+	// Claim marks one queued/failed message of the user as sending. 다른 상태면 false.
+	Claim(ctx context.Context, userID uuid.UUID, id int64) (*domain.OutboxMessage, bool, error)
+	MarkSent(ctx context.Context, id int64, providerID string, sentAt time.Time) error
+	// Reschedule records a failed attempt and queues the next one at nextAttemptAt.
+	Reschedule(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, id int64, lastError string) error
+	// Cancel cancels a queued/failed message of the user. 다른 상태면 false.
+	Cancel(ctx context.Context, userID uuid.UUID, id int64) (*domain.OutboxMessage, bool, error)
+}
@@ -0,0 +1,17 @@
+package out
+
+import "context"
+
+type primaryReadKey struct{}
+
+// WithPrimaryRead marks reads in ctx to skip read replicas.
+// 방금 쓴 데이터를 바로 읽어야 하는 경우 (동기화 직후 캐시 워밍 등) 사용한다.
+func WithPrimaryRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadKey{}, true)
+}
+
+// PrimaryReadRequired reports whether ctx was marked with WithPrimaryRead.
+func PrimaryReadRequired(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryReadKey{}).(bool)
+	return v
+}
@@ -0,0 +1,24 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// URLReputationChecker checks link destinations against blocklists (Safe Browsing 등).
+type URLReputationChecker interface {
+	// CheckURLs returns a verdict for each URL, keyed by the input URL.
+	// 판정할 수 없는 URL은 결과에서 빠질 수 있다.
+	CheckURLs(ctx context.Context, urls []string) (map[string]*domain.URLReputation, error)
+}
+
+// LinkClickRepository defines the outbound port for link click analytics.
+type LinkClickRepository interface {
+	Record(ctx context.Context, click *domain.LinkClick) error
+	ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.LinkClick, error)
+	GetStats(ctx context.Context, userID uuid.UUID, since time.Time, topN int) (*domain.LinkClickStats, error)
+}
@@ -0,0 +1,22 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// SendTrackingRepository defines the outbound port for opt-in open/click tracking.
+type SendTrackingRepository interface {
+	Create(ctx context.Context, tracking *domain.SentTracking) error
+	// SetExternalID links the tracking record to the provider message after sending.
+	SetExternalID(ctx context.Context, id int64, externalID string) error
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*domain.SentTracking, error)
+	GetByExternalID(ctx context.Context, userID uuid.UUID, connectionID int64, externalID string) (*domain.SentTracking, error)
+
+	RecordEvent(ctx context.Context, event *domain.TrackingEvent) error
+	ListEvents(ctx context.Context, trackingID int64) ([]*domain.TrackingEvent, error)
+}
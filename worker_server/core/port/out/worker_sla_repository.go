@@ -0,0 +1,29 @@
+package out
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"worker_server/core/domain"
+)
+
+// ErrSLANotFound is returned when the org has no SLA for the category.
+var ErrSLANotFound = errors.New("sla not found")
+
+// SLARepository defines the outbound port for shared inbox SLAs.
+type SLARepository interface {
+	ListSLAs(ctx context.Context, orgID int64) ([]*domain.OrgSLA, error)
+	UpsertSLA(ctx context.Context, sla *domain.OrgSLA) error
+	DeleteSLA(ctx context.Context, orgID int64, category string) error
+
+	// Evaluate recomputes the SLA state of recent emails in shared mailboxes with an SLA
+	// and returns the number of emails whose state changed.
+	Evaluate(ctx context.Context, now time.Time) (int, error)
+	// PendingEscalations returns at_risk/breached emails not yet notified for their current state.
+	PendingEscalations(ctx context.Context, limit int) ([]*domain.SLAEscalation, error)
+	MarkNotified(ctx context.Context, emailID int64, status domain.SLAStatus) error
+
+	// StatusByEmailIDs returns the SLA state of the given emails (목록 응답의 sla_status).
+	StatusByEmailIDs(ctx context.Context, emailIDs []int64) (map[int64]domain.SLAStatus, error)
+}
@@ -0,0 +1,17 @@
+package out
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrUnsupportedVoice is returned when the speech provider does not have the requested voice.
+var ErrUnsupportedVoice = errors.New("unsupported voice")
+
+// SpeechSynthesizer converts text to audio (TTS).
+type SpeechSynthesizer interface {
+	// Synthesize streams the audio of text and returns its MIME type. The caller closes the reader.
+	// voice가 비어있으면 provider 기본 음성을 사용한다.
+	Synthesize(ctx context.Context, text, voice string) (audio io.ReadCloser, mimeType string, err error)
+}
@@ -0,0 +1,20 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/domain"
+)
+
+// SyncLagRepository defines the outbound port for sync lag measurements.
+type SyncLagRepository interface {
+	// LatestStoredAt returns the newest stored email date of a connection (메일이 없으면 zero).
+	LatestStoredAt(ctx context.Context, connectionID int64) (time.Time, error)
+	// Get returns the last measurement (없으면 nil).
+	Get(ctx context.Context, connectionID int64) (*domain.SyncLag, error)
+	// Save upserts a measurement including alerted_at/remediated_at.
+	Save(ctx context.Context, lag *domain.SyncLag) error
+	// ListConnections lists sync states with their lag, most lagging first.
+	ListConnections(ctx context.Context, minLagSeconds int64, limit, offset int) ([]*domain.ConnectionSyncLag, int, error)
+}
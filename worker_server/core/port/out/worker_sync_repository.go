@@ -23,7 +23,14 @@ type SyncStateRepository interface {
 	// Watch 관리
 	// ==========================================================================
 	GetExpiredWatches(ctx context.Context, before time.Time) ([]*domain.SyncState, error)
+	// UpdateWatchExpiry는 갱신 성공으로 보고 실패 카운터/알림 상태를 초기화한다
 	UpdateWatchExpiry(ctx context.Context, connectionID int64, expiry time.Time, resourceID string) error
+	// RecordWatchFailure - 갱신 실패 기록 (status=watch_expired), 누적 연속 실패 수 반환
+	RecordWatchFailure(ctx context.Context, connectionID int64, retryAt time.Time, lastError string) (int, error)
+	// MarkWatchEscalated - 푸시 → 폴링 전환 알림 발송 기록
+	MarkWatchEscalated(ctx context.Context, connectionID int64, at time.Time) error
+	// CountWatches - 상태별 watch 수 (expiringWithin 안에 만료 예정인 수 포함)
+	CountWatches(ctx context.Context, expiringWithin time.Duration) (*domain.WatchStats, error)
 
 	// ==========================================================================
 	// Gap Sync 관리 (Phase 2)
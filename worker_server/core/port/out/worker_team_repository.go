@@ -0,0 +1,38 @@
+package out
+
+import (
+	"context"
+	"errors"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrTeamNotFound is returned when the org, member, mailbox or shared email is not visible to the user.
+	ErrTeamNotFound = errors.New("team resource not found")
+	// ErrTeamUserNotFound is returned when no user has the given email address.
+	ErrTeamUserNotFound = errors.New("user not found")
+)
+
+// TeamRepository defines the outbound port for orgs, members and shared mailboxes.
+type TeamRepository interface {
+	// CreateOrg creates an org with its owner as the first member.
+	CreateOrg(ctx context.Context, org *domain.Org) error
+	ListOrgs(ctx context.Context, userID uuid.UUID) ([]*domain.Org, error)
+	// MemberRole returns the user's role in the org (ErrTeamNotFound if not a member).
+	MemberRole(ctx context.Context, orgID int64, userID uuid.UUID) (domain.OrgRole, error)
+
+	ListMembers(ctx context.Context, orgID int64) ([]*domain.OrgMember, error)
+	AddMemberByEmail(ctx context.Context, orgID int64, email string, role domain.OrgRole) (*domain.OrgMember, error)
+	RemoveMember(ctx context.Context, orgID int64, userID uuid.UUID) error
+
+	ListMailboxes(ctx context.Context, orgID int64) ([]*domain.OrgMailbox, error)
+	// ShareMailbox shares a connection owned by userID (ErrTeamNotFound otherwise).
+	ShareMailbox(ctx context.Context, orgID int64, userID uuid.UUID, connectionID int64) (*domain.OrgMailbox, error)
+	UnshareMailbox(ctx context.Context, orgID, connectionID int64) error
+
+	// EmailOrg returns the org through which the user sees the email (공유 메일함 + 멤버십).
+	EmailOrg(ctx context.Context, userID uuid.UUID, emailID int64) (int64, error)
+}
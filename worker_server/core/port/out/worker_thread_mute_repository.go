@@ -0,0 +1,16 @@
+package out
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// ThreadMuteRepository defines the outbound port for muted threads (연결별 Provider 스레드 ID).
+type ThreadMuteRepository interface {
+	Mute(ctx context.Context, userID uuid.UUID, connectionID int64, threadID string) error
+	// Unmute returns false if the thread was not muted.
+	Unmute(ctx context.Context, userID uuid.UUID, threadID string) (bool, error)
+	// MutedThreads returns which of the given threads of a connection are muted.
+	MutedThreads(ctx context.Context, connectionID int64, threadIDs []string) (map[string]bool, error)
+}
@@ -0,0 +1,10 @@
+package out
+
+import "context"
+
+// Translator translates email content into another language (LLM, 번역 API 등).
+type Translator interface {
+	// Translate returns text translated into targetLang (ISO 639-1).
+	// isHTML이면 태그, 링크, 이미지는 그대로 두고 텍스트만 번역한다.
+	Translate(ctx context.Context, text, targetLang string, isHTML bool) (string, error)
+}
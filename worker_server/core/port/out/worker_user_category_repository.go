@@ -0,0 +1,22 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// UserCategoryRepository persists per-user custom categories and built-in overrides.
+type UserCategoryRepository interface {
+	// List returns all stored categories of the user.
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.UserCategory, error)
+
+	// Upsert creates or replaces a category (user_id, key).
+	Upsert(ctx context.Context, category *domain.UserCategory) error
+
+	// Delete removes a category. reassignTo가 비어 있지 않으면 그 카테고리의 메일을 같은 트랜잭션에서 옮긴다.
+	// Returns false when nothing was deleted.
+	Delete(ctx context.Context, userID uuid.UUID, key string, reassignTo domain.EmailCategory) (bool, error)
+}
@@ -0,0 +1,13 @@
+package out
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// UserTierRepository defines the outbound port for user plan tiers (free/pro).
+type UserTierRepository interface {
+	// GetTier returns the tier name of the user ("free" if the user is unknown).
+	GetTier(ctx context.Context, userID uuid.UUID) (string, error)
+}
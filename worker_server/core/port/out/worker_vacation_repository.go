@@ -0,0 +1,18 @@
+package out
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/domain"
+)
+
+// VacationRepository defines the outbound port for vacation responders.
+type VacationRepository interface {
+	Get(ctx context.Context, connectionID int64) (*domain.VacationResponder, error)
+	// Upsert saves the responder. 꺼져 있다가 켜지면 enabled_at을 갱신한다.
+	Upsert(ctx context.Context, responder *domain.VacationResponder) error
+	// TryRecordReply records an auto-reply to sender unless one was already sent after since.
+	// Returns false when the sender was already answered.
+	TryRecordReply(ctx context.Context, connectionID int64, sender string, since time.Time) (bool, error)
+}
@@ -4,6 +4,8 @@ package out
 import (
 	"context"
 	"time"
+
+	"worker_server/core/domain"
 )
 
 // =============================================================================
@@ -59,6 +61,61 @@ type VectorItem struct {
 	Metadata  map[string]interface{}
 }
 
+// =============================================================================
+// VectorStorePort (RAG 이메일 임베딩: pgvector / Qdrant / Milvus)
+// =============================================================================
+
+// VectorStorePort stores email embeddings and answers similarity queries.
+// 구현체는 VECTOR_STORE 설정으로 선택한다: pgvector (emails.embedding), qdrant, milvus.
+type VectorStorePort interface {
+	Store(ctx context.Context, record *EmailVector) error
+	StoreBatch(ctx context.Context, records []*EmailVector) error
+	Search(ctx context.Context, embedding []float32, opts *EmailVectorQuery) ([]*EmailVectorMatch, error)
+	Delete(ctx context.Context, emailID int64) error
+	HasEmbedding(ctx context.Context, emailID int64) (bool, error)
+
+	// Get returns the stored vector of an email, or nil if it has not been indexed.
+	Get(ctx context.Context, emailID int64) (*EmailVector, error)
+
+	// Scan returns up to limit stored vectors with email ID > afterID in ascending order.
+	// 백엔드 간 마이그레이션용: 마지막 email ID를 다음 afterID로 넘겨 전체를 순회한다.
+	Scan(ctx context.Context, afterID int64, limit int) ([]*EmailVector, error)
+}
+
+// EmailVector is the embedding of an email with the fields needed to filter and display results.
+type EmailVector struct {
+	ID        int64
+	EmailID   int64
+	UserID    string
+	Direction string // inbound, outbound
+	Embedding []float32
+	Content   string
+	Metadata  map[string]any
+}
+
+// EmailVectorQuery filters a similarity search.
+type EmailVectorQuery struct {
+	UserID       string
+	SentOnly     bool // outbound only (for style learning)
+	ReceivedOnly bool // inbound only
+	AllEmails    bool // both directions
+	Limit        int
+	MinScore     float64
+
+	// Scope (search v2): pgvector는 emails 컬럼, 외부 저장소는 색인 시 metadata의 thread_id/folder_id/label_ids로 거른다
+	ThreadID string  // Provider 스레드 ID
+	FolderID *int64  // folders.id
+	LabelIDs []int64 // 하나라도 일치
+}
+
+// EmailVectorMatch is a similarity search hit (Score: cosine similarity, 1 = identical).
+type EmailVectorMatch struct {
+	EmailID  int64
+	Score    float64
+	Content  string
+	Metadata map[string]any
+}
+
 // =============================================================================
 // PersonalizationStore (Neo4j)
 // =============================================================================
@@ -89,7 +146,10 @@ type PersonalizationStore interface {
 
 	// Signatures
 	GetSignatures(ctx context.Context, userID string) ([]*Signature, error)
+	SaveSignature(ctx context.Context, userID string, signature *Signature) error
+	DeleteSignature(ctx context.Context, userID string, signatureID string) error
 	SetDefaultSignature(ctx context.Context, userID string, signatureID string) error
+	SetConnectionSignature(ctx context.Context, userID string, connectionID int64, signatureID string) error
 }
 
 // UserProfile represents user profile for personalization.
@@ -136,9 +196,13 @@ type FrequentPhrase struct {
 
 // Signature represents an email signature.
 type Signature struct {
-	ID        string `json:"id"`
-	Text      string `json:"text"`
-	IsDefault bool   `json:"is_default"`
+	ID            string    `json:"id"`
+	Name          string    `json:"name,omitempty"`
+	Text          string    `json:"text"`                     // plain text 버전
+	HTML          string    `json:"html,omitempty"`           // HTML 버전 (없으면 text로 생성)
+	IsDefault     bool      `json:"is_default"`               // 사용자 기본 서명
+	ConnectionIDs []int64   `json:"connection_ids,omitempty"` // 이 서명을 기본으로 쓰는 계정
+	UpdatedAt     time.Time `json:"updated_at,omitempty"`
 }
 
 // =============================================================================
@@ -214,6 +278,12 @@ type ContactRelationship struct {
 	IsFrequent      bool    `json:"is_frequent"`
 	IsImportant     bool    `json:"is_important"`
 
+	// Enrichment (Contact 노드에 저장, 외부 프로필 조회 결과)
+	Company    string     `json:"company,omitempty"`
+	JobTitle   string     `json:"job_title,omitempty"`
+	AvatarURL  string     `json:"avatar_url,omitempty"`
+	EnrichedAt *time.Time `json:"enriched_at,omitempty"`
+
 	// Activity status
 	IsActive         bool      `json:"is_active"` // had contact in last 90 days
 	LastActivityDate time.Time `json:"last_activity_date"`
@@ -270,6 +340,10 @@ type ExtendedPersonalizationStore interface {
 	UpsertContactRelationship(ctx context.Context, userID string, rel *ContactRelationship) error
 	GetFrequentContacts(ctx context.Context, userID string, limit int) ([]*ContactRelationship, error)
 	GetImportantContacts(ctx context.Context, userID string, limit int) ([]*ContactRelationship, error)
+	// SetContactImportant marks or unmarks a contact as VIP (관계가 없으면 만든다).
+	SetContactImportant(ctx context.Context, userID, contactEmail, contactName string, important bool) error
+	// SetContactEnrichment stores company, title and avatar on the Contact node (빈 값은 기존 값 유지).
+	SetContactEnrichment(ctx context.Context, contactEmail string, enrichment *domain.ContactEnrichment) error
 
 	// Communication patterns
 	GetCommunicationPatterns(ctx context.Context, userID string, patternType string, limit int) ([]*CommunicationPattern, error)
@@ -0,0 +1,27 @@
+package out
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// WorkflowRepository defines the outbound port for workflow board stages and card order.
+type WorkflowRepository interface {
+	ListStages(ctx context.Context, userID uuid.UUID) ([]*domain.WorkflowStage, error)
+	// CreateDefaultStages inserts stages only if the user has none yet.
+	CreateDefaultStages(ctx context.Context, userID uuid.UUID, stages []*domain.WorkflowStage) error
+	CreateStage(ctx context.Context, stage *domain.WorkflowStage) error
+	UpdateStage(ctx context.Context, stage *domain.WorkflowStage) error
+	DeleteStage(ctx context.Context, userID uuid.UUID, stageID int64) error
+	// ReorderStages sets stage positions to the order of stageIDs.
+	ReorderStages(ctx context.Context, userID uuid.UUID, stageIDs []int64) error
+
+	// ListCards returns the cards of a stage (배치된 카드 먼저, 그다음 우선순위/날짜 순).
+	// includeUnplaced: 카드가 없는 같은 상태의 메일도 이 단계에 보여준다 (상태별 첫 단계).
+	ListCards(ctx context.Context, userID uuid.UUID, stage *domain.WorkflowStage, includeUnplaced bool, connectionID *int64, limit int) ([]*domain.WorkflowCard, int, error)
+	// PlaceCards moves emails into the stage in the given order and returns the IDs owned by the user.
+	PlaceCards(ctx context.Context, userID uuid.UUID, stageID int64, emailIDs []int64) ([]int64, error)
+}
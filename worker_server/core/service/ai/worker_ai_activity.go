@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+)
+
+// SetActivityRepository records classifications and AI jobs in the per-email timeline.
+func (s *Service) SetActivityRepository(repo out.EmailActivityRepository) {
+	s.activityRepo = repo
+}
+
+// recordClassified records the classification scores; 사용자 규칙으로 분류되면 rule_applied도 남긴다.
+func (s *Service) recordClassified(ctx context.Context, email *domain.Email, result *domain.ClassificationResult, llmUsed bool) {
+	detail := map[string]any{
+		"category":   string(*result.Category),
+		"priority":   float64(*result.Priority),
+		"source":     string(result.Source),
+		"stage":      string(result.Stage),
+		"confidence": result.Score,
+		"llm_used":   llmUsed,
+	}
+	if result.SubCategory != nil {
+		detail["sub_category"] = string(*result.SubCategory)
+	}
+	activities := []*domain.EmailActivity{{
+		EmailID: email.ID, UserID: email.UserID, Type: domain.ActivityClassified, Source: domain.ActivitySourceAI, Detail: detail,
+	}}
+	if result.Stage == domain.ClassificationStageRule {
+		activities = append(activities, &domain.EmailActivity{
+			EmailID: email.ID, UserID: email.UserID, Type: domain.ActivityRuleApplied, Source: domain.ActivitySourceAI,
+			Detail: map[string]any{"rule": "classification_rule", "category": string(*result.Category)},
+		})
+	}
+	s.recordActivity(ctx, activities...)
+}
+
+// recordAIJob records an LLM job (summary, reply draft, meeting extraction) run on an email.
+func (s *Service) recordAIJob(ctx context.Context, email *domain.Email, job string) {
+	s.recordActivity(ctx, &domain.EmailActivity{
+		EmailID: email.ID, UserID: email.UserID, Type: domain.ActivityAIJob, Source: domain.ActivitySourceAI,
+		Detail: map[string]any{"job": job},
+	})
+}
+
+// recordActivity saves activities best-effort.
+func (s *Service) recordActivity(ctx context.Context, activities ...*domain.EmailActivity) {
+	if s.activityRepo == nil {
+		return
+	}
+	if err := s.activityRepo.Record(ctx, activities...); err != nil {
+		logger.Warn("[AIService] Failed to record email activity: %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"worker_server/core/agent/llm"
+	"worker_server/core/port/in"
+	"worker_server/core/service/search"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAskSources = 6
+	// askBodyChars - 근거 메일 하나당 프롬프트에 넣을 본문 길이
+	askBodyChars = 1500
+)
+
+// askCitationPattern matches citations like [#123] in the answer.
+var askCitationPattern = regexp.MustCompile(`\[#(\d+)\]`)
+
+const askPrompt = `You answer questions about the user's own mailbox using ONLY the emails below.
+Rules:
+- After every fact taken from an email, cite it as [#<email_id>] (e.g. [#1234]).
+- If the emails do not contain the answer, say so briefly. Never guess numbers, dates or codes.
+- Answer in the same language as the question, concisely.
+- Today is %s (%s). Resolve relative dates ("next week", "다음 주") against it.
+
+Emails:
+%s
+Question: %s`
+
+// SetSearchService enables Ask (mailbox question answering).
+func (s *Service) SetSearchService(svc *search.Service) {
+	s.searchService = svc
+}
+
+// Ask retrieves emails relevant to the question and streams an answer grounded in them.
+// onSources는 LLM 호출 전에 근거 메일 목록으로 한 번 호출되며, 반환값의 Citations는 답변에서 실제로 인용된 메일 ID다.
+func (s *Service) Ask(ctx context.Context, userID uuid.UUID, req *in.AskRequest, onSources func([]*in.AskSource) error, handler in.StreamHandler) (*in.AskResult, error) {
+	if s.llmClient == nil {
+		return nil, ErrLLMNotConfigured
+	}
+	if s.searchService == nil || s.emailRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	ctx = llm.WithUsageScope(ctx, llm.UsageScope{UserID: userID, Task: llm.TaskChat})
+
+	limit := req.MaxSources
+	if limit <= 0 {
+		limit = defaultAskSources
+	}
+
+	resp, err := s.searchService.Search(ctx, &search.SearchRequest{
+		UserID:   userID,
+		Query:    req.Question,
+		Strategy: search.StrategyBalanced,
+		Limit:    limit,
+	}, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+
+	sources, emailContext := s.askContext(userID, resp.Results, limit)
+	if onSources != nil {
+		if err := onSources(sources); err != nil {
+			return nil, err
+		}
+	}
+
+	loc := time.UTC
+	if req.Timezone != "" {
+		if l, err := time.LoadLocation(req.Timezone); err == nil {
+			loc = l
+		}
+	}
+	now := time.Now().In(loc)
+	if emailContext == "" {
+		emailContext = "(no matching emails)\n"
+	}
+	prompt := fmt.Sprintf(askPrompt, now.Format("2006-01-02 Monday"), loc.String(), emailContext, req.Question)
+
+	var answer strings.Builder
+	err = s.llmClient.Stream(ctx, prompt, func(chunk string) error {
+		answer.WriteString(chunk)
+		return handler(chunk)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &in.AskResult{Citations: askCitations(answer.String(), sources)}, nil
+}
+
+// askContext loads the searched emails (본문 포함) and formats them for the prompt.
+func (s *Service) askContext(userID uuid.UUID, results []*search.SearchResult, limit int) ([]*in.AskSource, string) {
+	sources := make([]*in.AskSource, 0, limit)
+	var b strings.Builder
+	seen := make(map[int64]bool)
+
+	for _, r := range results {
+		if len(sources) >= limit {
+			break
+		}
+		// provider 결과는 아직 저장되지 않은 메일이라 ID가 없다
+		if r.EmailID == 0 || seen[r.EmailID] {
+			continue
+		}
+		seen[r.EmailID] = true
+
+		email, err := s.emailRepo.GetByID(r.EmailID)
+		if err != nil || email == nil || email.UserID != userID {
+			continue
+		}
+
+		text := email.Snippet
+		if body, err := s.emailRepo.GetBody(email.ID); err == nil && body != nil && body.TextBody != "" {
+			text = body.TextBody
+		}
+		if runes := []rune(text); len(runes) > askBodyChars {
+			text = string(runes[:askBodyChars]) + "..."
+		}
+
+		sources = append(sources, &in.AskSource{
+			EmailID:   email.ID,
+			Subject:   email.Subject,
+			FromEmail: email.FromEmail,
+			Date:      email.Date,
+			Score:     r.Score,
+		})
+		fmt.Fprintf(&b, "[#%d] From: %s | Date: %s | Subject: %s\n%s\n---\n",
+			email.ID, email.FromEmail, email.Date.Format("2006-01-02 15:04"), email.Subject, text)
+	}
+	return sources, b.String()
+}
+
+// askCitations returns the source email IDs cited in answer, in order of first appearance.
+// 검색 결과에 없는 ID는 모델이 지어낸 것이므로 제외한다.
+func askCitations(answer string, sources []*in.AskSource) []int64 {
+	valid := make(map[int64]bool, len(sources))
+	for _, src := range sources {
+		valid[src.EmailID] = true
+	}
+
+	citations := []int64{}
+	seen := make(map[int64]bool)
+	for _, m := range askCitationPattern.FindAllStringSubmatch(answer, -1) {
+		id, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil || !valid[id] || seen[id] {
+			continue
+		}
+		seen[id] = true
+		citations = append(citations, id)
+	}
+	return citations
+}
@@ -195,6 +195,7 @@ func (s *OptimizedService) ClassifyEmailBatch(ctx context.Context, emailIDs []in
 		if s.domainRepo != nil {
 			if body, err := s.domainRepo.GetBody(id); err == nil && body != nil {
 				snippet = llm.CleanEmailBody(body.TextBody)
+				email.Language = emailLanguage(email.Subject, snippet)
 				if len(snippet) > 500 {
 					snippet = snippet[:500]
 				}
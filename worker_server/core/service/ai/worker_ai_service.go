@@ -13,7 +13,10 @@ import (
 	"worker_server/core/agent/tools"
 	"worker_server/core/domain"
 	"worker_server/core/port/in"
+	"worker_server/core/port/out"
 	"worker_server/core/service/classification"
+	"worker_server/core/service/search"
+	"worker_server/pkg/langdetect"
 	"worker_server/pkg/logger"
 
 	"github.com/google/uuid"
@@ -46,6 +49,9 @@ type Service struct {
 	ragIndexer             *rag.IndexerService
 	toolRegistry           *tools.Registry
 	classificationPipeline *classification.Pipeline
+	securityRepo           out.EmailSecurityRepository
+	searchService          *search.Service
+	activityRepo           out.EmailActivityRepository // optional: per-email activity timeline
 }
 
 func NewService(
@@ -71,6 +77,11 @@ func (s *Service) SetClassificationPipeline(pipeline *classification.Pipeline) {
 	s.classificationPipeline = pipeline
 }
 
+// SetSecurityRepository enables the phishing/spoofing stage during classification.
+func (s *Service) SetSecurityRepository(repo out.EmailSecurityRepository) {
+	s.securityRepo = repo
+}
+
 // ClassifyEmail classifies an email using the 4-stage classification pipeline.
 // Stage 0: User Rules → Stage 1: Headers → Stage 2: Domain → Stage 3: LLM
 // This saves ~75% of LLM API costs.
@@ -79,56 +90,25 @@ func (s *Service) ClassifyEmail(ctx context.Context, emailID int64) (*domain.Cla
 		return nil, ErrRepoNotInitialized
 	}
 
-	// 1. Get email
-	email, err := s.emailRepo.GetByID(emailID)
+	// 1. Get email + body (from cache/mongodb)
+	email, body, htmlBody, err := s.loadForClassification(emailID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get email: %w", err)
-	}
-
-	// 2. Get email body (from cache/mongodb)
-	body := ""
-	if emailBody, err := s.emailRepo.GetBody(emailID); err == nil && emailBody != nil {
-		body = emailBody.TextBody
+		return nil, err
 	}
+	ctx = usageContext(ctx, email, llm.TaskClassify)
 
-	// 3. Use 4-stage classification pipeline if available
+	// 2. Use 4-stage classification pipeline if available
 	if s.classificationPipeline != nil {
-		// Build classification input
-		input := &classification.ClassifyInput{
+		pipelineResult, err := s.classificationPipeline.Classify(ctx, &classification.ClassifyInput{
 			UserID:  email.UserID,
 			Email:   email,
 			Headers: nil, // Headers will be populated during sync from provider
 			Body:    body,
-		}
-
-		pipelineResult, err := s.classificationPipeline.Classify(ctx, input)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("classification pipeline failed: %w", err)
 		}
-
-		// Update sender profile for learning
-		s.classificationPipeline.UpdateSenderProfile(ctx, email.UserID, email, pipelineResult)
-
-		// Save classification result to email
-		email.AICategory = &pipelineResult.Category
-		email.AIPriority = &pipelineResult.Priority
-		email.AISubCategory = pipelineResult.SubCategory
-		email.AIScore = &pipelineResult.Confidence
-		email.ClassificationSource = &pipelineResult.Source
-		if err := s.emailRepo.Update(email); err != nil {
-			logger.WithFields(map[string]any{"email_id": emailID, "error": err.Error()}).Warn("failed to save classification result")
-		}
-
-		// Convert pipeline result to domain result
-		result := &domain.ClassificationResult{
-			EmailID:     emailID,
-			Category:    &pipelineResult.Category,
-			Priority:    &pipelineResult.Priority,
-			SubCategory: pipelineResult.SubCategory,
-			Score:       pipelineResult.Confidence,
-			Source:      pipelineResult.Source,
-		}
-		return result, nil
+		return s.applyPipelineResult(ctx, email, body, htmlBody, pipelineResult), nil
 	}
 
 	// Fallback: Direct LLM classification (if pipeline not configured)
@@ -153,6 +133,7 @@ func (s *Service) ClassifyEmail(ctx context.Context, emailID int64) (*domain.Cla
 	category := domain.EmailCategory(llmResult.Category)
 	priority := domain.Priority(llmResult.Priority)
 	source := domain.ClassificationSourceLLM
+	stage := domain.ClassificationStageLLM
 
 	// Save classification result to email
 	email.AICategory = &category
@@ -160,25 +141,122 @@ func (s *Service) ClassifyEmail(ctx context.Context, emailID int64) (*domain.Cla
 	email.AITags = llmResult.Tags
 	email.AIScore = &llmResult.Score
 	email.ClassificationSource = &source
+	email.ClassificationStage = &stage
+	email.Language = emailLanguage(email.Subject, body)
 	if err := s.emailRepo.Update(email); err != nil {
 		logger.WithFields(map[string]any{"email_id": emailID}).WithError(err).Warn("failed to save classification result")
 	}
 
-	return &domain.ClassificationResult{
+	result := &domain.ClassificationResult{
 		EmailID:  emailID,
 		Category: &category,
 		Priority: &priority,
 		Tags:     llmResult.Tags,
 		Score:    llmResult.Score,
 		Source:   source,
-	}, nil
+		Stage:    stage,
+	}
+	s.recordClassified(ctx, email, result, true)
+	return result, nil
+}
+
+// usageContext attributes LLM calls made with the returned context to the email's user and connection.
+func usageContext(ctx context.Context, email *domain.Email, task llm.TaskType) context.Context {
+	return llm.WithUsageScope(ctx, llm.UsageScope{UserID: email.UserID, ConnectionID: email.ConnectionID, Task: task})
+}
+
+// loadForClassification loads an email with its text and HTML body.
+func (s *Service) loadForClassification(emailID int64) (*domain.Email, string, string, error) {
+	email, err := s.emailRepo.GetByID(emailID)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to get email: %w", err)
+	}
+
+	body := ""
+	htmlBody := ""
+	if emailBody, err := s.emailRepo.GetBody(emailID); err == nil && emailBody != nil {
+		body = emailBody.TextBody
+		htmlBody = emailBody.HTMLBody
+	}
+	return email, body, htmlBody, nil
+}
+
+// applyPipelineResult saves a pipeline result to the email and runs the security stage.
+func (s *Service) applyPipelineResult(ctx context.Context, email *domain.Email, body, htmlBody string, pipelineResult *domain.ClassificationPipelineResult) *domain.ClassificationResult {
+	// Update sender profile for learning
+	s.classificationPipeline.UpdateSenderProfile(ctx, email.UserID, email, pipelineResult)
+
+	// Save classification result to email
+	email.AICategory = &pipelineResult.Category
+	email.AIPriority = &pipelineResult.Priority
+	email.AISubCategory = pipelineResult.SubCategory
+	email.AIScore = &pipelineResult.Confidence
+	email.ClassificationSource = &pipelineResult.Source
+	email.ClassificationStage = &pipelineResult.Stage
+	email.Language = emailLanguage(email.Subject, body)
+	if err := s.emailRepo.Update(email); err != nil {
+		logger.WithFields(map[string]any{"email_id": email.ID, "error": err.Error()}).Warn("failed to save classification result")
+	}
+
+	// Convert pipeline result to domain result
+	result := &domain.ClassificationResult{
+		EmailID:     email.ID,
+		Category:    &pipelineResult.Category,
+		Priority:    &pipelineResult.Priority,
+		SubCategory: pipelineResult.SubCategory,
+		Score:       pipelineResult.Confidence,
+		Source:      pipelineResult.Source,
+		Stage:       pipelineResult.Stage,
+		Error:       pipelineResult.Error,
+	}
+	s.recordClassified(ctx, email, result, pipelineResult.LLMUsed)
+
+	// Security stage: 본문 URL 검사 후 동기화 시점 결과와 병합
+	if s.securityRepo != nil {
+		securityBody := htmlBody
+		if securityBody == "" {
+			securityBody = body
+		}
+		result.Security, result.SecurityEscalated = s.analyzeSecurity(ctx, email, securityBody)
+	}
+	return result
 }
 
-// ClassifyEmailBatch classifies multiple emails with concurrency control
+// analyzeSecurity runs the security stage, merges it with the stored analysis and saves it.
+// Returns whether the email newly became high risk.
+func (s *Service) analyzeSecurity(ctx context.Context, email *domain.Email, body string) (*domain.EmailSecurity, bool) {
+	sec := s.classificationPipeline.AnalyzeSecurity(&classification.ClassifyInput{
+		UserID: email.UserID,
+		Email:  email,
+		Body:   body,
+	})
+
+	wasHighRisk := false
+	if prev, err := s.securityRepo.GetByEmailID(ctx, email.ID); err == nil && prev != nil {
+		wasHighRisk = prev.IsHighRisk()
+		prev.Merge(sec)
+		sec = prev
+	}
+
+	if len(sec.Signals) == 0 && sec.SPF == "" && sec.DKIM == "" && sec.DMARC == "" {
+		return sec, false
+	}
+	if err := s.securityRepo.Save(ctx, email.UserID, sec); err != nil {
+		logger.WithFields(map[string]any{"email_id": email.ID}).WithError(err).Warn("failed to save security analysis")
+	}
+	return sec, sec.IsHighRisk() && !wasHighRisk
+}
+
+// ClassifyEmailBatch classifies multiple emails.
+// 파이프라인이 있으면 사용자별로 묶어 LLM 단계를 배치 요청으로 처리하고, 없으면 개별 LLM 호출을 병렬로 실행한다.
+// 분류에 실패한 이메일은 결과에서 빠진다.
 func (s *Service) ClassifyEmailBatch(ctx context.Context, emailIDs []int64) ([]*domain.ClassificationResult, error) {
 	if s.emailRepo == nil {
 		return nil, ErrRepoNotInitialized
 	}
+	if s.classificationPipeline != nil {
+		return s.classifyBatchWithPipeline(ctx, emailIDs), nil
+	}
 	if s.llmClient == nil {
 		return nil, ErrLLMNotConfigured
 	}
@@ -218,6 +296,79 @@ func (s *Service) ClassifyEmailBatch(ctx context.Context, emailIDs []int64) ([]*
 	return results, nil
 }
 
+// classifyBatchWithPipeline groups emails by user and classifies each group with one pipeline batch.
+func (s *Service) classifyBatchWithPipeline(ctx context.Context, emailIDs []int64) []*domain.ClassificationResult {
+	type loadedEmail struct {
+		email          *domain.Email
+		body, htmlBody string
+	}
+
+	byUser := make(map[uuid.UUID][]*loadedEmail)
+	var users []uuid.UUID
+	for _, emailID := range emailIDs {
+		email, body, htmlBody, err := s.loadForClassification(emailID)
+		if err != nil {
+			logger.WithFields(map[string]any{"email_id": emailID}).WithError(err).Warn("skipping email in batch classify")
+			continue
+		}
+		if _, ok := byUser[email.UserID]; !ok {
+			users = append(users, email.UserID)
+		}
+		byUser[email.UserID] = append(byUser[email.UserID], &loadedEmail{email: email, body: body, htmlBody: htmlBody})
+	}
+
+	results := make([]*domain.ClassificationResult, 0, len(emailIDs))
+	for _, userID := range users {
+		loaded := byUser[userID]
+		inputs := make([]*classification.ClassifyInput, len(loaded))
+		for i, l := range loaded {
+			inputs[i] = &classification.ClassifyInput{UserID: userID, Email: l.email, Body: l.body}
+		}
+
+		userCtx := llm.WithUsageScope(ctx, llm.UsageScope{UserID: userID, Task: llm.TaskClassify})
+		pipelineResults := s.classificationPipeline.ClassifyBatch(userCtx, userID, inputs)
+		for i, l := range loaded {
+			if pipelineResults[i] == nil {
+				continue
+			}
+			results = append(results, s.applyPipelineResult(ctx, l.email, l.body, l.htmlBody, pipelineResults[i]))
+		}
+	}
+	return results
+}
+
+// ReclassifyEmail classifies a failed email again with a different model or heuristics only.
+// 파이프라인이 없으면 heuristic 재처리를 할 수 없다.
+func (s *Service) ReclassifyEmail(ctx context.Context, emailID int64, opts classification.ReclassifyOptions) (*domain.ClassificationResult, error) {
+	if s.emailRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	if s.classificationPipeline == nil {
+		return nil, errors.New("classification pipeline not configured")
+	}
+
+	email, body, htmlBody, err := s.loadForClassification(emailID)
+	if err != nil {
+		return nil, err
+	}
+	ctx = usageContext(ctx, email, llm.TaskClassify)
+
+	pipelineResult, err := s.classificationPipeline.Reclassify(ctx, &classification.ClassifyInput{
+		UserID: email.UserID,
+		Email:  email,
+		Body:   body,
+	}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("reclassification failed: %w", err)
+	}
+
+	result := s.applyPipelineResult(ctx, email, body, htmlBody, pipelineResult)
+	if pipelineResult.LLMUsed {
+		result.ModelUsed = opts.Model
+	}
+	return result, nil
+}
+
 // SummarizeEmail generates a summary for an email
 // force=true: API 요청 시 길이 관계없이 AI 실행
 // force=false: 자동 처리 시 200자 미만은 본문 자체를 반환 (API 비용 절감)
@@ -238,6 +389,7 @@ func (s *Service) SummarizeEmail(ctx context.Context, emailID int64, force bool)
 	if !force && email.AISummary != nil && *email.AISummary != "" {
 		return *email.AISummary, nil
 	}
+	ctx = usageContext(ctx, email, llm.TaskSummarize)
 
 	body := ""
 	if emailBody, err := s.emailRepo.GetBody(emailID); err == nil && emailBody != nil {
@@ -262,6 +414,7 @@ func (s *Service) SummarizeEmail(ctx context.Context, emailID int64, force bool)
 	if err != nil {
 		return "", err
 	}
+	s.recordAIJob(ctx, email, "summarize")
 
 	// DB에 저장
 	email.AISummary = &summary
@@ -309,6 +462,7 @@ func (s *Service) SummarizeThread(ctx context.Context, threadID string) (string,
 	if len(emails) == 0 {
 		return "", fmt.Errorf("no emails found in thread")
 	}
+	ctx = usageContext(ctx, emails[0], llm.TaskSummarize)
 
 	// Convert to LLM format
 	emailContexts := make([]*llm.EmailContext, len(emails))
@@ -342,6 +496,8 @@ func (s *Service) GenerateReply(ctx context.Context, emailID int64, options *in.
 		return "", err
 	}
 
+	ctx = usageContext(ctx, email, llm.TaskReply)
+
 	// 1. Get original email body
 	body := ""
 	if emailBody, err := s.emailRepo.GetBody(emailID); err == nil && emailBody != nil {
@@ -361,7 +517,12 @@ func (s *Service) GenerateReply(ctx context.Context, emailID int64, options *in.
 	}
 
 	// 3. Generate reply with LLM
-	return s.llmClient.GenerateReply(ctx, email.Subject, body, email.FromEmail, styleContext, options)
+	reply, err := s.llmClient.GenerateReply(ctx, email.Subject, body, email.FromEmail, styleContext, options)
+	if err != nil {
+		return "", err
+	}
+	s.recordAIJob(ctx, email, "reply")
+	return reply, nil
 }
 
 // ExtractMeetingInfo extracts meeting information from email
@@ -388,8 +549,14 @@ func (s *Service) ExtractMeetingInfo(ctx context.Context, emailID int64) (*in.Me
 	if !containsMeetingKeyword(email.Subject, body) {
 		return &in.MeetingInfo{HasMeeting: false}, nil
 	}
+	ctx = usageContext(ctx, email, llm.TaskExtract)
 
-	return s.llmClient.ExtractMeeting(ctx, email.Subject, body)
+	info, err := s.llmClient.ExtractMeeting(ctx, email.Subject, body)
+	if err != nil {
+		return nil, err
+	}
+	s.recordAIJob(ctx, email, "extract_meeting")
+	return info, nil
 }
 
 // containsMeetingKeyword checks if content contains any meeting-related keywords
@@ -408,6 +575,7 @@ func (s *Service) Chat(ctx context.Context, userID uuid.UUID, req *in.ChatReques
 	if s.llmClient == nil {
 		return nil, ErrLLMNotConfigured
 	}
+	ctx = llm.WithUsageScope(ctx, llm.UsageScope{UserID: userID, Task: llm.TaskChat})
 
 	// 1. Retrieve relevant context from RAG
 	var ragContext string
@@ -467,6 +635,7 @@ func (s *Service) ChatStream(ctx context.Context, userID uuid.UUID, req *in.Chat
 	if s.llmClient == nil {
 		return ErrLLMNotConfigured
 	}
+	ctx = llm.WithUsageScope(ctx, llm.UsageScope{UserID: userID, Task: llm.TaskChat})
 
 	// 1. Retrieve relevant context from RAG
 	var ragContext string
@@ -578,6 +747,7 @@ func (s *Service) TranslateEmail(ctx context.Context, emailID int64, targetLang
 		}
 	}
 
+	ctx = usageContext(ctx, email, llm.TaskTranslate)
 	translatedSubject, translatedBody, err := s.llmClient.TranslateEmail(ctx, email.Subject, body, targetLang)
 	if err != nil {
 		return nil, err
@@ -628,35 +798,22 @@ func (s *Service) TranslateText(ctx context.Context, text, targetLang string) (*
 	}, nil
 }
 
-// DetectLanguage detects the language of the given text.
+// DetectLanguage detects the language of the given text (LLM 호출 없이 문자 체계/불용어로 판별).
 func (s *Service) DetectLanguage(ctx context.Context, text string) (string, float64, error) {
-	if s.llmClient == nil {
-		return "", 0, ErrLLMNotConfigured
+	lang, confidence := langdetect.Detect(text)
+	if lang == langdetect.Undetermined {
+		return "en", 0, nil
 	}
-
-	// 간단한 휴리스틱으로 언어 감지 (LLM 호출 없이)
-	// 한글이 포함되어 있으면 ko, 일본어면 ja, 그 외 en
-	lang := detectLanguageSimple(text)
-	return lang, 0.8, nil
+	return lang, confidence, nil
 }
 
-// detectLanguageSimple performs simple language detection without LLM.
-func detectLanguageSimple(text string) string {
-	for _, r := range text {
-		// 한글 범위
-		if r >= 0xAC00 && r <= 0xD7A3 {
-			return "ko"
-		}
-		// 일본어 히라가나/가타카나
-		if (r >= 0x3040 && r <= 0x309F) || (r >= 0x30A0 && r <= 0x30FF) {
-			return "ja"
-		}
-		// 중국어 (CJK 통합 한자)
-		if r >= 0x4E00 && r <= 0x9FFF {
-			return "zh"
-		}
+// emailLanguage detects the language of an email from its subject and body (판별 불가면 nil).
+func emailLanguage(subject, body string) *string {
+	lang, _ := langdetect.Detect(subject + "\n" + body)
+	if lang == langdetect.Undetermined {
+		return nil
 	}
-	return "en"
+	return &lang
 }
 
 // =============================================================================
@@ -682,6 +839,7 @@ func (s *Service) SummarizeEmailWithLang(ctx context.Context, emailID int64, lan
 		body = llm.CleanEmailBody(emailBody.TextBody)
 	}
 
+	ctx = usageContext(ctx, email, llm.TaskSummarize)
 	return s.llmClient.SummarizeEmailWithLang(ctx, email.Subject, body, language)
 }
 
@@ -702,6 +860,7 @@ func (s *Service) SummarizeThreadWithLang(ctx context.Context, threadID string,
 	if len(emails) == 0 {
 		return "", fmt.Errorf("no emails found in thread")
 	}
+	ctx = usageContext(ctx, emails[0], llm.TaskSummarize)
 
 	// Convert to LLM format
 	emailContexts := make([]llm.EmailContext, len(emails))
@@ -0,0 +1,110 @@
+// Package alias lists send-as addresses and validates from_alias on send.
+package alias
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrConnectionNotFound  = errors.New("connection not found")
+	ErrUnsupportedProvider = errors.New("provider does not support send-as aliases")
+	ErrAliasNotFound       = errors.New("from_alias is not a send-as address of this connection")
+	ErrAliasNotVerified    = errors.New("from_alias is not verified")
+)
+
+// ConnectionProvider resolves connections and tokens (auth.OAuthService).
+type ConnectionProvider interface {
+	GetConnection(ctx context.Context, connectionID int64) (*domain.OAuthConnection, error)
+	GetOAuth2Token(ctx context.Context, connectionID int64) (*oauth2.Token, error)
+}
+
+// aliasLister is implemented by providers exposing send-as addresses (Gmail, Outlook).
+type aliasLister interface {
+	ListAliases(ctx context.Context, token *oauth2.Token) ([]out.ProviderAlias, error)
+}
+
+// Service lists aliases through the provider of each connection.
+type Service struct {
+	connections ConnectionProvider
+	providers   map[string]out.EmailProviderPort
+}
+
+// NewService creates a new alias service.
+func NewService(connections ConnectionProvider) *Service {
+	return &Service{
+		connections: connections,
+		providers:   make(map[string]out.EmailProviderPort),
+	}
+}
+
+// RegisterProvider registers a provider under a connection provider name (google, gmail, outlook ...).
+func (s *Service) RegisterProvider(name string, provider out.EmailProviderPort) {
+	s.providers[name] = provider
+}
+
+// List returns the send-as addresses of a connection.
+func (s *Service) List(ctx context.Context, userID uuid.UUID, connectionID int64) ([]*domain.EmailAlias, error) {
+	conn, err := s.connections.GetConnection(ctx, connectionID)
+	if err != nil || conn == nil || conn.UserID != userID {
+		return nil, ErrConnectionNotFound
+	}
+	token, err := s.connections.GetOAuth2Token(ctx, conn.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListWithToken(ctx, conn, token)
+}
+
+// ListWithToken returns the send-as addresses using an already resolved token.
+func (s *Service) ListWithToken(ctx context.Context, conn *domain.OAuthConnection, token *oauth2.Token) ([]*domain.EmailAlias, error) {
+	lister, ok := s.providers[string(conn.Provider)].(aliasLister)
+	if !ok {
+		return nil, ErrUnsupportedProvider
+	}
+
+	list, err := lister.ListAliases(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make([]*domain.EmailAlias, len(list))
+	for i, a := range list {
+		aliases[i] = &domain.EmailAlias{
+			Email:       a.Email,
+			DisplayName: a.DisplayName,
+			ReplyTo:     a.ReplyTo,
+			IsPrimary:   a.IsPrimary,
+			IsDefault:   a.IsDefault,
+			Verified:    a.Verified,
+		}
+	}
+	return aliases, nil
+}
+
+// Resolve validates fromAlias against the verified aliases of the connection.
+func (s *Service) Resolve(ctx context.Context, conn *domain.OAuthConnection, token *oauth2.Token, fromAlias string) (*domain.EmailAlias, error) {
+	aliases, err := s.ListWithToken(ctx, conn, token)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAlias = strings.TrimSpace(fromAlias)
+	for _, a := range aliases {
+		if !strings.EqualFold(a.Email, fromAlias) {
+			continue
+		}
+		if !a.Verified {
+			return nil, ErrAliasNotVerified
+		}
+		return a, nil
+	}
+	return nil, ErrAliasNotFound
+}
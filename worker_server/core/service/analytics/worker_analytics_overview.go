@@ -0,0 +1,181 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultOverviewDays / maxOverviewDays - 대시보드 조회 기간
+	defaultOverviewDays = 30
+	maxOverviewDays     = 365
+	// defaultTopSenders / maxTopSenders - 상위 발신자 수
+	defaultTopSenders = 10
+	maxTopSenders     = 50
+
+	// statsInterval - 사용자별 증분 집계 주기
+	statsInterval = 5 * time.Minute
+	// statsSettle - AI 분류가 끝나도록 저장 후 이 시간이 지난 메일만 집계한다
+	statsSettle = 10 * time.Minute
+	// statsBatchSize / statsUsersPerRun - 한 번의 실행에서 사용자별 메일 수, 사용자 수 상한
+	statsBatchSize   = 20000
+	statsUsersPerRun = 200
+)
+
+var (
+	ErrStatsNotConfigured = errors.New("mailbox stats are not configured")
+	ErrInvalidTimezone    = errors.New("invalid timezone")
+)
+
+// SetMailboxStatsRepository enables the mailbox dashboard and its incremental aggregation.
+func (s *Service) SetMailboxStatsRepository(repo out.MailboxStatsRepository) {
+	s.statsRepo = repo
+}
+
+// Overview returns the mailbox dashboard for the last days in the given timezone.
+// 집계 테이블만 읽고 emails는 스캔하지 않는다.
+func (s *Service) Overview(ctx context.Context, userID uuid.UUID, days, top int, timezone string) (*domain.MailboxOverview, error) {
+	if s.statsRepo == nil {
+		return nil, ErrStatsNotConfigured
+	}
+	days = clamp(days, defaultOverviewDays, maxOverviewDays)
+	top = clamp(top, defaultTopSenders, maxTopSenders)
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	from := today.AddDate(0, 0, -(days - 1))
+	to := today.AddDate(0, 0, 1)
+
+	hourly, err := s.statsRepo.HourlyStats(ctx, userID, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	overview := buildOverview(hourly, from, days, loc)
+	overview.Timezone = timezone
+
+	if overview.TopSenders, err = s.statsRepo.TopSenders(ctx, userID, top); err != nil {
+		return nil, err
+	}
+	if overview.TopSenders == nil {
+		overview.TopSenders = []domain.SenderStat{}
+	}
+
+	categories, err := s.statsRepo.Categories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	overview.Categories = withPercent(categories)
+
+	totals, err := s.statsRepo.Totals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	overview.Attachments = &domain.AttachmentUsage{}
+	if totals != nil {
+		overview.Attachments.Count = totals.AttachmentCount
+		overview.Attachments.Bytes = totals.AttachmentBytes
+		overview.AggregatedAt = &totals.UpdatedAt
+	}
+	return overview, nil
+}
+
+// AggregateMailboxStats folds new mail activity of stale users into the aggregate tables (워커 스케줄러).
+func (s *Service) AggregateMailboxStats(ctx context.Context) (int, error) {
+	if s.statsRepo == nil {
+		return 0, nil
+	}
+	users, err := s.statsRepo.StaleUsers(ctx, statsInterval, statsUsersPerRun)
+	if err != nil {
+		return 0, err
+	}
+
+	aggregated := 0
+	for _, userID := range users {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := s.statsRepo.Aggregate(ctx, userID, statsSettle, statsBatchSize); err != nil {
+			logger.Warn("[AnalyticsService] Failed to aggregate mailbox stats for %s: %v", userID, err)
+			continue
+		}
+		aggregated++
+	}
+	return aggregated, nil
+}
+
+// buildOverview converts UTC hour buckets to daily and hour-of-day volume in loc.
+func buildOverview(hourly []*domain.MailboxHourlyStat, from time.Time, days int, loc *time.Location) *domain.MailboxOverview {
+	overview := &domain.MailboxOverview{
+		Days:         days,
+		VolumeByDay:  make([]*domain.DailyVolume, days),
+		VolumeByHour: make([]*domain.HourlyVolume, 24),
+	}
+	byDate := make(map[string]*domain.DailyVolume, days)
+	for i := 0; i < days; i++ {
+		day := &domain.DailyVolume{Date: from.AddDate(0, 0, i).Format("2006-01-02")}
+		overview.VolumeByDay[i] = day
+		byDate[day.Date] = day
+	}
+	for h := 0; h < 24; h++ {
+		overview.VolumeByHour[h] = &domain.HourlyVolume{Hour: h}
+	}
+
+	var readSeconds int64
+	for _, stat := range hourly {
+		local := stat.Bucket.In(loc)
+		overview.Received += stat.Received
+		overview.Sent += stat.Sent
+		overview.ReadCount += stat.ReadCount
+		readSeconds += stat.ReadSeconds
+
+		if day, ok := byDate[local.Format("2006-01-02")]; ok {
+			day.Received += stat.Received
+			day.Sent += stat.Sent
+		}
+		hour := overview.VolumeByHour[local.Hour()]
+		hour.Received += stat.Received
+		hour.Sent += stat.Sent
+	}
+
+	if overview.ReadCount > 0 {
+		avg := round1(float64(readSeconds) / float64(overview.ReadCount) / 60)
+		overview.AvgTimeToReadMinutes = &avg
+	}
+	return overview
+}
+
+func withPercent(categories []*domain.CategoryStat) []*domain.CategoryStat {
+	if categories == nil {
+		return []*domain.CategoryStat{}
+	}
+	total := 0
+	for _, c := range categories {
+		total += c.Emails
+	}
+	if total == 0 {
+		return categories
+	}
+	for _, c := range categories {
+		c.Percent = round1(float64(c.Emails) * 100 / float64(total))
+	}
+	return categories
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
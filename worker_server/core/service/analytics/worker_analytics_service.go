@@ -0,0 +1,290 @@
+// Package analytics turns stored communication metrics into reports (관계 그래프, 메일함 대시보드).
+package analytics
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxGraphContacts - 분석에 읽는 연락처 관계 수 (중요도 순)
+	maxGraphContacts = 2000
+	// defaultTrendMonths / maxTrendMonths - 월별 추이 기간
+	defaultTrendMonths = 12
+	maxTrendMonths     = 36
+	// defaultTopContacts / maxTopContacts - 중요 연락처 목록 크기
+	defaultTopContacts = 10
+	maxTopContacts     = 50
+	// activeWindow - 이 기간 안에 연락한 연락처를 활동 중으로 본다
+	activeWindow = 90 * 24 * time.Hour
+)
+
+var (
+	ErrGraphNotConfigured = errors.New("contact graph is not configured")
+	ErrContactNotFound    = errors.New("contact not found")
+	ErrInvalidEmail       = errors.New("invalid email address")
+)
+
+// replyTimeBuckets - 평균 답장 시간 분포 구간 (MaxHours 0은 상한 없음)
+var replyTimeBuckets = []domain.ReplyTimeBucket{
+	{Label: "under_4h", MinHours: 0, MaxHours: 4},
+	{Label: "same_day", MinHours: 4, MaxHours: 24},
+	{Label: "1_3_days", MinHours: 24, MaxHours: 72},
+	{Label: "over_3_days", MinHours: 72},
+}
+
+// Service computes communication analytics.
+type Service struct {
+	graphStore out.ExtendedPersonalizationStore
+	statsRepo  out.MailboxStatsRepository
+}
+
+// NewService creates a new analytics service.
+func NewService() *Service {
+	return &Service{}
+}
+
+// SetGraphStore enables relationship analytics from the Neo4j contact graph.
+func (s *Service) SetGraphStore(store out.ExtendedPersonalizationStore) {
+	s.graphStore = store
+}
+
+// Relationships summarizes reply times, send/receive balance and monthly trends over the user's contacts.
+func (s *Service) Relationships(ctx context.Context, userID uuid.UUID, months, top int) (*domain.RelationshipAnalytics, error) {
+	if s.graphStore == nil {
+		return nil, ErrGraphNotConfigured
+	}
+	months = clamp(months, defaultTrendMonths, maxTrendMonths)
+	top = clamp(top, defaultTopContacts, maxTopContacts)
+
+	rels, err := s.graphStore.GetContactRelationships(ctx, userID.String(), maxGraphContacts)
+	if err != nil {
+		return nil, err
+	}
+	return summarize(rels, months, top, time.Now()), nil
+}
+
+// Contact returns the drill-down for one contact.
+func (s *Service) Contact(ctx context.Context, userID uuid.UUID, email string) (*domain.ContactAnalytics, error) {
+	if s.graphStore == nil {
+		return nil, ErrGraphNotConfigured
+	}
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return nil, ErrInvalidEmail
+	}
+	email = strings.ToLower(addr.Address)
+
+	// 목록은 중요도 순이므로 순위도 함께 구한다
+	rels, err := s.graphStore.GetContactRelationships(ctx, userID.String(), maxGraphContacts)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i, rel := range rels {
+		if strings.EqualFold(rel.ContactEmail, email) {
+			contact := contactAnalytics(rel, now)
+			contact.ImportanceRank = i + 1
+			return contact, nil
+		}
+	}
+
+	rel, err := s.graphStore.GetContactRelationship(ctx, userID.String(), email)
+	if err != nil {
+		return nil, err
+	}
+	if rel == nil {
+		return nil, ErrContactNotFound
+	}
+	return contactAnalytics(rel, now), nil
+}
+
+func summarize(rels []*out.ContactRelationship, months, top int, now time.Time) *domain.RelationshipAnalytics {
+	result := &domain.RelationshipAnalytics{
+		TotalContacts: len(rels),
+		Balance: map[string]int{
+			domain.BalanceMostlySent:     0,
+			domain.BalanceBalanced:       0,
+			domain.BalanceMostlyReceived: 0,
+		},
+		RelationTypes: make(map[string]int),
+		TopContacts:   make([]*domain.ContactAnalytics, 0, top),
+		GeneratedAt:   now,
+	}
+
+	var replyHours []int
+	for i, rel := range rels {
+		result.EmailsSent += rel.EmailsSent
+		result.EmailsReceived += rel.EmailsReceived
+		if !rel.LastContact.IsZero() && now.Sub(rel.LastContact) <= activeWindow {
+			result.ActiveContacts++
+		}
+		result.Balance[balance(rel.EmailsSent, rel.EmailsReceived)]++
+
+		relationType := rel.RelationType
+		if relationType == "" {
+			relationType = "unknown"
+		}
+		result.RelationTypes[relationType]++
+
+		if rel.AvgReplyTime > 0 {
+			replyHours = append(replyHours, rel.AvgReplyTime)
+		}
+		if i < top {
+			contact := contactAnalytics(rel, now)
+			contact.ImportanceRank = i + 1
+			result.TopContacts = append(result.TopContacts, contact)
+		}
+	}
+	result.SendReceiveRatio = ratio(result.EmailsSent, result.EmailsReceived)
+	result.ReplyTime = replyTimeDistribution(replyHours, len(rels))
+	result.Trend = monthlyTrend(rels, months, now)
+	return result
+}
+
+func replyTimeDistribution(hours []int, total int) *domain.ReplyTimeDistribution {
+	dist := &domain.ReplyTimeDistribution{
+		Buckets:      make([]*domain.ReplyTimeBucket, len(replyTimeBuckets)),
+		SampleCount:  len(hours),
+		UnknownCount: total - len(hours),
+	}
+	for i := range replyTimeBuckets {
+		bucket := replyTimeBuckets[i]
+		dist.Buckets[i] = &bucket
+	}
+	if len(hours) == 0 {
+		return dist
+	}
+
+	sum := 0
+	for _, h := range hours {
+		sum += h
+		for _, bucket := range dist.Buckets {
+			if h >= bucket.MinHours && (bucket.MaxHours == 0 || h < bucket.MaxHours) {
+				bucket.Contacts++
+				break
+			}
+		}
+	}
+	dist.AvgHours = float64(sum) / float64(len(hours))
+
+	sorted := append([]int(nil), hours...)
+	sort.Ints(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		dist.MedianHours = float64(sorted[mid-1]+sorted[mid]) / 2
+	} else {
+		dist.MedianHours = float64(sorted[mid])
+	}
+	return dist
+}
+
+// monthlyTrend counts new and active contacts per month, oldest first.
+// 관계 엣지에는 첫/마지막 연락 시각만 있으므로 그 구간에 걸친 달을 활동 월로 본다.
+func monthlyTrend(rels []*out.ContactRelationship, months int, now time.Time) []*domain.RelationshipTrendPoint {
+	now = now.UTC()
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	trend := make([]*domain.RelationshipTrendPoint, months)
+	for i := 0; i < months; i++ {
+		start := current.AddDate(0, i-months+1, 0)
+		end := start.AddDate(0, 1, 0)
+		point := &domain.RelationshipTrendPoint{Month: start.Format("2006-01")}
+
+		for _, rel := range rels {
+			if rel.FirstContact.IsZero() {
+				continue
+			}
+			if !rel.FirstContact.Before(start) && rel.FirstContact.Before(end) {
+				point.NewContacts++
+			}
+			if rel.FirstContact.Before(end) && !rel.LastContact.IsZero() && !rel.LastContact.Before(start) {
+				point.ActiveContacts++
+			}
+		}
+		trend[i] = point
+	}
+	return trend
+}
+
+func contactAnalytics(rel *out.ContactRelationship, now time.Time) *domain.ContactAnalytics {
+	contact := &domain.ContactAnalytics{
+		Email:             rel.ContactEmail,
+		Name:              rel.ContactName,
+		RelationType:      rel.RelationType,
+		Company:           rel.Company,
+		JobTitle:          rel.JobTitle,
+		AvatarURL:         rel.AvatarURL,
+		EmailsSent:        rel.EmailsSent,
+		EmailsReceived:    rel.EmailsReceived,
+		SendReceiveRatio:  ratio(rel.EmailsSent, rel.EmailsReceived),
+		Balance:           balance(rel.EmailsSent, rel.EmailsReceived),
+		AvgReplyTimeHours: rel.AvgReplyTime,
+		ImportanceScore:   rel.ImportanceScore,
+		IsFrequent:        rel.IsFrequent,
+		IsImportant:       rel.IsImportant,
+	}
+	if rel.AvgReplyTime > 0 {
+		for _, bucket := range replyTimeBuckets {
+			if rel.AvgReplyTime >= bucket.MinHours && (bucket.MaxHours == 0 || rel.AvgReplyTime < bucket.MaxHours) {
+				contact.ReplyTimeBucket = bucket.Label
+				break
+			}
+		}
+	}
+	if !rel.FirstContact.IsZero() {
+		first := rel.FirstContact
+		contact.FirstContact = &first
+
+		// 첫 연락 이후 월 평균 메일 수 (최소 1개월)
+		monthsKnown := now.Sub(first).Hours() / 24 / 30
+		if monthsKnown < 1 {
+			monthsKnown = 1
+		}
+		contact.EmailsPerMonth = float64(rel.EmailsSent+rel.EmailsReceived) / monthsKnown
+	}
+	if !rel.LastContact.IsZero() {
+		last := rel.LastContact
+		contact.LastContact = &last
+		contact.DaysSinceLastContact = int(now.Sub(last).Hours() / 24)
+	}
+	return contact
+}
+
+func balance(sent, received int) string {
+	switch {
+	case sent > 0 && sent >= 2*received:
+		return domain.BalanceMostlySent
+	case received > 0 && received >= 2*sent:
+		return domain.BalanceMostlyReceived
+	default:
+		return domain.BalanceBalanced
+	}
+}
+
+func ratio(sent, received int) *float64 {
+	if received == 0 {
+		return nil
+	}
+	r := float64(sent) / float64(received)
+	return &r
+}
+
+func clamp(v, def, max int) int {
+	if v <= 0 {
+		return def
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,146 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+type fakeGraph struct {
+	out.ExtendedPersonalizationStore
+	rels []*out.ContactRelationship
+}
+
+func (g *fakeGraph) GetContactRelationships(_ context.Context, _ string, _ int) ([]*out.ContactRelationship, error) {
+	return g.rels, nil
+}
+
+func (g *fakeGraph) GetContactRelationship(_ context.Context, _, _ string) (*out.ContactRelationship, error) {
+	return nil, nil
+}
+
+func TestSummarizeRelationships(t *testing.T) {
+	now := time.Date(2026, 5, 15, 12, 0, 0, 0, time.UTC)
+	rels := []*out.ContactRelationship{
+		{ContactEmail: "boss@acme.com", RelationType: "boss", EmailsSent: 30, EmailsReceived: 10, AvgReplyTime: 2,
+			FirstContact: now.AddDate(0, -2, 0), LastContact: now.AddDate(0, 0, -1)},
+		{ContactEmail: "client@corp.com", EmailsSent: 5, EmailsReceived: 5, AvgReplyTime: 30,
+			FirstContact: now.AddDate(0, 0, -10), LastContact: now.AddDate(0, 0, -3)},
+		{ContactEmail: "news@list.com", EmailsSent: 0, EmailsReceived: 20,
+			FirstContact: now.AddDate(-1, 0, 0), LastContact: now.AddDate(0, -6, 0)},
+	}
+
+	got := summarize(rels, 3, 2, now)
+
+	if got.TotalContacts != 3 || got.ActiveContacts != 2 || got.EmailsSent != 35 || got.EmailsReceived != 35 {
+		t.Errorf("unexpected totals: %+v", got)
+	}
+	if got.SendReceiveRatio == nil || *got.SendReceiveRatio != 1 {
+		t.Errorf("ratio = %v, want 1", got.SendReceiveRatio)
+	}
+	if got.Balance[domain.BalanceMostlySent] != 1 || got.Balance[domain.BalanceBalanced] != 1 || got.Balance[domain.BalanceMostlyReceived] != 1 {
+		t.Errorf("unexpected balance %v", got.Balance)
+	}
+	if got.RelationTypes["boss"] != 1 || got.RelationTypes["unknown"] != 2 {
+		t.Errorf("unexpected relation types %v", got.RelationTypes)
+	}
+
+	rt := got.ReplyTime
+	if rt.SampleCount != 2 || rt.UnknownCount != 1 || rt.AvgHours != 16 || rt.MedianHours != 16 {
+		t.Errorf("unexpected reply time stats %+v", rt)
+	}
+	if rt.Buckets[0].Contacts != 1 || rt.Buckets[2].Contacts != 1 {
+		t.Errorf("unexpected reply buckets %+v %+v", rt.Buckets[0], rt.Buckets[2])
+	}
+
+	if len(got.Trend) != 3 || got.Trend[0].Month != "2026-03" || got.Trend[2].Month != "2026-05" {
+		t.Fatalf("unexpected trend months %+v", got.Trend)
+	}
+	// 3월: boss 첫 연락, 5월: client 첫 연락 + boss/client 활동
+	if got.Trend[0].NewContacts != 1 || got.Trend[2].NewContacts != 1 || got.Trend[2].ActiveContacts != 2 {
+		t.Errorf("unexpected trend %+v %+v", got.Trend[0], got.Trend[2])
+	}
+
+	if len(got.TopContacts) != 2 || got.TopContacts[1].ImportanceRank != 2 || got.TopContacts[0].ReplyTimeBucket != "under_4h" {
+		t.Errorf("unexpected top contacts %+v", got.TopContacts)
+	}
+}
+
+func TestContactDrillDown(t *testing.T) {
+	graph := &fakeGraph{rels: []*out.ContactRelationship{
+		{ContactEmail: "a@acme.com", EmailsSent: 1},
+		{ContactEmail: "b@acme.com", EmailsSent: 4, EmailsReceived: 2},
+	}}
+	svc := NewService()
+	if _, err := svc.Contact(context.Background(), uuid.New(), "b@acme.com"); !errors.Is(err, ErrGraphNotConfigured) {
+		t.Errorf("without graph: got %v", err)
+	}
+	svc.SetGraphStore(graph)
+
+	got, err := svc.Contact(context.Background(), uuid.New(), "B <B@acme.com>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ImportanceRank != 2 || got.Balance != domain.BalanceMostlySent || got.SendReceiveRatio == nil || *got.SendReceiveRatio != 2 {
+		t.Errorf("unexpected drill-down %+v", got)
+	}
+
+	if _, err := svc.Contact(context.Background(), uuid.New(), "missing@acme.com"); !errors.Is(err, ErrContactNotFound) {
+		t.Errorf("unknown contact: got %v", err)
+	}
+	if _, err := svc.Contact(context.Background(), uuid.New(), "not-an-email"); !errors.Is(err, ErrInvalidEmail) {
+		t.Errorf("invalid email: got %v", err)
+	}
+}
+
+func TestBuildOverviewInTimezone(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Seoul")
+	from := time.Date(2026, 5, 14, 0, 0, 0, 0, loc)
+	hourly := []*domain.MailboxHourlyStat{
+		// 5/13 15:00 UTC = 5/14 00:00 KST
+		{Bucket: time.Date(2026, 5, 13, 15, 0, 0, 0, time.UTC), Received: 3, Sent: 1, ReadCount: 2, ReadSeconds: 600},
+		{Bucket: time.Date(2026, 5, 15, 1, 0, 0, 0, time.UTC), Received: 2, ReadCount: 1, ReadSeconds: 1200},
+	}
+
+	got := buildOverview(hourly, from, 2, loc)
+
+	if got.Received != 5 || got.Sent != 1 || got.ReadCount != 3 {
+		t.Errorf("unexpected totals: %+v", got)
+	}
+	if len(got.VolumeByDay) != 2 || got.VolumeByDay[0].Date != "2026-05-14" || got.VolumeByDay[0].Received != 3 ||
+		got.VolumeByDay[1].Date != "2026-05-15" || got.VolumeByDay[1].Received != 2 {
+		t.Errorf("unexpected daily volume %+v %+v", got.VolumeByDay[0], got.VolumeByDay[1])
+	}
+	if len(got.VolumeByHour) != 24 || got.VolumeByHour[0].Received != 3 || got.VolumeByHour[10].Received != 2 {
+		t.Errorf("unexpected hourly volume %+v %+v", got.VolumeByHour[0], got.VolumeByHour[10])
+	}
+	if got.AvgTimeToReadMinutes == nil || *got.AvgTimeToReadMinutes != 10 {
+		t.Errorf("avg time to read = %v, want 10", got.AvgTimeToReadMinutes)
+	}
+
+	cats := withPercent([]*domain.CategoryStat{{Category: "work", Emails: 2}, {Category: domain.UncategorizedCategory, Emails: 1}})
+	if cats[0].Percent != 66.7 || cats[1].Percent != 33.3 {
+		t.Errorf("unexpected percents %v %v", cats[0].Percent, cats[1].Percent)
+	}
+}
+
+func TestOverviewRejectsInvalidTimezone(t *testing.T) {
+	svc := NewService()
+	if _, err := svc.Overview(context.Background(), uuid.New(), 30, 10, "UTC"); !errors.Is(err, ErrStatsNotConfigured) {
+		t.Errorf("without repo: got %v", err)
+	}
+	svc.SetMailboxStatsRepository(&fakeStatsRepo{})
+	if _, err := svc.Overview(context.Background(), uuid.New(), 30, 10, "Mars/Olympus"); !errors.Is(err, ErrInvalidTimezone) {
+		t.Errorf("invalid timezone: got %v", err)
+	}
+}
+
+type fakeStatsRepo struct {
+	out.MailboxStatsRepository
+}
@@ -69,6 +69,12 @@ func (s *SettingsService) UpdateSettings(ctx context.Context, userID uuid.UUID,
 	if v, ok := updates["auto_reply_message"].(string); ok {
 		settings.AutoReplyMessage = &v
 	}
+	if v, ok := updates["safe_links_enabled"].(bool); ok {
+		settings.SafeLinksEnabled = v
+	}
+	if v, ok := updates["large_attachment_links"].(bool); ok {
+		settings.LargeAttachmentLinks = v
+	}
 
 	if err := s.settingsRepo.Update(settings); err != nil {
 		return nil, err
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+// syncStaleThreshold - 마지막 성공 동기화 이후 이 시간이 지나면 sync_stale
+const syncStaleThreshold = time.Hour
+
+var ErrConnectionNotFound = errors.New("connection not found")
+
+// ConnectionHealthService checks whether a connection's token, push watch and sync are working.
+// 클라이언트가 어떤 계정이 고장났는지 정확히 표시할 수 있도록 문제 코드를 모아서 반환한다.
+type ConnectionHealthService struct {
+	oauth     *OAuthService
+	syncRepo  out.SyncStateRepository
+	providers map[string]out.EmailProviderPort
+}
+
+// NewConnectionHealthService creates a new connection health service.
+func NewConnectionHealthService(oauth *OAuthService, syncRepo out.SyncStateRepository) *ConnectionHealthService {
+	return &ConnectionHealthService{
+		oauth:     oauth,
+		syncRepo:  syncRepo,
+		providers: make(map[string]out.EmailProviderPort),
+	}
+}
+
+// RegisterProvider registers a provider under a connection provider name (google, gmail, outlook ...).
+func (s *ConnectionHealthService) RegisterProvider(name string, provider out.EmailProviderPort) {
+	s.providers[name] = provider
+}
+
+// Check returns the health of a connection owned by userID.
+func (s *ConnectionHealthService) Check(ctx context.Context, userID uuid.UUID, connectionID int64) (*domain.ConnectionHealth, error) {
+	conn, err := s.oauth.GetConnection(ctx, connectionID)
+	if err != nil || conn == nil || conn.UserID != userID {
+		return nil, ErrConnectionNotFound
+	}
+
+	now := time.Now()
+	health := &domain.ConnectionHealth{
+		ConnectionID: conn.ID,
+		Provider:     conn.Provider,
+		Email:        conn.Email,
+		Issues:       []string{},
+		CheckedAt:    now,
+	}
+
+	if !conn.IsConnected {
+		health.Issues = append(health.Issues, domain.HealthIssueDisconnected)
+	}
+	s.checkToken(ctx, conn, health)
+	s.checkSync(ctx, conn, health, now)
+
+	health.Status, health.NeedsReconnect = summarizeHealth(health.Issues)
+	return health, nil
+}
+
+// checkToken refreshes the token if needed and asks the provider whether it is accepted.
+func (s *ConnectionHealthService) checkToken(ctx context.Context, conn *domain.OAuthConnection, health *domain.ConnectionHealth) {
+	token, err := s.oauth.GetOAuth2Token(ctx, conn.ID)
+	if err != nil {
+		health.Token.Error = err.Error()
+		health.Issues = append(health.Issues, domain.HealthIssueTokenRefresh)
+		return
+	}
+	health.Token.ExpiresAt = token.Expiry
+
+	provider, ok := s.providers[string(conn.Provider)]
+	if !ok {
+		// 검증할 Provider가 없으면 갱신 성공만으로 유효하다고 본다
+		health.Token.Valid = true
+		return
+	}
+
+	valid, err := provider.ValidateToken(ctx, token)
+	switch {
+	case err != nil:
+		health.Token.Error = err.Error()
+		health.Issues = append(health.Issues, domain.HealthIssueProviderError)
+	case !valid:
+		health.Issues = append(health.Issues, domain.HealthIssueTokenInvalid)
+	default:
+		health.Token.Valid = true
+	}
+}
+
+// checkSync reads watch and sync progress from the sync state.
+func (s *ConnectionHealthService) checkSync(ctx context.Context, conn *domain.OAuthConnection, health *domain.ConnectionHealth, now time.Time) {
+	if s.syncRepo == nil {
+		return
+	}
+	state, err := s.syncRepo.GetByConnectionID(ctx, conn.ID)
+	if err != nil || state == nil {
+		health.Issues = append(health.Issues, domain.HealthIssueSyncStateMissing)
+		return
+	}
+
+	// Watch (push notification)
+	health.Watch.ResourceID = state.WatchResourceID
+	if !state.WatchExpiry.IsZero() {
+		expiry := state.WatchExpiry
+		health.Watch.ExpiresAt = &expiry
+		health.Watch.Active = expiry.After(now)
+	}
+	switch {
+	case state.WatchExpiry.IsZero():
+		health.Issues = append(health.Issues, domain.HealthIssueWatchMissing)
+	case !health.Watch.Active || state.Status == domain.SyncStatusWatchExpired:
+		health.Watch.Active = false
+		health.Issues = append(health.Issues, domain.HealthIssueWatchExpired)
+	}
+
+	// Sync progress
+	health.Sync = domain.SyncHealth{
+		Status:         state.Status,
+		Phase:          state.Phase,
+		LastError:      state.LastError,
+		RetryCount:     state.RetryCount,
+		TotalSynced:    state.TotalSynced,
+		LastSyncCount:  state.LastSyncCount,
+		LastDurationMs: state.LastSyncDurationMs,
+		Progress:       state.CheckpointProgress(),
+	}
+	if !state.NextRetryAt.IsZero() {
+		next := state.NextRetryAt
+		health.Sync.NextRetryAt = &next
+	}
+
+	if state.Status == domain.SyncStatusError || state.Status == domain.SyncStatusRetryScheduled {
+		health.Issues = append(health.Issues, domain.HealthIssueSyncError)
+	}
+	if state.LastSyncAt.IsZero() {
+		health.Issues = append(health.Issues, domain.HealthIssueNeverSynced)
+		return
+	}
+	last := state.LastSyncAt
+	health.Sync.LastSuccessAt = &last
+	lag := now.Sub(last)
+	health.Sync.LagSeconds = int64(lag.Seconds())
+	if lag > syncStaleThreshold {
+		health.Issues = append(health.Issues, domain.HealthIssueSyncStale)
+	}
+}
+
+// summarizeHealth derives the overall status from issue codes.
+func summarizeHealth(issues []string) (domain.ConnectionHealthStatus, bool) {
+	status := domain.ConnectionHealthy
+	for _, issue := range issues {
+		switch issue {
+		case domain.HealthIssueDisconnected, domain.HealthIssueTokenRefresh, domain.HealthIssueTokenInvalid:
+			return domain.ConnectionBroken, true
+		default:
+			status = domain.ConnectionDegraded
+		}
+	}
+	return status, false
+}
+
+// ReconnectURL returns a fresh OAuth URL for a connection owned by userID.
+func (s *ConnectionHealthService) ReconnectURL(ctx context.Context, userID uuid.UUID, connectionID int64, state string) (string, *domain.OAuthConnection, error) {
+	conn, err := s.oauth.GetConnection(ctx, connectionID)
+	if err != nil || conn == nil || conn.UserID != userID {
+		return "", nil, ErrConnectionNotFound
+	}
+	authURL, err := s.oauth.GetReconnectAuthURL(ctx, conn, state)
+	if err != nil {
+		return "", nil, err
+	}
+	return authURL, conn, nil
+}
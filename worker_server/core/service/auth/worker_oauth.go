@@ -57,6 +57,7 @@ func NewOAuthServiceWithConfig(
 				"https://www.googleapis.com/auth/gmail.readonly",
 				"https://www.googleapis.com/auth/gmail.send",
 				"https://www.googleapis.com/auth/gmail.modify",
+				"https://www.googleapis.com/auth/gmail.settings.basic", // vacation responder
 				"https://www.googleapis.com/auth/calendar.readonly",
 				"https://www.googleapis.com/auth/calendar.events",
 				"https://www.googleapis.com/auth/userinfo.email",
@@ -98,6 +99,24 @@ func (s *OAuthService) GetAuthURL(ctx context.Context, provider domain.OAuthProv
 	}
 }
 
+// GetReconnectAuthURL returns an OAuth URL that re-authorizes an existing account.
+// login_hint로 같은 계정을 선택하게 하고, 콜백은 이메일로 기존 연결을 갱신한다.
+func (s *OAuthService) GetReconnectAuthURL(ctx context.Context, conn *domain.OAuthConnection, state string) (string, error) {
+	switch conn.Provider {
+	case domain.ProviderGoogle, "gmail":
+		if s.googleConfig == nil {
+			return "", fmt.Errorf("google oauth not configured")
+		}
+		return s.googleConfig.AuthCodeURL(state,
+			oauth2.AccessTypeOffline,
+			oauth2.ApprovalForce,
+			oauth2.SetAuthURLParam("login_hint", conn.Email),
+		), nil
+	default:
+		return s.GetAuthURL(ctx, conn.Provider, state)
+	}
+}
+
 func (s *OAuthService) HandleCallback(ctx context.Context, provider domain.OAuthProvider, code string, userID uuid.UUID) (*domain.OAuthConnection, error) {
 	logger.Info("[OAuthService.HandleCallback] Starting for provider: %s, userID: %s", provider, userID)
 
@@ -460,6 +479,40 @@ func (s *OAuthService) GetConnectionByUserID(ctx context.Context, userID uuid.UU
 	return nil, fmt.Errorf("no active connection found for provider: %s", provider)
 }
 
+// GetOrCreateLocalConnection returns the user's local archive pseudo-connection.
+// MBOX/EML로 가져온 메일은 Provider 없이 이 연결 아래에 저장된다 (토큰 없음, 동기화/웹훅 대상 아님).
+func (s *OAuthService) GetOrCreateLocalConnection(ctx context.Context, userID uuid.UUID) (*domain.OAuthConnection, error) {
+	if s.oauthRepo == nil {
+		return nil, fmt.Errorf("oauth repository not initialized")
+	}
+
+	entity, err := s.oauthRepo.GetByEmail(ctx, userID.String(), string(domain.ProviderLocal), domain.LocalArchiveEmail)
+	if err == nil && entity != nil {
+		return toDomainOAuth(entity), nil
+	}
+	if err != nil && err != persistence.ErrNotFound {
+		return nil, err
+	}
+
+	now := time.Now()
+	entity = &out.OAuthConnectionEntity{
+		UserID:      userID.String(),
+		Provider:    string(domain.ProviderLocal),
+		Email:       domain.LocalArchiveEmail,
+		AccessToken: "local", // access_token NOT NULL
+		ExpiresAt:   now.AddDate(100, 0, 0),
+		IsConnected: true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.oauthRepo.Create(ctx, entity); err != nil {
+		return nil, fmt.Errorf("failed to create local archive connection: %w", err)
+	}
+
+	logger.Info("[OAuthService] created local archive connection %d for user %s", entity.ID, userID)
+	return toDomainOAuth(entity), nil
+}
+
 // GetConnectionByWebhookID finds a connection by webhook subscription ID.
 func (s *OAuthService) GetConnectionByWebhookID(ctx context.Context, subscriptionID string, provider string) (*domain.OAuthConnection, error) {
 	if s.oauthRepo == nil {
@@ -0,0 +1,456 @@
+// Package briefing compiles the per-user daily briefing.
+package briefing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// sectionLimit - 섹션별 최대 항목 수
+	sectionLimit = 10
+
+	// 보낸 지 awaitingReplyAfter가 지나도록 답장이 없으면 답장 대기로 본다 (awaitingReplyWindow 이내 메일만)
+	awaitingReplyAfter  = 2 * 24 * time.Hour
+	awaitingReplyWindow = 14 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidHour  = errors.New("hour must be between 0 and 23")
+	ErrNoConnection = errors.New("no connected account can send the briefing")
+)
+
+// ConnectionProvider resolves a user's connections and tokens (auth.OAuthService).
+type ConnectionProvider interface {
+	GetConnectionsByUser(ctx context.Context, userID uuid.UUID) ([]*domain.OAuthConnection, error)
+	GetOAuth2Token(ctx context.Context, connectionID int64) (*oauth2.Token, error)
+}
+
+// Service generates, stores and optionally emails daily briefings.
+type Service struct {
+	repo         out.BriefingRepository
+	mailRepo     out.EmailRepository
+	todoRepo     out.TodoRepository     // optional
+	calendarRepo out.CalendarRepository // optional
+
+	connections ConnectionProvider
+	providers   map[string]out.EmailProviderPort
+
+	now func() time.Time
+}
+
+// NewService creates a new briefing service.
+func NewService(repo out.BriefingRepository, mailRepo out.EmailRepository) *Service {
+	return &Service{
+		repo:      repo,
+		mailRepo:  mailRepo,
+		providers: make(map[string]out.EmailProviderPort),
+		now:       time.Now,
+	}
+}
+
+// SetTodoRepository enables the todo section.
+func (s *Service) SetTodoRepository(repo out.TodoRepository) {
+	s.todoRepo = repo
+}
+
+// SetCalendarRepository enables the upcoming events section.
+func (s *Service) SetCalendarRepository(repo out.CalendarRepository) {
+	s.calendarRepo = repo
+}
+
+// SetConnectionProvider enables emailing briefings.
+func (s *Service) SetConnectionProvider(connections ConnectionProvider) {
+	s.connections = connections
+}
+
+// RegisterProvider registers a provider under a connection provider name (google, gmail, outlook ...).
+func (s *Service) RegisterProvider(name string, provider out.EmailProviderPort) {
+	s.providers[name] = provider
+}
+
+// Today returns today's briefing of the user, generating it on first access.
+func (s *Service) Today(ctx context.Context, userID uuid.UUID) (*domain.Briefing, error) {
+	settings, err := s.repo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	date := s.now().In(settings.Location()).Format(time.DateOnly)
+
+	b, err := s.repo.Get(ctx, userID, date)
+	if err != nil {
+		return nil, err
+	}
+	if b != nil {
+		return b, nil
+	}
+	return s.Generate(ctx, settings)
+}
+
+// Generate compiles and stores today's briefing in the user's time zone.
+// 섹션 하나가 실패해도 나머지로 브리핑을 만든다.
+func (s *Service) Generate(ctx context.Context, settings *domain.BriefingSettings) (*domain.Briefing, error) {
+	loc := settings.Location()
+	now := s.now().In(loc)
+	b := &domain.Briefing{
+		UserID:          settings.UserID,
+		Date:            now.Format(time.DateOnly),
+		Timezone:        loc.String(),
+		GeneratedAt:     now.UTC(),
+		UnreadImportant: []domain.BriefingEmail{},
+		Todos:           []domain.BriefingTodo{},
+		UpcomingEvents:  []domain.BriefingEvent{},
+		AwaitingReply:   []domain.BriefingThread{},
+	}
+
+	if emails, err := s.unreadImportant(ctx, settings.UserID); err != nil {
+		logger.WithError(err).Warn("[Briefing] Failed to load unread important mail of %s", settings.UserID)
+	} else {
+		b.UnreadImportant = emails
+	}
+	if todos, err := s.todos(ctx, settings.UserID, now); err != nil {
+		logger.WithError(err).Warn("[Briefing] Failed to load todos of %s", settings.UserID)
+	} else {
+		b.Todos = todos
+	}
+	if events, err := s.upcomingEvents(ctx, settings.UserID, now); err != nil {
+		logger.WithError(err).Warn("[Briefing] Failed to load events of %s", settings.UserID)
+	} else {
+		b.UpcomingEvents = events
+	}
+	if threads, err := s.repo.ListAwaitingReply(ctx, settings.UserID, now.Add(-awaitingReplyWindow), now.Add(-awaitingReplyAfter), sectionLimit); err != nil {
+		logger.WithError(err).Warn("[Briefing] Failed to load awaiting reply threads of %s", settings.UserID)
+	} else {
+		for i := range threads {
+			threads[i].DaysWaiting = int(now.Sub(threads[i].SentAt) / (24 * time.Hour))
+		}
+		b.AwaitingReply = threads
+	}
+
+	if err := s.repo.Save(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// unreadImportant returns unread inbox mail with high or urgent priority.
+func (s *Service) unreadImportant(ctx context.Context, userID uuid.UUID) ([]domain.BriefingEmail, error) {
+	unread := false
+	minPriority := 0.60 // domain.PriorityHigh 구간 하한
+	mails, _, err := s.mailRepo.List(ctx, userID, &out.MailListQuery{
+		Folder:   "inbox",
+		IsRead:   &unread,
+		Priority: &minPriority,
+		Limit:    sectionLimit,
+		OrderBy:  "ai_priority",
+		Order:    "desc",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]domain.BriefingEmail, 0, len(mails))
+	for _, m := range mails {
+		emails = append(emails, domain.BriefingEmail{
+			EmailID:    m.ID,
+			Subject:    m.Subject,
+			FromEmail:  m.FromEmail,
+			FromName:   m.FromName,
+			Snippet:    m.Snippet,
+			Summary:    m.Summary,
+			Priority:   m.Priority,
+			ReceivedAt: m.ReceivedAt,
+		})
+	}
+	return emails, nil
+}
+
+// todos merges open todo items and emails marked as todo, most urgent first.
+func (s *Service) todos(ctx context.Context, userID uuid.UUID, now time.Time) ([]domain.BriefingTodo, error) {
+	var todos []domain.BriefingTodo
+
+	if s.todoRepo != nil {
+		items, _, err := s.todoRepo.ListTodos(ctx, &domain.TodoFilter{
+			UserID:    userID,
+			Statuses:  []domain.TodoStatus{domain.TodoStatusInbox, domain.TodoStatusPending, domain.TodoStatusInProgress},
+			Limit:     sectionLimit,
+			SortBy:    "priority",
+			SortOrder: "asc",
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range items {
+			due := t.DueDatetime
+			if due == nil {
+				due = t.DueDate
+			}
+			todos = append(todos, domain.BriefingTodo{
+				Source:   "todo",
+				TodoID:   t.ID,
+				EmailID:  t.RelatedEmailID,
+				Title:    t.Title,
+				Priority: todoPriorityScore(t.Priority),
+				DueAt:    due,
+				Overdue:  due != nil && due.Before(now),
+			})
+		}
+	}
+
+	mails, _, err := s.mailRepo.List(ctx, userID, &out.MailListQuery{
+		WorkflowStatus: "todo",
+		Limit:          sectionLimit,
+		OrderBy:        "ai_priority",
+		Order:          "desc",
+	})
+	if err != nil {
+		return nil, err
+	}
+	linked := make(map[int64]bool, len(todos))
+	for _, t := range todos {
+		if t.EmailID != nil {
+			linked[*t.EmailID] = true
+		}
+	}
+	for _, m := range mails {
+		if linked[m.ID] {
+			continue // 이미 todo 항목으로 만든 메일
+		}
+		id := m.ID
+		todos = append(todos, domain.BriefingTodo{
+			Source:   "email",
+			EmailID:  &id,
+			Title:    m.Subject,
+			Priority: m.Priority,
+		})
+	}
+
+	sort.SliceStable(todos, func(i, j int) bool {
+		if todos[i].Overdue != todos[j].Overdue {
+			return todos[i].Overdue
+		}
+		return todos[i].Priority > todos[j].Priority
+	})
+	if len(todos) > sectionLimit {
+		todos = todos[:sectionLimit]
+	}
+	return todos, nil
+}
+
+// todoPriorityScore maps todo priority (1=urgent ~ 4=low) onto the 0.0 ~ 1.0 mail priority scale.
+func todoPriorityScore(p domain.TodoPriority) float64 {
+	switch p {
+	case domain.TodoPriorityUrgent:
+		return float64(domain.PriorityUrgent)
+	case domain.TodoPriorityHigh:
+		return float64(domain.PriorityHigh)
+	case domain.TodoPriorityLow:
+		return float64(domain.PriorityLow)
+	default:
+		return float64(domain.PriorityNormal)
+	}
+}
+
+// upcomingEvents returns events starting before the end of tomorrow (local time).
+func (s *Service) upcomingEvents(ctx context.Context, userID uuid.UUID, now time.Time) ([]domain.BriefingEvent, error) {
+	if s.calendarRepo == nil {
+		return []domain.BriefingEvent{}, nil
+	}
+	entities, err := s.calendarRepo.GetUpcoming(ctx, userID, sectionLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	until := time.Date(now.Year(), now.Month(), now.Day()+2, 0, 0, 0, 0, now.Location())
+	events := make([]domain.BriefingEvent, 0, len(entities))
+	for _, e := range entities {
+		if !e.StartTime.Before(until) {
+			break // start_time 오름차순
+		}
+		events = append(events, domain.BriefingEvent{
+			EventID:        e.ID,
+			Title:          e.Title,
+			StartTime:      e.StartTime,
+			EndTime:        e.EndTime,
+			IsAllDay:       e.IsAllDay,
+			Location:       e.Location,
+			MeetingURL:     e.MeetingURL,
+			Extracted:      e.AutoGenerated,
+			RelatedEmailID: e.RelatedEmailID,
+		})
+	}
+	return events, nil
+}
+
+// GetSettings returns the user's briefing settings.
+func (s *Service) GetSettings(ctx context.Context, userID uuid.UUID) (*domain.BriefingSettings, error) {
+	return s.repo.GetSettings(ctx, userID)
+}
+
+// UpdateSettings saves the user's briefing settings.
+func (s *Service) UpdateSettings(ctx context.Context, settings *domain.BriefingSettings) (*domain.BriefingSettings, error) {
+	if settings.Hour < 0 || settings.Hour > 23 {
+		return nil, ErrInvalidHour
+	}
+	if err := s.repo.SaveSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSettings(ctx, settings.UserID)
+}
+
+// RunDue generates today's briefing for every user whose local briefing hour has passed,
+// and emails it to users who opted in. 스케줄러가 주기적으로 호출한다.
+func (s *Service) RunDue(ctx context.Context) (int, error) {
+	targets, err := s.repo.ListEnabledSettings(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, settings := range targets {
+		if ctx.Err() != nil {
+			return generated, ctx.Err()
+		}
+		local := s.now().In(settings.Location())
+		if local.Hour() < settings.Hour {
+			continue
+		}
+
+		b, err := s.repo.Get(ctx, settings.UserID, local.Format(time.DateOnly))
+		if err != nil {
+			logger.WithError(err).Warn("[Briefing] Failed to load briefing of %s", settings.UserID)
+			continue
+		}
+		if b == nil {
+			if b, err = s.Generate(ctx, settings); err != nil {
+				logger.WithError(err).Warn("[Briefing] Failed to generate briefing of %s", settings.UserID)
+				continue
+			}
+			generated++
+		}
+
+		if settings.EmailEnabled && b.EmailedAt == nil && !b.IsEmpty() {
+			if err := s.Email(ctx, b); err != nil {
+				logger.WithError(err).Warn("[Briefing] Failed to email briefing of %s", settings.UserID)
+			}
+		}
+	}
+	return generated, nil
+}
+
+// Email sends the briefing to the user's own address through their first connected account.
+func (s *Service) Email(ctx context.Context, b *domain.Briefing) error {
+	if s.connections == nil {
+		return ErrNoConnection
+	}
+	conns, err := s.connections.GetConnectionsByUser(ctx, b.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, conn := range conns {
+		provider, ok := s.providers[string(conn.Provider)]
+		if !conn.IsConnected || !ok {
+			continue
+		}
+		token, err := s.connections.GetOAuth2Token(ctx, conn.ID)
+		if err != nil {
+			return err
+		}
+		_, err = provider.Send(ctx, token, &out.ProviderOutgoingMessage{
+			To:      []out.ProviderEmailAddress{{Email: conn.Email}},
+			Subject: "Daily briefing - " + b.Date,
+			Body:    renderHTML(b),
+			IsHTML:  true,
+			Headers: []out.ProviderMessageHeader{
+				{Name: "Auto-Submitted", Value: "auto-generated"},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		at := s.now()
+		b.EmailedAt = &at
+		return s.repo.MarkEmailed(ctx, b.UserID, b.Date, at)
+	}
+	return ErrNoConnection
+}
+
+// renderHTML renders the briefing as a simple HTML email.
+func renderHTML(b *domain.Briefing) string {
+	loc, err := time.LoadLocation(b.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<h2>Daily briefing - " + html.EscapeString(b.Date) + "</h2>")
+
+	section := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		sb.WriteString(fmt.Sprintf("<h3>%s (%d)</h3><ul>", html.EscapeString(title), len(items)))
+		for _, item := range items {
+			sb.WriteString("<li>" + item + "</li>")
+		}
+		sb.WriteString("</ul>")
+	}
+
+	var items []string
+	for _, e := range b.UnreadImportant {
+		from := e.FromName
+		if from == "" {
+			from = e.FromEmail
+		}
+		items = append(items, fmt.Sprintf("<b>%s</b> - %s", html.EscapeString(e.Subject), html.EscapeString(from)))
+	}
+	section("Unread important", items)
+
+	items = nil
+	for _, t := range b.Todos {
+		item := html.EscapeString(t.Title)
+		if t.DueAt != nil {
+			item += " (due " + t.DueAt.In(loc).Format("Jan 2 15:04") + ")"
+		}
+		if t.Overdue {
+			item = "<b>[overdue]</b> " + item
+		}
+		items = append(items, item)
+	}
+	section("To do", items)
+
+	items = nil
+	for _, e := range b.UpcomingEvents {
+		when := e.StartTime.In(loc).Format("Mon Jan 2 15:04")
+		if e.IsAllDay {
+			when = e.StartTime.In(loc).Format("Mon Jan 2") + " (all day)"
+		}
+		items = append(items, when+" - "+html.EscapeString(e.Title))
+	}
+	section("Upcoming events", items)
+
+	items = nil
+	for _, t := range b.AwaitingReply {
+		items = append(items, fmt.Sprintf("%s - %s (%d days)", html.EscapeString(t.Subject), html.EscapeString(strings.Join(t.To, ", ")), t.DaysWaiting))
+	}
+	section("Awaiting reply", items)
+
+	if b.IsEmpty() {
+		sb.WriteString("<p>Nothing needs your attention today.</p>")
+	}
+	return sb.String()
+}
@@ -0,0 +1,142 @@
+package briefing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+type memBriefingRepo struct {
+	briefings map[string]*domain.Briefing
+	settings  *domain.BriefingSettings
+	awaiting  []domain.BriefingThread
+}
+
+func (r *memBriefingRepo) Get(_ context.Context, _ uuid.UUID, date string) (*domain.Briefing, error) {
+	return r.briefings[date], nil
+}
+
+func (r *memBriefingRepo) Save(_ context.Context, b *domain.Briefing) error {
+	r.briefings[b.Date] = b
+	return nil
+}
+
+func (r *memBriefingRepo) MarkEmailed(_ context.Context, _ uuid.UUID, date string, at time.Time) error {
+	r.briefings[date].EmailedAt = &at
+	return nil
+}
+
+func (r *memBriefingRepo) GetSettings(context.Context, uuid.UUID) (*domain.BriefingSettings, error) {
+	return r.settings, nil
+}
+
+func (r *memBriefingRepo) SaveSettings(_ context.Context, s *domain.BriefingSettings) error {
+	r.settings = s
+	return nil
+}
+
+func (r *memBriefingRepo) ListEnabledSettings(context.Context) ([]*domain.BriefingSettings, error) {
+	return []*domain.BriefingSettings{r.settings}, nil
+}
+
+func (r *memBriefingRepo) ListAwaitingReply(context.Context, uuid.UUID, time.Time, time.Time, int) ([]domain.BriefingThread, error) {
+	return r.awaiting, nil
+}
+
+// memMailRepo answers List by workflow status; 나머지 메서드는 사용하지 않는다.
+type memMailRepo struct {
+	out.EmailRepository
+	unread []*out.MailEntity
+	todo   []*out.MailEntity
+}
+
+func (r *memMailRepo) List(_ context.Context, _ uuid.UUID, q *out.MailListQuery) ([]*out.MailEntity, int, error) {
+	if q.WorkflowStatus == "todo" {
+		return r.todo, len(r.todo), nil
+	}
+	return r.unread, len(r.unread), nil
+}
+
+type memTodoRepo struct {
+	out.TodoRepository
+	todos []*domain.Todo
+}
+
+func (r *memTodoRepo) ListTodos(context.Context, *domain.TodoFilter) ([]*domain.Todo, int, error) {
+	return r.todos, len(r.todos), nil
+}
+
+func TestGenerateCompilesSections(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	now := time.Date(2026, 3, 2, 23, 30, 0, 0, time.UTC) // 서울 3월 3일 08:30
+
+	linked := int64(2)
+	yesterday := now.Add(-24 * time.Hour)
+	repo := &memBriefingRepo{
+		briefings: make(map[string]*domain.Briefing),
+		settings:  &domain.BriefingSettings{UserID: userID, Enabled: true, Hour: 7, Timezone: "Asia/Seoul"},
+		awaiting:  []domain.BriefingThread{{ThreadID: 9, EmailID: 5, SentAt: now.Add(-73 * time.Hour)}},
+	}
+	svc := NewService(repo, &memMailRepo{
+		unread: []*out.MailEntity{{ID: 1, Subject: "Contract", Priority: 0.8}},
+		todo:   []*out.MailEntity{{ID: 2, Subject: "Linked"}, {ID: 3, Subject: "Review deck", Priority: 0.65}},
+	})
+	svc.SetTodoRepository(&memTodoRepo{todos: []*domain.Todo{
+		{ID: 10, Title: "Pay invoice", Priority: domain.TodoPriorityLow, DueDate: &yesterday},
+		{ID: 11, Title: "Reply to Linked", Priority: domain.TodoPriorityNormal, RelatedEmailID: &linked},
+	}})
+	svc.now = func() time.Time { return now }
+
+	b, err := svc.Today(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Date != "2026-03-03" || b.Timezone != seoul.String() {
+		t.Errorf("briefing date should follow the user's time zone, got %s %s", b.Date, b.Timezone)
+	}
+	if len(b.UnreadImportant) != 1 || b.UnreadImportant[0].EmailID != 1 {
+		t.Errorf("unexpected unread important: %+v", b.UnreadImportant)
+	}
+	// 기한 지난 todo가 먼저, 이미 todo로 만든 메일(2)은 중복 제외
+	if len(b.Todos) != 3 || b.Todos[0].TodoID != 10 || !b.Todos[0].Overdue || b.Todos[1].Title != "Review deck" {
+		t.Errorf("unexpected todos: %+v", b.Todos)
+	}
+	if len(b.AwaitingReply) != 1 || b.AwaitingReply[0].DaysWaiting != 3 {
+		t.Errorf("unexpected awaiting reply: %+v", b.AwaitingReply)
+	}
+
+	// 같은 날 다시 조회하면 저장된 브리핑을 반환한다
+	again, _ := svc.Today(ctx, userID)
+	if again != b {
+		t.Error("today's briefing should be generated once")
+	}
+}
+
+func TestRunDueWaitsForLocalHour(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 21, 0, 0, 0, time.UTC) // 서울 3월 3일 06:00
+	repo := &memBriefingRepo{
+		briefings: make(map[string]*domain.Briefing),
+		settings:  &domain.BriefingSettings{UserID: uuid.New(), Enabled: true, Hour: 7, Timezone: "Asia/Seoul"},
+	}
+	svc := NewService(repo, &memMailRepo{})
+	svc.now = func() time.Time { return now }
+
+	if n, _ := svc.RunDue(ctx); n != 0 {
+		t.Fatalf("briefing generated before the local hour: %d", n)
+	}
+	now = now.Add(time.Hour)
+	if n, _ := svc.RunDue(ctx); n != 1 {
+		t.Fatalf("expected one briefing at the local hour, got %d", n)
+	}
+	if n, _ := svc.RunDue(ctx); n != 0 {
+		t.Errorf("briefing should be generated once a day, got %d", n)
+	}
+}
@@ -0,0 +1,256 @@
+// Package category manages the per-user category taxonomy (custom, renamed and hidden categories).
+package category
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// taxonomyTTL - 분류 워커가 매 배치마다 DB를 읽지 않도록 캐시한다 (변경 시 즉시 무효화)
+	taxonomyTTL = time.Minute
+
+	maxCustomCategories  = 30
+	maxNameLength        = 50
+	maxDescriptionLength = 500
+)
+
+// keyPattern matches custom category keys (emails.ai_category에 그대로 저장된다).
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,19}$`)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryExists   = errors.New("category already exists")
+	ErrInvalidKey       = errors.New("key must be 2-20 lowercase letters, digits or underscores")
+	ErrInvalidName      = errors.New("name must be 1-50 characters")
+	ErrTooLong          = errors.New("description is too long")
+	ErrTooManyCustom    = errors.New("too many custom categories")
+	ErrCannotHideOther  = errors.New("the other category cannot be hidden")
+)
+
+// CreateRequest describes a new custom category.
+type CreateRequest struct {
+	Key         string
+	Name        string
+	Description string
+	Color       string
+	Icon        string
+	SortOrder   int
+}
+
+// UpdateRequest changes a category; nil fields are left as they are.
+type UpdateRequest struct {
+	Name        *string
+	Description *string
+	Color       *string
+	Icon        *string
+	IsHidden    *bool
+	SortOrder   *int
+}
+
+type cachedTaxonomy struct {
+	taxonomy  *domain.CategoryTaxonomy
+	expiresAt time.Time
+}
+
+// Service manages user categories and serves the effective taxonomy to the classifier.
+type Service struct {
+	repo out.UserCategoryRepository
+
+	mu    sync.RWMutex
+	cache map[uuid.UUID]cachedTaxonomy
+}
+
+// NewService creates a new category service.
+func NewService(repo out.UserCategoryRepository) *Service {
+	return &Service{
+		repo:  repo,
+		cache: make(map[uuid.UUID]cachedTaxonomy),
+	}
+}
+
+// List returns the stored custom categories and overrides of the user.
+func (s *Service) List(ctx context.Context, userID uuid.UUID) ([]*domain.UserCategory, error) {
+	return s.repo.List(ctx, userID)
+}
+
+// Taxonomy returns the effective taxonomy of the user.
+func (s *Service) Taxonomy(ctx context.Context, userID uuid.UUID) (*domain.CategoryTaxonomy, error) {
+	s.mu.RLock()
+	cached, ok := s.cache[userID]
+	s.mu.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.taxonomy, nil
+	}
+
+	categories, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	taxonomy := domain.NewCategoryTaxonomy(categories)
+
+	s.mu.Lock()
+	s.cache[userID] = cachedTaxonomy{taxonomy: taxonomy, expiresAt: time.Now().Add(taxonomyTTL)}
+	s.mu.Unlock()
+	return taxonomy, nil
+}
+
+// IsValid reports whether key is a category of the user. 조회 실패 시 기본 카테고리만 허용한다.
+func (s *Service) IsValid(ctx context.Context, userID uuid.UUID, key string) bool {
+	taxonomy, err := s.Taxonomy(ctx, userID)
+	if err != nil {
+		return domain.IsBuiltinCategory(key)
+	}
+	return taxonomy.IsValid(key)
+}
+
+// Create adds a custom category.
+func (s *Service) Create(ctx context.Context, userID uuid.UUID, req *CreateRequest) (*domain.UserCategory, error) {
+	key := strings.ToLower(strings.TrimSpace(req.Key))
+	if !keyPattern.MatchString(key) {
+		return nil, ErrInvalidKey
+	}
+	name := strings.TrimSpace(req.Name)
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	if err := validateDescription(req.Description); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if domain.IsBuiltinCategory(key) {
+		return nil, ErrCategoryExists
+	}
+	custom := 0
+	for _, c := range categories {
+		if c.Key == key {
+			return nil, ErrCategoryExists
+		}
+		if c.IsCustom {
+			custom++
+		}
+	}
+	if custom >= maxCustomCategories {
+		return nil, ErrTooManyCustom
+	}
+
+	category := &domain.UserCategory{
+		UserID:      userID,
+		Key:         key,
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Color:       req.Color,
+		Icon:        req.Icon,
+		IsCustom:    true,
+		SortOrder:   req.SortOrder,
+	}
+	if err := s.repo.Upsert(ctx, category); err != nil {
+		return nil, err
+	}
+	s.invalidate(userID)
+	return category, nil
+}
+
+// Update renames, hides or restyles a category.
+// 기본 카테고리는 override 행을 만들고, 이름이 비어 있으면 기본 이름을 쓴다.
+func (s *Service) Update(ctx context.Context, userID uuid.UUID, key string, req *UpdateRequest) (*domain.UserCategory, error) {
+	categories, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	category := domain.NewCategoryTaxonomy(categories).Get(key)
+	if category == nil {
+		if !domain.IsBuiltinCategory(key) {
+			return nil, ErrCategoryNotFound
+		}
+		category = &domain.UserCategory{UserID: userID, Key: key}
+	}
+
+	if req.Name != nil {
+		category.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		category.Description = strings.TrimSpace(*req.Description)
+	}
+	if category.IsCustom || category.Name != "" {
+		if err := validateName(category.Name); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateDescription(category.Description); err != nil {
+		return nil, err
+	}
+	if req.Color != nil {
+		category.Color = *req.Color
+	}
+	if req.Icon != nil {
+		category.Icon = *req.Icon
+	}
+	if req.SortOrder != nil {
+		category.SortOrder = *req.SortOrder
+	}
+	if req.IsHidden != nil {
+		if *req.IsHidden && key == string(domain.CategoryOther) {
+			return nil, ErrCannotHideOther
+		}
+		category.IsHidden = *req.IsHidden
+	}
+
+	if err := s.repo.Upsert(ctx, category); err != nil {
+		return nil, err
+	}
+	s.invalidate(userID)
+	return category, nil
+}
+
+// Delete removes a custom category and moves its emails to other.
+// 기본 카테고리는 삭제 대신 override를 지워 원래 이름과 표시 상태로 되돌린다.
+func (s *Service) Delete(ctx context.Context, userID uuid.UUID, key string) error {
+	var reassignTo domain.EmailCategory
+	if !domain.IsBuiltinCategory(key) {
+		reassignTo = domain.CategoryOther
+	}
+	deleted, err := s.repo.Delete(ctx, userID, key, reassignTo)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrCategoryNotFound
+	}
+	s.invalidate(userID)
+	return nil
+}
+
+func (s *Service) invalidate(userID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.cache, userID)
+	s.mu.Unlock()
+}
+
+func validateName(name string) error {
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+func validateDescription(description string) error {
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return ErrTooLong
+	}
+	return nil
+}
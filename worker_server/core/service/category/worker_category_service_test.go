@@ -0,0 +1,123 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type memoryRepo struct {
+	categories map[string]*domain.UserCategory
+	reassigned map[string]domain.EmailCategory
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		categories: make(map[string]*domain.UserCategory),
+		reassigned: make(map[string]domain.EmailCategory),
+	}
+}
+
+func (r *memoryRepo) List(ctx context.Context, userID uuid.UUID) ([]*domain.UserCategory, error) {
+	var list []*domain.UserCategory
+	for _, c := range r.categories {
+		copied := *c
+		list = append(list, &copied)
+	}
+	return list, nil
+}
+
+func (r *memoryRepo) Upsert(ctx context.Context, category *domain.UserCategory) error {
+	copied := *category
+	r.categories[category.Key] = &copied
+	return nil
+}
+
+func (r *memoryRepo) Delete(ctx context.Context, userID uuid.UUID, key string, reassignTo domain.EmailCategory) (bool, error) {
+	if _, ok := r.categories[key]; !ok {
+		return false, nil
+	}
+	delete(r.categories, key)
+	r.reassigned[key] = reassignTo
+	return true, nil
+}
+
+func TestCustomCategoryLifecycle(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	repo := newMemoryRepo()
+	s := NewService(repo)
+
+	if _, err := s.Create(ctx, userID, &CreateRequest{Key: "Work", Name: "Work"}); !errors.Is(err, ErrCategoryExists) {
+		t.Fatalf("Create(built-in key) = %v, want ErrCategoryExists", err)
+	}
+	if _, err := s.Create(ctx, userID, &CreateRequest{Key: "hr team", Name: "HR"}); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("Create(invalid key) = %v, want ErrInvalidKey", err)
+	}
+	if _, err := s.Create(ctx, userID, &CreateRequest{Key: "hr", Name: "HR", Description: "HR팀 공지"}); err != nil {
+		t.Fatalf("Create = %v", err)
+	}
+	if _, err := s.Create(ctx, userID, &CreateRequest{Key: "hr", Name: "HR"}); !errors.Is(err, ErrCategoryExists) {
+		t.Fatalf("Create(duplicate) = %v, want ErrCategoryExists", err)
+	}
+
+	if !s.IsValid(ctx, userID, "hr") || s.IsValid(ctx, userID, "legal") {
+		t.Fatal("IsValid should accept custom categories and reject unknown ones")
+	}
+
+	if err := s.Delete(ctx, userID, "hr"); err != nil {
+		t.Fatalf("Delete = %v", err)
+	}
+	if repo.reassigned["hr"] != domain.CategoryOther {
+		t.Fatalf("deleted custom category emails moved to %q, want other", repo.reassigned["hr"])
+	}
+	if s.IsValid(ctx, userID, "hr") {
+		t.Fatal("deleted category should no longer be valid")
+	}
+	if err := s.Delete(ctx, userID, "hr"); !errors.Is(err, ErrCategoryNotFound) {
+		t.Fatalf("Delete(missing) = %v, want ErrCategoryNotFound", err)
+	}
+}
+
+func TestHiddenBuiltinResolvesToOther(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	repo := newMemoryRepo()
+	s := NewService(repo)
+
+	hidden := true
+	if _, err := s.Update(ctx, userID, "other", &UpdateRequest{IsHidden: &hidden}); !errors.Is(err, ErrCannotHideOther) {
+		t.Fatalf("Update(hide other) = %v, want ErrCannotHideOther", err)
+	}
+	name := "Promotions"
+	if _, err := s.Update(ctx, userID, "marketing", &UpdateRequest{Name: &name, IsHidden: &hidden}); err != nil {
+		t.Fatalf("Update = %v", err)
+	}
+
+	taxonomy, err := s.Taxonomy(ctx, userID)
+	if err != nil {
+		t.Fatalf("Taxonomy = %v", err)
+	}
+	if got := taxonomy.Resolve(domain.CategoryMarketing); got != domain.CategoryOther {
+		t.Fatalf("Resolve(hidden marketing) = %q, want other", got)
+	}
+	if !taxonomy.IsValid("marketing") {
+		t.Fatal("hidden built-in categories stay valid for listing")
+	}
+
+	// 기본 카테고리 삭제는 override 초기화 (메일은 옮기지 않는다)
+	if err := s.Delete(ctx, userID, "marketing"); err != nil {
+		t.Fatalf("Delete = %v", err)
+	}
+	if repo.reassigned["marketing"] != "" {
+		t.Fatal("resetting a built-in category must not move emails")
+	}
+	taxonomy, _ = s.Taxonomy(ctx, userID)
+	if got := taxonomy.Resolve(domain.CategoryMarketing); got != domain.CategoryMarketing {
+		t.Fatalf("Resolve(reset marketing) = %q, want marketing", got)
+	}
+}
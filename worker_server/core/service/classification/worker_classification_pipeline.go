@@ -32,6 +32,17 @@ type Pipeline struct {
 	rfcScoreClassifier     *RFCScoreClassifier
 	domainScoreClassifier  *DomainScoreClassifier
 	subjectScoreClassifier *SubjectScoreClassifier
+
+	// Security stage (phishing/spoofing)
+	securityAnalyzer *SecurityAnalyzer
+
+	// 사용자별 카테고리 체계 (optional, nil이면 기본 카테고리만)
+	taxonomies TaxonomyProvider
+}
+
+// TaxonomyProvider returns the category taxonomy of a user (category.Service).
+type TaxonomyProvider interface {
+	Taxonomy(ctx context.Context, userID uuid.UUID) (*domain.CategoryTaxonomy, error)
 }
 
 // normalizedRules는 소문자로 정규화된 분류 규칙입니다.
@@ -94,9 +105,15 @@ func NewPipeline(
 		rfcScoreClassifier:     NewRFCScoreClassifier(),
 		domainScoreClassifier:  NewDomainScoreClassifier(),
 		subjectScoreClassifier: NewSubjectScoreClassifier(),
+		securityAnalyzer:       NewSecurityAnalyzer(),
 	}
 }
 
+// SetTaxonomyProvider maps classification results onto each user's custom categories.
+func (p *Pipeline) SetTaxonomyProvider(provider TaxonomyProvider) {
+	p.taxonomies = provider
+}
+
 // ClassifyInput contains all inputs needed for classification.
 type ClassifyInput struct {
 	UserID  uuid.UUID
@@ -114,6 +131,96 @@ type ClassifyInput struct {
 // Stage 5: Cache           → (reserved for future)
 // Stage 6: LLM             → Natural language classification
 func (p *Pipeline) Classify(ctx context.Context, input *ClassifyInput) (*domain.ClassificationPipelineResult, error) {
+	taxonomy := p.userTaxonomy(ctx, input.UserID)
+	if result := p.classifyWithoutLLM(ctx, input); result != nil {
+		return applyTaxonomy(result, taxonomy), nil
+	}
+
+	// Stage 6: LLM-based classification with user's natural language rules
+	if p.llmClient != nil {
+		return p.classifyByLLM(ctx, input, p.userLLMRules(ctx, input.UserID, taxonomy), taxonomy), nil
+	}
+
+	// Default classification if no LLM is available
+	return defaultPipelineResult(), nil
+}
+
+// ClassifyBatch classifies emails of one user; results are in the same order as inputs.
+// Stage 0~5를 통과하지 못한 이메일은 최대 llm.MaxClassifyBatch개씩 묶어 한 번의 LLM 요청으로 분류하고,
+// 배치 호출이 실패하거나 응답에서 빠진 이메일은 개별 LLM 호출로 fallback한다.
+func (p *Pipeline) ClassifyBatch(ctx context.Context, userID uuid.UUID, inputs []*ClassifyInput) []*domain.ClassificationPipelineResult {
+	results := make([]*domain.ClassificationPipelineResult, len(inputs))
+	taxonomy := p.userTaxonomy(ctx, userID)
+
+	var pending []int
+	for i, input := range inputs {
+		if result := p.classifyWithoutLLM(ctx, input); result != nil {
+			results[i] = applyTaxonomy(result, taxonomy)
+		} else if p.llmClient == nil {
+			results[i] = defaultPipelineResult()
+		} else {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == 0 {
+		return results
+	}
+
+	userRules := p.userLLMRules(ctx, userID, taxonomy)
+	for start := 0; start < len(pending); start += llm.MaxClassifyBatch {
+		chunk := pending[start:min(start+llm.MaxClassifyBatch, len(pending))]
+
+		var batched map[int64]*llm.EnhancedClassificationResponse
+		if len(chunk) > 1 {
+			emails := make([]llm.BatchEmailInput, len(chunk))
+			for j, idx := range chunk {
+				emails[j] = llm.BatchEmailInput{Email: inputs[idx].Email, Body: inputs[idx].Body}
+			}
+			// 배치 전체 실패 시 batched는 nil → 모두 개별 호출
+			batched, _ = p.llmClient.ClassifyEmailsWithUserRules(ctx, emails, userRules)
+		}
+
+		for _, idx := range chunk {
+			if resp, ok := batched[inputs[idx].Email.ID]; ok {
+				results[idx] = llmResponseToPipelineResult(resp, taxonomy)
+				continue
+			}
+			results[idx] = p.classifyByLLM(ctx, inputs[idx], userRules, taxonomy)
+		}
+	}
+	return results
+}
+
+// ReclassifyOptions selects how a failed email is classified again.
+type ReclassifyOptions struct {
+	Model     string // LLM 단계에 쓸 모델 (비어 있으면 기본 모델)
+	Heuristic bool   // LLM 없이 Stage 0~5만, 매칭이 없으면 기본값
+}
+
+// Reclassify runs the pipeline for a retry of a failed classification.
+// Classify와 달리 LLM 오류를 기본값으로 감추지 않고 돌려준다.
+func (p *Pipeline) Reclassify(ctx context.Context, input *ClassifyInput, opts ReclassifyOptions) (*domain.ClassificationPipelineResult, error) {
+	taxonomy := p.userTaxonomy(ctx, input.UserID)
+	if result := p.classifyWithoutLLM(ctx, input); result != nil {
+		return applyTaxonomy(result, taxonomy), nil
+	}
+	if opts.Heuristic || p.llmClient == nil {
+		return defaultPipelineResult(), nil
+	}
+
+	client := p.llmClient
+	if opts.Model != "" {
+		client = client.WithModel(opts.Model)
+	}
+	resp, err := client.ClassifyEmailWithUserRules(ctx, input.Email, input.Body, p.userLLMRules(ctx, input.UserID, taxonomy))
+	if err != nil {
+		return nil, err
+	}
+	return llmResponseToPipelineResult(resp, taxonomy), nil
+}
+
+// classifyWithoutLLM runs Stage 0~5. Returns nil when the email needs the LLM stage.
+func (p *Pipeline) classifyWithoutLLM(ctx context.Context, input *ClassifyInput) *domain.ClassificationPipelineResult {
 	// Create score classifier input
 	scoreInput := &ScoreClassifierInput{
 		Email:   input.Email,
@@ -123,44 +230,56 @@ func (p *Pipeline) Classify(ctx context.Context, input *ClassifyInput) (*domain.
 
 	// Stage 0: RFC Header Classification (newsletters, marketing, notifications, developer services)
 	if result, err := p.rfcScoreClassifier.Classify(ctx, scoreInput); err == nil && result != nil {
-		return p.scoreResultToPipelineResult(result), nil
+		return p.scoreResultToPipelineResult(result, domain.ClassificationStageRFC)
 	}
 
 	// Stage 1: Domain Score Classification (known service domains)
 	if result, err := p.domainScoreClassifier.Classify(ctx, scoreInput); err == nil && result != nil {
-		return p.scoreResultToPipelineResult(result), nil
+		return p.scoreResultToPipelineResult(result, domain.ClassificationStageDomainScore)
 	}
 
 	// Stage 2: Subject Pattern Classification (CI/CD, finance, shipping patterns)
 	if result, err := p.subjectScoreClassifier.Classify(ctx, scoreInput); err == nil && result != nil {
-		return p.scoreResultToPipelineResult(result), nil
+		return p.scoreResultToPipelineResult(result, domain.ClassificationStageSubject)
 	}
 
 	// Stage 3: Simple User Rules (domain/keyword matching, no LLM)
 	if result, err := p.classifyBySimpleUserRules(ctx, input.UserID, input.Email); err == nil && result != nil {
-		return result, nil
+		result.Stage = domain.ClassificationStageRule
+		return result
 	}
 
 	// Stage 4: Known domain matching (SenderProfile, KnownDomain DB)
 	if result, err := p.classifyByDomain(ctx, input.UserID, input.Email.FromEmail); err == nil && result != nil {
-		return result, nil
+		result.Stage = domain.ClassificationStageSender
+		return result
 	}
 
 	// Stage 5: Cache (reserved for future)
+	return nil
+}
 
-	// Stage 6: LLM-based classification with user's natural language rules
-	if p.llmClient != nil {
-		return p.classifyByLLMWithUserRules(ctx, input)
-	}
-
-	// Default classification if no LLM is available
+// defaultPipelineResult is used when no stage matched and no LLM is available.
+func defaultPipelineResult() *domain.ClassificationPipelineResult {
 	return &domain.ClassificationPipelineResult{
 		Category:   domain.CategoryOther,
 		Priority:   domain.PriorityNormal,
 		Source:     domain.ClassificationSourceDomain,
+		Stage:      domain.ClassificationStageDefault,
 		Confidence: 0.5,
 		LLMUsed:    false,
-	}, nil
+	}
+}
+
+// AnalyzeSecurity runs the phishing/spoofing stage.
+// 카테고리 분류와 독립적으로 실행되며 분류 결과에 영향을 주지 않는다.
+func (p *Pipeline) AnalyzeSecurity(input *ClassifyInput) *domain.EmailSecurity {
+	return p.securityAnalyzer.Analyze(&ScoreClassifierInput{
+		UserID:  input.UserID,
+		Email:   input.Email,
+		Headers: input.Headers,
+		Body:    input.Body,
+	})
 }
 
 // ClassifyLegacy provides backward compatibility with the old API.
@@ -348,14 +467,12 @@ func (p *Pipeline) classifyByDomain(ctx context.Context, userID uuid.UUID, fromE
 // Stage 3: LLM with User Rules
 // =============================================================================
 
-// classifyByLLMWithUserRules performs Stage 3: LLM classification with user's natural language rules.
-func (p *Pipeline) classifyByLLMWithUserRules(ctx context.Context, input *ClassifyInput) (*domain.ClassificationPipelineResult, error) {
-	// Get user's LLM rules (natural language)
-	var userLLMRules *llm.UserLLMRules
+// userLLMRules loads the user's natural language rules and custom categories for the LLM stage.
+func (p *Pipeline) userLLMRules(ctx context.Context, userID uuid.UUID, taxonomy *domain.CategoryTaxonomy) *llm.UserLLMRules {
+	var userRules *llm.UserLLMRules
 	if p.settingsRepo != nil {
-		rules, err := p.settingsRepo.GetClassificationRules(ctx, input.UserID)
-		if err == nil && rules != nil {
-			userLLMRules = &llm.UserLLMRules{
+		if rules, err := p.settingsRepo.GetClassificationRules(ctx, userID); err == nil && rules != nil {
+			userRules = &llm.UserLLMRules{
 				HighPriorityRules:  rules.HighPriorityRules,
 				LowPriorityRules:   rules.LowPriorityRules,
 				CategoryRules:      rules.CategoryRules,
@@ -364,22 +481,61 @@ func (p *Pipeline) classifyByLLMWithUserRules(ctx context.Context, input *Classi
 		}
 	}
 
-	// Call LLM with user rules
-	resp, err := p.llmClient.ClassifyEmailWithUserRules(ctx, input.Email, input.Body, userLLMRules)
+	custom := taxonomy.Custom()
+	if len(custom) == 0 {
+		return userRules
+	}
+	if userRules == nil {
+		userRules = &llm.UserLLMRules{}
+	}
+	for _, c := range custom {
+		userRules.Categories = append(userRules.Categories, llm.CustomCategory{Key: c.Key, Name: c.Name, Description: c.Description})
+	}
+	return userRules
+}
+
+// userTaxonomy loads the user's category taxonomy. 조회 실패 시 nil (기본 카테고리만).
+func (p *Pipeline) userTaxonomy(ctx context.Context, userID uuid.UUID) *domain.CategoryTaxonomy {
+	if p.taxonomies == nil {
+		return nil
+	}
+	taxonomy, err := p.taxonomies.Taxonomy(ctx, userID)
+	if err != nil {
+		return nil
+	}
+	return taxonomy
+}
+
+// applyTaxonomy moves results in hidden or unknown categories to other.
+func applyTaxonomy(result *domain.ClassificationPipelineResult, taxonomy *domain.CategoryTaxonomy) *domain.ClassificationPipelineResult {
+	if category := taxonomy.Resolve(result.Category); category != result.Category {
+		result.Category = category
+		result.SubCategory = nil
+	}
+	return result
+}
+
+// classifyByLLM classifies a single email with the LLM.
+func (p *Pipeline) classifyByLLM(ctx context.Context, input *ClassifyInput, userRules *llm.UserLLMRules, taxonomy *domain.CategoryTaxonomy) *domain.ClassificationPipelineResult {
+	resp, err := p.llmClient.ClassifyEmailWithUserRules(ctx, input.Email, input.Body, userRules)
 	if err != nil {
 		// Fallback to default on LLM error
 		return &domain.ClassificationPipelineResult{
 			Category:   domain.CategoryOther,
 			Priority:   domain.PriorityNormal,
 			Source:     domain.ClassificationSourceLLM,
+			Stage:      domain.ClassificationStageLLMFailed,
 			Confidence: 0.5,
 			LLMUsed:    true,
-		}, nil
+			Error:      err.Error(),
+		}
 	}
+	return llmResponseToPipelineResult(resp, taxonomy)
+}
 
-	// Validate and convert response to domain types
-	validatedCategory := ValidateCategory(resp.Category)
-	category := domain.EmailCategory(validatedCategory)
+// llmResponseToPipelineResult validates an LLM response against the user's taxonomy and converts it to domain types.
+func llmResponseToPipelineResult(resp *llm.EnhancedClassificationResponse, taxonomy *domain.CategoryTaxonomy) *domain.ClassificationPipelineResult {
+	category := taxonomy.Resolve(domain.EmailCategory(resp.Category))
 
 	// Validate priority (0.0 ~ 1.0)
 	validatedPriority := ValidatePriority(resp.Priority)
@@ -389,6 +545,7 @@ func (p *Pipeline) classifyByLLMWithUserRules(ctx context.Context, input *Classi
 		Category:   category,
 		Priority:   priority,
 		Source:     domain.ClassificationSourceLLM,
+		Stage:      domain.ClassificationStageLLM,
 		Confidence: resp.Score,
 		LLMUsed:    true,
 	}
@@ -402,7 +559,7 @@ func (p *Pipeline) classifyByLLMWithUserRules(ctx context.Context, input *Classi
 		}
 	}
 
-	return result, nil
+	return result
 }
 
 // =============================================================================
@@ -410,12 +567,13 @@ func (p *Pipeline) classifyByLLMWithUserRules(ctx context.Context, input *Classi
 // =============================================================================
 
 // scoreResultToPipelineResult converts ScoreClassifierResult to ClassificationPipelineResult.
-func (p *Pipeline) scoreResultToPipelineResult(result *ScoreClassifierResult) *domain.ClassificationPipelineResult {
+func (p *Pipeline) scoreResultToPipelineResult(result *ScoreClassifierResult, stage domain.ClassificationStage) *domain.ClassificationPipelineResult {
 	pipelineResult := &domain.ClassificationPipelineResult{
 		Category:    result.Category,
 		SubCategory: result.SubCategory,
 		Priority:    result.Priority,
 		Source:      domain.ClassificationSourceHeader,
+		Stage:       stage,
 		Confidence:  result.Score,
 		LLMUsed:     result.LLMUsed,
 	}
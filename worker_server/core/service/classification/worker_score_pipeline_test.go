@@ -468,3 +468,112 @@ func TestESPDetection(t *testing.T) {
 		})
 	}
 }
+
+// TestSecurityAnalyzer tests phishing/spoofing signal detection.
+func TestSecurityAnalyzer(t *testing.T) {
+	analyzer := NewSecurityAnalyzer()
+	name := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		input       *ScoreClassifierInput
+		wantSignals []domain.SecuritySignalCode
+		wantLevel   domain.SecurityRiskLevel
+	}{
+		{
+			name: "Legitimate sender with passing auth",
+			input: &ScoreClassifierInput{
+				Email: &domain.Email{FromEmail: "service@paypal.com", FromName: name("PayPal")},
+				Headers: &out.ProviderClassificationHeaders{
+					AuthenticationResults: []string{"mx.google.com; spf=pass smtp.mailfrom=paypal.com; dkim=pass header.d=paypal.com; dmarc=pass header.from=paypal.com"},
+				},
+				Body: `<a href="https://www.paypal.com/signin">www.paypal.com</a>`,
+			},
+			wantLevel: domain.SecurityRiskNone,
+		},
+		{
+			name: "Lookalike domain with failed DMARC",
+			input: &ScoreClassifierInput{
+				Email: &domain.Email{FromEmail: "service@paypa1.com", FromName: name("PayPal Support")},
+				Headers: &out.ProviderClassificationHeaders{
+					AuthenticationResults: []string{"mx.google.com; spf=fail smtp.mailfrom=paypa1.com; dmarc=fail header.from=paypa1.com"},
+				},
+			},
+			wantSignals: []domain.SecuritySignalCode{domain.SignalLookalikeDomain, domain.SignalDMARCFail, domain.SignalSPFFail, domain.SignalDisplayNameMismatch},
+			wantLevel:   domain.SecurityRiskHigh,
+		},
+		{
+			name: "Display name shows another address",
+			input: &ScoreClassifierInput{
+				Email: &domain.Email{FromEmail: "x@evil.example", FromName: name("ceo@company.com")},
+			},
+			wantSignals: []domain.SecuritySignalCode{domain.SignalDisplayNameMismatch},
+			wantLevel:   domain.SecurityRiskLow,
+		},
+		{
+			name: "Link text mismatch and IP link",
+			input: &ScoreClassifierInput{
+				Email: &domain.Email{FromEmail: "noreply@shop.example"},
+				Body:  `<a href="http://192.168.10.5/login">https://bank.example.com</a>`,
+			},
+			wantSignals: []domain.SecuritySignalCode{domain.SignalLinkTextMismatch, domain.SignalSuspiciousURL},
+			wantLevel:   domain.SecurityRiskMedium,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzer.Analyze(tt.input)
+			for _, code := range tt.wantSignals {
+				if !result.HasSignal(code) {
+					t.Errorf("missing signal %s, got %+v", code, result.Signals)
+				}
+			}
+			if len(tt.wantSignals) == 0 && len(result.Signals) > 0 {
+				t.Errorf("unexpected signals %+v", result.Signals)
+			}
+			if result.RiskLevel != tt.wantLevel {
+				t.Errorf("risk level = %s, want %s (score %.2f)", result.RiskLevel, tt.wantLevel, result.RiskScore)
+			}
+		})
+	}
+}
+
+// TestPipelineHeuristicMode tests that a pipeline without LLM records the producing stage.
+func TestPipelineHeuristicMode(t *testing.T) {
+	pipeline := NewPipeline(nil, nil, nil, nil)
+
+	inputs := []*ClassifyInput{
+		{
+			Email: &domain.Email{ID: 1, FromEmail: "newsletter@example.com", Subject: "Weekly Newsletter"},
+			Headers: &out.ProviderClassificationHeaders{
+				ListUnsubscribe: "<mailto:unsubscribe@example.com>",
+			},
+		},
+		{
+			Email: &domain.Email{ID: 2, FromEmail: "friend@gmail.com", Subject: "Lunch tomorrow?"},
+		},
+	}
+	wantStages := []domain.ClassificationStage{domain.ClassificationStageRFC, domain.ClassificationStageDefault}
+
+	results := pipeline.ClassifyBatch(context.Background(), inputs[0].UserID, inputs)
+	if len(results) != len(inputs) {
+		t.Fatalf("expected %d results, got %d", len(inputs), len(results))
+	}
+	for i, result := range results {
+		if result == nil {
+			t.Fatalf("result %d is nil", i)
+		}
+		if result.Stage != wantStages[i] {
+			t.Errorf("result %d stage = %s, want %s", i, result.Stage, wantStages[i])
+		}
+		if result.LLMUsed {
+			t.Errorf("result %d used LLM in heuristic mode", i)
+		}
+	}
+
+	single, err := pipeline.Classify(context.Background(), inputs[1])
+	if err != nil || single.Stage != domain.ClassificationStageDefault {
+		t.Errorf("Classify() = %+v, %v; want default stage", single, err)
+	}
+}
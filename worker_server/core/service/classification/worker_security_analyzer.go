@@ -0,0 +1,374 @@
+package classification
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/pkg/mailparse"
+)
+
+// =============================================================================
+// Security Analyzer (Phishing / Spoofing Stage)
+// =============================================================================
+//
+// 분류 결과(카테고리)와 별개로 메일의 피싱/스푸핑 위험도를 계산한다.
+//   - 헤더 기반 (동기화 시점): SPF/DKIM/DMARC, 유사 도메인, 표시 이름 불일치, Reply-To 불일치
+//   - 본문 기반 (분류 시점): 의심 URL, 링크 텍스트와 실제 주소 불일치
+//
+// 각 신호는 가중치를 가지며 합계(최대 1.0)로 위험도를 결정한다.
+
+// Signal weights
+const (
+	weightDMARCFail           = 0.5
+	weightSPFFail             = 0.2
+	weightDKIMFail            = 0.2
+	weightLookalikeDomain     = 0.5
+	weightDisplayNameMismatch = 0.3
+	weightReplyToMismatch     = 0.15
+	weightSuspiciousURL       = 0.25
+	weightLinkTextMismatch    = 0.3
+)
+
+// protectedBrands maps frequently impersonated brands to their legitimate domains.
+var protectedBrands = map[string][]string{
+	"paypal":    {"paypal.com", "paypal.co.kr"},
+	"google":    {"google.com", "gmail.com", "googlemail.com", "youtube.com", "google.co.kr", "googleusercontent.com", "googlegroups.com"},
+	"apple":     {"apple.com", "icloud.com"},
+	"microsoft": {"microsoft.com", "microsoftonline.com", "outlook.com", "live.com", "office.com", "hotmail.com"},
+	"amazon":    {"amazon.com", "amazon.co.jp", "amazonaws.com", "amazonses.com"},
+	"netflix":   {"netflix.com"},
+	"github":    {"github.com", "githubusercontent.com"},
+	"facebook":  {"facebook.com", "facebookmail.com", "meta.com"},
+	"instagram": {"instagram.com", "mail.instagram.com"},
+	"linkedin":  {"linkedin.com"},
+	"dropbox":   {"dropbox.com", "dropboxmail.com"},
+	"docusign":  {"docusign.com", "docusign.net"},
+	"naver":     {"naver.com", "navercorp.com"},
+	"kakao":     {"kakao.com", "kakaocorp.com", "daum.net"},
+	"coupang":   {"coupang.com"},
+	"toss":      {"toss.im", "tossbank.com", "tosspayments.com"},
+	"stripe":    {"stripe.com"},
+	"slack":     {"slack.com"},
+}
+
+// homoglyphs maps characters commonly substituted in lookalike domains.
+var homoglyphReplacer = strings.NewReplacer(
+	"rn", "m", "vv", "w", "0", "o", "1", "l", "3", "e", "5", "s", "@", "a",
+)
+
+// secondLevelSuffixes are public suffixes with two labels (e.g. co.kr).
+var secondLevelSuffixes = map[string]bool{
+	"co.kr": true, "or.kr": true, "go.kr": true, "ac.kr": true, "ne.kr": true,
+	"co.uk": true, "org.uk": true, "ac.uk": true,
+	"co.jp": true, "ne.jp": true, "or.jp": true,
+	"com.au": true, "com.br": true, "com.cn": true,
+}
+
+var (
+	anchorRe  = regexp.MustCompile(`(?is)<a\s[^>]*href\s*=\s*["']([^"']+)["'][^>]*>(.*?)</a>`)
+	rawURLRe  = regexp.MustCompile(`https?://[^\s"'<>()]+`)
+	tagRe     = regexp.MustCompile(`<[^>]+>`)
+	emailInRe = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@([A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+)
+
+// maxURLsToCheck limits URL inspection per email.
+const maxURLsToCheck = 50
+
+// SecurityAnalyzer detects phishing and spoofing indicators.
+type SecurityAnalyzer struct {
+	brands map[string][]string
+}
+
+// NewSecurityAnalyzer creates a new security analyzer.
+func NewSecurityAnalyzer() *SecurityAnalyzer {
+	return &SecurityAnalyzer{brands: protectedBrands}
+}
+
+// Analyze runs all checks available for the input.
+// Headers가 없으면 헤더 기반 검사를, Body가 없으면 URL 검사를 건너뛴다.
+func (a *SecurityAnalyzer) Analyze(input *ScoreClassifierInput) *domain.EmailSecurity {
+	result := &domain.EmailSecurity{AnalyzedAt: time.Now()}
+	if input == nil || input.Email == nil {
+		result.Recalculate()
+		return result
+	}
+	result.EmailID = input.Email.ID
+
+	fromDomain := emailDomain(input.Email.FromEmail)
+
+	a.checkAuthentication(input, result)
+	a.checkLookalike(fromDomain, result)
+	a.checkDisplayName(input.Email, fromDomain, result)
+	a.checkReplyTo(input, fromDomain, result)
+	if input.Body != "" {
+		a.checkURLs(input.Body, result)
+	}
+
+	result.Recalculate()
+	return result
+}
+
+// checkAuthentication evaluates SPF/DKIM/DMARC from Authentication-Results.
+func (a *SecurityAnalyzer) checkAuthentication(input *ScoreClassifierInput, result *domain.EmailSecurity) {
+	h := input.Headers
+	if h == nil || (len(h.AuthenticationResults) == 0 && h.ReceivedSPF == "") {
+		return
+	}
+
+	auth := mailparse.ParseAuthenticationResults(h.AuthenticationResults, h.ReceivedSPF)
+	if auth.SPF != nil {
+		result.SPF = auth.SPF.Result
+		if auth.SPF.Failed() {
+			result.AddSignal(domain.SignalSPFFail, "spf="+auth.SPF.Result, weightSPFFail)
+		}
+	}
+	if auth.DKIM != nil {
+		result.DKIM = auth.DKIM.Result
+		if auth.DKIM.Failed() {
+			result.AddSignal(domain.SignalDKIMFail, "dkim="+auth.DKIM.Result, weightDKIMFail)
+		}
+	}
+	if auth.DMARC != nil {
+		result.DMARC = auth.DMARC.Result
+		if auth.DMARC.Failed() {
+			result.AddSignal(domain.SignalDMARCFail, "dmarc="+auth.DMARC.Result, weightDMARCFail)
+		}
+	}
+}
+
+// checkLookalike flags sender domains imitating a protected brand.
+func (a *SecurityAnalyzer) checkLookalike(fromDomain string, result *domain.EmailSecurity) {
+	if brand := a.lookalikeBrand(fromDomain); brand != "" {
+		result.AddSignal(domain.SignalLookalikeDomain, fromDomain+" imitates "+brand, weightLookalikeDomain)
+	}
+}
+
+// checkDisplayName flags display names that claim another address or brand.
+func (a *SecurityAnalyzer) checkDisplayName(email *domain.Email, fromDomain string, result *domain.EmailSecurity) {
+	if email.FromName == nil || fromDomain == "" {
+		return
+	}
+	name := strings.ToLower(*email.FromName)
+
+	// "support@paypal.com" <attacker@evil.com>
+	if m := emailInRe.FindStringSubmatch(name); m != nil {
+		if registrableDomain(m[1]) != registrableDomain(fromDomain) {
+			result.AddSignal(domain.SignalDisplayNameMismatch, "display name shows "+m[0], weightDisplayNameMismatch)
+			return
+		}
+	}
+
+	// "PayPal Support" <someone@gmail.com>
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		if _, ok := a.brands[word]; ok && !a.isBrandDomain(word, fromDomain) {
+			result.AddSignal(domain.SignalDisplayNameMismatch, "display name claims "+word, weightDisplayNameMismatch)
+			return
+		}
+	}
+}
+
+// checkReplyTo flags replies redirected to a different organization.
+func (a *SecurityAnalyzer) checkReplyTo(input *ScoreClassifierInput, fromDomain string, result *domain.EmailSecurity) {
+	replyTo := ""
+	if input.Headers != nil {
+		replyTo = input.Headers.ReplyTo
+	}
+	if replyTo == "" && input.Email.ReplyTo != nil {
+		replyTo = *input.Email.ReplyTo
+	}
+	if replyTo == "" || fromDomain == "" {
+		return
+	}
+
+	m := emailInRe.FindStringSubmatch(replyTo)
+	if m == nil {
+		return
+	}
+	if registrableDomain(m[1]) != registrableDomain(fromDomain) {
+		result.AddSignal(domain.SignalReplyToMismatch, "reply-to "+strings.ToLower(m[1]), weightReplyToMismatch)
+	}
+}
+
+// checkURLs inspects links in the body.
+func (a *SecurityAnalyzer) checkURLs(body string, result *domain.EmailSecurity) {
+	checked := 0
+
+	// <a href="...">text</a>: 링크 텍스트가 다른 도메인을 보여주는 경우
+	for _, m := range anchorRe.FindAllStringSubmatch(body, maxURLsToCheck) {
+		href, text := m[1], strings.TrimSpace(tagRe.ReplaceAllString(m[2], ""))
+		hrefHost := urlHost(href)
+		if hrefHost == "" {
+			continue
+		}
+		checked++
+
+		if textHost := displayedHost(text); textHost != "" &&
+			registrableDomain(textHost) != registrableDomain(hrefHost) {
+			result.AddSignal(domain.SignalLinkTextMismatch, text+" -> "+hrefHost, weightLinkTextMismatch)
+		}
+		if reason := a.suspiciousHost(hrefHost, href); reason != "" {
+			result.AddSignal(domain.SignalSuspiciousURL, reason, weightSuspiciousURL)
+		}
+	}
+
+	if checked >= maxURLsToCheck {
+		return
+	}
+	for _, raw := range rawURLRe.FindAllString(body, maxURLsToCheck-checked) {
+		if host := urlHost(raw); host != "" {
+			if reason := a.suspiciousHost(host, raw); reason != "" {
+				result.AddSignal(domain.SignalSuspiciousURL, reason, weightSuspiciousURL)
+				return
+			}
+		}
+	}
+}
+
+// suspiciousHost returns a reason if the link host looks malicious.
+func (a *SecurityAnalyzer) suspiciousHost(host, rawURL string) string {
+	switch {
+	case net.ParseIP(strings.Trim(host, "[]")) != nil:
+		return "ip address link " + host
+	case strings.HasPrefix(host, "xn--") || strings.Contains(host, ".xn--"):
+		return "punycode link " + host
+	}
+	if u, err := url.Parse(rawURL); err == nil && u.User != nil {
+		return "credentials in link " + host
+	}
+	if brand := a.lookalikeBrand(host); brand != "" {
+		return "lookalike link " + host + " imitates " + brand
+	}
+	return ""
+}
+
+// lookalikeBrand returns the imitated brand when domain resembles but is not a brand domain.
+func (a *SecurityAnalyzer) lookalikeBrand(host string) string {
+	if host == "" {
+		return ""
+	}
+	reg := registrableDomain(host)
+	label := strings.SplitN(reg, ".", 2)[0]
+	if len(label) < 4 {
+		return ""
+	}
+	normalized := homoglyphReplacer.Replace(label)
+
+	for brand := range a.brands {
+		if a.isBrandDomain(brand, host) {
+			continue
+		}
+		switch {
+		case normalized == brand && label != brand:
+			return brand // paypa1.com, rnicrosoft.com
+		case len(brand) >= 6 && levenshtein(label, brand) == 1:
+			return brand // paypall.com, githb.com
+		case label != brand && hasHyphenPart(label, brand):
+			return brand // paypal-secure.com, secure-paypal.com
+		}
+	}
+	return ""
+}
+
+// isBrandDomain checks whether host belongs to a legitimate domain of brand.
+func (a *SecurityAnalyzer) isBrandDomain(brand, host string) bool {
+	for _, d := range a.brands[brand] {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+func emailDomain(addr string) string {
+	if i := strings.LastIndex(addr, "@"); i >= 0 {
+		return strings.ToLower(strings.TrimSpace(addr[i+1:]))
+	}
+	return ""
+}
+
+// registrableDomain returns the eTLD+1 (approximation without a full PSL).
+func registrableDomain(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	labels := strings.Split(host, ".")
+	if len(labels) <= 2 {
+		return host
+	}
+	n := 2
+	if secondLevelSuffixes[strings.Join(labels[len(labels)-2:], ".")] {
+		n = 3
+	}
+	return strings.Join(labels[len(labels)-n:], ".")
+}
+
+func urlHost(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// displayedHost extracts a domain from link text such as "www.paypal.com" or "https://paypal.com/login".
+func displayedHost(text string) string {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if strings.ContainsAny(text, " \t\n") || !strings.Contains(text, ".") {
+		return ""
+	}
+	if !strings.HasPrefix(text, "http://") && !strings.HasPrefix(text, "https://") {
+		text = "https://" + text
+	}
+	host := urlHost(text)
+	if host == "" || !strings.Contains(host, ".") {
+		return ""
+	}
+	// TLD가 문자로만 구성된 경우만 도메인으로 인정 (예: "v1.2" 같은 텍스트 제외)
+	tld := host[strings.LastIndex(host, ".")+1:]
+	for _, r := range tld {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+	return host
+}
+
+func hasHyphenPart(label, part string) bool {
+	for _, p := range strings.Split(label, "-") {
+		if p == part {
+			return true
+		}
+	}
+	return false
+}
+
+func levenshtein(a, b string) int {
+	if a == b {
+		return 0
+	}
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
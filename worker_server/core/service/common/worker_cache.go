@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/goccy/go-json"
@@ -34,7 +36,15 @@ type CacheConfig struct {
 	AIResultTTL time.Duration // AI 결과 캐시 TTL (기본 1시간)
 
 	// MongoDB TTL
-	MongoBodyTTLDays int // MongoDB 본문 TTL (기본 30일)
+	MongoBodyTTLDays   int // MongoDB 본문 TTL (기본 30일)
+	ArchiveBodyTTLDays int // 30일 지난 메일 본문 TTL (기본 7일, 접근 시 연장)
+
+	// Body cache limits
+	MaxBodySize            int           // 이보다 큰 본문은 캐시하지 않음 (기본 2MB)
+	MaxConnectionCacheSize int64         // 연결당 MongoDB 캐시 상한, 초과 시 LRU 제거 (기본 256MB)
+	TouchInterval          time.Duration // last_accessed_at 갱신 최소 간격 (기본 1시간)
+	PrefetchInboxSize      int           // 인박스 첫 페이지 본문 prefetch 개수 (기본 20)
+	MetadataOnlyBodyTTL    time.Duration // metadata-only 연결의 Redis 본문 TTL (기본 5분, MongoDB에는 저장 안 함)
 
 	// Compression
 	CompressionThreshold int // 압축 임계값 (기본 1KB)
@@ -44,12 +54,18 @@ type CacheConfig struct {
 // 최적화: TTL 증가로 캐시 히트율 향상
 func DefaultCacheConfig() *CacheConfig {
 	return &CacheConfig{
-		BodyTTL:              30 * time.Minute, // 10분 → 30분 (본문은 잘 변하지 않음)
-		ListTTL:              15 * time.Minute, // 5분 → 15분 (목록 캐시 유지)
-		MetaTTL:              20 * time.Minute, // 10분 → 20분
-		AIResultTTL:          2 * time.Hour,    // 1시간 → 2시간 (AI 결과는 고정)
-		MongoBodyTTLDays:     30,
-		CompressionThreshold: 2048, // 1KB → 2KB (작은 데이터는 압축 오버헤드가 더 큼)
+		BodyTTL:                30 * time.Minute, // 10분 → 30분 (본문은 잘 변하지 않음)
+		ListTTL:                15 * time.Minute, // 5분 → 15분 (목록 캐시 유지)
+		MetaTTL:                20 * time.Minute, // 10분 → 20분
+		AIResultTTL:            2 * time.Hour,    // 1시간 → 2시간 (AI 결과는 고정)
+		MongoBodyTTLDays:       30,
+		ArchiveBodyTTLDays:     7,
+		MaxBodySize:            2 * 1024 * 1024,
+		MaxConnectionCacheSize: 256 * 1024 * 1024,
+		TouchInterval:          time.Hour,
+		PrefetchInboxSize:      20,
+		MetadataOnlyBodyTTL:    5 * time.Minute,
+		CompressionThreshold:   2048, // 1KB → 2KB (작은 데이터는 압축 오버헤드가 더 큼)
 	}
 }
 
@@ -58,11 +74,22 @@ func DefaultCacheConfig() *CacheConfig {
 // =============================================================================
 
 const (
-	keyPrefixBody     = "body:"     // body:{email_id}
-	keyPrefixList     = "list:"     // list:{user_id}:{folder}:{page}
-	keyPrefixMeta     = "meta:"     // meta:{email_id}
-	keyPrefixAI       = "ai:"       // ai:{email_id}
-	keyPrefixPrefetch = "prefetch:" // prefetch:{user_id}
+	keyPrefixBody        = "body:"        // body:{email_id}
+	keyPrefixList        = "list:"        // list:{user_id}:{folder}:{page}
+	keyPrefixMeta        = "meta:"        // meta:{email_id}
+	keyPrefixAI          = "ai:"          // ai:{email_id}
+	keyPrefixPrefetch    = "prefetch:"    // prefetch:{user_id}
+	keyPrefixTranslation = "translation:" // translation:{email_id}:{lang}
+	keyPrefixAudio       = "audio:"       // audio:{email_id}:{variant} → blob id
+)
+
+const (
+	// prefetchCooldown - 같은 사용자의 인박스 prefetch 재실행 간격
+	prefetchCooldown = 2 * time.Minute
+	// evictInterval - 연결별 LRU 제거 검사 간격 (매 저장마다 집계하지 않음)
+	evictInterval = 5 * time.Minute
+	// translationTTL - 번역 결과 캐시 TTL (본문이 바뀌지 않으므로 길게 유지)
+	translationTTL = 7 * 24 * time.Hour
 )
 
 func bodyKey(emailID int64) string {
@@ -81,6 +108,14 @@ func aiKey(emailID int64) string {
 	return fmt.Sprintf("%s%d", keyPrefixAI, emailID)
 }
 
+func translationKey(emailID int64, lang string) string {
+	return fmt.Sprintf("%s%d:%s", keyPrefixTranslation, emailID, lang)
+}
+
+func audioKey(emailID int64, variant string) string {
+	return fmt.Sprintf("%s%d:%s", keyPrefixAudio, emailID, variant)
+}
+
 // =============================================================================
 // Cache Service - 3-Tier Caching
 // =============================================================================
@@ -95,6 +130,11 @@ type EmailListFetcher interface {
 	ListEmails(ctx context.Context, filter *domain.EmailFilter) ([]*domain.Email, int, error)
 }
 
+// StorageGuard reports connections over their storage cap (storage.Service).
+type StorageGuard interface {
+	MetadataOnly(ctx context.Context, connectionID int64) bool
+}
+
 // CacheService provides 3-tier caching: Redis -> MongoDB -> Provider
 type CacheService struct {
 	redis          *redis.Client
@@ -104,13 +144,17 @@ type CacheService struct {
 	provider       out.EmailProviderPort
 	oauthService   OAuthTokenProvider
 	emailFetcher   EmailListFetcher // for prefetching email lists
-	config         *CacheConfig
+	config         atomic.Pointer[CacheConfig] // hot reload 시 교체
+	storageGuard   StorageGuard                // metadata-only 연결은 MongoDB에 저장하지 않음
 
 	// Singleflight for deduplicating concurrent requests
 	// 최적화: Tier 2 (MongoDB), Tier 3 (Provider) 각각 적용
 	mongoFlight singleflight.Group // MongoDB cache miss 중복 방지
 	bodyFlight  singleflight.Group // Provider fetch 중복 방지
 
+	// lastEvict tracks the last LRU check per connection (connectionID → time.Time)
+	lastEvict sync.Map
+
 	// Metrics
 	metrics *CacheMetrics
 }
@@ -138,7 +182,7 @@ func NewCacheService(
 	if config == nil {
 		config = DefaultCacheConfig()
 	}
-	return &CacheService{
+	s := &CacheService{
 		redis:          redis,
 		mongoRepo:      mongoRepo,
 		emailRepo:       emailRepo,
@@ -146,9 +190,26 @@ func NewCacheService(
 		provider:       provider,
 		oauthService:   oauthService,
 		emailFetcher:   emailFetcher,
-		config:         config,
 		metrics:        &CacheMetrics{},
 	}
+	s.config.Store(config)
+	return s
+}
+
+func (s *CacheService) cfg() *CacheConfig {
+	return s.config.Load()
+}
+
+// Config returns a copy of the current cache configuration.
+func (s *CacheService) Config() CacheConfig {
+	return *s.cfg()
+}
+
+// SetConfig replaces the cache configuration (TTL 변경은 이후 저장되는 항목부터 적용)
+func (s *CacheService) SetConfig(config *CacheConfig) {
+	if config != nil {
+		s.config.Store(config)
+	}
 }
 
 // SetEmailFetcher sets the email fetcher (for late binding to avoid circular deps)
@@ -181,6 +242,11 @@ func (s *CacheService) SetOAuthService(oauthService OAuthTokenProvider) {
 	s.oauthService = oauthService
 }
 
+// SetStorageGuard skips the MongoDB tier for connections in metadata-only mode (Redis만 짧게 캐시).
+func (s *CacheService) SetStorageGuard(guard StorageGuard) {
+	s.storageGuard = guard
+}
+
 // GetAttachments retrieves attachment metadata for an email
 func (s *CacheService) GetAttachments(ctx context.Context, emailID int64) ([]*out.EmailAttachmentEntity, error) {
 	if s.attachmentRepo == nil {
@@ -193,8 +259,9 @@ func (s *CacheService) GetAttachments(ctx context.Context, emailID int64) ([]*ou
 // Body Cache - 3 Tier
 // =============================================================================
 
-// GetBody retrieves email body with 3-tier caching
-// 30일 정책: 30일 이내 메일만 MongoDB에 저장, 오래된 메일은 API에서만 조회
+// GetBody retrieves email body with 3-tier caching (read-through)
+// 30일 이내 메일은 MongoBodyTTLDays, 오래된 메일은 ArchiveBodyTTLDays(접근 시 연장)로 MongoDB에 저장.
+// MaxBodySize를 넘는 본문은 캐시하지 않고, 연결별 용량 초과 시 오래 안 읽은 본문부터 제거한다.
 // 최적화: MongoDB와 Provider 모두 singleflight 적용으로 중복 요청 방지
 func (s *CacheService) GetBody(ctx context.Context, emailID int64, connectionID int64) (*domain.EmailBody, error) {
 	// Tier 1: Redis (단기 캐시 - 모든 메일) - 락 없이 빠른 확인
@@ -218,7 +285,7 @@ func (s *CacheService) GetBody(ctx context.Context, emailID int64, connectionID
 		if mongoErr == nil && mongoBody != nil && s.hasBodyContent(mongoBody) {
 			s.metrics.MongoHits++
 			// Cache to Redis (동기 - 다음 요청 빠르게)
-			s.cacheBodyToRedis(ctx, emailID, mongoBody)
+			s.cacheBodyToRedis(ctx, emailID, mongoBody, s.bodyRedisTTL(ctx, connectionID))
 			return mongoBody, nil
 		}
 		s.metrics.MongoMisses++
@@ -236,22 +303,12 @@ func (s *CacheService) GetBody(ctx context.Context, emailID int64, connectionID
 				return nil, fetchErr
 			}
 
-			// 30일 정책 적용
-			shouldSaveToMongo := true
-			if s.emailRepo != nil {
-				if entity, _ := s.emailRepo.GetByID(ctx, emailID); entity != nil {
-					thirtyDaysAgo := time.Now().AddDate(0, 0, -s.config.MongoBodyTTLDays)
-					if entity.ReceivedAt.Before(thirtyDaysAgo) {
-						shouldSaveToMongo = false
-					}
-				}
-			}
+			// 크기 상한 초과 시 캐시하지 않음
+			if s.isCacheable(fetchedBody) {
+				// Redis 저장 (동기)
+				s.cacheBodyToRedis(ctx, emailID, fetchedBody, s.bodyRedisTTL(ctx, connectionID))
 
-			// Redis 저장 (동기)
-			s.cacheBodyToRedis(ctx, emailID, fetchedBody)
-
-			// MongoDB 저장 (비동기)
-			if shouldSaveToMongo {
+				// MongoDB 저장 (비동기)
 				go s.cacheBodyToMongo(context.Background(), emailID, connectionID, fetchedBody)
 			}
 
@@ -302,7 +359,7 @@ func (s *CacheService) getBodyFromRedis(ctx context.Context, emailID int64) (*do
 }
 
 // cacheBodyToRedis stores body in Redis cache
-func (s *CacheService) cacheBodyToRedis(ctx context.Context, emailID int64, body *domain.EmailBody) error {
+func (s *CacheService) cacheBodyToRedis(ctx context.Context, emailID int64, body *domain.EmailBody, ttl time.Duration) error {
 	if s.redis == nil {
 		return nil
 	}
@@ -313,7 +370,7 @@ func (s *CacheService) cacheBodyToRedis(ctx context.Context, emailID int64, body
 	}
 
 	// Compress if large enough
-	if len(data) > s.config.CompressionThreshold {
+	if len(data) > s.cfg().CompressionThreshold {
 		data, err = compress(data)
 		if err != nil {
 			return err
@@ -321,7 +378,21 @@ func (s *CacheService) cacheBodyToRedis(ctx context.Context, emailID int64, body
 	}
 
 	key := bodyKey(emailID)
-	return s.redis.Set(ctx, key, data, s.config.BodyTTL).Err()
+	return s.redis.Set(ctx, key, data, ttl).Err()
+}
+
+// bodyRedisTTL returns the Redis body TTL of a connection.
+// metadata-only 연결은 본문을 열람 시에만 가져오므로 짧게만 캐시한다.
+func (s *CacheService) bodyRedisTTL(ctx context.Context, connectionID int64) time.Duration {
+	if s.metadataOnly(ctx, connectionID) && s.cfg().MetadataOnlyBodyTTL > 0 {
+		return s.cfg().MetadataOnlyBodyTTL
+	}
+	return s.cfg().BodyTTL
+}
+
+// metadataOnly reports whether bodies of the connection must not be stored or prefetched.
+func (s *CacheService) metadataOnly(ctx context.Context, connectionID int64) bool {
+	return s.storageGuard != nil && s.storageGuard.MetadataOnly(ctx, connectionID)
 }
 
 // getBodyFromMongo retrieves body from MongoDB
@@ -335,6 +406,19 @@ func (s *CacheService) getBodyFromMongo(ctx context.Context, emailID int64) (*do
 		return nil, err
 	}
 
+	// LRU: 접근 시각 갱신 + 만료 연장 (매 조회마다 쓰지 않도록 TouchInterval 적용)
+	if time.Since(entity.LastAccessedAt) > s.cfg().TouchInterval {
+		ttlDays := entity.TTLDays
+		if ttlDays <= 0 {
+			ttlDays = s.cfg().MongoBodyTTLDays
+		}
+		go func() {
+			if err := s.mongoRepo.TouchBody(context.Background(), emailID, time.Now().AddDate(0, 0, ttlDays)); err != nil {
+				log.Printf("[CacheService.getBodyFromMongo] Failed to touch email %d: %v", emailID, err)
+			}
+		}()
+	}
+
 	return &domain.EmailBody{
 		EmailID:  entity.EmailID,
 		TextBody: entity.Text,
@@ -347,27 +431,79 @@ func (s *CacheService) cacheBodyToMongo(ctx context.Context, emailID int64, conn
 	if s.mongoRepo == nil {
 		return nil
 	}
+	if s.metadataOnly(ctx, connectionID) {
+		return nil
+	}
 
 	// Get external ID from mail repo
 	externalID := ""
+	ttlDays := s.cfg().MongoBodyTTLDays
 	if s.emailRepo != nil {
 		if entity, err := s.emailRepo.GetByID(ctx, emailID); err == nil && entity != nil {
 			externalID = entity.ExternalID
+			ttlDays = s.bodyTTLDays(entity.ReceivedAt)
 		}
 	}
 
+	now := time.Now()
 	entity := &out.MailBodyEntity{
-		EmailID:      emailID,
-		ConnectionID: connectionID,
-		ExternalID:   externalID,
-		HTML:         body.HTMLBody,
-		Text:         body.TextBody,
-		CachedAt:     time.Now(),
-		ExpiresAt:    time.Now().AddDate(0, 0, s.config.MongoBodyTTLDays),
-		TTLDays:      s.config.MongoBodyTTLDays,
+		EmailID:        emailID,
+		ConnectionID:   connectionID,
+		ExternalID:     externalID,
+		HTML:           body.HTMLBody,
+		Text:           body.TextBody,
+		CachedAt:       now,
+		ExpiresAt:      now.AddDate(0, 0, ttlDays),
+		TTLDays:        ttlDays,
+		LastAccessedAt: now,
+	}
+
+	if err := s.mongoRepo.SaveBody(ctx, entity); err != nil {
+		return err
+	}
+
+	s.enforceConnectionCap(ctx, connectionID)
+	return nil
+}
+
+// bodyTTLDays returns the MongoDB TTL for a body received at receivedAt.
+// 오래된 메일은 짧은 TTL로 저장하고 다시 읽힐 때마다 연장한다.
+func (s *CacheService) bodyTTLDays(receivedAt time.Time) int {
+	if s.cfg().ArchiveBodyTTLDays > 0 && receivedAt.Before(time.Now().AddDate(0, 0, -s.cfg().MongoBodyTTLDays)) {
+		return s.cfg().ArchiveBodyTTLDays
 	}
+	return s.cfg().MongoBodyTTLDays
+}
 
-	return s.mongoRepo.SaveBody(ctx, entity)
+// isCacheable returns false for bodies larger than MaxBodySize.
+func (s *CacheService) isCacheable(body *domain.EmailBody) bool {
+	if s.cfg().MaxBodySize <= 0 {
+		return true
+	}
+	return len(body.HTMLBody)+len(body.TextBody) <= s.cfg().MaxBodySize
+}
+
+// enforceConnectionCap evicts least recently accessed bodies when a connection exceeds its cap.
+// 저장마다 집계하지 않도록 연결별로 evictInterval에 한 번만 검사한다.
+func (s *CacheService) enforceConnectionCap(ctx context.Context, connectionID int64) {
+	if s.cfg().MaxConnectionCacheSize <= 0 || connectionID == 0 {
+		return
+	}
+
+	now := time.Now()
+	if last, ok := s.lastEvict.Load(connectionID); ok && now.Sub(last.(time.Time)) < evictInterval {
+		return
+	}
+	s.lastEvict.Store(connectionID, now)
+
+	evicted, err := s.mongoRepo.EvictLRU(ctx, connectionID, s.cfg().MaxConnectionCacheSize)
+	if err != nil {
+		log.Printf("[CacheService.enforceConnectionCap] Failed for connection %d: %v", connectionID, err)
+		return
+	}
+	if evicted > 0 {
+		log.Printf("[CacheService.enforceConnectionCap] Evicted %d bodies for connection %d", evicted, connectionID)
+	}
 }
 
 // fetchBodyFromProvider fetches body from Gmail/Outlook API
@@ -487,7 +623,7 @@ func (s *CacheService) CacheList(ctx context.Context, userID string, folder stri
 	}
 
 	key := listKey(userID, folder, page)
-	return s.redis.Set(ctx, key, data, s.config.ListTTL).Err()
+	return s.redis.Set(ctx, key, data, s.cfg().ListTTL).Err()
 }
 
 // InvalidateList invalidates list cache for a user/folder
@@ -607,7 +743,85 @@ func (s *CacheService) CacheAIResult(ctx context.Context, emailID int64, result
 	}
 
 	key := aiKey(emailID)
-	return s.redis.Set(ctx, key, data, s.config.AIResultTTL).Err()
+	return s.redis.Set(ctx, key, data, s.cfg().AIResultTTL).Err()
+}
+
+// =============================================================================
+// Translation Cache
+// =============================================================================
+
+// CachedTranslation represents a cached email translation
+type CachedTranslation struct {
+	SourceLanguage string    `json:"source_language,omitempty"`
+	Subject        string    `json:"subject"`
+	Body           string    `json:"body"`
+	IsHTML         bool      `json:"is_html"`
+	CachedAt       time.Time `json:"cached_at"`
+}
+
+// GetTranslation retrieves a translation of an email from cache
+func (s *CacheService) GetTranslation(ctx context.Context, emailID int64, lang string) (*CachedTranslation, error) {
+	if s.redis == nil {
+		return nil, nil
+	}
+
+	data, err := s.redis.Get(ctx, translationKey(emailID, lang)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var cached CachedTranslation
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, err
+	}
+
+	return &cached, nil
+}
+
+// CacheTranslation stores a translation of an email in Redis
+func (s *CacheService) CacheTranslation(ctx context.Context, emailID int64, lang string, translation *CachedTranslation) error {
+	if s.redis == nil {
+		return nil
+	}
+
+	translation.CachedAt = time.Now()
+	data, err := json.Marshal(translation)
+	if err != nil {
+		return err
+	}
+
+	return s.redis.Set(ctx, translationKey(emailID, lang), data, translationTTL).Err()
+}
+
+// =============================================================================
+// Audio Cache
+// =============================================================================
+
+// GetAudioBlobID returns the blob store id of the generated audio of an email ("" if not cached).
+func (s *CacheService) GetAudioBlobID(ctx context.Context, emailID int64, variant string) (string, error) {
+	if s.redis == nil {
+		return "", nil
+	}
+
+	id, err := s.redis.Get(ctx, audioKey(emailID, variant)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", nil
+		}
+		return "", err
+	}
+	return id, nil
+}
+
+// CacheAudioBlobID remembers the blob store id of generated audio until the blob expires.
+func (s *CacheService) CacheAudioBlobID(ctx context.Context, emailID int64, variant, blobID string, ttl time.Duration) error {
+	if s.redis == nil {
+		return nil
+	}
+	return s.redis.Set(ctx, audioKey(emailID, variant), blobID, ttl).Err()
 }
 
 // =============================================================================
@@ -615,7 +829,11 @@ func (s *CacheService) CacheAIResult(ctx context.Context, emailID int64, result
 // =============================================================================
 
 // PrefetchBodies prefetches email bodies in background
+// metadata-only 연결은 열람할 때만 가져오므로 prefetch하지 않는다.
 func (s *CacheService) PrefetchBodies(ctx context.Context, emailIDs []int64, connectionID int64) {
+	if s.metadataOnly(ctx, connectionID) {
+		return
+	}
 	go func() {
 		bgCtx := context.Background()
 		for _, emailID := range emailIDs {
@@ -683,7 +901,7 @@ func (s *CacheService) PrefetchNextPage(ctx context.Context, userID string, fold
 // PrefetchEmailBodiesBatch prefetches multiple email bodies concurrently
 // 최적화: 병렬 처리로 여러 본문 동시 로드
 func (s *CacheService) PrefetchEmailBodiesBatch(ctx context.Context, emailIDs []int64, connectionID int64, concurrency int) {
-	if len(emailIDs) == 0 {
+	if len(emailIDs) == 0 || s.metadataOnly(ctx, connectionID) {
 		return
 	}
 	if concurrency <= 0 {
@@ -713,6 +931,36 @@ func (s *CacheService) PrefetchEmailBodiesBatch(ctx context.Context, emailIDs []
 	}()
 }
 
+// PrefetchInbox warms bodies for the first page of the inbox in background.
+// 목록 응답 직후 호출 - 같은 사용자에 대해 prefetchCooldown 내 재호출은 무시.
+func (s *CacheService) PrefetchInbox(userID string, emails []*domain.Email) {
+	if len(emails) == 0 || s.cfg().PrefetchInboxSize <= 0 {
+		return
+	}
+
+	if s.redis != nil {
+		ok, err := s.redis.SetNX(context.Background(), keyPrefixPrefetch+userID, 1, prefetchCooldown).Result()
+		if err == nil && !ok {
+			return
+		}
+	}
+
+	byConnection := make(map[int64][]int64)
+	for i, e := range emails {
+		if i >= s.cfg().PrefetchInboxSize {
+			break
+		}
+		if e.ConnectionID == 0 {
+			continue
+		}
+		byConnection[e.ConnectionID] = append(byConnection[e.ConnectionID], e.ID)
+	}
+
+	for connectionID, emailIDs := range byConnection {
+		s.PrefetchEmailBodiesBatch(context.Background(), emailIDs, connectionID, 3)
+	}
+}
+
 // GetMetrics returns cache metrics
 func (s *CacheService) GetMetrics() *CacheMetrics {
 	return s.metrics
@@ -474,7 +474,7 @@ func (c *HybridCache) GetBody(ctx context.Context, emailID int64, connectionID i
 		// Write back to L1 only if body has content (including empty body marker)
 		if c.l1 != nil && c.config.WriteToL1 && body != nil && (body.TextBody != "" || body.HTMLBody != "") {
 			if data, err := json.Marshal(body); err == nil {
-				l1TTL := time.Duration(float64(c.l2.config.BodyTTL) * c.config.L1TTLRatio)
+				l1TTL := time.Duration(float64(c.l2.cfg().BodyTTL) * c.config.L1TTLRatio)
 				c.l1.SetWithTTL(key, data, l1TTL)
 			}
 		}
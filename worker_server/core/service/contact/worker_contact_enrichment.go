@@ -0,0 +1,106 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// enrichmentRefreshInterval - 이 기간 안에 보강한 연락처는 외부 API를 다시 호출하지 않는다
+const enrichmentRefreshInterval = 30 * 24 * time.Hour
+
+var ErrEnrichmentNotConfigured = errors.New("contact enrichment is not configured")
+
+// SetEnricher enables contact enrichment (회사, 직함, 아바타 조회).
+// producer가 있으면 VIP로 지정한 연락처도 보강 작업을 요청한다.
+func (s *Service) SetEnricher(enricher out.ContactEnricher, producer out.MessageProducer) {
+	s.enricher = enricher
+	s.producer = producer
+}
+
+// RequestEnrichment queues an enrichment job for a contact that became important.
+func (s *Service) RequestEnrichment(ctx context.Context, userID uuid.UUID, email, name string) {
+	if s.enricher == nil || s.producer == nil {
+		return
+	}
+	job := &out.ContactEnrichJob{UserID: userID.String(), Email: email, Name: name}
+	if err := s.producer.PublishContactEnrich(ctx, job); err != nil {
+		logger.Warn("[ContactService] Failed to publish enrichment for %s: %v", email, err)
+	}
+}
+
+// EnrichContact looks up public profile data and stores it on the Contact node.
+// 사용자의 연락처(Postgres)에는 비어 있는 회사/직함/사진만 채운다.
+// 최근에 보강했거나 찾은 정보가 없으면 nil을 반환한다.
+func (s *Service) EnrichContact(ctx context.Context, userID uuid.UUID, email string) (*domain.ContactEnrichment, error) {
+	if s.enricher == nil {
+		return nil, ErrEnrichmentNotConfigured
+	}
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return nil, ErrInvalidEmail
+	}
+	email = strings.ToLower(addr.Address)
+
+	if s.graphStore != nil {
+		rel, err := s.graphStore.GetContactRelationship(ctx, userID.String(), email)
+		if err != nil {
+			return nil, err
+		}
+		if rel != nil && rel.EnrichedAt != nil && time.Since(*rel.EnrichedAt) < enrichmentRefreshInterval {
+			return nil, nil
+		}
+	}
+
+	enrichment, err := s.enricher.Enrich(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	// 찾지 못해도 enriched_at을 남겨 재조회 주기를 지킨다
+	if s.graphStore != nil {
+		if err := s.graphStore.SetContactEnrichment(ctx, email, enrichment); err != nil {
+			return nil, err
+		}
+	}
+	if enrichment.IsEmpty() {
+		return nil, nil
+	}
+
+	contact, err := s.contactRepo.GetByEmail(userID, email)
+	if err != nil {
+		return nil, err
+	}
+	if contact != nil && fillEnrichment(contact, enrichment) {
+		if err := s.contactRepo.Update(contact); err != nil {
+			return nil, err
+		}
+	}
+	return enrichment, nil
+}
+
+// fillEnrichment copies enrichment into empty contact fields and reports whether anything changed.
+func fillEnrichment(contact *domain.Contact, enrichment *domain.ContactEnrichment) bool {
+	changed := false
+	if contact.Company == "" && enrichment.Company != "" {
+		contact.Company = enrichment.Company
+		changed = true
+	}
+	if contact.JobTitle == "" && enrichment.JobTitle != "" {
+		contact.JobTitle = enrichment.JobTitle
+		changed = true
+	}
+	if contact.PhotoURL == "" && enrichment.AvatarURL != "" {
+		contact.PhotoURL = enrichment.AvatarURL
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,112 @@
+package contact
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+type fakeContactRepo struct {
+	domain.ContactRepository
+	contact *domain.Contact
+	updates int
+}
+
+func (r *fakeContactRepo) GetByEmail(_ uuid.UUID, email string) (*domain.Contact, error) {
+	if r.contact != nil && r.contact.Email == email {
+		return r.contact, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeContactRepo) Update(contact *domain.Contact) error {
+	r.updates++
+	r.contact = contact
+	return nil
+}
+
+type fakeGraph struct {
+	out.ExtendedPersonalizationStore
+	rel      *out.ContactRelationship
+	enriched map[string]*domain.ContactEnrichment
+}
+
+func (g *fakeGraph) GetContactRelationship(_ context.Context, _, _ string) (*out.ContactRelationship, error) {
+	return g.rel, nil
+}
+
+func (g *fakeGraph) SetContactEnrichment(_ context.Context, email string, e *domain.ContactEnrichment) error {
+	g.enriched[email] = e
+	return nil
+}
+
+type fakeEnricher struct {
+	result *domain.ContactEnrichment
+	calls  int
+}
+
+func (e *fakeEnricher) Enrich(_ context.Context, _ string) (*domain.ContactEnrichment, error) {
+	e.calls++
+	return e.result, nil
+}
+
+func TestEnrichContactFillsEmptyFields(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	repo := &fakeContactRepo{contact: &domain.Contact{Email: "kim@acme.com", JobTitle: "CTO"}}
+	graph := &fakeGraph{enriched: make(map[string]*domain.ContactEnrichment)}
+	enricher := &fakeEnricher{result: &domain.ContactEnrichment{
+		Company: "Acme", JobTitle: "Engineer", AvatarURL: "https://example.com/a.png", Source: "gravatar",
+	}}
+
+	svc := NewService(repo)
+	svc.SetGraphStore(graph)
+	svc.SetEnricher(enricher, nil)
+
+	got, err := svc.EnrichContact(ctx, userID, "Kim <KIM@acme.com>")
+	if err != nil || got == nil {
+		t.Fatalf("enrich: %v %v", got, err)
+	}
+	if graph.enriched["kim@acme.com"] != got {
+		t.Errorf("enrichment not stored on the contact node: %+v", graph.enriched)
+	}
+	// 사용자가 입력한 직함은 유지하고 빈 값만 채운다
+	c := repo.contact
+	if repo.updates != 1 || c.Company != "Acme" || c.JobTitle != "CTO" || c.PhotoURL != "https://example.com/a.png" {
+		t.Errorf("unexpected contact after enrichment: %+v (updates %d)", c, repo.updates)
+	}
+
+	// 최근에 보강한 연락처는 다시 조회하지 않는다
+	now := time.Now()
+	graph.rel = &out.ContactRelationship{ContactEmail: "kim@acme.com", EnrichedAt: &now}
+	if got, err := svc.EnrichContact(ctx, userID, "kim@acme.com"); err != nil || got != nil {
+		t.Errorf("recently enriched contact: got %v %v", got, err)
+	}
+	if enricher.calls != 1 {
+		t.Errorf("enricher called %d times, want 1", enricher.calls)
+	}
+}
+
+func TestEnrichContactNotFound(t *testing.T) {
+	repo := &fakeContactRepo{contact: &domain.Contact{Email: "lee@acme.com"}}
+	graph := &fakeGraph{enriched: make(map[string]*domain.ContactEnrichment)}
+	svc := NewService(repo)
+	svc.SetGraphStore(graph)
+	svc.SetEnricher(&fakeEnricher{}, nil)
+
+	got, err := svc.EnrichContact(context.Background(), uuid.New(), "lee@acme.com")
+	if err != nil || got != nil {
+		t.Fatalf("expected no enrichment, got %v %v", got, err)
+	}
+	if _, ok := graph.enriched["lee@acme.com"]; !ok {
+		t.Error("lookup time should be recorded even when nothing was found")
+	}
+	if repo.updates != 0 {
+		t.Errorf("contact should not be updated, got %d updates", repo.updates)
+	}
+}
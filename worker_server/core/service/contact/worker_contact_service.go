@@ -5,12 +5,20 @@ import (
 
 	"worker_server/core/domain"
 	"worker_server/core/port/in"
+	"worker_server/core/port/out"
 
 	"github.com/google/uuid"
 )
 
 type Service struct {
 	contactRepo domain.ContactRepository
+
+	// VIP 연락처 (Neo4j, nil이면 VIP 기능 비활성)
+	graphStore out.ExtendedPersonalizationStore
+
+	// 연락처 프로필 보강 (nil이면 비활성)
+	enricher out.ContactEnricher
+	producer out.MessageProducer
 }
 
 func NewService(contactRepo domain.ContactRepository) *Service {
@@ -0,0 +1,59 @@
+package contact
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+// maxVIPContacts - VIP 목록 조회 상한
+const maxVIPContacts = 500
+
+var (
+	ErrGraphNotConfigured = errors.New("contact graph is not configured")
+	ErrInvalidEmail       = errors.New("invalid email address")
+)
+
+// SetGraphStore enables VIP contacts, stored as is_important on the Neo4j relationship.
+func (s *Service) SetGraphStore(store out.ExtendedPersonalizationStore) {
+	s.graphStore = store
+}
+
+// ListVIPContacts returns the contacts the user marked as VIP.
+func (s *Service) ListVIPContacts(ctx context.Context, userID uuid.UUID) ([]*out.ContactRelationship, error) {
+	if s.graphStore == nil {
+		return nil, ErrGraphNotConfigured
+	}
+	contacts, err := s.graphStore.GetImportantContacts(ctx, userID.String(), maxVIPContacts)
+	if err != nil {
+		return nil, err
+	}
+	if contacts == nil {
+		contacts = []*out.ContactRelationship{}
+	}
+	return contacts, nil
+}
+
+// SetVIP marks or unmarks a contact as VIP. VIP 발신자의 새 메일은 동기화 즉시 알림된다.
+func (s *Service) SetVIP(ctx context.Context, userID uuid.UUID, email, name string, vip bool) error {
+	if s.graphStore == nil {
+		return ErrGraphNotConfigured
+	}
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return ErrInvalidEmail
+	}
+	email = strings.ToLower(addr.Address)
+	if err := s.graphStore.SetContactImportant(ctx, userID.String(), email, strings.TrimSpace(name), vip); err != nil {
+		return err
+	}
+	if vip {
+		s.RequestEnrichment(ctx, userID, email, strings.TrimSpace(name))
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package mail
+
+import (
+	"context"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const maxActivityEntries = 500 // 타임라인 최대 항목 수
+
+// modifyActivities maps mail modify actions to activity types.
+var modifyActivities = map[string]string{
+	"read":     domain.ActivityRead,
+	"unread":   domain.ActivityUnread,
+	"star":     domain.ActivityStarred,
+	"unstar":   domain.ActivityUnstarred,
+	"archive":  domain.ActivityArchived,
+	"trash":    domain.ActivityTrashed,
+	"delete":   domain.ActivityDeleted,
+	"restore":  domain.ActivityRestored,
+	"snooze":   domain.ActivitySnoozed,
+	"unsnooze": domain.ActivityUnsnoozed,
+	"workflow": domain.ActivityWorkflow,
+}
+
+// SetActivityRepository enables the per-email activity timeline (GET /email/:id/activity).
+func (s *Service) SetActivityRepository(repo out.EmailActivityRepository) {
+	s.activityRepo = repo
+}
+
+// SetActivityRepository records synced emails and thread-mute archiving in the activity timeline.
+func (s *SyncService) SetActivityRepository(repo out.EmailActivityRepository) {
+	s.activityRepo = repo
+}
+
+// EmailActivity returns the timeline of an email, oldest first.
+func (s *Service) EmailActivity(ctx context.Context, userID uuid.UUID, emailID int64) ([]*domain.EmailActivity, error) {
+	if s.activityRepo == nil || s.emailRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+
+	email, err := s.emailRepo.GetByID(ctx, emailID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrEmailNotFound
+		}
+		return nil, err
+	}
+	if email == nil || email.UserID != userID {
+		return nil, ErrEmailNotFound
+	}
+
+	return s.activityRepo.ListByEmail(ctx, userID, emailID, maxActivityEntries)
+}
+
+// recordModifyActivity records a user action (read, star, move...) on each email.
+func (s *Service) recordModifyActivity(ctx context.Context, userID uuid.UUID, emailIDs []int64, action string, detail map[string]any) {
+	if s.activityRepo == nil || len(emailIDs) == 0 {
+		return
+	}
+	activityType, ok := modifyActivities[action]
+	if folder, found := strings.CutPrefix(action, "move:"); found {
+		activityType, ok = domain.ActivityMoved, true
+		detail = map[string]any{"folder": folder}
+	}
+	if action == "labels" {
+		activityType, ok = domain.ActivityLabeled, true
+	}
+	if !ok {
+		return
+	}
+
+	activities := make([]*domain.EmailActivity, len(emailIDs))
+	for i, id := range emailIDs {
+		activities[i] = &domain.EmailActivity{EmailID: id, UserID: userID, Type: activityType, Source: domain.ActivitySourceUser, Detail: detail}
+	}
+	recordActivity(ctx, s.activityRepo, activities...)
+}
+
+// recordActivity saves activities best-effort; 타임라인 기록 실패가 본 작업을 막지 않는다.
+func recordActivity(ctx context.Context, repo out.EmailActivityRepository, activities ...*domain.EmailActivity) {
+	if repo == nil || len(activities) == 0 {
+		return
+	}
+	if err := repo.Record(ctx, activities...); err != nil {
+		logger.Warn("[EmailActivity] Failed to record %d activities: %v", len(activities), err)
+	}
+}
+
+// syncActivity describes a newly synced email.
+func syncActivity(email *domain.Email) *domain.EmailActivity {
+	detail := map[string]any{"connection_id": email.ConnectionID, "folder": string(email.Folder)}
+	if email.AICategory != nil {
+		detail["category"] = string(*email.AICategory)
+		if email.ClassificationStage != nil {
+			detail["stage"] = string(*email.ClassificationStage)
+		}
+	}
+	return &domain.EmailActivity{EmailID: email.ID, UserID: email.UserID, Type: domain.ActivitySynced, Source: domain.ActivitySourceSync, Detail: detail}
+}
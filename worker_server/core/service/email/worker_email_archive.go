@@ -0,0 +1,109 @@
+package mail
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// ArchivePolicy decides which mailboxes and emails move to the archive tier.
+type ArchivePolicy struct {
+	MinEmails   int // 이 이상 받은 메일함만 아카이브 (대용량 계정)
+	AfterMonths int // 이보다 오래된 메일을 아카이브
+}
+
+const (
+	archiveBatchSize    = 1000
+	archiveUsersPerRun  = 50
+	maxRehydrateEmails  = 500
+	archiveBatchTimeout = 2 * time.Minute
+)
+
+// SetArchiveRepository enables archiving old emails of large mailboxes.
+// bodyRepo는 아카이브된 메일의 본문 캐시를 지우는 데 쓴다 (다시 열면 Provider에서 가져옴).
+// 본문을 되찾을 수 없는 가져온 메일(local 연결)은 archive_emails가 옮기지 않는다 (078).
+func (s *Service) SetArchiveRepository(repo out.EmailArchiveRepository, bodyRepo out.EmailBodyRepository, policy ArchivePolicy) {
+	s.archiveRepo = repo
+	s.archiveBodies = bodyRepo
+	s.archivePolicy = policy
+}
+
+// ArchiveColdEmails moves old emails of large mailboxes to the archive (아카이브 스케줄러에서 호출).
+func (s *Service) ArchiveColdEmails(ctx context.Context) (int, error) {
+	if s.archiveRepo == nil || s.archivePolicy.AfterMonths <= 0 {
+		return 0, nil
+	}
+
+	users, err := s.archiveRepo.LargeMailboxes(ctx, s.archivePolicy.MinEmails, archiveUsersPerRun)
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().AddDate(0, -s.archivePolicy.AfterMonths, 0)
+	total := 0
+	for _, userID := range users {
+		n, err := s.archiveUser(ctx, userID, cutoff)
+		total += n
+		if err != nil {
+			logger.Warn("[EmailService.ArchiveColdEmails] Failed to archive user %s: %v", userID, err)
+		}
+		if ctx.Err() != nil {
+			return total, nil
+		}
+	}
+	return total, nil
+}
+
+func (s *Service) archiveUser(ctx context.Context, userID uuid.UUID, cutoff time.Time) (int, error) {
+	total := 0
+	for {
+		batchCtx, cancel := context.WithTimeout(ctx, archiveBatchTimeout)
+		ids, err := s.archiveRepo.Archive(batchCtx, userID, cutoff, archiveBatchSize)
+		cancel()
+		if err != nil {
+			return total, err
+		}
+		total += len(ids)
+
+		if len(ids) > 0 && s.archiveBodies != nil {
+			if err := s.archiveBodies.BulkDeleteBody(ctx, ids); err != nil {
+				logger.Warn("[EmailService.archiveUser] Failed to drop archived bodies: %v", err)
+			}
+		}
+		if len(ids) < archiveBatchSize || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
+
+// Rehydrate moves archived emails back to the mailbox and returns the restored IDs.
+func (s *Service) Rehydrate(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error) {
+	if s.archiveRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	if len(emailIDs) > maxRehydrateEmails {
+		emailIDs = emailIDs[:maxRehydrateEmails]
+	}
+	return s.archiveRepo.Rehydrate(ctx, userID, emailIDs)
+}
+
+// rehydrateOnOpen restores an archived email opened by ID (GetEmail에서 hot 테이블에 없을 때).
+func (s *Service) rehydrateOnOpen(ctx context.Context, userID uuid.UUID, emailID int64) bool {
+	if s.archiveRepo == nil {
+		return false
+	}
+	archived, err := s.archiveRepo.IsArchived(ctx, userID, emailID)
+	if err != nil || !archived {
+		return false
+	}
+	restored, err := s.archiveRepo.Rehydrate(ctx, userID, []int64{emailID})
+	if err != nil {
+		logger.Warn("[EmailService.rehydrateOnOpen] Failed to rehydrate email %d: %v", emailID, err)
+		return false
+	}
+	return len(restored) > 0
+}
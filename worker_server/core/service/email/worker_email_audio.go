@@ -0,0 +1,212 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/readability"
+
+	"github.com/google/uuid"
+)
+
+const (
+	audioCacheTTL     = 7 * 24 * time.Hour // 생성한 오디오 보관 기간 (blob 만료)
+	maxSpeechChars    = 4000               // TTS 입력 한도 (OpenAI 4096자)
+	maxAudioCacheSize = 25 << 20           // 이보다 긴 오디오는 캐시하지 않음
+)
+
+var ErrInvalidAudioMode = errors.New("mode must be summary or full")
+
+var voiceName = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// SetSpeechSynthesizer enables spoken emails (GET /email/:id/audio).
+// blobs가 있으면 생성한 오디오를 저장해 같은 내용·음성 요청에 재사용한다.
+func (s *Service) SetSpeechSynthesizer(speech out.SpeechSynthesizer, blobs out.AttachmentBlobStore) {
+	s.speech = speech
+	s.audioBlobs = blobs
+}
+
+// EmailAudio returns the spoken version of an email as a stream. The caller closes the reader.
+// 캐시에 없으면 TTS 결과를 그대로 흘려보내면서 끝까지 읽힌 오디오만 저장한다.
+func (s *Service) EmailAudio(ctx context.Context, userID uuid.UUID, emailID int64, mode, voice string) (*domain.EmailAudio, io.ReadCloser, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		mode = domain.EmailAudioSummary
+	}
+	if mode != domain.EmailAudioSummary && mode != domain.EmailAudioFull {
+		return nil, nil, ErrInvalidAudioMode
+	}
+	voice = strings.ToLower(strings.TrimSpace(voice))
+	if voice != "" && !voiceName.MatchString(voice) {
+		return nil, nil, out.ErrUnsupportedVoice
+	}
+	if s.speech == nil || s.emailRepo == nil {
+		return nil, nil, ErrRepoNotInitialized
+	}
+
+	email, err := s.emailRepo.GetByID(ctx, emailID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, nil, ErrEmailNotFound
+		}
+		return nil, nil, err
+	}
+	if email == nil || email.UserID != userID {
+		return nil, nil, ErrEmailNotFound
+	}
+
+	text, err := s.speechText(ctx, email, mode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	audio := &domain.EmailAudio{EmailID: emailID, Mode: mode, Voice: voice, Size: -1}
+	variant := audioVariant(text, voice)
+	if cached := s.cachedAudio(ctx, userID, emailID, variant, audio); cached != nil {
+		return audio, cached, nil
+	}
+
+	stream, mimeType, err := s.speech.Synthesize(ctx, text, voice)
+	if err != nil {
+		if errors.Is(err, out.ErrUnsupportedVoice) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("failed to synthesize speech: %w", err)
+	}
+	audio.MimeType = mimeType
+	if s.audioBlobs == nil || s.cacheService == nil {
+		return audio, stream, nil
+	}
+	return audio, &audioRecorder{src: stream, onComplete: func(data []byte) {
+		s.storeAudio(userID, emailID, variant, mimeType, data)
+	}}, nil
+}
+
+// speechText builds the text read aloud: 발신자와 제목 다음에 요약 또는 본문.
+func (s *Service) speechText(ctx context.Context, email *out.MailEntity, mode string) (string, error) {
+	content := ""
+	if mode == domain.EmailAudioSummary {
+		content = email.Summary
+	}
+	if strings.TrimSpace(content) == "" {
+		body, err := s.GetEmailBody(ctx, email.ID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get email body: %w", err)
+		}
+		// 읽기 모드와 같은 추출 (레이아웃, 푸터, 수신거부 문구 제외)
+		content = readability.Extract(body.HTMLBody, body.TextBody).Text
+	}
+
+	from := email.FromName
+	if from == "" {
+		from = email.FromEmail
+	}
+	var intro string
+	if email.Language == "ko" {
+		intro = fmt.Sprintf("%s님이 보낸 메일입니다. 제목: %s.", from, email.Subject)
+	} else {
+		intro = fmt.Sprintf("Email from %s. Subject: %s.", from, email.Subject)
+	}
+
+	text := intro + "\n\n" + strings.TrimSpace(content)
+	if runes := []rune(text); len(runes) > maxSpeechChars {
+		text = string(runes[:maxSpeechChars])
+	}
+	return text, nil
+}
+
+// audioVariant keys the cache by the spoken text and voice (요약이 바뀌면 새로 생성).
+func audioVariant(text, voice string) string {
+	sum := sha256.Sum256([]byte(voice + "\x00" + text))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cachedAudio opens previously generated audio and fills size and MIME type, or returns nil.
+func (s *Service) cachedAudio(ctx context.Context, userID uuid.UUID, emailID int64, variant string, audio *domain.EmailAudio) io.ReadCloser {
+	if s.audioBlobs == nil || s.cacheService == nil {
+		return nil
+	}
+	id, err := s.cacheService.GetAudioBlobID(ctx, emailID, variant)
+	if err != nil || id == "" {
+		return nil
+	}
+	blob, data, err := s.audioBlobs.Open(ctx, id)
+	if err != nil {
+		return nil
+	}
+	if blob.UserID != userID || time.Now().After(blob.ExpiresAt) {
+		data.Close()
+		return nil
+	}
+	audio.MimeType, audio.Size, audio.Cached = blob.MimeType, blob.Size, true
+	return data
+}
+
+// storeAudio saves fully streamed audio in the blob store in the background.
+func (s *Service) storeAudio(userID uuid.UUID, emailID int64, variant, mimeType string, data []byte) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		blob := &domain.LinkedAttachment{
+			UserID:    userID,
+			Filename:  fmt.Sprintf("email-%d-audio", emailID),
+			MimeType:  mimeType,
+			Size:      int64(len(data)),
+			ExpiresAt: time.Now().Add(audioCacheTTL),
+		}
+		if err := s.audioBlobs.Put(ctx, blob, bytes.NewReader(data)); err != nil {
+			logger.Warn("[MailService.storeAudio] failed to store audio of email %d: %v", emailID, err)
+			return
+		}
+		if err := s.cacheService.CacheAudioBlobID(ctx, emailID, variant, blob.ID, audioCacheTTL); err != nil {
+			logger.Warn("[MailService.storeAudio] failed to cache audio id of email %d: %v", emailID, err)
+		}
+	}()
+}
+
+// audioRecorder passes audio through and keeps a copy, handed to onComplete only if
+// the stream was read to the end (클라이언트가 중간에 끊으면 저장하지 않음).
+type audioRecorder struct {
+	src        io.ReadCloser
+	buf        bytes.Buffer
+	complete   bool
+	overflow   bool
+	onComplete func([]byte)
+}
+
+func (r *audioRecorder) Read(p []byte) (int, error) {
+	n, err := r.src.Read(p)
+	if n > 0 && !r.overflow {
+		if r.buf.Len()+n > maxAudioCacheSize {
+			r.overflow = true
+			r.buf = bytes.Buffer{}
+		} else {
+			r.buf.Write(p[:n])
+		}
+	}
+	if err == io.EOF {
+		r.complete = true
+	}
+	return n, err
+}
+
+func (r *audioRecorder) Close() error {
+	err := r.src.Close()
+	if r.complete && !r.overflow && r.buf.Len() > 0 && r.onComplete != nil {
+		r.onComplete(r.buf.Bytes())
+	}
+	r.onComplete = nil
+	return err
+}
@@ -0,0 +1,163 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/lock"
+	"worker_server/pkg/logger"
+)
+
+// =============================================================================
+// Classification Backfill - 미분류 메일 재분류 (체크포인트 기반 이어하기)
+// =============================================================================
+//
+// 초기 동기화/전체 재동기화 후 분류되지 않은 메일에 ai:classify:low를 발행한다 (새 메일 분류를 막지 않도록).
+// 이메일 ID 오름차순으로 배치 발행하고 배치마다 마지막 ID를 체크포인트로 저장하므로
+// 워커가 중간에 죽어도 BackfillResumeScheduler가 이어서 처리한다.
+
+const (
+	backfillBatchSize  = 50              // 한 번에 발행할 작업 수
+	backfillBatchDelay = 2 * time.Second // 배치 간 대기 (Worker pool 처리 속도에 맞춤)
+
+	// backfillStaleAfter - 이 시간 동안 체크포인트가 갱신되지 않으면 소유 워커가 죽은 것으로 본다
+	backfillStaleAfter = time.Minute
+)
+
+// SetBackfillRepository enables persisted classification backfill checkpoints.
+func (s *SyncService) SetBackfillRepository(repo out.ClassificationBackfillRepository) {
+	s.backfillRepo = repo
+}
+
+// reclassifyUnclassifiedEmails publishes ai:classify jobs for unclassified emails of the connection.
+// 진행 중인 backfill이 있으면 처음부터 다시 하지 않고 체크포인트부터 이어서 처리한다.
+func (s *SyncService) reclassifyUnclassifiedEmails(ctx context.Context, userID string, connectionID int64) {
+	if s.emailRepo == nil || s.messageProducer == nil {
+		return
+	}
+
+	err := s.withLock(ctx, fmt.Sprintf("classify:%d", connectionID), func(ctx context.Context) error {
+		bf, err := s.loadOrStartBackfill(ctx, userID, connectionID)
+		if err != nil || bf == nil {
+			return err
+		}
+		s.runBackfill(ctx, bf)
+		return nil
+	})
+	if errors.Is(err, lock.ErrNotAcquired) {
+		logger.Info("[SyncService.reclassify] Backfill for connection %d is running on another worker", connectionID)
+	} else if err != nil {
+		logger.Error("[SyncService.reclassify] Backfill for connection %d failed: %v", connectionID, err)
+	}
+}
+
+// ResumeClassificationBackfills resumes running backfills whose owner stopped updating them.
+func (s *SyncService) ResumeClassificationBackfills(ctx context.Context) {
+	if s.backfillRepo == nil || s.emailRepo == nil || s.messageProducer == nil {
+		return
+	}
+
+	running, err := s.backfillRepo.ListRunning(ctx)
+	if err != nil {
+		logger.Error("[SyncService.ResumeBackfills] Failed to list running backfills: %v", err)
+		return
+	}
+
+	for _, bf := range running {
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(bf.UpdatedAt) < backfillStaleAfter {
+			continue // 다른 워커(또는 이 프로세스)가 진행 중
+		}
+		logger.Info("[SyncService.ResumeBackfills] Resuming connection %d from email %d (%d/%d published)",
+			bf.ConnectionID, bf.CursorID, bf.Published, bf.Total)
+		s.reclassifyUnclassifiedEmails(ctx, bf.UserID, bf.ConnectionID)
+	}
+}
+
+// loadOrStartBackfill returns the running checkpoint or starts a new backfill.
+// Returns nil when there is nothing to classify.
+func (s *SyncService) loadOrStartBackfill(ctx context.Context, userID string, connectionID int64) (*domain.ClassificationBackfill, error) {
+	if s.backfillRepo != nil {
+		if bf, err := s.backfillRepo.Get(ctx, connectionID); err == nil && bf.Status == domain.BackfillRunning {
+			return bf, nil
+		}
+	}
+
+	total, err := s.emailRepo.CountUnclassified(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("count unclassified: %w", err)
+	}
+	if total == 0 {
+		logger.Info("[SyncService.reclassify] No unclassified emails for connection %d", connectionID)
+		return nil, nil
+	}
+
+	if s.backfillRepo == nil {
+		return &domain.ClassificationBackfill{
+			ConnectionID: connectionID,
+			UserID:       userID,
+			Status:       domain.BackfillRunning,
+			Total:        total,
+			StartedAt:    time.Now(),
+		}, nil
+	}
+	return s.backfillRepo.Start(ctx, userID, connectionID, total)
+}
+
+// runBackfill publishes classify jobs in batches after bf.CursorID, saving a checkpoint per batch.
+// ctx가 취소되면 running 상태로 남겨 두고 반환한다 (다음 시작 시 이어하기).
+func (s *SyncService) runBackfill(ctx context.Context, bf *domain.ClassificationBackfill) {
+	logger.Info("[SyncService.reclassify] Backfill for connection %d: %d unclassified, resuming after email %d",
+		bf.ConnectionID, bf.Total, bf.CursorID)
+
+	for {
+		ids, err := s.emailRepo.ListUnclassifiedIDsAfter(ctx, bf.ConnectionID, bf.CursorID, backfillBatchSize)
+		if err != nil {
+			logger.Error("[SyncService.reclassify] Failed to list unclassified for connection %d: %v", bf.ConnectionID, err)
+			return
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		for _, id := range ids {
+			if err := s.messageProducer.PublishAIClassify(ctx, &out.AIClassifyJob{
+				UserID:   bf.UserID,
+				EmailID:  id,
+				Priority: out.JobPriorityLow,
+			}); err != nil {
+				bf.Failed++
+			} else {
+				bf.Published++
+			}
+			bf.CursorID = id
+		}
+
+		if s.backfillRepo != nil {
+			if err := s.backfillRepo.SaveCheckpoint(ctx, bf.ConnectionID, bf.CursorID, bf.Published, bf.Failed); err != nil {
+				logger.Warn("[SyncService.reclassify] Failed to save checkpoint for connection %d: %v", bf.ConnectionID, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("[SyncService.reclassify] Backfill for connection %d interrupted at email %d", bf.ConnectionID, bf.CursorID)
+			return
+		case <-time.After(backfillBatchDelay):
+		}
+	}
+
+	if s.backfillRepo != nil {
+		if err := s.backfillRepo.Finish(ctx, bf.ConnectionID, domain.BackfillCompleted, ""); err != nil {
+			logger.Warn("[SyncService.reclassify] Failed to finish backfill for connection %d: %v", bf.ConnectionID, err)
+		}
+	}
+	logger.Info("[SyncService.reclassify] Completed: published %d classify jobs (%d failed) for connection %d",
+		bf.Published, bf.Failed, bf.ConnectionID)
+}
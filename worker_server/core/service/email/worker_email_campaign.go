@@ -0,0 +1,423 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/in"
+	"worker_server/core/port/out"
+	"worker_server/core/service"
+	"worker_server/core/service/auth"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// CampaignService - 메일 머지 (개인화 대량 발송)
+// =============================================================================
+//
+// 1. API: 템플릿/본문을 스냅샷으로 저장하고 수신자별 변수를 검증한 뒤 mail:campaign 작업 발행
+// 2. Worker: pending 수신자를 순서대로 렌더링 → 개별 발송 (throttle) → 수신자 상태/카운트 갱신
+//
+// 수신자 상태가 DB에 남으므로 작업이 중단되거나 시간 예산을 넘겨 재발행되어도 이어서 발송한다.
+
+const (
+	MaxCampaignRecipients     = 1000
+	DefaultCampaignThrottleMs = 1000
+	MinCampaignThrottleMs     = 200 // Provider rate limit 보호
+	MaxCampaignThrottleMs     = 60_000
+
+	campaignBatchSize           = 20
+	campaignTimeBudget          = 25 * time.Minute // worker JobMailCampaign timeout(30분)보다 짧게
+	campaignMaxConsecutiveFails = 5                // 연속 실패 시 중단 (토큰 만료, 할당량 초과 등)
+)
+
+var (
+	ErrCampaignNoRecipients       = errors.New("campaign has no recipients")
+	ErrCampaignTooManyRecipients  = errors.New("too many campaign recipients")
+	ErrCampaignInvalidRecipient   = errors.New("invalid campaign recipient")
+	ErrCampaignEmptyContent       = errors.New("campaign subject and body required")
+	ErrCampaignMissingVariables   = errors.New("campaign recipients missing template variables")
+	ErrCampaignInvalidConnection  = errors.New("invalid connection")
+	ErrCampaignAlreadyFinished    = errors.New("campaign already finished")
+	ErrCampaignTemplateNotEnabled = errors.New("template service not configured")
+)
+
+// CampaignRecipientInput is a recipient with its personalization variables.
+type CampaignRecipientInput struct {
+	Email     string
+	Name      string
+	Variables map[string]string
+}
+
+// CreateCampaignRequest is the input for creating a campaign.
+// Subject/Body/HTMLBody가 비어 있으면 템플릿 내용을 사용한다.
+type CreateCampaignRequest struct {
+	ConnectionID int64
+	TemplateID   int64
+	Name         string
+	Subject      string
+	Body         string
+	HTMLBody     string
+	UseSignature bool
+	ThrottleMs   int
+	Recipients   []CampaignRecipientInput
+}
+
+type CampaignService struct {
+	repo            out.CampaignRepository
+	emailService    *Service
+	oauthService    *auth.OAuthService
+	messageProducer out.MessageProducer
+	templates       *service.TemplateService
+}
+
+func NewCampaignService(
+	repo out.CampaignRepository,
+	emailService *Service,
+	oauthService *auth.OAuthService,
+	messageProducer out.MessageProducer,
+) *CampaignService {
+	return &CampaignService{
+		repo:            repo,
+		emailService:    emailService,
+		oauthService:    oauthService,
+		messageProducer: messageProducer,
+	}
+}
+
+// SetTemplateService enables template_id on campaigns.
+func (s *CampaignService) SetTemplateService(templates *service.TemplateService) {
+	s.templates = templates
+}
+
+// CreateCampaign validates recipients, stores the campaign and queues it for the worker.
+// 모든 수신자에 대해 미리 렌더링해서 누락 변수가 하나라도 있으면 생성하지 않는다.
+func (s *CampaignService) CreateCampaign(ctx context.Context, userID uuid.UUID, req *CreateCampaignRequest) (*domain.EmailCampaign, error) {
+	if len(req.Recipients) == 0 {
+		return nil, ErrCampaignNoRecipients
+	}
+	if len(req.Recipients) > MaxCampaignRecipients {
+		return nil, fmt.Errorf("%w: max %d", ErrCampaignTooManyRecipients, MaxCampaignRecipients)
+	}
+
+	conn, err := s.oauthService.GetConnection(ctx, req.ConnectionID)
+	if err != nil || conn.UserID != userID {
+		return nil, ErrCampaignInvalidConnection
+	}
+
+	campaign := &domain.EmailCampaign{
+		UserID:       userID,
+		ConnectionID: conn.ID,
+		Name:         strings.TrimSpace(req.Name),
+		Subject:      req.Subject,
+		Body:         req.Body,
+		HTMLBody:     req.HTMLBody,
+		UseSignature: req.UseSignature,
+		ThrottleMs:   clampThrottle(req.ThrottleMs),
+		Status:       domain.CampaignPending,
+	}
+
+	// 템플릿은 생성 시점 내용으로 스냅샷 (발송 중 템플릿 수정 영향 없음)
+	defaults := map[string]string{}
+	if req.TemplateID > 0 {
+		if err := s.snapshotTemplate(ctx, userID, req.TemplateID, campaign, defaults); err != nil {
+			return nil, err
+		}
+	}
+	if campaign.Subject == "" || (campaign.Body == "" && campaign.HTMLBody == "") {
+		return nil, ErrCampaignEmptyContent
+	}
+	if campaign.Name == "" {
+		campaign.Name = campaign.Subject
+	}
+
+	recipients, err := buildRecipients(req.Recipients, defaults, conn.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make(map[string]bool)
+	for _, r := range recipients {
+		for _, name := range service.RenderContent(campaign.Subject, campaign.Body, campaign.HTMLBody, r.Variables).Missing {
+			missing[name] = true
+		}
+	}
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%w: %s", ErrCampaignMissingVariables, strings.Join(names, ", "))
+	}
+
+	if s.messageProducer == nil {
+		return nil, fmt.Errorf("message producer not initialized")
+	}
+	if err := s.repo.Create(ctx, campaign, recipients); err != nil {
+		return nil, fmt.Errorf("failed to create campaign: %w", err)
+	}
+
+	if err := s.messageProducer.PublishMailCampaign(ctx, &out.MailCampaignJob{
+		UserID:     userID.String(),
+		CampaignID: campaign.ID,
+	}); err != nil {
+		_ = s.repo.MarkFinished(ctx, campaign.ID, domain.CampaignFailed, "failed to queue campaign")
+		return nil, fmt.Errorf("failed to publish campaign job: %w", err)
+	}
+
+	logger.Info("[CampaignService] queued campaign %d (%d recipients) for user %s", campaign.ID, len(recipients), userID)
+	return campaign, nil
+}
+
+// GetCampaign returns a campaign owned by the user.
+func (s *CampaignService) GetCampaign(ctx context.Context, userID uuid.UUID, id int64) (*domain.EmailCampaign, error) {
+	return s.repo.GetByID(ctx, userID, id)
+}
+
+// ListCampaigns returns recent campaigns.
+func (s *CampaignService) ListCampaigns(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.EmailCampaign, error) {
+	return s.repo.ListByUser(ctx, userID, limit)
+}
+
+// ListRecipients returns the per-recipient report of a campaign.
+func (s *CampaignService) ListRecipients(ctx context.Context, userID uuid.UUID, id int64, status domain.CampaignRecipientStatus, limit, offset int) ([]*domain.CampaignRecipient, error) {
+	if _, err := s.repo.GetByID(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRecipients(ctx, id, status, limit, offset)
+}
+
+// CancelCampaign stops a pending or sending campaign. 이미 발송된 메일은 취소되지 않는다.
+func (s *CampaignService) CancelCampaign(ctx context.Context, userID uuid.UUID, id int64) (*domain.EmailCampaign, error) {
+	campaign, err := s.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.IsFinished() {
+		return nil, ErrCampaignAlreadyFinished
+	}
+
+	if err := s.repo.MarkFinished(ctx, id, domain.CampaignCancelled, ""); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, userID, id)
+}
+
+// =============================================================================
+// Worker side
+// =============================================================================
+
+// ProcessCampaign sends pending recipients of a campaign with throttling.
+func (s *CampaignService) ProcessCampaign(ctx context.Context, userID uuid.UUID, campaignID int64) error {
+	campaign, err := s.repo.GetByID(ctx, userID, campaignID)
+	if err != nil {
+		return fmt.Errorf("failed to get campaign: %w", err)
+	}
+	if campaign.IsFinished() {
+		return nil
+	}
+	if err := s.repo.MarkSending(ctx, campaignID); err != nil {
+		return fmt.Errorf("failed to mark campaign sending: %w", err)
+	}
+
+	throttle := time.Duration(campaign.ThrottleMs) * time.Millisecond
+	deadline := time.Now().Add(campaignTimeBudget)
+	consecutiveFails := 0
+	first := true
+
+	for {
+		recipients, err := s.repo.NextPendingRecipients(ctx, campaignID, campaignBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to load recipients: %w", err)
+		}
+		if len(recipients) == 0 {
+			break
+		}
+
+		for _, r := range recipients {
+			if time.Now().After(deadline) {
+				return s.requeue(ctx, userID, campaignID)
+			}
+			if !first {
+				select {
+				case <-ctx.Done():
+					_ = s.repo.RefreshCounts(context.Background(), campaignID)
+					return ctx.Err()
+				case <-time.After(throttle):
+				}
+			}
+			first = false
+
+			if err := s.sendToRecipient(ctx, userID, campaign, r); err != nil {
+				consecutiveFails++
+				logger.WithError(err).Warn("[CampaignService.ProcessCampaign] campaign=%d recipient=%s failed", campaignID, r.Email)
+				_ = s.repo.MarkRecipientFailed(ctx, r.ID, err.Error())
+
+				if consecutiveFails >= campaignMaxConsecutiveFails {
+					_ = s.repo.RefreshCounts(ctx, campaignID)
+					return s.repo.MarkFinished(ctx, campaignID, domain.CampaignFailed,
+						fmt.Sprintf("stopped after %d consecutive failures: %v", consecutiveFails, err))
+				}
+				continue
+			}
+			consecutiveFails = 0
+		}
+
+		if err := s.repo.RefreshCounts(ctx, campaignID); err != nil {
+			logger.WithError(err).Warn("[CampaignService.ProcessCampaign] Failed to refresh counts")
+		}
+
+		// 배치마다 취소 여부 확인
+		current, err := s.repo.GetByID(ctx, userID, campaignID)
+		if err == nil && current.IsFinished() {
+			logger.Info("[CampaignService] campaign %d stopped (%s)", campaignID, current.Status)
+			return nil
+		}
+	}
+
+	if err := s.repo.RefreshCounts(ctx, campaignID); err != nil {
+		return err
+	}
+	logger.Info("[CampaignService] campaign %d completed", campaignID)
+	return s.repo.MarkFinished(ctx, campaignID, domain.CampaignCompleted, "")
+}
+
+// sendToRecipient renders the campaign for one recipient and sends it as an individual message.
+func (s *CampaignService) sendToRecipient(ctx context.Context, userID uuid.UUID, campaign *domain.EmailCampaign, r *domain.CampaignRecipient) error {
+	rendered := service.RenderContent(campaign.Subject, campaign.Body, campaign.HTMLBody, r.Variables)
+	if len(rendered.Missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrCampaignMissingVariables, strings.Join(rendered.Missing, ", "))
+	}
+
+	// 발송 전 경고는 확인할 사용자가 없으므로 건너뛴다 (수신 불가 주소는 바운스로 추적)
+	req := &in.SendEmailRequest{
+		ConnectionID:    campaign.ConnectionID,
+		To:              []string{r.Email},
+		Subject:         rendered.Subject,
+		Body:            rendered.Body,
+		UseSignature:    campaign.UseSignature,
+		ConfirmWarnings: true,
+	}
+	if rendered.HTMLBody != "" {
+		req.Body = rendered.HTMLBody
+		req.IsHTML = true
+	}
+
+	sent, err := s.emailService.SendEmail(ctx, userID, req)
+	if err != nil {
+		return err
+	}
+	return s.repo.MarkRecipientSent(ctx, r.ID, sent.ProviderID)
+}
+
+// requeue republishes the campaign when the time budget is exhausted.
+func (s *CampaignService) requeue(ctx context.Context, userID uuid.UUID, campaignID int64) error {
+	_ = s.repo.RefreshCounts(ctx, campaignID)
+	if s.messageProducer == nil {
+		return fmt.Errorf("message producer not initialized")
+	}
+	logger.Info("[CampaignService] campaign %d exceeded time budget, requeueing", campaignID)
+	return s.messageProducer.PublishMailCampaign(ctx, &out.MailCampaignJob{
+		UserID:     userID.String(),
+		CampaignID: campaignID,
+	})
+}
+
+// snapshotTemplate copies template content into empty campaign fields and collects variable defaults.
+func (s *CampaignService) snapshotTemplate(ctx context.Context, userID uuid.UUID, templateID int64, campaign *domain.EmailCampaign, defaults map[string]string) error {
+	if s.templates == nil {
+		return ErrCampaignTemplateNotEnabled
+	}
+
+	tmpl, err := s.templates.GetByID(ctx, userID, templateID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return service.ErrTemplateNotFound
+		}
+		return err
+	}
+
+	campaign.TemplateID = &tmpl.ID
+	if campaign.Subject == "" && tmpl.Subject != nil {
+		campaign.Subject = *tmpl.Subject
+	}
+	if campaign.Body == "" && campaign.HTMLBody == "" {
+		campaign.Body = tmpl.Body
+		if tmpl.HTMLBody != nil {
+			campaign.HTMLBody = *tmpl.HTMLBody
+		}
+	}
+	for _, v := range tmpl.Variables {
+		if v.DefaultVal != "" {
+			defaults[v.Name] = v.DefaultVal
+		}
+	}
+	return nil
+}
+
+// buildRecipients validates, normalizes and de-duplicates recipients.
+// 변수 우선순위: 수신자 변수 > 기본 제공 변수(recipientEmail/recipientName/senderEmail) > 템플릿 기본값
+func buildRecipients(inputs []CampaignRecipientInput, defaults map[string]string, senderEmail string) ([]*domain.CampaignRecipient, error) {
+	seen := make(map[string]bool, len(inputs))
+	recipients := make([]*domain.CampaignRecipient, 0, len(inputs))
+
+	for _, input := range inputs {
+		addr, err := netmail.ParseAddress(strings.TrimSpace(input.Email))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrCampaignInvalidRecipient, input.Email)
+		}
+		email := strings.ToLower(addr.Address)
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+
+		name := strings.TrimSpace(input.Name)
+		if name == "" {
+			name = addr.Name
+		}
+
+		variables := make(map[string]string, len(defaults)+len(input.Variables)+3)
+		for k, v := range defaults {
+			variables[k] = v
+		}
+		variables["senderEmail"] = senderEmail
+		variables["recipientEmail"] = email
+		if name != "" {
+			variables["recipientName"] = name
+		}
+		for k, v := range input.Variables {
+			variables[k] = v
+		}
+
+		recipients = append(recipients, &domain.CampaignRecipient{
+			Email:     email,
+			Name:      name,
+			Variables: variables,
+			Status:    domain.RecipientPending,
+		})
+	}
+	return recipients, nil
+}
+
+// clampThrottle applies the default and bounds to the per-message delay.
+func clampThrottle(ms int) int {
+	switch {
+	case ms <= 0:
+		return DefaultCampaignThrottleMs
+	case ms < MinCampaignThrottleMs:
+		return MinCampaignThrottleMs
+	case ms > MaxCampaignThrottleMs:
+		return MaxCampaignThrottleMs
+	}
+	return ms
+}
@@ -0,0 +1,159 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+// ChangeRetention is how long the change log is kept. 이보다 오래된 sync token은 전체 재동기화가 필요하다.
+const ChangeRetention = 30 * 24 * time.Hour
+
+const (
+	defaultChangesLimit = 500
+	maxChangesLimit     = 1000
+)
+
+var (
+	ErrInvalidSyncToken = errors.New("invalid sync token")
+	ErrSyncTokenExpired = errors.New("sync token expired, full resync required")
+)
+
+// SetChangeRepository enables the delta endpoint (GET /email/changes).
+func (s *Service) SetChangeRepository(repo out.EmailChangeRepository) {
+	s.changeRepo = repo
+}
+
+// Changes returns the emails created, updated and deleted since the sync token.
+// since가 비어 있으면 변경 없이 현재 위치의 토큰만 돌려준다 (전체 목록을 받은 직후 호출).
+func (s *Service) Changes(ctx context.Context, userID uuid.UUID, since string, limit int) (*domain.EmailChanges, error) {
+	if s.changeRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	if limit <= 0 || limit > maxChangesLimit {
+		limit = defaultChangesLimit
+	}
+
+	result := &domain.EmailChanges{Created: []int64{}, Updated: []int64{}, Deleted: []int64{}}
+	now := time.Now()
+	if since == "" {
+		pos, err := s.changeRepo.Position(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result.Cursor = encodeSyncToken(pos, now)
+		return result, nil
+	}
+
+	after, issuedAt, err := parseSyncToken(since)
+	if err != nil {
+		return nil, err
+	}
+	if now.Sub(issuedAt) > ChangeRetention {
+		return nil, ErrSyncTokenExpired
+	}
+
+	changes, err := s.changeRepo.ListSince(ctx, userID, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) > limit {
+		changes, result.HasMore = changes[:limit], true
+	}
+	if len(changes) == 0 {
+		result.Cursor = encodeSyncToken(after, now)
+		return result, nil
+	}
+
+	// 메일별 마지막 변경만 남김 (새로 생긴 메일의 수정은 created로 유지)
+	latest := make(map[int64]string, len(changes))
+	for _, ch := range changes {
+		if latest[ch.EmailID] == domain.EmailChangeCreated && ch.Type == domain.EmailChangeUpdated {
+			continue
+		}
+		latest[ch.EmailID] = ch.Type
+	}
+	for id, typ := range latest {
+		switch typ {
+		case domain.EmailChangeCreated:
+			result.Created = append(result.Created, id)
+		case domain.EmailChangeUpdated:
+			result.Updated = append(result.Updated, id)
+		case domain.EmailChangeDeleted:
+			result.Deleted = append(result.Deleted, id)
+		}
+	}
+	for _, ids := range [][]int64{result.Created, result.Updated, result.Deleted} {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	}
+
+	// 남은 변경이 있으면 토큰 시각을 마지막 변경 시각으로 둬서 만료 판정이 보수적이 되게 함
+	last := changes[len(changes)-1]
+	issued := now
+	if result.HasMore {
+		issued = last.CreatedAt
+	}
+	result.Cursor = encodeSyncToken(domain.EmailChangePosition{TxID: last.TxID, ID: last.ID}, issued)
+	return result, nil
+}
+
+// PruneChanges deletes change log entries older than ChangeRetention (purge 스케줄러에서 호출).
+func (s *Service) PruneChanges(ctx context.Context) (int, error) {
+	if s.changeRepo == nil {
+		return 0, nil
+	}
+
+	cutoff := time.Now().Add(-ChangeRetention)
+	total := 0
+	for {
+		n, err := s.changeRepo.Prune(ctx, cutoff, purgeBatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < purgeBatchSize || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
+
+// encodeSyncToken encodes the change log position and the time the token was issued ("<tx>:<id>.<unix>").
+func encodeSyncToken(pos domain.EmailChangePosition, issuedAt time.Time) string {
+	return fmt.Sprintf("%d:%d.%d", pos.TxID, pos.ID, issuedAt.Unix())
+}
+
+// parseSyncToken parses a sync token. 트랜잭션 ID가 없는 예전 토큰("<id>.<unix>")은 만료로 보고 전체 재동기화시킨다.
+func parseSyncToken(token string) (domain.EmailChangePosition, time.Time, error) {
+	var pos domain.EmailChangePosition
+	posPart, atPart, ok := strings.Cut(token, ".")
+	if !ok {
+		return pos, time.Time{}, ErrInvalidSyncToken
+	}
+	at, err := strconv.ParseInt(atPart, 10, 64)
+	if err != nil {
+		return pos, time.Time{}, ErrInvalidSyncToken
+	}
+	txPart, idPart, ok := strings.Cut(posPart, ":")
+	if !ok {
+		if _, err := strconv.ParseInt(posPart, 10, 64); err == nil {
+			return pos, time.Time{}, ErrSyncTokenExpired
+		}
+		return pos, time.Time{}, ErrInvalidSyncToken
+	}
+	if pos.TxID, err = strconv.ParseInt(txPart, 10, 64); err != nil || pos.TxID < 0 {
+		return pos, time.Time{}, ErrInvalidSyncToken
+	}
+	if pos.ID, err = strconv.ParseInt(idPart, 10, 64); err != nil || pos.ID < 0 {
+		return pos, time.Time{}, ErrInvalidSyncToken
+	}
+	return pos, time.Unix(at, 0), nil
+}
@@ -0,0 +1,231 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/in"
+	"worker_server/core/port/out"
+	"worker_server/core/service/filelink"
+	"worker_server/core/service/upload"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// composeInlineBudget - send_request에 base64로 실을 첨부 합계 (API body 10MB 이내)
+	composeInlineBudget int64 = 5 * 1024 * 1024
+	// outlookInlineMax - Graph는 3MB를 넘는 첨부를 업로드 세션으로만 받는다
+	outlookInlineMax int64 = 3 * 1024 * 1024
+)
+
+// PrepareCompose decides how each attachment reaches the provider, creates the draft
+// and the upload sessions, and returns the request to send once uploads are done.
+func (s *Service) PrepareCompose(ctx context.Context, userID uuid.UUID, req *in.PrepareComposeRequest) (*in.PreparedCompose, error) {
+	if s.oauthService == nil {
+		return nil, errors.New("oauth service not configured")
+	}
+
+	conn, err := s.sendConnection(ctx, userID, req.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+	if conn.UserID != userID {
+		return nil, upload.ErrConnectionNotFound
+	}
+
+	linksEnabled := s.fileLinks != nil && s.fileLinks.Enabled(userID)
+	modes, err := planCompose(string(conn.Provider), req.Attachments, int64(len(req.Body)), linksEnabled)
+	if err != nil {
+		return nil, err
+	}
+	for _, mode := range modes {
+		if mode != in.ComposeModeInline && s.uploads == nil {
+			return nil, upload.ErrNotConfigured
+		}
+	}
+
+	provider := s.composeProvider(conn)
+	if provider == nil {
+		return nil, upload.ErrUnsupportedProvider
+	}
+	token, err := s.oauthService.GetOAuth2Token(ctx, conn.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth token: %w", err)
+	}
+
+	outgoing := &out.ProviderOutgoingMessage{Subject: req.Subject, Body: req.Body, IsHTML: req.IsHTML}
+	for _, addr := range req.To {
+		outgoing.To = append(outgoing.To, out.ProviderEmailAddress{Email: addr})
+	}
+	for _, addr := range req.Cc {
+		outgoing.CC = append(outgoing.CC, out.ProviderEmailAddress{Email: addr})
+	}
+	for _, addr := range req.Bcc {
+		outgoing.BCC = append(outgoing.BCC, out.ProviderEmailAddress{Email: addr})
+	}
+	draft, err := provider.CreateDraft(ctx, token, outgoing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create draft: %w", err)
+	}
+
+	send := &in.SendEmailRequest{
+		ConnectionID: conn.ID,
+		To:           req.To,
+		Cc:           req.Cc,
+		Bcc:          req.Bcc,
+		Subject:      req.Subject,
+		Body:         req.Body,
+		IsHTML:       req.IsHTML,
+		DraftID:      draft.ExternalID,
+	}
+	prepared := &in.PreparedCompose{
+		ConnectionID: conn.ID,
+		Provider:     string(conn.Provider),
+		DraftID:      draft.ExternalID,
+		Attachments:  make([]*in.PreparedAttachment, 0, len(req.Attachments)),
+		SendRequest:  send,
+	}
+
+	for i, att := range req.Attachments {
+		item := &in.PreparedAttachment{Index: i, Filename: att.Filename, Size: att.Size, Mode: modes[i]}
+		if modes[i] != in.ComposeModeInline {
+			// Outlook 세션은 draft에 붙고, Gmail은 staged로 받아 발송 시 MIME에 넣는다
+			session, err := s.uploads.Create(ctx, userID, &upload.CreateRequest{
+				ConnectionID: conn.ID,
+				MessageID:    draft.ExternalID,
+				Filename:     att.Filename,
+				Size:         att.Size,
+				MimeType:     att.MimeType,
+				IsInline:     att.IsInline,
+				ContentID:    att.ContentID,
+				Proxy:        req.Proxy,
+			})
+			if err != nil {
+				s.discardComposeDraft(ctx, provider, token, draft.ExternalID)
+				return nil, err
+			}
+			item.UploadSession = session
+			send.UploadSessionIDs = append(send.UploadSessionIDs, session.ID)
+			if session.Mode != domain.UploadModeStaged {
+				send.DraftID = "" // draft 자체를 발송하므로 지우지 않는다
+			}
+		}
+		prepared.Attachments = append(prepared.Attachments, item)
+	}
+
+	check := s.sendCheckFor(ctx, userID, conn, send)
+	check.hasAttachments = len(req.Attachments) > 0
+	prepared.Warnings = s.checkSend(ctx, check)
+
+	return prepared, nil
+}
+
+// composeProvider returns the provider that keeps drafts for the connection.
+func (s *Service) composeProvider(conn *domain.OAuthConnection) out.EmailProviderPort {
+	if s.uploads != nil {
+		if provider := s.uploads.Provider(string(conn.Provider)); provider != nil {
+			return provider
+		}
+	}
+	if conn.Provider == domain.ProviderGoogle {
+		return s.provider
+	}
+	return nil
+}
+
+// discardComposeDraft deletes a compose draft (실패해도 발송 결과에는 영향 없음).
+func (s *Service) discardComposeDraft(ctx context.Context, provider out.EmailProviderPort, token *oauth2.Token, draftID string) {
+	if provider == nil || draftID == "" {
+		return
+	}
+	if err := provider.DeleteDraft(context.WithoutCancel(ctx), token, draftID); err != nil {
+		logger.WithError(err).Warn("[MailService.discardComposeDraft] Failed to delete draft %s", draftID)
+	}
+}
+
+// discardSentDraft deletes the compose draft of a sent request.
+// Outlook 업로드 세션이 있으면 draft 자체가 발송된 것이므로 남겨 둔다.
+func (s *Service) discardSentDraft(ctx context.Context, conn *domain.OAuthConnection, token *oauth2.Token, req *in.SendEmailRequest) {
+	if req.DraftID == "" || (len(req.UploadSessionIDs) > 0 && conn.Provider != domain.ProviderGoogle) {
+		return
+	}
+	s.discardComposeDraft(ctx, s.composeProvider(conn), token, req.DraftID)
+}
+
+// planCompose returns the delivery mode of each attachment.
+//
+// Gmail: 작은 첨부부터 inline 예산을 채우고 나머지는 staged 업로드, 메시지 한도를 넘으면
+// filelink.Apply와 같이 큰 첨부부터 링크로 보낸다.
+// Outlook: draft PATCH로는 첨부를 추가할 수 없으므로 업로드가 하나라도 필요하면 모두 업로드 세션으로 보낸다.
+func planCompose(provider string, atts []in.ComposeAttachment, bodySize int64, linksEnabled bool) ([]string, error) {
+	modes := make([]string, len(atts))
+	limit := filelink.ProviderLimit(provider)
+
+	order := make([]int, len(atts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return atts[order[a]].Size < atts[order[b]].Size })
+
+	switch provider {
+	case "google", "gmail":
+		size := bodySize
+		inline := int64(0)
+		for _, i := range order {
+			if atts[i].Size > upload.MaxStagedSize {
+				return nil, fmt.Errorf("%w: %s", upload.ErrTooLarge, atts[i].Filename)
+			}
+			size += encodedSize(atts[i].Size)
+			if inline+atts[i].Size <= composeInlineBudget {
+				modes[i] = in.ComposeModeInline
+				inline += atts[i].Size
+			} else {
+				modes[i] = in.ComposeModeUploadSession
+			}
+		}
+		for k := len(order) - 1; k >= 0 && size > limit; k-- {
+			if !linksEnabled {
+				return nil, fmt.Errorf("%w (%d MB)", filelink.ErrTooLarge, limit/(1024*1024))
+			}
+			i := order[k]
+			modes[i] = in.ComposeModeLink
+			size -= encodedSize(atts[i].Size)
+		}
+
+	case "outlook", "microsoft":
+		total := int64(0)
+		viaDraft := false
+		for _, att := range atts {
+			if att.Size > limit {
+				return nil, fmt.Errorf("%w: %s", upload.ErrTooLarge, att.Filename)
+			}
+			total += att.Size
+			viaDraft = viaDraft || att.Size > outlookInlineMax
+		}
+		if total > limit {
+			return nil, fmt.Errorf("%w (%d MB)", filelink.ErrTooLarge, limit/(1024*1024))
+		}
+		mode := in.ComposeModeInline
+		if viaDraft || total > composeInlineBudget {
+			mode = in.ComposeModeUploadSession
+		}
+		for i := range modes {
+			modes[i] = mode
+		}
+
+	default:
+		return nil, upload.ErrUnsupportedProvider
+	}
+	return modes, nil
+}
+
+// encodedSize is the base64 size of n bytes in a MIME part.
+func encodedSize(n int64) int64 {
+	return (n + 2) / 3 * 4
+}
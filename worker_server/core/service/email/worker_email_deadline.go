@@ -0,0 +1,132 @@
+package mail
+
+import (
+	"context"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/deadline"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// SetDeadlineRepository enables sync-time deadline extraction for the TODO view.
+func (s *SyncService) SetDeadlineRepository(repo out.EmailDeadlineRepository) {
+	s.deadlineRepo = repo
+}
+
+// deadlineLocation returns the user's timezone for resolving "tomorrow", "금요일까지" etc.
+// nil이면 마감일 추출을 하지 않는다 (deadlineRepo 미설정).
+func (s *SyncService) deadlineLocation(ctx context.Context, userID string) *time.Location {
+	if s.deadlineRepo == nil {
+		return nil
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil
+	}
+	return userLocation(ctx, s.deadlineRepo, uid)
+}
+
+// saveDeadline extracts a deadline from the subject and text and stores it.
+// 상대 표현("내일", "by Friday")은 메일 수신 시각 기준으로 해석한다.
+func (s *SyncService) saveDeadline(ctx context.Context, email *domain.Email, text string, loc *time.Location) {
+	if s.deadlineRepo == nil || loc == nil || email.ID == 0 {
+		return
+	}
+
+	ref := email.Date
+	if ref.IsZero() {
+		ref = time.Now()
+	}
+	match, ok := deadline.Extract(email.Subject+"\n"+text, ref.In(loc))
+	if !ok {
+		return
+	}
+
+	if err := s.deadlineRepo.Save(ctx, &domain.EmailDeadline{
+		EmailID:    email.ID,
+		UserID:     email.UserID,
+		DeadlineAt: match.At,
+		SourceText: match.Text,
+		Explicit:   match.Cued,
+	}); err != nil {
+		logger.Warn("[SyncService] Failed to save deadline for email %d: %v", email.ID, err)
+	}
+}
+
+// SetDeadlineRepository enables urgency sorting and explanations in the TODO view.
+func (s *Service) SetDeadlineRepository(repo out.EmailDeadlineRepository) {
+	s.deadlineRepo = repo
+}
+
+// ListTodo lists the TODO view sorted by urgency (AI priority + deadline proximity).
+// 마감일까지 남은 일수는 사용자 시간대(user_settings.timezone) 기준이며,
+// 각 메일에 점수와 설명(Urgency.Explain)을 채운다.
+// filter.SortBy가 "priority"면 예전처럼 ai_priority 순으로만 정렬한다.
+func (s *Service) ListTodo(ctx context.Context, filter *domain.EmailFilter) ([]*domain.Email, int, error) {
+	if s.domainRepo == nil {
+		return []*domain.Email{}, 0, nil
+	}
+	if filter.PinnedFirst && s.pinRepo == nil {
+		filter.PinnedFirst = false
+	}
+	if s.deadlineRepo == nil {
+		filter.SortBy = "priority"
+		emails, total, err := s.domainRepo.List(filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		if filter.PinnedFirst {
+			s.markPinned(ctx, filter.UserID, emails)
+		}
+		s.markSLA(ctx, emails)
+		return emails, total, nil
+	}
+
+	loc := userLocation(ctx, s.deadlineRepo, filter.UserID)
+	if filter.SortBy == "" {
+		filter.SortBy = "urgency"
+	}
+	filter.Timezone = loc.String()
+
+	emails, total, err := s.domainRepo.List(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]int64, len(emails))
+	for i, e := range emails {
+		ids[i] = e.ID
+	}
+	deadlines, err := s.deadlineRepo.GetByEmailIDs(ctx, filter.UserID, ids)
+	if err != nil {
+		logger.Warn("[MailService.ListTodo] Failed to load deadlines: %v", err)
+		deadlines = nil
+	}
+
+	now := time.Now()
+	for _, e := range emails {
+		e.Urgency = domain.ScoreTodoUrgency(e.AIPriority, deadlines[e.ID], now, loc)
+	}
+	if filter.PinnedFirst {
+		s.markPinned(ctx, filter.UserID, emails)
+	}
+	s.markSLA(ctx, emails)
+	return emails, total, nil
+}
+
+// userLocation loads the user's timezone, falling back to UTC.
+func userLocation(ctx context.Context, repo out.EmailDeadlineRepository, userID uuid.UUID) *time.Location {
+	tz, err := repo.UserTimezone(ctx, userID)
+	if err != nil || tz == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,157 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/core/service/common"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/mailparse"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// =============================================================================
+// Delivery Status - 바운스/스팸 신고 추적
+// =============================================================================
+//
+// 1. Sync: 메타데이터(From/Subject/Content-Type/X-Failed-Recipients)로 리포트 후보 선별
+// 2. 후보만 원본을 받아 DSN(RFC 3464)/ARF(RFC 5965) 파싱 → 수신자별 이벤트 저장
+// 3. 원본 Message-ID로 보낸 메일과 연결, 캠페인 수신자는 bounced로 갱신
+// 4. API: GET /email/sent/:id/status
+
+// maxDeliveryReportSize - 리포트 원본 최대 크기 (원본 메일 전체가 첨부된 DSN 대비)
+const maxDeliveryReportSize = 2 << 20
+
+var ErrNotSentEmail = errors.New("email is not a sent message")
+
+// SetDeliveryTracking enables sync-time bounce/complaint parsing.
+// campaigns가 있으면 바운스된 캠페인 수신자 상태도 갱신한다.
+func (s *SyncService) SetDeliveryTracking(repo out.DeliveryStatusRepository, campaigns out.CampaignRepository) {
+	s.deliveryRepo = repo
+	s.campaignRepo = campaigns
+}
+
+// trackDelivery parses a bounce/complaint report and links it to the sent message.
+func (s *SyncService) trackDelivery(ctx context.Context, email *domain.Email, msg out.ProviderMailMessage, token *oauth2.Token) {
+	if s.deliveryRepo == nil || email.ID == 0 || !isDeliveryReport(msg) {
+		return
+	}
+
+	raw, err := s.emailProvider.GetMessageRaw(ctx, token, msg.ExternalID, maxDeliveryReportSize)
+	if err != nil {
+		logger.Warn("[SyncService] Failed to fetch delivery report %d: %v", email.ID, err)
+		return
+	}
+	report, err := mailparse.ParseDeliveryReport(raw)
+	if err != nil || report == nil {
+		return
+	}
+
+	reportedAt := email.ReceivedAt
+	if reportedAt.IsZero() {
+		reportedAt = time.Now()
+	}
+
+	events := make([]*domain.DeliveryEvent, 0, len(report.Recipients))
+	for _, r := range report.Recipients {
+		events = append(events, &domain.DeliveryEvent{
+			UserID:            email.UserID,
+			ConnectionID:      email.ConnectionID,
+			ReportEmailID:     email.ID,
+			OriginalMessageID: report.OriginalMessageID,
+			Recipient:         r.Email,
+			State:             domain.DeliveryState(r.State),
+			StatusCode:        r.Status,
+			Diagnostic:        r.Diagnostic,
+			Permanent:         r.Permanent,
+			ReportingMTA:      report.ReportingMTA,
+			ReportedAt:        reportedAt,
+		})
+	}
+
+	if err := s.deliveryRepo.SaveEvents(ctx, events); err != nil {
+		logger.Warn("[SyncService] Failed to save delivery events for email %d: %v", email.ID, err)
+		return
+	}
+	logger.Info("[SyncService] Delivery report %d: %d recipients (original=%s)", email.ID, len(events), report.OriginalMessageID)
+
+	s.markCampaignBounces(ctx, events)
+}
+
+// markCampaignBounces updates campaign recipients whose message bounced.
+func (s *SyncService) markCampaignBounces(ctx context.Context, events []*domain.DeliveryEvent) {
+	if s.campaignRepo == nil {
+		return
+	}
+	for _, e := range events {
+		if e.State != domain.DeliveryBounced || e.SentEmailID == nil {
+			continue
+		}
+		sent, err := s.emailRepo.GetByID(ctx, *e.SentEmailID)
+		if err != nil || sent.ExternalID == "" {
+			continue
+		}
+		reason := e.StatusCode
+		if e.Diagnostic != "" {
+			reason = strings.TrimSpace(reason + " " + e.Diagnostic)
+		}
+		if err := s.campaignRepo.MarkRecipientBounced(ctx, sent.ExternalID, e.Recipient, reason); err != nil {
+			logger.Warn("[SyncService] Failed to mark campaign bounce for %s: %v", e.Recipient, err)
+		}
+	}
+}
+
+// isDeliveryReport reports whether a synced message is a bounce/complaint candidate.
+func isDeliveryReport(msg out.ProviderMailMessage) bool {
+	var contentType, failedRecipients string
+	if h := msg.ClassificationHeaders; h != nil {
+		contentType, failedRecipients = h.ContentType, h.XFailedRecipients
+	}
+	return mailparse.LooksLikeDeliveryReport(msg.From.Email, msg.Subject, contentType, failedRecipients)
+}
+
+// SetDeliveryStatusRepository enables GET /email/sent/:id/status.
+func (s *Service) SetDeliveryStatusRepository(repo out.DeliveryStatusRepository) {
+	s.deliveryRepo = repo
+}
+
+// GetDeliveryStatus returns per-recipient delivery state of a sent email.
+// 리포트가 없는 수신자는 delivered(confirmed=false)로 표시한다.
+func (s *Service) GetDeliveryStatus(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.SentDeliveryStatus, error) {
+	if s.emailRepo == nil || s.deliveryRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+
+	email, err := s.emailRepo.GetByID(ctx, emailID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrEmailNotFound
+		}
+		return nil, err
+	}
+	if email.UserID != userID {
+		return nil, common.ErrForbidden
+	}
+	if email.Direction != "outbound" && email.Folder != "sent" {
+		return nil, ErrNotSentEmail
+	}
+
+	events, err := s.deliveryRepo.ListForSentEmail(ctx, userID, email.ConnectionID, email.ID, email.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []string
+	for _, list := range [][]string{email.ToEmails, email.CcEmails, email.BccEmails} {
+		for _, addr := range list {
+			recipients = append(recipients, strings.ToLower(addr))
+		}
+	}
+	return domain.BuildSentDeliveryStatus(email.ID, email.MessageID, recipients, events), nil
+}
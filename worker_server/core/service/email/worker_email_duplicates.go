@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// maxDuplicateCleanup - 정리 한 번에 휴지통으로 보내는 사본 수 (나머지는 다시 요청)
+const maxDuplicateCleanup = 1000
+
+// ListDuplicates returns emails that have redundant copies (같은 Message-ID, 전달 사본).
+func (s *Service) ListDuplicates(ctx context.Context, userID uuid.UUID) ([]*domain.DuplicateGroup, error) {
+	if s.emailRepo == nil {
+		return []*domain.DuplicateGroup{}, nil
+	}
+	return s.emailRepo.ListDuplicateGroups(ctx, userID, maxDuplicateCleanup)
+}
+
+// CleanupDuplicates moves redundant copies to trash and keeps one email per group.
+// Returns the trashed email IDs (Provider 동기화는 Trash와 같다).
+func (s *Service) CleanupDuplicates(ctx context.Context, userID uuid.UUID) ([]int64, error) {
+	if s.emailRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	groups, err := s.emailRepo.ListDuplicateGroups(ctx, userID, maxDuplicateCleanup)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(groups))
+	for _, g := range groups {
+		for _, d := range g.Duplicates {
+			ids = append(ids, d.EmailID)
+		}
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+	if err := s.Trash(ctx, userID, ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
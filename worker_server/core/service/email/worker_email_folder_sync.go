@@ -0,0 +1,34 @@
+package mail
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// FolderSyncer reconciles custom folders with the provider of a connection (folder.Service).
+type FolderSyncer interface {
+	Sync(ctx context.Context, userID uuid.UUID, connectionID int64) (*domain.FolderSyncResult, error)
+}
+
+// SetFolderSyncer enables folder sync after initial sync and full resync.
+func (s *SyncService) SetFolderSyncer(syncer FolderSyncer) {
+	s.folders = syncer
+}
+
+// syncFolders imports provider folders and creates missing ones (실패해도 메일 동기화에는 영향 없음).
+func (s *SyncService) syncFolders(ctx context.Context, userID string, connectionID int64) {
+	if s.folders == nil {
+		return
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return
+	}
+	if _, err := s.folders.Sync(ctx, uid, connectionID); err != nil {
+		logger.WithError(err).Warn("[SyncService.syncFolders] Failed to sync folders of connection %d", connectionID)
+	}
+}
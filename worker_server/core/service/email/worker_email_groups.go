@@ -0,0 +1,138 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+)
+
+const (
+	// maxGroupSenders - 그룹 액션 한 번에 지정할 수 있는 발신자 수
+	maxGroupSenders = 50
+	// maxGroupActionEmails - 그룹 액션 한 번에 처리하는 메일 수 (나머지는 다시 요청)
+	maxGroupActionEmails = 5000
+)
+
+var (
+	ErrInvalidGroupAction = errors.New("action must be read or archive")
+	ErrInvalidSenders     = errors.New("senders are required (max 50)")
+)
+
+// 롤업 라벨의 단위 (단수, 복수) - 없는 카테고리는 email(s)
+var groupNouns = map[domain.EmailCategory][2]string{
+	domain.CategoryNotification: {"notification", "notifications"},
+	domain.CategoryNewsletter:   {"newsletter", "newsletters"},
+	domain.CategoryMarketing:    {"promotion", "promotions"},
+	domain.CategorySocial:       {"update", "updates"},
+}
+
+// ListSenderGroups collapses a category feed into one group per sender, latest first.
+// 그룹은 ?sender=로 펼치고 ApplyGroupAction으로 일괄 처리한다.
+func (s *Service) ListSenderGroups(ctx context.Context, filter *domain.EmailFilter) ([]*domain.SenderGroup, int, error) {
+	if s.emailRepo == nil {
+		return []*domain.SenderGroup{}, 0, nil
+	}
+	groups, total, err := s.emailRepo.ListSenderGroups(ctx, filter.UserID, s.groupQuery(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, g := range groups {
+		g.Label = groupLabel(g, filter.Category)
+	}
+	return groups, total, nil
+}
+
+// ApplyGroupAction marks read or archives every email of the given senders in the feed.
+// Returns the affected email IDs (최대 5000개, Provider 동기화는 기존 배치 작업과 같다).
+func (s *Service) ApplyGroupAction(ctx context.Context, filter *domain.EmailFilter, senders []string, action string) ([]int64, error) {
+	if s.emailRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	senders = normalizeSenders(senders)
+	if len(senders) == 0 || len(senders) > maxGroupSenders {
+		return nil, ErrInvalidSenders
+	}
+
+	query := s.groupQuery(filter)
+	switch action {
+	case domain.GroupActionRead:
+		unread := false
+		query.IsRead = &unread
+	case domain.GroupActionArchive:
+		query.Folder = string(domain.LegacyFolderInbox)
+	default:
+		return nil, ErrInvalidGroupAction
+	}
+
+	ids, err := s.emailRepo.ListIDsBySenders(ctx, filter.UserID, query, senders, maxGroupActionEmails)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []int64{}, nil
+	}
+
+	if action == domain.GroupActionRead {
+		err = s.MarkAsRead(ctx, filter.UserID, ids)
+	} else {
+		err = s.Archive(ctx, filter.UserID, ids)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// groupQuery maps the category feed filter to the repository query.
+func (s *Service) groupQuery(filter *domain.EmailFilter) *out.MailListQuery {
+	query := &out.MailListQuery{
+		ConnectionID:     filter.ConnectionID,
+		IsRead:           filter.IsRead,
+		ExcludeReadLater: filter.ExcludeReadLater && s.readLaterRepo != nil,
+		Limit:            filter.Limit,
+		Offset:           filter.Offset,
+	}
+	if filter.Category != nil {
+		query.Category = string(*filter.Category)
+	}
+	if filter.SubCategory != nil {
+		query.SubCategory = string(*filter.SubCategory)
+	}
+	return query
+}
+
+// groupLabel builds "GitHub — 14 notifications".
+func groupLabel(g *domain.SenderGroup, category *domain.EmailCategory) string {
+	name := g.SenderName
+	if name == "" {
+		name = g.Sender
+	}
+	nouns := [2]string{"email", "emails"}
+	if category != nil {
+		if n, ok := groupNouns[*category]; ok {
+			nouns = n
+		}
+	}
+	noun := nouns[1]
+	if g.Count == 1 {
+		noun = nouns[0]
+	}
+	return fmt.Sprintf("%s — %d %s", name, g.Count, noun)
+}
+
+func normalizeSenders(senders []string) []string {
+	seen := make(map[string]bool, len(senders))
+	result := make([]string, 0, len(senders))
+	for _, sender := range senders {
+		sender = strings.ToLower(strings.TrimSpace(sender))
+		if sender != "" && !seen[sender] {
+			seen[sender] = true
+			result = append(result, sender)
+		}
+	}
+	return result
+}
@@ -0,0 +1,263 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/lock"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/retry"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// History Backfill - 동기화 기간 이전 메일 가져오기 (요청 시, 저우선순위)
+// =============================================================================
+//
+// POST /email/backfill로 만든 작업을 HistoryBackfillScheduler가 조금씩 처리한다.
+// 한 번에 historyBackfillPagesPerRun 페이지만 가져오고 페이지마다 page token을 저장하므로
+// 워커가 재시작되어도 이어서 처리한다. 연결 동기화 락과는 다른 락을 쓰므로 delta sync를 막지 않는다.
+
+const (
+	historyBackfillPageSize    = 100
+	historyBackfillPagesPerRun = 10              // 스케줄러 한 번에 작업당 처리할 페이지 수
+	historyBackfillPageDelay   = 2 * time.Second // 페이지 간 대기 (Provider 할당량 보호)
+	historyBackfillBatch       = 5               // 스케줄러 한 번에 처리할 작업 수
+
+	// 사용자별 할당량은 최근 30일 동안 가져온 메시지 수로 계산한다
+	historyBackfillQuotaWindow = 30 * 24 * time.Hour
+
+	DefaultHistoryBackfillMaxMessages = 20000
+	DefaultHistoryBackfillQuota       = 100000
+)
+
+var (
+	ErrHistoryBackfillDisabled     = errors.New("history backfill is not configured")
+	ErrHistoryBackfillUnsupported  = errors.New("history backfill is only supported for Gmail connections")
+	ErrHistoryBackfillActive       = errors.New("a history backfill is already running for this connection")
+	ErrHistoryBackfillQuota        = errors.New("history backfill quota exhausted")
+	ErrHistoryBackfillInvalidRange = errors.New("since must be before until")
+	ErrHistoryBackfillNotFound     = errors.New("history backfill not found")
+	ErrConnectionNotFound          = errors.New("connection not found")
+)
+
+// SetHistoryBackfill enables POST /email/backfill.
+// maxMessages caps one backfill, quota caps what a user may fetch per 30 days (<= 0 uses the defaults).
+func (s *SyncService) SetHistoryBackfill(repo out.HistoryBackfillRepository, maxMessages, quota int) {
+	if maxMessages <= 0 {
+		maxMessages = DefaultHistoryBackfillMaxMessages
+	}
+	if quota <= 0 {
+		quota = DefaultHistoryBackfillQuota
+	}
+	s.historyBackfills = repo
+	s.historyBackfillMax = maxMessages
+	s.historyBackfillQuota = quota
+}
+
+// RequestHistoryBackfill queues a backfill of mail received in [since, until).
+// until이 비어 있으면 동기화 기간의 시작까지 가져온다. maxMessages <= 0이면 최대치를 쓴다.
+func (s *SyncService) RequestHistoryBackfill(ctx context.Context, userID uuid.UUID, connectionID int64, since, until time.Time, maxMessages int) (*domain.HistoryBackfill, error) {
+	if s.historyBackfills == nil {
+		return nil, ErrHistoryBackfillDisabled
+	}
+
+	conn, err := s.oauthService.GetConnection(ctx, connectionID)
+	if err != nil || conn == nil || conn.UserID != userID {
+		return nil, ErrConnectionNotFound
+	}
+	if conn.Provider != domain.ProviderGoogle {
+		return nil, ErrHistoryBackfillUnsupported
+	}
+
+	if until.IsZero() {
+		until = s.syncSince()
+	}
+	if now := time.Now(); until.After(now) {
+		until = now
+	}
+	if since.IsZero() || !since.Before(until) {
+		return nil, ErrHistoryBackfillInvalidRange
+	}
+
+	active, err := s.historyBackfills.GetActive(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return active, ErrHistoryBackfillActive
+	}
+
+	used, err := s.historyBackfills.UsedBudget(ctx, userID.String(), time.Now().Add(-historyBackfillQuotaWindow))
+	if err != nil {
+		return nil, err
+	}
+	remaining := s.historyBackfillQuota - used
+	if remaining <= 0 {
+		return nil, ErrHistoryBackfillQuota
+	}
+
+	budget := s.historyBackfillMax
+	if maxMessages > 0 && maxMessages < budget {
+		budget = maxMessages
+	}
+	budget = min(budget, remaining)
+
+	bf := &domain.HistoryBackfill{
+		UserID:       userID.String(),
+		ConnectionID: connectionID,
+		Since:        since,
+		Until:        until,
+		Budget:       budget,
+	}
+	if err := s.historyBackfills.Create(ctx, bf); err != nil {
+		return nil, err
+	}
+	logger.Info("[SyncService.HistoryBackfill] Queued backfill %d for connection %d: %s ~ %s (budget %d)",
+		bf.ID, connectionID, since.Format("2006-01-02"), until.Format("2006-01-02"), budget)
+	return bf, nil
+}
+
+// HistoryBackfillQuotaRemaining returns how many messages the user may still backfill in the quota window.
+func (s *SyncService) HistoryBackfillQuotaRemaining(ctx context.Context, userID uuid.UUID) (int, error) {
+	if s.historyBackfills == nil {
+		return 0, ErrHistoryBackfillDisabled
+	}
+	used, err := s.historyBackfills.UsedBudget(ctx, userID.String(), time.Now().Add(-historyBackfillQuotaWindow))
+	if err != nil {
+		return 0, err
+	}
+	return max(s.historyBackfillQuota-used, 0), nil
+}
+
+// ListHistoryBackfills lists the user's recent backfills.
+func (s *SyncService) ListHistoryBackfills(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.HistoryBackfill, error) {
+	if s.historyBackfills == nil {
+		return nil, ErrHistoryBackfillDisabled
+	}
+	return s.historyBackfills.ListByUser(ctx, userID.String(), limit)
+}
+
+// CancelHistoryBackfill stops an active backfill. 이미 가져온 메일은 그대로 둔다.
+func (s *SyncService) CancelHistoryBackfill(ctx context.Context, userID uuid.UUID, id int64) (*domain.HistoryBackfill, error) {
+	if s.historyBackfills == nil {
+		return nil, ErrHistoryBackfillDisabled
+	}
+	bf, err := s.historyBackfills.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if bf == nil || bf.UserID != userID.String() {
+		return nil, ErrHistoryBackfillNotFound
+	}
+	if bf.IsActive() {
+		if err := s.historyBackfills.Finish(ctx, id, domain.HistoryBackfillCancelled, ""); err != nil {
+			return nil, err
+		}
+		bf.Status = domain.HistoryBackfillCancelled
+	}
+	return bf, nil
+}
+
+// RunHistoryBackfills advances active backfills by a few pages each (HistoryBackfillScheduler).
+func (s *SyncService) RunHistoryBackfills(ctx context.Context) {
+	if s.historyBackfills == nil {
+		return
+	}
+
+	active, err := s.historyBackfills.ListActive(ctx, historyBackfillBatch)
+	if err != nil {
+		logger.Error("[SyncService.HistoryBackfill] Failed to list active backfills: %v", err)
+		return
+	}
+
+	for _, bf := range active {
+		if ctx.Err() != nil {
+			return
+		}
+		err := s.withLock(ctx, fmt.Sprintf("history-backfill:%d", bf.ConnectionID), func(ctx context.Context) error {
+			return s.runHistoryBackfill(ctx, bf)
+		})
+		if errors.Is(err, lock.ErrNotAcquired) {
+			continue // 다른 워커가 처리 중
+		}
+		if err != nil {
+			logger.Warn("[SyncService.HistoryBackfill] Backfill %d for connection %d paused: %v", bf.ID, bf.ConnectionID, err)
+		}
+	}
+}
+
+// runHistoryBackfill fetches up to historyBackfillPagesPerRun pages from the checkpoint.
+// 일시적 오류는 체크포인트를 남기고 다음 실행에서 이어서, 영구 오류는 failed로 끝낸다.
+func (s *SyncService) runHistoryBackfill(ctx context.Context, bf *domain.HistoryBackfill) error {
+	token, err := s.oauthService.GetOAuth2Token(ctx, bf.ConnectionID)
+	if err != nil {
+		return s.failHistoryBackfill(ctx, bf, fmt.Errorf("failed to get token: %w", err))
+	}
+	conn, err := s.oauthService.GetConnection(ctx, bf.ConnectionID)
+	if err != nil {
+		return s.failHistoryBackfill(ctx, bf, fmt.Errorf("failed to get connection: %w", err))
+	}
+
+	query := fmt.Sprintf("after:%s before:%s", bf.Since.Format("2006/01/02"), bf.Until.Format("2006/01/02"))
+
+	for page := 0; page < historyBackfillPagesPerRun; page++ {
+		if page > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(historyBackfillPageDelay):
+			}
+		}
+
+		result, err := s.emailProvider.ListMessages(ctx, token, &out.ProviderListOptions{
+			Query:      query,
+			MaxResults: min(historyBackfillPageSize, bf.Remaining()),
+			PageToken:  bf.PageToken,
+		})
+		if err != nil {
+			return s.failHistoryBackfill(ctx, bf, fmt.Errorf("failed to list messages: %w", err))
+		}
+
+		saved, err := s.processMessagesWithPriority(ctx, result.Messages, bf.UserID, bf.ConnectionID, conn.Email, token, out.JobPriorityLow)
+		if err != nil {
+			logger.Error("[SyncService.HistoryBackfill] Error processing messages: %v", err)
+		}
+		bf.Fetched += len(result.Messages)
+		bf.Saved += saved
+		bf.PageToken = result.NextPageToken
+
+		active, err := s.historyBackfills.SaveCheckpoint(ctx, bf.ID, bf.PageToken, bf.Fetched, bf.Saved)
+		if err != nil {
+			return err
+		}
+		if !active {
+			logger.Info("[SyncService.HistoryBackfill] Backfill %d was cancelled", bf.ID)
+			return nil
+		}
+
+		if bf.PageToken == "" || bf.Remaining() == 0 {
+			logger.Info("[SyncService.HistoryBackfill] Completed backfill %d for connection %d: fetched %d, saved %d",
+				bf.ID, bf.ConnectionID, bf.Fetched, bf.Saved)
+			return s.historyBackfills.Finish(ctx, bf.ID, domain.HistoryBackfillCompleted, "")
+		}
+	}
+	return nil
+}
+
+// failHistoryBackfill keeps the backfill for the next run on transient errors and fails it otherwise.
+func (s *SyncService) failHistoryBackfill(ctx context.Context, bf *domain.HistoryBackfill, err error) error {
+	if retry.IsRetriable(err) || ctx.Err() != nil {
+		return err
+	}
+	logger.Error("[SyncService.HistoryBackfill] Backfill %d for connection %d failed: %v", bf.ID, bf.ConnectionID, err)
+	if finishErr := s.historyBackfills.Finish(ctx, bf.ID, domain.HistoryBackfillFailed, err.Error()); finishErr != nil {
+		return finishErr
+	}
+	return err
+}
@@ -0,0 +1,373 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/core/service/auth"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/mailparse"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// ImportService - MBOX/EML 가져오기
+// =============================================================================
+//
+// 1. API: 업로드 파일을 mail_imports에 저장하고 mail:import 작업 발행
+// 2. Worker: 메시지 파싱 → local archive 연결 아래 저장 → AI 분류/RAG 인덱싱 발행
+//
+// 가져온 메일은 Provider에서 다시 가져올 수 없으므로 본문 TTL을 길게 둔다.
+
+const (
+	MaxImportSize        = 10 << 20  // 업로드 파일 최대 크기 (API BodyLimit과 동일)
+	MaxImportMessageSize = 25 << 20  // 단일 메시지 최대 크기 (초과 시 skip)
+	ImportBatchSize      = 100       // BulkUpsert 배치 크기
+	LocalBodyTTLDays     = 3650      // local archive 본문 보관 기간
+)
+
+var (
+	ErrImportEmpty         = errors.New("import file is empty")
+	ErrImportTooLarge      = errors.New("import file is too large")
+	ErrImportUnknownFormat = errors.New("unsupported import format (expected .mbox or .eml)")
+)
+
+type ImportService struct {
+	importRepo      out.MailImportRepository
+	emailRepo       out.EmailRepository
+	emailBodyRepo   out.EmailBodyRepository
+	oauthService    *auth.OAuthService
+	messageProducer out.MessageProducer
+}
+
+func NewImportService(
+	importRepo out.MailImportRepository,
+	emailRepo out.EmailRepository,
+	emailBodyRepo out.EmailBodyRepository,
+	oauthService *auth.OAuthService,
+	messageProducer out.MessageProducer,
+) *ImportService {
+	return &ImportService{
+		importRepo:      importRepo,
+		emailRepo:       emailRepo,
+		emailBodyRepo:   emailBodyRepo,
+		oauthService:    oauthService,
+		messageProducer: messageProducer,
+	}
+}
+
+// DetectImportFormat determines the archive format from the filename, falling back to content sniffing.
+func DetectImportFormat(filename string, data []byte) (domain.MailImportFormat, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".mbox", ".mbx":
+		return domain.MailImportFormatMbox, nil
+	case ".eml":
+		return domain.MailImportFormatEML, nil
+	}
+
+	if bytes.HasPrefix(data, []byte("From ")) {
+		return domain.MailImportFormatMbox, nil
+	}
+	if bytes.Contains(data[:min(len(data), 4096)], []byte("\nSubject:")) {
+		return domain.MailImportFormatEML, nil
+	}
+	return "", ErrImportUnknownFormat
+}
+
+// CreateImport stores the uploaded archive and queues it for the worker.
+func (s *ImportService) CreateImport(ctx context.Context, userID uuid.UUID, filename string, data []byte) (*domain.MailImport, error) {
+	if len(data) == 0 {
+		return nil, ErrImportEmpty
+	}
+	if len(data) > MaxImportSize {
+		return nil, ErrImportTooLarge
+	}
+
+	format, err := DetectImportFormat(filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &domain.MailImport{
+		UserID:    userID,
+		Filename:  filepath.Base(filename),
+		Format:    format,
+		SizeBytes: int64(len(data)),
+		Status:    domain.MailImportPending,
+	}
+	if err := s.importRepo.Create(ctx, job, data); err != nil {
+		return nil, fmt.Errorf("failed to create import: %w", err)
+	}
+
+	if s.messageProducer == nil {
+		return nil, fmt.Errorf("message producer not initialized")
+	}
+	if err := s.messageProducer.PublishMailImport(ctx, &out.MailImportJob{
+		UserID:   userID.String(),
+		ImportID: job.ID,
+	}); err != nil {
+		_ = s.importRepo.MarkFinished(ctx, job.ID, domain.MailImportFailed, "failed to queue import")
+		return nil, fmt.Errorf("failed to publish import job: %w", err)
+	}
+
+	logger.Info("[ImportService] queued import %d (%s, %d bytes) for user %s", job.ID, format, job.SizeBytes, userID)
+	return job, nil
+}
+
+// GetImport returns an import job owned by the user.
+func (s *ImportService) GetImport(ctx context.Context, userID uuid.UUID, id int64) (*domain.MailImport, error) {
+	return s.importRepo.GetByID(ctx, userID, id)
+}
+
+// ListImports returns recent import jobs.
+func (s *ImportService) ListImports(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.MailImport, error) {
+	return s.importRepo.ListByUser(ctx, userID, limit)
+}
+
+// =============================================================================
+// Worker side
+// =============================================================================
+
+// importProgress tracks counters while processing an archive.
+type importProgress struct {
+	total    int
+	imported int
+	skipped  int
+	failed   int
+}
+
+// ProcessImport parses the stored archive and saves messages under the local archive connection.
+func (s *ImportService) ProcessImport(ctx context.Context, userID uuid.UUID, importID int64) error {
+	job, err := s.importRepo.GetByID(ctx, userID, importID)
+	if err != nil {
+		return fmt.Errorf("failed to get import %d: %w", importID, err)
+	}
+	if job.IsFinished() {
+		return nil
+	}
+
+	conn, err := s.oauthService.GetOrCreateLocalConnection(ctx, userID)
+	if err != nil {
+		return s.failImport(ctx, importID, err)
+	}
+	if err := s.importRepo.MarkProcessing(ctx, importID, conn.ID); err != nil {
+		return fmt.Errorf("failed to mark import processing: %w", err)
+	}
+
+	source, err := s.importRepo.GetSource(ctx, importID)
+	if err != nil {
+		return s.failImport(ctx, importID, err)
+	}
+
+	progress := &importProgress{}
+	batch := make([]*mailparse.Message, 0, ImportBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.saveBatch(ctx, userID, conn, batch); err != nil {
+			logger.WithError(err).Warn("[ImportService] batch save failed (import=%d)", importID)
+			progress.failed += len(batch)
+		} else {
+			progress.imported += len(batch)
+		}
+		batch = batch[:0]
+		return s.importRepo.UpdateProgress(ctx, importID, progress.total, progress.imported, progress.skipped, progress.failed)
+	}
+
+	handle := func(raw []byte) error {
+		progress.total++
+		msg, err := mailparse.Parse(raw)
+		if err != nil {
+			progress.failed++
+			return nil
+		}
+		batch = append(batch, msg)
+		if len(batch) >= ImportBatchSize {
+			return flush()
+		}
+		return nil
+	}
+
+	switch job.Format {
+	case domain.MailImportFormatMbox:
+		skipped, splitErr := mailparse.SplitMbox(bytes.NewReader(source), MaxImportMessageSize, handle)
+		progress.total += skipped
+		progress.skipped += skipped
+		err = splitErr
+	default:
+		err = handle(source)
+	}
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		return s.failImport(ctx, importID, err)
+	}
+
+	if err := s.importRepo.MarkFinished(ctx, importID, domain.MailImportCompleted, ""); err != nil {
+		logger.Warn("[ImportService] failed to mark import %d completed: %v", importID, err)
+	}
+	if err := s.importRepo.ClearSource(ctx, importID); err != nil {
+		logger.Warn("[ImportService] failed to clear import source %d: %v", importID, err)
+	}
+
+	logger.Info("[ImportService] import %d completed: total=%d imported=%d skipped=%d failed=%d",
+		importID, progress.total, progress.imported, progress.skipped, progress.failed)
+	return nil
+}
+
+func (s *ImportService) failImport(ctx context.Context, importID int64, cause error) error {
+	if err := s.importRepo.MarkFinished(ctx, importID, domain.MailImportFailed, cause.Error()); err != nil {
+		logger.Warn("[ImportService] failed to mark import %d failed: %v", importID, err)
+	}
+	return cause
+}
+
+// saveBatch upserts metadata, stores bodies and queues the AI pipeline.
+func (s *ImportService) saveBatch(ctx context.Context, userID uuid.UUID, conn *domain.OAuthConnection, msgs []*mailparse.Message) error {
+	entities := make([]*out.MailEntity, 0, len(msgs))
+	byExternalID := make(map[string]*mailparse.Message, len(msgs))
+
+	for _, msg := range msgs {
+		externalID := importExternalID(msg)
+		if _, dup := byExternalID[externalID]; dup {
+			continue
+		}
+		byExternalID[externalID] = msg
+		entities = append(entities, s.toMailEntity(userID, conn, externalID, msg))
+	}
+
+	if err := s.emailRepo.BulkUpsert(ctx, userID, conn.ID, entities); err != nil {
+		return fmt.Errorf("failed to bulk upsert imported emails: %w", err)
+	}
+
+	externalIDs := make([]string, 0, len(byExternalID))
+	for id := range byExternalID {
+		externalIDs = append(externalIDs, id)
+	}
+	saved, err := s.emailRepo.GetByExternalIDs(ctx, conn.ID, externalIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load imported emails: %w", err)
+	}
+
+	emailIDs := make([]int64, 0, len(saved))
+	bodies := make([]*out.MailBodyEntity, 0, len(saved))
+	now := time.Now()
+	for externalID, entity := range saved {
+		msg := byExternalID[externalID]
+		if msg == nil {
+			continue
+		}
+		emailIDs = append(emailIDs, entity.ID)
+
+		body := out.NewMailBodyEntity(entity.ID, conn.ID, externalID)
+		body.HTML = msg.HTML
+		body.Text = msg.Text
+		body.OriginalSize = msg.Size
+		body.TTLDays = LocalBodyTTLDays
+		body.ExpiresAt = now.AddDate(0, 0, LocalBodyTTLDays)
+		for i, a := range msg.Attachments {
+			body.Attachments = append(body.Attachments, out.AttachmentEntity{
+				ID:        fmt.Sprintf("local-%d", i),
+				Name:      a.Filename,
+				MimeType:  a.MimeType,
+				Size:      a.Size,
+				ContentID: a.ContentID,
+				IsInline:  a.IsInline,
+			})
+		}
+		bodies = append(bodies, body)
+	}
+
+	if s.emailBodyRepo != nil && len(bodies) > 0 {
+		if err := s.emailBodyRepo.BulkSaveBody(ctx, bodies); err != nil {
+			logger.Warn("[ImportService] failed to save imported bodies: %v", err)
+		}
+	}
+
+	// AI 분류 + RAG 인덱싱 (provider 메일과 동일한 파이프라인)
+	if s.messageProducer != nil && len(emailIDs) > 0 {
+		if err := s.messageProducer.PublishAIBatchClassify(ctx, &out.AIBatchClassifyJob{
+			UserID:   userID.String(),
+			EmailIDs: emailIDs,
+			Priority: out.JobPriorityLow, // 가져온 과거 메일은 실시간 분류보다 뒤로
+		}); err != nil {
+			logger.Warn("[ImportService] failed to publish classify job: %v", err)
+		}
+		if err := s.messageProducer.PublishRAGBatchIndex(ctx, &out.RAGBatchIndexJob{
+			UserID:       userID.String(),
+			ConnectionID: conn.ID,
+			EmailIDs:     emailIDs,
+		}); err != nil {
+			logger.Warn("[ImportService] failed to publish RAG job: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *ImportService) toMailEntity(userID uuid.UUID, conn *domain.OAuthConnection, externalID string, msg *mailparse.Message) *out.MailEntity {
+	receivedAt := msg.Date
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+
+	return &out.MailEntity{
+		UserID:         userID,
+		ConnectionID:   conn.ID,
+		Provider:       string(domain.MailProviderLocal),
+		AccountEmail:   conn.Email,
+		ExternalID:     externalID,
+		MessageID:      msg.MessageID,
+		InReplyTo:      msg.InReplyTo,
+		References:     msg.References,
+		FromEmail:      msg.From.Email,
+		FromName:       msg.From.Name,
+		ToEmails:       addressEmails(msg.To),
+		CcEmails:       addressEmails(msg.Cc),
+		BccEmails:      addressEmails(msg.Bcc),
+		Subject:        msg.Subject,
+		Snippet:        msg.Snippet(200),
+		Direction:      "inbound",
+		IsRead:         true,
+		HasAttachment:  msg.HasAttachments(),
+		Folder:         "inbox",
+		Labels:         []string{"imported"},
+		ReceivedAt:     receivedAt,
+		AIStatus:       "pending",
+		WorkflowStatus: "none",
+	}
+}
+
+// importExternalID derives a stable ID so re-importing the same archive upserts instead of duplicating.
+func importExternalID(msg *mailparse.Message) string {
+	key := msg.MessageID
+	if key == "" {
+		key = fmt.Sprintf("%s|%s|%s|%d", msg.From.Email, msg.Subject, msg.Date.UTC().Format(time.RFC3339), msg.Size)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return "local_" + hex.EncodeToString(sum[:16])
+}
+
+func addressEmails(addrs []mailparse.Address) []string {
+	if len(addrs) == 0 {
+		return nil
+	}
+	emails := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		emails = append(emails, a.Email)
+	}
+	return emails
+}
@@ -0,0 +1,111 @@
+package mail
+
+import (
+	"context"
+	"slices"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+)
+
+// starredTag is the tag that marks an email as starred (is_starred 컬럼 없음).
+const starredTag = "starred"
+
+// applyLabelChanges stores provider-side label changes of existing emails and
+// pushes one cache invalidation hint with the changed fields.
+// 읽음/별표/폴더/라벨 중 실제로 바뀐 값만 갱신하고, 바뀐 메일의 이전/새 폴더를 scope에 기록한다.
+func (s *SyncService) applyLabelChanges(ctx context.Context, userID string, connectionID int64, changes []out.ProviderLabelChange, scope *ListCacheScope) {
+	if len(changes) == 0 {
+		return
+	}
+
+	externalIDs := make([]string, len(changes))
+	for i, c := range changes {
+		externalIDs[i] = c.ExternalID
+	}
+	existing, err := s.emailRepo.GetByExternalIDs(ctx, connectionID, externalIDs)
+	if err != nil {
+		logger.Error("[SyncService.applyLabelChanges] Failed to load emails: %v", err)
+		return
+	}
+
+	hint := domain.NewEmailInvalidateData(domain.InvalidateSourceLabelSync)
+	for _, c := range changes {
+		entity, ok := existing[c.ExternalID]
+		if !ok {
+			continue
+		}
+		patch, err := s.applyLabelChange(ctx, entity, c)
+		if err != nil {
+			logger.Warn("[SyncService.applyLabelChanges] Failed to update email %d: %v", entity.ID, err)
+		}
+		if len(patch) > 0 {
+			scope.AddEntity(entity)
+			if folder, ok := patch["folder"].(string); ok {
+				scope.Add(folder, "")
+			}
+		}
+		hint.Add(entity.ID, patch)
+	}
+
+	if s.realtime == nil || hint.Empty() {
+		return
+	}
+	s.realtime.Push(ctx, userID, domain.NewEmailInvalidateEvent(userID, hint))
+	logger.Debug("[SyncService.applyLabelChanges] %d emails changed: %v", len(hint.EmailIDs), hint.Fields)
+}
+
+// applyLabelChange updates one email and returns the fields that were stored.
+func (s *SyncService) applyLabelChange(ctx context.Context, entity *out.MailEntity, c out.ProviderLabelChange) (map[string]any, error) {
+	patch := make(map[string]any)
+
+	if entity.IsRead != c.IsRead {
+		if err := s.emailRepo.UpdateReadStatus(ctx, entity.ID, c.IsRead); err != nil {
+			return patch, err
+		}
+		patch["is_read"] = c.IsRead
+	}
+
+	if starred := slices.Contains(entity.Tags, starredTag); starred != c.IsStarred {
+		var add, remove []string
+		if c.IsStarred {
+			add = []string{starredTag}
+		} else {
+			remove = []string{starredTag}
+		}
+		if err := s.emailRepo.BatchUpdateTags(ctx, []int64{entity.ID}, add, remove); err != nil {
+			return patch, err
+		}
+		patch["is_starred"] = c.IsStarred
+	}
+
+	if c.Folder != "" && entity.Folder != c.Folder {
+		if err := s.emailRepo.UpdateFolder(ctx, entity.ID, c.Folder); err != nil {
+			return patch, err
+		}
+		patch["folder"] = c.Folder
+	}
+
+	changed := false
+	for _, label := range c.Labels {
+		if !slices.Contains(entity.Labels, label) {
+			if err := s.emailRepo.AddLabel(ctx, entity.ID, label); err != nil {
+				return patch, err
+			}
+			changed = true
+		}
+	}
+	for _, label := range entity.Labels {
+		if !slices.Contains(c.Labels, label) {
+			if err := s.emailRepo.RemoveLabel(ctx, entity.ID, label); err != nil {
+				return patch, err
+			}
+			changed = true
+		}
+	}
+	if changed {
+		patch["labels"] = c.Labels
+	}
+	return patch, nil
+}
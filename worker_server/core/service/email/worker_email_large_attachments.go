@@ -0,0 +1,20 @@
+package mail
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+// linkLargeAttachments replaces attachments over the provider limit with download links.
+// 서비스가 없으면 그대로 보내고 Provider가 한도를 판단한다.
+func (s *Service) linkLargeAttachments(ctx context.Context, userID uuid.UUID, conn *domain.OAuthConnection, outgoing *out.ProviderOutgoingMessage) error {
+	if s.fileLinks == nil || len(outgoing.Attachments) == 0 {
+		return nil
+	}
+	_, err := s.fileLinks.Apply(ctx, userID, string(conn.Provider), outgoing)
+	return err
+}
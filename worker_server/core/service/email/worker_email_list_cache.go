@@ -0,0 +1,261 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/ratelimit"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// List Cache - 목록 캐시 키와 동기화 후 워밍
+// 캐시 키는 email:list:<user>:<view>:... 형식이라 바뀐 뷰만 정확히 지울 수 있다.
+// =============================================================================
+
+// List cache views.
+const (
+	ListViewEmails   = "emails"   // GET /email (폴더별: emails:folder:<folder>)
+	ListViewInbox    = "inbox"    // GET /email/inbox
+	ListViewCategory = "category" // GET /email/category/:category (category:<category>)
+)
+
+// listPageSize is the default first-page size of list endpoints.
+const listPageSize = 20
+
+// InboxListFilter returns the filter of GET /email/inbox before optional query filters.
+func InboxListFilter(userID uuid.UUID, connectionID *int64) *domain.EmailFilter {
+	view := "inbox" // Inbox view: primary, work, personal only
+	return &domain.EmailFilter{
+		UserID:       userID,
+		ConnectionID: connectionID,
+		ViewType:     &view,
+		PinnedFirst:  true, // 고정 메일은 날짜와 관계없이 맨 위
+		Limit:        listPageSize,
+
+		ExcludeReadLater: true, // 나중에 읽기 큐는 별도 목록
+	}
+}
+
+// CategoryListFilter returns the filter of GET /email/category/:category before optional query filters.
+func CategoryListFilter(userID uuid.UUID, connectionID *int64, category domain.EmailCategory) *domain.EmailFilter {
+	return &domain.EmailFilter{
+		UserID:       userID,
+		ConnectionID: connectionID,
+		Category:     &category,
+		Limit:        listPageSize,
+
+		ExcludeReadLater: true, // 나중에 읽기 큐는 별도 목록
+	}
+}
+
+// EmailsListView returns the cache view of GET /email for a folder (빈 문자열이면 전체).
+func EmailsListView(folder string) string {
+	if folder == "" {
+		folder = "all"
+	}
+	return ListViewEmails + ":folder:" + folder
+}
+
+// CategoryListView returns the cache view of a category list.
+func CategoryListView(category string) string {
+	return ListViewCategory + ":" + category
+}
+
+// EmailsListCacheKey returns the cache key of GET /email.
+func EmailsListCacheKey(filter *domain.EmailFilter) string {
+	folder := ""
+	if filter.Folder != nil {
+		folder = string(*filter.Folder)
+	}
+	params := "conn:" + optInt64(filter.ConnectionID)
+	if filter.AssignedToMe {
+		params += ":assigned"
+	}
+	if filter.Language != nil {
+		params += ":lang:" + *filter.Language
+	}
+	if filter.IncludeArchived {
+		params += ":archived"
+	}
+	if filter.MinSize != nil || filter.MaxSize != nil {
+		params += ":size:" + optInt64(filter.MinSize) + "-" + optInt64(filter.MaxSize)
+	}
+	return ratelimit.ListKey(filter.UserID.String(), EmailsListView(folder), pageParams(params, filter))
+}
+
+// InboxListCacheKey returns the cache key of GET /email/inbox.
+func InboxListCacheKey(filter *domain.EmailFilter) string {
+	params := fmt.Sprintf("conn:%s:wf:%s:assigned:%t",
+		optInt64(filter.ConnectionID), optWorkflow(filter.WorkflowStatus), filter.AssignedToMe)
+	return ratelimit.ListKey(filter.UserID.String(), ListViewInbox, pageParams(params, filter))
+}
+
+// CategoryListCacheKey returns the cache key of GET /email/category/:category.
+func CategoryListCacheKey(category string, filter *domain.EmailFilter) string {
+	sender, language := "", ""
+	if filter.Sender != nil {
+		sender = *filter.Sender
+	}
+	if filter.Language != nil {
+		language = *filter.Language
+	}
+	params := fmt.Sprintf("conn:%s:wf:%s:sender:%s:lang:%s",
+		optInt64(filter.ConnectionID), optWorkflow(filter.WorkflowStatus), sender, language)
+	return ratelimit.ListKey(filter.UserID.String(), CategoryListView(category), pageParams(params, filter))
+}
+
+func pageParams(params string, filter *domain.EmailFilter) string {
+	return fmt.Sprintf("%s:limit:%d:offset:%d", params, filter.Limit, filter.Offset)
+}
+
+func optInt64(v *int64) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+func optWorkflow(v *domain.WorkflowStatus) string {
+	if v == nil {
+		return "-"
+	}
+	return string(*v)
+}
+
+// ListCacheScope collects the list views touched by a sync batch.
+type ListCacheScope struct {
+	folders    map[string]bool
+	categories map[string]bool
+}
+
+// Add records the folder and category of a changed email (빈 값은 무시).
+func (s *ListCacheScope) Add(folder, category string) {
+	if s.folders == nil {
+		s.folders = make(map[string]bool)
+		s.categories = make(map[string]bool)
+	}
+	if folder != "" {
+		s.folders[folder] = true
+	}
+	if category != "" {
+		s.categories[category] = true
+	}
+}
+
+// AddEmail records a synced email.
+func (s *ListCacheScope) AddEmail(email *domain.Email) {
+	category := ""
+	if email.AICategory != nil {
+		category = string(*email.AICategory)
+	}
+	s.Add(string(email.Folder), category)
+}
+
+// AddEntity records a stored email.
+func (s *ListCacheScope) AddEntity(entity *out.MailEntity) {
+	s.Add(entity.Folder, entity.Category)
+}
+
+// Empty reports whether nothing was added.
+func (s *ListCacheScope) Empty() bool {
+	return len(s.folders) == 0 && len(s.categories) == 0
+}
+
+// ListCacheWarmer busts the list views touched by a sync batch and refills their first pages.
+// InvalidateByUser와 달리 관련 없는 폴더/카테고리 캐시는 그대로 둔다.
+type ListCacheWarmer struct {
+	lister interface {
+		ListEmails(ctx context.Context, filter *domain.EmailFilter) ([]*domain.Email, int, error)
+	}
+	cache *ratelimit.EmailListCache
+}
+
+// NewListCacheWarmer creates a list cache warmer.
+func NewListCacheWarmer(service *Service, cache *ratelimit.EmailListCache) *ListCacheWarmer {
+	return &ListCacheWarmer{lister: service, cache: cache}
+}
+
+// Warm invalidates the touched views and warms the first inbox/category pages.
+// 폴더 목록(GET /email)은 필터 조합이 많아 지우기만 한다.
+// 다른 프로세스(API)의 L1은 지울 수 없으므로 L1TTL 안에 갱신된다.
+func (w *ListCacheWarmer) Warm(ctx context.Context, userID uuid.UUID, scope *ListCacheScope) {
+	if w == nil || scope == nil || scope.Empty() {
+		return
+	}
+	user := userID.String()
+
+	views := []string{EmailsListView("")}
+	for folder := range scope.folders {
+		views = append(views, EmailsListView(folder))
+	}
+	inbox := scope.folders[string(domain.LegacyFolderInbox)]
+	if inbox {
+		views = append(views, ListViewInbox)
+	}
+	for category := range scope.categories {
+		views = append(views, CategoryListView(category))
+	}
+	w.cache.InvalidateViews(ctx, user, views...)
+
+	if inbox {
+		filter := InboxListFilter(userID, nil)
+		w.warm(ctx, InboxListCacheKey(filter), filter)
+	}
+	for category := range scope.categories {
+		filter := CategoryListFilter(userID, nil, domain.EmailCategory(category))
+		w.warm(ctx, CategoryListCacheKey(category, filter), filter)
+	}
+}
+
+func (w *ListCacheWarmer) warm(ctx context.Context, key string, filter *domain.EmailFilter) {
+	// 방금 저장한 배치를 반영해야 하므로 replica가 아닌 primary에서 읽는다
+	filter.PrimaryRead = true
+	emails, _, err := w.lister.ListEmails(ctx, filter)
+	if err != nil {
+		logger.Warn("[ListCacheWarmer] Failed to load %s: %v", key, err)
+		return
+	}
+	data, err := json.Marshal(emails)
+	if err != nil {
+		return
+	}
+	w.cache.SetByString(ctx, key, filter.Offset, data)
+}
+
+// SetListCacheWarmer enables list cache warming after sync batches.
+func (s *SyncService) SetListCacheWarmer(w *ListCacheWarmer) {
+	s.listCache = w
+}
+
+// warmListCaches refreshes the list views touched by a sync batch.
+func (s *SyncService) warmListCaches(ctx context.Context, userID string, scope *ListCacheScope) {
+	if s.listCache == nil || scope.Empty() {
+		return
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return
+	}
+	s.listCache.Warm(ctx, uid, scope)
+}
+
+// scopeDeleted records the emails about to be deleted (삭제 후에는 폴더/카테고리를 알 수 없다).
+func (s *SyncService) scopeDeleted(ctx context.Context, connectionID int64, externalIDs []string, scope *ListCacheScope) {
+	if s.listCache == nil {
+		return
+	}
+	existing, err := s.emailRepo.GetByExternalIDs(ctx, connectionID, externalIDs)
+	if err != nil {
+		return
+	}
+	for _, entity := range existing {
+		scope.AddEntity(entity)
+	}
+}
@@ -0,0 +1,86 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+// maxMuteThreadEmails - 뮤트 시 보관하는 스레드 메일 수 (List 최대 페이지)
+const maxMuteThreadEmails = 100
+
+var (
+	ErrThreadNotFound = errors.New("thread not found")
+	ErrThreadNotMuted = errors.New("thread is not muted")
+)
+
+// SetThreadMuteRepository enables thread muting (새 메일은 SyncService가 자동 보관한다).
+func (s *Service) SetThreadMuteRepository(repo out.ThreadMuteRepository) {
+	s.threadMuteRepo = repo
+}
+
+// MuteThread mutes a thread on every connection it was synced to and archives its inbox emails.
+// Gmail 뮤트처럼 이후 메일은 inbox와 알림을 건너뛴다. Returns the archived email IDs.
+func (s *Service) MuteThread(ctx context.Context, userID uuid.UUID, threadID string, connectionID *int64) ([]int64, error) {
+	if s.emailRepo == nil || s.threadMuteRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	threadID = strings.TrimSpace(threadID)
+	if threadID == "" {
+		return nil, ErrThreadNotFound
+	}
+
+	// 조회 결과로 바로 보관 처리하므로 replica 지연 없이 primary에서 읽는다
+	emails, _, err := s.emailRepo.List(out.WithPrimaryRead(ctx), userID, &out.MailListQuery{
+		ConnectionID: connectionID,
+		ThreadID:     threadID,
+		Limit:        maxMuteThreadEmails,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(emails) == 0 {
+		return nil, ErrThreadNotFound
+	}
+
+	muted := make(map[int64]bool)
+	archive := make([]int64, 0, len(emails))
+	for _, e := range emails {
+		if !muted[e.ConnectionID] {
+			if err := s.threadMuteRepo.Mute(ctx, userID, e.ConnectionID, threadID); err != nil {
+				return nil, err
+			}
+			muted[e.ConnectionID] = true
+		}
+		if e.Folder == string(domain.LegacyFolderInbox) {
+			archive = append(archive, e.ID)
+		}
+	}
+
+	if len(archive) > 0 {
+		if err := s.Archive(ctx, userID, archive); err != nil {
+			return nil, err
+		}
+	}
+	return archive, nil
+}
+
+// UnmuteThread unmutes a thread. 이미 보관된 메일은 inbox로 되돌리지 않는다.
+func (s *Service) UnmuteThread(ctx context.Context, userID uuid.UUID, threadID string) error {
+	if s.threadMuteRepo == nil {
+		return ErrRepoNotInitialized
+	}
+	ok, err := s.threadMuteRepo.Unmute(ctx, userID, strings.TrimSpace(threadID))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrThreadNotMuted
+	}
+	return nil
+}
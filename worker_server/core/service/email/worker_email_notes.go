@@ -0,0 +1,138 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/in"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxNoteLength    = 10000
+	maxNoteTags      = 20
+	maxNoteTagLength = 50
+)
+
+var (
+	ErrNoteNotFound = errors.New("note not found")
+	ErrNoteEmpty    = errors.New("note body or tags required")
+	ErrNoteTooLong  = errors.New("note is too long (max 10000 characters)")
+	ErrInvalidTags  = errors.New("too many tags or tag too long (max 20 tags, 50 characters each)")
+)
+
+// SetNoteRepository enables private email notes (메일 상세에 포함, 검색 색인).
+func (s *Service) SetNoteRepository(repo out.EmailNoteRepository) {
+	s.noteRepo = repo
+}
+
+// ListNotes returns the notes of an email.
+func (s *Service) ListNotes(ctx context.Context, userID uuid.UUID, emailID int64) ([]*domain.EmailNote, error) {
+	if s.noteRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	return s.noteRepo.ListByEmailID(ctx, userID, emailID)
+}
+
+// AddNote attaches a private note to an email. 로컬에만 저장하고 Provider에는 동기화하지 않는다.
+func (s *Service) AddNote(ctx context.Context, userID uuid.UUID, emailID int64, req *in.EmailNoteRequest) (*domain.EmailNote, error) {
+	if s.noteRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	note := &domain.EmailNote{EmailID: emailID, UserID: userID}
+	if err := fillNote(note, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.noteRepo.Create(ctx, note); err != nil {
+		if errors.Is(err, out.ErrEmailNoteNotFound) {
+			return nil, ErrEmailNotFound
+		}
+		return nil, err
+	}
+	s.reindexForNotes(ctx, userID, emailID)
+	return note, nil
+}
+
+// UpdateNote replaces the body and tags of a note.
+func (s *Service) UpdateNote(ctx context.Context, userID uuid.UUID, emailID, noteID int64, req *in.EmailNoteRequest) (*domain.EmailNote, error) {
+	if s.noteRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	note := &domain.EmailNote{ID: noteID, EmailID: emailID, UserID: userID}
+	if err := fillNote(note, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.noteRepo.Update(ctx, note); err != nil {
+		if errors.Is(err, out.ErrEmailNoteNotFound) {
+			return nil, ErrNoteNotFound
+		}
+		return nil, err
+	}
+	s.reindexForNotes(ctx, userID, emailID)
+	return note, nil
+}
+
+// DeleteNote deletes a note.
+func (s *Service) DeleteNote(ctx context.Context, userID uuid.UUID, emailID, noteID int64) error {
+	if s.noteRepo == nil {
+		return ErrRepoNotInitialized
+	}
+	if err := s.noteRepo.Delete(ctx, userID, emailID, noteID); err != nil {
+		if errors.Is(err, out.ErrEmailNoteNotFound) {
+			return ErrNoteNotFound
+		}
+		return err
+	}
+	s.reindexForNotes(ctx, userID, emailID)
+	return nil
+}
+
+// reindexForNotes re-embeds the email so semantic search reflects its notes.
+func (s *Service) reindexForNotes(ctx context.Context, userID uuid.UUID, emailID int64) {
+	if s.messageProducer == nil {
+		return
+	}
+	if err := s.messageProducer.PublishRAGIndex(ctx, &out.RAGIndexJob{UserID: userID.String(), EmailID: emailID}); err != nil {
+		logger.Warn("[MailService.Notes] Failed to publish reindex for email %d: %v", emailID, err)
+	}
+}
+
+// fillNote validates the request and normalizes tags (소문자, 중복 제거).
+func fillNote(note *domain.EmailNote, req *in.EmailNoteRequest) error {
+	body := strings.TrimSpace(req.Body)
+	if utf8.RuneCountInString(body) > maxNoteLength {
+		return ErrNoteTooLong
+	}
+
+	tags := make([]string, 0, len(req.Tags))
+	seen := make(map[string]bool, len(req.Tags))
+	for _, tag := range req.Tags {
+		tag = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tag), "#")))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxNoteTagLength {
+			return ErrInvalidTags
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	if len(tags) > maxNoteTags {
+		return ErrInvalidTags
+	}
+	if body == "" && len(tags) == 0 {
+		return ErrNoteEmpty
+	}
+
+	note.Body = body
+	note.Tags = tags
+	return nil
+}
@@ -0,0 +1,306 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/resilience"
+	"worker_server/pkg/retry"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// maxOutboxAttempts - 자동 재시도 한도 (1m, 2m, 4m ... 최대 1시간 간격)
+	maxOutboxAttempts = 8
+	outboxBatchSize   = 20
+	outboxBaseBackoff = time.Minute
+	outboxMaxBackoff  = time.Hour
+	outboxListLimit   = 100
+)
+
+var (
+	ErrSendQueued       = errors.New("send failed temporarily and was queued for retry")
+	ErrOutboxNotFound   = errors.New("outbox message not found")
+	ErrOutboxNotPending = errors.New("outbox message is not queued or failed")
+)
+
+// SendQueuedError carries the outbox message of a send that will be retried.
+type SendQueuedError struct {
+	Message *domain.OutboxMessage
+	Cause   error
+}
+
+func (e *SendQueuedError) Error() string {
+	return fmt.Sprintf("%s: %v", ErrSendQueued.Error(), e.Cause)
+}
+
+func (e *SendQueuedError) Unwrap() error {
+	return ErrSendQueued
+}
+
+// delivery is one provider send (실패 시 그대로 outbox에 저장).
+type delivery struct {
+	kind       string // domain.OutboxKind*
+	replyToID  string
+	outgoing   *out.ProviderOutgoingMessage
+	trackingID int64
+}
+
+// SetOutboxRepository enables queueing sends that fail temporarily.
+func (s *Service) SetOutboxRepository(repo out.OutboxRepository) {
+	s.outboxRepo = repo
+}
+
+// SetRealtime sets the realtime port used for outbox events.
+func (s *Service) SetRealtime(realtime out.RealtimePort) {
+	s.realtime = realtime
+}
+
+// deliver sends through provider and queues the message when the failure is temporary.
+func (s *Service) deliver(ctx context.Context, userID uuid.UUID, conn *domain.OAuthConnection, token *oauth2.Token, provider out.EmailProviderPort, d *delivery) (*out.ProviderSendResult, error) {
+	result, err := sendDelivery(ctx, provider, token, d.kind, d.replyToID, d.outgoing)
+	if err == nil || s.outboxRepo == nil || !isTemporarySendError(err) {
+		return result, err
+	}
+
+	// 요청이 끊겨도 대기열 저장은 마친다
+	msg, qerr := s.enqueueOutbox(context.WithoutCancel(ctx), userID, conn.ID, d, err)
+	if qerr != nil {
+		logger.WithError(qerr).Warn("[MailService.deliver] Failed to queue message")
+		return nil, err
+	}
+	logger.Info("[MailService.deliver] user=%s queued outbox %d: %v", userID, msg.ID, err)
+	return nil, &SendQueuedError{Message: msg, Cause: err}
+}
+
+func sendDelivery(ctx context.Context, provider out.EmailProviderPort, token *oauth2.Token, kind, replyToID string, outgoing *out.ProviderOutgoingMessage) (*out.ProviderSendResult, error) {
+	if kind == domain.OutboxKindReply {
+		return provider.Reply(ctx, token, replyToID, outgoing)
+	}
+	return provider.Send(ctx, token, outgoing)
+}
+
+func (s *Service) enqueueOutbox(ctx context.Context, userID uuid.UUID, connectionID int64, d *delivery, cause error) (*domain.OutboxMessage, error) {
+	payload, err := json.Marshal(d.outgoing)
+	if err != nil {
+		return nil, err
+	}
+
+	next := time.Now().Add(outboxBackoff(1))
+	msg := &domain.OutboxMessage{
+		UserID:        userID,
+		ConnectionID:  connectionID,
+		Kind:          d.kind,
+		ReplyToID:     d.replyToID,
+		Subject:       d.outgoing.Subject,
+		ToEmails:      outgoingRecipients(d.outgoing),
+		Status:        domain.OutboxStatusQueued,
+		Attempts:      1,
+		LastError:     cause.Error(),
+		NextAttemptAt: &next,
+		TrackingID:    d.trackingID,
+		Payload:       payload,
+	}
+	if err := s.outboxRepo.Enqueue(ctx, msg); err != nil {
+		return nil, err
+	}
+	msg.Payload = nil
+	return msg, nil
+}
+
+// isTemporarySendError reports whether a send may succeed later (rate limit, 5xx, network).
+// 열린 circuit도 잠시 뒤 다시 보내면 되므로 대기열에 넣는다.
+func isTemporarySendError(err error) bool {
+	return retry.IsRetriable(err) || resilience.IsBreakerOpen(err)
+}
+
+// outboxRetryPolicy spaces automatic resends 1m, 2m, 4m ... up to 1h apart.
+var outboxRetryPolicy = retry.Policy{
+	MaxAttempts: maxOutboxAttempts,
+	BaseDelay:   outboxBaseBackoff,
+	MaxDelay:    outboxMaxBackoff,
+	Jitter:      0.1,
+}
+
+// outboxBackoff returns the delay before the next attempt after n attempts.
+func outboxBackoff(n int) time.Duration {
+	return outboxRetryPolicy.Delay(n - 1)
+}
+
+func outgoingRecipients(outgoing *out.ProviderOutgoingMessage) []string {
+	recipients := make([]string, 0, len(outgoing.To)+len(outgoing.CC))
+	for _, addr := range append(append([]out.ProviderEmailAddress{}, outgoing.To...), outgoing.CC...) {
+		recipients = append(recipients, addr.Email)
+	}
+	return recipients
+}
+
+// =============================================================================
+// Outbox API
+// =============================================================================
+
+// ListOutbox returns the user's queued, failed and cancelled messages (status로 필터 가능).
+func (s *Service) ListOutbox(ctx context.Context, userID uuid.UUID, status string) ([]*domain.OutboxMessage, error) {
+	if s.outboxRepo == nil {
+		return []*domain.OutboxMessage{}, nil
+	}
+	var statuses []string
+	if status != "" {
+		statuses = []string{status}
+	}
+	return s.outboxRepo.List(ctx, userID, statuses, outboxListLimit)
+}
+
+// RetryOutbox sends a queued or failed message now. 실패한 메시지는 재시도 횟수를 새로 시작한다.
+func (s *Service) RetryOutbox(ctx context.Context, userID uuid.UUID, id int64) (*domain.OutboxMessage, error) {
+	if s.outboxRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	msg, ok, err := s.outboxRepo.Claim(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, out.ErrOutboxNotFound) {
+			return nil, ErrOutboxNotFound
+		}
+		return nil, err
+	}
+	if !ok {
+		return msg, ErrOutboxNotPending
+	}
+
+	s.attemptOutbox(ctx, msg)
+	msg.Payload = nil
+	return msg, nil
+}
+
+// CancelOutbox cancels a queued or failed message.
+func (s *Service) CancelOutbox(ctx context.Context, userID uuid.UUID, id int64) (*domain.OutboxMessage, error) {
+	if s.outboxRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	msg, ok, err := s.outboxRepo.Cancel(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, out.ErrOutboxNotFound) {
+			return nil, ErrOutboxNotFound
+		}
+		return nil, err
+	}
+	if !ok {
+		return msg, ErrOutboxNotPending
+	}
+
+	if msg.TrackingID > 0 && s.tracking != nil {
+		s.tracking.Discard(ctx, &domain.SentTracking{ID: msg.TrackingID})
+	}
+	return msg, nil
+}
+
+// ProcessOutbox sends due outbox messages and returns the number sent (스케줄러가 주기적으로 호출).
+func (s *Service) ProcessOutbox(ctx context.Context) (int, error) {
+	if s.outboxRepo == nil || s.oauthService == nil {
+		return 0, nil
+	}
+	messages, err := s.outboxRepo.ClaimDue(ctx, outboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, msg := range messages {
+		if ctx.Err() != nil {
+			break // 남은 메시지는 staleSendingAfter 후 다시 가져간다
+		}
+		if s.attemptOutbox(ctx, msg) {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// attemptOutbox sends a claimed message and records the result on msg.
+func (s *Service) attemptOutbox(ctx context.Context, msg *domain.OutboxMessage) bool {
+	result, err := s.resendOutbox(ctx, msg)
+	msg.Attempts++
+	now := time.Now()
+
+	if err == nil {
+		if err := s.outboxRepo.MarkSent(ctx, msg.ID, result.ExternalID, result.SentAt); err != nil {
+			logger.WithError(err).Error("[MailService.attemptOutbox] Sent outbox %d but failed to record it", msg.ID)
+		}
+		if msg.TrackingID > 0 && s.tracking != nil {
+			s.tracking.Attach(ctx, &domain.SentTracking{ID: msg.TrackingID}, result.ExternalID)
+		}
+		msg.Status, msg.ProviderID, msg.LastError, msg.NextAttemptAt = domain.OutboxStatusSent, result.ExternalID, "", nil
+		msg.SentAt = &result.SentAt
+		s.pushOutboxEvent(ctx, domain.EventOutboxSent, msg)
+		return true
+	}
+
+	msg.LastError = err.Error()
+	if isTemporarySendError(err) && msg.Attempts < maxOutboxAttempts {
+		next := now.Add(outboxBackoff(msg.Attempts))
+		if rerr := s.outboxRepo.Reschedule(ctx, msg.ID, msg.LastError, next); rerr != nil {
+			logger.WithError(rerr).Error("[MailService.attemptOutbox] Failed to reschedule outbox %d", msg.ID)
+		}
+		msg.Status, msg.NextAttemptAt = domain.OutboxStatusQueued, &next
+		return false
+	}
+
+	if ferr := s.outboxRepo.MarkFailed(ctx, msg.ID, msg.LastError); ferr != nil {
+		logger.WithError(ferr).Error("[MailService.attemptOutbox] Failed to mark outbox %d failed", msg.ID)
+	}
+	msg.Status, msg.NextAttemptAt = domain.OutboxStatusFailed, nil
+	s.pushOutboxEvent(ctx, domain.EventOutboxFailed, msg)
+	logger.Warn("[MailService.attemptOutbox] outbox %d failed after %d attempts: %v", msg.ID, msg.Attempts, err)
+	return false
+}
+
+func (s *Service) resendOutbox(ctx context.Context, msg *domain.OutboxMessage) (*out.ProviderSendResult, error) {
+	var outgoing out.ProviderOutgoingMessage
+	if err := json.Unmarshal(msg.Payload, &outgoing); err != nil {
+		return nil, fmt.Errorf("invalid outbox payload: %w", err)
+	}
+	conn, err := s.oauthService.GetConnection(ctx, msg.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	token, err := s.oauthService.GetOAuth2Token(ctx, conn.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth token: %w", err)
+	}
+	return sendDelivery(ctx, s.sendProvider(conn), token, msg.Kind, msg.ReplyToID, &outgoing)
+}
+
+// sendProvider returns the provider of the connection (업로드 서비스에 등록된 Provider 우선).
+func (s *Service) sendProvider(conn *domain.OAuthConnection) out.EmailProviderPort {
+	if s.uploads != nil {
+		if provider := s.uploads.Provider(string(conn.Provider)); provider != nil {
+			return provider
+		}
+	}
+	return s.provider
+}
+
+func (s *Service) pushOutboxEvent(ctx context.Context, eventType domain.EventType, msg *domain.OutboxMessage) {
+	if s.realtime == nil {
+		return
+	}
+	s.realtime.Push(ctx, msg.UserID.String(), &domain.RealtimeEvent{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Data: &domain.OutboxEventData{
+			OutboxID:   msg.ID,
+			Status:     msg.Status,
+			Subject:    msg.Subject,
+			ProviderID: msg.ProviderID,
+			Error:      msg.LastError,
+		},
+	})
+}
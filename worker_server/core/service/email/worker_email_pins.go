@@ -0,0 +1,144 @@
+package mail
+
+import (
+	"context"
+	"errors"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// maxPinnedEmails - 사용자별 고정 메일 수 (목록 맨 위가 고정 메일로만 채워지지 않도록)
+const maxPinnedEmails = 50
+
+var (
+	ErrTooManyPins     = errors.New("too many pinned emails (max 50)")
+	ErrInvalidPinOrder = errors.New("email_ids must list every pinned email exactly once")
+)
+
+// SetPinRepository enables pinned emails on top of the inbox/todo views.
+func (s *Service) SetPinRepository(repo out.EmailPinRepository) {
+	s.pinRepo = repo
+}
+
+// Pin pins emails to the top of the inbox/todo views in the given order.
+// 로컬 전용이며 Provider에는 동기화하지 않는다. 사용자의 메일이 아닌 ID는 무시한다.
+func (s *Service) Pin(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error) {
+	if s.pinRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	emailIDs = uniqueIDs(emailIDs)
+	if len(emailIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	pinned, err := s.pinRepo.ListIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[int64]bool, len(pinned))
+	for _, id := range pinned {
+		existing[id] = true
+	}
+	count := len(pinned)
+	for _, id := range emailIDs {
+		if !existing[id] {
+			count++
+		}
+	}
+	if count > maxPinnedEmails {
+		return nil, ErrTooManyPins
+	}
+
+	return s.pinRepo.Pin(ctx, userID, emailIDs)
+}
+
+// Unpin removes emails from the pinned list.
+func (s *Service) Unpin(ctx context.Context, userID uuid.UUID, emailIDs []int64) error {
+	if s.pinRepo == nil {
+		return ErrRepoNotInitialized
+	}
+	if len(emailIDs) == 0 {
+		return nil
+	}
+	return s.pinRepo.Unpin(ctx, userID, emailIDs)
+}
+
+// ReorderPins sets the pinned order. emailIDs must contain every pinned email once.
+func (s *Service) ReorderPins(ctx context.Context, userID uuid.UUID, emailIDs []int64) error {
+	if s.pinRepo == nil {
+		return ErrRepoNotInitialized
+	}
+	pinned, err := s.pinRepo.ListIDs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(emailIDs) != len(pinned) {
+		return ErrInvalidPinOrder
+	}
+	existing := make(map[int64]bool, len(pinned))
+	for _, id := range pinned {
+		existing[id] = true
+	}
+	seen := make(map[int64]bool, len(emailIDs))
+	for _, id := range emailIDs {
+		if seen[id] || !existing[id] {
+			return ErrInvalidPinOrder
+		}
+		seen[id] = true
+	}
+	return s.pinRepo.Reorder(ctx, userID, emailIDs)
+}
+
+// ListPinned lists the pinned emails in the user's pinned order.
+func (s *Service) ListPinned(ctx context.Context, filter *domain.EmailFilter) ([]*domain.Email, int, error) {
+	if s.domainRepo == nil || s.pinRepo == nil {
+		return []*domain.Email{}, 0, nil
+	}
+	filter.PinnedOnly = true
+	filter.PinnedFirst = false
+
+	emails, total, err := s.domainRepo.List(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, e := range emails {
+		e.IsPinned = true
+	}
+	s.markSLA(ctx, emails)
+	return emails, total, nil
+}
+
+// markPinned sets IsPinned on listed emails. 실패해도 목록은 그대로 반환한다.
+func (s *Service) markPinned(ctx context.Context, userID uuid.UUID, emails []*domain.Email) {
+	if s.pinRepo == nil || len(emails) == 0 {
+		return
+	}
+	ids, err := s.pinRepo.ListIDs(ctx, userID)
+	if err != nil {
+		logger.Warn("[MailService.Pins] Failed to load pinned emails: %v", err)
+		return
+	}
+	pinned := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		pinned[id] = true
+	}
+	for _, e := range emails {
+		e.IsPinned = pinned[e.ID]
+	}
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/readability"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotNewsletter = errors.New("reader mode is only available for newsletter emails")
+
+// SetReadLaterRepository enables the read-later queue (inbox/카테고리 목록과 분리).
+func (s *Service) SetReadLaterRepository(repo out.EmailReadLaterRepository) {
+	s.readLaterRepo = repo
+}
+
+// Reader returns the reader mode of a newsletter-category email.
+// 본문을 추출하고 추적 픽셀, 클릭 추적 리다이렉트와 utm 파라미터를 제거한다.
+func (s *Service) Reader(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.ReaderView, error) {
+	if s.emailRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	email, err := s.emailRepo.GetByID(ctx, emailID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrEmailNotFound
+		}
+		return nil, err
+	}
+	if email == nil || email.UserID != userID {
+		return nil, ErrEmailNotFound
+	}
+	if email.Category != string(domain.CategoryNewsletter) {
+		return nil, ErrNotNewsletter
+	}
+
+	body, err := s.GetEmailBody(ctx, emailID)
+	if err != nil {
+		return nil, err
+	}
+	var htmlBody, textBody string
+	if body != nil {
+		htmlBody, textBody = body.HTMLBody, body.TextBody
+	}
+	article := readability.Extract(htmlBody, textBody)
+
+	view := &domain.ReaderView{
+		EmailID:         email.ID,
+		Subject:         email.Subject,
+		FromName:        email.FromName,
+		FromEmail:       email.FromEmail,
+		Date:            email.ReceivedAt,
+		ContentHTML:     article.HTML,
+		ContentText:     article.Text,
+		WordCount:       article.WordCount,
+		ReadingMinutes:  article.ReadingMinutes,
+		TrackersRemoved: article.TrackersRemoved,
+		LinksCleaned:    article.LinksCleaned,
+	}
+	if s.readLaterRepo != nil {
+		if queued, err := s.readLaterRepo.Contains(ctx, userID, emailID); err == nil {
+			view.InReadLater = queued
+		} else {
+			logger.Warn("[MailService.Reader] Failed to check read later queue for email %d: %v", emailID, err)
+		}
+	}
+	return view, nil
+}
+
+// AddReadLater queues emails for later reading. 큐에 넣은 메일은 inbox/카테고리 목록에서 빠진다.
+func (s *Service) AddReadLater(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error) {
+	if s.readLaterRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	emailIDs = uniqueIDs(emailIDs)
+	if len(emailIDs) == 0 {
+		return []int64{}, nil
+	}
+	return s.readLaterRepo.Add(ctx, userID, emailIDs)
+}
+
+// RemoveReadLater takes emails out of the queue (목록으로 돌아간다).
+func (s *Service) RemoveReadLater(ctx context.Context, userID uuid.UUID, emailIDs []int64) error {
+	if s.readLaterRepo == nil {
+		return ErrRepoNotInitialized
+	}
+	if len(emailIDs) == 0 {
+		return nil
+	}
+	return s.readLaterRepo.Remove(ctx, userID, emailIDs)
+}
+
+// ListReadLater lists the queued emails, most recently added first.
+func (s *Service) ListReadLater(ctx context.Context, filter *domain.EmailFilter) ([]*domain.Email, int, error) {
+	if s.domainRepo == nil || s.readLaterRepo == nil {
+		return []*domain.Email{}, 0, nil
+	}
+	filter.ReadLaterOnly = true
+	filter.ExcludeReadLater = false
+	filter.PinnedFirst = false
+
+	emails, total, err := s.domainRepo.List(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return emails, total, nil
+}
@@ -0,0 +1,131 @@
+package mail
+
+import (
+	"context"
+	netmail "net/mail"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// maxRecipientsWithoutWarning - 이보다 많은 수신자에게 보내면 confirm_warnings 확인을 요청한다
+const maxRecipientsWithoutWarning = 25
+
+// replyRecipients builds the To and Cc of a reply to original.
+// Reply-To가 있으면 그쪽으로, 내가 보낸 메일이면 원래 수신자에게 답장한다.
+// replyAll이면 원본 To/Cc를 Cc에 넣되 내 주소(own)와 중복 주소는 제외한다.
+func replyRecipients(original *domain.Email, own map[string]bool, replyAll bool) (to, cc []out.ProviderEmailAddress) {
+	seen := make(map[string]bool)
+	add := func(list *[]out.ProviderEmailAddress, raw string) {
+		for _, addr := range parseAddresses(raw) {
+			key := strings.ToLower(addr)
+			if seen[key] || own[key] {
+				continue
+			}
+			seen[key] = true
+			*list = append(*list, out.ProviderEmailAddress{Email: addr})
+		}
+	}
+
+	fromSelf := own[strings.ToLower(firstAddress(original.FromEmail))]
+	switch {
+	case fromSelf:
+		for _, addr := range original.ToEmails {
+			add(&to, addr)
+		}
+	case original.ReplyTo != nil && strings.TrimSpace(*original.ReplyTo) != "":
+		add(&to, *original.ReplyTo)
+	default:
+		add(&to, original.FromEmail)
+	}
+
+	if replyAll {
+		if !fromSelf {
+			for _, addr := range original.ToEmails {
+				add(&cc, addr)
+			}
+		}
+		for _, addr := range original.CcEmails {
+			add(&cc, addr)
+		}
+	}
+
+	// 나에게만 보낸 메일 등으로 To가 비면 Cc 첫 주소를 To로 올린다
+	if len(to) == 0 && len(cc) > 0 {
+		to, cc = cc[:1], cc[1:]
+	}
+	return to, cc
+}
+
+// ownAddresses returns the user's lowercased addresses: 모든 연결 계정 주소와 (reply all이면) conn의 send-as 주소.
+func (s *Service) ownAddresses(ctx context.Context, userID uuid.UUID, conn *domain.OAuthConnection, token *oauth2.Token, withAliases bool) map[string]bool {
+	own := map[string]bool{strings.ToLower(conn.Email): true}
+
+	if conns, err := s.oauthService.GetConnectionsByUser(ctx, userID); err == nil {
+		for _, c := range conns {
+			if c.Email != "" {
+				own[strings.ToLower(c.Email)] = true
+			}
+		}
+	}
+
+	if withAliases && s.aliases != nil {
+		aliases, err := s.aliases.ListWithToken(ctx, conn, token)
+		if err != nil {
+			// 별칭을 지원하지 않는 Provider도 있으므로 연결 계정 주소만으로 진행
+			logger.Debug("[MailService.ownAddresses] aliases unavailable for connection %d: %v", conn.ID, err)
+		}
+		for _, a := range aliases {
+			own[strings.ToLower(a.Email)] = true
+		}
+	}
+	return own
+}
+
+// parseAddresses returns the bare addresses of raw ("Name <a@b>, c@d" 형태 포함).
+func parseAddresses(raw string) []string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	list, err := netmail.ParseAddressList(raw)
+	if err != nil {
+		return []string{strings.Trim(raw, "<>")}
+	}
+	addrs := make([]string, 0, len(list))
+	for _, a := range list {
+		addrs = append(addrs, a.Address)
+	}
+	return addrs
+}
+
+func firstAddress(raw string) string {
+	if addrs := parseAddresses(raw); len(addrs) > 0 {
+		return addrs[0]
+	}
+	return ""
+}
+
+// uniqueRecipientCount counts recipients case-insensitively.
+func uniqueRecipientCount(recipients []string) int {
+	seen := make(map[string]bool, len(recipients))
+	for _, r := range recipients {
+		if addr := strings.ToLower(firstAddress(r)); addr != "" {
+			seen[addr] = true
+		}
+	}
+	return len(seen)
+}
+
+func addressList(addrs []out.ProviderEmailAddress) []string {
+	list := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		list = append(list, a.Email)
+	}
+	return list
+}
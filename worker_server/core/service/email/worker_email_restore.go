@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeleteRetention is how long deleted emails can be restored before they are purged.
+const DeleteRetention = 30 * 24 * time.Hour
+
+const purgeBatchSize = 500
+
+// Restore undeletes emails deleted within DeleteRetention and returns the restored IDs.
+// 기간이 지났거나 삭제되지 않은 메일은 건너뛴다.
+func (s *Service) Restore(ctx context.Context, userID uuid.UUID, emailIDs []int64) ([]int64, error) {
+	if s.emailRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+
+	restored, err := s.emailRepo.Restore(ctx, userID, emailIDs, time.Now().Add(-DeleteRetention))
+	if err != nil {
+		return nil, err
+	}
+	if len(restored) > 0 {
+		go s.publishMailModifyJob(context.Background(), userID, restored, "restore")
+	}
+	return restored, nil
+}
+
+// PurgeDeleted permanently deletes emails whose restore window has passed (purge 스케줄러에서 호출).
+func (s *Service) PurgeDeleted(ctx context.Context) (int, error) {
+	if s.emailRepo == nil {
+		return 0, ErrRepoNotInitialized
+	}
+
+	cutoff := time.Now().Add(-DeleteRetention)
+	total := 0
+	for {
+		n, err := s.emailRepo.PurgeDeleted(ctx, cutoff, purgeBatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < purgeBatchSize || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
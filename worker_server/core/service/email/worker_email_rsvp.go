@@ -0,0 +1,258 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/in"
+	"worker_server/core/port/out"
+	"worker_server/pkg/ical"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+const (
+	maxInviteSize = 1 << 20 // .ics 첨부 최대 크기
+	rsvpProdID    = "-//Worker//RSVP//EN"
+)
+
+var (
+	ErrNoCalendarInvite = errors.New("email has no calendar invite")
+	ErrInviteCancelled  = errors.New("event was cancelled by the organizer")
+	ErrInvalidRSVP      = errors.New("response must be accept, tentative or decline")
+)
+
+// rsvpAnswer maps an RSVP request to the iTIP PARTSTAT.
+type rsvpAnswer struct {
+	partStat string
+	response string // domain.RSVP*
+	subject  string
+	verb     string
+}
+
+var rsvpAnswers = map[string]rsvpAnswer{
+	"accept":    {ical.PartStatAccepted, domain.RSVPAccepted, "Accepted", "accepted"},
+	"tentative": {ical.PartStatTentative, domain.RSVPTentative, "Tentative", "tentatively accepted"},
+	"decline":   {ical.PartStatDeclined, domain.RSVPDeclined, "Declined", "declined"},
+}
+
+// SetCalendarInviteRepository enables invite extraction and RSVP (POST /email/:id/rsvp).
+func (s *Service) SetCalendarInviteRepository(repo out.CalendarInviteRepository) {
+	s.inviteRepo = repo
+}
+
+// SetAttachmentRepository sets the attachment metadata used to find .ics attachments.
+func (s *Service) SetAttachmentRepository(repo out.AttachmentRepository) {
+	s.attachmentRepo = repo
+}
+
+// GetInvite returns the calendar invite of an email. 처음 조회할 때 .ics 첨부에서 추출해 저장한다.
+func (s *Service) GetInvite(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.CalendarInvite, error) {
+	if s.inviteRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	invite, err := s.inviteRepo.Get(ctx, userID, emailID)
+	if err == nil {
+		return invite, nil
+	}
+	if !errors.Is(err, out.ErrCalendarInviteNotFound) {
+		return nil, err
+	}
+
+	src, err := s.loadInvite(ctx, userID, emailID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.inviteRepo.Save(ctx, src.invite); err != nil {
+		return nil, err
+	}
+	return src.invite, nil
+}
+
+// RSVP answers the invite of an email with an iTIP REPLY to the organizer and records the response.
+// 일시적 발송 실패로 outbox에 들어가도 응답은 기록하고 SendQueuedError를 함께 반환한다.
+func (s *Service) RSVP(ctx context.Context, userID uuid.UUID, emailID int64, req *in.RSVPRequest) (*domain.CalendarInvite, error) {
+	answer, ok := rsvpAnswers[strings.ToLower(strings.TrimSpace(req.Response))]
+	if !ok {
+		return nil, ErrInvalidRSVP
+	}
+	if s.inviteRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+
+	src, err := s.loadInvite(ctx, userID, emailID)
+	if err != nil {
+		return nil, err
+	}
+	if src.invite.Method == ical.MethodCancel {
+		return nil, ErrInviteCancelled
+	}
+	if src.event.Organizer.Email == "" {
+		return nil, ErrNoCalendarInvite
+	}
+
+	outgoing := &out.ProviderOutgoingMessage{
+		To:      []out.ProviderEmailAddress{{Name: src.event.Organizer.Name, Email: src.event.Organizer.Email}},
+		Subject: answer.subject + ": " + src.event.Summary,
+		Body:    rsvpBody(src.attendee, answer.verb, src.event.Summary, req.Comment),
+		Attachments: []out.ProviderOutgoingAttachment{{
+			Filename: "invite.ics",
+			MimeType: "text/calendar; method=REPLY; charset=UTF-8",
+			Data:     ical.Reply(src.event, src.attendee, answer.partStat, req.Comment, rsvpProdID, time.Now()),
+		}},
+	}
+	// 별칭 주소로 초대받았으면 그 주소로 답장 (주최자 캘린더가 참석자를 찾을 수 있도록)
+	if !strings.EqualFold(src.attendee.Email, src.conn.Email) && s.aliases != nil {
+		if from, err := s.aliases.Resolve(ctx, src.conn, src.token, src.attendee.Email); err == nil {
+			outgoing.From = &out.ProviderEmailAddress{Name: from.DisplayName, Email: from.Email}
+		}
+	}
+
+	_, sendErr := s.deliver(ctx, userID, src.conn, src.token, s.sendProvider(src.conn), &delivery{
+		kind:     domain.OutboxKindSend,
+		outgoing: outgoing,
+	})
+	if sendErr != nil && !errors.Is(sendErr, ErrSendQueued) {
+		return nil, fmt.Errorf("failed to send rsvp: %w", sendErr)
+	}
+
+	now := time.Now()
+	src.invite.Response, src.invite.RespondedAt = answer.response, &now
+	if err := s.inviteRepo.Save(ctx, src.invite); err != nil {
+		return nil, err
+	}
+	return src.invite, sendErr
+}
+
+// inviteSource is an invite parsed from the .ics attachment of an email.
+type inviteSource struct {
+	invite   *domain.CalendarInvite
+	event    *ical.Event
+	attendee ical.Address
+	conn     *domain.OAuthConnection
+	token    *oauth2.Token
+}
+
+// loadInvite downloads and parses the .ics attachment of an email.
+func (s *Service) loadInvite(ctx context.Context, userID uuid.UUID, emailID int64) (*inviteSource, error) {
+	if s.emailRepo == nil || s.attachmentRepo == nil || s.oauthService == nil {
+		return nil, ErrRepoNotInitialized
+	}
+
+	email, err := s.emailRepo.GetByID(ctx, emailID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrEmailNotFound
+		}
+		return nil, err
+	}
+	if email == nil || email.UserID != userID {
+		return nil, ErrEmailNotFound
+	}
+
+	attachments, err := s.attachmentRepo.GetByEmailID(ctx, emailID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	att := inviteAttachment(attachments)
+	if att == nil {
+		return nil, ErrNoCalendarInvite
+	}
+
+	conn, err := s.oauthService.GetConnection(ctx, email.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	token, err := s.oauthService.GetOAuth2Token(ctx, conn.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth token: %w", err)
+	}
+
+	data, _, err := s.sendProvider(conn).GetAttachment(ctx, token, email.ExternalID, att.ExternalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download invite: %w", err)
+	}
+	if len(data) > maxInviteSize {
+		return nil, ErrNoCalendarInvite
+	}
+	cal, err := ical.Parse(data)
+	if err != nil {
+		logger.Debug("[MailService.loadInvite] email %d: invalid calendar attachment: %v", emailID, err)
+		return nil, ErrNoCalendarInvite
+	}
+
+	event := cal.Events[0]
+	attendee, ok := event.Attendee(s.ownAddresses(ctx, userID, conn, token, true))
+	if !ok {
+		// 목록에 없으면 (전달받은 초대 등) 계정 주소로 응답
+		attendee = ical.Address{Email: conn.Email, PartStat: ical.PartStatNeedsAction}
+	}
+
+	method := cal.Method
+	if method == "" {
+		method = ical.MethodRequest
+	}
+	invite := &domain.CalendarInvite{
+		EmailID:   emailID,
+		UserID:    userID,
+		UID:       event.UID,
+		Sequence:  event.Sequence,
+		Method:    method,
+		Summary:   event.Summary,
+		Location:  event.Location,
+		Organizer: event.Organizer.Email,
+		Attendee:  attendee.Email,
+		IsAllDay:  event.AllDay,
+		Response:  rsvpResponse(attendee.PartStat),
+	}
+	if !event.Start.IsZero() {
+		invite.StartTime = &event.Start
+	}
+	if !event.End.IsZero() {
+		invite.EndTime = &event.End
+	}
+	return &inviteSource{invite: invite, event: event, attendee: attendee, conn: conn, token: token}, nil
+}
+
+// inviteAttachment returns the calendar attachment of an email (text/calendar, application/ics, *.ics).
+func inviteAttachment(attachments []*out.EmailAttachmentEntity) *out.EmailAttachmentEntity {
+	for _, a := range attachments {
+		mimeType := strings.ToLower(a.MimeType)
+		if strings.HasPrefix(mimeType, "text/calendar") || strings.HasPrefix(mimeType, "application/ics") ||
+			strings.EqualFold(path.Ext(a.Filename), ".ics") {
+			return a
+		}
+	}
+	return nil
+}
+
+func rsvpResponse(partStat string) string {
+	switch partStat {
+	case ical.PartStatAccepted:
+		return domain.RSVPAccepted
+	case ical.PartStatTentative:
+		return domain.RSVPTentative
+	case ical.PartStatDeclined:
+		return domain.RSVPDeclined
+	}
+	return domain.RSVPNeedsAction
+}
+
+func rsvpBody(attendee ical.Address, verb, summary, comment string) string {
+	name := attendee.Name
+	if name == "" {
+		name = attendee.Email
+	}
+	body := fmt.Sprintf("%s has %s this invitation: %s", name, verb, summary)
+	if comment = strings.TrimSpace(comment); comment != "" {
+		body += "\n\n" + comment
+	}
+	return body
+}
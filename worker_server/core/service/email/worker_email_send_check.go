@@ -0,0 +1,293 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/in"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// domainCheckTimeout - 발송 한 건의 수신 도메인 조회 전체 제한 시간 (초과 시 경고 없이 진행)
+	domainCheckTimeout = 3 * time.Second
+	domainCheckTTL     = time.Hour
+	maxCheckedDomains  = 20
+	maxDomainCache     = 10000
+)
+
+// ErrSendWarnings is returned when a send has warnings the client has not confirmed.
+var ErrSendWarnings = errors.New("send has unconfirmed warnings")
+
+// SendWarningsError carries the warnings to confirm (confirm_warnings: true로 다시 요청하면 발송).
+type SendWarningsError struct {
+	Warnings []*domain.SendWarning
+}
+
+func (e *SendWarningsError) Error() string {
+	return fmt.Sprintf("%s (%d)", ErrSendWarnings.Error(), len(e.Warnings))
+}
+
+func (e *SendWarningsError) Unwrap() error {
+	return ErrSendWarnings
+}
+
+// personalMailDomains are shared webmail domains. 이 도메인 사용자는 "내부" 개념이 없으므로 외부 수신자 경고를 하지 않는다.
+var personalMailDomains = map[string]bool{
+	"gmail.com": true, "googlemail.com": true,
+	"outlook.com": true, "hotmail.com": true, "live.com": true, "msn.com": true,
+	"yahoo.com": true, "icloud.com": true, "me.com": true, "aol.com": true,
+	"proton.me": true, "protonmail.com": true,
+	"naver.com": true, "daum.net": true, "hanmail.net": true, "kakao.com": true, "nate.com": true,
+}
+
+var (
+	attachmentMention = regexp.MustCompile(`(?i)\b(attached|attachments?|attaching|enclosed)\b|첨부`)
+	// 인용된 이전 메일은 검사하지 않는다 (답장/전달 구분선 이후)
+	quotedTextStart = regexp.MustCompile(`(?im)^\s*(on .+ wrote:|-{5,} ?(original|forwarded) message ?-{5,}|-{5}original message-{5}|.+ 님이 작성:)`)
+	quotedHTML      = regexp.MustCompile(`(?is)<blockquote.*?</blockquote>|<div class="gmail_quote.*$`)
+	htmlTag         = regexp.MustCompile(`(?s)<[^>]+>`)
+)
+
+// sendCheck is the input of the pre-send validation pass.
+type sendCheck struct {
+	from           string
+	recipients     []string
+	body           string
+	isHTML         bool
+	hasAttachments bool
+	// threadParticipants are the From/To/Cc of the email being replied to (없으면 내부 스레드 검사 생략).
+	threadParticipants []string
+}
+
+// ValidateSend runs the pre-send validation of SendEmail without sending.
+func (s *Service) ValidateSend(ctx context.Context, userID uuid.UUID, req *in.SendEmailRequest) ([]*domain.SendWarning, error) {
+	if s.oauthService == nil {
+		return nil, errors.New("oauth service not configured")
+	}
+	conn, err := s.sendConnection(ctx, userID, req.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.checkSend(ctx, s.sendCheckFor(ctx, userID, conn, req)), nil
+}
+
+// sendCheckFor builds the validation input of a SendEmail request.
+func (s *Service) sendCheckFor(ctx context.Context, userID uuid.UUID, conn *domain.OAuthConnection, req *in.SendEmailRequest) *sendCheck {
+	check := &sendCheck{
+		from:           conn.Email,
+		body:           req.Body,
+		isHTML:         req.IsHTML,
+		hasAttachments: len(req.Attachments) > 0 || len(req.UploadSessionIDs) > 0,
+	}
+	check.recipients = append(check.recipients, req.To...)
+	check.recipients = append(check.recipients, req.Cc...)
+	check.recipients = append(check.recipients, req.Bcc...)
+
+	if req.ReplyToEmailID > 0 && s.emailRepo != nil {
+		original, err := s.emailRepo.GetByID(ctx, req.ReplyToEmailID)
+		if err == nil && original != nil && original.UserID == userID {
+			check.threadParticipants = append([]string{original.FromEmail}, original.ToEmails...)
+			check.threadParticipants = append(check.threadParticipants, original.CcEmails...)
+		}
+	}
+	return check
+}
+
+// checkSend returns the warnings of an outgoing message.
+func (s *Service) checkSend(ctx context.Context, c *sendCheck) []*domain.SendWarning {
+	warnings := make([]*domain.SendWarning, 0)
+
+	if undeliverable := undeliverableRecipients(ctx, c.recipients); len(undeliverable) > 0 {
+		warnings = append(warnings, &domain.SendWarning{
+			Code:       domain.SendWarningUndeliverableDomain,
+			Message:    "Some recipient domains do not accept email",
+			Recipients: undeliverable,
+		})
+	}
+
+	if external := externalRecipients(c.from, c.threadParticipants, c.recipients); len(external) > 0 {
+		warnings = append(warnings, &domain.SendWarning{
+			Code:       domain.SendWarningExternalRecipients,
+			Message:    "Replying to an internal thread with recipients outside your organization",
+			Recipients: external,
+		})
+	}
+
+	if n := uniqueRecipientCount(c.recipients); n > maxRecipientsWithoutWarning {
+		warnings = append(warnings, &domain.SendWarning{
+			Code:    domain.SendWarningLargeRecipientList,
+			Message: fmt.Sprintf("The message will be sent to %d recipients", n),
+		})
+	}
+
+	if !c.hasAttachments && mentionsAttachment(c.body, c.isHTML) {
+		warnings = append(warnings, &domain.SendWarning{
+			Code:    domain.SendWarningMissingAttachment,
+			Message: "The message mentions an attachment but has none",
+		})
+	}
+	return warnings
+}
+
+// externalRecipients returns recipients outside the sender's domain when every thread participant is inside it.
+func externalRecipients(from string, participants, recipients []string) []string {
+	internal := mailDomain(from)
+	if internal == "" || personalMailDomains[internal] || len(participants) == 0 {
+		return nil
+	}
+	for _, p := range participants {
+		if !inDomain(p, internal) {
+			return nil // 이미 외부 참여자가 있는 스레드
+		}
+	}
+
+	var external []string
+	seen := make(map[string]bool)
+	for _, r := range recipients {
+		r = strings.ToLower(strings.TrimSpace(r))
+		if r != "" && !seen[r] && !inDomain(r, internal) {
+			seen[r] = true
+			external = append(external, r)
+		}
+	}
+	return external
+}
+
+// mentionsAttachment reports whether the new (unquoted) part of the body mentions an attachment.
+func mentionsAttachment(body string, isHTML bool) bool {
+	if isHTML {
+		body = quotedHTML.ReplaceAllString(body, "")
+		body = htmlTag.ReplaceAllString(body, " ")
+	}
+	if loc := quotedTextStart.FindStringIndex(body); loc != nil {
+		body = body[:loc[0]]
+	}
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), ">") {
+			lines = append(lines, line)
+		}
+	}
+	return attachmentMention.MatchString(strings.Join(lines, "\n"))
+}
+
+func mailDomain(addr string) string {
+	at := strings.LastIndex(addr, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(strings.TrimSuffix(addr[at+1:], ">")))
+}
+
+// inDomain reports whether addr belongs to orgDomain or one of its subdomains.
+func inDomain(addr, orgDomain string) bool {
+	d := mailDomain(addr)
+	return d == orgDomain || strings.HasSuffix(d, "."+orgDomain)
+}
+
+// =============================================================================
+// Recipient domain lookup (MX, 없으면 RFC 5321 A/AAAA fallback)
+// =============================================================================
+
+type domainCheck struct {
+	deliverable bool
+	checkedAt   time.Time
+}
+
+var (
+	domainCacheMu sync.Mutex
+	domainCache   = make(map[string]domainCheck)
+)
+
+// undeliverableRecipients returns recipients whose domain has no mail server.
+// DNS 장애/시간 초과는 경고하지 않는다.
+func undeliverableRecipients(ctx context.Context, recipients []string) []string {
+	byDomain := make(map[string][]string)
+	for _, r := range recipients {
+		if d := mailDomain(r); d != "" {
+			byDomain[d] = append(byDomain[d], r)
+		}
+	}
+	if len(byDomain) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, domainCheckTimeout)
+	defer cancel()
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		bad []string
+	)
+	checked := 0
+	for d, addrs := range byDomain {
+		if checked >= maxCheckedDomains {
+			break
+		}
+		checked++
+		wg.Add(1)
+		go func(d string, addrs []string) {
+			defer wg.Done()
+			if deliverable, known := lookupDomain(ctx, d); known && !deliverable {
+				mu.Lock()
+				bad = append(bad, addrs...)
+				mu.Unlock()
+			}
+		}(d, addrs)
+	}
+	wg.Wait()
+
+	sort.Strings(bad)
+	return bad
+}
+
+// lookupDomain reports whether the domain accepts mail. known is false when DNS could not answer.
+func lookupDomain(ctx context.Context, d string) (deliverable, known bool) {
+	domainCacheMu.Lock()
+	if c, ok := domainCache[d]; ok && time.Since(c.checkedAt) < domainCheckTTL {
+		domainCacheMu.Unlock()
+		return c.deliverable, true
+	}
+	domainCacheMu.Unlock()
+
+	mx, err := net.DefaultResolver.LookupMX(ctx, d)
+	switch {
+	case err == nil:
+		// Null MX (RFC 7505): 메일을 받지 않는 도메인
+		deliverable = !(len(mx) == 1 && (mx[0].Host == "." || mx[0].Host == ""))
+	case isNotFound(err):
+		_, hostErr := net.DefaultResolver.LookupHost(ctx, d)
+		if hostErr != nil && !isNotFound(hostErr) {
+			return false, false
+		}
+		deliverable = hostErr == nil
+	default:
+		logger.Debug("[MailService.checkSend] MX lookup failed for %s: %v", d, err)
+		return false, false
+	}
+
+	domainCacheMu.Lock()
+	if len(domainCache) >= maxDomainCache {
+		domainCache = make(map[string]domainCheck)
+	}
+	domainCache[d] = domainCheck{deliverable: deliverable, checkedAt: time.Now()}
+	domainCacheMu.Unlock()
+	return deliverable, true
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
@@ -10,11 +10,18 @@ import (
 	"worker_server/core/domain"
 	"worker_server/core/port/in"
 	"worker_server/core/port/out"
+	"worker_server/core/service"
+	"worker_server/core/service/alias"
 	"worker_server/core/service/auth"
 	"worker_server/core/service/common"
+	"worker_server/core/service/filelink"
+	"worker_server/core/service/signature"
+	"worker_server/core/service/tracking"
+	"worker_server/core/service/upload"
 	"worker_server/pkg/logger"
 
 	"github.com/google/uuid"
+	"golang.org/x/oauth2"
 )
 
 var (
@@ -30,6 +37,32 @@ type Service struct {
 	provider        out.EmailProviderPort // for sending
 	oauthService    *auth.OAuthService   // for token management
 	messageProducer out.MessageProducer  // for async provider sync + SSE broadcast via Worker
+	securityRepo    out.EmailSecurityRepository // optional: phishing/spoofing analysis
+	uploads         *upload.Service             // optional: large attachment upload sessions
+	signatures      *signature.Service          // optional: send-time signature injection
+	templates       *service.TemplateService    // optional: template_id rendering
+	deliveryRepo    out.DeliveryStatusRepository // optional: bounce/complaint status
+	tracking        *tracking.Service            // optional: opt-in open/click tracking (feature flag)
+	aliases         *alias.Service               // optional: from_alias (send-as) validation
+	deadlineRepo    out.EmailDeadlineRepository  // optional: TODO urgency (deadline proximity)
+	noteRepo        out.EmailNoteRepository      // optional: private notes/tags (never synced)
+	pinRepo         out.EmailPinRepository       // optional: pinned emails on top of inbox/todo
+	slaRepo         out.SLARepository            // optional: sla_status of shared mailbox emails
+	readLaterRepo   out.EmailReadLaterRepository // optional: read-later queue (hidden from inbox/category lists)
+	threadMuteRepo  out.ThreadMuteRepository     // optional: muted threads (auto-archived by delta sync)
+	fileLinks       *filelink.Service            // optional: oversized attachments sent as download links
+	outboxRepo      out.OutboxRepository         // optional: queue sends that fail temporarily (retried by worker)
+	realtime        out.RealtimePort             // optional: outbox sent/failed events
+	inviteRepo      out.CalendarInviteRepository // optional: invites extracted from .ics attachments (RSVP)
+	attachmentRepo  out.AttachmentRepository     // optional: attachment metadata (.ics lookup)
+	translator      out.Translator               // optional: on-demand body translation
+	speech          out.SpeechSynthesizer        // optional: spoken emails (TTS)
+	audioBlobs      out.AttachmentBlobStore      // optional: generated audio cache
+	activityRepo    out.EmailActivityRepository  // optional: per-email activity timeline
+	changeRepo      out.EmailChangeRepository    // optional: change log for delta sync
+	archiveRepo     out.EmailArchiveRepository   // optional: archive tier for old emails of large mailboxes
+	archiveBodies   out.EmailBodyRepository      // optional: body cache dropped when archiving
+	archivePolicy   ArchivePolicy
 }
 
 func NewService(domainRepo domain.EmailRepository, labelRepo domain.LabelRepository) *Service {
@@ -60,17 +93,67 @@ func NewServiceFull(
 	}
 }
 
+// SetSecurityRepository enables the security block in email detail.
+func (s *Service) SetSecurityRepository(repo out.EmailSecurityRepository) {
+	s.securityRepo = repo
+}
+
+// SetUploadService enables sending attachments uploaded via upload sessions.
+func (s *Service) SetUploadService(svc *upload.Service) {
+	s.uploads = svc
+}
+
+// SetTemplateService enables sending with template_id.
+func (s *Service) SetTemplateService(svc *service.TemplateService) {
+	s.templates = svc
+}
+
+// SetSignatureService enables signature injection (use_signature).
+func (s *Service) SetSignatureService(svc *signature.Service) {
+	s.signatures = svc
+}
+
+// SetAliasService enables sending from a verified send-as alias (from_alias).
+func (s *Service) SetAliasService(svc *alias.Service) {
+	s.aliases = svc
+}
+
+// SetFileLinkService enables sending attachments over the provider limit as download links.
+func (s *Service) SetFileLinkService(svc *filelink.Service) {
+	s.fileLinks = svc
+}
+
 func (s *Service) GetEmail(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.Email, error) {
 	if s.domainRepo == nil {
 		return nil, ErrRepoNotInitialized
 	}
 	email, err := s.domainRepo.GetByID(emailID)
 	if err != nil {
-		return nil, err
+		// 아카이브된 메일은 열 때 hot 테이블로 되돌린다
+		if !s.rehydrateOnOpen(ctx, userID, emailID) {
+			return nil, err
+		}
+		if email, err = s.domainRepo.GetByID(emailID); err != nil {
+			return nil, err
+		}
 	}
 	if email.UserID != userID {
 		return nil, common.ErrForbidden
 	}
+
+	// 보안 분석 결과 (피싱/스푸핑)
+	if s.securityRepo != nil {
+		if sec, err := s.securityRepo.GetByEmailID(ctx, emailID); err == nil {
+			email.Security = sec
+		}
+	}
+
+	// 개인 메모 (로컬 전용)
+	if s.noteRepo != nil {
+		if notes, err := s.noteRepo.ListByEmailID(ctx, userID, emailID); err == nil {
+			email.Notes = notes
+		}
+	}
 	return email, nil
 }
 
@@ -78,7 +161,22 @@ func (s *Service) ListEmails(ctx context.Context, filter *domain.EmailFilter) ([
 	if s.domainRepo == nil {
 		return []*domain.Email{}, 0, nil
 	}
-	return s.domainRepo.List(filter)
+	if filter.PinnedFirst && s.pinRepo == nil {
+		filter.PinnedFirst = false
+	}
+	if filter.ExcludeReadLater && s.readLaterRepo == nil {
+		filter.ExcludeReadLater = false
+	}
+
+	emails, total, err := s.domainRepo.List(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if filter.PinnedFirst {
+		s.markPinned(ctx, filter.UserID, emails)
+	}
+	s.markSLA(ctx, emails)
+	return emails, total, nil
 }
 
 // ListEmailsHybrid - DB 조회 후 부족하면 Gmail API에서 추가 로딩 (하이브리드 방식)
@@ -398,18 +496,21 @@ func (s *Service) Trash(ctx context.Context, userID uuid.UUID, emailIDs []int64)
 	})
 }
 
-// Delete permanently deletes emails (배치 지원)
+// Delete soft-deletes emails (배치 지원). DeleteRetention 동안 Restore로 복원할 수 있고,
+// 이후 PurgeDeleted가 영구 삭제한다. Provider 휴지통과는 별개로 DB에서만 숨긴다.
 func (s *Service) Delete(ctx context.Context, userID uuid.UUID, emailIDs []int64) error {
 	if s.domainRepo == nil {
 		return ErrRepoNotInitialized
 	}
 
 	if s.emailRepo != nil {
-		if err := s.emailRepo.BatchDelete(ctx, emailIDs); err == nil {
-			// Cache invalidation is handled by HTTP handler (optimistic patch)
-			go s.publishMailModifyJob(context.Background(), userID, emailIDs, "delete")
-			return nil
+		deleted, err := s.emailRepo.SoftDelete(ctx, userID, emailIDs)
+		if err != nil {
+			return err
 		}
+		// Cache invalidation is handled by HTTP handler (optimistic patch)
+		go s.publishMailModifyJob(context.Background(), userID, deleted, "delete")
+		return nil
 	}
 
 	// Fallback: individual deletes
@@ -548,16 +649,9 @@ func (s *Service) SendEmail(ctx context.Context, userID uuid.UUID, req *in.SendE
 	}
 
 	// Get OAuth connection
-	var conn *domain.OAuthConnection
-	var err error
-
-	if req.ConnectionID > 0 {
-		conn, err = s.oauthService.GetConnection(ctx, req.ConnectionID)
-	} else {
-		conn, err = s.oauthService.GetConnectionByUserID(ctx, userID, "google")
-	}
+	conn, err := s.sendConnection(ctx, userID, req.ConnectionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get connection: %w", err)
+		return nil, err
 	}
 
 	// Get OAuth token
@@ -566,12 +660,50 @@ func (s *Service) SendEmail(ctx context.Context, userID uuid.UUID, req *in.SendE
 		return nil, fmt.Errorf("failed to get oauth token: %w", err)
 	}
 
+	if req.TemplateID > 0 {
+		if err := s.applyTemplate(ctx, userID, conn, req); err != nil {
+			return nil, err
+		}
+	}
+
+	// 발송 전 검증 (수신 도메인 MX, 내부 스레드의 외부 수신자, 첨부 누락)
+	if !req.ConfirmWarnings {
+		if warnings := s.checkSend(ctx, s.sendCheckFor(ctx, userID, conn, req)); len(warnings) > 0 {
+			return nil, &SendWarningsError{Warnings: warnings}
+		}
+	}
+
 	// Build outgoing message
 	outgoing := &out.ProviderOutgoingMessage{
 		Subject: req.Subject,
 		Body:    req.Body,
 		IsHTML:  req.IsHTML,
 	}
+	fromEmail := conn.Email
+	if req.FromAlias != "" {
+		if s.aliases == nil {
+			return nil, alias.ErrUnsupportedProvider
+		}
+		from, err := s.aliases.Resolve(ctx, conn, token, req.FromAlias)
+		if err != nil {
+			return nil, err
+		}
+		outgoing.From = &out.ProviderEmailAddress{Name: from.DisplayName, Email: from.Email}
+		fromEmail = from.Email
+	}
+	if req.UseSignature {
+		if err := s.applySignature(ctx, userID, conn, req.SignatureID, outgoing); err != nil {
+			return nil, err
+		}
+	}
+
+	// 열람/클릭 추적 (서명까지 붙인 최종 본문 기준)
+	var tracked *domain.SentTracking
+	if req.TrackOpens || req.TrackClicks {
+		if tracked, err = s.startTracking(ctx, userID, conn, req, outgoing); err != nil {
+			return nil, err
+		}
+	}
 
 	for _, addr := range req.To {
 		outgoing.To = append(outgoing.To, out.ProviderEmailAddress{Email: addr})
@@ -592,22 +724,178 @@ func (s *Service) SendEmail(ctx context.Context, userID uuid.UUID, req *in.SendE
 		})
 	}
 
-	// Send email
-	result, err := s.provider.Send(ctx, token, outgoing)
+	// Send email (일시적 실패는 outbox에 저장되어 재시도)
+	d := &delivery{kind: domain.OutboxKindSend, outgoing: outgoing}
+	if tracked != nil {
+		d.trackingID = tracked.ID
+	}
+	var result *out.ProviderSendResult
+	if len(req.UploadSessionIDs) > 0 {
+		result, err = s.sendWithUploads(ctx, userID, conn, token, d, req.UploadSessionIDs)
+	} else if err = s.linkLargeAttachments(ctx, userID, conn, outgoing); err == nil {
+		result, err = s.deliver(ctx, userID, conn, token, s.provider, d)
+	}
 	if err != nil {
+		if tracked != nil && !errors.Is(err, ErrSendQueued) {
+			s.tracking.Discard(ctx, tracked)
+		}
+		if errors.Is(err, ErrSendQueued) {
+			s.discardSentDraft(ctx, conn, token, req) // 내용은 outbox에 저장됨
+		}
 		return nil, fmt.Errorf("failed to send email: %w", err)
 	}
+	if tracked != nil {
+		s.tracking.Attach(ctx, tracked, result.ExternalID)
+	}
+	s.discardSentDraft(ctx, conn, token, req)
 
 	// Return domain email (without persisting - will be synced later)
 	return &domain.Email{
 		ProviderID: result.ExternalID,
 		Subject:    req.Subject,
 		ToEmails:   req.To,
-		FromEmail:  conn.Email,
+		FromEmail:  fromEmail,
 		Date:       result.SentAt,
 	}, nil
 }
 
+// sendConnection returns the connection to send from (지정하지 않으면 Google 기본 계정).
+func (s *Service) sendConnection(ctx context.Context, userID uuid.UUID, connectionID int64) (*domain.OAuthConnection, error) {
+	var conn *domain.OAuthConnection
+	var err error
+	if connectionID > 0 {
+		conn, err = s.oauthService.GetConnection(ctx, connectionID)
+	} else {
+		conn, err = s.oauthService.GetConnectionByUserID(ctx, userID, "google")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	return conn, nil
+}
+
+// applyTemplate fills empty subject/body of req from a rendered template.
+// 발송 계정/수신자 정보는 변수로 넘기지 않아도 자동으로 채운다.
+func (s *Service) applyTemplate(ctx context.Context, userID uuid.UUID, conn *domain.OAuthConnection, req *in.SendEmailRequest) error {
+	if s.templates == nil {
+		return errors.New("template service not configured")
+	}
+
+	variables := map[string]string{"senderEmail": conn.Email}
+	if len(req.To) == 1 {
+		variables["recipientEmail"] = req.To[0]
+	}
+	for name, value := range req.Variables {
+		variables[name] = value
+	}
+
+	rendered, err := s.templates.RenderForSend(ctx, userID, req.TemplateID, variables)
+	if err != nil {
+		return err
+	}
+
+	if req.Subject == "" {
+		req.Subject = rendered.Subject
+	}
+	if req.Body == "" {
+		if rendered.HTMLBody != "" {
+			req.Body = rendered.HTMLBody
+			req.IsHTML = true
+		} else {
+			req.Body = rendered.Body
+			req.IsHTML = false
+		}
+	}
+	return nil
+}
+
+// applySignature appends the resolved signature to the outgoing body.
+// Neo4j 서명이 없으면 계정에 저장된 서명(oauth_connections.signature)을 사용한다.
+func (s *Service) applySignature(ctx context.Context, userID uuid.UUID, conn *domain.OAuthConnection, signatureID string, outgoing *out.ProviderOutgoingMessage) error {
+	var sig *out.Signature
+	if s.signatures != nil {
+		resolved, err := s.signatures.Resolve(ctx, userID, conn.ID, signatureID)
+		if err != nil {
+			if errors.Is(err, signature.ErrNotFound) {
+				return err
+			}
+			// 서명 저장소 장애로 발송을 막지 않는다
+			logger.WithError(err).Warn("[MailService.applySignature] Failed to resolve signature")
+		}
+		sig = resolved
+	}
+	if sig == nil && conn.Signature != nil && *conn.Signature != "" {
+		sig = &out.Signature{Text: *conn.Signature}
+	}
+
+	outgoing.Body = signature.Apply(outgoing.Body, outgoing.IsHTML, sig)
+	return nil
+}
+
+// sendWithUploads attaches completed upload sessions and sends the message.
+// Gmail(staged): 업로드된 바이트를 MIME에 포함해 전송.
+// Outlook(direct): 첨부가 이미 draft에 붙어 있으므로 draft 내용을 갱신한 뒤 draft를 전송.
+func (s *Service) sendWithUploads(ctx context.Context, userID uuid.UUID, conn *domain.OAuthConnection, token *oauth2.Token, d *delivery, sessionIDs []string) (*out.ProviderSendResult, error) {
+	outgoing := d.outgoing
+	if s.uploads == nil {
+		return nil, upload.ErrNotConfigured
+	}
+
+	provider := s.uploads.Provider(string(conn.Provider))
+	if provider == nil {
+		return nil, upload.ErrUnsupportedProvider
+	}
+
+	sessions, err := s.uploads.Resolve(ctx, userID, conn.ID, sessionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	draftID := ""
+	for _, session := range sessions {
+		switch session.Mode {
+		case domain.UploadModeStaged:
+			data, err := s.uploads.StagedData(ctx, session)
+			if err != nil {
+				return nil, err
+			}
+			outgoing.Attachments = append(outgoing.Attachments, out.ProviderOutgoingAttachment{
+				Filename: session.Filename,
+				MimeType: session.MimeType,
+				Data:     data,
+			})
+		case domain.UploadModeDirect, domain.UploadModeProxy:
+			if draftID != "" && draftID != session.MessageID {
+				return nil, upload.ErrDraftMismatch
+			}
+			draftID = session.MessageID
+		}
+	}
+
+	var result *out.ProviderSendResult
+	if draftID != "" {
+		// draft PATCH로는 첨부를 추가할 수 없음 - 모든 첨부는 업로드 세션으로 올려야 함
+		if len(outgoing.Attachments) > 0 {
+			return nil, fmt.Errorf("%w: inline attachments cannot be combined with draft uploads", upload.ErrDraftMismatch)
+		}
+		if _, err := provider.UpdateDraft(ctx, token, draftID, outgoing); err != nil {
+			return nil, fmt.Errorf("failed to update draft: %w", err)
+		}
+		result, err = provider.SendDraft(ctx, token, draftID)
+	} else if err = s.linkLargeAttachments(ctx, userID, conn, outgoing); err == nil {
+		result, err = s.deliver(ctx, userID, conn, token, provider, d)
+	}
+	if err != nil {
+		if errors.Is(err, ErrSendQueued) {
+			s.uploads.Release(ctx, sessions) // 첨부 데이터는 outbox에 저장됨
+		}
+		return nil, err
+	}
+
+	s.uploads.Release(ctx, sessions)
+	return result, nil
+}
+
 func (s *Service) ReplyEmail(ctx context.Context, userID uuid.UUID, emailID int64, req *in.ReplyEmailRequest) (*domain.Email, error) {
 	if s.provider == nil || s.oauthService == nil {
 		return nil, errors.New("mail provider or oauth service not configured")
@@ -641,16 +929,32 @@ func (s *Service) ReplyEmail(ctx context.Context, userID uuid.UUID, emailID int6
 		Body:    req.Body,
 		IsHTML:  req.IsHTML,
 	}
+	if req.UseSignature {
+		if err := s.applySignature(ctx, userID, conn, req.SignatureID, outgoing); err != nil {
+			return nil, err
+		}
+	}
 
-	// Set recipients based on ReplyAll flag
-	outgoing.To = append(outgoing.To, out.ProviderEmailAddress{Email: original.FromEmail})
+	// Set recipients based on ReplyAll flag (내 주소/별칭 제외, 중복 제거)
+	own := s.ownAddresses(ctx, userID, conn, token, req.ReplyAll)
+	outgoing.To, outgoing.CC = replyRecipients(original, own, req.ReplyAll)
+	if len(outgoing.To) == 0 {
+		return nil, errors.New("reply has no recipients")
+	}
 
-	if req.ReplyAll {
-		// Add all original recipients except self
-		for _, to := range original.ToEmails {
-			if to != conn.Email {
-				outgoing.CC = append(outgoing.CC, out.ProviderEmailAddress{Email: to})
-			}
+	if !req.ConfirmWarnings {
+		check := &sendCheck{
+			from:               conn.Email,
+			body:               req.Body,
+			isHTML:             req.IsHTML,
+			hasAttachments:     len(req.Attachments) > 0,
+			threadParticipants: append(append([]string{original.FromEmail}, original.ToEmails...), original.CcEmails...),
+		}
+		for _, addr := range append(outgoing.To, outgoing.CC...) {
+			check.recipients = append(check.recipients, addr.Email)
+		}
+		if warnings := s.checkSend(ctx, check); len(warnings) > 0 {
+			return nil, &SendWarningsError{Warnings: warnings}
 		}
 	}
 
@@ -663,16 +967,29 @@ func (s *Service) ReplyEmail(ctx context.Context, userID uuid.UUID, emailID int6
 		})
 	}
 
+	if err := s.linkLargeAttachments(ctx, userID, conn, outgoing); err != nil {
+		return nil, err
+	}
+
 	// Send reply
-	result, err := s.provider.Reply(ctx, token, original.ProviderID, outgoing)
+	result, err := s.deliver(ctx, userID, conn, token, s.provider, &delivery{
+		kind:      domain.OutboxKindReply,
+		replyToID: original.ProviderID,
+		outgoing:  outgoing,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to send reply: %w", err)
 	}
+	recordActivity(ctx, s.activityRepo, &domain.EmailActivity{
+		EmailID: emailID, UserID: userID, Type: domain.ActivityReplied, Source: domain.ActivitySourceUser,
+		Detail: map[string]any{"reply_all": req.ReplyAll, "to": addressList(outgoing.To)},
+	})
 
 	return &domain.Email{
 		ProviderID: result.ExternalID,
 		Subject:    outgoing.Subject,
-		ToEmails:   []string{original.FromEmail},
+		ToEmails:   addressList(outgoing.To),
+		CcEmails:   addressList(outgoing.CC),
 		FromEmail:  conn.Email,
 		Date:       result.SentAt,
 	}, nil
@@ -731,11 +1048,19 @@ func (s *Service) ForwardEmail(ctx context.Context, userID uuid.UUID, emailID in
 		})
 	}
 
+	if err := s.linkLargeAttachments(ctx, userID, conn, outgoing); err != nil {
+		return nil, err
+	}
+
 	// Send forward
-	result, err := s.provider.Send(ctx, token, outgoing)
+	result, err := s.deliver(ctx, userID, conn, token, s.provider, &delivery{kind: domain.OutboxKindSend, outgoing: outgoing})
 	if err != nil {
 		return nil, fmt.Errorf("failed to forward email: %w", err)
 	}
+	recordActivity(ctx, s.activityRepo, &domain.EmailActivity{
+		EmailID: emailID, UserID: userID, Type: domain.ActivityForwarded, Source: domain.ActivitySourceUser,
+		Detail: map[string]any{"to": req.To},
+	})
 
 	return &domain.Email{
 		ProviderID: result.ExternalID,
@@ -858,6 +1183,7 @@ func (s *Service) invalidateEmailCache(ctx context.Context, userID uuid.UUID, em
 
 // publishMailModifyJob publishes a mail modify job for async provider sync + SSE broadcast
 func (s *Service) publishMailModifyJob(ctx context.Context, userID uuid.UUID, emailIDs []int64, action string) {
+	s.recordModifyActivity(ctx, userID, emailIDs, action, nil)
 	if s.messageProducer == nil || s.emailRepo == nil {
 		return
 	}
@@ -899,6 +1225,10 @@ func (s *Service) publishMailModifyJob(ctx context.Context, userID uuid.UUID, em
 			EmailIDs:     data.emailIDs,    // DB IDs for SSE broadcast
 			ExternalIDs:  data.externalIDs, // Provider IDs for API sync
 		}
+		// soft delete/restore는 Provider 휴지통과 별개 - SSE만 보내고 Provider 동기화는 건너뛴다
+		if action == "delete" || action == "restore" {
+			job.ExternalIDs = nil
+		}
 
 		// Set labels based on action (Gmail specific)
 		switch action {
@@ -933,6 +1263,7 @@ func (s *Service) publishMailModifyJob(ctx context.Context, userID uuid.UUID, em
 
 // publishMailModifyJobWithLabels publishes a mail modify job with custom labels
 func (s *Service) publishMailModifyJobWithLabels(ctx context.Context, userID uuid.UUID, emailIDs []int64, addLabels, removeLabels []string) {
+	s.recordModifyActivity(ctx, userID, emailIDs, "labels", map[string]any{"add": addLabels, "remove": removeLabels})
 	if s.messageProducer == nil || s.emailRepo == nil {
 		return
 	}
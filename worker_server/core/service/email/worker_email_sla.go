@@ -0,0 +1,33 @@
+package mail
+
+import (
+	"context"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+)
+
+// SetSLARepository enables sla_status on listed emails of shared mailboxes.
+func (s *Service) SetSLARepository(repo out.SLARepository) {
+	s.slaRepo = repo
+}
+
+// markSLA sets SLAStatus on listed emails. 평가는 SLA 워커가 하고 여기서는 읽기만 한다.
+func (s *Service) markSLA(ctx context.Context, emails []*domain.Email) {
+	if s.slaRepo == nil || len(emails) == 0 {
+		return
+	}
+	ids := make([]int64, len(emails))
+	for i, e := range emails {
+		ids[i] = e.ID
+	}
+	statuses, err := s.slaRepo.StatusByEmailIDs(ctx, ids)
+	if err != nil {
+		logger.Warn("[MailService.SLA] Failed to load sla status: %v", err)
+		return
+	}
+	for _, e := range emails {
+		e.SLAStatus = statuses[e.ID]
+	}
+}
@@ -0,0 +1,18 @@
+package mail
+
+import "context"
+
+// StorageGuard reports connections over their storage cap (storage.Service).
+type StorageGuard interface {
+	MetadataOnly(ctx context.Context, connectionID int64) bool
+}
+
+// SetStorageGuard stops caching bodies during sync for connections in metadata-only mode.
+func (s *SyncService) SetStorageGuard(guard StorageGuard) {
+	s.storageGuard = guard
+}
+
+// metadataOnly reports whether bodies of the connection must not be stored.
+func (s *SyncService) metadataOnly(ctx context.Context, connectionID int64) bool {
+	return s.storageGuard != nil && s.storageGuard.MetadataOnly(ctx, connectionID)
+}
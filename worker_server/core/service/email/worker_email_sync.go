@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"worker_server/core/domain"
 	"worker_server/core/port/out"
 	"worker_server/core/service/auth"
 	"worker_server/core/service/classification"
+	"worker_server/core/service/vacation"
+	"worker_server/pkg/lock"
 	"worker_server/pkg/logger"
 
 	"github.com/google/uuid"
@@ -24,9 +28,18 @@ const (
 	FirstBatchSize     = 50  // 1단계: 즉시 표시할 메일 수
 	RemainingBatchSize = 100 // 2단계: 백그라운드 배치 크기
 	CheckpointInterval = 500 // 체크포인트 저장 간격 (DB 쓰기 최적화)
-	SyncPeriodMonths   = 3   // 동기화 기간: 최근 N개월
+	SyncPeriodMonths   = 3   // 기본 동기화 기간: 최근 N개월 (SetSyncWindow로 변경)
+
+	// syncLockTTL - 연결별 동기화 락 TTL (작업 중에는 TTL/3마다 자동 연장)
+	syncLockTTL = 2 * time.Minute
+
+	// maxVIPSenders - delta sync에서 조회할 VIP 연락처 상한
+	maxVIPSenders = 500
 )
 
+// ErrSyncInProgress is returned when another worker holds the sync lock of the connection.
+var ErrSyncInProgress = errors.New("sync already in progress for connection")
+
 type SyncService struct {
 	emailRepo        out.EmailRepository
 	emailBodyRepo    out.EmailBodyRepository
@@ -39,6 +52,58 @@ type SyncService struct {
 
 	// RFC 분류기 (동기화 시점에 헤더 기반 분류)
 	rfcClassifier *classification.RFCScoreClassifier
+
+	// 피싱/스푸핑 분석 (헤더 기반, securityRepo 설정 시에만 실행)
+	securityAnalyzer *classification.SecurityAnalyzer
+	securityRepo     out.EmailSecurityRepository
+
+	// 마감일 추출 (제목/본문, deadlineRepo 설정 시에만 실행) - TODO 긴급도 정렬용
+	deadlineRepo out.EmailDeadlineRepository
+
+	// 바운스/스팸 신고 추적 (deliveryRepo 설정 시에만 실행)
+	deliveryRepo out.DeliveryStatusRepository
+	campaignRepo out.CampaignRepository
+
+	// 부재중 자동 응답 (Provider 자체 설정을 쓸 수 없는 연결만, delta sync에서 실행)
+	vacation *vacation.Service
+
+	// 새 메일 모바일 푸시 (delta sync에서만, gap/초기 동기화는 알림하지 않음)
+	notifier NewMailNotifier
+
+	// VIP 연락처 (Neo4j is_important) - VIP 메일은 보류/필터 없이 즉시 알림
+	vipContacts VIPContactSource
+
+	// 동기화 기간 (개월, 0이면 SyncPeriodMonths) - hot reload로 변경 가능
+	syncWindowMonths atomic.Int32
+
+	// 연결별 분산 락 (다중 인스턴스에서 같은 연결의 동기화가 겹치지 않도록)
+	locker *lock.Locker
+
+	// 분류 backfill 체크포인트 (nil이면 메모리에서만 진행)
+	backfillRepo out.ClassificationBackfillRepository
+
+	// 뮤트한 스레드 (delta/gap sync에서 새 메일을 보관하고 알림하지 않음)
+	threadMuteRepo out.ThreadMuteRepository
+
+	// 메일별 타임라인 (동기화, 뮤트 스레드 자동 보관)
+	activityRepo out.EmailActivityRepository
+
+	// 목록 캐시 (동기화 배치 후 바뀐 뷰만 지우고 첫 페이지를 미리 채움)
+	listCache *ListCacheWarmer
+
+	// 사용자 폴더 동기화 (초기 동기화/전체 재동기화 후 Provider 폴더를 가져옴)
+	folders FolderSyncer
+
+	// Watch 갱신이 계속 실패할 때 사용자 알림 (푸시 → 폴링 전환)
+	systemNotifier SystemNotifier
+
+	// 연결별 저장 상한 (nil = 제한 없음): metadata-only 연결은 본문을 저장하지 않는다
+	storageGuard StorageGuard
+
+	// 동기화 기간 이전 메일 가져오기 (POST /email/backfill, 요청당/사용자별 메시지 한도)
+	historyBackfills     out.HistoryBackfillRepository
+	historyBackfillMax   int
+	historyBackfillQuota int
 }
 
 func NewSyncService(
@@ -61,7 +126,117 @@ func NewSyncService(
 		messageProducer: messageProducer,
 		realtime:        realtime,
 		rfcClassifier:   classification.NewRFCScoreClassifier(),
+
+		securityAnalyzer: classification.NewSecurityAnalyzer(),
+	}
+}
+
+// SetSecurityRepository enables sync-time phishing/spoofing analysis.
+func (s *SyncService) SetSecurityRepository(repo out.EmailSecurityRepository) {
+	s.securityRepo = repo
+}
+
+// SetThreadMuteRepository enables auto-archiving new mail of muted threads.
+func (s *SyncService) SetThreadMuteRepository(repo out.ThreadMuteRepository) {
+	s.threadMuteRepo = repo
+}
+
+// SetVacationService enables auto-replies for connections in auto_reply mode.
+func (s *SyncService) SetVacationService(svc *vacation.Service) {
+	s.vacation = svc
+}
+
+// NewMailNotifier sends out-of-app notifications (mobile push) for newly synced mail.
+type NewMailNotifier interface {
+	NotifyNewEmail(ctx context.Context, email *domain.Email)
+	// NotifyVIPEmail alerts immediately, bypassing quiet hours and notification filters.
+	NotifyVIPEmail(ctx context.Context, email *domain.Email)
+}
+
+// SetNewMailNotifier enables new mail notifications during delta sync.
+func (s *SyncService) SetNewMailNotifier(notifier NewMailNotifier) {
+	s.notifier = notifier
+}
+
+// VIPContactSource lists the contacts a user marked as VIP.
+type VIPContactSource interface {
+	GetImportantContacts(ctx context.Context, userID string, limit int) ([]*out.ContactRelationship, error)
+}
+
+// SetVIPContacts enables immediate alerts for mail from VIP contacts during delta sync.
+func (s *SyncService) SetVIPContacts(src VIPContactSource) {
+	s.vipContacts = src
+}
+
+// SetLocker enables the per-connection distributed sync lock.
+func (s *SyncService) SetLocker(locker *lock.Locker) {
+	s.locker = locker
+}
+
+// withSyncLock runs fn while holding the sync lock of the connection.
+func (s *SyncService) withSyncLock(ctx context.Context, connectionID int64, fn func(ctx context.Context) error) error {
+	err := s.withLock(ctx, fmt.Sprintf("sync:%d", connectionID), fn)
+	if errors.Is(err, lock.ErrNotAcquired) {
+		return ErrSyncInProgress
+	}
+	return err
+}
+
+// withLock runs fn while holding key. Returns lock.ErrNotAcquired if another worker holds it.
+// 락을 잃으면(연장 실패) ctx를 취소해 다른 소유자와 겹쳐 쓰지 않게 한다.
+func (s *SyncService) withLock(ctx context.Context, key string, fn func(ctx context.Context) error) error {
+	if s.locker == nil {
+		return fn(ctx)
+	}
+
+	lk, err := s.locker.Acquire(ctx, key, syncLockTTL)
+	if errors.Is(err, lock.ErrNotAcquired) {
+		return err
+	}
+	if err != nil {
+		// Redis 장애 시 작업을 막지 않는다
+		logger.Warn("[SyncService] Lock %s unavailable, continuing without lock: %v", key, err)
+		return fn(ctx)
+	}
+	defer lk.Release(context.WithoutCancel(ctx))
+
+	lockCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-lk.Lost():
+			logger.Warn("[SyncService] Lock %s lost, aborting", key)
+			cancel()
+		case <-lockCtx.Done():
+		}
+	}()
+
+	return fn(lockCtx)
+}
+
+// SetSyncWindow changes the initial/full sync window in months.
+// 이미 진행 중인 동기화에는 영향을 주지 않는다.
+func (s *SyncService) SetSyncWindow(months int) {
+	if months > 0 {
+		s.syncWindowMonths.Store(int32(months))
+	}
+}
+
+// syncSince returns the oldest date included in initial/full syncs.
+func (s *SyncService) syncSince() time.Time {
+	months := int(s.syncWindowMonths.Load())
+	if months <= 0 {
+		months = SyncPeriodMonths
 	}
+	return time.Now().AddDate(0, -months, 0)
+}
+
+// activeAutoResponder returns the auto_reply responder of a connection, or nil.
+func (s *SyncService) activeAutoResponder(ctx context.Context, connectionID int64) *domain.VacationResponder {
+	if s.vacation == nil {
+		return nil
+	}
+	return s.vacation.ActiveAutoResponder(ctx, connectionID)
 }
 
 // =============================================================================
@@ -70,7 +245,16 @@ func NewSyncService(
 //
 // 1단계: 최근 50개 즉시 가져와서 SSE로 UI 표시 (< 2초 목표)
 // 2단계: 나머지 백그라운드 동기화 (체크포인트 저장)
+//
+// Initial/Delta/GapSync는 연결별 분산 락 안에서 실행되며, 다른 워커가
+// 같은 연결을 동기화 중이면 ErrSyncInProgress를 반환한다.
 func (s *SyncService) InitialSync(ctx context.Context, userID string, connectionID int64) error {
+	return s.withSyncLock(ctx, connectionID, func(ctx context.Context) error {
+		return s.initialSync(ctx, userID, connectionID)
+	})
+}
+
+func (s *SyncService) initialSync(ctx context.Context, userID string, connectionID int64) error {
 	startTime := time.Now()
 	logger.Info("[SyncService.InitialSync] Starting for connection %d", connectionID)
 
@@ -113,7 +297,7 @@ func (s *SyncService) InitialSync(ctx context.Context, userID string, connection
 	// 1단계: 첫 번째 배치 (50개) - 즉시 표시
 	// 날짜 기반 동기화: 최근 3개월 메일만 동기화
 	// ==========================================================================
-	syncSince := time.Now().AddDate(0, -SyncPeriodMonths, 0)
+	syncSince := s.syncSince()
 	firstBatchResult, err := s.emailProvider.InitialSync(ctx, token, &out.ProviderSyncOptions{
 		MaxResults: FirstBatchSize,
 		StartDate:  &syncSince,
@@ -128,13 +312,19 @@ func (s *SyncService) InitialSync(ctx context.Context, userID string, connection
 		logger.Error("[SyncService] Error processing first batch: %v", err)
 	}
 
+	// 전체 추정치 (진행률/ETA 표시용) - 이어하기를 위해 체크포인트와 함께 저장
+	state.CheckpointTotalCount = int(firstBatchResult.TotalEstimate)
+	if firstBatchResult.NextPageToken != "" {
+		s.syncRepo.SaveCheckpoint(ctx, connectionID, firstBatchResult.NextPageToken, savedCount, state.CheckpointTotalCount)
+	}
+
 	// 첫 번째 배치 완료 이벤트
-	s.pushSyncEvent(ctx, userID, domain.EventSyncFirstBatch, &domain.SyncProgressData{
+	s.pushSyncEvent(ctx, userID, domain.EventSyncFirstBatch, (&domain.SyncProgressData{
 		ConnectionID: connectionID,
 		Current:      savedCount,
 		Status:       "first_batch_complete",
 		Phase:        string(domain.SyncPhaseInitialFirstBatch),
-	})
+	}).WithEstimate(state.CheckpointTotalCount, savedCount, time.Since(startTime)))
 
 	logger.Info("[SyncService.InitialSync] First batch complete: %d emails in %v",
 		savedCount, time.Since(startTime))
@@ -189,7 +379,8 @@ func (s *SyncService) resumeFromCheckpoint(ctx context.Context, state *domain.Sy
 func (s *SyncService) syncRemainingPages(ctx context.Context, state *domain.SyncState, token *oauth2.Token, accountEmail, pageToken string, currentCount int) (string, error) {
 	syncedCount := currentCount
 	var lastNextSyncState string
-	syncSince := time.Now().AddDate(0, -SyncPeriodMonths, 0)
+	syncSince := s.syncSince()
+	runStart := time.Now() // ETA는 이번 실행의 처리 속도로 계산
 
 	for pageToken != "" {
 		// 페이지 가져오기 (날짜 기반 필터 적용)
@@ -200,13 +391,18 @@ func (s *SyncService) syncRemainingPages(ctx context.Context, state *domain.Sync
 		})
 		if err != nil {
 			// 실패 시 현재 체크포인트 저장
-			s.syncRepo.SaveCheckpoint(ctx, state.ConnectionID, pageToken, syncedCount, 0)
+			s.syncRepo.SaveCheckpoint(ctx, state.ConnectionID, pageToken, syncedCount, state.CheckpointTotalCount)
 			return "", fmt.Errorf("failed to fetch page: %w", err)
 		}
 
 		// 마지막 NextSyncState 저장 (History ID)
 		lastNextSyncState = result.NextSyncState
 
+		// 이전 버전 체크포인트처럼 추정치가 없으면 이번 응답 값 사용
+		if state.CheckpointTotalCount == 0 {
+			state.CheckpointTotalCount = int(result.TotalEstimate)
+		}
+
 		// 메시지 처리
 		saved, err := s.processMessages(ctx, result.Messages, state.UserID, state.ConnectionID, accountEmail, token)
 		if err != nil {
@@ -215,17 +411,17 @@ func (s *SyncService) syncRemainingPages(ctx context.Context, state *domain.Sync
 		syncedCount += saved
 
 		// 체크포인트 저장
-		s.syncRepo.SaveCheckpoint(ctx, state.ConnectionID, result.NextPageToken, syncedCount, 0)
+		s.syncRepo.SaveCheckpoint(ctx, state.ConnectionID, result.NextPageToken, syncedCount, state.CheckpointTotalCount)
 
-		// 진행 상황 이벤트
-		s.pushSyncEvent(ctx, state.UserID, domain.EventSyncProgress, &domain.SyncProgressData{
+		// 진행 상황 이벤트 (current/total, ETA)
+		s.pushSyncEvent(ctx, state.UserID, domain.EventSyncProgress, (&domain.SyncProgressData{
 			ConnectionID: state.ConnectionID,
 			Current:      syncedCount,
 			Status:       "syncing",
 			Phase:        string(domain.SyncPhaseInitialRemaining),
-		})
+		}).WithEstimate(state.CheckpointTotalCount, syncedCount-currentCount, time.Since(runStart)))
 
-		logger.Info("[SyncService] Synced %d emails so far...", syncedCount)
+		logger.Info("[SyncService] Synced %d/~%d emails so far...", syncedCount, state.CheckpointTotalCount)
 
 		pageToken = result.NextPageToken
 	}
@@ -288,12 +484,19 @@ func (s *SyncService) completeInitialSync(ctx context.Context, state *domain.Syn
 
 	// 분류되지 않은 기존 이메일 재분류 (OAuth 재연결 후 누락된 분류 복구)
 	go s.reclassifyUnclassifiedEmails(context.Background(), state.UserID, state.ConnectionID)
+	go s.syncFolders(context.Background(), state.UserID, state.ConnectionID)
 
 	return nil
 }
 
 // =============================================================================
 func (s *SyncService) DeltaSync(ctx context.Context, connectionID int64, newHistoryID uint64) error {
+	return s.withSyncLock(ctx, connectionID, func(ctx context.Context) error {
+		return s.deltaSync(ctx, connectionID, newHistoryID)
+	})
+}
+
+func (s *SyncService) deltaSync(ctx context.Context, connectionID int64, newHistoryID uint64) error {
 	logger.Info("[SyncService.DeltaSync] Starting for connection %d, historyID %d", connectionID, newHistoryID)
 
 	// 1. 현재 상태 조회
@@ -302,6 +505,12 @@ func (s *SyncService) DeltaSync(ctx context.Context, connectionID int64, newHist
 		return fmt.Errorf("sync state not found for connection %d", connectionID)
 	}
 
+	// 이미 이 historyId 이후까지 동기화됨 (중복/순서가 뒤바뀐 Pub/Sub 알림으로 쌓인 작업)
+	if newHistoryID > 0 && newHistoryID <= state.HistoryID {
+		logger.Debug("[SyncService.DeltaSync] Skipping connection %d: historyID %d already synced (at %d)", connectionID, newHistoryID, state.HistoryID)
+		return nil
+	}
+
 	// 2. OAuth 토큰 가져오기
 	token, err := s.oauthService.GetOAuth2Token(ctx, connectionID)
 	if err != nil {
@@ -321,30 +530,68 @@ func (s *SyncService) DeltaSync(ctx context.Context, connectionID int64, newHist
 		// Full sync 필요
 		if providerErr, ok := err.(*out.ProviderError); ok && providerErr.Code == out.ProviderErrSyncRequired {
 			logger.Info("[SyncService.DeltaSync] Full sync required, triggering...")
-			return s.InitialSync(ctx, state.UserID, connectionID)
+			return s.initialSync(ctx, state.UserID, connectionID)
 		}
 		return fmt.Errorf("failed to get history: %w", err)
 	}
 
 	// 5. 새 메시지 처리
 	savedCount := 0
+	scope := &ListCacheScope{}
+	responder := s.activeAutoResponder(ctx, connectionID)
+	var vips map[string]bool
+	var loc *time.Location
+	if len(result.Messages) > 0 {
+		vips = s.loadVIPSenders(ctx, state.UserID)
+		loc = s.deadlineLocation(ctx, state.UserID)
+	}
+	mutedThreads := s.mutedThreads(ctx, connectionID, result.Messages)
 	for _, msg := range result.Messages {
 		email := s.convertProviderMessage(msg, state.UserID, connectionID, conn.Email)
+		muted := s.applyThreadMute(email, mutedThreads)
 
 		if err := s.saveEmailWithBody(ctx, email, msg, token); err != nil {
 			logger.Error("[SyncService] Failed to save email: %v", err)
 			continue
 		}
 		savedCount++
+		scope.AddEmail(email)
+		if muted {
+			s.archiveMutedOnProvider(ctx, token, email)
+		}
+
+		// VIP 메일은 body 조회/AI 작업보다 먼저 알린다
+		isVIP := vips[strings.ToLower(email.FromEmail)] && !email.IsRead && email.Folder == domain.LegacyFolderInbox
+		if isVIP {
+			s.pushVIPEmailEvent(ctx, state.UserID, email)
+			if s.notifier != nil {
+				s.notifier.NotifyVIPEmail(ctx, email)
+			}
+		}
+
+		if responder != nil {
+			s.vacation.AutoReply(ctx, responder, conn, token, email, msg.ClassificationHeaders)
+		}
 
 		// AI 작업 발행 (snippet 길이 기반으로 요약 여부 결정)
-		s.publishAIJobs(ctx, state.UserID, email.ID, len(msg.Snippet))
+		s.publishAIJobs(ctx, state.UserID, email.ID, len(msg.Snippet), out.JobPriorityHigh)
 
 		// Push-centric: body 즉시 가져와서 SSE 푸시
-		body, bodyErr := s.emailProvider.GetMessageBody(ctx, token, msg.ExternalID)
-		if bodyErr != nil {
-			logger.Warn("[SyncService] Failed to fetch body for push: %v", bodyErr)
-			s.pushNewEmailEvent(ctx, state.UserID, email, msg.Snippet)
+		// metadata-only 연결은 본문을 가져오지 않고 snippet만 푸시한다 (본문은 열람 시 가져옴)
+		metadataOnly := s.metadataOnly(ctx, connectionID)
+		var body *out.ProviderMessageBody
+		var bodyErr error
+		if !metadataOnly {
+			body, bodyErr = s.emailProvider.GetMessageBody(ctx, token, msg.ExternalID)
+		}
+		if metadataOnly || bodyErr != nil {
+			if bodyErr != nil {
+				logger.Warn("[SyncService] Failed to fetch body for push: %v", bodyErr)
+			}
+			if !muted {
+				s.pushNewEmailEvent(ctx, state.UserID, email, msg.Snippet)
+			}
+			s.saveDeadline(ctx, email, msg.Snippet, loc)
 		} else {
 			if s.emailBodyRepo != nil {
 				bodyEntity := out.NewMailBodyEntity(email.ID, connectionID, msg.ExternalID)
@@ -352,17 +599,35 @@ func (s *SyncService) DeltaSync(ctx context.Context, connectionID int64, newHist
 				bodyEntity.Text = body.Text
 				_ = s.emailBodyRepo.SaveBody(ctx, bodyEntity)
 			}
-			s.pushFullEmailEvent(ctx, state.UserID, email, body)
+			if !muted {
+				s.pushFullEmailEvent(ctx, state.UserID, email, body)
+			}
+
+			// 본문이 있으면 snippet보다 본문에서 마감일을 찾는다
+			text := body.Text
+			if text == "" {
+				text = msg.Snippet
+			}
+			s.saveDeadline(ctx, email, text, loc)
+		}
+
+		if s.notifier != nil && !isVIP && !muted {
+			s.notifier.NotifyNewEmail(ctx, email)
 		}
 	}
 
 	// 6. 삭제된 메시지 처리
 	if len(result.DeletedIDs) > 0 {
+		s.scopeDeleted(ctx, connectionID, result.DeletedIDs, scope)
 		if err := s.emailRepo.DeleteByExternalIDs(ctx, connectionID, result.DeletedIDs); err != nil {
 			logger.Error("[SyncService] Failed to delete emails: %v", err)
 		}
 	}
 
+	// 라벨 변경 반영 (읽음/별표/폴더) + 캐시 무효화 힌트
+	s.applyLabelChanges(ctx, state.UserID, connectionID, result.LabelChanges, scope)
+	s.warmListCaches(ctx, state.UserID, scope)
+
 	// 7. History ID 업데이트
 	var nextHistoryID uint64
 	fmt.Sscanf(result.NextSyncState, "%d", &nextHistoryID)
@@ -385,6 +650,12 @@ func (s *SyncService) DeltaSync(ctx context.Context, connectionID int64, newHist
 // 2. 차이가 있으면 History API로 Partial Sync
 // 3. 404 에러(historyID 만료)면 Full Sync 필요
 func (s *SyncService) GapSync(ctx context.Context, connectionID int64) error {
+	return s.withSyncLock(ctx, connectionID, func(ctx context.Context) error {
+		return s.gapSync(ctx, connectionID)
+	})
+}
+
+func (s *SyncService) gapSync(ctx context.Context, connectionID int64) error {
 	logger.Info("[SyncService.GapSync] Starting for connection %d", connectionID)
 
 	// 1. 현재 상태 조회
@@ -396,7 +667,7 @@ func (s *SyncService) GapSync(ctx context.Context, connectionID int64) error {
 	// 첫 동기화가 안됐으면 InitialSync로
 	if state.IsFirstSync() {
 		logger.Info("[SyncService.GapSync] First sync not complete, triggering InitialSync")
-		return s.InitialSync(ctx, state.UserID, connectionID)
+		return s.initialSync(ctx, state.UserID, connectionID)
 	}
 
 	// 2. OAuth 토큰 가져오기
@@ -437,7 +708,7 @@ func (s *SyncService) GapSync(ctx context.Context, connectionID int64) error {
 	}
 
 	// 6. 변경사항이 없으면 바로 완료
-	if len(result.Messages) == 0 && len(result.DeletedIDs) == 0 {
+	if len(result.Messages) == 0 && len(result.DeletedIDs) == 0 && len(result.LabelChanges) == 0 {
 		logger.Info("[SyncService.GapSync] No changes detected, already up to date")
 		s.syncRepo.UpdateStatus(ctx, connectionID, domain.SyncStatusIdle, "")
 		return nil
@@ -445,31 +716,50 @@ func (s *SyncService) GapSync(ctx context.Context, connectionID int64) error {
 
 	// 7. 새 메시지 처리
 	savedCount := 0
+	scope := &ListCacheScope{}
+	responder := s.activeAutoResponder(ctx, connectionID)
+	loc := s.deadlineLocation(ctx, state.UserID)
+	mutedThreads := s.mutedThreads(ctx, connectionID, result.Messages)
 	for _, msg := range result.Messages {
 		email := s.convertProviderMessage(msg, state.UserID, connectionID, conn.Email)
+		muted := s.applyThreadMute(email, mutedThreads)
 
 		if err := s.saveEmailWithBody(ctx, email, msg, token); err != nil {
 			logger.Error("[SyncService.GapSync] Failed to save email: %v", err)
 			continue
 		}
 		savedCount++
+		scope.AddEmail(email)
+		if muted {
+			s.archiveMutedOnProvider(ctx, token, email)
+		}
+		s.saveDeadline(ctx, email, msg.Snippet, loc)
+
+		if responder != nil {
+			s.vacation.AutoReply(ctx, responder, conn, token, email, msg.ClassificationHeaders)
+		}
 
 		// AI 작업 발행 (snippet 길이 기반으로 요약 여부 결정)
-		s.publishAIJobs(ctx, state.UserID, email.ID, len(msg.Snippet))
+		s.publishAIJobs(ctx, state.UserID, email.ID, len(msg.Snippet), out.JobPriorityHigh)
 
-		// 실시간 새 메일 알림
-		s.pushNewEmailEvent(ctx, state.UserID, email, msg.Snippet)
+		// 실시간 새 메일 알림 (뮤트 스레드 제외)
+		if !muted {
+			s.pushNewEmailEvent(ctx, state.UserID, email, msg.Snippet)
+		}
 	}
 
 	// 8. 삭제된 메시지 처리
 	deletedCount := 0
 	if len(result.DeletedIDs) > 0 {
+		s.scopeDeleted(ctx, connectionID, result.DeletedIDs, scope)
 		if err := s.emailRepo.DeleteByExternalIDs(ctx, connectionID, result.DeletedIDs); err != nil {
 			logger.Error("[SyncService.GapSync] Failed to delete emails: %v", err)
 		} else {
 			deletedCount = len(result.DeletedIDs)
 		}
 	}
+	s.applyLabelChanges(ctx, state.UserID, connectionID, result.LabelChanges, scope)
+	s.warmListCaches(ctx, state.UserID, scope)
 
 	// 9. History ID 업데이트
 	var nextHistoryID uint64
@@ -514,7 +804,7 @@ func (s *SyncService) fullResync(ctx context.Context, state *domain.SyncState, t
 
 	// 전체 메일 가져오기 (페이지네이션)
 	// 날짜 기반 필터: 최근 N개월 데이터만 재동기화
-	syncSince := time.Now().AddDate(0, -SyncPeriodMonths, 0)
+	syncSince := s.syncSince()
 	var pageToken string
 	syncedCount := 0
 	newCount := 0
@@ -560,7 +850,7 @@ func (s *SyncService) fullResync(ctx context.Context, state *domain.SyncState, t
 			newCount++
 
 			// AI 작업 발행
-			s.publishAIJobs(ctx, state.UserID, email.ID, len(msg.Snippet))
+			s.publishAIJobs(ctx, state.UserID, email.ID, len(msg.Snippet), out.JobPriorityNormal)
 		}
 
 		// 진행 상황 이벤트
@@ -611,66 +901,11 @@ func (s *SyncService) fullResync(ctx context.Context, state *domain.SyncState, t
 
 	// 분류되지 않은 기존 이메일 재분류 (OAuth 재연결 후 누락된 분류 복구)
 	go s.reclassifyUnclassifiedEmails(context.Background(), state.UserID, state.ConnectionID)
+	go s.syncFolders(context.Background(), state.UserID, state.ConnectionID)
 
 	return nil
 }
 
-// reclassifyUnclassifiedEmails publishes ai.classify jobs for unclassified emails.
-// This is called after fullResync to recover emails that failed classification before.
-func (s *SyncService) reclassifyUnclassifiedEmails(ctx context.Context, userID string, connectionID int64) {
-	if s.emailRepo == nil || s.messageProducer == nil {
-		return
-	}
-
-	// 배치 설정: Worker pool이 처리할 수 있는 속도로 발행
-	const (
-		batchSize  = 50              // 한 번에 발행할 작업 수
-		batchDelay = 2 * time.Second // 배치 간 대기 시간
-		maxEmails  = 2000            // 최대 처리할 이메일 수
-	)
-
-	// 분류되지 않은 이메일 조회
-	unclassified, err := s.emailRepo.ListUnclassifiedByConnection(ctx, connectionID, maxEmails)
-	if err != nil {
-		logger.Error("[SyncService.reclassifyUnclassifiedEmails] Failed to list unclassified: %v", err)
-		return
-	}
-
-	if len(unclassified) == 0 {
-		logger.Info("[SyncService.reclassifyUnclassifiedEmails] No unclassified emails for connection %d", connectionID)
-		return
-	}
-
-	logger.Info("[SyncService.reclassifyUnclassifiedEmails] Found %d unclassified emails for connection %d, publishing in batches of %d", len(unclassified), connectionID, batchSize)
-
-	// 배치로 나눠서 발행 (rate limiting 방지)
-	published := 0
-	for i := 0; i < len(unclassified); i += batchSize {
-		end := i + batchSize
-		if end > len(unclassified) {
-			end = len(unclassified)
-		}
-
-		batch := unclassified[i:end]
-		for _, email := range batch {
-			s.messageProducer.PublishAIClassify(ctx, &out.AIClassifyJob{
-				UserID:  userID,
-				EmailID: email.ID,
-			})
-			published++
-		}
-
-		logger.Info("[SyncService.reclassifyUnclassifiedEmails] Published batch %d/%d (%d emails)", (i/batchSize)+1, (len(unclassified)+batchSize-1)/batchSize, len(batch))
-
-		// 마지막 배치가 아니면 대기
-		if end < len(unclassified) {
-			time.Sleep(batchDelay)
-		}
-	}
-
-	logger.Info("[SyncService.reclassifyUnclassifiedEmails] Completed: published %d classify jobs for connection %d", published, connectionID)
-}
-
 // =============================================================================
 // 재시도 관련
 // =============================================================================
@@ -726,8 +961,9 @@ func (s *SyncService) handleSyncError(ctx context.Context, connectionID int64, m
 // Watch 관리
 // =============================================================================
 
+// RenewExpiredWatches renews watches expiring within 24h and retries failed ones whose backoff has passed.
 func (s *SyncService) RenewExpiredWatches(ctx context.Context) error {
-	expireBefore := time.Now().Add(24 * time.Hour)
+	expireBefore := time.Now().Add(watchExpiringWithin)
 	states, err := s.syncRepo.GetExpiredWatches(ctx, expireBefore)
 	if err != nil {
 		return fmt.Errorf("failed to get expired watches: %w", err)
@@ -735,22 +971,16 @@ func (s *SyncService) RenewExpiredWatches(ctx context.Context) error {
 
 	logger.Info("[SyncService.RenewExpiredWatches] Found %d watches to renew", len(states))
 
+	failed := 0
 	for _, state := range states {
-		token, err := s.oauthService.GetOAuth2Token(ctx, state.ConnectionID)
-		if err != nil {
-			logger.Error("[SyncService] Failed to get token for connection %d: %v", state.ConnectionID, err)
-			continue
-		}
-
-		watchResp, err := s.emailProvider.Watch(ctx, token)
-		if err != nil {
-			logger.Error("[SyncService] Failed to renew watch for connection %d: %v", state.ConnectionID, err)
-			s.syncRepo.UpdateStatus(ctx, state.ConnectionID, domain.SyncStatusWatchExpired, err.Error())
-			continue
+		if err := s.renewWatch(ctx, state); err != nil {
+			failed++
 		}
+	}
 
-		s.syncRepo.UpdateWatchExpiry(ctx, state.ConnectionID, watchResp.Expiration, watchResp.ExternalID)
-		logger.Info("[SyncService] Renewed watch for connection %d, expires %v", state.ConnectionID, watchResp.Expiration)
+	if stats, err := s.WatchStats(ctx); err == nil {
+		logger.Info("[SyncService.RenewExpiredWatches] renewed=%d failed=%d active=%d expired=%d degraded=%d",
+			len(states)-failed, failed, stats.Active, stats.Expired, stats.Degraded)
 	}
 
 	return nil
@@ -783,6 +1013,12 @@ func (s *SyncService) getOrCreateSyncState(ctx context.Context, userID string, c
 }
 
 func (s *SyncService) processMessages(ctx context.Context, messages []out.ProviderMailMessage, userID string, connectionID int64, accountEmail string, token *oauth2.Token) (int, error) {
+	return s.processMessagesWithPriority(ctx, messages, userID, connectionID, accountEmail, token, out.JobPriorityNormal)
+}
+
+// processMessagesWithPriority saves new messages and publishes their AI jobs at the given priority
+// (history backfill은 새 메일 분류를 막지 않도록 low로 발행).
+func (s *SyncService) processMessagesWithPriority(ctx context.Context, messages []out.ProviderMailMessage, userID string, connectionID int64, accountEmail string, token *oauth2.Token, priority out.JobPriority) (int, error) {
 	if len(messages) == 0 {
 		return 0, nil
 	}
@@ -830,7 +1066,7 @@ func (s *SyncService) processMessages(ctx context.Context, messages []out.Provid
 	if err := s.emailRepo.BulkUpsert(ctx, userUUID, connectionID, newEntities); err != nil {
 		logger.Error("[SyncService] BulkUpsert failed: %v", err)
 		// 폴백: 개별 저장 시도
-		return s.processMessagesFallback(ctx, newEmails, newMessages, userID, connectionID, accountEmail, token)
+		return s.processMessagesFallback(ctx, newEmails, newMessages, userID, connectionID, accountEmail, token, priority)
 	}
 
 	// 5. 저장된 ID 조회 (AI 작업 발행용)
@@ -840,6 +1076,8 @@ func (s *SyncService) processMessages(ctx context.Context, messages []out.Provid
 	}
 
 	// 6. AI 작업 일괄 발행 (RFC로 이미 분류된 경우 분류 작업 건너뜀)
+	loc := s.deadlineLocation(ctx, userID)
+	var activities []*domain.EmailActivity
 	for i, email := range newEmails {
 		if savedMap != nil {
 			if saved, ok := savedMap[email.ProviderID]; ok {
@@ -847,19 +1085,33 @@ func (s *SyncService) processMessages(ctx context.Context, messages []out.Provid
 			}
 		}
 		if email.ID > 0 {
+			activities = append(activities, syncActivity(email))
 			// RFC로 이미 분류된 경우 분류 작업 건너뜀
 			alreadyClassified := email.AICategory != nil
-			s.publishAIJobsWithClassification(ctx, userID, email.ID, len(newMessages[i].Snippet), alreadyClassified)
+			s.publishAIJobsWithClassification(ctx, userID, email.ID, len(newMessages[i].Snippet), alreadyClassified, priority)
+			s.saveSecurity(ctx, email)
+			s.saveDeadline(ctx, email, newMessages[i].Snippet, loc)
+			s.trackDelivery(ctx, email, newMessages[i], token)
 		}
 	}
 
+	recordActivity(ctx, s.activityRepo, activities...)
+
+	scope := &ListCacheScope{}
+	for _, email := range newEmails {
+		scope.AddEmail(email)
+	}
+	s.warmListCaches(ctx, userID, scope)
+
 	logger.Info("[SyncService] Batch saved %d emails", len(newEntities))
 	return len(newEntities), nil
 }
 
 // processMessagesFallback 개별 저장 폴백 (배치 실패 시)
-func (s *SyncService) processMessagesFallback(ctx context.Context, emails []*domain.Email, messages []out.ProviderMailMessage, userID string, connectionID int64, accountEmail string, token *oauth2.Token) (int, error) {
+func (s *SyncService) processMessagesFallback(ctx context.Context, emails []*domain.Email, messages []out.ProviderMailMessage, userID string, connectionID int64, accountEmail string, token *oauth2.Token, priority out.JobPriority) (int, error) {
 	savedCount := 0
+	scope := &ListCacheScope{}
+	loc := s.deadlineLocation(ctx, userID)
 	for i, email := range emails {
 		msg := messages[i]
 		if err := s.saveEmailWithBody(ctx, email, msg, token); err != nil {
@@ -867,11 +1119,57 @@ func (s *SyncService) processMessagesFallback(ctx context.Context, emails []*dom
 			continue
 		}
 		savedCount++
-		s.publishAIJobs(ctx, userID, email.ID, len(msg.Snippet))
+		scope.AddEmail(email)
+		s.publishAIJobs(ctx, userID, email.ID, len(msg.Snippet), priority)
+		s.saveSecurity(ctx, email)
+		s.saveDeadline(ctx, email, msg.Snippet, loc)
+		s.trackDelivery(ctx, email, msg, token)
 	}
+	s.warmListCaches(ctx, userID, scope)
 	return savedCount, nil
 }
 
+// mutedThreads returns the muted threads among the messages of a connection.
+func (s *SyncService) mutedThreads(ctx context.Context, connectionID int64, messages []out.ProviderMailMessage) map[string]bool {
+	if s.threadMuteRepo == nil || len(messages) == 0 {
+		return nil
+	}
+	threadIDs := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		if msg.ExternalThreadID != "" {
+			threadIDs = append(threadIDs, msg.ExternalThreadID)
+		}
+	}
+	muted, err := s.threadMuteRepo.MutedThreads(ctx, connectionID, threadIDs)
+	if err != nil {
+		logger.Warn("[SyncService] Failed to load muted threads of connection %d: %v", connectionID, err)
+		return nil
+	}
+	return muted
+}
+
+// applyThreadMute moves a new inbox email of a muted thread to archive before it is saved.
+// 보낸 메일 등 inbox 밖의 메일은 그대로 둔다.
+func (s *SyncService) applyThreadMute(email *domain.Email, muted map[string]bool) bool {
+	if email.ThreadID == "" || !muted[email.ThreadID] || email.Folder != domain.LegacyFolderInbox {
+		return false
+	}
+	email.Folder = domain.LegacyFolderArchive
+	return true
+}
+
+// archiveMutedOnProvider records the mute in the timeline and archives the email on the provider
+// so later syncs keep it out of the inbox.
+func (s *SyncService) archiveMutedOnProvider(ctx context.Context, token *oauth2.Token, email *domain.Email) {
+	recordActivity(ctx, s.activityRepo, &domain.EmailActivity{
+		EmailID: email.ID, UserID: email.UserID, Type: domain.ActivityRuleApplied, Source: domain.ActivitySourceSync,
+		Detail: map[string]any{"rule": "thread_mute", "folder": string(domain.LegacyFolderArchive)},
+	})
+	if err := s.emailProvider.Archive(ctx, token, email.ProviderID); err != nil {
+		logger.Warn("[SyncService] Failed to archive muted thread email %d: %v", email.ID, err)
+	}
+}
+
 func (s *SyncService) saveEmailWithBody(ctx context.Context, email *domain.Email, msg out.ProviderMailMessage, token *oauth2.Token) error {
 	// 중복 체크는 processMessages에서 이미 완료됨
 	entity := s.domainToEntity(email)
@@ -885,6 +1183,7 @@ func (s *SyncService) saveEmailWithBody(ctx context.Context, email *domain.Email
 		return err
 	}
 	email.ID = entity.ID
+	recordActivity(ctx, s.activityRepo, syncActivity(email))
 
 	// URL 기반 방식: 첨부파일 메타데이터는 DB에 저장하지 않음
 	// has_attachment 플래그는 has:attachment 쿼리 결과로 이미 설정됨
@@ -936,23 +1235,46 @@ func (s *SyncService) fetchAndCacheBody(ctx context.Context, emailID, connection
 	}
 }
 
+// saveSecurity stores the sync-time security analysis and alerts on high-risk mail.
+func (s *SyncService) saveSecurity(ctx context.Context, email *domain.Email) {
+	sec := email.Security
+	if s.securityRepo == nil || sec == nil || email.ID == 0 {
+		return
+	}
+	// 신호도 인증 결과도 없으면 저장하지 않음
+	if len(sec.Signals) == 0 && sec.SPF == "" && sec.DKIM == "" && sec.DMARC == "" {
+		return
+	}
+
+	sec.EmailID = email.ID
+	if err := s.securityRepo.Save(ctx, email.UserID, sec); err != nil {
+		logger.Warn("[SyncService] Failed to save security analysis for email %d: %v", email.ID, err)
+		return
+	}
+
+	if sec.IsHighRisk() {
+		s.pushSecurityAlert(ctx, email)
+	}
+}
+
 // saveAttachments is removed - URL 기반 방식으로 변경
 // 첨부파일 메타데이터는 DB에 저장하지 않고 Provider에서 직접 가져옴
 
-func (s *SyncService) publishAIJobs(ctx context.Context, userID string, emailID int64, contentLength int) {
-	s.publishAIJobsWithClassification(ctx, userID, emailID, contentLength, false)
+func (s *SyncService) publishAIJobs(ctx context.Context, userID string, emailID int64, contentLength int, priority out.JobPriority) {
+	s.publishAIJobsWithClassification(ctx, userID, emailID, contentLength, false, priority)
 }
 
 // publishAIJobsWithClassification publishes AI jobs, optionally skipping classification.
 // alreadyClassified: true if email was already classified by RFC at sync time
-func (s *SyncService) publishAIJobsWithClassification(ctx context.Context, userID string, emailID int64, contentLength int, alreadyClassified bool) {
+// priority: delta/gap sync로 새로 도착한 메일은 high (backfill보다 먼저 분류)
+func (s *SyncService) publishAIJobsWithClassification(ctx context.Context, userID string, emailID int64, contentLength int, alreadyClassified bool, priority out.JobPriority) {
 	if s.messageProducer == nil {
 		return
 	}
 
 	// 1. Classify only if not already classified by RFC
 	if !alreadyClassified {
-		s.messageProducer.PublishAIClassify(ctx, &out.AIClassifyJob{UserID: userID, EmailID: emailID})
+		s.messageProducer.PublishAIClassify(ctx, &out.AIClassifyJob{UserID: userID, EmailID: emailID, Priority: priority})
 	}
 
 	// 2. Summarize is on-demand only (via AI Agent tool) - removed auto-summarize for cost optimization
@@ -996,6 +1318,65 @@ func (s *SyncService) pushNewEmailEvent(ctx context.Context, userID string, emai
 	})
 }
 
+// pushVIPEmailEvent alerts the client immediately about mail from a VIP contact.
+func (s *SyncService) pushVIPEmailEvent(ctx context.Context, userID string, email *domain.Email) {
+	if s.realtime == nil {
+		return
+	}
+	s.realtime.Push(ctx, userID, &domain.RealtimeEvent{
+		Type:      domain.EventEmailVIP,
+		Timestamp: time.Now(),
+		Data: &domain.NewEmailData{
+			EmailID:   email.ID,
+			Subject:   email.Subject,
+			From:      email.FromEmail,
+			FromName:  stringValue(email.FromName),
+			Snippet:   email.Snippet,
+			Folder:    string(email.Folder),
+			IsRead:    email.IsRead,
+			HasAttach: email.HasAttach,
+		},
+	})
+}
+
+// loadVIPSenders returns the user's VIP contact addresses (lowercased).
+// 조회 실패 시 VIP 없이 일반 알림 경로로 처리한다.
+func (s *SyncService) loadVIPSenders(ctx context.Context, userID string) map[string]bool {
+	if s.vipContacts == nil {
+		return nil
+	}
+	contacts, err := s.vipContacts.GetImportantContacts(ctx, userID, maxVIPSenders)
+	if err != nil {
+		logger.Warn("[SyncService] Failed to load VIP contacts: %v", err)
+		return nil
+	}
+	vips := make(map[string]bool, len(contacts))
+	for _, c := range contacts {
+		vips[strings.ToLower(c.ContactEmail)] = true
+	}
+	return vips
+}
+
+// pushSecurityAlert notifies the client about a high-risk email.
+func (s *SyncService) pushSecurityAlert(ctx context.Context, email *domain.Email) {
+	if s.realtime == nil {
+		return
+	}
+	s.realtime.Push(ctx, email.UserID.String(), &domain.RealtimeEvent{
+		Type:      domain.EventEmailSecurityAlert,
+		Timestamp: time.Now(),
+		Data: &domain.SecurityAlertData{
+			EmailID:   email.ID,
+			Subject:   email.Subject,
+			From:      email.FromEmail,
+			FromName:  stringValue(email.FromName),
+			RiskLevel: email.Security.RiskLevel,
+			RiskScore: email.Security.RiskScore,
+			Signals:   email.Security.Signals,
+		},
+	})
+}
+
 // pushFullEmailEvent - Push-centric SSE: body 포함 전체 데이터 전송
 // DeltaSync에서 새 메일 도착 시 body까지 즉시 가져와서 클라이언트에 푸시
 func (s *SyncService) pushFullEmailEvent(ctx context.Context, userID string, email *domain.Email, body *out.ProviderMessageBody) {
@@ -1052,6 +1433,7 @@ func (s *SyncService) convertProviderMessage(msg out.ProviderMailMessage, userID
 		AccountEmail: accountEmail,
 		ProviderID:   msg.ExternalID,
 		ThreadID:     msg.ExternalThreadID,
+		MessageID:    strings.Trim(strings.TrimSpace(msg.MessageID), "<>"),
 		Subject:      msg.Subject,
 		Snippet:      msg.Snippet,
 		FromEmail:    msg.From.Email,
@@ -1074,6 +1456,15 @@ func (s *SyncService) convertProviderMessage(msg out.ProviderMailMessage, userID
 	// RFC 분류 적용 (동기화 시점에 헤더 기반 분류)
 	s.applyRFCClassification(email, msg.ClassificationHeaders)
 
+	// 보안 분석 (SPF/DKIM/DMARC, 유사 도메인, 표시 이름)
+	if s.securityRepo != nil {
+		email.Security = s.securityAnalyzer.Analyze(&classification.ScoreClassifierInput{
+			UserID:  email.UserID,
+			Email:   email,
+			Headers: msg.ClassificationHeaders,
+		})
+	}
+
 	return email
 }
 
@@ -1105,6 +1496,8 @@ func (s *SyncService) applyRFCClassification(email *domain.Email, headers *out.P
 
 	source := domain.ClassificationSourceHeader
 	email.ClassificationSource = &source
+	stage := domain.ClassificationStageRFC
+	email.ClassificationStage = &stage
 
 	logger.Debug("[SyncService] RFC classified: %s -> %s (score=%.2f, source=%s)",
 		email.FromEmail, result.Category, result.Score, result.Source)
@@ -1128,6 +1521,7 @@ func (s *SyncService) domainToEntity(d *domain.Email) *out.MailEntity {
 		Provider:       string(d.Provider),
 		AccountEmail:   d.AccountEmail,
 		ExternalID:     d.ProviderID,
+		MessageID:      d.MessageID,
 		FromEmail:      d.FromEmail,
 		FromName:       fromName,
 		ToEmails:       d.ToEmails,
@@ -1147,6 +1541,8 @@ func (s *SyncService) domainToEntity(d *domain.Email) *out.MailEntity {
 		WorkflowStatus: "none",
 	}
 
+	entity.ExternalThreadID = d.ThreadID
+
 	// RFC 분류 결과 반영 (동기화 시점에 이미 분류된 경우)
 	if d.AICategory != nil {
 		entity.Category = string(*d.AICategory)
@@ -1164,6 +1560,9 @@ func (s *SyncService) domainToEntity(d *domain.Email) *out.MailEntity {
 	if d.ClassificationSource != nil {
 		entity.ClassificationSource = string(*d.ClassificationSource)
 	}
+	if d.ClassificationStage != nil {
+		entity.ClassificationStage = string(*d.ClassificationStage)
+	}
 
 	return entity
 }
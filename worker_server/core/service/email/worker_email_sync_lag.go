@@ -0,0 +1,31 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"worker_server/core/port/out"
+)
+
+// ProviderLatestAt returns the date of the newest message at the provider (메일이 없으면 zero).
+// sync lag 모니터가 DB 최신 메일과 비교하는 기준이다.
+func (s *SyncService) ProviderLatestAt(ctx context.Context, connectionID int64) (time.Time, error) {
+	token, err := s.oauthService.GetOAuth2Token(ctx, connectionID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	result, err := s.emailProvider.ListMessages(ctx, token, &out.ProviderListOptions{MaxResults: 1})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to list provider messages: %w", err)
+	}
+	if len(result.Messages) == 0 {
+		return time.Time{}, nil
+	}
+	msg := result.Messages[0]
+	if msg.Date.IsZero() {
+		return msg.ReceivedAt, nil
+	}
+	return msg.Date, nil
+}
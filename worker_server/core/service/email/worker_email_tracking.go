@@ -0,0 +1,79 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/in"
+	"worker_server/core/port/out"
+	"worker_server/core/service/common"
+	"worker_server/core/service/tracking"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// Send Tracking - 열람(픽셀)/링크 클릭 추적 (opt-in, SEND_TRACKING_ENABLED)
+// =============================================================================
+//
+// 1. Send: track_opens/track_clicks가 켜진 HTML 메일만 추적 레코드 생성 후 본문 계측
+// 2. 발송 성공 시 provider message ID로 연결, 실패 시 레코드 삭제
+// 3. Public: GET /api/v1/track/o (픽셀), GET /api/v1/track/c (클릭 리다이렉트)
+// 4. API: GET /email/sent/:id/engagement
+
+var (
+	ErrTrackingDisabled     = errors.New("send tracking is disabled")
+	ErrTrackingRequiresHTML = errors.New("open/click tracking requires an HTML body")
+)
+
+// SetTrackingService enables track_opens/track_clicks on send.
+func (s *Service) SetTrackingService(svc *tracking.Service) {
+	s.tracking = svc
+}
+
+// startTracking creates a tracking record and instruments the outgoing HTML body.
+func (s *Service) startTracking(ctx context.Context, userID uuid.UUID, conn *domain.OAuthConnection, req *in.SendEmailRequest, outgoing *out.ProviderOutgoingMessage) (*domain.SentTracking, error) {
+	if s.tracking == nil {
+		return nil, ErrTrackingDisabled
+	}
+	if !outgoing.IsHTML {
+		return nil, ErrTrackingRequiresHTML
+	}
+
+	tracked, err := s.tracking.Start(ctx, userID, conn.ID, outgoing.Subject, req.TrackOpens, req.TrackClicks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start tracking: %w", err)
+	}
+	outgoing.Body = s.tracking.Instrument(tracked, outgoing.Body)
+	return tracked, nil
+}
+
+// GetEngagement returns opens/clicks of a sent email.
+// 추적 없이 보낸 메일은 tracked=false로 반환한다.
+func (s *Service) GetEngagement(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.SentEngagement, error) {
+	if s.tracking == nil {
+		return nil, ErrTrackingDisabled
+	}
+	if s.emailRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+
+	email, err := s.emailRepo.GetByID(ctx, emailID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrEmailNotFound
+		}
+		return nil, err
+	}
+	if email.UserID != userID {
+		return nil, common.ErrForbidden
+	}
+	if email.Direction != "outbound" && email.Folder != "sent" {
+		return nil, ErrNotSentEmail
+	}
+
+	return s.tracking.GetEngagement(ctx, userID, email.ConnectionID, email.ID, email.ExternalID)
+}
@@ -0,0 +1,131 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/core/service/common"
+	"worker_server/pkg/htmlsanitize"
+	"worker_server/pkg/langdetect"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/readability"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxTranslateHTML = 20000 // 이보다 큰 HTML 본문은 텍스트로 번역
+	maxTranslateText = 8000  // 텍스트 본문 최대 글자 수 (넘으면 앞부분만)
+)
+
+var ErrInvalidLanguage = errors.New("to must be a language code such as en, ko or zh-tw")
+
+// languageCode matches ISO 639-1 codes with an optional region (en, pt-br, zh-tw).
+var languageCode = regexp.MustCompile(`^[a-z]{2}(-[a-z]{2,4})?$`)
+
+// SetTranslator enables on-demand translation (GET /email/:id/translate).
+func (s *Service) SetTranslator(translator out.Translator) {
+	s.translator = translator
+}
+
+// TranslateEmail translates the subject and body of an email into the to language.
+// 결과는 메일+언어별로 캐시하고, 이미 같은 언어인 메일은 번역하지 않고 원문을 돌려준다.
+func (s *Service) TranslateEmail(ctx context.Context, userID uuid.UUID, emailID int64, to string) (*domain.EmailTranslation, error) {
+	target := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(to)), "_", "-")
+	if !languageCode.MatchString(target) {
+		return nil, ErrInvalidLanguage
+	}
+	if s.translator == nil || s.emailRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+
+	email, err := s.emailRepo.GetByID(ctx, emailID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrEmailNotFound
+		}
+		return nil, err
+	}
+	if email == nil || email.UserID != userID {
+		return nil, ErrEmailNotFound
+	}
+
+	result := &domain.EmailTranslation{
+		EmailID:        emailID,
+		SourceLanguage: email.Language,
+		TargetLanguage: target,
+	}
+	if s.cacheService != nil {
+		if cached, err := s.cacheService.GetTranslation(ctx, emailID, target); err == nil && cached != nil {
+			result.SourceLanguage = cached.SourceLanguage
+			result.Subject, result.Body, result.IsHTML = cached.Subject, cached.Body, cached.IsHTML
+			result.Cached = true
+			return result, nil
+		}
+	}
+
+	body, err := s.GetEmailBody(ctx, emailID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email body: %w", err)
+	}
+	text, isHTML := translationSource(body)
+	if result.SourceLanguage == "" {
+		// 분류 전 메일은 여기서 판별 (저장은 분류 단계에서)
+		plain := text
+		if isHTML {
+			plain = readability.Extract(text, "").Text
+		}
+		result.SourceLanguage, _ = langdetect.Detect(email.Subject + "\n" + plain)
+	}
+	result.IsHTML = isHTML
+
+	// 같은 언어면 원문 그대로 (zh-tw 요청에 zh 메일도 포함)
+	if base, _, _ := strings.Cut(target, "-"); result.SourceLanguage == base {
+		result.Subject, result.Body = email.Subject, text
+		return result, nil
+	}
+
+	if result.Body, err = s.translator.Translate(ctx, text, target, isHTML); err != nil {
+		return nil, fmt.Errorf("failed to translate body: %w", err)
+	}
+	if strings.TrimSpace(email.Subject) != "" {
+		if result.Subject, err = s.translator.Translate(ctx, email.Subject, target, false); err != nil {
+			return nil, fmt.Errorf("failed to translate subject: %w", err)
+		}
+	}
+
+	if s.cacheService != nil {
+		if err := s.cacheService.CacheTranslation(ctx, emailID, target, &common.CachedTranslation{
+			SourceLanguage: result.SourceLanguage,
+			Subject:        result.Subject,
+			Body:           result.Body,
+			IsHTML:         result.IsHTML,
+		}); err != nil {
+			logger.Warn("[MailService.TranslateEmail] failed to cache translation of email %d: %v", emailID, err)
+		}
+	}
+	return result, nil
+}
+
+// translationSource picks the body to translate. 스크립트/스타일을 뺀 HTML이 크면 텍스트 본문을 쓴다.
+func translationSource(body *domain.EmailBody) (string, bool) {
+	if strings.TrimSpace(body.HTMLBody) != "" {
+		if cleaned := htmlsanitize.Sanitize(body.HTMLBody, htmlsanitize.LevelStrict).HTML; len(cleaned) <= maxTranslateHTML {
+			return cleaned, true
+		}
+	}
+
+	text := body.TextBody
+	if strings.TrimSpace(text) == "" {
+		text = readability.Extract(body.HTMLBody, "").Text
+	}
+	if runes := []rune(text); len(runes) > maxTranslateText {
+		text = string(runes[:maxTranslateText])
+	}
+	return text, false
+}
@@ -0,0 +1,427 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// =============================================================================
+// TriageService - Inbox Zero 도우미
+// =============================================================================
+//
+// 1. API: 받은편지함을 훑어 일괄 작업을 제안한다 (POST /inbox/triage)
+//    - 오래된 뉴스레터/마케팅 메일 보관
+//    - 거의 읽지 않는 발신자 구독 해지 (발신자 뮤트 + 받은편지함 메일 보관)
+//    - 지난 알림 읽음 처리
+// 2. 사용자가 제안 ID를 확인하면 mail:triage 작업 발행
+// 3. Worker: 작업별로 실행하고 결과(요청/성공/실패 수)를 inbox_triages.results에 기록
+//
+// List-Unsubscribe 헤더는 저장하지 않으므로 구독 해지는 Provider가 아닌 sender_profiles 뮤트로 처리한다.
+
+const (
+	TriageExpiry = 30 * time.Minute // 제안 후 확인 가능 시간
+
+	triageStaleNewsletterAge = 14 * 24 * time.Hour // 이보다 오래된 뉴스레터는 보관 제안
+	triageStaleNotification  = 3 * 24 * time.Hour  // 이보다 오래된 안 읽은 알림은 읽음 제안
+	triageMaxEmails          = 500                 // 작업 하나가 다루는 최대 메일 수
+	triageMaxSenders         = 10                  // 구독 해지 제안 최대 발신자 수
+	triageMaxSenderEmails    = 100                 // 발신자 하나당 보관할 최대 메일 수
+	triageSenderMinEmails    = 5                   // 구독 해지 후보: 최소 수신 메일 수
+	triageSenderMaxReadRate  = 0.1                 // 구독 해지 후보: 최대 읽음률
+	triageChunkSize          = 100
+)
+
+// Triage action IDs
+const (
+	TriageStaleNewsletters = "stale_newsletters"
+	TriageUnsubscribe      = "unsubscribe_senders"
+	TriageOldNotifications = "old_notifications"
+)
+
+var (
+	ErrTriageExpired          = errors.New("triage proposal expired")
+	ErrTriageAlreadyConfirmed = errors.New("triage proposal already confirmed")
+	ErrTriageNothingToDo      = errors.New("triage proposal has no actions")
+	ErrTriageUnknownAction    = errors.New("unknown triage action")
+)
+
+// TriageService proposes and executes bulk inbox clean-up actions.
+type TriageService struct {
+	repo            out.InboxTriageRepository
+	emailService    *Service
+	senders         domain.SenderProfileRepository
+	messageProducer out.MessageProducer
+	listCache       *ListCacheWarmer
+}
+
+// NewTriageService creates a new TriageService.
+func NewTriageService(
+	repo out.InboxTriageRepository,
+	emailService *Service,
+	senders domain.SenderProfileRepository,
+	messageProducer out.MessageProducer,
+) *TriageService {
+	return &TriageService{
+		repo:            repo,
+		emailService:    emailService,
+		senders:         senders,
+		messageProducer: messageProducer,
+	}
+}
+
+// SetListCacheWarmer refreshes the touched inbox/category lists after a triage runs.
+func (s *TriageService) SetListCacheWarmer(w *ListCacheWarmer) {
+	s.listCache = w
+}
+
+// Propose scans the inbox and stores a triage proposal.
+// 제안할 작업이 없어도 저장하며 actions가 비어 있다.
+func (s *TriageService) Propose(ctx context.Context, userID uuid.UUID, connectionID *int64) (*domain.InboxTriage, error) {
+	now := time.Now()
+	triage := &domain.InboxTriage{
+		ID:           uuid.New(),
+		UserID:       userID,
+		ConnectionID: connectionID,
+		Status:       domain.TriageProposed,
+		Actions:      []*domain.TriageAction{},
+		ExpiresAt:    now.Add(TriageExpiry),
+	}
+
+	newsletters, err := s.proposeStaleNewsletters(ctx, userID, connectionID, now)
+	if err != nil {
+		return nil, err
+	}
+	unsubscribe, err := s.proposeUnsubscribe(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := s.proposeOldNotifications(ctx, userID, connectionID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 구독 해지 대상 발신자의 메일은 뉴스레터 보관에서 빼서 중복 처리하지 않는다
+	if newsletters != nil && unsubscribe != nil {
+		newsletters.EmailIDs = subtractIDs(newsletters.EmailIDs, unsubscribe.EmailIDs)
+		newsletters.Count = len(newsletters.EmailIDs)
+		newsletters.Description = fmt.Sprintf("Archive %d stale newsletter(s)", newsletters.Count)
+	}
+	for _, action := range []*domain.TriageAction{newsletters, unsubscribe, notifications} {
+		if action != nil && (action.Count > 0 || len(action.Senders) > 0) {
+			triage.Actions = append(triage.Actions, action)
+		}
+	}
+
+	if err := s.repo.Create(ctx, triage); err != nil {
+		return nil, fmt.Errorf("failed to store triage: %w", err)
+	}
+
+	logger.Info("[TriageService] proposed triage %s for user %s: %d action(s), %d email(s)",
+		triage.ID, userID, len(triage.Actions), triage.TotalEmails())
+	return triage, nil
+}
+
+// proposeStaleNewsletters archives newsletters and marketing emails left in the inbox.
+func (s *TriageService) proposeStaleNewsletters(ctx context.Context, userID uuid.UUID, connectionID *int64, now time.Time) (*domain.TriageAction, error) {
+	before := now.Add(-triageStaleNewsletterAge)
+	ids, err := s.inboxEmailIDs(ctx, &domain.EmailFilter{
+		UserID:       userID,
+		ConnectionID: connectionID,
+		Categories:   []domain.EmailCategory{domain.CategoryNewsletter, domain.CategoryMarketing},
+		DateTo:       &before,
+		Limit:        triageMaxEmails,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TriageAction{
+		ID:          TriageStaleNewsletters,
+		Type:        domain.TriageActionArchive,
+		Description: fmt.Sprintf("Archive %d stale newsletter(s)", len(ids)),
+		Reason:      fmt.Sprintf("Newsletters and promotions older than %d days", int(triageStaleNewsletterAge.Hours()/24)),
+		Count:       len(ids),
+		EmailIDs:    ids,
+	}, nil
+}
+
+// proposeUnsubscribe picks frequent senders the user almost never reads or replies to.
+func (s *TriageService) proposeUnsubscribe(ctx context.Context, userID uuid.UUID, connectionID *int64) (*domain.TriageAction, error) {
+	if s.senders == nil {
+		return nil, nil
+	}
+	profiles, err := s.senders.GetLowEngagementSenders(userID, triageSenderMaxReadRate, triageSenderMinEmails, triageMaxSenders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find low engagement senders: %w", err)
+	}
+
+	action := &domain.TriageAction{
+		ID:     TriageUnsubscribe,
+		Type:   domain.TriageActionUnsubscribe,
+		Reason: fmt.Sprintf("You read less than %d%% of their emails and never replied", int(triageSenderMaxReadRate*100)),
+	}
+	for _, p := range profiles {
+		sender := strings.ToLower(p.Email)
+		ids, err := s.inboxEmailIDs(ctx, &domain.EmailFilter{
+			UserID:       userID,
+			ConnectionID: connectionID,
+			Sender:       &sender,
+			Limit:        triageMaxSenderEmails,
+		})
+		if err != nil {
+			return nil, err
+		}
+		action.Senders = append(action.Senders, sender)
+		action.EmailIDs = append(action.EmailIDs, ids...)
+	}
+	action.Count = len(action.EmailIDs)
+	action.Description = fmt.Sprintf("Unsubscribe from %d sender(s)", len(action.Senders))
+	return action, nil
+}
+
+// proposeOldNotifications marks old unread notifications as read.
+func (s *TriageService) proposeOldNotifications(ctx context.Context, userID uuid.UUID, connectionID *int64, now time.Time) (*domain.TriageAction, error) {
+	before := now.Add(-triageStaleNotification)
+	category := domain.CategoryNotification
+	isRead := false
+	ids, err := s.inboxEmailIDs(ctx, &domain.EmailFilter{
+		UserID:       userID,
+		ConnectionID: connectionID,
+		Category:     &category,
+		IsRead:       &isRead,
+		DateTo:       &before,
+		Limit:        triageMaxEmails,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TriageAction{
+		ID:          TriageOldNotifications,
+		Type:        domain.TriageActionMarkRead,
+		Description: fmt.Sprintf("Mark %d notification(s) as read", len(ids)),
+		Reason:      fmt.Sprintf("Unread notifications older than %d days", int(triageStaleNotification.Hours()/24)),
+		Count:       len(ids),
+		EmailIDs:    ids,
+	}, nil
+}
+
+// inboxEmailIDs returns the IDs of inbox emails matching filter (primary에서 읽는다).
+func (s *TriageService) inboxEmailIDs(ctx context.Context, filter *domain.EmailFilter) ([]int64, error) {
+	inbox := domain.LegacyFolderInbox
+	filter.Folder = &inbox
+	filter.PrimaryRead = true
+	filter.Fields = []string{"id"}
+
+	emails, _, err := s.emailService.ListEmails(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list emails: %w", err)
+	}
+	ids := make([]int64, len(emails))
+	for i, e := range emails {
+		ids[i] = e.ID
+	}
+	return ids, nil
+}
+
+// GetTriage returns a triage owned by the user.
+func (s *TriageService) GetTriage(ctx context.Context, userID, id uuid.UUID) (*domain.InboxTriage, error) {
+	return s.repo.GetByID(ctx, userID, id)
+}
+
+// Confirm queues a proposed triage for the worker. actionIDs가 비어 있으면 모든 작업을 실행한다.
+func (s *TriageService) Confirm(ctx context.Context, userID, id uuid.UUID, actionIDs []string) (*domain.InboxTriage, error) {
+	triage, err := s.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if triage.Status != domain.TriageProposed {
+		return nil, ErrTriageAlreadyConfirmed
+	}
+	if triage.IsExpired(time.Now()) {
+		return nil, ErrTriageExpired
+	}
+
+	actions, err := selectTriageActions(triage.Actions, actionIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(actions) == 0 {
+		return nil, ErrTriageNothingToDo
+	}
+
+	if s.messageProducer == nil {
+		return nil, fmt.Errorf("message producer not initialized")
+	}
+	queued, err := s.repo.MarkQueued(ctx, id, actions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm triage: %w", err)
+	}
+	if !queued {
+		return nil, ErrTriageAlreadyConfirmed
+	}
+
+	if err := s.messageProducer.PublishMailTriage(ctx, &out.MailTriageJob{
+		UserID:   userID.String(),
+		TriageID: id.String(),
+	}); err != nil {
+		_ = s.repo.Finish(ctx, id, domain.TriageFailed, nil, "failed to queue triage")
+		return nil, fmt.Errorf("failed to publish triage job: %w", err)
+	}
+
+	now := time.Now()
+	triage.Status = domain.TriageQueued
+	triage.Actions = actions
+	triage.ConfirmedAt = &now
+	return triage, nil
+}
+
+// selectTriageActions keeps the actions named in ids (비어 있으면 전체).
+func selectTriageActions(actions []*domain.TriageAction, ids []string) ([]*domain.TriageAction, error) {
+	if len(ids) == 0 {
+		return actions, nil
+	}
+	byID := make(map[string]*domain.TriageAction, len(actions))
+	for _, a := range actions {
+		byID[a.ID] = a
+	}
+	selected := make([]*domain.TriageAction, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		a, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrTriageUnknownAction, id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			selected = append(selected, a)
+		}
+	}
+	return selected, nil
+}
+
+// ProcessTriage executes a confirmed triage (worker). 작업 하나가 실패해도 나머지는 계속 실행한다.
+func (s *TriageService) ProcessTriage(ctx context.Context, userID, id uuid.UUID) error {
+	triage, err := s.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return fmt.Errorf("failed to load triage: %w", err)
+	}
+	if triage.Status != domain.TriageQueued && triage.Status != domain.TriageRunning {
+		logger.Info("[TriageService] triage %s is %s, skipped", id, triage.Status)
+		return nil
+	}
+	if err := s.repo.MarkRunning(ctx, id); err != nil {
+		return fmt.Errorf("failed to start triage: %w", err)
+	}
+
+	results := make([]*domain.TriageActionResult, 0, len(triage.Actions))
+	scope := &ListCacheScope{}
+	succeeded := 0
+	for _, action := range triage.Actions {
+		result := s.runAction(ctx, userID, action)
+		results = append(results, result)
+		succeeded += result.Succeeded
+		if result.Succeeded > 0 {
+			scope.Add(string(domain.LegacyFolderInbox), "")
+			scope.Add(string(domain.LegacyFolderArchive), "")
+		}
+	}
+
+	status, errMsg := domain.TriageCompleted, ""
+	if succeeded == 0 && triage.TotalEmails() > 0 {
+		status, errMsg = domain.TriageFailed, "no action succeeded"
+	}
+	if err := s.repo.Finish(context.WithoutCancel(ctx), id, status, results, errMsg); err != nil {
+		return fmt.Errorf("failed to store triage results: %w", err)
+	}
+	s.listCache.Warm(ctx, userID, scope)
+
+	logger.Info("[TriageService] triage %s for user %s %s: %d email(s) processed", id, userID, status, succeeded)
+	return nil
+}
+
+// runAction executes one action in chunks and counts the outcome.
+func (s *TriageService) runAction(ctx context.Context, userID uuid.UUID, action *domain.TriageAction) *domain.TriageActionResult {
+	result := &domain.TriageActionResult{
+		ActionID:  action.ID,
+		Type:      action.Type,
+		Requested: len(action.EmailIDs),
+	}
+
+	var apply func(ctx context.Context, userID uuid.UUID, ids []int64) error
+	switch action.Type {
+	case domain.TriageActionArchive:
+		apply = s.emailService.Archive
+	case domain.TriageActionMarkRead:
+		apply = s.emailService.MarkAsRead
+	case domain.TriageActionUnsubscribe:
+		result.Senders = s.muteSenders(userID, action.Senders)
+		apply = s.emailService.Archive
+	default:
+		result.Failed = result.Requested
+		result.Error = fmt.Sprintf("%s: %s", ErrTriageUnknownAction, action.Type)
+		return result
+	}
+
+	for start := 0; start < len(action.EmailIDs); start += triageChunkSize {
+		end := min(start+triageChunkSize, len(action.EmailIDs))
+		chunk := action.EmailIDs[start:end]
+		if err := apply(ctx, userID, chunk); err != nil {
+			logger.Warn("[TriageService] %s failed for %d email(s): %v", action.ID, len(chunk), err)
+			result.Failed += len(chunk)
+			result.Error = err.Error()
+			continue
+		}
+		result.Succeeded += len(chunk)
+	}
+	return result
+}
+
+// muteSenders mutes the senders so their future emails are deprioritized, and returns how many were muted.
+func (s *TriageService) muteSenders(userID uuid.UUID, senders []string) int {
+	if s.senders == nil {
+		return 0
+	}
+	muted := 0
+	for _, email := range senders {
+		profile, err := s.senders.GetByEmail(userID, email)
+		if err != nil || profile == nil {
+			logger.Warn("[TriageService] sender profile %s not found: %v", email, err)
+			continue
+		}
+		if !profile.IsMuted {
+			profile.IsMuted = true
+			if err := s.senders.Update(profile); err != nil {
+				logger.Warn("[TriageService] failed to mute sender %s: %v", email, err)
+				continue
+			}
+		}
+		muted++
+	}
+	return muted
+}
+
+// subtractIDs returns ids without the IDs in remove, keeping the order.
+func subtractIDs(ids, remove []int64) []int64 {
+	if len(remove) == 0 {
+		return ids
+	}
+	skip := make(map[int64]bool, len(remove))
+	for _, id := range remove {
+		skip[id] = true
+	}
+	kept := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !skip[id] {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
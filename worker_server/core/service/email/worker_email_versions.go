@@ -0,0 +1,85 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/in"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidBatchAction = errors.New("unsupported batch action")
+
+// ModifyIfUnchanged applies a batch action (read, unread, star, unstar, archive, trash, move:<folder>)
+// only to emails whose updated_at is not newer than the client's version.
+// 동기화 등으로 먼저 바뀐 메일은 Conflicts로, 없거나 삭제된 메일은 NotFound로 돌려준다.
+func (s *Service) ModifyIfUnchanged(ctx context.Context, userID uuid.UUID, action string, versions map[int64]time.Time) (*in.BatchModifyResult, error) {
+	if s.emailRepo == nil {
+		return nil, ErrRepoNotInitialized
+	}
+	update, ok := batchUpdateFor(action)
+	if !ok {
+		return nil, ErrInvalidBatchAction
+	}
+
+	results, err := s.emailRepo.UpdateIfUnchanged(ctx, userID, versions, update)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &in.BatchModifyResult{Updated: []int64{}, Versions: make(map[int64]time.Time)}
+	found := make(map[int64]bool, len(results))
+	for _, r := range results {
+		found[r.ID] = true
+		if r.Updated {
+			result.Updated = append(result.Updated, r.ID)
+			result.Versions[r.ID] = r.UpdatedAt
+		} else {
+			result.Conflicts = append(result.Conflicts, in.VersionConflict{ID: r.ID, UpdatedAt: r.UpdatedAt})
+		}
+	}
+	for id := range versions {
+		if !found[id] {
+			result.NotFound = append(result.NotFound, id)
+		}
+	}
+	sort.Slice(result.Updated, func(i, j int) bool { return result.Updated[i] < result.Updated[j] })
+	sort.Slice(result.Conflicts, func(i, j int) bool { return result.Conflicts[i].ID < result.Conflicts[j].ID })
+	sort.Slice(result.NotFound, func(i, j int) bool { return result.NotFound[i] < result.NotFound[j] })
+
+	if len(result.Updated) > 0 {
+		go s.publishMailModifyJob(context.Background(), userID, result.Updated, action)
+	}
+	return result, nil
+}
+
+// batchUpdateFor maps a modify action to the repository update.
+func batchUpdateFor(action string) (*out.MailBatchUpdate, bool) {
+	isRead := func(v bool) *out.MailBatchUpdate { return &out.MailBatchUpdate{IsRead: &v} }
+	folder := func(f string) *out.MailBatchUpdate { return &out.MailBatchUpdate{Folder: &f} }
+
+	switch action {
+	case "read":
+		return isRead(true), true
+	case "unread":
+		return isRead(false), true
+	case "star":
+		return &out.MailBatchUpdate{AddTags: []string{"starred"}}, true
+	case "unstar":
+		return &out.MailBatchUpdate{RemoveTags: []string{"starred"}}, true
+	case "archive":
+		return folder(string(domain.LegacyFolderArchive)), true
+	case "trash":
+		return folder(string(domain.LegacyFolderTrash)), true
+	}
+	if f, ok := strings.CutPrefix(action, "move:"); ok && f != "" {
+		return folder(f), true
+	}
+	return nil, false
+}
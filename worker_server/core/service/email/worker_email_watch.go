@@ -0,0 +1,106 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/retry"
+
+	"github.com/google/uuid"
+)
+
+// WatchEscalateAfter - 연속 갱신 실패가 이 횟수에 이르면 사용자에게 푸시 → 폴링 전환을 알린다.
+const WatchEscalateAfter = 3
+
+// watchRetryPolicy spaces renewal attempts of a failing watch 1h, 2h, 4h ... up to 12h apart.
+// 갱신 스케줄러가 1시간마다 돌므로 실제 간격은 다음 tick까지 늘어난다.
+var watchRetryPolicy = retry.Policy{
+	BaseDelay: time.Hour,
+	MaxDelay:  12 * time.Hour,
+	Jitter:    0.1,
+}
+
+// watchExpiringWithin - 만료 예정으로 보고 갱신하는 범위
+const watchExpiringWithin = 24 * time.Hour
+
+// SystemNotifier sends a system notification to a user (notification.Service).
+type SystemNotifier interface {
+	SendSystemNotification(ctx context.Context, userID uuid.UUID, title, body string) error
+}
+
+// SetSystemNotifier enables user notifications when watch renewal keeps failing.
+func (s *SyncService) SetSystemNotifier(notifier SystemNotifier) {
+	s.systemNotifier = notifier
+}
+
+// renewWatch renews the watch of one connection. 실패하면 백오프 후 재시도를 예약하고,
+// WatchEscalateAfter번 연속 실패하면 한 번만 사용자에게 알린다 (성공 시 초기화).
+func (s *SyncService) renewWatch(ctx context.Context, state *domain.SyncState) error {
+	err := s.tryRenewWatch(ctx, state.ConnectionID)
+	if err == nil {
+		return nil
+	}
+
+	retryAt := time.Now().Add(watchRetryPolicy.Delay(state.WatchFailures))
+	failures, recErr := s.syncRepo.RecordWatchFailure(ctx, state.ConnectionID, retryAt, err.Error())
+	if recErr != nil {
+		logger.WithError(recErr).Warn("[SyncService] Failed to record watch failure for connection %d", state.ConnectionID)
+		return err
+	}
+	logger.Warn("[SyncService] Watch renewal failed for connection %d (%d in a row, next attempt %v): %v",
+		state.ConnectionID, failures, retryAt.Format(time.RFC3339), err)
+
+	if failures >= WatchEscalateAfter && state.WatchEscalatedAt.IsZero() {
+		s.escalateWatchFailure(ctx, state, failures)
+	}
+	return err
+}
+
+func (s *SyncService) tryRenewWatch(ctx context.Context, connectionID int64) error {
+	token, err := s.oauthService.GetOAuth2Token(ctx, connectionID)
+	if err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+
+	watchResp, err := s.emailProvider.Watch(ctx, token)
+	if err != nil {
+		return fmt.Errorf("failed to renew watch: %w", err)
+	}
+
+	if err := s.syncRepo.UpdateWatchExpiry(ctx, connectionID, watchResp.Expiration, watchResp.ExternalID); err != nil {
+		return fmt.Errorf("failed to save watch expiry: %w", err)
+	}
+	logger.Info("[SyncService] Renewed watch for connection %d, expires %v", connectionID, watchResp.Expiration)
+	return nil
+}
+
+// escalateWatchFailure tells the user new mail now arrives by polling only.
+func (s *SyncService) escalateWatchFailure(ctx context.Context, state *domain.SyncState, failures int) {
+	logger.Error("[SyncService] Watch for connection %d failed %d times, push degraded to polling", state.ConnectionID, failures)
+	if s.systemNotifier == nil {
+		return
+	}
+	userID, err := uuid.Parse(state.UserID)
+	if err != nil {
+		return
+	}
+
+	title := "New mail may arrive late"
+	body := "We couldn't renew real-time updates for your mailbox, so new mail is now checked periodically. " +
+		"Reconnecting the account usually fixes this."
+	if err := s.systemNotifier.SendSystemNotification(ctx, userID, title, body); err != nil {
+		logger.WithError(err).Warn("[SyncService] Failed to notify user %s of degraded push", state.UserID)
+		return
+	}
+	if err := s.syncRepo.MarkWatchEscalated(ctx, state.ConnectionID, time.Now()); err != nil {
+		logger.WithError(err).Warn("[SyncService] Failed to mark watch escalated for connection %d", state.ConnectionID)
+	}
+}
+
+// WatchStats counts watches by state (만료 상태 연결은 푸시 없이 폴링으로만 동기화된다).
+func (s *SyncService) WatchStats(ctx context.Context) (*domain.WatchStats, error) {
+	return s.syncRepo.CountWatches(ctx, watchExpiringWithin)
+}
@@ -0,0 +1,280 @@
+// Package filelink sends outgoing attachments over the provider size limit as download links.
+package filelink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/crypto"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// Path is the public download route that links point to.
+const Path = "/api/v1/files"
+
+const (
+	// DefaultExpiry - 다운로드 링크 유효 기간 (만료 후 파일 삭제)
+	DefaultExpiry = 30 * 24 * time.Hour
+
+	// Provider 메시지 크기 한도 (base64 인코딩 후 기준)
+	GmailLimit   int64 = 25 * 1024 * 1024
+	OutlookLimit int64 = 150 * 1024 * 1024
+
+	// linkReserve - 본문에 추가되는 링크 블록 여유분 (첨부 1개당)
+	linkReserve     = 1024
+	cleanupInterval = time.Hour
+)
+
+var (
+	ErrTooLarge         = errors.New("attachments exceed the provider size limit")
+	ErrNotFound         = errors.New("file not found or expired")
+	ErrInvalidSignature = errors.New("invalid or expired download link")
+)
+
+// Service stores oversized attachments and rewrites outgoing messages.
+type Service struct {
+	store        out.AttachmentBlobStore
+	signer       *crypto.URLSigner
+	baseURL      string
+	expiry       time.Duration
+	settingsRepo domain.SettingsRepository
+	lastCleanup  atomic.Int64
+}
+
+// NewService creates a new file link service.
+// baseURL must be the public origin of this server - 수신자가 직접 연다.
+func NewService(store out.AttachmentBlobStore, secret, baseURL string) *Service {
+	return &Service{
+		store:   store,
+		signer:  crypto.NewURLSigner(secret),
+		baseURL: strings.TrimRight(baseURL, "/"),
+		expiry:  DefaultExpiry,
+	}
+}
+
+// SetSettingsRepository sets the settings repository for the per-user toggle.
+func (s *Service) SetSettingsRepository(repo domain.SettingsRepository) {
+	s.settingsRepo = repo
+}
+
+// ProviderLimit returns the message size limit of a provider.
+func ProviderLimit(provider string) int64 {
+	switch provider {
+	case "outlook", "microsoft":
+		return OutlookLimit
+	default:
+		return GmailLimit
+	}
+}
+
+// Enabled reports whether the user sends oversized attachments as links (기본값: 켜짐).
+func (s *Service) Enabled(userID uuid.UUID) bool {
+	if s.settingsRepo == nil {
+		return true
+	}
+	settings, err := s.settingsRepo.GetByUserID(userID)
+	if err != nil || settings == nil {
+		return true
+	}
+	return settings.LargeAttachmentLinks
+}
+
+// Apply moves the largest attachments of msg to the blob store until the message fits the
+// provider limit, and appends their download links to the body.
+// 한도 이내면 아무것도 하지 않고, 사용자가 끈 경우 ErrTooLarge를 반환한다.
+func (s *Service) Apply(ctx context.Context, userID uuid.UUID, provider string, msg *out.ProviderOutgoingMessage) ([]*domain.LinkedAttachment, error) {
+	limit := ProviderLimit(provider)
+	if messageSize(msg) <= limit {
+		return nil, nil
+	}
+	if !s.Enabled(userID) {
+		return nil, fmt.Errorf("%w (%d MB)", ErrTooLarge, limit/(1024*1024))
+	}
+
+	keep, move := splitAttachments(msg, limit)
+	expiresAt := time.Now().Add(s.expiry)
+	linked := make([]*domain.LinkedAttachment, 0, len(move))
+	for _, att := range move {
+		blob := &domain.LinkedAttachment{
+			UserID:    userID,
+			Filename:  att.Filename,
+			MimeType:  att.MimeType,
+			Size:      int64(len(att.Data)),
+			ExpiresAt: expiresAt,
+		}
+		if blob.Filename == "" {
+			blob.Filename = "attachment"
+		}
+		if err := s.store.Put(ctx, blob, bytes.NewReader(att.Data)); err != nil {
+			return nil, fmt.Errorf("failed to store large attachment: %w", err)
+		}
+		blob.URL = s.downloadURL(blob.ID, expiresAt)
+		linked = append(linked, blob)
+	}
+
+	msg.Attachments = keep
+	msg.Body = appendLinks(msg.Body, msg.IsHTML, linked)
+	s.cleanupExpired()
+
+	logger.Info("[FileLinkService.Apply] user=%s converted %d attachment(s) to links", userID, len(linked))
+	return linked, nil
+}
+
+// Open verifies a download link and returns the stored file. The caller closes the reader.
+func (s *Service) Open(ctx context.Context, id, exp, sig string) (*domain.LinkedAttachment, io.ReadCloser, error) {
+	if err := s.signer.Verify(payload(id), exp, sig); err != nil {
+		return nil, nil, ErrInvalidSignature
+	}
+
+	blob, data, err := s.store.Open(ctx, id)
+	if err != nil {
+		if errors.Is(err, out.ErrBlobNotFound) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+	if time.Now().After(blob.ExpiresAt) {
+		data.Close()
+		return nil, nil, ErrNotFound
+	}
+	return blob, data, nil
+}
+
+func (s *Service) downloadURL(id string, expiresAt time.Time) string {
+	exp, sig := s.signer.Sign(payload(id), expiresAt)
+	return s.baseURL + Path + "/" + id + "?exp=" + exp + "&sig=" + sig
+}
+
+func payload(id string) string {
+	return "file:" + id
+}
+
+// cleanupExpired deletes expired blobs in the background, at most once per cleanupInterval.
+func (s *Service) cleanupExpired() {
+	now := time.Now()
+	last := s.lastCleanup.Load()
+	if now.Sub(time.Unix(last, 0)) < cleanupInterval || !s.lastCleanup.CompareAndSwap(last, now.Unix()) {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		deleted, err := s.store.DeleteExpired(ctx, now)
+		if err != nil {
+			logger.WithError(err).Warn("[FileLinkService.cleanupExpired] Failed to delete expired files")
+			return
+		}
+		if deleted > 0 {
+			logger.Info("[FileLinkService.cleanupExpired] deleted %d expired file(s)", deleted)
+		}
+	}()
+}
+
+// =============================================================================
+// Message size
+// =============================================================================
+
+// encodedSize is the base64 size of n bytes in a MIME part.
+func encodedSize(n int) int64 {
+	return int64((n + 2) / 3 * 4)
+}
+
+func messageSize(msg *out.ProviderOutgoingMessage) int64 {
+	size := int64(len(msg.Body))
+	for _, att := range msg.Attachments {
+		size += encodedSize(len(att.Data))
+	}
+	return size
+}
+
+// splitAttachments returns the attachments to keep (원래 순서 유지) and the ones to move,
+// moving the largest first until the rest fits in limit.
+func splitAttachments(msg *out.ProviderOutgoingMessage, limit int64) (keep, move []out.ProviderOutgoingAttachment) {
+	order := make([]int, len(msg.Attachments))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(msg.Attachments[order[a]].Data) > len(msg.Attachments[order[b]].Data)
+	})
+
+	size := messageSize(msg)
+	moved := make(map[int]bool)
+	for _, i := range order {
+		if size <= limit {
+			break
+		}
+		moved[i] = true
+		size += linkReserve - encodedSize(len(msg.Attachments[i].Data))
+	}
+
+	for i, att := range msg.Attachments {
+		if moved[i] {
+			move = append(move, att)
+		} else {
+			keep = append(keep, att)
+		}
+	}
+	return keep, move
+}
+
+// =============================================================================
+// Body
+// =============================================================================
+
+// appendLinks adds the download link block at the end of the body (HTML은 </body> 앞).
+func appendLinks(body string, isHTML bool, linked []*domain.LinkedAttachment) string {
+	if len(linked) == 0 {
+		return body
+	}
+	until := linked[0].ExpiresAt.Format("Jan 2, 2006")
+
+	if !isHTML {
+		var b strings.Builder
+		b.WriteString(body)
+		b.WriteString("\n\nLarge attachments (available until " + until + "):\n")
+		for _, l := range linked {
+			fmt.Fprintf(&b, "- %s (%s): %s\n", l.Filename, formatSize(l.Size), l.URL)
+		}
+		return b.String()
+	}
+
+	var b strings.Builder
+	b.WriteString(`<div class="large-attachments" style="margin-top:16px;padding:12px;border:1px solid #dadce0;border-radius:8px">`)
+	b.WriteString(`<p style="margin:0 0 8px">Large attachments (available until ` + until + `):</p><ul style="margin:0;padding-left:20px">`)
+	for _, l := range linked {
+		fmt.Fprintf(&b, `<li><a href="%s">%s</a> (%s)</li>`, html.EscapeString(l.URL), html.EscapeString(l.Filename), formatSize(l.Size))
+	}
+	b.WriteString(`</ul></div>`)
+
+	if i := strings.LastIndex(strings.ToLower(body), "</body>"); i >= 0 {
+		return body[:i] + b.String() + body[i:]
+	}
+	return body + b.String()
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1024*1024*1024:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1024*1024*1024))
+	case n >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	case n >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
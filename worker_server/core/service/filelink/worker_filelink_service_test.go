@@ -0,0 +1,156 @@
+package filelink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+type memoryStore struct {
+	blobs map[string]*domain.LinkedAttachment
+	data  map[string][]byte
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{blobs: map[string]*domain.LinkedAttachment{}, data: map[string][]byte{}}
+}
+
+func (m *memoryStore) Put(_ context.Context, blob *domain.LinkedAttachment, data io.Reader) error {
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	blob.ID = "blob" + strconv.Itoa(len(m.blobs)+1)
+	blob.CreatedAt = time.Now()
+	copied := *blob
+	m.blobs[blob.ID] = &copied
+	m.data[blob.ID] = b
+	return nil
+}
+
+func (m *memoryStore) Open(_ context.Context, id string) (*domain.LinkedAttachment, io.ReadCloser, error) {
+	blob, ok := m.blobs[id]
+	if !ok {
+		return nil, nil, out.ErrBlobNotFound
+	}
+	return blob, io.NopCloser(bytes.NewReader(m.data[id])), nil
+}
+
+func (m *memoryStore) DeleteExpired(context.Context, time.Time) (int, error) {
+	return 0, nil
+}
+
+type settingsRepo struct {
+	domain.SettingsRepository
+	settings *domain.UserSettings
+}
+
+func (r *settingsRepo) GetByUserID(uuid.UUID) (*domain.UserSettings, error) {
+	return r.settings, nil
+}
+
+func attachment(name string, size int) out.ProviderOutgoingAttachment {
+	return out.ProviderOutgoingAttachment{Filename: name, MimeType: "application/pdf", Data: make([]byte, size)}
+}
+
+func TestApplyMovesLargestAttachments(t *testing.T) {
+	store := newMemoryStore()
+	svc := NewService(store, "secret", "https://api.example.com/")
+	userID := uuid.New()
+
+	msg := &out.ProviderOutgoingMessage{
+		Body:   "<html><body><p>hi</p></body></html>",
+		IsHTML: true,
+		Attachments: []out.ProviderOutgoingAttachment{
+			attachment("small.pdf", 1024),
+			attachment("big.pdf", 20*1024*1024),
+			attachment("medium.pdf", 5*1024*1024),
+		},
+	}
+
+	linked, err := svc.Apply(context.Background(), userID, "google", msg)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	// big.pdf만 옮기면 25MB 이내
+	if len(linked) != 1 || linked[0].Filename != "big.pdf" {
+		t.Fatalf("linked = %+v, want big.pdf only", linked)
+	}
+	if len(msg.Attachments) != 2 || msg.Attachments[0].Filename != "small.pdf" || msg.Attachments[1].Filename != "medium.pdf" {
+		t.Errorf("kept attachments = %v", msg.Attachments)
+	}
+	if !strings.Contains(msg.Body, `>big.pdf</a> (20.0 MB)</li></ul></div></body>`) {
+		t.Errorf("link block not inserted before </body>: %s", msg.Body)
+	}
+
+	u, err := url.Parse(linked[0].URL)
+	if err != nil || !strings.HasPrefix(linked[0].URL, "https://api.example.com"+Path+"/") {
+		t.Fatalf("URL = %q", linked[0].URL)
+	}
+	id := strings.TrimPrefix(u.Path, Path+"/")
+	blob, data, err := svc.Open(context.Background(), id, u.Query().Get("exp"), u.Query().Get("sig"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	data.Close()
+	if blob.UserID != userID || blob.Size != 20*1024*1024 {
+		t.Errorf("Open() = %+v", blob)
+	}
+
+	if _, _, err := svc.Open(context.Background(), id, u.Query().Get("exp"), "bad"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Open() with bad signature error = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestApplyWithinLimit(t *testing.T) {
+	svc := NewService(newMemoryStore(), "secret", "")
+	msg := &out.ProviderOutgoingMessage{
+		Body:        "hello",
+		Attachments: []out.ProviderOutgoingAttachment{attachment("a.pdf", 30*1024*1024)},
+	}
+
+	// Outlook 한도(150MB) 이내
+	linked, err := svc.Apply(context.Background(), uuid.New(), "outlook", msg)
+	if err != nil || len(linked) != 0 || len(msg.Attachments) != 1 || msg.Body != "hello" {
+		t.Errorf("Apply() = %v, %v; message changed: %+v", linked, err, msg.Body)
+	}
+}
+
+func TestApplyDisabled(t *testing.T) {
+	svc := NewService(newMemoryStore(), "secret", "")
+	svc.SetSettingsRepository(&settingsRepo{settings: &domain.UserSettings{LargeAttachmentLinks: false}})
+	msg := &out.ProviderOutgoingMessage{
+		Body:        "hello",
+		Attachments: []out.ProviderOutgoingAttachment{attachment("a.pdf", 30*1024*1024)},
+	}
+
+	if _, err := svc.Apply(context.Background(), uuid.New(), "google", msg); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Apply() error = %v, want ErrTooLarge", err)
+	}
+	if len(msg.Attachments) != 1 {
+		t.Errorf("attachments changed when disabled: %d", len(msg.Attachments))
+	}
+}
+
+func TestAppendLinksText(t *testing.T) {
+	expires := time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC)
+	body := appendLinks("hello", false, []*domain.LinkedAttachment{
+		{Filename: "video.mp4", Size: 40 * 1024 * 1024, URL: "https://x/files/1?exp=1&sig=2", ExpiresAt: expires},
+	})
+
+	want := "hello\n\nLarge attachments (available until Nov 15, 2026):\n- video.mp4 (40.0 MB): https://x/files/1?exp=1&sig=2\n"
+	if body != want {
+		t.Errorf("appendLinks() = %q, want %q", body, want)
+	}
+}
@@ -0,0 +1,601 @@
+// Package folder manages the canonical folder model and its mapping to provider folders.
+//
+// 폴더는 워크스페이스 기준(folders)이고, 연결마다 Gmail 사용자 라벨 / Outlook mailFolder로
+// 매핑된다(folder_provider_mappings). 로컬 변경은 즉시 Provider에 반영하고,
+// Provider 쪽 변경(생성/이름 변경/삭제)은 Sync에서 가져온다.
+package folder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+const maxNameLength = 100
+
+var (
+	ErrNotFound           = errors.New("folder not found")
+	ErrSystemFolder       = errors.New("system folders cannot be renamed or deleted")
+	ErrNameTaken          = errors.New("a folder with this name already exists")
+	ErrInvalidName        = errors.New("folder name is required (max 100 characters)")
+	ErrConnectionNotFound = errors.New("connection not found")
+)
+
+// ConnectionProvider resolves connections and tokens (auth.OAuthService).
+type ConnectionProvider interface {
+	GetConnection(ctx context.Context, connectionID int64) (*domain.OAuthConnection, error)
+	GetConnectionsByUser(ctx context.Context, userID uuid.UUID) ([]*domain.OAuthConnection, error)
+	GetOAuth2Token(ctx context.Context, connectionID int64) (*oauth2.Token, error)
+}
+
+// folderManager is implemented by providers with custom folders (Gmail, Outlook).
+type folderManager interface {
+	ListFolders(ctx context.Context, token *oauth2.Token) ([]out.ProviderMailFolder, error)
+	CreateFolder(ctx context.Context, token *oauth2.Token, name string) (*out.ProviderMailFolder, error)
+	RenameFolder(ctx context.Context, token *oauth2.Token, folderID, name string) error
+	DeleteFolder(ctx context.Context, token *oauth2.Token, folderID string) error
+}
+
+// CreateRequest creates a custom folder.
+type CreateRequest struct {
+	Name  string  `json:"name" validate:"required,max=100"`
+	Color *string `json:"color,omitempty" validate:"omitempty,max=20"`
+	Icon  *string `json:"icon,omitempty" validate:"omitempty,max=50"`
+}
+
+// UpdateRequest changes a folder (nil 필드는 유지). 시스템 폴더는 색/아이콘/순서만 바꿀 수 있다.
+type UpdateRequest struct {
+	Name     *string `json:"name,omitempty" validate:"omitempty,max=100"`
+	Color    *string `json:"color,omitempty" validate:"omitempty,max=20"`
+	Icon     *string `json:"icon,omitempty" validate:"omitempty,max=50"`
+	Position *int    `json:"position,omitempty"`
+}
+
+// Service manages folders and their provider mappings.
+type Service struct {
+	repo        domain.FolderRepository
+	connections ConnectionProvider
+	providers   map[string]out.EmailProviderPort
+}
+
+// NewService creates a new folder service.
+func NewService(repo domain.FolderRepository, connections ConnectionProvider) *Service {
+	return &Service{
+		repo:        repo,
+		connections: connections,
+		providers:   make(map[string]out.EmailProviderPort),
+	}
+}
+
+// RegisterProvider registers a provider under a connection provider name (google, outlook ...).
+func (s *Service) RegisterProvider(name string, provider out.EmailProviderPort) {
+	s.providers[name] = provider
+}
+
+// =============================================================================
+// CRUD
+// =============================================================================
+
+// List returns the folders of a user with their provider mappings (시스템 폴더가 없으면 만든다).
+func (s *Service) List(ctx context.Context, userID uuid.UUID) ([]*domain.EmailFolderWithMappings, error) {
+	folders, err := s.ensureSystemFolders(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.EmailFolderWithMappings, len(folders))
+	for i, f := range folders {
+		mappings, err := s.repo.GetMappingsByFolder(f.ID)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = &domain.EmailFolderWithMappings{Folder: f, Mappings: mappings}
+	}
+	return result, nil
+}
+
+// Get returns a folder owned by the user.
+func (s *Service) Get(ctx context.Context, userID uuid.UUID, id int64) (*domain.EmailFolderWithMappings, error) {
+	folder, err := s.owned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := s.repo.GetMappingsByFolder(folder.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.EmailFolderWithMappings{Folder: folder, Mappings: mappings}, nil
+}
+
+// Create creates a custom folder and pushes it to every mail connection.
+// Provider 생성에 실패한 연결은 external_id 없이 매핑을 남겨 다음 Sync에서 다시 만든다.
+func (s *Service) Create(ctx context.Context, userID uuid.UUID, req *CreateRequest) (*domain.EmailFolderWithMappings, error) {
+	name, err := normalizeName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	folders, err := s.ensureSystemFolders(userID)
+	if err != nil {
+		return nil, err
+	}
+	if findByName(folders, name, 0) != nil {
+		return nil, ErrNameTaken
+	}
+
+	folder := &domain.EmailFolder{
+		UserID:   userID,
+		Name:     name,
+		Type:     domain.FolderTypeUser,
+		Color:    req.Color,
+		Icon:     req.Icon,
+		Position: nextPosition(folders),
+	}
+	if err := s.repo.Create(folder); err != nil {
+		return nil, err
+	}
+
+	var mappings []*domain.FolderProviderMapping
+	for _, target := range s.mailConnections(ctx, userID) {
+		mapping := &domain.FolderProviderMapping{
+			FolderID:     folder.ID,
+			ConnectionID: target.conn.ID,
+			Provider:     domain.Provider(target.conn.Provider),
+			MappingType:  domain.FolderMappingType(domain.Provider(target.conn.Provider)),
+		}
+		if created, err := target.manager.CreateFolder(ctx, target.token, name); err != nil {
+			logger.WithError(err).Warn("[FolderService.Create] Failed to create folder on connection %d", target.conn.ID)
+		} else {
+			mapping.ExternalID = &created.ExternalID
+		}
+		if err := s.repo.CreateMapping(mapping); err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	return &domain.EmailFolderWithMappings{Folder: folder, Mappings: mappings}, nil
+}
+
+// Update changes a folder and renames it on the providers.
+func (s *Service) Update(ctx context.Context, userID uuid.UUID, id int64, req *UpdateRequest) (*domain.EmailFolderWithMappings, error) {
+	folder, err := s.owned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed := false
+	if req.Name != nil {
+		name, err := normalizeName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		if name != folder.Name {
+			if folder.IsSystem() {
+				return nil, ErrSystemFolder
+			}
+			folders, err := s.repo.GetByUserID(userID)
+			if err != nil {
+				return nil, err
+			}
+			if findByName(folders, name, folder.ID) != nil {
+				return nil, ErrNameTaken
+			}
+			folder.Name = name
+			renamed = true
+		}
+	}
+	if req.Color != nil {
+		folder.Color = req.Color
+	}
+	if req.Icon != nil {
+		folder.Icon = req.Icon
+	}
+	if req.Position != nil {
+		folder.Position = *req.Position
+	}
+
+	if err := s.repo.Update(folder); err != nil {
+		return nil, err
+	}
+
+	mappings, err := s.repo.GetMappingsByFolder(folder.ID)
+	if err != nil {
+		return nil, err
+	}
+	if renamed {
+		s.renameOnProviders(ctx, userID, folder, mappings)
+	}
+	return &domain.EmailFolderWithMappings{Folder: folder, Mappings: mappings}, nil
+}
+
+// Delete deletes a custom folder locally and on every mapped provider.
+func (s *Service) Delete(ctx context.Context, userID uuid.UUID, id int64) error {
+	folder, err := s.owned(userID, id)
+	if err != nil {
+		return err
+	}
+	if folder.IsSystem() {
+		return ErrSystemFolder
+	}
+
+	s.deleteOnProviders(ctx, userID, folder, 0)
+	return s.repo.Delete(folder.ID)
+}
+
+// =============================================================================
+// Sync
+// =============================================================================
+
+// SyncAll reconciles the folders of every mail connection of the user.
+func (s *Service) SyncAll(ctx context.Context, userID uuid.UUID) ([]*domain.FolderSyncResult, error) {
+	var results []*domain.FolderSyncResult
+	for _, target := range s.mailConnections(ctx, userID) {
+		result, err := s.sync(ctx, userID, target)
+		if err != nil {
+			logger.WithError(err).Warn("[FolderService.SyncAll] Failed to sync connection %d", target.conn.ID)
+			result = &domain.FolderSyncResult{ConnectionID: target.conn.ID, Failed: 1}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Sync reconciles the folders of one connection in both directions:
+// Provider에서 생긴/이름이 바뀐/삭제된 폴더를 로컬에 반영하고, 아직 Provider에 없는 로컬 폴더를 만든다.
+func (s *Service) Sync(ctx context.Context, userID uuid.UUID, connectionID int64) (*domain.FolderSyncResult, error) {
+	conn, err := s.connections.GetConnection(ctx, connectionID)
+	if err != nil || conn == nil || conn.UserID != userID {
+		return nil, ErrConnectionNotFound
+	}
+	target, err := s.target(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	return s.sync(ctx, userID, target)
+}
+
+func (s *Service) sync(ctx context.Context, userID uuid.UUID, target *connectionTarget) (*domain.FolderSyncResult, error) {
+	conn := target.conn
+	provider := domain.Provider(conn.Provider)
+	result := &domain.FolderSyncResult{ConnectionID: conn.ID}
+
+	remote, err := target.manager.ListFolders(ctx, target.token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provider folders: %w", err)
+	}
+	folders, err := s.ensureSystemFolders(userID)
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := s.repo.GetMappingsByConnection(conn.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*domain.EmailFolder, len(folders))
+	for _, f := range folders {
+		byID[f.ID] = f
+	}
+	byFolder := make(map[int64]*domain.FolderProviderMapping, len(mappings))
+	byExternal := make(map[string]*domain.FolderProviderMapping, len(mappings))
+	for _, m := range mappings {
+		byFolder[m.FolderID] = m
+		if m.ExternalID != nil {
+			byExternal[*m.ExternalID] = m
+		}
+	}
+	remoteIDs := make(map[string]bool, len(remote))
+
+	// 1. 시스템 폴더 매핑 (고정 ID)
+	for _, f := range folders {
+		if !f.IsSystem() || f.SystemKey == nil || byFolder[f.ID] != nil {
+			continue
+		}
+		externalID := domain.SystemFolderExternalID(provider, *f.SystemKey)
+		if externalID == "" {
+			continue
+		}
+		mapping := &domain.FolderProviderMapping{
+			FolderID:     f.ID,
+			ConnectionID: conn.ID,
+			Provider:     provider,
+			ExternalID:   &externalID,
+			MappingType:  domain.FolderMappingType(provider),
+		}
+		if err := s.repo.CreateMapping(mapping); err != nil {
+			return nil, err
+		}
+		byFolder[f.ID] = mapping
+	}
+
+	// 2. Provider → 로컬: 새 폴더는 같은 이름의 로컬 폴더에 연결하거나 새로 만든다
+	for _, r := range remote {
+		remoteIDs[r.ExternalID] = true
+
+		if m := byExternal[r.ExternalID]; m != nil {
+			f := byID[m.FolderID]
+			if f != nil && f.Name != r.Name && findByName(folders, r.Name, f.ID) == nil {
+				f.Name = r.Name
+				if err := s.repo.Update(f); err != nil {
+					return nil, err
+				}
+				result.Renamed++
+			}
+			continue
+		}
+
+		f := findByName(folders, r.Name, 0)
+		if f != nil && (f.IsSystem() || byFolder[f.ID] != nil && byFolder[f.ID].ExternalID != nil) {
+			continue // 다른 Provider 폴더에 이미 연결됨
+		}
+		if f == nil {
+			f = &domain.EmailFolder{
+				UserID:   userID,
+				Name:     r.Name,
+				Type:     domain.FolderTypeUser,
+				Position: nextPosition(folders),
+			}
+			if err := s.repo.Create(f); err != nil {
+				return nil, err
+			}
+			folders = append(folders, f)
+			byID[f.ID] = f
+			result.Imported++
+		}
+
+		externalID := r.ExternalID
+		if m := byFolder[f.ID]; m != nil {
+			m.ExternalID = &externalID
+			if err := s.repo.UpdateMapping(m); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		mapping := &domain.FolderProviderMapping{
+			FolderID:     f.ID,
+			ConnectionID: conn.ID,
+			Provider:     provider,
+			ExternalID:   &externalID,
+			MappingType:  domain.FolderMappingType(provider),
+		}
+		if err := s.repo.CreateMapping(mapping); err != nil {
+			return nil, err
+		}
+		byFolder[f.ID] = mapping
+	}
+
+	// 3. Provider에서 삭제된 폴더는 로컬과 다른 연결에서도 지운다
+	removed := make(map[int64]bool)
+	for _, m := range mappings {
+		f := byID[m.FolderID]
+		if m.ExternalID == nil || remoteIDs[*m.ExternalID] || f == nil || f.IsSystem() {
+			continue
+		}
+		if err := s.repo.DeleteMapping(m.ID); err != nil {
+			return nil, err
+		}
+		s.deleteOnProviders(ctx, userID, f, conn.ID)
+		if err := s.repo.Delete(f.ID); err != nil {
+			return nil, err
+		}
+		removed[f.ID] = true
+		result.Removed++
+	}
+
+	// 4. 로컬 → Provider: 아직 Provider에 없는 사용자 폴더를 만든다
+	for _, f := range folders {
+		if f.IsSystem() || removed[f.ID] {
+			continue
+		}
+		m := byFolder[f.ID]
+		if m != nil && m.ExternalID != nil {
+			continue
+		}
+		created, err := target.manager.CreateFolder(ctx, target.token, f.Name)
+		if err != nil {
+			logger.WithError(err).Warn("[FolderService.Sync] Failed to create folder %d on connection %d", f.ID, conn.ID)
+			result.Failed++
+			continue
+		}
+		if m != nil {
+			m.ExternalID = &created.ExternalID
+			err = s.repo.UpdateMapping(m)
+		} else {
+			err = s.repo.CreateMapping(&domain.FolderProviderMapping{
+				FolderID:     f.ID,
+				ConnectionID: conn.ID,
+				Provider:     provider,
+				ExternalID:   &created.ExternalID,
+				MappingType:  domain.FolderMappingType(provider),
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Exported++
+	}
+
+	logger.Info("[FolderService.Sync] connection=%d imported=%d exported=%d renamed=%d removed=%d failed=%d",
+		conn.ID, result.Imported, result.Exported, result.Renamed, result.Removed, result.Failed)
+	return result, nil
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+// connectionTarget is a mail connection whose provider manages folders.
+type connectionTarget struct {
+	conn    *domain.OAuthConnection
+	token   *oauth2.Token
+	manager folderManager
+}
+
+func (s *Service) target(ctx context.Context, conn *domain.OAuthConnection) (*connectionTarget, error) {
+	manager, ok := s.providers[string(conn.Provider)].(folderManager)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not support folders", conn.Provider)
+	}
+	token, err := s.connections.GetOAuth2Token(ctx, conn.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &connectionTarget{conn: conn, token: token, manager: manager}, nil
+}
+
+// mailConnections returns the connected accounts that support folders (토큰 실패는 건너뛴다).
+func (s *Service) mailConnections(ctx context.Context, userID uuid.UUID) []*connectionTarget {
+	conns, err := s.connections.GetConnectionsByUser(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Warn("[FolderService] Failed to list connections")
+		return nil
+	}
+
+	var targets []*connectionTarget
+	for _, conn := range conns {
+		if !conn.IsConnected {
+			continue
+		}
+		if _, ok := s.providers[string(conn.Provider)].(folderManager); !ok {
+			continue
+		}
+		target, err := s.target(ctx, conn)
+		if err != nil {
+			logger.WithError(err).Warn("[FolderService] Skipping connection %d", conn.ID)
+			continue
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// renameOnProviders renames the provider folders of a folder (실패는 다음 Sync에서 Provider 이름으로 되돌아감).
+func (s *Service) renameOnProviders(ctx context.Context, userID uuid.UUID, folder *domain.EmailFolder, mappings []*domain.FolderProviderMapping) {
+	targets := s.mailConnections(ctx, userID)
+	for _, m := range mappings {
+		if m.ExternalID == nil {
+			continue
+		}
+		for _, target := range targets {
+			if target.conn.ID != m.ConnectionID {
+				continue
+			}
+			if err := target.manager.RenameFolder(ctx, target.token, *m.ExternalID, folder.Name); err != nil {
+				logger.WithError(err).Warn("[FolderService.Update] Failed to rename folder %d on connection %d", folder.ID, m.ConnectionID)
+				continue
+			}
+			if err := s.repo.UpdateMapping(m); err != nil {
+				logger.WithError(err).Warn("[FolderService.Update] Failed to update mapping %d", m.ID)
+			}
+		}
+	}
+}
+
+// deleteOnProviders deletes the provider folders of a folder except on skipConnectionID.
+func (s *Service) deleteOnProviders(ctx context.Context, userID uuid.UUID, folder *domain.EmailFolder, skipConnectionID int64) {
+	mappings, err := s.repo.GetMappingsByFolder(folder.ID)
+	if err != nil {
+		logger.WithError(err).Warn("[FolderService] Failed to load mappings of folder %d", folder.ID)
+		return
+	}
+
+	var targets []*connectionTarget
+	for _, m := range mappings {
+		if m.ExternalID == nil || m.ConnectionID == skipConnectionID {
+			continue
+		}
+		if targets == nil {
+			targets = s.mailConnections(ctx, userID)
+		}
+		for _, target := range targets {
+			if target.conn.ID != m.ConnectionID {
+				continue
+			}
+			if err := target.manager.DeleteFolder(ctx, target.token, *m.ExternalID); err != nil {
+				logger.WithError(err).Warn("[FolderService] Failed to delete folder %d on connection %d", folder.ID, m.ConnectionID)
+			}
+		}
+	}
+}
+
+// ensureSystemFolders creates missing system folders and returns all folders of the user.
+func (s *Service) ensureSystemFolders(userID uuid.UUID) ([]*domain.EmailFolder, error) {
+	folders, err := s.repo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[domain.SystemFolderKey]bool)
+	for _, f := range folders {
+		if f.SystemKey != nil {
+			existing[*f.SystemKey] = true
+		}
+	}
+
+	for i, key := range domain.SystemFolderKeys {
+		if existing[key] {
+			continue
+		}
+		systemKey := key
+		folder := &domain.EmailFolder{
+			UserID:    userID,
+			Name:      key.DefaultName(),
+			Type:      domain.FolderTypeSystem,
+			SystemKey: &systemKey,
+			Position:  i,
+		}
+		if err := s.repo.Create(folder); err != nil {
+			return nil, err
+		}
+		folders = append(folders, folder)
+	}
+	return folders, nil
+}
+
+func (s *Service) owned(userID uuid.UUID, id int64) (*domain.EmailFolder, error) {
+	folder, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if folder.UserID != userID {
+		return nil, ErrNotFound
+	}
+	return folder, nil
+}
+
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxNameLength {
+		return "", ErrInvalidName
+	}
+	return name, nil
+}
+
+// findByName finds a folder by name (대소문자 무시), skipping exceptID.
+func findByName(folders []*domain.EmailFolder, name string, exceptID int64) *domain.EmailFolder {
+	for _, f := range folders {
+		if f.ID != exceptID && strings.EqualFold(f.Name, name) {
+			return f
+		}
+	}
+	return nil
+}
+
+func nextPosition(folders []*domain.EmailFolder) int {
+	position := 0
+	for _, f := range folders {
+		if f.Position >= position {
+			position = f.Position + 1
+		}
+	}
+	return position
+}
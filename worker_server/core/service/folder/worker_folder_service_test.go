@@ -0,0 +1,332 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+type memoryRepo struct {
+	folders  map[int64]*domain.EmailFolder
+	mappings map[int64]*domain.FolderProviderMapping
+	nextID   int64
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		folders:  make(map[int64]*domain.EmailFolder),
+		mappings: make(map[int64]*domain.FolderProviderMapping),
+	}
+}
+
+func (r *memoryRepo) id() int64 {
+	r.nextID++
+	return r.nextID
+}
+
+func (r *memoryRepo) GetByID(id int64) (*domain.EmailFolder, error) {
+	if f, ok := r.folders[id]; ok {
+		return f, nil
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryRepo) GetByUserID(userID uuid.UUID) ([]*domain.EmailFolder, error) {
+	var list []*domain.EmailFolder
+	for id := int64(1); id <= r.nextID; id++ {
+		if f, ok := r.folders[id]; ok && f.UserID == userID {
+			list = append(list, f)
+		}
+	}
+	return list, nil
+}
+
+func (r *memoryRepo) GetSystemFolder(uuid.UUID, domain.SystemFolderKey) (*domain.EmailFolder, error) {
+	return nil, ErrNotFound
+}
+
+func (r *memoryRepo) Create(folder *domain.EmailFolder) error {
+	folder.ID = r.id()
+	r.folders[folder.ID] = folder
+	return nil
+}
+
+func (r *memoryRepo) Update(*domain.EmailFolder) error { return nil }
+
+func (r *memoryRepo) Delete(id int64) error {
+	delete(r.folders, id)
+	return nil
+}
+
+func (r *memoryRepo) GetMapping(folderID, connectionID int64) (*domain.FolderProviderMapping, error) {
+	for _, m := range r.mappings {
+		if m.FolderID == folderID && m.ConnectionID == connectionID {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRepo) GetMappingByExternalID(connectionID int64, externalID string) (*domain.FolderProviderMapping, error) {
+	for _, m := range r.mappings {
+		if m.ConnectionID == connectionID && m.ExternalID != nil && *m.ExternalID == externalID {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRepo) CreateMapping(mapping *domain.FolderProviderMapping) error {
+	mapping.ID = r.id()
+	r.mappings[mapping.ID] = mapping
+	return nil
+}
+
+func (r *memoryRepo) UpdateMapping(*domain.FolderProviderMapping) error { return nil }
+
+func (r *memoryRepo) DeleteMapping(id int64) error {
+	delete(r.mappings, id)
+	return nil
+}
+
+func (r *memoryRepo) GetMappingsByFolder(folderID int64) ([]*domain.FolderProviderMapping, error) {
+	var list []*domain.FolderProviderMapping
+	for _, m := range r.mappings {
+		if m.FolderID == folderID {
+			list = append(list, m)
+		}
+	}
+	return list, nil
+}
+
+func (r *memoryRepo) GetMappingsByConnection(connectionID int64) ([]*domain.FolderProviderMapping, error) {
+	var list []*domain.FolderProviderMapping
+	for _, m := range r.mappings {
+		if m.ConnectionID == connectionID {
+			list = append(list, m)
+		}
+	}
+	return list, nil
+}
+
+func (r *memoryRepo) UpdateCounts(int64, int, int) error { return nil }
+func (r *memoryRepo) RecalculateCounts(int64) error      { return nil }
+
+// fakeProvider keeps provider folders in memory (나머지 메서드는 호출되지 않음).
+type fakeProvider struct {
+	out.EmailProviderPort
+	folders map[string]string // external ID → name
+	nextID  int
+	deleted []string
+}
+
+func (p *fakeProvider) ListFolders(context.Context, *oauth2.Token) ([]out.ProviderMailFolder, error) {
+	var list []out.ProviderMailFolder
+	for id, name := range p.folders {
+		list = append(list, out.ProviderMailFolder{ExternalID: id, Name: name})
+	}
+	return list, nil
+}
+
+func (p *fakeProvider) CreateFolder(_ context.Context, _ *oauth2.Token, name string) (*out.ProviderMailFolder, error) {
+	p.nextID++
+	id := "Label_new" + string(rune('0'+p.nextID))
+	p.folders[id] = name
+	return &out.ProviderMailFolder{ExternalID: id, Name: name}, nil
+}
+
+func (p *fakeProvider) RenameFolder(_ context.Context, _ *oauth2.Token, folderID, name string) error {
+	p.folders[folderID] = name
+	return nil
+}
+
+func (p *fakeProvider) DeleteFolder(_ context.Context, _ *oauth2.Token, folderID string) error {
+	delete(p.folders, folderID)
+	p.deleted = append(p.deleted, folderID)
+	return nil
+}
+
+type fakeConnections struct {
+	conns []*domain.OAuthConnection
+}
+
+func (c *fakeConnections) GetConnection(_ context.Context, id int64) (*domain.OAuthConnection, error) {
+	for _, conn := range c.conns {
+		if conn.ID == id {
+			return conn, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (c *fakeConnections) GetConnectionsByUser(context.Context, uuid.UUID) ([]*domain.OAuthConnection, error) {
+	return c.conns, nil
+}
+
+func (c *fakeConnections) GetOAuth2Token(context.Context, int64) (*oauth2.Token, error) {
+	return &oauth2.Token{}, nil
+}
+
+func newTestService(userID uuid.UUID, provider *fakeProvider) (*Service, *memoryRepo) {
+	repo := newMemoryRepo()
+	conns := &fakeConnections{conns: []*domain.OAuthConnection{
+		{ID: 1, UserID: userID, Provider: domain.ProviderGoogle, IsConnected: true},
+	}}
+	svc := NewService(repo, conns)
+	svc.RegisterProvider("google", provider)
+	return svc, repo
+}
+
+func folderNames(t *testing.T, svc *Service, userID uuid.UUID) map[string]bool {
+	t.Helper()
+	list, err := svc.List(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	names := make(map[string]bool)
+	for _, f := range list {
+		if !f.Folder.IsSystem() {
+			names[f.Folder.Name] = true
+		}
+	}
+	return names
+}
+
+func TestSyncImportsAndExports(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	provider := &fakeProvider{folders: map[string]string{"Label_1": "Receipts"}}
+	svc, repo := newTestService(userID, provider)
+
+	// 로컬에서 만든 폴더 (Provider 생성 실패를 흉내 내기 위해 매핑 없이 저장)
+	local := &domain.EmailFolder{UserID: userID, Name: "Projects", Type: domain.FolderTypeUser}
+	if err := repo.Create(local); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := svc.Sync(ctx, userID, 1)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if result.Imported != 1 || result.Exported != 1 {
+		t.Errorf("Sync() = %+v, want 1 imported and 1 exported", result)
+	}
+
+	names := folderNames(t, svc, userID)
+	if !names["Receipts"] || !names["Projects"] {
+		t.Errorf("folders = %v, want Receipts and Projects", names)
+	}
+
+	found := false
+	for _, name := range provider.folders {
+		found = found || name == "Projects"
+	}
+	if !found {
+		t.Errorf("provider folders = %v, want Projects created", provider.folders)
+	}
+
+	// 시스템 폴더는 고정 ID로 매핑
+	inbox, _ := repo.GetMappingByExternalID(1, "INBOX")
+	if inbox == nil {
+		t.Error("expected INBOX mapping for the system inbox folder")
+	}
+
+	// 두 번째 Sync는 변경 없음
+	result, err = svc.Sync(ctx, userID, 1)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if *result != (domain.FolderSyncResult{ConnectionID: 1}) {
+		t.Errorf("second Sync() = %+v, want no changes", result)
+	}
+}
+
+func TestSyncAppliesProviderRenameAndDelete(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	provider := &fakeProvider{folders: map[string]string{"Label_1": "Receipts", "Label_2": "Travel"}}
+	svc, _ := newTestService(userID, provider)
+
+	if _, err := svc.Sync(ctx, userID, 1); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	provider.folders["Label_1"] = "Invoices"
+	delete(provider.folders, "Label_2")
+
+	result, err := svc.Sync(ctx, userID, 1)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if result.Renamed != 1 || result.Removed != 1 {
+		t.Errorf("Sync() = %+v, want 1 renamed and 1 removed", result)
+	}
+
+	names := folderNames(t, svc, userID)
+	if !names["Invoices"] || names["Receipts"] || names["Travel"] {
+		t.Errorf("folders = %v, want only Invoices", names)
+	}
+}
+
+func TestCreateRenameDelete(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	provider := &fakeProvider{folders: map[string]string{}}
+	svc, _ := newTestService(userID, provider)
+
+	created, err := svc.Create(ctx, userID, &CreateRequest{Name: "  Clients "})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.Folder.Name != "Clients" || len(created.Mappings) != 1 || created.Mappings[0].ExternalID == nil {
+		t.Fatalf("Create() = %+v, want Clients mapped on the connection", created)
+	}
+	externalID := *created.Mappings[0].ExternalID
+
+	if _, err := svc.Create(ctx, userID, &CreateRequest{Name: "clients"}); !errors.Is(err, ErrNameTaken) {
+		t.Errorf("duplicate Create() error = %v, want ErrNameTaken", err)
+	}
+
+	name := "Customers"
+	if _, err := svc.Update(ctx, userID, created.Folder.ID, &UpdateRequest{Name: &name}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if provider.folders[externalID] != "Customers" {
+		t.Errorf("provider name = %q, want Customers", provider.folders[externalID])
+	}
+
+	if err := svc.Delete(ctx, userID, created.Folder.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok := provider.folders[externalID]; ok {
+		t.Error("expected provider folder to be deleted")
+	}
+}
+
+func TestSystemFolderCannotBeRenamed(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	svc, _ := newTestService(userID, &fakeProvider{folders: map[string]string{}})
+
+	list, err := svc.List(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != len(domain.SystemFolderKeys) {
+		t.Fatalf("List() = %d folders, want %d system folders", len(list), len(domain.SystemFolderKeys))
+	}
+
+	name := "Mail"
+	if _, err := svc.Update(ctx, userID, list[0].Folder.ID, &UpdateRequest{Name: &name}); !errors.Is(err, ErrSystemFolder) {
+		t.Errorf("Update() error = %v, want ErrSystemFolder", err)
+	}
+	if err := svc.Delete(ctx, userID, list[0].Folder.ID); !errors.Is(err, ErrSystemFolder) {
+		t.Errorf("Delete() error = %v, want ErrSystemFolder", err)
+	}
+}
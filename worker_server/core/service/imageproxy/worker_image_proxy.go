@@ -0,0 +1,270 @@
+// Package imageproxy serves remote email images through a privacy-preserving cache.
+package imageproxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"worker_server/pkg/htmlsanitize"
+	"worker_server/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+// ProxyPath is the public route serving proxied images.
+const ProxyPath = "/api/v1/img"
+
+const (
+	cacheKeyPrefix  = "imgproxy:"
+	defaultCacheTTL = 24 * time.Hour
+	defaultMaxSize  = 5 * 1024 * 1024
+	// maxCacheableSize - 이보다 큰 이미지는 Redis에 저장하지 않음
+	maxCacheableSize = 1024 * 1024
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid image signature")
+	ErrNotImage         = errors.New("remote resource is not an image")
+	ErrImageTooLarge    = errors.New("remote image is too large")
+	ErrBlockedHost      = errors.New("remote host is not allowed")
+)
+
+// transparentGIF is served in place of blocked tracking pixels.
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// Image is a fetched (or cached) image.
+type Image struct {
+	Data        []byte
+	ContentType string
+	Blocked     bool // 추적 픽셀로 차단되어 투명 GIF로 대체됨
+}
+
+// Service signs image URLs and fetches them without leaking cookies or referrer.
+type Service struct {
+	secret   []byte
+	baseURL  string
+	client   *http.Client
+	redis    *redis.Client
+	maxSize  int64
+	cacheTTL time.Duration
+	flight   singleflight.Group
+}
+
+// NewService creates a new image proxy service. redisClient may be nil (캐시 없이 동작).
+func NewService(secret, baseURL string, redisClient *redis.Client, maxSize int64) *Service {
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+	return &Service{
+		secret:   []byte(secret),
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		client:   newSafeClient(),
+		redis:    redisClient,
+		maxSize:  maxSize,
+		cacheTTL: defaultCacheTTL,
+	}
+}
+
+// ProxyURL returns the signed proxy URL for a remote image.
+func (s *Service) ProxyURL(rawURL string) string {
+	enc := base64.RawURLEncoding.EncodeToString([]byte(rawURL))
+	return s.baseURL + ProxyPath + "?u=" + enc + "&s=" + s.sign(rawURL)
+}
+
+// Verify checks the signature and returns the original URL.
+func (s *Service) Verify(encodedURL, sig string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encodedURL)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+	rawURL := string(raw)
+	if !hmac.Equal([]byte(sig), []byte(s.sign(rawURL))) {
+		return "", ErrInvalidSignature
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", ErrInvalidSignature
+	}
+	return rawURL, nil
+}
+
+// RewriteHTML points remote images at the proxy and drops known tracking pixels.
+// Returns the HTML, the number of proxied images and the number of blocked trackers.
+func (s *Service) RewriteHTML(body string) (string, int, int) {
+	return htmlsanitize.RewriteImages(body, func(src string) (string, bool) {
+		if htmlsanitize.IsTrackerURL(src) {
+			return "", true
+		}
+		if strings.HasPrefix(src, "//") {
+			src = "https:" + src
+		}
+		if strings.HasPrefix(src, s.baseURL+ProxyPath) && s.baseURL != "" {
+			return "", false
+		}
+		return s.ProxyURL(src), true
+	})
+}
+
+// Fetch returns the image from cache or the remote server.
+func (s *Service) Fetch(ctx context.Context, rawURL string) (*Image, error) {
+	if htmlsanitize.IsTrackerURL(rawURL) {
+		return &Image{Data: transparentGIF, ContentType: "image/gif", Blocked: true}, nil
+	}
+
+	key := cacheKeyPrefix + s.sign(rawURL)
+	if img := s.getCached(ctx, key); img != nil {
+		return img, nil
+	}
+
+	// 같은 이미지를 동시에 여러 번 받지 않도록 singleflight
+	v, err, _ := s.flight.Do(key, func() (interface{}, error) {
+		img, err := s.download(ctx, rawURL)
+		if err != nil {
+			return nil, err
+		}
+		s.setCached(ctx, key, img)
+		return img, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Image), nil
+}
+
+func (s *Service) download(ctx context.Context, rawURL string) (*Image, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	// 쿠키, Referer 없이 요청 - 사용자 정보 노출 방지
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; MailImageProxy/1.0)")
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && errors.Is(opErr.Err, ErrBlockedHost) {
+			return nil, ErrBlockedHost
+		}
+		return nil, fmt.Errorf("failed to fetch image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("remote image returned %d", resp.StatusCode)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(strings.ToLower(contentType), "image/") {
+		return nil, ErrNotImage
+	}
+	if resp.ContentLength > s.maxSize {
+		return nil, ErrImageTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, s.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, ErrImageTooLarge
+	}
+
+	return &Image{Data: data, ContentType: contentType}, nil
+}
+
+// 캐시 포맷: "<content-type>\n<data>"
+func (s *Service) getCached(ctx context.Context, key string) *Image {
+	if s.redis == nil {
+		return nil
+	}
+	data, err := s.redis.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil
+	}
+	i := bytes.IndexByte(data, '\n')
+	if i < 0 {
+		return nil
+	}
+	return &Image{ContentType: string(data[:i]), Data: data[i+1:]}
+}
+
+func (s *Service) setCached(ctx context.Context, key string, img *Image) {
+	if s.redis == nil || len(img.Data) > maxCacheableSize {
+		return
+	}
+	buf := make([]byte, 0, len(img.ContentType)+1+len(img.Data))
+	buf = append(buf, img.ContentType...)
+	buf = append(buf, '\n')
+	buf = append(buf, img.Data...)
+	if err := s.redis.Set(ctx, key, buf, s.cacheTTL).Err(); err != nil {
+		logger.WithError(err).Warn("[ImageProxy] Failed to cache image")
+	}
+}
+
+func (s *Service) sign(rawURL string) string {
+	m := hmac.New(sha256.New, s.secret)
+	m.Write([]byte("img:" + rawURL))
+	return hex.EncodeToString(m.Sum(nil)[:16])
+}
+
+// newSafeClient returns an HTTP client that refuses private/loopback destinations (SSRF 방지).
+// 연결 시점에 IP를 검사하므로 리다이렉트와 DNS rebinding도 막는다.
+func newSafeClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !isPublicIP(ip) {
+				return ErrBlockedHost
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			MaxIdleConns:          50,
+			IdleConnTimeout:       60 * time.Second,
+			TLSHandshakeTimeout:   5 * time.Second,
+			ResponseHeaderTimeout: 5 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 3 {
+				return errors.New("too many redirects")
+			}
+			req.Header.Del("Referer")
+			return nil
+		},
+	}
+}
+
+func isPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
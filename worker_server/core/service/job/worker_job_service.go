@@ -0,0 +1,122 @@
+// Package job tracks fire-and-forget background jobs so clients can poll their status.
+package job
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+var ErrJobNotFound = errors.New("job not found")
+
+// Service creates jobs for API requests and records progress reported by workers.
+// 진행 상황 기록 실패는 작업 자체를 실패시키지 않도록 로그만 남긴다.
+type Service struct {
+	repo out.JobRepository
+}
+
+// NewService creates a new job service.
+func NewService(repo out.JobRepository) *Service {
+	return &Service{repo: repo}
+}
+
+// Create registers a queued job. total이 0이면 진행률 없이 상태만 추적한다.
+func (s *Service) Create(ctx context.Context, userID uuid.UUID, jobType domain.BackgroundJobType, connectionID int64, total int) (*domain.BackgroundJob, error) {
+	job := &domain.BackgroundJob{
+		ID:     uuid.New(),
+		UserID: userID,
+		Type:   jobType,
+		State:  domain.JobQueued,
+		Progress: domain.JobProgress{
+			Total: total,
+		},
+	}
+	if connectionID > 0 {
+		job.ConnectionID = &connectionID
+	}
+	if err := s.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Get returns a job owned by userID.
+func (s *Service) Get(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*domain.BackgroundJob, error) {
+	job, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	if job.UserID != userID {
+		return nil, ErrJobNotFound
+	}
+	job.Fill(time.Now())
+	return job, nil
+}
+
+// Latest returns the most recent job of jobType for a connection owned by userID.
+func (s *Service) Latest(ctx context.Context, userID uuid.UUID, jobType domain.BackgroundJobType, connectionID int64) (*domain.BackgroundJob, error) {
+	job, err := s.repo.GetLatest(ctx, userID, jobType, connectionID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	job.Fill(time.Now())
+	return job, nil
+}
+
+// Start marks a job as running. Empty or invalid IDs are ignored (untracked jobs).
+func (s *Service) Start(ctx context.Context, jobID string) {
+	id, ok := parseID(jobID)
+	if !ok {
+		return
+	}
+	if err := s.repo.MarkRunning(ctx, id); err != nil {
+		logger.Warn("[JobService] Failed to start job %s: %v", jobID, err)
+	}
+}
+
+// Progress adds processed item counts.
+func (s *Service) Progress(ctx context.Context, jobID string, done, failed int) {
+	id, ok := parseID(jobID)
+	if !ok || done+failed == 0 {
+		return
+	}
+	if err := s.repo.AddProgress(ctx, id, done, failed); err != nil {
+		logger.Warn("[JobService] Failed to update job %s: %v", jobID, err)
+	}
+}
+
+// Finish marks a job as succeeded, or failed when err is not nil.
+func (s *Service) Finish(ctx context.Context, jobID string, err error) {
+	id, ok := parseID(jobID)
+	if !ok {
+		return
+	}
+	state, msg := domain.JobSucceeded, ""
+	if err != nil {
+		state, msg = domain.JobFailed, err.Error()
+	}
+	if ferr := s.repo.Finish(ctx, id, state, msg); ferr != nil {
+		logger.Warn("[JobService] Failed to finish job %s: %v", jobID, ferr)
+	}
+}
+
+func parseID(jobID string) (uuid.UUID, bool) {
+	if jobID == "" {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(jobID)
+	return id, err == nil
+}
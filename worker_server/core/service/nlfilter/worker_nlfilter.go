@@ -0,0 +1,237 @@
+// Package nlfilter interprets natural-language mail list filters ("unread invoices from last month with attachments").
+package nlfilter
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"worker_server/core/agent/llm"
+	"worker_server/core/domain"
+	"worker_server/pkg/logger"
+)
+
+// Interpretation sources.
+const (
+	SourceRules    = "rules"
+	SourceRulesLLM = "rules+llm"
+)
+
+const llmTimeout = 5 * time.Second
+
+// Parser converts natural-language filters into EmailFilter fields.
+// 규칙으로 먼저 해석하고, 남은 표현이 있을 때만 LLM에 묻는다.
+type Parser struct {
+	llmClient *llm.Client
+}
+
+// NewParser creates a new Parser. llmClient가 nil이면 규칙만 쓴다.
+func NewParser(llmClient *llm.Client) *Parser {
+	return &Parser{llmClient: llmClient}
+}
+
+// Interpretation is how a natural-language filter was understood (응답에 그대로 돌려준다).
+type Interpretation struct {
+	Query    string   `json:"query"`
+	Source   string   `json:"source"`
+	Filter   Filter   `json:"filter"`
+	Matched  []string `json:"matched,omitempty"`  // 규칙이 알아본 표현
+	Unparsed string   `json:"unparsed,omitempty"` // 규칙으로 해석하지 못한 나머지
+}
+
+// Filter is the structured filter interpreted from the query.
+type Filter struct {
+	Folder        *domain.LegacyFolder     `json:"folder,omitempty"`
+	Category      *domain.EmailCategory    `json:"category,omitempty"`
+	SubCategory   *domain.EmailSubCategory `json:"sub_category,omitempty"`
+	MinPriority   *domain.Priority         `json:"min_priority,omitempty"`
+	IsRead        *bool                    `json:"is_read,omitempty"`
+	IsStarred     *bool                    `json:"is_starred,omitempty"`
+	HasAttachment *bool                    `json:"has_attachment,omitempty"`
+	FromEmail     *string                  `json:"from_email,omitempty"`
+	FromDomain    *string                  `json:"from_domain,omitempty"`
+	DateFrom      *time.Time               `json:"date_from,omitempty"`
+	DateTo        *time.Time               `json:"date_to,omitempty"`
+	Search        *string                  `json:"search,omitempty"`
+}
+
+// Apply copies the interpreted fields into filter. 요청에 명시된 파라미터가 우선한다.
+func (f *Filter) Apply(filter *domain.EmailFilter) {
+	if filter.Folder == nil {
+		filter.Folder = f.Folder
+	}
+	if filter.Category == nil {
+		filter.Category = f.Category
+	}
+	if filter.SubCategory == nil {
+		filter.SubCategory = f.SubCategory
+	}
+	if filter.MinPriority == nil && filter.Priority == nil {
+		filter.MinPriority = f.MinPriority
+	}
+	if filter.IsRead == nil {
+		filter.IsRead = f.IsRead
+	}
+	if filter.IsStarred == nil {
+		filter.IsStarred = f.IsStarred
+	}
+	if filter.HasAttachment == nil {
+		filter.HasAttachment = f.HasAttachment
+	}
+	if filter.FromEmail == nil {
+		filter.FromEmail = f.FromEmail
+	}
+	if filter.FromDomain == nil {
+		filter.FromDomain = f.FromDomain
+	}
+	if filter.DateFrom == nil {
+		filter.DateFrom = f.DateFrom
+	}
+	if filter.DateTo == nil {
+		filter.DateTo = f.DateTo
+	}
+	if filter.Search == nil {
+		filter.Search = f.Search
+	}
+}
+
+// Parse interprets query relative to now (now의 시간대로 날짜를 계산한다).
+func (p *Parser) Parse(ctx context.Context, query string, now time.Time) *Interpretation {
+	interp := &Interpretation{Query: query, Source: SourceRules}
+
+	text := " " + strings.ToLower(strings.TrimSpace(query)) + " "
+	for _, r := range rules {
+		for {
+			loc := r.pattern.FindStringSubmatchIndex(text)
+			if loc == nil {
+				break
+			}
+			match := submatches(text, loc)
+			if r.apply(&interp.Filter, match, now) {
+				interp.Matched = append(interp.Matched, strings.TrimSpace(match[0]))
+			}
+			text = text[:loc[0]] + " " + text[loc[1]:]
+		}
+	}
+	interp.Unparsed = remainder(text)
+
+	if interp.Unparsed == "" {
+		return interp
+	}
+	if p != nil && p.llmClient != nil && p.applyLLM(ctx, interp, now) {
+		interp.Source = SourceRulesLLM
+		return interp
+	}
+	search := interp.Unparsed
+	interp.Filter.Search = &search
+	return interp
+}
+
+// applyLLM fills the fields the rules left empty. 실패하면 false (규칙 결과만 쓴다).
+func (p *Parser) applyLLM(ctx context.Context, interp *Interpretation, now time.Time) bool {
+	ctx, cancel := context.WithTimeout(ctx, llmTimeout)
+	defer cancel()
+
+	intent, err := p.llmClient.ParseMailFilter(ctx, interp.Query, now, categoryNames(), subCategoryNames())
+	if err != nil {
+		logger.WithError(err).Warn("[nlfilter.Parser] LLM interpretation failed, using rules only")
+		return false
+	}
+
+	f := &interp.Filter
+	if f.Folder == nil && intent.Folder != "" {
+		if folder, ok := folders[strings.ToLower(intent.Folder)]; ok {
+			f.Folder = &folder
+		}
+	}
+	if f.Category == nil && isCategory(intent.Category) {
+		category := domain.EmailCategory(intent.Category)
+		f.Category = &category
+	}
+	if f.SubCategory == nil && isSubCategory(intent.SubCategory) {
+		sub := domain.EmailSubCategory(intent.SubCategory)
+		f.SubCategory = &sub
+	}
+	if f.MinPriority == nil {
+		switch intent.Priority {
+		case "urgent":
+			f.MinPriority = priority(domain.PriorityUrgent)
+		case "high":
+			f.MinPriority = priority(domain.PriorityHigh)
+		}
+	}
+	if f.IsRead == nil {
+		f.IsRead = intent.IsRead
+	}
+	if f.IsStarred == nil {
+		f.IsStarred = intent.IsStarred
+	}
+	if f.HasAttachment == nil {
+		f.HasAttachment = intent.HasAttachment
+	}
+	if f.FromEmail == nil && f.FromDomain == nil && intent.From != "" {
+		setSender(f, strings.ToLower(intent.From))
+	}
+	if f.DateFrom == nil {
+		if t, err := time.ParseInLocation("2006-01-02", intent.DateFrom, now.Location()); err == nil {
+			f.DateFrom = &t
+		}
+	}
+	if f.DateTo == nil {
+		if t, err := time.ParseInLocation("2006-01-02", intent.DateTo, now.Location()); err == nil {
+			end := t.AddDate(0, 0, 1).Add(-time.Second)
+			f.DateTo = &end
+		}
+	}
+	if search := strings.TrimSpace(intent.Search); search != "" {
+		f.Search = &search
+	}
+	return true
+}
+
+// setSender sets from_domain for a bare domain, otherwise a (부분 일치) from_email.
+func setSender(f *Filter, from string) {
+	from = strings.Trim(from, " \"'")
+	if domainName := strings.TrimPrefix(from, "@"); !strings.Contains(domainName, "@") && strings.Contains(domainName, ".") {
+		f.FromDomain = &domainName
+		return
+	}
+	f.FromEmail = &from
+}
+
+func submatches(text string, loc []int) []string {
+	match := make([]string, len(loc)/2)
+	for i := range match {
+		if loc[2*i] >= 0 {
+			match[i] = text[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return match
+}
+
+var punctuation = regexp.MustCompile(`[,.!?;:()]+`)
+
+// remainder drops filler words from what the rules left over.
+func remainder(text string) string {
+	var words []string
+	for _, word := range strings.Fields(punctuation.ReplaceAllString(text, " ")) {
+		if !stopwords[word] {
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+var stopwords = map[string]bool{
+	"show": true, "find": true, "get": true, "list": true, "me": true, "my": true, "all": true, "any": true,
+	"the": true, "a": true, "an": true, "and": true, "with": true, "that": true, "which": true, "are": true,
+	"is": true, "i": true, "got": true, "received": true, "emails": true, "email": true, "mails": true,
+	"mail": true, "messages": true, "message": true, "please": true, "in": true, "from": true,
+	"메일": true, "이메일": true, "메일들": true, "모든": true, "전부": true, "보여줘": true, "찾아줘": true,
+	"받은": true, "온": true, "있는": true, "중": true, "중에서": true, "것": true,
+}
+
+func boolPtr(v bool) *bool { return &v }
+
+func priority(p domain.Priority) *domain.Priority { return &p }
@@ -0,0 +1,286 @@
+package nlfilter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+)
+
+// rule matches one expression. apply가 false면 이미 채워진 필드라 무시한 것이다.
+type rule struct {
+	pattern *regexp.Regexp
+	apply   func(f *Filter, m []string, now time.Time) bool
+}
+
+// rules run in order on the lowercased query; 맞은 부분은 지우고 다음 규칙으로 넘어간다.
+// 한국어는 \b가 동작하지 않아 경계 없이 맞춘다.
+var rules = []rule{
+	// 읽음 상태 ("unread"를 "read"보다 먼저)
+	{regexp.MustCompile(`\b(unread|not read)\b|안 ?읽은|읽지 ?않은`), setBool(func(f *Filter) **bool { return &f.IsRead }, false)},
+	{regexp.MustCompile(`\bread\b|읽은`), setBool(func(f *Filter) **bool { return &f.IsRead }, true)},
+
+	// 별표
+	{regexp.MustCompile(`\b(unstarred|not starred)\b`), setBool(func(f *Filter) **bool { return &f.IsStarred }, false)},
+	{regexp.MustCompile(`\b(starred|flagged)\b|별표( 표시된| 친)?`), setBool(func(f *Filter) **bool { return &f.IsStarred }, true)},
+
+	// 첨부파일
+	{regexp.MustCompile(`\b(without|no) (an? )?attachments?\b|첨부(파일)? ?없는`), setBool(func(f *Filter) **bool { return &f.HasAttachment }, false)},
+	{regexp.MustCompile(`\b((with|has|having) (an? )?)?attachments?\b|첨부(파일)?( 있는| 포함된?)?`), setBool(func(f *Filter) **bool { return &f.HasAttachment }, true)},
+
+	// 중요도
+	{regexp.MustCompile(`\burgent\b|긴급한?`), setPriority(domain.PriorityUrgent)},
+	{regexp.MustCompile(`\b(important|high priority)\b|중요한?`), setPriority(domain.PriorityHigh)},
+
+	// 폴더
+	{regexp.MustCompile(`\b(in (the )?)?(inbox|sent|drafts|trash|spam|archived?)( folder)?\b`), setFolder(3)},
+	{regexp.MustCompile(`(받은 ?편지함|보낸 ?편지함|보낸 ?메일|임시 ?보관함|휴지통|스팸|보관함)`), setFolder(1)},
+
+	// 날짜 (from last month처럼 앞의 전치사까지 지운다)
+	{regexp.MustCompile(`\b((from|in|during|over) )?(the )?(last|past) (\d+) (days?|weeks?|months?)\b`), lastN(5, 6)},
+	{regexp.MustCompile(`최근 ?(\d+) ?(일|주|개월)`), lastN(1, 2)},
+	{regexp.MustCompile(`\b((from|since) )?today\b|오늘`), dateRange(func(now time.Time) (time.Time, time.Time) {
+		return startOfDay(now), time.Time{}
+	})},
+	{regexp.MustCompile(`\b(from )?yesterday\b|어제`), dateRange(func(now time.Time) (time.Time, time.Time) {
+		today := startOfDay(now)
+		return today.AddDate(0, 0, -1), today.Add(-time.Second)
+	})},
+	{regexp.MustCompile(`\b((from|since|in) )?this week\b|이번 ?주`), dateRange(func(now time.Time) (time.Time, time.Time) {
+		return startOfWeek(now), time.Time{}
+	})},
+	{regexp.MustCompile(`\b((from|in|during) )?last week\b|지난 ?주`), dateRange(func(now time.Time) (time.Time, time.Time) {
+		week := startOfWeek(now)
+		return week.AddDate(0, 0, -7), week.Add(-time.Second)
+	})},
+	{regexp.MustCompile(`\b((from|since|in) )?this month\b|이번 ?달`), dateRange(func(now time.Time) (time.Time, time.Time) {
+		return startOfMonth(now), time.Time{}
+	})},
+	{regexp.MustCompile(`\b((from|in|during) )?last month\b|지난 ?달`), dateRange(func(now time.Time) (time.Time, time.Time) {
+		month := startOfMonth(now)
+		return month.AddDate(0, -1, 0), month.Add(-time.Second)
+	})},
+	{regexp.MustCompile(`\b((from|since|in) )?this year\b|올해`), dateRange(func(now time.Time) (time.Time, time.Time) {
+		return time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location()), time.Time{}
+	})},
+	{regexp.MustCompile(`\b((from|in|during) )?last year\b|작년`), dateRange(func(now time.Time) (time.Time, time.Time) {
+		year := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+		return year.AddDate(-1, 0, 0), year.Add(-time.Second)
+	})},
+	{regexp.MustCompile(`\b(since|after) (\d{4}-\d{2}-\d{2})\b`), absoluteDate(2, false)},
+	{regexp.MustCompile(`\b(before|until) (\d{4}-\d{2}-\d{2})\b`), absoluteDate(2, true)},
+
+	// 보낸 사람 (날짜 규칙이 from last month 등을 먼저 지운 뒤)
+	{regexp.MustCompile(`\bfrom ([^\s@]+@[^\s]+|@?[a-z0-9-]+(\.[a-z0-9-]+)+|[a-z][\w.'-]*)`), setFrom(1)},
+	{regexp.MustCompile(`(\S+?)(에게서|한테서|로부터|이 보낸|가 보낸)`), setFrom(1)},
+}
+
+// kinds maps mail-kind nouns to a category or sub-category.
+var kinds = []struct {
+	pattern     *regexp.Regexp
+	category    domain.EmailCategory
+	subCategory domain.EmailSubCategory
+}{
+	{regexp.MustCompile(`\binvoices?\b|청구서|인보이스`), "", domain.SubCategoryInvoice},
+	{regexp.MustCompile(`\breceipts?\b|영수증`), "", domain.SubCategoryReceipt},
+	{regexp.MustCompile(`\brefunds?\b|환불`), "", domain.SubCategoryRefund},
+	{regexp.MustCompile(`\bpayments?\b|결제`), "", domain.SubCategoryPayment},
+	{regexp.MustCompile(`\bstatements?\b|명세서`), "", domain.SubCategoryStatement},
+	{regexp.MustCompile(`\borders?\b|주문`), "", domain.SubCategoryOrder},
+	{regexp.MustCompile(`\b(shipping|deliver(y|ies))\b|배송`), "", domain.SubCategoryShipping},
+	{regexp.MustCompile(`\bflights?\b|항공권?`), "", domain.SubCategoryFlight},
+	{regexp.MustCompile(`\b(hotels?|reservations?)\b|호텔|예약`), "", domain.SubCategoryHotel},
+	{regexp.MustCompile(`\b(meetings?|(calendar )?invites?)\b|회의|미팅`), "", domain.SubCategoryMeeting},
+	{regexp.MustCompile(`\b(code reviews?|pull requests?|prs)\b|코드 ?리뷰`), "", domain.SubCategoryCodeReview},
+	{regexp.MustCompile(`\balerts?\b|경보`), "", domain.SubCategoryAlert},
+	{regexp.MustCompile(`\bnewsletters?\b|뉴스레터`), domain.CategoryNewsletter, ""},
+	{regexp.MustCompile(`\b(promotions?|promotional|marketing|ads)\b|광고|프로모션`), domain.CategoryMarketing, ""},
+	{regexp.MustCompile(`\bnotifications?\b|알림`), domain.CategoryNotification, ""},
+	{regexp.MustCompile(`\bsocial\b|소셜`), domain.CategorySocial, ""},
+	{regexp.MustCompile(`\bsecurity\b|보안`), domain.CategorySecurity, ""},
+	{regexp.MustCompile(`\bwork\b|업무`), domain.CategoryWork, ""},
+	{regexp.MustCompile(`\bpersonal\b|개인`), domain.CategoryPersonal, ""},
+}
+
+func init() {
+	for _, k := range kinds {
+		rules = append(rules, rule{pattern: k.pattern, apply: setKind(k.category, k.subCategory)})
+	}
+}
+
+// folders maps folder words to legacy folders.
+var folders = map[string]domain.LegacyFolder{
+	"inbox": domain.LegacyFolderInbox, "받은편지함": domain.LegacyFolderInbox,
+	"sent": "sent", "보낸편지함": "sent", "보낸메일": "sent",
+	"drafts": "drafts", "임시보관함": "drafts",
+	"trash": "trash", "휴지통": "trash",
+	"spam": "spam", "스팸": "spam",
+	"archive": "archive", "archived": "archive", "보관함": "archive",
+}
+
+func setBool(field func(f *Filter) **bool, value bool) func(*Filter, []string, time.Time) bool {
+	return func(f *Filter, _ []string, _ time.Time) bool {
+		if *field(f) != nil {
+			return false
+		}
+		*field(f) = boolPtr(value)
+		return true
+	}
+}
+
+func setPriority(p domain.Priority) func(*Filter, []string, time.Time) bool {
+	return func(f *Filter, _ []string, _ time.Time) bool {
+		if f.MinPriority != nil {
+			return false
+		}
+		f.MinPriority = priority(p)
+		return true
+	}
+}
+
+func setFolder(group int) func(*Filter, []string, time.Time) bool {
+	return func(f *Filter, m []string, _ time.Time) bool {
+		folder, ok := folders[strings.ReplaceAll(m[group], " ", "")]
+		if !ok || f.Folder != nil {
+			return false
+		}
+		f.Folder = &folder
+		return true
+	}
+}
+
+func setFrom(group int) func(*Filter, []string, time.Time) bool {
+	return func(f *Filter, m []string, _ time.Time) bool {
+		if f.FromEmail != nil || f.FromDomain != nil {
+			return false
+		}
+		setSender(f, m[group])
+		return true
+	}
+}
+
+func setKind(category domain.EmailCategory, sub domain.EmailSubCategory) func(*Filter, []string, time.Time) bool {
+	return func(f *Filter, _ []string, _ time.Time) bool {
+		if sub != "" && f.SubCategory == nil {
+			f.SubCategory = &sub
+			return true
+		}
+		if category != "" && f.Category == nil {
+			f.Category = &category
+			return true
+		}
+		return false
+	}
+}
+
+func dateRange(span func(now time.Time) (time.Time, time.Time)) func(*Filter, []string, time.Time) bool {
+	return func(f *Filter, _ []string, now time.Time) bool {
+		if f.DateFrom != nil || f.DateTo != nil {
+			return false
+		}
+		from, to := span(now)
+		f.DateFrom = &from
+		if !to.IsZero() {
+			f.DateTo = &to
+		}
+		return true
+	}
+}
+
+// lastN handles "last 7 days" / "최근 3개월" (오늘을 포함해 N 단위).
+func lastN(countGroup, unitGroup int) func(*Filter, []string, time.Time) bool {
+	return func(f *Filter, m []string, now time.Time) bool {
+		n, err := strconv.Atoi(m[countGroup])
+		if err != nil || n <= 0 || f.DateFrom != nil {
+			return false
+		}
+		today := startOfDay(now)
+		var from time.Time
+		switch unit := m[unitGroup]; {
+		case strings.HasPrefix(unit, "day"), unit == "일":
+			from = today.AddDate(0, 0, -(n - 1))
+		case strings.HasPrefix(unit, "week"), unit == "주":
+			from = today.AddDate(0, 0, -7*n)
+		default:
+			from = today.AddDate(0, -n, 0)
+		}
+		f.DateFrom = &from
+		return true
+	}
+}
+
+func absoluteDate(group int, end bool) func(*Filter, []string, time.Time) bool {
+	return func(f *Filter, m []string, now time.Time) bool {
+		t, err := time.ParseInLocation("2006-01-02", m[group], now.Location())
+		if err != nil {
+			return false
+		}
+		if end {
+			if f.DateTo != nil {
+				return false
+			}
+			t = t.Add(-time.Second) // before: 그 날짜 이전
+			f.DateTo = &t
+			return true
+		}
+		if f.DateFrom != nil {
+			return false
+		}
+		f.DateFrom = &t
+		return true
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns Monday 00:00 of t's week.
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+func categoryNames() []string {
+	names := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		if k.category != "" {
+			names = append(names, string(k.category))
+		}
+	}
+	return names
+}
+
+func subCategoryNames() []string {
+	names := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		if k.subCategory != "" {
+			names = append(names, string(k.subCategory))
+		}
+	}
+	return names
+}
+
+func isCategory(name string) bool {
+	for _, k := range kinds {
+		if name != "" && string(k.category) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isSubCategory(name string) bool {
+	for _, k := range kinds {
+		if name != "" && string(k.subCategory) == name {
+			return true
+		}
+	}
+	return false
+}
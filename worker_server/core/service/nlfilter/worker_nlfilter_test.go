@@ -0,0 +1,123 @@
+package nlfilter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+)
+
+// 2026-10-16 (금요일)
+var now = time.Date(2026, 10, 16, 15, 4, 0, 0, time.UTC)
+
+func date(y int, m time.Month, d, h, min, s int) time.Time {
+	return time.Date(y, m, d, h, min, s, 0, time.UTC)
+}
+
+func TestParseRules(t *testing.T) {
+	interp := NewParser(nil).Parse(context.Background(), "unread invoices from last month with attachments", now)
+	f := interp.Filter
+
+	if f.IsRead == nil || *f.IsRead {
+		t.Errorf("IsRead = %v, want false", f.IsRead)
+	}
+	if f.HasAttachment == nil || !*f.HasAttachment {
+		t.Errorf("HasAttachment = %v, want true", f.HasAttachment)
+	}
+	if f.SubCategory == nil || *f.SubCategory != domain.SubCategoryInvoice {
+		t.Errorf("SubCategory = %v, want invoice", f.SubCategory)
+	}
+	if f.DateFrom == nil || !f.DateFrom.Equal(date(2026, 9, 1, 0, 0, 0)) {
+		t.Errorf("DateFrom = %v", f.DateFrom)
+	}
+	if f.DateTo == nil || !f.DateTo.Equal(date(2026, 9, 30, 23, 59, 59)) {
+		t.Errorf("DateTo = %v", f.DateTo)
+	}
+	if f.FromEmail != nil || f.Search != nil || interp.Unparsed != "" {
+		t.Errorf("FromEmail/Search/Unparsed = %v/%v/%q", f.FromEmail, f.Search, interp.Unparsed)
+	}
+	if interp.Source != SourceRules {
+		t.Errorf("Source = %q", interp.Source)
+	}
+}
+
+func TestParseSenderAndSearch(t *testing.T) {
+	tests := []struct {
+		query      string
+		wantEmail  string
+		wantDomain string
+		wantSearch string
+	}{
+		{"emails from boss@acme.com about budget", "boss@acme.com", "", "about budget"},
+		{"from github.com this week", "", "github.com", ""},
+		{"starred from john quarterly report", "john", "", "quarterly report"},
+		{"kim@acme.com에게서 온 메일", "kim@acme.com", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			f := NewParser(nil).Parse(context.Background(), tt.query, now).Filter
+			if got := deref(f.FromEmail); got != tt.wantEmail {
+				t.Errorf("FromEmail = %q, want %q", got, tt.wantEmail)
+			}
+			if got := deref(f.FromDomain); got != tt.wantDomain {
+				t.Errorf("FromDomain = %q, want %q", got, tt.wantDomain)
+			}
+			if got := deref(f.Search); got != tt.wantSearch {
+				t.Errorf("Search = %q, want %q", got, tt.wantSearch)
+			}
+		})
+	}
+}
+
+func TestParseKorean(t *testing.T) {
+	f := NewParser(nil).Parse(context.Background(), "지난주 받은 안 읽은 중요한 청구서", now).Filter
+
+	if f.IsRead == nil || *f.IsRead {
+		t.Errorf("IsRead = %v, want false", f.IsRead)
+	}
+	if f.MinPriority == nil || *f.MinPriority != domain.PriorityHigh {
+		t.Errorf("MinPriority = %v, want high", f.MinPriority)
+	}
+	if f.SubCategory == nil || *f.SubCategory != domain.SubCategoryInvoice {
+		t.Errorf("SubCategory = %v, want invoice", f.SubCategory)
+	}
+	// 2026-10-16 금요일 → 지난주는 10/5(월) ~ 10/11(일)
+	if f.DateFrom == nil || !f.DateFrom.Equal(date(2026, 10, 5, 0, 0, 0)) || !f.DateTo.Equal(date(2026, 10, 11, 23, 59, 59)) {
+		t.Errorf("DateFrom/DateTo = %v/%v", f.DateFrom, f.DateTo)
+	}
+	if f.Search != nil {
+		t.Errorf("Search = %q, want nil", *f.Search)
+	}
+}
+
+func TestParseLastNDaysAndFolder(t *testing.T) {
+	f := NewParser(nil).Parse(context.Background(), "sent mails in the last 7 days", now).Filter
+
+	if f.Folder == nil || *f.Folder != "sent" {
+		t.Errorf("Folder = %v, want sent", f.Folder)
+	}
+	if f.DateFrom == nil || !f.DateFrom.Equal(date(2026, 10, 10, 0, 0, 0)) || f.DateTo != nil {
+		t.Errorf("DateFrom/DateTo = %v/%v", f.DateFrom, f.DateTo)
+	}
+}
+
+func TestApplyKeepsExplicitParams(t *testing.T) {
+	read := true
+	filter := &domain.EmailFilter{IsRead: &read}
+	NewParser(nil).Parse(context.Background(), "unread newsletters", now).Filter.Apply(filter)
+
+	if !*filter.IsRead {
+		t.Error("explicit is_read was overwritten")
+	}
+	if filter.Category == nil || *filter.Category != domain.CategoryNewsletter {
+		t.Errorf("Category = %v, want newsletter", filter.Category)
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,241 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// pushTimeout - 새 메일 한 건의 푸시 발송 제한 시간 (동기화를 오래 막지 않도록)
+const pushTimeout = 5 * time.Second
+
+var (
+	ErrPushNotConfigured   = errors.New("push notifications are not configured")
+	ErrUnsupportedPlatform = errors.New("unsupported push platform")
+	ErrInvalidSettings     = errors.New("invalid notification settings")
+)
+
+var hhmmPattern = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$`)
+
+// SetSettingsRepository enables per-user notification settings.
+func (s *Service) SetSettingsRepository(repo domain.NotificationSettingsRepository) {
+	s.notificationSettingsRepo = repo
+}
+
+// SetPush enables mobile push through the given senders (FCM, APNs).
+func (s *Service) SetPush(devices out.PushDeviceRepository, senders ...out.MobilePushPort) {
+	s.pushDevices = devices
+	s.pushSenders = make(map[domain.PushPlatform]out.MobilePushPort, len(senders))
+	for _, sender := range senders {
+		s.pushSenders[sender.Platform()] = sender
+	}
+}
+
+// GetSettings returns the user's notification settings (저장된 값이 없으면 기본값).
+func (s *Service) GetSettings(ctx context.Context, userID uuid.UUID) (*domain.NotificationSettings, error) {
+	if s.notificationSettingsRepo == nil {
+		return domain.DefaultNotificationSettings(userID), nil
+	}
+	return s.notificationSettingsRepo.Get(ctx, userID)
+}
+
+// UpdateSettings validates and saves the user's notification settings.
+func (s *Service) UpdateSettings(ctx context.Context, settings *domain.NotificationSettings) (*domain.NotificationSettings, error) {
+	if s.notificationSettingsRepo == nil {
+		return nil, ErrPushNotConfigured
+	}
+	if err := validateSettings(settings); err != nil {
+		return nil, err
+	}
+	if err := s.notificationSettingsRepo.Update(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func validateSettings(settings *domain.NotificationSettings) error {
+	if settings.MinPriorityForNotification < 1 || settings.MinPriorityForNotification > 4 {
+		return errors.Join(ErrInvalidSettings, errors.New("min_priority_for_notification must be between 1 and 4"))
+	}
+	if !hhmmPattern.MatchString(settings.QuietHoursStart) || !hhmmPattern.MatchString(settings.QuietHoursEnd) {
+		return errors.Join(ErrInvalidSettings, errors.New("quiet hours must be HH:MM"))
+	}
+	if _, err := time.LoadLocation(settings.QuietHoursTimezone); err != nil || settings.QuietHoursTimezone == "" {
+		return errors.Join(ErrInvalidSettings, errors.New("invalid quiet_hours_timezone"))
+	}
+	if settings.ReminderMinutesBefore < 0 {
+		return errors.Join(ErrInvalidSettings, errors.New("reminder_minutes_before must not be negative"))
+	}
+
+	// 발신자 비교는 소문자 기준
+	for _, list := range [][]string{settings.VIPSenders, settings.MutedSenders, settings.PushCategories} {
+		for i := range list {
+			list[i] = strings.ToLower(strings.TrimSpace(list[i]))
+		}
+	}
+	return nil
+}
+
+// RegisterDevice registers (or refreshes) a push device token of the user.
+func (s *Service) RegisterDevice(ctx context.Context, device *domain.PushDevice) error {
+	if s.pushDevices == nil {
+		return ErrPushNotConfigured
+	}
+	if _, ok := s.pushSenders[device.Platform]; !ok {
+		return ErrUnsupportedPlatform
+	}
+	return s.pushDevices.Register(ctx, device)
+}
+
+// ListDevices returns the user's push devices.
+func (s *Service) ListDevices(ctx context.Context, userID uuid.UUID) ([]*domain.PushDevice, error) {
+	if s.pushDevices == nil {
+		return []*domain.PushDevice{}, nil
+	}
+	return s.pushDevices.ListByUser(ctx, userID)
+}
+
+// UnregisterDevice removes one of the user's push devices.
+func (s *Service) UnregisterDevice(ctx context.Context, userID uuid.UUID, token string) error {
+	if s.pushDevices == nil {
+		return ErrPushNotConfigured
+	}
+	return s.pushDevices.Delete(ctx, userID, token)
+}
+
+// NotifyNewEmail sends a mobile push for a newly synced email if the user's settings allow it.
+// 동기화 시점 RFC 분류가 없으면 개인 메일로 보고 primary/normal로 판단한다 (자동 발송 메일은 RFC 분류에서 걸러진다).
+func (s *Service) NotifyNewEmail(ctx context.Context, email *domain.Email) {
+	if s.pushDevices == nil || len(s.pushSenders) == 0 {
+		return
+	}
+	if email.IsRead || email.Folder != domain.LegacyFolderInbox {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pushTimeout)
+	defer cancel()
+
+	settings, err := s.GetSettings(ctx, email.UserID)
+	if err != nil {
+		logger.WithError(err).Warn("[Notification.Push] Failed to load settings of %s", email.UserID)
+		return
+	}
+
+	category := string(domain.CategoryPrimary)
+	if email.AICategory != nil {
+		category = string(*email.AICategory)
+	}
+	priority := domain.PriorityNormal
+	if email.AIPriority != nil {
+		priority = *email.AIPriority
+	}
+	if !settings.ShouldPushEmail(category, priority, strings.ToLower(email.FromEmail)) {
+		return
+	}
+
+	title := emailPushTitle(email)
+	notifPriority := domain.EmailNotificationPriority(priority)
+
+	// 방해 금지 시간이면 보류했다가 끝난 뒤 요약으로 보낸다
+	held := &domain.Notification{
+		UserID:     email.UserID,
+		Type:       domain.NotificationTypeEmail,
+		Title:      title,
+		Body:       email.Subject,
+		EntityType: "email",
+		EntityID:   email.ID,
+		Priority:   notifPriority,
+	}
+	if s.holdIfQuiet(ctx, settings, held, strings.ToLower(email.FromEmail)) {
+		return
+	}
+
+	s.pushToUser(ctx, email.UserID, emailPushMessage(email, domain.EventNewEmail, notifPriority))
+}
+
+// NotifyVIPEmail pushes mail from a VIP contact immediately.
+// 방해 금지 시간과 카테고리/우선순위 필터를 건너뛰고, 푸시 끄기와 뮤트된 발신자만 따른다.
+func (s *Service) NotifyVIPEmail(ctx context.Context, email *domain.Email) {
+	if s.pushDevices == nil || len(s.pushSenders) == 0 {
+		return
+	}
+	if email.IsRead || email.Folder != domain.LegacyFolderInbox {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pushTimeout)
+	defer cancel()
+
+	settings, err := s.GetSettings(ctx, email.UserID)
+	if err != nil {
+		logger.WithError(err).Warn("[Notification.Push] Failed to load settings of %s", email.UserID)
+		return
+	}
+	if !settings.ShouldPushVIPEmail(strings.ToLower(email.FromEmail)) {
+		return
+	}
+
+	s.pushToUser(ctx, email.UserID, emailPushMessage(email, domain.EventEmailVIP, domain.NotificationPriorityUrgent))
+}
+
+// emailPushTitle returns the sender name, or the address if it has none.
+func emailPushTitle(email *domain.Email) string {
+	if email.FromName != nil && *email.FromName != "" {
+		return *email.FromName
+	}
+	return email.FromEmail
+}
+
+func emailPushMessage(email *domain.Email, eventType domain.EventType, priority domain.NotificationPriority) *domain.PushMessage {
+	return &domain.PushMessage{
+		Title: emailPushTitle(email),
+		Body:  email.Subject,
+		Data: map[string]string{
+			"type":          string(eventType),
+			"email_id":      strconv.FormatInt(email.ID, 10),
+			"connection_id": strconv.FormatInt(email.ConnectionID, 10),
+		},
+		CollapseKey: email.ThreadID,
+		Priority:    priority,
+	}
+}
+
+// pushToUser sends msg to every registered device of the user, removing rejected tokens.
+func (s *Service) pushToUser(ctx context.Context, userID uuid.UUID, msg *domain.PushMessage) {
+	if s.pushDevices == nil || len(s.pushSenders) == 0 {
+		return
+	}
+
+	devices, err := s.pushDevices.ListByUser(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Warn("[Notification.Push] Failed to list devices of %s", userID)
+		return
+	}
+
+	for _, device := range devices {
+		sender, ok := s.pushSenders[device.Platform]
+		if !ok {
+			continue
+		}
+		err := sender.Send(ctx, device.Token, msg)
+		if errors.Is(err, out.ErrPushTokenInvalid) {
+			if err := s.pushDevices.DeleteByToken(ctx, device.Token); err != nil {
+				logger.WithError(err).Warn("[Notification.Push] Failed to remove invalid token")
+			}
+			continue
+		}
+		if err != nil {
+			logger.WithError(err).Warn("[Notification.Push] Failed to push to %s device %d", device.Platform, device.ID)
+		}
+	}
+}
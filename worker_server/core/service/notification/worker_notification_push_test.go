@@ -0,0 +1,151 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+type memSettingsRepo struct {
+	domain.NotificationSettingsRepository
+	settings *domain.NotificationSettings
+}
+
+func (r *memSettingsRepo) Get(context.Context, uuid.UUID) (*domain.NotificationSettings, error) {
+	return r.settings, nil
+}
+
+type memDeviceRepo struct {
+	devices []*domain.PushDevice
+	deleted []string
+}
+
+func (r *memDeviceRepo) Register(_ context.Context, d *domain.PushDevice) error {
+	r.devices = append(r.devices, d)
+	return nil
+}
+
+func (r *memDeviceRepo) ListByUser(context.Context, uuid.UUID) ([]*domain.PushDevice, error) {
+	return r.devices, nil
+}
+
+func (r *memDeviceRepo) Delete(context.Context, uuid.UUID, string) error { return nil }
+
+func (r *memDeviceRepo) DeleteByToken(_ context.Context, token string) error {
+	r.deleted = append(r.deleted, token)
+	return nil
+}
+
+type fakePushSender struct {
+	platform domain.PushPlatform
+	invalid  map[string]bool
+	sent     []*domain.PushMessage
+}
+
+func (f *fakePushSender) Platform() domain.PushPlatform { return f.platform }
+
+func (f *fakePushSender) Send(_ context.Context, token string, msg *domain.PushMessage) error {
+	if f.invalid[token] {
+		return out.ErrPushTokenInvalid
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func newPushTestService(settings *domain.NotificationSettings, devices *memDeviceRepo, senders ...out.MobilePushPort) *Service {
+	svc := NewService(nil, nil)
+	svc.SetSettingsRepository(&memSettingsRepo{settings: settings})
+	svc.SetPush(devices, senders...)
+	return svc
+}
+
+func TestNotifyNewEmailFollowsSettings(t *testing.T) {
+	userID := uuid.New()
+	settings := domain.DefaultNotificationSettings(userID)
+	settings.PushCategories = []string{"primary", "work"}
+	settings.MinPriorityForNotification = 2 // high 이상
+	settings.VIPSenders = []string{"ceo@example.com"}
+
+	fcm := &fakePushSender{platform: domain.PushPlatformFCM}
+	devices := &memDeviceRepo{devices: []*domain.PushDevice{{ID: 1, Platform: domain.PushPlatformFCM, Token: "t1"}}}
+	svc := newPushTestService(settings, devices, fcm)
+
+	newsletter, high, normal := domain.CategoryNewsletter, domain.PriorityHigh, domain.PriorityNormal
+	cases := []struct {
+		name  string
+		email *domain.Email
+		push  bool
+	}{
+		{"unclassified mail is primary/normal", &domain.Email{FromEmail: "a@example.com"}, false},
+		{"high priority work mail", &domain.Email{FromEmail: "a@example.com", AIPriority: &high}, true},
+		{"category not selected", &domain.Email{FromEmail: "a@example.com", AICategory: &newsletter, AIPriority: &high}, false},
+		{"vip ignores filters", &domain.Email{FromEmail: "CEO@example.com", AICategory: &newsletter, AIPriority: &normal}, true},
+		{"read mail", &domain.Email{FromEmail: "ceo@example.com", IsRead: true}, false},
+		{"sent mail", &domain.Email{FromEmail: "ceo@example.com", Folder: domain.LegacyFolderSent}, false},
+	}
+	for _, tc := range cases {
+		before := len(fcm.sent)
+		tc.email.UserID = userID
+		if tc.email.Folder == "" {
+			tc.email.Folder = domain.LegacyFolderInbox
+		}
+		svc.NotifyNewEmail(context.Background(), tc.email)
+		if pushed := len(fcm.sent) > before; pushed != tc.push {
+			t.Errorf("%s: pushed=%v, want %v", tc.name, pushed, tc.push)
+		}
+	}
+}
+
+func TestNotifyNewEmailRemovesInvalidTokens(t *testing.T) {
+	userID := uuid.New()
+	fcm := &fakePushSender{platform: domain.PushPlatformFCM, invalid: map[string]bool{"stale": true}}
+	devices := &memDeviceRepo{devices: []*domain.PushDevice{
+		{ID: 1, Platform: domain.PushPlatformFCM, Token: "stale"},
+		{ID: 2, Platform: domain.PushPlatformFCM, Token: "fresh"},
+		{ID: 3, Platform: domain.PushPlatformAPNs, Token: "ios"}, // APNs 미설정 - 건너뛴다
+	}}
+	svc := newPushTestService(domain.DefaultNotificationSettings(userID), devices, fcm)
+
+	svc.NotifyNewEmail(context.Background(), &domain.Email{ID: 7, UserID: userID, Folder: domain.LegacyFolderInbox, FromEmail: "a@example.com", Subject: "Hi"})
+
+	if len(fcm.sent) != 1 || fcm.sent[0].Data["email_id"] != "7" {
+		t.Errorf("unexpected pushes: %+v", fcm.sent)
+	}
+	if len(devices.deleted) != 1 || devices.deleted[0] != "stale" {
+		t.Errorf("invalid token should be removed, got %v", devices.deleted)
+	}
+	if err := svc.RegisterDevice(context.Background(), &domain.PushDevice{Platform: domain.PushPlatformAPNs, Token: "x"}); err != ErrUnsupportedPlatform {
+		t.Errorf("registering an unconfigured platform should fail, got %v", err)
+	}
+}
+
+func TestNotifyVIPEmailBypassesQuietHoursAndFilters(t *testing.T) {
+	userID := uuid.New()
+	settings := domain.DefaultNotificationSettings(userID)
+	settings.QuietHoursEnabled = true
+	settings.QuietHoursStart = "00:00"
+	settings.QuietHoursEnd = "23:59"
+	settings.NotifyImportantOnly = true
+	settings.PushCategories = []string{"work"}
+	settings.MutedSenders = []string{"muted@example.com"}
+
+	fcm := &fakePushSender{platform: domain.PushPlatformFCM}
+	devices := &memDeviceRepo{devices: []*domain.PushDevice{{ID: 1, Platform: domain.PushPlatformFCM, Token: "t1"}}}
+	svc := newPushTestService(settings, devices, fcm)
+
+	svc.NotifyVIPEmail(context.Background(), &domain.Email{ID: 9, UserID: userID, Folder: domain.LegacyFolderInbox, FromEmail: "boss@example.com", Subject: "Now"})
+	if len(fcm.sent) != 1 || fcm.sent[0].Data["type"] != string(domain.EventEmailVIP) || fcm.sent[0].Priority != domain.NotificationPriorityUrgent {
+		t.Fatalf("vip mail should be pushed as urgent, got %+v", fcm.sent)
+	}
+
+	svc.NotifyVIPEmail(context.Background(), &domain.Email{ID: 10, UserID: userID, Folder: domain.LegacyFolderInbox, FromEmail: "Muted@example.com"})
+	settings.PushEnabled = false
+	svc.NotifyVIPEmail(context.Background(), &domain.Email{ID: 11, UserID: userID, Folder: domain.LegacyFolderInbox, FromEmail: "boss@example.com"})
+	if len(fcm.sent) != 1 {
+		t.Errorf("muted senders and disabled push should still be respected, got %d pushes", len(fcm.sent))
+	}
+}
@@ -0,0 +1,160 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// releaseBatchSize - 한 번의 ReleaseHeld에서 처리할 최대 사용자 수
+	releaseBatchSize = 200
+	// summaryPreviewCount - 요약 알림 본문에 보여줄 보류 알림 수
+	summaryPreviewCount = 3
+	// quietSummaryEntityType - 요약 알림의 entity_type (앱에서 알림 목록으로 이동)
+	quietSummaryEntityType = "quiet_hours_summary"
+)
+
+// SetHoldRepository enables holding notifications during quiet hours.
+// 설정하지 않으면 방해 금지 시간의 알림은 예전처럼 실시간 전달 없이 버려진다.
+func (s *Service) SetHoldRepository(repo out.HeldNotificationRepository) {
+	s.holdRepo = repo
+}
+
+// holdIfQuiet holds n until quiet hours end and reports whether live delivery should be skipped.
+func (s *Service) holdIfQuiet(ctx context.Context, settings *domain.NotificationSettings, n *domain.Notification, senderEmail string) bool {
+	now := s.now()
+	if !settings.ShouldHold(n.Priority, senderEmail, now) {
+		return false
+	}
+	if s.holdRepo == nil {
+		return true
+	}
+
+	held := &domain.HeldNotification{
+		UserID:     n.UserID,
+		Type:       n.Type,
+		Title:      n.Title,
+		Body:       n.Body,
+		EntityType: n.EntityType,
+		EntityID:   n.EntityID,
+		Priority:   n.Priority,
+		ReleaseAt:  settings.QuietHoursEndAt(now),
+	}
+	if err := s.holdRepo.Hold(ctx, held); err != nil {
+		logger.WithError(err).Warn("[Notification.Quiet] Failed to hold notification for %s", n.UserID)
+	}
+	return true
+}
+
+// ReleaseHeld sends one summary per user whose quiet hours have ended and returns the number of users notified.
+func (s *Service) ReleaseHeld(ctx context.Context) (int, error) {
+	if s.holdRepo == nil {
+		return 0, nil
+	}
+
+	now := s.now()
+	userIDs, err := s.holdRepo.ListDueUsers(ctx, now, releaseBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, userID := range userIDs {
+		held, err := s.holdRepo.ClaimDue(ctx, userID, now)
+		if err != nil {
+			logger.WithError(err).Warn("[Notification.Quiet] Failed to claim held notifications of %s", userID)
+			continue
+		}
+		if len(held) == 0 {
+			continue // 다른 인스턴스가 먼저 가져갔다
+		}
+
+		summary := summarizeHeld(userID, held)
+		if err := s.Send(ctx, summary); err != nil {
+			logger.WithError(err).Warn("[Notification.Quiet] Failed to save summary of %s", userID)
+		}
+
+		pushCtx, cancel := context.WithTimeout(ctx, pushTimeout)
+		s.pushToUser(pushCtx, userID, &domain.PushMessage{
+			Title: summary.Title,
+			Body:  summary.Body,
+			Data: map[string]string{
+				"type":  quietSummaryEntityType,
+				"count": strconv.Itoa(len(held)),
+			},
+			CollapseKey: quietSummaryEntityType,
+			Priority:    summary.Priority,
+		})
+		cancel()
+		released++
+	}
+	return released, nil
+}
+
+// summarizeHeld builds a single notification covering everything held during quiet hours.
+// 높은 우선순위 알림을 본문 앞쪽에 보여준다.
+func summarizeHeld(userID uuid.UUID, held []*domain.HeldNotification) *domain.Notification {
+	byType := make(map[string]int)
+	var emailIDs []int64
+	priority := domain.NotificationPriorityNormal
+	for _, h := range held {
+		byType[string(h.Type)]++
+		if h.EntityType == "email" && h.EntityID != 0 {
+			emailIDs = append(emailIDs, h.EntityID)
+		}
+		if h.Priority == domain.NotificationPriorityHigh {
+			priority = domain.NotificationPriorityHigh
+		}
+	}
+
+	preview := make([]*domain.HeldNotification, 0, summaryPreviewCount)
+	for _, h := range held {
+		if h.Priority == domain.NotificationPriorityHigh && len(preview) < summaryPreviewCount {
+			preview = append(preview, h)
+		}
+	}
+	for _, h := range held {
+		if h.Priority != domain.NotificationPriorityHigh && len(preview) < summaryPreviewCount {
+			preview = append(preview, h)
+		}
+	}
+
+	lines := make([]string, 0, len(preview)+1)
+	for _, h := range preview {
+		line := h.Title
+		if h.Body != "" {
+			line += ": " + h.Body
+		}
+		lines = append(lines, line)
+	}
+	if rest := len(held) - len(preview); rest > 0 {
+		lines = append(lines, fmt.Sprintf("and %d more", rest))
+	}
+
+	title := fmt.Sprintf("%d notifications during quiet hours", len(held))
+	if len(held) == 1 {
+		title = "1 notification during quiet hours"
+	}
+
+	return &domain.Notification{
+		UserID:     userID,
+		Type:       domain.NotificationTypeSystem,
+		Title:      title,
+		Body:       strings.Join(lines, "\n"),
+		EntityType: quietSummaryEntityType,
+		Priority:   priority,
+		Data: map[string]any{
+			"count":     len(held),
+			"by_type":   byType,
+			"email_ids": emailIDs,
+		},
+	}
+}
@@ -0,0 +1,143 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type memHoldRepo struct {
+	held []*domain.HeldNotification
+}
+
+func (r *memHoldRepo) Hold(_ context.Context, n *domain.HeldNotification) error {
+	r.held = append(r.held, n)
+	return nil
+}
+
+func (r *memHoldRepo) ListDueUsers(_ context.Context, now time.Time, _ int) ([]uuid.UUID, error) {
+	seen := make(map[uuid.UUID]bool)
+	var users []uuid.UUID
+	for _, h := range r.held {
+		if !h.ReleaseAt.After(now) && !seen[h.UserID] {
+			seen[h.UserID] = true
+			users = append(users, h.UserID)
+		}
+	}
+	return users, nil
+}
+
+func (r *memHoldRepo) ClaimDue(_ context.Context, userID uuid.UUID, now time.Time) ([]*domain.HeldNotification, error) {
+	var due, rest []*domain.HeldNotification
+	for _, h := range r.held {
+		if h.UserID == userID && !h.ReleaseAt.After(now) {
+			due = append(due, h)
+		} else {
+			rest = append(rest, h)
+		}
+	}
+	r.held = rest
+	return due, nil
+}
+
+type memNotificationRepo struct {
+	domain.NotificationRepository
+	created []*domain.Notification
+}
+
+func (r *memNotificationRepo) Create(n *domain.Notification) error {
+	r.created = append(r.created, n)
+	return nil
+}
+
+func TestQuietHoursHoldAndReleaseSummary(t *testing.T) {
+	userID := uuid.New()
+	settings := domain.DefaultNotificationSettings(userID)
+	settings.QuietHoursEnabled = true
+	settings.QuietHoursStart = "22:00"
+	settings.QuietHoursEnd = "08:00"
+	settings.QuietHoursTimezone = "Asia/Seoul"
+	settings.VIPSenders = []string{"ceo@example.com"}
+
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	now := time.Date(2026, 3, 2, 23, 30, 0, 0, seoul)
+
+	fcm := &fakePushSender{platform: domain.PushPlatformFCM}
+	devices := &memDeviceRepo{devices: []*domain.PushDevice{{ID: 1, Platform: domain.PushPlatformFCM, Token: "t1"}}}
+	notifications := &memNotificationRepo{}
+	holds := &memHoldRepo{}
+	svc := newPushTestService(settings, devices, fcm)
+	svc.notificationRepo = notifications
+	svc.SetHoldRepository(holds)
+	svc.now = func() time.Time { return now }
+
+	urgent := domain.Priority(0.9)
+	inbox := func(id int64, from string, p *domain.Priority) *domain.Email {
+		return &domain.Email{ID: id, UserID: userID, Folder: domain.LegacyFolderInbox, FromEmail: from, Subject: "s", AIPriority: p}
+	}
+	svc.NotifyNewEmail(context.Background(), inbox(1, "a@example.com", nil))
+	svc.NotifyNewEmail(context.Background(), inbox(2, "b@example.com", nil))
+	svc.NotifyNewEmail(context.Background(), inbox(3, "ceo@example.com", nil))
+	svc.NotifyNewEmail(context.Background(), inbox(4, "c@example.com", &urgent))
+
+	if len(fcm.sent) != 2 {
+		t.Fatalf("only vip and urgent mail should be pushed during quiet hours, got %d", len(fcm.sent))
+	}
+	if len(holds.held) != 2 {
+		t.Fatalf("expected 2 held notifications, got %d", len(holds.held))
+	}
+	wantRelease := time.Date(2026, 3, 3, 8, 0, 0, 0, seoul)
+	if !holds.held[0].ReleaseAt.Equal(wantRelease) {
+		t.Errorf("release at %v, want %v", holds.held[0].ReleaseAt, wantRelease)
+	}
+
+	// 방해 금지 시간이 끝나기 전에는 아무것도 보내지 않는다
+	if n, _ := svc.ReleaseHeld(context.Background()); n != 0 {
+		t.Errorf("released %d users before quiet hours ended", n)
+	}
+
+	now = wantRelease.Add(time.Minute)
+	if n, _ := svc.ReleaseHeld(context.Background()); n != 1 {
+		t.Fatalf("expected one user released, got %d", n)
+	}
+	if len(notifications.created) != 1 || notifications.created[0].Data["count"] != 2 {
+		t.Errorf("expected one summary covering 2 notifications, got %+v", notifications.created)
+	}
+	if len(fcm.sent) != 3 || fcm.sent[2].Data["count"] != "2" {
+		t.Errorf("expected a single summary push, got %+v", fcm.sent)
+	}
+	if n, _ := svc.ReleaseHeld(context.Background()); n != 0 {
+		t.Errorf("held notifications should be released once, got %d", n)
+	}
+}
+
+func TestQuietHoursEndAtCrossesMidnight(t *testing.T) {
+	settings := domain.DefaultNotificationSettings(uuid.New())
+	settings.QuietHoursEnabled = true
+	settings.QuietHoursTimezone = "America/New_York"
+	ny, _ := time.LoadLocation("America/New_York")
+
+	cases := []struct {
+		now   time.Time
+		quiet bool
+		end   time.Time
+	}{
+		{time.Date(2026, 5, 1, 23, 0, 0, 0, ny), true, time.Date(2026, 5, 2, 8, 0, 0, 0, ny)},
+		{time.Date(2026, 5, 2, 6, 0, 0, 0, ny), true, time.Date(2026, 5, 2, 8, 0, 0, 0, ny)},
+		{time.Date(2026, 5, 2, 12, 0, 0, 0, ny), false, time.Time{}},
+	}
+	for _, tc := range cases {
+		// 서버 시간대(UTC)와 관계없이 사용자 시간대로 판단한다
+		now := tc.now.UTC()
+		if got := settings.InQuietHours(now); got != tc.quiet {
+			t.Errorf("%v: quiet=%v, want %v", tc.now, got, tc.quiet)
+		}
+		if tc.quiet && !settings.QuietHoursEndAt(now).Equal(tc.end) {
+			t.Errorf("%v: end=%v, want %v", tc.now, settings.QuietHoursEndAt(now), tc.end)
+		}
+	}
+}
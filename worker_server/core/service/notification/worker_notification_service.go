@@ -2,8 +2,10 @@ package notification
 
 import (
 	"context"
+	"time"
 
 	"worker_server/core/domain"
+	"worker_server/core/port/out"
 
 	"github.com/google/uuid"
 )
@@ -13,6 +15,14 @@ type Service struct {
 	notificationRepo         domain.NotificationRepository
 	notificationSettingsRepo domain.NotificationSettingsRepository // 알림 설정 확인
 	sseHub                   SSEHub                                // Interface for SSE push
+
+	// 모바일 푸시 (SetPush로 설정, nil이면 SSE만 보낸다)
+	pushDevices out.PushDeviceRepository
+	pushSenders map[domain.PushPlatform]out.MobilePushPort
+
+	// 방해 금지 시간 보류 (SetHoldRepository로 설정, nil이면 보류하지 않고 버린다)
+	holdRepo out.HeldNotificationRepository
+	now      func() time.Time
 }
 
 // SSEHub interface for pushing real-time notifications.
@@ -25,6 +35,7 @@ func NewService(notificationRepo domain.NotificationRepository, sseHub SSEHub) *
 	return &Service{
 		notificationRepo: notificationRepo,
 		sseHub:           sseHub,
+		now:              time.Now,
 	}
 }
 
@@ -34,6 +45,7 @@ func NewServiceWithSettings(notificationRepo domain.NotificationRepository, noti
 		notificationRepo:         notificationRepo,
 		notificationSettingsRepo: notificationSettingsRepo,
 		sseHub:                   sseHub,
+		now:                      time.Now,
 	}
 }
 
@@ -64,13 +76,15 @@ func (s *Service) SendWithCheck(ctx context.Context, notification *domain.Notifi
 	}
 
 	// 사용자 알림 설정 확인
+	var settings *domain.NotificationSettings
 	if s.notificationSettingsRepo != nil {
-		settings, err := s.notificationSettingsRepo.Get(ctx, notification.UserID)
-		if err == nil && settings != nil {
+		loaded, err := s.notificationSettingsRepo.Get(ctx, notification.UserID)
+		if err == nil && loaded != nil {
 			// 설정에 따라 알림을 보낼지 결정
-			if !settings.ShouldNotify(notification.Type, notification.Priority, senderEmail, threadID) {
+			if !loaded.ShouldNotify(notification.Type, notification.Priority, senderEmail, threadID) {
 				return nil // 설정에 의해 알림 차단됨
 			}
+			settings = loaded
 		}
 	}
 
@@ -79,6 +93,11 @@ func (s *Service) SendWithCheck(ctx context.Context, notification *domain.Notifi
 		return err
 	}
 
+	// 방해 금지 시간에는 목록에만 남기고 실시간 전달은 요약으로 미룬다
+	if settings != nil && s.holdIfQuiet(ctx, settings, notification, senderEmail) {
+		return nil
+	}
+
 	// Push via SSE if hub is available
 	if s.sseHub != nil {
 		s.sseHub.SendToUser(notification.UserID.String(), "notification", notification)
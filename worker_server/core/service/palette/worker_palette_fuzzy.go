@@ -0,0 +1,87 @@
+package palette
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Score rates how well query matches text in [0, 1] (0 = 맞지 않음).
+// 정확히 일치 > 접두어 > 단어 접두어 > 부분 문자열 > 순서대로 흩어진 글자(subsequence) 순이다.
+// 여러 단어 검색어는 단어마다 맞아야 하며 ("go inb" → "Go to Inbox") 평균을 조금 깎는다.
+func Score(query, text string) float64 {
+	q := strings.ToLower(strings.TrimSpace(query))
+	t := strings.ToLower(strings.TrimSpace(text))
+	if q == "" || t == "" {
+		return 0
+	}
+
+	if score := scoreTerm(q, t); score > 0 {
+		return score
+	}
+
+	words := strings.Fields(q)
+	if len(words) < 2 {
+		return 0
+	}
+	total := 0.0
+	for _, w := range words {
+		score := scoreTerm(w, t)
+		if score == 0 {
+			return 0
+		}
+		total += score
+	}
+	return total / float64(len(words)) * 0.95
+}
+
+func scoreTerm(q, t string) float64 {
+	switch {
+	case t == q:
+		return 1
+	case strings.HasPrefix(t, q):
+		return 0.9
+	case hasWordPrefix(t, q):
+		return 0.8
+	case strings.Contains(t, q):
+		return 0.7
+	}
+	return subsequence(q, t)
+}
+
+func hasWordPrefix(t, q string) bool {
+	for _, w := range strings.FieldsFunc(t, isSeparator) {
+		if strings.HasPrefix(w, q) {
+			return true
+		}
+	}
+	return false
+}
+
+// subsequence scores q's runes appearing in order in t (0.2 ~ 0.6).
+// 연속된 글자와 단어 첫 글자에 맞은 글자가 많을수록 높다.
+func subsequence(q, t string) float64 {
+	qr, tr := []rune(q), []rune(t)
+	matched, bonus := 0, 0
+	prev := -2
+	for i := 0; i < len(tr) && matched < len(qr); i++ {
+		if tr[i] != qr[matched] {
+			continue
+		}
+		if i == prev+1 {
+			bonus++
+		}
+		if i == 0 || isSeparator(tr[i-1]) {
+			bonus++
+		}
+		prev = i
+		matched++
+	}
+	if matched < len(qr) {
+		return 0
+	}
+	return 0.2 + 0.4*float64(bonus)/float64(2*len(qr))
+}
+
+func isSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == '@' || r == '.' || r == '-' || r == '_' || r == '/'
+}
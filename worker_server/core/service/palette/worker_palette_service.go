@@ -0,0 +1,240 @@
+// Package palette lists invokable actions for a client command palette (폴더 이동, 저장 검색, 연락처에 메일 쓰기, 동기화).
+package palette
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"worker_server/core/domain"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 50
+
+	// contactLimit - 연락처는 수가 많아 검색어가 있을 때 상위 몇 명만 후보로 둔다
+	contactLimit = 10
+)
+
+// Action kinds.
+const (
+	KindNavigate = "navigate" // 폴더/뷰 열기
+	KindSearch   = "search"   // 저장된 검색 (smart folder) 실행
+	KindCompose  = "compose"  // 연락처에게 메일 쓰기
+	KindSync     = "sync"     // 동기화 트리거
+)
+
+// kindOrder breaks score ties (같은 점수면 이동 → 저장 검색 → 작성 → 동기화).
+var kindOrder = map[string]int{KindNavigate: 0, KindSearch: 1, KindCompose: 2, KindSync: 3}
+
+// Action is one palette entry with the API call that performs it.
+type Action struct {
+	ID       string  `json:"id"`
+	Kind     string  `json:"kind"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle,omitempty"`
+	Invoke   Invoke  `json:"invoke"`
+	Score    float64 `json:"score"`
+
+	keywords []string // 제목 외에 매칭할 단어 (한국어 이름 등)
+}
+
+// Invoke is the API request that runs an action.
+type Invoke struct {
+	Method string            `json:"method"`
+	Path   string            `json:"path"`
+	Query  map[string]string `json:"query,omitempty"`
+	Body   map[string]any    `json:"body,omitempty"`
+}
+
+// FolderLister lists the user's folders.
+type FolderLister interface {
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.EmailFolderWithMappings, error)
+}
+
+// ContactLister searches the user's contacts.
+type ContactLister interface {
+	ListContacts(ctx context.Context, filter *domain.ContactFilter) ([]*domain.Contact, int, error)
+}
+
+// ConnectionLister lists the user's mail connections.
+type ConnectionLister interface {
+	GetConnectionsByUser(ctx context.Context, userID uuid.UUID) ([]*domain.OAuthConnection, error)
+}
+
+// Service builds and ranks palette actions. 각 소스는 nil이면 건너뛴다.
+type Service struct {
+	folders      FolderLister
+	smartFolders domain.SmartFolderRepository
+	contacts     ContactLister
+	connections  ConnectionLister
+}
+
+// NewService creates a new palette service.
+func NewService(folders FolderLister, smartFolders domain.SmartFolderRepository, contacts ContactLister, connections ConnectionLister) *Service {
+	return &Service{folders: folders, smartFolders: smartFolders, contacts: contacts, connections: connections}
+}
+
+// Search returns the actions matching query, best first. 빈 검색어면 기본 동작을 순서대로 돌려준다.
+// 소스 하나가 실패해도 나머지로 결과를 만든다.
+func (s *Service) Search(ctx context.Context, userID uuid.UUID, query string, limit int) []*Action {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	candidates := s.candidates(ctx, userID, query)
+	if query == "" {
+		if len(candidates) > limit {
+			candidates = candidates[:limit]
+		}
+		return candidates
+	}
+
+	matched := make([]*Action, 0, len(candidates))
+	for _, a := range candidates {
+		score := Score(query, a.Title)
+		for _, kw := range a.keywords {
+			if kwScore := Score(query, kw) * 0.9; kwScore > score {
+				score = kwScore
+			}
+		}
+		if score > 0 {
+			a.Score = score
+			matched = append(matched, a)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].Score != matched[j].Score {
+			return matched[i].Score > matched[j].Score
+		}
+		return kindOrder[matched[i].Kind] < kindOrder[matched[j].Kind]
+	})
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched
+}
+
+// candidates collects every action the user can run right now.
+func (s *Service) candidates(ctx context.Context, userID uuid.UUID, query string) []*Action {
+	var actions []*Action
+
+	if s.folders != nil {
+		folders, err := s.folders.List(ctx, userID)
+		if err != nil {
+			logger.WithError(err).Warn("[PaletteService] Failed to list folders")
+		}
+		for _, f := range folders {
+			actions = append(actions, folderAction(f.Folder))
+		}
+	}
+
+	if s.smartFolders != nil {
+		smartFolders, err := s.smartFolders.GetByUserID(userID)
+		if err != nil {
+			logger.WithError(err).Warn("[PaletteService] Failed to list smart folders")
+		}
+		for _, sf := range smartFolders {
+			actions = append(actions, &Action{
+				ID:       "smart_folder:" + strconv.FormatInt(sf.ID, 10),
+				Kind:     KindSearch,
+				Title:    sf.Name,
+				Subtitle: "Saved search",
+				Invoke:   Invoke{Method: "GET", Path: fmt.Sprintf("/smart-folders/%d", sf.ID)},
+				keywords: []string{"search " + sf.Name},
+			})
+		}
+	}
+
+	// 연락처는 검색어로 좁혀서 가져온다
+	if s.contacts != nil && query != "" {
+		contacts, _, err := s.contacts.ListContacts(ctx, &domain.ContactFilter{UserID: userID, Search: &query, Limit: contactLimit})
+		if err != nil {
+			logger.WithError(err).Warn("[PaletteService] Failed to search contacts")
+		}
+		for _, c := range contacts {
+			if c.Email == "" {
+				continue
+			}
+			name := c.Name
+			if name == "" {
+				name = c.Email
+			}
+			actions = append(actions, &Action{
+				ID:       "compose:" + c.Email,
+				Kind:     KindCompose,
+				Title:    "Write to " + name,
+				Subtitle: c.Email,
+				Invoke:   Invoke{Method: "POST", Path: "/compose/prepare", Body: map[string]any{"to": []string{c.Email}}},
+				keywords: []string{name, c.Email, "compose " + name, "email " + name},
+			})
+		}
+	}
+
+	actions = append(actions, &Action{
+		ID:       "sync:folders",
+		Kind:     KindSync,
+		Title:    "Sync folders",
+		Subtitle: "Import folders and labels from your accounts",
+		Invoke:   Invoke{Method: "POST", Path: "/folders/sync"},
+		keywords: []string{"refresh folders", "폴더 동기화"},
+	})
+	if s.connections != nil {
+		conns, err := s.connections.GetConnectionsByUser(ctx, userID)
+		if err != nil {
+			logger.WithError(err).Warn("[PaletteService] Failed to list connections")
+		}
+		for _, conn := range conns {
+			if !conn.IsConnected {
+				continue
+			}
+			actions = append(actions, &Action{
+				ID:       "sync:" + strconv.FormatInt(conn.ID, 10),
+				Kind:     KindSync,
+				Title:    "Sync " + conn.Email,
+				Subtitle: "Fetch new mail now",
+				Invoke:   Invoke{Method: "POST", Path: "/email/sync", Body: map[string]any{"connection_id": conn.ID}},
+				keywords: []string{"refresh " + conn.Email, "sync mail", "메일 동기화"},
+			})
+		}
+	}
+
+	return actions
+}
+
+// folderAction opens a folder's mail list.
+func folderAction(f *domain.EmailFolder) *Action {
+	a := &Action{
+		ID:     "folder:" + strconv.FormatInt(f.ID, 10),
+		Kind:   KindNavigate,
+		Title:  "Go to " + f.Name,
+		Invoke: Invoke{Method: "GET", Path: "/email", Query: map[string]string{"folder_id": strconv.FormatInt(f.ID, 10)}},
+	}
+	a.keywords = []string{f.Name}
+	if f.SystemKey != nil {
+		a.Subtitle = "Folder"
+		a.keywords = append(a.keywords, systemFolderKeywords[*f.SystemKey]...)
+	} else {
+		a.Subtitle = "Custom folder"
+	}
+	return a
+}
+
+// systemFolderKeywords lets "휴지통" or "bin" find the trash folder.
+var systemFolderKeywords = map[domain.SystemFolderKey][]string{
+	domain.SystemFolderInbox:   {"inbox", "받은편지함"},
+	domain.SystemFolderSent:    {"sent", "보낸편지함"},
+	domain.SystemFolderDrafts:  {"drafts", "임시보관함"},
+	domain.SystemFolderSpam:    {"spam", "junk", "스팸"},
+	domain.SystemFolderTrash:   {"trash", "bin", "deleted", "휴지통"},
+	domain.SystemFolderArchive: {"archive", "보관함"},
+}
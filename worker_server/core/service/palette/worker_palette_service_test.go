@@ -0,0 +1,105 @@
+package palette
+
+import (
+	"context"
+	"testing"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+func TestScoreRanking(t *testing.T) {
+	tests := []struct {
+		query, better, worse string
+	}{
+		{"inbox", "Inbox", "Go to Inbox"},
+		{"inb", "Go to Inbox", "Combined notes"},
+		{"go inb", "Go to Inbox", "Go to Sent"},
+		{"gti", "Go to Inbox", "Settings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			better, worse := Score(tt.query, tt.better), Score(tt.query, tt.worse)
+			if better <= worse {
+				t.Errorf("Score(%q, %q) = %v, want > Score(%q) = %v", tt.query, tt.better, better, tt.worse, worse)
+			}
+		})
+	}
+	if s := Score("xyz", "Go to Inbox"); s != 0 {
+		t.Errorf("Score(xyz) = %v, want 0", s)
+	}
+}
+
+type fakeFolders []*domain.EmailFolder
+
+func (f fakeFolders) List(ctx context.Context, userID uuid.UUID) ([]*domain.EmailFolderWithMappings, error) {
+	out := make([]*domain.EmailFolderWithMappings, len(f))
+	for i, folder := range f {
+		out[i] = &domain.EmailFolderWithMappings{Folder: folder}
+	}
+	return out, nil
+}
+
+type fakeContacts []*domain.Contact
+
+func (f fakeContacts) ListContacts(ctx context.Context, filter *domain.ContactFilter) ([]*domain.Contact, int, error) {
+	return f, len(f), nil
+}
+
+type fakeConnections []*domain.OAuthConnection
+
+func (f fakeConnections) GetConnectionsByUser(ctx context.Context, userID uuid.UUID) ([]*domain.OAuthConnection, error) {
+	return f, nil
+}
+
+func newTestService() *Service {
+	trash := domain.SystemFolderTrash
+	return NewService(
+		fakeFolders{{ID: 1, Name: "Inbox"}, {ID: 2, Name: "Trash", SystemKey: &trash}, {ID: 3, Name: "Invoices"}},
+		nil,
+		fakeContacts{{Name: "Ingrid Park", Email: "ingrid@acme.com"}},
+		fakeConnections{{ID: 9, Email: "me@gmail.com", IsConnected: true}, {ID: 10, Email: "old@outlook.com"}},
+	)
+}
+
+func TestSearchRanksAndFilters(t *testing.T) {
+	got := newTestService().Search(context.Background(), uuid.New(), "in", 10)
+	if len(got) < 3 {
+		t.Fatalf("Search(in) returned %d actions", len(got))
+	}
+	// 이동이 같은 점수의 연락처보다 먼저
+	if got[0].ID != "folder:1" && got[0].ID != "folder:3" {
+		t.Errorf("first action = %s, want a folder", got[0].ID)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].Score > got[i-1].Score {
+			t.Errorf("actions not sorted by score: %v then %v", got[i-1].Score, got[i].Score)
+		}
+	}
+}
+
+func TestSearchKeywordsAndInvoke(t *testing.T) {
+	svc := newTestService()
+
+	got := svc.Search(context.Background(), uuid.New(), "휴지통", 5)
+	if len(got) == 0 || got[0].ID != "folder:2" || got[0].Invoke.Query["folder_id"] != "2" {
+		t.Fatalf("Search(휴지통) = %+v, want trash folder", got)
+	}
+
+	got = svc.Search(context.Background(), uuid.New(), "sync me", 5)
+	if len(got) == 0 || got[0].ID != "sync:9" || got[0].Invoke.Body["connection_id"] != int64(9) {
+		t.Fatalf("Search(sync me) = %+v, want sync:9 first", got)
+	}
+	for _, a := range got {
+		if a.ID == "sync:10" {
+			t.Error("disconnected account should not be offered")
+		}
+	}
+}
+
+func TestSearchEmptyQueryLimit(t *testing.T) {
+	if got := newTestService().Search(context.Background(), uuid.New(), "", 2); len(got) != 2 {
+		t.Errorf("len = %d, want 2", len(got))
+	}
+}
@@ -0,0 +1,346 @@
+// Package providerquery translates EmailFilter into provider queries (Gmail q, Graph $filter/$search).
+package providerquery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+)
+
+// Report entry status.
+const (
+	StatusTranslated = "translated" // 두 Provider 모두 그대로 적용
+	StatusPartial    = "partial"    // 일부 Provider만 또는 정밀도를 잃고 적용
+	StatusLocalOnly  = "local_only" // 우리 DB에만 있는 필터 → API 호출 생략
+)
+
+// Translation is an EmailFilter translated for Gmail and Outlook.
+type Translation struct {
+	GmailQuery string `json:"gmail_query"`
+
+	// Graph는 메시지 $search와 $filter를 함께 쓸 수 없어, $search가 필요하면 KQL로 옮길 수 있는 조건만 보낸다.
+	OutlookFilter   string `json:"outlook_filter,omitempty"`
+	OutlookSearch   string `json:"outlook_search,omitempty"`    // KQL (어댑터가 따옴표로 감싼다)
+	OutlookFolderID string `json:"outlook_folder_id,omitempty"` // mailFolder ID 또는 well-known 이름 (비어 있으면 전체)
+
+	// Skip: Provider가 모르는 필터가 있어 API 호출이 의미 없음
+	Skip       bool   `json:"skip,omitempty"`
+	SkipReason string `json:"skip_reason,omitempty"`
+
+	// Report: 필드별 번역 결과 (Options.Debug일 때만)
+	Report []ReportEntry `json:"report,omitempty"`
+}
+
+// ReportEntry describes how one filter field was translated.
+type ReportEntry struct {
+	Field   string `json:"field"`
+	Gmail   string `json:"gmail,omitempty"`
+	Outlook string `json:"outlook,omitempty"`
+	Status  string `json:"status"`
+	Note    string `json:"note,omitempty"`
+}
+
+// Options controls a translation.
+type Options struct {
+	// Scope is the provider side of FolderID/LabelIDs (nil이면 로컬 전용 필터로 취급).
+	Scope *Scope
+	// Debug fills Translation.Report.
+	Debug bool
+}
+
+// part is one filter field in each provider syntax ("" = 표현할 수 없음).
+type part struct {
+	field  string
+	gmail  string
+	odata  string // Graph $filter
+	kql    string // Graph $search
+	folder string // Graph mailFolder (경로로 적용)
+	note   string
+	local  bool
+}
+
+// Translate converts filter into provider queries.
+func Translate(filter *domain.EmailFilter, opts *Options) *Translation {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if filter == nil {
+		return &Translation{}
+	}
+
+	var parts []part
+	parts = append(parts, folderParts(filter, opts.Scope)...)
+	parts = append(parts, labelParts(filter, opts.Scope)...)
+	parts = append(parts, flagParts(filter)...)
+	parts = append(parts, senderParts(filter)...)
+	parts = append(parts, dateParts(filter)...)
+	parts = append(parts, attachmentParts(filter)...)
+	parts = append(parts, sizeParts(filter)...)
+	parts = append(parts, textParts(filter)...)
+	parts = append(parts, localParts(filter)...)
+
+	// 본문 검색/크기처럼 KQL로만 되는 조건이 있으면 $search 모드
+	useSearch := false
+	for _, p := range parts {
+		if p.kql != "" && p.odata == "" {
+			useSearch = true
+		}
+	}
+
+	tr := &Translation{}
+	var gmail, odata, kql, local []string
+	for _, p := range parts {
+		if p.local {
+			local = append(local, p.field)
+			if opts.Debug {
+				tr.Report = append(tr.Report, ReportEntry{Field: p.field, Status: StatusLocalOnly, Note: p.note})
+			}
+			continue
+		}
+
+		entry := ReportEntry{Field: p.field, Gmail: p.gmail, Status: StatusTranslated, Note: p.note}
+		if p.gmail != "" {
+			gmail = append(gmail, p.gmail)
+		}
+		switch {
+		case p.folder != "":
+			tr.OutlookFolderID = p.folder
+			entry.Outlook = "mailFolders/" + p.folder
+		case useSearch && p.kql != "":
+			kql = append(kql, p.kql)
+			entry.Outlook = "$search " + p.kql
+		case !useSearch && p.odata != "":
+			odata = append(odata, p.odata)
+			entry.Outlook = "$filter " + p.odata
+		case useSearch && p.odata != "":
+			entry.Note = "Graph는 $search와 $filter를 함께 쓸 수 없어 Outlook에서는 빠짐"
+		}
+		if p.gmail == "" || entry.Outlook == "" || p.note != "" {
+			entry.Status = StatusPartial
+		}
+		if opts.Debug {
+			tr.Report = append(tr.Report, entry)
+		}
+	}
+
+	tr.GmailQuery = strings.Join(gmail, " ")
+	tr.OutlookFilter = strings.Join(odata, " and ")
+	tr.OutlookSearch = strings.Join(kql, " AND ")
+	if len(local) > 0 {
+		tr.Skip = true
+		tr.SkipReason = "local-only filters present (" + strings.Join(local, ", ") + ")"
+	}
+	return tr
+}
+
+// folderParts translates the FolderID scope or the legacy folder.
+func folderParts(filter *domain.EmailFilter, scope *Scope) []part {
+	if filter.FolderID != nil {
+		if scope == nil || (scope.SystemFolder == "" && scope.FolderName == "") {
+			return []part{{field: "folder_id", local: true, note: "Provider 매핑이 없는 폴더"}}
+		}
+		return []part{scopeFolderPart("folder_id", scope)}
+	}
+	if filter.Folder == nil {
+		return nil
+	}
+
+	key := domain.SystemFolderKey(strings.ToLower(string(*filter.Folder)))
+	if GmailSystemFolder(key) != "" {
+		return []part{scopeFolderPart("folder", &Scope{
+			SystemFolder: key,
+			FolderID:     domain.SystemFolderExternalID(domain.MailProviderOutlook, key),
+		})}
+	}
+	// 그 외 이름은 Gmail 사용자 라벨로 본다
+	return []part{{field: "folder", gmail: "label:" + GmailLabel(string(*filter.Folder)), note: "Outlook 폴더로 매핑되지 않음"}}
+}
+
+func scopeFolderPart(field string, scope *Scope) part {
+	p := part{field: field, folder: scope.FolderID}
+	if scope.SystemFolder != "" {
+		p.gmail = GmailSystemFolder(scope.SystemFolder)
+	} else {
+		p.gmail = "label:" + GmailLabel(scope.FolderName)
+	}
+	return p
+}
+
+// labelParts translates LabelIDs via the scope's provider label names.
+func labelParts(filter *domain.EmailFilter, scope *Scope) []part {
+	if len(filter.LabelIDs) == 0 {
+		return nil
+	}
+	if scope == nil || len(scope.LabelNames) == 0 {
+		return []part{{field: "label_ids", local: true, note: "Provider 매핑이 없는 라벨"}}
+	}
+
+	p := part{field: "label_ids", gmail: GmailLabels(scope.LabelNames), odata: OutlookCategories(scope.LabelNames)}
+	if len(scope.LabelNames) < len(filter.LabelIDs) {
+		p.note = "일부 라벨은 이 연결에 없음"
+	}
+	return []part{p}
+}
+
+// flagParts translates read/starred state (KQL로는 표현할 수 없음).
+func flagParts(filter *domain.EmailFilter) []part {
+	var parts []part
+	if filter.IsRead != nil {
+		gmail := "is:unread"
+		if *filter.IsRead {
+			gmail = "is:read"
+		}
+		parts = append(parts, part{field: "is_read", gmail: gmail, odata: fmt.Sprintf("isRead eq %t", *filter.IsRead)})
+	}
+	if filter.IsStarred != nil {
+		p := part{field: "is_starred", gmail: "is:starred", odata: "flag/flagStatus eq 'flagged'"}
+		if !*filter.IsStarred {
+			p.gmail, p.odata = "-is:starred", "flag/flagStatus ne 'flagged'"
+		}
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+// senderParts translates sender, from_email and from_domain.
+func senderParts(filter *domain.EmailFilter) []part {
+	var parts []part
+	if filter.Sender != nil && *filter.Sender != "" {
+		addr := *filter.Sender
+		parts = append(parts, part{
+			field: "sender",
+			gmail: "from:" + addr,
+			odata: fmt.Sprintf("from/emailAddress/address eq '%s'", ODataString(addr)),
+			kql:   "from:" + kqlValue(addr),
+		})
+	}
+	if filter.FromEmail != nil && *filter.FromEmail != "" {
+		addr := *filter.FromEmail
+		// '@'가 없으면 부분 일치
+		odata := fmt.Sprintf("contains(from/emailAddress/address, '%s')", ODataString(addr))
+		if strings.Contains(addr, "@") {
+			odata = fmt.Sprintf("from/emailAddress/address eq '%s'", ODataString(addr))
+		}
+		parts = append(parts, part{field: "from_email", gmail: "from:" + addr, odata: odata, kql: "from:" + kqlValue(addr)})
+	}
+	if filter.FromDomain != nil && *filter.FromDomain != "" {
+		domainName := strings.TrimPrefix(*filter.FromDomain, "@")
+		parts = append(parts, part{
+			field: "from_domain",
+			gmail: "from:@" + domainName,
+			odata: fmt.Sprintf("endswith(from/emailAddress/address, '@%s')", ODataString(domainName)),
+			kql:   "from:" + kqlValue(domainName),
+		})
+	}
+	return parts
+}
+
+// dateParts translates the received date range.
+// Gmail after:/before:는 자정이면 날짜, 아니면 Unix 초로 보낸다. KQL은 날짜 단위다.
+func dateParts(filter *domain.EmailFilter) []part {
+	var parts []part
+	if filter.DateFrom != nil {
+		from := filter.DateFrom.UTC()
+		p := part{
+			field: "date_from",
+			gmail: "after:" + gmailDate(from),
+			odata: "receivedDateTime ge " + from.Format(time.RFC3339),
+			kql:   "received>=" + from.Format("2006-01-02"),
+		}
+		parts = append(parts, p)
+	}
+	if filter.DateTo != nil {
+		to := filter.DateTo.UTC()
+		p := part{
+			field: "date_to",
+			gmail: "before:" + gmailDate(to),
+			odata: "receivedDateTime le " + to.Format(time.RFC3339),
+			kql:   "received<=" + to.Format("2006-01-02"),
+		}
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+func gmailDate(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format("2006/01/02")
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+// attachmentParts translates has_attachment.
+func attachmentParts(filter *domain.EmailFilter) []part {
+	if filter.HasAttachment == nil {
+		return nil
+	}
+	p := part{
+		field: "has_attachment",
+		gmail: "has:attachment",
+		odata: fmt.Sprintf("hasAttachments eq %t", *filter.HasAttachment),
+		kql:   fmt.Sprintf("hasattachment:%t", *filter.HasAttachment),
+	}
+	if !*filter.HasAttachment {
+		p.gmail = "-has:attachment"
+	}
+	return []part{p}
+}
+
+// sizeParts translates the message size range (bytes). Graph $filter에는 크기가 없어 KQL로만 보낸다.
+func sizeParts(filter *domain.EmailFilter) []part {
+	var parts []part
+	if filter.MinSize != nil && *filter.MinSize > 0 {
+		n := *filter.MinSize
+		// Gmail larger:는 초과 조건
+		parts = append(parts, part{field: "min_size", gmail: fmt.Sprintf("larger:%d", n-1), kql: fmt.Sprintf("size>=%d", n)})
+	}
+	if filter.MaxSize != nil && *filter.MaxSize > 0 {
+		n := *filter.MaxSize
+		parts = append(parts, part{field: "max_size", gmail: fmt.Sprintf("smaller:%d", n+1), kql: fmt.Sprintf("size<=%d", n)})
+	}
+	return parts
+}
+
+// textParts translates the free-text search. Gmail은 연산자를 포함한 원문을 그대로 쓴다.
+func textParts(filter *domain.EmailFilter) []part {
+	if filter.Search == nil || strings.TrimSpace(*filter.Search) == "" {
+		return nil
+	}
+	text := strings.TrimSpace(*filter.Search)
+	return []part{{field: "search", gmail: text, kql: kqlText(text)}}
+}
+
+// localParts lists filters only our DB knows (AI 분류, 워크플로 등).
+func localParts(filter *domain.EmailFilter) []part {
+	var parts []part
+	local := func(set bool, field string) {
+		if set {
+			parts = append(parts, part{field: field, local: true, note: "AI 분류/워크플로 등 우리 DB에만 있는 필터"})
+		}
+	}
+	local(filter.Category != nil || len(filter.Categories) > 0, "category")
+	local(filter.SubCategory != nil || len(filter.SubCategories) > 0, "sub_category")
+	local(filter.Priority != nil || filter.MinPriority != nil, "priority")
+	local(filter.Language != nil, "language")
+	local(filter.WorkflowStatus != nil, "workflow_status")
+	local(filter.AssignedToMe, "assigned_to_me")
+	return parts
+}
+
+// kqlValue strips quotes and spaces from an address (adapter가 $search 전체를 큰따옴표로 감싼다).
+func kqlValue(s string) string {
+	return strings.NewReplacer(`"`, "", "'", "", " ", "").Replace(s)
+}
+
+// kqlText strips characters that would break the quoted $search value.
+func kqlText(s string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(s, `"`, " ")), " ")
+}
+
+// ODataString escapes a value for an OData string literal.
+func ODataString(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
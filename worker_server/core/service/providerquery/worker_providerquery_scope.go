@@ -0,0 +1,140 @@
+package providerquery
+
+import (
+	"fmt"
+	"strings"
+
+	"worker_server/core/domain"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// gmailSystemFolders maps system folders to Gmail search operators (보관함은 받은편지함이 아닌 메일).
+var gmailSystemFolders = map[domain.SystemFolderKey]string{
+	domain.SystemFolderInbox:   "in:inbox",
+	domain.SystemFolderSent:    "in:sent",
+	domain.SystemFolderDrafts:  "in:drafts",
+	domain.SystemFolderSpam:    "in:spam",
+	domain.SystemFolderTrash:   "in:trash",
+	domain.SystemFolderArchive: "-in:inbox -in:spam -in:trash",
+}
+
+// Scope is a folder/label scope translated for a connection's provider.
+type Scope struct {
+	SystemFolder domain.SystemFolderKey // 시스템 폴더 → Gmail in:
+	FolderName   string                 // 사용자 폴더 → Gmail label:
+	FolderID     string                 // Outlook mailFolder ID (well-known 이름 포함)
+	LabelNames   []string               // Gmail label: / Outlook categories
+}
+
+// GmailSystemFolder returns the Gmail operator of a system folder ("" = 시스템 폴더 아님).
+func GmailSystemFolder(key domain.SystemFolderKey) string {
+	return gmailSystemFolders[key]
+}
+
+// GmailLabel converts a label name to the form Gmail search expects ("My Team/Q1" → "my-team-q1").
+func GmailLabel(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "-", "/", "-").Replace(name)
+}
+
+// GmailLabels matches any of the labels ({} = Gmail OR 그룹).
+func GmailLabels(names []string) string {
+	labels := make([]string, len(names))
+	for i, name := range names {
+		labels[i] = "label:" + GmailLabel(name)
+	}
+	if len(labels) == 1 {
+		return labels[0]
+	}
+	return "{" + strings.Join(labels, " ") + "}"
+}
+
+// OutlookCategories matches any of the categories in a Graph $filter.
+func OutlookCategories(names []string) string {
+	categories := make([]string, len(names))
+	for i, name := range names {
+		categories[i] = fmt.Sprintf("c eq '%s'", ODataString(name))
+	}
+	return fmt.Sprintf("categories/any(c:%s)", strings.Join(categories, " or "))
+}
+
+// Resolver translates folder/label IDs into a connection's provider scope via the provider mappings.
+type Resolver struct {
+	folders domain.FolderRepository
+	labels  domain.LabelRepository
+}
+
+// NewResolver creates a new Resolver. 둘 중 nil인 저장소의 범위는 해석하지 않는다.
+func NewResolver(folders domain.FolderRepository, labels domain.LabelRepository) *Resolver {
+	return &Resolver{folders: folders, labels: labels}
+}
+
+// Resolve returns the provider scope of folderID/labelIDs on the connection.
+// Provider로 옮길 수 없으면 false (Provider에 없는 폴더, 하나도 매핑되지 않은 라벨 등).
+func (r *Resolver) Resolve(userID uuid.UUID, connectionID int64, folderID *int64, labelIDs []int64) (*Scope, bool) {
+	scope := &Scope{}
+	if folderID != nil {
+		if r == nil || r.folders == nil || !r.resolveFolder(userID, connectionID, *folderID, scope) {
+			return nil, false
+		}
+	}
+	if len(labelIDs) > 0 {
+		if r == nil || r.labels == nil {
+			return nil, false
+		}
+		for _, labelID := range labelIDs {
+			if name := r.providerLabelName(userID, connectionID, labelID); name != "" {
+				scope.LabelNames = append(scope.LabelNames, name)
+			}
+		}
+		if len(scope.LabelNames) == 0 {
+			return nil, false
+		}
+	}
+	return scope, true
+}
+
+// resolveFolder sets the provider folder of the scope.
+// 시스템 폴더는 고정 ID, 사용자 폴더는 이 연결의 매핑이 있어야 한다.
+func (r *Resolver) resolveFolder(userID uuid.UUID, connectionID, folderID int64, scope *Scope) bool {
+	folder, err := r.folders.GetByID(folderID)
+	if err != nil || folder == nil || folder.UserID != userID {
+		return false
+	}
+
+	if folder.IsSystem() && folder.SystemKey != nil {
+		scope.SystemFolder = *folder.SystemKey
+		scope.FolderID = domain.SystemFolderExternalID(domain.MailProviderOutlook, *folder.SystemKey)
+		return true
+	}
+
+	mapping, err := r.folders.GetMapping(folder.ID, connectionID)
+	if err != nil {
+		logger.WithError(err).Warn("[providerquery.Resolver] Failed to get folder mapping %d", folder.ID)
+		return false
+	}
+	if mapping == nil || mapping.ExternalID == nil {
+		return false
+	}
+	scope.FolderName = folder.Name
+	scope.FolderID = *mapping.ExternalID
+	return true
+}
+
+// providerLabelName returns the provider-side name of a label ("" = 이 연결에 없는 라벨).
+func (r *Resolver) providerLabelName(userID uuid.UUID, connectionID, labelID int64) string {
+	label, err := r.labels.GetByID(labelID)
+	if err != nil || label == nil || label.UserID != userID {
+		return ""
+	}
+	mapping, err := r.labels.GetMapping(label.ID, connectionID)
+	if err != nil || mapping == nil || mapping.ExternalID == nil {
+		// Provider에서 가져온 라벨은 매핑 없이 provider_id만 가진다
+		if label.ConnectionID == nil || *label.ConnectionID != connectionID || label.ProviderID == nil {
+			return ""
+		}
+	}
+	return label.Name
+}
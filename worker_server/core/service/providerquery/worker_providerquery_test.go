@@ -0,0 +1,110 @@
+package providerquery
+
+import (
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestTranslateFilterMode(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 12, 30, 0, 0, time.UTC)
+	inbox := domain.LegacyFolder("inbox")
+
+	tr := Translate(&domain.EmailFilter{
+		Folder:        &inbox,
+		IsRead:        ptr(false),
+		FromDomain:    ptr("acme.com"),
+		DateFrom:      &from,
+		DateTo:        &to,
+		HasAttachment: ptr(true),
+	}, nil)
+
+	wantGmail := "in:inbox is:unread from:@acme.com after:2026/01/01 before:" + "1769949000" + " has:attachment"
+	if tr.GmailQuery != wantGmail {
+		t.Errorf("GmailQuery = %q, want %q", tr.GmailQuery, wantGmail)
+	}
+	wantFilter := "isRead eq false and endswith(from/emailAddress/address, '@acme.com') and " +
+		"receivedDateTime ge 2026-01-01T00:00:00Z and receivedDateTime le 2026-02-01T12:30:00Z and hasAttachments eq true"
+	if tr.OutlookFilter != wantFilter {
+		t.Errorf("OutlookFilter = %q, want %q", tr.OutlookFilter, wantFilter)
+	}
+	if tr.OutlookSearch != "" || tr.OutlookFolderID != "inbox" || tr.Skip {
+		t.Errorf("search/folder/skip = %q/%q/%t", tr.OutlookSearch, tr.OutlookFolderID, tr.Skip)
+	}
+	if tr.Report != nil {
+		t.Errorf("Report should be empty without Debug, got %v", tr.Report)
+	}
+}
+
+func TestTranslateSearchMode(t *testing.T) {
+	tr := Translate(&domain.EmailFilter{
+		Search:    ptr(`quarterly "report"`),
+		FromEmail: ptr("boss@acme.com"),
+		IsRead:    ptr(true),
+		MinSize:   ptr(int64(1048576)),
+		MaxSize:   ptr(int64(5242880)),
+	}, &Options{Debug: true})
+
+	if want := "is:read from:boss@acme.com larger:1048575 smaller:5242881 quarterly \"report\""; tr.GmailQuery != want {
+		t.Errorf("GmailQuery = %q, want %q", tr.GmailQuery, want)
+	}
+	// 본문 검색/크기가 있으면 $search만 쓰고 $filter 전용 조건(isRead)은 빠진다
+	if want := "from:boss@acme.com AND size>=1048576 AND size<=5242880 AND quarterly report"; tr.OutlookSearch != want {
+		t.Errorf("OutlookSearch = %q, want %q", tr.OutlookSearch, want)
+	}
+	if tr.OutlookFilter != "" {
+		t.Errorf("OutlookFilter = %q, want empty", tr.OutlookFilter)
+	}
+
+	statuses := map[string]string{}
+	for _, e := range tr.Report {
+		statuses[e.Field] = e.Status
+	}
+	if statuses["is_read"] != StatusPartial || statuses["from_email"] != StatusTranslated || statuses["min_size"] != StatusTranslated {
+		t.Errorf("report statuses = %v", statuses)
+	}
+}
+
+func TestTranslateScope(t *testing.T) {
+	filter := &domain.EmailFilter{FolderID: ptr(int64(7)), LabelIDs: []int64{1, 2}}
+	scope := &Scope{FolderName: "Clients/Acme", FolderID: "AAMk1", LabelNames: []string{"Finance", "Tax 2026"}}
+
+	tr := Translate(filter, &Options{Scope: scope})
+	if want := "label:clients-acme {label:finance label:tax-2026}"; tr.GmailQuery != want {
+		t.Errorf("GmailQuery = %q, want %q", tr.GmailQuery, want)
+	}
+	if tr.OutlookFolderID != "AAMk1" || tr.OutlookFilter != "categories/any(c:c eq 'Finance' or c eq 'Tax 2026')" {
+		t.Errorf("Outlook folder/filter = %q/%q", tr.OutlookFolderID, tr.OutlookFilter)
+	}
+
+	// 매핑이 없으면 로컬 전용
+	if tr := Translate(filter, nil); !tr.Skip || tr.SkipReason != "local-only filters present (folder_id, label_ids)" {
+		t.Errorf("Skip/SkipReason = %t/%q", tr.Skip, tr.SkipReason)
+	}
+}
+
+func TestTranslateLocalOnly(t *testing.T) {
+	category := domain.EmailCategory("work")
+	tr := Translate(&domain.EmailFilter{Category: &category, IsStarred: ptr(false)}, &Options{Debug: true})
+
+	if !tr.Skip || tr.SkipReason != "local-only filters present (category)" {
+		t.Errorf("Skip/SkipReason = %t/%q", tr.Skip, tr.SkipReason)
+	}
+	if tr.GmailQuery != "-is:starred" || tr.OutlookFilter != "flag/flagStatus ne 'flagged'" {
+		t.Errorf("Gmail/Outlook = %q/%q", tr.GmailQuery, tr.OutlookFilter)
+	}
+	if len(tr.Report) != 2 || tr.Report[1].Status != StatusLocalOnly {
+		t.Errorf("Report = %+v", tr.Report)
+	}
+}
+
+func TestODataStringEscapesQuotes(t *testing.T) {
+	tr := Translate(&domain.EmailFilter{Sender: ptr("o'brien@acme.com")}, nil)
+	if want := "from/emailAddress/address eq 'o''brien@acme.com'"; tr.OutlookFilter != want {
+		t.Errorf("OutlookFilter = %q, want %q", tr.OutlookFilter, want)
+	}
+}
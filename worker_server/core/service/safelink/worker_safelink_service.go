@@ -0,0 +1,222 @@
+// Package safelink rewrites email links through a checked redirect endpoint.
+package safelink
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+// RedirectPath is the public redirect route that rewritten links point to.
+const RedirectPath = "/api/v1/links/r"
+
+var (
+	ErrInvalidLinkToken = errors.New("invalid link token")
+	ErrNotConfigured    = errors.New("safe links not configured")
+)
+
+// href="http(s)://..." 만 재작성 (mailto:, cid:, # 등은 그대로)
+var hrefRe = regexp.MustCompile(`(?i)(<a\b[^>]*?\shref\s*=\s*)(["'])(https?://[^"']+)(["'])`)
+
+// Link is the decoded content of a signed link token.
+type Link struct {
+	UserID  uuid.UUID `json:"u"`
+	EmailID int64     `json:"e,omitempty"`
+	URL     string    `json:"l"`
+}
+
+// Service rewrites links, checks destinations and records clicks.
+type Service struct {
+	secret       []byte
+	baseURL      string
+	checker      out.URLReputationChecker
+	clickRepo    out.LinkClickRepository
+	settingsRepo domain.SettingsRepository
+}
+
+// NewService creates a new safe link service.
+// baseURL is the public origin of this server; empty means relative links.
+func NewService(secret, baseURL string, checker out.URLReputationChecker, clickRepo out.LinkClickRepository) *Service {
+	return &Service{
+		secret:    []byte(secret),
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		checker:   checker,
+		clickRepo: clickRepo,
+	}
+}
+
+// SetSettingsRepository sets the settings repository for the per-user opt-in.
+func (s *Service) SetSettingsRepository(repo domain.SettingsRepository) {
+	s.settingsRepo = repo
+}
+
+// Enabled reports whether the user turned on link rewriting.
+func (s *Service) Enabled(userID uuid.UUID) bool {
+	if s.settingsRepo == nil {
+		return false
+	}
+	settings, err := s.settingsRepo.GetByUserID(userID)
+	if err != nil || settings == nil {
+		return false
+	}
+	return settings.SafeLinksEnabled
+}
+
+// RewriteHTML replaces http(s) anchor hrefs with signed redirect URLs.
+// Returns the rewritten HTML and the number of rewritten links.
+func (s *Service) RewriteHTML(userID uuid.UUID, emailID int64, body string) (string, int) {
+	if len(s.secret) == 0 || body == "" {
+		return body, 0
+	}
+
+	count := 0
+	rewritten := hrefRe.ReplaceAllStringFunc(body, func(match string) string {
+		m := hrefRe.FindStringSubmatch(match)
+		if len(m) != 5 || m[2] != m[4] {
+			return match
+		}
+		target := html.UnescapeString(m[3])
+		if s.isOwnURL(target) {
+			return match
+		}
+		count++
+		return m[1] + m[2] + html.EscapeString(s.RedirectURL(&Link{UserID: userID, EmailID: emailID, URL: target})) + m[4]
+	})
+
+	return rewritten, count
+}
+
+// RedirectURL builds the signed redirect URL for a link.
+func (s *Service) RedirectURL(link *Link) string {
+	return s.baseURL + RedirectPath + "?t=" + s.Sign(link)
+}
+
+// Sign encodes a link as "payload.signature" (base64url).
+func (s *Service) Sign(link *Link) string {
+	payload, _ := json.Marshal(link)
+	enc := base64.RawURLEncoding.EncodeToString(payload)
+	return enc + "." + base64.RawURLEncoding.EncodeToString(s.mac([]byte(enc)))
+}
+
+// Verify decodes a token and checks its signature.
+func (s *Service) Verify(token string) (*Link, error) {
+	if len(s.secret) == 0 {
+		return nil, ErrNotConfigured
+	}
+
+	enc, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidLinkToken
+	}
+	gotMAC, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(gotMAC, s.mac([]byte(enc))) {
+		return nil, ErrInvalidLinkToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(enc)
+	if err != nil {
+		return nil, ErrInvalidLinkToken
+	}
+
+	var link Link
+	if err := json.Unmarshal(payload, &link); err != nil || link.URL == "" {
+		return nil, ErrInvalidLinkToken
+	}
+	if u, err := url.Parse(link.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, ErrInvalidLinkToken
+	}
+	return &link, nil
+}
+
+// Check returns the reputation of a link destination.
+// 체커가 없거나 실패하면 unknown으로 통과시킨다.
+func (s *Service) Check(ctx context.Context, link *Link) *domain.URLReputation {
+	unknown := &domain.URLReputation{URL: link.URL, Verdict: domain.LinkVerdictUnknown}
+	if s.checker == nil {
+		return unknown
+	}
+
+	reps, err := s.checker.CheckURLs(ctx, []string{link.URL})
+	if err != nil {
+		logger.WithError(err).Warn("[SafeLink.Check] Reputation lookup failed")
+		return unknown
+	}
+	if rep, ok := reps[link.URL]; ok && rep != nil {
+		return rep
+	}
+	return unknown
+}
+
+// RecordClick stores click analytics. Failures are logged, never returned.
+func (s *Service) RecordClick(ctx context.Context, link *Link, rep *domain.URLReputation) {
+	if s.clickRepo == nil {
+		return
+	}
+
+	verdict := domain.LinkVerdictUnknown
+	if rep != nil {
+		verdict = rep.Verdict
+	}
+	click := &domain.LinkClick{
+		UserID:    link.UserID,
+		EmailID:   link.EmailID,
+		URL:       link.URL,
+		Domain:    hostOf(link.URL),
+		Verdict:   verdict,
+		ClickedAt: time.Now(),
+	}
+	if err := s.clickRepo.Record(ctx, click); err != nil {
+		logger.WithError(err).Warn("[SafeLink.RecordClick] Failed to record click")
+	}
+}
+
+// ListClicks returns recent clicks of a user.
+func (s *Service) ListClicks(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.LinkClick, error) {
+	if s.clickRepo == nil {
+		return nil, ErrNotConfigured
+	}
+	return s.clickRepo.ListByUser(ctx, userID, limit)
+}
+
+// GetStats returns click statistics for the last days.
+func (s *Service) GetStats(ctx context.Context, userID uuid.UUID, days int) (*domain.LinkClickStats, error) {
+	if s.clickRepo == nil {
+		return nil, ErrNotConfigured
+	}
+	if days <= 0 {
+		days = 30
+	}
+	return s.clickRepo.GetStats(ctx, userID, time.Now().AddDate(0, 0, -days), 10)
+}
+
+func (s *Service) mac(data []byte) []byte {
+	m := hmac.New(sha256.New, s.secret)
+	m.Write(data)
+	return m.Sum(nil)
+}
+
+// isOwnURL prevents double rewriting of already-rewritten links.
+func (s *Service) isOwnURL(target string) bool {
+	return s.baseURL != "" && strings.HasPrefix(target, s.baseURL+RedirectPath)
+}
+
+func hostOf(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
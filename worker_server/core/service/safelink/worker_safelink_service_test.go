@@ -0,0 +1,71 @@
+package safelink
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSignVerify(t *testing.T) {
+	svc := NewService("secret", "https://api.example.com", nil, nil)
+	link := &Link{UserID: uuid.New(), EmailID: 42, URL: "https://example.com/a?b=1&c=2"}
+
+	token := svc.Sign(link)
+	got, err := svc.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got.URL != link.URL || got.EmailID != link.EmailID || got.UserID != link.UserID {
+		t.Errorf("Verify() = %+v, want %+v", got, link)
+	}
+
+	// 서명 변조
+	if _, err := svc.Verify(token[:len(token)-2] + "xx"); err != ErrInvalidLinkToken {
+		t.Errorf("tampered token error = %v, want ErrInvalidLinkToken", err)
+	}
+	// 다른 키로 서명된 토큰
+	other := NewService("other", "", nil, nil)
+	if _, err := svc.Verify(other.Sign(link)); err != ErrInvalidLinkToken {
+		t.Errorf("foreign token error = %v, want ErrInvalidLinkToken", err)
+	}
+	// http(s) 이외 스킴은 거부
+	if _, err := svc.Verify(svc.Sign(&Link{URL: "javascript:alert(1)"})); err != ErrInvalidLinkToken {
+		t.Errorf("javascript token error = %v, want ErrInvalidLinkToken", err)
+	}
+}
+
+func TestRewriteHTML(t *testing.T) {
+	svc := NewService("secret", "https://api.example.com", nil, nil)
+	userID := uuid.New()
+
+	body := `<p><a href="https://example.com/x?a=1&amp;b=2">one</a>` +
+		`<a class="btn" href='http://test.org'>two</a>` +
+		`<a href="mailto:me@example.com">mail</a>` +
+		`<a href="#top">top</a>` +
+		`<a href="https://api.example.com/api/v1/links/r?t=abc">own</a></p>`
+
+	out, n := svc.RewriteHTML(userID, 7, body)
+	if n != 2 {
+		t.Fatalf("rewritten = %d, want 2", n)
+	}
+	if strings.Contains(out, "https://example.com/x") || strings.Contains(out, "http://test.org") {
+		t.Errorf("original links left in output: %s", out)
+	}
+	for _, keep := range []string{"mailto:me@example.com", `href="#top"`, "links/r?t=abc"} {
+		if !strings.Contains(out, keep) {
+			t.Errorf("expected %q to be kept: %s", keep, out)
+		}
+	}
+
+	// 재작성된 토큰은 원래 URL(엔티티 해제)로 복원되어야 함
+	start := strings.Index(out, "?t=") + 3
+	end := strings.Index(out[start:], `"`) + start
+	link, err := svc.Verify(out[start:end])
+	if err != nil {
+		t.Fatalf("Verify(rewritten) error = %v", err)
+	}
+	if link.URL != "https://example.com/x?a=1&b=2" || link.EmailID != 7 {
+		t.Errorf("rewritten link = %+v", link)
+	}
+}
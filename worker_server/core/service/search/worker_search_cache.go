@@ -55,6 +55,15 @@ func (c *SearchCache) BuildKey(req *SearchRequest) string {
 		}
 	}
 
+	// Add scope if present
+	if scope := req.Scope; !scope.IsEmpty() {
+		if scope.FolderID != nil {
+			keyData += fmt.Sprintf(":folder:%d", *scope.FolderID)
+		}
+		keyData += ":thread:" + scope.ThreadID
+		keyData += fmt.Sprintf(":labels:%v", scope.LabelIDs)
+	}
+
 	// Hash for consistent key length
 	hash := sha256.Sum256([]byte(keyData))
 	return "search:" + hex.EncodeToString(hash[:16])
@@ -16,7 +16,7 @@ import (
 // SearchExecutor executes searches across multiple sources.
 type SearchExecutor struct {
 	emailRepo    out.EmailRepository
-	vectorStore *rag.VectorStore
+	vectorStore out.VectorStorePort
 	embedder    *rag.Embedder
 	planner     *StrategyPlanner
 }
@@ -24,7 +24,7 @@ type SearchExecutor struct {
 // NewSearchExecutor creates a new search executor.
 func NewSearchExecutor(
 	emailRepo out.EmailRepository,
-	vectorStore *rag.VectorStore,
+	vectorStore out.VectorStorePort,
 	embedder *rag.Embedder,
 	planner *StrategyPlanner,
 ) *SearchExecutor {
@@ -37,7 +37,8 @@ func NewSearchExecutor(
 }
 
 // ProviderSearchFunc is a function type for provider-specific search.
-type ProviderSearchFunc func(ctx context.Context, token *oauth2.Token, query string, limit int) ([]*SearchResult, error)
+// 연결의 Provider에 맞는 쿼리 (GmailQuery 또는 OutlookQuery/Filter/FolderID)를 골라 쓴다.
+type ProviderSearchFunc func(ctx context.Context, token *oauth2.Token, query *ProviderSearchQuery) ([]*SearchResult, error)
 
 // Execute runs the search plan and collects results from all sources.
 func (e *SearchExecutor) Execute(
@@ -79,7 +80,7 @@ func (e *SearchExecutor) Execute(
 
 	// Check if fallback to Provider is needed
 	if e.planner.ShouldFallbackToProvider(plan, response.DBCount, response.VectorCount, req.Limit) {
-		e.planner.AdjustPlanForFallback(plan, parsed, req.Limit)
+		e.planner.AdjustPlanForFallback(plan, parsed, req.Scope, req.Limit)
 	}
 
 	// Phase 2: Provider search if needed
@@ -175,8 +176,15 @@ func (e *SearchExecutor) searchDB(
 		return nil, nil
 	}
 
-	// Use existing repository search method
-	emails, _, err := e.emailRepo.Search(ctx, userID, query.Query, query.Limit, query.Offset)
+	// Scope는 DB 쿼리 조건으로 내려보낸다 (아카이브된 메일도 검색)
+	scope := &out.MailListQuery{IncludeArchived: true}
+	if !query.Scope.IsEmpty() {
+		scope.FolderID = query.Scope.FolderID
+		scope.ThreadID = query.Scope.ThreadID
+		scope.LabelIDs = query.Scope.LabelIDs
+	}
+
+	emails, _, err := e.emailRepo.Search(ctx, userID, query.Query, scope, query.Limit, query.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -193,6 +201,7 @@ func (e *SearchExecutor) searchDB(
 		results = append(results, &SearchResult{
 			EmailID:    email.ID,
 			ProviderID: email.ExternalID,
+			ThreadID:   email.ExternalThreadID,
 			Subject:    email.Subject,
 			Snippet:    email.Snippet,
 			From:       email.FromEmail,
@@ -229,12 +238,18 @@ func (e *SearchExecutor) searchVector(
 		return nil, err
 	}
 
-	// Search in vector store
-	vectorResults, err := e.vectorStore.Search(ctx, embedding, &rag.SearchOptions{
+	// Search in vector store (scope는 metadata 필터로)
+	opts := &rag.SearchOptions{
 		UserID:   userID.String(),
 		Limit:    query.Limit,
 		MinScore: query.MinScore,
-	})
+	}
+	if scope := query.Scope; scope != nil {
+		opts.ThreadID = scope.ThreadID
+		opts.FolderID = scope.FolderID
+		opts.LabelIDs = scope.LabelIDs
+	}
+	vectorResults, err := e.vectorStore.Search(ctx, embedding, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -279,7 +294,10 @@ func (e *SearchExecutor) executeProviderSearch(
 	providerCtx, cancel := context.WithTimeout(ctx, time.Duration(plan.Phase2TimeoutMs)*time.Millisecond)
 	defer cancel()
 
-	results, err := providerSearch(providerCtx, token, plan.ProviderQuery.GmailQuery, plan.ProviderQuery.Limit)
+	results, err := providerSearch(providerCtx, token, plan.ProviderQuery)
+	if err == nil && plan.ProviderQuery.ThreadID != "" {
+		results = filterByThread(results, plan.ProviderQuery.ThreadID)
+	}
 
 	return &PartialResult{
 		Source:  SourceProvider,
@@ -288,3 +306,14 @@ func (e *SearchExecutor) executeProviderSearch(
 		TimeMs:  time.Since(start).Milliseconds(),
 	}
 }
+
+// filterByThread keeps the results of one thread (Gmail 검색에는 스레드 연산자가 없다).
+func filterByThread(results []*SearchResult, threadID string) []*SearchResult {
+	filtered := results[:0]
+	for _, r := range results {
+		if r.ThreadID == threadID {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
@@ -114,6 +114,7 @@ func (p *StrategyPlanner) buildQueries(plan *SearchPlan, parsed *ParsedQuery, re
 		plan.DBQuery = &DBSearchQuery{
 			Query:   p.transformer.ToDBQuery(parsed),
 			Filters: req.Filters,
+			Scope:   req.Scope,
 			Limit:   limit,
 			Offset:  req.Offset,
 			UseRank: len(parsed.Keywords) > 0,
@@ -125,6 +126,7 @@ func (p *StrategyPlanner) buildQueries(plan *SearchPlan, parsed *ParsedQuery, re
 		plan.VectorQuery = &VectorSearchQuery{
 			Text:     parsed.SemanticQuery,
 			Filters:  req.Filters,
+			Scope:    req.Scope,
 			Limit:    limit,
 			MinScore: 0.5, // Minimum similarity threshold
 		}
@@ -132,7 +134,7 @@ func (p *StrategyPlanner) buildQueries(plan *SearchPlan, parsed *ParsedQuery, re
 
 	// Provider Query
 	if plan.UseProvider {
-		plan.ProviderQuery = p.transformer.BuildProviderSearchQuery(parsed, limit)
+		plan.ProviderQuery = p.transformer.BuildProviderSearchQuery(parsed, req.Scope, limit)
 	}
 }
 
@@ -152,10 +154,26 @@ func (p *StrategyPlanner) ShouldFallbackToProvider(plan *SearchPlan, dbCount, ve
 	return false
 }
 
+// DegradeForMetadataOnly replaces vector search for connections without bodies and embeddings.
+// 벡터 대신 DB(제목/snippet)로 찾고, 본문 검색은 Provider에 맡긴다.
+func (p *StrategyPlanner) DegradeForMetadataOnly(plan *SearchPlan, parsed *ParsedQuery, req *SearchRequest, providerAvailable bool) {
+	if !plan.UseVector {
+		return
+	}
+	plan.UseVector = false
+	plan.VectorQuery = nil
+	plan.UseDB = true
+	plan.MergeStrategy = MergeRRF
+	if providerAvailable {
+		plan.UseProvider = true
+	}
+	p.buildQueries(plan, parsed, req)
+}
+
 // AdjustPlanForFallback modifies plan to include Provider search.
-func (p *StrategyPlanner) AdjustPlanForFallback(plan *SearchPlan, parsed *ParsedQuery, limit int) {
+func (p *StrategyPlanner) AdjustPlanForFallback(plan *SearchPlan, parsed *ParsedQuery, scope *SearchScope, limit int) {
 	plan.UseProvider = true
 	if plan.ProviderQuery == nil {
-		plan.ProviderQuery = p.transformer.BuildProviderSearchQuery(parsed, limit)
+		plan.ProviderQuery = p.transformer.BuildProviderSearchQuery(parsed, scope, limit)
 	}
 }
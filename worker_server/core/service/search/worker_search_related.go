@@ -0,0 +1,187 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"worker_server/core/agent/llm"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultRelatedMinScore - 이보다 유사도가 낮은 메일은 "관련 메일"로 보여주지 않는다
+	DefaultRelatedMinScore = 0.75
+	DefaultRelatedLimit    = 5
+	MaxRelatedLimit        = 20
+
+	// relatedOverfetch - 같은 스레드/삭제된 메일을 걸러낸 뒤에도 limit을 채우도록 더 가져온다
+	relatedOverfetch = 3
+
+	relatedCacheTTL     = 10 * time.Minute
+	relatedCacheMaxSize = 10000
+)
+
+// ErrEmailNotFound is returned when the source email does not exist or belongs to another user.
+var ErrEmailNotFound = errors.New("email not found")
+
+// RelatedEmail is a past email semantically similar to the source email.
+type RelatedEmail struct {
+	EmailID   int64     `json:"email_id"`
+	ThreadID  *int64    `json:"thread_id,omitempty"`
+	Subject   string    `json:"subject"`
+	Snippet   string    `json:"snippet"`
+	FromEmail string    `json:"from_email"`
+	FromName  string    `json:"from_name,omitempty"`
+	Folder    string    `json:"folder"`
+	Date      time.Time `json:"date"`
+	Score     float64   `json:"score"`
+}
+
+// RelatedResponse is the result of Service.Related.
+type RelatedResponse struct {
+	EmailID  int64           `json:"email_id"`
+	Related  []*RelatedEmail `json:"related"`
+	MinScore float64         `json:"min_score"`
+	// Indexed is false when the source email had no stored embedding and was embedded on the fly.
+	Indexed bool `json:"indexed"`
+}
+
+// Related returns past emails similar to emailID, excluding messages of the same thread.
+// 결과는 검색 캐시에 함께 저장되며 (5분), 임계값 미만 유사도는 제외한다.
+func (s *Service) Related(ctx context.Context, userID uuid.UUID, emailID int64, limit int, minScore float64) (*RelatedResponse, error) {
+	if limit <= 0 {
+		limit = DefaultRelatedLimit
+	}
+	limit = min(limit, MaxRelatedLimit)
+	if minScore <= 0 {
+		minScore = DefaultRelatedMinScore
+	}
+
+	cacheKey := fmt.Sprintf("related:%s:%d:%d:%.2f", userID, emailID, limit, minScore)
+	if cached, ok := s.relatedCache.Get(cacheKey); ok {
+		return cached, nil
+	}
+
+	source, err := s.emailRepo.GetByID(ctx, emailID)
+	if err != nil || source == nil || source.UserID != userID {
+		return nil, ErrEmailNotFound
+	}
+
+	embedding, indexed, err := s.sourceEmbedding(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.vectorStore.Search(ctx, embedding, &out.EmailVectorQuery{
+		UserID:    userID.String(),
+		AllEmails: true,
+		Limit:     limit*relatedOverfetch + 1, // +1: 자기 자신
+		MinScore:  minScore,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &RelatedResponse{EmailID: emailID, Related: []*RelatedEmail{}, MinScore: minScore, Indexed: indexed}
+	for _, m := range matches {
+		if len(resp.Related) >= limit {
+			break
+		}
+		if m.EmailID == emailID || m.Score < minScore {
+			continue
+		}
+
+		// 벡터 저장소에 남아 있어도 삭제되었거나 다른 사용자의 메일이면 제외
+		email, err := s.emailRepo.GetByID(ctx, m.EmailID)
+		if err != nil || email == nil || email.UserID != userID {
+			continue
+		}
+		if source.ThreadID != nil && email.ThreadID != nil && *source.ThreadID == *email.ThreadID {
+			continue
+		}
+
+		resp.Related = append(resp.Related, &RelatedEmail{
+			EmailID:   email.ID,
+			ThreadID:  email.ThreadID,
+			Subject:   email.Subject,
+			Snippet:   email.Snippet,
+			FromEmail: email.FromEmail,
+			FromName:  email.FromName,
+			Folder:    email.Folder,
+			Date:      email.ReceivedAt,
+			Score:     m.Score,
+		})
+	}
+
+	s.relatedCache.Set(cacheKey, resp)
+	return resp, nil
+}
+
+// sourceEmbedding returns the stored embedding of the email, or embeds subject + snippet if it is not indexed yet.
+func (s *Service) sourceEmbedding(ctx context.Context, email *out.MailEntity) ([]float32, bool, error) {
+	stored, err := s.vectorStore.Get(ctx, email.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	if stored != nil && len(stored.Embedding) > 0 {
+		return stored.Embedding, true, nil
+	}
+
+	ctx = llm.WithUsageScope(ctx, llm.UsageScope{UserID: email.UserID, ConnectionID: email.ConnectionID, Task: llm.TaskEmbed})
+	embedding, err := s.embedder.Embed(ctx, s.embedder.PrepareText(email.Subject, email.Snippet, 8000))
+	if err != nil {
+		return nil, false, err
+	}
+	return embedding, false, nil
+}
+
+// relatedCache caches Related responses per user/email/options.
+// 메일 하나의 관련 메일은 자주 바뀌지 않으므로 검색 캐시보다 TTL을 길게 둔다.
+type relatedCache struct {
+	mu      sync.Mutex
+	entries map[string]relatedCacheEntry
+}
+
+type relatedCacheEntry struct {
+	resp      *RelatedResponse
+	expiresAt time.Time
+}
+
+func newRelatedCache() *relatedCache {
+	return &relatedCache{entries: make(map[string]relatedCacheEntry)}
+}
+
+func (c *relatedCache) Get(key string) (*RelatedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		return nil, false
+	}
+	return e.resp, true
+}
+
+func (c *relatedCache) Set(key string, resp *RelatedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= relatedCacheMaxSize {
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		// 만료된 항목이 없으면 전부 비운다 (상한 유지)
+		if len(c.entries) >= relatedCacheMaxSize {
+			c.entries = make(map[string]relatedCacheEntry)
+		}
+	}
+	c.entries[key] = relatedCacheEntry{resp: resp, expiresAt: now.Add(relatedCacheTTL)}
+}
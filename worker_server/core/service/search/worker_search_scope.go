@@ -0,0 +1,28 @@
+package search
+
+import (
+	"worker_server/core/domain"
+	"worker_server/core/service/providerquery"
+)
+
+// SetScopeRepositories sets the folder/label repositories used to translate a scope for provider search.
+// 설정하지 않으면 폴더/라벨 범위 검색은 Provider 없이 DB/벡터로만 수행한다.
+func (s *Service) SetScopeRepositories(folders domain.FolderRepository, labels domain.LabelRepository) {
+	s.scopes = providerquery.NewResolver(folders, labels)
+}
+
+// resolveProviderScope fills req.Scope.Provider from the connection's folder/label mappings.
+// Provider 쿼리로 옮길 수 없는 범위면 false를 돌려준다 (Provider에 없는 폴더/라벨 등).
+func (s *Service) resolveProviderScope(req *SearchRequest) bool {
+	scope := req.Scope
+	if scope.FolderID == nil && len(scope.LabelIDs) == 0 {
+		return true // 스레드 범위는 번역이 필요 없다
+	}
+
+	ps, ok := s.scopes.Resolve(req.UserID, req.ConnectionID, scope.FolderID, scope.LabelIDs)
+	if !ok {
+		return false
+	}
+	scope.Provider = ps
+	return true
+}
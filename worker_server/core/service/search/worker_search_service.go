@@ -6,6 +6,7 @@ import (
 
 	"worker_server/core/agent/rag"
 	"worker_server/core/port/out"
+	"worker_server/core/service/providerquery"
 	"worker_server/pkg/logger"
 
 	"golang.org/x/oauth2"
@@ -19,12 +20,34 @@ type Service struct {
 	executor    *SearchExecutor
 	merger      *ResultMerger
 	cache       *SearchCache
+
+	// related emails (GET /email/:id/related)
+	emailRepo    out.EmailRepository
+	vectorStore  out.VectorStorePort
+	embedder     *rag.Embedder
+	relatedCache *relatedCache
+
+	// scope 번역 (folder_id/label_id → Provider 폴더/라벨)
+	scopes *providerquery.Resolver
+
+	// metadata-only 연결 판별 (nil이면 항상 벡터 검색 가능으로 본다)
+	storageGuard StorageGuard
+}
+
+// StorageGuard reports connections that store no bodies or embeddings (storage.Service).
+type StorageGuard interface {
+	MetadataOnly(ctx context.Context, connectionID int64) bool
+}
+
+// SetStorageGuard degrades vector search to DB + Provider search for metadata-only connections.
+func (s *Service) SetStorageGuard(guard StorageGuard) {
+	s.storageGuard = guard
 }
 
 // NewService creates a new search service.
 func NewService(
 	emailRepo out.EmailRepository,
-	vectorStore *rag.VectorStore,
+	vectorStore out.VectorStorePort,
 	embedder *rag.Embedder,
 ) *Service {
 	analyzer := NewQueryAnalyzer()
@@ -41,6 +64,11 @@ func NewService(
 		executor:    executor,
 		merger:      merger,
 		cache:       cache,
+
+		emailRepo:    emailRepo,
+		vectorStore:  vectorStore,
+		embedder:     embedder,
+		relatedCache: newRelatedCache(),
 	}
 }
 
@@ -69,6 +97,11 @@ func (s *Service) Search(
 		return cached, nil
 	}
 
+	// 범위를 Provider 쿼리로 옮길 수 없으면 DB/벡터 결과만 쓴다
+	if !req.Scope.IsEmpty() && !s.resolveProviderScope(req) {
+		providerSearch = nil
+	}
+
 	// 1. Analyze query
 	parsed := s.analyzer.Analyze(req.Query)
 
@@ -81,6 +114,10 @@ func (s *Service) Search(
 
 	// 2. Create search plan
 	plan := s.planner.Plan(parsed, req)
+	metadataOnly := req.ConnectionID != 0 && s.storageGuard != nil && s.storageGuard.MetadataOnly(ctx, req.ConnectionID)
+	if metadataOnly {
+		s.planner.DegradeForMetadataOnly(plan, parsed, req, providerSearch != nil)
+	}
 
 	logger.WithFields(map[string]any{
 		"use_db":       plan.UseDB,
@@ -94,6 +131,8 @@ func (s *Service) Search(
 		return nil, err
 	}
 
+	response.MetadataOnly = metadataOnly
+
 	// 4. Merge and rank results
 	s.merger.Merge(response, plan.MergeStrategy, req.Limit)
 
@@ -150,13 +189,13 @@ func (s *Service) AnalyzeQuery(query string) *ParsedQuery {
 // TransformToGmail converts a query to Gmail search format.
 func (s *Service) TransformToGmail(query string) string {
 	parsed := s.analyzer.Analyze(query)
-	return s.transformer.ToGmailQuery(parsed)
+	return s.transformer.ToGmailQuery(parsed, nil)
 }
 
 // TransformToOutlook converts a query to Outlook search format.
 func (s *Service) TransformToOutlook(query string) (search, filter string) {
 	parsed := s.analyzer.Analyze(query)
-	return s.transformer.ToOutlookQuery(parsed)
+	return s.transformer.ToOutlookQuery(parsed, nil)
 }
 
 // InvalidateCache removes cached results for a user.
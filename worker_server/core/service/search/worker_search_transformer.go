@@ -3,6 +3,8 @@ package search
 import (
 	"fmt"
 	"strings"
+
+	"worker_server/core/service/providerquery"
 )
 
 // QueryTransformer converts parsed queries to provider-specific formats.
@@ -15,7 +17,8 @@ func NewQueryTransformer() *QueryTransformer {
 
 // ToGmailQuery converts a parsed query to Gmail search syntax.
 // Gmail supports: from:, to:, subject:, has:attachment, is:unread, after:, before:, in:, label:
-func (t *QueryTransformer) ToGmailQuery(parsed *ParsedQuery) string {
+// scope의 폴더/라벨은 in:/label:로 붙인다 (스레드는 결과에서 거른다).
+func (t *QueryTransformer) ToGmailQuery(parsed *ParsedQuery, scope *SearchScope) string {
 	var parts []string
 
 	// Add keywords (search in body and subject)
@@ -65,17 +68,34 @@ func (t *QueryTransformer) ToGmailQuery(parsed *ParsedQuery) string {
 		parts = append(parts, fmt.Sprintf("before:%s", parsed.DateTo.Format("2006/01/02")))
 	}
 
-	if parsed.Folder != nil {
+	var ps *ProviderScope
+	if scope != nil {
+		ps = scope.Provider
+	}
+	switch {
+	case ps != nil && ps.SystemFolder != "":
+		if op := providerquery.GmailSystemFolder(ps.SystemFolder); op != "" {
+			parts = append(parts, op)
+		}
+	case ps != nil && ps.FolderName != "":
+		parts = append(parts, "label:"+providerquery.GmailLabel(ps.FolderName))
+	case parsed.Folder != nil:
 		parts = append(parts, fmt.Sprintf("in:%s", *parsed.Folder))
 	}
 
+	if ps != nil && len(ps.LabelNames) > 0 {
+		// 라벨 중 하나라도 (Gmail OR 그룹)
+		parts = append(parts, providerquery.GmailLabels(ps.LabelNames))
+	}
+
 	return strings.Join(parts, " ")
 }
 
 // ToOutlookQuery converts a parsed query to Outlook search syntax (KQL).
 // Outlook $search supports: from:, subject:, hasAttachments:, etc.
-// Outlook $filter supports: isRead, receivedDateTime, etc.
-func (t *QueryTransformer) ToOutlookQuery(parsed *ParsedQuery) (search string, filter string) {
+// Outlook $filter supports: isRead, receivedDateTime, conversationId, categories, etc.
+// scope의 폴더는 $filter가 아니라 mailFolder 경로로 적용한다 (BuildProviderSearchQuery).
+func (t *QueryTransformer) ToOutlookQuery(parsed *ParsedQuery, scope *SearchScope) (search string, filter string) {
 	var searchParts []string
 	var filterParts []string
 
@@ -119,6 +139,14 @@ func (t *QueryTransformer) ToOutlookQuery(parsed *ParsedQuery) (search string, f
 		filterParts = append(filterParts, fmt.Sprintf("receivedDateTime lt %s", parsed.DateTo.Format("2006-01-02T00:00:00Z")))
 	}
 
+	// Scope: thread and labels (categories) in $filter
+	if scope != nil && scope.ThreadID != "" {
+		filterParts = append(filterParts, fmt.Sprintf("conversationId eq '%s'", providerquery.ODataString(scope.ThreadID)))
+	}
+	if scope != nil && scope.Provider != nil && len(scope.Provider.LabelNames) > 0 {
+		filterParts = append(filterParts, providerquery.OutlookCategories(scope.Provider.LabelNames))
+	}
+
 	search = strings.Join(searchParts, " AND ")
 	filter = strings.Join(filterParts, " and ")
 
@@ -243,14 +271,22 @@ func quoteIfNeeded(s string) string {
 	return s
 }
 
-// BuildProviderSearchQuery creates a ProviderSearchQuery from parsed query.
-func (t *QueryTransformer) BuildProviderSearchQuery(parsed *ParsedQuery, limit int) *ProviderSearchQuery {
-	gmailQuery := t.ToGmailQuery(parsed)
-	outlookSearch, _ := t.ToOutlookQuery(parsed)
+// BuildProviderSearchQuery creates a ProviderSearchQuery from parsed query and scope.
+func (t *QueryTransformer) BuildProviderSearchQuery(parsed *ParsedQuery, scope *SearchScope, limit int) *ProviderSearchQuery {
+	gmailQuery := t.ToGmailQuery(parsed, scope)
+	outlookSearch, outlookFilter := t.ToOutlookQuery(parsed, scope)
 
-	return &ProviderSearchQuery{
-		GmailQuery:   gmailQuery,
-		OutlookQuery: outlookSearch,
-		Limit:        limit,
+	query := &ProviderSearchQuery{
+		GmailQuery:    gmailQuery,
+		OutlookQuery:  outlookSearch,
+		OutlookFilter: outlookFilter,
+		Limit:         limit,
+	}
+	if scope != nil {
+		query.ThreadID = scope.ThreadID
+		if scope.Provider != nil {
+			query.OutlookFolderID = scope.Provider.FolderID
+		}
 	}
+	return query
 }
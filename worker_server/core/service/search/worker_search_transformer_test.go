@@ -0,0 +1,99 @@
+package search
+
+import (
+	"testing"
+
+	"worker_server/core/domain"
+)
+
+func TestToGmailQueryScope(t *testing.T) {
+	tr := NewQueryTransformer()
+	parsed := &ParsedQuery{Keywords: []string{"invoice"}}
+
+	tests := []struct {
+		name  string
+		scope *SearchScope
+		want  string
+	}{
+		{"no scope", nil, "invoice"},
+		{"system folder", &SearchScope{Provider: &ProviderScope{SystemFolder: domain.SystemFolderSent}}, "invoice in:sent"},
+		{"archive", &SearchScope{Provider: &ProviderScope{SystemFolder: domain.SystemFolderArchive}}, "invoice -in:inbox -in:spam -in:trash"},
+		{"user folder", &SearchScope{Provider: &ProviderScope{FolderName: "Clients/Acme Corp"}}, "invoice label:clients-acme-corp"},
+		{"one label", &SearchScope{Provider: &ProviderScope{LabelNames: []string{"Finance"}}}, "invoice label:finance"},
+		{"labels", &SearchScope{Provider: &ProviderScope{LabelNames: []string{"Finance", "Tax 2026"}}}, "invoice {label:finance label:tax-2026}"},
+		{"thread only", &SearchScope{ThreadID: "18c2f"}, "invoice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tr.ToGmailQuery(parsed, tt.scope); got != tt.want {
+				t.Errorf("ToGmailQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToGmailQueryScopeOverridesParsedFolder(t *testing.T) {
+	folder := "inbox"
+	parsed := &ParsedQuery{Keywords: []string{"report"}, Folder: &folder}
+	scope := &SearchScope{Provider: &ProviderScope{FolderName: "Reports"}}
+
+	if got, want := NewQueryTransformer().ToGmailQuery(parsed, scope), "report label:reports"; got != want {
+		t.Errorf("ToGmailQuery() = %q, want %q", got, want)
+	}
+}
+
+func TestToOutlookQueryScope(t *testing.T) {
+	tr := NewQueryTransformer()
+	unread := false
+	parsed := &ParsedQuery{Keywords: []string{"invoice"}, IsRead: &unread}
+
+	tests := []struct {
+		name       string
+		scope      *SearchScope
+		wantFilter string
+	}{
+		{"no scope", nil, "isRead eq false"},
+		{"thread", &SearchScope{ThreadID: "AAQk'1"}, "isRead eq false and conversationId eq 'AAQk''1'"},
+		{"labels", &SearchScope{Provider: &ProviderScope{LabelNames: []string{"Finance", "Tax"}}},
+			"isRead eq false and categories/any(c:c eq 'Finance' or c eq 'Tax')"},
+		{"folder only", &SearchScope{Provider: &ProviderScope{FolderID: "AAMk1"}}, "isRead eq false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			search, filter := tr.ToOutlookQuery(parsed, tt.scope)
+			if search != "invoice" {
+				t.Errorf("search = %q, want %q", search, "invoice")
+			}
+			if filter != tt.wantFilter {
+				t.Errorf("filter = %q, want %q", filter, tt.wantFilter)
+			}
+		})
+	}
+}
+
+func TestBuildProviderSearchQueryScope(t *testing.T) {
+	scope := &SearchScope{
+		ThreadID: "t1",
+		Provider: &ProviderScope{SystemFolder: domain.SystemFolderInbox, FolderID: "inbox"},
+	}
+	q := NewQueryTransformer().BuildProviderSearchQuery(&ParsedQuery{Keywords: []string{"hello"}}, scope, 10)
+
+	if q.GmailQuery != "hello in:inbox" {
+		t.Errorf("GmailQuery = %q", q.GmailQuery)
+	}
+	if q.OutlookFolderID != "inbox" || q.OutlookFilter != "conversationId eq 't1'" {
+		t.Errorf("Outlook folder/filter = %q/%q", q.OutlookFolderID, q.OutlookFilter)
+	}
+	if q.ThreadID != "t1" || q.Limit != 10 {
+		t.Errorf("ThreadID/Limit = %q/%d", q.ThreadID, q.Limit)
+	}
+}
+
+func TestFilterByThread(t *testing.T) {
+	results := []*SearchResult{{ProviderID: "a", ThreadID: "t1"}, {ProviderID: "b", ThreadID: "t2"}, {ProviderID: "c", ThreadID: "t1"}}
+
+	got := filterByThread(results, "t1")
+	if len(got) != 2 || got[0].ProviderID != "a" || got[1].ProviderID != "c" {
+		t.Errorf("filterByThread() = %v, want a and c", got)
+	}
+}
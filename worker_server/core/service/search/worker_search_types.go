@@ -4,6 +4,8 @@ package search
 import (
 	"time"
 
+	"worker_server/core/service/providerquery"
+
 	"github.com/google/uuid"
 )
 
@@ -65,8 +67,30 @@ type SearchRequest struct {
 
 	// Optional filters
 	Filters *SearchFilters
+
+	// Optional scope (folder_id, thread_id, label_id)
+	Scope *SearchScope
+}
+
+// SearchScope limits a search to a folder, a thread and/or labels.
+// DB 쿼리와 벡터 검색에는 그대로, Provider 검색에는 Provider 필드로 번역해 적용한다.
+type SearchScope struct {
+	FolderID *int64
+	ThreadID string  // Provider 스레드 ID (Gmail threadId, Outlook conversationId)
+	LabelIDs []int64 // 하나라도 붙은 메일
+
+	// Provider is the scope in provider terms (폴더/라벨 매핑으로 Service가 채운다).
+	Provider *ProviderScope
 }
 
+// IsEmpty reports whether the scope has no restriction.
+func (s *SearchScope) IsEmpty() bool {
+	return s == nil || (s.FolderID == nil && s.ThreadID == "" && len(s.LabelIDs) == 0)
+}
+
+// ProviderScope is a folder/label scope translated for the connection's provider.
+type ProviderScope = providerquery.Scope
+
 // SearchFilters contains structured filter options.
 type SearchFilters struct {
 	From          *string
@@ -131,6 +155,7 @@ type SearchPlan struct {
 type DBSearchQuery struct {
 	Query   string
 	Filters *SearchFilters
+	Scope   *SearchScope
 	Limit   int
 	Offset  int
 	UseRank bool
@@ -140,15 +165,19 @@ type DBSearchQuery struct {
 type VectorSearchQuery struct {
 	Text     string // text to embed
 	Filters  *SearchFilters
+	Scope    *SearchScope
 	Limit    int
 	MinScore float64
 }
 
 // ProviderSearchQuery for Gmail/Outlook API.
 type ProviderSearchQuery struct {
-	GmailQuery   string // Gmail q parameter
-	OutlookQuery string // Outlook $search parameter
-	Limit        int
+	GmailQuery      string // Gmail q parameter
+	OutlookQuery    string // Outlook $search parameter
+	OutlookFilter   string // Outlook $filter parameter
+	OutlookFolderID string // Outlook mailFolder (비어 있으면 전체 메일)
+	ThreadID        string // 결과를 이 스레드로 거른다 (Gmail q에는 스레드 연산자가 없음)
+	Limit           int
 }
 
 // MergeStrategy defines how to combine results from multiple sources.
@@ -169,6 +198,7 @@ const (
 type SearchResult struct {
 	EmailID    int64
 	ProviderID string
+	ThreadID   string
 	Subject    string
 	Snippet    string
 	From       string
@@ -198,6 +228,9 @@ type SearchResponse struct {
 	Strategy  SearchStrategy
 	Intent    SearchIntent
 
+	// MetadataOnly is true when the connection stores no bodies/embeddings (벡터 검색 대신 DB + Provider).
+	MetadataOnly bool
+
 	// Debug info
 	DBCount       int
 	VectorCount   int
@@ -0,0 +1,97 @@
+package share
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// attemptWindow - 비밀번호 실패를 세는 기간 (이 기간이 지나면 다시 시도할 수 있다)
+	attemptWindow = 15 * time.Minute
+	// maxTokenFailures - 링크 하나에 허용하는 실패 횟수 (IP를 바꿔 가며 추측해도 막힌다)
+	maxTokenFailures = 10
+	// maxClientFailures - 한 IP에 허용하는 실패 횟수 (여러 링크에 걸쳐)
+	maxClientFailures = 30
+
+	attemptPrefix = "share:pwfail:"
+)
+
+// attemptLimiter counts failed share password attempts per link and per client IP.
+// Redis가 없거나 실패하면 프로세스 메모리로 센다 (ratelimit.Debouncer와 같은 방식).
+type attemptLimiter struct {
+	redis *redis.Client
+	mu    sync.Mutex
+	local map[string]*attemptCount
+}
+
+type attemptCount struct {
+	n         int
+	expiresAt time.Time
+}
+
+func newAttemptLimiter(redisClient *redis.Client) *attemptLimiter {
+	return &attemptLimiter{redis: redisClient, local: make(map[string]*attemptCount)}
+}
+
+// blocked reports whether the link or the client used up its failures.
+func (l *attemptLimiter) blocked(ctx context.Context, tokenHash, client string) bool {
+	if l.failures(ctx, "token:"+tokenHash) >= maxTokenFailures {
+		return true
+	}
+	return client != "" && l.failures(ctx, "ip:"+client) >= maxClientFailures
+}
+
+// fail records a wrong password for the link and the client.
+func (l *attemptLimiter) fail(ctx context.Context, tokenHash, client string) {
+	l.incr(ctx, "token:"+tokenHash)
+	if client != "" {
+		l.incr(ctx, "ip:"+client)
+	}
+}
+
+func (l *attemptLimiter) failures(ctx context.Context, key string) int {
+	if l.redis != nil {
+		n, err := l.redis.Get(ctx, attemptPrefix+key).Int()
+		if err == nil || err == redis.Nil {
+			return n
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if c, ok := l.local[key]; ok && time.Now().Before(c.expiresAt) {
+		return c.n
+	}
+	return 0
+}
+
+func (l *attemptLimiter) incr(ctx context.Context, key string) {
+	if l.redis != nil {
+		n, err := l.redis.Incr(ctx, attemptPrefix+key).Result()
+		if err == nil {
+			if n == 1 {
+				l.redis.Expire(ctx, attemptPrefix+key, attemptWindow)
+			}
+			return
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	c, ok := l.local[key]
+	if !ok || !now.Before(c.expiresAt) {
+		// 만료된 항목 정리
+		for k, v := range l.local {
+			if !now.Before(v.expiresAt) {
+				delete(l.local, k)
+			}
+		}
+		c = &attemptCount{expiresAt: now.Add(attemptWindow)}
+		l.local[key] = c
+	}
+	c.n++
+}
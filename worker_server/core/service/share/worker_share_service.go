@@ -0,0 +1,357 @@
+// Package share creates expiring, read-only public links to emails for people outside the system.
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/core/service/common"
+	"worker_server/pkg/crypto"
+	"worker_server/pkg/htmlsanitize"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Path is the public route prefix that share links point to.
+const Path = "/api/v1/share"
+
+const (
+	DefaultExpiry = 7 * 24 * time.Hour
+	MaxExpiry     = 30 * 24 * time.Hour
+	// attachmentURLTTL - 공유 페이지에서 발급하는 첨부파일 다운로드 URL 유효 시간
+	attachmentURLTTL  = time.Hour
+	minPasswordLength = 8
+	maxPasswordLength = 72 // bcrypt 입력 한도
+	maxAttachments    = 20
+)
+
+var (
+	ErrShareNotFound     = errors.New("share link not found")
+	ErrShareExpired      = errors.New("share link expired or revoked")
+	ErrEmailNotFound     = errors.New("email not found")
+	ErrInvalidExpiry     = errors.New("expiry must be between 1 hour and 30 days")
+	ErrInvalidPassword   = errors.New("password must be 8-72 characters")
+	ErrInvalidAttachment = errors.New("attachment does not belong to the email")
+	ErrPasswordRequired  = errors.New("password required")
+	ErrWrongPassword     = errors.New("wrong password")
+	ErrInvalidSignature  = errors.New("invalid or expired download link")
+	ErrTooManyAttempts   = errors.New("too many wrong passwords, try again later")
+)
+
+// EmailReader loads the shared email (mail.Service).
+type EmailReader interface {
+	GetEmail(ctx context.Context, userID uuid.UUID, emailID int64) (*domain.Email, error)
+	GetEmailBody(ctx context.Context, emailID int64) (*domain.EmailBody, error)
+}
+
+// CreateRequest is the input for creating a share link.
+type CreateRequest struct {
+	ExpiresIn     time.Duration // 0이면 DefaultExpiry
+	Password      string        // 비어 있으면 비밀번호 없음
+	AttachmentIDs []int64
+}
+
+// Service manages share links and renders the shared view.
+type Service struct {
+	repo        out.EmailShareRepository
+	emails      EmailReader
+	attachments out.AttachmentRepository
+	signer      *crypto.URLSigner
+	baseURL     string
+	attempts    *attemptLimiter
+}
+
+// NewService creates a new share service.
+// baseURL must be the public origin of this server - 외부 사용자가 직접 연다.
+func NewService(repo out.EmailShareRepository, emails EmailReader, attachments out.AttachmentRepository, secret, baseURL string) *Service {
+	return &Service{
+		repo:        repo,
+		emails:      emails,
+		attachments: attachments,
+		signer:      crypto.NewURLSigner(secret),
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		attempts:    newAttemptLimiter(nil),
+	}
+}
+
+// SetRedis shares failed password counts across instances (없으면 인스턴스별로 센다).
+func (s *Service) SetRedis(redisClient *redis.Client) {
+	s.attempts = newAttemptLimiter(redisClient)
+}
+
+// Create creates a share link for an email of the user.
+// 반환값의 URL은 이때만 받을 수 있다 (토큰은 해시만 저장).
+func (s *Service) Create(ctx context.Context, userID uuid.UUID, emailID int64, req *CreateRequest) (*domain.EmailShare, error) {
+	expiresIn := req.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = DefaultExpiry
+	}
+	if expiresIn < time.Hour || expiresIn > MaxExpiry {
+		return nil, ErrInvalidExpiry
+	}
+
+	if _, err := s.emails.GetEmail(ctx, userID, emailID); err != nil {
+		if errors.Is(err, common.ErrForbidden) {
+			return nil, ErrEmailNotFound
+		}
+		return nil, err
+	}
+
+	attachmentIDs, err := s.checkAttachments(ctx, emailID, req.AttachmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	share := &domain.EmailShare{
+		EmailID:       emailID,
+		UserID:        userID,
+		AttachmentIDs: attachmentIDs,
+		ExpiresAt:     time.Now().Add(expiresIn),
+	}
+	if req.Password != "" {
+		n := utf8.RuneCountInString(req.Password)
+		if n < minPasswordLength || len(req.Password) > maxPasswordLength {
+			return nil, ErrInvalidPassword
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		share.PasswordHash = string(hash)
+		share.HasPassword = true
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	share.TokenHash = hashToken(token)
+
+	if err := s.repo.Create(ctx, share); err != nil {
+		if errors.Is(err, out.ErrEmailShareNotFound) {
+			return nil, ErrEmailNotFound
+		}
+		return nil, err
+	}
+	share.URL = s.baseURL + Path + "/" + token
+	return share, nil
+}
+
+// List returns the share links of an email with their view counts.
+func (s *Service) List(ctx context.Context, userID uuid.UUID, emailID int64) ([]*domain.EmailShare, error) {
+	return s.repo.ListByEmailID(ctx, userID, emailID)
+}
+
+// Revoke disables a share link immediately.
+func (s *Service) Revoke(ctx context.Context, userID uuid.UUID, emailID, shareID int64) error {
+	if err := s.repo.Revoke(ctx, userID, emailID, shareID); err != nil {
+		if errors.Is(err, out.ErrEmailShareNotFound) {
+			return ErrShareNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Open returns the active share link for token after checking its password.
+// 공개 경로라 API rate limit이 없으므로, 링크별/클라이언트 IP별 실패 횟수를 넘으면 bcrypt 전에 거부한다.
+func (s *Service) Open(ctx context.Context, token, password, clientIP string) (*domain.EmailShare, error) {
+	share, err := s.active(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if share.HasPassword {
+		if password == "" {
+			return nil, ErrPasswordRequired
+		}
+		if s.attempts.blocked(ctx, share.TokenHash, clientIP) {
+			return nil, ErrTooManyAttempts
+		}
+		if bcrypt.CompareHashAndPassword([]byte(share.PasswordHash), []byte(password)) != nil {
+			s.attempts.fail(ctx, share.TokenHash, clientIP)
+			return nil, ErrWrongPassword
+		}
+	}
+	return share, nil
+}
+
+// View renders the sanitized read-only email for a share link and counts the view.
+// 스크립트/폼/스타일 블록을 제거하고(strict), 열람자 추적을 막기 위해 원격 이미지도 제거한다.
+func (s *Service) View(ctx context.Context, token, password, clientIP string) (*domain.SharedEmail, error) {
+	share, err := s.Open(ctx, token, password, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := s.emails.GetEmail(ctx, share.UserID, share.EmailID)
+	if err != nil {
+		return nil, err
+	}
+	view := &domain.SharedEmail{
+		Subject:     email.Subject,
+		FromEmail:   email.FromEmail,
+		Date:        email.Date,
+		Attachments: []*domain.SharedAttachment{},
+		ExpiresAt:   share.ExpiresAt,
+	}
+	if email.FromName != nil {
+		view.FromName = *email.FromName
+	}
+
+	body, err := s.emails.GetEmailBody(ctx, share.EmailID)
+	if err != nil {
+		logger.WithError(err).Warn("[Share.View] Failed to load body of email %d", share.EmailID)
+	} else if body != nil {
+		if body.HTMLBody != "" {
+			cleaned := htmlsanitize.Sanitize(body.HTMLBody, htmlsanitize.LevelStrict).HTML
+			view.HTML, _, _ = htmlsanitize.RewriteImages(cleaned, func(string) (string, bool) { return "", true })
+		} else {
+			view.Text = body.TextBody
+		}
+	}
+
+	if len(share.AttachmentIDs) > 0 && s.attachments != nil {
+		attachments, err := s.attachments.GetByIDs(ctx, share.AttachmentIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range attachments {
+			if a.EmailID != share.EmailID {
+				continue
+			}
+			view.Attachments = append(view.Attachments, &domain.SharedAttachment{
+				ID:       a.ID,
+				Filename: a.Filename,
+				MimeType: a.MimeType,
+				Size:     a.Size,
+				URL:      s.attachmentURL(token, share, a.ID),
+			})
+		}
+	}
+
+	if err := s.repo.RecordView(ctx, share.ID); err != nil {
+		logger.WithError(err).Warn("[Share.View] Failed to record view of share %d", share.ID)
+	}
+	return view, nil
+}
+
+// Attachment checks a signed download URL and returns the shared attachment.
+// 비밀번호는 View에서 확인했으므로 서명만 검증하며, 폐기/만료된 링크는 거부한다.
+func (s *Service) Attachment(ctx context.Context, token string, attachmentID int64, exp, sig string) (*domain.EmailShare, *out.EmailAttachmentEntity, error) {
+	share, err := s.active(ctx, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.signer.Verify(attachmentPayload(share.ID, attachmentID), exp, sig); err != nil {
+		return nil, nil, ErrInvalidSignature
+	}
+
+	shared := false
+	for _, id := range share.AttachmentIDs {
+		if id == attachmentID {
+			shared = true
+			break
+		}
+	}
+	if !shared || s.attachments == nil {
+		return nil, nil, ErrShareNotFound
+	}
+
+	attachment, err := s.attachments.GetByID(ctx, attachmentID)
+	if err != nil || attachment == nil || attachment.EmailID != share.EmailID {
+		return nil, nil, ErrShareNotFound
+	}
+	return share, attachment, nil
+}
+
+func (s *Service) active(ctx context.Context, token string) (*domain.EmailShare, error) {
+	if token == "" {
+		return nil, ErrShareNotFound
+	}
+	share, err := s.repo.GetByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, out.ErrEmailShareNotFound) {
+			return nil, ErrShareNotFound
+		}
+		return nil, err
+	}
+	if !share.Active(time.Now()) {
+		return nil, ErrShareExpired
+	}
+	return share, nil
+}
+
+// checkAttachments keeps the requested attachments that belong to the email (인라인 제외).
+func (s *Service) checkAttachments(ctx context.Context, emailID int64, ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return []int64{}, nil
+	}
+	if len(ids) > maxAttachments || s.attachments == nil {
+		return nil, ErrInvalidAttachment
+	}
+
+	attachments, err := s.attachments.GetByEmailID(ctx, emailID)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[int64]bool, len(attachments))
+	for _, a := range attachments {
+		if !a.IsInline {
+			owned[a.ID] = true
+		}
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !owned[id] {
+			return nil, ErrInvalidAttachment
+		}
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+// attachmentURL signs a download URL that expires with the link or after attachmentURLTTL.
+func (s *Service) attachmentURL(token string, share *domain.EmailShare, attachmentID int64) string {
+	expiresAt := time.Now().Add(attachmentURLTTL)
+	if share.ExpiresAt.Before(expiresAt) {
+		expiresAt = share.ExpiresAt
+	}
+	exp, sig := s.signer.Sign(attachmentPayload(share.ID, attachmentID), expiresAt)
+	return s.baseURL + Path + "/" + token + "/attachments/" + strconv.FormatInt(attachmentID, 10) + "?exp=" + exp + "&sig=" + sig
+}
+
+func attachmentPayload(shareID, attachmentID int64) string {
+	return "share:" + strconv.FormatInt(shareID, 10) + ":" + strconv.FormatInt(attachmentID, 10)
+}
+
+// newToken returns a 256-bit random URL-safe token.
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,220 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+type memShareRepo struct {
+	shares []*domain.EmailShare
+	views  map[int64]int
+}
+
+func (r *memShareRepo) Create(_ context.Context, share *domain.EmailShare) error {
+	share.ID = int64(len(r.shares) + 1)
+	r.shares = append(r.shares, share)
+	return nil
+}
+
+func (r *memShareRepo) GetByTokenHash(_ context.Context, tokenHash string) (*domain.EmailShare, error) {
+	for _, s := range r.shares {
+		if s.TokenHash == tokenHash {
+			return s, nil
+		}
+	}
+	return nil, out.ErrEmailShareNotFound
+}
+
+func (r *memShareRepo) ListByEmailID(_ context.Context, _ uuid.UUID, _ int64) ([]*domain.EmailShare, error) {
+	return r.shares, nil
+}
+
+func (r *memShareRepo) Revoke(_ context.Context, _ uuid.UUID, _, shareID int64) error {
+	for _, s := range r.shares {
+		if s.ID == shareID {
+			now := time.Now()
+			s.RevokedAt = &now
+			return nil
+		}
+	}
+	return out.ErrEmailShareNotFound
+}
+
+func (r *memShareRepo) RecordView(_ context.Context, shareID int64) error {
+	r.views[shareID]++
+	return nil
+}
+
+type fakeEmails struct {
+	owner uuid.UUID
+	body  *domain.EmailBody
+}
+
+func (f *fakeEmails) GetEmail(_ context.Context, userID uuid.UUID, emailID int64) (*domain.Email, error) {
+	if userID != f.owner {
+		return nil, errors.New("forbidden")
+	}
+	return &domain.Email{ID: emailID, UserID: userID, Subject: "Quarterly numbers", FromEmail: "cfo@example.com"}, nil
+}
+
+func (f *fakeEmails) GetEmailBody(_ context.Context, emailID int64) (*domain.EmailBody, error) {
+	return f.body, nil
+}
+
+func newTestService(owner uuid.UUID) (*Service, *memShareRepo) {
+	repo := &memShareRepo{views: make(map[int64]int)}
+	emails := &fakeEmails{owner: owner, body: &domain.EmailBody{
+		HTMLBody: `<p onclick="x()">Hi</p><script>alert(1)</script><img src="https://tracker.example.com/p.gif">`,
+	}}
+	return NewService(repo, emails, nil, "secret", "https://mail.example.com/"), repo
+}
+
+func tokenOf(t *testing.T, share *domain.EmailShare) string {
+	t.Helper()
+	u, err := url.Parse(share.URL)
+	if err != nil {
+		t.Fatalf("invalid share url %q: %v", share.URL, err)
+	}
+	return strings.TrimPrefix(u.Path, Path+"/")
+}
+
+func TestCreateStoresOnlyTokenHash(t *testing.T) {
+	owner := uuid.New()
+	svc, repo := newTestService(owner)
+
+	share, err := svc.Create(context.Background(), owner, 7, &CreateRequest{})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !strings.HasPrefix(share.URL, "https://mail.example.com/api/v1/share/") {
+		t.Errorf("unexpected url %q", share.URL)
+	}
+	token := tokenOf(t, share)
+	if strings.Contains(repo.shares[0].TokenHash, token) || repo.shares[0].TokenHash != hashToken(token) {
+		t.Errorf("token must be stored hashed")
+	}
+	if d := time.Until(share.ExpiresAt); d < DefaultExpiry-time.Minute || d > DefaultExpiry {
+		t.Errorf("default expiry = %v", d)
+	}
+
+	if _, err := svc.Create(context.Background(), owner, 7, &CreateRequest{ExpiresIn: MaxExpiry + time.Hour}); !errors.Is(err, ErrInvalidExpiry) {
+		t.Errorf("too long expiry: got %v", err)
+	}
+	if _, err := svc.Create(context.Background(), owner, 7, &CreateRequest{AttachmentIDs: []int64{1}}); !errors.Is(err, ErrInvalidAttachment) {
+		t.Errorf("attachment without repository: got %v", err)
+	}
+}
+
+func TestViewChecksPasswordAndSanitizes(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.New()
+	svc, repo := newTestService(owner)
+
+	share, err := svc.Create(ctx, owner, 7, &CreateRequest{Password: "hunter22"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	token := tokenOf(t, share)
+
+	if _, err := svc.View(ctx, token, "", "203.0.113.1"); !errors.Is(err, ErrPasswordRequired) {
+		t.Errorf("no password: got %v", err)
+	}
+	if _, err := svc.View(ctx, token, "wrong", "203.0.113.1"); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("wrong password: got %v", err)
+	}
+	if _, err := svc.View(ctx, "unknown", "hunter22", "203.0.113.1"); !errors.Is(err, ErrShareNotFound) {
+		t.Errorf("unknown token: got %v", err)
+	}
+
+	view, err := svc.View(ctx, token, "hunter22", "203.0.113.1")
+	if err != nil {
+		t.Fatalf("View() error = %v", err)
+	}
+	if strings.Contains(view.HTML, "script") || strings.Contains(view.HTML, "onclick") || strings.Contains(view.HTML, "tracker.example.com") {
+		t.Errorf("active content or remote image left in shared html: %s", view.HTML)
+	}
+	if !strings.Contains(view.HTML, "Hi") || view.Subject != "Quarterly numbers" {
+		t.Errorf("unexpected view %+v", view)
+	}
+	if repo.views[share.ID] != 1 {
+		t.Errorf("view count = %d, want 1", repo.views[share.ID])
+	}
+
+	if err := svc.Revoke(ctx, owner, 7, share.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if _, err := svc.View(ctx, token, "hunter22", "203.0.113.1"); !errors.Is(err, ErrShareExpired) {
+		t.Errorf("revoked link: got %v", err)
+	}
+}
+
+func TestViewLocksOutRepeatedWrongPasswords(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.New()
+	svc, _ := newTestService(owner)
+
+	share, err := svc.Create(ctx, owner, 7, &CreateRequest{Password: "hunter22"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	token := tokenOf(t, share)
+
+	if _, err := svc.Create(ctx, owner, 7, &CreateRequest{Password: "1234"}); !errors.Is(err, ErrInvalidPassword) {
+		t.Errorf("short password: got %v", err)
+	}
+
+	// 링크별 한도: IP를 바꿔 가며 추측해도 막힌다
+	for i := 0; i < maxTokenFailures; i++ {
+		if _, err := svc.View(ctx, token, "wrong", fmt.Sprintf("198.51.100.%d", i)); !errors.Is(err, ErrWrongPassword) {
+			t.Fatalf("attempt %d: got %v", i, err)
+		}
+	}
+	if _, err := svc.View(ctx, token, "hunter22", "192.0.2.1"); !errors.Is(err, ErrTooManyAttempts) {
+		t.Errorf("after %d failures: got %v, want ErrTooManyAttempts", maxTokenFailures, err)
+	}
+
+	// 클라이언트별 한도: 링크마다 한도 아래로 나눠 추측해도 막힌다
+	other, err := svc.Create(ctx, owner, 7, &CreateRequest{Password: "hunter22"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	svc.attempts.local["ip:192.0.2.9"] = &attemptCount{n: maxClientFailures, expiresAt: time.Now().Add(time.Minute)}
+	if _, err := svc.View(ctx, tokenOf(t, other), "hunter22", "192.0.2.9"); !errors.Is(err, ErrTooManyAttempts) {
+		t.Errorf("blocked client: got %v, want ErrTooManyAttempts", err)
+	}
+	if _, err := svc.View(ctx, tokenOf(t, other), "hunter22", "192.0.2.10"); err != nil {
+		t.Errorf("other client: got %v", err)
+	}
+}
+
+func TestAttachmentRequiresSignature(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.New()
+	svc, repo := newTestService(owner)
+	share, _ := svc.Create(ctx, owner, 7, &CreateRequest{})
+	token := tokenOf(t, share)
+	repo.shares[0].AttachmentIDs = []int64{3}
+
+	exp, sig := svc.signer.Sign(attachmentPayload(share.ID, 3), time.Now().Add(time.Minute))
+	if _, _, err := svc.Attachment(ctx, token, 3, exp, sig+"0"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("tampered signature: got %v", err)
+	}
+	if _, _, err := svc.Attachment(ctx, token, 4, exp, sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("signature of another attachment: got %v", err)
+	}
+
+	repo.shares[0].ExpiresAt = time.Now().Add(-time.Second)
+	if _, _, err := svc.Attachment(ctx, token, 3, exp, sig); !errors.Is(err, ErrShareExpired) {
+		t.Errorf("expired link: got %v", err)
+	}
+}
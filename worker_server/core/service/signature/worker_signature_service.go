@@ -0,0 +1,250 @@
+// Package signature manages email signatures (Neo4j PersonalizationStore) and send-time injection.
+package signature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	"worker_server/core/port/out"
+	"worker_server/pkg/htmlsanitize"
+
+	"github.com/google/uuid"
+	xhtml "golang.org/x/net/html"
+)
+
+// maxSignatureSize - 서명 한 개의 최대 크기 (text + html)
+const maxSignatureSize = 64 * 1024
+
+var (
+	ErrNotFound = errors.New("signature not found")
+	ErrEmpty    = errors.New("signature text or html required")
+	ErrTooLarge = errors.New("signature too large")
+)
+
+// SaveRequest is the input for creating or updating a signature.
+type SaveRequest struct {
+	Name      string
+	Text      string
+	HTML      string
+	IsDefault bool
+}
+
+// Service manages user signatures.
+type Service struct {
+	store out.PersonalizationStore
+}
+
+// NewService creates a new signature service.
+func NewService(store out.PersonalizationStore) *Service {
+	return &Service{store: store}
+}
+
+// List returns all signatures of the user (default first).
+func (s *Service) List(ctx context.Context, userID uuid.UUID) ([]*out.Signature, error) {
+	signatures, err := s.store.GetSignatures(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	if signatures == nil {
+		signatures = []*out.Signature{}
+	}
+	return signatures, nil
+}
+
+// Get returns a signature by ID.
+func (s *Service) Get(ctx context.Context, userID uuid.UUID, signatureID string) (*out.Signature, error) {
+	signatures, err := s.store.GetSignatures(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, sig := range signatures {
+		if sig.ID == signatureID {
+			return sig, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Create creates a signature. 첫 서명은 자동으로 기본 서명이 된다.
+func (s *Service) Create(ctx context.Context, userID uuid.UUID, req *SaveRequest) (*out.Signature, error) {
+	sig := &out.Signature{ID: uuid.NewString()}
+	if err := fill(sig, req); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.store.GetSignatures(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SaveSignature(ctx, userID.String(), sig); err != nil {
+		return nil, err
+	}
+	if req.IsDefault || len(existing) == 0 {
+		if err := s.store.SetDefaultSignature(ctx, userID.String(), sig.ID); err != nil {
+			return nil, err
+		}
+		sig.IsDefault = true
+	}
+	return sig, nil
+}
+
+// Update replaces the content of a signature.
+func (s *Service) Update(ctx context.Context, userID uuid.UUID, signatureID string, req *SaveRequest) (*out.Signature, error) {
+	sig, err := s.Get(ctx, userID, signatureID)
+	if err != nil {
+		return nil, err
+	}
+	if err := fill(sig, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SaveSignature(ctx, userID.String(), sig); err != nil {
+		return nil, err
+	}
+	if req.IsDefault && !sig.IsDefault {
+		if err := s.store.SetDefaultSignature(ctx, userID.String(), sig.ID); err != nil {
+			return nil, err
+		}
+		sig.IsDefault = true
+	}
+	return sig, nil
+}
+
+// Delete deletes a signature.
+func (s *Service) Delete(ctx context.Context, userID uuid.UUID, signatureID string) error {
+	if _, err := s.Get(ctx, userID, signatureID); err != nil {
+		return err
+	}
+	return s.store.DeleteSignature(ctx, userID.String(), signatureID)
+}
+
+// SetDefault sets the user's default signature.
+func (s *Service) SetDefault(ctx context.Context, userID uuid.UUID, signatureID string) error {
+	if _, err := s.Get(ctx, userID, signatureID); err != nil {
+		return err
+	}
+	return s.store.SetDefaultSignature(ctx, userID.String(), signatureID)
+}
+
+// SetConnectionDefault sets the default signature of a connection. Empty signatureID clears it
+// so the user default is used again.
+func (s *Service) SetConnectionDefault(ctx context.Context, userID uuid.UUID, connectionID int64, signatureID string) error {
+	if signatureID != "" {
+		if _, err := s.Get(ctx, userID, signatureID); err != nil {
+			return err
+		}
+	}
+	return s.store.SetConnectionSignature(ctx, userID.String(), connectionID, signatureID)
+}
+
+// Resolve picks the signature to send with: explicit ID > connection default > user default.
+// Returns nil if the user has no applicable signature.
+func (s *Service) Resolve(ctx context.Context, userID uuid.UUID, connectionID int64, signatureID string) (*out.Signature, error) {
+	signatures, err := s.store.GetSignatures(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if signatureID != "" {
+		for _, sig := range signatures {
+			if sig.ID == signatureID {
+				return sig, nil
+			}
+		}
+		return nil, ErrNotFound
+	}
+
+	var fallback *out.Signature
+	for _, sig := range signatures {
+		for _, id := range sig.ConnectionIDs {
+			if id == connectionID {
+				return sig, nil
+			}
+		}
+		if sig.IsDefault && fallback == nil {
+			fallback = sig
+		}
+	}
+	return fallback, nil
+}
+
+// Apply appends the signature to a message body using the variant that matches the body format.
+func Apply(body string, isHTML bool, sig *out.Signature) string {
+	if sig == nil || (sig.Text == "" && sig.HTML == "") {
+		return body
+	}
+
+	if !isHTML {
+		text := sig.Text
+		if text == "" {
+			text = TextFromHTML(sig.HTML)
+		}
+		// RFC 3676 서명 구분자 "-- "
+		return strings.TrimRight(body, "\r\n") + "\n\n-- \n" + text
+	}
+
+	sigHTML := sig.HTML
+	if sigHTML == "" {
+		sigHTML = strings.ReplaceAll(html.EscapeString(sig.Text), "\n", "<br>")
+	}
+	block := `<br><div class="signature">` + sigHTML + `</div>`
+
+	if i := strings.LastIndex(strings.ToLower(body), "</body>"); i >= 0 {
+		return body[:i] + block + body[i:]
+	}
+	return body + block
+}
+
+// TextFromHTML converts a signature HTML fragment to plain text.
+func TextFromHTML(src string) string {
+	var b strings.Builder
+	z := xhtml.NewTokenizer(strings.NewReader(src))
+	skip := 0
+	for {
+		switch z.Next() {
+		case xhtml.ErrorToken:
+			return strings.TrimSpace(b.String())
+		case xhtml.TextToken:
+			if skip == 0 {
+				b.WriteString(strings.Join(strings.Fields(string(z.Text())), " "))
+			}
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "style", "script":
+				skip++
+			case "br", "p", "div", "tr", "li":
+				b.WriteString("\n")
+			}
+		case xhtml.EndTagToken:
+			name, _ := z.TagName()
+			if n := string(name); (n == "style" || n == "script") && skip > 0 {
+				skip--
+			}
+		}
+	}
+}
+
+// fill validates a request and copies it into sig. HTML은 발송 메일에 그대로 들어가므로 sanitize한다.
+func fill(sig *out.Signature, req *SaveRequest) error {
+	text := strings.TrimSpace(req.Text)
+	sigHTML := strings.TrimSpace(req.HTML)
+	if text == "" && sigHTML == "" {
+		return ErrEmpty
+	}
+	if len(text)+len(sigHTML) > maxSignatureSize {
+		return fmt.Errorf("%w: max %d bytes", ErrTooLarge, maxSignatureSize)
+	}
+	if sigHTML != "" {
+		sigHTML = htmlsanitize.Sanitize(sigHTML, htmlsanitize.LevelStandard).HTML
+	}
+
+	sig.Name = strings.TrimSpace(req.Name)
+	sig.Text = text
+	sig.HTML = sigHTML
+	return nil
+}
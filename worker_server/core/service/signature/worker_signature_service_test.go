@@ -0,0 +1,35 @@
+package signature
+
+import (
+	"strings"
+	"testing"
+
+	"worker_server/core/port/out"
+)
+
+func TestApplyPlain(t *testing.T) {
+	sig := &out.Signature{HTML: "<p>Jane Doe</p><p>ACME &amp; Co</p>"}
+
+	got := Apply("Hello\n\n", false, sig)
+	want := "Hello\n\n-- \nJane Doe\nACME & Co"
+	if got != want {
+		t.Errorf("Apply plain = %q, want %q", got, want)
+	}
+}
+
+func TestApplyHTML(t *testing.T) {
+	sig := &out.Signature{Text: "Jane <CEO>\nACME"}
+
+	got := Apply("<html><body><p>Hi</p></BODY></html>", true, sig)
+	want := `<html><body><p>Hi</p><br><div class="signature">Jane &lt;CEO&gt;<br>ACME</div></BODY></html>`
+	if got != want {
+		t.Errorf("Apply html = %q, want %q", got, want)
+	}
+
+	if got := Apply("<p>Hi</p>", true, &out.Signature{HTML: "<b>Jane</b>"}); !strings.HasSuffix(got, `<div class="signature"><b>Jane</b></div>`) {
+		t.Errorf("Apply fragment = %q", got)
+	}
+	if got := Apply("body", true, nil); got != "body" {
+		t.Errorf("Apply nil signature changed body: %q", got)
+	}
+}
@@ -0,0 +1,357 @@
+// Package storage measures storage consumed per connection and decides which connections store metadata only
+// (사용자 sync mode 또는 용량 상한 초과).
+package storage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// resumeRatio - metadata-only 해제 기준 (상한의 90% 아래로 내려가야 다시 저장한다)
+	resumeRatio = 0.9
+
+	// modeCacheTTL - MetadataOnly 조회 결과 캐시 (동기화 경로에서 매 메일마다 DB를 조회하지 않도록)
+	modeCacheTTL = time.Minute
+
+	measureTimeout = 30 * time.Second
+
+	// purgeBatch/purgeTimeout - metadata 모드 전환 시 기존 본문/임베딩 정리
+	purgeBatch   = 500
+	purgeTimeout = 30 * time.Minute
+)
+
+var (
+	ErrConnectionNotFound = errors.New("connection not found")
+	ErrInvalidCap         = errors.New("cap must be >= 0")
+	ErrInvalidSyncMode    = errors.New("sync mode must be full or metadata")
+	ErrLocalSyncMode      = errors.New("imported connections must keep full sync")
+)
+
+// ConnectionProvider resolves connections (auth.OAuthService).
+type ConnectionProvider interface {
+	GetConnection(ctx context.Context, connectionID int64) (*domain.OAuthConnection, error)
+}
+
+// Report summarizes one enforcement pass.
+type Report struct {
+	Measured     int `json:"measured"`
+	MetadataOnly int `json:"metadata_only"`
+	Entered      int `json:"entered"`
+	Resumed      int `json:"resumed"`
+	Failed       int `json:"failed"`
+}
+
+type modeEntry struct {
+	metadataOnly bool
+	at           time.Time
+}
+
+// Service tracks per-connection storage usage and enforces caps.
+type Service struct {
+	repo        out.ConnectionStorageRepository
+	bodies      out.EmailBodyRepository // nil이면 본문 용량은 0으로 집계
+	vectors     out.VectorStorePort     // nil이면 metadata 모드 전환 시 임베딩을 지우지 않음
+	connections ConnectionProvider
+	defaultCap  int64 // 0 = 상한 없음
+
+	modes sync.Map // connectionID → modeEntry
+
+	// purge는 요청과 무관하게 백그라운드에서 실행된다 (테스트에서 대기용)
+	inflight sync.WaitGroup
+	now      func() time.Time
+}
+
+// NewService creates a storage usage service. defaultCapBytes <= 0 disables the default cap.
+func NewService(repo out.ConnectionStorageRepository, bodies out.EmailBodyRepository, connections ConnectionProvider, defaultCapBytes int64) *Service {
+	if defaultCapBytes < 0 {
+		defaultCapBytes = 0
+	}
+	return &Service{
+		repo:        repo,
+		bodies:      bodies,
+		connections: connections,
+		defaultCap:  defaultCapBytes,
+		now:         time.Now,
+	}
+}
+
+// SetVectorStore deletes stored embeddings when a connection switches to metadata mode.
+func (s *Service) SetVectorStore(vectors out.VectorStorePort) {
+	s.vectors = vectors
+}
+
+// EffectiveCap returns the cap applied to a connection (0 = 상한 없음).
+func (s *Service) EffectiveCap(usage *domain.ConnectionStorage) int64 {
+	if usage.CapBytes != nil {
+		return *usage.CapBytes
+	}
+	return s.defaultCap
+}
+
+// Usage measures a connection of the user and applies its cap.
+func (s *Service) Usage(ctx context.Context, userID uuid.UUID, connectionID int64) (*domain.ConnectionStorage, error) {
+	prev, err := s.stored(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	usage, _, err := s.measure(ctx, prev)
+	return usage, err
+}
+
+// SetCap sets the per-connection cap (nil = 기본값, 0 = 상한 없음) and re-applies it.
+func (s *Service) SetCap(ctx context.Context, userID uuid.UUID, connectionID int64, capBytes *int64) (*domain.ConnectionStorage, error) {
+	if capBytes != nil && *capBytes < 0 {
+		return nil, ErrInvalidCap
+	}
+	prev, err := s.stored(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetCap(ctx, connectionID, userID.String(), capBytes); err != nil {
+		return nil, err
+	}
+	prev.CapBytes = capBytes
+	usage, _, err := s.measure(ctx, prev)
+	return usage, err
+}
+
+// SetSyncMode sets the sync mode of the user's connection.
+// metadata로 바꾸면 이미 저장된 본문과 임베딩을 백그라운드에서 지운다 (메일 메타데이터는 유지).
+// 가져온(local) 연결은 본문을 다시 가져올 수 없으므로 metadata 모드를 거부한다.
+func (s *Service) SetSyncMode(ctx context.Context, userID uuid.UUID, connectionID int64, mode domain.ConnectionSyncMode) (*domain.ConnectionStorage, error) {
+	if !mode.IsValid() {
+		return nil, ErrInvalidSyncMode
+	}
+	prev, err := s.stored(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if prev.Local && mode == domain.ConnectionSyncMetadata {
+		return nil, ErrLocalSyncMode
+	}
+	if err := s.repo.SetSyncMode(ctx, connectionID, userID.String(), mode); err != nil {
+		return nil, err
+	}
+
+	switching := mode == domain.ConnectionSyncMetadata && prev.SyncMode != mode
+	prev.SyncMode = mode
+	usage, _, err := s.measure(ctx, prev)
+	if err != nil {
+		return nil, err
+	}
+	if switching {
+		logger.Info("[Storage] connection=%d switched to metadata-only sync, purging bodies and embeddings", connectionID)
+		s.schedulePurge(connectionID)
+	}
+	return usage, nil
+}
+
+// EnforceAll measures every connection and switches metadata-only mode on or off.
+func (s *Service) EnforceAll(ctx context.Context) (*Report, error) {
+	connections, err := s.repo.ListConnections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	for _, prev := range connections {
+		if ctx.Err() != nil {
+			break
+		}
+		mctx, cancel := context.WithTimeout(ctx, measureTimeout)
+		usage, changed, err := s.measure(mctx, prev)
+		cancel()
+		if err != nil {
+			logger.WithError(err).Warn("[Storage] connection=%d measure failed", prev.ConnectionID)
+			report.Failed++
+			continue
+		}
+		report.Measured++
+		if usage.MetadataOnly {
+			report.MetadataOnly++
+		}
+		if changed {
+			if usage.MetadataOnly {
+				report.Entered++
+			} else {
+				report.Resumed++
+			}
+		}
+	}
+	return report, nil
+}
+
+// MetadataOnly reports whether bodies and embeddings of the connection must not be stored
+// (metadata 동기화 모드 또는 상한 초과). 조회 실패 시에는 저장을 막지 않는다 (fail open).
+func (s *Service) MetadataOnly(ctx context.Context, connectionID int64) bool {
+	if connectionID == 0 {
+		return false
+	}
+	if v, ok := s.modes.Load(connectionID); ok {
+		if e := v.(modeEntry); s.now().Sub(e.at) < modeCacheTTL {
+			return e.metadataOnly
+		}
+	}
+
+	usage, err := s.repo.Get(ctx, connectionID)
+	if err != nil {
+		logger.WithError(err).Debug("[Storage] connection=%d mode lookup failed", connectionID)
+		return false
+	}
+	metadataOnly := usage != nil && usage.SkipsBodies()
+	s.modes.Store(connectionID, modeEntry{metadataOnly: metadataOnly, at: s.now()})
+	return metadataOnly
+}
+
+// stored returns the last measurement of the user's connection (측정 전이면 빈 값).
+func (s *Service) stored(ctx context.Context, userID uuid.UUID, connectionID int64) (*domain.ConnectionStorage, error) {
+	conn, err := s.connections.GetConnection(ctx, connectionID)
+	if err != nil || conn == nil || conn.UserID != userID {
+		return nil, ErrConnectionNotFound
+	}
+	prev, err := s.repo.Get(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if prev == nil {
+		prev = &domain.ConnectionStorage{ConnectionID: connectionID, SyncMode: domain.ConnectionSyncFull}
+	}
+	prev.UserID = userID.String()
+	prev.Local = conn.Provider == domain.ProviderLocal
+	return prev, nil
+}
+
+// measure re-measures usage on top of prev (cap/mode) and saves it. changed는 모드 전환 여부.
+func (s *Service) measure(ctx context.Context, prev *domain.ConnectionStorage) (*domain.ConnectionStorage, bool, error) {
+	usage := &domain.ConnectionStorage{
+		ConnectionID:      prev.ConnectionID,
+		UserID:            prev.UserID,
+		SyncMode:          prev.SyncMode,
+		CapBytes:          prev.CapBytes,
+		MetadataOnly:      prev.MetadataOnly,
+		MetadataOnlySince: prev.MetadataOnlySince,
+		Local:             prev.Local,
+	}
+	if err := s.repo.MeasureDB(ctx, usage); err != nil {
+		return nil, false, err
+	}
+	if s.bodies != nil {
+		count, size, err := s.bodies.GetConnectionCacheUsage(ctx, usage.ConnectionID)
+		if err != nil {
+			return nil, false, err
+		}
+		usage.Bodies = domain.StorageUsage{Count: count, Bytes: size}
+	}
+
+	now := s.now()
+	usage.MeasuredAt = &now
+	changed := s.apply(usage, now)
+
+	if err := s.repo.Save(ctx, usage); err != nil {
+		return nil, false, err
+	}
+	s.modes.Store(usage.ConnectionID, modeEntry{metadataOnly: usage.SkipsBodies(), at: now})
+	return usage, changed, nil
+}
+
+// schedulePurge deletes stored bodies and embeddings of the connection in the background, then re-measures.
+func (s *Service) schedulePurge(connectionID int64) {
+	s.inflight.Add(1)
+	go func() {
+		defer s.inflight.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), purgeTimeout)
+		defer cancel()
+
+		if err := s.purge(ctx, connectionID); err != nil {
+			logger.WithError(err).Warn("[Storage] connection=%d purge failed", connectionID)
+			return
+		}
+		prev, err := s.repo.Get(ctx, connectionID)
+		if err != nil || prev == nil {
+			return
+		}
+		if _, _, err := s.measure(ctx, prev); err != nil {
+			logger.WithError(err).Debug("[Storage] connection=%d re-measure after purge failed", connectionID)
+		}
+	}()
+}
+
+// purge deletes bodies (MongoDB) and embeddings of every email of the connection.
+// 벡터 백엔드마다 연결 단위 삭제가 없으므로 메일 ID를 페이지로 순회하며 지운다.
+func (s *Service) purge(ctx context.Context, connectionID int64) error {
+	if usage, err := s.repo.Get(ctx, connectionID); err != nil || usage == nil || usage.Local {
+		// 가져온 연결의 본문은 원본이 없어 지우면 복구할 수 없다
+		return err
+	}
+	if s.bodies != nil {
+		deleted, err := s.bodies.DeleteByConnectionID(ctx, connectionID)
+		if err != nil {
+			return err
+		}
+		logger.Info("[Storage] connection=%d purged %d bodies", connectionID, deleted)
+	}
+	if s.vectors == nil {
+		return nil
+	}
+
+	var afterID int64
+	for {
+		ids, err := s.repo.ListEmailIDs(ctx, connectionID, afterID, purgeBatch)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := s.vectors.Delete(ctx, id); err != nil {
+				return err
+			}
+		}
+		if len(ids) < purgeBatch {
+			return nil
+		}
+		afterID = ids[len(ids)-1]
+	}
+}
+
+// Wait blocks until scheduled purges finish.
+func (s *Service) Wait() {
+	s.inflight.Wait()
+}
+
+// apply switches metadata-only mode by the cap and returns whether the mode changed.
+// 가져온(local) 연결은 상한을 넘어도 본문을 지우거나 저장을 멈추지 않는다.
+func (s *Service) apply(usage *domain.ConnectionStorage, now time.Time) bool {
+	limit := s.EffectiveCap(usage)
+	total := usage.TotalBytes()
+
+	switch {
+	case usage.Local:
+		if !usage.MetadataOnly {
+			return false
+		}
+		usage.MetadataOnly = false
+		usage.MetadataOnlySince = nil
+		return true
+	case !usage.MetadataOnly && limit > 0 && total > limit:
+		usage.MetadataOnly = true
+		usage.MetadataOnlySince = &now
+		logger.Warn("[Storage] connection=%d over cap (%d > %d bytes), storing metadata only",
+			usage.ConnectionID, total, limit)
+		return true
+	case usage.MetadataOnly && (limit <= 0 || float64(total) < float64(limit)*resumeRatio):
+		usage.MetadataOnly = false
+		usage.MetadataOnlySince = nil
+		logger.Info("[Storage] connection=%d back under cap (%d / %d bytes), storing bodies again",
+			usage.ConnectionID, total, limit)
+		return true
+	}
+	return false
+}
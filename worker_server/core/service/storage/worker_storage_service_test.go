@@ -0,0 +1,294 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+type fakeRepo struct {
+	out.ConnectionStorageRepository
+	dbBytes map[int64]int64
+	saved   map[int64]*domain.ConnectionStorage
+	emails  map[int64][]int64
+	gets    int
+}
+
+func (f *fakeRepo) MeasureDB(ctx context.Context, usage *domain.ConnectionStorage) error {
+	usage.Metadata = domain.StorageUsage{Count: 1, Bytes: f.dbBytes[usage.ConnectionID]}
+	return nil
+}
+
+func (f *fakeRepo) Get(ctx context.Context, connectionID int64) (*domain.ConnectionStorage, error) {
+	f.gets++
+	if s, ok := f.saved[connectionID]; ok {
+		c := *s
+		return &c, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeRepo) Save(ctx context.Context, usage *domain.ConnectionStorage) error {
+	c := *usage
+	f.saved[usage.ConnectionID] = &c
+	return nil
+}
+
+func (f *fakeRepo) SetCap(ctx context.Context, connectionID int64, userID string, capBytes *int64) error {
+	s, ok := f.saved[connectionID]
+	if !ok {
+		s = &domain.ConnectionStorage{ConnectionID: connectionID, UserID: userID}
+		f.saved[connectionID] = s
+	}
+	s.CapBytes = capBytes
+	return nil
+}
+
+func (f *fakeRepo) SetSyncMode(ctx context.Context, connectionID int64, userID string, mode domain.ConnectionSyncMode) error {
+	s, ok := f.saved[connectionID]
+	if !ok {
+		s = &domain.ConnectionStorage{ConnectionID: connectionID, UserID: userID}
+		f.saved[connectionID] = s
+	}
+	s.SyncMode = mode
+	return nil
+}
+
+func (f *fakeRepo) ListEmailIDs(ctx context.Context, connectionID, afterID int64, limit int) ([]int64, error) {
+	var ids []int64
+	for _, id := range f.emails[connectionID] {
+		if id > afterID && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (f *fakeRepo) ListConnections(ctx context.Context) ([]*domain.ConnectionStorage, error) {
+	var list []*domain.ConnectionStorage
+	for id := range f.dbBytes {
+		if s, ok := f.saved[id]; ok {
+			c := *s
+			list = append(list, &c)
+		} else {
+			list = append(list, &domain.ConnectionStorage{ConnectionID: id})
+		}
+	}
+	return list, nil
+}
+
+type fakeBodies struct {
+	out.EmailBodyRepository
+	bytes map[int64]int64
+}
+
+func (f *fakeBodies) DeleteByConnectionID(ctx context.Context, connectionID int64) (int64, error) {
+	delete(f.bytes, connectionID)
+	return 1, nil
+}
+
+type fakeVectors struct {
+	out.VectorStorePort
+	deleted []int64
+}
+
+func (f *fakeVectors) Delete(ctx context.Context, emailID int64) error {
+	f.deleted = append(f.deleted, emailID)
+	return nil
+}
+
+func (f *fakeBodies) GetConnectionCacheUsage(ctx context.Context, connectionID int64) (int64, int64, error) {
+	return 1, f.bytes[connectionID], nil
+}
+
+type fakeConnections map[int64]uuid.UUID
+
+func (f fakeConnections) GetConnection(ctx context.Context, connectionID int64) (*domain.OAuthConnection, error) {
+	userID, ok := f[connectionID]
+	if !ok {
+		return nil, nil
+	}
+	return &domain.OAuthConnection{ID: connectionID, UserID: userID}, nil
+}
+
+func TestEnforceAllSwitchesMetadataOnly(t *testing.T) {
+	repo := &fakeRepo{dbBytes: map[int64]int64{1: 100, 2: 100}, saved: map[int64]*domain.ConnectionStorage{}}
+	bodies := &fakeBodies{bytes: map[int64]int64{1: 50, 2: 1000}}
+	svc := NewService(repo, bodies, fakeConnections{}, 1000)
+
+	report, err := svc.EnforceAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Measured != 2 || report.Entered != 1 || report.MetadataOnly != 1 {
+		t.Errorf("report = %+v, want 2 measured, 1 entered", report)
+	}
+	if !svc.MetadataOnly(context.Background(), 2) || svc.MetadataOnly(context.Background(), 1) {
+		t.Errorf("metadata only = (1: %v, 2: %v), want only connection 2", svc.MetadataOnly(context.Background(), 1), svc.MetadataOnly(context.Background(), 2))
+	}
+	if s := repo.saved[2]; s == nil || s.MetadataOnlySince == nil || s.TotalBytes() != 1100 {
+		t.Errorf("saved = %+v, want 1100 bytes in metadata-only mode", s)
+	}
+
+	// 상한 바로 아래로는 해제되지 않는다 (90% 미만이어야 해제)
+	bodies.bytes[2] = 850
+	report, _ = svc.EnforceAll(context.Background())
+	if report.Resumed != 0 || !svc.MetadataOnly(context.Background(), 2) {
+		t.Errorf("report = %+v, want still metadata-only at 950/1000 bytes", report)
+	}
+
+	bodies.bytes[2] = 700
+	report, _ = svc.EnforceAll(context.Background())
+	if report.Resumed != 1 || svc.MetadataOnly(context.Background(), 2) || repo.saved[2].MetadataOnlySince != nil {
+		t.Errorf("report = %+v, want resumed at 800/1000 bytes", report)
+	}
+}
+
+func TestSetCap(t *testing.T) {
+	owner := uuid.New()
+	repo := &fakeRepo{dbBytes: map[int64]int64{1: 600}, saved: map[int64]*domain.ConnectionStorage{}}
+	svc := NewService(repo, nil, fakeConnections{1: owner}, 0)
+	ctx := context.Background()
+
+	if _, err := svc.Usage(ctx, uuid.New(), 1); err != ErrConnectionNotFound {
+		t.Errorf("other user's connection: err = %v, want ErrConnectionNotFound", err)
+	}
+
+	usage, err := svc.Usage(ctx, owner, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.MetadataOnly || svc.EffectiveCap(usage) != 0 {
+		t.Errorf("usage = %+v, want no cap by default", usage)
+	}
+
+	capBytes := int64(500)
+	usage, err = svc.SetCap(ctx, owner, 1, &capBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !usage.MetadataOnly || !svc.MetadataOnly(ctx, 1) {
+		t.Errorf("usage = %+v, want metadata-only over 500 byte cap", usage)
+	}
+
+	negative := int64(-1)
+	if _, err := svc.SetCap(ctx, owner, 1, &negative); err != ErrInvalidCap {
+		t.Errorf("negative cap: err = %v, want ErrInvalidCap", err)
+	}
+
+	// 상한을 없애면 바로 해제된다
+	usage, err = svc.SetCap(ctx, owner, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.MetadataOnly {
+		t.Errorf("usage = %+v, want resumed without cap", usage)
+	}
+}
+
+func TestMetadataOnlyCachesLookups(t *testing.T) {
+	repo := &fakeRepo{saved: map[int64]*domain.ConnectionStorage{3: {ConnectionID: 3, MetadataOnly: true}}}
+	svc := NewService(repo, nil, fakeConnections{}, 0)
+
+	for i := 0; i < 3; i++ {
+		if !svc.MetadataOnly(context.Background(), 3) {
+			t.Fatal("MetadataOnly() = false, want true")
+		}
+	}
+	if repo.gets != 1 {
+		t.Errorf("repo gets = %d, want 1 (cached)", repo.gets)
+	}
+	if svc.MetadataOnly(context.Background(), 0) {
+		t.Error("MetadataOnly(0) = true, want false")
+	}
+}
+
+func TestSetSyncModePurgesBodiesAndVectors(t *testing.T) {
+	owner := uuid.New()
+	repo := &fakeRepo{
+		dbBytes: map[int64]int64{1: 100},
+		saved:   map[int64]*domain.ConnectionStorage{},
+		emails:  map[int64][]int64{1: {10, 11, 12}},
+	}
+	bodies := &fakeBodies{bytes: map[int64]int64{1: 5000}}
+	vectors := &fakeVectors{}
+	svc := NewService(repo, bodies, fakeConnections{1: owner}, 0)
+	svc.SetVectorStore(vectors)
+	ctx := context.Background()
+
+	if _, err := svc.SetSyncMode(ctx, owner, 1, "headers"); err != ErrInvalidSyncMode {
+		t.Errorf("unknown mode: err = %v, want ErrInvalidSyncMode", err)
+	}
+
+	usage, err := svc.SetSyncMode(ctx, owner, 1, domain.ConnectionSyncMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.Wait()
+
+	if usage.SyncMode != domain.ConnectionSyncMetadata || usage.MetadataOnly || !svc.MetadataOnly(ctx, 1) {
+		t.Errorf("usage = %+v, want metadata sync mode without cap breach", usage)
+	}
+	if len(vectors.deleted) != 3 {
+		t.Errorf("deleted vectors = %v, want all 3 emails", vectors.deleted)
+	}
+	if s := repo.saved[1]; s.Bodies.Bytes != 0 || s.SyncMode != domain.ConnectionSyncMetadata {
+		t.Errorf("saved = %+v, want bodies purged and mode kept", s)
+	}
+
+	// full로 되돌리면 다시 저장한다 (지운 데이터는 열람/동기화 시 다시 채워짐)
+	usage, err = svc.SetSyncMode(ctx, owner, 1, domain.ConnectionSyncFull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.Wait()
+	if svc.MetadataOnly(ctx, 1) || len(vectors.deleted) != 3 {
+		t.Errorf("usage = %+v deleted = %v, want full mode without another purge", usage, vectors.deleted)
+	}
+}
+
+type localConnections map[int64]uuid.UUID
+
+func (f localConnections) GetConnection(ctx context.Context, connectionID int64) (*domain.OAuthConnection, error) {
+	userID, ok := f[connectionID]
+	if !ok {
+		return nil, nil
+	}
+	return &domain.OAuthConnection{ID: connectionID, UserID: userID, Provider: domain.ProviderLocal}, nil
+}
+
+func TestLocalConnectionKeepsBodies(t *testing.T) {
+	owner := uuid.New()
+	repo := &fakeRepo{
+		dbBytes: map[int64]int64{1: 600},
+		saved:   map[int64]*domain.ConnectionStorage{},
+		emails:  map[int64][]int64{1: {10, 11}},
+	}
+	bodies := &fakeBodies{bytes: map[int64]int64{1: 5000}}
+	vectors := &fakeVectors{}
+	svc := NewService(repo, bodies, localConnections{1: owner}, 100)
+	svc.SetVectorStore(vectors)
+	ctx := context.Background()
+
+	// 가져온 메일은 원본이 없으므로 metadata 모드를 거부한다
+	if _, err := svc.SetSyncMode(ctx, owner, 1, domain.ConnectionSyncMetadata); err != ErrLocalSyncMode {
+		t.Errorf("metadata mode: err = %v, want ErrLocalSyncMode", err)
+	}
+
+	// 상한을 넘어도 metadata-only로 바꾸지 않는다
+	usage, err := svc.Usage(ctx, owner, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.Wait()
+	if usage.MetadataOnly || svc.MetadataOnly(ctx, 1) {
+		t.Errorf("usage = %+v, want local connection never metadata-only", usage)
+	}
+	if bodies.bytes[1] != 5000 || len(vectors.deleted) != 0 {
+		t.Errorf("bodies = %v deleted = %v, want nothing purged", bodies.bytes, vectors.deleted)
+	}
+}
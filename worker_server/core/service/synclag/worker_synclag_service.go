@@ -0,0 +1,212 @@
+// Package synclag measures provider-to-DB sync lag per connection and remediates SLO breaches with a GapSync.
+package synclag
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+)
+
+const (
+	DefaultThreshold = 15 * time.Minute
+	DefaultCooldown  = 30 * time.Minute
+
+	// measureConcurrency - 동시에 Provider를 조회할 연결 수
+	measureConcurrency = 5
+	measureTimeout     = 30 * time.Second
+	gapSyncTimeout     = 5 * time.Minute
+)
+
+// Syncer reads the provider side and runs gap syncs (mail.SyncService).
+type Syncer interface {
+	ProviderLatestAt(ctx context.Context, connectionID int64) (time.Time, error)
+	GapSync(ctx context.Context, connectionID int64) error
+}
+
+// Report summarizes one monitor pass.
+type Report struct {
+	Checked    int `json:"checked"`
+	Breaches   int `json:"breaches"`
+	Remediated int `json:"remediated"`
+	Failed     int `json:"failed"`
+}
+
+// Service measures lag for idle connections and schedules a GapSync when it exceeds the threshold.
+type Service struct {
+	states    out.SyncStateRepository
+	lags      out.SyncLagRepository
+	syncer    Syncer
+	threshold time.Duration
+	cooldown  time.Duration // 같은 연결의 알림/GapSync 재실행 간격
+
+	// gapSync는 모니터 주기와 무관하게 백그라운드에서 실행된다 (테스트에서 대기용)
+	inflight sync.WaitGroup
+	now      func() time.Time
+}
+
+// NewService creates a sync lag monitor service. threshold/cooldown <= 0 uses the defaults.
+func NewService(states out.SyncStateRepository, lags out.SyncLagRepository, syncer Syncer, threshold, cooldown time.Duration) *Service {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultCooldown
+	}
+	return &Service{
+		states:    states,
+		lags:      lags,
+		syncer:    syncer,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Threshold returns the lag SLO threshold.
+func (s *Service) Threshold() time.Duration {
+	return s.threshold
+}
+
+// Check measures every idle connection once. 동기화 중인 연결은 측정값이 흔들리므로 건너뛴다.
+func (s *Service) Check(ctx context.Context) (*Report, error) {
+	states, err := s.states.GetByStatus(ctx, domain.SyncStatusIdle)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, measureConcurrency)
+
+	for _, state := range states {
+		if state.IsFirstSync() {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(state *domain.SyncState) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			breached, remediated, err := s.checkConnection(ctx, state)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checked++
+			if err != nil {
+				report.Failed++
+				return
+			}
+			if breached {
+				report.Breaches++
+			}
+			if remediated {
+				report.Remediated++
+			}
+		}(state)
+	}
+	wg.Wait()
+	return report, nil
+}
+
+// checkConnection measures one connection, alerts on breach and schedules a GapSync.
+func (s *Service) checkConnection(ctx context.Context, state *domain.SyncState) (breached, remediated bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, measureTimeout)
+	defer cancel()
+
+	providerLatest, err := s.syncer.ProviderLatestAt(ctx, state.ConnectionID)
+	if err != nil {
+		logger.WithError(err).Debug("[SyncLag] connection=%d provider check failed", state.ConnectionID)
+		return false, false, err
+	}
+	storedLatest, err := s.lags.LatestStoredAt(ctx, state.ConnectionID)
+	if err != nil {
+		return false, false, err
+	}
+
+	prev, err := s.lags.Get(ctx, state.ConnectionID)
+	if err != nil {
+		return false, false, err
+	}
+
+	now := s.now()
+	lag := &domain.SyncLag{
+		ConnectionID: state.ConnectionID,
+		UserID:       state.UserID,
+		LagSeconds:   int64(Lag(providerLatest, storedLatest).Seconds()),
+		CheckedAt:    now,
+	}
+	if !providerLatest.IsZero() {
+		lag.ProviderLatestAt = &providerLatest
+	}
+	if !storedLatest.IsZero() {
+		lag.StoredLatestAt = &storedLatest
+	}
+	if prev != nil {
+		lag.AlertedAt = prev.AlertedAt
+		lag.RemediatedAt = prev.RemediatedAt
+	}
+
+	if lag.Lag() > s.threshold {
+		breached = true
+		if lag.AlertedAt == nil || now.Sub(*lag.AlertedAt) >= s.cooldown {
+			logger.Warn("[SyncLag] SLO breach: connection=%d user=%s lag=%s (threshold %s, provider=%v stored=%v)",
+				state.ConnectionID, state.UserID, lag.Lag(), s.threshold, providerLatest, storedLatest)
+			lag.AlertedAt = &now
+		}
+		if lag.RemediatedAt == nil || now.Sub(*lag.RemediatedAt) >= s.cooldown {
+			lag.RemediatedAt = &now
+			remediated = true
+			s.scheduleGapSync(state.ConnectionID)
+		}
+	}
+
+	if err := s.lags.Save(ctx, lag); err != nil {
+		return breached, remediated, err
+	}
+	return breached, remediated, nil
+}
+
+// scheduleGapSync runs a GapSync in the background (다음 측정에서 복구 여부를 확인한다).
+func (s *Service) scheduleGapSync(connectionID int64) {
+	s.inflight.Add(1)
+	go func() {
+		defer s.inflight.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), gapSyncTimeout)
+		defer cancel()
+
+		logger.Info("[SyncLag] Scheduling gap sync for connection %d", connectionID)
+		if err := s.syncer.GapSync(ctx, connectionID); err != nil {
+			logger.WithError(err).Warn("[SyncLag] Gap sync failed for connection %d", connectionID)
+		}
+	}()
+}
+
+// Wait blocks until scheduled gap syncs finish.
+func (s *Service) Wait() {
+	s.inflight.Wait()
+}
+
+// Connections lists connections with their last lag measurement for the admin view.
+func (s *Service) Connections(ctx context.Context, minLag time.Duration, limit, offset int) ([]*domain.ConnectionSyncLag, int, error) {
+	return s.lags.ListConnections(ctx, int64(minLag.Seconds()), limit, offset)
+}
+
+// Lag returns how far stored trails providerLatest. 저장된 메일이 더 최신이면 0.
+// 저장된 메일이 없으면 Provider 최신 메일 전체가 누락된 것으로 본다 (현재 시각 기준 경과).
+func Lag(providerLatest, storedLatest time.Time) time.Duration {
+	if providerLatest.IsZero() {
+		return 0
+	}
+	if storedLatest.IsZero() {
+		return time.Since(providerLatest)
+	}
+	if lag := providerLatest.Sub(storedLatest); lag > 0 {
+		return lag
+	}
+	return 0
+}
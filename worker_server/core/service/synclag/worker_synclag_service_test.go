@@ -0,0 +1,125 @@
+package synclag
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+)
+
+type fakeStates struct {
+	out.SyncStateRepository
+	states []*domain.SyncState
+}
+
+func (f *fakeStates) GetByStatus(ctx context.Context, status domain.SyncStatus) ([]*domain.SyncState, error) {
+	return f.states, nil
+}
+
+type fakeLags struct {
+	out.SyncLagRepository
+	mu     sync.Mutex
+	stored map[int64]time.Time
+	saved  map[int64]*domain.SyncLag
+}
+
+func (f *fakeLags) LatestStoredAt(ctx context.Context, connectionID int64) (time.Time, error) {
+	return f.stored[connectionID], nil
+}
+
+func (f *fakeLags) Get(ctx context.Context, connectionID int64) (*domain.SyncLag, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.saved[connectionID], nil
+}
+
+func (f *fakeLags) Save(ctx context.Context, lag *domain.SyncLag) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.saved[lag.ConnectionID] = lag
+	return nil
+}
+
+type fakeSyncer struct {
+	mu       sync.Mutex
+	latest   map[int64]time.Time
+	gapSyncs []int64
+}
+
+func (f *fakeSyncer) ProviderLatestAt(ctx context.Context, connectionID int64) (time.Time, error) {
+	return f.latest[connectionID], nil
+}
+
+func (f *fakeSyncer) GapSync(ctx context.Context, connectionID int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gapSyncs = append(f.gapSyncs, connectionID)
+	return nil
+}
+
+func TestCheckRemediatesBreaches(t *testing.T) {
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	synced := time.Now()
+	states := &fakeStates{states: []*domain.SyncState{
+		{ConnectionID: 1, UserID: "u1", FirstSyncCompletedAt: synced}, // 최신
+		{ConnectionID: 2, UserID: "u2", FirstSyncCompletedAt: synced}, // 1시간 뒤처짐
+		{ConnectionID: 3, UserID: "u3"},                                // 첫 동기화 전 → 건너뜀
+	}}
+	lags := &fakeLags{
+		stored: map[int64]time.Time{1: base, 2: base.Add(-time.Hour)},
+		saved:  map[int64]*domain.SyncLag{},
+	}
+	syncer := &fakeSyncer{latest: map[int64]time.Time{1: base, 2: base, 3: base}}
+	svc := NewService(states, lags, syncer, 15*time.Minute, time.Hour)
+
+	report, err := svc.Check(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.Wait()
+
+	if report.Checked != 2 || report.Breaches != 1 || report.Remediated != 1 {
+		t.Errorf("report = %+v, want 2 checked, 1 breach, 1 remediated", report)
+	}
+	if len(syncer.gapSyncs) != 1 || syncer.gapSyncs[0] != 2 {
+		t.Errorf("gap syncs = %v, want [2]", syncer.gapSyncs)
+	}
+	if lag := lags.saved[2]; lag == nil || lag.LagSeconds != 3600 || lag.AlertedAt == nil || lag.RemediatedAt == nil {
+		t.Errorf("saved lag = %+v, want 3600s alerted and remediated", lag)
+	}
+	if lag := lags.saved[1]; lag == nil || lag.LagSeconds != 0 || lag.RemediatedAt != nil {
+		t.Errorf("saved lag = %+v, want 0s without remediation", lag)
+	}
+
+	// 쿨다운 안에서는 다시 GapSync하지 않는다
+	report, _ = svc.Check(context.Background())
+	svc.Wait()
+	if report.Breaches != 1 || report.Remediated != 0 || len(syncer.gapSyncs) != 1 {
+		t.Errorf("second pass report = %+v gap syncs = %v, want breach without remediation", report, syncer.gapSyncs)
+	}
+}
+
+func TestLag(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name             string
+		provider, stored time.Time
+		want             time.Duration
+	}{
+		{"empty mailbox", time.Time{}, time.Time{}, 0},
+		{"up to date", now, now, 0},
+		{"stored newer", now, now.Add(time.Minute), 0},
+		{"behind", now, now.Add(-20 * time.Minute), 20 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := Lag(tt.provider, tt.stored); got != tt.want {
+			t.Errorf("%s: Lag() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := Lag(now.Add(-time.Hour), time.Time{}); got < time.Hour {
+		t.Errorf("nothing stored: Lag() = %v, want >= 1h", got)
+	}
+}
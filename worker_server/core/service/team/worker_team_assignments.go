@@ -0,0 +1,146 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxAssignmentNoteLength = 500
+	assignmentHistoryLimit  = 50
+)
+
+var (
+	ErrAssignmentsDisabled = errors.New("email assignment is not configured")
+	ErrInvalidAssignee     = errors.New("assignee is not a member of the org")
+	ErrNoteTooLong         = errors.New("assignment note is too long (max 500 characters)")
+)
+
+// AssignRequest is the input for assigning a shared email. AssigneeID nil이면 담당 해제.
+type AssignRequest struct {
+	AssigneeID *uuid.UUID
+	Note       string
+}
+
+// AssignmentView is the current assignee of an email with its history.
+type AssignmentView struct {
+	Current *domain.EmailAssignment   `json:"current"`
+	History []*domain.EmailAssignment `json:"history"`
+}
+
+// SetAssignmentRepository enables email assignment.
+func (s *Service) SetAssignmentRepository(repo out.EmailAssignmentRepository) {
+	s.assignments = repo
+}
+
+// Assign sets (or clears) the assignee of a shared email and notifies the assignees over SSE.
+func (s *Service) Assign(ctx context.Context, userID uuid.UUID, emailID int64, req *AssignRequest) (*domain.EmailAssignment, error) {
+	if s.assignments == nil {
+		return nil, ErrAssignmentsDisabled
+	}
+	orgID, err := s.emailOrg(ctx, userID, emailID)
+	if err != nil {
+		return nil, err
+	}
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > maxAssignmentNoteLength {
+		return nil, ErrNoteTooLong
+	}
+
+	members, err := s.repo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	assignment := &domain.EmailAssignment{
+		EmailID:    emailID,
+		OrgID:      orgID,
+		AssigneeID: req.AssigneeID,
+		AssignedBy: userID,
+		Note:       note,
+	}
+	if req.AssigneeID != nil {
+		assignee := findMember(members, *req.AssigneeID)
+		if assignee == nil {
+			return nil, ErrInvalidAssignee
+		}
+		assignment.AssigneeEmail = assignee.Email
+		assignment.AssigneeName = assignee.Name
+	}
+	if assigner := findMember(members, userID); assigner != nil {
+		assignment.AssignerEmail = assigner.Email
+	}
+
+	previous, err := s.assignments.Set(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	// 새 담당자에게 지정, 이전 담당자에게 해제를 알린다 (본인이 한 변경은 제외)
+	if req.AssigneeID != nil && (previous == nil || *previous != *req.AssigneeID) {
+		s.pushAssignment(ctx, *req.AssigneeID, "assigned", assignment)
+	}
+	if previous != nil && (req.AssigneeID == nil || *previous != *req.AssigneeID) {
+		s.pushAssignment(ctx, *previous, "unassigned", assignment)
+	}
+	return assignment, nil
+}
+
+// Assignment returns the current assignee of a shared email and its history.
+func (s *Service) Assignment(ctx context.Context, userID uuid.UUID, emailID int64) (*AssignmentView, error) {
+	if s.assignments == nil {
+		return nil, ErrAssignmentsDisabled
+	}
+	if _, err := s.emailOrg(ctx, userID, emailID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.assignments.Get(ctx, emailID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.assignments.History(ctx, emailID, assignmentHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		history = []*domain.EmailAssignment{}
+	}
+	return &AssignmentView{Current: current, History: history}, nil
+}
+
+func (s *Service) pushAssignment(ctx context.Context, recipient uuid.UUID, action string, assignment *domain.EmailAssignment) {
+	if s.realtime == nil || recipient == assignment.AssignedBy {
+		return
+	}
+
+	event := &domain.RealtimeEvent{
+		Type: domain.EventEmailAssigned,
+		Data: map[string]any{
+			"action":     action,
+			"email_id":   assignment.EmailID,
+			"assignment": assignment,
+		},
+		Timestamp: time.Now(),
+	}
+	if err := s.realtime.Push(ctx, recipient.String(), event); err != nil {
+		logger.Warn("[TeamService] Failed to push assignment event to %s: %v", recipient, err)
+	}
+}
+
+func findMember(members []*domain.OrgMember, userID uuid.UUID) *domain.OrgMember {
+	for _, m := range members {
+		if m.UserID == userID {
+			return m
+		}
+	}
+	return nil
+}
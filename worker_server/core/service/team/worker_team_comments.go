@@ -0,0 +1,319 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxCommentLength = 5000
+	// mentionPreviewLength - 멘션 알림 본문에 넣는 댓글 길이
+	mentionPreviewLength = 140
+)
+
+var (
+	ErrEmailNotShared   = errors.New("email is not in a mailbox shared with your org")
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrCommentEmpty     = errors.New("comment body is required")
+	ErrCommentTooLong   = errors.New("comment is too long (max 5000 characters)")
+	ErrInvalidMentionID = errors.New("mentioned user is not a member of the org")
+)
+
+// mentionPattern matches "@member@example.com" tokens in a comment body.
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@([^\s@]+@[^\s@]+)`)
+
+// CommentRequest is the input for adding or editing a comment.
+type CommentRequest struct {
+	Body       string
+	ParentID   *int64      // 답글이면 부모 댓글
+	MentionIDs []uuid.UUID // 본문의 @이메일 외에 명시적으로 멘션할 멤버
+}
+
+// ListComments returns the comment threads of an email, oldest first.
+func (s *Service) ListComments(ctx context.Context, userID uuid.UUID, emailID int64) ([]*domain.EmailComment, error) {
+	if _, err := s.emailOrg(ctx, userID, emailID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.comments.ListByEmailID(ctx, emailID)
+	if err != nil {
+		return nil, err
+	}
+	return buildThreads(comments), nil
+}
+
+// AddComment adds a comment (or a reply) and notifies mentioned members.
+func (s *Service) AddComment(ctx context.Context, userID uuid.UUID, emailID int64, req *CommentRequest) (*domain.EmailComment, error) {
+	orgID, err := s.emailOrg(ctx, userID, emailID)
+	if err != nil {
+		return nil, err
+	}
+	body, err := validateBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.repo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	mentions, err := resolveMentions(body, req.MentionIDs, members)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &domain.EmailComment{
+		EmailID:  emailID,
+		OrgID:    orgID,
+		ParentID: req.ParentID,
+		AuthorID: userID,
+		Body:     body,
+		Mentions: mentions,
+	}
+	if err := s.comments.Create(ctx, comment); err != nil {
+		if errors.Is(err, out.ErrEmailCommentNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	fillAuthor(comment, members)
+
+	s.notifyMentions(ctx, comment, mentions, members)
+	s.broadcast(ctx, "created", comment, members)
+	return comment, nil
+}
+
+// UpdateComment edits the author's comment. 새로 멘션된 멤버에게만 알린다.
+func (s *Service) UpdateComment(ctx context.Context, userID uuid.UUID, emailID, commentID int64, req *CommentRequest) (*domain.EmailComment, error) {
+	orgID, err := s.emailOrg(ctx, userID, emailID)
+	if err != nil {
+		return nil, err
+	}
+	body, err := validateBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.repo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	mentions, err := resolveMentions(body, req.MentionIDs, members)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.comments.ListByEmailID(ctx, emailID)
+	if err != nil {
+		return nil, err
+	}
+	var previous []uuid.UUID
+	for _, c := range existing {
+		if c.ID == commentID {
+			previous = c.Mentions
+		}
+	}
+
+	comment := &domain.EmailComment{ID: commentID, EmailID: emailID, AuthorID: userID, Body: body, Mentions: mentions}
+	if err := s.comments.Update(ctx, comment); err != nil {
+		if errors.Is(err, out.ErrEmailCommentNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	fillAuthor(comment, members)
+
+	s.notifyMentions(ctx, comment, newMentions(previous, mentions), members)
+	s.broadcast(ctx, "updated", comment, members)
+	return comment, nil
+}
+
+// DeleteComment deletes the author's comment and its replies.
+func (s *Service) DeleteComment(ctx context.Context, userID uuid.UUID, emailID, commentID int64) error {
+	orgID, err := s.emailOrg(ctx, userID, emailID)
+	if err != nil {
+		return err
+	}
+	if err := s.comments.Delete(ctx, userID, emailID, commentID); err != nil {
+		if errors.Is(err, out.ErrEmailCommentNotFound) {
+			return ErrCommentNotFound
+		}
+		return err
+	}
+
+	if s.realtime != nil {
+		if members, err := s.repo.ListMembers(ctx, orgID); err == nil {
+			s.broadcast(ctx, "deleted", &domain.EmailComment{ID: commentID, EmailID: emailID, OrgID: orgID, AuthorID: userID}, members)
+		}
+	}
+	return nil
+}
+
+func (s *Service) emailOrg(ctx context.Context, userID uuid.UUID, emailID int64) (int64, error) {
+	orgID, err := s.repo.EmailOrg(ctx, userID, emailID)
+	if errors.Is(err, out.ErrTeamNotFound) {
+		return 0, ErrEmailNotShared
+	}
+	return orgID, err
+}
+
+// notifyMentions sends a mention notification to each mentioned member except the author.
+func (s *Service) notifyMentions(ctx context.Context, comment *domain.EmailComment, mentions []uuid.UUID, members []*domain.OrgMember) {
+	if s.notifier == nil {
+		return
+	}
+
+	author := comment.AuthorName
+	if author == "" {
+		author = comment.AuthorEmail
+	}
+	for _, id := range mentions {
+		if id == comment.AuthorID {
+			continue
+		}
+		n := &domain.Notification{
+			UserID:     id,
+			Type:       domain.NotificationTypeMention,
+			Title:      fmt.Sprintf("%s mentioned you in a comment", author),
+			Body:       preview(comment.Body),
+			EntityType: "email",
+			EntityID:   comment.EmailID,
+			Priority:   domain.NotificationPriorityNormal,
+			Data: map[string]any{
+				"comment_id": comment.ID,
+				"org_id":     comment.OrgID,
+			},
+		}
+		if err := s.notifier.Send(ctx, n); err != nil {
+			logger.Warn("[TeamService] Failed to notify mention for comment %d: %v", comment.ID, err)
+		}
+	}
+}
+
+// broadcast pushes the comment change to the other org members (공유 메일함 화면 실시간 갱신).
+func (s *Service) broadcast(ctx context.Context, action string, comment *domain.EmailComment, members []*domain.OrgMember) {
+	if s.realtime == nil {
+		return
+	}
+
+	event := &domain.RealtimeEvent{
+		Type: domain.EventEmailComment,
+		Data: map[string]any{
+			"action":   action,
+			"email_id": comment.EmailID,
+			"comment":  comment,
+		},
+		Timestamp: time.Now(),
+	}
+	for _, m := range members {
+		if m.UserID == comment.AuthorID {
+			continue
+		}
+		if err := s.realtime.Push(ctx, m.UserID.String(), event); err != nil {
+			logger.Warn("[TeamService] Failed to push comment event to %s: %v", m.UserID, err)
+		}
+	}
+}
+
+func validateBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", ErrCommentEmpty
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return "", ErrCommentTooLong
+	}
+	return body, nil
+}
+
+// resolveMentions returns the members mentioned by "@email" in the body or by explicit ID.
+// 본문에서 멤버가 아닌 주소는 무시하고, 명시적 ID가 멤버가 아니면 에러.
+func resolveMentions(body string, explicit []uuid.UUID, members []*domain.OrgMember) ([]uuid.UUID, error) {
+	byEmail := make(map[string]uuid.UUID, len(members))
+	isMember := make(map[uuid.UUID]bool, len(members))
+	for _, m := range members {
+		byEmail[strings.ToLower(m.Email)] = m.UserID
+		isMember[m.UserID] = true
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	mentions := make([]uuid.UUID, 0)
+	add := func(id uuid.UUID) {
+		if !seen[id] {
+			seen[id] = true
+			mentions = append(mentions, id)
+		}
+	}
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(strings.TrimRight(match[1], ".,;:!?)'\""))
+		if id, ok := byEmail[email]; ok {
+			add(id)
+		}
+	}
+	for _, id := range explicit {
+		if !isMember[id] {
+			return nil, ErrInvalidMentionID
+		}
+		add(id)
+	}
+	return mentions, nil
+}
+
+// newMentions returns the mentions not present before the edit.
+func newMentions(previous, current []uuid.UUID) []uuid.UUID {
+	before := make(map[uuid.UUID]bool, len(previous))
+	for _, id := range previous {
+		before[id] = true
+	}
+	var added []uuid.UUID
+	for _, id := range current {
+		if !before[id] {
+			added = append(added, id)
+		}
+	}
+	return added
+}
+
+// buildThreads nests replies under their parents. 부모가 삭제된 답글은 없으므로 (CASCADE) 고아는 최상위로 둔다.
+func buildThreads(comments []*domain.EmailComment) []*domain.EmailComment {
+	byID := make(map[int64]*domain.EmailComment, len(comments))
+	for _, c := range comments {
+		byID[c.ID] = c
+	}
+
+	roots := make([]*domain.EmailComment, 0)
+	for _, c := range comments {
+		if c.ParentID != nil {
+			if parent, ok := byID[*c.ParentID]; ok {
+				parent.Replies = append(parent.Replies, c)
+				continue
+			}
+		}
+		roots = append(roots, c)
+	}
+	return roots
+}
+
+func fillAuthor(comment *domain.EmailComment, members []*domain.OrgMember) {
+	if m := findMember(members, comment.AuthorID); m != nil {
+		comment.AuthorEmail = m.Email
+		comment.AuthorName = m.Name
+	}
+}
+
+func preview(body string) string {
+	if utf8.RuneCountInString(body) <= mentionPreviewLength {
+		return body
+	}
+	return string([]rune(body)[:mentionPreviewLength]) + "…"
+}
@@ -0,0 +1,255 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+type memTeamRepo struct {
+	out.TeamRepository
+	members  []*domain.OrgMember
+	emailOrg map[int64]int64
+}
+
+func (r *memTeamRepo) ListMembers(_ context.Context, _ int64) ([]*domain.OrgMember, error) {
+	return r.members, nil
+}
+
+func (r *memTeamRepo) EmailOrg(_ context.Context, userID uuid.UUID, emailID int64) (int64, error) {
+	for _, m := range r.members {
+		if m.UserID == userID {
+			if orgID, ok := r.emailOrg[emailID]; ok {
+				return orgID, nil
+			}
+		}
+	}
+	return 0, out.ErrTeamNotFound
+}
+
+type memCommentRepo struct {
+	comments []*domain.EmailComment
+}
+
+func (r *memCommentRepo) Create(_ context.Context, c *domain.EmailComment) error {
+	c.ID = int64(len(r.comments) + 1)
+	r.comments = append(r.comments, c)
+	return nil
+}
+
+func (r *memCommentRepo) Update(_ context.Context, c *domain.EmailComment) error {
+	for i, existing := range r.comments {
+		if existing.ID == c.ID && existing.AuthorID == c.AuthorID {
+			r.comments[i] = c
+			return nil
+		}
+	}
+	return out.ErrEmailCommentNotFound
+}
+
+func (r *memCommentRepo) Delete(_ context.Context, _ uuid.UUID, _, _ int64) error { return nil }
+
+func (r *memCommentRepo) ListByEmailID(_ context.Context, emailID int64) ([]*domain.EmailComment, error) {
+	var list []*domain.EmailComment
+	for _, c := range r.comments {
+		if c.EmailID == emailID {
+			copied := *c
+			list = append(list, &copied)
+		}
+	}
+	return list, nil
+}
+
+type fakeNotifier struct {
+	sent []*domain.Notification
+}
+
+func (f *fakeNotifier) Send(_ context.Context, n *domain.Notification) error {
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+func TestResolveMentions(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	members := []*domain.OrgMember{
+		{UserID: alice, Email: "alice@example.com"},
+		{UserID: bob, Email: "Bob@Example.com"},
+	}
+
+	got, err := resolveMentions("@bob@example.com, can you check? cc @alice@example.com. not@alice@example.com @eve@example.com", nil, members)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != bob || got[1] != alice {
+		t.Errorf("mentions = %v, want [bob alice]", got)
+	}
+
+	got, err = resolveMentions("@alice@example.com", []uuid.UUID{alice, bob}, members)
+	if err != nil || len(got) != 2 {
+		t.Errorf("explicit mentions should be merged without duplicates, got %v (%v)", got, err)
+	}
+
+	if _, err := resolveMentions("hi", []uuid.UUID{uuid.New()}, members); !errors.Is(err, ErrInvalidMentionID) {
+		t.Errorf("non-member mention id: got %v", err)
+	}
+}
+
+func TestBuildThreads(t *testing.T) {
+	one, two := int64(1), int64(2)
+	threads := buildThreads([]*domain.EmailComment{
+		{ID: 1},
+		{ID: 2, ParentID: &one},
+		{ID: 3},
+		{ID: 4, ParentID: &two},
+	})
+	if len(threads) != 2 || threads[0].ID != 1 || threads[1].ID != 3 {
+		t.Fatalf("unexpected roots %+v", threads)
+	}
+	if len(threads[0].Replies) != 1 || len(threads[0].Replies[0].Replies) != 1 {
+		t.Errorf("expected nested replies under comment 1")
+	}
+}
+
+func TestAddCommentNotifiesMentions(t *testing.T) {
+	ctx := context.Background()
+	author, teammate := uuid.New(), uuid.New()
+	repo := &memTeamRepo{
+		members: []*domain.OrgMember{
+			{UserID: author, Email: "author@example.com", Name: "Author"},
+			{UserID: teammate, Email: "mate@example.com"},
+		},
+		emailOrg: map[int64]int64{10: 7},
+	}
+	notifier := &fakeNotifier{}
+	svc := NewService(repo, &memCommentRepo{})
+	svc.SetNotifier(notifier)
+
+	comment, err := svc.AddComment(ctx, author, 10, &CommentRequest{Body: "@mate@example.com @author@example.com please reply"})
+	if err != nil {
+		t.Fatalf("add comment: %v", err)
+	}
+	if comment.OrgID != 7 || comment.AuthorName != "Author" || len(comment.Mentions) != 2 {
+		t.Errorf("unexpected comment %+v", comment)
+	}
+	if len(notifier.sent) != 1 || notifier.sent[0].UserID != teammate || notifier.sent[0].EntityID != 10 {
+		t.Fatalf("expected one mention notification to the teammate, got %+v", notifier.sent)
+	}
+
+	// 수정 시 이미 멘션된 멤버에게는 다시 알리지 않는다
+	notifier.sent = nil
+	if _, err := svc.UpdateComment(ctx, author, 10, comment.ID, &CommentRequest{Body: "@mate@example.com updated"}); err != nil {
+		t.Fatalf("update comment: %v", err)
+	}
+	if len(notifier.sent) != 0 {
+		t.Errorf("re-mention should not notify again, got %d", len(notifier.sent))
+	}
+
+	if _, err := svc.AddComment(ctx, author, 11, &CommentRequest{Body: "hi"}); !errors.Is(err, ErrEmailNotShared) {
+		t.Errorf("unshared email: got %v", err)
+	}
+	if _, err := svc.AddComment(ctx, teammate, 10, &CommentRequest{Body: "  "}); !errors.Is(err, ErrCommentEmpty) {
+		t.Errorf("empty body: got %v", err)
+	}
+}
+
+type memAssignmentRepo struct {
+	current map[int64]*domain.EmailAssignment
+	history []*domain.EmailAssignment
+}
+
+func (r *memAssignmentRepo) Set(_ context.Context, a *domain.EmailAssignment) (*uuid.UUID, error) {
+	var previous *uuid.UUID
+	if cur, ok := r.current[a.EmailID]; ok {
+		previous = cur.AssigneeID
+	}
+	if a.AssigneeID == nil {
+		delete(r.current, a.EmailID)
+	} else {
+		r.current[a.EmailID] = a
+	}
+	r.history = append(r.history, a)
+	return previous, nil
+}
+
+func (r *memAssignmentRepo) Get(_ context.Context, emailID int64) (*domain.EmailAssignment, error) {
+	return r.current[emailID], nil
+}
+
+func (r *memAssignmentRepo) History(_ context.Context, _ int64, _ int) ([]*domain.EmailAssignment, error) {
+	return r.history, nil
+}
+
+type fakeRealtime struct {
+	out.RealtimePort
+	pushed map[string][]string // user -> actions
+}
+
+func (f *fakeRealtime) Push(_ context.Context, userID string, event *domain.RealtimeEvent) error {
+	data := event.Data.(map[string]any)
+	f.pushed[userID] = append(f.pushed[userID], data["action"].(string))
+	return nil
+}
+
+func TestAssignPushesToAssignees(t *testing.T) {
+	ctx := context.Background()
+	lead, alice, bob := uuid.New(), uuid.New(), uuid.New()
+	repo := &memTeamRepo{
+		members: []*domain.OrgMember{
+			{UserID: lead, Email: "lead@example.com"},
+			{UserID: alice, Email: "alice@example.com"},
+			{UserID: bob, Email: "bob@example.com"},
+		},
+		emailOrg: map[int64]int64{10: 7},
+	}
+	realtime := &fakeRealtime{pushed: make(map[string][]string)}
+	assignments := &memAssignmentRepo{current: make(map[int64]*domain.EmailAssignment)}
+	svc := NewService(repo, &memCommentRepo{})
+	svc.SetRealtime(realtime)
+
+	if _, err := svc.Assign(ctx, lead, 10, &AssignRequest{AssigneeID: &alice}); !errors.Is(err, ErrAssignmentsDisabled) {
+		t.Fatalf("without repository: got %v", err)
+	}
+	svc.SetAssignmentRepository(assignments)
+
+	a, err := svc.Assign(ctx, lead, 10, &AssignRequest{AssigneeID: &alice, Note: " please handle "})
+	if err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	if a.OrgID != 7 || a.AssigneeEmail != "alice@example.com" || a.Note != "please handle" {
+		t.Errorf("unexpected assignment %+v", a)
+	}
+
+	// 재지정: 새 담당자에게 assigned, 이전 담당자에게 unassigned
+	if _, err := svc.Assign(ctx, lead, 10, &AssignRequest{AssigneeID: &bob}); err != nil {
+		t.Fatal(err)
+	}
+	// 본인이 스스로 해제하면 본인에게는 보내지 않는다
+	if _, err := svc.Assign(ctx, bob, 10, &AssignRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := realtime.pushed[alice.String()]; len(got) != 2 || got[0] != "assigned" || got[1] != "unassigned" {
+		t.Errorf("alice events = %v", got)
+	}
+	if got := realtime.pushed[bob.String()]; len(got) != 1 || got[0] != "assigned" {
+		t.Errorf("bob events = %v", got)
+	}
+	if len(realtime.pushed[lead.String()]) != 0 {
+		t.Errorf("assigner should not be notified")
+	}
+
+	view, err := svc.Assignment(ctx, alice, 10)
+	if err != nil || view.Current != nil || len(view.History) != 3 {
+		t.Errorf("expected unassigned email with 3 history entries, got %+v (%v)", view, err)
+	}
+
+	outsider := uuid.New()
+	if _, err := svc.Assign(ctx, lead, 10, &AssignRequest{AssigneeID: &outsider}); !errors.Is(err, ErrInvalidAssignee) {
+		t.Errorf("non-member assignee: got %v", err)
+	}
+}
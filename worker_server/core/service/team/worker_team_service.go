@@ -0,0 +1,206 @@
+// Package team manages orgs, shared mailboxes and internal comments on shared emails.
+package team
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+const maxOrgNameLength = 100
+
+var (
+	ErrOrgNotFound      = errors.New("org not found")
+	ErrForbidden        = errors.New("only the org owner can do this")
+	ErrInvalidName      = errors.New("org name is required (max 100 characters)")
+	ErrInvalidRole      = errors.New("role must be owner or member")
+	ErrUserNotFound     = errors.New("no user with that email")
+	ErrOwnerCannotLeave = errors.New("the org creator cannot be removed")
+	ErrMailboxNotFound  = errors.New("mailbox not found")
+)
+
+// Notifier stores and delivers a user notification (notification.Service).
+type Notifier interface {
+	Send(ctx context.Context, notification *domain.Notification) error
+}
+
+// Service manages orgs, shared mailboxes and email comments.
+type Service struct {
+	repo        out.TeamRepository
+	comments    out.EmailCommentRepository
+	assignments out.EmailAssignmentRepository
+	slas        out.SLARepository
+	notifier    Notifier
+	realtime    out.RealtimePort
+}
+
+// NewService creates a new team service.
+func NewService(repo out.TeamRepository, comments out.EmailCommentRepository) *Service {
+	return &Service{repo: repo, comments: comments}
+}
+
+// SetNotifier enables mention notifications.
+func (s *Service) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// SetRealtime enables live comment updates for online org members (SSE).
+func (s *Service) SetRealtime(realtime out.RealtimePort) {
+	s.realtime = realtime
+}
+
+// CreateOrg creates an org owned by the user.
+func (s *Service) CreateOrg(ctx context.Context, userID uuid.UUID, name string) (*domain.Org, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxOrgNameLength {
+		return nil, ErrInvalidName
+	}
+
+	org := &domain.Org{Name: name, OwnerID: userID}
+	if err := s.repo.CreateOrg(ctx, org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// ListOrgs returns the orgs the user belongs to.
+func (s *Service) ListOrgs(ctx context.Context, userID uuid.UUID) ([]*domain.Org, error) {
+	return s.repo.ListOrgs(ctx, userID)
+}
+
+// ListMembers returns the members of an org the user belongs to.
+func (s *Service) ListMembers(ctx context.Context, userID uuid.UUID, orgID int64) ([]*domain.OrgMember, error) {
+	if _, err := s.role(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMembers(ctx, orgID)
+}
+
+// AddMember adds an existing user by email. Owner only.
+func (s *Service) AddMember(ctx context.Context, userID uuid.UUID, orgID int64, email string, role domain.OrgRole) (*domain.OrgMember, error) {
+	if role == "" {
+		role = domain.OrgRoleMember
+	}
+	if role != domain.OrgRoleOwner && role != domain.OrgRoleMember {
+		return nil, ErrInvalidRole
+	}
+	if err := s.requireOwner(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	member, err := s.repo.AddMemberByEmail(ctx, orgID, strings.TrimSpace(email), role)
+	if errors.Is(err, out.ErrTeamUserNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return member, err
+}
+
+// RemoveMember removes a member. Owners remove anyone, members can only leave.
+// 조직 생성자는 제거할 수 없다.
+func (s *Service) RemoveMember(ctx context.Context, userID uuid.UUID, orgID int64, memberID uuid.UUID) error {
+	role, err := s.role(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if memberID != userID && role != domain.OrgRoleOwner {
+		return ErrForbidden
+	}
+
+	orgs, err := s.repo.ListOrgs(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, org := range orgs {
+		if org.ID == orgID && org.OwnerID == memberID {
+			return ErrOwnerCannotLeave
+		}
+	}
+
+	if err := s.repo.RemoveMember(ctx, orgID, memberID); err != nil {
+		if errors.Is(err, out.ErrTeamNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ListMailboxes returns the mailboxes shared with an org.
+func (s *Service) ListMailboxes(ctx context.Context, userID uuid.UUID, orgID int64) ([]*domain.OrgMailbox, error) {
+	if _, err := s.role(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMailboxes(ctx, orgID)
+}
+
+// ShareMailbox shares one of the user's own connections with the org.
+func (s *Service) ShareMailbox(ctx context.Context, userID uuid.UUID, orgID, connectionID int64) (*domain.OrgMailbox, error) {
+	if _, err := s.role(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	mailbox, err := s.repo.ShareMailbox(ctx, orgID, userID, connectionID)
+	if errors.Is(err, out.ErrTeamNotFound) {
+		return nil, ErrMailboxNotFound
+	}
+	return mailbox, err
+}
+
+// UnshareMailbox stops sharing a mailbox. The owner or the member who shared it.
+func (s *Service) UnshareMailbox(ctx context.Context, userID uuid.UUID, orgID, connectionID int64) error {
+	role, err := s.role(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	mailboxes, err := s.repo.ListMailboxes(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	var mailbox *domain.OrgMailbox
+	for _, mb := range mailboxes {
+		if mb.ConnectionID == connectionID {
+			mailbox = mb
+			break
+		}
+	}
+	if mailbox == nil {
+		return ErrMailboxNotFound
+	}
+	if role != domain.OrgRoleOwner && mailbox.SharedBy != userID {
+		return ErrForbidden
+	}
+
+	if err := s.repo.UnshareMailbox(ctx, orgID, connectionID); err != nil {
+		if errors.Is(err, out.ErrTeamNotFound) {
+			return ErrMailboxNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Service) role(ctx context.Context, orgID int64, userID uuid.UUID) (domain.OrgRole, error) {
+	role, err := s.repo.MemberRole(ctx, orgID, userID)
+	if errors.Is(err, out.ErrTeamNotFound) {
+		return "", ErrOrgNotFound
+	}
+	return role, err
+}
+
+func (s *Service) requireOwner(ctx context.Context, orgID int64, userID uuid.UUID) error {
+	role, err := s.role(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if role != domain.OrgRoleOwner {
+		return ErrForbidden
+	}
+	return nil
+}
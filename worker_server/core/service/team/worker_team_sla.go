@@ -0,0 +1,197 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxSLAMinutes        = 30 * 24 * 60
+	defaultAtRiskPercent = 75
+	// escalationBatch - 한 번의 평가에서 보내는 에스컬레이션 수
+	escalationBatch = 500
+)
+
+var (
+	ErrSLADisabled        = errors.New("shared inbox sla is not configured")
+	ErrSLANotFound        = errors.New("sla not found")
+	ErrInvalidSLACategory = errors.New("category must be an ai category (lowercase) or \"default\"")
+	ErrInvalidSLAMinutes  = errors.New("response_minutes must be between 1 and 43200")
+	ErrInvalidSLAPercent  = errors.New("at_risk_percent must be between 1 and 99")
+)
+
+var slaCategoryPattern = regexp.MustCompile(`^[a-z_]{1,50}$`)
+
+// SLARequest is the input for setting the SLA of a category.
+type SLARequest struct {
+	ResponseMinutes int
+	AtRiskPercent   int // 0이면 75
+}
+
+// SetSLARepository enables shared inbox SLAs.
+func (s *Service) SetSLARepository(repo out.SLARepository) {
+	s.slas = repo
+}
+
+// ListSLAs returns the SLAs of an org.
+func (s *Service) ListSLAs(ctx context.Context, userID uuid.UUID, orgID int64) ([]*domain.OrgSLA, error) {
+	if s.slas == nil {
+		return nil, ErrSLADisabled
+	}
+	if _, err := s.role(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.slas.ListSLAs(ctx, orgID)
+}
+
+// SetSLA creates or updates the response-time SLA of a category. Owner only.
+func (s *Service) SetSLA(ctx context.Context, userID uuid.UUID, orgID int64, category string, req *SLARequest) (*domain.OrgSLA, error) {
+	if s.slas == nil {
+		return nil, ErrSLADisabled
+	}
+	if !slaCategoryPattern.MatchString(category) {
+		return nil, ErrInvalidSLACategory
+	}
+	if req.ResponseMinutes < 1 || req.ResponseMinutes > maxSLAMinutes {
+		return nil, ErrInvalidSLAMinutes
+	}
+	percent := req.AtRiskPercent
+	if percent == 0 {
+		percent = defaultAtRiskPercent
+	}
+	if percent < 1 || percent > 99 {
+		return nil, ErrInvalidSLAPercent
+	}
+	if err := s.requireOwner(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	sla := &domain.OrgSLA{OrgID: orgID, Category: category, ResponseMinutes: req.ResponseMinutes, AtRiskPercent: percent}
+	if err := s.slas.UpsertSLA(ctx, sla); err != nil {
+		return nil, err
+	}
+	return sla, nil
+}
+
+// DeleteSLA deletes the SLA of a category. Owner only.
+func (s *Service) DeleteSLA(ctx context.Context, userID uuid.UUID, orgID int64, category string) error {
+	if s.slas == nil {
+		return ErrSLADisabled
+	}
+	if err := s.requireOwner(ctx, orgID, userID); err != nil {
+		return err
+	}
+	if err := s.slas.DeleteSLA(ctx, orgID, category); err != nil {
+		if errors.Is(err, out.ErrSLANotFound) {
+			return ErrSLANotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// EvaluateSLAs recomputes SLA states and sends escalation notifications (SLA 워커에서 주기적으로 호출).
+// at_risk는 담당자(없으면 모든 멤버)에게, breached는 담당자와 조직 소유자(담당자가 없으면 모든 멤버)에게 보낸다.
+func (s *Service) EvaluateSLAs(ctx context.Context) (int, error) {
+	if s.slas == nil {
+		return 0, nil
+	}
+	if _, err := s.slas.Evaluate(ctx, time.Now()); err != nil {
+		return 0, err
+	}
+
+	escalations, err := s.slas.PendingEscalations(ctx, escalationBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	members := make(map[int64][]*domain.OrgMember)
+	sent := 0
+	for _, esc := range escalations {
+		orgMembers, ok := members[esc.OrgID]
+		if !ok {
+			if orgMembers, err = s.repo.ListMembers(ctx, esc.OrgID); err != nil {
+				logger.Warn("[TeamService.SLA] Failed to load members of org %d: %v", esc.OrgID, err)
+				continue
+			}
+			members[esc.OrgID] = orgMembers
+		}
+
+		if s.notifier != nil {
+			for _, recipient := range escalationRecipients(esc, orgMembers) {
+				if err := s.notifier.Send(ctx, slaNotification(esc, recipient)); err != nil {
+					logger.Warn("[TeamService.SLA] Failed to notify %s for email %d: %v", recipient, esc.EmailID, err)
+				}
+			}
+		}
+		if err := s.slas.MarkNotified(ctx, esc.EmailID, esc.Status); err != nil {
+			logger.Warn("[TeamService.SLA] Failed to mark email %d notified: %v", esc.EmailID, err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// escalationRecipients returns who to notify for the escalation.
+func escalationRecipients(esc *domain.SLAEscalation, members []*domain.OrgMember) []uuid.UUID {
+	var recipients []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	add := func(id uuid.UUID) {
+		if !seen[id] {
+			seen[id] = true
+			recipients = append(recipients, id)
+		}
+	}
+
+	// 조직을 떠난 담당자는 무시한다
+	if esc.AssigneeID != nil && findMember(members, *esc.AssigneeID) != nil {
+		add(*esc.AssigneeID)
+		if esc.Status == domain.SLAStatusBreached {
+			for _, m := range members {
+				if m.Role == domain.OrgRoleOwner {
+					add(m.UserID)
+				}
+			}
+		}
+		return recipients
+	}
+
+	for _, m := range members {
+		add(m.UserID)
+	}
+	return recipients
+}
+
+func slaNotification(esc *domain.SLAEscalation, recipient uuid.UUID) *domain.Notification {
+	title := fmt.Sprintf("SLA at risk: %s", esc.Subject)
+	priority := domain.NotificationPriorityHigh
+	if esc.Status == domain.SLAStatusBreached {
+		title = fmt.Sprintf("SLA breached: %s", esc.Subject)
+		priority = domain.NotificationPriorityUrgent
+	}
+
+	return &domain.Notification{
+		UserID:     recipient,
+		Type:       domain.NotificationTypeSLA,
+		Title:      title,
+		Body:       fmt.Sprintf("Reply to %s due %s", esc.FromEmail, esc.DueAt.UTC().Format(time.RFC3339)),
+		EntityType: "email",
+		EntityID:   esc.EmailID,
+		Priority:   priority,
+		Data: map[string]any{
+			"org_id":     esc.OrgID,
+			"sla_status": esc.Status,
+			"due_at":     esc.DueAt,
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+type memSLARepo struct {
+	out.SLARepository
+	slas     []*domain.OrgSLA
+	pending  []*domain.SLAEscalation
+	notified map[int64]domain.SLAStatus
+}
+
+func (r *memSLARepo) UpsertSLA(_ context.Context, sla *domain.OrgSLA) error {
+	r.slas = append(r.slas, sla)
+	return nil
+}
+
+func (r *memSLARepo) Evaluate(_ context.Context, _ time.Time) (int, error) { return 0, nil }
+
+func (r *memSLARepo) PendingEscalations(_ context.Context, _ int) ([]*domain.SLAEscalation, error) {
+	var pending []*domain.SLAEscalation
+	for _, esc := range r.pending {
+		if r.notified[esc.EmailID] != esc.Status {
+			pending = append(pending, esc)
+		}
+	}
+	return pending, nil
+}
+
+func (r *memSLARepo) MarkNotified(_ context.Context, emailID int64, status domain.SLAStatus) error {
+	r.notified[emailID] = status
+	return nil
+}
+
+type roleTeamRepo struct {
+	*memTeamRepo
+}
+
+func (r roleTeamRepo) MemberRole(_ context.Context, _ int64, userID uuid.UUID) (domain.OrgRole, error) {
+	if m := findMember(r.members, userID); m != nil {
+		return m.Role, nil
+	}
+	return "", out.ErrTeamNotFound
+}
+
+func TestSetSLAValidation(t *testing.T) {
+	ctx := context.Background()
+	owner, member := uuid.New(), uuid.New()
+	repo := roleTeamRepo{&memTeamRepo{members: []*domain.OrgMember{
+		{UserID: owner, Role: domain.OrgRoleOwner},
+		{UserID: member, Role: domain.OrgRoleMember},
+	}}}
+	svc := NewService(repo, &memCommentRepo{})
+
+	if _, err := svc.SetSLA(ctx, owner, 1, "work", &SLARequest{ResponseMinutes: 60}); !errors.Is(err, ErrSLADisabled) {
+		t.Fatalf("without repository: got %v", err)
+	}
+	svc.SetSLARepository(&memSLARepo{notified: make(map[int64]domain.SLAStatus)})
+
+	sla, err := svc.SetSLA(ctx, owner, 1, "work", &SLARequest{ResponseMinutes: 60})
+	if err != nil || sla.AtRiskPercent != defaultAtRiskPercent {
+		t.Errorf("expected default at-risk percent, got %+v (%v)", sla, err)
+	}
+	if _, err := svc.SetSLA(ctx, member, 1, "work", &SLARequest{ResponseMinutes: 60}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("member setting sla: got %v", err)
+	}
+	if _, err := svc.SetSLA(ctx, owner, 1, "Work!", &SLARequest{ResponseMinutes: 60}); !errors.Is(err, ErrInvalidSLACategory) {
+		t.Errorf("invalid category: got %v", err)
+	}
+	if _, err := svc.SetSLA(ctx, owner, 1, "default", &SLARequest{ResponseMinutes: 0}); !errors.Is(err, ErrInvalidSLAMinutes) {
+		t.Errorf("zero minutes: got %v", err)
+	}
+	if _, err := svc.SetSLA(ctx, owner, 1, "default", &SLARequest{ResponseMinutes: 30, AtRiskPercent: 100}); !errors.Is(err, ErrInvalidSLAPercent) {
+		t.Errorf("percent 100: got %v", err)
+	}
+}
+
+func TestEvaluateSLAsEscalates(t *testing.T) {
+	ctx := context.Background()
+	owner, alice, bob := uuid.New(), uuid.New(), uuid.New()
+	repo := &memTeamRepo{members: []*domain.OrgMember{
+		{UserID: owner, Role: domain.OrgRoleOwner},
+		{UserID: alice, Role: domain.OrgRoleMember},
+		{UserID: bob, Role: domain.OrgRoleMember},
+	}}
+	slas := &memSLARepo{
+		notified: make(map[int64]domain.SLAStatus),
+		pending: []*domain.SLAEscalation{
+			{EmailID: 1, OrgID: 7, Status: domain.SLAStatusAtRisk, AssigneeID: &alice, Subject: "Invoice"},
+			{EmailID: 2, OrgID: 7, Status: domain.SLAStatusBreached, Subject: "Refund"},
+		},
+	}
+	notifier := &fakeNotifier{}
+	svc := NewService(repo, &memCommentRepo{})
+	svc.SetNotifier(notifier)
+	svc.SetSLARepository(slas)
+
+	n, err := svc.EvaluateSLAs(ctx)
+	if err != nil || n != 2 {
+		t.Fatalf("evaluate: n=%d err=%v", n, err)
+	}
+	// 1: 담당자만, 2: 담당자가 없으니 모든 멤버
+	if len(notifier.sent) != 4 {
+		t.Fatalf("expected 4 notifications, got %d", len(notifier.sent))
+	}
+	if notifier.sent[0].UserID != alice || notifier.sent[0].Priority != domain.NotificationPriorityHigh {
+		t.Errorf("at-risk notification %+v", notifier.sent[0])
+	}
+	if notifier.sent[1].Priority != domain.NotificationPriorityUrgent || notifier.sent[1].Type != domain.NotificationTypeSLA {
+		t.Errorf("breach notification %+v", notifier.sent[1])
+	}
+
+	// 같은 상태로는 다시 알리지 않고, 담당 메일이 초과되면 담당자와 소유자에게 알린다
+	notifier.sent = nil
+	slas.pending[0].Status = domain.SLAStatusBreached
+	if n, _ := svc.EvaluateSLAs(ctx); n != 1 {
+		t.Errorf("expected only the newly breached email, got %d", n)
+	}
+	if len(notifier.sent) != 2 || notifier.sent[0].UserID != alice || notifier.sent[1].UserID != owner {
+		t.Errorf("breach of assigned email should go to assignee and owner, got %+v", notifier.sent)
+	}
+}
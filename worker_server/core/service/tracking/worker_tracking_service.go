@@ -0,0 +1,229 @@
+// Package tracking implements opt-in open (pixel) and click tracking for sent mail.
+package tracking
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+// Public routes that tracked messages point to.
+const (
+	OpenPath  = "/api/v1/track/o"
+	ClickPath = "/api/v1/track/c"
+)
+
+// maxUserAgentLength - 저장할 User-Agent 최대 길이
+const maxUserAgentLength = 512
+
+var (
+	ErrInvalidToken  = errors.New("invalid tracking token")
+	ErrNotConfigured = errors.New("send tracking not configured")
+)
+
+// href="http(s)://..." 만 재작성 (mailto:, cid:, # 등은 그대로)
+var hrefRe = regexp.MustCompile(`(?i)(<a\b[^>]*?\shref\s*=\s*)(["'])(https?://[^"']+)(["'])`)
+
+var bodyCloseRe = regexp.MustCompile(`(?i)</body\s*>`)
+
+// Pixel is the 1x1 transparent GIF served by the open endpoint.
+var Pixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// Token is the decoded content of a signed tracking token.
+type Token struct {
+	TrackingID int64  `json:"t"`
+	URL        string `json:"l,omitempty"` // click 대상 (open 토큰은 비어 있음)
+}
+
+// Service instruments outgoing HTML and records opens/clicks.
+type Service struct {
+	secret  []byte
+	baseURL string
+	repo    out.SendTrackingRepository
+}
+
+// NewService creates a new tracking service.
+// baseURL must be the public origin of this server - 수신자 메일 클라이언트가 직접 요청한다.
+func NewService(secret, baseURL string, repo out.SendTrackingRepository) *Service {
+	return &Service{
+		secret:  []byte(secret),
+		baseURL: strings.TrimRight(baseURL, "/"),
+		repo:    repo,
+	}
+}
+
+// Start creates a tracking record for a message about to be sent.
+func (s *Service) Start(ctx context.Context, userID uuid.UUID, connectionID int64, subject string, opens, clicks bool) (*domain.SentTracking, error) {
+	tracking := &domain.SentTracking{
+		UserID:       userID,
+		ConnectionID: connectionID,
+		Subject:      subject,
+		TrackOpens:   opens,
+		TrackClicks:  clicks,
+	}
+	if err := s.repo.Create(ctx, tracking); err != nil {
+		return nil, err
+	}
+	return tracking, nil
+}
+
+// Attach links the tracking record to the sent provider message.
+func (s *Service) Attach(ctx context.Context, tracking *domain.SentTracking, externalID string) {
+	if externalID == "" {
+		return
+	}
+	if err := s.repo.SetExternalID(ctx, tracking.ID, externalID); err != nil {
+		logger.WithError(err).Warn("[Tracking.Attach] Failed to link tracking %d", tracking.ID)
+	}
+}
+
+// Discard removes the tracking record of a message that failed to send.
+func (s *Service) Discard(ctx context.Context, tracking *domain.SentTracking) {
+	if err := s.repo.Delete(ctx, tracking.ID); err != nil {
+		logger.WithError(err).Warn("[Tracking.Discard] Failed to delete tracking %d", tracking.ID)
+	}
+}
+
+// Instrument rewrites links and appends the open pixel to an HTML body.
+func (s *Service) Instrument(tracking *domain.SentTracking, body string) string {
+	if tracking.TrackClicks {
+		body = hrefRe.ReplaceAllStringFunc(body, func(match string) string {
+			m := hrefRe.FindStringSubmatch(match)
+			if len(m) != 5 || m[2] != m[4] {
+				return match
+			}
+			target := html.UnescapeString(m[3])
+			link := s.baseURL + ClickPath + "?t=" + s.sign(&Token{TrackingID: tracking.ID, URL: target})
+			return m[1] + m[2] + html.EscapeString(link) + m[4]
+		})
+	}
+
+	if tracking.TrackOpens {
+		src := s.baseURL + OpenPath + "?t=" + s.sign(&Token{TrackingID: tracking.ID})
+		pixel := `<img src="` + html.EscapeString(src) + `" width="1" height="1" alt="" style="border:0;width:1px;height:1px">`
+		if loc := bodyCloseRe.FindStringIndex(body); loc != nil {
+			body = body[:loc[0]] + pixel + body[loc[0]:]
+		} else {
+			body += pixel
+		}
+	}
+	return body
+}
+
+// RecordOpen stores a pixel load. Failures are logged, never returned.
+func (s *Service) RecordOpen(ctx context.Context, token, userAgent string) {
+	t, err := s.verify(token)
+	if err != nil || t.URL != "" {
+		return
+	}
+	s.record(ctx, &domain.TrackingEvent{TrackingID: t.TrackingID, Type: domain.TrackingOpen, UserAgent: userAgent})
+}
+
+// RecordClick stores a click and returns the destination URL.
+func (s *Service) RecordClick(ctx context.Context, token, userAgent string) (string, error) {
+	t, err := s.verify(token)
+	if err != nil {
+		return "", err
+	}
+	if t.URL == "" {
+		return "", ErrInvalidToken
+	}
+	s.record(ctx, &domain.TrackingEvent{TrackingID: t.TrackingID, Type: domain.TrackingClick, URL: t.URL, UserAgent: userAgent})
+	return t.URL, nil
+}
+
+// GetEngagement aggregates opens/clicks of a sent message.
+// 추적하지 않은 메일은 tracked=false인 빈 요약을 반환한다.
+func (s *Service) GetEngagement(ctx context.Context, userID uuid.UUID, connectionID, emailID int64, externalID string) (*domain.SentEngagement, error) {
+	if externalID == "" {
+		return domain.BuildSentEngagement(emailID, nil, nil), nil
+	}
+
+	tracking, err := s.repo.GetByExternalID(ctx, userID, connectionID, externalID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return domain.BuildSentEngagement(emailID, nil, nil), nil
+		}
+		return nil, err
+	}
+
+	events, err := s.repo.ListEvents(ctx, tracking.ID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildSentEngagement(emailID, tracking, events), nil
+}
+
+func (s *Service) record(ctx context.Context, event *domain.TrackingEvent) {
+	if len(event.UserAgent) > maxUserAgentLength {
+		event.UserAgent = event.UserAgent[:maxUserAgentLength]
+	}
+	event.OccurredAt = time.Now()
+	if err := s.repo.RecordEvent(ctx, event); err != nil {
+		logger.WithError(err).Warn("[Tracking.record] Failed to record %s for tracking %d", event.Type, event.TrackingID)
+	}
+}
+
+// sign encodes a token as "payload.signature" (base64url).
+func (s *Service) sign(t *Token) string {
+	payload, _ := json.Marshal(t)
+	enc := base64.RawURLEncoding.EncodeToString(payload)
+	return enc + "." + base64.RawURLEncoding.EncodeToString(s.mac([]byte(enc)))
+}
+
+// verify decodes a token and checks its signature.
+func (s *Service) verify(token string) (*Token, error) {
+	if len(s.secret) == 0 {
+		return nil, ErrNotConfigured
+	}
+
+	enc, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	gotMAC, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(gotMAC, s.mac([]byte(enc))) {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(enc)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var t Token
+	if err := json.Unmarshal(payload, &t); err != nil || t.TrackingID <= 0 {
+		return nil, ErrInvalidToken
+	}
+	if t.URL != "" {
+		if u, err := url.Parse(t.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, ErrInvalidToken
+		}
+	}
+	return &t, nil
+}
+
+// mac is domain-separated from safe link tokens signed with the same secret.
+func (s *Service) mac(data []byte) []byte {
+	m := hmac.New(sha256.New, s.secret)
+	m.Write([]byte("track:"))
+	m.Write(data)
+	return m.Sum(nil)
+}
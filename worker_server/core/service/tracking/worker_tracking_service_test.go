@@ -0,0 +1,63 @@
+package tracking
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"worker_server/core/domain"
+)
+
+func TestInstrument(t *testing.T) {
+	svc := NewService("secret", "https://api.example.com", nil)
+	tracking := &domain.SentTracking{ID: 9, TrackOpens: true, TrackClicks: true}
+
+	body := `<html><body><a href="https://example.com/x?a=1&amp;b=2">one</a>` +
+		`<a href="mailto:me@example.com">mail</a></body></html>`
+
+	out := svc.Instrument(tracking, body)
+
+	// 링크는 클릭 추적 URL로, mailto는 그대로
+	if !strings.Contains(out, `href="https://api.example.com/api/v1/track/c?t=`) {
+		t.Errorf("click link not rewritten: %s", out)
+	}
+	if !strings.Contains(out, `href="mailto:me@example.com"`) {
+		t.Errorf("mailto link rewritten: %s", out)
+	}
+	// 픽셀은 </body> 앞에 삽입
+	if !strings.Contains(out, `height="1" alt="" style="border:0;width:1px;height:1px"></body>`) {
+		t.Errorf("pixel not inserted before </body>: %s", out)
+	}
+
+	m := regexp.MustCompile(`/api/v1/track/c\?t=([^"]+)"`).FindStringSubmatch(out)
+	if m == nil {
+		t.Fatalf("click token not found: %s", out)
+	}
+	tok, err := svc.verify(html.UnescapeString(m[1]))
+	if err != nil {
+		t.Fatalf("verify() error = %v", err)
+	}
+	if tok.TrackingID != 9 || tok.URL != "https://example.com/x?a=1&b=2" {
+		t.Errorf("verify() = %+v", tok)
+	}
+}
+
+func TestVerifyRejectsForeignTokens(t *testing.T) {
+	svc := NewService("secret", "", nil)
+	token := svc.sign(&Token{TrackingID: 1, URL: "https://example.com"})
+
+	if _, err := svc.verify(token[:len(token)-2] + "xx"); err != ErrInvalidToken {
+		t.Errorf("tampered token error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := NewService("other", "", nil).verify(token); err != ErrInvalidToken {
+		t.Errorf("foreign token error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := svc.verify(svc.sign(&Token{TrackingID: 1, URL: "javascript:alert(1)"})); err != ErrInvalidToken {
+		t.Errorf("javascript token error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := url.Parse(token); err != nil {
+		t.Errorf("token is not URL safe: %v", err)
+	}
+}
@@ -0,0 +1,539 @@
+// Package upload orchestrates large attachment uploads for outgoing mail.
+//
+// Outlook: 클라이언트가 draft에 연결된 Graph 업로드 세션으로 직접 전송하고, 발송 시 draft를 보낸다.
+// Provider로 직접 보낼 수 없는 클라이언트(CORS 제한 webview)는 proxy 모드로 우리 API를 거쳐 전달한다.
+// Gmail: 첨부 업로드 API가 없으므로 우리 API로 청크를 받아 두었다가(staged) 발송 시 MIME에 포함한다.
+package upload
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/oauth2"
+)
+
+// ChunkPath is the API route (relative to /api/v1) that receives staged chunks.
+const ChunkPath = "/email/attachments/upload/"
+
+const (
+	sessionKeyPrefix = "upload:session:"
+	dataKeyPrefix    = "upload:data:"
+	lockKeyPrefix    = "upload:lock:"
+	targetKeyPrefix  = "upload:target:" // proxy 모드의 Provider 업로드 URL
+
+	// MaxStagedSize - Gmail 첨부 한도 (25MB)
+	MaxStagedSize     = 25 * 1024 * 1024
+	stagedSessionTTL  = 24 * time.Hour
+	stagedChunkSize   = 4 * 1024 * 1024
+	stagedMaxChunk    = 8 * 1024 * 1024
+	chunkLockDuration = 30 * time.Second
+)
+
+var (
+	ErrNotConfigured       = errors.New("upload sessions not configured")
+	ErrSessionNotFound     = errors.New("upload session not found")
+	ErrUploadIncomplete    = errors.New("upload session is not complete")
+	ErrInvalidRange        = errors.New("chunk does not continue the upload")
+	ErrTooLarge            = errors.New("attachment exceeds provider limit")
+	ErrUnsupportedProvider = errors.New("unsupported provider")
+	ErrDraftMismatch       = errors.New("upload sessions belong to different drafts")
+	ErrSessionBusy         = errors.New("another chunk is being written")
+	ErrConnectionNotFound  = errors.New("connection not found")
+)
+
+// ConnectionProvider resolves connections and tokens (auth.OAuthService).
+type ConnectionProvider interface {
+	GetConnection(ctx context.Context, connectionID int64) (*domain.OAuthConnection, error)
+	GetOAuth2Token(ctx context.Context, connectionID int64) (*oauth2.Token, error)
+}
+
+// messageAttachmentLister is implemented by providers that keep uploads on a draft (Outlook).
+type messageAttachmentLister interface {
+	ListMessageAttachments(ctx context.Context, token *oauth2.Token, messageID string) ([]out.ProviderMailAttachment, error)
+}
+
+// chunkUploader is implemented by providers whose upload URLs accept proxied chunks (Outlook).
+type chunkUploader interface {
+	UploadChunk(ctx context.Context, uploadURL string, offset, total int64, data []byte) (*out.UploadSessionStatus, error)
+}
+
+// CreateRequest describes a new upload session.
+type CreateRequest struct {
+	ConnectionID int64
+	MessageID    string // Outlook draft ID (비어 있으면 새 draft 생성)
+	Filename     string
+	Size         int64
+	MimeType     string
+	IsInline     bool
+	ContentID    string
+	Proxy        bool // 청크를 우리 API로 받아 Provider로 전달
+}
+
+// Service manages upload sessions in Redis.
+type Service struct {
+	redis       *redis.Client
+	connections ConnectionProvider
+	providers   map[string]out.EmailProviderPort
+	realtime    out.RealtimePort
+	baseURL     string
+}
+
+// NewService creates a new upload service. baseURL is the public origin used for staged upload URLs.
+func NewService(redisClient *redis.Client, connections ConnectionProvider, baseURL string) *Service {
+	return &Service{
+		redis:       redisClient,
+		connections: connections,
+		providers:   make(map[string]out.EmailProviderPort),
+		baseURL:     strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// RegisterProvider registers a provider under a connection provider name (google, gmail, outlook ...).
+func (s *Service) RegisterProvider(name string, provider out.EmailProviderPort) {
+	s.providers[name] = provider
+}
+
+// SetRealtime sets the realtime port used for progress events.
+func (s *Service) SetRealtime(realtime out.RealtimePort) {
+	s.realtime = realtime
+}
+
+// Provider returns the provider for a connection provider name.
+func (s *Service) Provider(name string) out.EmailProviderPort {
+	return s.providers[name]
+}
+
+// Create starts a new upload session.
+func (s *Service) Create(ctx context.Context, userID uuid.UUID, req *CreateRequest) (*domain.UploadSession, error) {
+	if s.redis == nil {
+		return nil, ErrNotConfigured
+	}
+
+	conn, token, err := s.connection(ctx, userID, req.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &domain.UploadSession{
+		ID:           newSessionID(),
+		UserID:       userID,
+		ConnectionID: conn.ID,
+		Provider:     string(conn.Provider),
+		Filename:     req.Filename,
+		MimeType:     req.MimeType,
+		Size:         req.Size,
+		IsInline:     req.IsInline,
+		ContentID:    req.ContentID,
+		CreatedAt:    now,
+	}
+
+	switch conn.Provider {
+	case "google", "gmail":
+		if req.Size > MaxStagedSize {
+			return nil, ErrTooLarge
+		}
+		session.Mode = domain.UploadModeStaged
+		session.UploadURL = s.baseURL + "/api/v1" + ChunkPath + session.ID
+		session.ChunkSize = stagedChunkSize
+		session.MaxChunkSize = stagedMaxChunk
+		session.ExpiresAt = now.Add(stagedSessionTTL)
+
+	case "outlook", "microsoft":
+		provider := s.providers[string(conn.Provider)]
+		if provider == nil {
+			return nil, ErrUnsupportedProvider
+		}
+
+		// Graph 업로드 세션은 메시지에 붙으므로 draft가 없으면 빈 draft를 만든다
+		messageID := req.MessageID
+		if messageID == "" {
+			draft, err := provider.CreateDraft(ctx, token, &out.ProviderOutgoingMessage{IsHTML: true})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create draft: %w", err)
+			}
+			messageID = draft.ExternalID
+		}
+
+		resp, err := provider.CreateUploadSession(ctx, token, messageID, &out.UploadSessionRequest{
+			Filename:  req.Filename,
+			Size:      req.Size,
+			MimeType:  req.MimeType,
+			IsInline:  req.IsInline,
+			ContentID: req.ContentID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		session.Mode = domain.UploadModeDirect
+		session.MessageID = messageID
+		session.UploadURL = resp.UploadURL
+		session.ChunkSize = resp.ChunkSize
+		session.MaxChunkSize = resp.MaxChunkSize
+		session.ExpiresAt = resp.ExpiresAt
+
+		if req.Proxy {
+			if _, ok := provider.(chunkUploader); !ok {
+				return nil, ErrUnsupportedProvider
+			}
+			session.Mode = domain.UploadModeProxy
+			session.UploadURL = s.baseURL + "/api/v1" + ChunkPath + session.ID
+			if err := s.redis.Set(ctx, targetKeyPrefix+session.ID, resp.UploadURL, time.Until(resp.ExpiresAt)).Err(); err != nil {
+				return nil, err
+			}
+		}
+
+	default:
+		return nil, ErrUnsupportedProvider
+	}
+
+	if err := s.save(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Get returns a session owned by userID.
+func (s *Service) Get(ctx context.Context, userID uuid.UUID, sessionID string) (*domain.UploadSession, error) {
+	if s.redis == nil {
+		return nil, ErrNotConfigured
+	}
+
+	data, err := s.redis.Get(ctx, sessionKeyPrefix+sessionID).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	var session domain.UploadSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+	if session.UserID != userID {
+		return nil, ErrSessionNotFound
+	}
+	return &session, nil
+}
+
+// WriteChunk stores (staged) or forwards (proxy) a chunk. offset must equal the bytes already
+// received so that clients can resume from bytes_uploaded after a failure.
+func (s *Service) WriteChunk(ctx context.Context, userID uuid.UUID, sessionID string, offset int64, data []byte) (*domain.UploadSession, error) {
+	session, err := s.Get(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Mode != domain.UploadModeStaged && session.Mode != domain.UploadModeProxy {
+		return nil, ErrInvalidRange
+	}
+	if session.MaxChunkSize > 0 && int64(len(data)) > session.MaxChunkSize {
+		return nil, ErrTooLarge
+	}
+
+	locked, err := s.redis.SetNX(ctx, lockKeyPrefix+sessionID, 1, chunkLockDuration).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, ErrSessionBusy
+	}
+	defer s.redis.Del(context.Background(), lockKeyPrefix+sessionID)
+
+	// 이미 받은 청크 재전송은 무시 (idempotent resume)
+	if offset+int64(len(data)) <= session.BytesUploaded {
+		return session, nil
+	}
+	if offset+int64(len(data)) > session.Size {
+		return nil, ErrTooLarge
+	}
+
+	if session.Mode == domain.UploadModeProxy {
+		err = s.forwardChunk(ctx, session, offset, data)
+	} else {
+		err = s.stageChunk(ctx, session, offset, data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, session); err != nil {
+		return nil, err
+	}
+	s.pushProgress(ctx, session)
+	return session, nil
+}
+
+// stageChunk appends a chunk to the staged data in Redis.
+func (s *Service) stageChunk(ctx context.Context, session *domain.UploadSession, offset int64, data []byte) error {
+	if offset != session.BytesUploaded {
+		return ErrInvalidRange
+	}
+
+	ttl := time.Until(session.ExpiresAt)
+	pipe := s.redis.TxPipeline()
+	pipe.SetRange(ctx, dataKeyPrefix+session.ID, offset, string(data))
+	pipe.Expire(ctx, dataKeyPrefix+session.ID, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to store chunk: %w", err)
+	}
+
+	session.BytesUploaded = offset + int64(len(data))
+	session.IsComplete = session.BytesUploaded == session.Size
+	return nil
+}
+
+// forwardChunk streams a chunk to the provider upload URL.
+func (s *Service) forwardChunk(ctx context.Context, session *domain.UploadSession, offset int64, data []byte) error {
+	provider := s.providers[session.Provider]
+	uploader, ok := provider.(chunkUploader)
+	if !ok {
+		return ErrUnsupportedProvider
+	}
+	target, err := s.providerURL(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	// 이전 청크 응답을 놓쳤을 수 있으므로 Provider 기준 진행률로 다시 맞춘다
+	if offset != session.BytesUploaded {
+		if err := s.refreshDirect(ctx, session); err != nil {
+			return err
+		}
+		if offset != session.BytesUploaded {
+			return ErrInvalidRange
+		}
+	}
+
+	status, err := uploader.UploadChunk(ctx, target, offset, session.Size, data)
+	if err != nil {
+		// Provider가 범위를 거부한 경우 재개 위치를 알려준다
+		if refreshErr := s.refreshDirect(ctx, session); refreshErr == nil && session.BytesUploaded != offset {
+			return ErrInvalidRange
+		}
+		return fmt.Errorf("failed to forward chunk: %w", err)
+	}
+
+	session.BytesUploaded = status.BytesUploaded
+	session.IsComplete = status.IsComplete
+	if status.AttachmentID != "" {
+		session.AttachmentID = status.AttachmentID
+	}
+	return nil
+}
+
+// Status refreshes and returns the upload progress.
+func (s *Service) Status(ctx context.Context, userID uuid.UUID, sessionID string) (*domain.UploadSession, error) {
+	session, err := s.Get(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.OnDraft() || session.IsComplete {
+		return session, nil
+	}
+
+	if err := s.refreshDirect(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Cancel aborts the upload and removes staged data.
+func (s *Service) Cancel(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	session, err := s.Get(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.OnDraft() && !session.IsComplete {
+		provider := s.providers[session.Provider]
+		target, targetErr := s.providerURL(ctx, session)
+		if provider != nil && targetErr == nil {
+			if token, err := s.connections.GetOAuth2Token(ctx, session.ConnectionID); err == nil {
+				if err := provider.CancelUploadSession(ctx, token, target); err != nil {
+					logger.WithError(err).Warn("[UploadService.Cancel] Failed to cancel provider session")
+				}
+			}
+		}
+	}
+
+	s.Release(ctx, []*domain.UploadSession{session})
+	return nil
+}
+
+// Resolve loads completed sessions for sending. All sessions must belong to connectionID.
+func (s *Service) Resolve(ctx context.Context, userID uuid.UUID, connectionID int64, sessionIDs []string) ([]*domain.UploadSession, error) {
+	sessions := make([]*domain.UploadSession, 0, len(sessionIDs))
+	for _, id := range sessionIDs {
+		session, err := s.Get(ctx, userID, id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, id)
+		}
+		if session.ConnectionID != connectionID {
+			return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, id)
+		}
+		if session.OnDraft() && !session.IsComplete {
+			if err := s.refreshDirect(ctx, session); err != nil {
+				return nil, err
+			}
+		}
+		if !session.IsComplete {
+			return nil, fmt.Errorf("%w: %s", ErrUploadIncomplete, id)
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// StagedData returns the uploaded bytes of a staged session.
+func (s *Service) StagedData(ctx context.Context, session *domain.UploadSession) ([]byte, error) {
+	data, err := s.redis.Get(ctx, dataKeyPrefix+session.ID).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, session.ID)
+		}
+		return nil, err
+	}
+	if int64(len(data)) != session.Size {
+		return nil, fmt.Errorf("%w: %s", ErrUploadIncomplete, session.ID)
+	}
+	return data, nil
+}
+
+// Release deletes sessions and staged data (after send or cancel).
+func (s *Service) Release(ctx context.Context, sessions []*domain.UploadSession) {
+	if s.redis == nil || len(sessions) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(sessions)*3)
+	for _, session := range sessions {
+		keys = append(keys, sessionKeyPrefix+session.ID, dataKeyPrefix+session.ID, targetKeyPrefix+session.ID)
+	}
+	if err := s.redis.Del(ctx, keys...).Err(); err != nil {
+		logger.WithError(err).Warn("[UploadService.Release] Failed to delete sessions")
+	}
+}
+
+// refreshDirect checks a provider upload. Graph 업로드 URL은 완료 후 사라지므로
+// 세션 조회가 실패하면 draft 첨부 목록에서 파일을 찾는다.
+func (s *Service) refreshDirect(ctx context.Context, session *domain.UploadSession) error {
+	provider := s.providers[session.Provider]
+	if provider == nil {
+		return ErrUnsupportedProvider
+	}
+	token, err := s.connections.GetOAuth2Token(ctx, session.ConnectionID)
+	if err != nil {
+		return fmt.Errorf("failed to get oauth token: %w", err)
+	}
+
+	target, err := s.providerURL(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	status, statusErr := provider.GetUploadSessionStatus(ctx, token, target)
+	if statusErr == nil {
+		session.BytesUploaded = status.BytesUploaded
+		session.IsComplete = status.IsComplete
+		if status.AttachmentID != "" {
+			session.AttachmentID = status.AttachmentID
+		}
+	}
+
+	if !session.IsComplete {
+		if lister, ok := provider.(messageAttachmentLister); ok && session.MessageID != "" {
+			attachments, err := lister.ListMessageAttachments(ctx, token, session.MessageID)
+			if err == nil {
+				for _, att := range attachments {
+					if att.Filename == session.Filename && att.Size >= session.Size {
+						session.IsComplete = true
+						session.BytesUploaded = session.Size
+						session.AttachmentID = att.ID
+						break
+					}
+				}
+			} else if statusErr != nil {
+				return fmt.Errorf("failed to check upload: %w", err)
+			}
+		}
+	}
+
+	return s.save(ctx, session)
+}
+
+// providerURL returns the provider upload URL of a direct or proxy session.
+func (s *Service) providerURL(ctx context.Context, session *domain.UploadSession) (string, error) {
+	if session.Mode != domain.UploadModeProxy {
+		return session.UploadURL, nil
+	}
+	target, err := s.redis.Get(ctx, targetKeyPrefix+session.ID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", fmt.Errorf("%w: %s", ErrSessionNotFound, session.ID)
+		}
+		return "", err
+	}
+	return target, nil
+}
+
+// pushProgress sends an upload progress event to the user's realtime channel.
+func (s *Service) pushProgress(ctx context.Context, session *domain.UploadSession) {
+	if s.realtime == nil {
+		return
+	}
+	s.realtime.Push(ctx, session.UserID.String(), &domain.RealtimeEvent{
+		Type:      domain.EventUploadProgress,
+		Timestamp: time.Now(),
+		Data: &domain.UploadProgressData{
+			SessionID:     session.ID,
+			Filename:      session.Filename,
+			BytesUploaded: session.BytesUploaded,
+			Size:          session.Size,
+			IsComplete:    session.IsComplete,
+		},
+	})
+}
+
+// connection loads a connection owned by userID with its token.
+func (s *Service) connection(ctx context.Context, userID uuid.UUID, connectionID int64) (*domain.OAuthConnection, *oauth2.Token, error) {
+	conn, err := s.connections.GetConnection(ctx, connectionID)
+	if err != nil || conn == nil || conn.UserID != userID {
+		return nil, nil, fmt.Errorf("%w: %d", ErrConnectionNotFound, connectionID)
+	}
+	token, err := s.connections.GetOAuth2Token(ctx, connectionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get oauth token: %w", err)
+	}
+	return conn, token, nil
+}
+
+func (s *Service) save(ctx context.Context, session *domain.UploadSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return s.redis.Set(ctx, sessionKeyPrefix+session.ID, data, ttl).Err()
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return "up_" + hex.EncodeToString(b)
+}
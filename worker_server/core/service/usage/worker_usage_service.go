@@ -0,0 +1,165 @@
+// Package usage records LLM/embedding token usage and enforces monthly AI budgets.
+package usage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"worker_server/core/agent/llm"
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+// spendCacheTTL - 월 누적 비용을 DB에서 다시 읽는 간격 (그 사이에는 기록한 비용을 더해 둔다)
+const spendCacheTTL = time.Minute
+
+// nonEssentialTasks are paused when a user exceeds the monthly budget.
+// 분류/답장/채팅 등 사용자가 기다리는 작업은 예산을 넘어도 계속 처리한다.
+var nonEssentialTasks = map[llm.TaskType]bool{
+	llm.TaskSummarize: true,
+	llm.TaskEmbed:     true,
+}
+
+// IsEssential reports whether task keeps running after the budget is exceeded.
+func IsEssential(task llm.TaskType) bool {
+	return !nonEssentialTasks[task]
+}
+
+// Service implements llm.UsageRecorder and answers budget checks.
+// 기록 실패는 LLM 호출을 실패시키지 않도록 로그만 남긴다.
+type Service struct {
+	repo      out.AIUsageRepository
+	budgetUSD float64 // 사용자별 월 예산, 0 = 무제한
+
+	mu    sync.Mutex
+	spent map[uuid.UUID]*monthlySpend
+	now   func() time.Time
+}
+
+type monthlySpend struct {
+	period   string
+	costUSD  float64
+	loadedAt time.Time
+}
+
+// NewService creates a usage service. budgetUSD <= 0 disables budget enforcement.
+func NewService(repo out.AIUsageRepository, budgetUSD float64) *Service {
+	return &Service{
+		repo:      repo,
+		budgetUSD: budgetUSD,
+		spent:     make(map[uuid.UUID]*monthlySpend),
+		now:       time.Now,
+	}
+}
+
+// BudgetUSD returns the monthly per-user budget (0 = unlimited).
+func (s *Service) BudgetUSD() float64 {
+	return s.budgetUSD
+}
+
+// RecordUsage accumulates the usage of one LLM call.
+func (s *Service) RecordUsage(ctx context.Context, e llm.UsageEvent) {
+	period := domain.UsagePeriod(s.now())
+	err := s.repo.Add(ctx, &domain.AIUsage{
+		UserID:           e.Scope.UserID,
+		ConnectionID:     e.Scope.ConnectionID,
+		Task:             string(e.Scope.Task),
+		Model:            e.Model,
+		Period:           period,
+		Requests:         1,
+		PromptTokens:     int64(e.PromptTokens),
+		CompletionTokens: int64(e.CompletionTokens),
+		CostUSD:          e.CostUSD,
+	})
+	if err != nil {
+		logger.Warn("[Usage] Failed to record %s usage for user %s: %v", e.Scope.Task, e.Scope.UserID, err)
+		return
+	}
+
+	s.mu.Lock()
+	if c, ok := s.spent[e.Scope.UserID]; ok && c.period == period {
+		c.costUSD += e.CostUSD
+	}
+	s.mu.Unlock()
+}
+
+// Allow reports whether task may run for the user under the monthly budget.
+// 예산 조회에 실패하면 작업을 막지 않는다 (fail open).
+func (s *Service) Allow(ctx context.Context, userID uuid.UUID, task llm.TaskType) bool {
+	if s.budgetUSD <= 0 || userID == uuid.Nil || IsEssential(task) {
+		return true
+	}
+	return !s.exceeded(ctx, userID)
+}
+
+// exceeded reports whether the user's spend this month reached the budget.
+func (s *Service) exceeded(ctx context.Context, userID uuid.UUID) bool {
+	now := s.now()
+	period := domain.UsagePeriod(now)
+
+	s.mu.Lock()
+	c, ok := s.spent[userID]
+	s.mu.Unlock()
+	if ok && c.period == period && now.Sub(c.loadedAt) < spendCacheTTL {
+		return c.costUSD >= s.budgetUSD
+	}
+
+	cost, err := s.repo.CostByUser(ctx, userID, period)
+	if err != nil {
+		logger.Warn("[Usage] Failed to load spend of user %s: %v", userID, err)
+		return false
+	}
+
+	s.mu.Lock()
+	s.spent[userID] = &monthlySpend{period: period, costUSD: cost, loadedAt: now}
+	s.mu.Unlock()
+	return cost >= s.budgetUSD
+}
+
+// Summary returns the user's usage in period (YYYY-MM, empty = current month).
+func (s *Service) Summary(ctx context.Context, userID uuid.UUID, period string) (*domain.AIUsageSummary, error) {
+	if period == "" {
+		period = domain.UsagePeriod(s.now())
+	}
+
+	rows, err := s.repo.ListByUser(ctx, userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.AIUsageSummary{
+		Period:       period,
+		ByTask:       make(map[string]domain.AIUsageTotals),
+		ByConnection: make(map[int64]domain.AIUsageTotals),
+		ByModel:      make(map[string]domain.AIUsageTotals),
+		BudgetUSD:    s.budgetUSD,
+	}
+	for _, u := range rows {
+		summary.Total.Add(u)
+		addTo(summary.ByTask, u.Task, u)
+		addTo(summary.ByConnection, u.ConnectionID, u)
+		addTo(summary.ByModel, u.Model, u)
+	}
+
+	if s.budgetUSD > 0 && summary.Total.CostUSD >= s.budgetUSD {
+		summary.BudgetExceeded = true
+		for task := range nonEssentialTasks {
+			summary.PausedTasks = append(summary.PausedTasks, string(task))
+		}
+		sort.Strings(summary.PausedTasks)
+	}
+	return summary, nil
+}
+
+func addTo[K comparable](m map[K]domain.AIUsageTotals, key K, u *domain.AIUsage) {
+	t := m[key]
+	t.Add(u)
+	m[key] = t
+}
+
+var _ llm.UsageRecorder = (*Service)(nil)
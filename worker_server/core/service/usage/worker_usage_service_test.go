@@ -0,0 +1,96 @@
+package usage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"worker_server/core/agent/llm"
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type memUsageRepo struct {
+	rows []*domain.AIUsage
+}
+
+func (r *memUsageRepo) Add(_ context.Context, u *domain.AIUsage) error {
+	r.rows = append(r.rows, u)
+	return nil
+}
+
+func (r *memUsageRepo) ListByUser(_ context.Context, userID uuid.UUID, period string) ([]*domain.AIUsage, error) {
+	var rows []*domain.AIUsage
+	for _, u := range r.rows {
+		if u.UserID == userID && u.Period == period {
+			rows = append(rows, u)
+		}
+	}
+	return rows, nil
+}
+
+func (r *memUsageRepo) CostByUser(ctx context.Context, userID uuid.UUID, period string) (float64, error) {
+	rows, _ := r.ListByUser(ctx, userID, period)
+	var cost float64
+	for _, u := range rows {
+		cost += u.CostUSD
+	}
+	return cost, nil
+}
+
+func TestBudgetPausesNonEssentialTasks(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	svc := NewService(&memUsageRepo{}, 1.0)
+
+	if !svc.Allow(ctx, userID, llm.TaskSummarize) {
+		t.Fatal("summarize should be allowed under budget")
+	}
+
+	svc.RecordUsage(ctx, llm.UsageEvent{
+		Scope:   llm.UsageScope{UserID: userID, ConnectionID: 7, Task: llm.TaskSummarize},
+		Model:   "gpt-4o-mini",
+		CostUSD: 1.5,
+	})
+
+	// 캐시된 월 비용에 기록한 비용이 바로 반영되어야 한다
+	if svc.Allow(ctx, userID, llm.TaskSummarize) || svc.Allow(ctx, userID, llm.TaskEmbed) {
+		t.Error("non-essential tasks should be paused over budget")
+	}
+	if !svc.Allow(ctx, userID, llm.TaskClassify) {
+		t.Error("classification is essential and should keep running")
+	}
+	if !svc.Allow(ctx, uuid.New(), llm.TaskSummarize) {
+		t.Error("budget is per user")
+	}
+
+	summary, err := svc.Summary(ctx, userID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !summary.BudgetExceeded || summary.ByConnection[7].Requests != 1 || len(summary.PausedTasks) != 2 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
+
+func TestBudgetResetsMonthly(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Date(2026, 1, 31, 23, 0, 0, 0, time.UTC)
+	svc := NewService(&memUsageRepo{}, 1.0)
+	svc.now = func() time.Time { return now }
+
+	svc.RecordUsage(ctx, llm.UsageEvent{
+		Scope:   llm.UsageScope{UserID: userID, Task: llm.TaskEmbed},
+		CostUSD: 2,
+	})
+	if svc.Allow(ctx, userID, llm.TaskEmbed) {
+		t.Fatal("embedding should be paused over budget")
+	}
+
+	now = now.Add(2 * time.Hour)
+	if !svc.Allow(ctx, userID, llm.TaskEmbed) {
+		t.Error("budget should reset in the next month")
+	}
+}
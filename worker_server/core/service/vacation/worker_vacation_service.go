@@ -0,0 +1,299 @@
+// Package vacation manages out-of-office responders per connection.
+package vacation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// replyInterval - 같은 발신자에게 다시 응답하기까지의 간격 (Gmail과 동일)
+	replyInterval = 4 * 24 * time.Hour
+
+	maxSubjectLength = 998
+	maxBodyLength    = 64 * 1024
+)
+
+var (
+	ErrConnectionNotFound  = errors.New("connection not found")
+	ErrUnsupportedProvider = errors.New("provider does not support sending")
+	ErrEmptyMessage        = errors.New("vacation message body is required")
+	ErrMessageTooLong      = errors.New("vacation message is too long")
+	ErrInvalidSchedule     = errors.New("end_at must be after start_at")
+)
+
+// ConnectionProvider resolves connections and tokens (auth.OAuthService).
+type ConnectionProvider interface {
+	GetConnection(ctx context.Context, connectionID int64) (*domain.OAuthConnection, error)
+	GetOAuth2Token(ctx context.Context, connectionID int64) (*oauth2.Token, error)
+}
+
+// nativeResponder is implemented by providers with a built-in vacation setting (Gmail, Outlook).
+type nativeResponder interface {
+	GetVacation(ctx context.Context, token *oauth2.Token) (*out.ProviderVacationSettings, error)
+	SetVacation(ctx context.Context, token *oauth2.Token, settings *out.ProviderVacationSettings) error
+}
+
+// UpdateRequest describes the responder to save.
+type UpdateRequest struct {
+	Enabled bool
+	Subject string
+	Body    string
+	IsHTML  bool
+	StartAt *time.Time
+	EndAt   *time.Time
+}
+
+// Service proxies provider vacation settings or auto-replies during delta sync.
+type Service struct {
+	repo        out.VacationRepository
+	connections ConnectionProvider
+	providers   map[string]out.EmailProviderPort
+}
+
+// NewService creates a new vacation service.
+func NewService(repo out.VacationRepository, connections ConnectionProvider) *Service {
+	return &Service{
+		repo:        repo,
+		connections: connections,
+		providers:   make(map[string]out.EmailProviderPort),
+	}
+}
+
+// RegisterProvider registers a provider under a connection provider name (google, gmail, outlook ...).
+func (s *Service) RegisterProvider(name string, provider out.EmailProviderPort) {
+	s.providers[name] = provider
+}
+
+// Get returns the responder of a connection.
+// native 모드는 Provider 값을 우선한다 (Gmail/Outlook에서 직접 바꾼 경우 반영).
+func (s *Service) Get(ctx context.Context, userID uuid.UUID, connectionID int64) (*domain.VacationResponder, error) {
+	conn, err := s.connection(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	responder, err := s.repo.Get(ctx, connectionID)
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			return nil, err
+		}
+		responder = &domain.VacationResponder{ConnectionID: conn.ID, UserID: userID, Mode: domain.VacationModeNative}
+	}
+	if responder.Mode != domain.VacationModeNative {
+		return responder, nil
+	}
+
+	native, ok := s.providers[string(conn.Provider)].(nativeResponder)
+	if !ok {
+		return responder, nil
+	}
+	token, err := s.connections.GetOAuth2Token(ctx, conn.ID)
+	if err != nil {
+		return responder, nil
+	}
+	settings, err := native.GetVacation(ctx, token)
+	if err != nil {
+		logger.WithError(err).Warn("[Vacation.Get] Failed to read provider settings for connection %d", conn.ID)
+		return responder, nil
+	}
+
+	responder.Enabled = settings.Enabled
+	responder.Body = settings.Body
+	responder.IsHTML = settings.IsHTML
+	responder.StartAt = settings.StartAt
+	responder.EndAt = settings.EndAt
+	if settings.Subject != "" {
+		responder.Subject = settings.Subject
+	}
+	return responder, nil
+}
+
+// Update saves the responder. Provider 자체 설정을 우선 사용하고, 지원하지 않거나
+// 권한(scope)이 없으면 auto_reply 모드로 저장해 delta sync에서 응답한다.
+func (s *Service) Update(ctx context.Context, userID uuid.UUID, connectionID int64, req *UpdateRequest) (*domain.VacationResponder, error) {
+	if err := validate(req); err != nil {
+		return nil, err
+	}
+
+	conn, err := s.connection(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	provider, ok := s.providers[string(conn.Provider)]
+	if !ok {
+		return nil, ErrUnsupportedProvider
+	}
+
+	responder := &domain.VacationResponder{
+		ConnectionID: conn.ID,
+		UserID:       userID,
+		Enabled:      req.Enabled,
+		Mode:         domain.VacationModeAutoReply,
+		Subject:      strings.TrimSpace(req.Subject),
+		Body:         req.Body,
+		IsHTML:       req.IsHTML,
+		StartAt:      req.StartAt,
+		EndAt:        req.EndAt,
+	}
+
+	if native, ok := provider.(nativeResponder); ok {
+		token, err := s.connections.GetOAuth2Token(ctx, conn.ID)
+		if err != nil {
+			return nil, err
+		}
+		err = native.SetVacation(ctx, token, &out.ProviderVacationSettings{
+			Enabled: req.Enabled,
+			Subject: responder.Subject,
+			Body:    req.Body,
+			IsHTML:  req.IsHTML,
+			StartAt: req.StartAt,
+			EndAt:   req.EndAt,
+		})
+		switch {
+		case err == nil:
+			responder.Mode = domain.VacationModeNative
+		case isPermissionError(err):
+			// 설정 scope 추가 전에 연결된 계정 - 재연결 전까지 직접 응답
+			logger.Warn("[Vacation.Update] Provider settings not permitted for connection %d, using auto-reply", conn.ID)
+		default:
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Upsert(ctx, responder); err != nil {
+		return nil, err
+	}
+	return responder, nil
+}
+
+// ActiveAutoResponder returns the auto_reply responder of a connection, or nil.
+// delta sync 배치마다 한 번 호출한다.
+func (s *Service) ActiveAutoResponder(ctx context.Context, connectionID int64) *domain.VacationResponder {
+	responder, err := s.repo.Get(ctx, connectionID)
+	if err != nil || responder.Mode != domain.VacationModeAutoReply || !responder.ActiveAt(time.Now()) {
+		return nil
+	}
+	return responder
+}
+
+// AutoReply answers a newly synced message on behalf of the user (RFC 3834).
+// 자동 생성 메일, 메일링 리스트, 자기 자신에게는 응답하지 않으며 발신자당 replyInterval에 한 번만 보낸다.
+func (s *Service) AutoReply(ctx context.Context, responder *domain.VacationResponder, conn *domain.OAuthConnection, token *oauth2.Token, email *domain.Email, headers *out.ProviderClassificationHeaders) {
+	if !responder.ActiveAt(email.ReceivedAt) || !shouldAutoReply(conn.Email, email, headers) {
+		return
+	}
+	provider, ok := s.providers[string(conn.Provider)]
+	if !ok {
+		return
+	}
+
+	sender := strings.ToLower(email.FromEmail)
+	since := time.Now().Add(-replyInterval)
+	if responder.EnabledAt != nil && responder.EnabledAt.After(since) {
+		since = *responder.EnabledAt // 다시 켠 경우 이전 응답 기록은 무시
+	}
+	ok, err := s.repo.TryRecordReply(ctx, conn.ID, sender, since)
+	if err != nil {
+		logger.WithError(err).Warn("[Vacation.AutoReply] Failed to check reply history")
+		return
+	}
+	if !ok {
+		return
+	}
+
+	subject := responder.Subject
+	if subject == "" {
+		subject = "Re: " + email.Subject
+	}
+	_, err = provider.Reply(ctx, token, email.ProviderID, &out.ProviderOutgoingMessage{
+		To:      []out.ProviderEmailAddress{{Email: email.FromEmail}},
+		Subject: subject,
+		Body:    responder.Body,
+		IsHTML:  responder.IsHTML,
+		Headers: []out.ProviderMessageHeader{
+			{Name: "Auto-Submitted", Value: "auto-replied"},
+			{Name: "X-Auto-Response-Suppress", Value: "All"},
+		},
+	})
+	if err != nil {
+		logger.WithError(err).Warn("[Vacation.AutoReply] Failed to reply to %s", sender)
+		return
+	}
+	logger.Info("[Vacation.AutoReply] Replied to %s (connection %d)", sender, conn.ID)
+}
+
+func (s *Service) connection(ctx context.Context, userID uuid.UUID, connectionID int64) (*domain.OAuthConnection, error) {
+	conn, err := s.connections.GetConnection(ctx, connectionID)
+	if err != nil || conn == nil || conn.UserID != userID {
+		return nil, ErrConnectionNotFound
+	}
+	return conn, nil
+}
+
+func validate(req *UpdateRequest) error {
+	if req.Enabled && strings.TrimSpace(req.Body) == "" {
+		return ErrEmptyMessage
+	}
+	if len(req.Subject) > maxSubjectLength || len(req.Body) > maxBodyLength {
+		return ErrMessageTooLong
+	}
+	if req.StartAt != nil && req.EndAt != nil && !req.EndAt.After(*req.StartAt) {
+		return ErrInvalidSchedule
+	}
+	return nil
+}
+
+// shouldAutoReply applies RFC 3834 loop prevention rules.
+func shouldAutoReply(accountEmail string, email *domain.Email, headers *out.ProviderClassificationHeaders) bool {
+	from := strings.ToLower(email.FromEmail)
+	if from == "" || strings.EqualFold(from, accountEmail) {
+		return false
+	}
+	switch email.Folder {
+	case domain.LegacyFolderSent, domain.LegacyFolderDrafts, domain.LegacyFolderSpam, domain.LegacyFolderTrash:
+		return false
+	}
+
+	local := from
+	if i := strings.Index(local, "@"); i >= 0 {
+		local = local[:i]
+	}
+	switch local {
+	case "mailer-daemon", "postmaster", "noreply", "no-reply", "donotreply", "do-not-reply":
+		return false
+	}
+
+	if headers == nil {
+		return true
+	}
+	if v := strings.ToLower(strings.TrimSpace(headers.AutoSubmitted)); v != "" && v != "no" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(headers.Precedence)) {
+	case "bulk", "junk", "list":
+		return false
+	}
+	if headers.ListID != "" || headers.ListUnsubscribe != "" {
+		return false
+	}
+	suppress := strings.ToLower(headers.AutoResponseSuppress)
+	if strings.Contains(suppress, "all") || strings.Contains(suppress, "oof") {
+		return false
+	}
+	return true
+}
+
+func isPermissionError(err error) bool {
+	var providerErr *out.ProviderError
+	return errors.As(err, &providerErr) && providerErr.Code == out.ProviderErrAuth
+}
@@ -0,0 +1,61 @@
+package vacation
+
+import (
+	"testing"
+	"time"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+)
+
+func TestShouldAutoReply(t *testing.T) {
+	inbox := func(from string) *domain.Email {
+		return &domain.Email{FromEmail: from, Folder: domain.LegacyFolderInbox}
+	}
+
+	tests := []struct {
+		name    string
+		email   *domain.Email
+		headers *out.ProviderClassificationHeaders
+		want    bool
+	}{
+		{"person", inbox("alice@example.com"), &out.ProviderClassificationHeaders{}, true},
+		{"no headers", inbox("alice@example.com"), nil, true},
+		{"self", inbox("Me@Example.com"), nil, false},
+		{"sent folder", &domain.Email{FromEmail: "alice@example.com", Folder: domain.LegacyFolderSent}, nil, false},
+		{"mailer daemon", inbox("MAILER-DAEMON@example.com"), nil, false},
+		{"noreply", inbox("no-reply@example.com"), nil, false},
+		{"auto submitted", inbox("bob@example.com"), &out.ProviderClassificationHeaders{AutoSubmitted: "auto-replied"}, false},
+		{"auto submitted no", inbox("bob@example.com"), &out.ProviderClassificationHeaders{AutoSubmitted: "no"}, true},
+		{"bulk", inbox("news@example.com"), &out.ProviderClassificationHeaders{Precedence: "bulk"}, false},
+		{"list", inbox("dev@lists.example.com"), &out.ProviderClassificationHeaders{ListID: "<dev.lists.example.com>"}, false},
+		{"suppress oof", inbox("carol@example.com"), &out.ProviderClassificationHeaders{AutoResponseSuppress: "OOF, AutoReply"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := shouldAutoReply("me@example.com", tt.email, tt.headers); got != tt.want {
+			t.Errorf("%s: shouldAutoReply() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResponderActiveAt(t *testing.T) {
+	now := time.Now()
+	start, end := now.Add(-time.Hour), now.Add(time.Hour)
+	enabledAt := now.Add(-30 * time.Minute)
+
+	v := &domain.VacationResponder{Enabled: true, StartAt: &start, EndAt: &end, EnabledAt: &enabledAt}
+	if !v.ActiveAt(now) {
+		t.Error("expected active within schedule")
+	}
+	if v.ActiveAt(now.Add(-45 * time.Minute)) {
+		t.Error("expected inactive before enabled_at")
+	}
+	if v.ActiveAt(end) {
+		t.Error("expected inactive at end_at")
+	}
+	v.Enabled = false
+	if v.ActiveAt(now) {
+		t.Error("expected inactive when disabled")
+	}
+}
@@ -2,20 +2,34 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"worker_server/core/domain"
 	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
 
 	"github.com/google/uuid"
 )
 
+var (
+	// ErrTemplateNotFound is returned when a template does not exist for the user.
+	ErrTemplateNotFound = errors.New("template not found")
+	// ErrMissingVariables is returned when a template is sent with unresolved variables.
+	ErrMissingVariables = errors.New("missing template variables")
+)
+
+// variablePattern matches ${name} and {{name}} placeholders
+var variablePattern = regexp.MustCompile(`\$\{\s*(\w+)\s*\}|\{\{\s*(\w+)\s*\}\}`)
+
 // TemplateService handles email template business logic
 type TemplateService struct {
-	repo out.TemplateRepository
+	repo    out.TemplateRepository
+	phrases out.PersonalizationStore // optional: 템플릿 사용 횟수를 문구 저장소에 반영
 }
 
 // NewTemplateService creates a new TemplateService
@@ -23,6 +37,11 @@ func NewTemplateService(repo out.TemplateRepository) *TemplateService {
 	return &TemplateService{repo: repo}
 }
 
+// SetPhraseStore enables feeding template usage into the personalization phrase store.
+func (s *TemplateService) SetPhraseStore(store out.PersonalizationStore) {
+	s.phrases = store
+}
+
 // CreateTemplateRequest represents the request to create a template
 type CreateTemplateRequest struct {
 	Name      string
@@ -275,22 +294,88 @@ func (s *TemplateService) UseTemplate(ctx context.Context, userID uuid.UUID, id
 
 	// Increment usage
 	_ = s.repo.IncrementUsage(ctx, id)
+	s.recordPhrases(userID, entity)
 
-	// Render template with variables
-	rendered := &RenderedTemplate{
-		Subject:  renderText(entity.Subject, variables),
-		Body:     renderText(entity.Body, variables),
-		HTMLBody: renderText(entity.HTMLBody, variables),
+	return renderEntity(entity, variables), nil
+}
+
+// RenderForSend renders a template for sending. 치환되지 않은 변수가 있으면 발송하지 않고
+// ErrMissingVariables를 반환하며, 성공 시에만 사용 횟수를 올린다.
+func (s *TemplateService) RenderForSend(ctx context.Context, userID uuid.UUID, id int64, variables map[string]string) (*RenderedTemplate, error) {
+	entity, err := s.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	rendered := renderEntity(entity, variables)
+	if len(rendered.Missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingVariables, strings.Join(rendered.Missing, ", "))
 	}
 
+	_ = s.repo.IncrementUsage(ctx, id)
+	s.recordPhrases(userID, entity)
 	return rendered, nil
 }
 
+// Render renders a template without counting it as used (preview).
+func (s *TemplateService) Render(ctx context.Context, userID uuid.UUID, id int64, variables map[string]string) (*RenderedTemplate, error) {
+	entity, err := s.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return renderEntity(entity, variables), nil
+}
+
 // RenderedTemplate represents a template with variables applied
 type RenderedTemplate struct {
 	Subject  string
 	Body     string
 	HTMLBody string
+	Missing  []string // 값이 없어 치환되지 않은 변수
+}
+
+// recordPhrases feeds the template's greeting/closing lines into the phrase store (async).
+// 변수가 포함된 줄은 수신자마다 달라지므로 제외한다.
+func (s *TemplateService) recordPhrases(userID uuid.UUID, entity *out.TemplateEntity) {
+	if s.phrases == nil {
+		return
+	}
+
+	var lines []string
+	for _, line := range strings.Split(entity.Body, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) < 2 {
+		return
+	}
+
+	phrases := make([]*out.FrequentPhrase, 0, 2)
+	for _, p := range []*out.FrequentPhrase{
+		{Text: lines[0], Category: "greeting"},
+		{Text: lines[len(lines)-1], Category: "closing"},
+	} {
+		if len(p.Text) <= 100 && !variablePattern.MatchString(p.Text) {
+			p.Count = entity.UsageCount + 1
+			p.LastUsed = time.Now()
+			phrases = append(phrases, p)
+		}
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for _, p := range phrases {
+			if err := s.phrases.AddPhrase(ctx, userID.String(), p); err != nil {
+				logger.WithError(err).Debug("[TemplateService.recordPhrases] Failed to add phrase")
+				return
+			}
+		}
+	}()
 }
 
 // DeleteBatch deletes multiple templates
@@ -340,19 +425,19 @@ func entityToDomain(e *out.TemplateEntity) *domain.EmailTemplate {
 
 // extractVariables extracts variable placeholders from text
 func extractVariables(text string) []domain.TemplateVariable {
-	// Match ${variableName} pattern
-	re := regexp.MustCompile(`\$\{(\w+)\}`)
-	matches := re.FindAllStringSubmatch(text, -1)
+	// Match ${variableName} and {{variableName}} patterns
+	matches := variablePattern.FindAllStringSubmatch(text, -1)
 
 	// Use map to deduplicate
 	seen := make(map[string]bool)
 	var variables []domain.TemplateVariable
 
 	for _, match := range matches {
-		if len(match) >= 2 && !seen[match[1]] {
-			seen[match[1]] = true
+		name := variableName(match)
+		if name != "" && !seen[name] {
+			seen[name] = true
 			variables = append(variables, domain.TemplateVariable{
-				Name:        match[1],
+				Name:        name,
 				Placeholder: match[0],
 			})
 		}
@@ -361,23 +446,70 @@ func extractVariables(text string) []domain.TemplateVariable {
 	return variables
 }
 
-// renderText replaces variables in text
-func renderText(text string, variables map[string]string) string {
-	if text == "" || len(variables) == 0 {
-		return text
+// renderEntity renders subject/body/html with variables, falling back to variable defaults.
+func renderEntity(entity *out.TemplateEntity, variables map[string]string) *RenderedTemplate {
+	values := make(map[string]string, len(variables)+len(entity.Variables))
+	for _, v := range entity.Variables {
+		if v.DefaultVal != "" {
+			values[v.Name] = v.DefaultVal
+		}
 	}
-
-	result := text
 	for name, value := range variables {
-		placeholder := "${" + name + "}"
-		result = strings.ReplaceAll(result, placeholder, value)
+		values[name] = value
+	}
+
+	return RenderContent(entity.Subject, entity.Body, entity.HTMLBody, values)
+}
+
+// RenderContent renders subject/body/html that are not stored as a template (예: 캠페인 스냅샷).
+func RenderContent(subject, body, htmlBody string, variables map[string]string) *RenderedTemplate {
+	missing := make(map[string]bool)
+	rendered := &RenderedTemplate{
+		Subject:  renderText(subject, variables, missing),
+		Body:     renderText(body, variables, missing),
+		HTMLBody: renderText(htmlBody, variables, missing),
+	}
+	for name := range missing {
+		rendered.Missing = append(rendered.Missing, name)
+	}
+	sort.Strings(rendered.Missing)
+	return rendered
+}
+
+// renderText replaces variables in text and records unresolved names in missing.
+func renderText(text string, variables map[string]string, missing map[string]bool) string {
+	if text == "" {
+		return text
 	}
 
 	// Handle date/time variables
 	now := time.Now()
-	result = strings.ReplaceAll(result, "${date}", now.Format("2006-01-02"))
-	result = strings.ReplaceAll(result, "${time}", now.Format("15:04"))
-	result = strings.ReplaceAll(result, "${datetime}", now.Format("2006-01-02 15:04"))
+	builtin := map[string]string{
+		"date":     now.Format("2006-01-02"),
+		"time":     now.Format("15:04"),
+		"datetime": now.Format("2006-01-02 15:04"),
+	}
 
-	return result
+	return variablePattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := variableName(variablePattern.FindStringSubmatch(placeholder))
+		if value, ok := variables[name]; ok {
+			return value
+		}
+		if value, ok := builtin[name]; ok {
+			return value
+		}
+		missing[name] = true
+		return placeholder
+	})
+}
+
+// variableName returns the variable name from a variablePattern match.
+func variableName(match []string) string {
+	if len(match) < 3 {
+		return ""
+	}
+	if match[1] != "" {
+		return match[1]
+	}
+	return match[2]
 }
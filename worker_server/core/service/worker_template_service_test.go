@@ -0,0 +1,38 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"worker_server/core/port/out"
+)
+
+func TestRenderEntity(t *testing.T) {
+	entity := &out.TemplateEntity{
+		Subject: "Hello {{ first_name }}",
+		Body:    "Hi {{first_name}},\nYour order ${orderId} from {{company}} ships soon.\n{{unknown}}",
+		Variables: []out.TemplateVariableEntity{
+			{Name: "company", DefaultVal: "ACME"},
+		},
+	}
+
+	rendered := renderEntity(entity, map[string]string{"first_name": "Jane", "orderId": "42"})
+
+	if rendered.Subject != "Hello Jane" {
+		t.Errorf("Subject = %q", rendered.Subject)
+	}
+	want := "Hi Jane,\nYour order 42 from ACME ships soon.\n{{unknown}}"
+	if rendered.Body != want {
+		t.Errorf("Body = %q, want %q", rendered.Body, want)
+	}
+	if !reflect.DeepEqual(rendered.Missing, []string{"unknown"}) {
+		t.Errorf("Missing = %v, want [unknown]", rendered.Missing)
+	}
+}
+
+func TestExtractVariables(t *testing.T) {
+	vars := extractVariables("Hi {{first_name}} ${first_name} {{ last_name }}")
+	if len(vars) != 2 || vars[0].Name != "first_name" || vars[1].Name != "last_name" {
+		t.Errorf("extractVariables = %+v", vars)
+	}
+}
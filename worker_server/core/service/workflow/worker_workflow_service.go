@@ -0,0 +1,313 @@
+// Package workflow manages the kanban workflow board: user-defined stages and card order.
+package workflow
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"worker_server/core/domain"
+	"worker_server/core/port/out"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxStages          = 20
+	maxStageNameLength = 50
+	// defaultCardLimit / maxCardLimit - 단계(열)별 카드 수
+	defaultCardLimit = 50
+	maxCardLimit     = 100
+	// maxPlaceCards - 한 번의 순서 저장에 보낼 수 있는 카드 수
+	maxPlaceCards = 500
+)
+
+var (
+	ErrStageNotFound = errors.New("workflow stage not found")
+	ErrInvalidName   = errors.New("stage name is required (max 50 characters)")
+	ErrInvalidStatus = errors.New("stage status must be todo or done")
+	ErrInvalidColor  = errors.New("color must be a hex color like #4f46e5")
+	ErrTooManyStages = errors.New("too many workflow stages")
+	ErrLastStage     = errors.New("each status needs at least one stage")
+	ErrInvalidOrder  = errors.New("stage_ids must list every stage exactly once")
+	ErrTooManyCards  = errors.New("too many cards")
+)
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// StatusUpdater changes emails.workflow_status and syncs it to the provider (mail.Service).
+type StatusUpdater interface {
+	UpdateWorkflowStatus(ctx context.Context, userID uuid.UUID, emailIDs []int64, status string) error
+}
+
+// StageRequest is the input for creating or updating a stage.
+type StageRequest struct {
+	Name   string
+	Color  string
+	Status domain.WorkflowStatus
+}
+
+// Service manages workflow board stages and card placement.
+type Service struct {
+	repo   out.WorkflowRepository
+	status StatusUpdater
+}
+
+// NewService creates a new workflow service.
+func NewService(repo out.WorkflowRepository, status StatusUpdater) *Service {
+	return &Service{repo: repo, status: status}
+}
+
+// ListStages returns the user's stages in board order, creating the defaults on first use.
+func (s *Service) ListStages(ctx context.Context, userID uuid.UUID) ([]*domain.WorkflowStage, error) {
+	stages, err := s.repo.ListStages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) > 0 {
+		return stages, nil
+	}
+
+	if err := s.repo.CreateDefaultStages(ctx, userID, domain.DefaultWorkflowStages(userID)); err != nil {
+		return nil, err
+	}
+	return s.repo.ListStages(ctx, userID)
+}
+
+// CreateStage appends a stage to the end of the board.
+func (s *Service) CreateStage(ctx context.Context, userID uuid.UUID, req *StageRequest) (*domain.WorkflowStage, error) {
+	stage := &domain.WorkflowStage{UserID: userID}
+	if err := fill(stage, req); err != nil {
+		return nil, err
+	}
+
+	stages, err := s.ListStages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) >= maxStages {
+		return nil, ErrTooManyStages
+	}
+	for _, st := range stages {
+		if st.Position >= stage.Position {
+			stage.Position = st.Position + 1
+		}
+	}
+
+	if err := s.repo.CreateStage(ctx, stage); err != nil {
+		return nil, err
+	}
+	return stage, nil
+}
+
+// UpdateStage renames, recolors or remaps a stage.
+// 상태를 바꾸면 그 단계에 배치된 메일의 workflow_status도 함께 바뀐다.
+func (s *Service) UpdateStage(ctx context.Context, userID uuid.UUID, stageID int64, req *StageRequest) (*domain.WorkflowStage, error) {
+	stages, err := s.ListStages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	stage := findStage(stages, stageID)
+	if stage == nil {
+		return nil, ErrStageNotFound
+	}
+
+	prevStatus := stage.Status
+	updated := *stage
+	if err := fill(&updated, req); err != nil {
+		return nil, err
+	}
+	if updated.Status != prevStatus && countStatus(stages, prevStatus) == 1 {
+		return nil, ErrLastStage
+	}
+
+	// 상태 변경 전 기준으로 배치된 카드를 모은다 (미배치 메일은 상태별 첫 단계 소속이라 건드리지 않는다)
+	var placed []int64
+	if updated.Status != prevStatus {
+		if placed, err = s.placedEmailIDs(ctx, userID, stage); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdateStage(ctx, &updated); err != nil {
+		return nil, err
+	}
+
+	if len(placed) > 0 && s.status != nil {
+		if err := s.status.UpdateWorkflowStatus(ctx, userID, placed, string(updated.Status)); err != nil {
+			return nil, err
+		}
+	}
+	return &updated, nil
+}
+
+// DeleteStage deletes a stage. 배치된 메일은 같은 상태의 첫 단계로 돌아간다.
+func (s *Service) DeleteStage(ctx context.Context, userID uuid.UUID, stageID int64) error {
+	stages, err := s.ListStages(ctx, userID)
+	if err != nil {
+		return err
+	}
+	stage := findStage(stages, stageID)
+	if stage == nil {
+		return ErrStageNotFound
+	}
+	if countStatus(stages, stage.Status) == 1 {
+		return ErrLastStage
+	}
+	return s.repo.DeleteStage(ctx, userID, stageID)
+}
+
+// ReorderStages sets the column order. stageIDs must contain every stage once.
+func (s *Service) ReorderStages(ctx context.Context, userID uuid.UUID, stageIDs []int64) ([]*domain.WorkflowStage, error) {
+	stages, err := s.ListStages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stageIDs) != len(stages) {
+		return nil, ErrInvalidOrder
+	}
+	seen := make(map[int64]bool, len(stageIDs))
+	for _, id := range stageIDs {
+		if seen[id] || findStage(stages, id) == nil {
+			return nil, ErrInvalidOrder
+		}
+		seen[id] = true
+	}
+
+	if err := s.repo.ReorderStages(ctx, userID, stageIDs); err != nil {
+		return nil, err
+	}
+	return s.repo.ListStages(ctx, userID)
+}
+
+// Board returns every stage with its cards.
+// 상태별 첫 단계가 아직 드래그로 배치되지 않은 메일(todo/done)을 받는다.
+func (s *Service) Board(ctx context.Context, userID uuid.UUID, connectionID *int64, limit int) (*domain.WorkflowBoard, error) {
+	if limit <= 0 {
+		limit = defaultCardLimit
+	}
+	if limit > maxCardLimit {
+		limit = maxCardLimit
+	}
+
+	stages, err := s.ListStages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &domain.WorkflowBoard{Columns: make([]*domain.WorkflowColumn, 0, len(stages))}
+	defaults := make(map[domain.WorkflowStatus]bool)
+	for _, stage := range stages {
+		includeUnplaced := !defaults[stage.Status]
+		defaults[stage.Status] = true
+
+		cards, total, err := s.repo.ListCards(ctx, userID, stage, includeUnplaced, connectionID, limit)
+		if err != nil {
+			return nil, err
+		}
+		if cards == nil {
+			cards = []*domain.WorkflowCard{}
+		}
+		board.Columns = append(board.Columns, &domain.WorkflowColumn{Stage: stage, Cards: cards, Total: total})
+	}
+	return board, nil
+}
+
+// MoveCards places emails into a stage in the given order (drag and drop).
+// 단계 상태와 다른 메일은 workflow_status를 바꾸고 Provider에도 동기화한다.
+func (s *Service) MoveCards(ctx context.Context, userID uuid.UUID, stageID int64, emailIDs []int64) ([]int64, error) {
+	if len(emailIDs) > maxPlaceCards {
+		return nil, ErrTooManyCards
+	}
+
+	stages, err := s.ListStages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	stage := findStage(stages, stageID)
+	if stage == nil {
+		return nil, ErrStageNotFound
+	}
+	if len(emailIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	placed, err := s.repo.PlaceCards(ctx, userID, stage.ID, dedupe(emailIDs))
+	if err != nil {
+		return nil, err
+	}
+	if len(placed) > 0 && s.status != nil {
+		if err := s.status.UpdateWorkflowStatus(ctx, userID, placed, string(stage.Status)); err != nil {
+			return nil, err
+		}
+	}
+	return placed, nil
+}
+
+// placedEmailIDs returns the emails dragged into the stage.
+func (s *Service) placedEmailIDs(ctx context.Context, userID uuid.UUID, stage *domain.WorkflowStage) ([]int64, error) {
+	cards, _, err := s.repo.ListCards(ctx, userID, stage, false, nil, maxPlaceCards)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(cards))
+	for i, c := range cards {
+		ids[i] = c.EmailID
+	}
+	return ids, nil
+}
+
+func fill(stage *domain.WorkflowStage, req *StageRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxStageNameLength {
+		return ErrInvalidName
+	}
+	if req.Color != "" && !colorPattern.MatchString(req.Color) {
+		return ErrInvalidColor
+	}
+	status := req.Status
+	if status == "" {
+		status = domain.WorkflowTodo
+	}
+	if !status.IsBoardStatus() {
+		return ErrInvalidStatus
+	}
+
+	stage.Name = name
+	stage.Color = strings.ToLower(req.Color)
+	stage.Status = status
+	return nil
+}
+
+func findStage(stages []*domain.WorkflowStage, id int64) *domain.WorkflowStage {
+	for _, st := range stages {
+		if st.ID == id {
+			return st
+		}
+	}
+	return nil
+}
+
+func countStatus(stages []*domain.WorkflowStage, status domain.WorkflowStatus) int {
+	n := 0
+	for _, st := range stages {
+		if st.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func dedupe(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,194 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"worker_server/core/domain"
+
+	"github.com/google/uuid"
+)
+
+type memRepo struct {
+	stages []*domain.WorkflowStage
+	cards  map[int64]int64 // email_id -> stage_id
+	owned  map[int64]bool
+	nextID int64
+}
+
+func newMemRepo(owned ...int64) *memRepo {
+	r := &memRepo{cards: make(map[int64]int64), owned: make(map[int64]bool)}
+	for _, id := range owned {
+		r.owned[id] = true
+	}
+	return r
+}
+
+func (r *memRepo) ListStages(_ context.Context, _ uuid.UUID) ([]*domain.WorkflowStage, error) {
+	return append([]*domain.WorkflowStage(nil), r.stages...), nil
+}
+
+func (r *memRepo) CreateDefaultStages(ctx context.Context, _ uuid.UUID, stages []*domain.WorkflowStage) error {
+	if len(r.stages) > 0 {
+		return nil
+	}
+	for _, st := range stages {
+		_ = r.CreateStage(ctx, st)
+	}
+	return nil
+}
+
+func (r *memRepo) CreateStage(_ context.Context, stage *domain.WorkflowStage) error {
+	r.nextID++
+	stage.ID = r.nextID
+	r.stages = append(r.stages, stage)
+	return nil
+}
+
+func (r *memRepo) UpdateStage(_ context.Context, stage *domain.WorkflowStage) error {
+	for i, st := range r.stages {
+		if st.ID == stage.ID {
+			r.stages[i] = stage
+		}
+	}
+	return nil
+}
+
+func (r *memRepo) DeleteStage(_ context.Context, _ uuid.UUID, stageID int64) error {
+	for i, st := range r.stages {
+		if st.ID == stageID {
+			r.stages = append(r.stages[:i], r.stages[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *memRepo) ReorderStages(_ context.Context, _ uuid.UUID, stageIDs []int64) error {
+	for pos, id := range stageIDs {
+		for _, st := range r.stages {
+			if st.ID == id {
+				st.Position = pos
+			}
+		}
+	}
+	return nil
+}
+
+func (r *memRepo) ListCards(_ context.Context, _ uuid.UUID, stage *domain.WorkflowStage, _ bool, _ *int64, _ int) ([]*domain.WorkflowCard, int, error) {
+	var cards []*domain.WorkflowCard
+	for emailID, stageID := range r.cards {
+		if stageID == stage.ID {
+			cards = append(cards, &domain.WorkflowCard{EmailID: emailID, Status: stage.Status})
+		}
+	}
+	return cards, len(cards), nil
+}
+
+func (r *memRepo) PlaceCards(_ context.Context, _ uuid.UUID, stageID int64, emailIDs []int64) ([]int64, error) {
+	var placed []int64
+	for _, id := range emailIDs {
+		if r.owned[id] {
+			r.cards[id] = stageID
+			placed = append(placed, id)
+		}
+	}
+	return placed, nil
+}
+
+type statusCall struct {
+	ids    []int64
+	status string
+}
+
+type fakeStatusUpdater struct {
+	calls []statusCall
+}
+
+func (f *fakeStatusUpdater) UpdateWorkflowStatus(_ context.Context, _ uuid.UUID, emailIDs []int64, status string) error {
+	f.calls = append(f.calls, statusCall{ids: emailIDs, status: status})
+	return nil
+}
+
+func TestStagesKeepOnePerStatus(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	svc := NewService(newMemRepo(), &fakeStatusUpdater{})
+
+	stages, err := svc.ListStages(ctx, userID)
+	if err != nil || len(stages) != 2 {
+		t.Fatalf("expected default stages, got %d (%v)", len(stages), err)
+	}
+	todo, done := stages[0], stages[1]
+
+	if err := svc.DeleteStage(ctx, userID, done.ID); !errors.Is(err, ErrLastStage) {
+		t.Errorf("deleting the only done stage: got %v", err)
+	}
+	if _, err := svc.UpdateStage(ctx, userID, todo.ID, &StageRequest{Name: "Inbox", Status: domain.WorkflowDone}); !errors.Is(err, ErrLastStage) {
+		t.Errorf("remapping the only todo stage: got %v", err)
+	}
+
+	review, err := svc.CreateStage(ctx, userID, &StageRequest{Name: " Review ", Color: "#4F46E5"})
+	if err != nil {
+		t.Fatalf("create stage: %v", err)
+	}
+	if review.Name != "Review" || review.Color != "#4f46e5" || review.Status != domain.WorkflowTodo || review.Position != 2 {
+		t.Errorf("unexpected stage %+v", review)
+	}
+	if err := svc.DeleteStage(ctx, userID, todo.ID); err != nil {
+		t.Errorf("deleting a todo stage when another exists: %v", err)
+	}
+
+	if _, err := svc.CreateStage(ctx, userID, &StageRequest{Name: "Later", Status: domain.WorkflowSnoozed}); !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("snoozed stage: got %v", err)
+	}
+	if _, err := svc.CreateStage(ctx, userID, &StageRequest{Name: "Blue", Color: "blue"}); !errors.Is(err, ErrInvalidColor) {
+		t.Errorf("invalid color: got %v", err)
+	}
+	if _, err := svc.ReorderStages(ctx, userID, []int64{review.ID}); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("partial order: got %v", err)
+	}
+	if _, err := svc.ReorderStages(ctx, userID, []int64{review.ID, review.ID}); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("duplicate order: got %v", err)
+	}
+}
+
+func TestMoveCardsUpdatesStatus(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	repo := newMemRepo(1, 2)
+	status := &fakeStatusUpdater{}
+	svc := NewService(repo, status)
+
+	stages, _ := svc.ListStages(ctx, userID)
+	done := stages[1]
+
+	// 3은 다른 사용자의 메일이라 배치되지 않는다
+	placed, err := svc.MoveCards(ctx, userID, done.ID, []int64{2, 1, 2, 3})
+	if err != nil {
+		t.Fatalf("move cards: %v", err)
+	}
+	if len(placed) != 2 || placed[0] != 2 || placed[1] != 1 {
+		t.Errorf("placed %v, want [2 1]", placed)
+	}
+	if len(status.calls) != 1 || status.calls[0].status != "done" || len(status.calls[0].ids) != 2 {
+		t.Errorf("expected one done status update for placed emails, got %+v", status.calls)
+	}
+
+	if _, err := svc.MoveCards(ctx, userID, 999, []int64{1}); !errors.Is(err, ErrStageNotFound) {
+		t.Errorf("unknown stage: got %v", err)
+	}
+
+	// 단계의 상태를 바꾸면 배치된 메일 상태도 따라간다
+	if _, err := svc.CreateStage(ctx, userID, &StageRequest{Name: "Archive", Status: domain.WorkflowDone}); err != nil {
+		t.Fatal(err)
+	}
+	status.calls = nil
+	if _, err := svc.UpdateStage(ctx, userID, done.ID, &StageRequest{Name: "Waiting", Status: domain.WorkflowTodo}); err != nil {
+		t.Fatalf("update stage: %v", err)
+	}
+	if len(status.calls) != 1 || status.calls[0].status != "todo" || len(status.calls[0].ids) != 2 {
+		t.Errorf("expected placed emails to move to todo, got %+v", status.calls)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"time"
 
+	"worker_server/pkg/metrics"
+
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
@@ -18,6 +20,7 @@ type PostgresConfig struct {
 	MaxConnLifetime   time.Duration
 	MaxConnIdleTime   time.Duration
 	HealthCheckPeriod time.Duration
+	StatementTimeout  time.Duration // 서버 측 statement_timeout (0이면 설정 안 함)
 }
 
 // DefaultPostgresConfig returns optimized defaults.
@@ -57,6 +60,9 @@ func NewPostgresWithConfig(databaseURL string, cfg *PostgresConfig) (*pgxpool.Po
 	config.MaxConnLifetime = cfg.MaxConnLifetime
 	config.MaxConnIdleTime = cfg.MaxConnIdleTime
 	config.HealthCheckPeriod = cfg.HealthCheckPeriod
+	if cfg.StatementTimeout > 0 {
+		config.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
 
 	// Disable prepared statement cache to avoid conflicts with sqlx
 	config.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeSimpleProtocol
@@ -96,6 +102,22 @@ func GetPoolStats(pool *pgxpool.Pool) *PoolStats {
 	}
 }
 
+// PoolMetrics maps pgxpool statistics to the shared pool monitor format.
+// 빈 풀에서 기다린 획득 수를 WaitCount로 본다.
+func PoolMetrics(pool *pgxpool.Pool) metrics.DBPoolStats {
+	stat := pool.Stat()
+	return metrics.DBPoolStats{
+		OpenConnections:    int(stat.TotalConns()),
+		InUse:              int(stat.AcquiredConns()),
+		Idle:               int(stat.IdleConns()),
+		MaxOpenConnections: int(stat.MaxConns()),
+		WaitCount:          stat.EmptyAcquireCount(),
+		WaitDuration:       stat.AcquireDuration(),
+		MaxIdleTimeClosed:  stat.MaxIdleDestroyCount(),
+		MaxLifetimeClosed:  stat.MaxLifetimeDestroyCount(),
+	}
+}
+
 // RedisConfig holds Redis configuration.
 type RedisConfig struct {
 	PoolSize     int
@@ -104,6 +126,7 @@ type RedisConfig struct {
 	DialTimeout  time.Duration
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	PoolTimeout  time.Duration // 풀이 가득 찼을 때 연결 대기 한도 (0이면 ReadTimeout + 1s)
 }
 
 // DefaultRedisConfig returns optimized Redis defaults.
@@ -146,6 +169,9 @@ func NewRedisWithConfig(redisURL string, cfg *RedisConfig) (*redis.Client, error
 	opt.DialTimeout = cfg.DialTimeout
 	opt.ReadTimeout = cfg.ReadTimeout
 	opt.WriteTimeout = cfg.WriteTimeout
+	if cfg.PoolTimeout > 0 {
+		opt.PoolTimeout = cfg.PoolTimeout
+	}
 
 	client := redis.NewClient(opt)
 
@@ -181,3 +207,16 @@ func GetRedisStats(client *redis.Client) *RedisStats {
 		StaleConns: stat.StaleConns,
 	}
 }
+
+// RedisPoolMetrics maps Redis pool statistics to the shared pool monitor format.
+// 연결 대기 timeout 횟수를 WaitCount로 본다.
+func RedisPoolMetrics(client *redis.Client) metrics.DBPoolStats {
+	stat := client.PoolStats()
+	return metrics.DBPoolStats{
+		OpenConnections:    int(stat.TotalConns),
+		InUse:              int(stat.TotalConns) - int(stat.IdleConns),
+		Idle:               int(stat.IdleConns),
+		MaxOpenConnections: client.Options().PoolSize,
+		WaitCount:          int64(stat.Timeouts),
+	}
+}
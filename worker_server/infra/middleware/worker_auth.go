@@ -15,6 +15,7 @@ import (
 
 	"github.com/goccy/go-json"
 
+	"worker_server/pkg/apperr"
 	"worker_server/pkg/logger"
 
 	"github.com/gofiber/fiber/v2"
@@ -270,7 +271,7 @@ func JWTAuth(secret string) fiber.Handler {
 		}
 
 		if tokenString == "" {
-			return c.Status(401).JSON(fiber.Map{"error": "missing authorization"})
+			return apperr.WriteProblem(c, apperr.Unauthorized("missing authorization"))
 		}
 
 		// Parse and validate token
@@ -318,28 +319,22 @@ func JWTAuth(secret string) fiber.Handler {
 
 		if err != nil {
 			logger.WithError(err).Warn("JWT validation failed")
-			return c.Status(401).JSON(fiber.Map{
-				"error":  "invalid token",
-				"detail": err.Error(),
-			})
+			return apperr.WriteProblem(c, apperr.InvalidToken("invalid token"))
 		}
 
 		if !token.Valid {
-			return c.Status(401).JSON(fiber.Map{"error": "invalid token"})
+			return apperr.WriteProblem(c, apperr.InvalidToken("invalid token"))
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			return c.Status(401).JSON(fiber.Map{"error": "invalid claims"})
+			return apperr.WriteProblem(c, apperr.InvalidToken("invalid claims"))
 		}
 
 		// Validate token expiration (exp claim)
 		if exp, ok := claims["exp"].(float64); ok {
 			if time.Now().Unix() > int64(exp) {
-				return c.Status(401).JSON(fiber.Map{
-					"error": "token expired",
-					"code":  "TOKEN_EXPIRED",
-				})
+				return apperr.WriteProblem(c, apperr.New(apperr.CodeTokenExpired, "token expired", 401))
 			}
 		}
 
@@ -348,32 +343,26 @@ func JWTAuth(secret string) fiber.Handler {
 			issuedAt := time.Unix(int64(iat), 0)
 			// Allow 1 minute clock skew
 			if issuedAt.After(time.Now().Add(time.Minute)) {
-				return c.Status(401).JSON(fiber.Map{
-					"error": "token issued in the future",
-					"code":  "INVALID_TOKEN_TIME",
-				})
+				return apperr.WriteProblem(c, apperr.New("INVALID_TOKEN_TIME", "token issued in the future", 401))
 			}
 		}
 
 		// Check token blacklist (for logout/revocation)
 		if jti, ok := claims["jti"].(string); ok && jti != "" {
 			if IsTokenRevoked(c.Context(), jti) {
-				return c.Status(401).JSON(fiber.Map{
-					"error": "token has been revoked",
-					"code":  "TOKEN_REVOKED",
-				})
+				return apperr.WriteProblem(c, apperr.New("TOKEN_REVOKED", "token has been revoked", 401))
 			}
 		}
 
 		// Extract user ID from "sub" claim
 		userIDStr, ok := claims["sub"].(string)
 		if !ok {
-			return c.Status(401).JSON(fiber.Map{"error": "missing user id in token"})
+			return apperr.WriteProblem(c, apperr.InvalidToken("missing user id in token"))
 		}
 
 		userID, err := uuid.Parse(userIDStr)
 		if err != nil {
-			return c.Status(401).JSON(fiber.Map{"error": "invalid user id format"})
+			return apperr.WriteProblem(c, apperr.InvalidToken("invalid user id format"))
 		}
 
 		// Extract email if available
@@ -133,6 +133,11 @@ func ETagWithConfig(config *ETagConfig) fiber.Handler {
 			return nil
 		}
 
+		// 핸들러가 데이터 기준 ETag(updated_at 등)를 이미 설정했으면 본문 해시로 덮어쓰지 않음
+		if len(c.Response().Header.Peek("ETag")) > 0 {
+			return nil
+		}
+
 		// 응답 본문으로 ETag 생성
 		body := c.Response().Body()
 		if len(body) == 0 {
@@ -155,6 +160,16 @@ func ETagWithConfig(config *ETagConfig) fiber.Handler {
 	}
 }
 
+// IsEventStream reports whether the request is a server-sent event stream.
+// SSE 응답은 압축하면 이벤트가 압축 버퍼에 묶여 바로 전달되지 않는다 (compress Next로 사용).
+func IsEventStream(c *fiber.Ctx) bool {
+	if strings.Contains(c.Get("Accept"), "text/event-stream") {
+		return true
+	}
+	path := c.Path()
+	return strings.HasSuffix(path, "/stream") || strings.HasSuffix(path, "/v1/events")
+}
+
 // =============================================================================
 // API-specific Cache Headers
 // =============================================================================
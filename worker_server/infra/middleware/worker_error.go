@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"runtime/debug"
@@ -13,68 +14,34 @@ import (
 	"github.com/google/uuid"
 )
 
-// ErrorResponse is the standard error response format
-type ErrorResponse struct {
-	Success   bool        `json:"success"`
-	Error     ErrorDetail `json:"error"`
-	RequestID string      `json:"request_id,omitempty"`
-	Timestamp string      `json:"timestamp"`
-}
-
-type ErrorDetail struct {
-	Code    string         `json:"code"`
-	Message string         `json:"message"`
-	Details map[string]any `json:"details,omitempty"`
-}
-
-// ErrorHandler is a centralized error handler for Fiber
+// ErrorHandler is a centralized error handler for Fiber.
+// 모든 에러는 RFC 7807 application/problem+json으로 응답한다.
 func ErrorHandler() fiber.ErrorHandler {
 	return func(c *fiber.Ctx, err error) error {
 		requestID, _ := c.Locals("request_id").(string)
 
-		// Default error response
-		response := ErrorResponse{
-			Success:   false,
-			RequestID: requestID,
-			Timestamp: time.Now().UTC().Format(time.RFC3339),
-		}
+		var appErr *apperr.AppError
 
 		// Handle different error types
-		var status int
-
-		switch e := err.(type) {
-		case *apperr.AppError:
-			status = e.Status
-			response.Error = ErrorDetail{
-				Code:    e.Code,
-				Message: e.Message,
-				Details: e.Details,
-			}
-
+		var fiberErr *fiber.Error
+		switch {
+		case errors.As(err, &appErr):
 			// Log application errors
 			log := logger.WithField("request_id", requestID).
-				WithField("error_code", e.Code).
-				WithError(e.Err)
+				WithField("error_code", appErr.Code).
+				WithError(appErr.Err)
 
-			if status >= 500 {
-				log.Error("Internal error: %s", e.Message)
+			if appErr.Status >= 500 {
+				log.Error("Internal error: %s", appErr.Message)
 			} else {
-				log.Warn("Client error: %s", e.Message)
+				log.Warn("Client error: %s", appErr.Message)
 			}
 
-		case *fiber.Error:
-			status = e.Code
-			response.Error = ErrorDetail{
-				Code:    mapHTTPStatusToCode(e.Code),
-				Message: e.Message,
-			}
+		case errors.As(err, &fiberErr):
+			appErr = apperr.New(mapHTTPStatusToCode(fiberErr.Code), fiberErr.Message, fiberErr.Code)
 
 		default:
-			status = fiber.StatusInternalServerError
-			response.Error = ErrorDetail{
-				Code:    apperr.CodeInternalError,
-				Message: "An unexpected error occurred",
-			}
+			appErr = apperr.Internal("An unexpected error occurred")
 
 			// Log unexpected errors with stack trace
 			logger.WithField("request_id", requestID).
@@ -83,7 +50,7 @@ func ErrorHandler() fiber.ErrorHandler {
 				Error("Unexpected error: %s", err.Error())
 		}
 
-		return c.Status(status).JSON(response)
+		return apperr.WriteProblem(c, appErr)
 	}
 }
 
@@ -171,15 +138,7 @@ func Recover() fiber.Handler {
 					"method":     c.Method(),
 				}).Error("Panic recovered")
 
-				c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-					Success:   false,
-					RequestID: requestID,
-					Timestamp: time.Now().UTC().Format(time.RFC3339),
-					Error: ErrorDetail{
-						Code:    apperr.CodeInternalError,
-						Message: "An unexpected error occurred",
-					},
-				})
+				_ = apperr.WriteProblem(c, apperr.Internal("An unexpected error occurred"))
 			}
 		}()
 		return c.Next()
@@ -198,13 +157,7 @@ func mapHTTPStatusToCode(status int) string {
 		return apperr.CodeNotFound
 	case 409:
 		return apperr.CodeConflict
-	case 429:
-		return "RATE_LIMITED"
-	case 500:
-		return apperr.CodeInternalError
-	case 502, 503, 504:
-		return "SERVICE_UNAVAILABLE"
 	default:
-		return "UNKNOWN_ERROR"
+		return apperr.CodeForStatus(status)
 	}
 }
@@ -0,0 +1,240 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"worker_server/pkg/apperr"
+	"worker_server/pkg/logger"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// IdempotencyKeyHeader is the request header clients set to make retries safe.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	idempotencyPrefix     = "idempotency:"
+	idempotencyLockTTL    = 2 * time.Minute // 처리 중 상태 유지 시간 (핸들러가 죽어도 풀리도록)
+	idempotencyResultTTL  = 24 * time.Hour  // 완료된 응답 재사용 기간
+	maxIdempotencyKeyLen  = 255
+	idempotencyProcessing = "processing"
+	idempotencyCompleted  = "completed"
+)
+
+// IdempotentRoutes defines mutating endpoints that honor Idempotency-Key.
+// "*"는 경로 파라미터 한 세그먼트와 매칭된다.
+var IdempotentRoutes = []string{
+	"POST:/api/v1/email",
+	"POST:/api/v1/email/*/reply",
+	"POST:/api/v1/email/*/forward",
+	"POST:/api/v1/email/read",
+	"POST:/api/v1/email/unread",
+	"POST:/api/v1/email/star",
+	"POST:/api/v1/email/unstar",
+	"POST:/api/v1/email/archive",
+	"POST:/api/v1/email/trash",
+	"POST:/api/v1/email/delete",
+	"POST:/api/v1/email/move",
+	"POST:/api/v1/email/snooze",
+	"POST:/api/v1/email/unsnooze",
+	"POST:/api/v1/email/labels/add",
+	"POST:/api/v1/email/labels/remove",
+	"POST:/api/v1/email/workflow",
+	"POST:/api/v1/email/campaign",
+}
+
+// IdempotencyStore keeps request hashes and responses in Redis.
+type IdempotencyStore struct {
+	redis *redis.Client
+}
+
+// idempotencyRecord is the stored state of a keyed request.
+type idempotencyRecord struct {
+	State       string `json:"state"`
+	Hash        string `json:"hash"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+var idempotencyStore *IdempotencyStore
+
+// InitIdempotencyStore initializes Idempotency-Key support with Redis
+func InitIdempotencyStore(redisClient *redis.Client) {
+	if redisClient == nil {
+		logger.Warn("Redis client not provided, idempotency keys disabled")
+		return
+	}
+	idempotencyStore = &IdempotencyStore{redis: redisClient}
+	logger.Info("Idempotency store initialized")
+}
+
+// Idempotency replays the stored response when a client retries a mutating request
+// with the same Idempotency-Key, so retried sends don't go out twice.
+//
+//   - 같은 키 + 같은 요청: 저장된 응답 재전송 (Idempotent-Replayed: true)
+//   - 같은 키 + 다른 요청: 422
+//   - 같은 키로 처리 중: 409
+//   - 5xx/에러 응답은 저장하지 않아 재시도 가능
+func Idempotency() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := strings.TrimSpace(c.Get(IdempotencyKeyHeader))
+		if key == "" || idempotencyStore == nil || !isIdempotentRoute(c.Method(), c.Path()) {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			return apperr.WriteProblem(c, apperr.New("IDEMPOTENCY_KEY_INVALID", "Idempotency-Key is too long", 400))
+		}
+
+		userID, ok := c.Locals("user_id").(uuid.UUID)
+		if !ok {
+			return c.Next()
+		}
+
+		ctx := c.UserContext()
+		redisKey := idempotencyPrefix + userID.String() + ":" + key
+		hash := requestHash(c)
+
+		acquired, existing, err := idempotencyStore.acquire(ctx, redisKey, hash)
+		if err != nil {
+			// Redis 장애 시에는 요청을 막지 않는다
+			logger.WithError(err).Warn("Idempotency check failed")
+			return c.Next()
+		}
+		if !acquired {
+			return replayIdempotent(c, existing, hash)
+		}
+
+		err = c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil || status >= 500 {
+			idempotencyStore.release(redisKey)
+			return err
+		}
+
+		record := &idempotencyRecord{
+			State:       idempotencyCompleted,
+			Hash:        hash,
+			Status:      status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		}
+		if saveErr := idempotencyStore.save(context.Background(), redisKey, record); saveErr != nil {
+			logger.WithError(saveErr).Warn("Failed to store idempotent response")
+		}
+		return nil
+	}
+}
+
+// replayIdempotent answers a request whose key was already used.
+func replayIdempotent(c *fiber.Ctx, record *idempotencyRecord, hash string) error {
+	if record.Hash != hash {
+		return apperr.WriteProblem(c, apperr.New("IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used with a different request", 422))
+	}
+	if record.State != idempotencyCompleted {
+		c.Set("Retry-After", "1")
+		return apperr.WriteProblem(c, apperr.New("IDEMPOTENCY_IN_PROGRESS", "a request with this Idempotency-Key is still being processed", 409))
+	}
+
+	c.Set("Idempotent-Replayed", "true")
+	if record.ContentType != "" {
+		c.Set(fiber.HeaderContentType, record.ContentType)
+	}
+	return c.Status(record.Status).Send(record.Body)
+}
+
+// acquire marks the key as processing. 이미 있으면 저장된 레코드를 반환한다.
+func (s *IdempotencyStore) acquire(ctx context.Context, key, hash string) (bool, *idempotencyRecord, error) {
+	data, err := json.Marshal(&idempotencyRecord{State: idempotencyProcessing, Hash: hash})
+	if err != nil {
+		return false, nil, err
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		ok, err := s.redis.SetNX(ctx, key, data, idempotencyLockTTL).Result()
+		if err != nil {
+			return false, nil, err
+		}
+		if ok {
+			return true, nil, nil
+		}
+
+		raw, err := s.redis.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue // SetNX와 Get 사이에 만료됨 - 다시 시도
+		}
+		if err != nil {
+			return false, nil, err
+		}
+		var record idempotencyRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return false, nil, err
+		}
+		return false, &record, nil
+	}
+	return false, nil, errors.New("idempotency key contention")
+}
+
+// save stores the completed response.
+func (s *IdempotencyStore) save(ctx context.Context, key string, record *idempotencyRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.redis.Set(ctx, key, data, idempotencyResultTTL).Err()
+}
+
+// release drops the processing marker so the client can retry.
+func (s *IdempotencyStore) release(key string) {
+	if err := s.redis.Del(context.Background(), key).Err(); err != nil {
+		logger.WithError(err).Warn("Failed to release idempotency key")
+	}
+}
+
+// requestHash fingerprints method, path, query and body.
+func requestHash(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method()))
+	h.Write([]byte{0})
+	h.Write([]byte(c.OriginalURL()))
+	h.Write([]byte{0})
+	h.Write(c.Body())
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isIdempotentRoute checks whether method and path match IdempotentRoutes.
+func isIdempotentRoute(method, path string) bool {
+	segments := splitPath(strings.TrimSuffix(path, "/"))
+	for _, route := range IdempotentRoutes {
+		routeMethod, routePath, _ := strings.Cut(route, ":")
+		if routeMethod != method {
+			continue
+		}
+		if matchSegments(segments, splitPath(routePath)) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(segments, pattern []string) bool {
+	if len(segments) != len(pattern) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != "*" && !strings.EqualFold(p, segments[i]) {
+			return false
+		}
+	}
+	return true
+}
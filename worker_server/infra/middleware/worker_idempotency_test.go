@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"strings"
+	"testing"
+
+	httpadapter "worker_server/adapter/in/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestIsIdempotentRoute(t *testing.T) {
+	cases := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{"POST", "/api/v1/email", true},
+		{"POST", "/api/v1/email/", true},
+		{"POST", "/api/v1/email/123/reply", true},
+		{"GET", "/api/v1/email", false},
+		{"POST", "/api/v1/email/123/reply/extra", false},
+		{"POST", "/api/v1/email/campaign/9/cancel", false},
+	}
+	for _, tc := range cases {
+		if got := isIdempotentRoute(tc.method, tc.path); got != tc.want {
+			t.Errorf("isIdempotentRoute(%s, %s) = %v, want %v", tc.method, tc.path, got, tc.want)
+		}
+	}
+}
+
+// 캠페인 생성은 실제로 등록된 경로에서 Idempotency-Key를 지원해야 한다
+func TestIdempotentRoutesMatchCampaignCreate(t *testing.T) {
+	app := fiber.New()
+	httpadapter.NewCampaignHandler(nil).Register(app.Group("/api/v1"))
+
+	var create []string
+	for _, r := range app.GetRoutes(true) {
+		if r.Method == fiber.MethodPost && !strings.Contains(r.Path, ":") && strings.Contains(r.Path, "campaign") {
+			create = append(create, r.Path)
+		}
+	}
+	if len(create) == 0 {
+		t.Fatal("campaign create route not registered")
+	}
+	for _, path := range create {
+		if !isIdempotentRoute(fiber.MethodPost, path) {
+			t.Errorf("POST %s is not idempotent", path)
+		}
+	}
+}
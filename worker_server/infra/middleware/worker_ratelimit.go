@@ -5,6 +5,8 @@ import (
 	"sync"
 	"time"
 
+	"worker_server/pkg/apperr"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
@@ -74,11 +76,7 @@ func (rl *RateLimiter) Handler() fiber.Handler {
 		if info.count >= rl.limit {
 			rl.mu.Unlock()
 			setRateLimitHeaders(c, rl.limit, 0, info)
-			return c.Status(429).JSON(fiber.Map{
-				"error":       "rate limit exceeded",
-				"code":        "RATE_LIMITED",
-				"retry_after": int(info.expiresAt.Sub(now).Seconds()),
-			})
+			return apperr.WriteProblem(c, apperr.RateLimited(int(info.expiresAt.Sub(now).Seconds())))
 		}
 
 		info.count++
@@ -150,14 +148,6 @@ func NewAdvancedRateLimiter(config RateLimitConfig) *AdvancedRateLimiter {
 		endpointLimits: make(map[string]*EndpointLimit),
 	}
 
-	// Register sensitive endpoint limits
-	rl.RegisterEndpoint("/api/v1/oauth", 10, time.Minute)           // OAuth: 10/min
-	rl.RegisterEndpoint("/api/v1/ai", 30, time.Minute)              // AI: 30/min
-	rl.RegisterEndpoint("/api/v1/email/send", 20, time.Minute)       // Send mail: 20/min
-	rl.RegisterEndpoint("/api/v1/email/sync", 5, time.Minute)        // Sync: 5/min
-	rl.RegisterEndpoint("/api/v1/reports", 10, time.Minute)         // Reports: 10/min
-	rl.RegisterEndpoint("/api/v1/calendar/events", 50, time.Minute) // Calendar: 50/min
-
 	// Cleanup goroutine
 	go func() {
 		ticker := time.NewTicker(time.Minute)
@@ -169,6 +159,17 @@ func NewAdvancedRateLimiter(config RateLimitConfig) *AdvancedRateLimiter {
 	return rl
 }
 
+// RegisterDefaultEndpoints registers in-memory limits for sensitive endpoints.
+// Redis 기반 RouteRateLimiter를 쓸 수 없을 때의 fallback이다.
+func (rl *AdvancedRateLimiter) RegisterDefaultEndpoints() {
+	rl.RegisterEndpoint("/api/v1/oauth", 10, time.Minute)           // OAuth: 10/min
+	rl.RegisterEndpoint("/api/v1/ai", 30, time.Minute)              // AI: 30/min
+	rl.RegisterEndpoint("/api/v1/email/send", 20, time.Minute)      // Send mail: 20/min
+	rl.RegisterEndpoint("/api/v1/email/sync", 5, time.Minute)       // Sync: 5/min
+	rl.RegisterEndpoint("/api/v1/reports", 10, time.Minute)         // Reports: 10/min
+	rl.RegisterEndpoint("/api/v1/calendar/events", 50, time.Minute) // Calendar: 50/min
+}
+
 // RegisterEndpoint adds a custom rate limit for a specific endpoint pattern
 func (rl *AdvancedRateLimiter) RegisterEndpoint(pattern string, limit int, window time.Duration) {
 	rl.mu.Lock()
@@ -249,12 +250,7 @@ func (rl *AdvancedRateLimiter) Handler() fiber.Handler {
 				if info.count >= el.Limit {
 					el.mu.Unlock()
 					setRateLimitHeaders(c, el.Limit, 0, info)
-					return c.Status(429).JSON(fiber.Map{
-						"error":       "rate limit exceeded for this endpoint",
-						"code":        "RATE_LIMITED",
-						"endpoint":    pattern,
-						"retry_after": int(info.expiresAt.Sub(now).Seconds()),
-					})
+					return apperr.WriteProblem(c, apperr.RateLimited(int(info.expiresAt.Sub(now).Seconds())).WithDetail("endpoint", pattern))
 				}
 
 				info.count++
@@ -267,57 +263,42 @@ func (rl *AdvancedRateLimiter) Handler() fiber.Handler {
 		// Apply general rate limits
 		userID, hasUserID := c.Locals("user_id").(uuid.UUID)
 
-		rl.mu.Lock()
-		defer rl.mu.Unlock()
-
+		// 카운터만 잠금 안에서 갱신하고 핸들러는 잠금 밖에서 실행한다
+		var allowed bool
+		var retryAfter int
 		if hasUserID {
 			// User-based rate limiting (higher limit)
-			key := userID.String()
-			info, exists := rl.userLimits[key]
-
-			if !exists || now.After(info.expiresAt) {
-				rl.userLimits[key] = &requestInfo{
-					count:     1,
-					expiresAt: now.Add(rl.window),
-				}
-				return c.Next()
-			}
-
-			if info.count >= rl.userLimit {
-				return c.Status(429).JSON(fiber.Map{
-					"error":       "rate limit exceeded",
-					"code":        "RATE_LIMITED",
-					"retry_after": int(info.expiresAt.Sub(now).Seconds()),
-				})
-			}
-
-			info.count++
+			allowed, retryAfter = rl.take(rl.userLimits, userID.String(), rl.userLimit, now)
 		} else {
 			// IP-based rate limiting (lower limit)
-			key := c.IP()
-			info, exists := rl.ipLimits[key]
+			allowed, retryAfter = rl.take(rl.ipLimits, c.IP(), rl.ipLimit, now)
+		}
+		if !allowed {
+			return apperr.WriteProblem(c, apperr.RateLimited(retryAfter))
+		}
 
-			if !exists || now.After(info.expiresAt) {
-				rl.ipLimits[key] = &requestInfo{
-					count:     1,
-					expiresAt: now.Add(rl.window),
-				}
-				return c.Next()
-			}
+		return c.Next()
+	}
+}
 
-			if info.count >= rl.ipLimit {
-				return c.Status(429).JSON(fiber.Map{
-					"error":       "rate limit exceeded",
-					"code":        "RATE_LIMITED",
-					"retry_after": int(info.expiresAt.Sub(now).Seconds()),
-				})
-			}
+// take counts a request in the fixed window and reports whether it's allowed.
+func (rl *AdvancedRateLimiter) take(counters map[string]*requestInfo, key string, limit int, now time.Time) (bool, int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
 
-			info.count++
+	info, exists := counters[key]
+	if !exists || now.After(info.expiresAt) {
+		counters[key] = &requestInfo{
+			count:     1,
+			expiresAt: now.Add(rl.window),
 		}
-
-		return c.Next()
+		return true, 0
+	}
+	if info.count >= limit {
+		return false, int(info.expiresAt.Sub(now).Seconds())
 	}
+	info.count++
+	return true, 0
 }
 
 // matchesPattern checks if a path matches a pattern prefix
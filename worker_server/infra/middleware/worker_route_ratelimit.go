@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"worker_server/pkg/apperr"
+	"worker_server/pkg/logger"
+	"worker_server/pkg/ratelimit"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// RouteLimit is a token bucket policy for a group of routes.
+// 같은 Name을 가진 라우트는 하나의 버킷을 공유한다 (예: send = 전송/답장/전달).
+type RouteLimit struct {
+	Name   string
+	Method string   // "" = 모든 메서드
+	Paths  []string // "*"는 한 세그먼트와 매칭
+	Prefix bool     // true면 Paths를 접두사로 매칭
+	Rate   int      // Window당 토큰 보충량
+	Window time.Duration
+	Burst  int // 버킷 크기 (순간 허용량)
+}
+
+// RouteRateLimitConfig holds the tunable list/send/default limits.
+type RouteRateLimitConfig struct {
+	ListPerMin    int
+	ListBurst     int
+	SendPerMin    int
+	SendBurst     int
+	DefaultPerMin int
+	DefaultBurst  int
+}
+
+// DefaultRouteLimits returns route policies; the first matching policy wins.
+// 목록 조회는 화면 전환 시 몰리므로 burst를 크게, 전송은 작게 잡는다.
+func DefaultRouteLimits(cfg RouteRateLimitConfig) []RouteLimit {
+	return []RouteLimit{
+		{
+			Name:   "send",
+			Method: fiber.MethodPost,
+			Paths:  []string{"/api/v1/email", "/api/v1/email/*/reply", "/api/v1/email/*/forward"},
+			Rate:   cfg.SendPerMin, Window: time.Minute, Burst: cfg.SendBurst,
+		},
+		{
+			Name:   "sync",
+			Method: fiber.MethodPost,
+			Paths:  []string{"/api/v1/email/sync", "/api/v1/email/resync", "/api/v1/email/reclassify"},
+			Rate:   5, Window: time.Minute, Burst: 2,
+		},
+		{Name: "oauth", Paths: []string{"/api/v1/oauth"}, Prefix: true, Rate: 10, Window: time.Minute, Burst: 5},
+		{Name: "ai", Paths: []string{"/api/v1/ai"}, Prefix: true, Rate: 30, Window: time.Minute, Burst: 10},
+		{Name: "reports", Paths: []string{"/api/v1/reports"}, Prefix: true, Rate: 10, Window: time.Minute, Burst: 5},
+		{
+			Name:   "list",
+			Method: fiber.MethodGet,
+			Paths:  []string{"/api/v1/email", "/api/v1/calendar", "/api/v1/contacts"},
+			Prefix: true,
+			Rate:   cfg.ListPerMin, Window: time.Minute, Burst: cfg.ListBurst,
+		},
+		{Name: "default", Paths: []string{"/api/v1"}, Prefix: true, Rate: cfg.DefaultPerMin, Window: time.Minute, Burst: cfg.DefaultBurst},
+	}
+}
+
+// RouteRateLimiter applies per-user, per-route token buckets stored in Redis.
+// 인증 이후에 적용해야 사용자 단위로 제한된다 (미인증 요청은 IP 기준).
+type RouteRateLimiter struct {
+	bucket *ratelimit.TokenBucket
+	limits atomic.Pointer[[]RouteLimit]
+}
+
+// NewRouteRateLimiter creates a new route rate limiter.
+func NewRouteRateLimiter(redisClient *redis.Client, limits []RouteLimit) *RouteRateLimiter {
+	rl := &RouteRateLimiter{
+		bucket: ratelimit.NewTokenBucket(redisClient),
+	}
+	rl.SetLimits(limits)
+	return rl
+}
+
+// SetLimits replaces the route policies at runtime. nil disables route limiting.
+// 버킷 상태는 Redis에 남아 있으므로 한도 변경 후에도 사용량이 이어진다.
+func (rl *RouteRateLimiter) SetLimits(limits []RouteLimit) {
+	rl.limits.Store(&limits)
+}
+
+// Handler returns the rate limiting middleware.
+func (rl *RouteRateLimiter) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() == fiber.MethodOptions {
+			return c.Next()
+		}
+
+		limit := rl.match(c.Method(), c.Path())
+		if limit == nil || limit.Rate <= 0 {
+			return c.Next()
+		}
+
+		subject := "ip:" + c.IP()
+		if userID, ok := c.Locals("user_id").(uuid.UUID); ok {
+			subject = "user:" + userID.String()
+		}
+
+		res, err := rl.bucket.Take(c.UserContext(), limit.Name+":"+subject, limit.Rate, limit.Window, limit.Burst)
+		if err != nil {
+			// Redis 장애 시 요청을 막지 않는다
+			logger.WithError(err).Debug("Route rate limit check failed")
+			return c.Next()
+		}
+
+		setRouteRateLimitHeaders(c, limit, res)
+		if !res.Allowed {
+			retryAfter := int(math.Ceil(res.RetryAfter.Seconds()))
+			return apperr.WriteProblem(c, apperr.RateLimited(max(retryAfter, 1)).WithDetail("policy", limit.Name))
+		}
+		return c.Next()
+	}
+}
+
+// match returns the first policy matching method and path.
+func (rl *RouteRateLimiter) match(method, path string) *RouteLimit {
+	segments := splitPath(strings.TrimSuffix(path, "/"))
+	limits := *rl.limits.Load()
+	for i := range limits {
+		l := &limits[i]
+		if l.Method != "" && l.Method != method {
+			continue
+		}
+		for _, p := range l.Paths {
+			pattern := splitPath(p)
+			if l.Prefix && len(segments) > len(pattern) {
+				if matchSegments(segments[:len(pattern)], pattern) {
+					return l
+				}
+				continue
+			}
+			if matchSegments(segments, pattern) {
+				return l
+			}
+		}
+	}
+	return nil
+}
+
+// setRouteRateLimitHeaders sets RateLimit-* headers (draft-ietf-httpapi-ratelimit-headers).
+func setRouteRateLimitHeaders(c *fiber.Ctx, limit *RouteLimit, res *ratelimit.BucketResult) {
+	c.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	c.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	c.Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(res.ResetAfter.Seconds()))))
+	c.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d;burst=%d;policy=%q", limit.Rate, int(limit.Window.Seconds()), res.Limit, limit.Name))
+}
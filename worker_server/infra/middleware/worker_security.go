@@ -4,6 +4,8 @@ import (
 	"regexp"
 	"strings"
 
+	"worker_server/pkg/apperr"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -101,27 +103,18 @@ func InputSanitizer() fiber.Handler {
 		queryString := string(c.Request().URI().QueryString())
 		if sqlInjectionPatterns.MatchString(queryString) {
 			logSuspiciousRequest(c, "sql_injection", queryString)
-			return c.Status(400).JSON(fiber.Map{
-				"error": "invalid request parameters",
-				"code":  "SQL_INJECTION_BLOCKED",
-			})
+			return apperr.WriteProblem(c, apperr.New("SQL_INJECTION_BLOCKED", "invalid request parameters", 400))
 		}
 		if xssPatterns.MatchString(queryString) {
 			logSuspiciousRequest(c, "xss", queryString)
-			return c.Status(400).JSON(fiber.Map{
-				"error": "invalid request parameters",
-				"code":  "XSS_BLOCKED",
-			})
+			return apperr.WriteProblem(c, apperr.New("XSS_BLOCKED", "invalid request parameters", 400))
 		}
 
 		// Check path parameters
 		path := c.Path()
 		if xssPatterns.MatchString(path) || cmdInjectionPatterns.MatchString(path) {
 			logSuspiciousRequest(c, "path_injection", path)
-			return c.Status(400).JSON(fiber.Map{
-				"error": "invalid request path",
-				"code":  "INVALID_INPUT",
-			})
+			return apperr.WriteProblem(c, apperr.New("INVALID_INPUT", "invalid request path", 400))
 		}
 
 		// Check request body for POST/PUT/PATCH
@@ -130,10 +123,7 @@ func InputSanitizer() fiber.Handler {
 			if len(body) > 0 && len(body) < 100000 { // Only check reasonable sized bodies
 				if sqlInjectionPatterns.MatchString(body) {
 					logSuspiciousRequest(c, "sql_injection_body", body[:min(500, len(body))])
-					return c.Status(400).JSON(fiber.Map{
-						"error": "invalid request body",
-						"code":  "SQL_INJECTION_BLOCKED",
-					})
+					return apperr.WriteProblem(c, apperr.New("SQL_INJECTION_BLOCKED", "invalid request body", 400))
 				}
 			}
 		}
@@ -178,10 +168,7 @@ func ValidateContentType() fiber.Handler {
 			// If there's a body, content type should be set
 			if bodyLen > 0 {
 				if contentType == "" {
-					return c.Status(400).JSON(fiber.Map{
-						"error": "content-type header required",
-						"code":  "MISSING_CONTENT_TYPE",
-					})
+					return apperr.WriteProblem(c, apperr.New("MISSING_CONTENT_TYPE", "content-type header required", 400))
 				}
 
 				// Allow only specific content types
@@ -200,10 +187,7 @@ func ValidateContentType() fiber.Handler {
 				}
 
 				if !valid {
-					return c.Status(415).JSON(fiber.Map{
-						"error": "unsupported content type",
-						"code":  "UNSUPPORTED_MEDIA_TYPE",
-					})
+					return apperr.WriteProblem(c, apperr.New("UNSUPPORTED_MEDIA_TYPE", "unsupported content type", 415))
 				}
 			}
 		}
@@ -216,11 +200,7 @@ func ValidateContentType() fiber.Handler {
 func MaxBodySize(maxBytes int) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		if len(c.Body()) > maxBytes {
-			return c.Status(413).JSON(fiber.Map{
-				"error":    "request body too large",
-				"code":     "PAYLOAD_TOO_LARGE",
-				"max_size": maxBytes,
-			})
+			return apperr.WriteProblem(c, apperr.New("PAYLOAD_TOO_LARGE", "request body too large", 413).WithDetail("max_size", maxBytes))
 		}
 		return c.Next()
 	}
@@ -236,10 +216,7 @@ func IPWhitelist(allowedIPs []string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		clientIP := c.IP()
 		if !ipSet[clientIP] {
-			return c.Status(403).JSON(fiber.Map{
-				"error": "access denied",
-				"code":  "IP_NOT_ALLOWED",
-			})
+			return apperr.WriteProblem(c, apperr.New("IP_NOT_ALLOWED", "access denied", 403))
 		}
 		return c.Next()
 	}
@@ -9,12 +9,15 @@ import (
 	"worker_server/adapter/out/persistence"
 	"worker_server/config"
 	"worker_server/infra/middleware"
+	"worker_server/pkg/crypto"
 	"worker_server/pkg/logger"
+	"worker_server/pkg/ratelimit"
 
 	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
 
@@ -43,6 +46,7 @@ func NewAPI(cfg *config.Config) (*fiber.App, func(), error) {
 	// Initialize security components with Redis
 	middleware.InitTokenBlacklist(deps.Redis)
 	middleware.InitAuditLogger(deps.Redis)
+	middleware.InitIdempotencyStore(deps.Redis)
 
 	app := fiber.New(fiber.Config{
 		ErrorHandler:          middleware.ErrorHandler(),
@@ -92,7 +96,8 @@ func NewAPI(cfg *config.Config) (*fiber.App, func(), error) {
 
 	// Response compression (gzip/brotli) - reduces response size by ~70%
 	app.Use(compress.New(compress.Config{
-		Level: compress.LevelBestSpeed, // 빠른 압축 (CPU vs 압축률 균형)
+		Level: compress.LevelBestSpeed,  // 빠른 압축 (CPU vs 압축률 균형)
+		Next:  middleware.IsEventStream, // SSE 스트림은 압축하지 않음
 	}))
 
 	// ETag 미들웨어 - 304 Not Modified 응답으로 대역폭 절약
@@ -115,14 +120,17 @@ func NewAPI(cfg *config.Config) (*fiber.App, func(), error) {
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     allowOrigins,
 		AllowMethods:     "GET,POST,PUT,DELETE,PATCH,OPTIONS",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Request-ID",
-		ExposeHeaders:    "X-Request-ID,X-RateLimit-Limit,X-RateLimit-Remaining,X-RateLimit-Reset",
+		AllowHeaders:     "Origin,Content-Type,Accept,Authorization,X-Request-ID,Idempotency-Key",
+		ExposeHeaders:    "X-Request-ID,X-RateLimit-Limit,X-RateLimit-Remaining,X-RateLimit-Reset,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,RateLimit-Policy,Retry-After,X-Quota-Tier,X-Quota-Limit,X-Quota-Remaining,Idempotent-Replayed",
 		AllowCredentials: allowCredentials,
 		MaxAge:           86400, // 24 hours
 	}))
 
 	// Health check (no auth required)
 	healthHandler := http.NewHealthHandlerWithDeps(deps.DB, deps.Redis)
+	if deps.MailSyncService != nil {
+		healthHandler.SetWatchStats(deps.MailSyncService)
+	}
 	healthHandler.Register(app)
 
 	// Development-only test endpoints (no auth, hardcoded test user)
@@ -156,27 +164,27 @@ func NewAPI(cfg *config.Config) (*fiber.App, func(), error) {
 	}
 	webhookHandler.Register(app)
 
-	// SSE Handler (using new RealtimePort-based SSEHub)
-	zlog := zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger()
-	sseHandler := http.NewSSEHandler(deps.SSEHub, zlog)
-
-	// API routes (with auth and rate limiting)
-	api := app.Group("/api/v1")
-
-	// Apply advanced rate limiting
-	rateLimiter := middleware.NewAdvancedRateLimiter(middleware.DefaultRateLimitConfig())
-	api.Use(rateLimiter.Handler())
-
-	api.Use(middleware.JWTAuth(cfg.JWTSecret))
+	// Safe link redirect (no auth required - 메일 본문 링크 클릭)
+	var safeLinkHandler *http.SafeLinkHandler
+	if deps.SafeLinkService != nil {
+		safeLinkHandler = http.NewSafeLinkHandler(deps.SafeLinkService)
+		safeLinkHandler.RegisterPublic(app)
+	}
 
-	// Audit logging for sensitive actions
-	api.Use(middleware.AuditMiddleware())
+	// Send tracking pixel/click (no auth required - 수신자 메일 클라이언트 요청)
+	if deps.TrackingService != nil {
+		http.NewTrackingHandler(deps.TrackingService).RegisterPublic(app)
+	}
 
-	// Register handlers
-	sseHandler.Register(api)
+	// Image proxy (no auth required - 메일 본문 <img> 요청)
+	if deps.ImageProxyService != nil {
+		http.NewImageProxyHandler(deps.ImageProxyService).RegisterPublic(app)
+	}
 
-	// OAuth handler (connect, connections, disconnect - requires auth)
-	oauthHandler.Register(api)
+	// Large attachment download (no auth required - 수신자가 메일 본문 링크로 연다)
+	if deps.FileLinkService != nil {
+		http.NewFileLinkHandler(deps.FileLinkService).RegisterPublic(app)
+	}
 
 	// Mail handler with provider for direct Gmail/Outlook API access
 	// API 보호 레이어: Semaphore + Rate Limiter + Debounce + Cache
@@ -194,12 +202,111 @@ func NewAPI(cfg *config.Config) (*fiber.App, func(), error) {
 		deps.VectorStore, // 통합 검색용 벡터 스토어
 		deps.Embedder,    // 통합 검색용 임베더
 	)
-	emailHandler.Register(api)
+	if deps.SafeLinkService != nil {
+		emailHandler.SetSafeLinkService(deps.SafeLinkService)
+	}
+	if deps.ImageProxyService != nil {
+		emailHandler.SetImageProxy(deps.ImageProxyService)
+	}
+	if deps.UploadService != nil {
+		emailHandler.SetUploadService(deps.UploadService)
+	}
+	if cfg.LinkSigningSecret != "" {
+		emailHandler.SetURLSigner(crypto.NewURLSigner(cfg.LinkSigningSecret), cfg.PublicBaseURL)
+	}
+	if deps.CacheService != nil {
+		emailHandler.SetBodyCache(deps.CacheService)
+	}
+	if deps.JobService != nil {
+		emailHandler.SetJobService(deps.JobService)
+	}
+	if deps.BackfillRepo != nil {
+		emailHandler.SetBackfillRepository(deps.BackfillRepo)
+	}
+	if deps.HistoryBackfillRepo != nil && deps.MailSyncService != nil {
+		emailHandler.SetHistoryBackfill(deps.MailSyncService)
+	}
+	if deps.AIAttemptRepo != nil {
+		emailHandler.SetClassifyAttemptRepository(deps.AIAttemptRepo)
+	}
+	if deps.ShareService != nil {
+		emailHandler.SetShareService(deps.ShareService)
+	}
+	if deps.CategoryService != nil {
+		emailHandler.SetCategoryService(deps.CategoryService)
+	}
+	if deps.FolderRepo != nil && deps.LabelRepo != nil {
+		emailHandler.SetSearchScopeRepositories(deps.FolderRepo, deps.LabelRepo)
+	}
+	if deps.NLFilterParser != nil {
+		emailHandler.SetNLFilterParser(deps.NLFilterParser)
+	}
+	emailHandler.SetUserQuotas(tierLimits(cfg), tierLookup(cfg, deps.UserTierRepo))
+	// 서명 URL 인라인 이미지 (no auth required - JWT 미들웨어보다 먼저 등록)
+	emailHandler.RegisterPublic(app)
+
+	// SSE Handler (using new RealtimePort-based SSEHub)
+	zlog := zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger()
+	sseHandler := http.NewSSEHandler(deps.SSEHub, zlog)
+
+	// API routes (with auth and rate limiting)
+	api := app.Group("/api/v1")
+
+	// Apply advanced rate limiting (IP/global guard)
+	rateLimiter := middleware.NewAdvancedRateLimiter(middleware.DefaultRateLimitConfig())
+	if deps.Redis == nil {
+		rateLimiter.RegisterDefaultEndpoints()
+	}
+	api.Use(rateLimiter.Handler())
+
+	api.Use(middleware.JWTAuth(cfg.JWTSecret))
+
+	// Per-user, per-route token buckets (인증 이후에 적용해야 사용자 단위로 제한됨)
+	// RATE_LIMIT_* 값은 ConfigWatcher를 통해 재시작 없이 바뀐다.
+	if deps.Redis != nil {
+		routeLimiter := middleware.NewRouteRateLimiter(deps.Redis, routeLimits(cfg))
+		deps.ConfigWatcher.OnChange(func(_, next *config.Config) {
+			routeLimiter.SetLimits(routeLimits(next))
+		})
+		api.Use(routeLimiter.Handler())
+	}
+
+	// Audit logging for sensitive actions
+	api.Use(middleware.AuditMiddleware())
+
+	// Idempotency-Key: 재시도된 전송/배치 요청이 중복 실행되지 않도록 응답 재사용
+	api.Use(middleware.Idempotency())
+
+	// Register handlers
+	sseHandler.Register(api)
+
+	// OAuth handler (connect, connections, disconnect, health/reconnect - requires auth)
+	if deps.ConnectionHealth != nil {
+		oauthHandler.SetHealthService(deps.ConnectionHealth)
+	}
+	oauthHandler.Register(api)
+
+	// Campaign handler (mail merge) - /email/:id 라우트보다 먼저 등록
+	if deps.CampaignService != nil {
+		campaignHandler := http.NewCampaignHandler(deps.CampaignService)
+		campaignHandler.Register(api)
+	}
 
-	// Category handler (category metadata & stats)
+	// Category handler (category metadata & stats) - /email/categories가 /email/:id보다 먼저 등록되어야 한다
 	categoryHandler := http.NewCategoryHandler(deps.MailRepo)
+	if deps.CategoryService != nil {
+		categoryHandler.SetCategoryService(deps.CategoryService)
+	}
 	categoryHandler.Register(api)
 
+	// Inbox triage handler (Inbox Zero 도우미)
+	if deps.TriageService != nil {
+		http.NewTriageHandler(deps.TriageService).Register(api)
+	}
+
+	// Mail handler (public 라우트 등록을 위해 위에서 생성)
+	emailHandler.Register(api)
+
 	// Calendar handler
 	calendarHandler := http.NewCalendarHandler(deps.CalendarService)
 	calendarHandler.Register(api)
@@ -217,8 +324,8 @@ func NewAPI(cfg *config.Config) (*fiber.App, func(), error) {
 	labelHandler.Register(api)
 
 	// Folder and Smart Folder handler
-	if deps.FolderRepo != nil && deps.SmartFolderRepo != nil {
-		folderHandler := http.NewFolderHandler(deps.FolderRepo, deps.SmartFolderRepo)
+	if deps.FolderService != nil && deps.SmartFolderRepo != nil {
+		folderHandler := http.NewFolderHandler(deps.FolderService, deps.SmartFolderRepo)
 		folderHandler.RegisterRoutes(api)
 		logger.Info("Folder and SmartFolder handlers registered")
 	}
@@ -256,6 +363,84 @@ func NewAPI(cfg *config.Config) (*fiber.App, func(), error) {
 		templateHandler.Register(api)
 	}
 
+	// Signature handler
+	if deps.SignatureService != nil {
+		signatureHandler := http.NewSignatureHandler(deps.SignatureService)
+		signatureHandler.Register(api)
+	}
+
+	// Vacation responder handler (연결별 부재중 응답)
+	if deps.VacationService != nil {
+		vacationHandler := http.NewVacationHandler(deps.VacationService)
+		vacationHandler.Register(api)
+	}
+
+	// Storage usage handler (GET /connections/:id/usage - 연결별 용량, 상한)
+	if deps.StorageService != nil {
+		storageHandler := http.NewStorageHandler(deps.StorageService)
+		storageHandler.Register(api)
+	}
+
+	// Command palette handler (GET /actions/search)
+	if deps.PaletteService != nil {
+		actionHandler := http.NewActionHandler(deps.PaletteService)
+		actionHandler.Register(api)
+	}
+
+	// Admin handler (GET /admin/connections - 동기화 lag)
+	if deps.SyncLagService != nil {
+		adminHandler := http.NewAdminHandler(cfg.AdminUserIDs, deps.SyncLagService)
+		adminHandler.Register(api)
+	}
+
+	// AI usage handler (GET /usage/ai)
+	if deps.UsageService != nil {
+		usageHandler := http.NewUsageHandler(deps.UsageService)
+		usageHandler.Register(api)
+	}
+
+	// Daily briefing handler (GET /briefing/today)
+	if deps.BriefingService != nil {
+		briefingHandler := http.NewBriefingHandler(deps.BriefingService)
+		briefingHandler.Register(api)
+	}
+
+	// Workflow board handler (칸반 보드, 드래그 앤 드롭 순서 저장)
+	if deps.WorkflowService != nil {
+		workflowHandler := http.NewWorkflowHandler(deps.WorkflowService)
+		workflowHandler.Register(api)
+	}
+
+	// Team handler (조직, 공유 메일함, 메일 내부 댓글)
+	if deps.TeamService != nil {
+		teamHandler := http.NewTeamHandler(deps.TeamService)
+		teamHandler.Register(api)
+	}
+
+	// Analytics handler (관계 그래프 분석, 연락처별 drill-down)
+	if deps.AnalyticsService != nil {
+		analyticsHandler := http.NewAnalyticsHandler(deps.AnalyticsService)
+		analyticsHandler.Register(api)
+	}
+
+	// Job status handler (GET /jobs/:id)
+	if deps.JobService != nil {
+		jobHandler := http.NewJobHandler(deps.JobService)
+		jobHandler.Register(api)
+	}
+
+	// Alias handler (연결별 send-as 주소)
+	if deps.AliasService != nil {
+		aliasHandler := http.NewAliasHandler(deps.AliasService)
+		aliasHandler.Register(api)
+	}
+
+	// Import handler (MBOX/EML → local archive)
+	if deps.ImportService != nil {
+		importHandler := http.NewImportHandler(deps.ImportService)
+		importHandler.Register(api)
+	}
+
 	// Image handler (DALL-E image generation)
 	if deps.ImageService != nil {
 		imageHandler := http.NewImageHandler(deps.ImageService)
@@ -263,6 +448,11 @@ func NewAPI(cfg *config.Config) (*fiber.App, func(), error) {
 		logger.Info("Image handler registered")
 	}
 
+	// Safe link click analytics
+	if safeLinkHandler != nil {
+		safeLinkHandler.Register(api)
+	}
+
 	// Webhook management handler (authenticated)
 	webhookHandler.RegisterManagement(api)
 
@@ -283,3 +473,61 @@ func NewAPI(cfg *config.Config) (*fiber.App, func(), error) {
 
 	return app, cleanup, nil
 }
+
+// routeLimits builds the per-route policies, or nil when rate limiting is disabled.
+func routeLimits(cfg *config.Config) []middleware.RouteLimit {
+	if !cfg.RateLimitEnabled {
+		return nil
+	}
+	return middleware.DefaultRouteLimits(middleware.RouteRateLimitConfig{
+		ListPerMin:    cfg.RateLimitListPerMin,
+		ListBurst:     cfg.RateLimitListBurst,
+		SendPerMin:    cfg.RateLimitSendPerMin,
+		SendBurst:     cfg.RateLimitSendBurst,
+		DefaultPerMin: cfg.RateLimitDefaultPerMin,
+		DefaultBurst:  cfg.RateLimitDefaultBurst,
+	})
+}
+
+// tierLimits builds the per-tier provider quotas from config.
+func tierLimits(cfg *config.Config) map[ratelimit.Tier]ratelimit.TierLimits {
+	return map[ratelimit.Tier]ratelimit.TierLimits{
+		ratelimit.TierFree: {
+			MaxConcurrent:     cfg.TierFreeMaxConcurrent,
+			RequestsPerSecond: cfg.TierFreeRPS,
+			BurstSize:         cfg.TierFreeRPS * 2,
+			MaxPayloadSize:    cfg.TierFreeMaxPayload,
+		},
+		ratelimit.TierPro: {
+			MaxConcurrent:     cfg.TierProMaxConcurrent,
+			RequestsPerSecond: cfg.TierProRPS,
+			BurstSize:         cfg.TierProRPS * 2,
+			MaxPayloadSize:    cfg.TierProMaxPayload,
+		},
+	}
+}
+
+// tierLookup resolves a user's tier: TIER_PRO_USERS first, then users.tier.
+func tierLookup(cfg *config.Config, repo *persistence.UserTierAdapter) ratelimit.TierLookup {
+	proUsers := make(map[string]bool, len(cfg.TierProUsers))
+	for _, id := range cfg.TierProUsers {
+		proUsers[strings.TrimSpace(id)] = true
+	}
+	return func(ctx context.Context, userID string) (ratelimit.Tier, error) {
+		if proUsers[userID] {
+			return ratelimit.TierPro, nil
+		}
+		if repo == nil {
+			return ratelimit.TierFree, nil
+		}
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			return ratelimit.TierFree, err
+		}
+		tier, err := repo.GetTier(ctx, id)
+		if err != nil {
+			return ratelimit.TierFree, err
+		}
+		return ratelimit.ParseTier(tier), nil
+	}
+}
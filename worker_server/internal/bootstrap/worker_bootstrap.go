@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/goccy/go-json"
 
@@ -18,6 +19,7 @@ import (
 type Worker struct {
 	pool                *worker.Pool
 	consumer            *messaging.Consumer
+	partitions          *messaging.PartitionAssigner
 	deps                *Dependencies
 	ctx                 context.Context
 	cancel              context.CancelFunc
@@ -26,6 +28,17 @@ type Worker struct {
 	syncRetryScheduler  *worker.SyncRetryScheduler
 	watchRenewScheduler *worker.WatchRenewScheduler
 	gapSyncScheduler    *worker.GapSyncScheduler
+	backfillScheduler   *worker.BackfillResumeScheduler
+	historyBackfill     *worker.HistoryBackfillScheduler
+	briefingScheduler   *worker.BriefingScheduler
+	heldNotifyScheduler *worker.HeldNotificationScheduler
+	slaScheduler        *worker.SLAScheduler
+	syncLagMonitor      *worker.SyncLagMonitor
+	storageScheduler    *worker.StorageUsageScheduler
+	statsScheduler      *worker.MailboxStatsScheduler
+	outboxScheduler     *worker.OutboxScheduler
+	purgeScheduler      *worker.EmailPurgeScheduler
+	archiveScheduler    *worker.EmailArchiveScheduler
 }
 
 func NewWorker(cfg *config.Config) (*Worker, func(), error) {
@@ -48,10 +61,32 @@ func NewWorker(cfg *config.Config) (*Worker, func(), error) {
 		deps.MessageProducer,
 		deps.RealtimeAdapter,
 	)
+	mailProcessor.SetImportService(deps.ImportService)
+	mailProcessor.SetCampaignService(deps.CampaignService)
+	mailProcessor.SetTriageService(deps.TriageService)
 	aiProcessor := worker.NewAIProcessor(deps.AIService, deps.MailRepo, deps.RealtimeAdapter)
+	if deps.JobService != nil {
+		mailProcessor.SetJobService(deps.JobService)
+		aiProcessor.SetJobService(deps.JobService)
+	}
+	if deps.AIAttemptRepo != nil {
+		aiProcessor.SetClassifyAttemptRepository(deps.AIAttemptRepo)
+	}
 	ragProcessor := worker.NewRAGProcessor(deps.RAGIndexer, deps.StyleAnalyzer, deps.MailRepo, deps.MailBodyRepo)
+	ragProcessor.SetIndexBatchSize(cfg.RAGEmbedBatchSize)
+	if deps.EmailNoteRepo != nil {
+		ragProcessor.SetNoteRepository(deps.EmailNoteRepo)
+	}
+	if deps.UsageService != nil {
+		aiProcessor.SetUsageService(deps.UsageService)
+		ragProcessor.SetUsageService(deps.UsageService)
+	}
+	if deps.StorageService != nil {
+		ragProcessor.SetStorageService(deps.StorageService)
+	}
 	calendarProcessor := worker.NewCalendarProcessor(deps.CalendarSyncService)
 	webhookProcessor := worker.NewWebhookProcessor(deps.WebhookService)
+	contactProcessor := worker.NewContactProcessor(deps.ContactService)
 
 	// Create handler
 	handler := worker.NewHandler(
@@ -61,6 +96,7 @@ func NewWorker(cfg *config.Config) (*Worker, func(), error) {
 		calendarProcessor,
 		webhookProcessor,
 	)
+	handler.SetContactProcessor(contactProcessor)
 
 	// Create intelligent pool with config (use DefaultPoolConfig as base)
 	defaultConfig := worker.DefaultPoolConfig()
@@ -98,6 +134,7 @@ func NewWorker(cfg *config.Config) (*Worker, func(), error) {
 	var syncRetryScheduler *worker.SyncRetryScheduler
 	var watchRenewScheduler *worker.WatchRenewScheduler
 	var gapSyncScheduler *worker.GapSyncScheduler
+	var backfillScheduler *worker.BackfillResumeScheduler
 
 	if deps.SyncStateRepo != nil && deps.MailSyncService != nil {
 		syncRetryScheduler = worker.NewSyncRetryScheduler(deps.SyncStateRepo, deps.MailSyncService)
@@ -105,6 +142,49 @@ func NewWorker(cfg *config.Config) (*Worker, func(), error) {
 		gapSyncScheduler = worker.NewGapSyncScheduler(deps.SyncStateRepo, deps.MailSyncService)
 		logger.Info("Sync schedulers configured (retry, watch renew, gap sync)")
 	}
+	if deps.BackfillRepo != nil && deps.MailSyncService != nil {
+		backfillScheduler = worker.NewBackfillResumeScheduler(deps.MailSyncService)
+	}
+	var historyBackfill *worker.HistoryBackfillScheduler
+	if deps.HistoryBackfillRepo != nil && deps.MailSyncService != nil {
+		historyBackfill = worker.NewHistoryBackfillScheduler(deps.MailSyncService)
+	}
+	var briefingScheduler *worker.BriefingScheduler
+	if deps.BriefingService != nil {
+		briefingScheduler = worker.NewBriefingScheduler(deps.BriefingService)
+	}
+	var heldNotifyScheduler *worker.HeldNotificationScheduler
+	if deps.NotificationService != nil && deps.HeldNotifyRepo != nil {
+		heldNotifyScheduler = worker.NewHeldNotificationScheduler(deps.NotificationService)
+	}
+	var slaScheduler *worker.SLAScheduler
+	if deps.TeamService != nil && deps.SLARepo != nil {
+		slaScheduler = worker.NewSLAScheduler(deps.TeamService)
+	}
+	var syncLagMonitor *worker.SyncLagMonitor
+	if deps.SyncLagService != nil {
+		syncLagMonitor = worker.NewSyncLagMonitor(deps.SyncLagService, time.Duration(cfg.SyncLagCheckIntervalMin)*time.Minute)
+	}
+	var storageScheduler *worker.StorageUsageScheduler
+	if deps.StorageService != nil {
+		storageScheduler = worker.NewStorageUsageScheduler(deps.StorageService, time.Duration(cfg.StorageCheckIntervalMin)*time.Minute)
+	}
+	var statsScheduler *worker.MailboxStatsScheduler
+	if deps.AnalyticsService != nil && deps.MailboxStatsRepo != nil {
+		statsScheduler = worker.NewMailboxStatsScheduler(deps.AnalyticsService)
+	}
+	var outboxScheduler *worker.OutboxScheduler
+	if deps.EmailService != nil && deps.OutboxRepo != nil {
+		outboxScheduler = worker.NewOutboxScheduler(deps.EmailService)
+	}
+	var purgeScheduler *worker.EmailPurgeScheduler
+	if deps.EmailService != nil && deps.MailRepo != nil {
+		purgeScheduler = worker.NewEmailPurgeScheduler(deps.EmailService)
+	}
+	var archiveScheduler *worker.EmailArchiveScheduler
+	if deps.EmailService != nil && deps.EmailArchiveRepo != nil && cfg.EmailArchiveAfterMonths > 0 {
+		archiveScheduler = worker.NewEmailArchiveScheduler(deps.EmailService)
+	}
 
 	w := &Worker{
 		pool:                pool,
@@ -115,6 +195,17 @@ func NewWorker(cfg *config.Config) (*Worker, func(), error) {
 		syncRetryScheduler:  syncRetryScheduler,
 		watchRenewScheduler: watchRenewScheduler,
 		gapSyncScheduler:    gapSyncScheduler,
+		backfillScheduler:   backfillScheduler,
+		historyBackfill:     historyBackfill,
+		briefingScheduler:   briefingScheduler,
+		heldNotifyScheduler: heldNotifyScheduler,
+		slaScheduler:        slaScheduler,
+		syncLagMonitor:      syncLagMonitor,
+		storageScheduler:    storageScheduler,
+		statsScheduler:      statsScheduler,
+		outboxScheduler:     outboxScheduler,
+		purgeScheduler:      purgeScheduler,
+		archiveScheduler:    archiveScheduler,
 	}
 
 	// Redis Stream Consumer 설정 (Redis가 있을 때만)
@@ -124,24 +215,40 @@ func NewWorker(cfg *config.Config) (*Worker, func(), error) {
 			messaging.StreamMailSync,
 			messaging.StreamMailSend,
 			messaging.StreamMailBatch,
-			messaging.StreamMailSave,   // 메일 저장 스트림
-			messaging.StreamMailModify, // 메일 상태 변경 + SSE 브로드캐스트
+			messaging.StreamMailSave,     // 메일 저장 스트림
+			messaging.StreamMailModify,   // 메일 상태 변경 + SSE 브로드캐스트
+			messaging.StreamMailImport,   // MBOX/EML 가져오기
+			messaging.StreamMailCampaign, // 메일 머지 캠페인 발송
+			messaging.StreamMailTriage,   // 받은편지함 정리 제안 실행
 			messaging.StreamCalendarSync,
 			messaging.StreamAIClassify,
 			messaging.StreamAISummarize,
 			messaging.StreamAIGenerateReply,
 			messaging.StreamRAGIndex,
 			messaging.StreamRAGBatchIndex,
+			messaging.StreamContactEnrich, // 중요 연락처 프로필 보강
 		}
 
+		// mail:sync 파티션 (lease를 가진 파티션만 읽음, 기존 mail:sync는 잔여 메시지 처리용으로 계속 구독)
+		if cfg.SyncPartitions > 1 {
+			w.partitions = messaging.NewPartitionAssigner(deps.Redis, messaging.StreamMailSync, cfg.SyncPartitions, cfg.WorkerID, zlog)
+		}
+
+		// 실시간 수신 메일 분류는 backfill보다 먼저 처리
+		priorityStreams := []string{messaging.StreamAIPriority}
+		lowStreams := []string{messaging.StreamAIClassifyLow}
+
 		w.consumer = messaging.NewConsumer(deps.Redis, &messaging.ConsumerConfig{
-			Group:    "workspace-workers",
-			Consumer: cfg.WorkerID,
-			Streams:  streams,
-			Handler:  &streamHandler{worker: w},
-			Logger:   zlog,
+			Group:           "workspace-workers",
+			Consumer:        cfg.WorkerID,
+			Streams:         streams,
+			PriorityStreams: priorityStreams,
+			LowStreams:      lowStreams,
+			Handler:         &streamHandler{worker: w},
+			Logger:          zlog,
+			Partitions:      w.partitions,
 		})
-		logger.Info("Redis Stream Consumer configured for %d streams", len(streams))
+		logger.Info("Redis Stream Consumer configured for %d streams", len(streams)+len(priorityStreams)+len(lowStreams))
 	} else {
 		logger.Warn("Redis not available, worker will only process direct submissions")
 	}
@@ -165,14 +272,14 @@ func (h *streamHandler) Handle(ctx context.Context, stream string, data []byte)
 	}
 
 	// Map stream to job type
-	jobType := streamToJobType(stream)
+	jobType := streamToJobType(messaging.BaseStream(stream))
 	logger.Info("[StreamHandler] Job type: %s, payload: %v", jobType, payload)
 
-	// Create worker message
-	msg := worker.NewMessage(jobType, payload)
+	// Create worker message (우선순위 스트림은 priority pool로 제출)
+	msg := worker.NewPriorityMessage(jobType, payload, streamPriority(stream))
 
 	// Submit to pool
-	if !h.worker.pool.Submit(msg) {
+	if !h.worker.Submit(msg) {
 		logger.Error("[StreamHandler] Failed to submit job to pool: %s", jobType)
 	} else {
 		logger.Info("[StreamHandler] Job submitted to pool: %s", jobType)
@@ -194,9 +301,15 @@ func streamToJobType(stream string) string {
 		return worker.JobMailSave
 	case messaging.StreamMailModify:
 		return worker.JobMailModify
+	case messaging.StreamMailImport:
+		return worker.JobMailImport
+	case messaging.StreamMailCampaign:
+		return worker.JobMailCampaign
+	case messaging.StreamMailTriage:
+		return worker.JobMailTriage
 	case messaging.StreamCalendarSync:
 		return worker.JobCalendarSync
-	case messaging.StreamAIClassify:
+	case messaging.StreamAIClassify, messaging.StreamAIPriority, messaging.StreamAIClassifyLow:
 		return worker.JobAIClassify
 	case messaging.StreamAISummarize:
 		return worker.JobAISummarize
@@ -206,11 +319,25 @@ func streamToJobType(stream string) string {
 		return worker.JobRAGIndex
 	case messaging.StreamRAGBatchIndex:
 		return worker.JobRAGBatchIndex
+	case messaging.StreamContactEnrich:
+		return worker.JobContactEnrich
 	default:
 		return stream
 	}
 }
 
+// streamPriority maps Redis stream names to pool priorities
+func streamPriority(stream string) worker.Priority {
+	switch stream {
+	case messaging.StreamAIPriority:
+		return worker.PriorityHigh
+	case messaging.StreamAIClassifyLow:
+		return worker.PriorityLow
+	default:
+		return worker.PriorityNormal
+	}
+}
+
 func (w *Worker) Start() {
 	// Worker Pool 시작
 	w.wg.Add(1)
@@ -219,6 +346,15 @@ func (w *Worker) Start() {
 		w.pool.Start()
 	}()
 
+	// 파티션 lease 관리 시작 (Consumer보다 먼저)
+	if w.partitions != nil {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			w.partitions.Run(w.ctx)
+		}()
+	}
+
 	// Redis Stream Consumer 시작 (있을 경우)
 	if w.consumer != nil {
 		w.wg.Add(1)
@@ -249,6 +385,72 @@ func (w *Worker) Start() {
 		w.zlog.Info().Msg("Started Gap Sync Scheduler")
 	}
 
+	// Backfill Resume Scheduler 시작 (중단된 분류 backfill 이어하기)
+	if w.backfillScheduler != nil {
+		w.backfillScheduler.Start()
+		w.zlog.Info().Msg("Started Backfill Resume Scheduler")
+	}
+
+	// Briefing Scheduler 시작 (사용자 시간대 기준 일일 브리핑)
+	if w.briefingScheduler != nil {
+		w.briefingScheduler.Start()
+		w.zlog.Info().Msg("Started Briefing Scheduler")
+	}
+
+	// Held Notification Scheduler 시작 (방해 금지 시간 종료 후 요약 알림)
+	if w.heldNotifyScheduler != nil {
+		w.heldNotifyScheduler.Start()
+		w.zlog.Info().Msg("Started Held Notification Scheduler")
+	}
+
+	// SLA Scheduler 시작 (공유 메일함 응답 SLA 평가 + 에스컬레이션)
+	if w.slaScheduler != nil {
+		w.slaScheduler.Start()
+		w.zlog.Info().Msg("Started SLA Scheduler")
+	}
+
+	// History Backfill Scheduler 시작 (요청한 과거 메일을 저우선순위로 가져옴)
+	if w.historyBackfill != nil {
+		w.historyBackfill.Start()
+		w.zlog.Info().Msg("Started History Backfill Scheduler")
+	}
+
+	// Sync Lag Monitor 시작 (Provider ↔ DB 지연 SLO, 초과 시 GapSync)
+	if w.syncLagMonitor != nil {
+		w.syncLagMonitor.Start()
+		w.zlog.Info().Msg("Started Sync Lag Monitor")
+	}
+
+	// Storage Usage Scheduler 시작 (연결별 용량 측정, 상한 초과 시 metadata-only)
+	if w.storageScheduler != nil {
+		w.storageScheduler.Start()
+		w.zlog.Info().Msg("Started Storage Usage Scheduler")
+	}
+
+	// Mailbox Stats Scheduler 시작 (메일함 대시보드 증분 집계)
+	if w.statsScheduler != nil {
+		w.statsScheduler.Start()
+		w.zlog.Info().Msg("Started Mailbox Stats Scheduler")
+	}
+
+	// Outbox Scheduler 시작 (일시적 발송 실패 메일 백오프 재시도)
+	if w.outboxScheduler != nil {
+		w.outboxScheduler.Start()
+		w.zlog.Info().Msg("Started Outbox Scheduler")
+	}
+
+	// Email Purge Scheduler 시작 (복원 기간이 지난 삭제 메일 영구 삭제)
+	if w.purgeScheduler != nil {
+		w.purgeScheduler.Start()
+		w.zlog.Info().Msg("Started Email Purge Scheduler")
+	}
+
+	// Email Archive Scheduler 시작 (대용량 메일함의 오래된 메일을 아카이브 파티션으로 이동)
+	if w.archiveScheduler != nil {
+		w.archiveScheduler.Start()
+		w.zlog.Info().Msg("Started Email Archive Scheduler")
+	}
+
 	// Block until context is cancelled
 	<-w.ctx.Done()
 }
@@ -266,6 +468,39 @@ func (w *Worker) Stop() {
 	if w.gapSyncScheduler != nil {
 		w.gapSyncScheduler.Stop()
 	}
+	if w.backfillScheduler != nil {
+		w.backfillScheduler.Stop()
+	}
+	if w.historyBackfill != nil {
+		w.historyBackfill.Stop()
+	}
+	if w.briefingScheduler != nil {
+		w.briefingScheduler.Stop()
+	}
+	if w.heldNotifyScheduler != nil {
+		w.heldNotifyScheduler.Stop()
+	}
+	if w.slaScheduler != nil {
+		w.slaScheduler.Stop()
+	}
+	if w.syncLagMonitor != nil {
+		w.syncLagMonitor.Stop()
+	}
+	if w.storageScheduler != nil {
+		w.storageScheduler.Stop()
+	}
+	if w.statsScheduler != nil {
+		w.statsScheduler.Stop()
+	}
+	if w.outboxScheduler != nil {
+		w.outboxScheduler.Stop()
+	}
+	if w.purgeScheduler != nil {
+		w.purgeScheduler.Stop()
+	}
+	if w.archiveScheduler != nil {
+		w.archiveScheduler.Stop()
+	}
 
 	w.pool.Stop()
 	w.wg.Wait()
@@ -2,15 +2,19 @@ package bootstrap
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
+	"worker_server/adapter/out/enrichment"
 	"worker_server/adapter/out/graph"
 	"worker_server/adapter/out/messaging"
 	"worker_server/adapter/out/mongodb"
 	"worker_server/adapter/out/persistence"
 	"worker_server/adapter/out/provider"
 	"worker_server/adapter/out/realtime"
+	"worker_server/adapter/out/reputation"
+	"worker_server/adapter/out/vector"
 	"worker_server/config"
 	"worker_server/core/agent"
 	"worker_server/core/agent/llm"
@@ -21,18 +25,42 @@ import (
 	"worker_server/core/port/out"
 	"worker_server/core/service"
 	"worker_server/core/service/ai"
+	"worker_server/core/service/alias"
+	"worker_server/core/service/analytics"
 	"worker_server/core/service/auth"
 	"worker_server/core/service/calendar"
+	"worker_server/core/service/category"
 	"worker_server/core/service/classification"
 	"worker_server/core/service/common"
+	"worker_server/core/service/briefing"
 	"worker_server/core/service/contact"
 	imageservice "worker_server/core/service/image"
+	"worker_server/core/service/imageproxy"
 	"worker_server/core/service/email"
+	"worker_server/core/service/job"
+	"worker_server/core/service/nlfilter"
+	"worker_server/core/service/palette"
+	"worker_server/core/service/storage"
+	"worker_server/core/service/synclag"
+	"worker_server/core/service/usage"
 	"worker_server/core/service/notification"
 	"worker_server/core/service/report"
+	"worker_server/core/service/filelink"
+	"worker_server/core/service/folder"
+	"worker_server/core/service/safelink"
+	"worker_server/core/service/search"
+	"worker_server/core/service/signature"
+	"worker_server/core/service/share"
+	"worker_server/core/service/team"
+	"worker_server/core/service/tracking"
+	"worker_server/core/service/upload"
+	"worker_server/core/service/vacation"
+	"worker_server/core/service/workflow"
 	"worker_server/infra/database"
+	"worker_server/pkg/lock"
 	"worker_server/pkg/logger"
 	"worker_server/pkg/metrics"
+	"worker_server/pkg/ratelimit"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib" // pgx driver for database/sql
@@ -48,23 +76,32 @@ type Dependencies struct {
 	Config  *config.Config
 	DB      *pgxpool.Pool
 	SQLDB   *sqlx.DB
+	Replica *persistence.ReadReplica // 목록/검색/통계 조회용 (nil이면 primary만)
 	Redis   *redis.Client
 	MongoDB *mongo.Client
 	Neo4j   neo4j.DriverWithContext
 
+	// Hot reload (rate limit, cache TTL, sync window, feature flags)
+	ConfigWatcher *config.Watcher
+
 	// Repositories
 	MailRepo           out.EmailRepository
 	AttachmentRepo     out.AttachmentRepository
 	OAuthRepo          out.OAuthRepository
 	CalendarRepo       out.CalendarRepository
 	CalendarSyncRepo   out.CalendarSyncRepository
+	TodoRepo           out.TodoRepository
 	MailBodyRepo       out.EmailBodyRepository
+	AttachmentBlobs    out.AttachmentBlobStore
 	SyncStateRepo      out.SyncStateRepository
 	ContactRepo        *persistence.ContactAdapter
 	LabelRepo          *persistence.LabelAdapter
 	SettingsRepo       *persistence.SettingsAdapter
 	SettingsDomainRepo *persistence.SettingsDomainWrapper
 	NotificationRepo   *persistence.NotificationAdapter
+	NotifySettingsRepo *persistence.NotificationSettingsAdapter
+	PushDeviceRepo     *persistence.PushDeviceAdapter
+	HeldNotifyRepo     *persistence.HeldNotificationAdapter
 	WebhookRepo        *persistence.WebhookAdapter
 	ShortcutRepo       *persistence.ShortcutAdapter
 	TemplateRepo       *persistence.TemplateAdapter
@@ -72,6 +109,41 @@ type Dependencies struct {
 	SmartFolderRepo    *persistence.SmartFolderAdapter
 	SenderProfileRepo  *persistence.SenderProfileAdapter
 	KnownDomainRepo    *persistence.KnownDomainAdapter
+	MailImportRepo     *persistence.MailImportAdapter
+	CampaignRepo       *persistence.CampaignAdapter
+	InboxTriageRepo    *persistence.InboxTriageAdapter
+	DeliveryStatusRepo *persistence.DeliveryStatusAdapter
+	EmailSecurityRepo  *persistence.EmailSecurityAdapter
+	EmailDeadlineRepo  *persistence.EmailDeadlineAdapter
+	EmailNoteRepo      *persistence.EmailNoteAdapter
+	EmailPinRepo       *persistence.EmailPinAdapter
+	ReadLaterRepo      *persistence.EmailReadLaterAdapter
+	ThreadMuteRepo     *persistence.ThreadMuteAdapter
+	UserTierRepo       *persistence.UserTierAdapter
+	SyncLagRepo        *persistence.SyncLagAdapter
+	ConnectionStorageRepo *persistence.ConnectionStorageAdapter
+	OutboxRepo         *persistence.OutboxAdapter
+	CalendarInviteRepo *persistence.CalendarInviteAdapter
+	EmailActivityRepo  *persistence.EmailActivityAdapter
+	EmailChangeRepo    *persistence.EmailChangeAdapter
+	EmailArchiveRepo   *persistence.EmailArchiveAdapter
+	EmailShareRepo     *persistence.EmailShareAdapter
+	TeamRepo           *persistence.TeamAdapter
+	EmailCommentRepo   *persistence.EmailCommentAdapter
+	EmailAssignRepo    *persistence.EmailAssignmentAdapter
+	SLARepo            *persistence.SLAAdapter
+	MailboxStatsRepo   *persistence.MailboxStatsAdapter
+	LinkClickRepo      *persistence.LinkClickAdapter
+	VacationRepo       *persistence.VacationAdapter
+	BackfillRepo       *persistence.BackfillAdapter
+	HistoryBackfillRepo *persistence.HistoryBackfillAdapter
+	SendTrackingRepo   *persistence.SendTrackingAdapter
+	JobRepo            *persistence.JobAdapter
+	AIAttemptRepo      *persistence.ClassifyAttemptAdapter
+	UserCategoryRepo   *persistence.UserCategoryAdapter
+	AIUsageRepo        *persistence.AIUsageAdapter
+	BriefingRepo       *persistence.BriefingAdapter
+	WorkflowRepo       *persistence.WorkflowAdapter
 
 	// Neo4j Adapters (Personalization)
 	PersonalizationRepo out.ExtendedPersonalizationStore
@@ -95,6 +167,9 @@ type Dependencies struct {
 	// Services
 	EmailService            *mail.Service
 	MailSyncService        *mail.SyncService
+	ImportService          *mail.ImportService
+	CampaignService        *mail.CampaignService
+	TriageService          *mail.TriageService
 	OAuthService           *auth.OAuthService
 	CalendarService        *calendar.Service
 	CalendarSyncService    *calendar.SyncService
@@ -106,6 +181,28 @@ type Dependencies struct {
 	ReportService          *report.Service
 	TemplateService        *service.TemplateService
 	ClassificationPipeline *classification.Pipeline
+	SafeLinkService        *safelink.Service
+	TrackingService        *tracking.Service
+	ShareService           *share.Service
+	FileLinkService        *filelink.Service
+	ImageProxyService      *imageproxy.Service
+	UploadService          *upload.Service
+	SignatureService       *signature.Service
+	VacationService        *vacation.Service
+	AliasService           *alias.Service
+	FolderService          *folder.Service
+	ConnectionHealth       *auth.ConnectionHealthService
+	JobService             *job.Service
+	UsageService           *usage.Service
+	BriefingService        *briefing.Service
+	WorkflowService        *workflow.Service
+	TeamService            *team.Service
+	AnalyticsService       *analytics.Service
+	CategoryService        *category.Service
+	NLFilterParser         *nlfilter.Parser
+	PaletteService         *palette.Service
+	SyncLagService         *synclag.Service
+	StorageService         *storage.Service
 
 	// Agent
 	LLMClient     *llm.Client
@@ -120,31 +217,35 @@ type Dependencies struct {
 	ImageService *imageservice.Service
 
 	// RAG Components (for unified search)
-	VectorStore *rag.VectorStore
-	Embedder    *rag.Embedder
+	VectorStore   out.VectorStorePort
+	Embedder      *rag.Embedder
+	SearchService *search.Service // POST /ask 근거 메일 검색
 }
 
 func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 	deps := &Dependencies{Config: cfg}
 	var cleanups []func()
 
+	// Per-call deadline for heavy repository reads
+	persistence.SetQueryTimeout(time.Duration(cfg.DBQueryTimeoutMS) * time.Millisecond)
+	statementTimeout := time.Duration(cfg.DBStatementTimeoutMS) * time.Millisecond
+
 	// Database (pgxpool)
-	db, err := database.NewPostgres(cfg.DatabaseURL)
+	pgConfig := database.DefaultPostgresConfig()
+	pgConfig.MaxConns = int32(cfg.DBMaxConns)
+	pgConfig.StatementTimeout = statementTimeout
+	db, err := database.NewPostgresWithConfig(cfg.DatabaseURL, pgConfig)
 	if err != nil {
 		return nil, nil, err
 	}
 	deps.DB = db
 	cleanups = append(cleanups, func() { db.Close() })
+	metrics.RegisterPoolStats("postgres_pgx", func() metrics.DBPoolStats { return database.PoolMetrics(db) })
 
 	// Database (sqlx for adapters that need it)
 	// Add statement_cache_mode=describe to avoid prepared statement conflicts with PgBouncer
 	logger.Debug("Connecting to database via sqlx...")
-	sqlxURL := cfg.DatabaseURL
-	if strings.Contains(sqlxURL, "?") {
-		sqlxURL += "&default_query_exec_mode=simple_protocol"
-	} else {
-		sqlxURL += "?default_query_exec_mode=simple_protocol"
-	}
+	sqlxURL := withSQLXParams(cfg.DatabaseURL, statementTimeout)
 	sqlDB, err := sqlx.Connect("pgx", sqlxURL)
 	if err != nil {
 		logger.Error("sqlx connection failed: %v", err)
@@ -152,8 +253,8 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 	} else {
 		// Optimize connection pool settings for production
 		// Based on typical backend workload patterns
-		sqlDB.SetMaxOpenConns(25)                  // Max concurrent connections
-		sqlDB.SetMaxIdleConns(10)                  // Keep idle connections ready
+		sqlDB.SetMaxOpenConns(cfg.DBMaxConns)      // Max concurrent connections
+		sqlDB.SetMaxIdleConns(cfg.DBMaxIdleConns)  // Keep idle connections ready
 		sqlDB.SetConnMaxLifetime(30 * time.Minute) // Recycle connections periodically
 		sqlDB.SetConnMaxIdleTime(5 * time.Minute)  // Close idle connections after 5min
 
@@ -163,32 +264,68 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 		// Register with global pool monitor
 		metrics.RegisterPool("postgres", sqlDB.DB)
 
-		logger.Info("sqlx database connection successful (pool: max=%d, idle=%d)", 25, 10)
+		logger.Info("sqlx database connection successful (pool: max=%d, idle=%d)", cfg.DBMaxConns, cfg.DBMaxIdleConns)
+	}
+
+	// Read replica (선택 - 실패하면 primary만 사용)
+	if deps.SQLDB != nil && cfg.DatabaseReplicaURL != "" {
+		replicaURL := withSQLXParams(cfg.DatabaseReplicaURL, statementTimeout)
+		replicaDB, err := sqlx.Connect("pgx", replicaURL)
+		if err != nil {
+			logger.Warn("Read replica connection failed, reading from primary: %v", err)
+		} else {
+			replicaDB.SetMaxOpenConns(cfg.DBMaxConns)
+			replicaDB.SetMaxIdleConns(cfg.DBMaxIdleConns)
+			replicaDB.SetConnMaxLifetime(30 * time.Minute)
+			replicaDB.SetConnMaxIdleTime(5 * time.Minute)
+			cleanups = append(cleanups, func() { replicaDB.Close() })
+			metrics.RegisterPool("postgres_replica", replicaDB.DB)
+
+			deps.Replica = persistence.NewReadReplica(deps.SQLDB, replicaDB, &persistence.ReplicaConfig{
+				MaxLag:        time.Duration(cfg.ReplicaMaxLagSeconds) * time.Second,
+				CheckInterval: time.Duration(cfg.ReplicaCheckIntervalS) * time.Second,
+				CheckTimeout:  2 * time.Second,
+			})
+			logger.Info("Read replica connected (max lag: %ds)", cfg.ReplicaMaxLagSeconds)
+		}
 	}
 
 	// Redis
-	redisClient, err := database.NewRedis(cfg.RedisURL)
+	redisConfig := database.DefaultRedisConfig()
+	redisConfig.PoolSize = cfg.RedisPoolSize
+	redisConfig.MinIdleConns = cfg.RedisMinIdleConns
+	redisConfig.ReadTimeout = time.Duration(cfg.RedisTimeoutMS) * time.Millisecond
+	redisConfig.WriteTimeout = redisConfig.ReadTimeout
+	redisConfig.PoolTimeout = time.Duration(cfg.RedisPoolTimeoutMS) * time.Millisecond
+	redisClient, err := database.NewRedisWithConfig(cfg.RedisURL, redisConfig)
 	if err != nil {
 		logger.Warn("Redis connection failed: %v", err)
 	} else {
 		deps.Redis = redisClient
 		cleanups = append(cleanups, func() { redisClient.Close() })
-
-		// Initialize Cache Service (L2 - Redis) - other deps added later
-		deps.CacheService = common.NewCacheService(
-			redisClient,
-			nil, // mongoRepo - added after MongoDB init
-			nil, // emailRepo - added after repo init
-			nil, // attachmentRepo - added after repo init (Phase 3: lazy 복구)
-			nil, // provider - added after provider init
-			nil, // oauthService - added after service init
-			nil, // emailFetcher - added via SetEmailFetcher
-			common.DefaultCacheConfig(),
-		)
-		logger.Info("CacheService (L2 Redis) initialized")
+		metrics.RegisterPoolStats("redis", func() metrics.DBPoolStats { return database.RedisPoolMetrics(redisClient) })
 		// Note: HybridCache removed - EmailListCache in EmailHandler handles L1+L2 with optimistic updates
 	}
 
+	// Initialize Cache Service (Redis는 선택 - 없으면 MongoDB → Provider) - other deps added later
+	cacheConfig := common.DefaultCacheConfig()
+	cacheConfig.MaxBodySize = cfg.BodyCacheMaxBodyKB * 1024
+	cacheConfig.MaxConnectionCacheSize = int64(cfg.BodyCacheConnectionMaxMB) * 1024 * 1024
+	cacheConfig.ArchiveBodyTTLDays = cfg.BodyCacheArchiveTTLDays
+	cacheConfig.PrefetchInboxSize = cfg.BodyCachePrefetchSize
+	cacheConfig.MetadataOnlyBodyTTL = time.Duration(cfg.BodyCacheMetadataOnlyTTLMin) * time.Minute
+	deps.CacheService = common.NewCacheService(
+		deps.Redis,
+		nil, // mongoRepo - added after MongoDB init
+		nil, // emailRepo - added after repo init
+		nil, // attachmentRepo - added after repo init (Phase 3: lazy 복구)
+		nil, // provider - added after provider init
+		nil, // oauthService - added after service init
+		nil, // emailFetcher - added via SetEmailFetcher
+		cacheConfig,
+	)
+	logger.Info("CacheService initialized (redis=%v)", deps.Redis != nil)
+
 	// MongoDB
 	if cfg.MongoDBURL != "" {
 		mongoClient, err := mongodb.NewClient(cfg.MongoDBURL, cfg.MongoDBName)
@@ -202,18 +339,33 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 
 			// Mail Body Repository (MongoDB)
 			mongoDB := mongoClient.Database(cfg.MongoDBName)
-			deps.MailBodyRepo = mongodb.NewMailBodyAdapter(mongoDB)
+			mailBodyAdapter := mongodb.NewMailBodyAdapter(mongoDB)
+			if err := mailBodyAdapter.EnsureIndexes(context.Background()); err != nil {
+				logger.Warn("Failed to ensure mail body indexes: %v", err)
+			}
+			deps.MailBodyRepo = mailBodyAdapter
 
 			// CacheService에 MongoRepo 주입
 			if deps.CacheService != nil {
 				deps.CacheService.SetMongoRepo(deps.MailBodyRepo)
 			}
+
+			// 대용량 첨부 링크 저장소 (GridFS)
+			blobAdapter := mongodb.NewAttachmentBlobAdapter(mongoDB)
+			if err := blobAdapter.EnsureIndexes(context.Background()); err != nil {
+				logger.Warn("Failed to ensure attachment blob indexes: %v", err)
+			}
+			deps.AttachmentBlobs = blobAdapter
 		}
 	}
 
 	// Neo4j (Personalization - 스타일, 톤, 선호도)
 	if cfg.Neo4jURL != "" {
-		neo4jDriver, err := graph.NewDriver(cfg.Neo4jURL, cfg.Neo4jUsername, cfg.Neo4jPassword)
+		neo4jDriver, err := graph.NewDriverWithConfig(cfg.Neo4jURL, cfg.Neo4jUsername, cfg.Neo4jPassword, &graph.DriverConfig{
+			MaxPoolSize:    cfg.Neo4jMaxPoolSize,
+			AcquireTimeout: time.Duration(cfg.Neo4jAcquireTimeoutMS) * time.Millisecond,
+			TxTimeout:      time.Duration(cfg.Neo4jQueryTimeoutMS) * time.Millisecond,
+		})
 		if err != nil {
 			logger.Warn("Neo4j connection failed: %v", err)
 		} else {
@@ -223,7 +375,8 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 			})
 
 			// Personalization Repository (Neo4j)
-			personalizationAdapter := graph.NewPersonalizationAdapter(neo4jDriver, "neo4j")
+			// 장애 시 빠르게 실패하고 조회는 마지막 결과로 대체
+			personalizationAdapter := graph.NewPersonalizationAdapter(graph.WithCircuitBreaker(neo4jDriver, "neo4j-personalization"), "neo4j")
 			deps.PersonalizationRepo = personalizationAdapter
 
 			// Ensure indexes
@@ -239,22 +392,29 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 
 	// Message Producer (Redis Streams)
 	if deps.Redis != nil {
-		deps.MessageProducer = messaging.NewRedisProducer(deps.Redis)
+		producer := messaging.NewRedisProducer(deps.Redis)
+		producer.SetSyncPartitions(cfg.SyncPartitions)
+		deps.MessageProducer = producer
 	}
 
 	// Repositories
 	if deps.SQLDB != nil {
-		deps.MailRepo = persistence.NewMailAdapter(deps.SQLDB)
+		mailAdapter := persistence.NewMailAdapter(deps.SQLDB)
+		deps.MailRepo = mailAdapter
 		deps.AttachmentRepo = persistence.NewAttachmentAdapter(deps.SQLDB)
 		deps.OAuthRepo = persistence.NewOAuthAdapter(deps.SQLDB)
 		deps.CalendarRepo = persistence.NewCalendarAdapter(deps.SQLDB)
 		deps.CalendarSyncRepo = persistence.NewCalendarSyncAdapter(deps.SQLDB)
+		deps.TodoRepo = persistence.NewTodoRepository(deps.SQLDB)
 		deps.SyncStateRepo = persistence.NewSyncStateAdapter(deps.SQLDB)
 		deps.ContactRepo = persistence.NewContactAdapter(deps.SQLDB)
 		deps.LabelRepo = persistence.NewLabelAdapter(deps.SQLDB)
 		deps.SettingsRepo = persistence.NewSettingsAdapter(deps.SQLDB)
 		deps.SettingsDomainRepo = persistence.NewSettingsDomainWrapper(deps.SettingsRepo)
 		deps.NotificationRepo = persistence.NewNotificationAdapter(deps.SQLDB)
+		deps.NotifySettingsRepo = persistence.NewNotificationSettingsAdapter(deps.SQLDB)
+		deps.PushDeviceRepo = persistence.NewPushDeviceAdapter(deps.SQLDB)
+		deps.HeldNotifyRepo = persistence.NewHeldNotificationAdapter(deps.SQLDB)
 		deps.WebhookRepo = persistence.NewWebhookAdapter(deps.SQLDB)
 		deps.ShortcutRepo = persistence.NewShortcutAdapter(deps.SQLDB)
 		deps.TemplateRepo = persistence.NewTemplateAdapter(deps.SQLDB)
@@ -262,8 +422,49 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 		deps.SmartFolderRepo = persistence.NewSmartFolderAdapter(deps.SQLDB)
 		deps.SenderProfileRepo = persistence.NewSenderProfileAdapter(deps.SQLDB)
 		deps.KnownDomainRepo = persistence.NewKnownDomainAdapter(deps.SQLDB)
+		deps.MailImportRepo = persistence.NewMailImportAdapter(deps.SQLDB)
+		deps.CampaignRepo = persistence.NewCampaignAdapter(deps.SQLDB)
+		deps.InboxTriageRepo = persistence.NewInboxTriageAdapter(deps.SQLDB)
+		deps.DeliveryStatusRepo = persistence.NewDeliveryStatusAdapter(deps.SQLDB)
+		deps.EmailSecurityRepo = persistence.NewEmailSecurityAdapter(deps.SQLDB)
+		deps.EmailDeadlineRepo = persistence.NewEmailDeadlineAdapter(deps.SQLDB)
+		deps.EmailNoteRepo = persistence.NewEmailNoteAdapter(deps.SQLDB)
+		deps.EmailPinRepo = persistence.NewEmailPinAdapter(deps.SQLDB)
+		deps.ReadLaterRepo = persistence.NewEmailReadLaterAdapter(deps.SQLDB)
+		deps.ThreadMuteRepo = persistence.NewThreadMuteAdapter(deps.SQLDB)
+		deps.UserTierRepo = persistence.NewUserTierAdapter(deps.SQLDB)
+		deps.SyncLagRepo = persistence.NewSyncLagAdapter(deps.SQLDB)
+		deps.ConnectionStorageRepo = persistence.NewConnectionStorageAdapter(deps.SQLDB)
+		deps.OutboxRepo = persistence.NewOutboxAdapter(deps.SQLDB)
+		deps.CalendarInviteRepo = persistence.NewCalendarInviteAdapter(deps.SQLDB)
+		deps.EmailActivityRepo = persistence.NewEmailActivityAdapter(deps.SQLDB)
+		deps.EmailChangeRepo = persistence.NewEmailChangeAdapter(deps.SQLDB)
+		deps.EmailArchiveRepo = persistence.NewEmailArchiveAdapter(deps.SQLDB)
+		deps.EmailShareRepo = persistence.NewEmailShareAdapter(deps.SQLDB)
+		deps.TeamRepo = persistence.NewTeamAdapter(deps.SQLDB)
+		deps.EmailCommentRepo = persistence.NewEmailCommentAdapter(deps.SQLDB)
+		deps.EmailAssignRepo = persistence.NewEmailAssignmentAdapter(deps.SQLDB)
+		deps.SLARepo = persistence.NewSLAAdapter(deps.SQLDB)
+		deps.MailboxStatsRepo = persistence.NewMailboxStatsAdapter(deps.SQLDB)
+		deps.LinkClickRepo = persistence.NewLinkClickAdapter(deps.SQLDB)
+		deps.VacationRepo = persistence.NewVacationAdapter(deps.SQLDB)
+		deps.BackfillRepo = persistence.NewBackfillAdapter(deps.SQLDB)
+		deps.HistoryBackfillRepo = persistence.NewHistoryBackfillAdapter(deps.SQLDB)
+		deps.SendTrackingRepo = persistence.NewSendTrackingAdapter(deps.SQLDB)
+		deps.JobRepo = persistence.NewJobAdapter(deps.SQLDB)
+		deps.AIAttemptRepo = persistence.NewClassifyAttemptAdapter(deps.SQLDB)
+		deps.UserCategoryRepo = persistence.NewUserCategoryAdapter(deps.SQLDB)
+		deps.AIUsageRepo = persistence.NewAIUsageAdapter(deps.SQLDB)
+		deps.BriefingRepo = persistence.NewBriefingAdapter(deps.SQLDB)
+		deps.WorkflowRepo = persistence.NewWorkflowAdapter(deps.SQLDB)
 		logger.Info("Folder, SmartFolder, SenderProfile, KnownDomain repositories initialized")
 
+		// 무거운 읽기(목록/검색/통계)만 replica로, 쓰기와 동기화는 primary
+		if deps.Replica != nil {
+			mailAdapter.SetReadReplica(deps.Replica)
+			deps.MailboxStatsRepo.SetReadReplica(deps.Replica)
+		}
+
 		// CacheService에 필요한 Repository 주입
 		if deps.CacheService != nil {
 			deps.CacheService.SetAttachmentRepo(deps.AttachmentRepo)
@@ -327,6 +528,13 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 			Temperature: cfg.LLMTemperature,
 		})
 
+		// AI 사용량 집계 + 월 예산 (초과 시 요약/임베딩 중단)
+		if deps.AIUsageRepo != nil {
+			deps.UsageService = usage.NewService(deps.AIUsageRepo, cfg.AIMonthlyBudgetUSD)
+			deps.LLMClient.SetUsageRecorder(deps.UsageService)
+			logger.Info("AI usage accounting initialized (monthly budget: $%.2f, 0 = unlimited)", cfg.AIMonthlyBudgetUSD)
+		}
+
 		// Image Client (DALL-E)
 		deps.ImageClient = llm.NewImageClient(cfg.OpenAIAPIKey)
 		logger.Info("Image Client (DALL-E) initialized")
@@ -335,30 +543,67 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 		deps.ImageService = imageservice.NewService(deps.ImageClient, nil, nil)
 		logger.Info("Image Service initialized")
 
-		// Initialize Classification Pipeline with LLM client
-		if deps.KnownDomainRepo != nil && deps.SenderProfileRepo != nil {
-			deps.ClassificationPipeline = classification.NewPipeline(
-				deps.KnownDomainRepo,
-				deps.SenderProfileRepo,
-				deps.SettingsDomainRepo,
-				deps.LLMClient,
-			)
+	}
+
+	// Category Service (사용자 카테고리 체계 - custom / 이름 변경 / 숨김)
+	if deps.UserCategoryRepo != nil {
+		deps.CategoryService = category.NewService(deps.UserCategoryRepo)
+	}
+
+	// 자연어 목록 필터 (GET /email?nl=) - LLM이 없으면 규칙만
+	deps.NLFilterParser = nlfilter.NewParser(deps.LLMClient)
+
+	// Classification Pipeline (heuristic 모드에서는 LLM 단계 없이 RFC 헤더 + 규칙만 사용)
+	if deps.KnownDomainRepo != nil && deps.SenderProfileRepo != nil {
+		var classifyLLM *llm.Client
+		if !cfg.HeuristicClassification() {
+			classifyLLM = deps.LLMClient
+		}
+		deps.ClassificationPipeline = classification.NewPipeline(
+			deps.KnownDomainRepo,
+			deps.SenderProfileRepo,
+			deps.SettingsDomainRepo,
+			classifyLLM,
+		)
+		if deps.CategoryService != nil {
+			deps.ClassificationPipeline.SetTaxonomyProvider(deps.CategoryService)
+		}
+		if classifyLLM != nil {
 			logger.Info("Classification Pipeline initialized (UserRules -> Header -> Domain -> LLM)")
+		} else {
+			logger.Info("Classification Pipeline initialized in heuristic mode (Header -> Domain -> Subject -> UserRules, no LLM)")
 		}
 	}
 
 	// RAG Components
 	if deps.LLMClient != nil {
 		deps.Embedder = rag.NewEmbedder(deps.LLMClient)
-		deps.VectorStore = rag.NewVectorStore(db)
+		deps.Embedder.SetBatchSize(cfg.RAGEmbedBatchSize)
+		deps.Embedder.SetRateLimit(cfg.RAGEmbedRPM)
+		vectorStore, err := NewVectorStore(cfg, cfg.VectorStore, db)
+		if err != nil {
+			// 잘못된 VECTOR_STORE로 다른 저장소에 기록하지 않도록 시작을 중단한다
+			for i := len(cleanups) - 1; i >= 0; i-- {
+				cleanups[i]()
+			}
+			return nil, nil, err
+		}
+		// 장애 시 빠르게 실패하고 검색은 마지막 결과로 대체
+		deps.VectorStore = vector.NewBreakerStore("vector-store", vectorStore)
+		logger.Info("Vector store: %s", cfg.VectorStore)
 		deps.RAGRetriever = rag.NewRetriever(deps.Embedder, deps.VectorStore)
 		deps.RAGIndexer = rag.NewIndexerService(deps.Embedder, deps.VectorStore)
+		deps.RAGIndexer.SetMinIndexChars(cfg.RAGMinIndexChars)
 
 		// StyleAnalyzer with Neo4j PersonalizationStore
 		if deps.PersonalizationRepo != nil {
 			deps.StyleAnalyzer = rag.NewStyleAnalyzer(deps.Embedder, deps.PersonalizationRepo, deps.VectorStore)
 			logger.Info("StyleAnalyzer initialized with Neo4j PersonalizationStore")
 		}
+
+		if deps.MailRepo != nil {
+			deps.SearchService = search.NewService(deps.MailRepo, deps.VectorStore, deps.Embedder)
+		}
 	}
 
 	// Agent Service with Tools
@@ -526,6 +771,57 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 				deps.OAuthService,
 				deps.MessageProducer, // async provider sync
 			)
+			if deps.EmailSecurityRepo != nil {
+				deps.EmailService.SetSecurityRepository(deps.EmailSecurityRepo)
+			}
+			if deps.EmailDeadlineRepo != nil {
+				deps.EmailService.SetDeadlineRepository(deps.EmailDeadlineRepo)
+			}
+			if deps.EmailNoteRepo != nil {
+				deps.EmailService.SetNoteRepository(deps.EmailNoteRepo)
+			}
+			if deps.EmailPinRepo != nil {
+				deps.EmailService.SetPinRepository(deps.EmailPinRepo)
+			}
+			if deps.ReadLaterRepo != nil {
+				deps.EmailService.SetReadLaterRepository(deps.ReadLaterRepo)
+			}
+			if deps.ThreadMuteRepo != nil {
+				deps.EmailService.SetThreadMuteRepository(deps.ThreadMuteRepo)
+			}
+			if deps.OutboxRepo != nil {
+				deps.EmailService.SetOutboxRepository(deps.OutboxRepo)
+			}
+			deps.EmailService.SetRealtime(deps.RealtimeAdapter)
+			if deps.CalendarInviteRepo != nil && deps.AttachmentRepo != nil {
+				deps.EmailService.SetCalendarInviteRepository(deps.CalendarInviteRepo)
+				deps.EmailService.SetAttachmentRepository(deps.AttachmentRepo)
+			}
+			if deps.EmailActivityRepo != nil {
+				deps.EmailService.SetActivityRepository(deps.EmailActivityRepo)
+			}
+			if deps.EmailChangeRepo != nil {
+				deps.EmailService.SetChangeRepository(deps.EmailChangeRepo)
+			}
+			if deps.EmailArchiveRepo != nil {
+				deps.EmailService.SetArchiveRepository(deps.EmailArchiveRepo, deps.MailBodyRepo, mail.ArchivePolicy{
+					MinEmails:   cfg.EmailArchiveMinEmails,
+					AfterMonths: cfg.EmailArchiveAfterMonths,
+				})
+			}
+			if deps.Orchestrator != nil {
+				deps.Orchestrator.SetEmailService(deps.EmailService)
+			}
+			if deps.LLMClient != nil {
+				deps.EmailService.SetTranslator(deps.LLMClient)
+				deps.EmailService.SetSpeechSynthesizer(deps.LLMClient, deps.AttachmentBlobs)
+			}
+			if deps.SLARepo != nil {
+				deps.EmailService.SetSLARepository(deps.SLARepo)
+			}
+			if deps.DeliveryStatusRepo != nil {
+				deps.EmailService.SetDeliveryStatusRepository(deps.DeliveryStatusRepo)
+			}
 			logger.Info("EmailService initialized with CacheService and LabelRepo")
 		} else {
 			deps.EmailService = mail.NewService(nil, nil)
@@ -534,6 +830,122 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 		deps.EmailService = mail.NewService(nil, nil)
 	}
 
+	// Upload Service (대용량 첨부파일 업로드 세션)
+	if deps.Redis != nil && deps.OAuthService != nil {
+		deps.UploadService = upload.NewService(deps.Redis, deps.OAuthService, cfg.PublicBaseURL)
+		if deps.GmailProvider != nil {
+			deps.UploadService.RegisterProvider("google", deps.GmailProvider)
+			deps.UploadService.RegisterProvider("gmail", deps.GmailProvider)
+		}
+		if deps.OutlookProvider != nil {
+			deps.UploadService.RegisterProvider("outlook", deps.OutlookProvider)
+			deps.UploadService.RegisterProvider("microsoft", deps.OutlookProvider)
+		}
+		if deps.RealtimeAdapter != nil {
+			deps.UploadService.SetRealtime(deps.RealtimeAdapter)
+		}
+		if deps.EmailService != nil {
+			deps.EmailService.SetUploadService(deps.UploadService)
+		}
+		logger.Info("UploadService initialized")
+	}
+
+	// Signature Service (Neo4j 서명 + 발송 시 삽입)
+	if deps.PersonalizationRepo != nil {
+		deps.SignatureService = signature.NewService(deps.PersonalizationRepo)
+		if deps.EmailService != nil {
+			deps.EmailService.SetSignatureService(deps.SignatureService)
+		}
+		logger.Info("SignatureService initialized")
+	}
+
+	// Vacation Service (부재중 응답 - Provider 설정 프록시 또는 동기화 중 자동 응답)
+	if deps.VacationRepo != nil && deps.OAuthService != nil {
+		deps.VacationService = vacation.NewService(deps.VacationRepo, deps.OAuthService)
+		if deps.GmailProvider != nil {
+			deps.VacationService.RegisterProvider("google", deps.GmailProvider)
+			deps.VacationService.RegisterProvider("gmail", deps.GmailProvider)
+		}
+		if deps.OutlookProvider != nil {
+			deps.VacationService.RegisterProvider("outlook", deps.OutlookProvider)
+			deps.VacationService.RegisterProvider("microsoft", deps.OutlookProvider)
+		}
+		logger.Info("VacationService initialized")
+	}
+
+	// Briefing Service (일일 브리핑 - 중요 메일, 할 일, 일정, 답장 대기)
+	if deps.BriefingRepo != nil && deps.MailRepo != nil {
+		deps.BriefingService = briefing.NewService(deps.BriefingRepo, deps.MailRepo)
+		deps.BriefingService.SetTodoRepository(deps.TodoRepo)
+		deps.BriefingService.SetCalendarRepository(deps.CalendarRepo)
+		if deps.OAuthService != nil {
+			deps.BriefingService.SetConnectionProvider(deps.OAuthService)
+			if deps.GmailProvider != nil {
+				deps.BriefingService.RegisterProvider("google", deps.GmailProvider)
+				deps.BriefingService.RegisterProvider("gmail", deps.GmailProvider)
+			}
+			if deps.OutlookProvider != nil {
+				deps.BriefingService.RegisterProvider("outlook", deps.OutlookProvider)
+				deps.BriefingService.RegisterProvider("microsoft", deps.OutlookProvider)
+			}
+		}
+		logger.Info("BriefingService initialized")
+	}
+
+	// Workflow Service (칸반 보드 - 사용자 정의 단계, 카드 순서)
+	if deps.WorkflowRepo != nil && deps.EmailService != nil {
+		deps.WorkflowService = workflow.NewService(deps.WorkflowRepo, deps.EmailService)
+		logger.Info("WorkflowService initialized")
+	}
+
+	// Alias Service (send-as 주소 조회 + from_alias 검증)
+	if deps.OAuthService != nil {
+		deps.AliasService = alias.NewService(deps.OAuthService)
+		if deps.GmailProvider != nil {
+			deps.AliasService.RegisterProvider("google", deps.GmailProvider)
+			deps.AliasService.RegisterProvider("gmail", deps.GmailProvider)
+		}
+		if deps.OutlookProvider != nil {
+			deps.AliasService.RegisterProvider("outlook", deps.OutlookProvider)
+			deps.AliasService.RegisterProvider("microsoft", deps.OutlookProvider)
+		}
+		if deps.EmailService != nil {
+			deps.EmailService.SetAliasService(deps.AliasService)
+		}
+		logger.Info("AliasService initialized")
+	}
+
+	// Folder Service (폴더 ↔ Gmail 라벨/Outlook 폴더 매핑, 양방향 동기화)
+	if deps.FolderRepo != nil && deps.OAuthService != nil {
+		deps.FolderService = folder.NewService(deps.FolderRepo, deps.OAuthService)
+		if deps.GmailProvider != nil {
+			deps.FolderService.RegisterProvider("google", deps.GmailProvider)
+		}
+		if deps.OutlookProvider != nil {
+			deps.FolderService.RegisterProvider("outlook", deps.OutlookProvider)
+		}
+		logger.Info("FolderService initialized")
+	}
+
+	// Job Service (sync/resync/reclassify 작업 상태 추적)
+	if deps.JobRepo != nil {
+		deps.JobService = job.NewService(deps.JobRepo)
+	}
+
+	// Connection Health (토큰/Watch/동기화 상태 점검 + 재연결 URL)
+	if deps.OAuthService != nil {
+		deps.ConnectionHealth = auth.NewConnectionHealthService(deps.OAuthService, deps.SyncStateRepo)
+		if deps.GmailProvider != nil {
+			deps.ConnectionHealth.RegisterProvider("google", deps.GmailProvider)
+			deps.ConnectionHealth.RegisterProvider("gmail", deps.GmailProvider)
+		}
+		if deps.OutlookProvider != nil {
+			deps.ConnectionHealth.RegisterProvider("outlook", deps.OutlookProvider)
+			deps.ConnectionHealth.RegisterProvider("microsoft", deps.OutlookProvider)
+		}
+		logger.Info("ConnectionHealthService initialized")
+	}
+
 	// Mail Sync Service (새로운 Pub/Sub 기반 동기화)
 	if deps.MailRepo != nil && deps.SyncStateRepo != nil && deps.GmailProvider != nil {
 		deps.MailSyncService = mail.NewSyncService(
@@ -546,9 +958,94 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 			deps.MessageProducer,
 			deps.RealtimeAdapter,
 		)
+		if deps.EmailSecurityRepo != nil {
+			deps.MailSyncService.SetSecurityRepository(deps.EmailSecurityRepo)
+		}
+		if deps.EmailDeadlineRepo != nil {
+			deps.MailSyncService.SetDeadlineRepository(deps.EmailDeadlineRepo)
+		}
+		if deps.ThreadMuteRepo != nil {
+			deps.MailSyncService.SetThreadMuteRepository(deps.ThreadMuteRepo)
+		}
+		if deps.EmailActivityRepo != nil {
+			deps.MailSyncService.SetActivityRepository(deps.EmailActivityRepo)
+		}
+		if deps.DeliveryStatusRepo != nil && deps.CampaignRepo != nil {
+			deps.MailSyncService.SetDeliveryTracking(deps.DeliveryStatusRepo, deps.CampaignRepo)
+		}
+		if deps.VacationService != nil {
+			deps.MailSyncService.SetVacationService(deps.VacationService)
+		}
+		if deps.BackfillRepo != nil {
+			deps.MailSyncService.SetBackfillRepository(deps.BackfillRepo)
+		}
+		if deps.HistoryBackfillRepo != nil {
+			deps.MailSyncService.SetHistoryBackfill(deps.HistoryBackfillRepo, cfg.HistoryBackfillMaxMessages, cfg.HistoryBackfillQuota)
+		}
+		if deps.Redis != nil {
+			// 여러 워커 인스턴스가 같은 연결을 동시에 동기화하지 않도록
+			deps.MailSyncService.SetLocker(lock.NewLocker(deps.Redis))
+		}
+		if deps.Redis != nil && deps.EmailService != nil {
+			// 동기화 배치 후 바뀐 목록 뷰만 지우고 첫 페이지를 미리 채운다 (L2는 API와 공유)
+			listCache := ratelimit.NewEmailListCache(deps.Redis, nil)
+			deps.MailSyncService.SetListCacheWarmer(mail.NewListCacheWarmer(deps.EmailService, listCache))
+		}
+		if deps.PersonalizationRepo != nil {
+			// VIP 연락처(Neo4j is_important) 메일은 delta sync에서 즉시 알림
+			deps.MailSyncService.SetVIPContacts(deps.PersonalizationRepo)
+		}
+		if deps.FolderService != nil {
+			// 초기 동기화 후 Provider 폴더를 가져온다
+			deps.MailSyncService.SetFolderSyncer(deps.FolderService)
+		}
 		logger.Info("MailSyncService initialized")
 	}
 
+	// Sync lag SLO monitor (Provider ↔ DB 지연 측정, 초과 시 GapSync)
+	if deps.MailSyncService != nil && deps.SyncLagRepo != nil {
+		deps.SyncLagService = synclag.NewService(
+			deps.SyncStateRepo,
+			deps.SyncLagRepo,
+			deps.MailSyncService,
+			time.Duration(cfg.SyncLagThresholdMin)*time.Minute,
+			0,
+		)
+	}
+
+	// Connection storage usage (연결별 용량 측정, 상한 초과 시 metadata-only)
+	if deps.ConnectionStorageRepo != nil && deps.OAuthService != nil {
+		deps.StorageService = storage.NewService(
+			deps.ConnectionStorageRepo,
+			deps.MailBodyRepo,
+			deps.OAuthService,
+			int64(cfg.StorageCapMB)*1024*1024,
+		)
+		if deps.MailSyncService != nil {
+			deps.MailSyncService.SetStorageGuard(deps.StorageService)
+		}
+		if deps.CacheService != nil {
+			deps.CacheService.SetStorageGuard(deps.StorageService)
+		}
+		if deps.SearchService != nil {
+			deps.SearchService.SetStorageGuard(deps.StorageService)
+		}
+		if deps.VectorStore != nil {
+			deps.StorageService.SetVectorStore(deps.VectorStore)
+		}
+	}
+
+	// Mail Import Service (MBOX/EML → local archive 연결)
+	if deps.MailRepo != nil && deps.MailImportRepo != nil {
+		deps.ImportService = mail.NewImportService(
+			deps.MailImportRepo,
+			deps.MailRepo,
+			deps.MailBodyRepo,
+			deps.OAuthService,
+			deps.MessageProducer,
+		)
+	}
+
 	// Calendar Service - with domain wrapper and provider support
 	if deps.CalendarRepo != nil {
 		calendarAdapter, ok := deps.CalendarRepo.(*persistence.CalendarAdapter)
@@ -596,8 +1093,28 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 	if deps.ContactRepo != nil {
 		contactDomainRepo := persistence.NewContactDomainWrapper(deps.ContactRepo)
 		deps.ContactService = contact.NewService(contactDomainRepo)
+		if deps.PersonalizationRepo != nil {
+			deps.ContactService.SetGraphStore(deps.PersonalizationRepo)
+		}
+
+		// 새 중요 연락처 프로필 보강 (Clearbit 스타일 API 우선, Gravatar로 빈 값 보충)
+		if cfg.ContactEnrichmentEnabled {
+			var enrichers []out.ContactEnricher
+			if cfg.PersonAPIURL != "" {
+				enrichers = append(enrichers, enrichment.NewPersonAPIAdapter(cfg.PersonAPIURL, cfg.PersonAPIKey))
+			}
+			enrichers = append(enrichers, enrichment.NewGravatarAdapter(cfg.GravatarAPIKey))
+			deps.ContactService.SetEnricher(enrichment.NewChainAdapter(enrichers...), deps.MessageProducer)
+			if deps.StyleAnalyzer != nil && deps.MessageProducer != nil {
+				deps.StyleAnalyzer.SetEnrichmentProducer(deps.MessageProducer)
+			}
+			logger.Info("Contact enrichment enabled (person api: %v)", cfg.PersonAPIURL != "")
+		}
 	}
 
+	// Command palette (GET /actions/search)
+	deps.PaletteService = newPaletteService(deps)
+
 	// Settings Service - using domain wrapper for type alignment
 	if deps.SettingsRepo != nil {
 		settingsDomainRepo := persistence.NewSettingsDomainWrapper(deps.SettingsRepo)
@@ -606,6 +1123,49 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 
 	// Notification Service
 	deps.NotificationService = notification.NewService(deps.NotificationRepo, nil) // SSE hub added later
+	if deps.NotifySettingsRepo != nil {
+		deps.NotificationService.SetSettingsRepository(deps.NotifySettingsRepo)
+	}
+	// 방해 금지 시간에는 보류했다가 끝난 뒤 요약으로 전달
+	if deps.HeldNotifyRepo != nil {
+		deps.NotificationService.SetHoldRepository(deps.HeldNotifyRepo)
+	}
+
+	// Mobile push (FCM/APNs) - 새 메일 delta sync 시 사용자 설정에 따라 발송
+	if senders := newPushSenders(cfg); len(senders) > 0 && deps.PushDeviceRepo != nil {
+		deps.NotificationService.SetPush(deps.PushDeviceRepo, senders...)
+		if deps.MailSyncService != nil {
+			deps.MailSyncService.SetNewMailNotifier(deps.NotificationService)
+		}
+	}
+
+	// Watch 갱신이 계속 실패하면 푸시 → 폴링 전환을 사용자에게 알림
+	if deps.MailSyncService != nil {
+		deps.MailSyncService.SetSystemNotifier(deps.NotificationService)
+	}
+
+	// Team Service (공유 메일함, 내부 댓글 + 멘션 알림)
+	if deps.TeamRepo != nil && deps.EmailCommentRepo != nil {
+		deps.TeamService = team.NewService(deps.TeamRepo, deps.EmailCommentRepo)
+		deps.TeamService.SetNotifier(deps.NotificationService)
+		deps.TeamService.SetRealtime(deps.RealtimeAdapter)
+		if deps.EmailAssignRepo != nil {
+			deps.TeamService.SetAssignmentRepository(deps.EmailAssignRepo)
+		}
+		if deps.SLARepo != nil {
+			deps.TeamService.SetSLARepository(deps.SLARepo)
+		}
+		logger.Info("TeamService initialized")
+	}
+
+	// Analytics Service (관계 그래프 분석, 메일함 대시보드)
+	deps.AnalyticsService = analytics.NewService()
+	if deps.PersonalizationRepo != nil {
+		deps.AnalyticsService.SetGraphStore(deps.PersonalizationRepo)
+	}
+	if deps.MailboxStatsRepo != nil {
+		deps.AnalyticsService.SetMailboxStatsRepository(deps.MailboxStatsRepo)
+	}
 
 	// Webhook Service
 	deps.WebhookService = notification.NewWebhookService(deps.WebhookRepo, deps.OAuthService, deps.GmailProvider)
@@ -629,9 +1189,92 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 	}
 	deps.AIService = ai.NewService(aiEmailRepo, nil, deps.LLMClient, deps.RAGRetriever, deps.RAGIndexer, nil)
 
+	if deps.SearchService != nil {
+		deps.AIService.SetSearchService(deps.SearchService)
+	}
+	if deps.EmailActivityRepo != nil {
+		deps.AIService.SetActivityRepository(deps.EmailActivityRepo)
+	}
+
 	// Connect Classification Pipeline to AI Service (4-stage classification)
 	if deps.ClassificationPipeline != nil {
 		deps.AIService.SetClassificationPipeline(deps.ClassificationPipeline)
+		if deps.EmailSecurityRepo != nil {
+			deps.AIService.SetSecurityRepository(deps.EmailSecurityRepo)
+		}
+	}
+
+	// Safe Link Service (링크 재작성 + 평판 조회 + 클릭 분석)
+	if cfg.LinkSigningSecret != "" {
+		checkers := []out.URLReputationChecker{reputation.NewBlocklistAdapter(cfg.LinkBlocklist)}
+		if cfg.SafeBrowsingAPIKey != "" {
+			checkers = append(checkers, reputation.NewSafeBrowsingAdapter(cfg.SafeBrowsingAPIKey))
+		}
+		var clickRepo out.LinkClickRepository
+		if deps.LinkClickRepo != nil {
+			clickRepo = deps.LinkClickRepo
+		}
+		deps.SafeLinkService = safelink.NewService(
+			cfg.LinkSigningSecret,
+			cfg.PublicBaseURL,
+			reputation.NewChainAdapter(checkers...),
+			clickRepo,
+		)
+		if deps.SettingsDomainRepo != nil {
+			deps.SafeLinkService.SetSettingsRepository(deps.SettingsDomainRepo)
+		}
+		logger.Info("SafeLinkService initialized (safe browsing: %v)", cfg.SafeBrowsingAPIKey != "")
+	}
+
+	// Image Proxy (원격 이미지 프록시 - 서명 키 공유)
+	if cfg.ImageProxyEnabled && cfg.LinkSigningSecret != "" {
+		deps.ImageProxyService = imageproxy.NewService(
+			cfg.LinkSigningSecret,
+			cfg.PublicBaseURL,
+			deps.Redis,
+			int64(cfg.ImageProxyMaxSizeMB)*1024*1024,
+		)
+		logger.Info("ImageProxyService initialized")
+	}
+
+	// Send Tracking (보낸 메일 열람/클릭 추적 - feature flag, 수신자가 접근할 공개 URL 필요)
+	if cfg.SendTrackingEnabled {
+		if cfg.LinkSigningSecret == "" || cfg.PublicBaseURL == "" || deps.SendTrackingRepo == nil {
+			logger.Warn("Send tracking enabled but LINK_SIGNING_SECRET/PUBLIC_BASE_URL/database missing - disabled")
+		} else {
+			deps.TrackingService = tracking.NewService(cfg.LinkSigningSecret, cfg.PublicBaseURL, deps.SendTrackingRepo)
+			if deps.EmailService != nil {
+				deps.EmailService.SetTrackingService(deps.TrackingService)
+			}
+			logger.Info("TrackingService initialized")
+		}
+	}
+
+	// Email Share Links (외부 사용자가 열 공개 URL 필요)
+	if deps.EmailShareRepo != nil && deps.EmailService != nil {
+		if cfg.LinkSigningSecret == "" || cfg.PublicBaseURL == "" {
+			logger.Warn("LINK_SIGNING_SECRET/PUBLIC_BASE_URL missing - email share links disabled")
+		} else {
+			deps.ShareService = share.NewService(deps.EmailShareRepo, deps.EmailService, deps.AttachmentRepo, cfg.LinkSigningSecret, cfg.PublicBaseURL)
+			if deps.Redis != nil {
+				deps.ShareService.SetRedis(deps.Redis)
+			}
+			logger.Info("ShareService initialized")
+		}
+	}
+
+	// Large attachment links (발송 한도 초과 첨부 → 다운로드 링크, 수신자가 여는 공개 URL 필요)
+	if deps.AttachmentBlobs != nil && deps.EmailService != nil {
+		if cfg.LinkSigningSecret == "" || cfg.PublicBaseURL == "" {
+			logger.Warn("LINK_SIGNING_SECRET/PUBLIC_BASE_URL missing - large attachment links disabled")
+		} else {
+			deps.FileLinkService = filelink.NewService(deps.AttachmentBlobs, cfg.LinkSigningSecret, cfg.PublicBaseURL)
+			if deps.SettingsDomainRepo != nil {
+				deps.FileLinkService.SetSettingsRepository(deps.SettingsDomainRepo)
+			}
+			deps.EmailService.SetFileLinkService(deps.FileLinkService)
+			logger.Info("FileLinkService initialized")
+		}
 	}
 
 	// Report Service
@@ -640,8 +1283,65 @@ func NewDependencies(cfg *config.Config) (*Dependencies, func(), error) {
 	// Template Service
 	if deps.TemplateRepo != nil {
 		deps.TemplateService = service.NewTemplateService(deps.TemplateRepo)
+		if deps.PersonalizationRepo != nil {
+			deps.TemplateService.SetPhraseStore(deps.PersonalizationRepo)
+		}
+		if deps.EmailService != nil {
+			deps.EmailService.SetTemplateService(deps.TemplateService)
+		}
 	}
 
+	// Campaign Service (메일 머지 - 수신자별 렌더링 후 throttle 발송)
+	if deps.CampaignRepo != nil && deps.EmailService != nil && deps.OAuthService != nil {
+		deps.CampaignService = mail.NewCampaignService(
+			deps.CampaignRepo,
+			deps.EmailService,
+			deps.OAuthService,
+			deps.MessageProducer,
+		)
+		if deps.TemplateService != nil {
+			deps.CampaignService.SetTemplateService(deps.TemplateService)
+		}
+	}
+
+	// Triage Service (Inbox Zero 도우미 - 참여도 기반 일괄 정리 제안 → 확인 후 워커 실행)
+	if deps.InboxTriageRepo != nil && deps.EmailService != nil {
+		var senders domain.SenderProfileRepository
+		if deps.SenderProfileRepo != nil {
+			senders = deps.SenderProfileRepo
+		}
+		deps.TriageService = mail.NewTriageService(
+			deps.InboxTriageRepo,
+			deps.EmailService,
+			senders,
+			deps.MessageProducer,
+		)
+		if deps.Redis != nil {
+			deps.TriageService.SetListCacheWarmer(mail.NewListCacheWarmer(deps.EmailService, ratelimit.NewEmailListCache(deps.Redis, nil)))
+		}
+	}
+
+	// Config Watcher (CONFIG_FILE / Redis config:overrides → 재배포 없이 반영)
+	deps.ConfigWatcher = config.NewWatcher(cfg)
+	if deps.Redis != nil {
+		deps.ConfigWatcher.SetRemoteSource(func(ctx context.Context) (map[string]string, error) {
+			return deps.Redis.HGetAll(ctx, config.RemoteOverridesKey).Result()
+		})
+	}
+	applyCacheTTLs(deps.CacheService, cfg)
+	if deps.MailSyncService != nil {
+		deps.MailSyncService.SetSyncWindow(cfg.SyncWindowMonths)
+	}
+	deps.ConfigWatcher.OnChange(func(_, next *config.Config) {
+		applyCacheTTLs(deps.CacheService, next)
+		if deps.MailSyncService != nil {
+			deps.MailSyncService.SetSyncWindow(next.SyncWindowMonths)
+		}
+	})
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	deps.ConfigWatcher.Start(watchCtx)
+	cleanups = append(cleanups, stopWatch)
+
 	cleanup := func() {
 		for i := len(cleanups) - 1; i >= 0; i-- {
 			cleanups[i]()
@@ -666,3 +1366,55 @@ func (d *Dependencies) HealthCheck(ctx context.Context) error {
 
 	return nil
 }
+
+// applyCacheTTLs copies the Redis cache TTLs from cfg into the cache service.
+func applyCacheTTLs(cache *common.CacheService, cfg *config.Config) {
+	if cache == nil {
+		return
+	}
+	next := cache.Config()
+	if cfg.CacheBodyTTLMin > 0 {
+		next.BodyTTL = time.Duration(cfg.CacheBodyTTLMin) * time.Minute
+	}
+	if cfg.CacheListTTLMin > 0 {
+		next.ListTTL = time.Duration(cfg.CacheListTTLMin) * time.Minute
+	}
+	cache.SetConfig(&next)
+}
+
+// withSQLXParams appends the sqlx connection parameters to a database URL.
+// simple_protocol은 PgBouncer와의 prepared statement 충돌을 피하고,
+// statement_timeout은 서버에서 느린 쿼리를 끊는다 (0이면 생략).
+func withSQLXParams(databaseURL string, statementTimeout time.Duration) string {
+	sep := "?"
+	if strings.Contains(databaseURL, "?") {
+		sep = "&"
+	}
+	databaseURL += sep + "default_query_exec_mode=simple_protocol"
+	if statementTimeout > 0 {
+		databaseURL += fmt.Sprintf("&statement_timeout=%d", statementTimeout.Milliseconds())
+	}
+	return databaseURL
+}
+
+// newPaletteService wires the command palette to the sources that are configured.
+// nil 포인터가 인터페이스에 들어가지 않도록 있는 소스만 넘긴다.
+func newPaletteService(deps *Dependencies) *palette.Service {
+	var folders palette.FolderLister
+	var smartFolders domain.SmartFolderRepository
+	var contacts palette.ContactLister
+	var connections palette.ConnectionLister
+	if deps.FolderService != nil {
+		folders = deps.FolderService
+	}
+	if deps.SmartFolderRepo != nil {
+		smartFolders = deps.SmartFolderRepo
+	}
+	if deps.ContactService != nil {
+		contacts = deps.ContactService
+	}
+	if deps.OAuthService != nil {
+		connections = deps.OAuthService
+	}
+	return palette.NewService(folders, smartFolders, contacts, connections)
+}
@@ -0,0 +1,47 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+
+	"worker_server/adapter/out/push"
+	"worker_server/config"
+	"worker_server/core/port/out"
+	"worker_server/pkg/logger"
+)
+
+// newPushSenders creates the mobile push adapters configured in cfg.
+// 키 파일을 읽지 못하면 해당 플랫폼만 비활성화하고 서버는 계속 시작한다.
+func newPushSenders(cfg *config.Config) []out.MobilePushPort {
+	var senders []out.MobilePushPort
+
+	if cfg.FCMCredentialsFile != "" {
+		credentials, err := os.ReadFile(cfg.FCMCredentialsFile)
+		if err == nil {
+			var fcm *push.FCMAdapter
+			if fcm, err = push.NewFCMAdapter(context.Background(), credentials, cfg.FCMProjectID); err == nil {
+				senders = append(senders, fcm)
+				logger.Info("FCM push enabled")
+			}
+		}
+		if err != nil {
+			logger.Error("FCM push disabled: %v", err)
+		}
+	}
+
+	if cfg.APNsKeyFile != "" {
+		key, err := os.ReadFile(cfg.APNsKeyFile)
+		if err == nil {
+			var apns *push.APNsAdapter
+			if apns, err = push.NewAPNsAdapter(key, cfg.APNsKeyID, cfg.APNsTeamID, cfg.APNsTopic, cfg.APNsProduction); err == nil {
+				senders = append(senders, apns)
+				logger.Info("APNs push enabled (production=%v)", cfg.APNsProduction)
+			}
+		}
+		if err != nil {
+			logger.Error("APNs push disabled: %v", err)
+		}
+	}
+
+	return senders
+}
@@ -0,0 +1,83 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"worker_server/adapter/out/vector"
+	"worker_server/config"
+	"worker_server/core/agent/rag"
+	"worker_server/core/port/out"
+	"worker_server/infra/database"
+	"worker_server/pkg/logger"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewVectorStore creates the email embedding store for backend (pgvector, qdrant, milvus).
+// 빈 문자열이면 pgvector를 사용한다.
+func NewVectorStore(cfg *config.Config, backend string, db *pgxpool.Pool) (out.VectorStorePort, error) {
+	switch {
+	case isPgVector(backend):
+		if db == nil {
+			return nil, fmt.Errorf("pgvector store requires a database connection")
+		}
+		return rag.NewVectorStore(db), nil
+	case strings.EqualFold(backend, "qdrant"):
+		return vector.NewQdrantAdapter(cfg.QdrantURL, cfg.QdrantAPIKey, cfg.QdrantCollection), nil
+	case strings.EqualFold(backend, "milvus"):
+		return vector.NewMilvusAdapter(cfg.MilvusURL, cfg.MilvusToken, cfg.MilvusCollection), nil
+	default:
+		return nil, fmt.Errorf("unknown vector store %q (expected pgvector, qdrant or milvus)", backend)
+	}
+}
+
+// MigrateVectors copies stored embeddings from one backend to another (-mode migrate-vectors).
+// 같은 email ID로 upsert하므로 중단 후 다시 실행해도 안전하며, afterID로 이어서 복사할 수 있다.
+func MigrateVectors(ctx context.Context, cfg *config.Config, from, to string, afterID int64, batchSize int) error {
+	if strings.EqualFold(from, to) {
+		return fmt.Errorf("source and destination vector stores are the same (%s)", from)
+	}
+
+	var db *pgxpool.Pool
+	if isPgVector(from) || isPgVector(to) {
+		var err error
+		if db, err = database.NewPostgres(cfg.DatabaseURL); err != nil {
+			return err
+		}
+		defer db.Close()
+	}
+
+	src, err := NewVectorStore(cfg, from, db)
+	if err != nil {
+		return err
+	}
+	dst, err := NewVectorStore(cfg, to, db)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("[VectorMigrate] Copying embeddings %s -> %s (after id %d)", from, to, afterID)
+	copied, lastID, err := rag.MigrateVectors(ctx, src, dst, rag.MigrateOptions{
+		BatchSize: batchSize,
+		AfterID:   afterID,
+		Progress: func(copied int, lastID int64) {
+			logger.Info("[VectorMigrate] %d vectors copied (last id %d)", copied, lastID)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("vector migration stopped after %d vectors, resume with -after %d: %w", copied, lastID, err)
+	}
+
+	logger.Info("[VectorMigrate] Done: %d vectors copied (last id %d)", copied, lastID)
+	return nil
+}
+
+func isPgVector(backend string) bool {
+	switch strings.ToLower(backend) {
+	case "", "pgvector", "postgres":
+		return true
+	}
+	return false
+}
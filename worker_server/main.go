@@ -31,7 +31,11 @@ func main() {
 		logger.Debug("No .env file found, using environment variables")
 	}
 
-	mode := flag.String("mode", "all", "Run mode: api, worker, all")
+	mode := flag.String("mode", "all", "Run mode: api, worker, all, migrate-vectors")
+	from := flag.String("from", "pgvector", "migrate-vectors: source vector store (pgvector, qdrant, milvus)")
+	to := flag.String("to", "", "migrate-vectors: destination vector store (pgvector, qdrant, milvus)")
+	after := flag.Int64("after", 0, "migrate-vectors: resume after this email ID")
+	batch := flag.Int("batch", 500, "migrate-vectors: vectors per batch")
 	flag.Parse()
 
 	cfg, err := config.Load()
@@ -47,6 +51,8 @@ func main() {
 	case "all":
 		go runWorker(cfg)
 		runAPI(cfg)
+	case "migrate-vectors":
+		runMigrateVectors(cfg, *from, *to, *after, *batch)
 	default:
 		logger.Fatal("Unknown mode: %s", *mode)
 	}
@@ -130,3 +136,16 @@ func runWorker(cfg *config.Config) {
 	logger.Info("Starting worker...")
 	worker.Start()
 }
+
+func runMigrateVectors(cfg *config.Config, from, to string, afterID int64, batchSize int) {
+	if to == "" {
+		logger.Fatal("migrate-vectors requires -to (pgvector, qdrant, milvus)")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := bootstrap.MigrateVectors(ctx, cfg, from, to, afterID, batchSize); err != nil {
+		logger.Fatal("Vector migration failed: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Mail Imports Table
+-- =============================================================================
+-- MBOX/EML 업로드 작업 추적
+-- 원본 파일은 워커가 처리할 때까지 source에 보관하고, 완료 후 비운다.
+-- 가져온 메일은 provider = 'local' 가상 연결(oauth_connections) 아래에 저장된다.
+CREATE TABLE IF NOT EXISTS mail_imports (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    connection_id BIGINT REFERENCES oauth_connections(id) ON DELETE SET NULL,
+
+    -- Source
+    filename VARCHAR(255) NOT NULL,
+    format VARCHAR(10) NOT NULL,          -- mbox, eml
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    source BYTEA,                         -- 처리 완료 후 NULL
+
+    -- Progress
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',  -- pending, processing, completed, failed
+    total_count INTEGER NOT NULL DEFAULT 0,
+    imported_count INTEGER NOT NULL DEFAULT 0,
+    skipped_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+
+    -- Timestamps
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_mail_imports_user ON mail_imports(user_id, created_at DESC);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS mail_imports;
@@ -0,0 +1,29 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Security Table
+-- =============================================================================
+-- 피싱/스푸핑 분석 결과 (SPF/DKIM/DMARC, 유사 도메인, 표시 이름 불일치, 의심 URL)
+-- 동기화 시점(헤더 기반)과 분류 시점(본문 URL 기반) 결과가 병합되어 저장된다.
+CREATE TABLE IF NOT EXISTS email_security (
+    email_id BIGINT PRIMARY KEY REFERENCES emails(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    risk_level VARCHAR(10) NOT NULL DEFAULT 'none',  -- none, low, medium, high
+    risk_score REAL NOT NULL DEFAULT 0,
+    signals JSONB NOT NULL DEFAULT '[]',
+
+    -- Sender authentication results
+    spf VARCHAR(20),
+    dkim VARCHAR(20),
+    dmarc VARCHAR(20),
+
+    analyzed_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_email_security_user_risk ON email_security(user_id, risk_level)
+    WHERE risk_level IN ('medium', 'high');
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS email_security;
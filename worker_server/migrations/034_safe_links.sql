@@ -0,0 +1,22 @@
+-- +migrate Up
+
+-- Per-user opt-in for link rewriting
+ALTER TABLE user_settings ADD COLUMN IF NOT EXISTS safe_links_enabled BOOLEAN DEFAULT FALSE;
+
+-- Click analytics for rewritten links
+CREATE TABLE IF NOT EXISTS link_clicks (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email_id BIGINT REFERENCES emails(id) ON DELETE SET NULL,
+    url TEXT NOT NULL,
+    domain VARCHAR(255) NOT NULL,
+    verdict VARCHAR(20) NOT NULL DEFAULT 'unknown',
+    clicked_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_link_clicks_user_time ON link_clicks(user_id, clicked_at DESC);
+CREATE INDEX IF NOT EXISTS idx_link_clicks_email ON link_clicks(email_id) WHERE email_id IS NOT NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS link_clicks;
+ALTER TABLE user_settings DROP COLUMN IF EXISTS safe_links_enabled;
@@ -0,0 +1,60 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Campaigns (Mail Merge)
+-- =============================================================================
+-- 템플릿 + 수신자별 변수로 개인화 메일을 일괄 발송한다.
+-- 발송 중 템플릿이 수정되어도 영향이 없도록 subject/body를 생성 시점에 복사해 둔다.
+CREATE TABLE IF NOT EXISTS email_campaigns (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    connection_id BIGINT NOT NULL REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    template_id BIGINT REFERENCES email_templates(id) ON DELETE SET NULL,
+
+    -- Content snapshot
+    name VARCHAR(255) NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    html_body TEXT NOT NULL DEFAULT '',
+    use_signature BOOLEAN NOT NULL DEFAULT FALSE,
+    throttle_ms INTEGER NOT NULL DEFAULT 1000,   -- 수신자 간 발송 간격
+
+    -- Progress
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',  -- pending, sending, completed, failed, cancelled
+    total_count INTEGER NOT NULL DEFAULT 0,
+    sent_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    bounced_count INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+
+    -- Timestamps
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_campaigns_user ON email_campaigns(user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS email_campaign_recipients (
+    id BIGSERIAL PRIMARY KEY,
+    campaign_id BIGINT NOT NULL REFERENCES email_campaigns(id) ON DELETE CASCADE,
+    email VARCHAR(320) NOT NULL,
+    name VARCHAR(255),
+    variables JSONB NOT NULL DEFAULT '{}',
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',  -- pending, sent, failed, bounced
+    provider_message_id VARCHAR(255),               -- 바운스 메일을 발송 건과 연결할 때 사용
+    error_message TEXT,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+
+    UNIQUE (campaign_id, email)
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_recipients_status ON email_campaign_recipients(campaign_id, status, id);
+CREATE INDEX IF NOT EXISTS idx_campaign_recipients_message ON email_campaign_recipients(provider_message_id) WHERE provider_message_id IS NOT NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_campaign_recipients;
+DROP TABLE IF EXISTS email_campaigns;
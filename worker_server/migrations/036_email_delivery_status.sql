@@ -0,0 +1,41 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Delivery Status (Bounce / Complaint Tracking)
+-- =============================================================================
+-- 동기화 중 수신한 DSN(RFC 3464)·ARF(RFC 5965) 리포트를 수신자 단위로 저장한다.
+-- 원본 발송 메일과는 Message-ID로 연결하며, 보낸 메일이 아직 동기화되지 않았으면
+-- sent_email_id 없이 저장해 두고 조회 시 original_message_id로 다시 매칭한다.
+CREATE TABLE IF NOT EXISTS email_delivery_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    connection_id BIGINT NOT NULL REFERENCES oauth_connections(id) ON DELETE CASCADE,
+
+    sent_email_id BIGINT REFERENCES emails(id) ON DELETE CASCADE,      -- 원본 발송 메일
+    report_email_id BIGINT REFERENCES emails(id) ON DELETE SET NULL,   -- 바운스/신고 리포트 메일
+    original_message_id VARCHAR(998),
+
+    recipient VARCHAR(320) NOT NULL,
+    state VARCHAR(20) NOT NULL,            -- delivered, delayed, bounced, complained
+    status_code VARCHAR(20),               -- enhanced status code (5.1.1)
+    diagnostic TEXT,
+    permanent BOOLEAN NOT NULL DEFAULT FALSE,
+    reporting_mta VARCHAR(255),
+
+    reported_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+
+    UNIQUE(report_email_id, recipient)
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_events_sent ON email_delivery_events(sent_email_id) WHERE sent_email_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_delivery_events_message ON email_delivery_events(connection_id, original_message_id);
+
+-- 보낸 메일을 Message-ID로 찾기 위한 인덱스 (동기화 시 message_id 저장)
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS message_id VARCHAR(998);
+CREATE INDEX IF NOT EXISTS idx_emails_connection_message_id ON emails(connection_id, message_id) WHERE message_id IS NOT NULL;
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_emails_connection_message_id;
+DROP TABLE IF EXISTS email_delivery_events;
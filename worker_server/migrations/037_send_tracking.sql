@@ -0,0 +1,34 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Send Tracking (Open Pixel / Link Clicks) - opt-in, SEND_TRACKING_ENABLED
+-- =============================================================================
+-- 발송 요청에서 track_opens/track_clicks를 켠 메일만 기록한다.
+-- 발송 직후에는 보낸 메일이 아직 동기화되지 않았으므로 provider message ID로 연결한다.
+CREATE TABLE IF NOT EXISTS sent_email_tracking (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    connection_id BIGINT NOT NULL REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    external_id VARCHAR(255),               -- provider message ID (발송 후 채움)
+    subject TEXT,
+    track_opens BOOLEAN NOT NULL DEFAULT FALSE,
+    track_clicks BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sent_tracking_message ON sent_email_tracking(connection_id, external_id) WHERE external_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS sent_email_tracking_events (
+    id BIGSERIAL PRIMARY KEY,
+    tracking_id BIGINT NOT NULL REFERENCES sent_email_tracking(id) ON DELETE CASCADE,
+    event_type VARCHAR(10) NOT NULL,        -- open, click
+    url TEXT,                               -- click 대상
+    user_agent VARCHAR(512),
+    occurred_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sent_tracking_events ON sent_email_tracking_events(tracking_id, occurred_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS sent_email_tracking_events;
+DROP TABLE IF EXISTS sent_email_tracking;
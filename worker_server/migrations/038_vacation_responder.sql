@@ -0,0 +1,32 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Vacation Responder (Out-of-office)
+-- =============================================================================
+-- mode = native     : Gmail/Outlook 자체 부재중 설정을 대신 변경 (여기에는 사본만 저장)
+-- mode = auto_reply : Provider 설정을 쓸 수 없으면 delta sync에서 직접 자동 응답
+CREATE TABLE IF NOT EXISTS vacation_responders (
+    connection_id BIGINT PRIMARY KEY REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    mode VARCHAR(20) NOT NULL DEFAULT 'native',
+    subject TEXT,
+    body TEXT,
+    is_html BOOLEAN NOT NULL DEFAULT FALSE,
+    start_at TIMESTAMPTZ,
+    end_at TIMESTAMPTZ,
+    enabled_at TIMESTAMPTZ,                 -- 마지막으로 켠 시각 (이전 메일에는 응답하지 않음)
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- 발신자별 자동 응답 기록 (중복 응답 방지)
+CREATE TABLE IF NOT EXISTS vacation_replies (
+    connection_id BIGINT NOT NULL REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    sender VARCHAR(320) NOT NULL,
+    replied_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (connection_id, sender)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS vacation_replies;
+DROP TABLE IF EXISTS vacation_responders;
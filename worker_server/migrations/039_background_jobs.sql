@@ -0,0 +1,26 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Background Jobs (TriggerSync / Resync / Reclassify 진행 상황)
+-- =============================================================================
+-- MessageProducer로 발행되는 작업은 fire-and-forget이므로 클라이언트가 job_id로 상태를 조회한다.
+CREATE TABLE IF NOT EXISTS background_jobs (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    connection_id BIGINT REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    state VARCHAR(20) NOT NULL DEFAULT 'queued', -- queued, running, succeeded, failed
+    progress_total INT NOT NULL DEFAULT 0,        -- 0이면 진행률을 알 수 없는 작업
+    progress_done INT NOT NULL DEFAULT 0,
+    progress_failed INT NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_background_jobs_user ON background_jobs(user_id, created_at DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS background_jobs;
@@ -0,0 +1,11 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Sync Progress Estimate
+-- =============================================================================
+-- checkpoint_total_count는 provider 추정치(Gmail resultSizeEstimate / Graph @odata.count)이고,
+-- checkpoint_started_at은 ETA 계산용 초기 동기화 시작 시각이다 (체크포인트와 함께 초기화).
+ALTER TABLE sync_states ADD COLUMN IF NOT EXISTS checkpoint_started_at TIMESTAMPTZ;
+
+-- +migrate Down
+ALTER TABLE sync_states DROP COLUMN IF EXISTS checkpoint_started_at;
@@ -0,0 +1,27 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Classification Backfill Checkpoints
+-- =============================================================================
+-- 초기 동기화 후 미분류 메일 재분류 진행 상황을 연결별로 저장한다.
+-- 이메일 ID 오름차순으로 ai:classify를 발행하고 cursor_id에 마지막 발행 ID를 남겨
+-- 워커가 재시작되면 running 상태의 backfill을 cursor_id 다음부터 이어서 처리한다.
+CREATE TABLE IF NOT EXISTS classification_backfills (
+    connection_id BIGINT PRIMARY KEY REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'running', -- running, completed, failed
+    cursor_id BIGINT NOT NULL DEFAULT 0,            -- 마지막으로 발행한 emails.id
+    total INT NOT NULL DEFAULT 0,                   -- 시작 시점 미분류 개수
+    published INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_classification_backfills_running
+    ON classification_backfills(updated_at) WHERE status = 'running';
+
+-- +migrate Down
+DROP TABLE IF EXISTS classification_backfills;
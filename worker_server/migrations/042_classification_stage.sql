@@ -0,0 +1,12 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Classification Stage
+-- =============================================================================
+-- classification_source(header/domain/llm/user)보다 세분화된, 라벨을 붙인 파이프라인 단계.
+-- rfc, domain_score, subject, rule, sender, llm, llm_failed, default
+-- heuristic 모드(CLASSIFICATION_MODE=heuristic)에서 어떤 단계가 분류했는지 추적하는 용도.
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS classification_stage VARCHAR(20);
+
+-- +migrate Down
+ALTER TABLE emails DROP COLUMN IF EXISTS classification_stage;
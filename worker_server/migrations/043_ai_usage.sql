@@ -0,0 +1,25 @@
+-- +migrate Up
+
+-- =============================================================================
+-- AI Usage Accounting
+-- =============================================================================
+-- LLM/임베딩 토큰 사용량을 사용자/연결/작업/모델별로 월 단위 집계한다.
+-- 호출마다 upsert로 누적하며, 월 예산(AI_MONTHLY_BUDGET_USD) 초과 여부 판단에 사용한다.
+-- user_id가 00000000-...인 행은 사용자에 귀속되지 않은 시스템 사용량이므로 users FK를 두지 않는다.
+-- connection_id 0 = 연결에 귀속되지 않은 사용량 (채팅, 검색 등).
+CREATE TABLE IF NOT EXISTS ai_usage (
+    user_id UUID NOT NULL,
+    connection_id BIGINT NOT NULL DEFAULT 0,
+    task VARCHAR(30) NOT NULL,                 -- classify, summarize, reply, chat, translate, embed, other ...
+    model VARCHAR(60) NOT NULL,
+    period CHAR(7) NOT NULL,                   -- YYYY-MM (UTC)
+    requests BIGINT NOT NULL DEFAULT 0,
+    prompt_tokens BIGINT NOT NULL DEFAULT 0,
+    completion_tokens BIGINT NOT NULL DEFAULT 0,
+    cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, period, connection_id, task, model)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS ai_usage;
@@ -0,0 +1,34 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Daily Briefing
+-- =============================================================================
+-- 사용자 시간대 기준 하루 한 번 생성하는 브리핑 (안읽은 중요 메일, 할 일, 다가오는 일정, 답장 대기 스레드).
+-- payload는 domain.Briefing JSON이며 GET /briefing/today가 그대로 반환한다.
+CREATE TABLE IF NOT EXISTS briefings (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    briefing_date DATE NOT NULL,
+    timezone VARCHAR(50) NOT NULL DEFAULT 'UTC',
+    payload JSONB NOT NULL,
+    generated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    emailed_at TIMESTAMPTZ,
+    PRIMARY KEY (user_id, briefing_date)
+);
+
+-- 브리핑 설정 (행이 없으면 기본값: 사용, 오전 7시, 메일 발송 안 함)
+CREATE TABLE IF NOT EXISTS briefing_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    email_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    hour SMALLINT NOT NULL DEFAULT 7 CHECK (hour BETWEEN 0 AND 23),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- 답장 대기 스레드 조회용 (보낸 메일)
+CREATE INDEX IF NOT EXISTS idx_emails_user_sent_date
+    ON emails(user_id, email_date DESC) WHERE folder = 'sent';
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_emails_user_sent_date;
+DROP TABLE IF EXISTS briefing_settings;
+DROP TABLE IF EXISTS briefings;
@@ -0,0 +1,72 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Notification Settings
+-- =============================================================================
+-- 018에서 삭제된 notification_settings를 domain.NotificationSettings 기준으로 다시 만든다.
+-- 012의 trigger_create_notification_settings가 user_id만 넣으므로 모든 컬럼에 기본값이 필요하다.
+CREATE TABLE IF NOT EXISTS notification_settings (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+
+    -- 채널
+    push_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    email_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    desktop_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    inapp_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- 메일
+    notify_new_email BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_important_only BOOLEAN NOT NULL DEFAULT FALSE,
+    notify_from_vip_only BOOLEAN NOT NULL DEFAULT FALSE,
+    notify_mentions BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- 캘린더
+    notify_calendar_events BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_calendar_reminder BOOLEAN NOT NULL DEFAULT TRUE,
+    reminder_minutes_before INTEGER NOT NULL DEFAULT 15,
+
+    -- 동기화 / AI
+    notify_sync_complete BOOLEAN NOT NULL DEFAULT FALSE,
+    notify_sync_error BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_ai_classified BOOLEAN NOT NULL DEFAULT FALSE,
+    notify_ai_summarized BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- 방해 금지 시간
+    quiet_hours_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    quiet_hours_start VARCHAR(5) NOT NULL DEFAULT '22:00',
+    quiet_hours_end VARCHAR(5) NOT NULL DEFAULT '08:00',
+    quiet_hours_timezone VARCHAR(50) NOT NULL DEFAULT 'Asia/Seoul',
+
+    -- VIP / 뮤트
+    vip_senders TEXT[] NOT NULL DEFAULT '{}',
+    muted_senders TEXT[] NOT NULL DEFAULT '{}',
+    muted_thread_ids BIGINT[] NOT NULL DEFAULT '{}',
+
+    -- 우선순위 (1=urgent ~ 4=low, 이 값 이하만 알림) / 푸시 카테고리 (비어 있으면 전체)
+    min_priority_for_notification SMALLINT NOT NULL DEFAULT 3 CHECK (min_priority_for_notification BETWEEN 1 AND 4),
+    push_categories TEXT[] NOT NULL DEFAULT '{}',
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- =============================================================================
+-- Push Devices
+-- =============================================================================
+-- FCM/APNs 디바이스 토큰. 토큰은 디바이스(앱 설치)마다 고유하므로 token에 UNIQUE를 둔다.
+CREATE TABLE IF NOT EXISTS push_devices (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform VARCHAR(10) NOT NULL CHECK (platform IN ('fcm', 'apns')),
+    token TEXT NOT NULL UNIQUE,
+    device_name VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_devices_user ON push_devices(user_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS push_devices;
+DROP TABLE IF EXISTS notification_settings;
@@ -0,0 +1,25 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Held Notifications
+-- =============================================================================
+-- 방해 금지 시간 동안 보류된 알림. release_at(방해 금지 종료 시각)이 지나면
+-- 사용자별로 한 번에 꺼내(DELETE ... RETURNING) 요약 알림 하나로 전달한다.
+CREATE TABLE IF NOT EXISTS held_notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT,
+    entity_type VARCHAR(50),
+    entity_id BIGINT,
+    priority VARCHAR(20) NOT NULL DEFAULT 'normal',
+    release_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_held_notifications_release ON held_notifications(release_at);
+CREATE INDEX IF NOT EXISTS idx_held_notifications_user ON held_notifications(user_id, release_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS held_notifications;
@@ -0,0 +1,20 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Deadlines
+-- =============================================================================
+-- 메일 제목/본문에서 추출한 마감일 (pkg/deadline). TODO 보기에서 ai_priority와
+-- 합쳐 긴급도를 계산할 때 사용자 시간대 기준 남은 일수로 쓴다.
+CREATE TABLE IF NOT EXISTS email_deadlines (
+    email_id BIGINT PRIMARY KEY REFERENCES emails(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    deadline_at TIMESTAMPTZ NOT NULL,
+    source_text VARCHAR(200),
+    explicit BOOLEAN NOT NULL DEFAULT FALSE,
+    extracted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_deadlines_user ON email_deadlines(user_id, deadline_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_deadlines;
@@ -0,0 +1,34 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Workflow Board (Kanban)
+-- =============================================================================
+-- 사용자별 보드 단계. 각 단계는 emails.workflow_status(todo/done) 하나에 매핑되며,
+-- 상태별 첫 단계(position 최소)가 아직 배치되지 않은 메일을 받는다.
+CREATE TABLE IF NOT EXISTS workflow_stages (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(20),
+    workflow_status VARCHAR(20) NOT NULL DEFAULT 'todo',
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_stages_user ON workflow_stages(user_id, position);
+
+-- 드래그 앤 드롭으로 배치된 메일의 단계와 순서
+CREATE TABLE IF NOT EXISTS workflow_board_cards (
+    email_id BIGINT PRIMARY KEY REFERENCES emails(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    stage_id BIGINT NOT NULL REFERENCES workflow_stages(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_board_cards_stage ON workflow_board_cards(stage_id, position);
+
+-- +migrate Down
+DROP TABLE IF EXISTS workflow_board_cards;
+DROP TABLE IF EXISTS workflow_stages;
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Notes
+-- =============================================================================
+-- 메일에 붙이는 개인 메모/태그. Provider에는 동기화하지 않고 로컬 검색
+-- (full-text, RAG 임베딩)에만 사용한다.
+CREATE TABLE IF NOT EXISTS email_notes (
+    id BIGSERIAL PRIMARY KEY,
+    email_id BIGINT NOT NULL REFERENCES emails(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL DEFAULT '',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_notes_email ON email_notes(email_id);
+CREATE INDEX IF NOT EXISTS idx_email_notes_body_search ON email_notes USING gin(to_tsvector('english', body));
+CREATE INDEX IF NOT EXISTS idx_email_notes_tags ON email_notes USING gin(tags);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_notes;
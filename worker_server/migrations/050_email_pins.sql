@@ -0,0 +1,18 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Pinned Emails
+-- =============================================================================
+-- 고정된 메일은 inbox/todo 목록에서 날짜와 관계없이 맨 위에 표시된다.
+-- position은 사용자별 고정 순서 (0이 가장 위).
+CREATE TABLE IF NOT EXISTS email_pins (
+    email_id BIGINT PRIMARY KEY REFERENCES emails(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    pinned_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_pins_user ON email_pins(user_id, position);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_pins;
@@ -0,0 +1,25 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Share Links
+-- =============================================================================
+-- 외부 사용자에게 메일을 읽기 전용으로 공유하는 만료형 링크.
+-- 토큰 원문은 저장하지 않고 SHA-256 해시만 저장한다 (생성 응답에서만 URL 반환).
+CREATE TABLE IF NOT EXISTS email_shares (
+    id BIGSERIAL PRIMARY KEY,
+    email_id BIGINT NOT NULL REFERENCES emails(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    password_hash TEXT,
+    attachment_ids BIGINT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    view_count INT NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_shares_email ON email_shares(email_id, created_at DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_shares;
@@ -0,0 +1,56 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Teams (Shared Mailboxes)
+-- =============================================================================
+-- 조직 단위로 메일함(oauth_connections)을 공유한다.
+-- 공유된 메일함의 메일에는 조직 멤버가 내부 댓글을 남길 수 있다.
+CREATE TABLE IF NOT EXISTS orgs (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS org_members (
+    org_id BIGINT NOT NULL REFERENCES orgs(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member', -- owner, member
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_members_user ON org_members(user_id);
+
+-- 메일함은 한 조직에만 공유할 수 있다
+CREATE TABLE IF NOT EXISTS org_mailboxes (
+    connection_id BIGINT PRIMARY KEY REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    org_id BIGINT NOT NULL REFERENCES orgs(id) ON DELETE CASCADE,
+    shared_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_mailboxes_org ON org_mailboxes(org_id);
+
+-- =============================================================================
+-- Email Comments (내부 댓글, Provider에는 동기화하지 않음)
+-- =============================================================================
+CREATE TABLE IF NOT EXISTS email_comments (
+    id BIGSERIAL PRIMARY KEY,
+    email_id BIGINT NOT NULL REFERENCES emails(id) ON DELETE CASCADE,
+    org_id BIGINT NOT NULL REFERENCES orgs(id) ON DELETE CASCADE,
+    parent_id BIGINT REFERENCES email_comments(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    mentions UUID[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_comments_email ON email_comments(email_id, created_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_comments;
+DROP TABLE IF EXISTS org_mailboxes;
+DROP TABLE IF EXISTS org_members;
+DROP TABLE IF EXISTS orgs;
@@ -0,0 +1,34 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Assignments (공유 메일함 담당자 지정)
+-- =============================================================================
+-- 메일당 현재 담당자 한 명. 해제하면 행을 지운다.
+CREATE TABLE IF NOT EXISTS email_assignments (
+    email_id BIGINT PRIMARY KEY REFERENCES emails(id) ON DELETE CASCADE,
+    org_id BIGINT NOT NULL REFERENCES orgs(id) ON DELETE CASCADE,
+    assignee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    note TEXT,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- assigned_to_me 목록 필터
+CREATE INDEX IF NOT EXISTS idx_email_assignments_assignee ON email_assignments(assignee_id, org_id);
+
+-- 지정/재지정/해제 이력 (assignee_id NULL = 해제)
+CREATE TABLE IF NOT EXISTS email_assignment_history (
+    id BIGSERIAL PRIMARY KEY,
+    email_id BIGINT NOT NULL REFERENCES emails(id) ON DELETE CASCADE,
+    org_id BIGINT NOT NULL REFERENCES orgs(id) ON DELETE CASCADE,
+    assignee_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    assigned_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_assignment_history_email ON email_assignment_history(email_id, created_at DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_assignment_history;
+DROP TABLE IF EXISTS email_assignments;
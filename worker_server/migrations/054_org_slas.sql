@@ -0,0 +1,33 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Shared Inbox SLAs (카테고리별 응답 시간 목표)
+-- =============================================================================
+-- category = ai_category, 'default'는 별도 SLA가 없는 카테고리에 적용
+CREATE TABLE IF NOT EXISTS org_slas (
+    org_id BIGINT NOT NULL REFERENCES orgs(id) ON DELETE CASCADE,
+    category VARCHAR(50) NOT NULL,
+    response_minutes INT NOT NULL CHECK (response_minutes > 0),
+    at_risk_percent INT NOT NULL DEFAULT 75 CHECK (at_risk_percent BETWEEN 1 AND 99),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (org_id, category)
+);
+
+-- 워커가 주기적으로 평가한 메일별 SLA 상태
+-- notified_status: 마지막으로 에스컬레이션 알림을 보낸 상태 (같은 상태로 중복 알림 방지)
+CREATE TABLE IF NOT EXISTS email_slas (
+    email_id BIGINT PRIMARY KEY REFERENCES emails(id) ON DELETE CASCADE,
+    org_id BIGINT NOT NULL REFERENCES orgs(id) ON DELETE CASCADE,
+    due_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL, -- ok, at_risk, breached, met
+    notified_status VARCHAR(20),
+    evaluated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_slas_pending ON email_slas(status)
+    WHERE status IN ('at_risk', 'breached');
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_slas;
+DROP TABLE IF EXISTS org_slas;
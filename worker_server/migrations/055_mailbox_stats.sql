@@ -0,0 +1,80 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Mailbox Analytics (워커가 증분 집계하는 대시보드 테이블)
+-- =============================================================================
+
+-- 메일을 처음 읽은 시각 (읽기까지 걸린 시간 계산용)
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS read_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_emails_user_read_at ON emails(user_id, read_at)
+    WHERE read_at IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION set_email_read_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.is_read AND NOT COALESCE(OLD.is_read, FALSE) AND NEW.read_at IS NULL THEN
+        NEW.read_at := NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_emails_read_at ON emails;
+CREATE TRIGGER trigger_emails_read_at
+    BEFORE UPDATE OF is_read ON emails
+    FOR EACH ROW
+    EXECUTE FUNCTION set_email_read_at();
+
+-- 시간 단위 메일 수 (UTC 정시 버킷, 조회 시 사용자 시간대로 변환)
+-- read_count/read_seconds: 해당 시간에 읽은 메일 수와 수신~읽기 시간 합계
+CREATE TABLE IF NOT EXISTS mailbox_hourly_stats (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    bucket TIMESTAMPTZ NOT NULL,
+    received INT NOT NULL DEFAULT 0,
+    sent INT NOT NULL DEFAULT 0,
+    read_count INT NOT NULL DEFAULT 0,
+    read_seconds BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, bucket)
+);
+
+-- 발신자별 받은 메일 수 (전체 기간 누적)
+CREATE TABLE IF NOT EXISTS mailbox_sender_stats (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_email VARCHAR(255) NOT NULL,
+    from_name VARCHAR(255),
+    emails INT NOT NULL DEFAULT 0,
+    last_received_at TIMESTAMPTZ,
+    PRIMARY KEY (user_id, from_email)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mailbox_sender_stats_top ON mailbox_sender_stats(user_id, emails DESC);
+
+-- 카테고리별 메일 수 (집계 시점의 ai_category, 미분류는 uncategorized)
+CREATE TABLE IF NOT EXISTS mailbox_category_stats (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category VARCHAR(50) NOT NULL,
+    emails INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, category)
+);
+
+-- 사용자별 집계 위치와 첨부파일 사용량
+CREATE TABLE IF NOT EXISTS mailbox_stats_state (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    last_email_id BIGINT NOT NULL DEFAULT 0,
+    last_attachment_id BIGINT NOT NULL DEFAULT 0,
+    read_through TIMESTAMPTZ NOT NULL DEFAULT 'epoch',
+    attachment_count BIGINT NOT NULL DEFAULT 0,
+    attachment_bytes BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS mailbox_stats_state;
+DROP TABLE IF EXISTS mailbox_category_stats;
+DROP TABLE IF EXISTS mailbox_sender_stats;
+DROP TABLE IF EXISTS mailbox_hourly_stats;
+DROP TRIGGER IF EXISTS trigger_emails_read_at ON emails;
+DROP FUNCTION IF EXISTS set_email_read_at();
+DROP INDEX IF EXISTS idx_emails_user_read_at;
+ALTER TABLE emails DROP COLUMN IF EXISTS read_at;
@@ -0,0 +1,17 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Read Later Queue
+-- =============================================================================
+-- 나중에 읽을 뉴스레터 큐. 큐에 있는 메일은 inbox/카테고리 목록에서 빠지고
+-- GET /email/read-later에서 추가한 순서(최신 먼저)로 조회된다.
+CREATE TABLE IF NOT EXISTS email_read_later (
+    email_id BIGINT PRIMARY KEY REFERENCES emails(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_read_later_user ON email_read_later(user_id, added_at DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_read_later;
@@ -0,0 +1,8 @@
+-- +migrate Up
+
+-- 중복 메일 탐지 (여러 연결 계정에 배달된 같은 Message-ID)
+CREATE INDEX IF NOT EXISTS idx_emails_user_message_id ON emails(user_id, message_id)
+    WHERE message_id IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_emails_user_message_id;
@@ -0,0 +1,22 @@
+-- +migrate Up
+
+-- Provider 스레드 ID (Gmail threadId, Outlook conversationId) - 동기화 시 저장
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS external_thread_id VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_emails_connection_external_thread ON emails(connection_id, external_thread_id)
+    WHERE external_thread_id IS NOT NULL;
+
+-- 뮤트한 스레드 (새 메일은 delta sync에서 자동 보관, 알림 없음)
+CREATE TABLE IF NOT EXISTS email_thread_mutes (
+    connection_id BIGINT NOT NULL REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    thread_id VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (connection_id, thread_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_thread_mutes_user ON email_thread_mutes(user_id, muted_at DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_thread_mutes;
+DROP INDEX IF EXISTS idx_emails_connection_external_thread;
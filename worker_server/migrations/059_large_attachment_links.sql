@@ -0,0 +1,7 @@
+-- +migrate Up
+
+-- 발송 한도를 넘는 첨부를 다운로드 링크로 바꿔 보낼지 (끄면 발송 실패)
+ALTER TABLE user_settings ADD COLUMN IF NOT EXISTS large_attachment_links BOOLEAN DEFAULT TRUE;
+
+-- +migrate Down
+ALTER TABLE user_settings DROP COLUMN IF EXISTS large_attachment_links;
@@ -0,0 +1,28 @@
+-- +migrate Up
+
+-- 발송 대기열 (Provider 발송 실패 시 백오프로 재시도)
+CREATE TABLE IF NOT EXISTS email_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    connection_id BIGINT NOT NULL REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL DEFAULT 'send',
+    reply_to_id VARCHAR(255),
+    subject TEXT NOT NULL DEFAULT '',
+    to_emails TEXT[] NOT NULL DEFAULT '{}',
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ,
+    provider_id VARCHAR(255),
+    sent_at TIMESTAMPTZ,
+    tracking_id BIGINT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_outbox_due ON email_outbox(next_attempt_at) WHERE status = 'queued';
+CREATE INDEX IF NOT EXISTS idx_email_outbox_user ON email_outbox(user_id, created_at DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_outbox;
@@ -0,0 +1,30 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Calendar Invites
+-- =============================================================================
+-- 초대 메일의 .ics 첨부에서 추출한 일정과 RSVP 응답 (POST /email/:id/rsvp).
+-- response: needs_action, accepted, tentative, declined
+CREATE TABLE IF NOT EXISTS email_calendar_invites (
+    email_id BIGINT PRIMARY KEY REFERENCES emails(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    uid TEXT NOT NULL,
+    sequence INT NOT NULL DEFAULT 0,
+    method VARCHAR(20) NOT NULL DEFAULT 'REQUEST',
+    summary TEXT NOT NULL DEFAULT '',
+    location TEXT,
+    organizer VARCHAR(255) NOT NULL DEFAULT '',
+    attendee VARCHAR(255) NOT NULL DEFAULT '',
+    start_time TIMESTAMPTZ,
+    end_time TIMESTAMPTZ,
+    is_all_day BOOLEAN NOT NULL DEFAULT FALSE,
+    response VARCHAR(20) NOT NULL DEFAULT 'needs_action',
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_calendar_invites_uid ON email_calendar_invites(user_id, uid);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_calendar_invites;
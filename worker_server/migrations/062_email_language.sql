@@ -0,0 +1,13 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Language
+-- =============================================================================
+-- 분류 시 감지한 본문 언어 (ISO 639-1). 목록 language 필터와 번역 원문 언어에 사용.
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS language VARCHAR(10);
+
+CREATE INDEX IF NOT EXISTS idx_emails_user_language ON emails(user_id, language) WHERE language IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_emails_user_language;
+ALTER TABLE emails DROP COLUMN IF EXISTS language;
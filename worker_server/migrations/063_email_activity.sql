@@ -0,0 +1,21 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Activity
+-- =============================================================================
+-- 메일별 타임라인: 동기화, 분류(점수), 읽음/별표/이동, 규칙 적용, 답장, AI 작업.
+-- 메일이 삭제되면 함께 삭제된다.
+CREATE TABLE IF NOT EXISTS email_activity (
+    id BIGSERIAL PRIMARY KEY,
+    email_id BIGINT NOT NULL REFERENCES emails(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    detail JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_activity_email ON email_activity(email_id, created_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS email_activity;
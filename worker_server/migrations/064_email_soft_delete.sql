@@ -0,0 +1,16 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Soft Delete
+-- =============================================================================
+-- 영구 삭제 요청은 deleted_at만 기록하고 30일 동안 복원할 수 있다 (Provider 휴지통과 별개).
+-- 기간이 지나면 purge 스케줄러가 실제로 삭제한다.
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_emails_deleted_at ON emails(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_emails_user_deleted ON emails(user_id, deleted_at DESC) WHERE deleted_at IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_emails_user_deleted;
+DROP INDEX IF EXISTS idx_emails_deleted_at;
+ALTER TABLE emails DROP COLUMN IF EXISTS deleted_at;
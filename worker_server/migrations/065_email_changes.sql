@@ -0,0 +1,54 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Change Log (GET /email/changes)
+-- =============================================================================
+-- 동기화와 사용자 작업이 emails를 바꿀 때마다 트리거가 기록한다.
+-- 클라이언트는 sync token(마지막으로 받은 id) 이후의 변경만 받아 로컬 캐시를 갱신한다.
+-- user_id에 FK를 두지 않음: 사용자 삭제로 메일이 cascade 삭제될 때도 기록이 실패하면 안 됨.
+CREATE TABLE IF NOT EXISTS email_changes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    email_id BIGINT NOT NULL,
+    change_type VARCHAR(10) NOT NULL, -- created, updated, deleted
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_changes_user ON email_changes(user_id, id);
+CREATE INDEX IF NOT EXISTS idx_email_changes_created ON email_changes(created_at);
+
+-- soft delete는 deleted, 복원은 created로 기록한다.
+-- 수정은 updated_at이 바뀐 경우만 기록 (임베딩 갱신 등 updated_at을 건드리지 않는 변경 제외)
+CREATE OR REPLACE FUNCTION record_email_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        IF NEW.deleted_at IS NULL THEN
+            INSERT INTO email_changes (user_id, email_id, change_type) VALUES (NEW.user_id, NEW.id, 'created');
+        END IF;
+    ELSIF TG_OP = 'DELETE' THEN
+        -- soft delete 때 이미 기록했으면 영구 삭제는 다시 기록하지 않음
+        IF OLD.deleted_at IS NULL THEN
+            INSERT INTO email_changes (user_id, email_id, change_type) VALUES (OLD.user_id, OLD.id, 'deleted');
+        END IF;
+    ELSIF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+        INSERT INTO email_changes (user_id, email_id, change_type) VALUES (NEW.user_id, NEW.id, 'deleted');
+    ELSIF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+        INSERT INTO email_changes (user_id, email_id, change_type) VALUES (NEW.user_id, NEW.id, 'created');
+    ELSIF NEW.deleted_at IS NULL AND NEW.updated_at IS DISTINCT FROM OLD.updated_at THEN
+        INSERT INTO email_changes (user_id, email_id, change_type) VALUES (NEW.user_id, NEW.id, 'updated');
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_emails_change_log ON emails;
+CREATE TRIGGER trigger_emails_change_log
+    AFTER INSERT OR UPDATE OR DELETE ON emails
+    FOR EACH ROW
+    EXECUTE FUNCTION record_email_change();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS trigger_emails_change_log ON emails;
+DROP FUNCTION IF EXISTS record_email_change();
+DROP TABLE IF EXISTS email_changes;
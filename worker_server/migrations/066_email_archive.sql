@@ -0,0 +1,143 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Archive Tier (대용량 메일함의 오래된 메일)
+-- =============================================================================
+-- emails는 19개 테이블이 email_id로 참조하고 PK가 id 단독이라 선언적 파티셔닝으로 바꿀 수 없다
+-- (파티션 테이블의 PK/UNIQUE는 파티션 키를 포함해야 함). 그래서 hot 테이블은 그대로 두고,
+-- 오래된 메일을 월별 RANGE 파티션 아카이브로 옮긴다.
+-- 본문은 PostgreSQL이 아니라 MongoDB mail_bodies에 있으므로 아카이브 시 캐시에서 지우고,
+-- 다시 열면 기존 캐시 miss 경로(Provider API)로 가져온다.
+--
+-- data: emails 행 전체 (embedding 제외, 압축되는 JSONB라 컬럼 추가에도 스키마 변경 불필요)
+-- related: emails 삭제 시 cascade로 지워지는 email_attachments, email_labels 행
+-- 오래된 파티션은 ALTER TABLE emails_archive_YYYYMM SET TABLESPACE로 저렴한 스토리지에 둘 수 있다.
+CREATE TABLE IF NOT EXISTS emails_archive (
+    id BIGINT NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    connection_id BIGINT NOT NULL REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    external_id VARCHAR(255),
+    email_date TIMESTAMPTZ NOT NULL,
+    data JSONB NOT NULL,
+    related JSONB NOT NULL DEFAULT '{}',
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, email_date)
+) PARTITION BY RANGE (email_date);
+
+CREATE INDEX IF NOT EXISTS idx_emails_archive_user_date ON emails_archive(user_id, email_date DESC);
+CREATE INDEX IF NOT EXISTS idx_emails_archive_user_id ON emails_archive(user_id, id);
+
+-- 월별 파티션 (UTC 기준), 없으면 만든다
+CREATE OR REPLACE FUNCTION ensure_emails_archive_partition(p_month DATE)
+RETURNS VOID AS $$
+DECLARE
+    v_start DATE := date_trunc('month', p_month)::date;
+BEGIN
+    EXECUTE format(
+        'CREATE TABLE IF NOT EXISTS %I PARTITION OF emails_archive FOR VALUES FROM (%L) TO (%L)',
+        'emails_archive_' || to_char(v_start, 'YYYYMM'),
+        v_start::text || ' 00:00:00+00',
+        (v_start + INTERVAL '1 month')::date::text || ' 00:00:00+00');
+END;
+$$ LANGUAGE plpgsql;
+
+-- 사용자의 p_before 이전 메일을 최대 p_limit개 아카이브로 옮기고 옮긴 ID를 돌려준다.
+-- 사용자가 직접 만든 데이터(고정, 메모, 공유, 댓글, 지정, 마감일, 보드, 초대, SLA, 발송 추적)가
+-- 있는 메일은 cascade로 잃지 않도록 hot 테이블에 남긴다.
+-- emails DELETE는 변경 로그에 deleted로 기록되어 클라이언트 캐시에서도 빠진다.
+CREATE OR REPLACE FUNCTION archive_emails(p_user_id UUID, p_before TIMESTAMPTZ, p_limit INT)
+RETURNS SETOF BIGINT AS $$
+DECLARE
+    v_ids BIGINT[];
+    v_month DATE;
+BEGIN
+    SELECT array_agg(c.id) INTO v_ids FROM (
+        SELECT e.id FROM emails e
+        WHERE e.user_id = p_user_id
+          AND e.email_date < p_before
+          AND e.deleted_at IS NULL
+          AND NOT COALESCE(e.is_draft, FALSE)
+          AND (e.snooze_until IS NULL OR e.snooze_until < NOW())
+          AND NOT EXISTS (SELECT 1 FROM email_pins x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_read_later x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_notes x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_shares x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_comments x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_assignments x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_deadlines x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM workflow_board_cards x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_calendar_invites x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_slas x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_delivery_events x WHERE x.sent_email_id = e.id)
+        ORDER BY e.email_date
+        LIMIT p_limit
+        FOR UPDATE OF e SKIP LOCKED
+    ) c;
+
+    IF v_ids IS NULL THEN
+        RETURN;
+    END IF;
+
+    FOR v_month IN
+        SELECT DISTINCT date_trunc('month', e.email_date AT TIME ZONE 'UTC')::date
+        FROM emails e WHERE e.id = ANY(v_ids)
+    LOOP
+        PERFORM ensure_emails_archive_partition(v_month);
+    END LOOP;
+
+    INSERT INTO emails_archive (id, user_id, connection_id, external_id, email_date, data, related)
+    SELECT e.id, e.user_id, e.connection_id, e.external_id, e.email_date,
+        to_jsonb(e) - 'embedding',
+        jsonb_build_object(
+            'email_attachments', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM email_attachments x WHERE x.email_id = e.id), '[]'::jsonb),
+            'email_labels', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM email_labels x WHERE x.email_id = e.id), '[]'::jsonb))
+    FROM emails e
+    WHERE e.id = ANY(v_ids);
+
+    RETURN QUERY DELETE FROM emails WHERE id = ANY(v_ids) RETURNING id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- 아카이브된 메일을 같은 ID로 hot 테이블에 되돌리고 되돌린 ID를 돌려준다.
+-- 그 사이 다시 동기화되어 같은 external_id가 hot 테이블에 있으면 아카이브 사본만 지운다.
+CREATE OR REPLACE FUNCTION rehydrate_emails(p_user_id UUID, p_ids BIGINT[])
+RETURNS SETOF BIGINT AS $$
+DECLARE
+    v_restored BIGINT[];
+BEGIN
+    WITH restored AS (
+        INSERT INTO emails
+        SELECT r.* FROM emails_archive a, jsonb_populate_record(NULL::emails, a.data) r
+        WHERE a.user_id = p_user_id AND a.id = ANY(p_ids)
+        ON CONFLICT DO NOTHING
+        RETURNING id
+    )
+    SELECT array_agg(id) INTO v_restored FROM restored;
+
+    IF v_restored IS NOT NULL THEN
+        INSERT INTO email_attachments
+        SELECT x.* FROM emails_archive a,
+            jsonb_populate_recordset(NULL::email_attachments, a.related->'email_attachments') x
+        WHERE a.user_id = p_user_id AND a.id = ANY(v_restored)
+        ON CONFLICT DO NOTHING;
+
+        -- 그 사이 삭제된 라벨은 건너뛴다
+        INSERT INTO email_labels
+        SELECT x.* FROM emails_archive a,
+            jsonb_populate_recordset(NULL::email_labels, a.related->'email_labels') x
+        WHERE a.user_id = p_user_id AND a.id = ANY(v_restored)
+          AND EXISTS (SELECT 1 FROM labels l WHERE l.id = x.label_id)
+        ON CONFLICT DO NOTHING;
+    END IF;
+
+    DELETE FROM emails_archive WHERE user_id = p_user_id AND id = ANY(p_ids);
+
+    RETURN QUERY SELECT unnest(COALESCE(v_restored, '{}'::BIGINT[]));
+END;
+$$ LANGUAGE plpgsql;
+
+-- +migrate Down
+DROP FUNCTION IF EXISTS rehydrate_emails(UUID, BIGINT[]);
+DROP FUNCTION IF EXISTS archive_emails(UUID, TIMESTAMPTZ, INT);
+DROP FUNCTION IF EXISTS ensure_emails_archive_partition(DATE);
+DROP TABLE IF EXISTS emails_archive;
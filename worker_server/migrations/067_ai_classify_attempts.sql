@@ -0,0 +1,25 @@
+-- +migrate Up
+
+-- =============================================================================
+-- AI Classify Attempts (분류 실패 재처리 이력)
+-- =============================================================================
+-- 분류할 때마다 한 행을 남긴다. 실패한 메일은 emails.ai_status = 'failed'가 되고,
+-- 마지막 시도의 error로 실패 사유를 확인한 뒤 다른 모델이나 heuristic으로 다시 분류한다.
+CREATE TABLE IF NOT EXISTS ai_classify_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    email_id BIGINT NOT NULL REFERENCES emails(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    mode VARCHAR(20) NOT NULL,   -- llm, heuristic
+    model VARCHAR(50),           -- 비어 있으면 기본 모델
+    succeeded BOOLEAN NOT NULL,
+    stage VARCHAR(30),
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ai_classify_attempts_email ON ai_classify_attempts(email_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_emails_ai_failed ON emails(user_id, updated_at DESC) WHERE ai_status = 'failed';
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_emails_ai_failed;
+DROP TABLE IF EXISTS ai_classify_attempts;
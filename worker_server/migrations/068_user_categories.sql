@@ -0,0 +1,24 @@
+-- +migrate Up
+
+-- =============================================================================
+-- User Categories (사용자별 카테고리 체계)
+-- =============================================================================
+-- 기본 카테고리(primary, work, ... other) 키의 행은 이름 변경/숨김 override이고,
+-- 그 밖의 키는 사용자가 만든 카테고리다. 숨긴 카테고리로 분류된 메일은 other로 간다.
+CREATE TABLE IF NOT EXISTS user_categories (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(20) NOT NULL,    -- emails.ai_category에 저장되는 값
+    name VARCHAR(50) NOT NULL,   -- 기본 카테고리 override에서 빈 문자열이면 기본 이름
+    description TEXT,            -- LLM 분류 프롬프트에 전달
+    color VARCHAR(20),
+    icon VARCHAR(30),
+    is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    is_custom BOOLEAN NOT NULL DEFAULT FALSE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, key)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS user_categories;
@@ -0,0 +1,26 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Inbox Triage (받은편지함 정리 제안)
+-- =============================================================================
+-- POST /inbox/triage가 참여도 기반 일괄 작업(오래된 뉴스레터 보관, 구독 해지, 알림 읽음)을
+-- 제안하고, 사용자가 확인하면 워커(mail:triage)가 실행해 results에 작업별 결과를 남긴다.
+CREATE TABLE IF NOT EXISTS inbox_triages (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    connection_id BIGINT,
+    status VARCHAR(20) NOT NULL DEFAULT 'proposed',
+    actions JSONB NOT NULL DEFAULT '[]',
+    results JSONB,
+    error_message TEXT,
+    expires_at TIMESTAMPTZ NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_inbox_triages_user ON inbox_triages(user_id, created_at DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS inbox_triages;
@@ -0,0 +1,24 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Folder Provider Sync (폴더 ↔ Gmail 라벨/Outlook 폴더 양방향 동기화)
+-- =============================================================================
+-- folder_provider_mappings에 갱신 시각을 추가하고, 삭제(soft delete)된 폴더와
+-- 같은 이름으로 다시 만들 수 있도록 이름 유니크 제약을 활성 폴더로 한정한다.
+ALTER TABLE folder_provider_mappings
+ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT NOW();
+
+ALTER TABLE folders DROP CONSTRAINT IF EXISTS uq_folders_user_name;
+ALTER TABLE folders DROP CONSTRAINT IF EXISTS uq_folders_user_system_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_folders_user_name_active
+    ON folders(user_id, LOWER(name)) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_folders_user_system_key_active
+    ON folders(user_id, system_key) WHERE deleted_at IS NULL AND system_key IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS uq_folders_user_system_key_active;
+DROP INDEX IF EXISTS uq_folders_user_name_active;
+ALTER TABLE folders ADD CONSTRAINT uq_folders_user_system_key UNIQUE (user_id, system_key);
+ALTER TABLE folders ADD CONSTRAINT uq_folders_user_name UNIQUE (user_id, name);
+ALTER TABLE folder_provider_mappings DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,14 @@
+-- +migrate Up
+
+-- =============================================================================
+-- User Tiers (free/pro) - Provider API 동시 요청/RPS/응답 크기 쿼터 기준
+-- =============================================================================
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS tier VARCHAR(20) NOT NULL DEFAULT 'free';
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_tier;
+ALTER TABLE users ADD CONSTRAINT chk_users_tier CHECK (tier IN ('free', 'pro'));
+
+-- +migrate Down
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_tier;
+ALTER TABLE users DROP COLUMN IF EXISTS tier;
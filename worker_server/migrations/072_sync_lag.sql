@@ -0,0 +1,20 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Sync Lag (Provider 최신 메일 ↔ DB 최신 메일 간격, SLO 모니터 측정값)
+-- =============================================================================
+CREATE TABLE IF NOT EXISTS sync_lags (
+    connection_id BIGINT PRIMARY KEY REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    provider_latest_at TIMESTAMPTZ,
+    stored_latest_at TIMESTAMPTZ,
+    lag_seconds BIGINT NOT NULL DEFAULT 0,
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    alerted_at TIMESTAMPTZ,     -- 마지막 SLO 위반 알림
+    remediated_at TIMESTAMPTZ   -- 마지막 자동 GapSync 예약
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_lags_lag ON sync_lags(lag_seconds DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS sync_lags;
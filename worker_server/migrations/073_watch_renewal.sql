@@ -0,0 +1,15 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Watch Renewal (Gmail watch 갱신 실패 추적 - 백오프, 사용자 알림)
+-- =============================================================================
+ALTER TABLE sync_states
+ADD COLUMN IF NOT EXISTS watch_failures INT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS watch_retry_at TIMESTAMPTZ,      -- 다음 갱신 시도 (실패 시 지수 백오프)
+ADD COLUMN IF NOT EXISTS watch_escalated_at TIMESTAMPTZ;  -- 푸시 → 폴링 전환 알림 시각
+
+-- +migrate Down
+ALTER TABLE sync_states
+DROP COLUMN IF EXISTS watch_escalated_at,
+DROP COLUMN IF EXISTS watch_retry_at,
+DROP COLUMN IF EXISTS watch_failures;
@@ -0,0 +1,32 @@
+-- +migrate Up
+
+-- =============================================================================
+-- History Backfill (동기화 기간 이전 메일을 요청 시 가져오기)
+-- =============================================================================
+-- POST /email/backfill로 만든 저우선순위 작업. 페이지마다 page_token을 저장해
+-- 워커가 재시작되어도 이어서 가져오고, budget(메시지 수)을 넘으면 멈춘다.
+CREATE TABLE IF NOT EXISTS history_backfills (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    connection_id BIGINT NOT NULL REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    since TIMESTAMPTZ NOT NULL,
+    until TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, running, completed, failed, cancelled
+    page_token TEXT,                               -- 다음에 가져올 페이지 (체크포인트)
+    budget INT NOT NULL,                           -- 가져올 최대 메시지 수
+    fetched INT NOT NULL DEFAULT 0,
+    saved INT NOT NULL DEFAULT 0,                  -- 새로 저장된 메일 (이미 있던 메일 제외)
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+-- 연결당 진행 중인 backfill은 하나
+CREATE UNIQUE INDEX IF NOT EXISTS idx_history_backfills_active
+    ON history_backfills(connection_id) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_history_backfills_user ON history_backfills(user_id, created_at DESC);
+
+-- +migrate Down
+DROP TABLE IF EXISTS history_backfills;
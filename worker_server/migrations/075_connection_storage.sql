@@ -0,0 +1,29 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Connection Storage (연결별 저장 용량 측정값 + 상한)
+-- =============================================================================
+-- 상한(cap_bytes, NULL = STORAGE_CAP_MB 기본값)을 넘으면 metadata_only가 켜지고
+-- 본문 캐시(MongoDB)와 임베딩 저장을 멈춘다. 메일 메타데이터 동기화는 계속된다.
+CREATE TABLE IF NOT EXISTS connection_storage (
+    connection_id BIGINT PRIMARY KEY REFERENCES oauth_connections(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    metadata_count BIGINT NOT NULL DEFAULT 0,
+    metadata_bytes BIGINT NOT NULL DEFAULT 0,
+    body_count BIGINT NOT NULL DEFAULT 0,
+    body_bytes BIGINT NOT NULL DEFAULT 0,       -- MongoDB 압축 후 크기
+    attachment_count BIGINT NOT NULL DEFAULT 0,
+    attachment_bytes BIGINT NOT NULL DEFAULT 0,
+    vector_count BIGINT NOT NULL DEFAULT 0,
+    vector_bytes BIGINT NOT NULL DEFAULT 0,     -- emails.embedding (pgvector)
+    cap_bytes BIGINT,
+    metadata_only BOOLEAN NOT NULL DEFAULT FALSE,
+    metadata_only_since TIMESTAMPTZ,
+    measured_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_connection_storage_user ON connection_storage(user_id);
+CREATE INDEX IF NOT EXISTS idx_connection_storage_metadata_only ON connection_storage(connection_id) WHERE metadata_only;
+
+-- +migrate Down
+DROP TABLE IF EXISTS connection_storage;
@@ -0,0 +1,12 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Connection Sync Mode (metadata: 본문/임베딩 없이 메타데이터만 동기화)
+-- =============================================================================
+-- 본문은 열람 시 Provider에서 가져와 Redis에만 짧게 캐시한다.
+ALTER TABLE connection_storage
+    ADD COLUMN IF NOT EXISTS sync_mode VARCHAR(20) NOT NULL DEFAULT 'full'
+        CHECK (sync_mode IN ('full', 'metadata'));
+
+-- +migrate Down
+ALTER TABLE connection_storage DROP COLUMN IF EXISTS sync_mode;
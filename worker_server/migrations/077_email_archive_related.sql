@@ -0,0 +1,138 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Archive - 연관 데이터 보존
+-- =============================================================================
+-- 066의 archive_emails는 첨부/라벨만 related에 담아, emails DELETE cascade로
+-- 보안 분석(email_security), 활동 이력(email_activity), 분류 시도(ai_classify_attempts)를 잃었다.
+-- 이제 이들도 related에 담아 rehydrate 시 되돌리고, 링크 클릭(link_clicks, SET NULL)은 ID를 기억해 다시 연결한다.
+-- 지정 이력(email_assignment_history)이 있는 메일은 지정과 마찬가지로 hot 테이블에 남긴다.
+-- 임베딩은 벡터 검색이 아카이브도 찾을 수 있도록 별도 컬럼에 옮긴다.
+ALTER TABLE emails_archive ADD COLUMN IF NOT EXISTS embedding vector(1536);
+
+CREATE OR REPLACE FUNCTION archive_emails(p_user_id UUID, p_before TIMESTAMPTZ, p_limit INT)
+RETURNS SETOF BIGINT AS $$
+DECLARE
+    v_ids BIGINT[];
+    v_month DATE;
+BEGIN
+    SELECT array_agg(c.id) INTO v_ids FROM (
+        SELECT e.id FROM emails e
+        WHERE e.user_id = p_user_id
+          AND e.email_date < p_before
+          AND e.deleted_at IS NULL
+          AND NOT COALESCE(e.is_draft, FALSE)
+          AND (e.snooze_until IS NULL OR e.snooze_until < NOW())
+          AND NOT EXISTS (SELECT 1 FROM email_pins x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_read_later x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_notes x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_shares x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_comments x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_assignments x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_assignment_history x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_deadlines x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM workflow_board_cards x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_calendar_invites x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_slas x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_delivery_events x WHERE x.sent_email_id = e.id)
+        ORDER BY e.email_date
+        LIMIT p_limit
+        FOR UPDATE OF e SKIP LOCKED
+    ) c;
+
+    IF v_ids IS NULL THEN
+        RETURN;
+    END IF;
+
+    FOR v_month IN
+        SELECT DISTINCT date_trunc('month', e.email_date AT TIME ZONE 'UTC')::date
+        FROM emails e WHERE e.id = ANY(v_ids)
+    LOOP
+        PERFORM ensure_emails_archive_partition(v_month);
+    END LOOP;
+
+    INSERT INTO emails_archive (id, user_id, connection_id, external_id, email_date, data, related, embedding)
+    SELECT e.id, e.user_id, e.connection_id, e.external_id, e.email_date,
+        to_jsonb(e) - 'embedding',
+        jsonb_build_object(
+            'email_attachments', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM email_attachments x WHERE x.email_id = e.id), '[]'::jsonb),
+            'email_labels', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM email_labels x WHERE x.email_id = e.id), '[]'::jsonb),
+            'email_security', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM email_security x WHERE x.email_id = e.id), '[]'::jsonb),
+            'email_activity', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM email_activity x WHERE x.email_id = e.id), '[]'::jsonb),
+            'ai_classify_attempts', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM ai_classify_attempts x WHERE x.email_id = e.id), '[]'::jsonb),
+            'link_click_ids', COALESCE((SELECT jsonb_agg(x.id) FROM link_clicks x WHERE x.email_id = e.id), '[]'::jsonb)),
+        e.embedding
+    FROM emails e
+    WHERE e.id = ANY(v_ids);
+
+    RETURN QUERY DELETE FROM emails WHERE id = ANY(v_ids) RETURNING id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION rehydrate_emails(p_user_id UUID, p_ids BIGINT[])
+RETURNS SETOF BIGINT AS $$
+DECLARE
+    v_restored BIGINT[];
+BEGIN
+    WITH restored AS (
+        INSERT INTO emails
+        SELECT r.* FROM emails_archive a, jsonb_populate_record(NULL::emails, a.data) r
+        WHERE a.user_id = p_user_id AND a.id = ANY(p_ids)
+        ON CONFLICT DO NOTHING
+        RETURNING id
+    )
+    SELECT array_agg(id) INTO v_restored FROM restored;
+
+    IF v_restored IS NOT NULL THEN
+        UPDATE emails e SET embedding = a.embedding
+        FROM emails_archive a
+        WHERE a.user_id = p_user_id AND a.id = ANY(v_restored) AND a.id = e.id AND a.embedding IS NOT NULL;
+
+        INSERT INTO email_attachments
+        SELECT x.* FROM emails_archive a,
+            jsonb_populate_recordset(NULL::email_attachments, a.related->'email_attachments') x
+        WHERE a.user_id = p_user_id AND a.id = ANY(v_restored)
+        ON CONFLICT DO NOTHING;
+
+        -- 그 사이 삭제된 라벨은 건너뛴다
+        INSERT INTO email_labels
+        SELECT x.* FROM emails_archive a,
+            jsonb_populate_recordset(NULL::email_labels, a.related->'email_labels') x
+        WHERE a.user_id = p_user_id AND a.id = ANY(v_restored)
+          AND EXISTS (SELECT 1 FROM labels l WHERE l.id = x.label_id)
+        ON CONFLICT DO NOTHING;
+
+        -- 077 이전에 아카이브된 메일은 아래 키가 없다 (COALESCE로 건너뜀)
+        INSERT INTO email_security
+        SELECT x.* FROM emails_archive a,
+            jsonb_populate_recordset(NULL::email_security, COALESCE(a.related->'email_security', '[]'::jsonb)) x
+        WHERE a.user_id = p_user_id AND a.id = ANY(v_restored)
+        ON CONFLICT DO NOTHING;
+
+        INSERT INTO email_activity
+        SELECT x.* FROM emails_archive a,
+            jsonb_populate_recordset(NULL::email_activity, COALESCE(a.related->'email_activity', '[]'::jsonb)) x
+        WHERE a.user_id = p_user_id AND a.id = ANY(v_restored)
+        ON CONFLICT DO NOTHING;
+
+        INSERT INTO ai_classify_attempts
+        SELECT x.* FROM emails_archive a,
+            jsonb_populate_recordset(NULL::ai_classify_attempts, COALESCE(a.related->'ai_classify_attempts', '[]'::jsonb)) x
+        WHERE a.user_id = p_user_id AND a.id = ANY(v_restored)
+        ON CONFLICT DO NOTHING;
+
+        UPDATE link_clicks lc SET email_id = a.id
+        FROM emails_archive a, jsonb_array_elements_text(COALESCE(a.related->'link_click_ids', '[]'::jsonb)) c(id)
+        WHERE a.user_id = p_user_id AND a.id = ANY(v_restored)
+          AND lc.id = c.id::bigint AND lc.email_id IS NULL;
+    END IF;
+
+    DELETE FROM emails_archive WHERE user_id = p_user_id AND id = ANY(p_ids);
+
+    RETURN QUERY SELECT unnest(COALESCE(v_restored, '{}'::BIGINT[]));
+END;
+$$ LANGUAGE plpgsql;
+
+-- +migrate Down
+-- 함수는 066 정의로 되돌리지 않는다 (related의 추가 키는 066 rehydrate_emails에서 무시됨)
+ALTER TABLE emails_archive DROP COLUMN IF EXISTS embedding;
@@ -0,0 +1,69 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Archive - 가져온 메일(local) 제외
+-- =============================================================================
+-- 아카이브 시 본문 캐시를 지우고 다시 열면 Provider에서 가져오는데, MBOX/EML로 가져온 메일(local 연결)은
+-- 본문이 MongoDB에만 있어 되찾을 수 없다. 가져온 메일은 대개 오래되어 아카이브 대상이 되므로 제외한다.
+CREATE OR REPLACE FUNCTION archive_emails(p_user_id UUID, p_before TIMESTAMPTZ, p_limit INT)
+RETURNS SETOF BIGINT AS $$
+DECLARE
+    v_ids BIGINT[];
+    v_month DATE;
+BEGIN
+    SELECT array_agg(c.id) INTO v_ids FROM (
+        SELECT e.id FROM emails e
+        WHERE e.user_id = p_user_id
+          AND e.email_date < p_before
+          AND e.deleted_at IS NULL
+          AND NOT COALESCE(e.is_draft, FALSE)
+          AND NOT EXISTS (SELECT 1 FROM oauth_connections oc WHERE oc.id = e.connection_id AND oc.provider = 'local')
+          AND (e.snooze_until IS NULL OR e.snooze_until < NOW())
+          AND NOT EXISTS (SELECT 1 FROM email_pins x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_read_later x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_notes x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_shares x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_comments x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_assignments x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_assignment_history x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_deadlines x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM workflow_board_cards x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_calendar_invites x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_slas x WHERE x.email_id = e.id)
+          AND NOT EXISTS (SELECT 1 FROM email_delivery_events x WHERE x.sent_email_id = e.id)
+        ORDER BY e.email_date
+        LIMIT p_limit
+        FOR UPDATE OF e SKIP LOCKED
+    ) c;
+
+    IF v_ids IS NULL THEN
+        RETURN;
+    END IF;
+
+    FOR v_month IN
+        SELECT DISTINCT date_trunc('month', e.email_date AT TIME ZONE 'UTC')::date
+        FROM emails e WHERE e.id = ANY(v_ids)
+    LOOP
+        PERFORM ensure_emails_archive_partition(v_month);
+    END LOOP;
+
+    INSERT INTO emails_archive (id, user_id, connection_id, external_id, email_date, data, related, embedding)
+    SELECT e.id, e.user_id, e.connection_id, e.external_id, e.email_date,
+        to_jsonb(e) - 'embedding',
+        jsonb_build_object(
+            'email_attachments', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM email_attachments x WHERE x.email_id = e.id), '[]'::jsonb),
+            'email_labels', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM email_labels x WHERE x.email_id = e.id), '[]'::jsonb),
+            'email_security', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM email_security x WHERE x.email_id = e.id), '[]'::jsonb),
+            'email_activity', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM email_activity x WHERE x.email_id = e.id), '[]'::jsonb),
+            'ai_classify_attempts', COALESCE((SELECT jsonb_agg(to_jsonb(x)) FROM ai_classify_attempts x WHERE x.email_id = e.id), '[]'::jsonb),
+            'link_click_ids', COALESCE((SELECT jsonb_agg(x.id) FROM link_clicks x WHERE x.email_id = e.id), '[]'::jsonb)),
+        e.embedding
+    FROM emails e
+    WHERE e.id = ANY(v_ids);
+
+    RETURN QUERY DELETE FROM emails WHERE id = ANY(v_ids) RETURNING id;
+END;
+$$ LANGUAGE plpgsql;
+
+-- +migrate Down
+-- 077 정의로 되돌리지 않는다 (local 제외는 데이터를 바꾸지 않음)
@@ -0,0 +1,34 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Archive - 다시 동기화된 메일 되돌리기
+-- =============================================================================
+-- 동기화 중복 검사는 emails만 보므로, 아카이브된 메일을 다시 받으면(backfill, 오래된 메일의 delta)
+-- 새 ID로 hot 행이 하나 더 생겼다. 저장 전에 같은 (connection_id, external_id)의 아카이브 행을
+-- 원래 ID로 되돌려, upsert가 그 행을 갱신하게 한다.
+CREATE INDEX IF NOT EXISTS idx_emails_archive_connection_external ON emails_archive(connection_id, external_id);
+
+-- 연결의 아카이브된 메일 중 p_external_ids를 hot 테이블로 되돌리고 되돌린 ID를 돌려준다.
+CREATE OR REPLACE FUNCTION rehydrate_external_emails(p_connection_id BIGINT, p_external_ids TEXT[])
+RETURNS SETOF BIGINT AS $$
+DECLARE
+    v_user_id UUID;
+    v_ids BIGINT[];
+BEGIN
+    SELECT a.user_id, array_agg(a.id) INTO v_user_id, v_ids
+    FROM emails_archive a
+    WHERE a.connection_id = p_connection_id AND a.external_id = ANY(p_external_ids)
+    GROUP BY a.user_id
+    LIMIT 1;
+
+    IF v_ids IS NULL THEN
+        RETURN;
+    END IF;
+
+    RETURN QUERY SELECT rehydrate_emails(v_user_id, v_ids);
+END;
+$$ LANGUAGE plpgsql;
+
+-- +migrate Down
+DROP FUNCTION IF EXISTS rehydrate_external_emails(BIGINT, TEXT[]);
+DROP INDEX IF EXISTS idx_emails_archive_connection_external;
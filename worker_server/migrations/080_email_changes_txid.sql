@@ -0,0 +1,19 @@
+-- +migrate Up
+
+-- =============================================================================
+-- Email Change Log - 트랜잭션 순서 커서
+-- =============================================================================
+-- id(BIGSERIAL)는 INSERT 시점에 받지만 커밋 순서는 다르다: 큰 COPY 동기화가 작은 id를 쥔 채
+-- 진행 중일 때 짧은 사용자 작업이 큰 id로 먼저 커밋되면, 그 사이 폴링한 클라이언트의 커서가
+-- 작은 id를 지나쳐 그 변경을 영영 받지 못했다.
+-- 기록한 트랜잭션 ID를 함께 저장하고, 진행 중인 가장 오래된 트랜잭션(snapshot xmin)보다 앞선
+-- 변경만 (tx_id, id) 순서로 돌려준다. 그 범위는 더 바뀌지 않고, 이후 변경은 모두 그 뒤에 온다.
+ALTER TABLE email_changes ADD COLUMN IF NOT EXISTS tx_id xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX IF NOT EXISTS idx_email_changes_user_tx ON email_changes(user_id, tx_id, id);
+DROP INDEX IF EXISTS idx_email_changes_user;
+
+-- +migrate Down
+CREATE INDEX IF NOT EXISTS idx_email_changes_user ON email_changes(user_id, id);
+DROP INDEX IF EXISTS idx_email_changes_user_tx;
+ALTER TABLE email_changes DROP COLUMN IF EXISTS tx_id;
@@ -30,19 +30,33 @@ const (
 	CodeDatabaseError = "DATABASE_ERROR"
 	CodeExternalError = "EXTERNAL_ERROR"
 
+	// Provider errors (Gmail, Outlook ...)
+	CodeProviderAuthFailed   = "PROVIDER_AUTH_FAILED" // 토큰 만료/권한 철회 - 재연결 필요
+	CodeProviderRateLimited  = "PROVIDER_RATE_LIMITED"
+	CodeProviderNotFound     = "PROVIDER_NOT_FOUND"
+	CodeProviderInvalidInput = "PROVIDER_INVALID_INPUT"
+	CodeProviderSyncRequired = "PROVIDER_SYNC_REQUIRED"
+	CodeProviderTooLarge     = "PROVIDER_TOO_LARGE"
+	CodeProviderUnavailable  = "PROVIDER_UNAVAILABLE"
+
+	// Rate limit errors
+	CodeRateLimited = "RATE_LIMITED"
+
 	// Internal errors
 	CodeInternalError = "INTERNAL_ERROR"
 	CodeConfigError   = "CONFIG_ERROR"
+	CodeNotConfigured = "NOT_CONFIGURED"
 	CodeTimeout       = "TIMEOUT"
 )
 
 // AppError represents a structured application error
 type AppError struct {
-	Code    string         `json:"code"`
-	Message string         `json:"message"`
-	Status  int            `json:"-"`
-	Details map[string]any `json:"details,omitempty"`
-	Err     error          `json:"-"`
+	Code      string         `json:"code"`
+	Message   string         `json:"message"`
+	Status    int            `json:"-"`
+	Details   map[string]any `json:"details,omitempty"`
+	Retryable bool           `json:"-"`
+	Err       error          `json:"-"`
 }
 
 func (e *AppError) Error() string {
@@ -213,6 +227,35 @@ func ExternalError(service string, err error) *AppError {
 	}
 }
 
+// Provider returns an error raised by an external mail/calendar provider.
+func Provider(code, provider, message string, status int, retryable bool, err error) *AppError {
+	e := &AppError{
+		Code:      code,
+		Message:   message,
+		Status:    status,
+		Retryable: retryable,
+		Err:       err,
+	}
+	if provider != "" {
+		e.WithDetail("provider", provider)
+	}
+	return e
+}
+
+// RateLimited returns a 429 error; retryAfter is in seconds (0 = unknown).
+func RateLimited(retryAfter int) *AppError {
+	e := &AppError{
+		Code:      CodeRateLimited,
+		Message:   "too many requests",
+		Status:    http.StatusTooManyRequests,
+		Retryable: true,
+	}
+	if retryAfter > 0 {
+		e.WithDetail("retry_after", retryAfter)
+	}
+	return e
+}
+
 // Internal errors
 func Internal(message string) *AppError {
 	if message == "" {
@@ -242,11 +285,22 @@ func ConfigError(message string) *AppError {
 	}
 }
 
+// NotConfigured reports a feature whose backing component isn't wired in this deployment.
+func NotConfigured(component string) *AppError {
+	return &AppError{
+		Code:    CodeNotConfigured,
+		Message: fmt.Sprintf("%s not configured", component),
+		Status:  http.StatusServiceUnavailable,
+		Details: map[string]any{"component": component},
+	}
+}
+
 func Timeout(operation string) *AppError {
 	return &AppError{
-		Code:    CodeTimeout,
-		Message: fmt.Sprintf("operation timed out: %s", operation),
-		Status:  http.StatusGatewayTimeout,
+		Code:      CodeTimeout,
+		Message:   fmt.Sprintf("operation timed out: %s", operation),
+		Status:    http.StatusGatewayTimeout,
+		Retryable: true,
 	}
 }
 
@@ -258,7 +312,7 @@ var (
 	ErrBadRequest   = BadRequest("bad request")
 	ErrInternal     = Internal("")
 	ErrConflict     = Conflict("resource conflict")
-	ErrRateLimited  = New("RATE_LIMITED", "too many requests", http.StatusTooManyRequests)
+	ErrRateLimited  = RateLimited(0)
 )
 
 // Helper functions
@@ -0,0 +1,141 @@
+package apperr
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ProblemContentType is the RFC 7807 media type for error responses.
+const ProblemContentType = "application/problem+json"
+
+// problemTypeBase prefixes problem type URIs (relative reference, RFC 7807 §3.1).
+const problemTypeBase = "/problems/"
+
+// Category groups error codes so clients can branch without knowing every code.
+type Category string
+
+const (
+	CategoryAuth       Category = "auth"
+	CategoryValidation Category = "validation"
+	CategoryResource   Category = "resource"
+	CategoryProvider   Category = "provider"
+	CategoryRateLimit  Category = "rate_limit"
+	CategoryInternal   Category = "internal"
+)
+
+// Problem is an RFC 7807 problem details body.
+// code/category/retryable은 프론트엔드 분기용 확장 필드다.
+type Problem struct {
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	Status    int            `json:"status"`
+	Detail    string         `json:"detail,omitempty"`
+	Instance  string         `json:"instance,omitempty"`
+	Code      string         `json:"code"`
+	Category  Category       `json:"category"`
+	Retryable bool           `json:"retryable"`
+	RequestID string         `json:"request_id,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+// Problem converts the error into a problem details body.
+func (e *AppError) Problem(instance, requestID string) *Problem {
+	status := e.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	return &Problem{
+		Type:      ProblemType(e.Code),
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    e.Message,
+		Instance:  instance,
+		Code:      e.Code,
+		Category:  CategoryOf(e.Code, status),
+		Retryable: e.Retryable,
+		RequestID: requestID,
+		Details:   e.Details,
+	}
+}
+
+// WriteProblem writes the error as application/problem+json (instance = request path).
+func WriteProblem(c *fiber.Ctx, e *AppError) error {
+	requestID, _ := c.Locals("request_id").(string)
+	problem := e.Problem(c.Path(), requestID)
+	if retryAfter, ok := e.Details["retry_after"].(int); ok && retryAfter > 0 {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+	}
+	return c.Status(problem.Status).JSON(problem, ProblemContentType)
+}
+
+// ProblemType returns the type URI of an error code (PROVIDER_AUTH_FAILED → /problems/provider-auth-failed).
+func ProblemType(code string) string {
+	if code == "" {
+		return "about:blank"
+	}
+	return problemTypeBase + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+}
+
+// CategoryOf returns the category of an error code; unknown codes fall back to the status.
+func CategoryOf(code string, status int) Category {
+	switch code {
+	case CodeUnauthorized, CodeInvalidToken, CodeTokenExpired, CodeForbidden, CodeOAuthFailed:
+		return CategoryAuth
+	case CodeValidationFailed, CodeBadRequest, CodeInvalidInput, CodeMissingField:
+		return CategoryValidation
+	case CodeNotFound, CodeAlreadyExists, CodeConflict:
+		return CategoryResource
+	case CodeRateLimited:
+		return CategoryRateLimit
+	}
+	if strings.HasPrefix(code, "PROVIDER_") || code == CodeExternalError {
+		return CategoryProvider
+	}
+
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return CategoryAuth
+	case status == http.StatusNotFound || status == http.StatusConflict:
+		return CategoryResource
+	case status == http.StatusTooManyRequests:
+		return CategoryRateLimit
+	case status >= 400 && status < 500:
+		return CategoryValidation
+	default:
+		return CategoryInternal
+	}
+}
+
+// CodeForStatus returns the default error code for an HTTP status.
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusUnprocessableEntity:
+		return CodeValidationFailed
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusBadGateway:
+		return CodeExternalError
+	case http.StatusServiceUnavailable:
+		return CodeNotConfigured
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	default:
+		if status >= 500 {
+			return CodeInternalError
+		}
+		return CodeBadRequest
+	}
+}
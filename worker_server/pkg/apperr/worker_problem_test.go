@@ -0,0 +1,46 @@
+package apperr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProblemFromAppError(t *testing.T) {
+	err := Provider(CodeProviderRateLimited, "gmail", "provider rate limit exceeded", 429, true, errors.New("quota"))
+	p := err.Problem("/api/v1/email", "req-1")
+
+	if p.Type != "/problems/provider-rate-limited" {
+		t.Errorf("Type = %q", p.Type)
+	}
+	if p.Title != "Too Many Requests" || p.Status != 429 {
+		t.Errorf("Title/Status = %q/%d", p.Title, p.Status)
+	}
+	if p.Category != CategoryProvider || !p.Retryable {
+		t.Errorf("Category/Retryable = %q/%v", p.Category, p.Retryable)
+	}
+	if p.Details["provider"] != "gmail" || p.Instance != "/api/v1/email" || p.RequestID != "req-1" {
+		t.Errorf("unexpected extensions: %+v", p)
+	}
+}
+
+func TestCategoryOf(t *testing.T) {
+	cases := []struct {
+		code   string
+		status int
+		want   Category
+	}{
+		{CodeTokenExpired, 401, CategoryAuth},
+		{CodeMissingField, 400, CategoryValidation},
+		{CodeNotFound, 404, CategoryResource},
+		{CodeRateLimited, 429, CategoryRateLimit},
+		{CodeProviderAuthFailed, 403, CategoryProvider},
+		{CodeNotConfigured, 503, CategoryInternal},
+		{"TOKEN_REVOKED", 401, CategoryAuth},
+		{"UNSUPPORTED_MEDIA_TYPE", 415, CategoryValidation},
+	}
+	for _, tc := range cases {
+		if got := CategoryOf(tc.code, tc.status); got != tc.want {
+			t.Errorf("CategoryOf(%s, %d) = %q, want %q", tc.code, tc.status, got, tc.want)
+		}
+	}
+}
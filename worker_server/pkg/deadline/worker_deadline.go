@@ -0,0 +1,298 @@
+// Package deadline extracts deadlines mentioned in email subjects and bodies.
+//
+// 규칙 기반(영어/한국어)으로 "by Friday 5pm", "3월 5일까지", "tomorrow EOD" 같은
+// 표현을 찾아 기준 시각(사용자 시간대) 기준의 절대 시각으로 바꾼다.
+package deadline
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxScanLength - 본문은 앞부분만 본다 (인용된 이전 메일까지 훑지 않도록)
+	maxScanLength = 4000
+	// maxHorizon - 이보다 먼 날짜는 마감일로 보지 않는다
+	maxHorizon = 366 * 24 * time.Hour
+	// maxTextLength - Match.Text 최대 길이
+	maxTextLength = 100
+
+	endOfDayHour      = 23
+	endOfDayMinute    = 59
+	endOfBusinessHour = 18
+	tonightHour       = 21
+)
+
+// Match is a deadline found in text.
+type Match struct {
+	At   time.Time // 마감 시각 (ref의 시간대)
+	Text string    // 원문 표현 (예: "by friday 5pm")
+	Cued bool      // "by", "due", "까지" 같은 마감 표현이 붙었는지
+}
+
+// monthNames matches English month names and abbreviations (앞 세 글자로 월을 찾는다).
+const monthNames = `jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sept?(?:ember)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?`
+
+type candidate struct {
+	start, end int
+	year       int // 0이면 추론
+	month      time.Month
+	day        int
+	hour, min  int // hour < 0이면 시각 없음
+	needsCue   bool
+}
+
+var (
+	relativeRe = regexp.MustCompile(`\b(today|tonight|tomorrow|eod|end of (?:the )?day|cob|close of business|end of (?:the )?week|eow)\b|(오늘|내일|모레|이번\s*주|금주)`)
+	weekdayRe  = regexp.MustCompile(`\b(?:(next|this)\s+)?(monday|tuesday|wednesday|thursday|friday|saturday|sunday)\b|(다음\s*주\s*|이번\s*주\s*)?([월화수목금토일])요일`)
+	monthDayRe = regexp.MustCompile(`\b(` + monthNames + `)\.?\s+(\d{1,2})(?:st|nd|rd|th)?\b(?:,?\s+(\d{4})\b)?`)
+	dayMonthRe = regexp.MustCompile(`\b(\d{1,2})(?:st|nd|rd|th)?\s+(` + monthNames + `)\b(?:\.?,?\s+(\d{4})\b)?`)
+	isoRe      = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)
+	slashRe    = regexp.MustCompile(`\b(\d{1,2})/(\d{1,2})(?:/(\d{4}|\d{2}))?\b`)
+	koreanRe   = regexp.MustCompile(`(?:(\d{4})\s*년\s*)?(\d{1,2})\s*월\s*(\d{1,2})\s*일`)
+
+	timeEnRe = regexp.MustCompile(`^[\s,]*(?:at\s+|@\s*)?(\d{1,2})(?::(\d{2}))?\s*(am|pm|a\.m\.|p\.m\.)`)
+	time24Re = regexp.MustCompile(`^[\s,]*(?:at\s+|@\s*)?([01]?\d|2[0-3]):([0-5]\d)\b`)
+	timeKoRe = regexp.MustCompile(`^\s*(오전|오후)?\s*(\d{1,2})\s*시(?:\s*(\d{1,2})\s*분)?`)
+
+	cueBeforeRe = regexp.MustCompile(`(?:\bby|\bdue(?:\s+(?:on|by|date:?))?|\buntil|\btill|\bbefore|no later than|deadline(?:\s+is)?:?|\bexpires?(?:\s+on)?|\bends?(?:\s+on)?)\s*(?:the\s+|this\s+|next\s+)?$`)
+	cueAfterRe  = regexp.MustCompile(`^\s*(?:까지|전까지|마감|이내)`)
+)
+
+var monthAbbr = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+	"일": time.Sunday, "월": time.Monday, "화": time.Tuesday, "수": time.Wednesday,
+	"목": time.Thursday, "금": time.Friday, "토": time.Saturday,
+}
+
+// Extract finds the most relevant upcoming deadline in text.
+// ref는 메일 수신 시각을 사용자 시간대로 바꾼 값이며, 상대 표현(내일, 금요일)의 기준이 된다.
+// 마감 표현이 붙은 날짜를 우선하고, 같은 조건이면 가장 이른 날짜를 고른다.
+func Extract(text string, ref time.Time) (Match, bool) {
+	if len(text) > maxScanLength {
+		text = text[:maxScanLength]
+	}
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// 소문자 변환으로 바이트 길이가 바뀌면 원문 인덱스를 쓸 수 없다
+		text = lower
+	}
+
+	var best Match
+	found := false
+	for _, c := range findCandidates(lower, ref) {
+		cued := hasCue(lower, c.start, c.end)
+		if c.needsCue && !cued {
+			continue
+		}
+		at, ok := resolve(c, ref)
+		if !ok {
+			continue
+		}
+		if !found || (cued && !best.Cued) || (cued == best.Cued && at.Before(best.At)) {
+			best = Match{At: at, Text: matchText(text, lower, c), Cued: cued}
+			found = true
+		}
+	}
+	return best, found
+}
+
+func findCandidates(s string, ref time.Time) []candidate {
+	var out []candidate
+	today := func() (int, time.Month, int) { return ref.Date() }
+	addDays := func(days int) (int, time.Month, int) { return ref.AddDate(0, 0, days).Date() }
+
+	for _, m := range relativeRe.FindAllStringSubmatchIndex(s, -1) {
+		word := strings.Join(strings.Fields(group(s, m, 1)+group(s, m, 2)), " ")
+		c := candidate{start: m[0], end: m[1], hour: -1}
+		switch word {
+		case "today", "오늘":
+			c.year, c.month, c.day = today()
+		case "tonight":
+			c.year, c.month, c.day = today()
+			c.hour = tonightHour
+		case "eod", "end of day", "end of the day", "cob", "close of business":
+			c.year, c.month, c.day = today()
+			c.hour = endOfBusinessHour
+		case "tomorrow", "내일":
+			c.year, c.month, c.day = addDays(1)
+		case "모레":
+			c.year, c.month, c.day = addDays(2)
+		default: // end of week, eow, 이번 주, 금주
+			c.year, c.month, c.day = addDays(daysUntil(ref.Weekday(), time.Friday))
+			// "이번 주" 단독은 흔한 표현이라 마감 표현이 붙은 경우만 본다
+			c.needsCue = strings.HasPrefix(word, "이번") || word == "금주"
+		}
+		out = append(out, c)
+	}
+
+	for _, m := range weekdayRe.FindAllStringSubmatchIndex(s, -1) {
+		modifier, name := group(s, m, 1), group(s, m, 2)
+		if name == "" {
+			modifier, name = strings.ReplaceAll(group(s, m, 3), " ", ""), group(s, m, 4)
+		}
+		ahead := daysUntil(ref.Weekday(), weekdayNames[name])
+		if modifier == "next" || modifier == "다음주" {
+			// 다음 주(월요일 시작)의 해당 요일
+			toMonday := daysUntil(ref.Weekday(), time.Monday)
+			if toMonday == 0 {
+				toMonday = 7
+			}
+			ahead = toMonday + (int(weekdayNames[name])+6)%7
+		}
+		c := candidate{start: m[0], end: m[1], hour: -1}
+		c.year, c.month, c.day = addDays(ahead)
+		out = append(out, c)
+	}
+
+	for _, m := range monthDayRe.FindAllStringSubmatchIndex(s, -1) {
+		day, _ := strconv.Atoi(group(s, m, 2))
+		year, _ := strconv.Atoi(group(s, m, 3))
+		out = append(out, candidate{start: m[0], end: m[1], year: year, month: monthAbbr[group(s, m, 1)[:3]], day: day, hour: -1})
+	}
+	for _, m := range dayMonthRe.FindAllStringSubmatchIndex(s, -1) {
+		day, _ := strconv.Atoi(group(s, m, 1))
+		year, _ := strconv.Atoi(group(s, m, 3))
+		out = append(out, candidate{start: m[0], end: m[1], year: year, month: monthAbbr[group(s, m, 2)[:3]], day: day, hour: -1})
+	}
+	for _, m := range isoRe.FindAllStringSubmatchIndex(s, -1) {
+		year, _ := strconv.Atoi(group(s, m, 1))
+		month, _ := strconv.Atoi(group(s, m, 2))
+		day, _ := strconv.Atoi(group(s, m, 3))
+		out = append(out, candidate{start: m[0], end: m[1], year: year, month: time.Month(month), day: day, hour: -1})
+	}
+	for _, m := range slashRe.FindAllStringSubmatchIndex(s, -1) {
+		// M/D는 분수·버전 번호와 구분이 안 되므로 마감 표현이 붙은 경우만 본다
+		month, _ := strconv.Atoi(group(s, m, 1))
+		day, _ := strconv.Atoi(group(s, m, 2))
+		year, _ := strconv.Atoi(group(s, m, 3))
+		if year > 0 && year < 100 {
+			year += 2000
+		}
+		out = append(out, candidate{start: m[0], end: m[1], year: year, month: time.Month(month), day: day, hour: -1, needsCue: true})
+	}
+	for _, m := range koreanRe.FindAllStringSubmatchIndex(s, -1) {
+		year, _ := strconv.Atoi(group(s, m, 1))
+		month, _ := strconv.Atoi(group(s, m, 2))
+		day, _ := strconv.Atoi(group(s, m, 3))
+		out = append(out, candidate{start: m[0], end: m[1], year: year, month: time.Month(month), day: day, hour: -1})
+	}
+
+	for i := range out {
+		parseTimeAfter(s, &out[i])
+	}
+	return out
+}
+
+// parseTimeAfter reads an explicit time right after the date ("friday 5pm", "3월 5일 오후 3시").
+func parseTimeAfter(s string, c *candidate) {
+	rest := s[c.end:]
+	if m := timeEnRe.FindStringSubmatchIndex(rest); m != nil {
+		hour, _ := strconv.Atoi(group(rest, m, 1))
+		minute, _ := strconv.Atoi(group(rest, m, 2))
+		if hour < 1 || hour > 12 {
+			return
+		}
+		if strings.HasPrefix(group(rest, m, 3), "p") && hour != 12 {
+			hour += 12
+		} else if strings.HasPrefix(group(rest, m, 3), "a") && hour == 12 {
+			hour = 0
+		}
+		c.hour, c.min, c.end = hour, minute, c.end+m[1]
+		return
+	}
+	if m := time24Re.FindStringSubmatchIndex(rest); m != nil {
+		hour, _ := strconv.Atoi(group(rest, m, 1))
+		minute, _ := strconv.Atoi(group(rest, m, 2))
+		c.hour, c.min, c.end = hour, minute, c.end+m[1]
+		return
+	}
+	if m := timeKoRe.FindStringSubmatchIndex(rest); m != nil {
+		hour, _ := strconv.Atoi(group(rest, m, 2))
+		minute, _ := strconv.Atoi(group(rest, m, 3))
+		if hour > 23 {
+			return
+		}
+		if group(rest, m, 1) == "오후" && hour < 12 {
+			hour += 12
+		}
+		c.hour, c.min, c.end = hour, minute, c.end+m[1]
+	}
+}
+
+// resolve turns a candidate into an absolute time in ref's location.
+// 지난 날짜(인용된 이전 메일 등)와 너무 먼 날짜는 버린다.
+func resolve(c candidate, ref time.Time) (time.Time, bool) {
+	if c.month < time.January || c.month > time.December || c.day < 1 || c.day > 31 {
+		return time.Time{}, false
+	}
+	hour, minute := endOfDayHour, endOfDayMinute
+	if c.hour >= 0 {
+		hour, minute = c.hour, c.min
+	}
+
+	loc := ref.Location()
+	startOfToday := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, loc)
+	year := c.year
+	if year == 0 {
+		year = ref.Year()
+		// 연도 없는 날짜가 한 달 이상 지났으면 내년으로 본다 (12월 메일의 "Jan 5")
+		if time.Date(year, c.month, c.day, 0, 0, 0, 0, loc).Before(startOfToday.AddDate(0, -1, 0)) {
+			year++
+		}
+	}
+
+	at := time.Date(year, c.month, c.day, hour, minute, 0, 0, loc)
+	if at.Day() != c.day { // 2월 30일 같은 잘못된 날짜
+		return time.Time{}, false
+	}
+	if at.Before(startOfToday) || at.Sub(ref) > maxHorizon {
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+// hasCue reports whether a deadline expression surrounds s[start:end].
+func hasCue(s string, start, end int) bool {
+	before := s[max(0, start-24):start]
+	return cueBeforeRe.MatchString(before) || cueAfterRe.MatchString(s[end:min(len(s), end+12)])
+}
+
+// matchText returns the original phrase including a leading cue word.
+func matchText(text, lower string, c candidate) string {
+	start := c.start
+	before := lower[max(0, start-24):start]
+	if loc := cueBeforeRe.FindStringIndex(before); loc != nil {
+		start -= len(before) - loc[0]
+	}
+	end := c.end
+	if loc := cueAfterRe.FindStringIndex(lower[end:min(len(lower), end+12)]); loc != nil {
+		end += loc[1]
+	}
+	phrase := strings.Join(strings.Fields(text[start:end]), " ")
+	if len(phrase) > maxTextLength {
+		phrase = phrase[:maxTextLength]
+	}
+	return phrase
+}
+
+// daysUntil returns days from one weekday to the next occurrence of another (0 if same day).
+func daysUntil(from, to time.Weekday) int {
+	return (int(to) - int(from) + 7) % 7
+}
+
+func group(s string, m []int, i int) string {
+	if 2*i+1 >= len(m) || m[2*i] < 0 {
+		return ""
+	}
+	return s[m[2*i]:m[2*i+1]]
+}
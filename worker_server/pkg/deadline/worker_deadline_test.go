@@ -0,0 +1,67 @@
+package deadline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExtract(t *testing.T) {
+	seoul, _ := time.LoadLocation("Asia/Seoul")
+	// 2026-03-04 (수) 10:00 KST
+	ref := time.Date(2026, 3, 4, 10, 0, 0, 0, seoul)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, seoul)
+	}
+
+	cases := []struct {
+		name string
+		text string
+		want time.Time
+		cued bool
+		ok   bool
+	}{
+		{"tomorrow", "Can you review this tomorrow?", at(3, 5, 23, 59), false, true},
+		{"by weekday with time", "Please send the report by Friday 5pm.", at(3, 6, 17, 0), true, true},
+		{"next weekday", "Let's finish next Monday", at(3, 9, 23, 59), false, true},
+		{"same weekday is today", "due Wednesday", at(3, 4, 23, 59), true, true},
+		{"eod", "Need this EOD", at(3, 4, 18, 0), false, true},
+		{"month day", "Submission deadline: March 20th", at(3, 20, 23, 59), true, true},
+		{"day month with year", "Offer ends 2 April 2026", at(4, 2, 23, 59), true, true},
+		{"iso with 24h time", "Due on 2026-03-10 14:30", at(3, 10, 14, 30), true, true},
+		{"korean date", "3월 12일 오후 3시까지 회신 부탁드립니다", at(3, 12, 15, 0), true, true},
+		{"korean weekday", "다음 주 화요일까지 제출", at(3, 10, 23, 59), true, true},
+		{"korean relative", "내일 회의 자료 공유드립니다", at(3, 5, 23, 59), false, true},
+		{"cued date wins over earlier mention", "Meeting tomorrow. Final draft due by March 15.", at(3, 15, 23, 59), true, true},
+		{"year rollover", "Renewal on Jan 5", time.Date(2027, 1, 5, 23, 59, 0, 0, seoul), false, true},
+		{"slash date needs cue", "Version 3/4 of the spec", time.Time{}, false, false},
+		{"cued slash date", "Please pay by 3/18", at(3, 18, 23, 59), true, true},
+		{"past date ignored", "On Mon, Mar 2, 2026 someone wrote:", time.Time{}, false, false},
+		{"no date", "Thanks for the update!", time.Time{}, false, false},
+		{"not a month", "Our marketing 5 step plan", time.Time{}, false, false},
+	}
+
+	for _, tc := range cases {
+		got, ok := Extract(tc.text, ref)
+		if ok != tc.ok {
+			t.Errorf("%s: ok=%v, want %v (%+v)", tc.name, ok, tc.ok, got)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if !got.At.Equal(tc.want) {
+			t.Errorf("%s: at=%v, want %v", tc.name, got.At, tc.want)
+		}
+		if got.Cued != tc.cued {
+			t.Errorf("%s: cued=%v, want %v (text %q)", tc.name, got.Cued, tc.cued, got.Text)
+		}
+	}
+}
+
+func TestExtractText(t *testing.T) {
+	ref := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	got, ok := Extract("Hi team,\nPlease send it By  Friday 5pm thanks", ref)
+	if !ok || got.Text != "By Friday 5pm" {
+		t.Errorf("unexpected match text %q (ok=%v)", got.Text, ok)
+	}
+}
@@ -0,0 +1,55 @@
+package htmlsanitize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	cssCommentRe = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	cssEscapeRe  = regexp.MustCompile(`\\([0-9a-fA-F]{1,6})\s?|\\(.)`)
+	// 선언/규칙 단위 세그먼트 ({, }, ; 로 구분)
+	cssSegmentRe = regexp.MustCompile(`[^;{}]+`)
+	// 스크립트 실행 또는 외부 리소스 로드가 가능한 CSS
+	cssDangerRe    = regexp.MustCompile(`expression\s*\(|javascript:|vbscript:|behavior\s*:|-moz-binding|@import|url\s*\(\s*['"]?\s*data:text`)
+	cssRemoteURLRe = regexp.MustCompile(`url\s*\(\s*['"]?\s*(https?:)?//`)
+)
+
+// SanitizeCSS removes dangerous declarations from a style attribute or <style> block.
+// strict 모드에서는 원격 url() 참조도 제거한다.
+func SanitizeCSS(css string, level Level) string {
+	if level == LevelOff || css == "" {
+		return css
+	}
+
+	css = cssCommentRe.ReplaceAllString(css, "")
+	return cssSegmentRe.ReplaceAllStringFunc(css, func(seg string) string {
+		normalized := strings.ToLower(decodeCSSEscapes(seg))
+		if cssDangerRe.MatchString(normalized) {
+			return ""
+		}
+		if level == LevelStrict && cssRemoteURLRe.MatchString(normalized) {
+			return ""
+		}
+		return seg
+	})
+}
+
+// decodeCSSEscapes resolves "\65 xpression" / "\e" style escapes used for obfuscation.
+func decodeCSSEscapes(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	return cssEscapeRe.ReplaceAllStringFunc(s, func(m string) string {
+		sub := cssEscapeRe.FindStringSubmatch(m)
+		if sub[1] != "" {
+			n, err := strconv.ParseUint(sub[1], 16, 32)
+			if err != nil || n == 0 || n > 0x10FFFF {
+				return ""
+			}
+			return string(rune(n))
+		}
+		return sub[2]
+	})
+}
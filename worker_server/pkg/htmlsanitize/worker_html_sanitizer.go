@@ -0,0 +1,319 @@
+// Package htmlsanitize strips active content and trackers from email HTML.
+package htmlsanitize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Level controls how aggressively HTML is sanitized.
+type Level string
+
+const (
+	// LevelStrict also removes <style> blocks, forms, media, comments and remote CSS urls.
+	LevelStrict Level = "strict"
+	// LevelStandard removes scripts, embeds, event handlers, dangerous CSS and tracking pixels.
+	LevelStandard Level = "standard"
+	// LevelOff returns the HTML as-is.
+	LevelOff Level = "off"
+)
+
+// ParseLevel parses the sanitize query value. Empty means standard.
+func ParseLevel(s string) (Level, error) {
+	switch Level(strings.ToLower(strings.TrimSpace(s))) {
+	case "", LevelStandard:
+		return LevelStandard, nil
+	case LevelStrict:
+		return LevelStrict, nil
+	case LevelOff:
+		return LevelOff, nil
+	}
+	return "", fmt.Errorf("invalid sanitize level: %s", s)
+}
+
+// Result is the outcome of sanitizing a document.
+type Result struct {
+	HTML            string
+	TrackersBlocked int // 제거된 추적 픽셀 수
+	RemovedElements int // 제거된 위험 요소 수 (script, iframe ...)
+}
+
+// 내용까지 통째로 제거하는 요소
+var dropStandard = map[string]bool{
+	"script": true, "noscript": true, "iframe": true, "frame": true, "frameset": true,
+	"object": true, "embed": true, "applet": true, "template": true, "svg": true,
+	"math": true, "base": true, "meta": true, "link": true, "portal": true,
+}
+
+// strict 모드에서 추가로 제거하는 요소
+var dropStrict = map[string]bool{
+	"style": true, "form": true, "input": true, "button": true, "select": true,
+	"textarea": true, "audio": true, "video": true, "source": true, "track": true,
+}
+
+// 태그만 제거하고 자식은 남기는 요소 (standard)
+var unwrapStandard = map[string]bool{
+	"form": true,
+}
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true,
+	"img": true, "input": true, "link": true, "meta": true, "source": true,
+	"track": true, "wbr": true,
+}
+
+// URL을 담는 속성
+var urlAttrs = map[string]bool{
+	"href": true, "src": true, "action": true, "background": true, "poster": true,
+	"cite": true, "longdesc": true, "lowsrc": true, "dynsrc": true, "xlink:href": true,
+}
+
+// data:image/ 를 허용하는 속성 (인라인 이미지)
+var dataImageAttrs = map[string]bool{
+	"src": true, "background": true, "poster": true,
+}
+
+var droppedAttrs = map[string]bool{
+	"srcdoc": true, "formaction": true, "ping": true,
+}
+
+// Sanitize removes active content from HTML according to level.
+func Sanitize(src string, level Level) *Result {
+	result := &Result{HTML: src}
+	if level == LevelOff || src == "" {
+		return result
+	}
+
+	z := html.NewTokenizer(strings.NewReader(src))
+	var b strings.Builder
+	b.Grow(len(src))
+
+	skipName := ""
+	skipDepth := 0
+	inStyle := false
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			// io.EOF 이외의 에러(파싱 불가)면 남은 입력은 버린다
+			break
+		}
+		raw := string(z.Raw())
+		tok := z.Token()
+
+		if skipName != "" {
+			switch tt {
+			case html.StartTagToken:
+				if tok.Data == skipName {
+					skipDepth++
+				}
+			case html.EndTagToken:
+				if tok.Data == skipName {
+					skipDepth--
+					if skipDepth == 0 {
+						skipName = ""
+					}
+				}
+			}
+			continue
+		}
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name := tok.Data
+			if shouldDrop(name, level) {
+				result.RemovedElements++
+				if tt == html.StartTagToken && !voidElements[name] {
+					skipName = name
+					skipDepth = 1
+				}
+				continue
+			}
+			if level == LevelStandard && unwrapStandard[name] {
+				continue
+			}
+			if name == "img" && isTrackingPixel(&tok) {
+				result.TrackersBlocked++
+				continue
+			}
+			sanitizeAttrs(&tok, level)
+			if name == "style" && tt == html.StartTagToken {
+				inStyle = true
+			}
+			b.WriteString(tok.String())
+
+		case html.EndTagToken:
+			name := tok.Data
+			if shouldDrop(name, level) || (level == LevelStandard && unwrapStandard[name]) {
+				continue
+			}
+			if name == "style" {
+				inStyle = false
+			}
+			b.WriteString(raw)
+
+		case html.TextToken:
+			if inStyle {
+				b.WriteString(SanitizeCSS(raw, level))
+			} else {
+				b.WriteString(raw)
+			}
+
+		case html.CommentToken:
+			// 조건부 주석(<!--[if mso]>)은 Outlook 레이아웃에 필요해서 standard에서는 유지
+			if level != LevelStrict {
+				b.WriteString(raw)
+			}
+
+		case html.DoctypeToken:
+			b.WriteString(raw)
+		}
+	}
+
+	result.HTML = b.String()
+	return result
+}
+
+func shouldDrop(name string, level Level) bool {
+	return dropStandard[name] || (level == LevelStrict && dropStrict[name])
+}
+
+func sanitizeAttrs(tok *html.Token, level Level) {
+	attrs := tok.Attr[:0]
+	for _, attr := range tok.Attr {
+		key := strings.ToLower(attr.Key)
+
+		switch {
+		case strings.HasPrefix(key, "on"), droppedAttrs[key]:
+			continue
+		case urlAttrs[key]:
+			if !isSafeURL(attr.Val, dataImageAttrs[key]) {
+				continue
+			}
+			if level == LevelStrict && key == "background" && isRemoteURL(attr.Val) {
+				continue
+			}
+		case key == "style":
+			attr.Val = SanitizeCSS(attr.Val, level)
+			if strings.TrimSpace(attr.Val) == "" {
+				continue
+			}
+		}
+		attrs = append(attrs, attr)
+	}
+	tok.Attr = attrs
+}
+
+// isSafeURL rejects script-capable schemes. 브라우저는 스킴 안의 공백/제어문자를 무시하므로 제거 후 비교한다.
+func isSafeURL(val string, allowDataImage bool) bool {
+	normalized := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, strings.ToLower(html.UnescapeString(val)))
+
+	switch {
+	case strings.HasPrefix(normalized, "javascript:"), strings.HasPrefix(normalized, "vbscript:"):
+		return false
+	case strings.HasPrefix(normalized, "data:"):
+		return allowDataImage && strings.HasPrefix(normalized, "data:image/")
+	}
+	return true
+}
+
+func isRemoteURL(val string) bool {
+	v := strings.ToLower(strings.TrimSpace(val))
+	return strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://") || strings.HasPrefix(v, "//")
+}
+
+// =============================================================================
+// Tracking pixels
+// =============================================================================
+
+// 알려진 열람 추적 URL 패턴 (소문자 부분 문자열)
+var trackerPatterns = []string{
+	"/track/open", "/wf/open", "/open.gif", "/open.php", "/pixel.gif", "/pixel.png",
+	"/trk?", "/beacon", "mailtrack.io", "list-manage.com/track",
+	"mandrillapp.com/track", "google-analytics.com/collect", "t.yesware.com",
+	"mixmax.com/api/track", "t.sidekickopen", "mailstat.us", "app.getresponse.com/open",
+	"click.convertkit-mail.com/o", "/o.gif",
+}
+
+// isTrackingPixel detects remote 1x1, hidden, or known-tracker images.
+func isTrackingPixel(tok *html.Token) bool {
+	var src, style string
+	width, height := -1, -1
+	for _, attr := range tok.Attr {
+		switch strings.ToLower(attr.Key) {
+		case "src":
+			src = strings.ToLower(strings.TrimSpace(attr.Val))
+		case "width":
+			width = parseDimension(attr.Val)
+		case "height":
+			height = parseDimension(attr.Val)
+		case "style":
+			style = strings.ToLower(attr.Val)
+		}
+	}
+	if !isRemoteURL(src) {
+		return false
+	}
+
+	for _, decl := range strings.Split(style, ";") {
+		prop, val, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		prop, val = strings.TrimSpace(prop), strings.TrimSpace(val)
+		switch prop {
+		case "width", "max-width":
+			if d := parseDimension(val); d >= 0 && (width < 0 || d < width) {
+				width = d
+			}
+		case "height", "max-height":
+			if d := parseDimension(val); d >= 0 && (height < 0 || d < height) {
+				height = d
+			}
+		case "display":
+			if val == "none" {
+				return true
+			}
+		case "visibility":
+			if val == "hidden" {
+				return true
+			}
+		}
+	}
+
+	if width >= 0 && width <= 1 && height >= 0 && height <= 1 {
+		return true
+	}
+
+	return IsTrackerURL(src)
+}
+
+// IsTrackerURL reports whether the URL matches a known open-tracking pattern.
+func IsTrackerURL(rawURL string) bool {
+	lower := strings.ToLower(rawURL)
+	for _, p := range trackerPatterns {
+		if strings.Contains(lower, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDimension parses "1", "1px", "0.5px"; returns -1 for unknown units (%, em).
+func parseDimension(v string) int {
+	v = strings.TrimSpace(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(v)), "!important"))
+	v = strings.TrimSuffix(v, "px")
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || f < 0 {
+		return -1
+	}
+	return int(f)
+}
@@ -0,0 +1,120 @@
+package htmlsanitize
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeStandard(t *testing.T) {
+	input := `<html><head><style>p{color:red} .x{background:url(javascript:alert(1))} @import url(evil.css);</style>` +
+		`<script>alert(1)</script></head><body onload="steal()">` +
+		`<p style="color:blue;width:expression(alert(1))">Hello &amp; welcome</p>` +
+		`<a href="javascript:alert(1)">bad</a><a href=" JaVa&#x09;script:alert(1)">bad2</a>` +
+		`<a href="https://example.com">good</a>` +
+		`<iframe src="https://evil.com"><p>inside</p></iframe>` +
+		`<img src="https://t.example.com/o/abc" width="1" height="1">` +
+		`<img src="https://tracker.example.com/x.png" style="display:none">` +
+		`<img src="https://cdn.example.com/logo.png" width="120" height="40">` +
+		`<img src="data:image/png;base64,AAAA">` +
+		`<form action="https://evil.com"><input name="pw"></form>` +
+		`<!--[if mso]><table><![endif]--></body></html>`
+
+	res := Sanitize(input, LevelStandard)
+	out := res.HTML
+
+	for _, bad := range []string{"<script", "alert(1)</script>", "onload", "expression", "javascript:", "JaVa", "<iframe", "inside", "@import", "t.example.com", "tracker.example.com", "<form"} {
+		if strings.Contains(out, bad) {
+			t.Errorf("output contains %q: %s", bad, out)
+		}
+	}
+	for _, keep := range []string{"Hello &amp; welcome", `href="https://example.com"`, "cdn.example.com/logo.png", "data:image/png", "color:blue", "p{color:red}", `<input name="pw">`, "<!--[if mso]>"} {
+		if !strings.Contains(out, keep) {
+			t.Errorf("output missing %q: %s", keep, out)
+		}
+	}
+	if res.TrackersBlocked != 2 {
+		t.Errorf("TrackersBlocked = %d, want 2", res.TrackersBlocked)
+	}
+}
+
+func TestSanitizeStrict(t *testing.T) {
+	input := `<style>p{color:red}</style><div style="background:url(https://t.example.com/bg.png);color:red">x</div>` +
+		`<form><input name="pw"></form><!-- comment --><p>kept</p>`
+
+	out := Sanitize(input, LevelStrict).HTML
+	for _, bad := range []string{"<style", "t.example.com", "<form", "<input", "comment"} {
+		if strings.Contains(out, bad) {
+			t.Errorf("strict output contains %q: %s", bad, out)
+		}
+	}
+	if !strings.Contains(out, "color:red") || !strings.Contains(out, "<p>kept</p>") {
+		t.Errorf("strict output lost safe content: %s", out)
+	}
+}
+
+func TestSanitizeCSSEscapes(t *testing.T) {
+	got := SanitizeCSS(`color:red;width:\65 xpression(alert(1));x:/**/ex/**/pression(1)`, LevelStandard)
+	if strings.Contains(got, "xpression") || strings.Contains(got, "pression") {
+		t.Errorf("escaped expression kept: %q", got)
+	}
+	if !strings.Contains(got, "color:red") {
+		t.Errorf("safe declaration removed: %q", got)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for in, want := range map[string]Level{"": LevelStandard, "STRICT": LevelStrict, "off": LevelOff, "standard": LevelStandard} {
+		got, err := ParseLevel(in)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("none"); err == nil {
+		t.Error("ParseLevel(none) should fail")
+	}
+}
+
+func TestRewriteImages(t *testing.T) {
+	input := `<p>hi</p><img src="https://cdn.example.com/a.png" srcset="https://cdn.example.com/a@2x.png 2x" alt="a">` +
+		`<img src="cid:logo"><img src="https://list-manage.com/track/open.php?u=1"><img src="data:image/png;base64,AAAA">`
+
+	out, rewritten, dropped := RewriteImages(input, func(src string) (string, bool) {
+		if IsTrackerURL(src) {
+			return "", true
+		}
+		return "/proxy?u=" + src, true
+	})
+
+	if rewritten != 1 || dropped != 1 {
+		t.Fatalf("rewritten=%d dropped=%d, want 1, 1", rewritten, dropped)
+	}
+	if !strings.Contains(out, `src="/proxy?u=https://cdn.example.com/a.png"`) || strings.Contains(out, "srcset") {
+		t.Errorf("remote image not rewritten: %s", out)
+	}
+	if strings.Contains(out, "list-manage.com") {
+		t.Errorf("tracker not dropped: %s", out)
+	}
+	if !strings.Contains(out, `src="cid:logo"`) || !strings.Contains(out, "data:image/png") || !strings.Contains(out, "<p>hi</p>") {
+		t.Errorf("non-remote content changed: %s", out)
+	}
+}
+
+func TestCleanTrackingURL(t *testing.T) {
+	cases := []struct {
+		in, want string
+		changed  bool
+	}{
+		{"https://example.com/post?id=3&utm_source=news&utm_medium=email&mc_eid=abc", "https://example.com/post?id=3", true},
+		{"https://acme.us1.list-manage.com/track/click?u=1&id=2&url=https%3A%2F%2Fblog.example.com%2Fa%3Futm_campaign%3Dx", "https://blog.example.com/a", true},
+		{"https://click.mail.example.com/?to=https%3A%2F%2Fexample.com%2Fdeal", "https://example.com/deal", true},
+		{"https://example.com/search?url=https://other.com", "https://example.com/search?url=https://other.com", false},
+		{"https://example.com/a?b=1", "https://example.com/a?b=1", false},
+		{"mailto:team@example.com?subject=hi", "mailto:team@example.com?subject=hi", false},
+	}
+	for _, tc := range cases {
+		got, changed := CleanTrackingURL(tc.in)
+		if got != tc.want || changed != tc.changed {
+			t.Errorf("CleanTrackingURL(%q) = %q, %v; want %q, %v", tc.in, got, changed, tc.want, tc.changed)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package htmlsanitize
+
+import (
+	"net/url"
+	"strings"
+)
+
+// 링크에서 제거하는 추적 파라미터 (utm_* 는 접두사로 처리)
+var trackingParams = map[string]bool{
+	"mc_cid": true, "mc_eid": true, "fbclid": true, "gclid": true, "dclid": true,
+	"msclkid": true, "yclid": true, "igshid": true, "_hsenc": true, "_hsmi": true,
+	"__hstc": true, "__hssc": true, "__hsfp": true, "hsctatracking": true, "mkt_tok": true,
+	"vero_id": true, "vero_conv": true, "oly_anon_id": true, "oly_enc_id": true,
+	"rb_clickid": true, "s_cid": true, "ck_subscriber_id": true, "wickedid": true,
+	"trk": true, "sc_campaign": true, "sc_channel": true, "sc_content": true,
+}
+
+// 클릭 추적 리다이렉트에서 실제 목적지를 담는 파라미터
+var redirectParams = []string{"url", "u", "redirect", "redirect_url", "redirect_uri", "target", "dest", "destination", "link", "to"}
+
+// 클릭 추적 리다이렉트 URL 패턴 (소문자 부분 문자열)
+var clickTrackerPatterns = []string{
+	"/click", "/track", "/redirect", "/ls/click", "/wf/click", "/l/", "/r/",
+	"list-manage.com", "mandrillapp.com", "sendgrid.net", "mailchi.mp", "substack.com/redirect",
+}
+
+// maxRedirectUnwrap - 중첩된 리다이렉트를 풀 때의 최대 깊이
+const maxRedirectUnwrap = 3
+
+// CleanTrackingURL unwraps click-tracking redirects and removes tracking query
+// parameters (utm_*, mc_eid, fbclid ...). Returns the URL and whether it changed.
+// http(s)가 아닌 링크는 그대로 둔다.
+func CleanTrackingURL(rawURL string) (string, bool) {
+	current := strings.TrimSpace(rawURL)
+	changed := false
+
+	for i := 0; i < maxRedirectUnwrap; i++ {
+		dest, ok := redirectTarget(current)
+		if !ok {
+			break
+		}
+		current, changed = dest, true
+	}
+
+	u, err := url.Parse(current)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return rawURL, false
+	}
+	if u.RawQuery != "" {
+		parts := strings.Split(u.RawQuery, "&")
+		kept := parts[:0]
+		for _, part := range parts {
+			key, _, _ := strings.Cut(part, "=")
+			if k, err := url.QueryUnescape(key); err == nil {
+				key = k
+			}
+			key = strings.ToLower(key)
+			if strings.HasPrefix(key, "utm_") || trackingParams[key] {
+				changed = true
+				continue
+			}
+			kept = append(kept, part)
+		}
+		u.RawQuery = strings.Join(kept, "&")
+	}
+
+	if !changed {
+		return rawURL, false
+	}
+	return u.String(), true
+}
+
+// redirectTarget returns the destination of a known click-tracking redirect.
+func redirectTarget(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.RawQuery == "" {
+		return "", false
+	}
+
+	lower := strings.ToLower(u.Host + u.Path)
+	tracker := strings.HasPrefix(strings.ToLower(u.Host), "click.") || strings.HasPrefix(strings.ToLower(u.Host), "links.")
+	for _, p := range clickTrackerPatterns {
+		if strings.Contains(lower, p) {
+			tracker = true
+			break
+		}
+	}
+	if !tracker {
+		return "", false
+	}
+
+	query := u.Query()
+	for _, name := range redirectParams {
+		dest := strings.TrimSpace(query.Get(name))
+		if d, err := url.Parse(dest); err == nil && (d.Scheme == "http" || d.Scheme == "https") && d.Host != "" {
+			return dest, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,81 @@
+package htmlsanitize
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ImageRewriteFunc maps an <img src> to a new value.
+// Return ok=false to keep the original, or an empty src to drop the image.
+type ImageRewriteFunc func(src string) (newSrc string, ok bool)
+
+// RewriteImages applies fn to every remote <img src>. srcset is removed from
+// rewritten images so the browser cannot bypass the new source.
+// Returns the HTML, the number of rewritten images and the number of dropped images.
+func RewriteImages(src string, fn ImageRewriteFunc) (string, int, int) {
+	if src == "" || !strings.Contains(strings.ToLower(src), "<img") {
+		return src, 0, 0
+	}
+
+	z := html.NewTokenizer(strings.NewReader(src))
+	var b strings.Builder
+	b.Grow(len(src))
+	rewritten, dropped := 0, 0
+
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		raw := z.Raw()
+
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			b.Write(raw)
+			continue
+		}
+		tok := z.Token()
+		if tok.Data != "img" {
+			b.Write(raw)
+			continue
+		}
+
+		srcIdx := -1
+		for i, attr := range tok.Attr {
+			if attr.Key == "src" {
+				srcIdx = i
+				break
+			}
+		}
+		if srcIdx < 0 || !isRemoteURL(tok.Attr[srcIdx].Val) {
+			b.Write(raw)
+			continue
+		}
+
+		newSrc, ok := fn(strings.TrimSpace(tok.Attr[srcIdx].Val))
+		if !ok {
+			b.Write(raw)
+			continue
+		}
+		if newSrc == "" {
+			dropped++
+			continue
+		}
+
+		attrs := tok.Attr[:0]
+		for i, attr := range tok.Attr {
+			switch {
+			case i == srcIdx:
+				attr.Val = newSrc
+			case attr.Key == "srcset":
+				continue
+			}
+			attrs = append(attrs, attr)
+		}
+		tok.Attr = attrs
+		b.WriteString(tok.String())
+		rewritten++
+	}
+
+	return b.String(), rewritten, dropped
+}
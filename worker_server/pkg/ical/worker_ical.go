@@ -0,0 +1,342 @@
+// Package ical parses calendar invites and builds iTIP replies (RFC 5545, RFC 5546).
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// iTIP methods
+const (
+	MethodRequest = "REQUEST"
+	MethodReply   = "REPLY"
+	MethodCancel  = "CANCEL"
+)
+
+// Attendee participation status (PARTSTAT)
+const (
+	PartStatNeedsAction = "NEEDS-ACTION"
+	PartStatAccepted    = "ACCEPTED"
+	PartStatTentative   = "TENTATIVE"
+	PartStatDeclined    = "DECLINED"
+)
+
+const (
+	dateTimeLayout    = "20060102T150405"
+	dateTimeUTCLayout = "20060102T150405Z"
+	dateLayout        = "20060102"
+	maxLineOctets     = 75
+)
+
+var ErrNoEvent = errors.New("calendar has no event")
+
+// Calendar is a parsed VCALENDAR.
+type Calendar struct {
+	Method string
+	Events []*Event
+}
+
+// Address is a calendar user (ORGANIZER, ATTENDEE).
+type Address struct {
+	Email    string
+	Name     string
+	PartStat string // ATTENDEE만 사용
+}
+
+// Event is a parsed VEVENT.
+type Event struct {
+	UID         string
+	Sequence    int
+	Summary     string
+	Location    string
+	Description string
+	Status      string
+	Organizer   Address
+	Attendees   []Address
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+
+	// raw keeps DTSTART/DTEND/RECURRENCE-ID lines as received (답장에 그대로 넣는다).
+	raw map[string]string
+}
+
+// Attendee returns the attendee whose address is in emails (소문자 주소).
+func (e *Event) Attendee(emails map[string]bool) (Address, bool) {
+	for _, a := range e.Attendees {
+		if emails[strings.ToLower(a.Email)] {
+			return a, true
+		}
+	}
+	return Address{}, false
+}
+
+// Parse parses an iCalendar object. 알 수 없는 속성과 VEVENT 안의 VALARM 등은 무시한다.
+func Parse(data []byte) (*Calendar, error) {
+	cal := &Calendar{}
+	var (
+		event  *Event
+		depth  int // VEVENT 안의 하위 컴포넌트 깊이
+		inCal  bool
+		parsed bool
+	)
+
+	for _, line := range unfold(data) {
+		name, params, value, ok := splitLine(line)
+		if !ok {
+			continue
+		}
+
+		switch name {
+		case "BEGIN":
+			switch {
+			case strings.EqualFold(value, "VCALENDAR"):
+				inCal, parsed = true, true
+			case strings.EqualFold(value, "VEVENT") && event == nil:
+				event = &Event{raw: map[string]string{}}
+			case event != nil:
+				depth++
+			}
+			continue
+		case "END":
+			switch {
+			case event != nil && depth > 0:
+				depth--
+			case event != nil && strings.EqualFold(value, "VEVENT"):
+				cal.Events = append(cal.Events, event)
+				event = nil
+			case strings.EqualFold(value, "VCALENDAR"):
+				inCal = false
+			}
+			continue
+		}
+
+		if event == nil {
+			if inCal && name == "METHOD" {
+				cal.Method = strings.ToUpper(value)
+			}
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+		event.set(name, params, value, line)
+	}
+
+	if !parsed {
+		return nil, errors.New("not an iCalendar object")
+	}
+	if len(cal.Events) == 0 {
+		return nil, ErrNoEvent
+	}
+	return cal, nil
+}
+
+func (e *Event) set(name string, params map[string]string, value, line string) {
+	switch name {
+	case "UID":
+		e.UID = value
+	case "SEQUENCE":
+		e.Sequence, _ = strconv.Atoi(value)
+	case "SUMMARY":
+		e.Summary = unescapeText(value)
+	case "LOCATION":
+		e.Location = unescapeText(value)
+	case "DESCRIPTION":
+		e.Description = unescapeText(value)
+	case "STATUS":
+		e.Status = strings.ToUpper(value)
+	case "ORGANIZER":
+		e.Organizer = address(params, value)
+	case "ATTENDEE":
+		a := address(params, value)
+		if a.PartStat == "" {
+			a.PartStat = PartStatNeedsAction
+		}
+		e.Attendees = append(e.Attendees, a)
+	case "DTSTART":
+		e.raw[name] = line
+		e.Start, e.AllDay = parseTime(params, value)
+	case "DTEND":
+		e.raw[name] = line
+		e.End, _ = parseTime(params, value)
+	case "RECURRENCE-ID":
+		e.raw[name] = line
+	}
+}
+
+func address(params map[string]string, value string) Address {
+	email := value
+	if len(email) >= 7 && strings.EqualFold(email[:7], "mailto:") {
+		email = email[7:]
+	}
+	return Address{
+		Email:    strings.TrimSpace(email),
+		Name:     params["CN"],
+		PartStat: strings.ToUpper(params["PARTSTAT"]),
+	}
+}
+
+// parseTime parses DATE, UTC DATE-TIME and TZID DATE-TIME values (TZID를 못 찾으면 UTC로 본다).
+func parseTime(params map[string]string, value string) (time.Time, bool) {
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len(dateLayout) {
+		t, err := time.Parse(dateLayout, value)
+		return t, err == nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, _ := time.Parse(dateTimeUTCLayout, value)
+		return t, false
+	}
+	loc := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(strings.Trim(tzid, "/")); err == nil {
+			loc = l
+		}
+	}
+	t, _ := time.ParseInLocation(dateTimeLayout, value, loc)
+	return t, false
+}
+
+// unfold joins folded lines (CRLF + 공백/탭으로 이어진 줄).
+func unfold(data []byte) []string {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// splitLine splits "NAME;PARAM=a;PARAM=\"b:c\":VALUE" into its parts.
+func splitLine(line string) (name string, params map[string]string, value string, ok bool) {
+	inQuote := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuote = !inQuote
+		} else if r == ':' && !inQuote {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := splitParams(head)
+	name = strings.ToUpper(parts[0])
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		if k, v, found := strings.Cut(p, "="); found {
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return name, params, value, true
+}
+
+func splitParams(head string) []string {
+	var parts []string
+	inQuote := false
+	start := 0
+	for i, r := range head {
+		if r == '"' {
+			inQuote = !inQuote
+		} else if r == ';' && !inQuote {
+			parts = append(parts, head[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, head[start:])
+}
+
+func unescapeText(s string) string {
+	r := strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return r.Replace(s)
+}
+
+func escapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// =============================================================================
+// iTIP REPLY
+// =============================================================================
+
+// Reply builds a METHOD:REPLY calendar that answers event as attendee with partStat.
+func Reply(event *Event, attendee Address, partStat, comment, prodID string, now time.Time) []byte {
+	var b bytes.Buffer
+	write := func(line string) {
+		b.WriteString(fold(line))
+		b.WriteString("\r\n")
+	}
+
+	write("BEGIN:VCALENDAR")
+	write("PRODID:" + prodID)
+	write("VERSION:2.0")
+	write("CALSCALE:GREGORIAN")
+	write("METHOD:" + MethodReply)
+	write("BEGIN:VEVENT")
+	write("UID:" + event.UID)
+	write("SEQUENCE:" + strconv.Itoa(event.Sequence))
+	write("DTSTAMP:" + now.UTC().Format(dateTimeUTCLayout))
+	for _, name := range []string{"RECURRENCE-ID", "DTSTART", "DTEND"} {
+		if line, ok := event.raw[name]; ok {
+			write(line)
+		}
+	}
+	write(addressLine("ORGANIZER", event.Organizer, ""))
+	write(addressLine("ATTENDEE", attendee, partStat))
+	if event.Summary != "" {
+		write("SUMMARY:" + escapeText(event.Summary))
+	}
+	if comment != "" {
+		write("COMMENT:" + escapeText(comment))
+	}
+	write("END:VEVENT")
+	write("END:VCALENDAR")
+	return b.Bytes()
+}
+
+func addressLine(name string, a Address, partStat string) string {
+	line := name
+	if partStat != "" {
+		line += ";PARTSTAT=" + partStat
+	}
+	if a.Name != "" {
+		line += fmt.Sprintf(`;CN="%s"`, strings.ReplaceAll(a.Name, `"`, "'"))
+	}
+	return line + ":mailto:" + a.Email
+}
+
+// fold splits lines longer than 75 octets without breaking UTF-8 sequences.
+func fold(line string) string {
+	if len(line) <= maxLineOctets {
+		return line
+	}
+	var b strings.Builder
+	limit := maxLineOctets
+	n := 0
+	for _, r := range line {
+		size := len(string(r))
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			n = 0
+			limit = maxLineOctets - 1 // 이어지는 줄은 앞의 공백 포함
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const invite = "BEGIN:VCALENDAR\r\n" +
+	"PRODID:-//Google Inc//Google Calendar 70.9054//EN\r\n" +
+	"VERSION:2.0\r\n" +
+	"METHOD:REQUEST\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;TZID=Asia/Seoul:20261020T100000\r\n" +
+	"DTEND;TZID=Asia/Seoul:20261020T110000\r\n" +
+	"UID:abc123@google.com\r\n" +
+	"ORGANIZER;CN=Kim:mailto:kim@example.com\r\n" +
+	"ATTENDEE;CUTYPE=INDIVIDUAL;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;CN=\"Lee, J\r\n" +
+	" \";X-NUM-GUESTS=0:mailto:Lee@Example.com\r\n" +
+	"SEQUENCE:2\r\n" +
+	"SUMMARY:Weekly sync\\, Q4\r\n" +
+	"LOCATION:Room 3\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"DESCRIPTION:This is an event reminder\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParse(t *testing.T) {
+	cal, err := Parse([]byte(invite))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cal.Method != MethodRequest || len(cal.Events) != 1 {
+		t.Fatalf("Parse() = method %q, %d events", cal.Method, len(cal.Events))
+	}
+
+	ev := cal.Events[0]
+	if ev.UID != "abc123@google.com" || ev.Sequence != 2 || ev.Summary != "Weekly sync, Q4" || ev.Location != "Room 3" {
+		t.Errorf("event = %+v", ev)
+	}
+	if ev.Description != "" {
+		t.Errorf("VALARM description leaked into event: %q", ev.Description)
+	}
+	if ev.Organizer.Email != "kim@example.com" || ev.Organizer.Name != "Kim" {
+		t.Errorf("organizer = %+v", ev.Organizer)
+	}
+	if want := time.Date(2026, 10, 20, 1, 0, 0, 0, time.UTC); !ev.Start.Equal(want) {
+		t.Errorf("start = %v, want %v", ev.Start, want)
+	}
+
+	attendee, ok := ev.Attendee(map[string]bool{"lee@example.com": true})
+	if !ok || attendee.Name != "Lee, J" || attendee.PartStat != PartStatNeedsAction {
+		t.Errorf("Attendee() = %+v, %v", attendee, ok)
+	}
+}
+
+func TestParseAllDay(t *testing.T) {
+	cal, err := Parse([]byte("BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:1\nDTSTART;VALUE=DATE:20261101\nEND:VEVENT\nEND:VCALENDAR\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if ev := cal.Events[0]; !ev.AllDay || ev.Start.Day() != 1 {
+		t.Errorf("event = %+v", ev)
+	}
+
+	if _, err := Parse([]byte("BEGIN:VCALENDAR\nEND:VCALENDAR\n")); err != ErrNoEvent {
+		t.Errorf("Parse() without event error = %v, want ErrNoEvent", err)
+	}
+}
+
+func TestReply(t *testing.T) {
+	cal, _ := Parse([]byte(invite))
+	ev := cal.Events[0]
+	attendee, _ := ev.Attendee(map[string]bool{"lee@example.com": true})
+
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	reply := string(Reply(ev, attendee, PartStatAccepted, "See you there", "-//Worker//RSVP//EN", now))
+
+	for _, want := range []string{
+		"METHOD:REPLY\r\n",
+		"UID:abc123@google.com\r\n",
+		"SEQUENCE:2\r\n",
+		"DTSTAMP:20261016T090000Z\r\n",
+		"DTSTART;TZID=Asia/Seoul:20261020T100000\r\n",
+		"ORGANIZER;CN=\"Kim\":mailto:kim@example.com\r\n",
+		"ATTENDEE;PARTSTAT=ACCEPTED;CN=\"Lee, J\":mailto:Lee@Example.com\r\n",
+		"SUMMARY:Weekly sync\\, Q4\r\n",
+		"COMMENT:See you there\r\n",
+	} {
+		if !strings.Contains(reply, want) {
+			t.Errorf("reply missing %q:\n%s", want, reply)
+		}
+	}
+
+	// 답장도 다시 파싱할 수 있어야 한다
+	parsed, err := Parse([]byte(reply))
+	if err != nil || parsed.Method != MethodReply || parsed.Events[0].Attendees[0].PartStat != PartStatAccepted {
+		t.Errorf("Parse(reply) = %+v, %v", parsed, err)
+	}
+}
+
+func TestFold(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("가", 40)
+	folded := fold(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > maxLineOctets {
+			t.Errorf("folded line has %d octets", len(part))
+		}
+	}
+	if got := strings.ReplaceAll(folded, "\r\n ", ""); got != line {
+		t.Errorf("unfolded = %q, want %q", got, line)
+	}
+}
@@ -0,0 +1,149 @@
+// Package langdetect detects the language of short texts without external calls.
+// 문자 체계(한글, 가나, 키릴 등)로 먼저 판별하고, 라틴 문자는 불용어 빈도로 구분한다.
+package langdetect
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Undetermined is returned when the text has too few letters.
+const Undetermined = ""
+
+const (
+	minLetters    = 8    // 이보다 짧으면 판별하지 않음
+	maxScanRunes  = 4000 // 앞부분만 검사
+	minStopwords  = 2    // 라틴 문자 판별에 필요한 최소 불용어 수
+	scriptShare   = 0.3  // 비라틴 문자 체계가 이 비율 이상이면 해당 언어
+	latinFallback = "en"
+)
+
+// stopwords are frequent function words of Latin-script languages.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "to", "of", "for", "you", "your", "this", "that", "with", "have", "will", "please", "thanks", "from", "we"},
+	"es": {"el", "la", "los", "las", "que", "de", "y", "en", "por", "para", "con", "una", "es", "gracias", "usted", "su", "del"},
+	"fr": {"le", "la", "les", "des", "et", "est", "que", "pour", "vous", "avec", "une", "dans", "sur", "merci", "nous", "du", "pas"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "sie", "mit", "für", "ein", "eine", "zu", "den", "ich", "wir", "danke", "bitte"},
+	"pt": {"o", "os", "as", "que", "de", "e", "em", "para", "com", "uma", "não", "você", "obrigado", "por", "do", "da", "são"},
+	"it": {"il", "lo", "gli", "che", "di", "e", "per", "con", "una", "non", "sono", "grazie", "della", "del", "si", "ci", "questo"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "dat", "met", "voor", "je", "u", "wij", "bedankt", "zijn", "op", "ook"},
+}
+
+var stopwordIndex = buildIndex()
+
+func buildIndex() map[string][]string {
+	index := make(map[string][]string)
+	for lang, words := range stopwords {
+		for _, w := range words {
+			index[w] = append(index[w], lang)
+		}
+	}
+	return index
+}
+
+// Detect returns the ISO 639-1 code of text and a confidence between 0 and 1.
+// 판별할 수 없으면 Undetermined와 0을 반환한다.
+func Detect(text string) (string, float64) {
+	counts := make(map[string]int)
+	letters, latin := 0, 0
+	n := 0
+	for _, r := range text {
+		if n++; n > maxScanRunes {
+			break
+		}
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			counts["ja"]++
+		case unicode.Is(unicode.Han, r):
+			counts["han"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		case unicode.Is(unicode.Devanagari, r):
+			counts["hi"]++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	if letters < minLetters {
+		return Undetermined, 0
+	}
+
+	// 한자는 가나가 섞여 있으면 일본어, 아니면 중국어
+	if counts["han"] > 0 {
+		if counts["ja"] > 0 {
+			counts["ja"] += counts["han"]
+		} else {
+			counts["zh"] = counts["han"]
+		}
+		delete(counts, "han")
+	}
+
+	best, bestCount := "", 0
+	for lang, c := range counts {
+		if c > bestCount || (c == bestCount && lang < best) {
+			best, bestCount = lang, c
+		}
+	}
+	if share := float64(bestCount) / float64(letters); best != "" && share >= scriptShare {
+		return best, share
+	}
+	if latin == 0 {
+		return Undetermined, 0
+	}
+	return detectLatin(text)
+}
+
+// detectLatin picks the Latin-script language with the most stopword hits.
+func detectLatin(text string) (string, float64) {
+	hits := make(map[string]int)
+	total := 0
+	words := strings.FieldsFunc(strings.ToLower(truncate(text, maxScanRunes)), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, w := range words {
+		langs, ok := stopwordIndex[w]
+		if !ok {
+			continue
+		}
+		total++
+		for _, lang := range langs {
+			hits[lang]++
+		}
+	}
+	if total < minStopwords {
+		return latinFallback, 0.3
+	}
+
+	best, bestHits := "", 0
+	for lang, h := range hits {
+		if h > bestHits || (h == bestHits && lang < best) {
+			best, bestHits = lang, h
+		}
+	}
+	return best, float64(bestHits) / float64(total)
+}
+
+func truncate(s string, maxRunes int) string {
+	n := 0
+	for i := range s {
+		if n == maxRunes {
+			return s[:i]
+		}
+		n++
+	}
+	return s
+}
@@ -0,0 +1,29 @@
+package langdetect
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"korean", "안녕하세요, 다음 주 회의 일정을 공유드립니다. Zoom 링크는 아래에 있습니다.", "ko"},
+		{"japanese", "お世話になっております。来週の会議資料を送付いたします。", "ja"},
+		{"chinese", "您好，附件是下周会议的资料，请查收。", "zh"},
+		{"russian", "Здравствуйте, отправляю вам документы к встрече.", "ru"},
+		{"english", "Hi team, please review the attached report and send your feedback by Friday. Thanks!", "en"},
+		{"spanish", "Hola, le envío el informe para la reunión del lunes. Muchas gracias por su ayuda.", "es"},
+		{"french", "Bonjour, vous trouverez le document dans la pièce jointe. Merci pour votre aide.", "fr"},
+		{"german", "Hallo, anbei die Unterlagen für das Treffen. Ich danke Ihnen und bitte um Rückmeldung.", "de"},
+		{"too short", "Hi", Undetermined},
+		{"digits only", "12345 67890 !!!", Undetermined},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := Detect(tt.text); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
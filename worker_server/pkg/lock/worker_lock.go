@@ -0,0 +1,142 @@
+// Package lock provides a Redis based distributed lock with automatic renewal.
+//
+// 락은 소유자 토큰으로 보호되므로 만료 후 다른 인스턴스가 잡은 락을
+// 이전 소유자가 해제하거나 연장하지 못한다.
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNotAcquired is returned when the lock is held by someone else.
+var ErrNotAcquired = errors.New("lock: not acquired")
+
+// releaseScript deletes the key only if it still holds our token.
+var releaseScript = redis.NewScript(`
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('DEL', KEYS[1])
+	end
+	return 0
+`)
+
+// refreshScript extends the TTL only if the key still holds our token.
+var refreshScript = redis.NewScript(`
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	end
+	return 0
+`)
+
+// Locker acquires locks under a common key prefix.
+type Locker struct {
+	redis  *redis.Client
+	prefix string
+}
+
+// NewLocker creates a new Locker. Keys are stored as "lock:{key}".
+func NewLocker(redisClient *redis.Client) *Locker {
+	return &Locker{
+		redis:  redisClient,
+		prefix: "lock:",
+	}
+}
+
+// Lock is a held lock. It is renewed every ttl/3 until Release.
+type Lock struct {
+	redis *redis.Client
+	key   string
+	token string
+	ttl   time.Duration
+
+	stop     chan struct{}
+	lost     chan struct{}
+	stopOnce sync.Once
+	lostOnce sync.Once
+	done     chan struct{}
+}
+
+// Acquire tries once to take the lock. Returns ErrNotAcquired if it is held.
+func (l *Locker) Acquire(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := l.redis.SetNX(ctx, l.prefix+key, token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotAcquired
+	}
+
+	lk := &Lock{
+		redis: l.redis,
+		key:   l.prefix + key,
+		token: token,
+		ttl:   ttl,
+		stop:  make(chan struct{}),
+		lost:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go lk.keepAlive()
+	return lk, nil
+}
+
+// Lost is closed when renewal fails and the lock may be held by someone else.
+// 장시간 작업은 이 채널을 보고 중단해야 한다.
+func (lk *Lock) Lost() <-chan struct{} {
+	return lk.lost
+}
+
+// Release stops renewal and deletes the lock if still owned.
+func (lk *Lock) Release(ctx context.Context) error {
+	lk.stopOnce.Do(func() { close(lk.stop) })
+	<-lk.done
+	return releaseScript.Run(ctx, lk.redis, []string{lk.key}, lk.token).Err()
+}
+
+func (lk *Lock) keepAlive() {
+	defer close(lk.done)
+
+	interval := lk.ttl / 3
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-lk.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			n, err := refreshScript.Run(ctx, lk.redis, []string{lk.key}, lk.token, lk.ttl.Milliseconds()).Int()
+			cancel()
+			if err != nil {
+				// 일시적 오류는 다음 주기에 재시도 (TTL 안에 복구되면 락 유지)
+				continue
+			}
+			if n == 0 {
+				lk.lostOnce.Do(func() { close(lk.lost) })
+				return
+			}
+		}
+	}
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,207 @@
+package mailparse
+
+import (
+	"strings"
+)
+
+// AuthCheck is the outcome of a single sender authentication method.
+type AuthCheck struct {
+	Result string `json:"result"`           // pass, fail, softfail, neutral, none, temperror, permerror
+	Domain string `json:"domain,omitempty"` // 검증 대상 도메인
+	Detail string `json:"detail,omitempty"` // 괄호 안 설명 (서버 코멘트)
+}
+
+// Passed reports whether the check passed.
+func (c *AuthCheck) Passed() bool {
+	return c != nil && c.Result == "pass"
+}
+
+// Failed reports whether the check explicitly failed.
+func (c *AuthCheck) Failed() bool {
+	return c != nil && (c.Result == "fail" || c.Result == "softfail" || c.Result == "permerror")
+}
+
+// AuthResults summarizes SPF/DKIM/DMARC results (RFC 8601 Authentication-Results).
+type AuthResults struct {
+	AuthServID string     `json:"authserv_id,omitempty"`
+	SPF        *AuthCheck `json:"spf,omitempty"`
+	DKIM       *AuthCheck `json:"dkim,omitempty"`
+	DMARC      *AuthCheck `json:"dmarc,omitempty"`
+}
+
+// Verdict returns pass when every present check passed, fail when any failed,
+// none when nothing was found, otherwise partial.
+func (r *AuthResults) Verdict() string {
+	if r == nil || (r.SPF == nil && r.DKIM == nil && r.DMARC == nil) {
+		return "none"
+	}
+	checks := []*AuthCheck{r.SPF, r.DKIM, r.DMARC}
+	allPass := true
+	for _, c := range checks {
+		if c.Failed() {
+			return "fail"
+		}
+		if c != nil && !c.Passed() {
+			allPass = false
+		}
+	}
+	if allPass && r.SPF != nil && r.DKIM != nil && r.DMARC != nil {
+		return "pass"
+	}
+	return "partial"
+}
+
+// ParseAuthenticationResults parses Authentication-Results header values in header order.
+// 첫 번째(가장 최근, 수신 서버가 추가한) 헤더의 결과를 우선한다.
+// receivedSPF is used as a fallback when no spf= entry is present.
+func ParseAuthenticationResults(values []string, receivedSPF string) *AuthResults {
+	result := &AuthResults{}
+
+	for _, value := range values {
+		segments := strings.Split(value, ";")
+		if len(segments) == 0 {
+			continue
+		}
+		if result.AuthServID == "" {
+			result.AuthServID = strings.TrimSpace(stripComments(segments[0]))
+		}
+
+		for _, seg := range segments[1:] {
+			method, check := parseAuthSegment(seg)
+			if check == nil {
+				continue
+			}
+			switch method {
+			case "spf":
+				if result.SPF == nil {
+					result.SPF = check
+				}
+			case "dkim":
+				// 서명이 여러 개면 pass 결과를 우선
+				if result.DKIM == nil || (!result.DKIM.Passed() && check.Passed()) {
+					result.DKIM = check
+				}
+			case "dmarc":
+				if result.DMARC == nil {
+					result.DMARC = check
+				}
+			}
+		}
+	}
+
+	if result.SPF == nil && receivedSPF != "" {
+		result.SPF = ParseReceivedSPF(receivedSPF)
+	}
+
+	return result
+}
+
+// ParseReceivedSPF parses a Received-SPF header (RFC 7208 §9.1).
+func ParseReceivedSPF(value string) *AuthCheck {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	fields := strings.Fields(value)
+	check := &AuthCheck{
+		Result: strings.ToLower(fields[0]),
+		Detail: extractComment(value),
+	}
+	// "pass (comment) client-ip=...; envelope-from=...; helo=..."
+	for _, f := range strings.Fields(strings.ReplaceAll(stripComments(value), ";", " ")) {
+		key, val, ok := strings.Cut(f, "=")
+		if ok && strings.EqualFold(key, "envelope-from") {
+			check.Domain = domainOf(strings.Trim(val, `"<>`))
+		}
+	}
+	return check
+}
+
+// parseAuthSegment parses "method=result (comment) prop=value ..." segments.
+func parseAuthSegment(seg string) (string, *AuthCheck) {
+	detail := extractComment(seg)
+	fields := strings.Fields(stripComments(seg))
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	method, res, ok := strings.Cut(fields[0], "=")
+	if !ok {
+		return "", nil
+	}
+	method = strings.ToLower(method)
+	check := &AuthCheck{Result: strings.ToLower(res), Detail: detail}
+
+	props := make(map[string]string, len(fields)-1)
+	for _, f := range fields[1:] {
+		if k, v, ok := strings.Cut(f, "="); ok {
+			props[strings.ToLower(k)] = strings.Trim(v, `"`)
+		}
+	}
+
+	switch method {
+	case "spf":
+		if v := props["smtp.mailfrom"]; v != "" {
+			check.Domain = domainOf(v)
+		} else {
+			check.Domain = props["smtp.helo"]
+		}
+	case "dkim":
+		if v := props["header.d"]; v != "" {
+			check.Domain = v
+		} else {
+			check.Domain = domainOf(props["header.i"])
+		}
+	case "dmarc":
+		check.Domain = props["header.from"]
+	}
+	check.Domain = strings.ToLower(check.Domain)
+
+	return method, check
+}
+
+// stripComments removes RFC 5322 parenthesized comments.
+func stripComments(s string) string {
+	var sb strings.Builder
+	depth := 0
+	for _, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// extractComment returns the first parenthesized comment.
+func extractComment(s string) string {
+	start := strings.Index(s, "(")
+	if start < 0 {
+		return ""
+	}
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(s[start+1 : i])
+			}
+		}
+	}
+	return ""
+}
+
+func domainOf(addr string) string {
+	if i := strings.LastIndex(addr, "@"); i >= 0 {
+		return strings.ToLower(addr[i+1:])
+	}
+	return strings.ToLower(addr)
+}
@@ -0,0 +1,248 @@
+package mailparse
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"mime"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+// ReportState is the delivery outcome reported for a recipient.
+type ReportState string
+
+const (
+	ReportDelivered  ReportState = "delivered"
+	ReportDelayed    ReportState = "delayed"
+	ReportBounced    ReportState = "bounced"
+	ReportComplained ReportState = "complained"
+)
+
+// ReportRecipient is the per-recipient part of a delivery report.
+type ReportRecipient struct {
+	Email      string      `json:"email"`
+	State      ReportState `json:"state"`
+	Action     string      `json:"action,omitempty"`     // DSN Action (failed, delayed, delivered, relayed, expanded)
+	Status     string      `json:"status,omitempty"`     // enhanced status code (예: 5.1.1)
+	Diagnostic string      `json:"diagnostic,omitempty"` // Diagnostic-Code / Feedback-Type
+	Permanent  bool        `json:"permanent,omitempty"`  // 5.x.x hard bounce
+}
+
+// DeliveryReport is a parsed DSN (RFC 3464), ARF complaint (RFC 5965) or
+// non-standard bounce carrying X-Failed-Recipients.
+type DeliveryReport struct {
+	OriginalMessageID string            `json:"original_message_id,omitempty"`
+	ReportingMTA      string            `json:"reporting_mta,omitempty"`
+	Recipients        []ReportRecipient `json:"recipients"`
+}
+
+// bounceSubjects are subject prefixes used by common MTAs for non-delivery reports.
+var bounceSubjects = []string{
+	"undeliverable",
+	"undelivered mail",
+	"delivery status notification",
+	"mail delivery failed",
+	"mail delivery failure",
+	"delivery failure",
+	"failure notice",
+	"returned mail",
+	"mail system error",
+	"delivery has failed",
+}
+
+// LooksLikeDeliveryReport reports whether header fields suggest a bounce or complaint report.
+// 본문을 받기 전(메타데이터 단계)에 후보를 고르는 용도로, 최종 판정은 ParseDeliveryReport가 한다.
+func LooksLikeDeliveryReport(from, subject, contentType, failedRecipients string) bool {
+	if failedRecipients != "" {
+		return true
+	}
+	if mediaType, params, err := mime.ParseMediaType(contentType); err == nil && mediaType == "multipart/report" {
+		switch strings.ToLower(params["report-type"]) {
+		case "delivery-status", "feedback-report":
+			return true
+		}
+	}
+
+	local := strings.ToLower(from)
+	if i := strings.Index(local, "@"); i >= 0 {
+		local = local[:i]
+	}
+	if local != "mailer-daemon" && local != "postmaster" {
+		return false
+	}
+
+	subject = strings.ToLower(strings.TrimSpace(subject))
+	for _, prefix := range bounceSubjects {
+		if strings.HasPrefix(subject, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDeliveryReport extracts per-recipient delivery results from a raw report message.
+// Returns nil when the message is not a delivery report.
+func ParseDeliveryReport(raw []byte) (*DeliveryReport, error) {
+	msg, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DeliveryReport{}
+	for _, att := range msg.Attachments {
+		switch att.MimeType {
+		case "message/delivery-status", "message/global-delivery-status":
+			parseDeliveryStatus(att.Data, report)
+		case "message/feedback-report":
+			parseFeedbackReport(att.Data, report)
+		case "message/rfc822", "text/rfc822-headers", "message/rfc822-headers", "message/global", "message/global-headers":
+			if report.OriginalMessageID == "" {
+				report.OriginalMessageID = originalMessageID(att.Data)
+			}
+		}
+	}
+
+	// 비표준 바운스 (qmail, 일부 Exchange): X-Failed-Recipients 헤더만 있는 경우
+	if len(report.Recipients) == 0 {
+		for _, addr := range strings.Split(msg.Header.Get("X-Failed-Recipients"), ",") {
+			if addr = strings.ToLower(strings.TrimSpace(addr)); addr != "" {
+				report.Recipients = append(report.Recipients, ReportRecipient{
+					Email:     addr,
+					State:     ReportBounced,
+					Action:    "failed",
+					Permanent: true,
+				})
+			}
+		}
+	}
+	if len(report.Recipients) == 0 {
+		return nil, nil
+	}
+
+	// 원본 첨부가 없으면 In-Reply-To/References로 연결 (Gmail 등은 원본 Message-ID를 참조)
+	if report.OriginalMessageID == "" {
+		if msg.InReplyTo != "" {
+			report.OriginalMessageID = msg.InReplyTo
+		} else if len(msg.References) > 0 {
+			report.OriginalMessageID = msg.References[len(msg.References)-1]
+		}
+	}
+	return report, nil
+}
+
+// parseDeliveryStatus parses a message/delivery-status body: one per-message block
+// followed by per-recipient blocks separated by blank lines.
+func parseDeliveryStatus(data []byte, report *DeliveryReport) {
+	blocks := readFieldBlocks(data)
+	if len(blocks) == 0 {
+		return
+	}
+
+	if mta := blocks[0].Get("Reporting-MTA"); mta != "" {
+		report.ReportingMTA = addressValue(mta)
+	}
+
+	for _, block := range blocks {
+		recipient := addressValue(block.Get("Final-Recipient"))
+		if recipient == "" {
+			recipient = addressValue(block.Get("Original-Recipient"))
+		}
+		if recipient == "" {
+			continue
+		}
+
+		action := strings.ToLower(strings.TrimSpace(block.Get("Action")))
+		status := strings.TrimSpace(block.Get("Status"))
+		if i := strings.IndexAny(status, " ("); i > 0 {
+			status = status[:i]
+		}
+
+		r := ReportRecipient{
+			Email:      strings.ToLower(recipient),
+			Action:     action,
+			Status:     status,
+			Diagnostic: addressValue(block.Get("Diagnostic-Code")),
+			Permanent:  strings.HasPrefix(status, "5"),
+		}
+		switch action {
+		case "failed":
+			r.State = ReportBounced
+		case "delayed":
+			r.State = ReportDelayed
+		case "delivered", "relayed", "expanded":
+			r.State = ReportDelivered
+		default:
+			continue
+		}
+		report.Recipients = append(report.Recipients, r)
+	}
+}
+
+// parseFeedbackReport parses an ARF message/feedback-report body (spam complaint).
+func parseFeedbackReport(data []byte, report *DeliveryReport) {
+	blocks := readFieldBlocks(data)
+	if len(blocks) == 0 {
+		return
+	}
+	fields := blocks[0]
+
+	recipient := addressValue(fields.Get("Original-Rcpt-To"))
+	if recipient == "" {
+		return
+	}
+	report.Recipients = append(report.Recipients, ReportRecipient{
+		Email:      strings.ToLower(recipient),
+		State:      ReportComplained,
+		Diagnostic: strings.TrimSpace(fields.Get("Feedback-Type")),
+	})
+	if report.ReportingMTA == "" {
+		report.ReportingMTA = addressValue(fields.Get("Reporting-MTA"))
+	}
+}
+
+// readFieldBlocks splits RFC 822-style field groups separated by blank lines.
+func readFieldBlocks(data []byte) []textproto.MIMEHeader {
+	var blocks []textproto.MIMEHeader
+	r := textproto.NewReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		header, err := r.ReadMIMEHeader()
+		if len(header) > 0 {
+			blocks = append(blocks, header)
+		}
+		if err != nil {
+			return blocks
+		}
+		// 연속된 빈 줄 건너뛰기
+		for {
+			b, err := r.R.Peek(1)
+			if err != nil {
+				return blocks
+			}
+			if b[0] != '\r' && b[0] != '\n' {
+				break
+			}
+			if _, err := r.R.ReadByte(); err != nil {
+				return blocks
+			}
+		}
+	}
+}
+
+// addressValue strips the "type;" prefix of DSN fields (예: "rfc822; user@example.com").
+func addressValue(v string) string {
+	if i := strings.Index(v, ";"); i >= 0 {
+		v = v[i+1:]
+	}
+	return strings.Trim(strings.TrimSpace(v), "<>")
+}
+
+// originalMessageID reads the Message-ID of an embedded original message or header block.
+func originalMessageID(data []byte) string {
+	m, err := mail.ReadMessage(io.MultiReader(bytes.NewReader(data), strings.NewReader("\r\n\r\n")))
+	if err != nil {
+		return ""
+	}
+	return trimAngle(m.Header.Get("Message-Id"))
+}
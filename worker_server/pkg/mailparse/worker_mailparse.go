@@ -0,0 +1,403 @@
+// Package mailparse parses RFC 5322 / MIME messages and MBOX archives.
+//
+// Provider API 없이 원본 메일(.eml, .mbox)을 직접 읽어야 하는 경우
+// (가져오기, 원본 헤더 분석 등)에 사용한다.
+package mailparse
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxPartDepth limits multipart nesting to guard against malicious input.
+const maxPartDepth = 10
+
+var (
+	ErrEmptyMessage    = errors.New("empty message")
+	ErrMessageTooLarge = errors.New("message too large")
+)
+
+// Address is a parsed mailbox.
+type Address struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email"`
+}
+
+// Attachment is a non-body MIME part.
+type Attachment struct {
+	Filename  string
+	MimeType  string
+	ContentID string
+	IsInline  bool
+	Size      int64
+	Data      []byte
+}
+
+// Message is a parsed RFC 5322 message.
+type Message struct {
+	Header     mail.Header
+	MessageID  string
+	InReplyTo  string
+	References []string
+	Subject    string
+	From       Address
+	To         []Address
+	Cc         []Address
+	Bcc        []Address
+	Date       time.Time
+	Text       string
+	HTML       string
+
+	Attachments []Attachment
+	Size        int64
+}
+
+// Parse parses a single raw RFC 5322 message.
+func Parse(raw []byte) (*Message, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, ErrEmptyMessage
+	}
+
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("read message: %w", err)
+	}
+
+	msg := &Message{
+		Header:     m.Header,
+		MessageID:  trimAngle(m.Header.Get("Message-Id")),
+		InReplyTo:  trimAngle(m.Header.Get("In-Reply-To")),
+		References: splitReferences(m.Header.Get("References")),
+		Subject:    DecodeHeader(m.Header.Get("Subject")),
+		To:         parseAddressList(m.Header, "To"),
+		Cc:         parseAddressList(m.Header, "Cc"),
+		Bcc:        parseAddressList(m.Header, "Bcc"),
+		Size:       int64(len(raw)),
+	}
+	if from := parseAddressList(m.Header, "From"); len(from) > 0 {
+		msg.From = from[0]
+	}
+	if date, err := m.Header.Date(); err == nil {
+		msg.Date = date
+	}
+
+	part := textproto.MIMEHeader(m.Header)
+	if err := msg.walk(part, m.Body, 0); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// walk recursively collects text/html bodies and attachments.
+func (msg *Message) walk(header textproto.MIMEHeader, body io.Reader, depth int) error {
+	if depth > maxPartDepth {
+		return fmt.Errorf("multipart nesting exceeds %d levels", maxPartDepth)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		boundary := params["boundary"]
+		if boundary == "" {
+			return nil
+		}
+		mr := multipart.NewReader(body, boundary)
+		for {
+			p, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				// 잘린 multipart는 읽은 부분까지만 사용
+				return nil
+			}
+			if err := msg.walk(p.Header, p, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := io.ReadAll(decodeTransfer(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return fmt.Errorf("decode part: %w", err)
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := dispParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	filename = DecodeHeader(filename)
+
+	if filename == "" && disposition != "attachment" {
+		switch {
+		case mediaType == "text/plain" && msg.Text == "":
+			msg.Text = decodeCharset(data, params["charset"])
+			return nil
+		case mediaType == "text/html" && msg.HTML == "":
+			msg.HTML = decodeCharset(data, params["charset"])
+			return nil
+		}
+	}
+
+	msg.Attachments = append(msg.Attachments, Attachment{
+		Filename:  filename,
+		MimeType:  mediaType,
+		ContentID: trimAngle(header.Get("Content-Id")),
+		IsInline:  disposition == "inline",
+		Size:      int64(len(data)),
+		Data:      data,
+	})
+	return nil
+}
+
+// HasAttachments reports whether the message carries non-inline attachments.
+func (msg *Message) HasAttachments() bool {
+	for _, a := range msg.Attachments {
+		if !a.IsInline {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	htmlTagRe    = regexp.MustCompile(`(?s)<(script|style)[^>]*>.*?</(script|style)>|<[^>]+>`)
+	whitespaceRe = regexp.MustCompile(`\s+`)
+)
+
+// Snippet returns a plain-text preview of at most n runes.
+func (msg *Message) Snippet(n int) string {
+	text := msg.Text
+	if strings.TrimSpace(text) == "" {
+		text = htmlTagRe.ReplaceAllString(msg.HTML, " ")
+	}
+	text = strings.TrimSpace(whitespaceRe.ReplaceAllString(text, " "))
+
+	if runes := []rune(text); len(runes) > n {
+		return string(runes[:n])
+	}
+	return text
+}
+
+// SplitMbox reads an MBOX stream and calls fn with each raw message.
+// Messages larger than maxSize are skipped and counted; maxSize <= 0 disables the limit.
+// mboxrd 방식의 ">From " 이스케이프를 복원한다.
+func SplitMbox(r io.Reader, maxSize int, fn func(raw []byte) error) (skipped int, err error) {
+	br := bufio.NewReaderSize(r, 64*1024)
+
+	var (
+		buf       bytes.Buffer
+		started   bool
+		oversized bool
+		prevBlank = true
+	)
+
+	flush := func() error {
+		if !started {
+			return nil
+		}
+		defer buf.Reset()
+		if oversized {
+			skipped++
+			oversized = false
+			return nil
+		}
+		raw := bytes.TrimRight(buf.Bytes(), "\r\n")
+		if len(raw) == 0 {
+			return nil
+		}
+		out := make([]byte, len(raw))
+		copy(out, raw)
+		return fn(out)
+	}
+
+	for {
+		line, readErr := br.ReadBytes('\n')
+		if len(line) > 0 {
+			if prevBlank && bytes.HasPrefix(line, []byte("From ")) {
+				if err := flush(); err != nil {
+					return skipped, err
+				}
+				started = true
+				prevBlank = false
+				continue
+			}
+
+			if !started {
+				// 구분선 없이 시작하는 파일은 단일 메시지로 취급
+				started = true
+			}
+
+			if unescaped := unescapeFrom(line); !oversized {
+				buf.Write(unescaped)
+				if maxSize > 0 && buf.Len() > maxSize {
+					oversized = true
+					buf.Reset()
+				}
+			}
+			prevBlank = len(bytes.TrimRight(line, "\r\n")) == 0
+		}
+
+		if readErr == io.EOF {
+			return skipped, flush()
+		}
+		if readErr != nil {
+			return skipped, readErr
+		}
+	}
+}
+
+// unescapeFrom strips one '>' from mboxrd-quoted ">From " lines.
+func unescapeFrom(line []byte) []byte {
+	i := 0
+	for i < len(line) && line[i] == '>' {
+		i++
+	}
+	if i > 0 && bytes.HasPrefix(line[i:], []byte("From ")) {
+		return line[1:]
+	}
+	return line
+}
+
+// DecodeHeader decodes RFC 2047 encoded-words, returning the input on failure.
+func DecodeHeader(s string) string {
+	if s == "" {
+		return s
+	}
+	dec := &mime.WordDecoder{CharsetReader: charsetReader}
+	decoded, err := dec.DecodeHeader(s)
+	if err != nil {
+		return s
+	}
+	return decoded
+}
+
+func parseAddressList(h mail.Header, key string) []Address {
+	value := h.Get(key)
+	if value == "" {
+		return nil
+	}
+
+	parser := &mail.AddressParser{WordDecoder: &mime.WordDecoder{CharsetReader: charsetReader}}
+	list, err := parser.ParseList(value)
+	if err != nil {
+		// 파싱 실패 시 주소 형태만 추출
+		var result []Address
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if start, end := strings.LastIndex(part, "<"), strings.LastIndex(part, ">"); start >= 0 && end > start {
+				result = append(result, Address{
+					Name:  strings.Trim(strings.TrimSpace(part[:start]), `"`),
+					Email: strings.ToLower(part[start+1 : end]),
+				})
+			} else if strings.Contains(part, "@") {
+				result = append(result, Address{Email: strings.ToLower(part)})
+			}
+		}
+		return result
+	}
+
+	result := make([]Address, 0, len(list))
+	for _, a := range list {
+		result = append(result, Address{Name: a.Name, Email: strings.ToLower(a.Address)})
+	}
+	return result
+}
+
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &whitespaceStripper{r: r})
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
+
+// whitespaceStripper removes CR/LF/space so base64 bodies with line breaks decode cleanly.
+type whitespaceStripper struct {
+	r io.Reader
+}
+
+func (w *whitespaceStripper) Read(p []byte) (int, error) {
+	for {
+		n, err := w.r.Read(p)
+		j := 0
+		for i := 0; i < n; i++ {
+			switch p[i] {
+			case '\r', '\n', ' ', '\t':
+				continue
+			}
+			p[j] = p[i]
+			j++
+		}
+		if j > 0 || err != nil {
+			return j, err
+		}
+	}
+}
+
+// decodeCharset converts text to UTF-8. Only UTF-8 and Latin-1 family are converted;
+// other charsets are passed through with invalid sequences replaced.
+func decodeCharset(data []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252", "cp1252":
+		return latin1ToUTF8(data)
+	default:
+		return strings.ToValidUTF8(string(data), string(utf8.RuneError))
+	}
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	return strings.NewReader(decodeCharset(data, charset)), nil
+}
+
+func latin1ToUTF8(data []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(data))
+	for _, b := range data {
+		sb.WriteRune(rune(b))
+	}
+	return sb.String()
+}
+
+func trimAngle(s string) string {
+	return strings.Trim(strings.TrimSpace(s), "<>")
+}
+
+func splitReferences(s string) []string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil
+	}
+	refs := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if ref := trimAngle(f); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
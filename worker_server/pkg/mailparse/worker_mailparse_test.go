@@ -0,0 +1,200 @@
+package mailparse
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleMultipart = "From: =?UTF-8?B?7ZmN6ri464+Z?= <Hong@Example.com>\r\n" +
+	"To: a@example.com, \"B\" <b@example.com>\r\n" +
+	"Subject: =?UTF-8?Q?Quarterly_report?=\r\n" +
+	"Message-ID: <abc@example.com>\r\n" +
+	"References: <r1@example.com> <r2@example.com>\r\n" +
+	"Date: Mon, 02 Jan 2006 15:04:05 +0900\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"XX\"\r\n" +
+	"\r\n" +
+	"--XX\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"YY\"\r\n" +
+	"\r\n" +
+	"--YY\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Hello =3D world\r\n" +
+	"--YY\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"\r\n" +
+	"<p>Hello</p>\r\n" +
+	"--YY--\r\n" +
+	"--XX\r\n" +
+	"Content-Type: application/pdf; name=\"report.pdf\"\r\n" +
+	"Content-Disposition: attachment; filename=\"report.pdf\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"aGVsbG8g\r\nd29ybGQ=\r\n" +
+	"--XX--\r\n"
+
+func TestParse_Multipart(t *testing.T) {
+	msg, err := Parse([]byte(sampleMultipart))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if msg.From.Email != "hong@example.com" || msg.From.Name != "홍길동" {
+		t.Errorf("From = %+v", msg.From)
+	}
+	if len(msg.To) != 2 || msg.To[1].Email != "b@example.com" {
+		t.Errorf("To = %+v", msg.To)
+	}
+	if msg.Subject != "Quarterly report" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if msg.MessageID != "abc@example.com" {
+		t.Errorf("MessageID = %q", msg.MessageID)
+	}
+	if len(msg.References) != 2 {
+		t.Errorf("References = %v", msg.References)
+	}
+	if strings.TrimSpace(msg.Text) != "Hello = world" {
+		t.Errorf("Text = %q", msg.Text)
+	}
+	if strings.TrimSpace(msg.HTML) != "<p>Hello</p>" {
+		t.Errorf("HTML = %q", msg.HTML)
+	}
+	if len(msg.Attachments) != 1 || string(msg.Attachments[0].Data) != "hello world" {
+		t.Fatalf("Attachments = %+v", msg.Attachments)
+	}
+	if !msg.HasAttachments() {
+		t.Error("HasAttachments() = false")
+	}
+}
+
+func TestSplitMbox(t *testing.T) {
+	mbox := "From alice@example.com Mon Jan  2 15:04:05 2006\n" +
+		"Subject: one\n\nbody one\n>From the archive\n\n" +
+		"From bob@example.com Mon Jan  2 15:04:05 2006\n" +
+		"Subject: two\n\n" + strings.Repeat("x", 200) + "\n\n" +
+		"From carol@example.com Mon Jan  2 15:04:05 2006\n" +
+		"Subject: three\n\nbody three\n"
+
+	var subjects []string
+	var bodies []string
+	skipped, err := SplitMbox(strings.NewReader(mbox), 100, func(raw []byte) error {
+		msg, err := Parse(raw)
+		if err != nil {
+			return err
+		}
+		subjects = append(subjects, msg.Subject)
+		bodies = append(bodies, msg.Text)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SplitMbox() error = %v", err)
+	}
+	if skipped != 1 {
+		t.Errorf("skipped = %d, want 1", skipped)
+	}
+	if strings.Join(subjects, ",") != "one,three" {
+		t.Errorf("subjects = %v", subjects)
+	}
+	if !strings.Contains(bodies[0], "\nFrom the archive") {
+		t.Errorf("mboxrd escape not restored: %q", bodies[0])
+	}
+}
+
+func TestParseAuthenticationResults(t *testing.T) {
+	values := []string{
+		"mx.google.com; dkim=fail header.i=@other.example header.s=s1; " +
+			"dkim=pass header.i=@example.com header.s=s2 header.b=abc; " +
+			"spf=softfail (google.com: domain of x@example.com does not designate 1.2.3.4 as permitted sender) smtp.mailfrom=x@Example.com; " +
+			"dmarc=pass (p=REJECT sp=REJECT dis=NONE) header.from=example.com",
+		"relay.example.net; spf=pass smtp.mailfrom=relay.example.net",
+	}
+
+	r := ParseAuthenticationResults(values, "")
+	if r.AuthServID != "mx.google.com" {
+		t.Errorf("authserv-id = %q", r.AuthServID)
+	}
+	if r.SPF == nil || r.SPF.Result != "softfail" || r.SPF.Domain != "example.com" {
+		t.Errorf("spf = %+v", r.SPF)
+	}
+	if r.DKIM == nil || !r.DKIM.Passed() || r.DKIM.Domain != "example.com" {
+		t.Errorf("dkim = %+v", r.DKIM)
+	}
+	if r.DMARC == nil || r.DMARC.Detail != "p=REJECT sp=REJECT dis=NONE" {
+		t.Errorf("dmarc = %+v", r.DMARC)
+	}
+	if v := r.Verdict(); v != "fail" {
+		t.Errorf("verdict = %q, want fail", v)
+	}
+
+	spf := ParseAuthenticationResults(nil,
+		"pass (example.net: domain of a@b.org designates 1.2.3.4 as permitted sender) client-ip=1.2.3.4; envelope-from=<a@b.org>;").SPF
+	if spf == nil || spf.Result != "pass" || spf.Domain != "b.org" {
+		t.Errorf("received-spf = %+v", spf)
+	}
+}
+
+const sampleDSN = "From: Mail Delivery Subsystem <mailer-daemon@googlemail.com>\r\n" +
+	"To: me@example.com\r\n" +
+	"Subject: Delivery Status Notification (Failure)\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/report; report-type=delivery-status; boundary=\"RR\"\r\n" +
+	"\r\n" +
+	"--RR\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"\r\n" +
+	"Address not found\r\n" +
+	"--RR\r\n" +
+	"Content-Type: message/delivery-status\r\n" +
+	"\r\n" +
+	"Reporting-MTA: dns; googlemail.com\r\n" +
+	"\r\n" +
+	"Final-Recipient: rfc822; Nobody@Example.org\r\n" +
+	"Action: failed\r\n" +
+	"Status: 5.1.1\r\n" +
+	"Diagnostic-Code: smtp; 550 5.1.1 user unknown\r\n" +
+	"\r\n" +
+	"Final-Recipient: rfc822; slow@example.org\r\n" +
+	"Action: delayed\r\n" +
+	"Status: 4.4.7\r\n" +
+	"\r\n" +
+	"--RR\r\n" +
+	"Content-Type: text/rfc822-headers\r\n" +
+	"\r\n" +
+	"Message-ID: <orig@example.com>\r\n" +
+	"Subject: hello\r\n" +
+	"--RR--\r\n"
+
+func TestParseDeliveryReport(t *testing.T) {
+	report, err := ParseDeliveryReport([]byte(sampleDSN))
+	if err != nil || report == nil {
+		t.Fatalf("ParseDeliveryReport: %v, %v", report, err)
+	}
+	if report.OriginalMessageID != "orig@example.com" || report.ReportingMTA != "googlemail.com" {
+		t.Errorf("report = %+v", report)
+	}
+	if len(report.Recipients) != 2 {
+		t.Fatalf("recipients = %+v", report.Recipients)
+	}
+	bounce := report.Recipients[0]
+	if bounce.Email != "nobody@example.org" || bounce.State != ReportBounced || bounce.Status != "5.1.1" || !bounce.Permanent ||
+		bounce.Diagnostic != "550 5.1.1 user unknown" {
+		t.Errorf("bounce = %+v", bounce)
+	}
+	if delayed := report.Recipients[1]; delayed.State != ReportDelayed || delayed.Permanent {
+		t.Errorf("delayed = %+v", delayed)
+	}
+
+	if !LooksLikeDeliveryReport("mailer-daemon@googlemail.com", "Delivery Status Notification (Failure)", "", "") {
+		t.Error("LooksLikeDeliveryReport should match mailer-daemon bounce")
+	}
+	if LooksLikeDeliveryReport("friend@example.com", "Undeliverable thoughts", "text/plain", "") {
+		t.Error("LooksLikeDeliveryReport matched a regular message")
+	}
+
+	if report, _ := ParseDeliveryReport([]byte(sampleMultipart)); report != nil {
+		t.Errorf("regular message parsed as report: %+v", report)
+	}
+}
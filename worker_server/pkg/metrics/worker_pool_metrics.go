@@ -127,24 +127,33 @@ func AssessDBPoolHealth(stats DBPoolStats) PoolHealth {
 // Multi-Pool Monitor Registry
 // =============================================================================
 
+// PoolStatsFunc reports the current statistics of a pool.
+// database/sql 외의 풀(pgxpool, Redis 등)은 이 함수로 DBPoolStats에 맞춰 등록한다.
+type PoolStatsFunc func() DBPoolStats
+
 // PoolMonitor tracks multiple connection pools.
 type PoolMonitor struct {
 	mu    sync.RWMutex
-	pools map[string]*sql.DB
+	pools map[string]PoolStatsFunc
 }
 
 // NewPoolMonitor creates a new pool monitor.
 func NewPoolMonitor() *PoolMonitor {
 	return &PoolMonitor{
-		pools: make(map[string]*sql.DB),
+		pools: make(map[string]PoolStatsFunc),
 	}
 }
 
 // Register adds a database pool to be monitored.
 func (m *PoolMonitor) Register(name string, db *sql.DB) {
+	m.RegisterStats(name, func() DBPoolStats { return GetDBPoolStats(db) })
+}
+
+// RegisterStats adds a pool whose statistics come from fn.
+func (m *PoolMonitor) RegisterStats(name string, fn PoolStatsFunc) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.pools[name] = db
+	m.pools[name] = fn
 }
 
 // Unregister removes a database pool from monitoring.
@@ -157,13 +166,13 @@ func (m *PoolMonitor) Unregister(name string) {
 // Stats returns statistics for a specific pool.
 func (m *PoolMonitor) Stats(name string) (DBPoolStats, bool) {
 	m.mu.RLock()
-	db, ok := m.pools[name]
+	fn, ok := m.pools[name]
 	m.mu.RUnlock()
 
 	if !ok {
 		return DBPoolStats{}, false
 	}
-	return GetDBPoolStats(db), true
+	return fn(), true
 }
 
 // AllStats returns statistics for all registered pools.
@@ -172,8 +181,8 @@ func (m *PoolMonitor) AllStats() map[string]DBPoolStats {
 	defer m.mu.RUnlock()
 
 	result := make(map[string]DBPoolStats, len(m.pools))
-	for name, db := range m.pools {
-		result[name] = GetDBPoolStats(db)
+	for name, fn := range m.pools {
+		result[name] = fn()
 	}
 	return result
 }
@@ -184,9 +193,8 @@ func (m *PoolMonitor) AllHealth() map[string]PoolHealth {
 	defer m.mu.RUnlock()
 
 	result := make(map[string]PoolHealth, len(m.pools))
-	for name, db := range m.pools {
-		stats := GetDBPoolStats(db)
-		result[name] = AssessDBPoolHealth(stats)
+	for name, fn := range m.pools {
+		result[name] = AssessDBPoolHealth(fn())
 	}
 	return result
 }
@@ -213,6 +221,11 @@ func RegisterPool(name string, db *sql.DB) {
 	GlobalPoolMonitor().Register(name, db)
 }
 
+// RegisterPoolStats registers a non database/sql pool with the global monitor.
+func RegisterPoolStats(name string, fn PoolStatsFunc) {
+	GlobalPoolMonitor().RegisterStats(name, fn)
+}
+
 // GetPoolStats gets stats from the global pool monitor.
 func GetPoolStats(name string) (DBPoolStats, bool) {
 	return GlobalPoolMonitor().Stats(name)
@@ -53,6 +53,7 @@ type APIProtector struct {
 	rateLimiter *SlidingWindowLimiter
 	debouncer   *Debouncer
 	redis       *redis.Client
+	quotas      *userQuotas // SetTiers로 설정 (nil이면 전역 한도만)
 	mu          sync.RWMutex
 }
 
@@ -78,6 +79,7 @@ type ProtectionResult struct {
 	ShouldWait   bool
 	WaitDuration time.Duration
 	FromDebounce bool
+	Quota        *QuotaStatus // AcquireForUser에서만 설정
 }
 
 // Acquire tries to acquire permission for API call.
@@ -1,19 +1,24 @@
 package ratelimit
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"math"
+	"math/rand/v2"
 	"sync"
 	"time"
 
 	"github.com/goccy/go-json"
 
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
 // =============================================================================
 // EmailListCache - 이메일 목록 캐시
 // 전략: 최신 메일 (offset < 100)만 캐시, 오래된 메일은 캐시 X
+// 스탬피드 방지: 동시 미스 요청 병합 + 만료 직전 확률적 조기 갱신 + 빈 결과 짧게 캐시
 // =============================================================================
 
 // CacheConfig holds cache configuration.
@@ -27,6 +32,13 @@ type CacheConfig struct {
 
 	// 캐시 대상 제한
 	MaxCacheableOffset int // 이 offset 이상은 캐시 안 함 (기본: 100)
+
+	// 빈 목록 캐시 TTL (기본: 10초, 0이면 빈 목록은 캐시 안 함)
+	NegativeTTL time.Duration
+
+	// 조기 갱신 (XFetch): 예상 재계산 시간. 남은 TTL이 이 값에 가까울수록
+	// 일부 요청이 미스로 처리되어 만료 전에 다시 채운다 (0이면 조기 갱신 안 함)
+	EarlyRefreshDelta time.Duration
 }
 
 // DefaultCacheConfig returns default cache configuration.
@@ -36,6 +48,8 @@ func DefaultCacheConfig() *CacheConfig {
 		L1TTL:              30 * time.Second,
 		L2TTL:              1 * time.Minute,
 		MaxCacheableOffset: 100, // offset 100 이상은 캐시 안 함
+		NegativeTTL:        10 * time.Second,
+		EarlyRefreshDelta:  300 * time.Millisecond,
 	}
 }
 
@@ -44,6 +58,7 @@ type EmailListCache struct {
 	config *CacheConfig
 	l1     *L1Cache
 	redis  *redis.Client
+	flight singleflight.Group // 같은 키의 동시 미스 병합
 }
 
 // NewEmailListCache creates a new email list cache.
@@ -73,6 +88,12 @@ func (k *CacheKey) String() string {
 		k.UserID, k.ConnectionID, k.Folder, k.Offset, k.Limit)
 }
 
+// ListKey builds the cache key of a list view: email:list:<user>:<view>:<params>.
+// 사용자·뷰 prefix가 있어 InvalidateByUser, Patch*, InvalidateViews가 키를 찾을 수 있다.
+func ListKey(userID, view, params string) string {
+	return "email:list:" + userID + ":" + view + ":" + params
+}
+
 // ShouldCache returns true if this query should be cached.
 func (c *EmailListCache) ShouldCache(offset int) bool {
 	return offset < c.config.MaxCacheableOffset
@@ -84,20 +105,51 @@ func (c *EmailListCache) Get(ctx context.Context, key *CacheKey) ([]byte, bool)
 	if !c.ShouldCache(key.Offset) {
 		return nil, false
 	}
+	return c.lookup(ctx, key.String())
+}
+
+// Set stores email list in cache.
+func (c *EmailListCache) Set(ctx context.Context, key *CacheKey, data []byte) {
+	// 오래된 메일은 캐시하지 않음
+	if !c.ShouldCache(key.Offset) {
+		return
+	}
+	c.store(ctx, key.String(), data)
+}
 
-	keyStr := key.String()
+// Coalesce runs fn once for concurrent callers with the same key and shares the result.
+// 캐시 미스 시 DB/Provider 조회를 한 번으로 합친다. shared는 다른 요청의 결과를 받았는지 여부.
+func (c *EmailListCache) Coalesce(key string, fn func() (any, error)) (v any, shared bool, err error) {
+	v, err, shared = c.flight.Do(key, fn)
+	return v, shared, err
+}
 
+// lookup checks L1 then L2. 만료가 가까우면 확률적으로 미스를 돌려 이 요청이 갱신하게 한다.
+func (c *EmailListCache) lookup(ctx context.Context, key string) ([]byte, bool) {
 	// 1. L1 캐시 확인
-	if data, ok := c.l1.Get(keyStr); ok {
+	if data, expiresAt, ok := c.l1.getEntry(key); ok {
+		if c.refreshEarly(time.Until(expiresAt)) {
+			return nil, false
+		}
 		return data, true
 	}
 
-	// 2. L2 (Redis) 캐시 확인
+	// 2. L2 (Redis) 캐시 확인 - 남은 TTL도 같은 왕복에서 조회
 	if c.redis != nil {
-		data, err := c.redis.Get(ctx, keyStr).Bytes()
-		if err == nil {
-			// L1에도 저장
-			c.l1.Set(keyStr, data)
+		pipe := c.redis.Pipeline()
+		get := pipe.Get(ctx, key)
+		ttl := pipe.PTTL(ctx, key)
+		if _, err := pipe.Exec(ctx); err == nil {
+			data, _ := get.Bytes()
+			if c.refreshEarly(ttl.Val()) {
+				return nil, false
+			}
+			// L1에도 저장 (L2보다 오래 남지 않도록)
+			l1TTL := c.l1.ttl
+			if remaining := ttl.Val(); remaining > 0 {
+				l1TTL = min(l1TTL, remaining)
+			}
+			c.l1.SetWithTTL(key, data, l1TTL)
 			return data, true
 		}
 	}
@@ -105,24 +157,41 @@ func (c *EmailListCache) Get(ctx context.Context, key *CacheKey) ([]byte, bool)
 	return nil, false
 }
 
-// Set stores email list in cache.
-func (c *EmailListCache) Set(ctx context.Context, key *CacheKey, data []byte) {
-	// 오래된 메일은 캐시하지 않음
-	if !c.ShouldCache(key.Offset) {
-		return
+// store saves data in L1 and L2. 빈 목록은 NegativeTTL로 짧게 캐시한다.
+func (c *EmailListCache) store(ctx context.Context, key string, data []byte) {
+	l1TTL, l2TTL := c.config.L1TTL, c.config.L2TTL
+	if isEmptyList(data) {
+		if c.config.NegativeTTL <= 0 {
+			return
+		}
+		l1TTL, l2TTL = min(l1TTL, c.config.NegativeTTL), c.config.NegativeTTL
 	}
 
-	keyStr := key.String()
-
 	// 1. L1 캐시에 저장
-	c.l1.Set(keyStr, data)
+	c.l1.SetWithTTL(key, data, l1TTL)
 
 	// 2. L2 (Redis)에 저장
 	if c.redis != nil {
-		c.redis.Set(ctx, keyStr, data, c.config.L2TTL)
+		c.redis.Set(ctx, key, data, l2TTL)
 	}
 }
 
+// refreshEarly implements XFetch: remaining <= delta * -ln(rand) 이면 조기 갱신.
+// 만료 직전일수록 확률이 높아져, 동시에 만료되어 모두 미스가 나는 것을 막는다.
+func (c *EmailListCache) refreshEarly(remaining time.Duration) bool {
+	delta := c.config.EarlyRefreshDelta
+	if delta <= 0 || remaining <= 0 {
+		return false
+	}
+	return float64(remaining) <= float64(delta)*-math.Log(1-rand.Float64())
+}
+
+// isEmptyList reports whether cached data is an empty JSON list.
+func isEmptyList(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return bytes.Equal(data, []byte("[]")) || bytes.Equal(data, []byte("null"))
+}
+
 // Invalidate removes cache entries for a user/connection.
 func (c *EmailListCache) Invalidate(ctx context.Context, userID string, connectionID int64) {
 	// L1 캐시 무효화 (패턴 매칭)
@@ -153,6 +222,25 @@ func (c *EmailListCache) InvalidateByUser(ctx context.Context, userID string) {
 	}
 }
 
+// InvalidateViews removes the cache entries of the given list views of a user.
+// 동기화 후처럼 어떤 뷰가 바뀌었는지 알 때 InvalidateByUser 대신 사용한다.
+func (c *EmailListCache) InvalidateViews(ctx context.Context, userID string, views ...string) {
+	for _, view := range views {
+		prefix := ListKey(userID, view, "")
+
+		// L1 캐시 무효화
+		c.l1.InvalidateByPrefix(prefix)
+
+		// L2 캐시 무효화
+		if c.redis != nil {
+			keys, _ := c.redis.Keys(ctx, prefix+"*").Result()
+			if len(keys) > 0 {
+				c.redis.Del(ctx, keys...)
+			}
+		}
+	}
+}
+
 // =============================================================================
 // Cache Patch Methods (Optimistic Update)
 // 전체 캐시 삭제 대신 해당 이메일만 패치하여 성능 최적화
@@ -386,26 +474,45 @@ func NewL1Cache(maxSize int, ttl time.Duration) *L1Cache {
 
 // Get retrieves value from cache.
 func (c *L1Cache) Get(key string) ([]byte, bool) {
+	data, _, ok := c.getEntry(key)
+	return data, ok
+}
+
+// getEntry returns the value and its expiry.
+func (c *L1Cache) getEntry(key string) ([]byte, time.Time, bool) {
 	c.mu.RLock()
 	entry, exists := c.items[key]
+	var data []byte
+	var expiresAt time.Time
+	if exists {
+		data, expiresAt = entry.data, entry.expiresAt
+	}
 	c.mu.RUnlock()
 
 	if !exists {
-		return nil, false
+		return nil, time.Time{}, false
 	}
 
-	if time.Now().After(entry.expiresAt) {
+	if time.Now().After(expiresAt) {
 		c.mu.Lock()
 		delete(c.items, key)
 		c.mu.Unlock()
-		return nil, false
+		return nil, time.Time{}, false
 	}
 
-	return entry.data, true
+	return data, expiresAt, true
 }
 
 // Set stores value in cache.
 func (c *L1Cache) Set(key string, data []byte) {
+	c.SetWithTTL(key, data, c.ttl)
+}
+
+// SetWithTTL stores value in cache with a custom TTL.
+func (c *L1Cache) SetWithTTL(key string, data []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -420,7 +527,7 @@ func (c *L1Cache) Set(key string, data []byte) {
 
 	c.items[key] = &cacheEntry{
 		data:      data,
-		expiresAt: time.Now().Add(c.ttl),
+		expiresAt: time.Now().Add(ttl),
 	}
 	c.order = append(c.order, key)
 }
@@ -468,23 +575,7 @@ func (c *EmailListCache) GetByString(ctx context.Context, key string, offset int
 	if !c.ShouldCache(offset) {
 		return nil, false
 	}
-
-	// 1. L1 캐시 확인
-	if data, ok := c.l1.Get(key); ok {
-		return data, true
-	}
-
-	// 2. L2 (Redis) 캐시 확인
-	if c.redis != nil {
-		data, err := c.redis.Get(ctx, key).Bytes()
-		if err == nil {
-			// L1에도 저장
-			c.l1.Set(key, data)
-			return data, true
-		}
-	}
-
-	return nil, false
+	return c.lookup(ctx, key)
 }
 
 // SetByString stores data using string key.
@@ -493,14 +584,7 @@ func (c *EmailListCache) SetByString(ctx context.Context, key string, offset int
 	if !c.ShouldCache(offset) {
 		return
 	}
-
-	// 1. L1 캐시에 저장
-	c.l1.Set(key, data)
-
-	// 2. L2 (Redis)에 저장
-	if c.redis != nil {
-		c.redis.Set(ctx, key, data, c.config.L2TTL)
-	}
+	c.store(ctx, key, data)
 }
 
 // =============================================================================
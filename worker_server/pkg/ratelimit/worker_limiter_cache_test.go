@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEmailListCacheNegativeTTL(t *testing.T) {
+	cache := NewEmailListCache(nil, &CacheConfig{
+		L1MaxSize:          10,
+		L1TTL:              time.Minute,
+		MaxCacheableOffset: 100,
+		NegativeTTL:        20 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	cache.SetByString(ctx, "full", 0, []byte(`[{"id":1}]`))
+	cache.SetByString(ctx, "empty", 0, []byte(`[]`))
+	if _, ok := cache.GetByString(ctx, "empty", 0); !ok {
+		t.Fatal("empty list not cached")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if _, ok := cache.GetByString(ctx, "empty", 0); ok {
+		t.Error("empty list outlived NegativeTTL")
+	}
+	if _, ok := cache.GetByString(ctx, "full", 0); !ok {
+		t.Error("non-empty list expired with NegativeTTL")
+	}
+}
+
+func TestEmailListCacheRefreshEarly(t *testing.T) {
+	cache := NewEmailListCache(nil, &CacheConfig{L1MaxSize: 1, EarlyRefreshDelta: time.Second})
+
+	if cache.refreshEarly(time.Hour) {
+		t.Error("refreshEarly() = true far from expiry")
+	}
+	refreshed := 0
+	for range 1000 {
+		if cache.refreshEarly(time.Millisecond) {
+			refreshed++
+		}
+	}
+	if refreshed < 900 {
+		t.Errorf("refreshEarly() near expiry = %d/1000, want most", refreshed)
+	}
+}
+
+func TestEmailListCacheCoalesce(t *testing.T) {
+	cache := NewEmailListCache(nil, nil)
+	release := make(chan struct{})
+	var calls atomic.Int32
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, _, err := cache.Coalesce("key", func() (any, error) {
+				calls.Add(1)
+				<-release
+				return 42, nil
+			})
+			if err != nil || v != 42 {
+				t.Errorf("Coalesce() = %v, %v", v, err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond) // 모든 요청이 대기하도록
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("loader ran %d times, want 1", n)
+	}
+}
@@ -0,0 +1,270 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// =============================================================================
+// Per-user tier quotas
+// 구조: 사용자 동시 요청 → 사용자 RPS → (전역) Semaphore → Debounce → Rate Limiter
+// =============================================================================
+
+// Tier is a user's plan level.
+type Tier string
+
+const (
+	TierFree Tier = "free"
+	TierPro  Tier = "pro"
+)
+
+// Quota names reported when a user quota is exhausted.
+const (
+	QuotaConcurrency = "concurrency"
+	QuotaRate        = "rate"
+)
+
+// tierCacheTTL - 사용자 tier 조회 결과를 메모리에 두는 시간 (플랜 변경은 이 시간 안에 반영)
+const tierCacheTTL = 5 * time.Minute
+
+// TierLimits holds the per-user limits of a tier.
+type TierLimits struct {
+	MaxConcurrent     int // 사용자당 동시 Provider 요청 수
+	RequestsPerSecond int // 사용자당 초당 요청 수
+	BurstSize         int
+	MaxPayloadSize    int // 응답 최대 개수
+}
+
+// DefaultTierLimits returns the built-in free/pro limits.
+func DefaultTierLimits() map[Tier]TierLimits {
+	return map[Tier]TierLimits{
+		TierFree: {MaxConcurrent: 2, RequestsPerSecond: 2, BurstSize: 4, MaxPayloadSize: 50},
+		TierPro:  {MaxConcurrent: 8, RequestsPerSecond: 10, BurstSize: 20, MaxPayloadSize: 100},
+	}
+}
+
+// ParseTier parses a tier name; unknown names fall back to free.
+func ParseTier(name string) Tier {
+	if Tier(name) == TierPro {
+		return TierPro
+	}
+	return TierFree
+}
+
+// TierLookup loads a user's tier (DB 등). 에러면 free로 취급한다.
+type TierLookup func(ctx context.Context, userID string) (Tier, error)
+
+// QuotaStatus is a user's quota state after an acquisition attempt (응답 헤더용).
+type QuotaStatus struct {
+	Tier       Tier
+	Exceeded   string // 소진된 쿼터 (QuotaConcurrency, QuotaRate), 통과 시 ""
+	Limit      int    // 동시 요청 한도
+	Remaining  int    // 남은 동시 요청 수
+	RetryAfter time.Duration
+}
+
+type tierEntry struct {
+	tier      Tier
+	expiresAt time.Time
+}
+
+// userQuotas tracks per-user concurrency and per-tier rate limiters.
+type userQuotas struct {
+	limits   map[Tier]TierLimits
+	limiters map[Tier]*SlidingWindowLimiter
+	lookup   TierLookup
+
+	mu     sync.Mutex
+	active map[string]int
+	tiers  map[string]tierEntry
+}
+
+func newUserQuotas(redisClient *redis.Client, limits map[Tier]TierLimits, lookup TierLookup) *userQuotas {
+	if limits == nil {
+		limits = DefaultTierLimits()
+	}
+	q := &userQuotas{
+		limits:   limits,
+		limiters: make(map[Tier]*SlidingWindowLimiter, len(limits)),
+		lookup:   lookup,
+		active:   make(map[string]int),
+		tiers:    make(map[string]tierEntry),
+	}
+	for tier, l := range limits {
+		q.limiters[tier] = NewSlidingWindowLimiter(redisClient, l.RequestsPerSecond, l.BurstSize)
+	}
+	return q
+}
+
+// tierOf returns the cached tier of a user, loading it on miss.
+func (q *userQuotas) tierOf(ctx context.Context, userID string) Tier {
+	now := time.Now()
+	q.mu.Lock()
+	entry, ok := q.tiers[userID]
+	q.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.tier
+	}
+
+	tier := TierFree
+	if q.lookup != nil {
+		t, err := q.lookup(ctx, userID)
+		if err != nil {
+			// 조회 실패는 이번 요청만 free로 처리하고 캐시하지 않는다 (다음 요청에서 다시 조회)
+			return TierFree
+		}
+		tier = t
+	}
+	if _, known := q.limits[tier]; !known {
+		tier = TierFree
+	}
+
+	q.mu.Lock()
+	q.tiers[userID] = tierEntry{tier: tier, expiresAt: now.Add(tierCacheTTL)}
+	q.mu.Unlock()
+	return tier
+}
+
+// limitsOf returns the limits of tier (없으면 free).
+func (q *userQuotas) limitsOf(tier Tier) TierLimits {
+	if l, ok := q.limits[tier]; ok {
+		return l
+	}
+	return q.limits[TierFree]
+}
+
+// reserve takes a concurrency slot; false when the user is at the limit.
+func (q *userQuotas) reserve(userID string, limit int) (remaining int, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if limit > 0 && q.active[userID] >= limit {
+		return 0, false
+	}
+	q.active[userID]++
+	return limit - q.active[userID], true
+}
+
+func (q *userQuotas) release(userID string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.active[userID] <= 1 {
+		delete(q.active, userID)
+		return
+	}
+	q.active[userID]--
+}
+
+// SetTiers enables per-user tier quotas. limits가 nil이면 DefaultTierLimits를 쓴다.
+func (p *APIProtector) SetTiers(limits map[Tier]TierLimits, lookup TierLookup) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.quotas = newUserQuotas(p.redis, limits, lookup)
+}
+
+func (p *APIProtector) userQuotas() *userQuotas {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.quotas
+}
+
+// TierOf returns the user's tier (tier 미설정 시 free).
+func (p *APIProtector) TierOf(ctx context.Context, userID string) Tier {
+	q := p.userQuotas()
+	if q == nil {
+		return TierFree
+	}
+	return q.tierOf(ctx, userID)
+}
+
+// MaxPayloadSizeFor returns the max payload size of the user's tier.
+// tier 미설정이면 전역 MaxPayloadSize.
+func (p *APIProtector) MaxPayloadSizeFor(ctx context.Context, userID string) int {
+	q := p.userQuotas()
+	if q == nil {
+		return p.MaxPayloadSize()
+	}
+	if size := q.limitsOf(q.tierOf(ctx, userID)).MaxPayloadSize; size > 0 {
+		return size
+	}
+	return p.MaxPayloadSize()
+}
+
+// AcquireForUser checks the user's tier quotas and then the global protection layer.
+// 사용자 쿼터로 거부되면 result.Quota.Exceeded가 설정된다 (429 응답 대상).
+func (p *APIProtector) AcquireForUser(ctx context.Context, userID, key string) (*ProtectionResult, func()) {
+	q := p.userQuotas()
+	if q == nil {
+		return p.Acquire(ctx, key)
+	}
+
+	tier := q.tierOf(ctx, userID)
+	limits := q.limitsOf(tier)
+	status := &QuotaStatus{Tier: tier, Limit: limits.MaxConcurrent}
+
+	// 1. 사용자 동시 요청
+	remaining, ok := q.reserve(userID, limits.MaxConcurrent)
+	if !ok {
+		status.Exceeded = QuotaConcurrency
+		status.RetryAfter = time.Second
+		return &ProtectionResult{Allowed: false, Reason: "user concurrency quota exceeded", Quota: status}, nil
+	}
+	status.Remaining = remaining
+
+	// 2. 사용자 RPS (tier별 한도, 사용자 단위 키)
+	if limiter := q.limiters[tier]; limiter != nil && limits.RequestsPerSecond > 0 {
+		if allowed, wait := limiter.Allow(ctx, "user:"+userID); !allowed {
+			q.release(userID)
+			status.Exceeded = QuotaRate
+			status.Remaining = remaining + 1
+			status.RetryAfter = wait
+			return &ProtectionResult{
+				Allowed:      false,
+				Reason:       "user rate quota exceeded",
+				ShouldWait:   wait > 0,
+				WaitDuration: wait,
+				Quota:        status,
+			}, nil
+		}
+	}
+
+	// 3. 전역 보호 레이어
+	result, release := p.Acquire(ctx, key)
+	if !result.Allowed || release == nil {
+		q.release(userID)
+		status.Remaining = remaining + 1
+		result.Quota = status
+		return result, nil
+	}
+	result.Quota = status
+
+	var once sync.Once
+	return result, func() {
+		once.Do(func() {
+			release()
+			q.release(userID)
+		})
+	}
+}
+
+// AcquireForUserWithWait is AcquireForUser with waiting if rate limited.
+func (p *APIProtector) AcquireForUserWithWait(ctx context.Context, userID, key string, maxWait time.Duration) (*ProtectionResult, func()) {
+	result, release := p.AcquireForUser(ctx, userID, key)
+
+	if !result.Allowed && result.ShouldWait && result.WaitDuration <= maxWait {
+		select {
+		case <-time.After(result.WaitDuration):
+			return p.AcquireForUser(ctx, userID, key)
+		case <-ctx.Done():
+			return &ProtectionResult{
+				Allowed: false,
+				Reason:  "context cancelled",
+				Quota:   result.Quota,
+			}, nil
+		}
+	}
+
+	return result, release
+}
@@ -0,0 +1,113 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTierProtector(lookup TierLookup) *APIProtector {
+	p := NewAPIProtector(nil, &Config{MaxConcurrent: 10, DebounceDuration: time.Millisecond, MaxPayloadSize: 30})
+	p.SetTiers(map[Tier]TierLimits{
+		TierFree: {MaxConcurrent: 1, MaxPayloadSize: 20},
+		TierPro:  {MaxConcurrent: 2, MaxPayloadSize: 80},
+	}, lookup)
+	return p
+}
+
+func TestAcquireForUserConcurrencyQuota(t *testing.T) {
+	p := newTierProtector(nil)
+	ctx := context.Background()
+
+	first, release := p.AcquireForUser(ctx, "u1", "k1")
+	if !first.Allowed || release == nil {
+		t.Fatalf("first acquire denied: %s", first.Reason)
+	}
+	if first.Quota == nil || first.Quota.Tier != TierFree || first.Quota.Remaining != 0 {
+		t.Errorf("Quota = %+v, want free with 0 remaining", first.Quota)
+	}
+
+	second, _ := p.AcquireForUser(ctx, "u1", "k2")
+	if second.Allowed || second.Quota == nil || second.Quota.Exceeded != QuotaConcurrency {
+		t.Fatalf("second acquire = %+v, want concurrency quota exceeded", second)
+	}
+
+	other, releaseOther := p.AcquireForUser(ctx, "u2", "k3")
+	if !other.Allowed {
+		t.Errorf("other user denied: %s", other.Reason)
+	}
+	releaseOther()
+
+	release()
+	release() // 두 번 호출해도 슬롯을 한 번만 반환
+	third, releaseThird := p.AcquireForUser(ctx, "u1", "k4")
+	if !third.Allowed {
+		t.Fatalf("acquire after release denied: %s", third.Reason)
+	}
+	releaseThird()
+}
+
+func TestAcquireForUserReleasesSlotOnGlobalDenial(t *testing.T) {
+	p := newTierProtector(nil)
+	ctx := context.Background()
+
+	_, release := p.AcquireForUser(ctx, "u1", "same")
+	release()
+	// 같은 키는 debounce로 거부되지만 사용자 슬롯은 남아 있어야 한다
+	if res, _ := p.AcquireForUser(ctx, "u1", "same"); res.Allowed || !res.FromDebounce || res.Quota.Exceeded != "" {
+		t.Fatalf("duplicate acquire = %+v, want debounced without quota error", res)
+	}
+	if res, release := p.AcquireForUser(ctx, "u1", "other"); !res.Allowed {
+		t.Errorf("slot leaked after global denial: %s", res.Reason)
+	} else {
+		release()
+	}
+}
+
+func TestTierLookup(t *testing.T) {
+	calls := 0
+	p := newTierProtector(func(ctx context.Context, userID string) (Tier, error) {
+		calls++
+		switch userID {
+		case "pro":
+			return TierPro, nil
+		case "broken":
+			return "", errors.New("db down")
+		}
+		return Tier("enterprise"), nil
+	})
+	ctx := context.Background()
+
+	tests := []struct {
+		userID  string
+		tier    Tier
+		payload int
+	}{
+		{"pro", TierPro, 80},
+		{"broken", TierFree, 20},
+		{"unknown-tier", TierFree, 20},
+	}
+	for _, tt := range tests {
+		if got := p.TierOf(ctx, tt.userID); got != tt.tier {
+			t.Errorf("TierOf(%s) = %s, want %s", tt.userID, got, tt.tier)
+		}
+		if got := p.MaxPayloadSizeFor(ctx, tt.userID); got != tt.payload {
+			t.Errorf("MaxPayloadSizeFor(%s) = %d, want %d", tt.userID, got, tt.payload)
+		}
+	}
+	// 조회 실패(broken)만 캐시하지 않아 TierOf/MaxPayloadSizeFor에서 각각 조회한다
+	if calls != len(tests)+1 {
+		t.Errorf("lookup calls = %d, want %d (cached except failures)", calls, len(tests)+1)
+	}
+}
+
+func TestMaxPayloadSizeForWithoutTiers(t *testing.T) {
+	p := NewAPIProtector(nil, &Config{MaxConcurrent: 1, MaxPayloadSize: 30})
+	if got := p.MaxPayloadSizeFor(context.Background(), "u1"); got != 30 {
+		t.Errorf("MaxPayloadSizeFor() = %d, want global 30", got)
+	}
+	if got := p.TierOf(context.Background(), "u1"); got != TierFree {
+		t.Errorf("TierOf() = %s, want free", got)
+	}
+}
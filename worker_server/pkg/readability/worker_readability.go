@@ -0,0 +1,527 @@
+// Package readability extracts the article content of newsletter emails (reader mode).
+//
+// 단락 점수 기반(Arc90 readability 방식)으로 본문 컨테이너를 고르고, 레이아웃 테이블,
+// 헤더/푸터/수신거부 영역, 추적 픽셀과 링크 추적 파라미터를 제거한 단순한 HTML을 만든다.
+package readability
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"worker_server/pkg/htmlsanitize"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	// wordsPerMinute / charsPerMinute - 읽기 속도 (공백으로 나뉘는 언어 / 한중일 문자)
+	wordsPerMinute = 230
+	charsPerMinute = 500
+	// minParagraphLength - 점수를 매기는 단락의 최소 글자 수
+	minParagraphLength = 25
+	// maxBoilerplateLength - 수신거부 문구가 있는 단락을 버리는 최대 글자 수
+	maxBoilerplateLength = 300
+)
+
+// Article is the extracted content of an email.
+type Article struct {
+	HTML            string // 정리된 본문 (p, h1~h6, a, img, ul/ol, blockquote ...)
+	Text            string // 단락을 빈 줄로 구분한 텍스트
+	WordCount       int
+	ReadingMinutes  int
+	TrackersRemoved int // 제거된 추적 픽셀 수
+	LinksCleaned    int // 클릭 추적 리다이렉트/추적 파라미터를 제거한 링크 수
+}
+
+var (
+	unlikelyPattern    = regexp.MustCompile(`(?i)footer|unsubscribe|preheader|preview-text|social|share|sidebar|sponsor|advert|banner|menu|nav|legal|copyright|view-?in-?browser|view-?online|web-?version`)
+	likelyPattern      = regexp.MustCompile(`(?i)article|content|main|post|story|entry|body-text`)
+	boilerplatePattern = regexp.MustCompile(`(?i)unsubscribe|manage (your )?(email )?preferences|update your preferences|view (this email )?in (your )?browser|수신\s*거부|구독\s*취소|구독\s*해지`)
+	urlPattern         = regexp.MustCompile(`https?://[^\s<>"')\]]+`)
+	paragraphBreak     = regexp.MustCompile(`\n\s*\n`)
+	// 뉴스레터 preheader에 흔한 보이지 않는 공백 문자(nbsp, ZWNJ, CGJ, BOM)도 공백으로 본다
+	spaceRun = regexp.MustCompile(`[\s\x{00a0}\x{200c}\x{034f}\x{2007}\x{feff}]+`)
+)
+
+// 본문 추출 전에 통째로 버리는 요소 (LevelStrict 정리 후 남는 것들)
+var removedTags = map[string]bool{
+	"head": true, "title": true, "style": true, "nav": true, "header": true,
+	"footer": true, "aside": true, "button": true,
+}
+
+// 그대로 남기는 요소 (속성은 모두 제거)
+var keptTags = map[string]bool{
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"p": true, "ul": true, "ol": true, "li": true, "blockquote": true, "pre": true,
+	"code": true, "em": true, "strong": true, "b": true, "i": true, "u": true,
+	"br": true, "hr": true, "figure": true, "figcaption": true, "sup": true, "sub": true,
+}
+
+// 자식이 인라인뿐이면 <p>로 바꾸고, 블록 자식이 있으면 풀어내는 레이아웃 요소
+var containerTags = map[string]bool{
+	"div": true, "td": true, "th": true, "section": true, "article": true,
+	"main": true, "center": true,
+}
+
+var blockTags = map[string]bool{
+	"p": true, "div": true, "table": true, "tbody": true, "thead": true, "tfoot": true,
+	"tr": true, "td": true, "th": true, "ul": true, "ol": true, "li": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"blockquote": true, "pre": true, "section": true, "article": true, "main": true,
+	"center": true, "hr": true, "figure": true,
+}
+
+// Extract returns the readable content of an email. HTML 본문이 없으면 텍스트 본문을 단락으로 나눈다.
+func Extract(htmlBody, textBody string) *Article {
+	if strings.TrimSpace(htmlBody) == "" {
+		return fromText(textBody)
+	}
+
+	sanitized := htmlsanitize.Sanitize(htmlBody, htmlsanitize.LevelStrict)
+	doc, err := html.Parse(strings.NewReader(sanitized.HTML))
+	if err != nil {
+		return fromText(textBody)
+	}
+	body := findBody(doc)
+	prune(body)
+
+	w := &writer{}
+	for _, n := range selectContent(body) {
+		w.out.WriteString(w.render(n))
+	}
+
+	article := &Article{
+		HTML:            strings.TrimSpace(w.out.String()),
+		Text:            normalizeText(w.text.String()),
+		TrackersRemoved: sanitized.TrackersBlocked,
+		LinksCleaned:    w.linksCleaned,
+	}
+	if article.Text == "" && strings.TrimSpace(textBody) != "" {
+		fallback := fromText(textBody)
+		fallback.TrackersRemoved = article.TrackersRemoved
+		return fallback
+	}
+	article.WordCount, article.ReadingMinutes = readingStats(article.Text)
+	return article
+}
+
+// =============================================================================
+// Content selection
+// =============================================================================
+
+func findBody(doc *html.Node) *html.Node {
+	var body *html.Node
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if body != nil {
+			return
+		}
+		if n.Type == html.ElementNode && n.Data == "body" {
+			body = n
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	if body == nil {
+		return doc
+	}
+	return body
+}
+
+// prune removes hidden, navigational and boilerplate (수신거부, 푸터) nodes.
+func prune(n *html.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		switch {
+		case c.Type == html.CommentNode:
+			n.RemoveChild(c)
+		case c.Type == html.ElementNode && shouldRemove(c):
+			n.RemoveChild(c)
+		default:
+			prune(c)
+		}
+		c = next
+	}
+}
+
+func shouldRemove(n *html.Node) bool {
+	if removedTags[n.Data] || isHidden(n) {
+		return true
+	}
+	if marker := attr(n, "class") + " " + attr(n, "id"); strings.TrimSpace(marker) != "" {
+		if unlikelyPattern.MatchString(marker) && !likelyPattern.MatchString(marker) {
+			return true
+		}
+	}
+	// 단락 단위로만 본다 (본문 전체를 감싼 테이블이 짧다고 통째로 버리지 않도록)
+	if isParagraph(n) {
+		text := innerText(n)
+		if utf8.RuneCountInString(text) <= maxBoilerplateLength && boilerplatePattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHidden detects preheader text and other hidden blocks.
+func isHidden(n *html.Node) bool {
+	style := strings.ToLower(strings.ReplaceAll(attr(n, "style"), " ", ""))
+	for _, hidden := range []string{"display:none", "visibility:hidden", "max-height:0", "font-size:0", "opacity:0"} {
+		if strings.Contains(style, hidden) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectContent picks the best scoring container and its related siblings.
+func selectContent(body *html.Node) []*html.Node {
+	scores := make(map[*html.Node]float64)
+	var candidates []*html.Node
+	addScore := func(n *html.Node, score float64) {
+		if n == nil || n.Type != html.ElementNode {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			scores[n] = classWeight(n)
+			candidates = append(candidates, n)
+		}
+		scores[n] += score
+	}
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && isParagraph(n) {
+			text := innerText(n)
+			length := utf8.RuneCountInString(text)
+			if length >= minParagraphLength {
+				score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "，")) + math.Min(float64(length)/100, 3)
+				addScore(n.Parent, score)
+				if n.Parent != nil {
+					addScore(n.Parent.Parent, score/2)
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(body)
+
+	var top *html.Node
+	best := 0.0
+	for _, n := range candidates {
+		scores[n] *= 1 - linkDensity(n)
+		if scores[n] > best {
+			top, best = n, scores[n]
+		}
+	}
+	if top == nil || top == body || top.Parent == nil {
+		return []*html.Node{body}
+	}
+
+	// 뉴스레터는 기사마다 행(tr)이 나뉘어 있어 점수가 충분한 형제도 포함한다
+	threshold := math.Max(10, best*0.2)
+	var nodes []*html.Node
+	for s := top.Parent.FirstChild; s != nil; s = s.NextSibling {
+		if s.Type != html.ElementNode {
+			continue
+		}
+		switch {
+		case s == top:
+			nodes = append(nodes, s)
+		case scores[s] >= threshold:
+			nodes = append(nodes, s)
+		case isParagraph(s) && utf8.RuneCountInString(innerText(s)) > 80 && linkDensity(s) < 0.25:
+			nodes = append(nodes, s)
+		}
+	}
+	return nodes
+}
+
+// isParagraph reports whether n is a text block (단락, 또는 블록 자식이 없는 div/td).
+func isParagraph(n *html.Node) bool {
+	switch n.Data {
+	case "p", "pre", "blockquote":
+		return true
+	case "div", "td", "th", "li", "section", "article", "center":
+		return !hasBlockChild(n)
+	}
+	return false
+}
+
+func hasBlockChild(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && (blockTags[c.Data] || hasBlockChild(c)) {
+			return true
+		}
+	}
+	return false
+}
+
+func classWeight(n *html.Node) float64 {
+	marker := attr(n, "class") + " " + attr(n, "id")
+	weight := 0.0
+	if likelyPattern.MatchString(marker) {
+		weight += 25
+	}
+	if unlikelyPattern.MatchString(marker) {
+		weight -= 25
+	}
+	return weight
+}
+
+// linkDensity is the share of text inside links (0~1).
+func linkDensity(n *html.Node) float64 {
+	total := utf8.RuneCountInString(innerText(n))
+	if total == 0 {
+		return 0
+	}
+	linked := 0
+	var walk func(*html.Node)
+	walk = func(c *html.Node) {
+		if c.Type == html.ElementNode && c.Data == "a" {
+			linked += utf8.RuneCountInString(innerText(c))
+			return
+		}
+		for cc := c.FirstChild; cc != nil; cc = cc.NextSibling {
+			walk(cc)
+		}
+	}
+	walk(n)
+	return float64(linked) / float64(total)
+}
+
+// =============================================================================
+// Rendering
+// =============================================================================
+
+type writer struct {
+	out          strings.Builder
+	text         strings.Builder
+	linksCleaned int
+	inPre        bool
+}
+
+// render converts a node to simplified HTML and collects its text.
+func (w *writer) render(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		text := n.Data
+		if !w.inPre {
+			text = collapseSpaces(text)
+		}
+		w.text.WriteString(text)
+		return html.EscapeString(text)
+	case html.ElementNode:
+	default:
+		return w.renderChildren(n)
+	}
+
+	switch {
+	case n.Data == "a":
+		inner := w.renderChildren(n)
+		href, ok := w.cleanHref(attr(n, "href"))
+		if !ok || strings.TrimSpace(inner) == "" {
+			return inner
+		}
+		return `<a href="` + html.EscapeString(href) + `">` + inner + `</a>`
+
+	case n.Data == "img":
+		src := strings.TrimSpace(attr(n, "src"))
+		if !isImageSource(src) {
+			return ""
+		}
+		img := `<img src="` + html.EscapeString(src) + `"`
+		if alt := strings.TrimSpace(attr(n, "alt")); alt != "" {
+			img += ` alt="` + html.EscapeString(alt) + `"`
+		}
+		return img + `>`
+
+	case n.Data == "br" || n.Data == "hr":
+		w.text.WriteString("\n")
+		return "<" + n.Data + ">"
+
+	case keptTags[n.Data]:
+		if n.Data == "pre" {
+			w.inPre = true
+			defer func() { w.inPre = false }()
+		}
+		inner := w.renderChildren(n)
+		if blockTags[n.Data] {
+			w.text.WriteString("\n\n")
+			if isEmpty(inner) {
+				return ""
+			}
+		} else if strings.TrimSpace(inner) == "" {
+			return inner
+		}
+		return "<" + n.Data + ">" + inner + "</" + n.Data + ">"
+
+	case containerTags[n.Data] && !hasBlockChild(n):
+		inner := w.renderChildren(n)
+		w.text.WriteString("\n\n")
+		if isEmpty(inner) {
+			return ""
+		}
+		return "<p>" + strings.TrimSpace(inner) + "</p>"
+	}
+
+	// table, tr, span, font 등 레이아웃 요소는 풀어낸다
+	inner := w.renderChildren(n)
+	if blockTags[n.Data] {
+		w.text.WriteString("\n\n")
+	}
+	return inner
+}
+
+func (w *writer) renderChildren(n *html.Node) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(w.render(c))
+	}
+	return b.String()
+}
+
+// cleanHref keeps http(s)/mailto links and strips click tracking.
+func (w *writer) cleanHref(href string) (string, bool) {
+	href = strings.TrimSpace(href)
+	lower := strings.ToLower(href)
+	switch {
+	case strings.HasPrefix(lower, "mailto:"):
+		return href, true
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"):
+		if cleaned, changed := htmlsanitize.CleanTrackingURL(href); changed {
+			w.linksCleaned++
+			return cleaned, true
+		}
+		return href, true
+	}
+	return "", false
+}
+
+func isImageSource(src string) bool {
+	lower := strings.ToLower(src)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") ||
+		strings.HasPrefix(lower, "cid:") || strings.HasPrefix(lower, "data:image/")
+}
+
+// isEmpty reports whether rendered HTML has neither text nor images.
+func isEmpty(rendered string) bool {
+	if strings.Contains(rendered, "<img") {
+		return false
+	}
+	doc, err := html.Parse(strings.NewReader(rendered))
+	if err != nil {
+		return strings.TrimSpace(rendered) == ""
+	}
+	return strings.TrimSpace(innerText(doc)) == ""
+}
+
+// =============================================================================
+// Text
+// =============================================================================
+
+// fromText builds an article from a plain text body (빈 줄 = 단락).
+func fromText(textBody string) *Article {
+	article := &Article{}
+	var htmlOut, textOut strings.Builder
+	for _, para := range paragraphBreak.Split(strings.ReplaceAll(textBody, "\r\n", "\n"), -1) {
+		para = strings.TrimSpace(para)
+		if para == "" || (utf8.RuneCountInString(para) <= maxBoilerplateLength && boilerplatePattern.MatchString(para)) {
+			continue
+		}
+		para = urlPattern.ReplaceAllStringFunc(para, func(u string) string {
+			if cleaned, changed := htmlsanitize.CleanTrackingURL(u); changed {
+				article.LinksCleaned++
+				return cleaned
+			}
+			return u
+		})
+		htmlOut.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(para), "\n", "<br>") + "</p>")
+		textOut.WriteString(para + "\n\n")
+	}
+	article.HTML = htmlOut.String()
+	article.Text = normalizeText(textOut.String())
+	article.WordCount, article.ReadingMinutes = readingStats(article.Text)
+	return article
+}
+
+// readingStats counts words and estimates reading time. 한중일 문자는 글자 수 기준으로 계산한다.
+func readingStats(text string) (words, minutes int) {
+	cjk, latin := 0, 0
+	for _, field := range strings.Fields(text) {
+		words++
+		hasCJK := false
+		for _, r := range field {
+			if unicode.In(r, unicode.Hangul, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+				cjk++
+				hasCJK = true
+			}
+		}
+		if !hasCJK {
+			latin++
+		}
+	}
+	if words == 0 {
+		return 0, 0
+	}
+	minutes = int(math.Ceil(float64(latin)/wordsPerMinute + float64(cjk)/charsPerMinute))
+	if minutes < 1 {
+		minutes = 1
+	}
+	return words, minutes
+}
+
+// normalizeText collapses spaces per line and keeps one blank line between paragraphs.
+func normalizeText(text string) string {
+	var paras []string
+	for _, para := range paragraphBreak.Split(text, -1) {
+		var lines []string
+		for _, line := range strings.Split(para, "\n") {
+			if line = strings.TrimSpace(collapseSpaces(line)); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) > 0 {
+			paras = append(paras, strings.Join(lines, "\n"))
+		}
+	}
+	return strings.Join(paras, "\n\n")
+}
+
+// collapseSpaces turns whitespace runs into one space, keeping the space between inline nodes.
+func collapseSpaces(s string) string {
+	return spaceRun.ReplaceAllString(s, " ")
+}
+
+func innerText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(c *html.Node) {
+		if c.Type == html.TextNode {
+			b.WriteString(c.Data)
+			b.WriteByte(' ')
+		}
+		for cc := c.FirstChild; cc != nil; cc = cc.NextSibling {
+			walk(cc)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
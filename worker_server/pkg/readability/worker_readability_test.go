@@ -0,0 +1,61 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+const newsletter = `<html><head><title>Weekly</title><style>.x{color:red}</style></head><body>
+<div style="display:none;max-height:0">Preview text you should not see</div>
+<table class="wrapper"><tr><td class="header-nav"><a href="https://example.com">Home</a> | <a href="https://example.com/blog">Blog</a></td></tr>
+<tr><td class="content">
+<h1>The Weekly Digest</h1>
+<p>Go 1.24 ships with generic type aliases, a faster map implementation, and a new weak package for caches.</p>
+<p>Read more in the <a href="https://acme.us1.list-manage.com/track/click?u=1&amp;url=https%3A%2F%2Fgo.dev%2Fblog%2Fgo1.24%3Futm_source%3Dnews">release notes</a>, which cover tooling, runtime and library changes in detail.</p>
+<img src="https://cdn.example.com/hero.png" alt="hero" width="600">
+<img src="https://t.example.com/open.gif" width="1" height="1">
+</td></tr>
+<tr><td class="footer">You received this because you subscribed. <a href="https://example.com/unsub">Unsubscribe</a></td></tr>
+</table></body></html>`
+
+func TestExtractNewsletter(t *testing.T) {
+	a := Extract(newsletter, "")
+
+	for _, keep := range []string{"<h1>The Weekly Digest</h1>", "generic type aliases", `<a href="https://go.dev/blog/go1.24">release notes</a>`, `<img src="https://cdn.example.com/hero.png" alt="hero">`} {
+		if !strings.Contains(a.HTML, keep) {
+			t.Errorf("output missing %q: %s", keep, a.HTML)
+		}
+	}
+	for _, bad := range []string{"Preview text", "Unsubscribe", "Home", "open.gif", "color:red", "class="} {
+		if strings.Contains(a.HTML, bad) {
+			t.Errorf("output contains %q: %s", bad, a.HTML)
+		}
+	}
+	if a.TrackersRemoved != 1 || a.LinksCleaned != 1 {
+		t.Errorf("trackers=%d links=%d, want 1, 1", a.TrackersRemoved, a.LinksCleaned)
+	}
+	if !strings.HasPrefix(a.Text, "The Weekly Digest\n\nGo 1.24") || a.WordCount < 30 || a.ReadingMinutes != 1 {
+		t.Errorf("unexpected text stats: words=%d minutes=%d text=%q", a.WordCount, a.ReadingMinutes, a.Text)
+	}
+}
+
+func TestExtractPlainText(t *testing.T) {
+	a := Extract("", "첫 번째 단락입니다.\n\nhttps://example.com/a?utm_source=x\n\n수신거부: https://example.com/unsub")
+
+	if a.HTML != "<p>첫 번째 단락입니다.</p><p>https://example.com/a</p>" || a.LinksCleaned != 1 {
+		t.Errorf("unexpected html %q (links %d)", a.HTML, a.LinksCleaned)
+	}
+	if a.WordCount != 4 || a.ReadingMinutes != 1 {
+		t.Errorf("words=%d minutes=%d, want 4, 1", a.WordCount, a.ReadingMinutes)
+	}
+}
+
+func TestReadingStats(t *testing.T) {
+	words, minutes := readingStats(strings.Repeat("word ", 460) + strings.Repeat("가나다라마 ", 100))
+	if words != 560 || minutes != 3 {
+		t.Errorf("words=%d minutes=%d, want 560, 3", words, minutes)
+	}
+	if words, minutes := readingStats("   "); words != 0 || minutes != 0 {
+		t.Errorf("empty text: words=%d minutes=%d", words, minutes)
+	}
+}
@@ -0,0 +1,114 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"worker_server/pkg/logger"
+
+	"github.com/sony/gobreaker"
+)
+
+// =============================================================================
+// gobreaker 기반 외부 의존성 breaker
+// Gmail, Outlook, Neo4j, 벡터 저장소, Redis producer가 같은 설정과 상태 보고를 쓴다.
+// =============================================================================
+
+// breaker is implemented by gobreaker.CircuitBreaker and gobreaker.TwoStepCircuitBreaker.
+type breaker interface {
+	Name() string
+	State() gobreaker.State
+	Counts() gobreaker.Counts
+}
+
+var (
+	breakersMu sync.RWMutex
+	breakers   = make(map[string]breaker)
+)
+
+// BreakerSettings returns the shared settings for an external dependency breaker.
+// isFailure decides which errors count toward opening the circuit (nil이면 취소 외 모든 에러).
+func BreakerSettings(name string, isFailure func(error) bool) gobreaker.Settings {
+	return gobreaker.Settings{
+		Name:        name,
+		MaxRequests: 3,                // Half-open 상태에서 허용할 요청 수
+		Interval:    60 * time.Second, // Closed 상태에서 카운터 리셋 간격
+		Timeout:     30 * time.Second, // Open 상태 유지 시간 (이후 Half-open)
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			// 연속 5회 실패 또는 60% 이상 실패율 (최소 10회 요청)
+			failureRatio := float64(counts.TotalFailures) / float64(counts.Requests)
+			return counts.ConsecutiveFailures > 5 ||
+				(counts.Requests >= 10 && failureRatio >= 0.6)
+		},
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			logger.Warn("[CircuitBreaker] %s: state changed from %s to %s", name, from.String(), to.String())
+		},
+		IsSuccessful: func(err error) bool {
+			if err == nil || errors.Is(err, context.Canceled) {
+				return true
+			}
+			return isFailure != nil && !isFailure(err)
+		},
+	}
+}
+
+// NewBreaker creates a circuit breaker and registers it for state reporting.
+func NewBreaker(name string, isFailure func(error) bool) *gobreaker.CircuitBreaker {
+	cb := gobreaker.NewCircuitBreaker(BreakerSettings(name, isFailure))
+	RegisterBreaker(cb)
+	return cb
+}
+
+// NewTwoStepBreaker creates a two-step circuit breaker and registers it for state reporting.
+// 결과를 나중에 판정해야 하는 경우(HTTP 응답 상태 코드 등)에 쓴다.
+func NewTwoStepBreaker(name string) *gobreaker.TwoStepCircuitBreaker {
+	cb := gobreaker.NewTwoStepCircuitBreaker(BreakerSettings(name, nil))
+	RegisterBreaker(cb)
+	return cb
+}
+
+// RegisterBreaker adds a breaker to the state report (같은 이름이면 교체).
+func RegisterBreaker(b breaker) {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+	breakers[b.Name()] = b
+}
+
+// IsBreakerOpen reports whether err was returned because a circuit rejected the call.
+func IsBreakerOpen(err error) bool {
+	return errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests)
+}
+
+// BreakerStatus is the reported state of a registered breaker.
+type BreakerStatus struct {
+	Name                 string `json:"name"`
+	State                string `json:"state"`
+	Requests             uint32 `json:"requests"`
+	TotalFailures        uint32 `json:"total_failures"`
+	ConsecutiveFailures  uint32 `json:"consecutive_failures"`
+	ConsecutiveSuccesses uint32 `json:"consecutive_successes"`
+}
+
+// BreakerStatuses returns the state of all registered breakers sorted by name.
+func BreakerStatuses() []BreakerStatus {
+	breakersMu.RLock()
+	defer breakersMu.RUnlock()
+
+	result := make([]BreakerStatus, 0, len(breakers))
+	for _, b := range breakers {
+		counts := b.Counts()
+		result = append(result, BreakerStatus{
+			Name:                 b.Name(),
+			State:                b.State().String(),
+			Requests:             counts.Requests,
+			TotalFailures:        counts.TotalFailures,
+			ConsecutiveFailures:  counts.ConsecutiveFailures,
+			ConsecutiveSuccesses: counts.ConsecutiveSuccesses,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
@@ -0,0 +1,75 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreakerOpensOnFailures(t *testing.T) {
+	errClient := errors.New("client error")
+	errDown := errors.New("connection refused")
+	cb := NewBreaker("test-breaker", func(err error) bool { return !errors.Is(err, errClient) })
+
+	fail := func(err error) func() (any, error) {
+		return func() (any, error) { return nil, err }
+	}
+
+	// 클라이언트 에러와 취소는 세지 않는다
+	for i := 0; i < 10; i++ {
+		cb.Execute(fail(errClient))
+		cb.Execute(fail(context.Canceled))
+	}
+	if got := cb.State().String(); got != "closed" {
+		t.Fatalf("state after ignored errors = %s, want closed", got)
+	}
+
+	for i := 0; i < 6; i++ {
+		cb.Execute(fail(errDown))
+	}
+	_, err := cb.Execute(fail(nil))
+	if !IsBreakerOpen(err) {
+		t.Fatalf("Execute after outage err = %v, want open circuit", err)
+	}
+
+	var found bool
+	for _, s := range BreakerStatuses() {
+		if s.Name == "test-breaker" {
+			found = true
+			if s.State != "open" {
+				t.Errorf("reported state = %s, want open", s.State)
+			}
+		}
+	}
+	if !found {
+		t.Error("breaker not reported")
+	}
+}
+
+func TestStaleCache(t *testing.T) {
+	c := NewStaleCache(2, time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	if v, ok := c.Get("c"); !ok || v.(int) != 3 {
+		t.Fatalf("Get(c) = %v, %v", v, ok)
+	}
+	n := 0
+	for _, k := range []string{"a", "b", "c"} {
+		if _, ok := c.Get(k); ok {
+			n++
+		}
+	}
+	if n != 2 {
+		t.Errorf("entries = %d, want 2", n)
+	}
+
+	expired := NewStaleCache(10, time.Nanosecond)
+	expired.Set("a", 1)
+	time.Sleep(time.Millisecond)
+	if _, ok := expired.Get("a"); ok {
+		t.Error("expired entry served")
+	}
+}
@@ -0,0 +1,60 @@
+package resilience
+
+import (
+	"sync"
+	"time"
+)
+
+// StaleCache keeps the last successful result per key so it can be served
+// while a dependency's circuit is open (마지막 성공 결과를 장애 중 대체 응답으로 사용).
+type StaleCache struct {
+	mu      sync.Mutex
+	maxSize int
+	maxAge  time.Duration
+	entries map[string]staleEntry
+}
+
+type staleEntry struct {
+	value    any
+	storedAt time.Time
+}
+
+// NewStaleCache creates a cache holding at most maxSize entries no older than maxAge.
+func NewStaleCache(maxSize int, maxAge time.Duration) *StaleCache {
+	return &StaleCache{
+		maxSize: maxSize,
+		maxAge:  maxAge,
+		entries: make(map[string]staleEntry, maxSize),
+	}
+}
+
+// Set stores the latest value for key.
+func (c *StaleCache) Set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxSize {
+		// 가득 차면 임의의 항목 하나를 버린다 (대체 응답용이라 정확한 LRU는 불필요)
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = staleEntry{value: value, storedAt: time.Now()}
+}
+
+// Get returns the stored value for key if it is not older than maxAge.
+func (c *StaleCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.maxAge > 0 && time.Since(entry.storedAt) > c.maxAge {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
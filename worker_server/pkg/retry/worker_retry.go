@@ -0,0 +1,164 @@
+// Package retry provides jittered exponential backoff and retriable error classification.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"time"
+
+	"worker_server/pkg/resilience"
+)
+
+// =============================================================================
+// Retry Policy - 지수 백오프 + jitter
+// =============================================================================
+//
+// 여러 인스턴스가 같은 시각에 다시 시도하지 않도록(thundering herd) 지연에 jitter를 섞는다.
+// 재시도 여부는 에러가 스스로 알려준다 (out.ProviderError.Retryable 등).
+
+// Policy describes how an operation is retried.
+type Policy struct {
+	MaxAttempts int           // 총 시도 횟수 (첫 시도 포함)
+	BaseDelay   time.Duration // 첫 재시도 전 지연, 이후 두 배씩 증가
+	MaxDelay    time.Duration // 지연 상한
+	Jitter      float64       // 0~1, 지연을 ±Jitter 비율만큼 무작위로 흔든다
+
+	// Retriable decides whether err is worth another attempt (nil이면 IsRetriable).
+	Retriable func(err error) bool
+}
+
+// DefaultPolicy returns the policy used for external API calls.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// Delay returns the wait before retry number n (0 = first retry).
+func (p Policy) Delay(n int) time.Duration {
+	if n < 0 {
+		n = 0
+	}
+	delay := p.BaseDelay
+	for i := 0; i < n && i < 30 && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delta := float64(delay) * p.Jitter
+		delay += time.Duration(delta * (2*rand.Float64() - 1))
+	}
+	return delay
+}
+
+func (p Policy) retriable(err error) bool {
+	if p.Retriable != nil {
+		return p.Retriable(err)
+	}
+	return IsRetriable(err)
+}
+
+// Do calls fn until it succeeds, returns a non-retriable error, or attempts run out.
+// ctx가 끝나면 대기 중에도 바로 마지막 에러를 돌려준다.
+func Do(ctx context.Context, p Policy, fn func(ctx context.Context) error) error {
+	attempts := p.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(p.Delay(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if !p.retriable(err) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	var perm *permanentError
+	if errors.As(err, &perm) {
+		return perm.err
+	}
+	return err
+}
+
+// =============================================================================
+// Error Classification
+// =============================================================================
+
+// retryable is implemented by errors that know whether a retry may succeed (out.ProviderError).
+type retryable interface {
+	IsRetryable() bool
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying; Do returns the unwrapped error.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err is known to fail again on retry.
+// 분류할 수 없는 에러는 false (호출자의 기본 동작을 따른다).
+func IsPermanent(err error) bool {
+	if err == nil {
+		return false
+	}
+	var perm *permanentError
+	if errors.As(err, &perm) {
+		return true
+	}
+	var r retryable
+	if errors.As(err, &r) {
+		return !r.IsRetryable()
+	}
+	return false
+}
+
+// IsRetriable reports whether err is known to be transient
+// (retryable provider error, network error, deadline). 취소와 열린 circuit은 재시도하지 않는다.
+func IsRetriable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || resilience.IsBreakerOpen(err) {
+		return false
+	}
+	var perm *permanentError
+	if errors.As(err, &perm) {
+		return false
+	}
+	var r retryable
+	if errors.As(err, &r) {
+		return r.IsRetryable()
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
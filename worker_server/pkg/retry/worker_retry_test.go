@@ -0,0 +1,98 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type statusError struct {
+	retryable bool
+}
+
+func (e *statusError) Error() string     { return "status error" }
+func (e *statusError) IsRetryable() bool { return e.retryable }
+
+func TestDelayBounds(t *testing.T) {
+	p := Policy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0.2}
+
+	for n, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second} {
+		for i := 0; i < 20; i++ {
+			got := p.Delay(n)
+			if got < want*8/10 || got > want*12/10 {
+				t.Fatalf("Delay(%d) = %v, want %v ±20%%", n, got, want)
+			}
+		}
+	}
+
+	// 큰 n에서도 overflow 없이 상한을 지킨다
+	if got := (Policy{BaseDelay: time.Second, MaxDelay: time.Minute}).Delay(1000); got != time.Minute {
+		t.Fatalf("Delay(1000) = %v, want %v", got, time.Minute)
+	}
+}
+
+func TestDoRetriesTransientErrors(t *testing.T) {
+	p := Policy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	calls := 0
+	err := Do(context.Background(), p, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return &statusError{retryable: true}
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("Do = %v after %d calls, want nil after 3", err, calls)
+	}
+}
+
+func TestDoStopsOnPermanentErrors(t *testing.T) {
+	p := Policy{MaxAttempts: 5, BaseDelay: time.Millisecond}
+	errBad := errors.New("bad request")
+
+	calls := 0
+	err := Do(context.Background(), p, func(ctx context.Context) error {
+		calls++
+		return Permanent(errBad)
+	})
+	if err != errBad || calls != 1 {
+		t.Fatalf("Do = %v after %d calls, want %v after 1", err, calls, errBad)
+	}
+
+	calls = 0
+	Do(context.Background(), p, func(ctx context.Context) error {
+		calls++
+		return &statusError{retryable: false}
+	})
+	if calls != 1 {
+		t.Fatalf("non-retryable error called fn %d times, want 1", calls)
+	}
+}
+
+func TestClassification(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retriable bool
+		permanent bool
+	}{
+		{"nil", nil, false, false},
+		{"canceled", context.Canceled, false, false},
+		{"deadline", context.DeadlineExceeded, true, false},
+		{"retryable", &statusError{retryable: true}, true, false},
+		{"not retryable", &statusError{retryable: false}, false, true},
+		{"wrapped", errors.Join(errors.New("op"), &statusError{retryable: true}), true, false},
+		{"permanent", Permanent(errors.New("x")), false, true},
+		{"unknown", errors.New("x"), false, false},
+	}
+	for _, tt := range tests {
+		if got := IsRetriable(tt.err); got != tt.retriable {
+			t.Errorf("IsRetriable(%s) = %v, want %v", tt.name, got, tt.retriable)
+		}
+		if got := IsPermanent(tt.err); got != tt.permanent {
+			t.Errorf("IsPermanent(%s) = %v, want %v", tt.name, got, tt.permanent)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// maxFolderNameLen - Gmail 라벨/Outlook 폴더 이름 최대 길이
+const maxFolderNameLen = 225
+
+func init() {
+	Register("email", isEmail, "must be a valid email address")
+	Register("uuid", isUUID, "must be a valid UUID")
+	Register("oneof", isOneOf, "must be one of: %s")
+	Register("folder", isFolderName, "must be a valid folder name")
+	Register("date", isDate, "must be a date in YYYY-MM-DD format")
+}
+
+// isEmail accepts a bare address or "Name <addr>".
+func isEmail(v reflect.Value, _ string) bool {
+	if v.Kind() != reflect.String {
+		return false
+	}
+	addr, err := mail.ParseAddress(strings.TrimSpace(v.String()))
+	if err != nil {
+		return false
+	}
+	_, domain, ok := strings.Cut(addr.Address, "@")
+	return ok && strings.Contains(domain, ".") && !strings.HasSuffix(domain, ".")
+}
+
+func isUUID(v reflect.Value, _ string) bool {
+	if v.Kind() != reflect.String {
+		return false
+	}
+	_, err := uuid.Parse(v.String())
+	return err == nil
+}
+
+// isOneOf checks the value against a space-separated list.
+func isOneOf(v reflect.Value, param string) bool {
+	s := fmt.Sprint(v.Interface())
+	for _, allowed := range strings.Fields(param) {
+		if s == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// isDate accepts a calendar date (YYYY-MM-DD).
+func isDate(v reflect.Value, _ string) bool {
+	if v.Kind() != reflect.String {
+		return false
+	}
+	_, err := time.Parse(time.DateOnly, v.String())
+	return err == nil
+}
+
+// isFolderName rejects control characters, path traversal and leading/trailing separators.
+func isFolderName(v reflect.Value, _ string) bool {
+	if v.Kind() != reflect.String {
+		return false
+	}
+	name := v.String()
+	if strings.TrimSpace(name) != name || len([]rune(name)) > maxFolderNameLen {
+		return false
+	}
+	if strings.Contains(name, "..") || strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") {
+		return false
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
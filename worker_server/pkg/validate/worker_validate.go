@@ -0,0 +1,299 @@
+// Package validate checks request structs against `validate` struct tags
+// and reports every failing field instead of the first one.
+//
+//	type Req struct {
+//	    To     []string  `json:"to" validate:"required,max=100,email"`
+//	    Folder string    `json:"folder" validate:"required,folder"`
+//	    Start  time.Time `json:"start" validate:"required"`
+//	    End    time.Time `json:"end" validate:"required,after=Start"`
+//	}
+//
+// 규칙은 쉼표로 구분한다. required가 아닌 규칙은 값이 비어 있으면 건너뛴다.
+// 슬라이스에 붙은 email/uuid/folder/oneof는 각 요소에 적용되고,
+// 중첩 구조체(포인터·슬라이스 포함)는 재귀적으로 검사한다.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// FieldError describes one invalid field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Errors is the list of invalid fields.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	parts := make([]string, len(e))
+	for i, fe := range e {
+		parts[i] = fe.Field + ": " + fe.Message
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+// Func reports whether v satisfies the rule with the given param.
+type Func func(v reflect.Value, param string) bool
+
+type customRule struct {
+	fn      Func
+	message string
+}
+
+var (
+	customMu    sync.RWMutex
+	customRules = map[string]customRule{}
+)
+
+// Register adds a custom rule. message may contain %s for the param.
+// 슬라이스 필드에 붙으면 각 요소에 적용된다.
+func Register(name string, fn Func, message string) {
+	customMu.Lock()
+	defer customMu.Unlock()
+	customRules[name] = customRule{fn: fn, message: message}
+}
+
+// Struct validates v (a struct or pointer to struct). Returns Errors or nil.
+func Struct(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs Errors
+	validateStruct(rv, "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func validateStruct(rv reflect.Value, prefix string, errs *Errors) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		name := fieldName(sf)
+		if name == "-" {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			validateStruct(fv, prefix, errs)
+			continue
+		}
+
+		if tag := sf.Tag.Get("validate"); tag != "" && tag != "-" {
+			validateField(rv, fv, path, tag, errs)
+		}
+		descend(fv, path, errs)
+	}
+}
+
+// descend validates nested structs.
+func descend(fv reflect.Value, path string, errs *Errors) {
+	switch fv.Kind() {
+	case reflect.Pointer:
+		if !fv.IsNil() && fv.Elem().Kind() == reflect.Struct && !isTime(fv.Elem()) {
+			validateStruct(fv.Elem(), path, errs)
+		}
+	case reflect.Struct:
+		if !isTime(fv) {
+			validateStruct(fv, path, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < fv.Len(); i++ {
+			descend(fv.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	}
+}
+
+func validateField(parent, fv reflect.Value, path, tag string, errs *Errors) {
+	value := indirect(fv)
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if name == "" {
+			continue
+		}
+
+		if name == "required" {
+			if isZero(value) {
+				*errs = append(*errs, FieldError{Field: path, Rule: name, Message: "is required"})
+				return
+			}
+			continue
+		}
+		if isZero(value) {
+			continue
+		}
+
+		switch name {
+		case "min", "max":
+			if msg, ok := checkBound(value, name, param); !ok {
+				*errs = append(*errs, FieldError{Field: path, Rule: name, Param: param, Message: msg})
+			}
+		case "after":
+			if !checkAfter(parent, value, param) {
+				*errs = append(*errs, FieldError{Field: path, Rule: name, Param: param, Message: "must be after " + fieldNameOf(parent, param)})
+			}
+		default:
+			fn, message, ok := lookupRule(name)
+			if !ok {
+				panic("validate: unknown rule " + strconv.Quote(name))
+			}
+			if isList(value) {
+				for i := 0; i < value.Len(); i++ {
+					if elem := indirect(value.Index(i)); !fn(elem, param) {
+						*errs = append(*errs, FieldError{Field: fmt.Sprintf("%s[%d]", path, i), Rule: name, Param: param, Message: formatMessage(message, param)})
+					}
+				}
+				continue
+			}
+			if !fn(value, param) {
+				*errs = append(*errs, FieldError{Field: path, Rule: name, Param: param, Message: formatMessage(message, param)})
+			}
+		}
+	}
+}
+
+// checkBound applies min/max to string length, collection length or numeric value.
+func checkBound(v reflect.Value, rule, param string) (string, bool) {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic("validate: invalid " + rule + " param " + strconv.Quote(param))
+	}
+
+	var n float64
+	unit := ""
+	switch v.Kind() {
+	case reflect.String:
+		n, unit = float64(utf8.RuneCountInString(v.String())), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n, unit = float64(v.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = v.Float()
+	default:
+		return "", true
+	}
+
+	if rule == "min" && n < limit {
+		if unit != "" {
+			return "must have at least " + param + unit, false
+		}
+		return "must be at least " + param, false
+	}
+	if rule == "max" && n > limit {
+		if unit != "" {
+			return "must have at most " + param + unit, false
+		}
+		return "must be at most " + param, false
+	}
+	return "", true
+}
+
+// checkAfter reports whether a time field is after the sibling field named param.
+func checkAfter(parent, v reflect.Value, param string) bool {
+	other := indirect(parent.FieldByName(param))
+	if !other.IsValid() || !isTime(v) || !isTime(other) {
+		return true
+	}
+	start := other.Interface().(time.Time)
+	if start.IsZero() {
+		return true
+	}
+	return v.Interface().(time.Time).After(start)
+}
+
+func lookupRule(name string) (Func, string, bool) {
+	customMu.RLock()
+	defer customMu.RUnlock()
+	r, ok := customRules[name]
+	return r.fn, r.message, ok
+}
+
+func formatMessage(message, param string) string {
+	if strings.Contains(message, "%s") {
+		return fmt.Sprintf(message, param)
+	}
+	return message
+}
+
+// fieldName returns the JSON name of a struct field.
+func fieldName(sf reflect.StructField) string {
+	if tag := sf.Tag.Get("json"); tag != "" {
+		name, _, _ := strings.Cut(tag, ",")
+		if name != "" {
+			return name
+		}
+	}
+	return sf.Name
+}
+
+func fieldNameOf(parent reflect.Value, goName string) string {
+	if sf, ok := parent.Type().FieldByName(goName); ok {
+		return fieldName(sf)
+	}
+	return goName
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func isZero(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	}
+	if isTime(v) {
+		return v.Interface().(time.Time).IsZero()
+	}
+	return v.IsZero()
+}
+
+func isList(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func isTime(v reflect.Value) bool {
+	return v.IsValid() && v.Type() == timeType
+}
@@ -0,0 +1,80 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type sendRequest struct {
+	To     []string `json:"to" validate:"required,max=3,email"`
+	Folder string   `json:"folder" validate:"folder"`
+	Status string   `json:"status" validate:"oneof=todo done"`
+}
+
+type rangeRequest struct {
+	Start *time.Time `json:"start"`
+	End   *time.Time `json:"end" validate:"after=Start"`
+	Date  string     `json:"date" validate:"date"`
+}
+
+func fieldErrors(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected Errors, got %v", err)
+	}
+	got := make(map[string]string, len(errs))
+	for _, fe := range errs {
+		got[fe.Field] = fe.Rule
+	}
+	return got
+}
+
+func TestStructValid(t *testing.T) {
+	req := sendRequest{To: []string{"a@example.com", "Bob <bob@example.org>"}, Folder: "Work/Projects", Status: "done"}
+	if err := Struct(&req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestStructFieldErrors(t *testing.T) {
+	req := sendRequest{To: []string{"ok@example.com", "not-an-email"}, Folder: "../etc", Status: "later"}
+	got := fieldErrors(t, Struct(&req))
+
+	want := map[string]string{"to[1]": "email", "folder": "folder", "status": "oneof"}
+	for field, rule := range want {
+		if got[field] != rule {
+			t.Errorf("%s: rule = %q, want %q (all: %v)", field, got[field], rule, got)
+		}
+	}
+
+	got = fieldErrors(t, Struct(&sendRequest{}))
+	if got["to"] != "required" || len(got) != 1 {
+		t.Errorf("empty request: %v", got)
+	}
+
+	got = fieldErrors(t, Struct(&sendRequest{To: []string{"a@x.io", "b@x.io", "c@x.io", "d@x.io"}}))
+	if got["to"] != "max" {
+		t.Errorf("too many recipients: %v", got)
+	}
+}
+
+func TestStructDateRange(t *testing.T) {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	end := start.Add(-time.Hour)
+
+	got := fieldErrors(t, Struct(&rangeRequest{Start: &start, End: &end, Date: "2026-13-01"}))
+	if got["end"] != "after" || got["date"] != "date" {
+		t.Errorf("unexpected errors: %v", got)
+	}
+
+	later := start.Add(time.Hour)
+	if err := Struct(&rangeRequest{Start: &start, End: &later, Date: "2026-01-02"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// start가 없으면 after는 검사하지 않는다
+	if err := Struct(&rangeRequest{End: &end}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}